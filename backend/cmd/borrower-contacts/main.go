@@ -0,0 +1,138 @@
+// borrower-contacts reports borrower emails and phone numbers stored before
+// contact validation was added that are malformed or not yet normalized
+// (phone numbers in E.164). It only reads; fixing the flagged borrowers is
+// left to whoever owns them.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/borrower"
+)
+
+func main() {
+	defaultRegion := os.Getenv("BORROWER_PHONE_REGION")
+	if defaultRegion == "" {
+		defaultRegion = "FI"
+	}
+
+	workspace := flag.String("workspace", "", "Workspace ID (optional, all if not specified)")
+	region := flag.String("region", defaultRegion, "Region phone numbers without a country code are read in")
+	flag.Usage = printUsage
+	flag.Parse()
+
+	if *region != "" && !borrower.SupportedPhoneRegion(*region) {
+		log.Fatalf("Unsupported phone region: %s", *region)
+	}
+
+	var workspaceID *uuid.UUID
+	if *workspace != "" {
+		id, err := uuid.Parse(*workspace)
+		if err != nil {
+			log.Fatalf("Invalid workspace ID: %v", err)
+		}
+		workspaceID = &id
+	}
+
+	runReport(workspaceID, *region)
+}
+
+func printUsage() {
+	fmt.Println(`borrower-contacts - Report malformed borrower contact details
+
+Usage:
+  borrower-contacts [options]
+
+Options:
+  --workspace   Workspace ID (optional, reports all if not specified)
+  --region      Region phone numbers without a country code are read in
+                (default: BORROWER_PHONE_REGION, or FI)
+
+Each flagged value is listed with what it would be stored as today, or with
+the reason it is rejected.
+
+Environment:
+  GO_DATABASE_URL        PostgreSQL connection string (required)
+  BORROWER_PHONE_REGION  Default for --region, as for the server`)
+}
+
+func runReport(workspaceID *uuid.UUID, region string) {
+	ctx := context.Background()
+
+	dbURL := os.Getenv("GO_DATABASE_URL")
+	if dbURL == "" {
+		log.Fatal("GO_DATABASE_URL environment variable is required")
+	}
+	pool, err := pgxpool.New(ctx, dbURL)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer pool.Close()
+
+	rows, err := pool.Query(ctx, `
+		SELECT id, workspace_id, name, email, phone, notes, reminder_channel, is_archived, created_at, updated_at
+		FROM warehouse.borrowers
+		WHERE ($1::uuid IS NULL OR workspace_id = $1)
+		  AND (email IS NOT NULL OR phone IS NOT NULL)
+		ORDER BY workspace_id, name
+	`, workspaceID)
+	if err != nil {
+		log.Fatalf("Failed to query borrowers: %v", err)
+	}
+	defer rows.Close()
+
+	fmt.Println("Borrower Contact Report")
+	fmt.Println("=======================")
+	fmt.Println()
+	fmt.Printf("%-36s  %-30s %-6s %-30s %s\n", "Borrower", "Name", "Field", "Value", "Result")
+	fmt.Println(strings.Repeat("-", 130))
+
+	var checked, fixable, invalid int
+	for rows.Next() {
+		var (
+			id, wsID             uuid.UUID
+			name                 string
+			email, phone, notes  *string
+			reminderChannel      string
+			isArchived           bool
+			createdAt, updatedAt time.Time
+		)
+		if err := rows.Scan(&id, &wsID, &name, &email, &phone, &notes, &reminderChannel, &isArchived, &createdAt, &updatedAt); err != nil {
+			log.Printf("Failed to scan borrower: %v", err)
+			continue
+		}
+		checked++
+
+		b := borrower.Reconstruct(id, wsID, name, email, phone, notes, borrower.ReminderChannel(reminderChannel), isArchived, createdAt, updatedAt)
+		for _, issue := range borrower.CheckContact(b, region) {
+			result := "-> " + issue.Normalized
+			if issue.Err != nil {
+				result = "INVALID: " + issue.Err.Error()
+				invalid++
+			} else {
+				fixable++
+			}
+
+			// Truncate long names
+			if len(name) > 28 {
+				name = name[:25] + "..."
+			}
+			fmt.Printf("%-36s  %-30s %-6s %-30s %s\n", id, name, issue.Field, issue.Value, result)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		log.Fatalf("Failed to read borrowers: %v", err)
+	}
+
+	fmt.Println(strings.Repeat("-", 130))
+	fmt.Printf("Checked %d borrowers: %d values need normalizing, %d are invalid\n", checked, fixable, invalid)
+}
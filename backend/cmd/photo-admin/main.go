@@ -1,8 +1,9 @@
 // photo-admin provides CLI tools for photo management.
 // Commands:
-//   - regenerate: Regenerate thumbnails for all photos
+//   - regenerate: Regenerate thumbnails made under an older thumbnail config
 //   - cleanup: Remove orphaned photo files
 //   - report: Show storage usage report
+//   - backfill-blurhash: Compute blurhash placeholders for existing photos
 package main
 
 import (
@@ -17,7 +18,10 @@ import (
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	"github.com/antti/home-warehouse/go-backend/internal/infra/events"
 	"github.com/antti/home-warehouse/go-backend/internal/infra/imageprocessor"
+	"github.com/antti/home-warehouse/go-backend/internal/infra/storage"
+	"github.com/antti/home-warehouse/go-backend/internal/jobs"
 )
 
 const msgFailedConnectDatabase = "Failed to connect to database: %v"
@@ -35,11 +39,12 @@ func main() {
 		regenerateCmd := flag.NewFlagSet("regenerate", flag.ExitOnError)
 		workspaceID := regenerateCmd.String("workspace", "", "Workspace ID (optional, all if not specified)")
 		photoID := regenerateCmd.String("photo", "", "Single photo ID (optional)")
+		force := regenerateCmd.Bool("force", false, "Regenerate photos whose thumbnails match the current config too")
 		dryRun := regenerateCmd.Bool("dry-run", false, "Preview changes without executing")
 		if err := regenerateCmd.Parse(os.Args[2:]); err != nil {
 			log.Fatal(err)
 		}
-		runRegenerate(*workspaceID, *photoID, *dryRun)
+		runRegenerate(*workspaceID, *photoID, *force, *dryRun)
 
 	case "cleanup":
 		cleanupCmd := flag.NewFlagSet("cleanup", flag.ExitOnError)
@@ -53,6 +58,16 @@ func main() {
 	case "report":
 		runReport()
 
+	case "backfill-blurhash":
+		backfillCmd := flag.NewFlagSet("backfill-blurhash", flag.ExitOnError)
+		workspaceID := backfillCmd.String("workspace", "", "Workspace ID (optional, all if not specified)")
+		force := backfillCmd.Bool("force", false, "Recompute blurhash for photos that already have one")
+		dryRun := backfillCmd.Bool("dry-run", false, "Preview changes without executing")
+		if err := backfillCmd.Parse(os.Args[2:]); err != nil {
+			log.Fatal(err)
+		}
+		runBackfillBlurHash(*workspaceID, *force, *dryRun)
+
 	case "help", "-h", "--help":
 		printUsage()
 
@@ -70,9 +85,11 @@ Usage:
   photo-admin <command> [options]
 
 Commands:
-  regenerate    Regenerate thumbnails for photos
+  regenerate    Regenerate thumbnails generated under a different thumbnail
+                config (sizes, format, quality) than the current one
     --workspace   Workspace ID (optional, regenerates all if not specified)
     --photo       Single photo ID (optional)
+    --force       Regenerate all matching photos, even up-to-date ones
     --dry-run     Preview changes without executing
 
   cleanup       Remove orphaned photo files (files without database records)
@@ -81,16 +98,28 @@ Commands:
 
   report        Show storage usage report by workspace
 
+  backfill-blurhash  Compute blurhash placeholders for existing photos
+    --workspace   Workspace ID (optional, backfills all if not specified)
+    --force       Recompute blurhash for photos that already have one
+    --dry-run     Preview changes without executing
+
   help          Show this help message
 
 Environment:
-  GO_DATABASE_URL   PostgreSQL connection string (required)
-  UPLOAD_DIR        Upload directory path (default: ./uploads)
+  GO_DATABASE_URL      PostgreSQL connection string (required)
+  UPLOAD_DIR           Upload directory path (default: ./uploads)
+  PHOTO_STORAGE_DIR    Photo storage directory (default: ./uploads/photos)
+  PHOTO_STORAGE_LAYOUT Path layout under the storage directory, as for the
+                       server (default: {workspace}/{item}/{filename})
+  PHOTO_*           Image processor settings, as for the scheduler
 
 Examples:
-  # Regenerate all thumbnails
+  # Regenerate thumbnails made under an older config
   photo-admin regenerate
 
+  # Regenerate every thumbnail
+  photo-admin regenerate --force
+
   # Regenerate thumbnails for a specific workspace
   photo-admin regenerate --workspace 01234567-89ab-cdef-0123-456789abcdef
 
@@ -101,7 +130,10 @@ Examples:
   photo-admin cleanup --execute
 
   # View storage usage
-  photo-admin report`)
+  photo-admin report
+
+  # Fill in missing blurhash placeholders
+  photo-admin backfill-blurhash`)
 }
 
 func getDBPool() (*pgxpool.Pool, error) {
@@ -112,6 +144,28 @@ func getDBPool() (*pgxpool.Pool, error) {
 	return pgxpool.New(context.Background(), dbURL)
 }
 
+func getPhotoStorageDir() string {
+	dir := os.Getenv("PHOTO_STORAGE_DIR")
+	if dir == "" {
+		dir = "./uploads/photos"
+	}
+	return dir
+}
+
+// getPathLayouts returns the configured storage path layout, followed by the
+// default layout when they differ: files saved before the layout was changed
+// keep their old paths, and must not be mistaken for orphans.
+func getPathLayouts() []*storage.PathLayout {
+	layout, err := storage.LoadPathLayoutFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to load storage layout: %v", err)
+	}
+	if layout.String() == storage.DefaultPathLayout {
+		return []*storage.PathLayout{layout}
+	}
+	return []*storage.PathLayout{layout, storage.DefaultLayout()}
+}
+
 func getUploadDir() string {
 	dir := os.Getenv("UPLOAD_DIR")
 	if dir == "" {
@@ -120,7 +174,11 @@ func getUploadDir() string {
 	return dir
 }
 
-func runRegenerate(workspaceID, photoID string, dryRun bool) {
+// runRegenerate regenerates all thumbnail sizes through the same code path as
+// the background thumbnail job, so the new paths and the current config
+// version are recorded on each photo. Unless force is set, photos whose
+// thumbnails already match the current config version are skipped.
+func runRegenerate(workspaceID, photoID string, force, dryRun bool) {
 	ctx := context.Background()
 
 	pool, err := getDBPool()
@@ -134,19 +192,30 @@ func runRegenerate(workspaceID, photoID string, dryRun bool) {
 	if err != nil {
 		log.Fatalf("Failed to load config: %v", err)
 	}
-	processor := imageprocessor.NewProcessor(cfg)
+	version := cfg.ThumbnailVersion()
 
-	uploadDir := getUploadDir()
+	photoStorage, err := storage.NewLocalStorage(getPhotoStorageDir())
+	if err != nil {
+		log.Fatalf("Failed to initialize photo storage: %v", err)
+	}
+	photoStorage.SetPathLayout(getPathLayouts()[0])
 
 	// Build query
 	query := `
-		SELECT id, storage_path, thumbnail_path, workspace_id
+		SELECT id, workspace_id, item_id, storage_path, thumbnail_config_version,
+		       thumbnail_small_path, thumbnail_medium_path, thumbnail_large_path
 		FROM warehouse.item_photos
 		WHERE 1=1
 	`
 	args := []any{}
 	argNum := 1
 
+	if !force {
+		query += fmt.Sprintf(" AND thumbnail_config_version IS DISTINCT FROM $%d", argNum)
+		args = append(args, version)
+		argNum++
+	}
+
 	if workspaceID != "" {
 		wsID, err := uuid.Parse(workspaceID)
 		if err != nil {
@@ -167,48 +236,180 @@ func runRegenerate(workspaceID, photoID string, dryRun bool) {
 		argNum++
 	}
 
+	type stalePhoto struct {
+		id, workspaceID, itemID uuid.UUID
+		storagePath             string
+		version                 *string
+		oldThumbnails           [3]*string
+	}
+
+	// Collect the batch first; each regeneration below needs its own pool
+	// connections.
 	rows, err := pool.Query(ctx, query, args...)
 	if err != nil {
 		log.Fatalf("Failed to query photos: %v", err)
 	}
-	defer rows.Close()
+	var photos []stalePhoto
+	for rows.Next() {
+		var p stalePhoto
+		if err := rows.Scan(&p.id, &p.workspaceID, &p.itemID, &p.storagePath, &p.version,
+			&p.oldThumbnails[0], &p.oldThumbnails[1], &p.oldThumbnails[2]); err != nil {
+			rows.Close()
+			log.Fatalf("Error scanning row: %v", err)
+		}
+		photos = append(photos, p)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		log.Fatalf("Failed to query photos: %v", err)
+	}
+
+	fmt.Printf("Current thumbnail config version: %s\n", version)
+	if len(photos) == 0 {
+		fmt.Println("All thumbnails are up to date. Run with --force to regenerate anyway.")
+		return
+	}
+
+	thumbnails := jobs.NewThumbnailProcessor(pool, imageprocessor.NewProcessor(cfg), photoStorage, events.NewBroadcaster(), getUploadDir())
+	thumbnails.SetConfigVersion(version)
 
 	var successCount, errorCount int
 
-	for rows.Next() {
-		var id, wsID uuid.UUID
-		var storagePath, thumbnailPath string
+	for _, p := range photos {
+		oldVersion := "none"
+		if p.version != nil {
+			oldVersion = *p.version
+		}
+
+		if dryRun {
+			fmt.Printf("[DRY-RUN] Would regenerate: %s (version %s)\n", p.id, oldVersion)
+			successCount++
+			continue
+		}
+
+		task := jobs.NewThumbnailGenerationTask(p.id, p.workspaceID, p.itemID, p.storagePath)
+		if err := thumbnails.ProcessTask(ctx, task); err != nil {
+			log.Printf("Error regenerating %s: %v", p.id, err)
+			errorCount++
+			continue
+		}
 
-		if err := rows.Scan(&id, &storagePath, &thumbnailPath, &wsID); err != nil {
-			log.Printf("Error scanning row: %v", err)
+		// The job marks the photo failed without returning an error for
+		// failures that retrying cannot fix, such as a processing timeout.
+		var status string
+		if err := pool.QueryRow(ctx, `
+			SELECT thumbnail_status FROM warehouse.item_photos WHERE id = $1
+		`, p.id).Scan(&status); err != nil || status != "complete" {
+			log.Printf("Error regenerating %s: thumbnail status %q", p.id, status)
 			errorCount++
 			continue
 		}
 
-		sourcePath := filepath.Join(uploadDir, storagePath)
-		destPath := filepath.Join(uploadDir, thumbnailPath)
+		// New thumbnails are saved under new names; remove the old files.
+		for _, old := range p.oldThumbnails {
+			if old != nil && *old != "" {
+				if err := photoStorage.Delete(ctx, *old); err != nil {
+					log.Printf("Error deleting old thumbnail %s: %v", *old, err)
+				}
+			}
+		}
+
+		fmt.Printf("Regenerated: %s (version %s -> %s)\n", p.id, oldVersion, version)
+		successCount++
+	}
+
+	fmt.Printf("\nCompleted: %d successful, %d errors\n", successCount, errorCount)
+}
+
+func runBackfillBlurHash(workspaceID string, force, dryRun bool) {
+	ctx := context.Background()
+
+	pool, err := getDBPool()
+	if err != nil {
+		log.Fatalf(msgFailedConnectDatabase, err)
+	}
+	defer pool.Close()
 
+	blurHasher := imageprocessor.NewBlurHasher()
+	uploadDir := getUploadDir()
+
+	query := `
+		SELECT id, storage_path
+		FROM warehouse.item_photos
+		WHERE 1=1
+	`
+	args := []any{}
+
+	if !force {
+		query += " AND blurhash IS NULL"
+	}
+
+	if workspaceID != "" {
+		wsID, err := uuid.Parse(workspaceID)
+		if err != nil {
+			log.Fatalf("Invalid workspace ID: %v", err)
+		}
+		query += " AND workspace_id = $1"
+		args = append(args, wsID)
+	}
+
+	type pendingPhoto struct {
+		id          uuid.UUID
+		storagePath string
+	}
+
+	// Collect the batch first so the updates below don't compete with an
+	// open result set for a pool connection.
+	rows, err := pool.Query(ctx, query, args...)
+	if err != nil {
+		log.Fatalf("Failed to query photos: %v", err)
+	}
+	var photos []pendingPhoto
+	for rows.Next() {
+		var p pendingPhoto
+		if err := rows.Scan(&p.id, &p.storagePath); err != nil {
+			rows.Close()
+			log.Fatalf("Error scanning row: %v", err)
+		}
+		photos = append(photos, p)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		log.Fatalf("Failed to query photos: %v", err)
+	}
+
+	var successCount, errorCount int
+
+	for _, p := range photos {
 		if dryRun {
-			fmt.Printf("[DRY-RUN] Would regenerate: %s -> %s\n", storagePath, thumbnailPath)
+			fmt.Printf("[DRY-RUN] Would compute blurhash: %s\n", p.storagePath)
 			successCount++
 			continue
 		}
 
-		// Check if source exists
+		sourcePath := filepath.Join(uploadDir, p.storagePath)
 		if _, err := os.Stat(sourcePath); os.IsNotExist(err) {
 			log.Printf("Source file not found: %s", sourcePath)
 			errorCount++
 			continue
 		}
 
-		// Generate thumbnail
-		if err := processor.GenerateThumbnail(ctx, sourcePath, destPath, cfg.MediumSize, cfg.MediumSize); err != nil {
-			log.Printf("Error regenerating %s: %v", id, err)
+		blurHash, err := blurHasher.GenerateBlurHash(ctx, sourcePath)
+		if err != nil {
+			log.Printf("Error computing blurhash for %s: %v", p.id, err)
+			errorCount++
+			continue
+		}
+
+		if _, err := pool.Exec(ctx, `
+			UPDATE warehouse.item_photos SET blurhash = $1, updated_at = now() WHERE id = $2
+		`, blurHash, p.id); err != nil {
+			log.Printf("Error saving blurhash for %s: %v", p.id, err)
 			errorCount++
 			continue
 		}
 
-		fmt.Printf("Regenerated: %s\n", id)
+		fmt.Printf("Updated: %s (%s)\n", p.id, blurHash)
 		successCount++
 	}
 
@@ -224,16 +425,16 @@ func runCleanup(dryRun bool) {
 	}
 	defer pool.Close()
 
-	uploadDir := getUploadDir()
+	storageDir := getPhotoStorageDir()
 
 	knownFiles, err := loadKnownPhotoPaths(ctx, pool)
 	if err != nil {
 		log.Fatalf("Failed to query photos: %v", err)
 	}
 
-	orphanedFiles, totalOrphanedSize, err := findOrphanedFiles(uploadDir, knownFiles)
+	orphanedFiles, totalOrphanedSize, err := findOrphanedFiles(storageDir, knownFiles, getPathLayouts())
 	if err != nil {
-		log.Fatalf("Error walking upload directory: %v", err)
+		log.Fatalf("Error walking photo storage directory: %v", err)
 	}
 
 	if len(orphanedFiles) == 0 {
@@ -256,7 +457,9 @@ func runCleanup(dryRun bool) {
 // the database, used to tell live files from orphans on disk.
 func loadKnownPhotoPaths(ctx context.Context, pool *pgxpool.Pool) (map[string]bool, error) {
 	rows, err := pool.Query(ctx, `
-		SELECT storage_path, thumbnail_path FROM warehouse.item_photos
+		SELECT storage_path, thumbnail_path,
+		       thumbnail_small_path, thumbnail_medium_path, thumbnail_large_path
+		FROM warehouse.item_photos
 	`)
 	if err != nil {
 		return nil, err
@@ -266,22 +469,30 @@ func loadKnownPhotoPaths(ctx context.Context, pool *pgxpool.Pool) (map[string]bo
 	knownFiles := make(map[string]bool)
 	for rows.Next() {
 		var storagePath, thumbnailPath string
-		if err := rows.Scan(&storagePath, &thumbnailPath); err != nil {
+		var small, medium, large *string
+		if err := rows.Scan(&storagePath, &thumbnailPath, &small, &medium, &large); err != nil {
 			continue
 		}
 		knownFiles[storagePath] = true
 		knownFiles[thumbnailPath] = true
+		for _, p := range []*string{small, medium, large} {
+			if p != nil {
+				knownFiles[*p] = true
+			}
+		}
 	}
-	return knownFiles, nil
+	return knownFiles, rows.Err()
 }
 
-// findOrphanedFiles walks uploadDir and returns the image files (and their total
-// size) whose path relative to uploadDir is absent from knownFiles.
-func findOrphanedFiles(uploadDir string, knownFiles map[string]bool) ([]string, int64, error) {
+// findOrphanedFiles walks storageDir and returns the image files (and their
+// total size) whose path relative to storageDir is absent from knownFiles.
+// Files that follow none of layouts were not saved by the photo storage and
+// are left alone.
+func findOrphanedFiles(storageDir string, knownFiles map[string]bool, layouts []*storage.PathLayout) ([]string, int64, error) {
 	var orphanedFiles []string
 	var totalOrphanedSize int64
 
-	err := filepath.Walk(uploadDir, func(path string, info os.FileInfo, err error) error {
+	err := filepath.Walk(storageDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil {
 			return err
 		}
@@ -295,10 +506,14 @@ func findOrphanedFiles(uploadDir string, knownFiles map[string]bool) ([]string,
 			return nil
 		}
 
-		relPath, err := filepath.Rel(uploadDir, path)
+		relPath, err := filepath.Rel(storageDir, path)
 		if err != nil {
 			return nil
 		}
+		relPath = filepath.ToSlash(relPath)
+		if _, ok := storage.ParseStoragePath(relPath, layouts...); !ok {
+			return nil
+		}
 
 		if !knownFiles[relPath] {
 			orphanedFiles = append(orphanedFiles, path)
@@ -334,6 +549,10 @@ func runReport() {
 	}
 	defer pool.Close()
 
+	// Disk usage per workspace, read from the paths the same way cleanup does
+	storageDir := getPhotoStorageDir()
+	disk := diskUsageByWorkspace(storageDir, getPathLayouts())
+
 	// Get storage usage by workspace
 	rows, err := pool.Query(ctx, `
 		SELECT
@@ -354,8 +573,8 @@ func runReport() {
 	fmt.Println("Storage Usage Report")
 	fmt.Println("====================")
 	fmt.Println()
-	fmt.Printf("%-40s %-12s %s\n", "Workspace", "Photos", "Size")
-	fmt.Println(strings.Repeat("-", 70))
+	fmt.Printf("%-40s %-12s %-14s %s\n", "Workspace", "Photos", "Size", "On disk")
+	fmt.Println(strings.Repeat("-", 84))
 
 	var totalPhotos int64
 	var totalSize int64
@@ -374,33 +593,61 @@ func runReport() {
 			wsName = wsName[:35] + "..."
 		}
 
-		fmt.Printf("%-40s %-12d %.2f MB\n", wsName, photoCount, float64(size)/(1024*1024))
+		fmt.Printf("%-40s %-12d %-14s %s\n", wsName, photoCount, formatMB(size), formatMB(disk.byWorkspace[wsID.String()]))
 		totalPhotos += photoCount
 		totalSize += size
 	}
 
-	fmt.Println(strings.Repeat("-", 70))
-	fmt.Printf("%-40s %-12d %.2f MB\n\n", "TOTAL", totalPhotos, float64(totalSize)/(1024*1024))
+	fmt.Println(strings.Repeat("-", 84))
+	fmt.Printf("%-40s %-12d %-14s %s\n\n", "TOTAL", totalPhotos, formatMB(totalSize), formatMB(disk.total))
 
-	// Get upload directory size
-	uploadDir := getUploadDir()
-	var diskSize int64
-	var fileCount int64
+	fmt.Printf("Disk usage: %s (%d files)\n", formatMB(disk.total), disk.files)
+	if disk.unrecognized > 0 {
+		fmt.Printf("Not following the storage layout: %s\n", formatMB(disk.unrecognized))
+	}
+
+	if disk.total > totalSize {
+		diff := disk.total - totalSize
+		fmt.Printf("Potential orphaned data: %s\n", formatMB(diff))
+		fmt.Println("Run 'photo-admin cleanup --dry-run' to identify orphaned files.")
+	}
+}
+
+// diskUsage is the size of the files under the photo storage directory.
+type diskUsage struct {
+	total        int64
+	files        int64
+	byWorkspace  map[string]int64
+	unrecognized int64 // files that follow none of the layouts
+}
+
+// diskUsageByWorkspace walks storageDir, attributing each file to the
+// workspace its path names under the first of layouts it follows.
+func diskUsageByWorkspace(storageDir string, layouts []*storage.PathLayout) diskUsage {
+	usage := diskUsage{byWorkspace: make(map[string]int64)}
 
-	filepath.Walk(uploadDir, func(path string, info os.FileInfo, err error) error {
+	filepath.Walk(storageDir, func(path string, info os.FileInfo, err error) error {
 		if err != nil || info.IsDir() {
 			return nil
 		}
-		diskSize += info.Size()
-		fileCount++
+		usage.total += info.Size()
+		usage.files++
+
+		relPath, err := filepath.Rel(storageDir, path)
+		if err != nil {
+			return nil
+		}
+		if vars, ok := storage.ParseStoragePath(filepath.ToSlash(relPath), layouts...); ok {
+			usage.byWorkspace[vars.WorkspaceID] += info.Size()
+		} else {
+			usage.unrecognized += info.Size()
+		}
 		return nil
 	})
 
-	fmt.Printf("Disk usage: %.2f MB (%d files)\n", float64(diskSize)/(1024*1024), fileCount)
+	return usage
+}
 
-	if diskSize > totalSize {
-		diff := float64(diskSize-totalSize) / (1024 * 1024)
-		fmt.Printf("Potential orphaned data: %.2f MB\n", diff)
-		fmt.Println("Run 'photo-admin cleanup --dry-run' to identify orphaned files.")
-	}
+func formatMB(bytes int64) string {
+	return fmt.Sprintf("%.2f MB", float64(bytes)/(1024*1024))
 }
@@ -10,13 +10,17 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/antti/home-warehouse/go-backend/internal/config"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/itemphoto"
 	"github.com/antti/home-warehouse/go-backend/internal/infra/events"
 	"github.com/antti/home-warehouse/go-backend/internal/infra/imageprocessor"
 	"github.com/antti/home-warehouse/go-backend/internal/infra/postgres"
+	"github.com/antti/home-warehouse/go-backend/internal/infra/sms"
 	"github.com/antti/home-warehouse/go-backend/internal/infra/storage"
+	"github.com/antti/home-warehouse/go-backend/internal/infra/urlfetch"
 	"github.com/antti/home-warehouse/go-backend/internal/infra/webpush"
 	"github.com/antti/home-warehouse/go-backend/internal/jobs"
 )
@@ -70,10 +74,24 @@ func main() {
 		log.Println("Web push notifications disabled (VAPID keys not configured)")
 	}
 
-	// Create scheduler with default config
+	// Create scheduler with default config, applying the configured retry policy
 	schedulerConfig := jobs.DefaultSchedulerConfig(redisURL)
+	schedulerConfig.MaxRetry = cfg.SchedulerMaxRetry
+	schedulerConfig.RetryBaseDelay = cfg.SchedulerRetryBaseDelay
+	schedulerConfig.RetryMaxDelay = cfg.SchedulerRetryMaxDelay
+	schedulerConfig.PhoneRegion = cfg.BorrowerPhoneRegion
+	log.Printf("Task retry policy: max %d retries, backoff %s doubling up to %s",
+		schedulerConfig.MaxRetry, schedulerConfig.RetryBaseDelay, schedulerConfig.RetryMaxDelay)
 	scheduler := jobs.NewScheduler(dbPool, schedulerConfig)
 
+	// SMS loan reminders (optional - only if Twilio is configured)
+	if cfg.SMSEnabled() {
+		scheduler.SetSMSSender(sms.NewSender(cfg.TwilioAccountSID, cfg.TwilioAuthToken, cfg.TwilioFrom))
+		log.Println("SMS reminders enabled")
+	} else {
+		log.Println("SMS reminders disabled (Twilio not configured)")
+	}
+
 	// Initialize storage and image processor for thumbnail processing
 	uploadDir := getUploadDir()
 	photoStorageDir := getPhotoStorageDir()
@@ -81,17 +99,58 @@ func main() {
 	if err != nil {
 		log.Fatalf("Failed to initialize photo storage: %v", err)
 	}
-	imgProcessor := imageprocessor.NewProcessor(imageprocessor.DefaultConfig())
+	storageLayout, err := storage.LoadPathLayoutFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to load photo storage layout: %v", err)
+	}
+	photoStorage.SetPathLayout(storageLayout)
+	imgConfig, err := imageprocessor.LoadConfigFromEnv()
+	if err != nil {
+		log.Fatalf("Failed to load image processor config: %v", err)
+	}
+	imgProcessor := imageprocessor.NewProcessor(imgConfig)
 	broadcaster := events.NewBroadcaster()
+	scheduler.SetBroadcaster(broadcaster)
 
 	// Register task handlers
 	// Note: emailSender is nil - implement when email service is added
 	cleanupConfig := jobs.DefaultCleanupConfig()
+	cleanupConfig.UploadDir = uploadDir
+	cleanupConfig.UploadTempMaxAge = cfg.UploadTempMaxAge
+	cleanupConfig.DeletedRecordsArchiveDir = cfg.DeletedRecordsArchiveDir
+	cleanupConfig.AuthEventsRetentionDays = cfg.AuthEventRetentionDays
+	sweepUploadDir(uploadDir, cfg.UploadTempMaxAge)
+	// Photos named in the photo_url column of item imports are downloaded
+	// here, through the same SSRF and size checks as other remote photos, and
+	// get the same limits and hashes as uploaded ones (see api/router.go).
+	itemPhotoSvc := itemphoto.NewService(postgres.NewItemPhotoRepository(dbPool, postgres.NewTxManager(dbPool)), photoStorage, imgProcessor, uploadDir)
+	itemPhotoSvc.SetAsynqClient(scheduler.Client())
+	itemPhotoSvc.SetRemoteFetcher(urlfetch.New(itemphoto.MaxFileSize))
+	itemPhotoSvc.SetHasher(imageprocessor.NewHasher())
+	itemPhotoSvc.SetDeduplicateUploads(imgConfig.DedupUploads)
+	itemPhotoSvc.SetAllowedMimeTypes(imgConfig.AllowedMimeTypes)
+	if imgConfig.HEICConverter != "" {
+		itemPhotoSvc.SetHEICConverter(imgProcessor)
+	}
+	itemPhotoSvc.SetSettingsRepository(postgres.NewPhotoSettingsRepository(dbPool))
+	if imgConfig.BlurHashEnabled {
+		itemPhotoSvc.SetBlurHasher(imageprocessor.NewBlurHasher())
+	}
 	thumbnailConfig := &jobs.ThumbnailConfig{
-		Processor:   imgProcessor,
-		Storage:     photoStorage,
-		Broadcaster: broadcaster,
-		UploadDir:   uploadDir,
+		Processor:     imgProcessor,
+		Storage:       photoStorage,
+		Broadcaster:   broadcaster,
+		UploadDir:     uploadDir,
+		ConfigVersion: imgConfig.ThumbnailVersion(),
+		ImportUploader: func(ctx context.Context, workspaceID, itemID, userID uuid.UUID, rawURL string) (uuid.UUID, error) {
+			photo, err := itemPhotoSvc.UploadPhotoFromURL(ctx, itemID, workspaceID, userID, rawURL, nil)
+			if err != nil {
+				return uuid.Nil, err
+			}
+			return photo.ID, nil
+		},
+		ImportResults: postgres.NewImportJobRepository(dbPool),
+		ImportPrimary: importPrimaryPhotos{itemPhotoSvc},
 	}
 	mux := scheduler.RegisterHandlers(nil, pushSender, cleanupConfig, thumbnailConfig)
 
@@ -112,6 +171,12 @@ func main() {
 		fmt.Fprintf(w, `{"status":"healthy","scheduler":"running"}`)
 	})
 
+	// Queue status and dead-letter inspection (tasks that exhausted retries).
+	// This server is unauthenticated, so dead letters are listed without payloads.
+	inspector := scheduler.Inspector()
+	healthMux.HandleFunc("GET /status", inspector.StatusHandler())
+	healthMux.HandleFunc("GET /status/dead-letters", inspector.DeadLettersHandler())
+
 	healthServer := &http.Server{
 		Addr:    ":8082",
 		Handler: healthMux,
@@ -138,6 +203,7 @@ func main() {
 	log.Println("  - Repair reminders: daily at 9 AM")
 	log.Println("  - Deleted records cleanup: weekly Sunday 3 AM")
 	log.Println("  - Activity logs cleanup: weekly Sunday 4 AM")
+	log.Println("  - Upload temp files cleanup: hourly")
 
 	// Wait for shutdown signal
 	<-sigChan
@@ -159,6 +225,51 @@ func main() {
 	log.Println("Scheduler stopped")
 }
 
+// sweepUploadDir removes temp files left in the upload directory by jobs that
+// crashed before the scheduler last stopped. The hourly cleanup task keeps
+// it clean from then on.
+// importPrimaryPhotos adapts itemphoto.Service to jobs.ImportPrimaryPhotos.
+type importPrimaryPhotos struct {
+	svc *itemphoto.Service
+}
+
+func (a importPrimaryPhotos) PrimaryPhotoID(ctx context.Context, workspaceID, itemID uuid.UUID) (*uuid.UUID, error) {
+	photo, err := a.svc.GetPrimary(ctx, itemID, workspaceID)
+	if err != nil || photo == nil {
+		return nil, err
+	}
+	return &photo.ID, nil
+}
+
+func (a importPrimaryPhotos) PhotoArea(ctx context.Context, workspaceID, photoID uuid.UUID) (int64, error) {
+	photo, err := a.svc.GetPhoto(ctx, photoID)
+	if err != nil {
+		return 0, err
+	}
+	if photo.WorkspaceID != workspaceID {
+		return 0, itemphoto.ErrUnauthorized
+	}
+	return int64(photo.Width) * int64(photo.Height), nil
+}
+
+func (a importPrimaryPhotos) SetPrimaryPhoto(ctx context.Context, workspaceID, photoID uuid.UUID) error {
+	return a.svc.SetPrimaryPhoto(ctx, photoID, workspaceID)
+}
+
+func sweepUploadDir(dir string, maxAge time.Duration) {
+	if maxAge <= 0 {
+		return
+	}
+	removed, err := jobs.CleanupUploadDir(dir, maxAge, time.Now())
+	if err != nil {
+		log.Printf("Warning: failed to clean upload directory %s: %v", dir, err)
+		return
+	}
+	if removed > 0 {
+		log.Printf("Removed %d stale temp files from %s", removed, dir)
+	}
+}
+
 // getUploadDir returns the configured temporary upload directory for processing files
 func getUploadDir() string {
 	dir := os.Getenv("PHOTO_UPLOAD_DIR")
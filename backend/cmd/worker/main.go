@@ -2,7 +2,7 @@ package main
 
 import (
 	"context"
-	"fmt"
+	"encoding/json"
 	"log"
 	"net/http"
 	"os"
@@ -10,6 +10,7 @@ import (
 	"syscall"
 	"time"
 
+	"github.com/hibiken/asynq"
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/joho/godotenv"
 	"github.com/redis/go-redis/v9"
@@ -21,9 +22,9 @@ import (
 	"github.com/antti/home-warehouse/go-backend/internal/worker"
 )
 
-// drainTimeout bounds how long shutdown waits for an in-flight import to
+// drainTimeout bounds how long shutdown waits for in-flight imports to
 // finish. If exceeded, the process exits anyway; the queue's in-flight list
-// recovers the job on the next worker start.
+// recovers the jobs on the next worker start.
 const drainTimeout = 60 * time.Second
 
 func main() {
@@ -74,6 +75,14 @@ func main() {
 
 	// Create worker
 	w := worker.NewImportWorker(importQueue, importJobRepo, broadcaster, dbPool)
+	w.SetThrottle(cfg.ImportRowsPerSecond, cfg.ImportBatchSize)
+	w.SetConcurrency(cfg.ImportConcurrency)
+	w.SetPhoneRegion(cfg.BorrowerPhoneRegion)
+
+	// Photos from the photo_url column are downloaded by the scheduler
+	asynqClient := asynq.NewClient(asynq.RedisClientOpt{Addr: redisOpts.Addr, Password: redisOpts.Password, DB: redisOpts.DB})
+	defer asynqClient.Close()
+	w.SetPhotoEnqueuer(asynqClient)
 
 	// Setup graceful shutdown
 	sigChan := make(chan os.Signal, 1)
@@ -81,10 +90,27 @@ func main() {
 
 	// Start health check server on port 8081
 	healthMux := http.NewServeMux()
-	healthMux.HandleFunc("/health", func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "application/json")
-		w.WriteHeader(http.StatusOK)
-		fmt.Fprintf(w, `{"status":"healthy","worker":"running"}`)
+	healthMux.HandleFunc("/health", func(rw http.ResponseWriter, r *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+		stats, err := w.Stats(r.Context())
+		if err != nil {
+			// Redis is unreachable: the worker cannot dequeue either.
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			_ = json.NewEncoder(rw).Encode(map[string]any{
+				"status": "unhealthy",
+				"worker": "running",
+				"error":  err.Error(),
+			})
+			return
+		}
+		rw.WriteHeader(http.StatusOK)
+		_ = json.NewEncoder(rw).Encode(map[string]any{
+			"status":      "healthy",
+			"worker":      "running",
+			"concurrency": stats.Concurrency,
+			"active_jobs": stats.Active,
+			"queue_depth": stats.Queued,
+		})
 	})
 
 	healthServer := &http.Server{
@@ -115,7 +141,7 @@ func main() {
 	<-sigChan
 	log.Println("Shutdown signal received, draining worker...")
 
-	// Cancel context to stop dequeuing; in-flight job keeps running.
+	// Cancel context to stop dequeuing; in-flight jobs keep running.
 	cancel()
 
 	// Shutdown health check server
@@ -130,7 +156,7 @@ func main() {
 	case <-done:
 		log.Println("Worker drained")
 	case <-time.After(drainTimeout):
-		log.Printf("Worker drain timed out after %s; exiting with a job in flight (it will be recovered from the in-flight list on next start)", drainTimeout)
+		log.Printf("Worker drain timed out after %s; exiting with jobs in flight (they will be recovered from the in-flight list on next start)", drainTimeout)
 	}
 
 	log.Println("Worker stopped")
@@ -0,0 +1,13 @@
+-- migrate:up
+
+-- Compact BlurHash string rendered by clients as a blurred placeholder while
+-- the real thumbnail loads. Computed during upload processing when
+-- PHOTO_BLURHASH_ENABLED is set; existing rows can be filled in with
+-- `photo-admin backfill-blurhash`.
+ALTER TABLE warehouse.item_photos ADD COLUMN blurhash text;
+
+COMMENT ON COLUMN warehouse.item_photos.blurhash IS 'BlurHash placeholder string for the photo. NULL when not yet computed or when blurhash generation is disabled.';
+
+-- migrate:down
+
+ALTER TABLE warehouse.item_photos DROP COLUMN IF EXISTS blurhash;
@@ -0,0 +1,29 @@
+-- migrate:up
+
+-- Per-row outcome of an import job for rows that did NOT fail (failures stay
+-- in import_errors). With the on_conflict import option a row can create a
+-- new entity, update the existing one, or be skipped, and clients need to see
+-- which happened to each row.
+
+CREATE TABLE warehouse.import_row_results (
+    id uuid DEFAULT uuidv7() NOT NULL,
+    import_job_id uuid NOT NULL,
+    row_number integer NOT NULL,
+    action character varying(20) NOT NULL,
+    entity_id uuid,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT import_row_results_pkey PRIMARY KEY (id),
+    CONSTRAINT import_row_results_action_check CHECK (action IN ('created', 'updated', 'skipped'))
+);
+
+COMMENT ON TABLE warehouse.import_row_results IS 'Outcome of each successfully handled import row: created, updated (on_conflict=update) or skipped (on_conflict=skip). Failed rows are recorded in import_errors.';
+COMMENT ON COLUMN warehouse.import_row_results.entity_id IS 'Entity the row created, updated or matched. NULL when not applicable.';
+
+CREATE INDEX idx_import_row_results_import_job_id ON warehouse.import_row_results USING btree (import_job_id);
+
+ALTER TABLE ONLY warehouse.import_row_results
+    ADD CONSTRAINT import_row_results_import_job_id_fkey FOREIGN KEY (import_job_id) REFERENCES warehouse.import_jobs(id) ON DELETE CASCADE;
+
+-- migrate:down
+
+DROP TABLE warehouse.import_row_results;
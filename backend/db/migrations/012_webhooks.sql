@@ -0,0 +1,60 @@
+-- migrate:up
+
+-- Outgoing webhooks: a workspace registers a URL and the event types it wants,
+-- and every matching event is POSTed there as signed JSON by the job worker.
+-- Each attempt series is recorded in webhook_deliveries so admins can see what
+-- was sent and whether the receiver accepted it.
+
+CREATE TABLE warehouse.webhooks (
+    id uuid DEFAULT uuidv7() NOT NULL,
+    workspace_id uuid NOT NULL,
+    url character varying(2000) NOT NULL,
+    secret character varying(100) NOT NULL,
+    event_types text[] NOT NULL,
+    is_active boolean DEFAULT true NOT NULL,
+    created_by uuid,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT webhooks_pkey PRIMARY KEY (id)
+);
+
+COMMENT ON TABLE warehouse.webhooks IS 'Outgoing webhook subscriptions. Matching workspace events are POSTed to url, signed with HMAC-SHA256 using secret.';
+COMMENT ON COLUMN warehouse.webhooks.event_types IS 'Event types delivered to this webhook, e.g. loan.created, item.low_stock, pendingchange.approved.';
+
+CREATE INDEX idx_webhooks_workspace ON warehouse.webhooks USING btree (workspace_id);
+
+ALTER TABLE ONLY warehouse.webhooks
+    ADD CONSTRAINT webhooks_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES auth.workspaces(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY warehouse.webhooks
+    ADD CONSTRAINT webhooks_created_by_fkey FOREIGN KEY (created_by) REFERENCES auth.users(id) ON DELETE SET NULL;
+
+CREATE TABLE warehouse.webhook_deliveries (
+    id uuid DEFAULT uuidv7() NOT NULL,
+    webhook_id uuid NOT NULL,
+    workspace_id uuid NOT NULL,
+    event_type character varying(100) NOT NULL,
+    payload jsonb NOT NULL,
+    status character varying(20) DEFAULT 'pending'::character varying NOT NULL,
+    attempts integer DEFAULT 0 NOT NULL,
+    response_status integer,
+    last_error text,
+    delivered_at timestamp with time zone,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT webhook_deliveries_pkey PRIMARY KEY (id),
+    CONSTRAINT webhook_deliveries_status_check CHECK (status IN ('pending', 'succeeded', 'failed'))
+);
+
+COMMENT ON TABLE warehouse.webhook_deliveries IS 'One row per event sent to a webhook. status stays pending while the worker retries and becomes succeeded or failed once it settles.';
+COMMENT ON COLUMN warehouse.webhook_deliveries.payload IS 'Exact JSON body POSTed to the webhook; retries resend it byte-for-byte so the signature stays stable.';
+
+CREATE INDEX idx_webhook_deliveries_webhook_created ON warehouse.webhook_deliveries USING btree (webhook_id, created_at DESC);
+
+ALTER TABLE ONLY warehouse.webhook_deliveries
+    ADD CONSTRAINT webhook_deliveries_webhook_id_fkey FOREIGN KEY (webhook_id) REFERENCES warehouse.webhooks(id) ON DELETE CASCADE;
+
+-- migrate:down
+
+DROP TABLE warehouse.webhook_deliveries;
+DROP TABLE warehouse.webhooks;
@@ -0,0 +1,24 @@
+-- migrate:up
+
+-- Per-workspace reporting currency. Inventory prices keep their own
+-- currency_code; value reports convert them to base_currency using the
+-- workspace-maintained exchange_rates.
+
+CREATE TABLE warehouse.currency_settings (
+    workspace_id uuid NOT NULL,
+    base_currency character varying(3) DEFAULT 'EUR'::character varying NOT NULL,
+    exchange_rates jsonb DEFAULT '{}'::jsonb NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT currency_settings_pkey PRIMARY KEY (workspace_id),
+    CONSTRAINT chk_currency_settings_base_currency CHECK (((base_currency)::text ~ '^[A-Z]{3}$'::text))
+);
+
+COMMENT ON TABLE warehouse.currency_settings IS 'Workspace base currency and exchange rates used to convert inventory prices in value reports. Workspaces without a row report in EUR.';
+COMMENT ON COLUMN warehouse.currency_settings.exchange_rates IS 'Map of ISO 4217 code to the amount of base currency one unit of that currency is worth, e.g. {"USD": 0.92}.';
+
+ALTER TABLE ONLY warehouse.currency_settings
+    ADD CONSTRAINT currency_settings_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES auth.workspaces(id) ON DELETE CASCADE;
+
+-- migrate:down
+
+DROP TABLE warehouse.currency_settings;
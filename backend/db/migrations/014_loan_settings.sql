@@ -0,0 +1,22 @@
+-- migrate:up
+
+-- Per-workspace loan policy. A loan only counts as overdue once
+-- overdue_grace_days have passed after its due_date.
+
+CREATE TABLE warehouse.loan_settings (
+    workspace_id uuid NOT NULL,
+    overdue_grace_days integer DEFAULT 0 NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT loan_settings_pkey PRIMARY KEY (workspace_id),
+    CONSTRAINT chk_loan_settings_overdue_grace_days CHECK (((overdue_grace_days >= 0) AND (overdue_grace_days <= 365)))
+);
+
+COMMENT ON TABLE warehouse.loan_settings IS 'Workspace loan policy. Workspaces without a row have no overdue grace period.';
+COMMENT ON COLUMN warehouse.loan_settings.overdue_grace_days IS 'Days after due_date before a loan is reported and reminded as overdue.';
+
+ALTER TABLE ONLY warehouse.loan_settings
+    ADD CONSTRAINT loan_settings_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES auth.workspaces(id) ON DELETE CASCADE;
+
+-- migrate:down
+
+DROP TABLE warehouse.loan_settings;
@@ -0,0 +1,32 @@
+-- migrate:up
+
+-- Photos fetched from the photo_url column of an item import. Downloads run
+-- as background jobs after the row is imported, so their outcome is tracked
+-- here rather than in import_row_results / import_errors.
+
+CREATE TABLE warehouse.import_photo_results (
+    id uuid DEFAULT uuidv7() NOT NULL,
+    import_job_id uuid NOT NULL,
+    row_number integer NOT NULL,
+    item_id uuid NOT NULL,
+    url text NOT NULL,
+    status character varying(20) NOT NULL,
+    photo_id uuid,
+    message text,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT import_photo_results_pkey PRIMARY KEY (id),
+    CONSTRAINT import_photo_results_status_check CHECK (status IN ('pending', 'attached', 'failed', 'skipped'))
+);
+
+COMMENT ON TABLE warehouse.import_photo_results IS 'Outcome of fetching the photo_url of an imported item row. Photo failures never fail the row itself.';
+COMMENT ON COLUMN warehouse.import_photo_results.message IS 'Why the photo was skipped or failed. NULL when pending or attached.';
+
+CREATE INDEX idx_import_photo_results_import_job_id ON warehouse.import_photo_results USING btree (import_job_id);
+
+ALTER TABLE ONLY warehouse.import_photo_results
+    ADD CONSTRAINT import_photo_results_import_job_id_fkey FOREIGN KEY (import_job_id) REFERENCES warehouse.import_jobs(id) ON DELETE CASCADE;
+
+-- migrate:down
+
+DROP TABLE warehouse.import_photo_results;
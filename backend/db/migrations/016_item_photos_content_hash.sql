@@ -0,0 +1,15 @@
+-- migrate:up
+
+-- Hex SHA-256 of the uploaded file's bytes, used to spot a photo being
+-- uploaded to the same item twice. NULL for photos uploaded before this
+-- column existed.
+ALTER TABLE warehouse.item_photos ADD COLUMN content_hash text;
+
+COMMENT ON COLUMN warehouse.item_photos.content_hash IS 'Hex SHA-256 of the original file bytes, for exact-duplicate detection within an item. NULL for photos uploaded before it was recorded.';
+
+CREATE INDEX idx_item_photos_item_content_hash ON warehouse.item_photos USING btree (item_id, content_hash) WHERE (content_hash IS NOT NULL);
+
+-- migrate:down
+
+DROP INDEX IF EXISTS warehouse.idx_item_photos_item_content_hash;
+ALTER TABLE warehouse.item_photos DROP COLUMN IF EXISTS content_hash;
@@ -0,0 +1,52 @@
+-- migrate:up
+
+-- Workspace-defined item attributes (voltage, caliber, size, ...). Values are
+-- validated against the field type by the application and stored in a
+-- canonical text form: numbers as decimal strings, dates as YYYY-MM-DD and
+-- booleans as true/false.
+
+CREATE TABLE warehouse.custom_fields (
+    id uuid DEFAULT uuidv7() NOT NULL,
+    workspace_id uuid NOT NULL,
+    name character varying(100) NOT NULL,
+    field_type character varying(10) NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT custom_fields_pkey PRIMARY KEY (id),
+    CONSTRAINT custom_fields_workspace_id_name_key UNIQUE (workspace_id, name),
+    CONSTRAINT uq_custom_fields_ws_id UNIQUE (workspace_id, id),
+    CONSTRAINT custom_fields_field_type_check CHECK (field_type IN ('text', 'number', 'date', 'bool'))
+);
+
+COMMENT ON TABLE warehouse.custom_fields IS 'Per-workspace custom item attribute definitions.';
+
+CREATE TABLE warehouse.item_custom_values (
+    item_id uuid NOT NULL,
+    field_id uuid NOT NULL,
+    workspace_id uuid NOT NULL,
+    value text NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT item_custom_values_pkey PRIMARY KEY (item_id, field_id)
+);
+
+COMMENT ON TABLE warehouse.item_custom_values IS 'Custom field values of items, one row per item and field.';
+COMMENT ON COLUMN warehouse.item_custom_values.value IS 'Canonical text form of the value for the field type.';
+
+CREATE INDEX idx_item_custom_values_field ON warehouse.item_custom_values USING btree (field_id);
+
+ALTER TABLE ONLY warehouse.custom_fields
+    ADD CONSTRAINT custom_fields_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES auth.workspaces(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY warehouse.item_custom_values
+    ADD CONSTRAINT item_custom_values_field_fk FOREIGN KEY (workspace_id, field_id) REFERENCES warehouse.custom_fields(workspace_id, id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY warehouse.item_custom_values
+    ADD CONSTRAINT item_custom_values_item_fk FOREIGN KEY (workspace_id, item_id) REFERENCES warehouse.items(workspace_id, id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY warehouse.item_custom_values
+    ADD CONSTRAINT item_custom_values_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES auth.workspaces(id) ON DELETE CASCADE;
+
+-- migrate:down
+
+DROP TABLE warehouse.item_custom_values;
+DROP TABLE warehouse.custom_fields;
@@ -0,0 +1,13 @@
+-- migrate:up
+
+-- Thumbnail settings (imageprocessor.Config.ThumbnailVersion) the photo's
+-- thumbnails were generated with. Lets photo-admin regenerate only the
+-- photos made under an older config. NULL for thumbnails generated before
+-- this column existed, which are treated as stale.
+ALTER TABLE warehouse.item_photos ADD COLUMN thumbnail_config_version text;
+
+COMMENT ON COLUMN warehouse.item_photos.thumbnail_config_version IS 'Thumbnail config version (sizes, format, quality) the thumbnails were generated with. NULL when unknown.';
+
+-- migrate:down
+
+ALTER TABLE warehouse.item_photos DROP COLUMN IF EXISTS thumbnail_config_version;
@@ -0,0 +1,22 @@
+-- migrate:up
+
+-- Per-workspace photo policy. Uploads beyond max_photos_per_item are
+-- rejected.
+
+CREATE TABLE warehouse.photo_settings (
+    workspace_id uuid NOT NULL,
+    max_photos_per_item integer DEFAULT 20 NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT photo_settings_pkey PRIMARY KEY (workspace_id),
+    CONSTRAINT chk_photo_settings_max_photos_per_item CHECK (((max_photos_per_item >= 1) AND (max_photos_per_item <= 500)))
+);
+
+COMMENT ON TABLE warehouse.photo_settings IS 'Workspace photo policy. Workspaces without a row allow 20 photos per item.';
+COMMENT ON COLUMN warehouse.photo_settings.max_photos_per_item IS 'Maximum number of photos a single item may have.';
+
+ALTER TABLE ONLY warehouse.photo_settings
+    ADD CONSTRAINT photo_settings_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES auth.workspaces(id) ON DELETE CASCADE;
+
+-- migrate:down
+
+DROP TABLE warehouse.photo_settings;
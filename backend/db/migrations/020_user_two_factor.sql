@@ -0,0 +1,42 @@
+-- migrate:up
+
+-- TOTP two-factor authentication. A row with enabled_at NULL is an
+-- enrollment that has not been confirmed with a code yet.
+
+CREATE TABLE auth.user_two_factor (
+    user_id uuid NOT NULL,
+    totp_secret_encrypted text NOT NULL,
+    last_used_step bigint DEFAULT 0 NOT NULL,
+    enabled_at timestamp with time zone,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT user_two_factor_pkey PRIMARY KEY (user_id)
+);
+
+COMMENT ON TABLE auth.user_two_factor IS 'TOTP two-factor settings. Unconfirmed enrollments have enabled_at NULL.';
+COMMENT ON COLUMN auth.user_two_factor.totp_secret_encrypted IS 'AES-GCM encrypted base32 TOTP secret. Never store plain secrets.';
+COMMENT ON COLUMN auth.user_two_factor.last_used_step IS 'Time step of the last accepted code; codes at or before it are rejected as replays.';
+
+CREATE TABLE auth.user_recovery_codes (
+    id uuid DEFAULT uuidv7() NOT NULL,
+    user_id uuid NOT NULL,
+    code_hash character varying(64) NOT NULL,
+    used_at timestamp with time zone,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT user_recovery_codes_pkey PRIMARY KEY (id)
+);
+
+COMMENT ON TABLE auth.user_recovery_codes IS 'Single-use two-factor recovery codes.';
+COMMENT ON COLUMN auth.user_recovery_codes.code_hash IS 'SHA-256 hash of the normalized recovery code. Never store plain codes.';
+
+CREATE INDEX ix_user_recovery_codes_user ON auth.user_recovery_codes USING btree (user_id);
+
+ALTER TABLE ONLY auth.user_two_factor
+    ADD CONSTRAINT user_two_factor_user_id_fkey FOREIGN KEY (user_id) REFERENCES auth.users(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY auth.user_recovery_codes
+    ADD CONSTRAINT user_recovery_codes_user_id_fkey FOREIGN KEY (user_id) REFERENCES auth.user_two_factor(user_id) ON DELETE CASCADE;
+
+-- migrate:down
+
+DROP TABLE auth.user_recovery_codes;
+DROP TABLE auth.user_two_factor;
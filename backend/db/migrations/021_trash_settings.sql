@@ -0,0 +1,23 @@
+-- migrate:up
+
+-- Per-workspace trash policy. The cleanup job hard-deletes deletion
+-- tombstones older than trash_retention_days, or the server-wide retention
+-- for workspaces without a row.
+
+CREATE TABLE warehouse.trash_settings (
+    workspace_id uuid NOT NULL,
+    trash_retention_days integer NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT trash_settings_pkey PRIMARY KEY (workspace_id),
+    CONSTRAINT chk_trash_settings_trash_retention_days CHECK (((trash_retention_days >= 1) AND (trash_retention_days <= 3650)))
+);
+
+COMMENT ON TABLE warehouse.trash_settings IS 'Workspace trash policy. Workspaces without a row use the server-wide retention.';
+COMMENT ON COLUMN warehouse.trash_settings.trash_retention_days IS 'Days deleted records are kept before the cleanup job removes them for good.';
+
+ALTER TABLE ONLY warehouse.trash_settings
+    ADD CONSTRAINT trash_settings_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES auth.workspaces(id) ON DELETE CASCADE;
+
+-- migrate:down
+
+DROP TABLE warehouse.trash_settings;
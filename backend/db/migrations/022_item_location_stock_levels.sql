@@ -0,0 +1,33 @@
+-- migrate:up
+
+-- Per-location minimum stock. An item with rows here is reported low at each
+-- of those locations whose stock falls below the row's minimum; items without
+-- rows keep using items.min_stock_level against their total stock.
+
+CREATE TABLE warehouse.item_location_stock_levels (
+    item_id uuid NOT NULL,
+    location_id uuid NOT NULL,
+    workspace_id uuid NOT NULL,
+    min_stock_level integer NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT item_location_stock_levels_pkey PRIMARY KEY (item_id, location_id),
+    CONSTRAINT chk_item_location_stock_levels_min_stock_positive CHECK ((min_stock_level > 0))
+);
+
+COMMENT ON TABLE warehouse.item_location_stock_levels IS 'Minimum stock of an item at one location. Overrides items.min_stock_level for low-stock reporting.';
+COMMENT ON COLUMN warehouse.item_location_stock_levels.min_stock_level IS 'The item is low at this location when its stock there falls below this.';
+
+CREATE INDEX ix_item_location_stock_levels_workspace ON warehouse.item_location_stock_levels USING btree (workspace_id);
+
+ALTER TABLE ONLY warehouse.item_location_stock_levels
+    ADD CONSTRAINT item_location_stock_levels_item_fk FOREIGN KEY (workspace_id, item_id) REFERENCES warehouse.items(workspace_id, id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY warehouse.item_location_stock_levels
+    ADD CONSTRAINT item_location_stock_levels_location_fk FOREIGN KEY (workspace_id, location_id) REFERENCES warehouse.locations(workspace_id, id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY warehouse.item_location_stock_levels
+    ADD CONSTRAINT item_location_stock_levels_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES auth.workspaces(id) ON DELETE CASCADE;
+
+-- migrate:down
+
+DROP TABLE warehouse.item_location_stock_levels;
@@ -0,0 +1,27 @@
+-- migrate:up
+
+-- Photo display orders are spaced 1024 apart so a single photo can be moved
+-- between two others by rewriting only its own row. Renumber existing
+-- galleries, keeping their current order.
+
+UPDATE warehouse.item_photos p
+SET display_order = o.new_order
+FROM (
+    SELECT id, ((ROW_NUMBER() OVER (PARTITION BY item_id ORDER BY display_order, created_at)) - 1) * 1024 AS new_order
+    FROM warehouse.item_photos
+) o
+WHERE p.id = o.id AND p.display_order <> o.new_order;
+
+COMMENT ON COLUMN warehouse.item_photos.display_order IS 'Gallery position. Spaced 1024 apart so a photo can move between neighbours without renumbering the rest.';
+
+-- migrate:down
+
+UPDATE warehouse.item_photos p
+SET display_order = o.new_order
+FROM (
+    SELECT id, (ROW_NUMBER() OVER (PARTITION BY item_id ORDER BY display_order, created_at)) - 1 AS new_order
+    FROM warehouse.item_photos
+) o
+WHERE p.id = o.id AND p.display_order <> o.new_order;
+
+COMMENT ON COLUMN warehouse.item_photos.display_order IS NULL;
@@ -0,0 +1,32 @@
+-- migrate:up
+
+-- Extra scannable codes per item (a second UPC, an EAN, an old asset tag).
+-- items.barcode stays the primary code shown on the item; scanning resolves
+-- against it and every identifier here. A value is unique per workspace.
+
+CREATE TABLE warehouse.item_identifiers (
+    id uuid DEFAULT uuidv7() NOT NULL,
+    workspace_id uuid NOT NULL,
+    item_id uuid NOT NULL,
+    identifier_type character varying(20) NOT NULL,
+    value character varying(50) NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT item_identifiers_pkey PRIMARY KEY (id),
+    CONSTRAINT uq_item_identifiers_ws_value UNIQUE (workspace_id, value),
+    CONSTRAINT chk_item_identifiers_type CHECK (((identifier_type)::text = ANY ((ARRAY['upc'::character varying, 'ean'::character varying, 'isbn'::character varying, 'asset_tag'::character varying, 'other'::character varying])::text[])))
+);
+
+COMMENT ON TABLE warehouse.item_identifiers IS 'Additional barcodes and codes an item can be scanned by. items.barcode remains the primary one.';
+COMMENT ON COLUMN warehouse.item_identifiers.value IS 'The scanned code, matched exactly. Unique per workspace.';
+
+CREATE INDEX ix_item_identifiers_item ON warehouse.item_identifiers USING btree (item_id);
+
+ALTER TABLE ONLY warehouse.item_identifiers
+    ADD CONSTRAINT item_identifiers_item_fk FOREIGN KEY (workspace_id, item_id) REFERENCES warehouse.items(workspace_id, id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY warehouse.item_identifiers
+    ADD CONSTRAINT item_identifiers_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES auth.workspaces(id) ON DELETE CASCADE;
+
+-- migrate:down
+
+DROP TABLE warehouse.item_identifiers;
@@ -0,0 +1,19 @@
+-- migrate:up
+
+-- Loans created without a due date are due default_loan_days after they
+-- were loaned out.
+
+ALTER TABLE warehouse.loan_settings
+    ADD COLUMN default_loan_days integer DEFAULT 14 NOT NULL,
+    ADD CONSTRAINT chk_loan_settings_default_loan_days CHECK (((default_loan_days > 0) AND (default_loan_days <= 365)));
+
+COMMENT ON TABLE warehouse.loan_settings IS 'Workspace loan policy. Workspaces without a row have no overdue grace period and a 14-day default loan period.';
+COMMENT ON COLUMN warehouse.loan_settings.default_loan_days IS 'Loan period in days used for the due date when a loan is created without one.';
+
+-- migrate:down
+
+ALTER TABLE warehouse.loan_settings
+    DROP CONSTRAINT chk_loan_settings_default_loan_days,
+    DROP COLUMN default_loan_days;
+
+COMMENT ON TABLE warehouse.loan_settings IS 'Workspace loan policy. Workspaces without a row have no overdue grace period.';
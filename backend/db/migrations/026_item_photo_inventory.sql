@@ -0,0 +1,20 @@
+-- migrate:up
+
+-- A photo can show one physical unit (e.g. the scratched one) rather than
+-- the item in general. Deleting the inventory record keeps the photo on the
+-- item and only drops the tag.
+ALTER TABLE warehouse.item_photos ADD COLUMN inventory_id uuid;
+
+ALTER TABLE warehouse.item_photos
+    ADD CONSTRAINT item_photos_inventory_fk FOREIGN KEY (workspace_id, inventory_id)
+    REFERENCES warehouse.inventory(workspace_id, id) ON DELETE SET NULL (inventory_id);
+
+CREATE INDEX idx_item_photos_inventory ON warehouse.item_photos (inventory_id, display_order) WHERE inventory_id IS NOT NULL;
+
+COMMENT ON COLUMN warehouse.item_photos.inventory_id IS 'Inventory record (physical unit) the photo shows. NULL when it shows the item in general.';
+
+-- migrate:down
+
+DROP INDEX IF EXISTS warehouse.idx_item_photos_inventory;
+ALTER TABLE warehouse.item_photos DROP CONSTRAINT IF EXISTS item_photos_inventory_fk;
+ALTER TABLE warehouse.item_photos DROP COLUMN IF EXISTS inventory_id;
@@ -0,0 +1,21 @@
+-- migrate:up
+
+-- Per-workspace inventory policy. Workspaces without a row treat RESERVED
+-- stock as unavailable.
+
+CREATE TABLE warehouse.inventory_settings (
+    workspace_id uuid NOT NULL,
+    reserved_is_available boolean DEFAULT false NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT inventory_settings_pkey PRIMARY KEY (workspace_id)
+);
+
+COMMENT ON TABLE warehouse.inventory_settings IS 'Workspace inventory policy. Workspaces without a row use the defaults.';
+COMMENT ON COLUMN warehouse.inventory_settings.reserved_is_available IS 'Whether RESERVED entries count as available stock and can be loaned out. Off by default.';
+
+ALTER TABLE ONLY warehouse.inventory_settings
+    ADD CONSTRAINT inventory_settings_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES auth.workspaces(id) ON DELETE CASCADE;
+
+-- migrate:down
+
+DROP TABLE warehouse.inventory_settings;
@@ -0,0 +1,10 @@
+-- migrate:up
+
+-- Trigram similarity over item names and brands, used to suggest likely
+-- duplicate items for merging.
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm WITH SCHEMA public;
+
+-- migrate:down
+
+DROP EXTENSION IF EXISTS pg_trgm;
@@ -0,0 +1,12 @@
+-- migrate:up
+
+-- Records which imported photo was made its item's primary photo.
+
+ALTER TABLE warehouse.import_photo_results
+    ADD COLUMN is_primary boolean DEFAULT false NOT NULL;
+
+COMMENT ON COLUMN warehouse.import_photo_results.is_primary IS 'Whether this photo was chosen as the item''s primary photo once all of the item''s imported photos settled.';
+
+-- migrate:down
+
+ALTER TABLE warehouse.import_photo_results DROP COLUMN IF EXISTS is_primary;
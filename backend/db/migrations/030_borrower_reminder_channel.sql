@@ -0,0 +1,14 @@
+-- migrate:up
+
+-- How each borrower wants loan reminders: by email, by SMS or not at all.
+-- One channel per borrower so a reminder is never sent twice.
+
+ALTER TABLE warehouse.borrowers
+    ADD COLUMN reminder_channel character varying(10) DEFAULT 'email'::character varying NOT NULL,
+    ADD CONSTRAINT chk_borrowers_reminder_channel CHECK (((reminder_channel)::text = ANY ((ARRAY['email'::character varying, 'sms'::character varying, 'none'::character varying])::text[])));
+
+COMMENT ON COLUMN warehouse.borrowers.reminder_channel IS 'Channel loan reminders are sent to the borrower on: email, sms or none.';
+
+-- migrate:down
+
+ALTER TABLE warehouse.borrowers DROP COLUMN IF EXISTS reminder_channel;
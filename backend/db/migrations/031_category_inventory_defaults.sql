@@ -0,0 +1,25 @@
+-- migrate:up
+
+-- Per-category condition and status pre-filled on new inventory for items in
+-- the category when the create request leaves them out. Categories without a
+-- row (or with a NULL column) fall back to NEW / AVAILABLE.
+
+CREATE TABLE warehouse.category_inventory_defaults (
+    category_id uuid NOT NULL,
+    workspace_id uuid NOT NULL,
+    default_condition warehouse.item_condition_enum,
+    default_status warehouse.item_status_enum,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT category_inventory_defaults_pkey PRIMARY KEY (category_id)
+);
+
+COMMENT ON TABLE warehouse.category_inventory_defaults IS 'Condition and status applied to new inventory of items in the category when the request omits them.';
+COMMENT ON COLUMN warehouse.category_inventory_defaults.default_condition IS 'Condition for new inventory. NULL uses the global default (NEW).';
+COMMENT ON COLUMN warehouse.category_inventory_defaults.default_status IS 'Status for new inventory. NULL uses the global default (AVAILABLE).';
+
+ALTER TABLE ONLY warehouse.category_inventory_defaults
+    ADD CONSTRAINT category_inventory_defaults_category_fk FOREIGN KEY (workspace_id, category_id) REFERENCES warehouse.categories(workspace_id, id) ON DELETE CASCADE;
+
+-- migrate:down
+
+DROP TABLE warehouse.category_inventory_defaults;
@@ -0,0 +1,14 @@
+-- migrate:up
+
+-- Fixed assets (a mounted shelf, a built-in appliance) are tracked as items
+-- but never lent out. Their inventory can still be AVAILABLE, meaning present;
+-- loans and the available-inventory listing skip them.
+
+ALTER TABLE warehouse.items
+    ADD COLUMN loanable boolean DEFAULT true NOT NULL;
+
+COMMENT ON COLUMN warehouse.items.loanable IS 'False for fixed assets (a mounted shelf, a built-in) that are never lent out.';
+
+-- migrate:down
+
+ALTER TABLE warehouse.items DROP COLUMN IF EXISTS loanable;
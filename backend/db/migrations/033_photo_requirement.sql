@@ -0,0 +1,19 @@
+-- migrate:up
+
+-- Workspaces that catalog by photo can require every item to have one: items
+-- without a photo are reported as incomplete, and optionally cannot get
+-- inventory until they have one.
+
+ALTER TABLE warehouse.photo_settings
+    ADD COLUMN require_photo boolean DEFAULT false NOT NULL,
+    ADD COLUMN block_inventory_without_photo boolean DEFAULT false NOT NULL;
+
+COMMENT ON COLUMN warehouse.photo_settings.require_photo IS 'Items need at least one photo to count as cataloged; items without one are reported as incomplete.';
+
+COMMENT ON COLUMN warehouse.photo_settings.block_inventory_without_photo IS 'Refuse new inventory for items without a photo. Only applies with require_photo.';
+
+-- migrate:down
+
+ALTER TABLE warehouse.photo_settings
+    DROP COLUMN IF EXISTS block_inventory_without_photo,
+    DROP COLUMN IF EXISTS require_photo;
@@ -0,0 +1,14 @@
+-- migrate:up
+
+-- Workspaces that treat expiry as more than information can have the daily
+-- expiry job move inventory past its expiration_date out of available stock.
+-- NULL (the default) leaves expired entries alone.
+
+ALTER TABLE warehouse.inventory_settings
+    ADD COLUMN expired_status warehouse.item_status_enum;
+
+COMMENT ON COLUMN warehouse.inventory_settings.expired_status IS 'Status the daily expiry job moves expired, non-loaned inventory to (e.g. DISPOSED). NULL turns the job off for the workspace.';
+
+-- migrate:down
+
+ALTER TABLE warehouse.inventory_settings DROP COLUMN IF EXISTS expired_status;
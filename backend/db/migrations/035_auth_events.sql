@@ -0,0 +1,34 @@
+-- migrate:up
+
+-- Security log of authentication events: sign-ins (successful and failed),
+-- password and two-factor changes, and session revocations. Failed sign-ins
+-- for an unknown email have no user_id; email is kept so repeated failures
+-- against one account can be counted for lockout.
+
+CREATE TABLE auth.auth_events (
+    id uuid DEFAULT uuidv7() NOT NULL,
+    user_id uuid,
+    email character varying(255),
+    event_type character varying(30) NOT NULL,
+    success boolean NOT NULL,
+    failure_reason character varying(30),
+    ip_address inet,
+    user_agent text,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT auth_events_pkey PRIMARY KEY (id)
+);
+
+COMMENT ON TABLE auth.auth_events IS 'Authentication events (sign-ins, password and two-factor changes, session revocations) for the security log.';
+COMMENT ON COLUMN auth.auth_events.email IS 'Email the sign-in was attempted with, lowercased. Set even when no user matched.';
+COMMENT ON COLUMN auth.auth_events.event_type IS 'login, password_change, two_factor_enabled, two_factor_disabled, session_revoked.';
+COMMENT ON COLUMN auth.auth_events.failure_reason IS 'Why a failed event failed (e.g. invalid_credentials, invalid_totp). Never the submitted secret.';
+
+CREATE INDEX ix_auth_events_user_created ON auth.auth_events USING btree (user_id, created_at DESC);
+CREATE INDEX ix_auth_events_failed_email ON auth.auth_events USING btree (email, created_at) WHERE (NOT success);
+
+ALTER TABLE ONLY auth.auth_events
+    ADD CONSTRAINT auth_events_user_id_fkey FOREIGN KEY (user_id) REFERENCES auth.users(id) ON DELETE CASCADE;
+
+-- migrate:down
+
+DROP TABLE auth.auth_events;
@@ -0,0 +1,40 @@
+-- migrate:up
+
+-- Ordered keyword rules that pick a category for new items created (or
+-- imported) without one: the first rule whose pattern matches the item name
+-- assigns its category. Import row results record which created items got
+-- their category this way.
+
+CREATE TABLE warehouse.category_rules (
+    id uuid DEFAULT uuidv7() NOT NULL,
+    workspace_id uuid NOT NULL,
+    category_id uuid NOT NULL,
+    pattern character varying(200) NOT NULL,
+    match_type character varying(20) NOT NULL,
+    "position" integer NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT category_rules_pkey PRIMARY KEY (id),
+    CONSTRAINT category_rules_match_type_check CHECK (match_type IN ('substring', 'regex'))
+);
+
+COMMENT ON TABLE warehouse.category_rules IS 'Keyword rules assigning a category to new items created without one. Rules are tried in position order; the first match wins.';
+COMMENT ON COLUMN warehouse.category_rules.pattern IS 'Text matched case-insensitively against the item name: a plain substring or an RE2 regular expression, per match_type.';
+COMMENT ON COLUMN warehouse.category_rules."position" IS 'Evaluation order within the workspace, lowest first.';
+
+CREATE INDEX ix_category_rules_workspace_position ON warehouse.category_rules USING btree (workspace_id, "position");
+
+ALTER TABLE ONLY warehouse.category_rules
+    ADD CONSTRAINT category_rules_category_fk FOREIGN KEY (workspace_id, category_id) REFERENCES warehouse.categories(workspace_id, id) ON DELETE CASCADE;
+
+ALTER TABLE warehouse.import_row_results
+    ADD COLUMN auto_categorized boolean DEFAULT false NOT NULL;
+
+COMMENT ON COLUMN warehouse.import_row_results.auto_categorized IS 'The row created an item whose category was assigned by a category rule.';
+
+-- migrate:down
+
+ALTER TABLE warehouse.import_row_results
+    DROP COLUMN IF EXISTS auto_categorized;
+
+DROP TABLE warehouse.category_rules;
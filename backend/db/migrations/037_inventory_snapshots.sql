@@ -0,0 +1,32 @@
+-- migrate:up
+
+-- Point-in-time copies of a workspace's inventory for year-end records. The
+-- per-item summary is computed when the snapshot is taken and stored as is,
+-- so later inventory changes never alter it.
+
+CREATE TABLE warehouse.inventory_snapshots (
+    id uuid DEFAULT uuidv7() NOT NULL,
+    workspace_id uuid NOT NULL,
+    label character varying(200) NOT NULL,
+    currency_code character varying(3) NOT NULL,
+    total_quantity bigint NOT NULL,
+    total_value bigint NOT NULL,
+    unvalued_count integer NOT NULL,
+    items jsonb NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT inventory_snapshots_pkey PRIMARY KEY (id)
+);
+
+COMMENT ON TABLE warehouse.inventory_snapshots IS 'Frozen summaries of a workspace''s inventory, computed when taken and never updated.';
+COMMENT ON COLUMN warehouse.inventory_snapshots.currency_code IS 'Workspace base currency at the time; total_value and the item values are in it.';
+COMMENT ON COLUMN warehouse.inventory_snapshots.unvalued_count IS 'Inventory rows left out of total_value: unpriced, or priced in a currency with no exchange rate.';
+COMMENT ON COLUMN warehouse.inventory_snapshots.items IS 'Per-item summary: quantity, quantity by condition and total value of the item''s unarchived inventory.';
+
+CREATE INDEX ix_inventory_snapshots_workspace_created ON warehouse.inventory_snapshots USING btree (workspace_id, created_at DESC);
+
+ALTER TABLE ONLY warehouse.inventory_snapshots
+    ADD CONSTRAINT inventory_snapshots_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES auth.workspaces(id) ON DELETE CASCADE;
+
+-- migrate:down
+
+DROP TABLE warehouse.inventory_snapshots;
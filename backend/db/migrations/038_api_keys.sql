@@ -0,0 +1,42 @@
+-- migrate:up
+
+-- Per-workspace API keys for machine clients (home-automation scripts) that
+-- cannot sign in interactively. A key authenticates requests to its own
+-- workspace with a fixed role. Only a SHA-256 hash of the key is stored; the
+-- key itself is shown once, when it is created.
+
+CREATE TABLE auth.api_keys (
+    id uuid DEFAULT uuidv7() NOT NULL,
+    workspace_id uuid NOT NULL,
+    name character varying(100) NOT NULL,
+    key_prefix character varying(20) NOT NULL,
+    key_hash character varying(64) NOT NULL,
+    role character varying(20) NOT NULL,
+    expires_at timestamp with time zone,
+    last_used_at timestamp with time zone,
+    revoked_at timestamp with time zone,
+    created_by uuid NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT api_keys_pkey PRIMARY KEY (id),
+    CONSTRAINT api_keys_key_hash_key UNIQUE (key_hash),
+    CONSTRAINT api_keys_role_check CHECK (role IN ('admin', 'member', 'viewer'))
+);
+
+COMMENT ON TABLE auth.api_keys IS 'API keys machine clients authenticate with (Authorization: ApiKey <key>). Each key is limited to one workspace and acts with a fixed role.';
+COMMENT ON COLUMN auth.api_keys.key_prefix IS 'Start of the key, shown so owners can tell keys apart.';
+COMMENT ON COLUMN auth.api_keys.key_hash IS 'SHA-256 of the key, hex. The key itself is never stored.';
+COMMENT ON COLUMN auth.api_keys.role IS 'Workspace role requests made with the key act with: admin, member or viewer.';
+COMMENT ON COLUMN auth.api_keys.expires_at IS 'When the key stops working. NULL never expires.';
+COMMENT ON COLUMN auth.api_keys.created_by IS 'Owner who created the key. The key stops working if they leave the workspace.';
+
+CREATE INDEX ix_api_keys_workspace ON auth.api_keys USING btree (workspace_id, created_at DESC);
+
+ALTER TABLE ONLY auth.api_keys
+    ADD CONSTRAINT api_keys_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES auth.workspaces(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY auth.api_keys
+    ADD CONSTRAINT api_keys_created_by_fkey FOREIGN KEY (created_by) REFERENCES auth.users(id) ON DELETE CASCADE;
+
+-- migrate:down
+
+DROP TABLE auth.api_keys;
@@ -0,0 +1,38 @@
+-- migrate:up
+
+-- Read-only links to a single item for people outside the workspace, e.g. a
+-- buyer. Only a hash of the link token is stored.
+
+CREATE TABLE warehouse.item_shares (
+    id uuid DEFAULT uuidv7() NOT NULL,
+    workspace_id uuid NOT NULL,
+    item_id uuid NOT NULL,
+    token_hash character varying(64) NOT NULL,
+    include_prices boolean DEFAULT false NOT NULL,
+    expires_at timestamp with time zone,
+    revoked_at timestamp with time zone,
+    created_by uuid,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT item_shares_pkey PRIMARY KEY (id),
+    CONSTRAINT item_shares_token_hash_key UNIQUE (token_hash)
+);
+
+COMMENT ON TABLE warehouse.item_shares IS 'Public read-only links to one item, opened with a token that carries no workspace or item IDs.';
+COMMENT ON COLUMN warehouse.item_shares.token_hash IS 'SHA-256 of the link token, hex. The token itself is shown once and never stored.';
+COMMENT ON COLUMN warehouse.item_shares.include_prices IS 'Whether the shared view shows the purchase prices of the item''s inventory.';
+COMMENT ON COLUMN warehouse.item_shares.expires_at IS 'When the link stops working. NULL never expires.';
+
+CREATE INDEX ix_item_shares_item ON warehouse.item_shares USING btree (workspace_id, item_id, created_at DESC);
+
+ALTER TABLE ONLY warehouse.item_shares
+    ADD CONSTRAINT item_shares_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES auth.workspaces(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY warehouse.item_shares
+    ADD CONSTRAINT item_shares_item_id_fkey FOREIGN KEY (item_id) REFERENCES warehouse.items(id) ON DELETE CASCADE;
+
+ALTER TABLE ONLY warehouse.item_shares
+    ADD CONSTRAINT item_shares_created_by_fkey FOREIGN KEY (created_by) REFERENCES auth.users(id) ON DELETE SET NULL;
+
+-- migrate:down
+
+DROP TABLE warehouse.item_shares;
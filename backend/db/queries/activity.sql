@@ -11,6 +11,22 @@ WHERE a.workspace_id = $1
 ORDER BY a.created_at DESC
 LIMIT $2 OFFSET $3;
 
+-- name: ListActivityFeed :many
+SELECT a.*, u.full_name as user_name
+FROM warehouse.activity_log a
+LEFT JOIN auth.users u ON a.user_id = u.id
+WHERE a.workspace_id = $1
+  AND (sqlc.narg('entity_type')::warehouse.activity_entity_enum IS NULL OR a.entity_type = sqlc.narg('entity_type'))
+  AND (sqlc.narg('user_id')::uuid IS NULL OR a.user_id = sqlc.narg('user_id'))
+ORDER BY a.created_at DESC, a.id DESC
+LIMIT $2 OFFSET $3;
+
+-- name: CountActivityFeed :one
+SELECT COUNT(*)::int FROM warehouse.activity_log
+WHERE workspace_id = $1
+  AND (sqlc.narg('entity_type')::warehouse.activity_entity_enum IS NULL OR entity_type = sqlc.narg('entity_type'))
+  AND (sqlc.narg('user_id')::uuid IS NULL OR user_id = sqlc.narg('user_id'));
+
 -- name: ListActivityByEntity :many
 SELECT a.*, u.full_name as user_name
 FROM warehouse.activity_log a
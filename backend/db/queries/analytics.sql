@@ -9,13 +9,22 @@ SELECT
     (SELECT COUNT(*) FROM warehouse.containers con WHERE con.workspace_id = sqlc.arg(workspace_id) AND con.is_archived = false)::int as total_containers,
     (SELECT COUNT(*) FROM warehouse.loans ln WHERE ln.workspace_id = sqlc.arg(workspace_id) AND ln.returned_at IS NULL)::int as active_loans,
     (SELECT COUNT(*) FROM warehouse.loans ln2 WHERE ln2.workspace_id = sqlc.arg(workspace_id) AND ln2.returned_at IS NULL AND ln2.due_date < CURRENT_DATE)::int as overdue_loans,
-    (SELECT COUNT(*) FROM (
+    (SELECT COUNT(DISTINCT low_stock.id) FROM (
         SELECT i.id
         FROM warehouse.items i
         LEFT JOIN warehouse.inventory inven ON i.id = inven.item_id AND inven.is_archived = false
         WHERE i.workspace_id = sqlc.arg(workspace_id) AND i.is_archived = false AND i.min_stock_level > 0
+          AND NOT EXISTS (SELECT 1 FROM warehouse.item_location_stock_levels sl WHERE sl.item_id = i.id)
         GROUP BY i.id, i.min_stock_level
         HAVING COALESCE(SUM(inven.quantity), 0) < i.min_stock_level
+        UNION ALL
+        SELECT sl.item_id
+        FROM warehouse.item_location_stock_levels sl
+        JOIN warehouse.items i ON i.id = sl.item_id AND i.is_archived = false
+        LEFT JOIN warehouse.inventory inven ON inven.item_id = sl.item_id AND inven.location_id = sl.location_id AND inven.is_archived = false
+        WHERE sl.workspace_id = sqlc.arg(workspace_id)
+        GROUP BY sl.item_id, sl.location_id, sl.min_stock_level
+        HAVING COALESCE(SUM(inven.quantity), 0) < sl.min_stock_level
     ) low_stock)::int as low_stock_items,
     (SELECT COUNT(*) FROM warehouse.categories cat WHERE cat.workspace_id = sqlc.arg(workspace_id) AND cat.is_archived = false)::int as total_categories,
     (SELECT COUNT(*) FROM warehouse.borrowers bor WHERE bor.workspace_id = sqlc.arg(workspace_id) AND bor.is_archived = false)::int as total_borrowers;
@@ -52,7 +61,10 @@ SELECT
     COALESCE(SUM(inv.quantity), 0)::int as total_quantity,
     COALESCE(SUM(COALESCE(inv.purchase_price, 0) * inv.quantity), 0)::int as total_value
 FROM warehouse.locations l
-LEFT JOIN warehouse.inventory inv ON inv.location_id = l.id AND inv.is_archived = false
+LEFT JOIN (
+    warehouse.inventory inv
+    JOIN warehouse.items it ON it.id = inv.item_id AND it.is_archived = false
+) ON inv.location_id = l.id AND inv.is_archived = false
 WHERE l.workspace_id = $1 AND l.is_archived = false
 GROUP BY l.id, l.name
 ORDER BY total_value DESC
@@ -117,3 +129,204 @@ WHERE workspace_id = $1
   AND loaned_at >= $2
 GROUP BY DATE_TRUNC('month', loaned_at)
 ORDER BY month ASC;
+
+-- name: GetStaleInventory :many
+-- Inventory rows whose most recent activity is older than the cutoff. Activity
+-- is the latest of the row's own updated_at/last_used_at, its newest movement
+-- and its newest loan checkout/return. Oldest activity first.
+SELECT
+    inv.id,
+    inv.item_id,
+    it.name AS item_name,
+    it.sku,
+    inv.location_id,
+    l.name AS location_name,
+    inv.container_id,
+    c.name AS container_name,
+    inv.quantity,
+    activity.last_activity_at::timestamptz AS last_activity_at
+FROM warehouse.inventory inv
+JOIN warehouse.items it ON it.id = inv.item_id
+JOIN warehouse.locations l ON l.id = inv.location_id
+LEFT JOIN warehouse.containers c ON c.id = inv.container_id
+CROSS JOIN LATERAL (
+    SELECT GREATEST(
+        inv.updated_at,
+        inv.last_used_at,
+        (SELECT MAX(m.created_at) FROM warehouse.inventory_movements m WHERE m.inventory_id = inv.id),
+        (SELECT MAX(COALESCE(ln.returned_at, ln.loaned_at)) FROM warehouse.loans ln WHERE ln.inventory_id = inv.id)
+    ) AS last_activity_at
+) activity
+WHERE inv.workspace_id = sqlc.arg(workspace_id)
+  AND inv.is_archived = false
+  AND it.is_archived = false
+  AND activity.last_activity_at < sqlc.arg(cutoff)::timestamptz
+ORDER BY activity.last_activity_at ASC, inv.id
+LIMIT sqlc.arg(row_limit);
+
+-- name: GetTopValueItems :many
+-- Items ranked by total inventory value (purchase_price * quantity), with each
+-- inventory row's price converted to the workspace base currency. Rows without
+-- a purchase price, or priced in a currency with no configured exchange rate,
+-- are left out; CountTopValueExclusions counts them. unit_price is the
+-- quantity-weighted average across the item's inventory.
+WITH settings AS (
+    SELECT
+        COALESCE(cs.base_currency, 'EUR') AS base_currency,
+        COALESCE(cs.exchange_rates, '{}'::jsonb) AS exchange_rates
+    FROM (SELECT 1) AS one
+    LEFT JOIN warehouse.currency_settings cs ON cs.workspace_id = sqlc.arg(workspace_id)
+),
+converted AS (
+    SELECT
+        inv.item_id,
+        inv.quantity,
+        inv.purchase_price * CASE
+            WHEN COALESCE(inv.currency_code, s.base_currency) = s.base_currency THEN 1::numeric
+            ELSE (s.exchange_rates ->> inv.currency_code)::numeric
+        END AS base_price
+    FROM warehouse.inventory inv
+    CROSS JOIN settings s
+    WHERE inv.workspace_id = sqlc.arg(workspace_id)
+      AND inv.is_archived = false
+      AND inv.purchase_price IS NOT NULL
+)
+SELECT
+    it.id,
+    it.name,
+    it.sku,
+    SUM(c.quantity)::int AS quantity,
+    ROUND(SUM(c.base_price * c.quantity) / SUM(c.quantity))::bigint AS unit_price,
+    ROUND(SUM(c.base_price * c.quantity))::bigint AS total_value
+FROM converted c
+JOIN warehouse.items it ON it.id = c.item_id
+WHERE c.base_price IS NOT NULL
+  AND it.is_archived = false
+GROUP BY it.id, it.name, it.sku
+HAVING SUM(c.quantity) > 0
+ORDER BY total_value DESC, it.name
+LIMIT sqlc.arg(row_limit);
+
+-- name: CountTopValueExclusions :one
+-- Inventory rows GetTopValueItems leaves out: unpriced rows have no
+-- purchase_price; unconverted rows are priced in a currency other than the
+-- base currency with no exchange rate configured.
+WITH settings AS (
+    SELECT
+        COALESCE(cs.base_currency, 'EUR') AS base_currency,
+        COALESCE(cs.exchange_rates, '{}'::jsonb) AS exchange_rates
+    FROM (SELECT 1) AS one
+    LEFT JOIN warehouse.currency_settings cs ON cs.workspace_id = sqlc.arg(workspace_id)
+)
+SELECT
+    COUNT(*) FILTER (WHERE inv.purchase_price IS NULL)::int AS unpriced,
+    COUNT(*) FILTER (
+        WHERE inv.purchase_price IS NOT NULL
+          AND COALESCE(inv.currency_code, s.base_currency) <> s.base_currency
+          AND (s.exchange_rates ->> inv.currency_code) IS NULL
+    )::int AS unconverted
+FROM warehouse.inventory inv
+JOIN warehouse.items it ON it.id = inv.item_id
+CROSS JOIN settings s
+WHERE inv.workspace_id = sqlc.arg(workspace_id)
+  AND inv.is_archived = false
+  AND it.is_archived = false;
+
+-- name: GetInventoryAging :many
+-- Inventory grouped by how many calendar years before current_year it was
+-- acquired (date_acquired): this_year, 1_2_years, 3_plus_years, or unknown
+-- without a date. total_value is purchase_price * quantity converted to the
+-- workspace base currency as in GetTopValueItems; unpriced and unconverted
+-- count the rows it leaves out.
+WITH settings AS (
+    SELECT
+        COALESCE(cs.base_currency, 'EUR') AS base_currency,
+        COALESCE(cs.exchange_rates, '{}'::jsonb) AS exchange_rates
+    FROM (SELECT 1) AS one
+    LEFT JOIN warehouse.currency_settings cs ON cs.workspace_id = sqlc.arg(workspace_id)
+),
+aged AS (
+    SELECT
+        CASE
+            WHEN inv.date_acquired IS NULL THEN 'unknown'
+            WHEN EXTRACT(YEAR FROM inv.date_acquired) >= sqlc.arg(current_year)::int THEN 'this_year'
+            WHEN EXTRACT(YEAR FROM inv.date_acquired) >= sqlc.arg(current_year)::int - 2 THEN '1_2_years'
+            ELSE '3_plus_years'
+        END AS bucket,
+        inv.quantity,
+        inv.purchase_price,
+        inv.purchase_price * CASE
+            WHEN COALESCE(inv.currency_code, s.base_currency) = s.base_currency THEN 1::numeric
+            ELSE (s.exchange_rates ->> inv.currency_code)::numeric
+        END AS base_price
+    FROM warehouse.inventory inv
+    JOIN warehouse.items it ON it.id = inv.item_id
+    CROSS JOIN settings s
+    WHERE inv.workspace_id = sqlc.arg(workspace_id)
+      AND inv.is_archived = false
+      AND it.is_archived = false
+)
+SELECT
+    bucket::text AS bucket,
+    COUNT(*)::int AS inventory_count,
+    COALESCE(SUM(quantity), 0)::bigint AS quantity,
+    COALESCE(ROUND(SUM(base_price * quantity)), 0)::bigint AS total_value,
+    COUNT(*) FILTER (WHERE purchase_price IS NULL)::int AS unpriced,
+    COUNT(*) FILTER (WHERE purchase_price IS NOT NULL AND base_price IS NULL)::int AS unconverted
+FROM aged
+GROUP BY bucket;
+
+-- name: GetWorkspaceStats :one
+-- Headline counts for the dashboard summary. expiring_soon counts inventory
+-- whose expiration_date falls between today and today + expiring_days.
+SELECT
+    (SELECT COUNT(*) FROM warehouse.items it WHERE it.workspace_id = sqlc.arg(workspace_id) AND it.is_archived = false)::int AS total_items,
+    (SELECT COALESCE(SUM(inv.quantity), 0) FROM warehouse.inventory inv WHERE inv.workspace_id = sqlc.arg(workspace_id) AND inv.is_archived = false)::bigint AS total_quantity,
+    (SELECT COUNT(*) FROM warehouse.loans ln WHERE ln.workspace_id = sqlc.arg(workspace_id) AND ln.returned_at IS NULL)::int AS active_loans,
+    (SELECT COUNT(*) FROM warehouse.loans ln2 WHERE ln2.workspace_id = sqlc.arg(workspace_id) AND ln2.returned_at IS NULL AND ln2.due_date < CURRENT_DATE)::int AS overdue_loans,
+    (SELECT COUNT(DISTINCT low_stock.id) FROM (
+        SELECT i.id
+        FROM warehouse.items i
+        LEFT JOIN warehouse.inventory inven ON i.id = inven.item_id AND inven.is_archived = false
+        WHERE i.workspace_id = sqlc.arg(workspace_id) AND i.is_archived = false AND i.min_stock_level > 0
+          AND NOT EXISTS (SELECT 1 FROM warehouse.item_location_stock_levels sl WHERE sl.item_id = i.id)
+        GROUP BY i.id, i.min_stock_level
+        HAVING COALESCE(SUM(inven.quantity), 0) < i.min_stock_level
+        UNION ALL
+        SELECT sl.item_id
+        FROM warehouse.item_location_stock_levels sl
+        JOIN warehouse.items i ON i.id = sl.item_id AND i.is_archived = false
+        LEFT JOIN warehouse.inventory inven ON inven.item_id = sl.item_id AND inven.location_id = sl.location_id AND inven.is_archived = false
+        WHERE sl.workspace_id = sqlc.arg(workspace_id)
+        GROUP BY sl.item_id, sl.location_id, sl.min_stock_level
+        HAVING COALESCE(SUM(inven.quantity), 0) < sl.min_stock_level
+    ) low_stock)::int AS low_stock_items,
+    (SELECT COUNT(*) FROM warehouse.inventory ex
+     WHERE ex.workspace_id = sqlc.arg(workspace_id)
+       AND ex.is_archived = false
+       AND ex.expiration_date >= CURRENT_DATE
+       AND ex.expiration_date <= CURRENT_DATE + sqlc.arg(expiring_days)::int)::int AS expiring_soon;
+
+-- name: GetWorkspaceInventoryValue :one
+-- Total purchase value of the workspace's inventory in its base currency,
+-- converted as in GetTopValueItems. Unpriced rows and rows with no exchange
+-- rate are left out.
+WITH settings AS (
+    SELECT
+        COALESCE(cs.base_currency, 'EUR') AS base_currency,
+        COALESCE(cs.exchange_rates, '{}'::jsonb) AS exchange_rates
+    FROM (SELECT 1) AS one
+    LEFT JOIN warehouse.currency_settings cs ON cs.workspace_id = sqlc.arg(workspace_id)
+)
+SELECT
+    COALESCE(ROUND(SUM(inv.quantity * inv.purchase_price * CASE
+        WHEN COALESCE(inv.currency_code, s.base_currency) = s.base_currency THEN 1::numeric
+        ELSE (s.exchange_rates ->> inv.currency_code)::numeric
+    END)), 0)::bigint AS total_value
+FROM warehouse.inventory inv
+JOIN warehouse.items it ON it.id = inv.item_id
+CROSS JOIN settings s
+WHERE inv.workspace_id = sqlc.arg(workspace_id)
+  AND inv.is_archived = false
+  AND it.is_archived = false
+  AND inv.purchase_price IS NOT NULL;
@@ -3,13 +3,13 @@ SELECT * FROM warehouse.borrowers
 WHERE id = $1 AND workspace_id = $2;
 
 -- name: CreateBorrower :one
-INSERT INTO warehouse.borrowers (id, workspace_id, name, email, phone, notes)
-VALUES ($1, $2, $3, $4, $5, $6)
+INSERT INTO warehouse.borrowers (id, workspace_id, name, email, phone, notes, reminder_channel)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
 RETURNING *;
 
 -- name: UpdateBorrower :one
 UPDATE warehouse.borrowers
-SET name = $3, email = $4, phone = $5, notes = $6, updated_at = now()
+SET name = $3, email = $4, phone = $5, notes = $6, reminder_channel = $7, updated_at = now()
 WHERE id = $1 AND workspace_id = $2
 RETURNING *;
 
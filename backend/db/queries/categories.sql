@@ -46,3 +46,19 @@ SELECT EXISTS(
 
 -- name: DeleteCategory :exec
 DELETE FROM warehouse.categories WHERE id = $1 AND workspace_id = $2;
+
+-- name: MoveCategoryItems :execrows
+-- Re-point every item in one category to another (category merge).
+UPDATE warehouse.items
+SET category_id = @to_category_id::uuid,
+    updated_at = now()
+WHERE workspace_id = @workspace_id
+  AND category_id = @from_category_id;
+
+-- name: MoveCategoryChildren :execrows
+-- Re-parent every direct child of one category onto another (category merge).
+UPDATE warehouse.categories
+SET parent_category_id = @to_category_id::uuid,
+    updated_at = now()
+WHERE workspace_id = @workspace_id
+  AND parent_category_id = @from_category_id;
@@ -0,0 +1,25 @@
+-- name: GetCategoryInventoryDefaults :one
+SELECT * FROM warehouse.category_inventory_defaults
+WHERE category_id = $1 AND workspace_id = $2;
+
+-- name: ListCategoryInventoryDefaults :many
+SELECT * FROM warehouse.category_inventory_defaults
+WHERE workspace_id = $1
+ORDER BY category_id;
+
+-- name: UpsertCategoryInventoryDefaults :one
+-- Selecting from categories scopes the category to the workspace: a category
+-- of another workspace inserts nothing and returns no row.
+INSERT INTO warehouse.category_inventory_defaults (category_id, workspace_id, default_condition, default_status)
+SELECT c.id, c.workspace_id, sqlc.narg('default_condition')::warehouse.item_condition_enum, sqlc.narg('default_status')::warehouse.item_status_enum
+FROM warehouse.categories c
+WHERE c.id = sqlc.arg('category_id') AND c.workspace_id = sqlc.arg('workspace_id')
+ON CONFLICT (category_id) DO UPDATE
+SET default_condition = EXCLUDED.default_condition,
+    default_status = EXCLUDED.default_status,
+    updated_at = now()
+RETURNING *;
+
+-- name: DeleteCategoryInventoryDefaults :exec
+DELETE FROM warehouse.category_inventory_defaults
+WHERE category_id = $1 AND workspace_id = $2;
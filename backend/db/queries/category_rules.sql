@@ -0,0 +1,24 @@
+-- name: ListCategoryRules :many
+SELECT * FROM warehouse.category_rules
+WHERE workspace_id = $1
+ORDER BY "position", created_at;
+
+-- name: CreateCategoryRule :one
+INSERT INTO warehouse.category_rules (id, workspace_id, category_id, pattern, match_type, "position")
+VALUES ($1, $2, $3, $4, $5, $6)
+RETURNING *;
+
+-- name: UpdateCategoryRule :one
+UPDATE warehouse.category_rules
+SET category_id = $3, pattern = $4, match_type = $5, updated_at = now()
+WHERE id = $1 AND workspace_id = $2
+RETURNING *;
+
+-- name: UpdateCategoryRulePosition :exec
+UPDATE warehouse.category_rules
+SET "position" = $3, updated_at = now()
+WHERE id = $1 AND workspace_id = $2;
+
+-- name: DeleteCategoryRule :exec
+DELETE FROM warehouse.category_rules
+WHERE id = $1 AND workspace_id = $2;
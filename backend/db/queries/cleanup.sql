@@ -0,0 +1,25 @@
+-- Maintenance queries for the weekly cleanup jobs.
+
+-- name: PurgeOrphanedItemLabels :execrows
+-- Removes item/label links whose item or label no longer exists.
+DELETE FROM warehouse.item_labels il
+WHERE NOT EXISTS (SELECT 1 FROM warehouse.items i WHERE i.id = il.item_id)
+   OR NOT EXISTS (SELECT 1 FROM warehouse.labels l WHERE l.id = il.label_id);
+
+-- name: PurgeOrphanedRepairAttachments :execrows
+-- Removes repair log/file links whose repair log or file no longer exists.
+DELETE FROM warehouse.repair_attachments ra
+WHERE NOT EXISTS (SELECT 1 FROM warehouse.repair_logs r WHERE r.id = ra.repair_log_id)
+   OR NOT EXISTS (SELECT 1 FROM warehouse.files f WHERE f.id = ra.file_id);
+
+-- name: PurgeOrphanedFavorites :execrows
+-- Removes favorites pointing at an item, location or container that no longer exists.
+DELETE FROM warehouse.favorites f
+WHERE (f.item_id IS NOT NULL AND NOT EXISTS (SELECT 1 FROM warehouse.items i WHERE i.id = f.item_id))
+   OR (f.location_id IS NOT NULL AND NOT EXISTS (SELECT 1 FROM warehouse.locations l WHERE l.id = f.location_id))
+   OR (f.container_id IS NOT NULL AND NOT EXISTS (SELECT 1 FROM warehouse.containers c WHERE c.id = f.container_id));
+
+-- name: CleanupOldAuthEvents :execrows
+-- Removes security log entries older than the retention cutoff.
+DELETE FROM auth.auth_events
+WHERE created_at < $1;
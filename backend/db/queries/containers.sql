@@ -27,6 +27,75 @@ UPDATE warehouse.containers
 SET is_archived = false, updated_at = now()
 WHERE id = $1 AND workspace_id = $2;
 
+-- name: GetContainerContentsSummary :one
+-- Totals over ListContainerContents. total_value is in the workspace base
+-- currency and leaves out unpriced rows and rows priced in a currency with no
+-- exchange rate, which are counted as unpriced and unconverted instead.
+WITH settings AS (
+    SELECT
+        COALESCE(cs.base_currency, 'EUR') AS base_currency,
+        COALESCE(cs.exchange_rates, '{}'::jsonb) AS exchange_rates
+    FROM (SELECT 1) AS one
+    LEFT JOIN warehouse.currency_settings cs ON cs.workspace_id = sqlc.arg(workspace_id)
+),
+contents AS (
+    SELECT
+        inv.quantity,
+        inv.purchase_price,
+        inv.quantity * inv.purchase_price * CASE
+            WHEN COALESCE(inv.currency_code, s.base_currency) = s.base_currency THEN 1::numeric
+            ELSE (s.exchange_rates ->> inv.currency_code)::numeric
+        END AS value
+    FROM warehouse.inventory inv
+    CROSS JOIN settings s
+    WHERE inv.workspace_id = sqlc.arg(workspace_id)
+      AND inv.container_id = sqlc.arg(container_id)
+      AND inv.is_archived = false
+)
+SELECT
+    (SELECT base_currency FROM settings)::text AS base_currency,
+    COUNT(*)::int AS entries,
+    COALESCE(SUM(c.quantity), 0)::bigint AS total_quantity,
+    COALESCE(ROUND(SUM(c.value)), 0)::bigint AS total_value,
+    COUNT(*) FILTER (WHERE c.purchase_price IS NULL)::int AS unpriced,
+    COUNT(*) FILTER (WHERE c.purchase_price IS NOT NULL AND c.value IS NULL)::int AS unconverted
+FROM contents c;
+
+-- name: ListContainerContents :many
+-- Inventory stored in a container with its item, ordered by item name. value
+-- is quantity * purchase_price converted to the workspace base currency as in
+-- GetTopValueItems; it is NULL for unpriced rows and rows priced in a
+-- currency with no exchange rate.
+WITH settings AS (
+    SELECT
+        COALESCE(cs.base_currency, 'EUR') AS base_currency,
+        COALESCE(cs.exchange_rates, '{}'::jsonb) AS exchange_rates
+    FROM (SELECT 1) AS one
+    LEFT JOIN warehouse.currency_settings cs ON cs.workspace_id = sqlc.arg(workspace_id)
+)
+SELECT
+    inv.id,
+    inv.item_id,
+    it.name AS item_name,
+    it.sku AS item_sku,
+    inv.quantity,
+    inv.condition,
+    inv.status,
+    inv.purchase_price,
+    inv.currency_code,
+    ROUND(inv.quantity * inv.purchase_price * CASE
+        WHEN COALESCE(inv.currency_code, s.base_currency) = s.base_currency THEN 1::numeric
+        ELSE (s.exchange_rates ->> inv.currency_code)::numeric
+    END)::bigint AS value
+FROM warehouse.inventory inv
+JOIN warehouse.items it ON it.id = inv.item_id
+CROSS JOIN settings s
+WHERE inv.workspace_id = sqlc.arg(workspace_id)
+  AND inv.container_id = sqlc.arg(container_id)
+  AND inv.is_archived = false
+ORDER BY it.name, inv.created_at, inv.id
+LIMIT sqlc.arg(row_limit) OFFSET sqlc.arg(row_offset);
+
 -- name: ListContainersByLocation :many
 SELECT * FROM warehouse.containers
 WHERE workspace_id = $1 AND location_id = $2 AND is_archived = false
@@ -0,0 +1,11 @@
+-- name: GetCurrencySettings :one
+SELECT * FROM warehouse.currency_settings WHERE workspace_id = $1;
+
+-- name: UpsertCurrencySettings :one
+INSERT INTO warehouse.currency_settings (workspace_id, base_currency, exchange_rates)
+VALUES ($1, $2, $3)
+ON CONFLICT (workspace_id) DO UPDATE
+SET base_currency = EXCLUDED.base_currency,
+    exchange_rates = EXCLUDED.exchange_rates,
+    updated_at = now()
+RETURNING *;
@@ -0,0 +1,56 @@
+-- name: CreateCustomField :one
+INSERT INTO warehouse.custom_fields (id, workspace_id, name, field_type)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetCustomField :one
+SELECT * FROM warehouse.custom_fields
+WHERE id = $1 AND workspace_id = $2;
+
+-- name: ListCustomFields :many
+SELECT * FROM warehouse.custom_fields
+WHERE workspace_id = $1
+ORDER BY name;
+
+-- name: UpdateCustomField :one
+UPDATE warehouse.custom_fields
+SET name = $3, updated_at = now()
+WHERE id = $1 AND workspace_id = $2
+RETURNING *;
+
+-- name: DeleteCustomField :exec
+DELETE FROM warehouse.custom_fields
+WHERE id = $1 AND workspace_id = $2;
+
+-- name: CustomFieldNameExists :one
+SELECT EXISTS(
+    SELECT 1 FROM warehouse.custom_fields
+    WHERE workspace_id = $1 AND name = $2
+);
+
+-- name: UpsertItemCustomValue :exec
+INSERT INTO warehouse.item_custom_values (item_id, field_id, workspace_id, value)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (item_id, field_id) DO UPDATE
+SET value = EXCLUDED.value,
+    updated_at = now();
+
+-- name: DeleteItemCustomValue :exec
+DELETE FROM warehouse.item_custom_values
+WHERE item_id = $1 AND field_id = $2 AND workspace_id = $3;
+
+-- name: ListItemCustomValuesByItemIDs :many
+-- Custom values of a batch of items joined with their field definitions,
+-- ordered by field name. Scoped on workspace_id for isolation.
+SELECT v.item_id, v.field_id, f.name AS field_name, f.field_type, v.value
+FROM warehouse.item_custom_values v
+JOIN warehouse.custom_fields f ON f.id = v.field_id
+WHERE v.workspace_id = $1
+  AND v.item_id = ANY(@item_ids::uuid[])
+ORDER BY v.item_id, f.name;
+
+-- name: ItemExistsInWorkspace :one
+SELECT EXISTS(
+    SELECT 1 FROM warehouse.items
+    WHERE id = $1 AND workspace_id = $2
+);
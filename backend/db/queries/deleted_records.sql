@@ -11,3 +11,22 @@ ORDER BY deleted_at ASC;
 -- name: CleanupOldDeletedRecords :exec
 DELETE FROM warehouse.deleted_records
 WHERE deleted_at < $1;
+
+-- name: CleanupExpiredDeletedRecords :execrows
+-- Each workspace keeps its records for its own trash_retention_days, or
+-- default_retention_days when it has no trash settings.
+DELETE FROM warehouse.deleted_records d
+WHERE d.deleted_at < sqlc.arg(now)::timestamptz - make_interval(days => COALESCE(
+    (SELECT s.trash_retention_days FROM warehouse.trash_settings s WHERE s.workspace_id = d.workspace_id),
+    sqlc.arg(default_retention_days)::int
+));
+
+-- name: PurgeExpiredDeletedRecords :many
+-- CleanupExpiredDeletedRecords returning the removed rows, so they can be
+-- archived before the transaction commits.
+DELETE FROM warehouse.deleted_records d
+WHERE d.deleted_at < sqlc.arg(now)::timestamptz - make_interval(days => COALESCE(
+    (SELECT s.trash_retention_days FROM warehouse.trash_settings s WHERE s.workspace_id = d.workspace_id),
+    sqlc.arg(default_retention_days)::int
+))
+RETURNING id, workspace_id, entity_type, entity_id, deleted_at, deleted_by;
@@ -0,0 +1,26 @@
+-- name: GetCategoryDeletionImpact :one
+-- Count dependents of a category for the deletion impact preview. No row
+-- means the category doesn't exist in the workspace.
+SELECT
+    (SELECT COUNT(*) FROM warehouse.categories ch
+     WHERE ch.workspace_id = c.workspace_id AND ch.parent_category_id = c.id AND ch.is_archived = false) AS child_categories,
+    (SELECT COUNT(*) FROM warehouse.items i
+     WHERE i.workspace_id = c.workspace_id AND i.category_id = c.id) AS items
+FROM warehouse.categories c
+WHERE c.id = @id AND c.workspace_id = @workspace_id;
+
+-- name: GetLocationDeletionImpact :one
+-- Count dependents of a location for the deletion impact preview. No row
+-- means the location doesn't exist in the workspace.
+SELECT
+    (SELECT COUNT(*) FROM warehouse.locations ch
+     WHERE ch.workspace_id = l.workspace_id AND ch.parent_location = l.id) AS child_locations,
+    (SELECT COUNT(*) FROM warehouse.inventory inv
+     WHERE inv.workspace_id = l.workspace_id AND inv.location_id = l.id) AS inventory,
+    (SELECT COUNT(*) FROM warehouse.containers ct
+     WHERE ct.workspace_id = l.workspace_id AND ct.location_id = l.id) AS containers,
+    (SELECT COUNT(*) FROM warehouse.loans ln
+     JOIN warehouse.inventory inv ON inv.id = ln.inventory_id
+     WHERE inv.workspace_id = l.workspace_id AND inv.location_id = l.id AND ln.returned_at IS NULL) AS active_loans
+FROM warehouse.locations l
+WHERE l.id = @id AND l.workspace_id = @workspace_id;
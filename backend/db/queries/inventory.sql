@@ -31,6 +31,13 @@ SET quantity = $2, updated_at = now()
 WHERE id = $1 AND workspace_id = $3
 RETURNING *;
 
+-- name: AddInventoryQuantity :one
+-- Adds in one statement, so concurrent additions to an entry are not lost.
+UPDATE warehouse.inventory
+SET quantity = quantity + sqlc.arg('amount'), updated_at = now()
+WHERE id = sqlc.arg('id') AND workspace_id = sqlc.arg('workspace_id')
+RETURNING *;
+
 -- name: MoveInventory :one
 UPDATE warehouse.inventory
 SET location_id = $2, container_id = $3, updated_at = now()
@@ -48,14 +55,23 @@ SET is_archived = false, updated_at = now()
 WHERE id = $1 AND workspace_id = $2;
 
 -- name: ListInventory :many
+-- A NULL condition/status filters as the domain default (NEW / AVAILABLE).
 SELECT * FROM warehouse.inventory
 WHERE workspace_id = $1 AND is_archived = false
+  AND (sqlc.narg('item_id')::uuid IS NULL OR item_id = sqlc.narg('item_id'))
+  AND (sqlc.narg('location_id')::uuid IS NULL OR location_id = sqlc.narg('location_id'))
+  AND (sqlc.narg('conditions')::text[] IS NULL OR COALESCE(condition, 'NEW')::text = ANY(sqlc.narg('conditions')::text[]))
+  AND (sqlc.narg('statuses')::text[] IS NULL OR COALESCE(status, 'AVAILABLE')::text = ANY(sqlc.narg('statuses')::text[]))
 ORDER BY created_at DESC
 LIMIT $2 OFFSET $3;
 
 -- name: CountInventory :one
 SELECT COUNT(*) FROM warehouse.inventory
-WHERE workspace_id = $1 AND is_archived = false;
+WHERE workspace_id = $1 AND is_archived = false
+  AND (sqlc.narg('item_id')::uuid IS NULL OR item_id = sqlc.narg('item_id'))
+  AND (sqlc.narg('location_id')::uuid IS NULL OR location_id = sqlc.narg('location_id'))
+  AND (sqlc.narg('conditions')::text[] IS NULL OR COALESCE(condition, 'NEW')::text = ANY(sqlc.narg('conditions')::text[]))
+  AND (sqlc.narg('statuses')::text[] IS NULL OR COALESCE(status, 'AVAILABLE')::text = ANY(sqlc.narg('statuses')::text[]));
 
 -- name: ListInventoryByItem :many
 SELECT * FROM warehouse.inventory
@@ -73,8 +89,10 @@ WHERE workspace_id = $1 AND container_id = $2 AND is_archived = false
 ORDER BY created_at DESC;
 
 -- name: GetAvailableInventory :many
+-- RESERVED entries count too when the workspace policy says so.
 SELECT * FROM warehouse.inventory
-WHERE workspace_id = $1 AND item_id = $2 AND status = 'AVAILABLE' AND is_archived = false;
+WHERE workspace_id = @workspace_id AND item_id = @item_id AND is_archived = false
+  AND (status = 'AVAILABLE' OR (@include_reserved::boolean AND status = 'RESERVED'));
 
 -- name: GetInventoryWithDetails :one
 SELECT i.*, it.name as item_name, it.sku, l.name as location_name, c.name as container_name
@@ -85,13 +103,17 @@ LEFT JOIN warehouse.containers c ON i.container_id = c.id
 WHERE i.id = $1 AND i.workspace_id = $2;
 
 -- name: ListInventoryWithDetails :many
-SELECT i.*, it.name as item_name, it.sku, l.name as location_name, c.name as container_name
+SELECT i.*, it.name as item_name, it.brand as item_brand, it.sku, l.name as location_name, c.name as container_name
 FROM warehouse.inventory i
 JOIN warehouse.items it ON i.item_id = it.id
 JOIN warehouse.locations l ON i.location_id = l.id
 LEFT JOIN warehouse.containers c ON i.container_id = c.id
 WHERE i.workspace_id = $1 AND i.is_archived = false
-ORDER BY it.name
+  AND (sqlc.narg('item_id')::uuid IS NULL OR i.item_id = sqlc.narg('item_id'))
+  AND (sqlc.narg('location_id')::uuid IS NULL OR i.location_id = sqlc.narg('location_id'))
+  AND (sqlc.narg('conditions')::text[] IS NULL OR COALESCE(i.condition, 'NEW')::text = ANY(sqlc.narg('conditions')::text[]))
+  AND (sqlc.narg('statuses')::text[] IS NULL OR COALESCE(i.status, 'AVAILABLE')::text = ANY(sqlc.narg('statuses')::text[]))
+ORDER BY i.created_at DESC
 LIMIT $2 OFFSET $3;
 
 -- name: GetTotalQuantityByItem :one
@@ -100,12 +122,29 @@ FROM warehouse.inventory
 WHERE workspace_id = $1 AND item_id = $2 AND is_archived = false;
 
 -- name: GetLowStockItems :many
-SELECT i.id, i.name, i.min_stock_level, COALESCE(SUM(inv.quantity), 0)::int as current_stock
+-- Low-stock report. An item with per-location minimums
+-- (item_location_stock_levels) is checked at each of those locations against
+-- the stock there; any other item is checked on its total stock against
+-- items.min_stock_level, and its row has no location.
+SELECT i.id AS item_id, i.name AS item_name, NULL::uuid AS location_id, NULL::text AS location_name,
+       i.min_stock_level, COALESCE(SUM(inv.quantity), 0)::int as current_stock
 FROM warehouse.items i
 LEFT JOIN warehouse.inventory inv ON i.id = inv.item_id AND inv.is_archived = false
 WHERE i.workspace_id = $1 AND i.is_archived = false AND i.min_stock_level > 0
+  AND NOT EXISTS (SELECT 1 FROM warehouse.item_location_stock_levels sl WHERE sl.item_id = i.id)
 GROUP BY i.id, i.name, i.min_stock_level
-HAVING COALESCE(SUM(inv.quantity), 0) < i.min_stock_level;
+HAVING COALESCE(SUM(inv.quantity), 0) < i.min_stock_level
+UNION ALL
+SELECT i.id, i.name, sl.location_id, l.name,
+       sl.min_stock_level, COALESCE(SUM(inv.quantity), 0)::int
+FROM warehouse.item_location_stock_levels sl
+JOIN warehouse.items i ON i.id = sl.item_id AND i.is_archived = false
+JOIN warehouse.locations l ON l.id = sl.location_id
+LEFT JOIN warehouse.inventory inv ON inv.item_id = sl.item_id AND inv.location_id = sl.location_id AND inv.is_archived = false
+WHERE sl.workspace_id = $1
+GROUP BY i.id, i.name, sl.location_id, l.name, sl.min_stock_level
+HAVING COALESCE(SUM(inv.quantity), 0) < sl.min_stock_level
+ORDER BY item_name, location_name NULLS FIRST;
 
 -- name: GetOutOfStockItems :many
 -- Returns items that are completely out of stock (total quantity = 0)
@@ -118,6 +157,17 @@ WHERE i.workspace_id = $1 AND i.is_archived = false
 GROUP BY i.id, i.name, i.sku, i.min_stock_level, c.id, c.name
 HAVING COALESCE(SUM(inv.quantity), 0) = 0;
 
+-- name: ListInventoryForRepair :many
+-- Inventory rows in FOR_REPAIR condition with their item name, longest waiting
+-- (least recently updated) first. Workspace-scoped; archived rows excluded.
+SELECT inv.id, inv.item_id, inv.quantity, inv.updated_at, it.name AS item_name
+FROM warehouse.inventory inv
+JOIN warehouse.items it ON inv.item_id = it.id AND it.workspace_id = inv.workspace_id
+WHERE inv.workspace_id = $1
+  AND inv.is_archived = false
+  AND inv.condition = 'FOR_REPAIR'
+ORDER BY inv.updated_at, inv.id;
+
 -- name: ListInventoryExpiringSoon :many
 -- Inventory rows whose expiration_date falls between today and the cutoff
 -- date (today + window). Used by the expiry reminder job and the
@@ -133,6 +183,26 @@ WHERE inv.workspace_id = $1
   AND inv.expiration_date <= $2
 ORDER BY inv.expiration_date, inv.id;
 
+-- name: ListInventoryExpired :many
+-- Inventory rows whose expiration_date is before the given date (today).
+-- Used by the expiry status job. Workspace-scoped; archived rows excluded.
+SELECT inv.id, inv.workspace_id, inv.item_id, inv.status,
+       inv.expiration_date, it.name AS item_name
+FROM warehouse.inventory inv
+JOIN warehouse.items it ON inv.item_id = it.id AND it.workspace_id = inv.workspace_id
+WHERE inv.workspace_id = $1
+  AND inv.is_archived = false
+  AND inv.expiration_date IS NOT NULL
+  AND inv.expiration_date < $2
+ORDER BY inv.expiration_date, inv.id;
+
+-- name: ExpireInventory :execrows
+-- Moves an expired row to the workspace's expired status, but only while it
+-- is still in the status the job saw, so a loan that starts in between wins.
+UPDATE warehouse.inventory
+SET status = @expired_status, updated_at = now()
+WHERE id = @id AND workspace_id = @workspace_id AND status = @status;
+
 -- name: ListWarrantiesExpiringSoon :many
 -- Inventory rows whose warranty_expires falls between today and the cutoff
 -- date (today + window). Items flagged lifetime_warranty never expire and are
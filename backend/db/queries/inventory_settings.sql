@@ -0,0 +1,18 @@
+-- name: GetInventorySettings :one
+SELECT * FROM warehouse.inventory_settings WHERE workspace_id = $1;
+
+-- name: UpsertInventorySettings :one
+INSERT INTO warehouse.inventory_settings (workspace_id, reserved_is_available, expired_status)
+VALUES ($1, $2, $3)
+ON CONFLICT (workspace_id) DO UPDATE
+SET reserved_is_available = EXCLUDED.reserved_is_available,
+    expired_status = EXCLUDED.expired_status,
+    updated_at = now()
+RETURNING *;
+
+-- name: ListExpiredStatusSettings :many
+-- Workspaces that opted in to the expiry status job, with their target status.
+SELECT workspace_id, expired_status
+FROM warehouse.inventory_settings
+WHERE expired_status IS NOT NULL
+ORDER BY workspace_id;
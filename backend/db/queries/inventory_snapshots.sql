@@ -0,0 +1,56 @@
+-- name: SummarizeInventoryForSnapshot :many
+-- The workspace's unarchived inventory of unarchived items, per item and
+-- condition, valued in the base currency as in GetTopValueItems. unvalued
+-- counts the rows left out of total_value; condition is empty for rows without
+-- one.
+WITH settings AS (
+    SELECT
+        COALESCE(cs.base_currency, 'EUR') AS base_currency,
+        COALESCE(cs.exchange_rates, '{}'::jsonb) AS exchange_rates
+    FROM (SELECT 1) AS one
+    LEFT JOIN warehouse.currency_settings cs ON cs.workspace_id = sqlc.arg(workspace_id)
+),
+converted AS (
+    SELECT
+        inv.item_id,
+        COALESCE(inv.condition::text, '') AS condition,
+        inv.quantity,
+        inv.purchase_price * CASE
+            WHEN COALESCE(inv.currency_code, s.base_currency) = s.base_currency THEN 1::numeric
+            ELSE (s.exchange_rates ->> inv.currency_code)::numeric
+        END AS base_price
+    FROM warehouse.inventory inv
+    CROSS JOIN settings s
+    WHERE inv.workspace_id = sqlc.arg(workspace_id)
+      AND inv.is_archived = false
+)
+SELECT
+    it.id AS item_id,
+    it.name AS item_name,
+    it.sku,
+    c.condition::text AS condition,
+    SUM(c.quantity)::bigint AS quantity,
+    COALESCE(ROUND(SUM(c.base_price * c.quantity)), 0)::bigint AS total_value,
+    COUNT(*) FILTER (WHERE c.base_price IS NULL)::int AS unvalued
+FROM converted c
+JOIN warehouse.items it ON it.id = c.item_id
+WHERE it.is_archived = false
+GROUP BY it.id, it.name, it.sku, c.condition
+ORDER BY it.name, it.id, c.condition;
+
+-- name: CreateInventorySnapshot :one
+INSERT INTO warehouse.inventory_snapshots (
+    id, workspace_id, label, currency_code, total_quantity, total_value, unvalued_count, items
+) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
+RETURNING *;
+
+-- name: GetInventorySnapshot :one
+SELECT * FROM warehouse.inventory_snapshots
+WHERE id = $1 AND workspace_id = $2;
+
+-- name: ListInventorySnapshots :many
+-- Snapshot headers, newest first, without the per-item summary.
+SELECT id, workspace_id, label, currency_code, total_quantity, total_value, unvalued_count, created_at
+FROM warehouse.inventory_snapshots
+WHERE workspace_id = $1
+ORDER BY created_at DESC, id DESC;
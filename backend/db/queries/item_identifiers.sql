@@ -0,0 +1,17 @@
+-- name: ListItemIdentifiers :many
+SELECT * FROM warehouse.item_identifiers
+WHERE workspace_id = $1 AND item_id = $2
+ORDER BY created_at, id;
+
+-- name: GetItemIdentifierByValue :one
+SELECT * FROM warehouse.item_identifiers
+WHERE workspace_id = $1 AND value = $2;
+
+-- name: CreateItemIdentifier :one
+INSERT INTO warehouse.item_identifiers (id, workspace_id, item_id, identifier_type, value)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: DeleteItemIdentifier :execrows
+DELETE FROM warehouse.item_identifiers
+WHERE workspace_id = $1 AND item_id = $2 AND id = $3;
@@ -0,0 +1,18 @@
+-- name: ListItemLocationStockLevels :many
+SELECT sl.item_id, sl.location_id, l.name AS location_name, sl.min_stock_level
+FROM warehouse.item_location_stock_levels sl
+JOIN warehouse.locations l ON l.id = sl.location_id
+WHERE sl.workspace_id = $1 AND sl.item_id = $2
+ORDER BY l.name;
+
+-- name: UpsertItemLocationStockLevel :one
+INSERT INTO warehouse.item_location_stock_levels (item_id, location_id, workspace_id, min_stock_level)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (item_id, location_id) DO UPDATE
+SET min_stock_level = EXCLUDED.min_stock_level,
+    updated_at = now()
+RETURNING *;
+
+-- name: DeleteItemLocationStockLevel :execrows
+DELETE FROM warehouse.item_location_stock_levels
+WHERE workspace_id = $1 AND item_id = $2 AND location_id = $3;
@@ -2,9 +2,9 @@
 INSERT INTO warehouse.item_photos (
     id, item_id, workspace_id, filename, storage_path, thumbnail_path,
     file_size, mime_type, width, height, display_order, is_primary,
-    caption, uploaded_by
+    caption, uploaded_by, content_hash, inventory_id
 )
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
 RETURNING *;
 
 -- name: GetItemPhoto :one
@@ -15,11 +15,31 @@ WHERE id = $1;
 SELECT * FROM warehouse.item_photos
 WHERE id = $1 AND workspace_id = $2;
 
+-- name: GetItemPhotoByContentHash :one
+-- Oldest photo of an item with the given file content, for upload dedup.
+SELECT * FROM warehouse.item_photos
+WHERE item_id = $1 AND workspace_id = $2 AND content_hash = $3
+ORDER BY created_at ASC
+LIMIT 1;
+
 -- name: ListItemPhotosByItem :many
 SELECT * FROM warehouse.item_photos
 WHERE item_id = $1 AND workspace_id = $2
 ORDER BY display_order ASC, created_at ASC;
 
+-- name: ListItemPhotosByInventory :many
+-- Photos of one physical unit, in the item's gallery order.
+SELECT * FROM warehouse.item_photos
+WHERE inventory_id = $1 AND workspace_id = $2
+ORDER BY display_order ASC, created_at ASC;
+
+-- name: ItemHasInventory :one
+-- Whether an inventory record is a unit of the item, for tagging photos.
+SELECT EXISTS(
+    SELECT 1 FROM warehouse.inventory
+    WHERE id = @inventory_id AND item_id = @item_id AND workspace_id = @workspace_id
+);
+
 -- name: GetPrimaryItemPhoto :one
 SELECT * FROM warehouse.item_photos
 WHERE item_id = $1 AND workspace_id = $2 AND is_primary = true
@@ -33,6 +53,17 @@ WHERE workspace_id = @workspace_id
   AND item_id = ANY(@item_ids::uuid[])
   AND is_primary = true;
 
+-- name: ListItemsWithoutPhotos :many
+-- Active items with no photo at all, for the workspace require-photo rule.
+SELECT i.id, i.sku, i.name, i.short_code FROM warehouse.items i
+WHERE i.workspace_id = $1
+  AND i.is_archived = false
+  AND NOT EXISTS (
+      SELECT 1 FROM warehouse.item_photos p
+      WHERE p.item_id = i.id AND p.workspace_id = i.workspace_id
+  )
+ORDER BY i.name ASC, i.id ASC;
+
 -- name: UpdateItemPhoto :one
 UPDATE warehouse.item_photos
 SET
@@ -49,6 +80,18 @@ UPDATE warehouse.item_photos
 SET display_order = $2, updated_at = now()
 WHERE id = $1;
 
+-- name: MoveItemPhotoToItem :exec
+-- Re-point one photo to another item at a gallery position. It arrives
+-- without the primary flag and without its unit tag, since the inventory
+-- record belongs to the old item.
+UPDATE warehouse.item_photos
+SET item_id = @item_id,
+    display_order = @display_order,
+    is_primary = false,
+    inventory_id = NULL,
+    updated_at = now()
+WHERE id = @id AND workspace_id = @workspace_id;
+
 -- name: UnsetPrimaryPhotosForItem :exec
 UPDATE warehouse.item_photos
 SET is_primary = false, updated_at = now()
@@ -71,8 +114,8 @@ WHERE item_id = $1 AND workspace_id = $2;
 SELECT COUNT(*) FROM warehouse.item_photos
 WHERE item_id = $1 AND workspace_id = $2;
 
--- name: GetNextDisplayOrder :one
-SELECT COALESCE(MAX(display_order) + 1, 0) as next_order
+-- name: GetMaxItemPhotoDisplayOrder :one
+SELECT COALESCE(MAX(display_order), 0)::int as max_order
 FROM warehouse.item_photos
 WHERE item_id = $1 AND workspace_id = $2;
 
@@ -88,11 +131,13 @@ SET thumbnail_status = $2,
 WHERE id = $1;
 
 -- name: UpdateThumbnailPaths :one
--- Set all thumbnail paths and mark as complete
+-- Set all thumbnail paths, record the config version they were generated
+-- with and mark as complete
 UPDATE warehouse.item_photos
 SET thumbnail_small_path = $2,
     thumbnail_medium_path = $3,
     thumbnail_large_path = $4,
+    thumbnail_config_version = $5,
     thumbnail_status = 'complete',
     thumbnail_error = NULL,
     updated_at = now()
@@ -156,3 +201,9 @@ ORDER BY display_order ASC;
 UPDATE warehouse.item_photos
 SET perceptual_hash = @perceptual_hash, updated_at = now()
 WHERE id = @id;
+
+-- name: UpdateBlurhash :exec
+-- Set blurhash placeholder after upload processing
+UPDATE warehouse.item_photos
+SET blurhash = @blurhash, updated_at = now()
+WHERE id = @id;
@@ -0,0 +1,19 @@
+-- name: CreateItemShare :one
+INSERT INTO warehouse.item_shares (id, workspace_id, item_id, token_hash, include_prices, expires_at, created_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: GetItemShareByTokenHash :one
+SELECT * FROM warehouse.item_shares
+WHERE token_hash = $1;
+
+-- name: ListItemShares :many
+SELECT * FROM warehouse.item_shares
+WHERE workspace_id = $1 AND item_id = $2
+ORDER BY created_at DESC;
+
+-- name: RevokeItemShare :execrows
+-- Only unrevoked shares are affected, so revoking twice reports no rows.
+UPDATE warehouse.item_shares
+SET revoked_at = now()
+WHERE id = $1 AND workspace_id = $2 AND item_id = $3 AND revoked_at IS NULL;
@@ -19,9 +19,9 @@ INSERT INTO warehouse.items (
     id, workspace_id, sku, name, description, category_id, brand, model,
     image_url, serial_number, manufacturer, barcode, is_insured,
     lifetime_warranty, warranty_details, purchased_from, min_stock_level,
-    short_code, obsidian_vault_path, obsidian_note_path, needs_review
+    short_code, obsidian_vault_path, obsidian_note_path, needs_review, loanable
 )
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22)
 RETURNING *;
 
 -- name: UpdateItem :one
@@ -30,8 +30,8 @@ SET name = $2, description = $3, category_id = $4, brand = $5, model = $6,
     image_url = $7, serial_number = $8, manufacturer = $9, barcode = $10,
     is_insured = $11, lifetime_warranty = $12, warranty_details = $13,
     purchased_from = $14, min_stock_level = $15, obsidian_vault_path = $16,
-    obsidian_note_path = $17, needs_review = $18, updated_at = now()
-WHERE id = $1 AND workspace_id = $19
+    obsidian_note_path = $17, needs_review = $18, loanable = $19, updated_at = now()
+WHERE id = $1 AND workspace_id = $20
 RETURNING *;
 
 -- name: ArchiveItem :exec
@@ -44,12 +44,27 @@ UPDATE warehouse.items
 SET is_archived = false, updated_at = now()
 WHERE id = $1 AND workspace_id = $2;
 
+-- name: ReassignItemsCategory :many
+-- Moves a batch of items to a category (NULL clears it) in one statement.
+-- Scoped on workspace_id: IDs from other workspaces are not touched.
+UPDATE warehouse.items
+SET category_id = sqlc.narg(category_id), updated_at = now()
+WHERE workspace_id = @workspace_id AND id = ANY(@item_ids::uuid[])
+RETURNING id, name;
+
 -- name: ListItems :many
 SELECT * FROM warehouse.items
 WHERE workspace_id = $1 AND is_archived = false
 ORDER BY name
 LIMIT $2 OFFSET $3;
 
+-- name: ListItemsByIDs :many
+-- Items with the given IDs, in the order the IDs are listed. IDs that do not
+-- exist in the workspace are skipped.
+SELECT * FROM warehouse.items
+WHERE workspace_id = @workspace_id AND id = ANY(@ids::uuid[])
+ORDER BY array_position(@ids::uuid[], id);
+
 -- name: ListItemsByCategory :many
 SELECT * FROM warehouse.items
 WHERE workspace_id = $1 AND category_id = $2 AND is_archived = false
@@ -116,6 +131,15 @@ WHERE workspace_id = $1
        OR is_insured = sqlc.narg('is_insured')::bool)
   AND (sqlc.narg('needs_review')::bool IS NULL
        OR needs_review = sqlc.narg('needs_review')::bool)
+  AND (sqlc.narg('brand')::text IS NULL
+       OR lower(brand) = lower(sqlc.narg('brand')::text))
+  AND (sqlc.narg('has_inventory')::bool IS NULL
+       OR EXISTS (
+            SELECT 1 FROM warehouse.inventory inv
+            WHERE inv.item_id = items.id
+              AND inv.is_archived = false
+              AND inv.quantity > 0
+          ) = sqlc.narg('has_inventory')::bool)
 ORDER BY
   CASE WHEN sqlc.arg('sort_field')::text = 'name'        AND sqlc.arg('sort_dir')::text = 'asc'  THEN name        END ASC NULLS LAST,
   CASE WHEN sqlc.arg('sort_field')::text = 'name'        AND sqlc.arg('sort_dir')::text = 'desc' THEN name        END DESC NULLS LAST,
@@ -141,7 +165,78 @@ WHERE workspace_id = $1
   AND (sqlc.narg('is_insured')::bool IS NULL
        OR is_insured = sqlc.narg('is_insured')::bool)
   AND (sqlc.narg('needs_review')::bool IS NULL
-       OR needs_review = sqlc.narg('needs_review')::bool);
+       OR needs_review = sqlc.narg('needs_review')::bool)
+  AND (sqlc.narg('brand')::text IS NULL
+       OR lower(brand) = lower(sqlc.narg('brand')::text))
+  AND (sqlc.narg('has_inventory')::bool IS NULL
+       OR EXISTS (
+            SELECT 1 FROM warehouse.inventory inv
+            WHERE inv.item_id = items.id
+              AND inv.is_archived = false
+              AND inv.quantity > 0
+          ) = sqlc.narg('has_inventory')::bool);
 
 -- name: DeleteItem :exec
 DELETE FROM warehouse.items WHERE id = $1 AND workspace_id = $2;
+
+-- name: FindSimilarItems :many
+-- Pairs of active items whose "name brand" text is trigram-similar, most
+-- similar first. Each pair is listed once (lower id first).
+SELECT a.id AS item_id, a.name AS item_name, a.sku AS item_sku, a.brand AS item_brand,
+       b.id AS match_id, b.name AS match_name, b.sku AS match_sku, b.brand AS match_brand,
+       similarity(concat_ws(' ', a.name, a.brand), concat_ws(' ', b.name, b.brand))::float8 AS similarity
+FROM warehouse.items a
+JOIN warehouse.items b ON b.workspace_id = a.workspace_id AND a.id < b.id
+WHERE a.workspace_id = @workspace_id
+  AND a.is_archived = false
+  AND b.is_archived = false
+  AND similarity(concat_ws(' ', a.name, a.brand), concat_ws(' ', b.name, b.brand)) >= @threshold::float8
+ORDER BY similarity DESC, a.name, b.name
+LIMIT @max_results;
+
+-- name: CountItemLoans :one
+-- Loans (open and returned) against any of an item's inventory.
+SELECT COUNT(*) FROM warehouse.loans l
+JOIN warehouse.inventory inv ON inv.id = l.inventory_id
+WHERE inv.workspace_id = $1 AND inv.item_id = $2;
+
+-- name: MoveItemInventory :execrows
+-- Re-point every inventory row of one item to another (item merge). Loans
+-- follow their inventory.
+UPDATE warehouse.inventory
+SET item_id = @to_item_id, updated_at = now()
+WHERE workspace_id = @workspace_id
+  AND item_id = @from_item_id;
+
+-- name: MoveItemLabels :execrows
+-- Copy one item's labels onto another (item merge), skipping labels the
+-- target already has. The source rows go when the source item is deleted.
+INSERT INTO warehouse.item_labels (item_id, label_id, workspace_id)
+SELECT @to_item_id, label_id, workspace_id
+FROM warehouse.item_labels
+WHERE workspace_id = @workspace_id
+  AND item_id = @from_item_id
+ON CONFLICT DO NOTHING;
+
+-- name: MoveItemPhotos :execrows
+-- Re-point every photo of one item to another (item merge), after the
+-- target's own photos. A moved primary photo stays primary only if the
+-- target has none.
+UPDATE warehouse.item_photos p
+SET item_id = @to_item_id,
+    display_order = p.display_order + (
+        SELECT COALESCE(MAX(t.display_order) + 1, 0) FROM warehouse.item_photos t
+        WHERE t.workspace_id = @workspace_id AND t.item_id = @to_item_id
+    ),
+    is_primary = p.is_primary AND NOT EXISTS (
+        SELECT 1 FROM warehouse.item_photos t
+        WHERE t.workspace_id = @workspace_id AND t.item_id = @to_item_id AND t.is_primary
+    ),
+    updated_at = now()
+WHERE p.workspace_id = @workspace_id
+  AND p.item_id = @from_item_id;
+
+-- name: UpdateItemSKU :exec
+UPDATE warehouse.items
+SET sku = $3, updated_at = now()
+WHERE id = $1 AND workspace_id = $2;
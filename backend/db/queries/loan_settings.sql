@@ -0,0 +1,11 @@
+-- name: GetLoanSettings :one
+SELECT * FROM warehouse.loan_settings WHERE workspace_id = $1;
+
+-- name: UpsertLoanSettings :one
+INSERT INTO warehouse.loan_settings (workspace_id, overdue_grace_days, default_loan_days)
+VALUES ($1, $2, $3)
+ON CONFLICT (workspace_id) DO UPDATE
+SET overdue_grace_days = EXCLUDED.overdue_grace_days,
+    default_loan_days = EXCLUDED.default_loan_days,
+    updated_at = now()
+RETURNING *;
@@ -48,9 +48,25 @@ WHERE workspace_id = $1 AND returned_at IS NULL
 ORDER BY due_date ASC NULLS LAST;
 
 -- name: ListOverdueLoans :many
-SELECT * FROM warehouse.loans
-WHERE workspace_id = $1 AND returned_at IS NULL AND due_date < now()
-ORDER BY due_date ASC;
+SELECT l.* FROM warehouse.loans l
+LEFT JOIN warehouse.loan_settings s ON s.workspace_id = l.workspace_id
+WHERE l.workspace_id = $1 AND l.returned_at IS NULL
+  AND l.due_date + COALESCE(s.overdue_grace_days, 0) < now()
+ORDER BY l.due_date ASC;
+
+-- name: ListOverdueLoanSummaries :many
+-- The loans ListOverdueLoans returns, with the loaned item and the borrower's
+-- name.
+SELECT l.id, l.inventory_id, inv.item_id, it.name AS item_name,
+       b.name AS borrower_name, l.quantity, l.due_date
+FROM warehouse.loans l
+JOIN warehouse.inventory inv ON inv.id = l.inventory_id
+JOIN warehouse.items it ON it.id = inv.item_id
+JOIN warehouse.borrowers b ON b.id = l.borrower_id
+LEFT JOIN warehouse.loan_settings s ON s.workspace_id = l.workspace_id
+WHERE l.workspace_id = $1 AND l.returned_at IS NULL
+  AND l.due_date + COALESCE(s.overdue_grace_days, 0) < now()
+ORDER BY l.due_date ASC, l.id;
 
 -- name: GetActiveLoanForInventory :one
 SELECT * FROM warehouse.loans
@@ -67,6 +83,20 @@ JOIN warehouse.items it ON i.item_id = it.id
 JOIN warehouse.borrowers b ON l.borrower_id = b.id
 WHERE l.id = $1 AND l.workspace_id = $2;
 
+-- name: GetLoanReceiptDetails :one
+-- Everything a printed loan receipt shows besides the loan row itself.
+-- Scoped by workspace_id.
+SELECT w.name as workspace_name,
+       it.name as item_name, it.sku, it.brand, it.model, it.serial_number,
+       i.condition,
+       b.name as borrower_name, b.email as borrower_email, b.phone as borrower_phone
+FROM warehouse.loans l
+JOIN auth.workspaces w ON l.workspace_id = w.id
+JOIN warehouse.inventory i ON l.inventory_id = i.id
+JOIN warehouse.items it ON i.item_id = it.id
+JOIN warehouse.borrowers b ON l.borrower_id = b.id
+WHERE l.id = $1 AND l.workspace_id = $2;
+
 -- name: ListActiveLoansWithDetails :many
 SELECT l.*,
        i.quantity as inventory_quantity,
@@ -118,16 +148,21 @@ WHERE workspace_id = @workspace_id
   AND id = ANY(@borrower_ids::uuid[]);
 
 -- name: ListLoansNeedingReminder :many
--- Lists loans that are due within the specified date and have borrowers with email addresses.
+-- Lists loans that are due within the specified date and whose borrowers can be reached on their
+-- reminder channel (an email address for email, a phone number for sms).
 -- Used by the background job to send reminder notifications.
 SELECT l.id, l.workspace_id, l.due_date, l.quantity, l.notes,
        b.id as borrower_id, b.name as borrower_name, b.email as borrower_email,
-       it.name as item_name, it.sku
+       b.phone as borrower_phone, b.reminder_channel as borrower_reminder_channel,
+       it.name as item_name, it.sku,
+       COALESCE(s.overdue_grace_days, 0)::int as overdue_grace_days
 FROM warehouse.loans l
 JOIN warehouse.borrowers b ON l.borrower_id = b.id
 JOIN warehouse.inventory inv ON l.inventory_id = inv.id
 JOIN warehouse.items it ON inv.item_id = it.id
+LEFT JOIN warehouse.loan_settings s ON s.workspace_id = l.workspace_id
 WHERE l.returned_at IS NULL 
   AND l.due_date <= $1 
-  AND b.email IS NOT NULL
+  AND ((b.reminder_channel = 'email' AND b.email IS NOT NULL)
+       OR (b.reminder_channel = 'sms' AND b.phone IS NOT NULL))
 ORDER BY l.due_date ASC;
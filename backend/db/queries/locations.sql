@@ -53,3 +53,38 @@ LIMIT $3;
 
 -- name: DeleteLocation :exec
 DELETE FROM warehouse.locations WHERE id = $1 AND workspace_id = $2;
+
+-- name: RecordLocationEvacuationMovements :exec
+-- Write one movement per inventory row about to leave a location (run before
+-- EvacuateLocationInventory, in the same transaction).
+INSERT INTO warehouse.inventory_movements (
+    workspace_id, inventory_id, from_location_id, from_container_id,
+    to_location_id, to_container_id, quantity, reason
+)
+SELECT
+    workspace_id, id, location_id, container_id,
+    @to_location_id::uuid,
+    CASE WHEN @keep_containers::boolean THEN container_id END,
+    quantity, 'Location evacuated'
+FROM warehouse.inventory
+WHERE workspace_id = @workspace_id
+  AND location_id = @from_location_id
+  AND quantity > 0;
+
+-- name: EvacuateLocationInventory :execrows
+-- Move every inventory row out of a location, optionally taking it out of
+-- its container.
+UPDATE warehouse.inventory
+SET location_id = @to_location_id::uuid,
+    container_id = CASE WHEN @keep_containers::boolean THEN container_id END,
+    updated_at = now()
+WHERE workspace_id = @workspace_id
+  AND location_id = @from_location_id;
+
+-- name: EvacuateLocationContainers :execrows
+-- Move every container out of a location.
+UPDATE warehouse.containers
+SET location_id = @to_location_id::uuid,
+    updated_at = now()
+WHERE workspace_id = @workspace_id
+  AND location_id = @from_location_id;
@@ -20,7 +20,19 @@ WHERE id = $1 AND workspace_id = $2;
 SELECT * FROM warehouse.pending_changes
 WHERE workspace_id = $1
   AND (sqlc.narg('status')::warehouse.pending_change_status_enum IS NULL OR status = sqlc.narg('status'))
-ORDER BY created_at DESC;
+  AND (sqlc.narg('entity_type')::text IS NULL OR entity_type = sqlc.narg('entity_type'))
+  AND (sqlc.narg('action')::warehouse.pending_change_action_enum IS NULL OR action = sqlc.narg('action'))
+  AND (sqlc.narg('requester_id')::uuid IS NULL OR requester_id = sqlc.narg('requester_id'))
+ORDER BY created_at DESC
+LIMIT $2 OFFSET $3;
+
+-- name: CountPendingChangesByWorkspaceFiltered :one
+SELECT COUNT(*)::int FROM warehouse.pending_changes
+WHERE workspace_id = $1
+  AND (sqlc.narg('status')::warehouse.pending_change_status_enum IS NULL OR status = sqlc.narg('status'))
+  AND (sqlc.narg('entity_type')::text IS NULL OR entity_type = sqlc.narg('entity_type'))
+  AND (sqlc.narg('action')::warehouse.pending_change_action_enum IS NULL OR action = sqlc.narg('action'))
+  AND (sqlc.narg('requester_id')::uuid IS NULL OR requester_id = sqlc.narg('requester_id'));
 
 -- name: ListPendingChangesByRequester :many
 SELECT * FROM warehouse.pending_changes
@@ -51,3 +63,23 @@ WHERE id = $1 AND workspace_id = $2;
 -- name: CountPendingChangesByWorkspace :one
 SELECT COUNT(*) FROM warehouse.pending_changes
 WHERE workspace_id = $1 AND status = 'pending';
+
+-- name: CountPendingChangesByRequesterStatus :many
+SELECT status, COUNT(*)::int AS count FROM warehouse.pending_changes
+WHERE workspace_id = $1 AND requester_id = $2
+GROUP BY status;
+
+-- name: ReviewerStats :many
+-- Per-reviewer approve/reject counts and mean time from submission to review
+-- for changes reviewed in [from_time, to_time), busiest reviewer first.
+SELECT reviewed_by::uuid AS reviewer_id,
+       COUNT(*) FILTER (WHERE status = 'approved')::int AS approved_count,
+       COUNT(*) FILTER (WHERE status = 'rejected')::int AS rejected_count,
+       AVG(EXTRACT(EPOCH FROM (reviewed_at - created_at)))::float8 AS avg_review_seconds
+FROM warehouse.pending_changes
+WHERE workspace_id = $1
+  AND reviewed_by IS NOT NULL
+  AND reviewed_at >= sqlc.arg(from_time)::timestamptz
+  AND reviewed_at < sqlc.arg(to_time)::timestamptz
+GROUP BY reviewed_by
+ORDER BY COUNT(*) DESC, reviewed_by;
@@ -0,0 +1,12 @@
+-- name: GetPhotoSettings :one
+SELECT * FROM warehouse.photo_settings WHERE workspace_id = $1;
+
+-- name: UpsertPhotoSettings :one
+INSERT INTO warehouse.photo_settings (workspace_id, max_photos_per_item, require_photo, block_inventory_without_photo)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (workspace_id) DO UPDATE
+SET max_photos_per_item = EXCLUDED.max_photos_per_item,
+    require_photo = EXCLUDED.require_photo,
+    block_inventory_without_photo = EXCLUDED.block_inventory_without_photo,
+    updated_at = now()
+RETURNING *;
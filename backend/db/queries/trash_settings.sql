@@ -0,0 +1,13 @@
+-- name: GetTrashSettings :one
+SELECT * FROM warehouse.trash_settings WHERE workspace_id = $1;
+
+-- name: UpsertTrashSettings :one
+INSERT INTO warehouse.trash_settings (workspace_id, trash_retention_days)
+VALUES ($1, $2)
+ON CONFLICT (workspace_id) DO UPDATE
+SET trash_retention_days = EXCLUDED.trash_retention_days,
+    updated_at = now()
+RETURNING *;
+
+-- name: DeleteTrashSettings :exec
+DELETE FROM warehouse.trash_settings WHERE workspace_id = $1;
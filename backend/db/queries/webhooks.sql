@@ -0,0 +1,58 @@
+-- name: GetWebhook :one
+SELECT * FROM warehouse.webhooks
+WHERE id = $1 AND workspace_id = $2;
+
+-- name: ListWebhooksByWorkspace :many
+SELECT * FROM warehouse.webhooks
+WHERE workspace_id = $1
+ORDER BY created_at;
+
+-- name: ListActiveWebhooksForEvent :many
+SELECT * FROM warehouse.webhooks
+WHERE workspace_id = $1 AND is_active = true AND @event_type::text = ANY(event_types);
+
+-- name: CreateWebhook :one
+INSERT INTO warehouse.webhooks (id, workspace_id, url, secret, event_types, is_active, created_by)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING *;
+
+-- name: UpdateWebhook :one
+UPDATE warehouse.webhooks
+SET url = $3, secret = $4, event_types = $5, is_active = $6, updated_at = now()
+WHERE id = $1 AND workspace_id = $2
+RETURNING *;
+
+-- name: DeleteWebhook :exec
+DELETE FROM warehouse.webhooks
+WHERE id = $1 AND workspace_id = $2;
+
+-- name: CreateWebhookDelivery :one
+INSERT INTO warehouse.webhook_deliveries (id, webhook_id, workspace_id, event_type, payload)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: ListWebhookDeliveries :many
+SELECT * FROM warehouse.webhook_deliveries
+WHERE webhook_id = $1 AND workspace_id = $2
+ORDER BY created_at DESC
+LIMIT $3 OFFSET $4;
+
+-- name: CountWebhookDeliveries :one
+SELECT COUNT(*)::int FROM warehouse.webhook_deliveries
+WHERE webhook_id = $1 AND workspace_id = $2;
+
+-- name: GetWebhookDeliveryTarget :one
+SELECT d.id, d.event_type, d.payload, d.status, w.url, w.secret, w.is_active
+FROM warehouse.webhook_deliveries d
+JOIN warehouse.webhooks w ON w.id = d.webhook_id
+WHERE d.id = $1;
+
+-- name: RecordWebhookDeliveryAttempt :exec
+UPDATE warehouse.webhook_deliveries
+SET status = $2,
+    attempts = attempts + 1,
+    response_status = $3,
+    last_error = $4,
+    delivered_at = CASE WHEN $2 = 'succeeded' THEN now() ELSE delivered_at END,
+    updated_at = now()
+WHERE id = $1;
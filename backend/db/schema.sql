@@ -43,6 +43,20 @@ CREATE EXTENSION IF NOT EXISTS citext WITH SCHEMA public;
 COMMENT ON EXTENSION citext IS 'data type for case-insensitive character strings';
 
 
+--
+-- Name: pg_trgm; Type: EXTENSION; Schema: -; Owner: -
+--
+
+CREATE EXTENSION IF NOT EXISTS pg_trgm WITH SCHEMA public;
+
+
+--
+-- Name: EXTENSION pg_trgm; Type: COMMENT; Schema: -; Owner: -
+--
+
+COMMENT ON EXTENSION pg_trgm IS 'text similarity measurement and index searching based on trigrams';
+
+
 --
 -- Name: notification_type_enum; Type: TYPE; Schema: auth; Owner: -
 --
@@ -376,6 +390,113 @@ SET default_tablespace = '';
 
 SET default_table_access_method = heap;
 
+--
+-- Name: api_keys; Type: TABLE; Schema: auth; Owner: -
+--
+
+CREATE TABLE auth.api_keys (
+    id uuid DEFAULT uuidv7() NOT NULL,
+    workspace_id uuid NOT NULL,
+    name character varying(100) NOT NULL,
+    key_prefix character varying(20) NOT NULL,
+    key_hash character varying(64) NOT NULL,
+    role character varying(20) NOT NULL,
+    expires_at timestamp with time zone,
+    last_used_at timestamp with time zone,
+    revoked_at timestamp with time zone,
+    created_by uuid NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT api_keys_role_check CHECK (((role)::text = ANY ((ARRAY['admin'::character varying, 'member'::character varying, 'viewer'::character varying])::text[])))
+);
+
+
+--
+-- Name: TABLE api_keys; Type: COMMENT; Schema: auth; Owner: -
+--
+
+COMMENT ON TABLE auth.api_keys IS 'API keys machine clients authenticate with (Authorization: ApiKey <key>). Each key is limited to one workspace and acts with a fixed role.';
+
+
+--
+-- Name: COLUMN api_keys.key_prefix; Type: COMMENT; Schema: auth; Owner: -
+--
+
+COMMENT ON COLUMN auth.api_keys.key_prefix IS 'Start of the key, shown so owners can tell keys apart.';
+
+
+--
+-- Name: COLUMN api_keys.key_hash; Type: COMMENT; Schema: auth; Owner: -
+--
+
+COMMENT ON COLUMN auth.api_keys.key_hash IS 'SHA-256 of the key, hex. The key itself is never stored.';
+
+
+--
+-- Name: COLUMN api_keys.role; Type: COMMENT; Schema: auth; Owner: -
+--
+
+COMMENT ON COLUMN auth.api_keys.role IS 'Workspace role requests made with the key act with: admin, member or viewer.';
+
+
+--
+-- Name: COLUMN api_keys.expires_at; Type: COMMENT; Schema: auth; Owner: -
+--
+
+COMMENT ON COLUMN auth.api_keys.expires_at IS 'When the key stops working. NULL never expires.';
+
+
+--
+-- Name: COLUMN api_keys.created_by; Type: COMMENT; Schema: auth; Owner: -
+--
+
+COMMENT ON COLUMN auth.api_keys.created_by IS 'Owner who created the key. The key stops working if they leave the workspace.';
+
+
+--
+-- Name: auth_events; Type: TABLE; Schema: auth; Owner: -
+--
+
+CREATE TABLE auth.auth_events (
+    id uuid DEFAULT uuidv7() NOT NULL,
+    user_id uuid,
+    email character varying(255),
+    event_type character varying(30) NOT NULL,
+    success boolean NOT NULL,
+    failure_reason character varying(30),
+    ip_address inet,
+    user_agent text,
+    created_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+
+--
+-- Name: TABLE auth_events; Type: COMMENT; Schema: auth; Owner: -
+--
+
+COMMENT ON TABLE auth.auth_events IS 'Authentication events (sign-ins, password and two-factor changes, session revocations) for the security log.';
+
+
+--
+-- Name: COLUMN auth_events.email; Type: COMMENT; Schema: auth; Owner: -
+--
+
+COMMENT ON COLUMN auth.auth_events.email IS 'Email the sign-in was attempted with, lowercased. Set even when no user matched.';
+
+
+--
+-- Name: COLUMN auth_events.event_type; Type: COMMENT; Schema: auth; Owner: -
+--
+
+COMMENT ON COLUMN auth.auth_events.event_type IS 'login, password_change, two_factor_enabled, two_factor_disabled, session_revoked.';
+
+
+--
+-- Name: COLUMN auth_events.failure_reason; Type: COMMENT; Schema: auth; Owner: -
+--
+
+COMMENT ON COLUMN auth.auth_events.failure_reason IS 'Why a failed event failed (e.g. invalid_credentials, invalid_totp). Never the submitted secret.';
+
+
 --
 -- Name: notifications; Type: TABLE; Schema: auth; Owner: -
 --
@@ -514,6 +635,33 @@ COMMENT ON TABLE auth.user_oauth_accounts IS 'External OAuth provider accounts l
 COMMENT ON COLUMN auth.user_oauth_accounts.access_token IS 'OAuth access token. Must be encrypted at application layer.';
 
 
+--
+-- Name: user_recovery_codes; Type: TABLE; Schema: auth; Owner: -
+--
+
+CREATE TABLE auth.user_recovery_codes (
+    id uuid DEFAULT uuidv7() NOT NULL,
+    user_id uuid NOT NULL,
+    code_hash character varying(64) NOT NULL,
+    used_at timestamp with time zone,
+    created_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+
+--
+-- Name: TABLE user_recovery_codes; Type: COMMENT; Schema: auth; Owner: -
+--
+
+COMMENT ON TABLE auth.user_recovery_codes IS 'Single-use two-factor recovery codes.';
+
+
+--
+-- Name: COLUMN user_recovery_codes.code_hash; Type: COMMENT; Schema: auth; Owner: -
+--
+
+COMMENT ON COLUMN auth.user_recovery_codes.code_hash IS 'SHA-256 hash of the normalized recovery code. Never store plain codes.';
+
+
 --
 -- Name: user_sessions; Type: TABLE; Schema: auth; Owner: -
 --
@@ -566,6 +714,40 @@ COMMENT ON COLUMN auth.user_sessions.ip_address IS 'Client IP address at login t
 COMMENT ON COLUMN auth.user_sessions.last_active_at IS 'Updated on token refresh to track session activity.';
 
 
+--
+-- Name: user_two_factor; Type: TABLE; Schema: auth; Owner: -
+--
+
+CREATE TABLE auth.user_two_factor (
+    user_id uuid NOT NULL,
+    totp_secret_encrypted text NOT NULL,
+    last_used_step bigint DEFAULT 0 NOT NULL,
+    enabled_at timestamp with time zone,
+    created_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+
+--
+-- Name: TABLE user_two_factor; Type: COMMENT; Schema: auth; Owner: -
+--
+
+COMMENT ON TABLE auth.user_two_factor IS 'TOTP two-factor settings. Unconfirmed enrollments have enabled_at NULL.';
+
+
+--
+-- Name: COLUMN user_two_factor.totp_secret_encrypted; Type: COMMENT; Schema: auth; Owner: -
+--
+
+COMMENT ON COLUMN auth.user_two_factor.totp_secret_encrypted IS 'AES-GCM encrypted base32 TOTP secret. Never store plain secrets.';
+
+
+--
+-- Name: COLUMN user_two_factor.last_used_step; Type: COMMENT; Schema: auth; Owner: -
+--
+
+COMMENT ON COLUMN auth.user_two_factor.last_used_step IS 'Time step of the last accepted code; codes at or before it are rejected as replays.';
+
+
 --
 -- Name: users; Type: TABLE; Schema: auth; Owner: -
 --
@@ -878,10 +1060,19 @@ CREATE TABLE warehouse.borrowers (
     is_archived boolean DEFAULT false NOT NULL,
     search_vector tsvector,
     created_at timestamp with time zone DEFAULT now(),
-    updated_at timestamp with time zone DEFAULT now()
+    updated_at timestamp with time zone DEFAULT now(),
+    reminder_channel character varying(10) DEFAULT 'email'::character varying NOT NULL,
+    CONSTRAINT chk_borrowers_reminder_channel CHECK (((reminder_channel)::text = ANY ((ARRAY['email'::character varying, 'sms'::character varying, 'none'::character varying])::text[])))
 );
 
 
+--
+-- Name: COLUMN borrowers.reminder_channel; Type: COMMENT; Schema: warehouse; Owner: -
+--
+
+COMMENT ON COLUMN warehouse.borrowers.reminder_channel IS 'Channel loan reminders are sent to the borrower on: email, sms or none.';
+
+
 --
 -- Name: categories; Type: TABLE; Schema: warehouse; Owner: -
 --
@@ -899,6 +1090,78 @@ CREATE TABLE warehouse.categories (
 );
 
 
+--
+-- Name: category_inventory_defaults; Type: TABLE; Schema: warehouse; Owner: -
+--
+
+CREATE TABLE warehouse.category_inventory_defaults (
+    category_id uuid NOT NULL,
+    workspace_id uuid NOT NULL,
+    default_condition warehouse.item_condition_enum,
+    default_status warehouse.item_status_enum,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+
+--
+-- Name: TABLE category_inventory_defaults; Type: COMMENT; Schema: warehouse; Owner: -
+--
+
+COMMENT ON TABLE warehouse.category_inventory_defaults IS 'Condition and status applied to new inventory of items in the category when the request omits them.';
+
+
+--
+-- Name: COLUMN category_inventory_defaults.default_condition; Type: COMMENT; Schema: warehouse; Owner: -
+--
+
+COMMENT ON COLUMN warehouse.category_inventory_defaults.default_condition IS 'Condition for new inventory. NULL uses the global default (NEW).';
+
+
+--
+-- Name: COLUMN category_inventory_defaults.default_status; Type: COMMENT; Schema: warehouse; Owner: -
+--
+
+COMMENT ON COLUMN warehouse.category_inventory_defaults.default_status IS 'Status for new inventory. NULL uses the global default (AVAILABLE).';
+
+
+--
+-- Name: category_rules; Type: TABLE; Schema: warehouse; Owner: -
+--
+
+CREATE TABLE warehouse.category_rules (
+    id uuid DEFAULT uuidv7() NOT NULL,
+    workspace_id uuid NOT NULL,
+    category_id uuid NOT NULL,
+    pattern character varying(200) NOT NULL,
+    match_type character varying(20) NOT NULL,
+    "position" integer NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT category_rules_match_type_check CHECK (((match_type)::text = ANY ((ARRAY['substring'::character varying, 'regex'::character varying])::text[])))
+);
+
+
+--
+-- Name: TABLE category_rules; Type: COMMENT; Schema: warehouse; Owner: -
+--
+
+COMMENT ON TABLE warehouse.category_rules IS 'Keyword rules assigning a category to new items created without one. Rules are tried in position order; the first match wins.';
+
+
+--
+-- Name: COLUMN category_rules.pattern; Type: COMMENT; Schema: warehouse; Owner: -
+--
+
+COMMENT ON COLUMN warehouse.category_rules.pattern IS 'Text matched case-insensitively against the item name: a plain substring or an RE2 regular expression, per match_type.';
+
+
+--
+-- Name: COLUMN category_rules."position"; Type: COMMENT; Schema: warehouse; Owner: -
+--
+
+COMMENT ON COLUMN warehouse.category_rules."position" IS 'Evaluation order within the workspace, lowest first.';
+
+
 --
 -- Name: companies; Type: TABLE; Schema: warehouse; Owner: -
 --
@@ -956,6 +1219,55 @@ CREATE TABLE warehouse.containers (
 COMMENT ON COLUMN warehouse.containers.short_code IS 'Short alphanumeric code for QR labels. Unique within workspace. Auto-generated if not provided.';
 
 
+--
+-- Name: currency_settings; Type: TABLE; Schema: warehouse; Owner: -
+--
+
+CREATE TABLE warehouse.currency_settings (
+    workspace_id uuid NOT NULL,
+    base_currency character varying(3) DEFAULT 'EUR'::character varying NOT NULL,
+    exchange_rates jsonb DEFAULT '{}'::jsonb NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT chk_currency_settings_base_currency CHECK (((base_currency)::text ~ '^[A-Z]{3}$'::text))
+);
+
+
+--
+-- Name: TABLE currency_settings; Type: COMMENT; Schema: warehouse; Owner: -
+--
+
+COMMENT ON TABLE warehouse.currency_settings IS 'Workspace base currency and exchange rates used to convert inventory prices in value reports. Workspaces without a row report in EUR.';
+
+
+--
+-- Name: COLUMN currency_settings.exchange_rates; Type: COMMENT; Schema: warehouse; Owner: -
+--
+
+COMMENT ON COLUMN warehouse.currency_settings.exchange_rates IS 'Map of ISO 4217 code to the amount of base currency one unit of that currency is worth, e.g. {"USD": 0.92}.';
+
+
+--
+-- Name: custom_fields; Type: TABLE; Schema: warehouse; Owner: -
+--
+
+CREATE TABLE warehouse.custom_fields (
+    id uuid DEFAULT uuidv7() NOT NULL,
+    workspace_id uuid NOT NULL,
+    name character varying(100) NOT NULL,
+    field_type character varying(10) NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT custom_fields_field_type_check CHECK (((field_type)::text = ANY ((ARRAY['text'::character varying, 'number'::character varying, 'date'::character varying, 'bool'::character varying])::text[])))
+);
+
+
+--
+-- Name: TABLE custom_fields; Type: COMMENT; Schema: warehouse; Owner: -
+--
+
+COMMENT ON TABLE warehouse.custom_fields IS 'Per-workspace custom item attribute definitions.';
+
+
 --
 -- Name: deleted_records; Type: TABLE; Schema: warehouse; Owner: -
 --
@@ -1095,49 +1407,127 @@ CREATE TABLE warehouse.import_jobs (
 
 
 --
--- Name: inventory; Type: TABLE; Schema: warehouse; Owner: -
+-- Name: import_photo_results; Type: TABLE; Schema: warehouse; Owner: -
 --
 
-CREATE TABLE warehouse.inventory (
+CREATE TABLE warehouse.import_photo_results (
     id uuid DEFAULT uuidv7() NOT NULL,
-    workspace_id uuid NOT NULL,
+    import_job_id uuid NOT NULL,
+    row_number integer NOT NULL,
     item_id uuid NOT NULL,
-    location_id uuid NOT NULL,
-    container_id uuid,
-    quantity integer DEFAULT 1 NOT NULL,
-    condition warehouse.item_condition_enum,
-    status warehouse.item_status_enum DEFAULT 'AVAILABLE'::warehouse.item_status_enum,
-    date_acquired date,
-    purchase_price integer,
-    currency_code character varying(3) DEFAULT 'EUR'::character varying,
-    warranty_expires date,
-    expiration_date date,
-    notes text,
-    last_used_at timestamp with time zone,
-    is_archived boolean DEFAULT false NOT NULL,
-    created_at timestamp with time zone DEFAULT now(),
-    updated_at timestamp with time zone DEFAULT now(),
-    CONSTRAINT chk_inventory_currency CHECK (((currency_code IS NULL) OR ((currency_code)::text ~ '^[A-Z]{3}$'::text))),
-    CONSTRAINT chk_inventory_price_nonneg CHECK (((purchase_price IS NULL) OR (purchase_price >= 0))),
-    CONSTRAINT chk_inventory_quantity_non_negative CHECK ((quantity >= 0))
+    url text NOT NULL,
+    status character varying(20) NOT NULL,
+    photo_id uuid,
+    message text,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    is_primary boolean DEFAULT false NOT NULL,
+    CONSTRAINT import_photo_results_status_check CHECK (((status)::text = ANY ((ARRAY['pending'::character varying, 'attached'::character varying, 'failed'::character varying, 'skipped'::character varying])::text[])))
 );
 
 
 --
--- Name: COLUMN inventory.last_used_at; Type: COMMENT; Schema: warehouse; Owner: -
+-- Name: TABLE import_photo_results; Type: COMMENT; Schema: warehouse; Owner: -
 --
 
-COMMENT ON COLUMN warehouse.inventory.last_used_at IS 'Timestamp when this inventory was last marked as "used". Used for declutter assistant.';
+COMMENT ON TABLE warehouse.import_photo_results IS 'Outcome of fetching the photo_url of an imported item row. Photo failures never fail the row itself.';
 
 
 --
--- Name: inventory_movements; Type: TABLE; Schema: warehouse; Owner: -
+-- Name: COLUMN import_photo_results.is_primary; Type: COMMENT; Schema: warehouse; Owner: -
 --
 
-CREATE TABLE warehouse.inventory_movements (
+COMMENT ON COLUMN warehouse.import_photo_results.is_primary IS 'Whether this photo was chosen as the item''s primary photo once all of the item''s imported photos settled.';
+
+
+--
+-- Name: COLUMN import_photo_results.message; Type: COMMENT; Schema: warehouse; Owner: -
+--
+
+COMMENT ON COLUMN warehouse.import_photo_results.message IS 'Why the photo was skipped or failed. NULL when pending or attached.';
+
+
+--
+-- Name: import_row_results; Type: TABLE; Schema: warehouse; Owner: -
+--
+
+CREATE TABLE warehouse.import_row_results (
     id uuid DEFAULT uuidv7() NOT NULL,
-    workspace_id uuid NOT NULL,
-    inventory_id uuid NOT NULL,
+    import_job_id uuid NOT NULL,
+    row_number integer NOT NULL,
+    action character varying(20) NOT NULL,
+    entity_id uuid,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    auto_categorized boolean DEFAULT false NOT NULL,
+    CONSTRAINT import_row_results_action_check CHECK (((action)::text = ANY ((ARRAY['created'::character varying, 'updated'::character varying, 'skipped'::character varying])::text[])))
+);
+
+
+--
+-- Name: TABLE import_row_results; Type: COMMENT; Schema: warehouse; Owner: -
+--
+
+COMMENT ON TABLE warehouse.import_row_results IS 'Outcome of each successfully handled import row: created, updated (on_conflict=update) or skipped (on_conflict=skip). Failed rows are recorded in import_errors.';
+
+
+--
+-- Name: COLUMN import_row_results.entity_id; Type: COMMENT; Schema: warehouse; Owner: -
+--
+
+COMMENT ON COLUMN warehouse.import_row_results.entity_id IS 'Entity the row created, updated or matched. NULL when not applicable.';
+
+
+--
+-- Name: COLUMN import_row_results.auto_categorized; Type: COMMENT; Schema: warehouse; Owner: -
+--
+
+COMMENT ON COLUMN warehouse.import_row_results.auto_categorized IS 'The row created an item whose category was assigned by a category rule.';
+
+
+--
+-- Name: inventory; Type: TABLE; Schema: warehouse; Owner: -
+--
+
+CREATE TABLE warehouse.inventory (
+    id uuid DEFAULT uuidv7() NOT NULL,
+    workspace_id uuid NOT NULL,
+    item_id uuid NOT NULL,
+    location_id uuid NOT NULL,
+    container_id uuid,
+    quantity integer DEFAULT 1 NOT NULL,
+    condition warehouse.item_condition_enum,
+    status warehouse.item_status_enum DEFAULT 'AVAILABLE'::warehouse.item_status_enum,
+    date_acquired date,
+    purchase_price integer,
+    currency_code character varying(3) DEFAULT 'EUR'::character varying,
+    warranty_expires date,
+    expiration_date date,
+    notes text,
+    last_used_at timestamp with time zone,
+    is_archived boolean DEFAULT false NOT NULL,
+    created_at timestamp with time zone DEFAULT now(),
+    updated_at timestamp with time zone DEFAULT now(),
+    CONSTRAINT chk_inventory_currency CHECK (((currency_code IS NULL) OR ((currency_code)::text ~ '^[A-Z]{3}$'::text))),
+    CONSTRAINT chk_inventory_price_nonneg CHECK (((purchase_price IS NULL) OR (purchase_price >= 0))),
+    CONSTRAINT chk_inventory_quantity_non_negative CHECK ((quantity >= 0))
+);
+
+
+--
+-- Name: COLUMN inventory.last_used_at; Type: COMMENT; Schema: warehouse; Owner: -
+--
+
+COMMENT ON COLUMN warehouse.inventory.last_used_at IS 'Timestamp when this inventory was last marked as "used". Used for declutter assistant.';
+
+
+--
+-- Name: inventory_movements; Type: TABLE; Schema: warehouse; Owner: -
+--
+
+CREATE TABLE warehouse.inventory_movements (
+    id uuid DEFAULT uuidv7() NOT NULL,
+    workspace_id uuid NOT NULL,
+    inventory_id uuid NOT NULL,
     from_location_id uuid,
     from_container_id uuid,
     to_location_id uuid,
@@ -1150,6 +1540,140 @@ CREATE TABLE warehouse.inventory_movements (
 );
 
 
+--
+-- Name: inventory_settings; Type: TABLE; Schema: warehouse; Owner: -
+--
+
+CREATE TABLE warehouse.inventory_settings (
+    workspace_id uuid NOT NULL,
+    reserved_is_available boolean DEFAULT false NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    expired_status warehouse.item_status_enum
+);
+
+
+--
+-- Name: TABLE inventory_settings; Type: COMMENT; Schema: warehouse; Owner: -
+--
+
+COMMENT ON TABLE warehouse.inventory_settings IS 'Workspace inventory policy. Workspaces without a row use the defaults.';
+
+
+--
+-- Name: COLUMN inventory_settings.reserved_is_available; Type: COMMENT; Schema: warehouse; Owner: -
+--
+
+COMMENT ON COLUMN warehouse.inventory_settings.reserved_is_available IS 'Whether RESERVED entries count as available stock and can be loaned out. Off by default.';
+
+
+--
+-- Name: COLUMN inventory_settings.expired_status; Type: COMMENT; Schema: warehouse; Owner: -
+--
+
+COMMENT ON COLUMN warehouse.inventory_settings.expired_status IS 'Status the daily expiry job moves expired, non-loaned inventory to (e.g. DISPOSED). NULL turns the job off for the workspace.';
+
+
+--
+-- Name: inventory_snapshots; Type: TABLE; Schema: warehouse; Owner: -
+--
+
+CREATE TABLE warehouse.inventory_snapshots (
+    id uuid DEFAULT uuidv7() NOT NULL,
+    workspace_id uuid NOT NULL,
+    label character varying(200) NOT NULL,
+    currency_code character varying(3) NOT NULL,
+    total_quantity bigint NOT NULL,
+    total_value bigint NOT NULL,
+    unvalued_count integer NOT NULL,
+    items jsonb NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+
+--
+-- Name: TABLE inventory_snapshots; Type: COMMENT; Schema: warehouse; Owner: -
+--
+
+COMMENT ON TABLE warehouse.inventory_snapshots IS 'Frozen summaries of a workspace''s inventory, computed when taken and never updated.';
+
+
+--
+-- Name: COLUMN inventory_snapshots.currency_code; Type: COMMENT; Schema: warehouse; Owner: -
+--
+
+COMMENT ON COLUMN warehouse.inventory_snapshots.currency_code IS 'Workspace base currency at the time; total_value and the item values are in it.';
+
+
+--
+-- Name: COLUMN inventory_snapshots.unvalued_count; Type: COMMENT; Schema: warehouse; Owner: -
+--
+
+COMMENT ON COLUMN warehouse.inventory_snapshots.unvalued_count IS 'Inventory rows left out of total_value: unpriced, or priced in a currency with no exchange rate.';
+
+
+--
+-- Name: COLUMN inventory_snapshots.items; Type: COMMENT; Schema: warehouse; Owner: -
+--
+
+COMMENT ON COLUMN warehouse.inventory_snapshots.items IS 'Per-item summary: quantity, quantity by condition and total value of the item''s unarchived inventory.';
+
+
+--
+-- Name: item_custom_values; Type: TABLE; Schema: warehouse; Owner: -
+--
+
+CREATE TABLE warehouse.item_custom_values (
+    item_id uuid NOT NULL,
+    field_id uuid NOT NULL,
+    workspace_id uuid NOT NULL,
+    value text NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+
+--
+-- Name: TABLE item_custom_values; Type: COMMENT; Schema: warehouse; Owner: -
+--
+
+COMMENT ON TABLE warehouse.item_custom_values IS 'Custom field values of items, one row per item and field.';
+
+
+--
+-- Name: COLUMN item_custom_values.value; Type: COMMENT; Schema: warehouse; Owner: -
+--
+
+COMMENT ON COLUMN warehouse.item_custom_values.value IS 'Canonical text form of the value for the field type.';
+
+
+--
+-- Name: item_identifiers; Type: TABLE; Schema: warehouse; Owner: -
+--
+
+CREATE TABLE warehouse.item_identifiers (
+    id uuid DEFAULT uuidv7() NOT NULL,
+    workspace_id uuid NOT NULL,
+    item_id uuid NOT NULL,
+    identifier_type character varying(20) NOT NULL,
+    value character varying(50) NOT NULL,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT chk_item_identifiers_type CHECK (((identifier_type)::text = ANY ((ARRAY['upc'::character varying, 'ean'::character varying, 'isbn'::character varying, 'asset_tag'::character varying, 'other'::character varying])::text[])))
+);
+
+
+--
+-- Name: TABLE item_identifiers; Type: COMMENT; Schema: warehouse; Owner: -
+--
+
+COMMENT ON TABLE warehouse.item_identifiers IS 'Additional barcodes and codes an item can be scanned by. items.barcode remains the primary one.';
+
+
+--
+-- Name: COLUMN item_identifiers.value; Type: COMMENT; Schema: warehouse; Owner: -
+--
+
+COMMENT ON COLUMN warehouse.item_identifiers.value IS 'The scanned code, matched exactly. Unique per workspace.';
+
+
 --
 -- Name: item_labels; Type: TABLE; Schema: warehouse; Owner: -
 --
@@ -1161,6 +1685,34 @@ CREATE TABLE warehouse.item_labels (
 );
 
 
+--
+-- Name: item_location_stock_levels; Type: TABLE; Schema: warehouse; Owner: -
+--
+
+CREATE TABLE warehouse.item_location_stock_levels (
+    item_id uuid NOT NULL,
+    location_id uuid NOT NULL,
+    workspace_id uuid NOT NULL,
+    min_stock_level integer NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT chk_item_location_stock_levels_min_stock_positive CHECK ((min_stock_level > 0))
+);
+
+
+--
+-- Name: TABLE item_location_stock_levels; Type: COMMENT; Schema: warehouse; Owner: -
+--
+
+COMMENT ON TABLE warehouse.item_location_stock_levels IS 'Minimum stock of an item at one location. Overrides items.min_stock_level for low-stock reporting.';
+
+
+--
+-- Name: COLUMN item_location_stock_levels.min_stock_level; Type: COMMENT; Schema: warehouse; Owner: -
+--
+
+COMMENT ON COLUMN warehouse.item_location_stock_levels.min_stock_level IS 'The item is low at this location when its stock there falls below this.';
+
+
 --
 -- Name: item_photos; Type: TABLE; Schema: warehouse; Owner: -
 --
@@ -1189,10 +1741,21 @@ CREATE TABLE warehouse.item_photos (
     perceptual_hash bigint,
     created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
     updated_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP NOT NULL,
+    blurhash text,
+    content_hash text,
+    thumbnail_config_version text,
+    inventory_id uuid,
     CONSTRAINT item_photos_thumbnail_status_check CHECK (((thumbnail_status)::text = ANY (ARRAY[('pending'::character varying)::text, ('processing'::character varying)::text, ('complete'::character varying)::text, ('failed'::character varying)::text])))
 );
 
 
+--
+-- Name: COLUMN item_photos.display_order; Type: COMMENT; Schema: warehouse; Owner: -
+--
+
+COMMENT ON COLUMN warehouse.item_photos.display_order IS 'Gallery position. Spaced 1024 apart so a photo can move between neighbours without renumbering the rest.';
+
+
 --
 -- Name: COLUMN item_photos.thumbnail_status; Type: COMMENT; Schema: warehouse; Owner: -
 --
@@ -1242,6 +1805,79 @@ COMMENT ON COLUMN warehouse.item_photos.thumbnail_error IS 'Last error message i
 COMMENT ON COLUMN warehouse.item_photos.perceptual_hash IS '64-bit difference hash (dHash) for duplicate detection. Similar images have similar hashes with small Hamming distance.';
 
 
+--
+-- Name: COLUMN item_photos.blurhash; Type: COMMENT; Schema: warehouse; Owner: -
+--
+
+COMMENT ON COLUMN warehouse.item_photos.blurhash IS 'BlurHash placeholder string for the photo. NULL when not yet computed or when blurhash generation is disabled.';
+
+
+--
+-- Name: COLUMN item_photos.content_hash; Type: COMMENT; Schema: warehouse; Owner: -
+--
+
+COMMENT ON COLUMN warehouse.item_photos.content_hash IS 'Hex SHA-256 of the original file bytes, for exact-duplicate detection within an item. NULL for photos uploaded before it was recorded.';
+
+
+--
+-- Name: COLUMN item_photos.thumbnail_config_version; Type: COMMENT; Schema: warehouse; Owner: -
+--
+
+COMMENT ON COLUMN warehouse.item_photos.thumbnail_config_version IS 'Thumbnail config version (sizes, format, quality) the thumbnails were generated with. NULL when unknown.';
+
+
+--
+-- Name: COLUMN item_photos.inventory_id; Type: COMMENT; Schema: warehouse; Owner: -
+--
+
+COMMENT ON COLUMN warehouse.item_photos.inventory_id IS 'Inventory record (physical unit) the photo shows. NULL when it shows the item in general.';
+
+
+--
+-- Name: item_shares; Type: TABLE; Schema: warehouse; Owner: -
+--
+
+CREATE TABLE warehouse.item_shares (
+    id uuid DEFAULT uuidv7() NOT NULL,
+    workspace_id uuid NOT NULL,
+    item_id uuid NOT NULL,
+    token_hash character varying(64) NOT NULL,
+    include_prices boolean DEFAULT false NOT NULL,
+    expires_at timestamp with time zone,
+    revoked_at timestamp with time zone,
+    created_by uuid,
+    created_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+
+--
+-- Name: TABLE item_shares; Type: COMMENT; Schema: warehouse; Owner: -
+--
+
+COMMENT ON TABLE warehouse.item_shares IS 'Public read-only links to one item, opened with a token that carries no workspace or item IDs.';
+
+
+--
+-- Name: COLUMN item_shares.token_hash; Type: COMMENT; Schema: warehouse; Owner: -
+--
+
+COMMENT ON COLUMN warehouse.item_shares.token_hash IS 'SHA-256 of the link token, hex. The token itself is shown once and never stored.';
+
+
+--
+-- Name: COLUMN item_shares.include_prices; Type: COMMENT; Schema: warehouse; Owner: -
+--
+
+COMMENT ON COLUMN warehouse.item_shares.include_prices IS 'Whether the shared view shows the purchase prices of the item''s inventory.';
+
+
+--
+-- Name: COLUMN item_shares.expires_at; Type: COMMENT; Schema: warehouse; Owner: -
+--
+
+COMMENT ON COLUMN warehouse.item_shares.expires_at IS 'When the link stops working. NULL never expires.';
+
+
 --
 -- Name: items; Type: TABLE; Schema: warehouse; Owner: -
 --
@@ -1272,6 +1908,7 @@ CREATE TABLE warehouse.items (
     search_vector tsvector GENERATED ALWAYS AS ((((setweight(to_tsvector('english'::regconfig, (COALESCE(name, ''::character varying))::text), 'A'::"char") || setweight(to_tsvector('english'::regconfig, (COALESCE(brand, ''::character varying))::text), 'B'::"char")) || setweight(to_tsvector('english'::regconfig, (COALESCE(model, ''::character varying))::text), 'B'::"char")) || setweight(to_tsvector('english'::regconfig, COALESCE(description, ''::text)), 'C'::"char"))) STORED,
     created_at timestamp with time zone DEFAULT now(),
     updated_at timestamp with time zone DEFAULT now(),
+    loanable boolean DEFAULT true NOT NULL,
     CONSTRAINT chk_items_min_stock_non_negative CHECK ((min_stock_level >= 0))
 );
 
@@ -1311,6 +1948,13 @@ COMMENT ON COLUMN warehouse.items.obsidian_vault_path IS 'Local path to Obsidian
 COMMENT ON COLUMN warehouse.items.obsidian_note_path IS 'Relative path to note within vault.';
 
 
+--
+-- Name: COLUMN items.loanable; Type: COMMENT; Schema: warehouse; Owner: -
+--
+
+COMMENT ON COLUMN warehouse.items.loanable IS 'False for fixed assets (a mounted shelf, a built-in) that are never lent out.';
+
+
 --
 -- Name: labels; Type: TABLE; Schema: warehouse; Owner: -
 --
@@ -1328,6 +1972,41 @@ CREATE TABLE warehouse.labels (
 );
 
 
+--
+-- Name: loan_settings; Type: TABLE; Schema: warehouse; Owner: -
+--
+
+CREATE TABLE warehouse.loan_settings (
+    workspace_id uuid NOT NULL,
+    overdue_grace_days integer DEFAULT 0 NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    default_loan_days integer DEFAULT 14 NOT NULL,
+    CONSTRAINT chk_loan_settings_default_loan_days CHECK (((default_loan_days > 0) AND (default_loan_days <= 365))),
+    CONSTRAINT chk_loan_settings_overdue_grace_days CHECK (((overdue_grace_days >= 0) AND (overdue_grace_days <= 365)))
+);
+
+
+--
+-- Name: TABLE loan_settings; Type: COMMENT; Schema: warehouse; Owner: -
+--
+
+COMMENT ON TABLE warehouse.loan_settings IS 'Workspace loan policy. Workspaces without a row have no overdue grace period and a 14-day default loan period.';
+
+
+--
+-- Name: COLUMN loan_settings.overdue_grace_days; Type: COMMENT; Schema: warehouse; Owner: -
+--
+
+COMMENT ON COLUMN warehouse.loan_settings.overdue_grace_days IS 'Days after due_date before a loan is reported and reminded as overdue.';
+
+
+--
+-- Name: COLUMN loan_settings.default_loan_days; Type: COMMENT; Schema: warehouse; Owner: -
+--
+
+COMMENT ON COLUMN warehouse.loan_settings.default_loan_days IS 'Loan period in days used for the due date when a loan is created without one.';
+
+
 --
 -- Name: loans; Type: TABLE; Schema: warehouse; Owner: -
 --
@@ -1460,6 +2139,48 @@ COMMENT ON COLUMN warehouse.pending_changes.client_change_id IS 'Client-generate
 COMMENT ON COLUMN warehouse.pending_changes.base_updated_at IS 'Optimistic concurrency token: the target entity''s updated_at as observed by the client when the change was composed.';
 
 
+--
+-- Name: photo_settings; Type: TABLE; Schema: warehouse; Owner: -
+--
+
+CREATE TABLE warehouse.photo_settings (
+    workspace_id uuid NOT NULL,
+    max_photos_per_item integer DEFAULT 20 NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    require_photo boolean DEFAULT false NOT NULL,
+    block_inventory_without_photo boolean DEFAULT false NOT NULL,
+    CONSTRAINT chk_photo_settings_max_photos_per_item CHECK (((max_photos_per_item >= 1) AND (max_photos_per_item <= 500)))
+);
+
+
+--
+-- Name: TABLE photo_settings; Type: COMMENT; Schema: warehouse; Owner: -
+--
+
+COMMENT ON TABLE warehouse.photo_settings IS 'Workspace photo policy. Workspaces without a row allow 20 photos per item.';
+
+
+--
+-- Name: COLUMN photo_settings.max_photos_per_item; Type: COMMENT; Schema: warehouse; Owner: -
+--
+
+COMMENT ON COLUMN warehouse.photo_settings.max_photos_per_item IS 'Maximum number of photos a single item may have.';
+
+
+--
+-- Name: COLUMN photo_settings.require_photo; Type: COMMENT; Schema: warehouse; Owner: -
+--
+
+COMMENT ON COLUMN warehouse.photo_settings.require_photo IS 'Items need at least one photo to count as cataloged; items without one are reported as incomplete.';
+
+
+--
+-- Name: COLUMN photo_settings.block_inventory_without_photo; Type: COMMENT; Schema: warehouse; Owner: -
+--
+
+COMMENT ON COLUMN warehouse.photo_settings.block_inventory_without_photo IS 'Refuse new inventory for items without a photo. Only applies with require_photo.';
+
+
 --
 -- Name: repair_attachments; Type: TABLE; Schema: warehouse; Owner: -
 --
@@ -1703,6 +2424,98 @@ UNION ALL
 COMMENT ON VIEW warehouse.v_archived_records IS 'All soft-deleted records across entity types for restoration UI.';
 
 
+--
+-- Name: trash_settings; Type: TABLE; Schema: warehouse; Owner: -
+--
+
+CREATE TABLE warehouse.trash_settings (
+    workspace_id uuid NOT NULL,
+    trash_retention_days integer NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT chk_trash_settings_trash_retention_days CHECK (((trash_retention_days >= 1) AND (trash_retention_days <= 3650)))
+);
+
+
+--
+-- Name: TABLE trash_settings; Type: COMMENT; Schema: warehouse; Owner: -
+--
+
+COMMENT ON TABLE warehouse.trash_settings IS 'Workspace trash policy. Workspaces without a row use the server-wide retention.';
+
+
+--
+-- Name: COLUMN trash_settings.trash_retention_days; Type: COMMENT; Schema: warehouse; Owner: -
+--
+
+COMMENT ON COLUMN warehouse.trash_settings.trash_retention_days IS 'Days deleted records are kept before the cleanup job removes them for good.';
+
+
+--
+-- Name: webhook_deliveries; Type: TABLE; Schema: warehouse; Owner: -
+--
+
+CREATE TABLE warehouse.webhook_deliveries (
+    id uuid DEFAULT uuidv7() NOT NULL,
+    webhook_id uuid NOT NULL,
+    workspace_id uuid NOT NULL,
+    event_type character varying(100) NOT NULL,
+    payload jsonb NOT NULL,
+    status character varying(20) DEFAULT 'pending'::character varying NOT NULL,
+    attempts integer DEFAULT 0 NOT NULL,
+    response_status integer,
+    last_error text,
+    delivered_at timestamp with time zone,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL,
+    CONSTRAINT webhook_deliveries_status_check CHECK (((status)::text = ANY ((ARRAY['pending'::character varying, 'succeeded'::character varying, 'failed'::character varying])::text[])))
+);
+
+
+--
+-- Name: TABLE webhook_deliveries; Type: COMMENT; Schema: warehouse; Owner: -
+--
+
+COMMENT ON TABLE warehouse.webhook_deliveries IS 'One row per event sent to a webhook. status stays pending while the worker retries and becomes succeeded or failed once it settles.';
+
+
+--
+-- Name: COLUMN webhook_deliveries.payload; Type: COMMENT; Schema: warehouse; Owner: -
+--
+
+COMMENT ON COLUMN warehouse.webhook_deliveries.payload IS 'Exact JSON body POSTed to the webhook; retries resend it byte-for-byte so the signature stays stable.';
+
+
+--
+-- Name: webhooks; Type: TABLE; Schema: warehouse; Owner: -
+--
+
+CREATE TABLE warehouse.webhooks (
+    id uuid DEFAULT uuidv7() NOT NULL,
+    workspace_id uuid NOT NULL,
+    url character varying(2000) NOT NULL,
+    secret character varying(100) NOT NULL,
+    event_types text[] NOT NULL,
+    is_active boolean DEFAULT true NOT NULL,
+    created_by uuid,
+    created_at timestamp with time zone DEFAULT now() NOT NULL,
+    updated_at timestamp with time zone DEFAULT now() NOT NULL
+);
+
+
+--
+-- Name: TABLE webhooks; Type: COMMENT; Schema: warehouse; Owner: -
+--
+
+COMMENT ON TABLE warehouse.webhooks IS 'Outgoing webhook subscriptions. Matching workspace events are POSTed to url, signed with HMAC-SHA256 using secret.';
+
+
+--
+-- Name: COLUMN webhooks.event_types; Type: COMMENT; Schema: warehouse; Owner: -
+--
+
+COMMENT ON COLUMN warehouse.webhooks.event_types IS 'Event types delivered to this webhook, e.g. loan.created, item.low_stock, pendingchange.approved.';
+
+
 --
 -- Name: wishlist_items; Type: TABLE; Schema: warehouse; Owner: -
 --
@@ -1764,6 +2577,30 @@ COMMENT ON COLUMN warehouse.wishlist_items.status IS 'Lifecycle: wanted -> order
 COMMENT ON COLUMN warehouse.wishlist_items.acquired_item_id IS 'The warehouse.items row created when this wish was acquired. Set by the acquire flow.';
 
 
+--
+-- Name: api_keys api_keys_key_hash_key; Type: CONSTRAINT; Schema: auth; Owner: -
+--
+
+ALTER TABLE ONLY auth.api_keys
+    ADD CONSTRAINT api_keys_key_hash_key UNIQUE (key_hash);
+
+
+--
+-- Name: api_keys api_keys_pkey; Type: CONSTRAINT; Schema: auth; Owner: -
+--
+
+ALTER TABLE ONLY auth.api_keys
+    ADD CONSTRAINT api_keys_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: auth_events auth_events_pkey; Type: CONSTRAINT; Schema: auth; Owner: -
+--
+
+ALTER TABLE ONLY auth.auth_events
+    ADD CONSTRAINT auth_events_pkey PRIMARY KEY (id);
+
+
 --
 -- Name: notifications notifications_pkey; Type: CONSTRAINT; Schema: auth; Owner: -
 --
@@ -1828,6 +2665,14 @@ ALTER TABLE ONLY auth.user_oauth_accounts
     ADD CONSTRAINT user_oauth_accounts_provider_provider_user_id_key UNIQUE (provider, provider_user_id);
 
 
+--
+-- Name: user_recovery_codes user_recovery_codes_pkey; Type: CONSTRAINT; Schema: auth; Owner: -
+--
+
+ALTER TABLE ONLY auth.user_recovery_codes
+    ADD CONSTRAINT user_recovery_codes_pkey PRIMARY KEY (id);
+
+
 --
 -- Name: user_sessions user_sessions_pkey; Type: CONSTRAINT; Schema: auth; Owner: -
 --
@@ -1836,6 +2681,14 @@ ALTER TABLE ONLY auth.user_sessions
     ADD CONSTRAINT user_sessions_pkey PRIMARY KEY (id);
 
 
+--
+-- Name: user_two_factor user_two_factor_pkey; Type: CONSTRAINT; Schema: auth; Owner: -
+--
+
+ALTER TABLE ONLY auth.user_two_factor
+    ADD CONSTRAINT user_two_factor_pkey PRIMARY KEY (user_id);
+
+
 --
 -- Name: users users_email_key; Type: CONSTRAINT; Schema: auth; Owner: -
 --
@@ -1948,6 +2801,22 @@ ALTER TABLE ONLY warehouse.categories
     ADD CONSTRAINT categories_pkey PRIMARY KEY (id);
 
 
+--
+-- Name: category_inventory_defaults category_inventory_defaults_pkey; Type: CONSTRAINT; Schema: warehouse; Owner: -
+--
+
+ALTER TABLE ONLY warehouse.category_inventory_defaults
+    ADD CONSTRAINT category_inventory_defaults_pkey PRIMARY KEY (category_id);
+
+
+--
+-- Name: category_rules category_rules_pkey; Type: CONSTRAINT; Schema: warehouse; Owner: -
+--
+
+ALTER TABLE ONLY warehouse.category_rules
+    ADD CONSTRAINT category_rules_pkey PRIMARY KEY (id);
+
+
 --
 -- Name: companies companies_pkey; Type: CONSTRAINT; Schema: warehouse; Owner: -
 --
@@ -1980,6 +2849,30 @@ ALTER TABLE ONLY warehouse.containers
     ADD CONSTRAINT containers_pkey PRIMARY KEY (id);
 
 
+--
+-- Name: currency_settings currency_settings_pkey; Type: CONSTRAINT; Schema: warehouse; Owner: -
+--
+
+ALTER TABLE ONLY warehouse.currency_settings
+    ADD CONSTRAINT currency_settings_pkey PRIMARY KEY (workspace_id);
+
+
+--
+-- Name: custom_fields custom_fields_pkey; Type: CONSTRAINT; Schema: warehouse; Owner: -
+--
+
+ALTER TABLE ONLY warehouse.custom_fields
+    ADD CONSTRAINT custom_fields_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: custom_fields custom_fields_workspace_id_name_key; Type: CONSTRAINT; Schema: warehouse; Owner: -
+--
+
+ALTER TABLE ONLY warehouse.custom_fields
+    ADD CONSTRAINT custom_fields_workspace_id_name_key UNIQUE (workspace_id, name);
+
+
 --
 -- Name: deleted_records deleted_records_pkey; Type: CONSTRAINT; Schema: warehouse; Owner: -
 --
@@ -2060,6 +2953,30 @@ ALTER TABLE ONLY warehouse.inventory_movements
     ADD CONSTRAINT inventory_movements_pkey PRIMARY KEY (id);
 
 
+--
+-- Name: inventory_settings inventory_settings_pkey; Type: CONSTRAINT; Schema: warehouse; Owner: -
+--
+
+ALTER TABLE ONLY warehouse.inventory_settings
+    ADD CONSTRAINT inventory_settings_pkey PRIMARY KEY (workspace_id);
+
+
+--
+-- Name: import_photo_results import_photo_results_pkey; Type: CONSTRAINT; Schema: warehouse; Owner: -
+--
+
+ALTER TABLE ONLY warehouse.import_photo_results
+    ADD CONSTRAINT import_photo_results_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: import_row_results import_row_results_pkey; Type: CONSTRAINT; Schema: warehouse; Owner: -
+--
+
+ALTER TABLE ONLY warehouse.import_row_results
+    ADD CONSTRAINT import_row_results_pkey PRIMARY KEY (id);
+
+
 --
 -- Name: inventory inventory_pkey; Type: CONSTRAINT; Schema: warehouse; Owner: -
 --
@@ -2068,6 +2985,38 @@ ALTER TABLE ONLY warehouse.inventory
     ADD CONSTRAINT inventory_pkey PRIMARY KEY (id);
 
 
+--
+-- Name: inventory_snapshots inventory_snapshots_pkey; Type: CONSTRAINT; Schema: warehouse; Owner: -
+--
+
+ALTER TABLE ONLY warehouse.inventory_snapshots
+    ADD CONSTRAINT inventory_snapshots_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: item_custom_values item_custom_values_pkey; Type: CONSTRAINT; Schema: warehouse; Owner: -
+--
+
+ALTER TABLE ONLY warehouse.item_custom_values
+    ADD CONSTRAINT item_custom_values_pkey PRIMARY KEY (item_id, field_id);
+
+
+--
+-- Name: item_identifiers item_identifiers_pkey; Type: CONSTRAINT; Schema: warehouse; Owner: -
+--
+
+ALTER TABLE ONLY warehouse.item_identifiers
+    ADD CONSTRAINT item_identifiers_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: item_identifiers uq_item_identifiers_ws_value; Type: CONSTRAINT; Schema: warehouse; Owner: -
+--
+
+ALTER TABLE ONLY warehouse.item_identifiers
+    ADD CONSTRAINT uq_item_identifiers_ws_value UNIQUE (workspace_id, value);
+
+
 --
 -- Name: item_labels item_labels_pkey; Type: CONSTRAINT; Schema: warehouse; Owner: -
 --
@@ -2076,6 +3025,14 @@ ALTER TABLE ONLY warehouse.item_labels
     ADD CONSTRAINT item_labels_pkey PRIMARY KEY (item_id, label_id);
 
 
+--
+-- Name: item_location_stock_levels item_location_stock_levels_pkey; Type: CONSTRAINT; Schema: warehouse; Owner: -
+--
+
+ALTER TABLE ONLY warehouse.item_location_stock_levels
+    ADD CONSTRAINT item_location_stock_levels_pkey PRIMARY KEY (item_id, location_id);
+
+
 --
 -- Name: item_photos item_photos_pkey; Type: CONSTRAINT; Schema: warehouse; Owner: -
 --
@@ -2084,6 +3041,22 @@ ALTER TABLE ONLY warehouse.item_photos
     ADD CONSTRAINT item_photos_pkey PRIMARY KEY (id);
 
 
+--
+-- Name: item_shares item_shares_pkey; Type: CONSTRAINT; Schema: warehouse; Owner: -
+--
+
+ALTER TABLE ONLY warehouse.item_shares
+    ADD CONSTRAINT item_shares_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: item_shares item_shares_token_hash_key; Type: CONSTRAINT; Schema: warehouse; Owner: -
+--
+
+ALTER TABLE ONLY warehouse.item_shares
+    ADD CONSTRAINT item_shares_token_hash_key UNIQUE (token_hash);
+
+
 --
 -- Name: items items_pkey; Type: CONSTRAINT; Schema: warehouse; Owner: -
 --
@@ -2116,6 +3089,14 @@ ALTER TABLE ONLY warehouse.labels
     ADD CONSTRAINT labels_workspace_id_name_key UNIQUE (workspace_id, name);
 
 
+--
+-- Name: loan_settings loan_settings_pkey; Type: CONSTRAINT; Schema: warehouse; Owner: -
+--
+
+ALTER TABLE ONLY warehouse.loan_settings
+    ADD CONSTRAINT loan_settings_pkey PRIMARY KEY (workspace_id);
+
+
 --
 -- Name: loans loans_pkey; Type: CONSTRAINT; Schema: warehouse; Owner: -
 --
@@ -2148,6 +3129,14 @@ ALTER TABLE ONLY warehouse.pending_changes
     ADD CONSTRAINT pending_changes_pkey PRIMARY KEY (id);
 
 
+--
+-- Name: photo_settings photo_settings_pkey; Type: CONSTRAINT; Schema: warehouse; Owner: -
+--
+
+ALTER TABLE ONLY warehouse.photo_settings
+    ADD CONSTRAINT photo_settings_pkey PRIMARY KEY (workspace_id);
+
+
 --
 -- Name: repair_attachments repair_attachments_pkey; Type: CONSTRAINT; Schema: warehouse; Owner: -
 --
@@ -2180,6 +3169,14 @@ ALTER TABLE ONLY warehouse.short_codes
     ADD CONSTRAINT short_codes_pkey PRIMARY KEY (code);
 
 
+--
+-- Name: trash_settings trash_settings_pkey; Type: CONSTRAINT; Schema: warehouse; Owner: -
+--
+
+ALTER TABLE ONLY warehouse.trash_settings
+    ADD CONSTRAINT trash_settings_pkey PRIMARY KEY (workspace_id);
+
+
 --
 -- Name: borrowers uq_borrowers_ws_id; Type: CONSTRAINT; Schema: warehouse; Owner: -
 --
@@ -2220,6 +3217,14 @@ ALTER TABLE ONLY warehouse.containers
     ADD CONSTRAINT uq_containers_ws_id UNIQUE (workspace_id, id);
 
 
+--
+-- Name: custom_fields uq_custom_fields_ws_id; Type: CONSTRAINT; Schema: warehouse; Owner: -
+--
+
+ALTER TABLE ONLY warehouse.custom_fields
+    ADD CONSTRAINT uq_custom_fields_ws_id UNIQUE (workspace_id, id);
+
+
 --
 -- Name: deleted_records uq_deleted_records_entity; Type: CONSTRAINT; Schema: warehouse; Owner: -
 --
@@ -2292,6 +3297,22 @@ ALTER TABLE ONLY warehouse.short_codes
     ADD CONSTRAINT uq_short_codes_entity UNIQUE (workspace_id, entity_type, entity_id);
 
 
+--
+-- Name: webhook_deliveries webhook_deliveries_pkey; Type: CONSTRAINT; Schema: warehouse; Owner: -
+--
+
+ALTER TABLE ONLY warehouse.webhook_deliveries
+    ADD CONSTRAINT webhook_deliveries_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: webhooks webhooks_pkey; Type: CONSTRAINT; Schema: warehouse; Owner: -
+--
+
+ALTER TABLE ONLY warehouse.webhooks
+    ADD CONSTRAINT webhooks_pkey PRIMARY KEY (id);
+
+
 --
 -- Name: wishlist_items uq_wishlist_items_ws_id; Type: CONSTRAINT; Schema: warehouse; Owner: -
 --
@@ -2322,6 +3343,27 @@ CREATE INDEX idx_user_sessions_expires_at ON auth.user_sessions USING btree (exp
 CREATE INDEX idx_user_sessions_user_id ON auth.user_sessions USING btree (user_id);
 
 
+--
+-- Name: ix_api_keys_workspace; Type: INDEX; Schema: auth; Owner: -
+--
+
+CREATE INDEX ix_api_keys_workspace ON auth.api_keys USING btree (workspace_id, created_at DESC);
+
+
+--
+-- Name: ix_auth_events_failed_email; Type: INDEX; Schema: auth; Owner: -
+--
+
+CREATE INDEX ix_auth_events_failed_email ON auth.auth_events USING btree (email, created_at) WHERE (NOT success);
+
+
+--
+-- Name: ix_auth_events_user_created; Type: INDEX; Schema: auth; Owner: -
+--
+
+CREATE INDEX ix_auth_events_user_created ON auth.auth_events USING btree (user_id, created_at DESC);
+
+
 --
 -- Name: ix_notifications_created; Type: INDEX; Schema: auth; Owner: -
 --
@@ -2392,6 +3434,13 @@ CREATE INDEX ix_push_subscriptions_endpoint ON auth.push_subscriptions USING btr
 CREATE INDEX ix_push_subscriptions_user ON auth.push_subscriptions USING btree (user_id);
 
 
+--
+-- Name: ix_user_recovery_codes_user; Type: INDEX; Schema: auth; Owner: -
+--
+
+CREATE INDEX ix_user_recovery_codes_user ON auth.user_recovery_codes USING btree (user_id);
+
+
 --
 -- Name: ix_workspace_exports_user; Type: INDEX; Schema: auth; Owner: -
 --
@@ -2469,6 +3518,34 @@ CREATE INDEX idx_import_jobs_user_id ON warehouse.import_jobs USING btree (user_
 CREATE INDEX idx_import_jobs_workspace_id ON warehouse.import_jobs USING btree (workspace_id);
 
 
+--
+-- Name: idx_import_photo_results_import_job_id; Type: INDEX; Schema: warehouse; Owner: -
+--
+
+CREATE INDEX idx_import_photo_results_import_job_id ON warehouse.import_photo_results USING btree (import_job_id);
+
+
+--
+-- Name: idx_import_row_results_import_job_id; Type: INDEX; Schema: warehouse; Owner: -
+--
+
+CREATE INDEX idx_import_row_results_import_job_id ON warehouse.import_row_results USING btree (import_job_id);
+
+
+--
+-- Name: idx_item_custom_values_field; Type: INDEX; Schema: warehouse; Owner: -
+--
+
+CREATE INDEX idx_item_custom_values_field ON warehouse.item_custom_values USING btree (field_id);
+
+
+--
+-- Name: idx_item_photos_inventory; Type: INDEX; Schema: warehouse; Owner: -
+--
+
+CREATE INDEX idx_item_photos_inventory ON warehouse.item_photos USING btree (inventory_id, display_order) WHERE (inventory_id IS NOT NULL);
+
+
 --
 -- Name: idx_item_photos_item; Type: INDEX; Schema: warehouse; Owner: -
 --
@@ -2476,6 +3553,13 @@ CREATE INDEX idx_import_jobs_workspace_id ON warehouse.import_jobs USING btree (
 CREATE INDEX idx_item_photos_item ON warehouse.item_photos USING btree (item_id, display_order);
 
 
+--
+-- Name: idx_item_photos_item_content_hash; Type: INDEX; Schema: warehouse; Owner: -
+--
+
+CREATE INDEX idx_item_photos_item_content_hash ON warehouse.item_photos USING btree (item_id, content_hash) WHERE (content_hash IS NOT NULL);
+
+
 --
 -- Name: idx_item_photos_perceptual_hash; Type: INDEX; Schema: warehouse; Owner: -
 --
@@ -2546,6 +3630,20 @@ CREATE INDEX idx_repair_photos_repair ON warehouse.repair_photos USING btree (re
 CREATE INDEX idx_repair_photos_workspace ON warehouse.repair_photos USING btree (workspace_id);
 
 
+--
+-- Name: idx_webhook_deliveries_webhook_created; Type: INDEX; Schema: warehouse; Owner: -
+--
+
+CREATE INDEX idx_webhook_deliveries_webhook_created ON warehouse.webhook_deliveries USING btree (webhook_id, created_at DESC);
+
+
+--
+-- Name: idx_webhooks_workspace; Type: INDEX; Schema: warehouse; Owner: -
+--
+
+CREATE INDEX idx_webhooks_workspace ON warehouse.webhooks USING btree (workspace_id);
+
+
 --
 -- Name: ix_activity_log_created; Type: INDEX; Schema: warehouse; Owner: -
 --
@@ -2644,6 +3742,13 @@ CREATE INDEX ix_categories_active ON warehouse.categories USING btree (workspace
 CREATE INDEX ix_categories_parent ON warehouse.categories USING btree (parent_category_id);
 
 
+--
+-- Name: ix_category_rules_workspace_position; Type: INDEX; Schema: warehouse; Owner: -
+--
+
+CREATE INDEX ix_category_rules_workspace_position ON warehouse.category_rules USING btree (workspace_id, "position");
+
+
 --
 -- Name: ix_companies_active; Type: INDEX; Schema: warehouse; Owner: -
 --
@@ -2805,6 +3910,13 @@ CREATE INDEX ix_inventory_movements_workspace ON warehouse.inventory_movements U
 CREATE INDEX ix_inventory_movements_ws_created ON warehouse.inventory_movements USING btree (workspace_id, created_at DESC);
 
 
+--
+-- Name: ix_inventory_snapshots_workspace_created; Type: INDEX; Schema: warehouse; Owner: -
+--
+
+CREATE INDEX ix_inventory_snapshots_workspace_created ON warehouse.inventory_snapshots USING btree (workspace_id, created_at DESC);
+
+
 --
 -- Name: ix_inventory_workspace; Type: INDEX; Schema: warehouse; Owner: -
 --
@@ -2812,6 +3924,13 @@ CREATE INDEX ix_inventory_movements_ws_created ON warehouse.inventory_movements
 CREATE INDEX ix_inventory_workspace ON warehouse.inventory USING btree (workspace_id);
 
 
+--
+-- Name: ix_item_identifiers_item; Type: INDEX; Schema: warehouse; Owner: -
+--
+
+CREATE INDEX ix_item_identifiers_item ON warehouse.item_identifiers USING btree (item_id);
+
+
 --
 -- Name: ix_item_labels_workspace; Type: INDEX; Schema: warehouse; Owner: -
 --
@@ -2819,6 +3938,20 @@ CREATE INDEX ix_inventory_workspace ON warehouse.inventory USING btree (workspac
 CREATE INDEX ix_item_labels_workspace ON warehouse.item_labels USING btree (workspace_id);
 
 
+--
+-- Name: ix_item_location_stock_levels_workspace; Type: INDEX; Schema: warehouse; Owner: -
+--
+
+CREATE INDEX ix_item_location_stock_levels_workspace ON warehouse.item_location_stock_levels USING btree (workspace_id);
+
+
+--
+-- Name: ix_item_shares_item; Type: INDEX; Schema: warehouse; Owner: -
+--
+
+CREATE INDEX ix_item_shares_item ON warehouse.item_shares USING btree (workspace_id, item_id, created_at DESC);
+
+
 --
 -- Name: ix_items_active; Type: INDEX; Schema: warehouse; Owner: -
 --
@@ -3050,6 +4183,30 @@ CREATE TRIGGER trg_validate_loan_quantity BEFORE INSERT OR UPDATE ON warehouse.l
 CREATE TRIGGER trgr_borrowers_search_vector BEFORE INSERT OR UPDATE ON warehouse.borrowers FOR EACH ROW EXECUTE FUNCTION warehouse.update_borrower_search_vector();
 
 
+--
+-- Name: api_keys api_keys_created_by_fkey; Type: FK CONSTRAINT; Schema: auth; Owner: -
+--
+
+ALTER TABLE ONLY auth.api_keys
+    ADD CONSTRAINT api_keys_created_by_fkey FOREIGN KEY (created_by) REFERENCES auth.users(id) ON DELETE CASCADE;
+
+
+--
+-- Name: api_keys api_keys_workspace_id_fkey; Type: FK CONSTRAINT; Schema: auth; Owner: -
+--
+
+ALTER TABLE ONLY auth.api_keys
+    ADD CONSTRAINT api_keys_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES auth.workspaces(id) ON DELETE CASCADE;
+
+
+--
+-- Name: auth_events auth_events_user_id_fkey; Type: FK CONSTRAINT; Schema: auth; Owner: -
+--
+
+ALTER TABLE ONLY auth.auth_events
+    ADD CONSTRAINT auth_events_user_id_fkey FOREIGN KEY (user_id) REFERENCES auth.users(id) ON DELETE CASCADE;
+
+
 --
 -- Name: notifications notifications_user_id_fkey; Type: FK CONSTRAINT; Schema: auth; Owner: -
 --
@@ -3090,6 +4247,14 @@ ALTER TABLE ONLY auth.user_oauth_accounts
     ADD CONSTRAINT user_oauth_accounts_user_id_fkey FOREIGN KEY (user_id) REFERENCES auth.users(id) ON DELETE CASCADE;
 
 
+--
+-- Name: user_recovery_codes user_recovery_codes_user_id_fkey; Type: FK CONSTRAINT; Schema: auth; Owner: -
+--
+
+ALTER TABLE ONLY auth.user_recovery_codes
+    ADD CONSTRAINT user_recovery_codes_user_id_fkey FOREIGN KEY (user_id) REFERENCES auth.user_two_factor(user_id) ON DELETE CASCADE;
+
+
 --
 -- Name: user_sessions user_sessions_user_id_fkey; Type: FK CONSTRAINT; Schema: auth; Owner: -
 --
@@ -3098,6 +4263,14 @@ ALTER TABLE ONLY auth.user_sessions
     ADD CONSTRAINT user_sessions_user_id_fkey FOREIGN KEY (user_id) REFERENCES auth.users(id) ON DELETE CASCADE;
 
 
+--
+-- Name: user_two_factor user_two_factor_user_id_fkey; Type: FK CONSTRAINT; Schema: auth; Owner: -
+--
+
+ALTER TABLE ONLY auth.user_two_factor
+    ADD CONSTRAINT user_two_factor_user_id_fkey FOREIGN KEY (user_id) REFERENCES auth.users(id) ON DELETE CASCADE;
+
+
 --
 -- Name: workspace_exports workspace_exports_exported_by_fkey; Type: FK CONSTRAINT; Schema: auth; Owner: -
 --
@@ -3210,6 +4383,22 @@ ALTER TABLE ONLY warehouse.categories
     ADD CONSTRAINT categories_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES auth.workspaces(id) ON DELETE CASCADE;
 
 
+--
+-- Name: category_inventory_defaults category_inventory_defaults_category_fk; Type: FK CONSTRAINT; Schema: warehouse; Owner: -
+--
+
+ALTER TABLE ONLY warehouse.category_inventory_defaults
+    ADD CONSTRAINT category_inventory_defaults_category_fk FOREIGN KEY (workspace_id, category_id) REFERENCES warehouse.categories(workspace_id, id) ON DELETE CASCADE;
+
+
+--
+-- Name: category_rules category_rules_category_fk; Type: FK CONSTRAINT; Schema: warehouse; Owner: -
+--
+
+ALTER TABLE ONLY warehouse.category_rules
+    ADD CONSTRAINT category_rules_category_fk FOREIGN KEY (workspace_id, category_id) REFERENCES warehouse.categories(workspace_id, id) ON DELETE CASCADE;
+
+
 --
 -- Name: companies companies_workspace_id_fkey; Type: FK CONSTRAINT; Schema: warehouse; Owner: -
 --
@@ -3250,6 +4439,22 @@ ALTER TABLE ONLY warehouse.containers
     ADD CONSTRAINT containers_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES auth.workspaces(id) ON DELETE CASCADE;
 
 
+--
+-- Name: currency_settings currency_settings_workspace_id_fkey; Type: FK CONSTRAINT; Schema: warehouse; Owner: -
+--
+
+ALTER TABLE ONLY warehouse.currency_settings
+    ADD CONSTRAINT currency_settings_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES auth.workspaces(id) ON DELETE CASCADE;
+
+
+--
+-- Name: custom_fields custom_fields_workspace_id_fkey; Type: FK CONSTRAINT; Schema: warehouse; Owner: -
+--
+
+ALTER TABLE ONLY warehouse.custom_fields
+    ADD CONSTRAINT custom_fields_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES auth.workspaces(id) ON DELETE CASCADE;
+
+
 --
 -- Name: deleted_records deleted_records_deleted_by_fkey; Type: FK CONSTRAINT; Schema: warehouse; Owner: -
 --
@@ -3354,6 +4559,22 @@ ALTER TABLE ONLY warehouse.import_jobs
     ADD CONSTRAINT import_jobs_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES auth.workspaces(id) ON DELETE CASCADE;
 
 
+--
+-- Name: import_photo_results import_photo_results_import_job_id_fkey; Type: FK CONSTRAINT; Schema: warehouse; Owner: -
+--
+
+ALTER TABLE ONLY warehouse.import_photo_results
+    ADD CONSTRAINT import_photo_results_import_job_id_fkey FOREIGN KEY (import_job_id) REFERENCES warehouse.import_jobs(id) ON DELETE CASCADE;
+
+
+--
+-- Name: import_row_results import_row_results_import_job_id_fkey; Type: FK CONSTRAINT; Schema: warehouse; Owner: -
+--
+
+ALTER TABLE ONLY warehouse.import_row_results
+    ADD CONSTRAINT import_row_results_import_job_id_fkey FOREIGN KEY (import_job_id) REFERENCES warehouse.import_jobs(id) ON DELETE CASCADE;
+
+
 --
 -- Name: inventory inventory_container_fk; Type: FK CONSTRAINT; Schema: warehouse; Owner: -
 --
@@ -3434,6 +4655,22 @@ ALTER TABLE ONLY warehouse.inventory_movements
     ADD CONSTRAINT inventory_movements_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES auth.workspaces(id) ON DELETE CASCADE;
 
 
+--
+-- Name: inventory_settings inventory_settings_workspace_id_fkey; Type: FK CONSTRAINT; Schema: warehouse; Owner: -
+--
+
+ALTER TABLE ONLY warehouse.inventory_settings
+    ADD CONSTRAINT inventory_settings_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES auth.workspaces(id) ON DELETE CASCADE;
+
+
+--
+-- Name: inventory_snapshots inventory_snapshots_workspace_id_fkey; Type: FK CONSTRAINT; Schema: warehouse; Owner: -
+--
+
+ALTER TABLE ONLY warehouse.inventory_snapshots
+    ADD CONSTRAINT inventory_snapshots_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES auth.workspaces(id) ON DELETE CASCADE;
+
+
 --
 -- Name: inventory inventory_workspace_id_fkey; Type: FK CONSTRAINT; Schema: warehouse; Owner: -
 --
@@ -3442,6 +4679,46 @@ ALTER TABLE ONLY warehouse.inventory
     ADD CONSTRAINT inventory_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES auth.workspaces(id) ON DELETE CASCADE;
 
 
+--
+-- Name: item_custom_values item_custom_values_field_fk; Type: FK CONSTRAINT; Schema: warehouse; Owner: -
+--
+
+ALTER TABLE ONLY warehouse.item_custom_values
+    ADD CONSTRAINT item_custom_values_field_fk FOREIGN KEY (workspace_id, field_id) REFERENCES warehouse.custom_fields(workspace_id, id) ON DELETE CASCADE;
+
+
+--
+-- Name: item_custom_values item_custom_values_item_fk; Type: FK CONSTRAINT; Schema: warehouse; Owner: -
+--
+
+ALTER TABLE ONLY warehouse.item_custom_values
+    ADD CONSTRAINT item_custom_values_item_fk FOREIGN KEY (workspace_id, item_id) REFERENCES warehouse.items(workspace_id, id) ON DELETE CASCADE;
+
+
+--
+-- Name: item_custom_values item_custom_values_workspace_id_fkey; Type: FK CONSTRAINT; Schema: warehouse; Owner: -
+--
+
+ALTER TABLE ONLY warehouse.item_custom_values
+    ADD CONSTRAINT item_custom_values_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES auth.workspaces(id) ON DELETE CASCADE;
+
+
+--
+-- Name: item_identifiers item_identifiers_item_fk; Type: FK CONSTRAINT; Schema: warehouse; Owner: -
+--
+
+ALTER TABLE ONLY warehouse.item_identifiers
+    ADD CONSTRAINT item_identifiers_item_fk FOREIGN KEY (workspace_id, item_id) REFERENCES warehouse.items(workspace_id, id) ON DELETE CASCADE;
+
+
+--
+-- Name: item_identifiers item_identifiers_workspace_id_fkey; Type: FK CONSTRAINT; Schema: warehouse; Owner: -
+--
+
+ALTER TABLE ONLY warehouse.item_identifiers
+    ADD CONSTRAINT item_identifiers_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES auth.workspaces(id) ON DELETE CASCADE;
+
+
 --
 -- Name: item_labels item_labels_item_fk; Type: FK CONSTRAINT; Schema: warehouse; Owner: -
 --
@@ -3466,6 +4743,38 @@ ALTER TABLE ONLY warehouse.item_labels
     ADD CONSTRAINT item_labels_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES auth.workspaces(id) ON DELETE CASCADE;
 
 
+--
+-- Name: item_location_stock_levels item_location_stock_levels_item_fk; Type: FK CONSTRAINT; Schema: warehouse; Owner: -
+--
+
+ALTER TABLE ONLY warehouse.item_location_stock_levels
+    ADD CONSTRAINT item_location_stock_levels_item_fk FOREIGN KEY (workspace_id, item_id) REFERENCES warehouse.items(workspace_id, id) ON DELETE CASCADE;
+
+
+--
+-- Name: item_location_stock_levels item_location_stock_levels_location_fk; Type: FK CONSTRAINT; Schema: warehouse; Owner: -
+--
+
+ALTER TABLE ONLY warehouse.item_location_stock_levels
+    ADD CONSTRAINT item_location_stock_levels_location_fk FOREIGN KEY (workspace_id, location_id) REFERENCES warehouse.locations(workspace_id, id) ON DELETE CASCADE;
+
+
+--
+-- Name: item_location_stock_levels item_location_stock_levels_workspace_id_fkey; Type: FK CONSTRAINT; Schema: warehouse; Owner: -
+--
+
+ALTER TABLE ONLY warehouse.item_location_stock_levels
+    ADD CONSTRAINT item_location_stock_levels_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES auth.workspaces(id) ON DELETE CASCADE;
+
+
+--
+-- Name: item_photos item_photos_inventory_fk; Type: FK CONSTRAINT; Schema: warehouse; Owner: -
+--
+
+ALTER TABLE ONLY warehouse.item_photos
+    ADD CONSTRAINT item_photos_inventory_fk FOREIGN KEY (workspace_id, inventory_id) REFERENCES warehouse.inventory(workspace_id, id) ON DELETE SET NULL (inventory_id);
+
+
 --
 -- Name: item_photos item_photos_item_fk; Type: FK CONSTRAINT; Schema: warehouse; Owner: -
 --
@@ -3490,6 +4799,30 @@ ALTER TABLE ONLY warehouse.item_photos
     ADD CONSTRAINT item_photos_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES auth.workspaces(id) ON DELETE CASCADE;
 
 
+--
+-- Name: item_shares item_shares_created_by_fkey; Type: FK CONSTRAINT; Schema: warehouse; Owner: -
+--
+
+ALTER TABLE ONLY warehouse.item_shares
+    ADD CONSTRAINT item_shares_created_by_fkey FOREIGN KEY (created_by) REFERENCES auth.users(id) ON DELETE SET NULL;
+
+
+--
+-- Name: item_shares item_shares_item_id_fkey; Type: FK CONSTRAINT; Schema: warehouse; Owner: -
+--
+
+ALTER TABLE ONLY warehouse.item_shares
+    ADD CONSTRAINT item_shares_item_id_fkey FOREIGN KEY (item_id) REFERENCES warehouse.items(id) ON DELETE CASCADE;
+
+
+--
+-- Name: item_shares item_shares_workspace_id_fkey; Type: FK CONSTRAINT; Schema: warehouse; Owner: -
+--
+
+ALTER TABLE ONLY warehouse.item_shares
+    ADD CONSTRAINT item_shares_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES auth.workspaces(id) ON DELETE CASCADE;
+
+
 --
 -- Name: items items_category_fk; Type: FK CONSTRAINT; Schema: warehouse; Owner: -
 --
@@ -3522,6 +4855,14 @@ ALTER TABLE ONLY warehouse.labels
     ADD CONSTRAINT labels_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES auth.workspaces(id) ON DELETE CASCADE;
 
 
+--
+-- Name: loan_settings loan_settings_workspace_id_fkey; Type: FK CONSTRAINT; Schema: warehouse; Owner: -
+--
+
+ALTER TABLE ONLY warehouse.loan_settings
+    ADD CONSTRAINT loan_settings_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES auth.workspaces(id) ON DELETE CASCADE;
+
+
 --
 -- Name: loans loans_borrower_fk; Type: FK CONSTRAINT; Schema: warehouse; Owner: -
 --
@@ -3602,6 +4943,14 @@ ALTER TABLE ONLY warehouse.pending_changes
     ADD CONSTRAINT pending_changes_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES auth.workspaces(id) ON DELETE CASCADE;
 
 
+--
+-- Name: photo_settings photo_settings_workspace_id_fkey; Type: FK CONSTRAINT; Schema: warehouse; Owner: -
+--
+
+ALTER TABLE ONLY warehouse.photo_settings
+    ADD CONSTRAINT photo_settings_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES auth.workspaces(id) ON DELETE CASCADE;
+
+
 --
 -- Name: repair_attachments repair_attachments_file_fk; Type: FK CONSTRAINT; Schema: warehouse; Owner: -
 --
@@ -3674,6 +5023,38 @@ ALTER TABLE ONLY warehouse.short_codes
     ADD CONSTRAINT short_codes_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES auth.workspaces(id) ON DELETE CASCADE;
 
 
+--
+-- Name: trash_settings trash_settings_workspace_id_fkey; Type: FK CONSTRAINT; Schema: warehouse; Owner: -
+--
+
+ALTER TABLE ONLY warehouse.trash_settings
+    ADD CONSTRAINT trash_settings_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES auth.workspaces(id) ON DELETE CASCADE;
+
+
+--
+-- Name: webhook_deliveries webhook_deliveries_webhook_id_fkey; Type: FK CONSTRAINT; Schema: warehouse; Owner: -
+--
+
+ALTER TABLE ONLY warehouse.webhook_deliveries
+    ADD CONSTRAINT webhook_deliveries_webhook_id_fkey FOREIGN KEY (webhook_id) REFERENCES warehouse.webhooks(id) ON DELETE CASCADE;
+
+
+--
+-- Name: webhooks webhooks_created_by_fkey; Type: FK CONSTRAINT; Schema: warehouse; Owner: -
+--
+
+ALTER TABLE ONLY warehouse.webhooks
+    ADD CONSTRAINT webhooks_created_by_fkey FOREIGN KEY (created_by) REFERENCES auth.users(id) ON DELETE SET NULL;
+
+
+--
+-- Name: webhooks webhooks_workspace_id_fkey; Type: FK CONSTRAINT; Schema: warehouse; Owner: -
+--
+
+ALTER TABLE ONLY warehouse.webhooks
+    ADD CONSTRAINT webhooks_workspace_id_fkey FOREIGN KEY (workspace_id) REFERENCES auth.workspaces(id) ON DELETE CASCADE;
+
+
 --
 -- Name: wishlist_items wishlist_items_acquired_item_fk; Type: FK CONSTRAINT; Schema: warehouse; Owner: -
 --
@@ -3726,4 +5107,34 @@ INSERT INTO public.schema_migrations (version) VALUES
     ('006'),
     ('007'),
     ('008'),
-    ('009');
+    ('009'),
+    ('010'),
+    ('011'),
+    ('012'),
+    ('013'),
+    ('014'),
+    ('015'),
+    ('016'),
+    ('017'),
+    ('018'),
+    ('019'),
+    ('020'),
+    ('021'),
+    ('022'),
+    ('023'),
+    ('024'),
+    ('025'),
+    ('026'),
+    ('027'),
+    ('028'),
+    ('029'),
+    ('030'),
+    ('031'),
+    ('032'),
+    ('033'),
+    ('034'),
+    ('035'),
+    ('036'),
+    ('037'),
+    ('038'),
+    ('039');
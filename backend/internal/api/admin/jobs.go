@@ -0,0 +1,125 @@
+// Package admin provides system-administration endpoints that aren't tied to
+// a workspace. Every route requires a superuser.
+package admin
+
+import (
+	"context"
+	"errors"
+	"log"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	appMiddleware "github.com/antti/home-warehouse/go-backend/internal/api/middleware"
+	"github.com/antti/home-warehouse/go-backend/internal/jobs"
+)
+
+const (
+	msgNotAuthenticated        = "not authenticated"
+	msgSuperuserAccessRequired = "superuser access required"
+)
+
+// JobRunner enqueues a scheduled job immediately.
+type JobRunner interface {
+	RunNow(name string) (string, error)
+}
+
+// jobTriggerRunner adapts jobs.JobTrigger to JobRunner.
+type jobTriggerRunner struct {
+	trigger *jobs.JobTrigger
+}
+
+// NewJobRunner wraps a jobs.JobTrigger for the admin job routes.
+func NewJobRunner(trigger *jobs.JobTrigger) JobRunner {
+	return jobTriggerRunner{trigger: trigger}
+}
+
+func (r jobTriggerRunner) RunNow(name string) (string, error) {
+	info, err := r.trigger.RunNow(name)
+	if err != nil {
+		return "", err
+	}
+	return info.ID, nil
+}
+
+// RegisterJobRoutes registers the scheduled job listing and "run now"
+// routes. Scheduled jobs are system-wide (reminders go out for every
+// workspace), so these are superuser-only rather than workspace-admin.
+func RegisterJobRoutes(api huma.API, runner JobRunner) {
+	huma.Get(api, "/admin/jobs", func(ctx context.Context, input *struct{}) (*ListJobsOutput, error) {
+		if err := requireSuperuser(ctx); err != nil {
+			return nil, err
+		}
+
+		scheduled := jobs.ScheduledJobs()
+		items := make([]JobResponse, len(scheduled))
+		for i, job := range scheduled {
+			items[i] = JobResponse{
+				Name:        job.Name,
+				Description: job.Description,
+				Cron:        job.Cronspec,
+				Schedule:    job.ScheduleText,
+				Queue:       job.Queue,
+			}
+		}
+		return &ListJobsOutput{Body: ListJobsResponse{Items: items}}, nil
+	})
+
+	huma.Post(api, "/admin/jobs/{name}/run", func(ctx context.Context, input *RunJobInput) (*RunJobOutput, error) {
+		if err := requireSuperuser(ctx); err != nil {
+			return nil, err
+		}
+
+		taskID, err := runner.RunNow(input.Name)
+		if errors.Is(err, jobs.ErrUnknownJob) {
+			return nil, huma.Error404NotFound("scheduled job not found")
+		}
+		if err != nil {
+			log.Printf("Failed to enqueue job %s: %v", input.Name, err)
+			return nil, huma.Error503ServiceUnavailable("failed to enqueue job")
+		}
+
+		return &RunJobOutput{Body: RunJobResponse{Job: input.Name, TaskID: taskID}}, nil
+	})
+}
+
+func requireSuperuser(ctx context.Context) error {
+	authUser, ok := appMiddleware.GetAuthUser(ctx)
+	if !ok {
+		return huma.Error401Unauthorized(msgNotAuthenticated)
+	}
+	if !authUser.IsSuperuser {
+		return huma.Error403Forbidden(msgSuperuserAccessRequired)
+	}
+	return nil
+}
+
+// Request/Response types
+
+type ListJobsOutput struct {
+	Body ListJobsResponse
+}
+
+type ListJobsResponse struct {
+	Items []JobResponse `json:"items"`
+}
+
+type JobResponse struct {
+	Name        string `json:"name" doc:"Identifier to pass to the run endpoint"`
+	Description string `json:"description"`
+	Cron        string `json:"cron" doc:"Cron expression the scheduler runs this job on"`
+	Schedule    string `json:"schedule" doc:"Human-readable schedule"`
+	Queue       string `json:"queue"`
+}
+
+type RunJobInput struct {
+	Name string `path:"name" doc:"Scheduled job name, e.g. loan-reminders"`
+}
+
+type RunJobOutput struct {
+	Body RunJobResponse
+}
+
+type RunJobResponse struct {
+	Job    string `json:"job"`
+	TaskID string `json:"task_id" doc:"ID of the enqueued task"`
+}
@@ -0,0 +1,89 @@
+package admin_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/api/admin"
+	"github.com/antti/home-warehouse/go-backend/internal/jobs"
+	"github.com/antti/home-warehouse/go-backend/internal/testutil"
+)
+
+// fakeRunner records triggered job names.
+type fakeRunner struct {
+	ran []string
+	err error
+}
+
+func (f *fakeRunner) RunNow(name string) (string, error) {
+	if f.err != nil {
+		return "", f.err
+	}
+	f.ran = append(f.ran, name)
+	return "task-" + name, nil
+}
+
+func TestJobRoutes_List(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	setup.MakeSuperuser()
+	admin.RegisterJobRoutes(setup.API, &fakeRunner{})
+
+	rec := setup.Get("/admin/jobs")
+
+	testutil.AssertStatus(t, rec, http.StatusOK)
+	resp := testutil.ParseJSONResponse[admin.ListJobsResponse](t, rec)
+	assert.Len(t, resp.Items, len(jobs.ScheduledJobs()))
+	assert.Equal(t, "loan-reminders", resp.Items[0].Name)
+}
+
+func TestJobRoutes_Run(t *testing.T) {
+	t.Run("superuser triggers job", func(t *testing.T) {
+		setup := testutil.NewHandlerTestSetup()
+		setup.MakeSuperuser()
+		runner := &fakeRunner{}
+		admin.RegisterJobRoutes(setup.API, runner)
+
+		rec := setup.Post("/admin/jobs/loan-reminders/run", "")
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		resp := testutil.ParseJSONResponse[admin.RunJobResponse](t, rec)
+		assert.Equal(t, "loan-reminders", resp.Job)
+		assert.Equal(t, "task-loan-reminders", resp.TaskID)
+		assert.Equal(t, []string{"loan-reminders"}, runner.ran)
+	})
+
+	t.Run("non-superuser is forbidden", func(t *testing.T) {
+		setup := testutil.NewHandlerTestSetup()
+		runner := &fakeRunner{}
+		admin.RegisterJobRoutes(setup.API, runner)
+
+		rec := setup.Post("/admin/jobs/loan-reminders/run", "")
+
+		testutil.AssertStatus(t, rec, http.StatusForbidden)
+		require.Empty(t, runner.ran)
+	})
+
+	t.Run("unknown job", func(t *testing.T) {
+		setup := testutil.NewHandlerTestSetup()
+		setup.MakeSuperuser()
+		admin.RegisterJobRoutes(setup.API, &fakeRunner{err: jobs.ErrUnknownJob})
+
+		rec := setup.Post("/admin/jobs/nope/run", "")
+
+		testutil.AssertStatus(t, rec, http.StatusNotFound)
+	})
+
+	t.Run("enqueue failure", func(t *testing.T) {
+		setup := testutil.NewHandlerTestSetup()
+		setup.MakeSuperuser()
+		admin.RegisterJobRoutes(setup.API, &fakeRunner{err: errors.New("redis down")})
+
+		rec := setup.Post("/admin/jobs/loan-reminders/run", "")
+
+		testutil.AssertStatus(t, rec, http.StatusServiceUnavailable)
+	})
+}
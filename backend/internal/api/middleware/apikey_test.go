@@ -0,0 +1,214 @@
+package middleware
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/shared/jwt"
+)
+
+// fakeAPIKeyAuthenticator knows one key; every other key is invalid.
+type fakeAPIKeyAuthenticator struct {
+	key       string
+	principal *APIKeyPrincipal
+	err       error // returned for key instead of principal
+}
+
+func (f fakeAPIKeyAuthenticator) AuthenticateAPIKey(_ context.Context, key string) (*APIKeyPrincipal, error) {
+	if key != f.key {
+		return nil, ErrAPIKeyInvalid
+	}
+	if f.err != nil {
+		return nil, f.err
+	}
+	return f.principal, nil
+}
+
+// apiKeyChain is the production order of the protected routes: APIKeyAuth,
+// JWTAuth, then Workspace on the workspace routes.
+func apiKeyChain(authn APIKeyAuthenticator, memberRepo MemberRepository, next http.HandlerFunc) http.Handler {
+	r := chi.NewRouter()
+	r.Use(APIKeyAuth(authn))
+	r.Use(JWTAuth(jwt.NewService("test-secret", 24)))
+	r.Get("/users/me", next)
+	r.Route("/workspaces/{workspace_id}", func(r chi.Router) {
+		r.Use(Workspace(memberRepo))
+		r.Get("/items", next)
+	})
+	return r
+}
+
+func TestAPIKeyAuth(t *testing.T) {
+	workspaceID := uuid.New()
+	ownerID := uuid.New()
+	principal := &APIKeyPrincipal{
+		ID:          uuid.New(),
+		WorkspaceID: workspaceID,
+		Name:        "Home Assistant",
+		Role:        "viewer",
+		CreatedBy:   ownerID,
+	}
+	memberRepo := newMockMemberRepo()
+	memberRepo.addMember(workspaceID, ownerID, "owner")
+
+	tests := []struct {
+		name        string
+		authn       fakeAPIKeyAuthenticator
+		path        string
+		header      string
+		wantStatus  int
+		wantMessage string
+	}{
+		{
+			name:       "valid key acts with the key's role",
+			authn:      fakeAPIKeyAuthenticator{key: "hwk_valid", principal: principal},
+			path:       "/workspaces/" + workspaceID.String() + "/items",
+			header:     "ApiKey hwk_valid",
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:        "expired key",
+			authn:       fakeAPIKeyAuthenticator{key: "hwk_valid", err: ErrAPIKeyExpired},
+			path:        "/workspaces/" + workspaceID.String() + "/items",
+			header:      "ApiKey hwk_valid",
+			wantStatus:  http.StatusUnauthorized,
+			wantMessage: "API key has expired",
+		},
+		{
+			name:        "revoked key",
+			authn:       fakeAPIKeyAuthenticator{key: "hwk_valid", err: ErrAPIKeyRevoked},
+			path:        "/workspaces/" + workspaceID.String() + "/items",
+			header:      "ApiKey hwk_valid",
+			wantStatus:  http.StatusUnauthorized,
+			wantMessage: "API key has been revoked",
+		},
+		{
+			name:        "unknown key",
+			authn:       fakeAPIKeyAuthenticator{key: "hwk_valid", principal: principal},
+			path:        "/workspaces/" + workspaceID.String() + "/items",
+			header:      "ApiKey hwk_other",
+			wantStatus:  http.StatusUnauthorized,
+			wantMessage: "invalid API key",
+		},
+		{
+			name:       "lookup failure",
+			authn:      fakeAPIKeyAuthenticator{key: "hwk_valid", err: errors.New("db down")},
+			path:       "/workspaces/" + workspaceID.String() + "/items",
+			header:     "ApiKey hwk_valid",
+			wantStatus: http.StatusInternalServerError,
+		},
+		{
+			name:        "another workspace",
+			authn:       fakeAPIKeyAuthenticator{key: "hwk_valid", principal: principal},
+			path:        "/workspaces/" + uuid.NewString() + "/items",
+			header:      "ApiKey hwk_valid",
+			wantStatus:  http.StatusForbidden,
+			wantMessage: "API keys can only access their own workspace",
+		},
+		{
+			name:       "user-level route",
+			authn:      fakeAPIKeyAuthenticator{key: "hwk_valid", principal: principal},
+			path:       "/users/me",
+			header:     "ApiKey hwk_valid",
+			wantStatus: http.StatusForbidden,
+		},
+		{
+			name:        "bearer requests are left to JWTAuth",
+			authn:       fakeAPIKeyAuthenticator{key: "hwk_valid", principal: principal},
+			path:        "/workspaces/" + workspaceID.String() + "/items",
+			header:      "Bearer not-a-jwt",
+			wantStatus:  http.StatusUnauthorized,
+			wantMessage: "invalid token",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var role string
+			var user *AuthUser
+			handler := apiKeyChain(tt.authn, memberRepo, func(w http.ResponseWriter, r *http.Request) {
+				role, _ = GetRole(r.Context())
+				user, _ = GetAuthUser(r.Context())
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set("Authorization", tt.header)
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			if tt.wantMessage != "" {
+				assert.Contains(t, rec.Body.String(), tt.wantMessage)
+			}
+			if tt.wantStatus == http.StatusOK {
+				assert.Equal(t, "viewer", role)
+				require.NotNil(t, user)
+				assert.Equal(t, ownerID, user.ID)
+			}
+		})
+	}
+}
+
+func TestAPIKeyAuth_CreatorLeftWorkspace(t *testing.T) {
+	workspaceID := uuid.New()
+	authn := fakeAPIKeyAuthenticator{key: "hwk_valid", principal: &APIKeyPrincipal{
+		ID: uuid.New(), WorkspaceID: workspaceID, Role: "member", CreatedBy: uuid.New(),
+	}}
+	handler := apiKeyChain(authn, newMockMemberRepo(), func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/workspaces/"+workspaceID.String()+"/items", nil)
+	req.Header.Set("Authorization", "ApiKey hwk_valid")
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestAPIKeyAuth_RoleCappedByCreator(t *testing.T) {
+	workspaceID := uuid.New()
+	creatorID := uuid.New()
+
+	tests := []struct {
+		name        string
+		keyRole     string
+		creatorRole string
+		wantRole    string
+	}{
+		{"creator demoted below the key", "admin", "viewer", "viewer"},
+		{"creator still outranks the key", "member", "owner", "member"},
+		{"same role", "admin", "admin", "admin"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			memberRepo := newMockMemberRepo()
+			memberRepo.addMember(workspaceID, creatorID, tt.creatorRole)
+			authn := fakeAPIKeyAuthenticator{key: "hwk_valid", principal: &APIKeyPrincipal{
+				ID: uuid.New(), WorkspaceID: workspaceID, Role: tt.keyRole, CreatedBy: creatorID,
+			}}
+			var role string
+			handler := apiKeyChain(authn, memberRepo, func(w http.ResponseWriter, r *http.Request) {
+				role, _ = GetRole(r.Context())
+				w.WriteHeader(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/workspaces/"+workspaceID.String()+"/items", nil)
+			req.Header.Set("Authorization", "ApiKey hwk_valid")
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Equal(t, tt.wantRole, role)
+		})
+	}
+}
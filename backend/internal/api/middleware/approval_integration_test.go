@@ -299,6 +299,116 @@ func TestApprovalMiddleware_Integration(t *testing.T) {
 	})
 }
 
+func TestApprovalMiddleware_InventoryTransfer(t *testing.T) {
+	inventoryID := uuid.New()
+	locationID := uuid.New()
+	path := "/workspaces/550e8400-e29b-41d4-a716-446655440000/inventory/" + inventoryID.String() + "/move"
+	body := `{"location_id":"` + locationID.String() + `"}`
+
+	serve := func(role string, mock *testPendingChangeCreator) (*httptest.ResponseRecorder, bool) {
+		handlerCalled := false
+		r := chi.NewRouter()
+		r.Use(func(next http.Handler) http.Handler {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				ctx := context.WithValue(r.Context(), WorkspaceContextKey, uuid.New())
+				ctx = context.WithValue(ctx, UserContextKey, &AuthUser{ID: uuid.New(), Email: role + "@example.com"})
+				ctx = context.WithValue(ctx, RoleContextKey, role)
+				next.ServeHTTP(w, r.WithContext(ctx))
+			})
+		})
+		r.Use(ApprovalMiddleware(mock))
+		r.Post("/workspaces/{workspace_id}/inventory/{id}/move", func(w http.ResponseWriter, r *http.Request) {
+			handlerCalled = true
+			w.WriteHeader(http.StatusOK)
+		})
+
+		req := httptest.NewRequest(http.MethodPost, path, bytes.NewBufferString(body))
+		req.Header.Set("Content-Type", "application/json")
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+		return rr, handlerCalled
+	}
+
+	t.Run("member transfer creates an update change with a transfer payload", func(t *testing.T) {
+		mock := &testPendingChangeCreator{returnChangeID: uuid.New()}
+
+		rr, handlerCalled := serve("member", mock)
+
+		assert.Equal(t, http.StatusAccepted, rr.Code)
+		assert.False(t, handlerCalled)
+		assert.Equal(t, "inventory", mock.createdEntityType)
+		assert.Equal(t, "update", mock.createdAction)
+		require.NotNil(t, mock.createdEntityID)
+		assert.Equal(t, inventoryID, *mock.createdEntityID)
+		assert.JSONEq(t, `{"transfer":`+body+`}`, string(mock.createdPayload))
+	})
+
+	for _, role := range []string{"owner", "admin"} {
+		t.Run(role+" transfer executes immediately", func(t *testing.T) {
+			mock := &testPendingChangeCreator{}
+
+			rr, handlerCalled := serve(role, mock)
+
+			assert.Equal(t, http.StatusOK, rr.Code)
+			assert.True(t, handlerCalled)
+			assert.False(t, mock.createCalled)
+		})
+	}
+}
+
+func TestApprovalMiddleware_DirectRoutes(t *testing.T) {
+	ws := "/workspaces/550e8400-e29b-41d4-a716-446655440000"
+	id := uuid.New().String()
+
+	for _, tc := range []struct {
+		method string
+		path   string
+	}{
+		{http.MethodPost, ws + "/categories/" + id + "/merge"},
+		{http.MethodPost, ws + "/locations/" + id + "/evacuate"},
+		{http.MethodPost, ws + "/items/" + id + "/duplicate"},
+		{http.MethodPost, ws + "/items/" + id + "/shares"},
+		{http.MethodDelete, ws + "/items/" + id + "/shares/" + uuid.New().String()},
+		{http.MethodPost, ws + "/inventory/bulk-status"},
+		{http.MethodPost, ws + "/inventory/" + id + "/consume"},
+		{http.MethodPost, ws + "/inventory/by-item/" + id + "/consume"},
+	} {
+		t.Run(tc.method+" "+tc.path[len(ws):], func(t *testing.T) {
+			mock := &testPendingChangeCreator{}
+			handlerCalled := false
+
+			r := chi.NewRouter()
+			r.Use(func(next http.Handler) http.Handler {
+				return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+					ctx := context.WithValue(r.Context(), WorkspaceContextKey, uuid.New())
+					ctx = context.WithValue(ctx, UserContextKey, &AuthUser{ID: uuid.New(), Email: "member@example.com"})
+					ctx = context.WithValue(ctx, RoleContextKey, "member")
+					next.ServeHTTP(w, r.WithContext(ctx))
+				})
+			})
+			r.Use(ApprovalMiddleware(mock))
+			r.HandleFunc("/*", func(w http.ResponseWriter, r *http.Request) {
+				handlerCalled = true
+				w.WriteHeader(http.StatusForbidden)
+			})
+
+			req := httptest.NewRequest(tc.method, tc.path, bytes.NewBufferString(`{}`))
+			rr := httptest.NewRecorder()
+			r.ServeHTTP(rr, req)
+
+			assert.True(t, handlerCalled, "the handler decides")
+			assert.False(t, mock.createCalled, "no pending change is recorded")
+			assert.Equal(t, http.StatusForbidden, rr.Code)
+		})
+	}
+
+	t.Run("plain entity writes stay gated", func(t *testing.T) {
+		assert.False(t, isDirectRoute(httptest.NewRequest(http.MethodPost, ws+"/items", nil)))
+		assert.False(t, isDirectRoute(httptest.NewRequest(http.MethodPatch, ws+"/inventory/"+id, nil)))
+		assert.False(t, isDirectRoute(httptest.NewRequest(http.MethodPost, ws+"/inventory/"+id+"/move", nil)))
+	})
+}
+
 func TestExtractEntityType(t *testing.T) {
 	tests := []struct {
 		path           string
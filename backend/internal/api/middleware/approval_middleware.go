@@ -46,6 +46,15 @@ type PendingChangeCreator interface {
 //  5. Creates a pending change instead of executing the operation
 //  6. Returns 202 Accepted with pending change details
 //
+// A member's inventory transfer (POST /inventory/{id}/move) is gated as an
+// update of that entry whose payload wraps the request body under "transfer";
+// approving it performs the move, movement record included.
+//
+// Operations with no approvable equivalent (category merge, location
+// evacuation, item duplication, bulk status changes, consumption and share
+// links; see directRoutes) pass through untouched. Their handlers apply them
+// immediately and reject members with 403.
+//
 // Gated entity types: item, category, location, container, inventory, borrower,
 // loan, label, maintenance, wishlist. These are the first-class, member-mutable
 // resources routed through approval.
@@ -56,7 +65,7 @@ type PendingChangeCreator interface {
 // derived from — their parent entity, which is itself gated. See
 // docs/APPROVAL_PIPELINE.md ("Entity coverage and deliberate exclusions") for the
 // full rationale. The set below MUST stay in sync with
-// pendingchange.Service.isValidEntityType.
+// pendingchange.EntityTypes.
 //
 // See docs/APPROVAL_PIPELINE.md for complete documentation.
 func ApprovalMiddleware(pendingChangeCreator PendingChangeCreator) func(http.Handler) http.Handler {
@@ -93,6 +102,20 @@ func ApprovalMiddleware(pendingChangeCreator PendingChangeCreator) func(http.Han
 				return
 			}
 
+			// Operations that cannot be expressed as a pending change are
+			// left to their handlers, which reject members
+			if isDirectRoute(r) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			// An inventory transfer moves an existing entry: gate it as an
+			// update of that entry carrying a transfer payload
+			transfer := isInventoryTransfer(r, entityType)
+			if transfer {
+				action = "update"
+			}
+
 			// Extract entity ID for update/delete operations
 			entityID := extractEntityID(r, action)
 
@@ -103,6 +126,9 @@ func ApprovalMiddleware(pendingChangeCreator PendingChangeCreator) func(http.Han
 				http.Error(w, `{"error":"bad_request","message":"invalid request body"}`, http.StatusBadRequest)
 				return
 			}
+			if transfer {
+				payload = transferPayload(payload)
+			}
 
 			// Get workspace ID and user ID from context
 			workspaceID, _ := GetWorkspaceID(r.Context())
@@ -243,6 +269,54 @@ func extractEntityID(r *http.Request, action string) *uuid.UUID {
 	return &id
 }
 
+// isInventoryTransfer reports whether r is a transfer of an inventory entry:
+// POST /workspaces/{workspace_id}/inventory/{id}/move.
+func isInventoryTransfer(r *http.Request, entityType string) bool {
+	if entityType != "inventory" || r.Method != http.MethodPost {
+		return false
+	}
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	return len(parts) == 5 && parts[4] == "move"
+}
+
+// directRoutes are the write operations on gated entities that the approval
+// pipeline cannot replay, as path patterns below /workspaces/{workspace_id}.
+// Recording one as a pending "create" of its entity would approve into the
+// wrong operation, so they bypass the pipeline and are owner/admin-only in
+// their handlers instead.
+var directRoutes = map[string]bool{
+	"categories/{id}/merge":          true,
+	"locations/{id}/evacuate":        true,
+	"items/{id}/duplicate":           true,
+	"items/{id}/shares":              true,
+	"items/{id}/shares/{id}":         true,
+	"inventory/bulk-status":          true,
+	"inventory/{id}/consume":         true,
+	"inventory/by-item/{id}/consume": true,
+}
+
+// isDirectRoute reports whether r targets one of directRoutes.
+func isDirectRoute(r *http.Request) bool {
+	parts := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	if len(parts) < 3 {
+		return false
+	}
+	pattern := parts[2:]
+	for i, part := range pattern {
+		if _, err := uuid.Parse(part); err == nil {
+			pattern[i] = "{id}"
+		}
+	}
+	return directRoutes[strings.Join(pattern, "/")]
+}
+
+// transferPayload wraps a move request body as the pending change payload of
+// an inventory transfer (pendingchange.InventoryTransferPayload), so the apply
+// path can tell it apart from a field update.
+func transferPayload(body json.RawMessage) json.RawMessage {
+	return json.RawMessage(`{"transfer":` + string(body) + `}`)
+}
+
 // extractPayload reads and buffers the request body, validating it as JSON.
 // The body is restored after reading so downstream handlers can still access it if needed.
 // Returns empty JSON object "{}" if the body is nil or empty.
@@ -4,8 +4,10 @@ import (
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
+	"log/slog"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 
@@ -29,14 +31,34 @@ type AuthUser struct {
 	IsSuperuser bool
 }
 
+// TokenRevocationChecker reports the cutoff set by "sign out everywhere":
+// access tokens of the user issued at or before it are rejected.
+// *tokenrevocation.Store satisfies this interface.
+type TokenRevocationChecker interface {
+	RevokedBefore(ctx context.Context, userID uuid.UUID) (time.Time, bool, error)
+}
+
 // JWTAuth creates an authentication middleware with JWT validation.
 // Supports multiple token sources in order of priority:
 // 1. Authorization header (Bearer token)
 // 2. Cookie (access_token)
 // 3. Query parameter (token=...) - for SSE since EventSource doesn't support custom headers
 func JWTAuth(jwtService *jwt.Service) func(http.Handler) http.Handler {
+	return JWTAuthWithRevocation(jwtService, nil)
+}
+
+// JWTAuthWithRevocation is JWTAuth that additionally rejects tokens revoked
+// via checker. A nil checker disables the check. If the checker fails the
+// request is let through: an outage of the revocation store should not lock
+// every user out. Requests APIKeyAuth already authenticated pass through.
+func JWTAuthWithRevocation(jwtService *jwt.Service, checker TokenRevocationChecker) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if _, ok := GetAPIKey(r.Context()); ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+
 			token, ok := extractToken(r)
 			if !ok {
 				http.Error(w, `{"error":"unauthorized","message":"invalid authorization format"}`, http.StatusUnauthorized)
@@ -57,6 +79,16 @@ func JWTAuth(jwtService *jwt.Service) func(http.Handler) http.Handler {
 				return
 			}
 
+			if checker != nil {
+				cutoff, revoked, err := checker.RevokedBefore(r.Context(), claims.UserID)
+				if err != nil {
+					slog.Warn("jwt auth: token revocation check failed", "error", err, "user_id", claims.UserID)
+				} else if revoked && (claims.IssuedAt == nil || !claims.IssuedAt.After(cutoff)) {
+					http.Error(w, `{"error":"unauthorized","message":"token has been revoked"}`, http.StatusUnauthorized)
+					return
+				}
+			}
+
 			user := &AuthUser{
 				ID:          claims.UserID,
 				Email:       claims.Email,
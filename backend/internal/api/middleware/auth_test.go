@@ -2,9 +2,11 @@ package middleware
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -312,6 +314,58 @@ func TestJWTAuth_TokenWithWrongSecret(t *testing.T) {
 	assert.Contains(t, rec.Body.String(), "invalid token")
 }
 
+// fakeRevocationChecker returns a fixed cutoff for every user.
+type fakeRevocationChecker struct {
+	cutoff time.Time
+	set    bool
+	err    error
+}
+
+func (f fakeRevocationChecker) RevokedBefore(context.Context, uuid.UUID) (time.Time, bool, error) {
+	return f.cutoff, f.set, f.err
+}
+
+func TestJWTAuthWithRevocation(t *testing.T) {
+	jwtService := jwt.NewService("test-secret", 24)
+	token, err := jwtService.GenerateToken(uuid.New(), "test@example.com", "Test User", false)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name       string
+		checker    TokenRevocationChecker
+		wantStatus int
+	}{
+		{"no checker", nil, http.StatusOK},
+		{"no cutoff", fakeRevocationChecker{}, http.StatusOK},
+		{"token issued before cutoff", fakeRevocationChecker{cutoff: time.Now().Add(time.Minute), set: true}, http.StatusUnauthorized},
+		{"token issued in cutoff second", fakeRevocationChecker{cutoff: time.Now(), set: true}, http.StatusUnauthorized},
+		{"token issued after cutoff", fakeRevocationChecker{cutoff: time.Now().Add(-time.Minute), set: true}, http.StatusOK},
+		{"checker error fails open", fakeRevocationChecker{err: errors.New("redis down")}, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nextCalled := false
+			handler := JWTAuthWithRevocation(jwtService, tt.checker)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				nextCalled = true
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			req := httptest.NewRequest(http.MethodGet, "/test", nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			rec := httptest.NewRecorder()
+
+			handler.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, tt.wantStatus == http.StatusOK, nextCalled)
+			if tt.wantStatus == http.StatusUnauthorized {
+				assert.Contains(t, rec.Body.String(), "token has been revoked")
+			}
+		})
+	}
+}
+
 func TestJWTAuth_UserInContext(t *testing.T) {
 	jwtService := jwt.NewService("test-secret", 24)
 	userID := uuid.New()
@@ -0,0 +1,65 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+const publicBaseURLKey contextKey = "public_base_url"
+
+// PublicBaseURL stores the API's externally visible base URL in the request
+// context for handlers that serialize absolute URLs (photo links). A
+// non-empty configured URL (PUBLIC_BASE_URL) is always used; otherwise the
+// base is derived from the request, honouring the X-Forwarded-Proto,
+// X-Forwarded-Host and X-Forwarded-Prefix headers set by a reverse proxy.
+func PublicBaseURL(configured string) func(http.Handler) http.Handler {
+	configured = strings.TrimRight(configured, "/")
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			base := configured
+			if base == "" {
+				base = RequestBaseURL(r)
+			}
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), publicBaseURLKey, base)))
+		})
+	}
+}
+
+// GetPublicBaseURL returns the base URL stored by PublicBaseURL, or "" when
+// the middleware did not run (URLs then come out host-relative).
+func GetPublicBaseURL(ctx context.Context) string {
+	base, _ := ctx.Value(publicBaseURLKey).(string)
+	return base
+}
+
+// RequestBaseURL derives the base URL a client used to reach the API, e.g.
+// "https://warehouse.example.com/api", without a trailing slash.
+func RequestBaseURL(r *http.Request) string {
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	if proto := firstForwardedValue(r.Header.Get("X-Forwarded-Proto")); proto == "http" || proto == "https" {
+		scheme = proto
+	}
+
+	host := r.Host
+	if fwd := firstForwardedValue(r.Header.Get("X-Forwarded-Host")); fwd != "" {
+		host = fwd
+	}
+
+	prefix := strings.TrimRight(firstForwardedValue(r.Header.Get("X-Forwarded-Prefix")), "/")
+	if prefix != "" && !strings.HasPrefix(prefix, "/") {
+		prefix = "/" + prefix
+	}
+
+	return scheme + "://" + host + prefix
+}
+
+// firstForwardedValue returns the first entry of a comma-separated forwarding
+// header: the value set by the proxy closest to the client.
+func firstForwardedValue(v string) string {
+	first, _, _ := strings.Cut(v, ",")
+	return strings.TrimSpace(first)
+}
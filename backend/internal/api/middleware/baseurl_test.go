@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"crypto/tls"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublicBaseURL(t *testing.T) {
+	serve := func(configured string, r *http.Request) string {
+		var got string
+		handler := PublicBaseURL(configured)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got = GetPublicBaseURL(r.Context())
+		}))
+		handler.ServeHTTP(httptest.NewRecorder(), r)
+		return got
+	}
+
+	t.Run("uses the configured URL", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "http://backend:8080/workspaces", nil)
+		r.Header.Set("X-Forwarded-Host", "evil.example.com")
+
+		assert.Equal(t, "https://warehouse.example.com/api", serve("https://warehouse.example.com/api/", r))
+	})
+
+	t.Run("derives the URL from the request", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "http://backend:8080/workspaces", nil)
+
+		assert.Equal(t, "http://backend:8080", serve("", r))
+	})
+
+	t.Run("derives the URL from proxy headers", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "http://backend:8080/workspaces", nil)
+		r.Header.Set("X-Forwarded-Proto", "https, http")
+		r.Header.Set("X-Forwarded-Host", "warehouse.example.com, backend")
+		r.Header.Set("X-Forwarded-Prefix", "/api/")
+
+		assert.Equal(t, "https://warehouse.example.com/api", serve("", r))
+	})
+
+	t.Run("derives https from TLS", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "https://warehouse.local/workspaces", nil)
+		r.TLS = &tls.ConnectionState{}
+
+		assert.Equal(t, "https://warehouse.local", serve("", r))
+	})
+
+	t.Run("ignores an unknown forwarded scheme", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "http://warehouse.local/workspaces", nil)
+		r.Header.Set("X-Forwarded-Proto", "javascript")
+
+		assert.Equal(t, "http://warehouse.local", serve("", r))
+	})
+
+	t.Run("empty without the middleware", func(t *testing.T) {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+
+		assert.Empty(t, GetPublicBaseURL(r.Context()))
+	})
+}
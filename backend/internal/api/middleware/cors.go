@@ -3,26 +3,64 @@ package middleware
 import (
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 )
 
+// Defaults for the CORS policy, overridable via CORS_ALLOWED_METHODS and
+// CORS_ALLOWED_HEADERS.
+const (
+	defaultCORSMethods = "GET, POST, PUT, PATCH, DELETE, OPTIONS"
+	defaultCORSHeaders = "Accept, Authorization, Content-Type, Idempotency-Key, If-None-Match, X-CSRF-Token, X-Workspace-ID"
+)
+
+// corsPolicy is the CORS configuration read from the environment when the
+// middleware is built.
+type corsPolicy struct {
+	origins          []string
+	methods          string
+	headers          string
+	allowCredentials bool
+}
+
+// loadCORSPolicy reads the CORS policy from the environment:
+//   - origins: see allowedOrigins,
+//   - CORS_ALLOWED_METHODS / CORS_ALLOWED_HEADERS: comma-separated, replacing
+//     the defaults above,
+//   - CORS_ALLOW_CREDENTIALS: whether cross-origin requests may carry cookies
+//     (default true; the SPA authenticates with cookies).
+func loadCORSPolicy() corsPolicy {
+	return corsPolicy{
+		origins:          allowedOrigins(),
+		methods:          envList("CORS_ALLOWED_METHODS", defaultCORSMethods),
+		headers:          envList("CORS_ALLOWED_HEADERS", defaultCORSHeaders),
+		allowCredentials: envBool("CORS_ALLOW_CREDENTIALS", true),
+	}
+}
+
 // allowedOrigins returns the explicit list of allowed origins for CORS.
 //
 // The list is built from:
-//   - a small set of local development defaults,
 //   - APP_URL (the deployed frontend origin),
-//   - CORS_ALLOWED_ORIGINS (comma-separated extra origins).
+//   - CORS_ALLOWED_ORIGINS (comma-separated extra origins),
+//   - a small set of local development origins, only when DEBUG=true.
+//
+// With none of these set the API is same-origin only.
 //
 // There is deliberately NO private-network auto-allow: with
 // Access-Control-Allow-Credentials true, reflecting arbitrary LAN origins
 // would let any site on a private IP (or a DNS-rebinding attacker) make
 // credentialed requests and read the responses.
 func allowedOrigins() []string {
-	// Default allowed origins for development
-	origins := []string{
-		"http://localhost:3000",
-		"http://localhost:3001",
-		"http://127.0.0.1:3000",
+	var origins []string
+
+	// Development frontends (Vite/Next dev servers)
+	if envBool("DEBUG", false) {
+		origins = append(origins,
+			"http://localhost:3000",
+			"http://localhost:3001",
+			"http://127.0.0.1:3000",
+		)
 	}
 
 	// Add the configured frontend origin
@@ -42,6 +80,30 @@ func allowedOrigins() []string {
 	return origins
 }
 
+// envList normalizes a comma-separated env var to "A, B, C", falling back to
+// def when unset or empty.
+func envList(key, def string) string {
+	var items []string
+	for _, item := range strings.Split(os.Getenv(key), ",") {
+		if trimmed := strings.TrimSpace(item); trimmed != "" {
+			items = append(items, trimmed)
+		}
+	}
+	if len(items) == 0 {
+		return def
+	}
+	return strings.Join(items, ", ")
+}
+
+// envBool parses a boolean env var, falling back to def when unset or invalid.
+func envBool(key string, def bool) bool {
+	v, err := strconv.ParseBool(os.Getenv(key))
+	if err != nil {
+		return def
+	}
+	return v
+}
+
 // isAllowedOrigin checks if the given origin is in the allowed list.
 func isAllowedOrigin(origin string, allowed []string) bool {
 	for _, o := range allowed {
@@ -52,9 +114,11 @@ func isAllowedOrigin(origin string, allowed []string) bool {
 	return false
 }
 
-// CORS adds CORS headers to responses.
+// CORS adds CORS headers for allowlisted origins and answers preflight
+// (OPTIONS) requests directly with 204. Requests from other origins get no
+// Access-Control-Allow-* headers, so browsers keep them same-origin.
 func CORS(next http.Handler) http.Handler {
-	allowed := allowedOrigins()
+	policy := loadCORSPolicy()
 
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		origin := r.Header.Get("Origin")
@@ -63,16 +127,19 @@ func CORS(next http.Handler) http.Handler {
 		w.Header().Set("Vary", "Origin")
 
 		// Set the specific origin if allowed (required for credentials)
-		if origin != "" && isAllowedOrigin(origin, allowed) {
+		if origin != "" && isAllowedOrigin(origin, policy.origins) {
 			w.Header().Set("Access-Control-Allow-Origin", origin)
-			w.Header().Set("Access-Control-Allow-Credentials", "true")
+			if policy.allowCredentials {
+				w.Header().Set("Access-Control-Allow-Credentials", "true")
+			}
+			w.Header().Set("Access-Control-Allow-Methods", policy.methods)
+			w.Header().Set("Access-Control-Allow-Headers", policy.headers)
+			w.Header().Set("Access-Control-Max-Age", "300")
+			// Let the SPA read entity tags for conditional GETs
+			w.Header().Set("Access-Control-Expose-Headers", "ETag")
 		}
 
-		w.Header().Set("Access-Control-Allow-Methods", "GET, POST, PUT, PATCH, DELETE, OPTIONS")
-		w.Header().Set("Access-Control-Allow-Headers", "Accept, Authorization, Content-Type, Idempotency-Key, X-CSRF-Token, X-Workspace-ID")
-		w.Header().Set("Access-Control-Max-Age", "300")
-
-		if r.Method == "OPTIONS" {
+		if r.Method == http.MethodOptions {
 			w.WriteHeader(http.StatusNoContent)
 			return
 		}
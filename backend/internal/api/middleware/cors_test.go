@@ -14,6 +14,8 @@ import (
 // =============================================================================
 
 func TestCORS_AllowedOrigin_Localhost3000(t *testing.T) {
+	t.Setenv("DEBUG", "true")
+
 	handler := CORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 	}))
@@ -31,6 +33,8 @@ func TestCORS_AllowedOrigin_Localhost3000(t *testing.T) {
 }
 
 func TestCORS_AllowedOrigin_Localhost3001(t *testing.T) {
+	t.Setenv("DEBUG", "true")
+
 	handler := CORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 	}))
@@ -47,6 +51,8 @@ func TestCORS_AllowedOrigin_Localhost3001(t *testing.T) {
 }
 
 func TestCORS_AllowedOrigin_Localhost127(t *testing.T) {
+	t.Setenv("DEBUG", "true")
+
 	handler := CORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 	}))
@@ -145,6 +151,8 @@ func TestCORS_NoOriginHeader(t *testing.T) {
 // =============================================================================
 
 func TestCORS_AllowedMethods(t *testing.T) {
+	t.Setenv("DEBUG", "true")
+
 	handler := CORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 	}))
@@ -165,6 +173,8 @@ func TestCORS_AllowedMethods(t *testing.T) {
 }
 
 func TestCORS_AllowedHeaders(t *testing.T) {
+	t.Setenv("DEBUG", "true")
+
 	handler := CORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 	}))
@@ -185,6 +195,8 @@ func TestCORS_AllowedHeaders(t *testing.T) {
 }
 
 func TestCORS_MaxAge(t *testing.T) {
+	t.Setenv("DEBUG", "true")
+
 	handler := CORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 	}))
@@ -203,6 +215,8 @@ func TestCORS_MaxAge(t *testing.T) {
 // =============================================================================
 
 func TestCORS_OptionsRequest_AllowedOrigin(t *testing.T) {
+	t.Setenv("DEBUG", "true")
+
 	nextCalled := false
 	handler := CORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		nextCalled = true
@@ -280,6 +294,8 @@ func TestCORS_CustomOriginFromEnv(t *testing.T) {
 }
 
 func TestCORS_VaryHeader_PreventsCachingIssues(t *testing.T) {
+	t.Setenv("DEBUG", "true")
+
 	handler := CORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 	}))
@@ -299,6 +315,8 @@ func TestCORS_VaryHeader_PreventsCachingIssues(t *testing.T) {
 // =============================================================================
 
 func TestCORS_CredentialsHeader_WithAllowedOrigin(t *testing.T) {
+	t.Setenv("DEBUG", "true")
+
 	handler := CORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 	}))
@@ -331,6 +349,8 @@ func TestCORS_CredentialsHeader_NotSetForPrivateNetworkOrigin(t *testing.T) {
 // =============================================================================
 
 func TestCORS_GetRequest(t *testing.T) {
+	t.Setenv("DEBUG", "true")
+
 	handler := CORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 	}))
@@ -346,6 +366,8 @@ func TestCORS_GetRequest(t *testing.T) {
 }
 
 func TestCORS_PostRequest(t *testing.T) {
+	t.Setenv("DEBUG", "true")
+
 	handler := CORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusCreated)
 	}))
@@ -361,6 +383,8 @@ func TestCORS_PostRequest(t *testing.T) {
 }
 
 func TestCORS_DeleteRequest(t *testing.T) {
+	t.Setenv("DEBUG", "true")
+
 	handler := CORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusNoContent)
 	}))
@@ -380,6 +404,8 @@ func TestCORS_DeleteRequest(t *testing.T) {
 // =============================================================================
 
 func TestCORS_CallsNextHandler_OnNonOptionsRequest(t *testing.T) {
+	t.Setenv("DEBUG", "true")
+
 	nextCalled := false
 	handler := CORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		nextCalled = true
@@ -396,6 +422,8 @@ func TestCORS_CallsNextHandler_OnNonOptionsRequest(t *testing.T) {
 }
 
 func TestCORS_DoesNotCallNextHandler_OnOptionsRequest(t *testing.T) {
+	t.Setenv("DEBUG", "true")
+
 	nextCalled := false
 	handler := CORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		nextCalled = true
@@ -410,3 +438,79 @@ func TestCORS_DoesNotCallNextHandler_OnOptionsRequest(t *testing.T) {
 
 	assert.False(t, nextCalled)
 }
+
+// =============================================================================
+// Policy Configuration Tests
+// =============================================================================
+
+func TestCORS_DefaultIsSameOriginOnly(t *testing.T) {
+	t.Setenv("DEBUG", "")
+	t.Setenv("APP_URL", "")
+	t.Setenv("CORS_ALLOWED_ORIGINS", "")
+
+	handler := CORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.Header.Set("Origin", "http://localhost:3000")
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Credentials"))
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Methods"))
+}
+
+func TestCORS_AppURLIsAllowed(t *testing.T) {
+	t.Setenv("APP_URL", "https://warehouse.example.com/")
+
+	handler := CORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodGet, "/test", nil)
+	req.Header.Set("Origin", "https://warehouse.example.com")
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, "https://warehouse.example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func TestCORS_ConfigurableMethodsHeadersAndCredentials(t *testing.T) {
+	t.Setenv("CORS_ALLOWED_ORIGINS", "https://app.example.com")
+	t.Setenv("CORS_ALLOWED_METHODS", "GET,POST, OPTIONS")
+	t.Setenv("CORS_ALLOWED_HEADERS", " Authorization ,Content-Type")
+	t.Setenv("CORS_ALLOW_CREDENTIALS", "false")
+
+	handler := CORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	req := httptest.NewRequest(http.MethodOptions, "/test", nil)
+	req.Header.Set("Origin", "https://app.example.com")
+	req.Header.Set("Access-Control-Request-Method", "POST")
+	rec := httptest.NewRecorder()
+
+	handler.ServeHTTP(rec, req)
+
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Equal(t, "https://app.example.com", rec.Header().Get("Access-Control-Allow-Origin"))
+	assert.Equal(t, "GET, POST, OPTIONS", rec.Header().Get("Access-Control-Allow-Methods"))
+	assert.Equal(t, "Authorization, Content-Type", rec.Header().Get("Access-Control-Allow-Headers"))
+	assert.Empty(t, rec.Header().Get("Access-Control-Allow-Credentials"))
+}
+
+func TestCORS_InvalidCredentialsValueKeepsDefault(t *testing.T) {
+	t.Setenv("CORS_ALLOWED_ORIGINS", "https://app.example.com")
+	t.Setenv("CORS_ALLOW_CREDENTIALS", "sometimes")
+
+	policy := loadCORSPolicy()
+
+	assert.True(t, policy.allowCredentials)
+	assert.Equal(t, defaultCORSMethods, policy.methods)
+	assert.Equal(t, defaultCORSHeaders, policy.headers)
+}
@@ -11,8 +11,9 @@ import (
 // Sec-Fetch-Site and Origin headers.
 //
 // Rules (mutating methods only — POST/PUT/PATCH/DELETE):
-//   - Requests carrying an Authorization: Bearer header are skipped: header
-//     tokens cannot be attached cross-site by a victim's browser.
+//   - Requests carrying an Authorization: Bearer or ApiKey header are
+//     skipped: header credentials cannot be attached cross-site by a
+//     victim's browser.
 //   - Sec-Fetch-Site: cross-site is rejected unless the Origin is in the
 //     CORS allowlist (allowedOrigins — the same config value used by the
 //     CORS middleware, covering intentionally split frontend/API origins).
@@ -29,8 +30,8 @@ func CSRFProtect(next http.Handler) http.Handler {
 			return
 		}
 
-		// Bearer-token requests are not cookie-authenticated; skip.
-		if strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+		// Bearer-token and API key requests are not cookie-authenticated; skip.
+		if auth := r.Header.Get("Authorization"); strings.HasPrefix(auth, "Bearer ") || strings.HasPrefix(auth, apiKeyScheme) {
 			next.ServeHTTP(w, r)
 			return
 		}
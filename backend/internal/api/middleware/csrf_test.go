@@ -80,6 +80,7 @@ func TestCSRFProtect_OriginFallback(t *testing.T) {
 	})
 
 	t.Run("allowed origin without Sec-Fetch-Site passes", func(t *testing.T) {
+		t.Setenv("DEBUG", "true")
 		h, called := csrfHandler()
 		req := httptest.NewRequest(http.MethodPost, "/test", nil)
 		req.Header.Set("Origin", "http://localhost:3000")
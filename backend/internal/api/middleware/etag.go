@@ -0,0 +1,54 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+)
+
+// ETag returns a strong entity tag for a response body: a hash of its JSON
+// encoding. Hashing the whole body rather than just the entity's updated_at
+// means decorations that change on their own, such as the primary photo or
+// custom field values, change the tag too. Returns "" if body cannot be
+// encoded.
+func ETag(body any) string {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return ""
+	}
+	sum := sha256.Sum256(b)
+	return `"` + hex.EncodeToString(sum[:16]) + `"`
+}
+
+// NotModified returns a 304 Not Modified error carrying etag when the
+// request's If-None-Match header matches it, and nil otherwise. GET handlers
+// return it in place of the response:
+//
+//	if err := appMiddleware.NotModified(input.IfNoneMatch, etag); err != nil {
+//		return nil, err
+//	}
+func NotModified(ifNoneMatch, etag string) error {
+	if etag == "" || !etagMatches(ifNoneMatch, etag) {
+		return nil
+	}
+	headers := http.Header{}
+	headers.Set("ETag", etag)
+	return huma.ErrorWithHeaders(huma.Status304NotModified(), headers)
+}
+
+// etagMatches reports whether an If-None-Match header value, a
+// comma-separated list of tags or "*", matches etag. Weak tags compare equal
+// to their strong form as GETs use the weak comparison (RFC 9110 13.1.2).
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestETag(t *testing.T) {
+	a := ETag(map[string]any{"name": "Drill", "quantity": 1})
+
+	assert.Regexp(t, `^"[0-9a-f]{32}"$`, a)
+	assert.Equal(t, a, ETag(map[string]any{"name": "Drill", "quantity": 1}))
+	assert.NotEqual(t, a, ETag(map[string]any{"name": "Drill", "quantity": 2}))
+	assert.Empty(t, ETag(func() {}), "unencodable bodies get no tag")
+}
+
+func TestNotModified(t *testing.T) {
+	etag := `"abc123"`
+
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		want        bool
+	}{
+		{"no header", "", false},
+		{"matching tag", `"abc123"`, true},
+		{"weak form of the tag", `W/"abc123"`, true},
+		{"one of several tags", `"old", "abc123"`, true},
+		{"wildcard", "*", true},
+		{"different tag", `"old"`, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := NotModified(tt.ifNoneMatch, etag)
+			if !tt.want {
+				assert.NoError(t, err)
+				return
+			}
+
+			var se huma.StatusError
+			require.ErrorAs(t, err, &se)
+			assert.Equal(t, http.StatusNotModified, se.GetStatus())
+			var he huma.HeadersError
+			require.ErrorAs(t, err, &he)
+			assert.Equal(t, etag, he.GetHeaders().Get("ETag"))
+		})
+	}
+
+	t.Run("no tag never matches", func(t *testing.T) {
+		assert.NoError(t, NotModified("*", ""))
+	})
+}
@@ -259,6 +259,8 @@ func TestAuth_ValidBearerToken_ReturnsError(t *testing.T) {
 // =============================================================================
 
 func TestCORS_SetsHeaders(t *testing.T) {
+	t.Setenv("DEBUG", "true")
+
 	handler := CORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusOK)
 	}))
@@ -278,6 +280,8 @@ func TestCORS_SetsHeaders(t *testing.T) {
 }
 
 func TestCORS_OptionsRequest(t *testing.T) {
+	t.Setenv("DEBUG", "true")
+
 	nextCalled := false
 	handler := CORS(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		nextCalled = true
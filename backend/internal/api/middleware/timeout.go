@@ -1,6 +1,8 @@
 package middleware
 
 import (
+	"context"
+	"errors"
 	"net/http"
 	"strings"
 	"time"
@@ -28,3 +30,97 @@ func TimeoutWithSkip(timeout time.Duration, skipSuffixes ...string) func(http.Ha
 		})
 	}
 }
+
+// RouteTimeout overrides the request budget for paths containing Match
+// (e.g. "/export/"). Routes are workspace-prefixed, so a substring match is
+// used rather than a prefix.
+type RouteTimeout struct {
+	Match   string
+	Timeout time.Duration
+}
+
+// timeoutResponseBody is the JSON body sent when a request exceeds its budget.
+const timeoutResponseBody = `{"error":"request_timeout","message":"the request took too long to process, please try again"}`
+
+// TimeoutByRoute bounds each request with a context deadline: the first
+// matching override's budget, or defaultTimeout otherwise. Paths ending in
+// any of skipSuffixes (long-lived SSE streams) get no deadline.
+//
+// A handler that has not started its response by the time the deadline
+// passes gets a 503 with a JSON body instead of whatever it writes afterwards
+// (typically a 500 from a cancelled query). A response that was already
+// started is left alone.
+func TimeoutByRoute(defaultTimeout time.Duration, overrides []RouteTimeout, skipSuffixes ...string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			for _, suffix := range skipSuffixes {
+				if strings.HasSuffix(r.URL.Path, suffix) {
+					next.ServeHTTP(w, r)
+					return
+				}
+			}
+
+			ctx, cancel := context.WithTimeout(r.Context(), routeTimeout(r.URL.Path, defaultTimeout, overrides))
+			defer cancel()
+
+			tw := &timeoutWriter{ResponseWriter: w, ctx: ctx}
+			next.ServeHTTP(tw, r.WithContext(ctx))
+
+			if !tw.wroteHeader && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				tw.WriteHeader(http.StatusServiceUnavailable)
+			}
+		})
+	}
+}
+
+// routeTimeout picks the budget for path.
+func routeTimeout(path string, defaultTimeout time.Duration, overrides []RouteTimeout) time.Duration {
+	for _, o := range overrides {
+		if strings.Contains(path, o.Match) {
+			return o.Timeout
+		}
+	}
+	return defaultTimeout
+}
+
+// timeoutWriter replaces a response started after the deadline with the 503
+// timeout response and discards the handler's own body.
+type timeoutWriter struct {
+	http.ResponseWriter
+	ctx         context.Context
+	wroteHeader bool
+	timedOut    bool
+}
+
+func (tw *timeoutWriter) WriteHeader(code int) {
+	if tw.wroteHeader {
+		return
+	}
+	tw.wroteHeader = true
+	if errors.Is(tw.ctx.Err(), context.DeadlineExceeded) {
+		tw.timedOut = true
+		h := tw.ResponseWriter.Header()
+		h.Del("Content-Length")
+		h.Set("Content-Type", "application/json")
+		tw.ResponseWriter.WriteHeader(http.StatusServiceUnavailable)
+		_, _ = tw.ResponseWriter.Write([]byte(timeoutResponseBody))
+		return
+	}
+	tw.ResponseWriter.WriteHeader(code)
+}
+
+func (tw *timeoutWriter) Write(b []byte) (int, error) {
+	if !tw.wroteHeader {
+		tw.WriteHeader(http.StatusOK)
+	}
+	if tw.timedOut {
+		return 0, http.ErrHandlerTimeout
+	}
+	return tw.ResponseWriter.Write(b)
+}
+
+// Unwrap lets http.ResponseController reach the underlying writer (flush,
+// per-request write deadlines).
+func (tw *timeoutWriter) Unwrap() http.ResponseWriter {
+	return tw.ResponseWriter
+}
@@ -15,7 +15,7 @@ import (
 
 func TestTimeoutWithSkip_AppliesTimeout_ToNormalRoute(t *testing.T) {
 	handlerCalled := false
-	handler := TimeoutWithSkip(100*time.Millisecond)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	handler := TimeoutWithSkip(100 * time.Millisecond)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		handlerCalled = true
 		// Sleep longer than timeout to trigger timeout
 		time.Sleep(200 * time.Millisecond)
@@ -53,10 +53,10 @@ func TestTimeoutWithSkip_SkipsTimeout_ForSSERoute(t *testing.T) {
 
 func TestTimeoutWithSkip_SkipsTimeout_MultipleRoutes(t *testing.T) {
 	tests := []struct {
-		name           string
-		path           string
-		skipSuffixes   []string
-		shouldTimeout  bool
+		name          string
+		path          string
+		skipSuffixes  []string
+		shouldTimeout bool
 	}{
 		{"skip /sse", "/api/sse", []string{"/sse"}, false},
 		{"skip /stream", "/api/stream", []string{"/stream"}, false},
@@ -129,7 +129,7 @@ func TestTimeoutWithSkip_SkipsTimeout_PartialSuffix(t *testing.T) {
 
 func TestTimeoutWithSkip_EnforcesTimeout_QuickRequest(t *testing.T) {
 	handlerCalled := false
-	handler := TimeoutWithSkip(1*time.Second)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	handler := TimeoutWithSkip(1 * time.Second)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		handlerCalled = true
 		// Quick response within timeout
 		w.WriteHeader(http.StatusOK)
@@ -147,7 +147,7 @@ func TestTimeoutWithSkip_EnforcesTimeout_QuickRequest(t *testing.T) {
 func TestTimeoutWithSkip_EmptySkipSuffixes(t *testing.T) {
 	var hasDeadline bool
 
-	handler := TimeoutWithSkip(100*time.Millisecond)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	handler := TimeoutWithSkip(100 * time.Millisecond)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		_, hasDeadline = r.Context().Deadline()
 		w.WriteHeader(http.StatusOK)
 	}))
@@ -187,7 +187,7 @@ func TestTimeoutWithSkip_ContextDeadline_IsSet(t *testing.T) {
 	var hasDeadline bool
 	var deadlineReasonable bool
 
-	handler := TimeoutWithSkip(1*time.Second)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	handler := TimeoutWithSkip(1 * time.Second)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		deadline, hasDeadline = r.Context().Deadline()
 		// Check that deadline is in the future
 		deadlineReasonable = deadline.After(time.Now())
@@ -292,7 +292,7 @@ func TestTimeoutWithSkip_MultipleSkipSuffixes_NoneMatch(t *testing.T) {
 func TestTimeoutWithSkip_CallsNextHandler_WithTimeout(t *testing.T) {
 	handlerCalled := false
 
-	handler := TimeoutWithSkip(1*time.Second)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	handler := TimeoutWithSkip(1 * time.Second)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		handlerCalled = true
 		w.WriteHeader(http.StatusOK)
 	}))
@@ -322,7 +322,7 @@ func TestTimeoutWithSkip_CallsNextHandler_WithoutTimeout(t *testing.T) {
 }
 
 func TestTimeoutWithSkip_ResponseStatus_PreservedWithTimeout(t *testing.T) {
-	handler := TimeoutWithSkip(1*time.Second)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	handler := TimeoutWithSkip(1 * time.Second)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.WriteHeader(http.StatusAccepted)
 	}))
 
@@ -354,7 +354,7 @@ func TestTimeoutWithSkip_ResponseStatus_PreservedWithoutTimeout(t *testing.T) {
 func TestTimeoutWithSkip_AppliesTimeout_ToGET(t *testing.T) {
 	var hasDeadline bool
 
-	handler := TimeoutWithSkip(1*time.Second)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	handler := TimeoutWithSkip(1 * time.Second)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		_, hasDeadline = r.Context().Deadline()
 		w.WriteHeader(http.StatusOK)
 	}))
@@ -369,7 +369,7 @@ func TestTimeoutWithSkip_AppliesTimeout_ToGET(t *testing.T) {
 func TestTimeoutWithSkip_AppliesTimeout_ToPOST(t *testing.T) {
 	var hasDeadline bool
 
-	handler := TimeoutWithSkip(1*time.Second)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+	handler := TimeoutWithSkip(1 * time.Second)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		_, hasDeadline = r.Context().Deadline()
 		w.WriteHeader(http.StatusOK)
 	}))
@@ -451,3 +451,100 @@ func TestTimeoutWithSkip_PathMatching_SuffixNotMatched(t *testing.T) {
 	// /sse-backup has /sse but doesn't END with /sse
 	assert.True(t, hasDeadline)
 }
+
+// =============================================================================
+// TimeoutByRoute Middleware Tests
+// =============================================================================
+
+func TestTimeoutByRoute_PicksBudgetByRoute(t *testing.T) {
+	overrides := []RouteTimeout{
+		{Match: "/export/", Timeout: 10 * time.Minute},
+		{Match: "/photos", Timeout: 5 * time.Minute},
+	}
+
+	tests := []struct {
+		name    string
+		path    string
+		wantMin time.Duration
+		wantMax time.Duration
+	}{
+		{"default", "/workspaces/ws/items", 0, time.Second},
+		{"export override", "/workspaces/ws/export/items", 9 * time.Minute, 10 * time.Minute},
+		{"upload override", "/workspaces/ws/items/abc/photos", 4 * time.Minute, 5 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var remaining time.Duration
+			handler := TimeoutByRoute(time.Second, overrides)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				deadline, ok := r.Context().Deadline()
+				assert.True(t, ok)
+				remaining = time.Until(deadline)
+				w.WriteHeader(http.StatusOK)
+			}))
+
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Greater(t, remaining, tt.wantMin)
+			assert.LessOrEqual(t, remaining, tt.wantMax)
+		})
+	}
+}
+
+func TestTimeoutByRoute_SkipsSuffix(t *testing.T) {
+	var hasDeadline bool
+	handler := TimeoutByRoute(time.Second, nil, "/sse")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, hasDeadline = r.Context().Deadline()
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/workspaces/ws/sse", nil))
+
+	assert.False(t, hasDeadline)
+	assert.Equal(t, http.StatusOK, rec.Code)
+}
+
+func TestTimeoutByRoute_Returns503_WhenHandlerWritesNothing(t *testing.T) {
+	handler := TimeoutByRoute(20*time.Millisecond, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/items", nil))
+
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), "request_timeout")
+}
+
+func TestTimeoutByRoute_Returns503_InsteadOfLateErrorResponse(t *testing.T) {
+	handler := TimeoutByRoute(20*time.Millisecond, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done()
+		// A cancelled query typically surfaces as a 500 from the handler.
+		http.Error(w, "failed to list items", http.StatusInternalServerError)
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/items", nil))
+
+	assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "failed to list items")
+	assert.Contains(t, rec.Body.String(), "request_timeout")
+}
+
+func TestTimeoutByRoute_LeavesStartedResponseAlone(t *testing.T) {
+	handler := TimeoutByRoute(20*time.Millisecond, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("partial"))
+		<-r.Context().Done()
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/items", nil))
+
+	assert.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "partial", rec.Body.String())
+}
@@ -117,14 +117,41 @@ func Workspace(memberRepo MemberRepository) func(http.Handler) http.Handler {
 				return
 			}
 
+			// A request made with an API key acts with the key's role, and only
+			// in the key's workspace, for as long as its creator is a member.
+			// The key never outranks its creator, so demoting the creator
+			// demotes their keys too.
+			role := string(membership.Role())
+			if key, ok := GetAPIKey(r.Context()); ok {
+				if key.WorkspaceID != workspaceID {
+					http.Error(w, `{"error":"forbidden","message":"API keys can only access their own workspace"}`, http.StatusForbidden)
+					return
+				}
+				role = lowerRole(key.Role, role)
+			}
+
 			// Add workspace ID and role to context
 			ctx := context.WithValue(r.Context(), WorkspaceContextKey, workspaceID)
-			ctx = context.WithValue(ctx, RoleContextKey, string(membership.Role()))
+			ctx = context.WithValue(ctx, RoleContextKey, role)
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	}
 }
 
+// roleRank orders the workspace roles from least to most privileged.
+var roleRank = map[string]int{"viewer": 0, "member": 1, "admin": 2, "owner": 3}
+
+// lowerRole returns the less privileged of two roles. An unknown role ranks
+// lowest.
+func lowerRole(a, b string) string {
+	rankA, okA := roleRank[a]
+	rankB, okB := roleRank[b]
+	if !okA || (okB && rankA <= rankB) {
+		return a
+	}
+	return b
+}
+
 // GetWorkspaceID retrieves the workspace ID from context.
 func GetWorkspaceID(ctx context.Context) (uuid.UUID, bool) {
 	workspaceID, ok := ctx.Value(WorkspaceContextKey).(uuid.UUID)
@@ -15,11 +15,14 @@ import (
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	"github.com/antti/home-warehouse/go-backend/internal/api/admin"
 	"github.com/antti/home-warehouse/go-backend/internal/api/health"
 	appMiddleware "github.com/antti/home-warehouse/go-backend/internal/api/middleware"
 	"github.com/antti/home-warehouse/go-backend/internal/config"
 	"github.com/antti/home-warehouse/go-backend/internal/domain/analytics"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/auth/apikey"
 	"github.com/antti/home-warehouse/go-backend/internal/domain/auth/authelia"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/auth/authevent"
 	"github.com/antti/home-warehouse/go-backend/internal/domain/auth/member"
 	"github.com/antti/home-warehouse/go-backend/internal/domain/auth/notification"
 	"github.com/antti/home-warehouse/go-backend/internal/domain/auth/oauth"
@@ -31,6 +34,7 @@ import (
 	"github.com/antti/home-warehouse/go-backend/internal/domain/batch"
 	"github.com/antti/home-warehouse/go-backend/internal/domain/events"
 	"github.com/antti/home-warehouse/go-backend/internal/domain/importexport"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/meta"
 	"github.com/antti/home-warehouse/go-backend/internal/domain/paperless"
 	"github.com/antti/home-warehouse/go-backend/internal/domain/shortlink"
 	"github.com/antti/home-warehouse/go-backend/internal/domain/sync"
@@ -40,31 +44,44 @@ import (
 	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/category"
 	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/company"
 	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/container"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/customfield"
 	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/declutter"
 	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/deleted"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/deletionimpact"
 	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/favorite"
 	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/importjob"
 	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/inventory"
 	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/item"
 	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/itemphoto"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/itemshare"
 	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/label"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/labelprint"
 	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/loan"
 	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/location"
 	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/maintenance"
 	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/movement"
 	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/pendingchange"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/quickadd"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/receiving"
 	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/repairattachment"
 	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/repairlog"
 	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/repairphoto"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/webhook"
 	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/wishlist"
+	"github.com/antti/home-warehouse/go-backend/internal/infra/email"
 	infraEvents "github.com/antti/home-warehouse/go-backend/internal/infra/events"
 	"github.com/antti/home-warehouse/go-backend/internal/infra/imageprocessor"
+	"github.com/antti/home-warehouse/go-backend/internal/infra/loginlockout"
 	infrapaperless "github.com/antti/home-warehouse/go-backend/internal/infra/paperless"
 	"github.com/antti/home-warehouse/go-backend/internal/infra/postgres"
+	"github.com/antti/home-warehouse/go-backend/internal/infra/printqueue"
 	"github.com/antti/home-warehouse/go-backend/internal/infra/queries"
 	"github.com/antti/home-warehouse/go-backend/internal/infra/queue"
+	"github.com/antti/home-warehouse/go-backend/internal/infra/recentviews"
 	"github.com/antti/home-warehouse/go-backend/internal/infra/storage"
+	"github.com/antti/home-warehouse/go-backend/internal/infra/tokenrevocation"
 	"github.com/antti/home-warehouse/go-backend/internal/infra/webpush"
+	"github.com/antti/home-warehouse/go-backend/internal/jobs"
 	"github.com/antti/home-warehouse/go-backend/internal/shared"
 	"github.com/antti/home-warehouse/go-backend/internal/shared/crypto"
 	"github.com/antti/home-warehouse/go-backend/internal/shared/jwt"
@@ -96,6 +113,35 @@ func (a sessionResolverAdapter) FindByTokenHash(ctx context.Context, tokenHash s
 	return sess, nil
 }
 
+// apiKeyAuthenticatorAdapter adapts *apikey.Service to the
+// appMiddleware.APIKeyAuthenticator interface, which the middleware package
+// declares for the same import-cycle reason as SessionResolver. It maps the
+// apikey errors to the middleware's.
+type apiKeyAuthenticatorAdapter struct {
+	svc *apikey.Service
+}
+
+func (a apiKeyAuthenticatorAdapter) AuthenticateAPIKey(ctx context.Context, key string) (*appMiddleware.APIKeyPrincipal, error) {
+	k, err := a.svc.Authenticate(ctx, key)
+	switch {
+	case errors.Is(err, apikey.ErrKeyExpired):
+		return nil, appMiddleware.ErrAPIKeyExpired
+	case errors.Is(err, apikey.ErrKeyRevoked):
+		return nil, appMiddleware.ErrAPIKeyRevoked
+	case errors.Is(err, apikey.ErrInvalidKey):
+		return nil, appMiddleware.ErrAPIKeyInvalid
+	case err != nil:
+		return nil, err
+	}
+	return &appMiddleware.APIKeyPrincipal{
+		ID:          k.ID(),
+		WorkspaceID: k.WorkspaceID(),
+		Name:        k.Name(),
+		Role:        string(k.Role()),
+		CreatedBy:   k.CreatedBy(),
+	}, nil
+}
+
 // memberUserFinder adapts the user service to the member.UserFinder port,
 // resolving an email to an existing user id and mapping a not-found user to
 // member.ErrUserNotRegistered (which the member handler maps to a 404).
@@ -117,6 +163,24 @@ func (f memberUserFinder) FindUserIDByEmail(ctx context.Context, email string) (
 	return u.ID(), nil
 }
 
+// heavyRouteTimeouts gives uploads, imports and exports the longer request
+// budget; everything else gets the default.
+func heavyRouteTimeouts(timeout time.Duration) []appMiddleware.RouteTimeout {
+	matches := []string{
+		"/export/",
+		"/import/",
+		"/imports/upload",
+		"/photos",
+		"/attachments",
+		"/avatar",
+	}
+	overrides := make([]appMiddleware.RouteTimeout, len(matches))
+	for i, m := range matches {
+		overrides[i] = appMiddleware.RouteTimeout{Match: m, Timeout: timeout}
+	}
+	return overrides
+}
+
 // NewRouter creates and configures the main router.
 func NewRouter(pool *pgxpool.Pool, cfg *config.Config) chi.Router {
 	r := chi.NewRouter()
@@ -127,9 +191,12 @@ func NewRouter(pool *pgxpool.Pool, cfg *config.Config) chi.Router {
 	// Global middleware
 	r.Use(middleware.RequestID) // Must be first to generate request IDs
 	r.Use(middleware.RealIP)
+	// Base of absolute URLs in responses (photo links); after RealIP so the
+	// forwarded headers are already trusted.
+	r.Use(appMiddleware.PublicBaseURL(cfg.PublicBaseURL))
 	r.Use(appMiddleware.StructuredLogger(logger)) // Structured logging with user context
 	r.Use(middleware.Recoverer)
-	r.Use(appMiddleware.TimeoutWithSkip(60*time.Second, "/sse"))
+	r.Use(appMiddleware.TimeoutByRoute(cfg.ServerTimeout, heavyRouteTimeouts(cfg.HeavyRequestTimeout), "/sse"))
 	r.Use(appMiddleware.SecurityHeaders)
 	r.Use(appMiddleware.MaxBodySize(cfg.MaxBodyBytes))
 	r.Use(appMiddleware.CORS)
@@ -139,11 +206,15 @@ func NewRouter(pool *pgxpool.Pool, cfg *config.Config) chi.Router {
 	// signal in config (replaces the previous per-package env checks).
 	user.SetSecureCookies(cfg.SecureCookies())
 
+	// Cap the page size of every listing.
+	shared.SetMaxPageSize(cfg.MaxPageSize)
+
 	// Create JWT service
 	jwtService := jwt.NewService(cfg.JWTSecret, cfg.JWTExpirationHours)
 
 	// Create event broadcaster for SSE
 	broadcaster := infraEvents.NewBroadcaster()
+	broadcaster.SetClientBuffer(cfg.SSEClientBuffer)
 
 	// Initialize Redis for background jobs (single source of truth: config's
 	// RedisURL, which already defaults REDIS_URL to redis://localhost:6379/0).
@@ -204,6 +275,7 @@ func NewRouter(pool *pgxpool.Pool, cfg *config.Config) chi.Router {
 	// Phase 3 repositories
 	itemRepo := postgres.NewItemRepository(pool)
 	itemPhotoRepo := postgres.NewItemPhotoRepository(pool, txManager)
+	customFieldRepo := postgres.NewCustomFieldRepository(pool)
 	inventoryRepo := postgres.NewInventoryRepository(pool)
 	// Phase 4 repositories
 	borrowerRepo := postgres.NewBorrowerRepository(pool)
@@ -226,6 +298,8 @@ func NewRouter(pool *pgxpool.Pool, cfg *config.Config) chi.Router {
 	repairAttachmentRepo := postgres.NewRepairAttachmentRepository(pool)
 	repairPhotoRepo := postgres.NewRepairPhotoRepository(pool)
 	declutterRepo := postgres.NewDeclutterRepository(pool)
+	deletionImpactRepo := postgres.NewDeletionImpactRepository(pool)
+	webhookRepo := postgres.NewWebhookRepository(pool)
 
 	// Initialize web push sender (optional - only if VAPID keys are configured)
 	var pushSender *webpush.Sender
@@ -247,20 +321,59 @@ func NewRouter(pool *pgxpool.Pool, cfg *config.Config) chi.Router {
 	// Initialize services
 	// Auth services
 	userSvc := user.NewService(userRepo)
+	userSvc.SetPasswordPolicy(user.PasswordPolicy{
+		MinLength:        cfg.PasswordMinLength,
+		RequireMixedCase: cfg.PasswordRequireMixedCase,
+		RequireDigit:     cfg.PasswordRequireDigit,
+	})
+	// Password reset needs a way to deliver the link (optional - only if Resend is configured)
+	if cfg.ResendAPIKey != "" {
+		userSvc.SetPasswordReset(
+			postgres.NewPasswordResetRepository(pool),
+			email.NewSender(cfg.ResendAPIKey, cfg.EmailFromAddress, cfg.EmailFromName),
+			cfg.AppURL,
+		)
+	} else {
+		log.Println("Password reset disabled (RESEND_API_KEY not configured)")
+	}
+	// TOTP two-factor. The repository is always wired so enrolled users are
+	// never let in without their code; without TOTP_SECRET_KEY they get 503.
+	var totpCipher user.SecretCipher
+	if cfg.TOTPSecretKey != "" {
+		enc, err := crypto.NewEncryptor(cfg.TOTPSecretKey)
+		if err != nil {
+			logger.Error("totp secret encryptor init failed; two-factor disabled", "error", err)
+		} else {
+			totpCipher = enc
+		}
+	}
+	userSvc.SetTwoFactor(postgres.NewTwoFactorRepository(pool), totpCipher, user.DefaultTOTPIssuer)
 	sessionSvc := session.NewService(sessionRepo)
+	// Access tokens are stateless; sign-out-everywhere cutoffs live in Redis
+	// for as long as an access token can.
+	tokenRevocations := tokenrevocation.NewStore(redisClient, time.Duration(cfg.JWTExpirationHours)*time.Hour)
+	sessionSvc.SetTokenRevoker(tokenRevocations)
 	workspaceSvc := workspace.NewService(workspaceRepo, memberRepo)
+	workspaceSvc.SetTransactor(txManager) // Workspace delete removes all data atomically
 	memberSvc := member.NewService(memberRepo, memberUserFinder{users: userSvc})
 	notificationSvc := notification.NewService(notificationRepo)
 	pushSubscriptionSvc := pushsubscription.NewService(pushSubscriptionRepo)
 	// Phase 1 services
 	categorySvc := category.NewService(categoryRepo)
+	categorySvc.SetTransactor(txManager) // Merges move items + children and delete atomically
 	locationSvc := location.NewService(locationRepo)
+	locationSvc.SetTransactor(txManager) // Evacuation moves inventory + containers atomically
+	// Shared delete rules: DELETE and GET .../deletion-impact agree on what blocks
+	deletionImpactSvc := deletionimpact.NewService(deletionImpactRepo)
+	categorySvc.SetDeletionGuard(deletionImpactSvc)
+	locationSvc.SetDeletionGuard(deletionImpactSvc)
 	containerSvc := container.NewService(containerRepo, locationRepo)
 	// Phase 2 services
 	companySvc := company.NewService(companyRepo)
 	labelSvc := label.NewService(labelRepo)
 	// Phase 3 services
 	itemSvc := item.NewService(itemRepo, categoryRepo)
+	customFieldSvc := customfield.NewService(customFieldRepo)
 
 	// Offline-first PWA: dedup replayed CREATE requests (Idempotency-Key
 	// header) so a lost-response retry returns the original entity instead
@@ -269,22 +382,74 @@ func NewRouter(pool *pgxpool.Pool, cfg *config.Config) chi.Router {
 	locationSvc.SetIdempotencyStore(idempotencyRepo)
 	containerSvc.SetIdempotencyStore(idempotencyRepo)
 	itemSvc.SetIdempotencyStore(idempotencyRepo)
+	itemSvc.SetRecentViewStore(recentviews.NewStore(redisClient))
+	itemSvc.SetTransactor(txManager) // Merges move inventory, labels, photos + delete atomically
+	itemSvc.SetCategoryRuleRepository(postgres.NewCategoryRuleRepository(pool))
+	labelPrintSvc := labelprint.NewService(printqueue.NewStore(redisClient), itemSvc)
+	labelPrintSvc.SetShortCodeLister(postgres.NewShortlinkRepository(pool))
 
 	// Initialize storage and image processor for item photos
 	uploadDir := getUploadDir()
+	// Temp files of uploads cut off by a crash or restart; the scheduler's
+	// hourly cleanup task removes any left after this.
+	if cfg.UploadTempMaxAge > 0 {
+		if removed, err := jobs.CleanupUploadDir(uploadDir, cfg.UploadTempMaxAge, time.Now()); err != nil {
+			log.Printf("Warning: failed to clean upload directory %s: %v", uploadDir, err)
+		} else if removed > 0 {
+			log.Printf("Removed %d stale temp files from %s", removed, uploadDir)
+		}
+	}
 	photoStorageDir := getPhotoStorageDir()
 	photoStorage, err := storage.NewLocalStorage(photoStorageDir)
 	if err != nil {
 		log.Fatalf("failed to initialize photo storage: %v", err)
 	}
-	imageProcessor := imageprocessor.NewProcessor(imageprocessor.DefaultConfig())
+	storageLayout, err := storage.LoadPathLayoutFromEnv()
+	if err != nil {
+		log.Fatalf("failed to load photo storage layout: %v", err)
+	}
+	photoStorage.SetPathLayout(storageLayout)
+	workspaceSvc.SetFileStorage(photoStorage)
+	imageConfig, err := imageprocessor.LoadConfigFromEnv()
+	if err != nil {
+		log.Fatalf("failed to load image processor config: %v", err)
+	}
+	imageProcessor := imageprocessor.NewProcessor(imageConfig)
+	// Served for thumbnails that cannot be served yet (pending) or at all (failed)
+	photoPlaceholders, err := itemphoto.LoadPlaceholders(os.Getenv("PHOTO_PLACEHOLDER_DIR"))
+	if err != nil {
+		log.Fatalf("failed to load thumbnail placeholders: %v", err)
+	}
 	imageHasher := imageprocessor.NewHasher() // Perceptual hasher for duplicate detection
 	itemPhotoSvc := itemphoto.NewService(itemPhotoRepo, photoStorage, imageProcessor, uploadDir)
 	itemPhotoSvc.SetAsynqClient(asynqClient) // Enable async thumbnail generation
 	itemPhotoSvc.SetHasher(imageHasher)      // Enable duplicate detection
+	itemPhotoSvc.SetTransactor(txManager)    // Atomic photo moves between items
+	itemPhotoSvc.SetDeduplicateUploads(imageConfig.DedupUploads)
+	itemPhotoSvc.SetAllowedMimeTypes(imageConfig.AllowedMimeTypes)
+	if imageConfig.HEICConverter != "" {
+		itemPhotoSvc.SetHEICConverter(imageProcessor) // Convert HEIC uploads to JPEG
+	}
+	itemPhotoSvc.SetSettingsRepository(postgres.NewPhotoSettingsRepository(pool))
+	if imageConfig.BlurHashEnabled {
+		itemPhotoSvc.SetBlurHasher(imageprocessor.NewBlurHasher()) // Enable blurhash placeholders
+	}
+	// Missing or failed thumbnails are generated while being served
+	thumbnailGenerator := jobs.NewThumbnailProcessor(pool, imageProcessor, photoStorage, broadcaster, uploadDir)
+	thumbnailGenerator.SetConfigVersion(imageConfig.ThumbnailVersion())
+	itemPhotoSvc.SetOnDemandThumbnails(thumbnailGenerator, imageConfig.OnDemandThumbnails, imageConfig.OnDemandThumbnailTimeout)
 	// Phase 5 services (movement service created before inventory to allow dependency)
 	movementSvc := movement.NewService(movementRepo)
 	inventorySvc := inventory.NewService(inventoryRepo, movementSvc, itemRepo, locationRepo, containerRepo)
+	inventorySvc.SetStockLevelRepository(postgres.NewStockLevelRepository(pool))
+	inventorySvc.SetAttentionRepository(postgres.NewAttentionRepository(pool))
+	inventorySvc.SetSettingsRepository(postgres.NewInventorySettingsRepository(pool))
+	inventorySvc.SetCategoryDefaultsRepository(postgres.NewCategoryInventoryDefaultsRepository(pool))
+	inventorySvc.SetSnapshotRepository(postgres.NewInventorySnapshotRepository(pool))
+	itemShareSvc := itemshare.NewService(postgres.NewItemShareRepository(pool), itemRepo, inventoryRepo)
+	inventorySvc.SetPhotoRequirement(itemPhotoSvc) // Workspace require-photo rule can block new inventory
+	inventorySvc.SetTransactor(txManager)          // Bulk status updates, moves and consumption save atomically
+	inventorySvc.SetEmptyAction(inventory.EmptyAction(cfg.InventoryEmptyAction))
 	// Offline-first PWA C-create: inventory (stock) CREATE also dedupes on the
 	// Idempotency-Key so a replayed offline create returns the original entry
 	// (see idempotency package; wired here because inventorySvc is constructed
@@ -292,7 +457,11 @@ func NewRouter(pool *pgxpool.Pool, cfg *config.Config) chi.Router {
 	inventorySvc.SetIdempotencyStore(idempotencyRepo)
 	// Phase 4 services
 	borrowerSvc := borrower.NewService(borrowerRepo)
+	borrowerSvc.SetDefaultPhoneRegion(cfg.BorrowerPhoneRegion)
 	loanSvc := loan.NewService(loanRepo, inventoryRepo, txManager)
+	loanSvc.SetSettingsRepository(postgres.NewLoanSettingsRepository(pool))
+	loanSvc.SetAvailabilityPolicy(inventorySvc)
+	loanSvc.SetItemLookup(itemRepo)
 	repairLogSvc := repairlog.NewService(repairLogRepo, inventoryRepo)
 	maintenanceSvc := maintenance.NewService(maintenanceRepo, inventoryRepo, txManager)
 	wishlistSvc := wishlist.NewService(wishlistRepo, categoryRepo, itemRepo)
@@ -321,13 +490,22 @@ func NewRouter(pool *pgxpool.Pool, cfg *config.Config) chi.Router {
 	attachmentSvc := attachment.NewService(fileRepo, attachmentRepo, photoStorage)
 	activitySvc := activity.NewService(activityRepo)
 	// Every entity SSE publish also writes an activity_log row (single chokepoint).
-	broadcaster.SetTap(activity.NewEventTap(activitySvc, logger))
+	broadcaster.AddTap(activity.NewEventTap(activitySvc, logger))
+	// Subscribed events are also queued for delivery to workspace webhooks.
+	webhookSvc := webhook.NewService(webhookRepo, asynqClient)
+	broadcaster.AddTap(webhook.NewEventTap(webhookSvc, logger))
 	deletedSvc := deleted.NewService(deletedRepo)
+	deletedSvc.SetSettingsRepository(postgres.NewTrashSettingsRepository(pool), jobs.DefaultCleanupConfig().DeletedRecordsRetentionDays)
 	favoriteSvc := favorite.NewService(favoriteRepo)
 	// Analytics service
 	analyticsSvc := analytics.NewService(analyticsRepo)
+	analyticsSvc.SetStatsCacheTTL(cfg.WorkspaceStatsCacheTTL)
 	// Import/Export and Sync services
 	importExportSvc := importexport.NewService(importExportRepo)
+	importExportSvc.SetTransactor(txManager) // Bundle imports and item transfers write atomically
+	importExportSvc.SetRoleLookup(memberSvc)
+	importExportSvc.SetPathLayout(storageLayout)  // Bundle photo paths follow the configured storage layout
+	importExportSvc.SetPhotoStorage(photoStorage) // Bundle imports copy photo files
 	workspaceBackupSvc := importexport.NewWorkspaceBackupService(queries.New(pool))
 	syncSvc := sync.NewService(syncRepo)
 	// Barcode service
@@ -358,6 +536,14 @@ func NewRouter(pool *pgxpool.Pool, cfg *config.Config) chi.Router {
 	if pushSender != nil {
 		pendingChangeSvc.SetPushSender(pushSender)
 	}
+	// Quick add: item + inventory + photo in one request; approved member
+	// quick adds are applied through the same service
+	quickAddSvc := quickadd.NewService(itemSvc, inventorySvc, itemPhotoSvc)
+	quickAddSvc.SetTransactor(txManager)
+	pendingChangeSvc.SetQuickAddApplier(quickAddSvc)
+	// Receiving: a scanned shipment booked into stock, one transaction per line
+	receivingSvc := receiving.NewService(itemSvc, inventorySvc, movementSvc)
+	receivingSvc.SetTransactor(txManager)
 
 	// Initialize OAuth service and handler
 	oauthRepo := postgres.NewOAuthRepository(pool)
@@ -378,10 +564,27 @@ func NewRouter(pool *pgxpool.Pool, cfg *config.Config) chi.Router {
 
 	// Create session handler
 	sessionHandler := session.NewHandler(sessionSvc)
+
+	// Security log of sign-ins, password/two-factor changes and session
+	// revocations. The log stays readable when recording is turned off.
+	authEventSvc := authevent.NewService(postgres.NewAuthEventRepository(pool))
+	if cfg.AuthEventLog {
+		userHandler.SetAuthEventRecorder(authEventSvc)
+		sessionHandler.SetAuthEventRecorder(authEventSvc)
+	}
+	// API keys for machine clients (Authorization: ApiKey <key>)
+	apiKeySvc := apikey.NewService(postgres.NewAPIKeyRepository(pool))
 	analyticsHandler := analytics.NewHandler(analyticsSvc)
 	importExportHandler := importexport.NewHandler(importExportSvc, workspaceBackupSvc)
+	importExportHandler.SetBroadcaster(broadcaster)
 	syncHandler := sync.NewHandler(syncSvc)
 
+	// Brute-force lockout per email and IP address, on top of the per-IP
+	// rate limit below
+	if cfg.LoginLockoutEnabled {
+		userHandler.SetLoginLimiter(loginlockout.NewStore(redisClient, cfg.LoginLockoutMaxAttempts, cfg.LoginLockoutWindow, cfg.LoginLockoutDuration))
+	}
+
 	// Rate limiter for auth endpoints (20 requests per minute per IP)
 	authRateLimiter := appMiddleware.NewRateLimiter(20, time.Minute)
 
@@ -438,6 +641,18 @@ func NewRouter(pool *pgxpool.Pool, cfg *config.Config) chi.Router {
 	// Register barcode lookup (public, no auth required)
 	barcode.RegisterRoutes(api, barcodeSvc)
 
+	// Public item share links (no auth, rate-limited at 60/min per IP so
+	// tokens cannot be guessed at speed)
+	r.Group(func(r chi.Router) {
+		shareRateLimiter := appMiddleware.NewRateLimiter(60, time.Minute)
+		r.Use(appMiddleware.RateLimit(shareRateLimiter))
+		sharedConfig := huma.DefaultConfig(apiTitle, "1.0.0")
+		sharedConfig.DocsPath = ""
+		sharedConfig.OpenAPIPath = ""
+		sharedAPI := humachi.New(r, sharedConfig)
+		itemshare.RegisterPublicRoutes(sharedAPI, itemShareSvc)
+	})
+
 	// QR shortlink redirect (s.go/{code} -> Angie rewrites to /r/{code}).
 	// Registered at the TOP-LEVEL chi router, OFF the /api workspace tree and
 	// NOT behind appMiddleware.JWTAuth: the handler reads the access_token
@@ -449,7 +664,9 @@ func NewRouter(pool *pgxpool.Pool, cfg *config.Config) chi.Router {
 
 	// Protected routes
 	r.Group(func(r chi.Router) {
-		r.Use(appMiddleware.JWTAuth(jwtService))
+		// API keys authenticate first; JWTAuth skips requests they authenticated
+		r.Use(appMiddleware.APIKeyAuth(apiKeyAuthenticatorAdapter{apiKeySvc}))
+		r.Use(appMiddleware.JWTAuthWithRevocation(jwtService, tokenRevocations))
 		// Resolve the current server-side session from the refresh_token cookie
 		// so is_current and revoke-all-others work (AUTH-07). Best-effort:
 		// cookieless callers (SSE, Bearer-only) still authenticate via JWTAuth.
@@ -470,12 +687,14 @@ func NewRouter(pool *pgxpool.Pool, cfg *config.Config) chi.Router {
 
 		// Register session routes
 		sessionHandler.RegisterRoutes(protectedAPI)
+		authevent.RegisterRoutes(protectedAPI, authEventSvc)
 
 		// Register avatar routes (uses Chi directly for multipart handling)
 		userHandler.RegisterAvatarRoutes(r)
 
 		// Register admin routes (requires superuser check in handler)
 		userHandler.RegisterAdminRoutes(protectedAPI)
+		admin.RegisterJobRoutes(protectedAPI, admin.NewJobRunner(jobs.NewJobTrigger(asynqClient, cfg.SchedulerMaxRetry)))
 
 		// Register workspace management routes (user-level)
 		workspace.RegisterRoutes(protectedAPI, workspaceSvc)
@@ -486,6 +705,9 @@ func NewRouter(pool *pgxpool.Pool, cfg *config.Config) chi.Router {
 		// Register push subscription routes (user-level)
 		pushsubscription.RegisterRoutes(protectedAPI, pushSubscriptionSvc)
 
+		// Register enum vocabularies for the frontend
+		meta.RegisterRoutes(protectedAPI)
+
 		// Workspace-scoped routes
 		r.Route("/workspaces/{workspace_id}", func(r chi.Router) {
 			r.Use(appMiddleware.Workspace(appMiddleware.NewMemberAdapter(memberRepo)))
@@ -515,11 +737,16 @@ func NewRouter(pool *pgxpool.Pool, cfg *config.Config) chi.Router {
 
 			// Register workspace member routes (auth domain)
 			member.RegisterRoutes(wsAPI, memberSvc)
+			authevent.RegisterWorkspaceRoutes(wsAPI, authEventSvc)
+			apikey.RegisterRoutes(wsAPI, apiKeySvc)
 
 			// Register Phase 1 domain routes (hierarchical data)
 			category.RegisterRoutes(wsAPI, categorySvc, broadcaster)
 			location.RegisterRoutes(wsAPI, locationSvc, broadcaster)
+			location.RegisterLabelRoutes(wsAPI, locationSvc, cfg.AppURL)
+			deletionimpact.RegisterRoutes(wsAPI, deletionImpactSvc)
 			container.RegisterRoutes(wsAPI, containerSvc, broadcaster)
+			container.RegisterLabelRoutes(wsAPI, containerSvc, cfg.AppURL)
 
 			// Register Phase 2 domain routes (supporting data)
 			company.RegisterRoutes(wsAPI, companySvc, broadcaster)
@@ -527,30 +754,48 @@ func NewRouter(pool *pgxpool.Pool, cfg *config.Config) chi.Router {
 
 			// Item photo URL generator — shared by itemphoto routes AND item handler
 			// (the latter uses it to emit primary_photo_thumbnail_url on ItemResponse).
-			photoURLGenerator := func(workspaceID, itemID, photoID uuid.UUID, isThumbnail bool) string {
+			// The base is PUBLIC_BASE_URL, or derived from the request when unset.
+			photoURLGenerator := func(ctx context.Context, workspaceID, itemID, photoID uuid.UUID, isThumbnail bool) string {
+				baseURL := appMiddleware.GetPublicBaseURL(ctx)
 				if isThumbnail {
 					return fmt.Sprintf("%s/workspaces/%s/items/%s/photos/%s/thumbnail",
-						cfg.BackendURL, workspaceID, itemID, photoID)
+						baseURL, workspaceID, itemID, photoID)
 				}
 				return fmt.Sprintf("%s/workspaces/%s/items/%s/photos/%s",
-					cfg.BackendURL, workspaceID, itemID, photoID)
+					baseURL, workspaceID, itemID, photoID)
 			}
 
 			// Register Phase 3 domain routes (core inventory)
 			// Item handler takes the itemphoto service to decorate ItemResponse with
-			// a primary photo thumbnail URL in list/detail endpoints (61-01).
-			item.RegisterRoutes(wsAPI, itemSvc, broadcaster, itemPhotoSvc, photoURLGenerator)
+			// a primary photo thumbnail URL in list/detail endpoints (61-01), and
+			// the custom field service to embed custom values.
+			item.RegisterRoutes(wsAPI, itemSvc, broadcaster, itemPhotoSvc, photoURLGenerator, customFieldSvc)
+			customfield.RegisterRoutes(wsAPI, customFieldSvc)
 			inventory.RegisterRoutes(wsAPI, inventorySvc, broadcaster)
+			inventory.RegisterStockLevelRoutes(wsAPI, inventorySvc)
+			inventory.RegisterAttentionRoutes(wsAPI, inventorySvc)
+			inventory.RegisterSettingsRoutes(wsAPI, inventorySvc)
+			inventory.RegisterCategoryDefaultsRoutes(wsAPI, inventorySvc)
+			inventory.RegisterSnapshotRoutes(wsAPI, inventorySvc)
+			item.RegisterCategoryRuleRoutes(wsAPI, itemSvc)
+			itemshare.RegisterRoutes(wsAPI, itemShareSvc)
+			receiving.RegisterRoutes(wsAPI, receivingSvc, broadcaster)
+			labelprint.RegisterRoutes(wsAPI, labelPrintSvc)
 
 			// Register item photo routes
 			itemphoto.RegisterRoutes(wsAPI, itemPhotoSvc, broadcaster, photoURLGenerator)
+			itemphoto.RegisterSettingsRoutes(wsAPI, itemPhotoSvc)
 
 			// Register photo upload and serve handlers (use Chi directly for multipart)
 			storageGetter := &photoStorageGetter{storage: photoStorage}
 			itemphoto.RegisterUploadHandler(r, itemPhotoSvc, broadcaster, photoURLGenerator)
-			itemphoto.RegisterServeHandler(r, itemPhotoSvc, storageGetter)
+			itemphoto.RegisterServeHandler(r, itemPhotoSvc, storageGetter, photoPlaceholders)
 			itemphoto.RegisterBulkHandler(r, itemPhotoSvc, storageGetter, imageHasher, broadcaster, photoURLGenerator)
 
+			// Quick add is multipart too; the handler routes members through
+			// approval itself since the middleware only captures JSON bodies
+			quickadd.RegisterRoutes(r, quickAddSvc, pendingChangeAdapter, broadcaster)
+
 			// Attachment byte upload + serve (14b-02) — Chi multipart, alongside
 			// the huma JSON metadata routes registered below. Distinct /file
 			// suffixes avoid a huma route collision at boot.
@@ -566,6 +811,10 @@ func NewRouter(pool *pgxpool.Pool, cfg *config.Config) chi.Router {
 			// are stable across endpoints.
 			loanDecorationLookup := postgres.NewLoanDecorationLookup(pool, itemPhotoSvc, postgres.PhotoURLGenerator(photoURLGenerator))
 			loan.RegisterRoutes(wsAPI, loanSvc, broadcaster, loanDecorationLookup)
+			loan.RegisterReceiptRoutes(wsAPI, loanSvc, postgres.NewLoanReceiptLookup(pool))
+			loanSvc.SetItemNameLookup(loanDecorationLookup)
+			loan.RegisterBorrowerSummaryRoutes(wsAPI, loanSvc)
+			loan.RegisterSettingsRoutes(wsAPI, loanSvc)
 
 			// Register repair log routes
 			repairlog.RegisterRoutes(wsAPI, repairLogSvc, broadcaster)
@@ -574,13 +823,14 @@ func NewRouter(pool *pgxpool.Pool, cfg *config.Config) chi.Router {
 			paperless.RegisterRoutes(wsAPI, paperlessSvc)
 
 			// Register repair photo routes
-			repairPhotoURLGenerator := func(workspaceID, repairLogID, photoID uuid.UUID, isThumbnail bool) string {
+			repairPhotoURLGenerator := func(ctx context.Context, workspaceID, repairLogID, photoID uuid.UUID, isThumbnail bool) string {
+				baseURL := appMiddleware.GetPublicBaseURL(ctx)
 				if isThumbnail {
 					return fmt.Sprintf("%s/workspaces/%s/repairs/%s/photos/%s/thumbnail",
-						cfg.BackendURL, workspaceID, repairLogID, photoID)
+						baseURL, workspaceID, repairLogID, photoID)
 				}
 				return fmt.Sprintf("%s/workspaces/%s/repairs/%s/photos/%s/file",
-					cfg.BackendURL, workspaceID, repairLogID, photoID)
+					baseURL, workspaceID, repairLogID, photoID)
 			}
 			repairphoto.RegisterRoutes(wsAPI, repairPhotoSvc, broadcaster, repairPhotoURLGenerator)
 			repairPhotoStorageGetter := &repairPhotoStorageGetter{storage: photoStorage}
@@ -596,6 +846,7 @@ func NewRouter(pool *pgxpool.Pool, cfg *config.Config) chi.Router {
 			// Register Phase 5 domain routes (activity & sync)
 			activity.RegisterRoutes(wsAPI, activitySvc)
 			deleted.RegisterRoutes(wsAPI, deletedSvc)
+			deleted.RegisterSettingsRoutes(wsAPI, deletedSvc)
 			favorite.RegisterRoutes(wsAPI, favoriteSvc, broadcaster)
 			movement.RegisterRoutes(wsAPI, movementSvc)
 			attachment.RegisterRoutes(wsAPI, attachmentSvc, broadcaster)
@@ -619,6 +870,9 @@ func NewRouter(pool *pgxpool.Pool, cfg *config.Config) chi.Router {
 
 			// Register pending change management routes (approval workflow)
 			pendingchange.RegisterRoutes(wsAPI, pendingChangeSvc, userRepo)
+
+			// Register outgoing webhook management routes
+			webhook.RegisterRoutes(wsAPI, webhookSvc)
 		})
 	})
 
@@ -19,24 +19,89 @@ type Config struct {
 	// Redis
 	RedisURL string
 
+	// Scheduler retry policy for background jobs. Failed tasks are retried
+	// with exponential backoff (base doubling per attempt, capped at max);
+	// once MaxRetry is exhausted they land in the dead-letter archive.
+	SchedulerMaxRetry       int
+	SchedulerRetryBaseDelay time.Duration
+	SchedulerRetryMaxDelay  time.Duration
+
+	// Import worker pacing. ImportRowsPerSecond caps how fast rows are
+	// processed (0 = unlimited) so a large import does not starve the API
+	// of database connections. ImportBatchSize is how many row errors and
+	// results are buffered before being written in one COPY (1 or less writes
+	// each row as it is processed). ImportConcurrency is how many import
+	// jobs the worker processes at once; the rest wait in the queue.
+	ImportRowsPerSecond int
+	ImportBatchSize     int
+	ImportConcurrency   int
+
+	// UploadTempMaxAge is how old a file in the photo processing directory
+	// (PHOTO_UPLOAD_DIR) must be before it is treated as left behind by a
+	// crashed upload or thumbnail job and removed. Zero disables the cleanup.
+	UploadTempMaxAge time.Duration
+
+	// DeletedRecordsArchiveDir is where the deleted records cleanup writes a
+	// JSON snapshot of the records it purges. Empty disables the snapshot.
+	DeletedRecordsArchiveDir string
+
 	// JWT
 	JWTSecret          string
 	JWTAlgorithm       string
 	JWTExpirationHours int
 
+	// Password policy applied on sign-up and password change. The minimum
+	// length can only be raised above the default of 8; the character-class
+	// requirements are off unless enabled.
+	PasswordMinLength        int
+	PasswordRequireMixedCase bool
+	PasswordRequireDigit     bool
+
 	// Server
-	ServerHost    string
-	ServerPort    int
-	ServerTimeout time.Duration
+	ServerHost string
+	ServerPort int
+
+	// ServerTimeout is the per-request budget for ordinary API requests.
+	// HeavyRequestTimeout is the longer budget for uploads, imports and
+	// exports (see api.heavyRouteTimeouts).
+	ServerTimeout       time.Duration
+	HeavyRequestTimeout time.Duration
 
 	// MaxBodyBytes is the global HTTP request body size cap (bytes).
 	MaxBodyBytes int64
 
+	// WorkspaceStatsCacheTTL is how long the dashboard stats summary is
+	// reused before being recomputed. Zero disables the cache.
+	WorkspaceStatsCacheTTL time.Duration
+
+	// MaxPageSize caps the page size of every listing; larger requests are
+	// clamped to it. Defaults to shared.MaxPageSize (100).
+	MaxPageSize int
+
+	// SSEClientBuffer is how many events each SSE connection queues before
+	// the oldest queued event is dropped to make room for a new one.
+	SSEClientBuffer int
+
+	// InventoryEmptyAction is what happens to an inventory entry consumed
+	// down to zero: "keep", "dispose" (the default) or "archive".
+	InventoryEmptyAction string
+
+	// BorrowerPhoneRegion is the country (ISO 3166-1 alpha-2) borrower
+	// phone numbers without a country code are assumed to be in when they
+	// are normalized to E.164. Empty requires a country code on every number.
+	BorrowerPhoneRegion string
+
 	// Email (Resend)
 	ResendAPIKey     string
 	EmailFromAddress string
 	EmailFromName    string
 
+	// SMS (Twilio). SMS loan reminders are skipped unless all three are set.
+	// TwilioFrom is a phone number or messaging service SID.
+	TwilioAccountSID string
+	TwilioAuthToken  string
+	TwilioFrom       string
+
 	// OAuth
 	GoogleClientID     string
 	GoogleClientSecret string
@@ -61,9 +126,32 @@ type Config struct {
 	// API tokens at rest (AES-256-GCM). Empty disables token storage.
 	PaperlessTokenKey string
 
+	// Two-factor authentication. Key material for encrypting TOTP secrets at
+	// rest (AES-256-GCM). Empty disables TOTP enrollment and sign-in for
+	// users who already enrolled.
+	TOTPSecretKey string
+
+	// Security log of authentication events (GET /users/me/security-log).
+	// AuthEventLog turns the logging off when false; the activity cleanup
+	// removes events older than AuthEventRetentionDays (0 keeps them forever).
+	AuthEventLog           bool
+	AuthEventRetentionDays int
+
+	// Brute-force sign-in lockout. After LoginLockoutMaxAttempts failed
+	// sign-ins for one email from one IP address within LoginLockoutWindow,
+	// that pair gets 429 for LoginLockoutDuration. Tracked in Redis.
+	LoginLockoutEnabled     bool
+	LoginLockoutMaxAttempts int
+	LoginLockoutWindow      time.Duration
+	LoginLockoutDuration    time.Duration
+
 	// URLs
 	AppURL     string // Frontend URL
 	BackendURL string
+	// PublicBaseURL is the base of absolute URLs in API responses, such as
+	// photo links (PUBLIC_BASE_URL, e.g. "https://warehouse.example.com/api").
+	// Empty derives it from each request and its X-Forwarded-* headers.
+	PublicBaseURL string
 
 	// AppEnv is the deployment environment name (APP_ENV, e.g. "production").
 	AppEnv string
@@ -85,6 +173,11 @@ func (c *Config) SecureCookies() bool {
 	return c.IsProduction()
 }
 
+// SMSEnabled reports whether Twilio is configured for sending SMS.
+func (c *Config) SMSEnabled() bool {
+	return c.TwilioAccountSID != "" && c.TwilioAuthToken != "" && c.TwilioFrom != ""
+}
+
 // Load reads configuration from environment variables with sensible defaults.
 func Load() *Config {
 	return &Config{
@@ -100,6 +193,22 @@ func Load() *Config {
 		// Redis
 		RedisURL: getEnv("REDIS_URL", "redis://localhost:6379/0"),
 
+		// Scheduler
+		SchedulerMaxRetry:       getEnvInt("SCHEDULER_MAX_RETRY", 5),
+		SchedulerRetryBaseDelay: time.Duration(getEnvInt("SCHEDULER_RETRY_BASE_DELAY_SECONDS", 30)) * time.Second,
+		SchedulerRetryMaxDelay:  time.Duration(getEnvInt("SCHEDULER_RETRY_MAX_DELAY_SECONDS", 3600)) * time.Second,
+
+		// Import worker
+		ImportRowsPerSecond: getEnvInt("IMPORT_ROWS_PER_SECOND", 0),
+		ImportBatchSize:     getEnvInt("IMPORT_BATCH_SIZE", 100),
+		ImportConcurrency:   getEnvInt("IMPORT_CONCURRENCY", 1),
+
+		// Upload temp files
+		UploadTempMaxAge: time.Duration(getEnvInt("UPLOAD_TEMP_MAX_AGE_HOURS", 24)) * time.Hour,
+
+		// Deleted records cleanup
+		DeletedRecordsArchiveDir: getEnv("DELETED_RECORDS_ARCHIVE_DIR", ""),
+
 		// JWT
 		// No usable default: Validate() rejects empty/weak secrets and only
 		// substitutes a clearly-logged dev fallback when DebugMode is on.
@@ -107,17 +216,36 @@ func Load() *Config {
 		JWTAlgorithm:       getEnv("JWT_ALGORITHM", "HS256"),
 		JWTExpirationHours: getEnvInt("JWT_EXPIRATION_HOURS", 24),
 
+		// Password policy
+		PasswordMinLength:        getEnvInt("PASSWORD_MIN_LENGTH", 8),
+		PasswordRequireMixedCase: getEnvBool("PASSWORD_REQUIRE_MIXED_CASE", false),
+		PasswordRequireDigit:     getEnvBool("PASSWORD_REQUIRE_DIGIT", false),
+
 		// Server
-		ServerHost:    getEnv("SERVER_HOST", "0.0.0.0"),
-		ServerPort:    getEnvInt("SERVER_PORT", 8080),
-		ServerTimeout: time.Duration(getEnvInt("SERVER_TIMEOUT_SECONDS", 60)) * time.Second,
-		MaxBodyBytes:  int64(getEnvInt("MAX_BODY_SIZE_MB", 64)) << 20,
+		ServerHost:          getEnv("SERVER_HOST", "0.0.0.0"),
+		ServerPort:          getEnvInt("SERVER_PORT", 8080),
+		ServerTimeout:       time.Duration(getEnvInt("SERVER_TIMEOUT_SECONDS", 30)) * time.Second,
+		HeavyRequestTimeout: time.Duration(getEnvInt("HEAVY_REQUEST_TIMEOUT_SECONDS", 600)) * time.Second,
+		MaxBodyBytes:        int64(getEnvInt("MAX_BODY_SIZE_MB", 64)) << 20,
+
+		WorkspaceStatsCacheTTL: time.Duration(getEnvInt("WORKSPACE_STATS_CACHE_SECONDS", 30)) * time.Second,
+
+		MaxPageSize:     getEnvInt("MAX_PAGE_SIZE", 100),
+		SSEClientBuffer: getEnvInt("SSE_CLIENT_BUFFER", 100),
+
+		InventoryEmptyAction: getEnv("INVENTORY_EMPTY_ACTION", "dispose"),
+		BorrowerPhoneRegion:  strings.ToUpper(getEnv("BORROWER_PHONE_REGION", "FI")),
 
 		// Email
 		ResendAPIKey:     getEnv("RESEND_API_KEY", ""),
 		EmailFromAddress: getEnv("EMAIL_FROM_ADDRESS", "noreply@example.com"),
 		EmailFromName:    getEnv("EMAIL_FROM_NAME", "Home Warehouse"),
 
+		// SMS
+		TwilioAccountSID: getEnv("TWILIO_ACCOUNT_SID", ""),
+		TwilioAuthToken:  getEnv("TWILIO_AUTH_TOKEN", ""),
+		TwilioFrom:       getEnv("TWILIO_FROM", ""),
+
 		// OAuth
 		GoogleClientID:     getEnv("GOOGLE_CLIENT_ID", ""),
 		GoogleClientSecret: getEnv("GOOGLE_CLIENT_SECRET", ""),
@@ -136,10 +264,24 @@ func Load() *Config {
 		// Paperless-ngx DMS integration
 		PaperlessTokenKey: getEnv("PAPERLESS_TOKEN_KEY", ""),
 
+		// Two-factor authentication
+		TOTPSecretKey: getEnv("TOTP_SECRET_KEY", ""),
+
+		// Security log
+		AuthEventLog:           getEnvBool("AUTH_EVENT_LOG", true),
+		AuthEventRetentionDays: getEnvInt("AUTH_EVENT_RETENTION_DAYS", 365),
+
+		// Sign-in lockout
+		LoginLockoutEnabled:     getEnvBool("LOGIN_LOCKOUT", true),
+		LoginLockoutMaxAttempts: getEnvInt("LOGIN_LOCKOUT_MAX_ATTEMPTS", 10),
+		LoginLockoutWindow:      time.Duration(getEnvInt("LOGIN_LOCKOUT_WINDOW_MINUTES", 15)) * time.Minute,
+		LoginLockoutDuration:    time.Duration(getEnvInt("LOGIN_LOCKOUT_DURATION_MINUTES", 15)) * time.Minute,
+
 		// URLs
-		AppURL:     getEnv("APP_URL", "http://localhost:3000"),
-		BackendURL: getEnv("BACKEND_URL", "http://localhost:8080"),
-		AppEnv:     getEnv("APP_ENV", ""),
+		AppURL:        getEnv("APP_URL", "http://localhost:3000"),
+		BackendURL:    getEnv("BACKEND_URL", "http://localhost:8080"),
+		PublicBaseURL: getEnv("PUBLIC_BASE_URL", ""),
+		AppEnv:        getEnv("APP_ENV", ""),
 
 		// Feature Flags
 		DebugMode: getEnvBool("DEBUG", false),
@@ -169,11 +311,44 @@ func (c *Config) Validate() error {
 	if c.ServerPort < 1 || c.ServerPort > 65535 {
 		return errors.New("SERVER_PORT must be between 1 and 65535")
 	}
+	// bcrypt only hashes the first 72 bytes, so a longer minimum would be
+	// meaningless.
+	if c.PasswordMinLength < 8 || c.PasswordMinLength > 72 {
+		return errors.New("PASSWORD_MIN_LENGTH must be between 8 and 72")
+	}
 	// Refuse to trust Authelia headers without a shared secret -- otherwise any
 	// client could forge Remote-Email and impersonate any user.
 	if c.AutheliaEnabled && c.AutheliaSharedSecret == "" {
 		return errors.New("AUTHELIA_SHARED_SECRET is required when AUTHELIA_ENABLED is true")
 	}
+	if c.ImportRowsPerSecond < 0 {
+		return errors.New("IMPORT_ROWS_PER_SECOND must not be negative")
+	}
+	if c.ImportConcurrency < 0 {
+		return errors.New("IMPORT_CONCURRENCY must not be negative")
+	}
+	if c.AuthEventRetentionDays < 0 {
+		return errors.New("AUTH_EVENT_RETENTION_DAYS must not be negative")
+	}
+	if c.LoginLockoutEnabled {
+		if c.LoginLockoutMaxAttempts < 1 {
+			return errors.New("LOGIN_LOCKOUT_MAX_ATTEMPTS must be at least 1")
+		}
+		if c.LoginLockoutWindow <= 0 {
+			return errors.New("LOGIN_LOCKOUT_WINDOW_MINUTES must be positive")
+		}
+		if c.LoginLockoutDuration <= 0 {
+			return errors.New("LOGIN_LOCKOUT_DURATION_MINUTES must be positive")
+		}
+	}
+	switch c.InventoryEmptyAction {
+	case "", "keep", "dispose", "archive":
+	default:
+		return errors.New("INVENTORY_EMPTY_ACTION must be one of keep, dispose, archive")
+	}
+	if c.BorrowerPhoneRegion != "" && len(c.BorrowerPhoneRegion) != 2 {
+		return errors.New("BORROWER_PHONE_REGION must be a two-letter country code")
+	}
 	return nil
 }
 
@@ -3,6 +3,7 @@ package config
 import (
 	"os"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -19,11 +20,37 @@ func TestLoad(t *testing.T) {
 		assert.Equal(t, 25, cfg.DatabaseMaxConn)
 		assert.Equal(t, 5, cfg.DatabaseMinConn)
 		assert.Equal(t, "redis://localhost:6379/0", cfg.RedisURL)
+		assert.Equal(t, 5, cfg.SchedulerMaxRetry)
+		assert.Equal(t, 30*time.Second, cfg.SchedulerRetryBaseDelay)
+		assert.Equal(t, time.Hour, cfg.SchedulerRetryMaxDelay)
+		assert.Equal(t, 0, cfg.ImportRowsPerSecond)
+		assert.Equal(t, 100, cfg.ImportBatchSize)
+		assert.Equal(t, 1, cfg.ImportConcurrency)
+		assert.Equal(t, 24*time.Hour, cfg.UploadTempMaxAge)
+		assert.Empty(t, cfg.DeletedRecordsArchiveDir)
+		assert.True(t, cfg.AuthEventLog)
+		assert.Equal(t, 365, cfg.AuthEventRetentionDays)
+		assert.True(t, cfg.LoginLockoutEnabled)
+		assert.Equal(t, 10, cfg.LoginLockoutMaxAttempts)
+		assert.Equal(t, 15*time.Minute, cfg.LoginLockoutWindow)
+		assert.Equal(t, 15*time.Minute, cfg.LoginLockoutDuration)
 		assert.Equal(t, "", cfg.JWTSecret)
 		assert.Equal(t, "HS256", cfg.JWTAlgorithm)
 		assert.Equal(t, 24, cfg.JWTExpirationHours)
+		assert.Equal(t, 8, cfg.PasswordMinLength)
+		assert.False(t, cfg.PasswordRequireMixedCase)
+		assert.False(t, cfg.PasswordRequireDigit)
 		assert.Equal(t, "0.0.0.0", cfg.ServerHost)
 		assert.Equal(t, 8080, cfg.ServerPort)
+		assert.Equal(t, 30*time.Second, cfg.ServerTimeout)
+		assert.Equal(t, 10*time.Minute, cfg.HeavyRequestTimeout)
+		assert.Equal(t, 30*time.Second, cfg.WorkspaceStatsCacheTTL)
+		assert.Equal(t, 100, cfg.MaxPageSize)
+		assert.Equal(t, 100, cfg.SSEClientBuffer)
+		assert.Equal(t, "dispose", cfg.InventoryEmptyAction)
+		assert.Equal(t, "FI", cfg.BorrowerPhoneRegion)
+		assert.Empty(t, cfg.TwilioAccountSID)
+		assert.False(t, cfg.SMSEnabled())
 		assert.Equal(t, "http://localhost:3000", cfg.AppURL)
 		assert.Equal(t, "http://localhost:8080", cfg.BackendURL)
 		assert.False(t, cfg.DebugMode)
@@ -36,15 +63,31 @@ func TestLoad(t *testing.T) {
 		os.Setenv("DATABASE_MAX_CONN", "50")
 		os.Setenv("DATABASE_MIN_CONN", "10")
 		os.Setenv("REDIS_URL", "redis://localhost:6380/1")
+		os.Setenv("SCHEDULER_MAX_RETRY", "8")
+		os.Setenv("SCHEDULER_RETRY_BASE_DELAY_SECONDS", "10")
+		os.Setenv("SCHEDULER_RETRY_MAX_DELAY_SECONDS", "600")
+		os.Setenv("IMPORT_ROWS_PER_SECOND", "50")
+		os.Setenv("IMPORT_BATCH_SIZE", "500")
+		os.Setenv("IMPORT_CONCURRENCY", "3")
+		os.Setenv("UPLOAD_TEMP_MAX_AGE_HOURS", "6")
+		os.Setenv("DELETED_RECORDS_ARCHIVE_DIR", "/var/lib/warehouse/archive")
+		os.Setenv("BORROWER_PHONE_REGION", "us")
 		os.Setenv("JWT_SECRET", "custom-secret")
 		os.Setenv("JWT_ALGORITHM", "HS512")
 		os.Setenv("JWT_EXPIRATION_HOURS", "48")
 		os.Setenv("SERVER_HOST", "localhost")
 		os.Setenv("SERVER_PORT", "3000")
 		os.Setenv("SERVER_TIMEOUT_SECONDS", "120")
+		os.Setenv("HEAVY_REQUEST_TIMEOUT_SECONDS", "1800")
+		os.Setenv("WORKSPACE_STATS_CACHE_SECONDS", "0")
+		os.Setenv("MAX_PAGE_SIZE", "40")
+		os.Setenv("SSE_CLIENT_BUFFER", "16")
 		os.Setenv("RESEND_API_KEY", "re_test_key")
 		os.Setenv("EMAIL_FROM_ADDRESS", "test@example.com")
 		os.Setenv("EMAIL_FROM_NAME", "Test App")
+		os.Setenv("TWILIO_ACCOUNT_SID", "AC123")
+		os.Setenv("TWILIO_AUTH_TOKEN", "twilio_token")
+		os.Setenv("TWILIO_FROM", "+15005550006")
 		os.Setenv("GOOGLE_CLIENT_ID", "google_client_id")
 		os.Setenv("GOOGLE_CLIENT_SECRET", "google_secret")
 		os.Setenv("GITHUB_CLIENT_ID", "github_client_id")
@@ -59,14 +102,32 @@ func TestLoad(t *testing.T) {
 		assert.Equal(t, 50, cfg.DatabaseMaxConn)
 		assert.Equal(t, 10, cfg.DatabaseMinConn)
 		assert.Equal(t, "redis://localhost:6380/1", cfg.RedisURL)
+		assert.Equal(t, 8, cfg.SchedulerMaxRetry)
+		assert.Equal(t, 10*time.Second, cfg.SchedulerRetryBaseDelay)
+		assert.Equal(t, 10*time.Minute, cfg.SchedulerRetryMaxDelay)
+		assert.Equal(t, 50, cfg.ImportRowsPerSecond)
+		assert.Equal(t, 500, cfg.ImportBatchSize)
+		assert.Equal(t, 3, cfg.ImportConcurrency)
+		assert.Equal(t, 6*time.Hour, cfg.UploadTempMaxAge)
+		assert.Equal(t, "/var/lib/warehouse/archive", cfg.DeletedRecordsArchiveDir)
+		assert.Equal(t, "US", cfg.BorrowerPhoneRegion)
 		assert.Equal(t, "custom-secret", cfg.JWTSecret)
 		assert.Equal(t, "HS512", cfg.JWTAlgorithm)
 		assert.Equal(t, 48, cfg.JWTExpirationHours)
 		assert.Equal(t, "localhost", cfg.ServerHost)
 		assert.Equal(t, 3000, cfg.ServerPort)
+		assert.Equal(t, 2*time.Minute, cfg.ServerTimeout)
+		assert.Equal(t, 30*time.Minute, cfg.HeavyRequestTimeout)
+		assert.Equal(t, time.Duration(0), cfg.WorkspaceStatsCacheTTL)
+		assert.Equal(t, 40, cfg.MaxPageSize)
+		assert.Equal(t, 16, cfg.SSEClientBuffer)
 		assert.Equal(t, "re_test_key", cfg.ResendAPIKey)
 		assert.Equal(t, "test@example.com", cfg.EmailFromAddress)
 		assert.Equal(t, "Test App", cfg.EmailFromName)
+		assert.Equal(t, "AC123", cfg.TwilioAccountSID)
+		assert.Equal(t, "twilio_token", cfg.TwilioAuthToken)
+		assert.Equal(t, "+15005550006", cfg.TwilioFrom)
+		assert.True(t, cfg.SMSEnabled())
 		assert.Equal(t, "google_client_id", cfg.GoogleClientID)
 		assert.Equal(t, "google_secret", cfg.GoogleClientSecret)
 		assert.Equal(t, "github_client_id", cfg.GitHubClientID)
@@ -112,10 +173,11 @@ const testStrongSecret = "0123456789abcdef0123456789abcdef" // 32 chars
 func TestValidate(t *testing.T) {
 	t.Run("passes validation with valid config", func(t *testing.T) {
 		cfg := &Config{
-			DatabaseURL: "postgresql://localhost/db",
-			JWTSecret:   testStrongSecret,
-			ServerPort:  8080,
-			DebugMode:   false,
+			DatabaseURL:       "postgresql://localhost/db",
+			JWTSecret:         testStrongSecret,
+			ServerPort:        8080,
+			PasswordMinLength: 8,
+			DebugMode:         false,
 		}
 
 		err := cfg.Validate()
@@ -176,10 +238,11 @@ func TestValidate(t *testing.T) {
 
 	t.Run("substitutes dev fallback for missing JWT secret in debug mode", func(t *testing.T) {
 		cfg := &Config{
-			DatabaseURL: "postgresql://localhost/db",
-			JWTSecret:   "",
-			ServerPort:  8080,
-			DebugMode:   true,
+			DatabaseURL:       "postgresql://localhost/db",
+			JWTSecret:         "",
+			ServerPort:        8080,
+			PasswordMinLength: 8,
+			DebugMode:         true,
 		}
 
 		err := cfg.Validate()
@@ -212,6 +275,110 @@ func TestValidate(t *testing.T) {
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "SERVER_PORT")
 	})
+
+	t.Run("fails validation with password minimum below the default", func(t *testing.T) {
+		cfg := &Config{
+			DatabaseURL:       "postgresql://localhost/db",
+			JWTSecret:         testStrongSecret,
+			ServerPort:        8080,
+			PasswordMinLength: 6,
+			DebugMode:         false,
+		}
+
+		err := cfg.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "PASSWORD_MIN_LENGTH")
+	})
+
+	t.Run("fails validation with negative import rate", func(t *testing.T) {
+		cfg := &Config{
+			DatabaseURL:         "postgresql://localhost/db",
+			JWTSecret:           testStrongSecret,
+			ServerPort:          8080,
+			PasswordMinLength:   8,
+			ImportRowsPerSecond: -1,
+		}
+
+		err := cfg.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "IMPORT_ROWS_PER_SECOND")
+	})
+
+	t.Run("fails validation with negative import concurrency", func(t *testing.T) {
+		cfg := &Config{
+			DatabaseURL:       "postgresql://localhost/db",
+			JWTSecret:         testStrongSecret,
+			ServerPort:        8080,
+			PasswordMinLength: 8,
+			ImportConcurrency: -1,
+		}
+
+		err := cfg.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "IMPORT_CONCURRENCY")
+	})
+
+	t.Run("fails validation with negative auth event retention", func(t *testing.T) {
+		cfg := &Config{
+			DatabaseURL:            "postgresql://localhost/db",
+			JWTSecret:              testStrongSecret,
+			ServerPort:             8080,
+			PasswordMinLength:      8,
+			AuthEventRetentionDays: -1,
+		}
+
+		err := cfg.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "AUTH_EVENT_RETENTION_DAYS")
+	})
+
+	t.Run("fails validation with an enabled lockout that never triggers", func(t *testing.T) {
+		cfg := &Config{
+			DatabaseURL:             "postgresql://localhost/db",
+			JWTSecret:               testStrongSecret,
+			ServerPort:              8080,
+			PasswordMinLength:       8,
+			LoginLockoutEnabled:     true,
+			LoginLockoutMaxAttempts: 0,
+			LoginLockoutWindow:      time.Minute,
+			LoginLockoutDuration:    time.Minute,
+		}
+
+		err := cfg.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "LOGIN_LOCKOUT_MAX_ATTEMPTS")
+
+		cfg.LoginLockoutEnabled = false
+		assert.NoError(t, cfg.Validate(), "thresholds are not checked while the lockout is off")
+	})
+
+	t.Run("fails validation with unknown inventory empty action", func(t *testing.T) {
+		cfg := &Config{
+			DatabaseURL:          "postgresql://localhost/db",
+			JWTSecret:            testStrongSecret,
+			ServerPort:           8080,
+			PasswordMinLength:    8,
+			InventoryEmptyAction: "delete",
+		}
+
+		err := cfg.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "INVENTORY_EMPTY_ACTION")
+	})
+
+	t.Run("fails validation with malformed borrower phone region", func(t *testing.T) {
+		cfg := &Config{
+			DatabaseURL:         "postgresql://localhost/db",
+			JWTSecret:           testStrongSecret,
+			ServerPort:          8080,
+			PasswordMinLength:   8,
+			BorrowerPhoneRegion: "FIN",
+		}
+
+		err := cfg.Validate()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "BORROWER_PHONE_REGION")
+	})
 }
 
 func TestIsProduction(t *testing.T) {
@@ -233,3 +400,16 @@ func TestIsProduction(t *testing.T) {
 		assert.False(t, cfg.SecureCookies())
 	})
 }
+
+func TestSMSEnabled(t *testing.T) {
+	full := Config{TwilioAccountSID: "AC123", TwilioAuthToken: "token", TwilioFrom: "+15005550006"}
+	assert.True(t, full.SMSEnabled())
+
+	noToken := full
+	noToken.TwilioAuthToken = ""
+	assert.False(t, noToken.SMSEnabled())
+
+	noFrom := full
+	noFrom.TwilioFrom = ""
+	assert.False(t, noFrom.SMSEnabled())
+}
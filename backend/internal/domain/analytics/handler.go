@@ -2,12 +2,14 @@ package analytics
 
 import (
 	"context"
+	"errors"
 	"net/http"
 	"time"
 
 	"github.com/danielgtaylor/huma/v2"
 
 	appMiddleware "github.com/antti/home-warehouse/go-backend/internal/api/middleware"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
 )
 
 const msgWorkspaceContextRequired = "workspace context required"
@@ -120,6 +122,59 @@ type OutOfStockItemsResponse struct {
 	Body []OutOfStockItem
 }
 
+// StaleInventoryRequest is the input for the stale inventory report
+type StaleInventoryRequest struct {
+	Days  int `query:"days" default:"180" minimum:"1" maximum:"3650" doc:"Report inventory with no activity in this many days"`
+	Limit int `query:"limit" default:"100" minimum:"1" maximum:"500" doc:"Maximum number of records to return"`
+}
+
+// StaleInventoryResponse is the response for the stale inventory report
+type StaleInventoryResponse struct {
+	Body StaleInventoryReport
+}
+
+// TopValueItemsRequest is the input for the top-value items report
+type TopValueItemsRequest struct {
+	Limit int `query:"limit" default:"20" minimum:"1" maximum:"200" doc:"Maximum number of items to return"`
+}
+
+// TopValueItemsResponse is the response for the top-value items report
+type TopValueItemsResponse struct {
+	Body TopValueReport
+}
+
+// InventoryAgingRequest is the input for the inventory aging report
+type InventoryAgingRequest struct{}
+
+// InventoryAgingResponse is the response for the inventory aging report
+type InventoryAgingResponse struct {
+	Body AgingReport
+}
+
+// GetCurrencySettingsRequest is the input for reading currency settings
+type GetCurrencySettingsRequest struct{}
+
+// UpdateCurrencySettingsRequest is the input for replacing currency settings
+type UpdateCurrencySettingsRequest struct {
+	Body struct {
+		BaseCurrency  string             `json:"base_currency" minLength:"3" maxLength:"3" doc:"ISO 4217 code reports are valued in"`
+		ExchangeRates map[string]float64 `json:"exchange_rates,omitempty" doc:"Amount of base currency one unit of each keyed currency is worth, e.g. {\"USD\": 0.92}"`
+	}
+}
+
+// CurrencySettingsResponse is the response for currency settings
+type CurrencySettingsResponse struct {
+	Body CurrencySettings
+}
+
+// WorkspaceStatsRequest is the input for the workspace stats summary
+type WorkspaceStatsRequest struct{}
+
+// WorkspaceStatsResponse is the response for the workspace stats summary
+type WorkspaceStatsResponse struct {
+	Body WorkspaceStats
+}
+
 // RegisterRoutes registers analytics routes with the Huma API.
 // Note: These routes are registered within a workspace-scoped router group,
 // so paths are relative to /workspaces/{workspace_id}.
@@ -222,6 +277,60 @@ func (h *Handler) RegisterRoutes(api huma.API) {
 		Description: "Returns items that are completely out of stock (quantity = 0) and need restocking.",
 		Tags:        []string{"Analytics"},
 	}, h.GetOutOfStockItems)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-stale-inventory-report",
+		Method:      http.MethodGet,
+		Path:        "/reports/stale",
+		Summary:     "Get stale inventory report",
+		Description: "Returns inventory that has not been moved, loaned, used or updated in the given number of days, with its location and last activity date.",
+		Tags:        []string{"Reports"},
+	}, h.GetStaleInventory)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-top-value-items-report",
+		Method:      http.MethodGet,
+		Path:        "/reports/top-value",
+		Summary:     "Get most valuable items",
+		Description: "Returns items ranked by purchase price times quantity, converted to the workspace base currency. Inventory without a purchase price, or in a currency with no exchange rate, is excluded and counted in the response.",
+		Tags:        []string{"Reports"},
+	}, h.GetTopValueItems)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-inventory-aging-report",
+		Method:      http.MethodGet,
+		Path:        "/reports/aging",
+		Summary:     "Get inventory aging report",
+		Description: "Returns inventory counts and value grouped by purchase date: acquired this year, 1-2 years ago, 3 or more years ago, or with no purchase date (unknown). Values are converted to the workspace base currency; inventory without a purchase price, or in a currency with no exchange rate, is counted but not valued.",
+		Tags:        []string{"Reports"},
+	}, h.GetInventoryAging)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-currency-settings",
+		Method:      http.MethodGet,
+		Path:        "/reports/currency",
+		Summary:     "Get report currency settings",
+		Description: "Returns the base currency value reports use and the configured exchange rates.",
+		Tags:        []string{"Reports"},
+	}, h.GetCurrencySettings)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "update-currency-settings",
+		Method:      http.MethodPut,
+		Path:        "/reports/currency",
+		Summary:     "Update report currency settings",
+		Description: "Replaces the base currency and exchange rates. Requires owner or admin role.",
+		Tags:        []string{"Reports"},
+	}, h.UpdateCurrencySettings)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "get-workspace-stats",
+		Method:      http.MethodGet,
+		Path:        "/stats",
+		Summary:     "Get workspace stats summary",
+		Description: "Returns the dashboard summary in one call: item and inventory totals, active and overdue loans, low-stock and expiring-soon counts, and total inventory value in the base currency. Results are cached briefly, see generated_at.",
+		Tags:        []string{"Analytics"},
+	}, h.GetWorkspaceStats)
 }
 
 // GetDashboardStats handles the dashboard stats request
@@ -367,3 +476,91 @@ func (h *Handler) GetOutOfStockItems(ctx context.Context, input *OutOfStockItems
 	}
 	return &OutOfStockItemsResponse{Body: items}, nil
 }
+
+// GetStaleInventory handles the stale inventory report request
+func (h *Handler) GetStaleInventory(ctx context.Context, input *StaleInventoryRequest) (*StaleInventoryResponse, error) {
+	workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+	if !ok {
+		return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+	}
+	report, err := h.svc.GetStaleInventory(ctx, workspaceID, input.Days, int32(input.Limit))
+	if err != nil {
+		return nil, huma.Error500InternalServerError("failed to fetch stale inventory report", err)
+	}
+	return &StaleInventoryResponse{Body: *report}, nil
+}
+
+// GetTopValueItems handles the top-value items report request
+func (h *Handler) GetTopValueItems(ctx context.Context, input *TopValueItemsRequest) (*TopValueItemsResponse, error) {
+	workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+	if !ok {
+		return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+	}
+	report, err := h.svc.TopValueItems(ctx, workspaceID, int32(input.Limit))
+	if err != nil {
+		return nil, huma.Error500InternalServerError("failed to fetch top value items", err)
+	}
+	return &TopValueItemsResponse{Body: *report}, nil
+}
+
+// GetInventoryAging handles the inventory aging report request
+func (h *Handler) GetInventoryAging(ctx context.Context, input *InventoryAgingRequest) (*InventoryAgingResponse, error) {
+	workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+	if !ok {
+		return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+	}
+	report, err := h.svc.InventoryAging(ctx, workspaceID)
+	if err != nil {
+		return nil, huma.Error500InternalServerError("failed to fetch inventory aging report", err)
+	}
+	return &InventoryAgingResponse{Body: *report}, nil
+}
+
+// GetCurrencySettings handles the currency settings request
+func (h *Handler) GetCurrencySettings(ctx context.Context, input *GetCurrencySettingsRequest) (*CurrencySettingsResponse, error) {
+	workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+	if !ok {
+		return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+	}
+	settings, err := h.svc.GetCurrencySettings(ctx, workspaceID)
+	if err != nil {
+		return nil, huma.Error500InternalServerError("failed to fetch currency settings", err)
+	}
+	return &CurrencySettingsResponse{Body: *settings}, nil
+}
+
+// UpdateCurrencySettings handles replacing the currency settings
+func (h *Handler) UpdateCurrencySettings(ctx context.Context, input *UpdateCurrencySettingsRequest) (*CurrencySettingsResponse, error) {
+	workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+	if !ok {
+		return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+	}
+	if role, ok := appMiddleware.GetRole(ctx); !ok || (role != "owner" && role != "admin") {
+		return nil, huma.Error403Forbidden("only workspace owners and admins can change currency settings")
+	}
+	settings, err := h.svc.UpdateCurrencySettings(ctx, workspaceID, CurrencySettings{
+		BaseCurrency:  input.Body.BaseCurrency,
+		ExchangeRates: input.Body.ExchangeRates,
+	})
+	var domainErr *shared.DomainError
+	if errors.As(err, &domainErr) {
+		return nil, appMiddleware.MapDomainError(err)
+	}
+	if err != nil {
+		return nil, huma.Error500InternalServerError("failed to update currency settings", err)
+	}
+	return &CurrencySettingsResponse{Body: *settings}, nil
+}
+
+// GetWorkspaceStats handles the workspace stats summary request
+func (h *Handler) GetWorkspaceStats(ctx context.Context, input *WorkspaceStatsRequest) (*WorkspaceStatsResponse, error) {
+	workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+	if !ok {
+		return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+	}
+	stats, err := h.svc.WorkspaceStats(ctx, workspaceID)
+	if err != nil {
+		return nil, huma.Error500InternalServerError("failed to fetch workspace stats", err)
+	}
+	return &WorkspaceStatsResponse{Body: *stats}, nil
+}
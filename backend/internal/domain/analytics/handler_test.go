@@ -2,12 +2,14 @@ package analytics_test
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
 	"github.com/antti/home-warehouse/go-backend/internal/domain/analytics"
@@ -86,6 +88,54 @@ func (m *MockService) GetOutOfStockItems(ctx context.Context, workspaceID uuid.U
 	return args.Get(0).([]analytics.OutOfStockItem), args.Error(1)
 }
 
+func (m *MockService) GetStaleInventory(ctx context.Context, workspaceID uuid.UUID, days int, limit int32) (*analytics.StaleInventoryReport, error) {
+	args := m.Called(ctx, workspaceID, days, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*analytics.StaleInventoryReport), args.Error(1)
+}
+
+func (m *MockService) TopValueItems(ctx context.Context, workspaceID uuid.UUID, limit int32) (*analytics.TopValueReport, error) {
+	args := m.Called(ctx, workspaceID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*analytics.TopValueReport), args.Error(1)
+}
+
+func (m *MockService) InventoryAging(ctx context.Context, workspaceID uuid.UUID) (*analytics.AgingReport, error) {
+	args := m.Called(ctx, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*analytics.AgingReport), args.Error(1)
+}
+
+func (m *MockService) GetCurrencySettings(ctx context.Context, workspaceID uuid.UUID) (*analytics.CurrencySettings, error) {
+	args := m.Called(ctx, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*analytics.CurrencySettings), args.Error(1)
+}
+
+func (m *MockService) UpdateCurrencySettings(ctx context.Context, workspaceID uuid.UUID, settings analytics.CurrencySettings) (*analytics.CurrencySettings, error) {
+	args := m.Called(ctx, workspaceID, settings)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*analytics.CurrencySettings), args.Error(1)
+}
+
+func (m *MockService) WorkspaceStats(ctx context.Context, workspaceID uuid.UUID) (*analytics.WorkspaceStats, error) {
+	args := m.Called(ctx, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*analytics.WorkspaceStats), args.Error(1)
+}
+
 // Tests
 
 func TestAnalyticsHandler_GetDashboardStats(t *testing.T) {
@@ -557,3 +607,207 @@ func TestAnalyticsHandler_GetOutOfStockItems(t *testing.T) {
 		mockSvc.AssertExpectations(t)
 	})
 }
+
+func TestAnalyticsHandler_GetStaleInventory(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	handler := analytics.NewHandler(mockSvc)
+	handler.RegisterRoutes(setup.API)
+
+	t.Run("gets stale inventory with default window", func(t *testing.T) {
+		report := &analytics.StaleInventoryReport{
+			Days: 180,
+			Items: []analytics.StaleInventory{
+				{InventoryID: uuid.New(), ItemName: "Tent", LocationName: "Shed", Quantity: 1},
+			},
+		}
+
+		mockSvc.On("GetStaleInventory", mock.Anything, setup.WorkspaceID, 180, int32(100)).
+			Return(report, nil).Once()
+
+		rec := setup.Get("/reports/stale")
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("passes custom days and limit", func(t *testing.T) {
+		mockSvc.On("GetStaleInventory", mock.Anything, setup.WorkspaceID, 30, int32(10)).
+			Return(&analytics.StaleInventoryReport{Days: 30, Items: []analytics.StaleInventory{}}, nil).Once()
+
+		rec := setup.Get("/reports/stale?days=30&limit=10")
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("rejects invalid days", func(t *testing.T) {
+		rec := setup.Get("/reports/stale?days=0")
+
+		testutil.AssertStatus(t, rec, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("handles service error", func(t *testing.T) {
+		mockSvc.On("GetStaleInventory", mock.Anything, setup.WorkspaceID, 180, int32(100)).
+			Return(nil, fmt.Errorf("test error")).Once()
+
+		rec := setup.Get("/reports/stale")
+
+		testutil.AssertStatus(t, rec, http.StatusInternalServerError)
+		mockSvc.AssertExpectations(t)
+	})
+}
+
+func TestAnalyticsHandler_GetTopValueItems(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	handler := analytics.NewHandler(mockSvc)
+	handler.RegisterRoutes(setup.API)
+
+	t.Run("uses default limit", func(t *testing.T) {
+		report := &analytics.TopValueReport{
+			Currency: "EUR",
+			Items: []analytics.TopValueItem{
+				{ItemID: uuid.New(), Name: "Camera", Quantity: 1, UnitPrice: 90000, TotalValue: 90000},
+			},
+			ExcludedUnpricedCount: 3,
+		}
+		mockSvc.On("TopValueItems", mock.Anything, setup.WorkspaceID, int32(20)).
+			Return(report, nil).Once()
+
+		rec := setup.Get("/reports/top-value")
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		body := testutil.ParseJSONResponse[analytics.TopValueReport](t, rec)
+		assert.Equal(t, 3, body.ExcludedUnpricedCount)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("passes custom limit", func(t *testing.T) {
+		mockSvc.On("TopValueItems", mock.Anything, setup.WorkspaceID, int32(5)).
+			Return(&analytics.TopValueReport{Currency: "EUR"}, nil).Once()
+
+		rec := setup.Get("/reports/top-value?limit=5")
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("rejects limit above maximum", func(t *testing.T) {
+		rec := setup.Get("/reports/top-value?limit=1000")
+
+		testutil.AssertStatus(t, rec, http.StatusUnprocessableEntity)
+	})
+}
+
+func TestAnalyticsHandler_GetInventoryAging(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	handler := analytics.NewHandler(mockSvc)
+	handler.RegisterRoutes(setup.API)
+
+	t.Run("returns the buckets", func(t *testing.T) {
+		report := &analytics.AgingReport{
+			Currency: "EUR",
+			Year:     2026,
+			Buckets: []analytics.AgingBucket{
+				{Bucket: analytics.AgingThisYear, InventoryCount: 2, Quantity: 3, TotalValue: 4500},
+				{Bucket: analytics.AgingOneToTwo},
+				{Bucket: analytics.AgingThreePlus, InventoryCount: 1, Quantity: 1, TotalValue: 900},
+				{Bucket: analytics.AgingUnknownYears, InventoryCount: 4, Quantity: 6, ExcludedUnpricedCount: 4},
+			},
+		}
+		mockSvc.On("InventoryAging", mock.Anything, setup.WorkspaceID).Return(report, nil).Once()
+
+		rec := setup.Get("/reports/aging")
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		body := testutil.ParseJSONResponse[analytics.AgingReport](t, rec)
+		assert.Len(t, body.Buckets, 4)
+		assert.Equal(t, analytics.AgingUnknownYears, body.Buckets[3].Bucket)
+		assert.Equal(t, 4, body.Buckets[3].ExcludedUnpricedCount)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("handles service error", func(t *testing.T) {
+		mockSvc.On("InventoryAging", mock.Anything, setup.WorkspaceID).
+			Return(nil, fmt.Errorf("test error")).Once()
+
+		rec := setup.Get("/reports/aging")
+
+		testutil.AssertStatus(t, rec, http.StatusInternalServerError)
+		mockSvc.AssertExpectations(t)
+	})
+}
+
+func TestAnalyticsHandler_GetWorkspaceStats(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	handler := analytics.NewHandler(mockSvc)
+	handler.RegisterRoutes(setup.API)
+
+	t.Run("returns the summary", func(t *testing.T) {
+		stats := &analytics.WorkspaceStats{
+			TotalItems:         12,
+			TotalQuantity:      140,
+			ActiveLoans:        3,
+			OverdueLoans:       1,
+			LowStockItems:      2,
+			ExpiringSoon:       4,
+			ExpiringWithinDays: 30,
+			TotalValue:         125000,
+			Currency:           "EUR",
+		}
+		mockSvc.On("WorkspaceStats", mock.Anything, setup.WorkspaceID).Return(stats, nil).Once()
+
+		rec := setup.Get("/stats")
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		body := testutil.ParseJSONResponse[analytics.WorkspaceStats](t, rec)
+		assert.Equal(t, int32(12), body.TotalItems)
+		assert.Equal(t, int64(140), body.TotalQuantity)
+		assert.Equal(t, int32(4), body.ExpiringSoon)
+		assert.Equal(t, int64(125000), body.TotalValue)
+		assert.Equal(t, "EUR", body.Currency)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("service error returns 500", func(t *testing.T) {
+		mockSvc.On("WorkspaceStats", mock.Anything, setup.WorkspaceID).
+			Return(nil, errors.New("database error")).Once()
+
+		rec := setup.Get("/stats")
+
+		testutil.AssertStatus(t, rec, http.StatusInternalServerError)
+		mockSvc.AssertExpectations(t)
+	})
+}
+
+func TestAnalyticsHandler_UpdateCurrencySettings(t *testing.T) {
+	t.Run("saves settings as admin", func(t *testing.T) {
+		setup := testutil.NewHandlerTestSetup()
+		mockSvc := new(MockService)
+		analytics.NewHandler(mockSvc).RegisterRoutes(setup.API)
+
+		want := analytics.CurrencySettings{BaseCurrency: "USD", ExchangeRates: map[string]float64{"EUR": 1.08}}
+		mockSvc.On("UpdateCurrencySettings", mock.Anything, setup.WorkspaceID, want).
+			Return(&want, nil).Once()
+
+		rec := setup.Put("/reports/currency", `{"base_currency":"USD","exchange_rates":{"EUR":1.08}}`)
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("forbids members", func(t *testing.T) {
+		setup := testutil.NewHandlerTestSetup()
+		setup.SetRole("member")
+		mockSvc := new(MockService)
+		analytics.NewHandler(mockSvc).RegisterRoutes(setup.API)
+
+		rec := setup.Put("/reports/currency", `{"base_currency":"USD"}`)
+
+		testutil.AssertStatus(t, rec, http.StatusForbidden)
+		mockSvc.AssertNotCalled(t, "UpdateCurrencySettings", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
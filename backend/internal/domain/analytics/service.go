@@ -2,14 +2,27 @@ package analytics
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
 
 	"github.com/antti/home-warehouse/go-backend/internal/infra/queries"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
 )
 
+// defaultBaseCurrency is the reporting currency of workspaces that have not
+// configured one; it matches the inventory currency_code default.
+const defaultBaseCurrency = "EUR"
+
+var currencyCodePattern = regexp.MustCompile(`^[A-Z]{3}$`)
+
 // Repository defines the interface for analytics data access
 type Repository interface {
 	GetDashboardStats(ctx context.Context, workspaceID uuid.UUID) (queries.GetDashboardStatsRow, error)
@@ -22,6 +35,14 @@ type Repository interface {
 	GetTopBorrowers(ctx context.Context, workspaceID uuid.UUID, limit int32) ([]queries.GetTopBorrowersRow, error)
 	GetMonthlyLoanActivity(ctx context.Context, workspaceID uuid.UUID, since time.Time) ([]queries.GetMonthlyLoanActivityRow, error)
 	GetOutOfStockItems(ctx context.Context, workspaceID uuid.UUID) ([]queries.GetOutOfStockItemsRow, error)
+	GetStaleInventory(ctx context.Context, workspaceID uuid.UUID, cutoff time.Time, limit int32) ([]queries.GetStaleInventoryRow, error)
+	GetTopValueItems(ctx context.Context, workspaceID uuid.UUID, limit int32) ([]queries.GetTopValueItemsRow, error)
+	CountTopValueExclusions(ctx context.Context, workspaceID uuid.UUID) (queries.CountTopValueExclusionsRow, error)
+	GetInventoryAging(ctx context.Context, workspaceID uuid.UUID, currentYear int32) ([]queries.GetInventoryAgingRow, error)
+	GetCurrencySettings(ctx context.Context, workspaceID uuid.UUID) (queries.WarehouseCurrencySetting, error)
+	UpsertCurrencySettings(ctx context.Context, workspaceID uuid.UUID, baseCurrency string, exchangeRates []byte) (queries.WarehouseCurrencySetting, error)
+	GetWorkspaceStats(ctx context.Context, workspaceID uuid.UUID, expiringDays int32) (queries.GetWorkspaceStatsRow, error)
+	GetWorkspaceInventoryValue(ctx context.Context, workspaceID uuid.UUID) (int64, error)
 }
 
 // ServiceInterface defines the interface for analytics service operations
@@ -37,16 +58,23 @@ type ServiceInterface interface {
 	GetMonthlyLoanActivity(ctx context.Context, workspaceID uuid.UUID, since time.Time) ([]MonthlyLoanActivity, error)
 	GetAnalyticsSummary(ctx context.Context, workspaceID uuid.UUID) (*AnalyticsSummary, error)
 	GetOutOfStockItems(ctx context.Context, workspaceID uuid.UUID) ([]OutOfStockItem, error)
+	GetStaleInventory(ctx context.Context, workspaceID uuid.UUID, days int, limit int32) (*StaleInventoryReport, error)
+	TopValueItems(ctx context.Context, workspaceID uuid.UUID, limit int32) (*TopValueReport, error)
+	InventoryAging(ctx context.Context, workspaceID uuid.UUID) (*AgingReport, error)
+	GetCurrencySettings(ctx context.Context, workspaceID uuid.UUID) (*CurrencySettings, error)
+	UpdateCurrencySettings(ctx context.Context, workspaceID uuid.UUID, settings CurrencySettings) (*CurrencySettings, error)
+	WorkspaceStats(ctx context.Context, workspaceID uuid.UUID) (*WorkspaceStats, error)
 }
 
 // Service handles analytics operations
 type Service struct {
-	repo Repository
+	repo       Repository
+	statsCache *statsCache
 }
 
 // NewService creates a new analytics service
 func NewService(repo Repository) *Service {
-	return &Service{repo: repo}
+	return &Service{repo: repo, statsCache: newStatsCache(DefaultStatsCacheTTL)}
 }
 
 // GetDashboardStats returns overall workspace statistics
@@ -346,6 +374,201 @@ func (s *Service) GetOutOfStockItems(ctx context.Context, workspaceID uuid.UUID)
 	return result, nil
 }
 
+// GetStaleInventory returns inventory whose last activity (update, movement,
+// loan checkout/return or "mark used") is more than days days old, oldest first.
+func (s *Service) GetStaleInventory(ctx context.Context, workspaceID uuid.UUID, days int, limit int32) (*StaleInventoryReport, error) {
+	if days <= 0 {
+		days = 180
+	}
+	if limit <= 0 {
+		limit = 100
+	}
+
+	now := time.Now()
+	cutoff := now.AddDate(0, 0, -days)
+
+	rows, err := s.repo.GetStaleInventory(ctx, workspaceID, cutoff, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]StaleInventory, len(rows))
+	for i, row := range rows {
+		var containerID *uuid.UUID
+		if row.ContainerID.Valid {
+			id := uuid.UUID(row.ContainerID.Bytes)
+			containerID = &id
+		}
+
+		var lastActivity time.Time
+		if row.LastActivityAt.Valid {
+			lastActivity = row.LastActivityAt.Time
+		}
+
+		items[i] = StaleInventory{
+			InventoryID:    row.ID,
+			ItemID:         row.ItemID,
+			ItemName:       row.ItemName,
+			SKU:            row.Sku,
+			LocationID:     row.LocationID,
+			LocationName:   row.LocationName,
+			ContainerID:    containerID,
+			ContainerName:  row.ContainerName,
+			Quantity:       row.Quantity,
+			LastActivityAt: lastActivity,
+			DaysIdle:       int(now.Sub(lastActivity).Hours() / 24),
+		}
+	}
+
+	return &StaleInventoryReport{
+		Days:   days,
+		Cutoff: cutoff,
+		Items:  items,
+	}, nil
+}
+
+// TopValueItems returns the limit most valuable items by purchase_price *
+// quantity, converted to the workspace base currency.
+func (s *Service) TopValueItems(ctx context.Context, workspaceID uuid.UUID, limit int32) (*TopValueReport, error) {
+	if limit <= 0 {
+		limit = 20
+	}
+
+	settings, err := s.GetCurrencySettings(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.repo.GetTopValueItems(ctx, workspaceID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	excluded, err := s.repo.CountTopValueExclusions(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]TopValueItem, len(rows))
+	for i, row := range rows {
+		items[i] = TopValueItem{
+			ItemID:     row.ID,
+			Name:       row.Name,
+			SKU:        row.Sku,
+			Quantity:   row.Quantity,
+			UnitPrice:  row.UnitPrice,
+			TotalValue: row.TotalValue,
+		}
+	}
+
+	return &TopValueReport{
+		Currency:                 settings.BaseCurrency,
+		Items:                    items,
+		ExcludedUnpricedCount:    int(excluded.Unpriced),
+		ExcludedUnconvertedCount: int(excluded.Unconverted),
+	}, nil
+}
+
+// InventoryAging totals inventory by aging bucket as of the current year,
+// valued in the workspace base currency. Empty buckets are included.
+func (s *Service) InventoryAging(ctx context.Context, workspaceID uuid.UUID) (*AgingReport, error) {
+	settings, err := s.GetCurrencySettings(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	year := time.Now().Year()
+	rows, err := s.repo.GetInventoryAging(ctx, workspaceID, int32(year))
+	if err != nil {
+		return nil, err
+	}
+
+	byBucket := make(map[string]queries.GetInventoryAgingRow, len(rows))
+	for _, row := range rows {
+		byBucket[row.Bucket] = row
+	}
+
+	buckets := make([]AgingBucket, len(AgingBuckets))
+	for i, name := range AgingBuckets {
+		row := byBucket[name]
+		buckets[i] = AgingBucket{
+			Bucket:                   name,
+			InventoryCount:           int(row.InventoryCount),
+			Quantity:                 row.Quantity,
+			TotalValue:               row.TotalValue,
+			ExcludedUnpricedCount:    int(row.Unpriced),
+			ExcludedUnconvertedCount: int(row.Unconverted),
+		}
+	}
+
+	return &AgingReport{
+		Currency: settings.BaseCurrency,
+		Year:     year,
+		Buckets:  buckets,
+	}, nil
+}
+
+// GetCurrencySettings returns the workspace currency settings, defaulting to
+// EUR with no exchange rates when none have been saved.
+func (s *Service) GetCurrencySettings(ctx context.Context, workspaceID uuid.UUID) (*CurrencySettings, error) {
+	row, err := s.repo.GetCurrencySettings(ctx, workspaceID)
+	if errors.Is(err, shared.ErrNotFound) {
+		return &CurrencySettings{BaseCurrency: defaultBaseCurrency, ExchangeRates: map[string]float64{}}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return currencySettingsFromRow(row)
+}
+
+// UpdateCurrencySettings replaces the workspace currency settings. Codes are
+// upper-cased; a rate for the base currency itself is dropped since it is
+// always 1.
+func (s *Service) UpdateCurrencySettings(ctx context.Context, workspaceID uuid.UUID, settings CurrencySettings) (*CurrencySettings, error) {
+	base := strings.ToUpper(strings.TrimSpace(settings.BaseCurrency))
+	if !currencyCodePattern.MatchString(base) {
+		return nil, shared.NewFieldError(shared.ErrInvalidInput, "base_currency", "must be a 3-letter ISO 4217 code")
+	}
+
+	rates := make(map[string]float64, len(settings.ExchangeRates))
+	for code, rate := range settings.ExchangeRates {
+		code = strings.ToUpper(strings.TrimSpace(code))
+		if !currencyCodePattern.MatchString(code) {
+			return nil, shared.NewFieldError(shared.ErrInvalidInput, "exchange_rates", fmt.Sprintf("%q is not a 3-letter ISO 4217 code", code))
+		}
+		if rate <= 0 || math.IsInf(rate, 0) || math.IsNaN(rate) {
+			return nil, shared.NewFieldError(shared.ErrInvalidInput, "exchange_rates", fmt.Sprintf("rate for %s must be a positive number", code))
+		}
+		if code == base {
+			continue
+		}
+		rates[code] = rate
+	}
+
+	encoded, err := json.Marshal(rates)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := s.repo.UpsertCurrencySettings(ctx, workspaceID, base, encoded)
+	if err != nil {
+		return nil, err
+	}
+	// The cached summary's total value is in the old currency settings.
+	s.statsCache.invalidate(workspaceID)
+	return currencySettingsFromRow(row)
+}
+
+func currencySettingsFromRow(row queries.WarehouseCurrencySetting) (*CurrencySettings, error) {
+	rates := map[string]float64{}
+	if len(row.ExchangeRates) > 0 {
+		if err := json.Unmarshal(row.ExchangeRates, &rates); err != nil {
+			return nil, fmt.Errorf("decode exchange rates: %w", err)
+		}
+	}
+	return &CurrencySettings{BaseCurrency: row.BaseCurrency, ExchangeRates: rates}, nil
+}
+
 // Helper to convert time to pgtype.Timestamptz
 func timeToPgTimestamptz(t time.Time) pgtype.Timestamptz {
 	return pgtype.Timestamptz{
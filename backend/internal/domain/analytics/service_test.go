@@ -10,8 +10,10 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/antti/home-warehouse/go-backend/internal/infra/queries"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
 )
 
 // MockRepository implements Repository interface
@@ -93,6 +95,55 @@ func (m *MockRepository) GetOutOfStockItems(ctx context.Context, workspaceID uui
 	return args.Get(0).([]queries.GetOutOfStockItemsRow), args.Error(1)
 }
 
+func (m *MockRepository) GetStaleInventory(ctx context.Context, workspaceID uuid.UUID, cutoff time.Time, limit int32) ([]queries.GetStaleInventoryRow, error) {
+	args := m.Called(ctx, workspaceID, cutoff, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]queries.GetStaleInventoryRow), args.Error(1)
+}
+
+func (m *MockRepository) GetTopValueItems(ctx context.Context, workspaceID uuid.UUID, limit int32) ([]queries.GetTopValueItemsRow, error) {
+	args := m.Called(ctx, workspaceID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]queries.GetTopValueItemsRow), args.Error(1)
+}
+
+func (m *MockRepository) GetInventoryAging(ctx context.Context, workspaceID uuid.UUID, currentYear int32) ([]queries.GetInventoryAgingRow, error) {
+	args := m.Called(ctx, workspaceID, currentYear)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]queries.GetInventoryAgingRow), args.Error(1)
+}
+
+func (m *MockRepository) CountTopValueExclusions(ctx context.Context, workspaceID uuid.UUID) (queries.CountTopValueExclusionsRow, error) {
+	args := m.Called(ctx, workspaceID)
+	return args.Get(0).(queries.CountTopValueExclusionsRow), args.Error(1)
+}
+
+func (m *MockRepository) GetCurrencySettings(ctx context.Context, workspaceID uuid.UUID) (queries.WarehouseCurrencySetting, error) {
+	args := m.Called(ctx, workspaceID)
+	return args.Get(0).(queries.WarehouseCurrencySetting), args.Error(1)
+}
+
+func (m *MockRepository) UpsertCurrencySettings(ctx context.Context, workspaceID uuid.UUID, baseCurrency string, exchangeRates []byte) (queries.WarehouseCurrencySetting, error) {
+	args := m.Called(ctx, workspaceID, baseCurrency, exchangeRates)
+	return args.Get(0).(queries.WarehouseCurrencySetting), args.Error(1)
+}
+
+func (m *MockRepository) GetWorkspaceStats(ctx context.Context, workspaceID uuid.UUID, expiringDays int32) (queries.GetWorkspaceStatsRow, error) {
+	args := m.Called(ctx, workspaceID, expiringDays)
+	return args.Get(0).(queries.GetWorkspaceStatsRow), args.Error(1)
+}
+
+func (m *MockRepository) GetWorkspaceInventoryValue(ctx context.Context, workspaceID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, workspaceID)
+	return args.Get(0).(int64), args.Error(1)
+}
+
 // ============================================================================
 // Service Tests
 // ============================================================================
@@ -1200,3 +1251,327 @@ func TestOutOfStockItem(t *testing.T) {
 	assert.NotNil(t, item.CategoryName)
 	assert.Equal(t, "Electronics", *item.CategoryName)
 }
+
+func TestService_GetStaleInventory(t *testing.T) {
+	workspaceID := uuid.New()
+	containerID := uuid.New()
+	containerName := "Blue bin"
+	lastActivity := time.Now().AddDate(0, 0, -200)
+
+	t.Run("maps rows and computes cutoff from days", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("GetStaleInventory", mock.Anything, workspaceID, mock.MatchedBy(func(cutoff time.Time) bool {
+			want := time.Now().AddDate(0, 0, -180)
+			return cutoff.Sub(want).Abs() < time.Minute
+		}), int32(50)).Return([]queries.GetStaleInventoryRow{
+			{
+				ID:             uuid.New(),
+				ItemID:         uuid.New(),
+				ItemName:       "Tent",
+				Sku:            "TENT-001",
+				LocationID:     uuid.New(),
+				LocationName:   "Shed",
+				ContainerID:    pgtype.UUID{Bytes: containerID, Valid: true},
+				ContainerName:  &containerName,
+				Quantity:       1,
+				LastActivityAt: pgtype.Timestamptz{Time: lastActivity, Valid: true},
+			},
+		}, nil)
+		service := NewService(mockRepo)
+
+		report, err := service.GetStaleInventory(context.Background(), workspaceID, 180, 50)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 180, report.Days)
+		assert.Len(t, report.Items, 1)
+		assert.Equal(t, "Shed", report.Items[0].LocationName)
+		assert.Equal(t, &containerID, report.Items[0].ContainerID)
+		assert.Equal(t, lastActivity, report.Items[0].LastActivityAt)
+		assert.Equal(t, 200, report.Items[0].DaysIdle)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("applies defaults for non-positive days and limit", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("GetStaleInventory", mock.Anything, workspaceID, mock.AnythingOfType("time.Time"), int32(100)).
+			Return([]queries.GetStaleInventoryRow{}, nil)
+		service := NewService(mockRepo)
+
+		report, err := service.GetStaleInventory(context.Background(), workspaceID, 0, 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 180, report.Days)
+		assert.Empty(t, report.Items)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("repository returns error", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("GetStaleInventory", mock.Anything, workspaceID, mock.AnythingOfType("time.Time"), int32(100)).
+			Return(nil, errors.New("database error"))
+		service := NewService(mockRepo)
+
+		report, err := service.GetStaleInventory(context.Background(), workspaceID, 90, 100)
+
+		assert.Error(t, err)
+		assert.Nil(t, report)
+	})
+}
+
+func TestService_TopValueItems(t *testing.T) {
+	workspaceID := uuid.New()
+	itemID := uuid.New()
+
+	t.Run("reports items in the configured base currency", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("GetCurrencySettings", mock.Anything, workspaceID).
+			Return(queries.WarehouseCurrencySetting{WorkspaceID: workspaceID, BaseCurrency: "USD", ExchangeRates: []byte(`{"EUR":1.08}`)}, nil)
+		mockRepo.On("GetTopValueItems", mock.Anything, workspaceID, int32(20)).
+			Return([]queries.GetTopValueItemsRow{
+				{ID: itemID, Name: "Camera", Sku: "CAM-1", Quantity: 2, UnitPrice: 54000, TotalValue: 108000},
+			}, nil)
+		mockRepo.On("CountTopValueExclusions", mock.Anything, workspaceID).
+			Return(queries.CountTopValueExclusionsRow{Unpriced: 4, Unconverted: 1}, nil)
+		service := NewService(mockRepo)
+
+		report, err := service.TopValueItems(context.Background(), workspaceID, 20)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "USD", report.Currency)
+		assert.Len(t, report.Items, 1)
+		assert.Equal(t, itemID, report.Items[0].ItemID)
+		assert.Equal(t, int64(54000), report.Items[0].UnitPrice)
+		assert.Equal(t, int64(108000), report.Items[0].TotalValue)
+		assert.Equal(t, 4, report.ExcludedUnpricedCount)
+		assert.Equal(t, 1, report.ExcludedUnconvertedCount)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("defaults to EUR and limit 20", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("GetCurrencySettings", mock.Anything, workspaceID).
+			Return(queries.WarehouseCurrencySetting{}, shared.ErrNotFound)
+		mockRepo.On("GetTopValueItems", mock.Anything, workspaceID, int32(20)).
+			Return([]queries.GetTopValueItemsRow{}, nil)
+		mockRepo.On("CountTopValueExclusions", mock.Anything, workspaceID).
+			Return(queries.CountTopValueExclusionsRow{}, nil)
+		service := NewService(mockRepo)
+
+		report, err := service.TopValueItems(context.Background(), workspaceID, 0)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "EUR", report.Currency)
+		assert.Empty(t, report.Items)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("repository returns error", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("GetCurrencySettings", mock.Anything, workspaceID).
+			Return(queries.WarehouseCurrencySetting{}, shared.ErrNotFound)
+		mockRepo.On("GetTopValueItems", mock.Anything, workspaceID, int32(10)).
+			Return(nil, errors.New("database error"))
+		service := NewService(mockRepo)
+
+		report, err := service.TopValueItems(context.Background(), workspaceID, 10)
+
+		assert.Error(t, err)
+		assert.Nil(t, report)
+	})
+}
+
+func TestService_InventoryAging(t *testing.T) {
+	workspaceID := uuid.New()
+	year := int32(time.Now().Year())
+
+	t.Run("returns every bucket in order", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("GetCurrencySettings", mock.Anything, workspaceID).
+			Return(queries.WarehouseCurrencySetting{WorkspaceID: workspaceID, BaseCurrency: "USD", ExchangeRates: []byte(`{}`)}, nil)
+		mockRepo.On("GetInventoryAging", mock.Anything, workspaceID, year).
+			Return([]queries.GetInventoryAgingRow{
+				{Bucket: AgingUnknownYears, InventoryCount: 2, Quantity: 5, Unpriced: 2},
+				{Bucket: AgingThisYear, InventoryCount: 3, Quantity: 4, TotalValue: 12000, Unconverted: 1},
+			}, nil)
+		service := NewService(mockRepo)
+
+		report, err := service.InventoryAging(context.Background(), workspaceID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "USD", report.Currency)
+		assert.Equal(t, int(year), report.Year)
+		assert.Equal(t, []AgingBucket{
+			{Bucket: AgingThisYear, InventoryCount: 3, Quantity: 4, TotalValue: 12000, ExcludedUnconvertedCount: 1},
+			{Bucket: AgingOneToTwo},
+			{Bucket: AgingThreePlus},
+			{Bucket: AgingUnknownYears, InventoryCount: 2, Quantity: 5, ExcludedUnpricedCount: 2},
+		}, report.Buckets)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("repository returns error", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("GetCurrencySettings", mock.Anything, workspaceID).
+			Return(queries.WarehouseCurrencySetting{}, shared.ErrNotFound)
+		mockRepo.On("GetInventoryAging", mock.Anything, workspaceID, year).
+			Return(nil, errors.New("database error"))
+		service := NewService(mockRepo)
+
+		report, err := service.InventoryAging(context.Background(), workspaceID)
+
+		assert.Error(t, err)
+		assert.Nil(t, report)
+	})
+}
+
+func TestService_UpdateCurrencySettings(t *testing.T) {
+	workspaceID := uuid.New()
+
+	t.Run("normalizes codes and drops the base currency rate", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("UpsertCurrencySettings", mock.Anything, workspaceID, "EUR", []byte(`{"USD":0.92}`)).
+			Return(queries.WarehouseCurrencySetting{WorkspaceID: workspaceID, BaseCurrency: "EUR", ExchangeRates: []byte(`{"USD":0.92}`)}, nil)
+		service := NewService(mockRepo)
+
+		settings, err := service.UpdateCurrencySettings(context.Background(), workspaceID, CurrencySettings{
+			BaseCurrency:  "eur",
+			ExchangeRates: map[string]float64{"usd": 0.92, "EUR": 1},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "EUR", settings.BaseCurrency)
+		assert.Equal(t, map[string]float64{"USD": 0.92}, settings.ExchangeRates)
+		mockRepo.AssertExpectations(t)
+	})
+
+	tests := []struct {
+		name     string
+		settings CurrencySettings
+	}{
+		{"invalid base currency", CurrencySettings{BaseCurrency: "EURO"}},
+		{"invalid rate currency", CurrencySettings{BaseCurrency: "EUR", ExchangeRates: map[string]float64{"US": 1}}},
+		{"non-positive rate", CurrencySettings{BaseCurrency: "EUR", ExchangeRates: map[string]float64{"USD": 0}}},
+	}
+	for _, tt := range tests {
+		t.Run("rejects "+tt.name, func(t *testing.T) {
+			mockRepo := new(MockRepository)
+			service := NewService(mockRepo)
+
+			_, err := service.UpdateCurrencySettings(context.Background(), workspaceID, tt.settings)
+
+			assert.ErrorIs(t, err, shared.ErrInvalidInput)
+			mockRepo.AssertNotCalled(t, "UpsertCurrencySettings", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestService_WorkspaceStats(t *testing.T) {
+	workspaceID := uuid.New()
+	counts := queries.GetWorkspaceStatsRow{
+		TotalItems:    12,
+		TotalQuantity: 140,
+		ActiveLoans:   3,
+		OverdueLoans:  1,
+		LowStockItems: 2,
+		ExpiringSoon:  4,
+	}
+
+	t.Run("combines counts, value and currency", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("GetWorkspaceStats", mock.Anything, workspaceID, int32(30)).Return(counts, nil)
+		mockRepo.On("GetWorkspaceInventoryValue", mock.Anything, workspaceID).Return(int64(125000), nil)
+		mockRepo.On("GetCurrencySettings", mock.Anything, workspaceID).
+			Return(queries.WarehouseCurrencySetting{WorkspaceID: workspaceID, BaseCurrency: "USD"}, nil)
+		service := NewService(mockRepo)
+
+		stats, err := service.WorkspaceStats(context.Background(), workspaceID)
+
+		require.NoError(t, err)
+		assert.Equal(t, int32(12), stats.TotalItems)
+		assert.Equal(t, int64(140), stats.TotalQuantity)
+		assert.Equal(t, int32(3), stats.ActiveLoans)
+		assert.Equal(t, int32(1), stats.OverdueLoans)
+		assert.Equal(t, int32(2), stats.LowStockItems)
+		assert.Equal(t, int32(4), stats.ExpiringSoon)
+		assert.Equal(t, int32(30), stats.ExpiringWithinDays)
+		assert.Equal(t, int64(125000), stats.TotalValue)
+		assert.Equal(t, "USD", stats.Currency)
+		assert.False(t, stats.GeneratedAt.IsZero())
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("serves cached result until the TTL passes", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("GetWorkspaceStats", mock.Anything, workspaceID, int32(30)).Return(counts, nil)
+		mockRepo.On("GetWorkspaceInventoryValue", mock.Anything, workspaceID).Return(int64(0), nil)
+		mockRepo.On("GetCurrencySettings", mock.Anything, workspaceID).
+			Return(queries.WarehouseCurrencySetting{}, shared.ErrNotFound)
+		service := NewService(mockRepo)
+		service.SetStatsCacheTTL(time.Minute)
+		now := time.Now()
+		service.statsCache.now = func() time.Time { return now }
+
+		first, err := service.WorkspaceStats(context.Background(), workspaceID)
+		require.NoError(t, err)
+		now = now.Add(59 * time.Second)
+		second, err := service.WorkspaceStats(context.Background(), workspaceID)
+		require.NoError(t, err)
+		assert.Same(t, first, second)
+		mockRepo.AssertNumberOfCalls(t, "GetWorkspaceStats", 1)
+
+		now = now.Add(time.Second)
+		_, err = service.WorkspaceStats(context.Background(), workspaceID)
+		require.NoError(t, err)
+		mockRepo.AssertNumberOfCalls(t, "GetWorkspaceStats", 2)
+	})
+
+	t.Run("zero TTL disables the cache", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("GetWorkspaceStats", mock.Anything, workspaceID, int32(30)).Return(counts, nil)
+		mockRepo.On("GetWorkspaceInventoryValue", mock.Anything, workspaceID).Return(int64(0), nil)
+		mockRepo.On("GetCurrencySettings", mock.Anything, workspaceID).
+			Return(queries.WarehouseCurrencySetting{}, shared.ErrNotFound)
+		service := NewService(mockRepo)
+		service.SetStatsCacheTTL(0)
+
+		for i := 0; i < 2; i++ {
+			_, err := service.WorkspaceStats(context.Background(), workspaceID)
+			require.NoError(t, err)
+		}
+		mockRepo.AssertNumberOfCalls(t, "GetWorkspaceStats", 2)
+	})
+
+	t.Run("currency change invalidates the cached result", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("GetWorkspaceStats", mock.Anything, workspaceID, int32(30)).Return(counts, nil)
+		mockRepo.On("GetWorkspaceInventoryValue", mock.Anything, workspaceID).Return(int64(0), nil)
+		mockRepo.On("GetCurrencySettings", mock.Anything, workspaceID).
+			Return(queries.WarehouseCurrencySetting{}, shared.ErrNotFound)
+		mockRepo.On("UpsertCurrencySettings", mock.Anything, workspaceID, "USD", mock.Anything).
+			Return(queries.WarehouseCurrencySetting{WorkspaceID: workspaceID, BaseCurrency: "USD"}, nil)
+		service := NewService(mockRepo)
+
+		_, err := service.WorkspaceStats(context.Background(), workspaceID)
+		require.NoError(t, err)
+		_, err = service.UpdateCurrencySettings(context.Background(), workspaceID, CurrencySettings{BaseCurrency: "USD"})
+		require.NoError(t, err)
+		_, err = service.WorkspaceStats(context.Background(), workspaceID)
+		require.NoError(t, err)
+
+		mockRepo.AssertNumberOfCalls(t, "GetWorkspaceStats", 2)
+	})
+
+	t.Run("repository error is not cached", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("GetWorkspaceStats", mock.Anything, workspaceID, int32(30)).
+			Return(queries.GetWorkspaceStatsRow{}, errors.New("database error"))
+		service := NewService(mockRepo)
+
+		stats, err := service.WorkspaceStats(context.Background(), workspaceID)
+
+		assert.Error(t, err)
+		assert.Nil(t, stats)
+		_, ok := service.statsCache.get(workspaceID)
+		assert.False(t, ok)
+	})
+}
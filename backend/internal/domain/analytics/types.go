@@ -106,3 +106,103 @@ type AnalyticsSummary struct {
 	TopBorrowers        []TopBorrower            `json:"top_borrowers"`
 	MonthlyLoanActivity []MonthlyLoanActivity    `json:"monthly_loan_activity,omitempty"`
 }
+
+// StaleInventory represents an inventory record with no recent activity
+type StaleInventory struct {
+	InventoryID    uuid.UUID  `json:"inventory_id"`
+	ItemID         uuid.UUID  `json:"item_id"`
+	ItemName       string     `json:"item_name"`
+	SKU            string     `json:"sku"`
+	LocationID     uuid.UUID  `json:"location_id"`
+	LocationName   string     `json:"location_name"`
+	ContainerID    *uuid.UUID `json:"container_id,omitempty"`
+	ContainerName  *string    `json:"container_name,omitempty"`
+	Quantity       int32      `json:"quantity"`
+	LastActivityAt time.Time  `json:"last_activity_at"`
+	DaysIdle       int        `json:"days_idle"`
+}
+
+// StaleInventoryReport lists inventory not moved, loaned or updated in Days days
+type StaleInventoryReport struct {
+	Days   int              `json:"days"`
+	Cutoff time.Time        `json:"cutoff"`
+	Items  []StaleInventory `json:"items"`
+}
+
+// TopValueItem is an item ranked by the value of its inventory. Prices are in
+// cents of the report's base currency.
+type TopValueItem struct {
+	ItemID     uuid.UUID `json:"item_id"`
+	Name       string    `json:"name"`
+	SKU        string    `json:"sku"`
+	Quantity   int32     `json:"quantity"`
+	UnitPrice  int64     `json:"unit_price"`  // Quantity-weighted average, in cents
+	TotalValue int64     `json:"total_value"` // In cents
+}
+
+// TopValueReport lists the most valuable items, valued in Currency. Inventory
+// records without a purchase price, or priced in a currency that has no
+// exchange rate, are excluded and counted instead.
+type TopValueReport struct {
+	Currency                 string         `json:"currency"`
+	Items                    []TopValueItem `json:"items"`
+	ExcludedUnpricedCount    int            `json:"excluded_unpriced_count"`
+	ExcludedUnconvertedCount int            `json:"excluded_unconverted_count"`
+}
+
+// Aging buckets group inventory by how many calendar years ago it was
+// acquired, counted from the current year.
+const (
+	AgingThisYear     = "this_year"
+	AgingOneToTwo     = "1_2_years"
+	AgingThreePlus    = "3_plus_years"
+	AgingUnknownYears = "unknown"
+)
+
+// AgingBuckets lists the aging buckets, newest first.
+var AgingBuckets = []string{AgingThisYear, AgingOneToTwo, AgingThreePlus, AgingUnknownYears}
+
+// AgingBucket totals the inventory in one aging bucket. TotalValue is in
+// cents of the report's base currency.
+type AgingBucket struct {
+	Bucket                   string `json:"bucket" enum:"this_year,1_2_years,3_plus_years,unknown"`
+	InventoryCount           int    `json:"inventory_count"`
+	Quantity                 int64  `json:"quantity"`
+	TotalValue               int64  `json:"total_value"`
+	ExcludedUnpricedCount    int    `json:"excluded_unpriced_count"`
+	ExcludedUnconvertedCount int    `json:"excluded_unconverted_count"`
+}
+
+// AgingReport groups inventory by purchase date (date_acquired) into every
+// bucket of AgingBuckets, in that order, valued in Currency. Records without
+// a purchase price, or priced in a currency that has no exchange rate, count
+// towards InventoryCount but not TotalValue.
+type AgingReport struct {
+	Currency string        `json:"currency"`
+	Year     int           `json:"year"`
+	Buckets  []AgingBucket `json:"buckets"`
+}
+
+// CurrencySettings holds the workspace base currency that value reports use
+// and the rates for converting other currencies to it. A rate is the amount
+// of base currency one unit of the keyed currency is worth.
+type CurrencySettings struct {
+	BaseCurrency  string             `json:"base_currency"`
+	ExchangeRates map[string]float64 `json:"exchange_rates"`
+}
+
+// WorkspaceStats is the dashboard summary of a workspace. TotalValue is in
+// cents of Currency, the workspace base currency; inventory without a purchase
+// price or exchange rate is not counted in it.
+type WorkspaceStats struct {
+	TotalItems         int32     `json:"total_items"`
+	TotalQuantity      int64     `json:"total_quantity"`
+	ActiveLoans        int32     `json:"active_loans"`
+	OverdueLoans       int32     `json:"overdue_loans"`
+	LowStockItems      int32     `json:"low_stock_items"`
+	ExpiringSoon       int32     `json:"expiring_soon"`
+	ExpiringWithinDays int32     `json:"expiring_within_days"`
+	TotalValue         int64     `json:"total_value"`
+	Currency           string    `json:"currency"`
+	GeneratedAt        time.Time `json:"generated_at"`
+}
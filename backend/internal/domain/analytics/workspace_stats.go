@@ -0,0 +1,121 @@
+package analytics
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// DefaultStatsCacheTTL is how long WorkspaceStats results are reused
+	// unless SetStatsCacheTTL overrides it.
+	DefaultStatsCacheTTL = 30 * time.Second
+
+	// statsExpiringDays is the window for WorkspaceStats.ExpiringSoon,
+	// matching the /inventory/expiring default.
+	statsExpiringDays = 30
+)
+
+// statsCache holds recently computed WorkspaceStats per workspace. The
+// dashboard reads the summary on every load, so a short TTL saves most of the
+// aggregate queries while keeping the numbers close to live.
+type statsCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	now     func() time.Time
+	entries map[uuid.UUID]*WorkspaceStats
+}
+
+func newStatsCache(ttl time.Duration) *statsCache {
+	return &statsCache{
+		ttl:     ttl,
+		now:     time.Now,
+		entries: make(map[uuid.UUID]*WorkspaceStats),
+	}
+}
+
+func (c *statsCache) get(workspaceID uuid.UUID) (*WorkspaceStats, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	stats, ok := c.entries[workspaceID]
+	if !ok || c.now().Sub(stats.GeneratedAt) >= c.ttl {
+		return nil, false
+	}
+	return stats, true
+}
+
+// put stores stats and drops expired entries so workspaces that stop asking
+// don't stay in memory.
+func (c *statsCache) put(workspaceID uuid.UUID, stats *WorkspaceStats) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.ttl <= 0 {
+		return
+	}
+	now := c.now()
+	for id, s := range c.entries {
+		if now.Sub(s.GeneratedAt) >= c.ttl {
+			delete(c.entries, id)
+		}
+	}
+	c.entries[workspaceID] = stats
+}
+
+func (c *statsCache) invalidate(workspaceID uuid.UUID) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.entries, workspaceID)
+}
+
+func (c *statsCache) setTTL(ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.ttl = ttl
+	c.entries = make(map[uuid.UUID]*WorkspaceStats)
+}
+
+// SetStatsCacheTTL sets how long WorkspaceStats results are cached. Zero or
+// less disables caching.
+func (s *Service) SetStatsCacheTTL(ttl time.Duration) {
+	s.statsCache.setTTL(ttl)
+}
+
+// WorkspaceStats returns the dashboard summary for a workspace, served from
+// the cache when a result younger than the cache TTL exists.
+func (s *Service) WorkspaceStats(ctx context.Context, workspaceID uuid.UUID) (*WorkspaceStats, error) {
+	if stats, ok := s.statsCache.get(workspaceID); ok {
+		return stats, nil
+	}
+
+	counts, err := s.repo.GetWorkspaceStats(ctx, workspaceID, statsExpiringDays)
+	if err != nil {
+		return nil, err
+	}
+	value, err := s.repo.GetWorkspaceInventoryValue(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	settings, err := s.GetCurrencySettings(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	stats := &WorkspaceStats{
+		TotalItems:         counts.TotalItems,
+		TotalQuantity:      counts.TotalQuantity,
+		ActiveLoans:        counts.ActiveLoans,
+		OverdueLoans:       counts.OverdueLoans,
+		LowStockItems:      counts.LowStockItems,
+		ExpiringSoon:       counts.ExpiringSoon,
+		ExpiringWithinDays: statsExpiringDays,
+		TotalValue:         value,
+		Currency:           settings.BaseCurrency,
+		GeneratedAt:        s.statsCache.now(),
+	}
+	s.statsCache.put(workspaceID, stats)
+	return stats, nil
+}
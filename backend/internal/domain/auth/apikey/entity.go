@@ -0,0 +1,131 @@
+// Package apikey manages per-workspace API keys, which let machine clients
+// (home-automation scripts) call the workspace API without signing in.
+package apikey
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/auth/member"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+const (
+	// keyPrefix marks API keys so they are recognizable in scripts and
+	// secret scanners.
+	keyPrefix = "hwk_"
+
+	// displayPrefixLength is how much of the key is kept in the clear so
+	// owners can tell keys apart.
+	displayPrefixLength = len(keyPrefix) + 8
+
+	// MaxNameLength is the longest key name, in characters.
+	MaxNameLength = 100
+)
+
+// IsValidRole reports whether a key can act with role. Keys never act as
+// the owner, so a key cannot manage keys or the workspace itself.
+func IsValidRole(role member.Role) bool {
+	return role == member.RoleAdmin || role == member.RoleMember || role == member.RoleViewer
+}
+
+// APIKey is a workspace API key. Only the hash of the key is kept.
+type APIKey struct {
+	id          uuid.UUID
+	workspaceID uuid.UUID
+	name        string
+	prefix      string
+	keyHash     string
+	role        member.Role
+	expiresAt   *time.Time
+	lastUsedAt  *time.Time
+	revokedAt   *time.Time
+	createdBy   uuid.UUID
+	createdAt   time.Time
+}
+
+// NewAPIKey creates a key acting with role in the workspace and returns it
+// with the key itself, which is not kept and cannot be shown again. A nil
+// expiresAt never expires.
+func NewAPIKey(workspaceID, createdBy uuid.UUID, name string, role member.Role, expiresAt *time.Time) (*APIKey, string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, "", shared.NewFieldError(shared.ErrInvalidInput, "name", "name is required")
+	}
+	if utf8.RuneCountInString(name) > MaxNameLength {
+		return nil, "", shared.NewFieldError(shared.ErrInvalidInput, "name", "name is too long")
+	}
+	if !IsValidRole(role) {
+		return nil, "", shared.NewFieldError(shared.ErrInvalidInput, "role", "role must be admin, member or viewer")
+	}
+	now := time.Now()
+	if expiresAt != nil && !expiresAt.After(now) {
+		return nil, "", shared.NewFieldError(shared.ErrInvalidInput, "expires_at", "expiry must be in the future")
+	}
+
+	key, err := generateKey()
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &APIKey{
+		id:          shared.NewUUID(),
+		workspaceID: workspaceID,
+		name:        name,
+		prefix:      key[:displayPrefixLength],
+		keyHash:     HashKey(key),
+		role:        role,
+		expiresAt:   expiresAt,
+		createdBy:   createdBy,
+		createdAt:   now,
+	}, key, nil
+}
+
+// Reconstruct rebuilds a key from persistence.
+func Reconstruct(id, workspaceID uuid.UUID, name, prefix, keyHash string, role member.Role, expiresAt, lastUsedAt, revokedAt *time.Time, createdBy uuid.UUID, createdAt time.Time) *APIKey {
+	return &APIKey{id, workspaceID, name, prefix, keyHash, role, expiresAt, lastUsedAt, revokedAt, createdBy, createdAt}
+}
+
+func (k *APIKey) ID() uuid.UUID          { return k.id }
+func (k *APIKey) WorkspaceID() uuid.UUID { return k.workspaceID }
+func (k *APIKey) Name() string           { return k.name }
+func (k *APIKey) Prefix() string         { return k.prefix }
+func (k *APIKey) KeyHash() string        { return k.keyHash }
+func (k *APIKey) Role() member.Role      { return k.role }
+func (k *APIKey) ExpiresAt() *time.Time  { return k.expiresAt }
+func (k *APIKey) LastUsedAt() *time.Time { return k.lastUsedAt }
+func (k *APIKey) RevokedAt() *time.Time  { return k.revokedAt }
+func (k *APIKey) CreatedBy() uuid.UUID   { return k.createdBy }
+func (k *APIKey) CreatedAt() time.Time   { return k.createdAt }
+
+// IsExpired reports whether the key had expired at now.
+func (k *APIKey) IsExpired(now time.Time) bool {
+	return k.expiresAt != nil && !now.Before(*k.expiresAt)
+}
+
+// IsRevoked reports whether the key has been revoked.
+func (k *APIKey) IsRevoked() bool {
+	return k.revokedAt != nil
+}
+
+// HashKey is how keys are stored and looked up: SHA-256, hex. Keys are long
+// random strings, so a fast hash is enough.
+func HashKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
+
+// generateKey returns a new key: keyPrefix and 32 random bytes, hex.
+func generateKey() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return keyPrefix + hex.EncodeToString(b), nil
+}
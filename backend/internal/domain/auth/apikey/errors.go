@@ -0,0 +1,13 @@
+package apikey
+
+import (
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// Domain-specific errors for the API key domain.
+var (
+	ErrKeyNotFound = shared.NewDomainError(shared.ErrNotFound, "API key not found")
+	ErrInvalidKey  = shared.NewDomainError(shared.ErrUnauthorized, "invalid API key")
+	ErrKeyExpired  = shared.NewDomainError(shared.ErrUnauthorized, "API key has expired")
+	ErrKeyRevoked  = shared.NewDomainError(shared.ErrUnauthorized, "API key has been revoked")
+)
@@ -0,0 +1,161 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/google/uuid"
+
+	appMiddleware "github.com/antti/home-warehouse/go-backend/internal/api/middleware"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/auth/member"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// RegisterRoutes registers the workspace API key endpoints
+// (workspace-scoped). Only the owner may manage keys; since no key acts as
+// the owner, a key cannot be used to create more keys.
+func RegisterRoutes(api huma.API, svc ServiceInterface) {
+	huma.Get(api, "/api-keys", listKeys(svc))
+	huma.Post(api, "/api-keys", createKey(svc))
+	huma.Delete(api, "/api-keys/{id}", revokeKey(svc))
+}
+
+// requireOwner returns the workspace and the owner making the request.
+func requireOwner(ctx context.Context) (uuid.UUID, uuid.UUID, error) {
+	workspaceID, err := appMiddleware.RequireWorkspaceID(ctx)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, huma.Error401Unauthorized(err.Error())
+	}
+	authUser, ok := appMiddleware.GetAuthUser(ctx)
+	if !ok {
+		return uuid.Nil, uuid.Nil, huma.Error401Unauthorized("not authenticated")
+	}
+	if role, ok := appMiddleware.GetRole(ctx); !ok || role != string(member.RoleOwner) {
+		return uuid.Nil, uuid.Nil, huma.Error403Forbidden("only the workspace owner can manage API keys")
+	}
+	return workspaceID, authUser.ID, nil
+}
+
+func listKeys(svc ServiceInterface) func(context.Context, *struct{}) (*ListAPIKeysOutput, error) {
+	return func(ctx context.Context, _ *struct{}) (*ListAPIKeysOutput, error) {
+		workspaceID, _, err := requireOwner(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		keys, err := svc.List(ctx, workspaceID)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to list API keys")
+		}
+
+		items := make([]APIKeyResponse, len(keys))
+		for i, k := range keys {
+			items[i] = toAPIKeyResponse(k)
+		}
+		return &ListAPIKeysOutput{Body: APIKeyListResponse{Items: items}}, nil
+	}
+}
+
+func createKey(svc ServiceInterface) func(context.Context, *CreateAPIKeyInput) (*CreateAPIKeyOutput, error) {
+	return func(ctx context.Context, input *CreateAPIKeyInput) (*CreateAPIKeyOutput, error) {
+		workspaceID, ownerID, err := requireOwner(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		key, secret, err := svc.Create(ctx, CreateInput{
+			WorkspaceID: workspaceID,
+			CreatedBy:   ownerID,
+			Name:        input.Body.Name,
+			Role:        member.Role(input.Body.Role),
+			ExpiresAt:   input.Body.ExpiresAt,
+		})
+		if err != nil {
+			if errors.Is(err, shared.ErrInvalidInput) {
+				return nil, appMiddleware.MapDomainError(err)
+			}
+			return nil, huma.Error500InternalServerError("failed to create API key")
+		}
+
+		return &CreateAPIKeyOutput{Body: CreatedAPIKeyResponse{
+			APIKeyResponse: toAPIKeyResponse(key),
+			Key:            secret,
+		}}, nil
+	}
+}
+
+func revokeKey(svc ServiceInterface) func(context.Context, *RevokeAPIKeyInput) (*struct{}, error) {
+	return func(ctx context.Context, input *RevokeAPIKeyInput) (*struct{}, error) {
+		workspaceID, _, err := requireOwner(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := svc.Revoke(ctx, workspaceID, input.ID); err != nil {
+			if errors.Is(err, shared.ErrNotFound) {
+				return nil, huma.Error404NotFound("API key not found")
+			}
+			return nil, huma.Error500InternalServerError("failed to revoke API key")
+		}
+		return nil, nil
+	}
+}
+
+func toAPIKeyResponse(k *APIKey) APIKeyResponse {
+	return APIKeyResponse{
+		ID:         k.ID(),
+		Name:       k.Name(),
+		Prefix:     k.Prefix(),
+		Role:       string(k.Role()),
+		ExpiresAt:  k.ExpiresAt(),
+		LastUsedAt: k.LastUsedAt(),
+		RevokedAt:  k.RevokedAt(),
+		CreatedBy:  k.CreatedBy(),
+		CreatedAt:  k.CreatedAt(),
+	}
+}
+
+// Request/Response types
+
+type CreateAPIKeyInput struct {
+	Body struct {
+		Name      string     `json:"name" minLength:"1" maxLength:"100" doc:"What the key is for, e.g. 'Home Assistant'"`
+		Role      string     `json:"role" enum:"admin,member,viewer" doc:"Workspace role requests made with the key act with"`
+		ExpiresAt *time.Time `json:"expires_at,omitempty" doc:"When the key stops working. Omit for a key that never expires"`
+	}
+}
+
+type RevokeAPIKeyInput struct {
+	ID uuid.UUID `path:"id"`
+}
+
+type ListAPIKeysOutput struct {
+	Body APIKeyListResponse
+}
+
+type CreateAPIKeyOutput struct {
+	Body CreatedAPIKeyResponse
+}
+
+type APIKeyListResponse struct {
+	Items []APIKeyResponse `json:"items"`
+}
+
+type APIKeyResponse struct {
+	ID         uuid.UUID  `json:"id"`
+	Name       string     `json:"name"`
+	Prefix     string     `json:"prefix" doc:"Start of the key, to tell keys apart"`
+	Role       string     `json:"role"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty"`
+	CreatedBy  uuid.UUID  `json:"created_by"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+type CreatedAPIKeyResponse struct {
+	APIKeyResponse
+	Key string `json:"key" doc:"The API key. Send it as 'Authorization: ApiKey <key>'. It is shown only this once"`
+}
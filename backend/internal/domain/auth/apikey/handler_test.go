@@ -0,0 +1,152 @@
+package apikey_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/auth/apikey"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/auth/member"
+	"github.com/antti/home-warehouse/go-backend/internal/testutil"
+)
+
+// MockService implements apikey.ServiceInterface
+type MockService struct {
+	mock.Mock
+}
+
+func (m *MockService) Create(ctx context.Context, input apikey.CreateInput) (*apikey.APIKey, string, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, "", args.Error(2)
+	}
+	return args.Get(0).(*apikey.APIKey), args.String(1), args.Error(2)
+}
+
+func (m *MockService) List(ctx context.Context, workspaceID uuid.UUID) ([]*apikey.APIKey, error) {
+	args := m.Called(ctx, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*apikey.APIKey), args.Error(1)
+}
+
+func (m *MockService) Revoke(ctx context.Context, workspaceID, id uuid.UUID) error {
+	args := m.Called(ctx, workspaceID, id)
+	return args.Error(0)
+}
+
+func (m *MockService) Authenticate(ctx context.Context, key string) (*apikey.APIKey, error) {
+	args := m.Called(ctx, key)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*apikey.APIKey), args.Error(1)
+}
+
+func TestAPIKeyHandler_Create(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	apikey.RegisterRoutes(setup.API, mockSvc)
+
+	t.Run("owner gets the key once", func(t *testing.T) {
+		setup.SetRole("owner")
+		key, secret, err := apikey.NewAPIKey(setup.WorkspaceID, setup.UserID, "Home Assistant", member.RoleViewer, nil)
+		require.NoError(t, err)
+		mockSvc.On("Create", mock.Anything, mock.MatchedBy(func(in apikey.CreateInput) bool {
+			return in.WorkspaceID == setup.WorkspaceID && in.CreatedBy == setup.UserID &&
+				in.Name == "Home Assistant" && in.Role == member.RoleViewer && in.ExpiresAt == nil
+		})).Return(key, secret, nil).Once()
+
+		rec := setup.Post("/api-keys", `{"name":"Home Assistant","role":"viewer"}`)
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		body := testutil.ParseJSONResponse[apikey.CreatedAPIKeyResponse](t, rec)
+		assert.Equal(t, secret, body.Key)
+		assert.Equal(t, key.Prefix(), body.Prefix)
+		assert.Equal(t, "viewer", body.Role)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("rejects the owner role", func(t *testing.T) {
+		setup.SetRole("owner")
+
+		rec := setup.Post("/api-keys", `{"name":"script","role":"owner"}`)
+
+		testutil.AssertStatus(t, rec, http.StatusUnprocessableEntity)
+	})
+
+	for _, role := range []string{"admin", "member", "viewer"} {
+		t.Run(role+" cannot create keys", func(t *testing.T) {
+			setup.SetRole(role)
+
+			rec := setup.Post("/api-keys", `{"name":"script","role":"viewer"}`)
+
+			testutil.AssertStatus(t, rec, http.StatusForbidden)
+		})
+	}
+}
+
+func TestAPIKeyHandler_List(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	apikey.RegisterRoutes(setup.API, mockSvc)
+
+	t.Run("lists keys without the secret", func(t *testing.T) {
+		setup.SetRole("owner")
+		revokedAt := time.Now()
+		key := apikey.Reconstruct(uuid.New(), setup.WorkspaceID, "old script", "hwk_12345678", "hash",
+			member.RoleMember, nil, nil, &revokedAt, setup.UserID, time.Now().Add(-time.Hour))
+		mockSvc.On("List", mock.Anything, setup.WorkspaceID).Return([]*apikey.APIKey{key}, nil).Once()
+
+		rec := setup.Get("/api-keys")
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		assert.NotContains(t, rec.Body.String(), "hash")
+		body := testutil.ParseJSONResponse[apikey.APIKeyListResponse](t, rec)
+		require.Len(t, body.Items, 1)
+		assert.Equal(t, "old script", body.Items[0].Name)
+		assert.NotNil(t, body.Items[0].RevokedAt)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("admin cannot list keys", func(t *testing.T) {
+		setup.SetRole("admin")
+
+		rec := setup.Get("/api-keys")
+
+		testutil.AssertStatus(t, rec, http.StatusForbidden)
+	})
+}
+
+func TestAPIKeyHandler_Revoke(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	apikey.RegisterRoutes(setup.API, mockSvc)
+	setup.SetRole("owner")
+
+	t.Run("revokes the key", func(t *testing.T) {
+		id := uuid.New()
+		mockSvc.On("Revoke", mock.Anything, setup.WorkspaceID, id).Return(nil).Once()
+
+		rec := setup.Delete("/api-keys/" + id.String())
+
+		testutil.AssertStatus(t, rec, http.StatusNoContent)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("returns 404 for an unknown key", func(t *testing.T) {
+		id := uuid.New()
+		mockSvc.On("Revoke", mock.Anything, setup.WorkspaceID, id).Return(apikey.ErrKeyNotFound).Once()
+
+		rec := setup.Delete("/api-keys/" + id.String())
+
+		testutil.AssertStatus(t, rec, http.StatusNotFound)
+	})
+}
@@ -0,0 +1,28 @@
+package apikey
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Repository defines the interface for API key persistence.
+type Repository interface {
+	// Save persists a new key.
+	Save(ctx context.Context, key *APIKey) error
+
+	// FindByHash returns the key with the hash, revoked and expired keys
+	// included, or shared.ErrNotFound.
+	FindByHash(ctx context.Context, keyHash string) (*APIKey, error)
+
+	// FindByWorkspace returns a workspace's keys, newest first.
+	FindByWorkspace(ctx context.Context, workspaceID uuid.UUID) ([]*APIKey, error)
+
+	// Revoke marks a workspace's key revoked at a time. It returns
+	// shared.ErrNotFound if the workspace has no such unrevoked key.
+	Revoke(ctx context.Context, workspaceID, id uuid.UUID, at time.Time) error
+
+	// TouchLastUsed records when a key was last used.
+	TouchLastUsed(ctx context.Context, id uuid.UUID, at time.Time) error
+}
@@ -0,0 +1,106 @@
+package apikey
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/auth/member"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// lastUsedInterval is how stale last_used_at may get before Authenticate
+// writes it again, so a chatty script does not cost a write per request.
+const lastUsedInterval = time.Minute
+
+// ServiceInterface defines API key service operations.
+type ServiceInterface interface {
+	Create(ctx context.Context, input CreateInput) (*APIKey, string, error)
+	List(ctx context.Context, workspaceID uuid.UUID) ([]*APIKey, error)
+	Revoke(ctx context.Context, workspaceID, id uuid.UUID) error
+	Authenticate(ctx context.Context, key string) (*APIKey, error)
+}
+
+// CreateInput describes a new key. A nil ExpiresAt never expires.
+type CreateInput struct {
+	WorkspaceID uuid.UUID
+	CreatedBy   uuid.UUID
+	Name        string
+	Role        member.Role
+	ExpiresAt   *time.Time
+}
+
+// Service handles API key business logic.
+type Service struct {
+	repo Repository
+	now  func() time.Time
+}
+
+// NewService creates a new API key service.
+func NewService(repo Repository) *Service {
+	return &Service{repo: repo, now: time.Now}
+}
+
+// Create makes a new key and returns it with the key itself, which is shown
+// to the owner once and never stored.
+func (s *Service) Create(ctx context.Context, input CreateInput) (*APIKey, string, error) {
+	key, secret, err := NewAPIKey(input.WorkspaceID, input.CreatedBy, input.Name, input.Role, input.ExpiresAt)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := s.repo.Save(ctx, key); err != nil {
+		return nil, "", err
+	}
+	return key, secret, nil
+}
+
+// List returns a workspace's keys, revoked and expired ones included.
+func (s *Service) List(ctx context.Context, workspaceID uuid.UUID) ([]*APIKey, error) {
+	return s.repo.FindByWorkspace(ctx, workspaceID)
+}
+
+// Revoke stops a key from working. Revoking is permanent.
+func (s *Service) Revoke(ctx context.Context, workspaceID, id uuid.UUID) error {
+	err := s.repo.Revoke(ctx, workspaceID, id, s.now())
+	if errors.Is(err, shared.ErrNotFound) {
+		return ErrKeyNotFound
+	}
+	return err
+}
+
+// Authenticate returns the key a request presented. It fails with
+// ErrInvalidKey for an unknown key, ErrKeyRevoked or ErrKeyExpired.
+func (s *Service) Authenticate(ctx context.Context, key string) (*APIKey, error) {
+	if !strings.HasPrefix(key, keyPrefix) {
+		return nil, ErrInvalidKey
+	}
+	found, err := s.repo.FindByHash(ctx, HashKey(key))
+	if err != nil {
+		if errors.Is(err, shared.ErrNotFound) {
+			return nil, ErrInvalidKey
+		}
+		return nil, err
+	}
+
+	now := s.now()
+	if found.IsRevoked() {
+		return nil, ErrKeyRevoked
+	}
+	if found.IsExpired(now) {
+		return nil, ErrKeyExpired
+	}
+
+	// Best effort: last_used_at is informational and must not fail requests.
+	if found.lastUsedAt == nil || now.Sub(*found.lastUsedAt) >= lastUsedInterval {
+		if err := s.repo.TouchLastUsed(ctx, found.ID(), now); err != nil {
+			slog.WarnContext(ctx, "api key: failed to record last use", "api_key_id", found.ID(), "error", err)
+		} else {
+			found.lastUsedAt = &now
+		}
+	}
+	return found, nil
+}
@@ -0,0 +1,245 @@
+package apikey
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/auth/member"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// MockRepository is a mock implementation of the Repository interface.
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) Save(ctx context.Context, key *APIKey) error {
+	args := m.Called(ctx, key)
+	return args.Error(0)
+}
+
+func (m *MockRepository) FindByHash(ctx context.Context, keyHash string) (*APIKey, error) {
+	args := m.Called(ctx, keyHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*APIKey), args.Error(1)
+}
+
+func (m *MockRepository) FindByWorkspace(ctx context.Context, workspaceID uuid.UUID) ([]*APIKey, error) {
+	args := m.Called(ctx, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*APIKey), args.Error(1)
+}
+
+func (m *MockRepository) Revoke(ctx context.Context, workspaceID, id uuid.UUID, at time.Time) error {
+	args := m.Called(ctx, workspaceID, id, at)
+	return args.Error(0)
+}
+
+func (m *MockRepository) TouchLastUsed(ctx context.Context, id uuid.UUID, at time.Time) error {
+	args := m.Called(ctx, id, at)
+	return args.Error(0)
+}
+
+func TestNewAPIKey(t *testing.T) {
+	workspaceID, ownerID := uuid.New(), uuid.New()
+
+	t.Run("creates a key with a recognizable prefix", func(t *testing.T) {
+		key, secret, err := NewAPIKey(workspaceID, ownerID, "  Home Assistant ", member.RoleViewer, nil)
+
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(secret, "hwk_"))
+		assert.Equal(t, "Home Assistant", key.Name())
+		assert.Equal(t, secret[:displayPrefixLength], key.Prefix())
+		assert.Equal(t, HashKey(secret), key.KeyHash())
+		assert.NotContains(t, key.KeyHash(), secret)
+		assert.Equal(t, member.RoleViewer, key.Role())
+		assert.Nil(t, key.ExpiresAt())
+	})
+
+	t.Run("generates a different key each time", func(t *testing.T) {
+		_, a, err := NewAPIKey(workspaceID, ownerID, "a", member.RoleMember, nil)
+		require.NoError(t, err)
+		_, b, err := NewAPIKey(workspaceID, ownerID, "b", member.RoleMember, nil)
+		require.NoError(t, err)
+		assert.NotEqual(t, a, b)
+	})
+
+	past := time.Now().Add(-time.Hour)
+	tests := []struct {
+		name      string
+		keyName   string
+		role      member.Role
+		expiresAt *time.Time
+	}{
+		{"empty name", "  ", member.RoleMember, nil},
+		{"name too long", strings.Repeat("x", MaxNameLength+1), member.RoleMember, nil},
+		{"owner role", "script", member.RoleOwner, nil},
+		{"unknown role", "script", member.Role("root"), nil},
+		{"expiry in the past", "script", member.RoleMember, &past},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := NewAPIKey(workspaceID, ownerID, tt.keyName, tt.role, tt.expiresAt)
+			assert.ErrorIs(t, err, shared.ErrInvalidInput)
+		})
+	}
+}
+
+func TestService_Authenticate(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	secret := "hwk_" + strings.Repeat("ab", 32)
+
+	newKey := func(expiresAt, lastUsedAt, revokedAt *time.Time) *APIKey {
+		return Reconstruct(uuid.New(), uuid.New(), "script", secret[:displayPrefixLength], HashKey(secret),
+			member.RoleMember, expiresAt, lastUsedAt, revokedAt, uuid.New(), now.Add(-24*time.Hour))
+	}
+	newService := func(repo *MockRepository) *Service {
+		svc := NewService(repo)
+		svc.now = func() time.Time { return now }
+		return svc
+	}
+
+	t.Run("valid key records its use", func(t *testing.T) {
+		repo := new(MockRepository)
+		key := newKey(nil, nil, nil)
+		repo.On("FindByHash", ctx, HashKey(secret)).Return(key, nil)
+		repo.On("TouchLastUsed", ctx, key.ID(), now).Return(nil)
+
+		got, err := newService(repo).Authenticate(ctx, secret)
+
+		require.NoError(t, err)
+		assert.Equal(t, key.ID(), got.ID())
+		require.NotNil(t, got.LastUsedAt())
+		assert.Equal(t, now, *got.LastUsedAt())
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("recent use is not written again", func(t *testing.T) {
+		repo := new(MockRepository)
+		recent := now.Add(-10 * time.Second)
+		repo.On("FindByHash", ctx, HashKey(secret)).Return(newKey(nil, &recent, nil), nil)
+
+		_, err := newService(repo).Authenticate(ctx, secret)
+
+		require.NoError(t, err)
+		repo.AssertNotCalled(t, "TouchLastUsed", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("failing to record use does not fail the request", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("FindByHash", ctx, HashKey(secret)).Return(newKey(nil, nil, nil), nil)
+		repo.On("TouchLastUsed", ctx, mock.Anything, now).Return(assert.AnError)
+
+		_, err := newService(repo).Authenticate(ctx, secret)
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("expired key", func(t *testing.T) {
+		repo := new(MockRepository)
+		expired := now.Add(-time.Minute)
+		repo.On("FindByHash", ctx, HashKey(secret)).Return(newKey(&expired, nil, nil), nil)
+
+		_, err := newService(repo).Authenticate(ctx, secret)
+
+		assert.ErrorIs(t, err, ErrKeyExpired)
+	})
+
+	t.Run("revoked key", func(t *testing.T) {
+		repo := new(MockRepository)
+		revoked := now.Add(-time.Minute)
+		repo.On("FindByHash", ctx, HashKey(secret)).Return(newKey(nil, nil, &revoked), nil)
+
+		_, err := newService(repo).Authenticate(ctx, secret)
+
+		assert.ErrorIs(t, err, ErrKeyRevoked)
+	})
+
+	t.Run("unknown key", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("FindByHash", ctx, HashKey(secret)).Return(nil, shared.ErrNotFound)
+
+		_, err := newService(repo).Authenticate(ctx, secret)
+
+		assert.ErrorIs(t, err, ErrInvalidKey)
+	})
+
+	t.Run("key without the prefix is not looked up", func(t *testing.T) {
+		repo := new(MockRepository)
+
+		_, err := newService(repo).Authenticate(ctx, "eyJhbGciOiJIUzI1NiJ9")
+
+		assert.ErrorIs(t, err, ErrInvalidKey)
+		repo.AssertNotCalled(t, "FindByHash", mock.Anything, mock.Anything)
+	})
+
+	t.Run("lookup failure", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("FindByHash", ctx, HashKey(secret)).Return(nil, assert.AnError)
+
+		_, err := newService(repo).Authenticate(ctx, secret)
+
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
+
+func TestService_Create(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("saves the key", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("Save", ctx, mock.AnythingOfType("*apikey.APIKey")).Return(nil)
+
+		key, secret, err := NewService(repo).Create(ctx, CreateInput{
+			WorkspaceID: uuid.New(), CreatedBy: uuid.New(), Name: "script", Role: member.RoleAdmin,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, HashKey(secret), key.KeyHash())
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("invalid input is not saved", func(t *testing.T) {
+		repo := new(MockRepository)
+
+		_, _, err := NewService(repo).Create(ctx, CreateInput{Name: "script", Role: member.RoleOwner})
+
+		assert.ErrorIs(t, err, shared.ErrInvalidInput)
+		repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+}
+
+func TestService_Revoke(t *testing.T) {
+	ctx := context.Background()
+	workspaceID, id := uuid.New(), uuid.New()
+
+	t.Run("revokes the key", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("Revoke", ctx, workspaceID, id, mock.AnythingOfType("time.Time")).Return(nil)
+
+		assert.NoError(t, NewService(repo).Revoke(ctx, workspaceID, id))
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("unknown or already revoked key", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("Revoke", ctx, workspaceID, id, mock.AnythingOfType("time.Time")).Return(shared.ErrNotFound)
+
+		err := NewService(repo).Revoke(ctx, workspaceID, id)
+
+		assert.ErrorIs(t, err, ErrKeyNotFound)
+		assert.ErrorIs(t, err, shared.ErrNotFound)
+	})
+}
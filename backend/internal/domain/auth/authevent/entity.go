@@ -0,0 +1,106 @@
+// Package authevent records authentication events (sign-ins, password and
+// two-factor changes, session revocations) for the security log.
+package authevent
+
+import (
+	"net"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Type is the kind of authentication event.
+type Type string
+
+const (
+	TypeLogin             Type = "login"
+	TypePasswordChange    Type = "password_change"
+	TypeTwoFactorEnabled  Type = "two_factor_enabled"
+	TypeTwoFactorDisabled Type = "two_factor_disabled"
+	TypeSessionRevoked    Type = "session_revoked"
+)
+
+// Failure reasons of unsuccessful events. They describe what was wrong, never
+// what was submitted.
+const (
+	ReasonInvalidCredentials = "invalid_credentials"
+	ReasonAccountInactive    = "account_inactive"
+	ReasonInvalidTOTP        = "invalid_totp"
+	ReasonInvalidPassword    = "invalid_password"
+	ReasonLockedOut          = "locked_out"
+)
+
+// Client is where a request came from.
+type Client struct {
+	IPAddress string
+	UserAgent string
+}
+
+// Event is one entry of the security log. UserID is nil for a failed sign-in
+// with an email no account uses.
+type Event struct {
+	id            uuid.UUID
+	userID        *uuid.UUID
+	email         string
+	eventType     Type
+	success       bool
+	failureReason string
+	ipAddress     net.IP
+	userAgent     string
+	createdAt     time.Time
+}
+
+// NewEvent creates an event that happened now. The email is lowercased so
+// failed sign-ins can be counted per account.
+func NewEvent(userID *uuid.UUID, email string, eventType Type, success bool, failureReason string, client Client) *Event {
+	return &Event{
+		id:            uuid.Must(uuid.NewV7()),
+		userID:        userID,
+		email:         normalizeEmail(email),
+		eventType:     eventType,
+		success:       success,
+		failureReason: failureReason,
+		ipAddress:     net.ParseIP(client.IPAddress),
+		userAgent:     client.UserAgent,
+		createdAt:     time.Now(),
+	}
+}
+
+// Reconstitute creates an Event from persistence data.
+func Reconstitute(id uuid.UUID, userID *uuid.UUID, email string, eventType Type, success bool, failureReason, ipAddr, userAgent string, createdAt time.Time) *Event {
+	return &Event{
+		id:            id,
+		userID:        userID,
+		email:         email,
+		eventType:     eventType,
+		success:       success,
+		failureReason: failureReason,
+		ipAddress:     net.ParseIP(ipAddr),
+		userAgent:     userAgent,
+		createdAt:     createdAt,
+	}
+}
+
+// Getters
+func (e *Event) ID() uuid.UUID         { return e.id }
+func (e *Event) UserID() *uuid.UUID    { return e.userID }
+func (e *Event) Email() string         { return e.email }
+func (e *Event) Type() Type            { return e.eventType }
+func (e *Event) Success() bool         { return e.success }
+func (e *Event) FailureReason() string { return e.failureReason }
+func (e *Event) UserAgent() string     { return e.userAgent }
+func (e *Event) CreatedAt() time.Time  { return e.createdAt }
+
+// IPAddress returns the IP address as a string.
+func (e *Event) IPAddress() string {
+	if e.ipAddress == nil {
+		return ""
+	}
+	return e.ipAddress.String()
+}
+
+// normalizeEmail is how emails are stored and looked up.
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
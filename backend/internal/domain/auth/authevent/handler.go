@@ -0,0 +1,157 @@
+package authevent
+
+import (
+	"context"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/google/uuid"
+
+	appMiddleware "github.com/antti/home-warehouse/go-backend/internal/api/middleware"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// RegisterRoutes registers the user's own security log (protected).
+func RegisterRoutes(api huma.API, svc ServiceInterface) {
+	huma.Get(api, "/users/me/security-log", listMySecurityLog(svc))
+}
+
+// RegisterWorkspaceRoutes registers the workspace owner's view of the
+// members' security log (workspace-scoped).
+func RegisterWorkspaceRoutes(api huma.API, svc ServiceInterface) {
+	huma.Get(api, "/security-log", listWorkspaceSecurityLog(svc))
+}
+
+// listMySecurityLog lists the authenticated user's auth events.
+func listMySecurityLog(svc ServiceInterface) func(context.Context, *ListSecurityLogInput) (*ListSecurityLogOutput, error) {
+	return func(ctx context.Context, input *ListSecurityLogInput) (*ListSecurityLogOutput, error) {
+		authUser, ok := appMiddleware.GetAuthUser(ctx)
+		if !ok {
+			return nil, huma.Error401Unauthorized("not authenticated")
+		}
+
+		result, err := svc.ListForUser(ctx, authUser.ID, shared.Pagination{Page: input.Page, PageSize: input.Limit})
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to list security log")
+		}
+
+		return &ListSecurityLogOutput{Body: toSecurityLogResponse(result)}, nil
+	}
+}
+
+// listWorkspaceSecurityLog lists the auth events of every workspace member.
+// Only the owner may see it: it reveals where and when members sign in.
+func listWorkspaceSecurityLog(svc ServiceInterface) func(context.Context, *ListSecurityLogInput) (*ListSecurityLogOutput, error) {
+	return func(ctx context.Context, input *ListSecurityLogInput) (*ListSecurityLogOutput, error) {
+		workspaceID, err := appMiddleware.RequireWorkspaceID(ctx)
+		if err != nil {
+			return nil, huma.Error401Unauthorized(err.Error())
+		}
+		if role, ok := appMiddleware.GetRole(ctx); !ok || role != "owner" {
+			return nil, huma.Error403Forbidden("only the workspace owner can view the security log")
+		}
+
+		result, err := svc.ListForWorkspace(ctx, workspaceID, shared.Pagination{Page: input.Page, PageSize: input.Limit})
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to list security log")
+		}
+
+		return &ListSecurityLogOutput{Body: toSecurityLogResponse(result)}, nil
+	}
+}
+
+// ClientHeaders are embedded in the input of every endpoint that records an
+// auth event, so the event carries the client's IP address and user agent.
+type ClientHeaders struct {
+	UserAgent     string `header:"User-Agent"`
+	XForwardedFor string `header:"X-Forwarded-For"`
+	XRealIP       string `header:"X-Real-IP"`
+
+	remoteAddr string
+}
+
+// Resolve captures the connection's address for requests that did not come
+// through a proxy.
+func (h *ClientHeaders) Resolve(ctx huma.Context) []error {
+	h.remoteAddr = ctx.RemoteAddr()
+	if host, _, err := net.SplitHostPort(h.remoteAddr); err == nil {
+		h.remoteAddr = host
+	}
+	return nil
+}
+
+// Client returns where the request came from. The IP address is the first
+// X-Forwarded-For hop, else X-Real-IP, else the connection's address.
+func (h ClientHeaders) Client() Client {
+	client := Client{UserAgent: h.UserAgent, IPAddress: h.remoteAddr}
+	switch {
+	case h.XForwardedFor != "":
+		client.IPAddress = strings.TrimSpace(strings.Split(h.XForwardedFor, ",")[0])
+	case h.XRealIP != "":
+		client.IPAddress = h.XRealIP
+	}
+	return client
+}
+
+// RemoteIP returns the connection's address, which chi's RealIP middleware
+// has already resolved from the proxy headers. Rate limits key on it rather
+// than on Client's IP address, whose first X-Forwarded-For hop the client
+// sets itself.
+func (h ClientHeaders) RemoteIP() string {
+	return h.remoteAddr
+}
+
+func toSecurityLogResponse(result *shared.PagedResult[*Event]) SecurityLogResponse {
+	items := make([]EventResponse, len(result.Items))
+	for i, e := range result.Items {
+		items[i] = EventResponse{
+			ID:            e.ID(),
+			UserID:        e.UserID(),
+			Email:         e.Email(),
+			EventType:     string(e.Type()),
+			Success:       e.Success(),
+			FailureReason: e.FailureReason(),
+			IPAddress:     e.IPAddress(),
+			UserAgent:     e.UserAgent(),
+			CreatedAt:     e.CreatedAt(),
+		}
+	}
+	return SecurityLogResponse{
+		Items:      items,
+		Total:      result.Total,
+		Page:       result.Page,
+		TotalPages: result.TotalPages,
+	}
+}
+
+// Request/Response types
+
+type ListSecurityLogInput struct {
+	Page  int `query:"page" default:"1" minimum:"1"`
+	Limit int `query:"limit" default:"50" minimum:"1" maximum:"100"`
+}
+
+type ListSecurityLogOutput struct {
+	Body SecurityLogResponse
+}
+
+type SecurityLogResponse struct {
+	Items      []EventResponse `json:"items"`
+	Total      int             `json:"total"`
+	Page       int             `json:"page"`
+	TotalPages int             `json:"total_pages"`
+}
+
+type EventResponse struct {
+	ID            uuid.UUID  `json:"id"`
+	UserID        *uuid.UUID `json:"user_id,omitempty"`
+	Email         string     `json:"email,omitempty"`
+	EventType     string     `json:"event_type" doc:"login, password_change, two_factor_enabled, two_factor_disabled or session_revoked"`
+	Success       bool       `json:"success"`
+	FailureReason string     `json:"failure_reason,omitempty" doc:"Why the attempt failed, e.g. invalid_credentials, invalid_totp or locked_out"`
+	IPAddress     string     `json:"ip_address,omitempty"`
+	UserAgent     string     `json:"user_agent,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
@@ -0,0 +1,111 @@
+package authevent_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/auth/authevent"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+	"github.com/antti/home-warehouse/go-backend/internal/testutil"
+)
+
+// MockService implements authevent.ServiceInterface
+type MockService struct {
+	mock.Mock
+}
+
+func (m *MockService) Record(ctx context.Context, event *authevent.Event) {
+	m.Called(ctx, event)
+}
+
+func (m *MockService) ListForUser(ctx context.Context, userID uuid.UUID, pagination shared.Pagination) (*shared.PagedResult[*authevent.Event], error) {
+	args := m.Called(ctx, userID, pagination)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*shared.PagedResult[*authevent.Event]), args.Error(1)
+}
+
+func (m *MockService) ListForWorkspace(ctx context.Context, workspaceID uuid.UUID, pagination shared.Pagination) (*shared.PagedResult[*authevent.Event], error) {
+	args := m.Called(ctx, workspaceID, pagination)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*shared.PagedResult[*authevent.Event]), args.Error(1)
+}
+
+func (m *MockService) RecentFailedLogins(ctx context.Context, email string, window time.Duration) (int, error) {
+	args := m.Called(ctx, email, window)
+	return args.Int(0), args.Error(1)
+}
+
+func TestAuthEventHandler_MySecurityLog(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	authevent.RegisterRoutes(setup.API, mockSvc)
+
+	t.Run("lists the user's events", func(t *testing.T) {
+		userID := setup.UserID
+		failed := authevent.NewEvent(&userID, "test@example.com", authevent.TypeLogin, false, authevent.ReasonInvalidCredentials,
+			authevent.Client{IPAddress: "203.0.113.7", UserAgent: "Mozilla/5.0"})
+		result := shared.NewPagedResult([]*authevent.Event{failed}, 1, shared.Pagination{Page: 1, PageSize: 50})
+		mockSvc.On("ListForUser", mock.Anything, setup.UserID, mock.MatchedBy(func(p shared.Pagination) bool {
+			return p.Page == 1 && p.PageSize == 50
+		})).Return(&result, nil).Once()
+
+		rec := setup.Get("/users/me/security-log")
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		body := testutil.ParseJSONResponse[authevent.SecurityLogResponse](t, rec)
+		require.Len(t, body.Items, 1)
+		assert.Equal(t, "login", body.Items[0].EventType)
+		assert.False(t, body.Items[0].Success)
+		assert.Equal(t, "invalid_credentials", body.Items[0].FailureReason)
+		assert.Equal(t, "203.0.113.7", body.Items[0].IPAddress)
+		assert.Equal(t, "Mozilla/5.0", body.Items[0].UserAgent)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("returns 500 on service error", func(t *testing.T) {
+		mockSvc.On("ListForUser", mock.Anything, setup.UserID, mock.Anything).Return(nil, assert.AnError).Once()
+
+		rec := setup.Get("/users/me/security-log")
+
+		testutil.AssertStatus(t, rec, http.StatusInternalServerError)
+	})
+}
+
+func TestAuthEventHandler_WorkspaceSecurityLog(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	authevent.RegisterWorkspaceRoutes(setup.API, mockSvc)
+
+	t.Run("lists members' events for the owner", func(t *testing.T) {
+		setup.SetRole("owner")
+		result := shared.NewPagedResult([]*authevent.Event{}, 0, shared.Pagination{Page: 1, PageSize: 50})
+		mockSvc.On("ListForWorkspace", mock.Anything, setup.WorkspaceID, mock.Anything).Return(&result, nil).Once()
+
+		rec := setup.Get("/security-log")
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		mockSvc.AssertExpectations(t)
+	})
+
+	for _, role := range []string{"admin", "member", "viewer"} {
+		t.Run("forbids "+role, func(t *testing.T) {
+			setup.SetRole(role)
+
+			rec := setup.Get("/security-log")
+
+			testutil.AssertStatus(t, rec, http.StatusForbidden)
+		})
+	}
+	mockSvc.AssertNumberOfCalls(t, "ListForWorkspace", 1)
+}
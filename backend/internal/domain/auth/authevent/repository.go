@@ -0,0 +1,26 @@
+package authevent
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// Repository defines the interface for auth event persistence.
+type Repository interface {
+	// Save persists a new event.
+	Save(ctx context.Context, event *Event) error
+
+	// FindByUser returns a user's events, newest first, and their total.
+	FindByUser(ctx context.Context, userID uuid.UUID, pagination shared.Pagination) ([]*Event, int, error)
+
+	// FindByWorkspace returns the events of a workspace's members, newest
+	// first, and their total.
+	FindByWorkspace(ctx context.Context, workspaceID uuid.UUID, pagination shared.Pagination) ([]*Event, int, error)
+
+	// CountFailedLogins counts failed sign-ins for an email since a time.
+	CountFailedLogins(ctx context.Context, email string, since time.Time) (int, error)
+}
@@ -0,0 +1,72 @@
+package authevent
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// Recorder records authentication events. The auth handlers take one as an
+// optional dependency; without it nothing is logged.
+type Recorder interface {
+	Record(ctx context.Context, event *Event)
+}
+
+// ServiceInterface defines auth event service operations.
+type ServiceInterface interface {
+	Recorder
+	ListForUser(ctx context.Context, userID uuid.UUID, pagination shared.Pagination) (*shared.PagedResult[*Event], error)
+	ListForWorkspace(ctx context.Context, workspaceID uuid.UUID, pagination shared.Pagination) (*shared.PagedResult[*Event], error)
+	RecentFailedLogins(ctx context.Context, email string, window time.Duration) (int, error)
+}
+
+// Service handles auth event business logic.
+type Service struct {
+	repo Repository
+}
+
+// NewService creates a new auth event service.
+func NewService(repo Repository) *Service {
+	return &Service{repo: repo}
+}
+
+// Record saves an event. A failure is logged rather than returned: the
+// security log must never be the reason a sign-in or password change fails.
+func (s *Service) Record(ctx context.Context, event *Event) {
+	if err := s.repo.Save(ctx, event); err != nil {
+		slog.ErrorContext(ctx, "auth event: failed to record",
+			"event_type", event.Type(), "user_id", event.UserID(), "error", err)
+	}
+}
+
+// ListForUser returns a user's own security log.
+func (s *Service) ListForUser(ctx context.Context, userID uuid.UUID, pagination shared.Pagination) (*shared.PagedResult[*Event], error) {
+	events, total, err := s.repo.FindByUser(ctx, userID, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	result := shared.NewPagedResult(events, total, pagination)
+	return &result, nil
+}
+
+// ListForWorkspace returns the security log of every member of a workspace.
+func (s *Service) ListForWorkspace(ctx context.Context, workspaceID uuid.UUID, pagination shared.Pagination) (*shared.PagedResult[*Event], error) {
+	events, total, err := s.repo.FindByWorkspace(ctx, workspaceID, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	result := shared.NewPagedResult(events, total, pagination)
+	return &result, nil
+}
+
+// RecentFailedLogins counts failed sign-ins for an email within the window,
+// the basis for locking an account out after repeated failures.
+func (s *Service) RecentFailedLogins(ctx context.Context, email string, window time.Duration) (int, error) {
+	return s.repo.CountFailedLogins(ctx, normalizeEmail(email), time.Now().Add(-window))
+}
@@ -0,0 +1,168 @@
+package authevent
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// MockRepository is a mock implementation of the Repository interface.
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) Save(ctx context.Context, event *Event) error {
+	args := m.Called(ctx, event)
+	return args.Error(0)
+}
+
+func (m *MockRepository) FindByUser(ctx context.Context, userID uuid.UUID, pagination shared.Pagination) ([]*Event, int, error) {
+	args := m.Called(ctx, userID, pagination)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*Event), args.Int(1), args.Error(2)
+}
+
+func (m *MockRepository) FindByWorkspace(ctx context.Context, workspaceID uuid.UUID, pagination shared.Pagination) ([]*Event, int, error) {
+	args := m.Called(ctx, workspaceID, pagination)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*Event), args.Int(1), args.Error(2)
+}
+
+func (m *MockRepository) CountFailedLogins(ctx context.Context, email string, since time.Time) (int, error) {
+	args := m.Called(ctx, email, since)
+	return args.Int(0), args.Error(1)
+}
+
+func TestNewEvent(t *testing.T) {
+	userID := uuid.New()
+
+	event := NewEvent(&userID, "  Jane@Example.COM ", TypeLogin, false, ReasonInvalidCredentials,
+		Client{IPAddress: "203.0.113.7", UserAgent: "Mozilla/5.0"})
+
+	assert.NotEqual(t, uuid.Nil, event.ID())
+	assert.Equal(t, userID, *event.UserID())
+	assert.Equal(t, "jane@example.com", event.Email())
+	assert.Equal(t, TypeLogin, event.Type())
+	assert.False(t, event.Success())
+	assert.Equal(t, ReasonInvalidCredentials, event.FailureReason())
+	assert.Equal(t, "203.0.113.7", event.IPAddress())
+	assert.Equal(t, "Mozilla/5.0", event.UserAgent())
+	assert.WithinDuration(t, time.Now(), event.CreatedAt(), time.Second)
+}
+
+func TestNewEvent_InvalidIPIsDropped(t *testing.T) {
+	event := NewEvent(nil, "jane@example.com", TypeLogin, true, "", Client{IPAddress: "not-an-ip"})
+
+	assert.Empty(t, event.IPAddress())
+	assert.Nil(t, event.UserID())
+}
+
+func TestClientHeaders_Client(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers ClientHeaders
+		wantIP  string
+	}{
+		{"first forwarded hop", ClientHeaders{XForwardedFor: "203.0.113.7, 10.0.0.1", XRealIP: "10.0.0.2"}, "203.0.113.7"},
+		{"real IP without forwarded", ClientHeaders{XRealIP: "198.51.100.4"}, "198.51.100.4"},
+		{"connection address without headers", ClientHeaders{remoteAddr: "192.0.2.1"}, "192.0.2.1"},
+		{"no address", ClientHeaders{}, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.headers.UserAgent = "curl/8"
+			client := tt.headers.Client()
+			assert.Equal(t, tt.wantIP, client.IPAddress)
+			assert.Equal(t, "curl/8", client.UserAgent)
+		})
+	}
+}
+
+func TestClientHeaders_RemoteIP(t *testing.T) {
+	headers := ClientHeaders{XForwardedFor: "203.0.113.7", XRealIP: "198.51.100.4", remoteAddr: "192.0.2.1"}
+
+	assert.Equal(t, "192.0.2.1", headers.RemoteIP(), "proxy headers are ignored")
+}
+
+func TestService_Record(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("saves the event", func(t *testing.T) {
+		repo := new(MockRepository)
+		svc := NewService(repo)
+		event := NewEvent(nil, "jane@example.com", TypeLogin, true, "", Client{})
+		repo.On("Save", ctx, event).Return(nil).Once()
+
+		svc.Record(ctx, event)
+
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("swallows a save failure", func(t *testing.T) {
+		repo := new(MockRepository)
+		svc := NewService(repo)
+		repo.On("Save", ctx, mock.Anything).Return(assert.AnError).Once()
+
+		assert.NotPanics(t, func() {
+			svc.Record(ctx, NewEvent(nil, "jane@example.com", TypeLogin, true, "", Client{}))
+		})
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestService_ListForUser(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockRepository)
+	svc := NewService(repo)
+	userID := uuid.New()
+	pagination := shared.Pagination{Page: 2, PageSize: 1}
+	events := []*Event{NewEvent(&userID, "jane@example.com", TypeLogin, true, "", Client{})}
+	repo.On("FindByUser", ctx, userID, pagination).Return(events, 3, nil).Once()
+
+	result, err := svc.ListForUser(ctx, userID, pagination)
+
+	require.NoError(t, err)
+	assert.Equal(t, events, result.Items)
+	assert.Equal(t, 3, result.Total)
+	assert.Equal(t, 2, result.Page)
+	assert.Equal(t, 3, result.TotalPages)
+}
+
+func TestService_ListForWorkspace_Error(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockRepository)
+	svc := NewService(repo)
+	workspaceID := uuid.New()
+	repo.On("FindByWorkspace", ctx, workspaceID, mock.Anything).Return(nil, 0, assert.AnError).Once()
+
+	result, err := svc.ListForWorkspace(ctx, workspaceID, shared.DefaultPagination())
+
+	assert.ErrorIs(t, err, assert.AnError)
+	assert.Nil(t, result)
+}
+
+func TestService_RecentFailedLogins(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockRepository)
+	svc := NewService(repo)
+	repo.On("CountFailedLogins", ctx, "jane@example.com", mock.MatchedBy(func(since time.Time) bool {
+		return time.Since(since) > 14*time.Minute && time.Since(since) < 16*time.Minute
+	})).Return(4, nil).Once()
+
+	count, err := svc.RecentFailedLogins(ctx, "Jane@Example.com", 15*time.Minute)
+
+	require.NoError(t, err)
+	assert.Equal(t, 4, count)
+	repo.AssertExpectations(t)
+}
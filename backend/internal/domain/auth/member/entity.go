@@ -1,6 +1,7 @@
 package member
 
 import (
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
@@ -147,12 +148,14 @@ func (m *Member) IsOwner() bool {
 	return m.role == RoleOwner
 }
 
+var roles = []Role{RoleOwner, RoleAdmin, RoleMember, RoleViewer}
+
+// Roles returns every member role, most privileged first.
+func Roles() []Role {
+	return slices.Clone(roles)
+}
+
 // isValidRole checks if a role is valid.
 func isValidRole(role Role) bool {
-	switch role {
-	case RoleOwner, RoleAdmin, RoleMember, RoleViewer:
-		return true
-	default:
-		return false
-	}
+	return slices.Contains(roles, role)
 }
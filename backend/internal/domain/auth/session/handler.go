@@ -8,13 +8,15 @@ import (
 	"github.com/google/uuid"
 
 	appMiddleware "github.com/antti/home-warehouse/go-backend/internal/api/middleware"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/auth/authevent"
 )
 
 const msgNotAuthenticated = "not authenticated"
 
 // Handler holds dependencies for session HTTP handlers.
 type Handler struct {
-	svc ServiceInterface
+	svc        ServiceInterface
+	authEvents authevent.Recorder
 }
 
 // NewHandler creates a new session handler.
@@ -22,11 +24,25 @@ func NewHandler(svc ServiceInterface) *Handler {
 	return &Handler{svc: svc}
 }
 
+// SetAuthEventRecorder sets where session revocations are logged. Optional —
+// without it nothing is logged.
+func (h *Handler) SetAuthEventRecorder(recorder authevent.Recorder) {
+	h.authEvents = recorder
+}
+
+// recordRevoked logs a revocation by the signed-in user.
+func (h *Handler) recordRevoked(ctx context.Context, authUser *appMiddleware.AuthUser, client authevent.Client) {
+	if h.authEvents == nil {
+		return
+	}
+	h.authEvents.Record(ctx, authevent.NewEvent(&authUser.ID, authUser.Email, authevent.TypeSessionRevoked, true, "", client))
+}
+
 // RegisterRoutes registers session routes (protected).
 func (h *Handler) RegisterRoutes(api huma.API) {
 	huma.Get(api, "/users/me/sessions", h.listSessions)
 	huma.Delete(api, "/users/me/sessions/{id}", h.revokeSession)
-	huma.Delete(api, "/users/me/sessions", h.revokeAllOtherSessions)
+	huma.Delete(api, "/users/me/sessions", h.revokeAllSessions)
 }
 
 // SessionResponse represents a session in API responses.
@@ -73,6 +89,7 @@ func (h *Handler) listSessions(ctx context.Context, input *struct{}) (*ListSessi
 }
 
 type RevokeSessionInput struct {
+	authevent.ClientHeaders
 	ID uuid.UUID `path:"id" format:"uuid"`
 }
 
@@ -92,15 +109,31 @@ func (h *Handler) revokeSession(ctx context.Context, input *RevokeSessionInput)
 		return nil, huma.Error500InternalServerError("failed to revoke session")
 	}
 
+	h.recordRevoked(ctx, authUser, input.Client())
 	return nil, nil
 }
 
-func (h *Handler) revokeAllOtherSessions(ctx context.Context, input *struct{}) (*struct{}, error) {
+type RevokeAllSessionsInput struct {
+	authevent.ClientHeaders
+	IncludeCurrent bool `query:"include_current" doc:"Also sign out this session and invalidate all access tokens (sign out everywhere)"`
+}
+
+// revokeAllSessions revokes every session except the current one, or with
+// include_current=true signs the user out everywhere.
+func (h *Handler) revokeAllSessions(ctx context.Context, input *RevokeAllSessionsInput) (*struct{}, error) {
 	authUser, ok := appMiddleware.GetAuthUser(ctx)
 	if !ok {
 		return nil, huma.Error401Unauthorized(msgNotAuthenticated)
 	}
 
+	if input.IncludeCurrent {
+		if err := h.svc.SignOutEverywhere(ctx, authUser.ID); err != nil {
+			return nil, huma.Error500InternalServerError("failed to sign out everywhere")
+		}
+		h.recordRevoked(ctx, authUser, input.Client())
+		return nil, nil
+	}
+
 	currentSessionID, ok := appMiddleware.GetCurrentSessionID(ctx)
 	if !ok {
 		return nil, huma.Error400BadRequest("current session not found")
@@ -110,5 +143,6 @@ func (h *Handler) revokeAllOtherSessions(ctx context.Context, input *struct{}) (
 		return nil, huma.Error500InternalServerError("failed to revoke sessions")
 	}
 
+	h.recordRevoked(ctx, authUser, input.Client())
 	return nil, nil
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	appMiddleware "github.com/antti/home-warehouse/go-backend/internal/api/middleware"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/auth/authevent"
 )
 
 // MockServiceInterface is a mock implementation of ServiceInterface.
@@ -64,6 +65,11 @@ func (m *MockServiceInterface) RevokeAll(ctx context.Context, userID uuid.UUID)
 	return args.Error(0)
 }
 
+func (m *MockServiceInterface) SignOutEverywhere(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
 func authedContext(userID uuid.UUID) context.Context {
 	ctx := context.WithValue(context.Background(), appMiddleware.UserContextKey, &appMiddleware.AuthUser{ID: userID})
 	return ctx
@@ -142,6 +148,49 @@ func TestHandler_RevokeSession_Success(t *testing.T) {
 	svc.AssertExpectations(t)
 }
 
+type recordedEvents struct {
+	events []*authevent.Event
+}
+
+func (r *recordedEvents) Record(ctx context.Context, event *authevent.Event) {
+	r.events = append(r.events, event)
+}
+
+func TestHandler_RevokeSession_RecordsAuthEvent(t *testing.T) {
+	svc := new(MockServiceInterface)
+	h := NewHandler(svc)
+	recorder := &recordedEvents{}
+	h.SetAuthEventRecorder(recorder)
+	userID, targetID := uuid.New(), uuid.New()
+	svc.On("Revoke", mock.Anything, userID, targetID).Return(nil)
+
+	_, err := h.revokeSession(authedContext(userID), &RevokeSessionInput{
+		ClientHeaders: authevent.ClientHeaders{UserAgent: "curl/8", XRealIP: "198.51.100.4"},
+		ID:            targetID,
+	})
+
+	require.NoError(t, err)
+	require.Len(t, recorder.events, 1)
+	assert.Equal(t, authevent.TypeSessionRevoked, recorder.events[0].Type())
+	assert.Equal(t, userID, *recorder.events[0].UserID())
+	assert.Equal(t, "198.51.100.4", recorder.events[0].IPAddress())
+	assert.Equal(t, "curl/8", recorder.events[0].UserAgent())
+}
+
+func TestHandler_RevokeSession_FailureRecordsNothing(t *testing.T) {
+	svc := new(MockServiceInterface)
+	h := NewHandler(svc)
+	recorder := &recordedEvents{}
+	h.SetAuthEventRecorder(recorder)
+	userID, targetID := uuid.New(), uuid.New()
+	svc.On("Revoke", mock.Anything, userID, targetID).Return(assert.AnError)
+
+	_, err := h.revokeSession(authedContext(userID), &RevokeSessionInput{ID: targetID})
+
+	require.Error(t, err)
+	assert.Empty(t, recorder.events)
+}
+
 func TestHandler_RevokeSession_NotAuthenticated(t *testing.T) {
 	svc := new(MockServiceInterface)
 	h := NewHandler(svc)
@@ -180,7 +229,7 @@ func TestHandler_RevokeSession_ServiceErrorMapsTo500(t *testing.T) {
 	assert.Equal(t, http.StatusInternalServerError, statusOf(t, err))
 }
 
-// --- revokeAllOtherSessions ---
+// --- revokeAllSessions ---
 
 func TestHandler_RevokeAllOtherSessions_Success(t *testing.T) {
 	svc := new(MockServiceInterface)
@@ -189,18 +238,33 @@ func TestHandler_RevokeAllOtherSessions_Success(t *testing.T) {
 	ctx := appMiddleware.WithCurrentSessionID(authedContext(userID), currentID)
 	svc.On("RevokeAllExcept", mock.Anything, userID, currentID).Return(nil)
 
-	out, err := h.revokeAllOtherSessions(ctx, &struct{}{})
+	out, err := h.revokeAllSessions(ctx, &RevokeAllSessionsInput{})
 
 	require.NoError(t, err)
 	assert.Nil(t, out)
 	svc.AssertExpectations(t)
 }
 
+func TestHandler_RevokeAllSessions_RecordsAuthEvent(t *testing.T) {
+	svc := new(MockServiceInterface)
+	h := NewHandler(svc)
+	recorder := &recordedEvents{}
+	h.SetAuthEventRecorder(recorder)
+	userID := uuid.New()
+	svc.On("SignOutEverywhere", mock.Anything, userID).Return(nil)
+
+	_, err := h.revokeAllSessions(authedContext(userID), &RevokeAllSessionsInput{IncludeCurrent: true})
+
+	require.NoError(t, err)
+	require.Len(t, recorder.events, 1)
+	assert.Equal(t, authevent.TypeSessionRevoked, recorder.events[0].Type())
+}
+
 func TestHandler_RevokeAllOtherSessions_NotAuthenticated(t *testing.T) {
 	svc := new(MockServiceInterface)
 	h := NewHandler(svc)
 
-	out, err := h.revokeAllOtherSessions(context.Background(), &struct{}{})
+	out, err := h.revokeAllSessions(context.Background(), &RevokeAllSessionsInput{})
 
 	require.Error(t, err)
 	assert.Nil(t, out)
@@ -212,7 +276,7 @@ func TestHandler_RevokeAllOtherSessions_NoCurrentSessionInContext(t *testing.T)
 	h := NewHandler(svc)
 	userID := uuid.New()
 
-	out, err := h.revokeAllOtherSessions(authedContext(userID), &struct{}{})
+	out, err := h.revokeAllSessions(authedContext(userID), &RevokeAllSessionsInput{})
 
 	require.Error(t, err)
 	assert.Nil(t, out)
@@ -227,7 +291,35 @@ func TestHandler_RevokeAllOtherSessions_ServiceErrorMapsTo500(t *testing.T) {
 	ctx := appMiddleware.WithCurrentSessionID(authedContext(userID), currentID)
 	svc.On("RevokeAllExcept", mock.Anything, userID, currentID).Return(assert.AnError)
 
-	out, err := h.revokeAllOtherSessions(ctx, &struct{}{})
+	out, err := h.revokeAllSessions(ctx, &RevokeAllSessionsInput{})
+
+	require.Error(t, err)
+	assert.Nil(t, out)
+	assert.Equal(t, http.StatusInternalServerError, statusOf(t, err))
+}
+
+func TestHandler_RevokeAllSessions_IncludeCurrentSignsOutEverywhere(t *testing.T) {
+	svc := new(MockServiceInterface)
+	h := NewHandler(svc)
+	userID := uuid.New()
+	svc.On("SignOutEverywhere", mock.Anything, userID).Return(nil)
+
+	// No current session in context: signing out everywhere does not need one.
+	out, err := h.revokeAllSessions(authedContext(userID), &RevokeAllSessionsInput{IncludeCurrent: true})
+
+	require.NoError(t, err)
+	assert.Nil(t, out)
+	svc.AssertExpectations(t)
+	svc.AssertNotCalled(t, "RevokeAllExcept", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestHandler_RevokeAllSessions_IncludeCurrentServiceErrorMapsTo500(t *testing.T) {
+	svc := new(MockServiceInterface)
+	h := NewHandler(svc)
+	userID := uuid.New()
+	svc.On("SignOutEverywhere", mock.Anything, userID).Return(assert.AnError)
+
+	out, err := h.revokeAllSessions(authedContext(userID), &RevokeAllSessionsInput{IncludeCurrent: true})
 
 	require.Error(t, err)
 	assert.Nil(t, out)
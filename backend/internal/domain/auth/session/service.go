@@ -22,12 +22,21 @@ type ServiceInterface interface {
 	Revoke(ctx context.Context, userID, sessionID uuid.UUID) error
 	RevokeAllExcept(ctx context.Context, userID, currentSessionID uuid.UUID) error
 	RevokeAll(ctx context.Context, userID uuid.UUID) error
+	SignOutEverywhere(ctx context.Context, userID uuid.UUID) error
+}
+
+// TokenRevoker rejects a user's access tokens issued at or before a cutoff.
+// Sessions only gate refresh tokens; without a revoker, access tokens stay
+// valid until they expire.
+type TokenRevoker interface {
+	RevokeUserTokens(ctx context.Context, userID uuid.UUID, at time.Time) error
 }
 
 // Service handles session business logic.
 type Service struct {
 	repo            Repository
 	refreshDuration time.Duration
+	tokenRevoker    TokenRevoker
 }
 
 // NewService creates a new session service.
@@ -38,6 +47,12 @@ func NewService(repo Repository) *Service {
 	}
 }
 
+// SetTokenRevoker sets the store SignOutEverywhere uses to cut off access
+// tokens. Optional — without it only refresh tokens are revoked.
+func (s *Service) SetTokenRevoker(revoker TokenRevoker) {
+	s.tokenRevoker = revoker
+}
+
 // Create creates a new session.
 func (s *Service) Create(ctx context.Context, userID uuid.UUID, refreshToken, userAgent, ipAddress string) (*Session, error) {
 	expiresAt := time.Now().Add(s.refreshDuration)
@@ -84,3 +99,15 @@ func (s *Service) RevokeAllExcept(ctx context.Context, userID, currentSessionID
 func (s *Service) RevokeAll(ctx context.Context, userID uuid.UUID) error {
 	return s.repo.DeleteAllForUser(ctx, userID)
 }
+
+// SignOutEverywhere deletes all sessions for a user, including the current
+// one, and rejects every access token already issued to them.
+func (s *Service) SignOutEverywhere(ctx context.Context, userID uuid.UUID) error {
+	if err := s.repo.DeleteAllForUser(ctx, userID); err != nil {
+		return err
+	}
+	if s.tokenRevoker == nil {
+		return nil
+	}
+	return s.tokenRevoker.RevokeUserTokens(ctx, userID, time.Now())
+}
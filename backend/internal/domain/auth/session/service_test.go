@@ -222,3 +222,74 @@ func TestService_RevokeAll_DeletesEveryUserSession(t *testing.T) {
 	require.NoError(t, err)
 	repo.AssertExpectations(t)
 }
+
+// --- SignOutEverywhere ---
+
+// recordingRevoker records the cutoffs it is asked to set.
+type recordingRevoker struct {
+	userIDs []uuid.UUID
+	cutoffs []time.Time
+	err     error
+}
+
+func (r *recordingRevoker) RevokeUserTokens(_ context.Context, userID uuid.UUID, at time.Time) error {
+	r.userIDs = append(r.userIDs, userID)
+	r.cutoffs = append(r.cutoffs, at)
+	return r.err
+}
+
+func TestService_SignOutEverywhere_DeletesSessionsAndRevokesTokens(t *testing.T) {
+	repo := new(MockRepository)
+	svc := NewService(repo)
+	revoker := &recordingRevoker{}
+	svc.SetTokenRevoker(revoker)
+	userID := uuid.New()
+	repo.On("DeleteAllForUser", mock.Anything, userID).Return(nil)
+
+	before := time.Now()
+	err := svc.SignOutEverywhere(context.Background(), userID)
+
+	require.NoError(t, err)
+	repo.AssertExpectations(t)
+	require.Len(t, revoker.cutoffs, 1)
+	assert.Equal(t, userID, revoker.userIDs[0])
+	assert.WithinRange(t, revoker.cutoffs[0], before, time.Now())
+}
+
+func TestService_SignOutEverywhere_WithoutRevokerOnlyDeletesSessions(t *testing.T) {
+	repo := new(MockRepository)
+	svc := NewService(repo)
+	userID := uuid.New()
+	repo.On("DeleteAllForUser", mock.Anything, userID).Return(nil)
+
+	err := svc.SignOutEverywhere(context.Background(), userID)
+
+	require.NoError(t, err)
+	repo.AssertExpectations(t)
+}
+
+func TestService_SignOutEverywhere_RepoErrorSkipsRevocation(t *testing.T) {
+	repo := new(MockRepository)
+	svc := NewService(repo)
+	revoker := &recordingRevoker{}
+	svc.SetTokenRevoker(revoker)
+	userID := uuid.New()
+	repo.On("DeleteAllForUser", mock.Anything, userID).Return(assert.AnError)
+
+	err := svc.SignOutEverywhere(context.Background(), userID)
+
+	require.ErrorIs(t, err, assert.AnError)
+	assert.Empty(t, revoker.cutoffs)
+}
+
+func TestService_SignOutEverywhere_RevokerErrorPropagates(t *testing.T) {
+	repo := new(MockRepository)
+	svc := NewService(repo)
+	svc.SetTokenRevoker(&recordingRevoker{err: assert.AnError})
+	userID := uuid.New()
+	repo.On("DeleteAllForUser", mock.Anything, userID).Return(nil)
+
+	err := svc.SignOutEverywhere(context.Background(), userID)
+
+	require.ErrorIs(t, err, assert.AnError)
+}
@@ -5,6 +5,8 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/auth/authevent"
 )
 
 type RegisterInput struct {
@@ -24,12 +26,11 @@ type RegisterOutput struct {
 }
 
 type LoginInput struct {
-	UserAgent     string `header:"User-Agent"`
-	XForwardedFor string `header:"X-Forwarded-For"`
-	XRealIP       string `header:"X-Real-IP"`
-	Body          struct {
+	authevent.ClientHeaders
+	Body struct {
 		Email    string `json:"email" required:"true" format:"email"`
 		Password string `json:"password" required:"true"`
+		TOTPCode string `json:"totp_code,omitempty" doc:"Authenticator or recovery code; required when two-factor authentication is enabled"`
 	}
 }
 
@@ -67,6 +68,48 @@ type LogoutOutput struct {
 	SetCookie []http.Cookie `header:"Set-Cookie"`
 }
 
+type RequestPasswordResetInput struct {
+	Body struct {
+		Email string `json:"email" required:"true" format:"email"`
+	}
+}
+
+type ResetPasswordInput struct {
+	authevent.ClientHeaders
+	Body struct {
+		Token       string `json:"token" required:"true" minLength:"1" doc:"Token from the emailed reset link"`
+		NewPassword string `json:"new_password" required:"true" minLength:"8"`
+	}
+}
+
+type TwoFactorStatusOutput struct {
+	Body struct {
+		Enabled                bool `json:"enabled"`
+		RecoveryCodesRemaining int  `json:"recovery_codes_remaining"`
+	}
+}
+
+type EnableTOTPOutput struct {
+	Body struct {
+		Secret          string `json:"secret" doc:"Base32 secret for manual entry"`
+		ProvisioningURI string `json:"provisioning_uri" doc:"otpauth:// URI to render as a QR code"`
+	}
+}
+
+// TOTPCodeInput carries a code from the user's authenticator app.
+type TOTPCodeInput struct {
+	authevent.ClientHeaders
+	Body struct {
+		Code string `json:"code" required:"true" minLength:"6" maxLength:"6" pattern:"^[0-9]+$"`
+	}
+}
+
+type RecoveryCodesOutput struct {
+	Body struct {
+		RecoveryCodes []string `json:"recovery_codes" doc:"Single-use codes; shown only once"`
+	}
+}
+
 type UserResponse struct {
 	ID                      uuid.UUID       `json:"id"`
 	Email                   string          `json:"email"`
@@ -112,6 +155,7 @@ type UpdateMeOutput struct {
 }
 
 type UpdatePasswordInput struct {
+	authevent.ClientHeaders
 	Body struct {
 		CurrentPassword string `json:"current_password"`
 		NewPassword     string `json:"new_password" required:"true" minLength:"8"`
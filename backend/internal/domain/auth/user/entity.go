@@ -36,16 +36,23 @@ type User struct {
 	updatedAt               time.Time
 }
 
-// NewUser creates a new user with the given parameters.
+// NewUser creates a new user with the given parameters, validating the
+// password against DefaultPasswordPolicy.
 func NewUser(email, fullName, password string) (*User, error) {
+	return NewUserWithPolicy(email, fullName, password, DefaultPasswordPolicy())
+}
+
+// NewUserWithPolicy creates a new user, validating the password against the
+// given policy.
+func NewUserWithPolicy(email, fullName, password string, policy PasswordPolicy) (*User, error) {
 	if email == "" {
 		return nil, shared.NewFieldError(shared.ErrInvalidInput, "email", msgEmailRequired)
 	}
 	if fullName == "" {
 		return nil, shared.NewFieldError(shared.ErrInvalidInput, "full_name", msgFullNameRequired)
 	}
-	if len(password) < 8 {
-		return nil, shared.NewFieldError(shared.ErrInvalidInput, "password", "password must be at least 8 characters")
+	if err := policy.Validate(password); err != nil {
+		return nil, err
 	}
 
 	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
@@ -189,6 +196,19 @@ func (u *User) ShowArchived() bool { return u.showArchived }
 // NotificationPreferences returns the user's notification preferences.
 func (u *User) NotificationPreferences() map[string]bool { return u.notificationPreferences }
 
+// NotificationEnabled reports whether notifications gated by key are on.
+// Preferences are opt-out: a missing key means enabled, and the master
+// "enabled" key turns everything off.
+func (u *User) NotificationEnabled(key string) bool {
+	if v, ok := u.notificationPreferences["enabled"]; ok && !v {
+		return false
+	}
+	if v, ok := u.notificationPreferences[key]; ok && !v {
+		return false
+	}
+	return true
+}
+
 // AvatarPath returns the user's avatar storage path.
 func (u *User) AvatarPath() *string { return u.avatarPath }
 
@@ -218,10 +238,17 @@ func (u *User) UpdateProfile(fullName string) error {
 	return nil
 }
 
-// UpdatePassword changes the user's password.
+// UpdatePassword changes the user's password, validating it against
+// DefaultPasswordPolicy.
 func (u *User) UpdatePassword(newPassword string) error {
-	if len(newPassword) < 8 {
-		return shared.NewFieldError(shared.ErrInvalidInput, "password", "password must be at least 8 characters")
+	return u.UpdatePasswordWithPolicy(newPassword, DefaultPasswordPolicy())
+}
+
+// UpdatePasswordWithPolicy changes the user's password, validating it
+// against the given policy.
+func (u *User) UpdatePasswordWithPolicy(newPassword string, policy PasswordPolicy) error {
+	if err := policy.Validate(newPassword); err != nil {
+		return err
 	}
 
 	hash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
@@ -190,6 +190,30 @@ func TestUser_Activate(t *testing.T) {
 	assert.True(t, u.IsActive())
 }
 
+func TestUser_NotificationEnabled(t *testing.T) {
+	tests := []struct {
+		name  string
+		prefs map[string]bool
+		want  bool
+	}{
+		{"empty preferences", nil, true},
+		{"key on", map[string]bool{"approval_alerts": true}, true},
+		{"key off", map[string]bool{"approval_alerts": false}, false},
+		{"other key off", map[string]bool{"loan_alerts": false}, true},
+		{"master switch off", map[string]bool{"enabled": false, "approval_alerts": true}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := user.NewUser("test@example.com", "John Doe", "SecurePass123!")
+			assert.NoError(t, err)
+			u.UpdateNotificationPreferences(tt.prefs)
+
+			assert.Equal(t, tt.want, u.NotificationEnabled("approval_alerts"))
+		})
+	}
+}
+
 func TestUser_Reconstruct(t *testing.T) {
 	id := uuid.New()
 	now := time.Now()
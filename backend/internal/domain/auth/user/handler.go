@@ -1,9 +1,13 @@
 package user
 
 import (
+	"context"
+
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
 
+	"github.com/antti/home-warehouse/go-backend/internal/domain/auth/authevent"
 	"github.com/antti/home-warehouse/go-backend/internal/domain/auth/session"
 	"github.com/antti/home-warehouse/go-backend/internal/domain/auth/workspace"
 	"github.com/antti/home-warehouse/go-backend/internal/shared/jwt"
@@ -29,6 +33,8 @@ type Handler struct {
 	jwtService     jwt.ServiceInterface
 	workspaceSvc   workspace.ServiceInterface
 	sessionSvc     session.ServiceInterface
+	authEvents     authevent.Recorder
+	loginLimiter   LoginLimiter
 	avatarStorage  AvatarStorage
 	imageProcessor AvatarImageProcessor
 	uploadDir      string
@@ -63,12 +69,35 @@ func (h *Handler) SetSessionService(sessionSvc session.ServiceInterface) {
 	h.sessionSvc = sessionSvc
 }
 
+// SetAuthEventRecorder sets where sign-ins and password and two-factor
+// changes are logged. Optional — without it nothing is logged.
+func (h *Handler) SetAuthEventRecorder(recorder authevent.Recorder) {
+	h.authEvents = recorder
+}
+
+// SetLoginLimiter sets the lockout applied after repeated failed sign-ins.
+// Optional — without it failed sign-ins are only rate limited per IP address.
+func (h *Handler) SetLoginLimiter(limiter LoginLimiter) {
+	h.loginLimiter = limiter
+}
+
+// recordAuthEvent logs an auth event if a recorder is configured. failureReason
+// is empty for a successful event.
+func (h *Handler) recordAuthEvent(ctx context.Context, userID *uuid.UUID, email string, eventType authevent.Type, failureReason string, client authevent.Client) {
+	if h.authEvents == nil {
+		return
+	}
+	h.authEvents.Record(ctx, authevent.NewEvent(userID, email, eventType, failureReason == "", failureReason, client))
+}
+
 // RegisterPublicRoutes registers public user routes (no auth required).
 func (h *Handler) RegisterPublicRoutes(api huma.API) {
 	huma.Post(api, "/auth/register", h.register)
 	huma.Post(api, "/auth/login", h.login)
 	huma.Post(api, "/auth/refresh", h.refreshToken)
 	huma.Post(api, "/auth/logout", h.logout)
+	huma.Post(api, "/auth/password-reset/request", h.requestPasswordReset)
+	huma.Post(api, "/auth/password-reset/confirm", h.resetPassword)
 }
 
 // RegisterProtectedRoutes registers protected user routes (auth required).
@@ -81,6 +110,11 @@ func (h *Handler) RegisterProtectedRoutes(api huma.API) {
 	huma.Patch(api, "/users/me/preferences", h.updatePreferences)
 	huma.Delete(api, routeUsersMeAvatar, h.deleteAvatar)
 	huma.Delete(api, routeUsersMe, h.deleteMe)
+	huma.Get(api, "/users/me/2fa", h.getTwoFactorStatus)
+	huma.Post(api, "/users/me/2fa/totp", h.enableTOTP)
+	huma.Post(api, "/users/me/2fa/totp/confirm", h.confirmTOTP)
+	huma.Post(api, "/users/me/2fa/totp/disable", h.disableTOTP)
+	huma.Post(api, "/users/me/2fa/recovery-codes", h.regenerateRecoveryCodes)
 }
 
 // RegisterAvatarRoutes registers avatar upload and serve routes on a Chi router.
@@ -21,15 +21,9 @@ func (h *Handler) listUsers(ctx context.Context, input *ListUsersInput) (*ListUs
 		return nil, huma.Error403Forbidden(msgSuperuserAccessRequired)
 	}
 
-	pagination := shared.Pagination{
-		Page:     input.Page,
-		PageSize: input.PageSize,
-	}
-	if pagination.Page == 0 {
-		pagination.Page = 1
-	}
-	if pagination.PageSize == 0 {
-		pagination.PageSize = 20
+	pagination, err := shared.Pagination{Page: input.Page, PageSize: input.PageSize}.Normalize()
+	if err != nil {
+		return nil, huma.Error422UnprocessableEntity(err.Error())
 	}
 
 	result, err := h.svc.List(ctx, pagination)
@@ -2,16 +2,23 @@ package user
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log/slog"
+	"math"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/danielgtaylor/huma/v2"
+	"github.com/google/uuid"
 
 	appMiddleware "github.com/antti/home-warehouse/go-backend/internal/api/middleware"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/auth/authevent"
 	"github.com/antti/home-warehouse/go-backend/internal/domain/auth/session"
 	"github.com/antti/home-warehouse/go-backend/internal/domain/auth/workspace"
 	"github.com/antti/home-warehouse/go-backend/internal/shared"
+	"github.com/antti/home-warehouse/go-backend/internal/shared/apierror"
 	"github.com/antti/home-warehouse/go-backend/internal/shared/jwt"
 )
 
@@ -75,11 +82,32 @@ func (h *Handler) register(ctx context.Context, input *RegisterInput) (*Register
 }
 
 func (h *Handler) login(ctx context.Context, input *LoginInput) (*LoginOutput, error) {
+	client := input.Client()
+	ip := input.RemoteIP()
+	// A locked out client is turned away before its password is checked, so
+	// guessing on cannot reveal whether a guess was right.
+	if err := h.checkLoginLockout(ctx, input.Body.Email, ip, client); err != nil {
+		return nil, err
+	}
+
 	user, err := h.svc.Authenticate(ctx, input.Body.Email, input.Body.Password)
 	if err != nil {
+		reason := authevent.ReasonInvalidCredentials
+		if errors.Is(err, ErrInactiveUser) {
+			reason = authevent.ReasonAccountInactive
+		}
+		h.recordFailedLogin(ctx, input.Body.Email, reason, client)
+		if err := h.countFailedLogin(ctx, input.Body.Email, ip); err != nil {
+			return nil, err
+		}
 		return nil, huma.Error401Unauthorized("invalid credentials")
 	}
 
+	if err := h.checkSecondFactor(ctx, user, input.Body.TOTPCode, ip, client); err != nil {
+		return nil, err
+	}
+	h.resetFailedLogins(ctx, input.Body.Email, ip)
+
 	token, err := h.jwtService.GenerateToken(user.ID(), user.Email(), user.FullName(), user.IsSuperuser())
 	if err != nil {
 		return nil, huma.Error500InternalServerError(msgFailedGenerateToken)
@@ -92,10 +120,12 @@ func (h *Handler) login(ctx context.Context, input *LoginInput) (*LoginOutput, e
 
 	// Create session if session service is configured
 	if h.sessionSvc != nil {
-		ipAddress := getClientIPFromHeaders(input.XForwardedFor, input.XRealIP)
-		_, _ = h.sessionSvc.Create(ctx, user.ID(), refreshToken, input.UserAgent, ipAddress)
+		_, _ = h.sessionSvc.Create(ctx, user.ID(), refreshToken, client.UserAgent, client.IPAddress)
 	}
 
+	userID := user.ID()
+	h.recordAuthEvent(ctx, &userID, user.Email(), authevent.TypeLogin, "", client)
+
 	return &LoginOutput{
 		SetCookie: []http.Cookie{
 			*createAuthCookie(accessTokenCookie, token, accessTokenMaxAge),
@@ -111,6 +141,90 @@ func (h *Handler) login(ctx context.Context, input *LoginInput) (*LoginOutput, e
 	}, nil
 }
 
+// recordFailedLogin logs a rejected sign-in. The event belongs to the
+// account the email names, if there is one, so its owner sees the attempt.
+func (h *Handler) recordFailedLogin(ctx context.Context, email, reason string, client authevent.Client) {
+	if h.authEvents == nil {
+		return
+	}
+	var userID *uuid.UUID
+	if user, err := h.svc.GetByEmail(ctx, email); err == nil {
+		id := user.ID()
+		userID = &id
+	}
+	h.recordAuthEvent(ctx, userID, email, authevent.TypeLogin, reason, client)
+}
+
+// LoginLimiter locks out an email and IP address pair after too many failed
+// sign-ins within a window.
+type LoginLimiter interface {
+	// LockedFor returns how long the pair stays locked out, or zero.
+	LockedFor(ctx context.Context, email, ip string) (time.Duration, error)
+	// RecordFailure counts a failed sign-in and returns the lockout duration
+	// when this failure starts one, else zero.
+	RecordFailure(ctx context.Context, email, ip string) (time.Duration, error)
+	// Reset clears the pair's failures after a successful sign-in.
+	Reset(ctx context.Context, email, ip string) error
+}
+
+// checkLoginLockout returns a 429 while the email and IP address pair is
+// locked out. ip is the connection's address, not the client-supplied
+// forwarded one, so rotating X-Forwarded-For cannot dodge the lockout. The
+// limiter failing lets the sign-in through: a Redis outage must not lock
+// everyone out.
+func (h *Handler) checkLoginLockout(ctx context.Context, email, ip string, client authevent.Client) error {
+	if h.loginLimiter == nil {
+		return nil
+	}
+	lockedFor, err := h.loginLimiter.LockedFor(ctx, email, ip)
+	if err != nil {
+		slog.WarnContext(ctx, "login lockout check failed", "error", err)
+		return nil
+	}
+	if lockedFor <= 0 {
+		return nil
+	}
+	h.recordFailedLogin(ctx, email, authevent.ReasonLockedOut, client)
+	return loginLockedError(lockedFor)
+}
+
+// countFailedLogin counts a failed sign-in and returns a 429 when it is the
+// one that starts a lockout.
+func (h *Handler) countFailedLogin(ctx context.Context, email, ip string) error {
+	if h.loginLimiter == nil {
+		return nil
+	}
+	lockedFor, err := h.loginLimiter.RecordFailure(ctx, email, ip)
+	if err != nil {
+		slog.WarnContext(ctx, "failed to count failed sign-in", "error", err)
+		return nil
+	}
+	if lockedFor <= 0 {
+		return nil
+	}
+	return loginLockedError(lockedFor)
+}
+
+// resetFailedLogins clears the failure count after a successful sign-in.
+func (h *Handler) resetFailedLogins(ctx context.Context, email, ip string) {
+	if h.loginLimiter == nil {
+		return
+	}
+	if err := h.loginLimiter.Reset(ctx, email, ip); err != nil {
+		slog.WarnContext(ctx, "failed to reset failed sign-ins", "error", err)
+	}
+}
+
+// loginLockedError is the 429 for a locked out sign-in, with Retry-After in
+// whole seconds rounded up.
+func loginLockedError(lockedFor time.Duration) error {
+	headers := http.Header{}
+	headers.Set("Retry-After", strconv.Itoa(int(math.Ceil(lockedFor.Seconds()))))
+	return huma.ErrorWithHeaders(huma.Error429TooManyRequests("too many failed sign-in attempts, please try again later", &huma.ErrorDetail{
+		Message: string(apierror.ErrCodeLoginLocked),
+	}), headers)
+}
+
 func (h *Handler) refreshToken(ctx context.Context, input *RefreshTokenInput) (*RefreshTokenOutput, error) {
 	userID, err := h.jwtService.ValidateRefreshToken(input.Body.RefreshToken)
 	if err != nil {
@@ -188,3 +302,42 @@ func (h *Handler) logout(ctx context.Context, input *LogoutInput) (*LogoutOutput
 		},
 	}, nil
 }
+
+// requestPasswordReset emails a reset link. It answers 204 whether or not the
+// email is registered (and even if sending fails) so the response cannot be
+// used to enumerate accounts.
+func (h *Handler) requestPasswordReset(ctx context.Context, input *RequestPasswordResetInput) (*struct{}, error) {
+	if err := h.svc.RequestPasswordReset(ctx, input.Body.Email); err != nil {
+		if errors.Is(err, ErrPasswordResetUnavailable) {
+			return nil, huma.Error503ServiceUnavailable("password reset is not available on this server")
+		}
+		slog.ErrorContext(ctx, "password reset: failed to send reset link", "error", err)
+	}
+	return nil, nil
+}
+
+// resetPassword sets a new password from a reset link and signs the user out
+// everywhere, since whoever held the old password may still have sessions.
+func (h *Handler) resetPassword(ctx context.Context, input *ResetPasswordInput) (*struct{}, error) {
+	user, err := h.svc.ResetPassword(ctx, input.Body.Token, input.Body.NewPassword)
+	if err != nil {
+		if errors.Is(err, ErrPasswordResetUnavailable) {
+			return nil, huma.Error503ServiceUnavailable("password reset is not available on this server")
+		}
+		if shared.IsInvalidInput(err) {
+			return nil, appMiddleware.MapDomainError(err)
+		}
+		return nil, huma.Error500InternalServerError("failed to reset password")
+	}
+
+	userID := user.ID()
+	h.recordAuthEvent(ctx, &userID, user.Email(), authevent.TypePasswordChange, "", input.Client())
+
+	if h.sessionSvc != nil {
+		if err := h.sessionSvc.SignOutEverywhere(ctx, user.ID()); err != nil {
+			slog.ErrorContext(ctx, "password reset: failed to revoke sessions",
+				"user_id", user.ID(), "error", err)
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,197 @@
+package user_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/auth/authevent"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/auth/user"
+	"github.com/antti/home-warehouse/go-backend/internal/shared/jwt"
+	"github.com/antti/home-warehouse/go-backend/internal/testutil"
+)
+
+// recordedEvents collects the auth events a handler records.
+type recordedEvents struct {
+	events []*authevent.Event
+}
+
+func (r *recordedEvents) Record(ctx context.Context, event *authevent.Event) {
+	r.events = append(r.events, event)
+}
+
+// requestFromClient sends a JSON request with a browser's user agent through
+// a proxy that set X-Forwarded-For.
+func requestFromClient(setup *testutil.HandlerTestSetup, method, path, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Mozilla/5.0 (test)")
+	req.Header.Set("X-Forwarded-For", "203.0.113.7, 10.0.0.1")
+	rec := httptest.NewRecorder()
+	setup.Router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestUserHandler_Login_RecordsAuthEvents(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	handler := user.NewHandler(mockSvc, jwt.NewService("test-secret", 24), nil)
+	recorder := &recordedEvents{}
+	handler.SetAuthEventRecorder(recorder)
+	handler.RegisterPublicRoutes(setup.API)
+
+	testUser, _ := user.NewUser("jane@example.com", "Jane", "password123")
+
+	t.Run("records a successful sign-in with the client", func(t *testing.T) {
+		recorder.events = nil
+		mockSvc.On("Authenticate", mock.Anything, "jane@example.com", "password123").Return(testUser, nil).Once()
+		mockSvc.On("GetTwoFactorStatus", mock.Anything, testUser.ID()).Return(&user.TwoFactorStatus{}, nil).Once()
+
+		rec := requestFromClient(setup, http.MethodPost, "/auth/login", `{"email":"jane@example.com","password":"password123"}`)
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		require.Len(t, recorder.events, 1)
+		event := recorder.events[0]
+		assert.Equal(t, authevent.TypeLogin, event.Type())
+		assert.True(t, event.Success())
+		assert.Equal(t, testUser.ID(), *event.UserID())
+		assert.Equal(t, "jane@example.com", event.Email())
+		assert.Equal(t, "203.0.113.7", event.IPAddress())
+		assert.Equal(t, "Mozilla/5.0 (test)", event.UserAgent())
+	})
+
+	t.Run("records a wrong password against the account", func(t *testing.T) {
+		recorder.events = nil
+		mockSvc.On("Authenticate", mock.Anything, "Jane@Example.com", "guess-123").Return(nil, user.ErrInvalidPassword).Once()
+		mockSvc.On("GetByEmail", mock.Anything, "Jane@Example.com").Return(testUser, nil).Once()
+
+		rec := requestFromClient(setup, http.MethodPost, "/auth/login", `{"email":"Jane@Example.com","password":"guess-123"}`)
+
+		testutil.AssertStatus(t, rec, http.StatusUnauthorized)
+		require.Len(t, recorder.events, 1)
+		event := recorder.events[0]
+		assert.Equal(t, authevent.TypeLogin, event.Type())
+		assert.False(t, event.Success())
+		assert.Equal(t, authevent.ReasonInvalidCredentials, event.FailureReason())
+		assert.Equal(t, testUser.ID(), *event.UserID())
+		assert.Equal(t, "jane@example.com", event.Email(), "emails are lowercased so failures can be counted per account")
+		assert.NotContains(t, event.FailureReason(), "guess-123")
+	})
+
+	t.Run("records an unknown email without a user", func(t *testing.T) {
+		recorder.events = nil
+		mockSvc.On("Authenticate", mock.Anything, "nobody@example.com", "password123").Return(nil, user.ErrInvalidPassword).Once()
+		mockSvc.On("GetByEmail", mock.Anything, "nobody@example.com").Return(nil, user.ErrUserNotFound).Once()
+
+		rec := requestFromClient(setup, http.MethodPost, "/auth/login", `{"email":"nobody@example.com","password":"password123"}`)
+
+		testutil.AssertStatus(t, rec, http.StatusUnauthorized)
+		require.Len(t, recorder.events, 1)
+		assert.Nil(t, recorder.events[0].UserID())
+		assert.Equal(t, "nobody@example.com", recorder.events[0].Email())
+		assert.False(t, recorder.events[0].Success())
+	})
+
+	t.Run("records a deactivated account", func(t *testing.T) {
+		recorder.events = nil
+		mockSvc.On("Authenticate", mock.Anything, "jane@example.com", "password123").Return(nil, user.ErrInactiveUser).Once()
+		mockSvc.On("GetByEmail", mock.Anything, "jane@example.com").Return(testUser, nil).Once()
+
+		rec := requestFromClient(setup, http.MethodPost, "/auth/login", `{"email":"jane@example.com","password":"password123"}`)
+
+		testutil.AssertStatus(t, rec, http.StatusUnauthorized)
+		require.Len(t, recorder.events, 1)
+		assert.Equal(t, authevent.ReasonAccountInactive, recorder.events[0].FailureReason())
+	})
+
+	t.Run("records a wrong two-factor code but not the prompt for one", func(t *testing.T) {
+		recorder.events = nil
+		mockSvc.On("Authenticate", mock.Anything, "jane@example.com", "password123").Return(testUser, nil).Twice()
+		mockSvc.On("GetTwoFactorStatus", mock.Anything, testUser.ID()).Return(&user.TwoFactorStatus{Enabled: true}, nil).Twice()
+		mockSvc.On("VerifySecondFactor", mock.Anything, testUser.ID(), "000000").Return(user.ErrInvalidTwoFactorCode).Once()
+
+		rec := requestFromClient(setup, http.MethodPost, "/auth/login", `{"email":"jane@example.com","password":"password123"}`)
+		testutil.AssertStatus(t, rec, http.StatusUnauthorized)
+		assert.Empty(t, recorder.events)
+
+		rec = requestFromClient(setup, http.MethodPost, "/auth/login", `{"email":"jane@example.com","password":"password123","totp_code":"000000"}`)
+		testutil.AssertStatus(t, rec, http.StatusUnauthorized)
+		require.Len(t, recorder.events, 1)
+		assert.Equal(t, authevent.ReasonInvalidTOTP, recorder.events[0].FailureReason())
+		assert.Equal(t, testUser.ID(), *recorder.events[0].UserID())
+	})
+
+	mockSvc.AssertExpectations(t)
+}
+
+func TestUserHandler_Login_WithoutRecorder(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	handler := user.NewHandler(mockSvc, jwt.NewService("test-secret", 24), nil)
+	handler.RegisterPublicRoutes(setup.API)
+
+	mockSvc.On("Authenticate", mock.Anything, "jane@example.com", "wrong-pass").Return(nil, user.ErrInvalidPassword).Once()
+
+	rec := setup.Post("/auth/login", `{"email":"jane@example.com","password":"wrong-pass"}`)
+
+	testutil.AssertStatus(t, rec, http.StatusUnauthorized)
+	mockSvc.AssertNotCalled(t, "GetByEmail", mock.Anything, mock.Anything)
+}
+
+func TestUserHandler_AccountChanges_RecordAuthEvents(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	handler := user.NewHandler(mockSvc, jwt.NewService("test-secret", 24), nil)
+	recorder := &recordedEvents{}
+	handler.SetAuthEventRecorder(recorder)
+	handler.RegisterProtectedRoutes(setup.API)
+
+	t.Run("password change", func(t *testing.T) {
+		recorder.events = nil
+		mockSvc.On("UpdatePassword", mock.Anything, setup.UserID, "oldpass123", "newpass123").Return(nil).Once()
+
+		rec := requestFromClient(setup, http.MethodPatch, "/users/me/password", `{"current_password":"oldpass123","new_password":"newpass123"}`)
+
+		testutil.AssertStatus(t, rec, http.StatusNoContent)
+		require.Len(t, recorder.events, 1)
+		assert.Equal(t, authevent.TypePasswordChange, recorder.events[0].Type())
+		assert.True(t, recorder.events[0].Success())
+		assert.Equal(t, setup.UserID, *recorder.events[0].UserID())
+		assert.Equal(t, "203.0.113.7", recorder.events[0].IPAddress())
+	})
+
+	t.Run("password change with the wrong current password", func(t *testing.T) {
+		recorder.events = nil
+		mockSvc.On("UpdatePassword", mock.Anything, setup.UserID, "wrongpass", "newpass123").Return(user.ErrInvalidPassword).Once()
+
+		rec := requestFromClient(setup, http.MethodPatch, "/users/me/password", `{"current_password":"wrongpass","new_password":"newpass123"}`)
+
+		testutil.AssertStatus(t, rec, http.StatusBadRequest)
+		require.Len(t, recorder.events, 1)
+		assert.False(t, recorder.events[0].Success())
+		assert.Equal(t, authevent.ReasonInvalidPassword, recorder.events[0].FailureReason())
+	})
+
+	t.Run("two-factor enabled and disabled", func(t *testing.T) {
+		recorder.events = nil
+		mockSvc.On("ConfirmTOTP", mock.Anything, setup.UserID, "123456").Return([]string{"aaaa-bbbb"}, nil).Once()
+		mockSvc.On("DisableTOTP", mock.Anything, setup.UserID, "654321").Return(nil).Once()
+
+		rec := requestFromClient(setup, http.MethodPost, "/users/me/2fa/totp/confirm", `{"code":"123456"}`)
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		rec = requestFromClient(setup, http.MethodPost, "/users/me/2fa/totp/disable", `{"code":"654321"}`)
+		testutil.AssertStatus(t, rec, http.StatusNoContent)
+
+		require.Len(t, recorder.events, 2)
+		assert.Equal(t, authevent.TypeTwoFactorEnabled, recorder.events[0].Type())
+		assert.Equal(t, authevent.TypeTwoFactorDisabled, recorder.events[1].Type())
+	})
+
+	mockSvc.AssertExpectations(t)
+}
@@ -0,0 +1,244 @@
+package user_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/auth/authevent"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/auth/user"
+	"github.com/antti/home-warehouse/go-backend/internal/shared/jwt"
+	"github.com/antti/home-warehouse/go-backend/internal/testutil"
+)
+
+// fakeLoginLimiter is an in-memory user.LoginLimiter with a controllable
+// clock, keyed like the Redis store by lowercased email and IP address.
+type fakeLoginLimiter struct {
+	maxAttempts int
+	lockout     time.Duration
+	now         time.Time
+	failures    map[string]int
+	lockedUntil map[string]time.Time
+	err         error
+}
+
+func newFakeLoginLimiter(maxAttempts int, lockout time.Duration) *fakeLoginLimiter {
+	return &fakeLoginLimiter{
+		maxAttempts: maxAttempts,
+		lockout:     lockout,
+		now:         time.Now(),
+		failures:    map[string]int{},
+		lockedUntil: map[string]time.Time{},
+	}
+}
+
+func (f *fakeLoginLimiter) key(email, ip string) string {
+	return strings.ToLower(strings.TrimSpace(email)) + "|" + ip
+}
+
+func (f *fakeLoginLimiter) LockedFor(ctx context.Context, email, ip string) (time.Duration, error) {
+	if f.err != nil {
+		return 0, f.err
+	}
+	if until, ok := f.lockedUntil[f.key(email, ip)]; ok && until.After(f.now) {
+		return until.Sub(f.now), nil
+	}
+	return 0, nil
+}
+
+func (f *fakeLoginLimiter) RecordFailure(ctx context.Context, email, ip string) (time.Duration, error) {
+	if f.err != nil {
+		return 0, f.err
+	}
+	k := f.key(email, ip)
+	f.failures[k]++
+	if f.failures[k] < f.maxAttempts {
+		return 0, nil
+	}
+	delete(f.failures, k)
+	f.lockedUntil[k] = f.now.Add(f.lockout)
+	return f.lockout, nil
+}
+
+func (f *fakeLoginLimiter) Reset(ctx context.Context, email, ip string) error {
+	k := f.key(email, ip)
+	delete(f.failures, k)
+	delete(f.lockedUntil, k)
+	return f.err
+}
+
+func setupLockoutHandler(limiter user.LoginLimiter) (*testutil.HandlerTestSetup, *MockService, *recordedEvents) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	handler := user.NewHandler(mockSvc, jwt.NewService("test-secret", 24), nil)
+	recorder := &recordedEvents{}
+	handler.SetAuthEventRecorder(recorder)
+	handler.SetLoginLimiter(limiter)
+	handler.RegisterPublicRoutes(setup.API)
+	return setup, mockSvc, recorder
+}
+
+const (
+	wrongPasswordBody = `{"email":"jane@example.com","password":"wrong-pass"}`
+	rightPasswordBody = `{"email":"jane@example.com","password":"password123"}`
+)
+
+func TestUserHandler_Login_LocksOutAtThreshold(t *testing.T) {
+	limiter := newFakeLoginLimiter(3, 15*time.Minute)
+	setup, mockSvc, recorder := setupLockoutHandler(limiter)
+	mockSvc.On("Authenticate", mock.Anything, "jane@example.com", "wrong-pass").Return(nil, user.ErrInvalidPassword).Times(3)
+	mockSvc.On("GetByEmail", mock.Anything, "jane@example.com").Return(nil, user.ErrUserNotFound)
+
+	for i := 0; i < 2; i++ {
+		rec := requestFromClient(setup, http.MethodPost, "/auth/login", wrongPasswordBody)
+		testutil.AssertStatus(t, rec, http.StatusUnauthorized)
+	}
+
+	rec := requestFromClient(setup, http.MethodPost, "/auth/login", wrongPasswordBody)
+	testutil.AssertStatus(t, rec, http.StatusTooManyRequests)
+	assert.Equal(t, "900", rec.Header().Get("Retry-After"))
+	assert.Contains(t, rec.Body.String(), "AUTH_LOGIN_LOCKED")
+
+	// Even the right password is refused while locked out, without being checked.
+	limiter.now = limiter.now.Add(time.Minute)
+	rec = requestFromClient(setup, http.MethodPost, "/auth/login", rightPasswordBody)
+	testutil.AssertStatus(t, rec, http.StatusTooManyRequests)
+	assert.Equal(t, "840", rec.Header().Get("Retry-After"))
+	mockSvc.AssertNotCalled(t, "Authenticate", mock.Anything, "jane@example.com", "password123")
+
+	require.NotEmpty(t, recorder.events)
+	assert.Equal(t, authevent.ReasonLockedOut, recorder.events[len(recorder.events)-1].FailureReason())
+	mockSvc.AssertExpectations(t)
+}
+
+// loginFrom sends a sign-in from the given connection address, with a
+// client-supplied X-Forwarded-For header when forwardedFor is set.
+func loginFrom(setup *testutil.HandlerTestSetup, remoteAddr, forwardedFor, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/auth/login", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.RemoteAddr = remoteAddr
+	if forwardedFor != "" {
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+	}
+	rec := httptest.NewRecorder()
+	setup.Router.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestUserHandler_Login_LockoutIsPerIPAddress(t *testing.T) {
+	limiter := newFakeLoginLimiter(1, 15*time.Minute)
+	setup, mockSvc, recorder := setupLockoutHandler(limiter)
+	testUser, _ := user.NewUser("jane@example.com", "Jane", "password123")
+	mockSvc.On("Authenticate", mock.Anything, "jane@example.com", "wrong-pass").Return(nil, user.ErrInvalidPassword).Once()
+	mockSvc.On("GetByEmail", mock.Anything, "jane@example.com").Return(testUser, nil)
+	mockSvc.On("Authenticate", mock.Anything, "jane@example.com", "password123").Return(testUser, nil).Once()
+	mockSvc.On("GetTwoFactorStatus", mock.Anything, testUser.ID()).Return(&user.TwoFactorStatus{}, nil).Once()
+
+	rec := loginFrom(setup, "198.51.100.4:40000", "", wrongPasswordBody)
+	testutil.AssertStatus(t, rec, http.StatusTooManyRequests)
+
+	rec = loginFrom(setup, "192.0.2.1:40000", "", rightPasswordBody)
+	testutil.AssertStatus(t, rec, http.StatusOK)
+	require.NotEmpty(t, recorder.events)
+	assert.Equal(t, "192.0.2.1", recorder.events[len(recorder.events)-1].IPAddress())
+	mockSvc.AssertExpectations(t)
+}
+
+func TestUserHandler_Login_LockoutIgnoresForwardedFor(t *testing.T) {
+	limiter := newFakeLoginLimiter(1, 15*time.Minute)
+	setup, mockSvc, _ := setupLockoutHandler(limiter)
+	mockSvc.On("Authenticate", mock.Anything, "jane@example.com", "wrong-pass").Return(nil, user.ErrInvalidPassword).Once()
+	mockSvc.On("GetByEmail", mock.Anything, "jane@example.com").Return(nil, user.ErrUserNotFound)
+
+	rec := loginFrom(setup, "198.51.100.4:40000", "203.0.113.7", wrongPasswordBody)
+	testutil.AssertStatus(t, rec, http.StatusTooManyRequests)
+
+	// A new X-Forwarded-For value from the same connection is still locked out.
+	rec = loginFrom(setup, "198.51.100.4:40000", "203.0.113.8", rightPasswordBody)
+	testutil.AssertStatus(t, rec, http.StatusTooManyRequests)
+	mockSvc.AssertNotCalled(t, "Authenticate", mock.Anything, "jane@example.com", "password123")
+	mockSvc.AssertExpectations(t)
+}
+
+func TestUserHandler_Login_LockoutExpires(t *testing.T) {
+	limiter := newFakeLoginLimiter(1, 15*time.Minute)
+	setup, mockSvc, _ := setupLockoutHandler(limiter)
+	testUser, _ := user.NewUser("jane@example.com", "Jane", "password123")
+	mockSvc.On("Authenticate", mock.Anything, "jane@example.com", "wrong-pass").Return(nil, user.ErrInvalidPassword).Once()
+	mockSvc.On("GetByEmail", mock.Anything, "jane@example.com").Return(testUser, nil)
+	mockSvc.On("Authenticate", mock.Anything, "jane@example.com", "password123").Return(testUser, nil).Once()
+	mockSvc.On("GetTwoFactorStatus", mock.Anything, testUser.ID()).Return(&user.TwoFactorStatus{}, nil).Once()
+
+	rec := requestFromClient(setup, http.MethodPost, "/auth/login", wrongPasswordBody)
+	testutil.AssertStatus(t, rec, http.StatusTooManyRequests)
+
+	limiter.now = limiter.now.Add(15 * time.Minute)
+
+	rec = requestFromClient(setup, http.MethodPost, "/auth/login", rightPasswordBody)
+	testutil.AssertStatus(t, rec, http.StatusOK)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestUserHandler_Login_SuccessResetsFailures(t *testing.T) {
+	limiter := newFakeLoginLimiter(3, 15*time.Minute)
+	setup, mockSvc, _ := setupLockoutHandler(limiter)
+	testUser, _ := user.NewUser("jane@example.com", "Jane", "password123")
+	mockSvc.On("Authenticate", mock.Anything, "jane@example.com", "wrong-pass").Return(nil, user.ErrInvalidPassword).Times(4)
+	mockSvc.On("GetByEmail", mock.Anything, "jane@example.com").Return(testUser, nil)
+	mockSvc.On("Authenticate", mock.Anything, "jane@example.com", "password123").Return(testUser, nil).Once()
+	mockSvc.On("GetTwoFactorStatus", mock.Anything, testUser.ID()).Return(&user.TwoFactorStatus{}, nil).Once()
+
+	for i := 0; i < 2; i++ {
+		rec := requestFromClient(setup, http.MethodPost, "/auth/login", wrongPasswordBody)
+		testutil.AssertStatus(t, rec, http.StatusUnauthorized)
+	}
+	rec := requestFromClient(setup, http.MethodPost, "/auth/login", rightPasswordBody)
+	testutil.AssertStatus(t, rec, http.StatusOK)
+
+	// The count starts over: two more failures stay below the threshold.
+	for i := 0; i < 2; i++ {
+		rec := requestFromClient(setup, http.MethodPost, "/auth/login", wrongPasswordBody)
+		testutil.AssertStatus(t, rec, http.StatusUnauthorized)
+	}
+	mockSvc.AssertExpectations(t)
+}
+
+func TestUserHandler_Login_WrongTOTPCountsTowardLockout(t *testing.T) {
+	limiter := newFakeLoginLimiter(1, 15*time.Minute)
+	setup, mockSvc, _ := setupLockoutHandler(limiter)
+	testUser, _ := user.NewUser("jane@example.com", "Jane", "password123")
+	mockSvc.On("Authenticate", mock.Anything, "jane@example.com", "password123").Return(testUser, nil).Once()
+	mockSvc.On("GetTwoFactorStatus", mock.Anything, testUser.ID()).Return(&user.TwoFactorStatus{Enabled: true}, nil).Once()
+	mockSvc.On("VerifySecondFactor", mock.Anything, testUser.ID(), "000000").Return(user.ErrInvalidTwoFactorCode).Once()
+
+	rec := requestFromClient(setup, http.MethodPost, "/auth/login", `{"email":"jane@example.com","password":"password123","totp_code":"000000"}`)
+
+	testutil.AssertStatus(t, rec, http.StatusTooManyRequests)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestUserHandler_Login_LimiterErrorLetsSignInThrough(t *testing.T) {
+	limiter := newFakeLoginLimiter(1, 15*time.Minute)
+	limiter.err = errors.New("redis down")
+	setup, mockSvc, _ := setupLockoutHandler(limiter)
+	testUser, _ := user.NewUser("jane@example.com", "Jane", "password123")
+	mockSvc.On("Authenticate", mock.Anything, "jane@example.com", "wrong-pass").Return(nil, user.ErrInvalidPassword).Once()
+	mockSvc.On("GetByEmail", mock.Anything, "jane@example.com").Return(testUser, nil)
+	mockSvc.On("Authenticate", mock.Anything, "jane@example.com", "password123").Return(testUser, nil).Once()
+	mockSvc.On("GetTwoFactorStatus", mock.Anything, testUser.ID()).Return(&user.TwoFactorStatus{}, nil).Once()
+
+	rec := requestFromClient(setup, http.MethodPost, "/auth/login", wrongPasswordBody)
+	testutil.AssertStatus(t, rec, http.StatusUnauthorized)
+
+	rec = requestFromClient(setup, http.MethodPost, "/auth/login", rightPasswordBody)
+	testutil.AssertStatus(t, rec, http.StatusOK)
+	mockSvc.AssertExpectations(t)
+}
@@ -3,11 +3,11 @@ package user
 import (
 	"context"
 	"errors"
-	"strings"
 
 	"github.com/danielgtaylor/huma/v2"
 
 	appMiddleware "github.com/antti/home-warehouse/go-backend/internal/api/middleware"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/auth/authevent"
 	"github.com/antti/home-warehouse/go-backend/internal/shared"
 )
 
@@ -107,11 +107,14 @@ func (h *Handler) updatePassword(ctx context.Context, input *UpdatePasswordInput
 	err := h.svc.UpdatePassword(ctx, authUser.ID, input.Body.CurrentPassword, input.Body.NewPassword)
 	if err != nil {
 		if errors.Is(err, ErrInvalidPassword) {
+			h.recordAuthEvent(ctx, &authUser.ID, authUser.Email, authevent.TypePasswordChange, authevent.ReasonInvalidPassword, input.Client())
 			return nil, huma.Error400BadRequest("current password is incorrect")
 		}
 		return nil, appMiddleware.MapDomainError(err)
 	}
 
+	h.recordAuthEvent(ctx, &authUser.ID, authUser.Email, authevent.TypePasswordChange, "", input.Client())
+
 	return nil, nil
 }
 
@@ -148,17 +151,3 @@ func generateAvatarURL(avatarPath *string) *string {
 	url := "/api/users/me/avatar"
 	return &url
 }
-
-// getClientIPFromHeaders extracts the client IP address from headers.
-func getClientIPFromHeaders(xForwardedFor, xRealIP string) string {
-	// Check X-Forwarded-For first (for proxies)
-	if xForwardedFor != "" {
-		parts := strings.Split(xForwardedFor, ",")
-		return strings.TrimSpace(parts[0])
-	}
-	// Check X-Real-IP
-	if xRealIP != "" {
-		return xRealIP
-	}
-	return ""
-}
@@ -67,6 +67,11 @@ func (m *MockSessionService) RevokeAll(ctx context.Context, userID uuid.UUID) er
 	return args.Error(0)
 }
 
+func (m *MockSessionService) SignOutEverywhere(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
 // postWithCookie posts to the test router with a cookie attached.
 func postWithCookie(setup *testutil.HandlerTestSetup, path, body string, cookie *http.Cookie) *httptest.ResponseRecorder {
 	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
@@ -192,3 +197,72 @@ func TestUserHandler_RefreshToken_ValidSessionRotates(t *testing.T) {
 	mockSessionSvc.AssertExpectations(t)
 	mockSvc.AssertExpectations(t)
 }
+
+// Password reset requests must look the same whether or not the email exists.
+func TestUserHandler_RequestPasswordReset_NoEnumeration(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	handler := user.NewHandler(mockSvc, jwt.NewService("test-secret", 24), nil)
+	handler.RegisterPublicRoutes(setup.API)
+
+	mockSvc.On("RequestPasswordReset", mock.Anything, "known@example.com").Return(nil).Once()
+	mockSvc.On("RequestPasswordReset", mock.Anything, "unknown@example.com").Return(nil).Once()
+	mockSvc.On("RequestPasswordReset", mock.Anything, "failing@example.com").Return(fmt.Errorf("smtp down")).Once()
+
+	for _, email := range []string{"known@example.com", "unknown@example.com", "failing@example.com"} {
+		rec := setup.Post("/auth/password-reset/request", `{"email":"`+email+`"}`)
+		testutil.AssertStatus(t, rec, http.StatusNoContent)
+	}
+	mockSvc.AssertExpectations(t)
+}
+
+func TestUserHandler_RequestPasswordReset_Unavailable(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	handler := user.NewHandler(mockSvc, jwt.NewService("test-secret", 24), nil)
+	handler.RegisterPublicRoutes(setup.API)
+
+	mockSvc.On("RequestPasswordReset", mock.Anything, "known@example.com").
+		Return(user.ErrPasswordResetUnavailable).Once()
+
+	rec := setup.Post("/auth/password-reset/request", `{"email":"known@example.com"}`)
+
+	testutil.AssertStatus(t, rec, http.StatusServiceUnavailable)
+}
+
+// A successful reset must sign the user out everywhere.
+func TestUserHandler_ResetPassword_RevokesSessions(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	mockSessionSvc := new(MockSessionService)
+	handler := user.NewHandler(mockSvc, jwt.NewService("test-secret", 24), nil)
+	handler.SetSessionService(mockSessionSvc)
+	handler.RegisterPublicRoutes(setup.API)
+
+	testUser, _ := user.NewUser("test@example.com", "Test User", "password123")
+	mockSvc.On("ResetPassword", mock.Anything, "tok", "newpassword456").Return(testUser, nil).Once()
+	mockSessionSvc.On("SignOutEverywhere", mock.Anything, testUser.ID()).Return(nil).Once()
+
+	rec := setup.Post("/auth/password-reset/confirm", `{"token":"tok","new_password":"newpassword456"}`)
+
+	testutil.AssertStatus(t, rec, http.StatusNoContent)
+	mockSvc.AssertExpectations(t)
+	mockSessionSvc.AssertExpectations(t)
+}
+
+func TestUserHandler_ResetPassword_ExpiredToken(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	mockSessionSvc := new(MockSessionService)
+	handler := user.NewHandler(mockSvc, jwt.NewService("test-secret", 24), nil)
+	handler.SetSessionService(mockSessionSvc)
+	handler.RegisterPublicRoutes(setup.API)
+
+	mockSvc.On("ResetPassword", mock.Anything, "tok", "newpassword456").
+		Return(nil, user.ErrResetTokenExpired).Once()
+
+	rec := setup.Post("/auth/password-reset/confirm", `{"token":"tok","new_password":"newpassword456"}`)
+
+	testutil.AssertStatus(t, rec, http.StatusBadRequest)
+	mockSessionSvc.AssertNotCalled(t, "SignOutEverywhere", mock.Anything, mock.Anything)
+}
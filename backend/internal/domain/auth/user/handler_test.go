@@ -129,6 +129,61 @@ func (m *MockService) CreateOAuthUser(ctx context.Context, input user.CreateOAut
 	return args.Get(0).(*user.User), args.Error(1)
 }
 
+func (m *MockService) RequestPasswordReset(ctx context.Context, email string) error {
+	args := m.Called(ctx, email)
+	return args.Error(0)
+}
+
+func (m *MockService) ResetPassword(ctx context.Context, token, newPassword string) (*user.User, error) {
+	args := m.Called(ctx, token, newPassword)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*user.User), args.Error(1)
+}
+
+func (m *MockService) EnableTOTP(ctx context.Context, userID uuid.UUID) (*user.TOTPEnrollment, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*user.TOTPEnrollment), args.Error(1)
+}
+
+func (m *MockService) ConfirmTOTP(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	args := m.Called(ctx, userID, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockService) DisableTOTP(ctx context.Context, userID uuid.UUID, code string) error {
+	args := m.Called(ctx, userID, code)
+	return args.Error(0)
+}
+
+func (m *MockService) RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	args := m.Called(ctx, userID, code)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (m *MockService) GetTwoFactorStatus(ctx context.Context, userID uuid.UUID) (*user.TwoFactorStatus, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*user.TwoFactorStatus), args.Error(1)
+}
+
+func (m *MockService) VerifySecondFactor(ctx context.Context, userID uuid.UUID, code string) error {
+	args := m.Called(ctx, userID, code)
+	return args.Error(0)
+}
+
 // MockWorkspaceService implements workspace.ServiceInterface
 type MockWorkspaceService struct {
 	mock.Mock
@@ -174,8 +229,16 @@ func (m *MockWorkspaceService) Update(ctx context.Context, id uuid.UUID, input w
 	return args.Get(0).(*workspace.Workspace), args.Error(1)
 }
 
-func (m *MockWorkspaceService) Delete(ctx context.Context, id uuid.UUID) error {
+func (m *MockWorkspaceService) WorkspaceDeletionImpact(ctx context.Context, id uuid.UUID) (*workspace.DeletionImpact, error) {
 	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*workspace.DeletionImpact), args.Error(1)
+}
+
+func (m *MockWorkspaceService) Delete(ctx context.Context, id uuid.UUID, confirmation string) error {
+	args := m.Called(ctx, id, confirmation)
 	return args.Error(0)
 }
 
@@ -290,6 +353,8 @@ func TestUserHandler_Login(t *testing.T) {
 
 		mockSvc.On("Authenticate", mock.Anything, "test@example.com", "password123").
 			Return(testUser, nil).Once()
+		mockSvc.On("GetTwoFactorStatus", mock.Anything, testUser.ID()).
+			Return(&user.TwoFactorStatus{}, nil).Once()
 
 		body := `{"email":"test@example.com","password":"password123"}`
 		rec := setup.Post("/auth/login", body)
@@ -303,6 +368,8 @@ func TestUserHandler_Login(t *testing.T) {
 
 		mockSvc.On("Authenticate", mock.Anything, "cookie@example.com", "password123").
 			Return(testUser, nil).Once()
+		mockSvc.On("GetTwoFactorStatus", mock.Anything, testUser.ID()).
+			Return(&user.TwoFactorStatus{}, nil).Once()
 
 		body := `{"email":"cookie@example.com","password":"password123"}`
 		rec := setup.Post("/auth/login", body)
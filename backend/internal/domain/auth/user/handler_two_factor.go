@@ -0,0 +1,146 @@
+package user
+
+import (
+	"context"
+	"errors"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	appMiddleware "github.com/antti/home-warehouse/go-backend/internal/api/middleware"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/auth/authevent"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+	"github.com/antti/home-warehouse/go-backend/internal/shared/apierror"
+)
+
+const msgTwoFactorUnavailable = "two-factor authentication is not available on this server"
+
+// checkSecondFactor enforces TOTP at password login for users who have
+// enabled it. The error details carry a machine-readable code so the login
+// form can tell "ask for a code" apart from "wrong code".
+func (h *Handler) checkSecondFactor(ctx context.Context, user *User, code, ip string, client authevent.Client) error {
+	userID := user.ID()
+	status, err := h.svc.GetTwoFactorStatus(ctx, userID)
+	if err != nil {
+		return huma.Error500InternalServerError("failed to check two-factor authentication")
+	}
+	if !status.Enabled {
+		return nil
+	}
+
+	if code == "" {
+		return huma.Error401Unauthorized("two-factor code required", &huma.ErrorDetail{
+			Message:  string(apierror.ErrCodeTOTPRequired),
+			Location: "body.totp_code",
+		})
+	}
+	if err := h.svc.VerifySecondFactor(ctx, userID, code); err != nil {
+		if errors.Is(err, ErrTwoFactorUnavailable) {
+			return huma.Error503ServiceUnavailable(msgTwoFactorUnavailable)
+		}
+		if shared.IsInvalidInput(err) {
+			h.recordAuthEvent(ctx, &userID, user.Email(), authevent.TypeLogin, authevent.ReasonInvalidTOTP, client)
+			if err := h.countFailedLogin(ctx, user.Email(), ip); err != nil {
+				return err
+			}
+			return huma.Error401Unauthorized("invalid two-factor code", &huma.ErrorDetail{
+				Message:  string(apierror.ErrCodeTOTPInvalid),
+				Location: "body.totp_code",
+			})
+		}
+		return huma.Error500InternalServerError("failed to verify two-factor code")
+	}
+	return nil
+}
+
+func (h *Handler) getTwoFactorStatus(ctx context.Context, input *struct{}) (*TwoFactorStatusOutput, error) {
+	authUser, ok := appMiddleware.GetAuthUser(ctx)
+	if !ok {
+		return nil, huma.Error401Unauthorized(msgNotAuthenticated)
+	}
+
+	status, err := h.svc.GetTwoFactorStatus(ctx, authUser.ID)
+	if err != nil {
+		return nil, huma.Error500InternalServerError("failed to get two-factor status")
+	}
+
+	out := &TwoFactorStatusOutput{}
+	out.Body.Enabled = status.Enabled
+	out.Body.RecoveryCodesRemaining = status.RecoveryCodesRemaining
+	return out, nil
+}
+
+// enableTOTP starts enrollment. Two-factor is not on until confirmTOTP
+// succeeds.
+func (h *Handler) enableTOTP(ctx context.Context, input *struct{}) (*EnableTOTPOutput, error) {
+	authUser, ok := appMiddleware.GetAuthUser(ctx)
+	if !ok {
+		return nil, huma.Error401Unauthorized(msgNotAuthenticated)
+	}
+
+	enrollment, err := h.svc.EnableTOTP(ctx, authUser.ID)
+	if err != nil {
+		return nil, twoFactorError(err, "failed to start two-factor enrollment")
+	}
+
+	out := &EnableTOTPOutput{}
+	out.Body.Secret = enrollment.Secret
+	out.Body.ProvisioningURI = enrollment.ProvisioningURI
+	return out, nil
+}
+
+func (h *Handler) confirmTOTP(ctx context.Context, input *TOTPCodeInput) (*RecoveryCodesOutput, error) {
+	authUser, ok := appMiddleware.GetAuthUser(ctx)
+	if !ok {
+		return nil, huma.Error401Unauthorized(msgNotAuthenticated)
+	}
+
+	codes, err := h.svc.ConfirmTOTP(ctx, authUser.ID, input.Body.Code)
+	if err != nil {
+		return nil, twoFactorError(err, "failed to enable two-factor authentication")
+	}
+	h.recordAuthEvent(ctx, &authUser.ID, authUser.Email, authevent.TypeTwoFactorEnabled, "", input.Client())
+
+	out := &RecoveryCodesOutput{}
+	out.Body.RecoveryCodes = codes
+	return out, nil
+}
+
+func (h *Handler) disableTOTP(ctx context.Context, input *TOTPCodeInput) (*struct{}, error) {
+	authUser, ok := appMiddleware.GetAuthUser(ctx)
+	if !ok {
+		return nil, huma.Error401Unauthorized(msgNotAuthenticated)
+	}
+
+	if err := h.svc.DisableTOTP(ctx, authUser.ID, input.Body.Code); err != nil {
+		return nil, twoFactorError(err, "failed to disable two-factor authentication")
+	}
+	h.recordAuthEvent(ctx, &authUser.ID, authUser.Email, authevent.TypeTwoFactorDisabled, "", input.Client())
+	return nil, nil
+}
+
+func (h *Handler) regenerateRecoveryCodes(ctx context.Context, input *TOTPCodeInput) (*RecoveryCodesOutput, error) {
+	authUser, ok := appMiddleware.GetAuthUser(ctx)
+	if !ok {
+		return nil, huma.Error401Unauthorized(msgNotAuthenticated)
+	}
+
+	codes, err := h.svc.RegenerateRecoveryCodes(ctx, authUser.ID, input.Body.Code)
+	if err != nil {
+		return nil, twoFactorError(err, "failed to regenerate recovery codes")
+	}
+
+	out := &RecoveryCodesOutput{}
+	out.Body.RecoveryCodes = codes
+	return out, nil
+}
+
+// twoFactorError maps two-factor service errors to HTTP errors.
+func twoFactorError(err error, fallback string) error {
+	if errors.Is(err, ErrTwoFactorUnavailable) {
+		return huma.Error503ServiceUnavailable(msgTwoFactorUnavailable)
+	}
+	if shared.IsInvalidInput(err) || errors.Is(err, shared.ErrConflict) {
+		return appMiddleware.MapDomainError(err)
+	}
+	return huma.Error500InternalServerError(fallback)
+}
@@ -0,0 +1,162 @@
+package user_test
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/auth/user"
+	"github.com/antti/home-warehouse/go-backend/internal/shared/jwt"
+	"github.com/antti/home-warehouse/go-backend/internal/testutil"
+)
+
+func TestUserHandler_Login_TwoFactor(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	handler := user.NewHandler(mockSvc, jwt.NewService("test-secret", 24), nil)
+	handler.RegisterPublicRoutes(setup.API)
+
+	testUser, _ := user.NewUser("jane@example.com", "Jane", "password123")
+	mockSvc.On("Authenticate", mock.Anything, "jane@example.com", "password123").Return(testUser, nil)
+	mockSvc.On("GetTwoFactorStatus", mock.Anything, testUser.ID()).
+		Return(&user.TwoFactorStatus{Enabled: true}, nil)
+
+	t.Run("asks for a code when none is given", func(t *testing.T) {
+		rec := setup.Post("/auth/login", `{"email":"jane@example.com","password":"password123"}`)
+
+		testutil.AssertStatus(t, rec, http.StatusUnauthorized)
+		assert.Contains(t, rec.Body.String(), "AUTH_TOTP_REQUIRED")
+		assert.Nil(t, getCookie(rec, "access_token"))
+	})
+
+	t.Run("rejects a wrong code", func(t *testing.T) {
+		mockSvc.On("VerifySecondFactor", mock.Anything, testUser.ID(), "000000").
+			Return(user.ErrInvalidTwoFactorCode).Once()
+
+		rec := setup.Post("/auth/login", `{"email":"jane@example.com","password":"password123","totp_code":"000000"}`)
+
+		testutil.AssertStatus(t, rec, http.StatusUnauthorized)
+		assert.Contains(t, rec.Body.String(), "AUTH_TOTP_INVALID")
+		assert.Nil(t, getCookie(rec, "access_token"))
+	})
+
+	t.Run("returns 503 when secrets cannot be decrypted", func(t *testing.T) {
+		mockSvc.On("VerifySecondFactor", mock.Anything, testUser.ID(), "111111").
+			Return(user.ErrTwoFactorUnavailable).Once()
+
+		rec := setup.Post("/auth/login", `{"email":"jane@example.com","password":"password123","totp_code":"111111"}`)
+
+		testutil.AssertStatus(t, rec, http.StatusServiceUnavailable)
+	})
+
+	t.Run("signs in with a valid code", func(t *testing.T) {
+		mockSvc.On("VerifySecondFactor", mock.Anything, testUser.ID(), "123456").Return(nil).Once()
+
+		rec := setup.Post("/auth/login", `{"email":"jane@example.com","password":"password123","totp_code":"123456"}`)
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		assert.NotNil(t, getCookie(rec, "access_token"))
+	})
+}
+
+func TestUserHandler_TwoFactorEndpoints(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	handler := user.NewHandler(mockSvc, jwt.NewService("test-secret", 24), nil)
+	handler.RegisterProtectedRoutes(setup.API)
+
+	t.Run("reports status", func(t *testing.T) {
+		mockSvc.On("GetTwoFactorStatus", mock.Anything, setup.UserID).
+			Return(&user.TwoFactorStatus{Enabled: true, RecoveryCodesRemaining: 7}, nil).Once()
+
+		rec := setup.Get("/users/me/2fa")
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		body := testutil.ParseJSONResponse[map[string]any](t, rec)
+		assert.Equal(t, true, body["enabled"])
+		assert.Equal(t, float64(7), body["recovery_codes_remaining"])
+	})
+
+	t.Run("starts enrollment", func(t *testing.T) {
+		mockSvc.On("EnableTOTP", mock.Anything, setup.UserID).
+			Return(&user.TOTPEnrollment{Secret: "JBSWY3DPEHPK3PXP", ProvisioningURI: "otpauth://totp/x"}, nil).Once()
+
+		rec := setup.Post("/users/me/2fa/totp", "")
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		body := testutil.ParseJSONResponse[map[string]any](t, rec)
+		assert.Equal(t, "JBSWY3DPEHPK3PXP", body["secret"])
+		assert.Equal(t, "otpauth://totp/x", body["provisioning_uri"])
+	})
+
+	t.Run("enrollment conflicts when already enabled", func(t *testing.T) {
+		mockSvc.On("EnableTOTP", mock.Anything, setup.UserID).
+			Return(nil, user.ErrTwoFactorAlreadyEnabled).Once()
+
+		rec := setup.Post("/users/me/2fa/totp", "")
+
+		testutil.AssertStatus(t, rec, http.StatusConflict)
+	})
+
+	t.Run("confirms enrollment and returns recovery codes", func(t *testing.T) {
+		mockSvc.On("ConfirmTOTP", mock.Anything, setup.UserID, "123456").
+			Return([]string{"aaaaa-bbbbb", "ccccc-ddddd"}, nil).Once()
+
+		rec := setup.Post("/users/me/2fa/totp/confirm", `{"code":"123456"}`)
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		body := testutil.ParseJSONResponse[struct {
+			RecoveryCodes []string `json:"recovery_codes"`
+		}](t, rec)
+		assert.Equal(t, []string{"aaaaa-bbbbb", "ccccc-ddddd"}, body.RecoveryCodes)
+	})
+
+	t.Run("confirm rejects a wrong code", func(t *testing.T) {
+		mockSvc.On("ConfirmTOTP", mock.Anything, setup.UserID, "654321").
+			Return(nil, user.ErrInvalidTwoFactorCode).Once()
+
+		rec := setup.Post("/users/me/2fa/totp/confirm", `{"code":"654321"}`)
+
+		testutil.AssertStatus(t, rec, http.StatusBadRequest)
+	})
+
+	t.Run("disable requires a six digit code", func(t *testing.T) {
+		rec := setup.Post("/users/me/2fa/totp/disable", `{"code":"aaaaa-bbbbb"}`)
+
+		testutil.AssertStatus(t, rec, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("disables with a valid code", func(t *testing.T) {
+		mockSvc.On("DisableTOTP", mock.Anything, setup.UserID, "123456").Return(nil).Once()
+
+		rec := setup.Post("/users/me/2fa/totp/disable", `{"code":"123456"}`)
+
+		testutil.AssertStatus(t, rec, http.StatusNoContent)
+	})
+
+	t.Run("regenerates recovery codes", func(t *testing.T) {
+		mockSvc.On("RegenerateRecoveryCodes", mock.Anything, setup.UserID, "123456").
+			Return([]string{"eeeee-fffff"}, nil).Once()
+
+		rec := setup.Post("/users/me/2fa/recovery-codes", `{"code":"123456"}`)
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		body := testutil.ParseJSONResponse[struct {
+			RecoveryCodes []string `json:"recovery_codes"`
+		}](t, rec)
+		assert.Equal(t, []string{"eeeee-fffff"}, body.RecoveryCodes)
+	})
+
+	t.Run("returns 503 when unavailable", func(t *testing.T) {
+		mockSvc.On("EnableTOTP", mock.Anything, setup.UserID).
+			Return(nil, user.ErrTwoFactorUnavailable).Once()
+
+		rec := setup.Post("/users/me/2fa/totp", "")
+
+		testutil.AssertStatus(t, rec, http.StatusServiceUnavailable)
+	})
+
+	mockSvc.AssertExpectations(t)
+}
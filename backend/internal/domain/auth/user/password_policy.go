@@ -0,0 +1,65 @@
+package user
+
+import (
+	"fmt"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// DefaultMinPasswordLength is the shortest password accepted by default. It
+// is also the floor for configured policies: the request schemas enforce it
+// before a password ever reaches the domain.
+const DefaultMinPasswordLength = 8
+
+// Password rule errors. Validate wraps them in a field error carrying the
+// user-facing message, so callers can tell the rules apart with errors.Is.
+var (
+	ErrPasswordTooShort       = shared.NewDomainError(shared.ErrInvalidInput, "password is too short")
+	ErrPasswordNeedsMixedCase = shared.NewDomainError(shared.ErrInvalidInput, "password needs upper- and lowercase letters")
+	ErrPasswordNeedsDigit     = shared.NewDomainError(shared.ErrInvalidInput, "password needs a digit")
+)
+
+// PasswordPolicy is the set of rules a new password must satisfy. The values
+// come from configuration (PASSWORD_* env vars) so self-hosted instances can
+// tighten them.
+type PasswordPolicy struct {
+	MinLength        int
+	RequireMixedCase bool
+	RequireDigit     bool
+}
+
+// DefaultPasswordPolicy returns the policy used when none is configured:
+// a minimum length and no character-class requirements.
+func DefaultPasswordPolicy() PasswordPolicy {
+	return PasswordPolicy{MinLength: DefaultMinPasswordLength}
+}
+
+// Validate checks password against the policy and returns an error for the
+// first rule it fails. Length is counted in characters, not bytes.
+func (p PasswordPolicy) Validate(password string) error {
+	if utf8.RuneCountInString(password) < p.MinLength {
+		return shared.NewFieldError(ErrPasswordTooShort, "password", fmt.Sprintf("password must be at least %d characters", p.MinLength))
+	}
+
+	var hasUpper, hasLower, hasDigit bool
+	for _, r := range password {
+		switch {
+		case unicode.IsUpper(r):
+			hasUpper = true
+		case unicode.IsLower(r):
+			hasLower = true
+		case unicode.IsDigit(r):
+			hasDigit = true
+		}
+	}
+
+	if p.RequireMixedCase && (!hasUpper || !hasLower) {
+		return shared.NewFieldError(ErrPasswordNeedsMixedCase, "password", "password must contain both uppercase and lowercase letters")
+	}
+	if p.RequireDigit && !hasDigit {
+		return shared.NewFieldError(ErrPasswordNeedsDigit, "password", "password must contain at least one digit")
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package user_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/auth/user"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+func TestPasswordPolicy_Validate(t *testing.T) {
+	strict := user.PasswordPolicy{MinLength: 12, RequireMixedCase: true, RequireDigit: true}
+
+	tests := []struct {
+		name     string
+		policy   user.PasswordPolicy
+		password string
+		wantErr  error
+		wantMsg  string
+	}{
+		{
+			name:     "default accepts eight lowercase characters",
+			policy:   user.DefaultPasswordPolicy(),
+			password: "password",
+		},
+		{
+			name:     "default rejects seven characters",
+			policy:   user.DefaultPasswordPolicy(),
+			password: "passwor",
+			wantErr:  user.ErrPasswordTooShort,
+			wantMsg:  "password must be at least 8 characters",
+		},
+		{
+			name:     "configured minimum length is enforced",
+			policy:   strict,
+			password: "Passw0rd",
+			wantErr:  user.ErrPasswordTooShort,
+			wantMsg:  "password must be at least 12 characters",
+		},
+		{
+			name:     "length counts characters, not bytes",
+			policy:   user.PasswordPolicy{MinLength: 8},
+			password: "ääääääää",
+		},
+		{
+			name:     "mixed case rejects all lowercase",
+			policy:   strict,
+			password: "longpassword1",
+			wantErr:  user.ErrPasswordNeedsMixedCase,
+			wantMsg:  "password must contain both uppercase and lowercase letters",
+		},
+		{
+			name:     "mixed case rejects all uppercase",
+			policy:   strict,
+			password: "LONGPASSWORD1",
+			wantErr:  user.ErrPasswordNeedsMixedCase,
+		},
+		{
+			name:     "digit rule rejects letters only",
+			policy:   strict,
+			password: "LongPassword",
+			wantErr:  user.ErrPasswordNeedsDigit,
+			wantMsg:  "password must contain at least one digit",
+		},
+		{
+			name:     "strict policy accepts compliant password",
+			policy:   strict,
+			password: "LongPassword1",
+		},
+		{
+			name:     "rules are off unless enabled",
+			policy:   user.PasswordPolicy{MinLength: 8},
+			password: "longpassword",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Validate(tt.password)
+
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.ErrorIs(t, err, tt.wantErr)
+			assert.ErrorIs(t, err, shared.ErrInvalidInput)
+			if tt.wantMsg != "" {
+				assert.Contains(t, err.Error(), tt.wantMsg)
+			}
+		})
+	}
+}
+
+func TestNewUserWithPolicy(t *testing.T) {
+	policy := user.PasswordPolicy{MinLength: 8, RequireDigit: true}
+
+	_, err := user.NewUserWithPolicy("test@example.com", "John Doe", "password", policy)
+	assert.ErrorIs(t, err, user.ErrPasswordNeedsDigit)
+
+	u, err := user.NewUserWithPolicy("test@example.com", "John Doe", "password1", policy)
+	require.NoError(t, err)
+	assert.True(t, u.CheckPassword("password1"))
+}
+
+func TestUser_UpdatePasswordWithPolicy(t *testing.T) {
+	policy := user.PasswordPolicy{MinLength: 8, RequireMixedCase: true}
+	u, err := user.NewUser("test@example.com", "John Doe", "OldPassword123")
+	require.NoError(t, err)
+	oldHash := u.PasswordHash()
+
+	err = u.UpdatePasswordWithPolicy("newpassword456", policy)
+	assert.ErrorIs(t, err, user.ErrPasswordNeedsMixedCase)
+	assert.Equal(t, oldHash, u.PasswordHash())
+
+	err = u.UpdatePasswordWithPolicy("NewPassword456", policy)
+	require.NoError(t, err)
+	assert.True(t, u.CheckPassword("NewPassword456"))
+}
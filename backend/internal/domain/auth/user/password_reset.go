@@ -0,0 +1,166 @@
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// DefaultPasswordResetTTL is how long a password reset link stays valid.
+const DefaultPasswordResetTTL = time.Hour
+
+// Password reset errors. The three token errors are deliberately separate so
+// the UI can tell the user to request a new link rather than retry.
+var (
+	ErrPasswordResetUnavailable = shared.NewDomainError(shared.ErrInternal, "password reset is not configured")
+	ErrInvalidResetToken        = shared.NewDomainError(shared.ErrInvalidInput, "password reset link is invalid")
+	ErrResetTokenExpired        = shared.NewDomainError(shared.ErrInvalidInput, "password reset link has expired")
+	ErrResetTokenUsed           = shared.NewDomainError(shared.ErrInvalidInput, "password reset link has already been used")
+)
+
+// PasswordResetToken is a stored reset token. Only the SHA-256 hash of the
+// token is persisted; the raw value exists solely in the emailed link.
+type PasswordResetToken struct {
+	ID        uuid.UUID
+	UserID    uuid.UUID
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+}
+
+// PasswordResetRepository persists reset tokens (auth.password_reset_tokens).
+type PasswordResetRepository interface {
+	// Create stores a new token hash for the user.
+	Create(ctx context.Context, userID uuid.UUID, tokenHash string, expiresAt time.Time) error
+	// FindByHash returns the token with the given hash, or shared.ErrNotFound.
+	FindByHash(ctx context.Context, tokenHash string) (*PasswordResetToken, error)
+	// Consume marks the token used if it is still unused and unexpired, and
+	// reports whether it did. This is the single-use guard, so it must be
+	// atomic.
+	Consume(ctx context.Context, id uuid.UUID) (bool, error)
+	// InvalidateForUser marks every outstanding token of the user used.
+	InvalidateForUser(ctx context.Context, userID uuid.UUID) error
+}
+
+// EmailSender delivers the account emails sent by the user service.
+type EmailSender interface {
+	SendPasswordReset(ctx context.Context, to, fullName, resetURL string) error
+}
+
+// SetPasswordReset wires the password reset flow: token storage, the sender
+// for reset emails and the frontend base URL the reset link points at.
+// Optional — without it RequestPasswordReset and ResetPassword return
+// ErrPasswordResetUnavailable.
+func (s *Service) SetPasswordReset(tokens PasswordResetRepository, sender EmailSender, appURL string) {
+	s.resetTokens = tokens
+	s.emailSender = sender
+	s.appURL = strings.TrimRight(appURL, "/")
+}
+
+// RequestPasswordReset emails a single-use reset link to the user with the
+// given email. Any earlier outstanding links for the user stop working.
+//
+// Unknown and inactive accounts are ignored without error so the caller's
+// response does not reveal whether an email is registered.
+func (s *Service) RequestPasswordReset(ctx context.Context, email string) error {
+	if s.resetTokens == nil || s.emailSender == nil {
+		return ErrPasswordResetUnavailable
+	}
+
+	user, err := s.repo.FindByEmail(ctx, email)
+	if err != nil {
+		if shared.IsNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if user == nil || !user.IsActive() {
+		return nil
+	}
+
+	token, err := generateResetToken()
+	if err != nil {
+		return err
+	}
+
+	if err := s.resetTokens.InvalidateForUser(ctx, user.ID()); err != nil {
+		return err
+	}
+	if err := s.resetTokens.Create(ctx, user.ID(), hashResetToken(token), time.Now().Add(DefaultPasswordResetTTL)); err != nil {
+		return err
+	}
+
+	resetURL := s.appURL + "/reset-password?token=" + url.QueryEscape(token)
+	return s.emailSender.SendPasswordReset(ctx, user.Email(), user.FullName(), resetURL)
+}
+
+// ResetPassword sets a new password using a token from a reset link and
+// returns the updated user. The new password must satisfy the password
+// policy; a rejected password does not use up the token.
+func (s *Service) ResetPassword(ctx context.Context, token, newPassword string) (*User, error) {
+	if s.resetTokens == nil {
+		return nil, ErrPasswordResetUnavailable
+	}
+
+	stored, err := s.resetTokens.FindByHash(ctx, hashResetToken(token))
+	if err != nil {
+		if shared.IsNotFound(err) {
+			return nil, ErrInvalidResetToken
+		}
+		return nil, err
+	}
+	if stored.UsedAt != nil {
+		return nil, ErrResetTokenUsed
+	}
+	if !time.Now().Before(stored.ExpiresAt) {
+		return nil, ErrResetTokenExpired
+	}
+
+	user, err := s.GetByID(ctx, stored.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if err := user.UpdatePasswordWithPolicy(newPassword, s.passwordPolicy); err != nil {
+		return nil, err
+	}
+
+	// Claim the token before saving so two concurrent resets with the same
+	// link cannot both succeed.
+	consumed, err := s.resetTokens.Consume(ctx, stored.ID)
+	if err != nil {
+		return nil, err
+	}
+	if !consumed {
+		return nil, ErrResetTokenUsed
+	}
+
+	if err := s.repo.Save(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// generateResetToken returns a random URL-safe token with 256 bits of
+// entropy.
+func generateResetToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// hashResetToken returns the hex SHA-256 of a reset token, the form stored in
+// auth.password_reset_tokens.token_hash.
+func hashResetToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,233 @@
+package user
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// memResetTokens is an in-memory PasswordResetRepository keyed by hash.
+type memResetTokens struct {
+	byHash map[string]*PasswordResetToken
+}
+
+func newMemResetTokens() *memResetTokens {
+	return &memResetTokens{byHash: make(map[string]*PasswordResetToken)}
+}
+
+func (m *memResetTokens) Create(_ context.Context, userID uuid.UUID, tokenHash string, expiresAt time.Time) error {
+	m.byHash[tokenHash] = &PasswordResetToken{ID: uuid.New(), UserID: userID, ExpiresAt: expiresAt}
+	return nil
+}
+
+func (m *memResetTokens) FindByHash(_ context.Context, tokenHash string) (*PasswordResetToken, error) {
+	t, ok := m.byHash[tokenHash]
+	if !ok {
+		return nil, shared.ErrNotFound
+	}
+	cp := *t
+	return &cp, nil
+}
+
+func (m *memResetTokens) Consume(_ context.Context, id uuid.UUID) (bool, error) {
+	for _, t := range m.byHash {
+		if t.ID == id && t.UsedAt == nil && time.Now().Before(t.ExpiresAt) {
+			now := time.Now()
+			t.UsedAt = &now
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *memResetTokens) InvalidateForUser(_ context.Context, userID uuid.UUID) error {
+	for _, t := range m.byHash {
+		if t.UserID == userID && t.UsedAt == nil {
+			now := time.Now()
+			t.UsedAt = &now
+		}
+	}
+	return nil
+}
+
+// recordingSender captures the reset links it is asked to send.
+type recordingSender struct {
+	to   []string
+	urls []string
+}
+
+func (r *recordingSender) SendPasswordReset(_ context.Context, to, _, resetURL string) error {
+	r.to = append(r.to, to)
+	r.urls = append(r.urls, resetURL)
+	return nil
+}
+
+// lastToken extracts the raw token from the most recent reset link.
+func (r *recordingSender) lastToken(t *testing.T) string {
+	t.Helper()
+	require.NotEmpty(t, r.urls)
+	u, err := url.Parse(r.urls[len(r.urls)-1])
+	require.NoError(t, err)
+	return u.Query().Get("token")
+}
+
+func TestService_RequestPasswordReset(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("emails a reset link to a registered user", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		tokens := newMemResetTokens()
+		sender := &recordingSender{}
+		svc := NewService(mockRepo)
+		svc.SetPasswordReset(tokens, sender, "https://app.example.com/")
+
+		u, _ := NewUser("jane@example.com", "Jane", "password123")
+		mockRepo.On("FindByEmail", ctx, "jane@example.com").Return(u, nil)
+
+		err := svc.RequestPasswordReset(ctx, "jane@example.com")
+
+		require.NoError(t, err)
+		require.Len(t, sender.urls, 1)
+		assert.Equal(t, []string{"jane@example.com"}, sender.to)
+		assert.Contains(t, sender.urls[0], "https://app.example.com/reset-password?token=")
+		token := sender.lastToken(t)
+		assert.NotContains(t, tokens.byHash, token, "raw token must not be stored")
+		assert.Contains(t, tokens.byHash, hashResetToken(token))
+	})
+
+	t.Run("does not reveal unknown emails", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		sender := &recordingSender{}
+		svc := NewService(mockRepo)
+		svc.SetPasswordReset(newMemResetTokens(), sender, "https://app.example.com")
+
+		mockRepo.On("FindByEmail", ctx, "nobody@example.com").Return(nil, shared.ErrNotFound)
+
+		err := svc.RequestPasswordReset(ctx, "nobody@example.com")
+
+		assert.NoError(t, err)
+		assert.Empty(t, sender.urls)
+	})
+
+	t.Run("ignores inactive users", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		sender := &recordingSender{}
+		svc := NewService(mockRepo)
+		svc.SetPasswordReset(newMemResetTokens(), sender, "https://app.example.com")
+
+		u, _ := NewUser("jane@example.com", "Jane", "password123")
+		u.Deactivate()
+		mockRepo.On("FindByEmail", ctx, "jane@example.com").Return(u, nil)
+
+		err := svc.RequestPasswordReset(ctx, "jane@example.com")
+
+		assert.NoError(t, err)
+		assert.Empty(t, sender.urls)
+	})
+
+	t.Run("is unavailable when not configured", func(t *testing.T) {
+		svc := NewService(new(MockRepository))
+
+		err := svc.RequestPasswordReset(ctx, "jane@example.com")
+
+		assert.ErrorIs(t, err, ErrPasswordResetUnavailable)
+	})
+}
+
+func TestService_ResetPassword(t *testing.T) {
+	ctx := context.Background()
+
+	// setup requests a reset for a fresh user and returns the emailed token.
+	setup := func(t *testing.T) (*Service, *MockRepository, *memResetTokens, *recordingSender, *User) {
+		t.Helper()
+		mockRepo := new(MockRepository)
+		tokens := newMemResetTokens()
+		sender := &recordingSender{}
+		svc := NewService(mockRepo)
+		svc.SetPasswordReset(tokens, sender, "https://app.example.com")
+
+		u, _ := NewUser("jane@example.com", "Jane", "password123")
+		mockRepo.On("FindByEmail", ctx, "jane@example.com").Return(u, nil)
+		mockRepo.On("FindByID", ctx, u.ID()).Return(u, nil)
+		mockRepo.On("Save", ctx, mock.Anything).Return(nil)
+
+		require.NoError(t, svc.RequestPasswordReset(ctx, "jane@example.com"))
+		return svc, mockRepo, tokens, sender, u
+	}
+
+	t.Run("sets the new password", func(t *testing.T) {
+		svc, mockRepo, _, sender, u := setup(t)
+
+		got, err := svc.ResetPassword(ctx, sender.lastToken(t), "newpassword456")
+
+		require.NoError(t, err)
+		assert.Equal(t, u.ID(), got.ID())
+		assert.True(t, u.CheckPassword("newpassword456"))
+		assert.False(t, u.CheckPassword("password123"))
+		mockRepo.AssertCalled(t, "Save", ctx, u)
+	})
+
+	t.Run("rejects reuse of a token", func(t *testing.T) {
+		svc, _, _, sender, u := setup(t)
+		token := sender.lastToken(t)
+
+		_, err := svc.ResetPassword(ctx, token, "newpassword456")
+		require.NoError(t, err)
+
+		_, err = svc.ResetPassword(ctx, token, "anotherpass789")
+		assert.ErrorIs(t, err, ErrResetTokenUsed)
+		assert.True(t, u.CheckPassword("newpassword456"))
+	})
+
+	t.Run("rejects an expired token", func(t *testing.T) {
+		svc, mockRepo, tokens, sender, u := setup(t)
+		token := sender.lastToken(t)
+		tokens.byHash[hashResetToken(token)].ExpiresAt = time.Now().Add(-time.Minute)
+
+		_, err := svc.ResetPassword(ctx, token, "newpassword456")
+
+		assert.ErrorIs(t, err, ErrResetTokenExpired)
+		assert.True(t, u.CheckPassword("password123"))
+		mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects an unknown token", func(t *testing.T) {
+		svc, _, _, _, _ := setup(t)
+
+		_, err := svc.ResetPassword(ctx, "not-a-real-token", "newpassword456")
+
+		assert.ErrorIs(t, err, ErrInvalidResetToken)
+	})
+
+	t.Run("a newer request invalidates the older link", func(t *testing.T) {
+		svc, _, _, sender, _ := setup(t)
+		oldToken := sender.lastToken(t)
+		require.NoError(t, svc.RequestPasswordReset(ctx, "jane@example.com"))
+
+		_, err := svc.ResetPassword(ctx, oldToken, "newpassword456")
+		assert.ErrorIs(t, err, ErrResetTokenUsed)
+
+		_, err = svc.ResetPassword(ctx, sender.lastToken(t), "newpassword456")
+		assert.NoError(t, err)
+	})
+
+	t.Run("a rejected password does not use up the token", func(t *testing.T) {
+		svc, _, _, sender, _ := setup(t)
+		svc.SetPasswordPolicy(PasswordPolicy{MinLength: 8, RequireDigit: true})
+		token := sender.lastToken(t)
+
+		_, err := svc.ResetPassword(ctx, token, "nodigitshere")
+		assert.ErrorIs(t, err, ErrPasswordNeedsDigit)
+
+		_, err = svc.ResetPassword(ctx, token, "withdigit1")
+		assert.NoError(t, err)
+	})
+}
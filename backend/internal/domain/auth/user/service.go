@@ -25,16 +25,37 @@ type ServiceInterface interface {
 	UpdateEmail(ctx context.Context, id uuid.UUID, newEmail string) (*User, error)
 	CanDelete(ctx context.Context, userID uuid.UUID) (canDelete bool, blockingWorkspaces []BlockingWorkspace, err error)
 	Delete(ctx context.Context, userID uuid.UUID) error
+	RequestPasswordReset(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, token, newPassword string) (*User, error)
+	EnableTOTP(ctx context.Context, userID uuid.UUID) (*TOTPEnrollment, error)
+	ConfirmTOTP(ctx context.Context, userID uuid.UUID, code string) ([]string, error)
+	DisableTOTP(ctx context.Context, userID uuid.UUID, code string) error
+	RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) ([]string, error)
+	GetTwoFactorStatus(ctx context.Context, userID uuid.UUID) (*TwoFactorStatus, error)
+	VerifySecondFactor(ctx context.Context, userID uuid.UUID, code string) error
 }
 
 // Service handles user business logic.
 type Service struct {
-	repo Repository
+	repo           Repository
+	passwordPolicy PasswordPolicy
+	resetTokens    PasswordResetRepository
+	emailSender    EmailSender
+	appURL         string
+	twoFactor      TwoFactorRepository
+	secretCipher   SecretCipher
+	totpIssuer     string
 }
 
 // NewService creates a new user service.
 func NewService(repo Repository) *Service {
-	return &Service{repo: repo}
+	return &Service{repo: repo, passwordPolicy: DefaultPasswordPolicy()}
+}
+
+// SetPasswordPolicy sets the rules new passwords must satisfy on sign-up and
+// password change. Optional — without it DefaultPasswordPolicy applies.
+func (s *Service) SetPasswordPolicy(policy PasswordPolicy) {
+	s.passwordPolicy = policy
 }
 
 // CreateUserInput holds the input for creating a user.
@@ -56,7 +77,7 @@ func (s *Service) Create(ctx context.Context, input CreateUserInput) (*User, err
 	}
 
 	// Create user entity
-	user, err := NewUser(input.Email, input.FullName, input.Password)
+	user, err := NewUserWithPolicy(input.Email, input.FullName, input.Password, s.passwordPolicy)
 	if err != nil {
 		return nil, err
 	}
@@ -183,7 +204,7 @@ func (s *Service) UpdatePassword(ctx context.Context, id uuid.UUID, currentPassw
 		}
 	}
 
-	if err := user.UpdatePassword(newPassword); err != nil {
+	if err := user.UpdatePasswordWithPolicy(newPassword, s.passwordPolicy); err != nil {
 		return err
 	}
 
@@ -1015,3 +1015,42 @@ func TestService_List_RepoError(t *testing.T) {
 	assert.Nil(t, result)
 	mockRepo.AssertExpectations(t)
 }
+
+func TestService_PasswordPolicy(t *testing.T) {
+	ctx := context.Background()
+	policy := PasswordPolicy{MinLength: 10, RequireMixedCase: true, RequireDigit: true}
+
+	t.Run("Create applies the configured policy", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		svc := NewService(mockRepo)
+		svc.SetPasswordPolicy(policy)
+
+		mockRepo.On("ExistsByEmail", ctx, "new@example.com").Return(false, nil)
+
+		_, err := svc.Create(ctx, CreateUserInput{
+			Email:    "new@example.com",
+			FullName: "New User",
+			Password: "password123",
+		})
+
+		assert.ErrorIs(t, err, ErrPasswordNeedsMixedCase)
+		mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("UpdatePassword applies the configured policy", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		svc := NewService(mockRepo)
+		svc.SetPasswordPolicy(policy)
+
+		userID := uuid.New()
+		u, _ := NewUser("test@example.com", "Test User", "password123")
+		mockRepo.On("FindByID", ctx, userID).Return(u, nil)
+
+		err := svc.UpdatePassword(ctx, userID, "password123", "Password1")
+		assert.ErrorIs(t, err, ErrPasswordTooShort)
+
+		mockRepo.On("Save", ctx, mock.Anything).Return(nil)
+		err = svc.UpdatePassword(ctx, userID, "password123", "Password123")
+		assert.NoError(t, err)
+	})
+}
@@ -0,0 +1,321 @@
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base32"
+	"encoding/hex"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+	"github.com/antti/home-warehouse/go-backend/internal/shared/totp"
+)
+
+const (
+	// DefaultTOTPIssuer is the account label authenticator apps show.
+	DefaultTOTPIssuer = "Home Warehouse"
+	// RecoveryCodeCount is how many recovery codes a user gets at a time.
+	RecoveryCodeCount = 10
+	// totpSkew accepts codes from one step either side of now (±30s drift).
+	totpSkew = 1
+)
+
+// Two-factor errors.
+var (
+	ErrTwoFactorUnavailable    = shared.NewDomainError(shared.ErrInternal, "two-factor authentication is not configured")
+	ErrTwoFactorAlreadyEnabled = shared.NewDomainError(shared.ErrConflict, "two-factor authentication is already enabled")
+	ErrTwoFactorNotEnabled     = shared.NewDomainError(shared.ErrInvalidInput, "two-factor authentication is not enabled")
+	ErrTwoFactorNotEnrolling   = shared.NewDomainError(shared.ErrInvalidInput, "start two-factor enrollment first")
+	ErrInvalidTwoFactorCode    = shared.NewFieldError(shared.ErrInvalidInput, "code", "invalid two-factor code")
+)
+
+// TwoFactorSettings is a user's stored TOTP configuration. EnabledAt is nil
+// until the enrollment has been confirmed with a valid code.
+type TwoFactorSettings struct {
+	UserID          uuid.UUID
+	SecretEncrypted string
+	LastUsedStep    int64
+	EnabledAt       *time.Time
+}
+
+// TwoFactorRepository persists TOTP settings (auth.user_two_factor) and
+// recovery codes (auth.user_recovery_codes).
+type TwoFactorRepository interface {
+	// Get returns the user's settings, or shared.ErrNotFound.
+	Get(ctx context.Context, userID uuid.UUID) (*TwoFactorSettings, error)
+	// SavePending stores a new unconfirmed secret, replacing any earlier
+	// unconfirmed one.
+	SavePending(ctx context.Context, userID uuid.UUID, secretEncrypted string) error
+	// Enable confirms the enrollment and records step as used.
+	Enable(ctx context.Context, userID uuid.UUID, step int64) error
+	// MarkStepUsed records step as the last accepted one if it is newer than
+	// the stored step, and reports whether it did. This is the replay guard,
+	// so it must be atomic.
+	MarkStepUsed(ctx context.Context, userID uuid.UUID, step int64) (bool, error)
+	// Delete removes the settings and all recovery codes.
+	Delete(ctx context.Context, userID uuid.UUID) error
+	// ReplaceRecoveryCodes swaps the user's recovery codes for the given hashes.
+	ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codeHashes []string) error
+	// ConsumeRecoveryCode marks an unused code used and reports whether one
+	// matched. Must be atomic.
+	ConsumeRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) (bool, error)
+	// CountUnusedRecoveryCodes returns how many recovery codes remain.
+	CountUnusedRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error)
+}
+
+// SecretCipher encrypts TOTP secrets at rest. *crypto.Encryptor satisfies it.
+type SecretCipher interface {
+	Encrypt(plaintext string) (string, error)
+	Decrypt(ciphertext string) (string, error)
+}
+
+// TOTPEnrollment is what the user needs to add the account to an
+// authenticator app.
+type TOTPEnrollment struct {
+	Secret          string
+	ProvisioningURI string
+}
+
+// TwoFactorStatus summarizes a user's two-factor setup.
+type TwoFactorStatus struct {
+	Enabled                bool
+	RecoveryCodesRemaining int
+}
+
+// SetTwoFactor wires TOTP storage and the cipher for secrets. cipher may be
+// nil: enrolled users then cannot sign in (ErrTwoFactorUnavailable) rather
+// than silently skipping their second factor. Optional — without it no user
+// has two-factor authentication.
+func (s *Service) SetTwoFactor(repo TwoFactorRepository, cipher SecretCipher, issuer string) {
+	s.twoFactor = repo
+	s.secretCipher = cipher
+	s.totpIssuer = issuer
+}
+
+// EnableTOTP starts enrollment: it generates a secret and returns it with its
+// provisioning URI. Two-factor stays off until ConfirmTOTP is called with a
+// code from the authenticator app.
+func (s *Service) EnableTOTP(ctx context.Context, userID uuid.UUID) (*TOTPEnrollment, error) {
+	if s.twoFactor == nil || s.secretCipher == nil {
+		return nil, ErrTwoFactorUnavailable
+	}
+
+	user, err := s.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if settings, err := s.twoFactorSettings(ctx, userID); err != nil {
+		return nil, err
+	} else if settings != nil && settings.EnabledAt != nil {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := s.secretCipher.Encrypt(secret)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.twoFactor.SavePending(ctx, userID, encrypted); err != nil {
+		return nil, err
+	}
+
+	return &TOTPEnrollment{
+		Secret:          secret,
+		ProvisioningURI: totp.ProvisioningURI(s.totpIssuer, user.Email(), secret),
+	}, nil
+}
+
+// ConfirmTOTP finishes enrollment with a code from the authenticator app,
+// turns two-factor on and returns a fresh set of recovery codes. The codes
+// are only ever returned here and from RegenerateRecoveryCodes.
+func (s *Service) ConfirmTOTP(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	if s.twoFactor == nil || s.secretCipher == nil {
+		return nil, ErrTwoFactorUnavailable
+	}
+
+	settings, err := s.twoFactorSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil {
+		return nil, ErrTwoFactorNotEnrolling
+	}
+	if settings.EnabledAt != nil {
+		return nil, ErrTwoFactorAlreadyEnabled
+	}
+
+	step, ok, err := s.checkTOTP(settings, code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrInvalidTwoFactorCode
+	}
+	if err := s.twoFactor.Enable(ctx, userID, step); err != nil {
+		return nil, err
+	}
+	return s.replaceRecoveryCodes(ctx, userID)
+}
+
+// DisableTOTP turns two-factor off. It requires a current TOTP code; a
+// recovery code is not enough.
+func (s *Service) DisableTOTP(ctx context.Context, userID uuid.UUID, code string) error {
+	if err := s.verifyTOTP(ctx, userID, code); err != nil {
+		return err
+	}
+	return s.twoFactor.Delete(ctx, userID)
+}
+
+// RegenerateRecoveryCodes replaces the user's recovery codes, invalidating
+// the old ones. It requires a current TOTP code.
+func (s *Service) RegenerateRecoveryCodes(ctx context.Context, userID uuid.UUID, code string) ([]string, error) {
+	if err := s.verifyTOTP(ctx, userID, code); err != nil {
+		return nil, err
+	}
+	return s.replaceRecoveryCodes(ctx, userID)
+}
+
+// GetTwoFactorStatus reports whether two-factor is on and how many recovery
+// codes are left.
+func (s *Service) GetTwoFactorStatus(ctx context.Context, userID uuid.UUID) (*TwoFactorStatus, error) {
+	settings, err := s.twoFactorSettings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if settings == nil || settings.EnabledAt == nil {
+		return &TwoFactorStatus{}, nil
+	}
+
+	remaining, err := s.twoFactor.CountUnusedRecoveryCodes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return &TwoFactorStatus{Enabled: true, RecoveryCodesRemaining: remaining}, nil
+}
+
+// VerifySecondFactor checks the code given at sign-in: a current TOTP code
+// or an unused recovery code, which is then used up. Each TOTP code is
+// accepted once.
+func (s *Service) VerifySecondFactor(ctx context.Context, userID uuid.UUID, code string) error {
+	code = strings.TrimSpace(code)
+	if len(code) == totp.Digits {
+		return s.verifyTOTP(ctx, userID, code)
+	}
+
+	if s.twoFactor == nil {
+		return ErrTwoFactorNotEnabled
+	}
+	consumed, err := s.twoFactor.ConsumeRecoveryCode(ctx, userID, hashRecoveryCode(code))
+	if err != nil {
+		return err
+	}
+	if !consumed {
+		return ErrInvalidTwoFactorCode
+	}
+	return nil
+}
+
+// verifyTOTP checks a TOTP code for a user with two-factor enabled and
+// records its step so the same code cannot be used again.
+func (s *Service) verifyTOTP(ctx context.Context, userID uuid.UUID, code string) error {
+	settings, err := s.twoFactorSettings(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if settings == nil || settings.EnabledAt == nil {
+		return ErrTwoFactorNotEnabled
+	}
+	if s.secretCipher == nil {
+		return ErrTwoFactorUnavailable
+	}
+
+	step, ok, err := s.checkTOTP(settings, code)
+	if err != nil {
+		return err
+	}
+	if !ok || step <= settings.LastUsedStep {
+		return ErrInvalidTwoFactorCode
+	}
+
+	fresh, err := s.twoFactor.MarkStepUsed(ctx, userID, step)
+	if err != nil {
+		return err
+	}
+	if !fresh {
+		return ErrInvalidTwoFactorCode
+	}
+	return nil
+}
+
+// checkTOTP decrypts the stored secret and validates code against it.
+func (s *Service) checkTOTP(settings *TwoFactorSettings, code string) (int64, bool, error) {
+	secret, err := s.secretCipher.Decrypt(settings.SecretEncrypted)
+	if err != nil {
+		return 0, false, err
+	}
+	step, ok := totp.Validate(secret, code, time.Now(), totpSkew)
+	return step, ok, nil
+}
+
+// twoFactorSettings returns the user's settings, or nil if there are none.
+func (s *Service) twoFactorSettings(ctx context.Context, userID uuid.UUID) (*TwoFactorSettings, error) {
+	if s.twoFactor == nil {
+		return nil, nil
+	}
+	settings, err := s.twoFactor.Get(ctx, userID)
+	if err != nil {
+		if shared.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return settings, nil
+}
+
+// replaceRecoveryCodes generates and stores a new set of recovery codes and
+// returns them in display form.
+func (s *Service) replaceRecoveryCodes(ctx context.Context, userID uuid.UUID) ([]string, error) {
+	codes := make([]string, RecoveryCodeCount)
+	hashes := make([]string, RecoveryCodeCount)
+	for i := range codes {
+		code, err := generateRecoveryCode()
+		if err != nil {
+			return nil, err
+		}
+		codes[i] = code
+		hashes[i] = hashRecoveryCode(code)
+	}
+
+	if err := s.twoFactor.ReplaceRecoveryCodes(ctx, userID, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// generateRecoveryCode returns a random 10-character code (50 bits) shown as
+// "xxxxx-xxxxx".
+func generateRecoveryCode() (string, error) {
+	b := make([]byte, 7)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	raw := strings.ToLower(recoveryCodeEncoding.EncodeToString(b))[:10]
+	return raw[:5] + "-" + raw[5:], nil
+}
+
+// hashRecoveryCode returns the hex SHA-256 of a recovery code, ignoring case,
+// spaces and dashes so users can type it however it was written down.
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+	hash := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(hash[:])
+}
@@ -0,0 +1,334 @@
+package user
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+	"github.com/antti/home-warehouse/go-backend/internal/shared/totp"
+)
+
+// memTwoFactor is an in-memory TwoFactorRepository.
+type memTwoFactor struct {
+	settings map[uuid.UUID]*TwoFactorSettings
+	codes    map[uuid.UUID]map[string]bool // hash -> used
+}
+
+func newMemTwoFactor() *memTwoFactor {
+	return &memTwoFactor{
+		settings: make(map[uuid.UUID]*TwoFactorSettings),
+		codes:    make(map[uuid.UUID]map[string]bool),
+	}
+}
+
+func (m *memTwoFactor) Get(_ context.Context, userID uuid.UUID) (*TwoFactorSettings, error) {
+	s, ok := m.settings[userID]
+	if !ok {
+		return nil, shared.ErrNotFound
+	}
+	cp := *s
+	return &cp, nil
+}
+
+func (m *memTwoFactor) SavePending(_ context.Context, userID uuid.UUID, secretEncrypted string) error {
+	if s, ok := m.settings[userID]; ok && s.EnabledAt != nil {
+		return nil
+	}
+	m.settings[userID] = &TwoFactorSettings{UserID: userID, SecretEncrypted: secretEncrypted}
+	return nil
+}
+
+func (m *memTwoFactor) Enable(_ context.Context, userID uuid.UUID, step int64) error {
+	now := time.Now()
+	m.settings[userID].EnabledAt = &now
+	m.settings[userID].LastUsedStep = step
+	return nil
+}
+
+func (m *memTwoFactor) MarkStepUsed(_ context.Context, userID uuid.UUID, step int64) (bool, error) {
+	s := m.settings[userID]
+	if step <= s.LastUsedStep {
+		return false, nil
+	}
+	s.LastUsedStep = step
+	return true, nil
+}
+
+func (m *memTwoFactor) Delete(_ context.Context, userID uuid.UUID) error {
+	delete(m.settings, userID)
+	delete(m.codes, userID)
+	return nil
+}
+
+func (m *memTwoFactor) ReplaceRecoveryCodes(_ context.Context, userID uuid.UUID, codeHashes []string) error {
+	m.codes[userID] = make(map[string]bool)
+	for _, h := range codeHashes {
+		m.codes[userID][h] = false
+	}
+	return nil
+}
+
+func (m *memTwoFactor) ConsumeRecoveryCode(_ context.Context, userID uuid.UUID, codeHash string) (bool, error) {
+	used, ok := m.codes[userID][codeHash]
+	if !ok || used {
+		return false, nil
+	}
+	m.codes[userID][codeHash] = true
+	return true, nil
+}
+
+func (m *memTwoFactor) CountUnusedRecoveryCodes(_ context.Context, userID uuid.UUID) (int, error) {
+	n := 0
+	for _, used := range m.codes[userID] {
+		if !used {
+			n++
+		}
+	}
+	return n, nil
+}
+
+// reverseCipher is a reversible stand-in for crypto.Encryptor that makes
+// sure the service never stores the plain secret.
+type reverseCipher struct{}
+
+func (reverseCipher) Encrypt(s string) (string, error) { return "enc:" + reverse(s), nil }
+func (reverseCipher) Decrypt(s string) (string, error) {
+	return reverse(strings.TrimPrefix(s, "enc:")), nil
+}
+
+func reverse(s string) string {
+	r := []rune(s)
+	for i, j := 0, len(r)-1; i < j; i, j = i+1, j-1 {
+		r[i], r[j] = r[j], r[i]
+	}
+	return string(r)
+}
+
+// enrolledUser sets up a service with two-factor enabled for a fresh user
+// and returns the TOTP secret and recovery codes.
+func enrolledUser(t *testing.T) (*Service, *memTwoFactor, *User, string, []string) {
+	t.Helper()
+	ctx := context.Background()
+
+	mockRepo := new(MockRepository)
+	tf := newMemTwoFactor()
+	svc := NewService(mockRepo)
+	svc.SetTwoFactor(tf, reverseCipher{}, DefaultTOTPIssuer)
+
+	u, _ := NewUser("jane@example.com", "Jane", "password123")
+	mockRepo.On("FindByID", ctx, u.ID()).Return(u, nil)
+
+	enrollment, err := svc.EnableTOTP(ctx, u.ID())
+	require.NoError(t, err)
+
+	// Confirm with the previous step's code so the current step is still
+	// unused for the test itself.
+	code, err := totp.Code(enrollment.Secret, time.Now().Add(-totp.Period))
+	require.NoError(t, err)
+	codes, err := svc.ConfirmTOTP(ctx, u.ID(), code)
+	require.NoError(t, err)
+
+	return svc, tf, u, enrollment.Secret, codes
+}
+
+func currentCode(t *testing.T, secret string, offset time.Duration) string {
+	t.Helper()
+	code, err := totp.Code(secret, time.Now().Add(offset))
+	require.NoError(t, err)
+	return code
+}
+
+func TestService_EnableTOTP(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("returns a secret and provisioning URI and stores it encrypted", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		tf := newMemTwoFactor()
+		svc := NewService(mockRepo)
+		svc.SetTwoFactor(tf, reverseCipher{}, "Home Warehouse")
+
+		u, _ := NewUser("jane@example.com", "Jane", "password123")
+		mockRepo.On("FindByID", ctx, u.ID()).Return(u, nil)
+
+		enrollment, err := svc.EnableTOTP(ctx, u.ID())
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, enrollment.Secret)
+		assert.Contains(t, enrollment.ProvisioningURI, "otpauth://totp/Home%20Warehouse:jane@example.com")
+		assert.Contains(t, enrollment.ProvisioningURI, "secret="+enrollment.Secret)
+		stored := tf.settings[u.ID()]
+		require.NotNil(t, stored)
+		assert.NotContains(t, stored.SecretEncrypted, enrollment.Secret)
+		assert.Nil(t, stored.EnabledAt, "not enabled until confirmed")
+
+		status, err := svc.GetTwoFactorStatus(ctx, u.ID())
+		require.NoError(t, err)
+		assert.False(t, status.Enabled)
+	})
+
+	t.Run("rejects when already enabled", func(t *testing.T) {
+		svc, _, u, _, _ := enrolledUser(t)
+
+		_, err := svc.EnableTOTP(ctx, u.ID())
+
+		assert.ErrorIs(t, err, ErrTwoFactorAlreadyEnabled)
+	})
+
+	t.Run("is unavailable without a cipher", func(t *testing.T) {
+		svc := NewService(new(MockRepository))
+		svc.SetTwoFactor(newMemTwoFactor(), nil, DefaultTOTPIssuer)
+
+		_, err := svc.EnableTOTP(ctx, uuid.New())
+
+		assert.ErrorIs(t, err, ErrTwoFactorUnavailable)
+	})
+}
+
+func TestService_ConfirmTOTP(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("enables two-factor and issues recovery codes", func(t *testing.T) {
+		svc, _, u, _, codes := enrolledUser(t)
+
+		assert.Len(t, codes, RecoveryCodeCount)
+		status, err := svc.GetTwoFactorStatus(ctx, u.ID())
+		require.NoError(t, err)
+		assert.True(t, status.Enabled)
+		assert.Equal(t, RecoveryCodeCount, status.RecoveryCodesRemaining)
+	})
+
+	t.Run("rejects a wrong code", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		svc := NewService(mockRepo)
+		svc.SetTwoFactor(newMemTwoFactor(), reverseCipher{}, DefaultTOTPIssuer)
+		u, _ := NewUser("jane@example.com", "Jane", "password123")
+		mockRepo.On("FindByID", ctx, u.ID()).Return(u, nil)
+
+		enrollment, err := svc.EnableTOTP(ctx, u.ID())
+		require.NoError(t, err)
+
+		_, err = svc.ConfirmTOTP(ctx, u.ID(), currentCode(t, enrollment.Secret, -3*totp.Period))
+		assert.ErrorIs(t, err, ErrInvalidTwoFactorCode)
+	})
+
+	t.Run("requires enrollment first", func(t *testing.T) {
+		svc := NewService(new(MockRepository))
+		svc.SetTwoFactor(newMemTwoFactor(), reverseCipher{}, DefaultTOTPIssuer)
+
+		_, err := svc.ConfirmTOTP(ctx, uuid.New(), "123456")
+
+		assert.ErrorIs(t, err, ErrTwoFactorNotEnrolling)
+	})
+}
+
+func TestService_VerifySecondFactor(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("accepts codes within one step of now", func(t *testing.T) {
+		for _, offset := range []time.Duration{0, totp.Period} {
+			svc, _, u, secret, _ := enrolledUser(t)
+			assert.NoError(t, svc.VerifySecondFactor(ctx, u.ID(), currentCode(t, secret, offset)), "offset %s", offset)
+		}
+	})
+
+	t.Run("rejects codes outside the window", func(t *testing.T) {
+		for _, offset := range []time.Duration{-3 * totp.Period, 2 * totp.Period} {
+			svc, _, u, secret, _ := enrolledUser(t)
+			err := svc.VerifySecondFactor(ctx, u.ID(), currentCode(t, secret, offset))
+			assert.ErrorIs(t, err, ErrInvalidTwoFactorCode, "offset %s", offset)
+		}
+	})
+
+	t.Run("rejects a replayed code", func(t *testing.T) {
+		svc, _, u, secret, _ := enrolledUser(t)
+		code := currentCode(t, secret, 0)
+
+		require.NoError(t, svc.VerifySecondFactor(ctx, u.ID(), code))
+		assert.ErrorIs(t, svc.VerifySecondFactor(ctx, u.ID(), code), ErrInvalidTwoFactorCode)
+	})
+
+	t.Run("rejects a code older than the last accepted one", func(t *testing.T) {
+		svc, _, u, secret, _ := enrolledUser(t)
+
+		require.NoError(t, svc.VerifySecondFactor(ctx, u.ID(), currentCode(t, secret, totp.Period)))
+		err := svc.VerifySecondFactor(ctx, u.ID(), currentCode(t, secret, 0))
+		assert.ErrorIs(t, err, ErrInvalidTwoFactorCode)
+	})
+
+	t.Run("recovery codes work once", func(t *testing.T) {
+		svc, _, u, _, codes := enrolledUser(t)
+
+		require.NoError(t, svc.VerifySecondFactor(ctx, u.ID(), codes[0]))
+		assert.ErrorIs(t, svc.VerifySecondFactor(ctx, u.ID(), codes[0]), ErrInvalidTwoFactorCode)
+
+		status, err := svc.GetTwoFactorStatus(ctx, u.ID())
+		require.NoError(t, err)
+		assert.Equal(t, RecoveryCodeCount-1, status.RecoveryCodesRemaining)
+	})
+
+	t.Run("recovery codes ignore case and dashes", func(t *testing.T) {
+		svc, _, u, _, codes := enrolledUser(t)
+
+		typed := strings.ToUpper(strings.ReplaceAll(codes[1], "-", ""))
+		assert.NoError(t, svc.VerifySecondFactor(ctx, u.ID(), typed))
+	})
+
+	t.Run("rejects an unknown recovery code", func(t *testing.T) {
+		svc, _, u, _, _ := enrolledUser(t)
+
+		assert.ErrorIs(t, svc.VerifySecondFactor(ctx, u.ID(), "aaaaa-bbbbb"), ErrInvalidTwoFactorCode)
+	})
+
+	t.Run("enrolled users cannot bypass a missing cipher", func(t *testing.T) {
+		svc, tf, u, secret, _ := enrolledUser(t)
+		svc.SetTwoFactor(tf, nil, DefaultTOTPIssuer)
+
+		err := svc.VerifySecondFactor(ctx, u.ID(), currentCode(t, secret, 0))
+		assert.ErrorIs(t, err, ErrTwoFactorUnavailable)
+
+		status, err := svc.GetTwoFactorStatus(ctx, u.ID())
+		require.NoError(t, err)
+		assert.True(t, status.Enabled)
+	})
+}
+
+func TestService_DisableTOTP(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("requires a current TOTP code", func(t *testing.T) {
+		svc, _, u, secret, codes := enrolledUser(t)
+
+		assert.ErrorIs(t, svc.DisableTOTP(ctx, u.ID(), codes[0]), ErrInvalidTwoFactorCode, "recovery codes do not disable")
+		require.NoError(t, svc.DisableTOTP(ctx, u.ID(), currentCode(t, secret, 0)))
+
+		status, err := svc.GetTwoFactorStatus(ctx, u.ID())
+		require.NoError(t, err)
+		assert.False(t, status.Enabled)
+	})
+
+	t.Run("rejects when not enabled", func(t *testing.T) {
+		svc := NewService(new(MockRepository))
+		svc.SetTwoFactor(newMemTwoFactor(), reverseCipher{}, DefaultTOTPIssuer)
+
+		assert.ErrorIs(t, svc.DisableTOTP(ctx, uuid.New(), "123456"), ErrTwoFactorNotEnabled)
+	})
+}
+
+func TestService_RegenerateRecoveryCodes(t *testing.T) {
+	ctx := context.Background()
+	svc, _, u, secret, oldCodes := enrolledUser(t)
+
+	newCodes, err := svc.RegenerateRecoveryCodes(ctx, u.ID(), currentCode(t, secret, 0))
+
+	require.NoError(t, err)
+	assert.Len(t, newCodes, RecoveryCodeCount)
+	assert.NotEqual(t, oldCodes, newCodes)
+	assert.ErrorIs(t, svc.VerifySecondFactor(ctx, u.ID(), oldCodes[0]), ErrInvalidTwoFactorCode)
+	assert.NoError(t, svc.VerifySecondFactor(ctx, u.ID(), newCodes[0]))
+}
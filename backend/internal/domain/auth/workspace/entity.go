@@ -96,3 +96,13 @@ type WorkspaceWithRole struct {
 	*Workspace
 	Role string
 }
+
+// DeletionImpact is what deleting a workspace would destroy.
+type DeletionImpact struct {
+	// Counts holds the number of rows per dependent table, e.g. "items".
+	Counts map[string]int64
+	// StorageFiles and StorageBytes cover uploaded photos and attachments.
+	// Bytes are original file sizes; thumbnails add a little on top.
+	StorageFiles int64
+	StorageBytes int64
+}
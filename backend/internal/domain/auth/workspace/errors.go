@@ -6,7 +6,8 @@ import (
 
 // Domain-specific errors for the workspace domain.
 var (
-	ErrWorkspaceNotFound    = shared.NewDomainError(shared.ErrNotFound, "workspace not found")
-	ErrSlugTaken            = shared.NewDomainError(shared.ErrAlreadyExists, "workspace slug is already taken")
-	ErrCannotDeletePersonal = shared.NewDomainError(shared.ErrForbidden, "cannot delete personal workspace")
+	ErrWorkspaceNotFound          = shared.NewDomainError(shared.ErrNotFound, "workspace not found")
+	ErrSlugTaken                  = shared.NewDomainError(shared.ErrAlreadyExists, "workspace slug is already taken")
+	ErrCannotDeletePersonal       = shared.NewDomainError(shared.ErrForbidden, "cannot delete personal workspace")
+	ErrDeleteConfirmationMismatch = shared.NewDomainError(shared.ErrInvalidInput, "confirmation does not match the workspace name")
 )
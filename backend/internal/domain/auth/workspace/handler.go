@@ -31,9 +31,20 @@ func RegisterRoutes(api huma.API, svc ServiceInterface) {
 func RegisterWorkspaceScopedRoutes(api huma.API, svc ServiceInterface) {
 	huma.Get(api, "/", getWorkspace(svc))
 	huma.Patch(api, "/", updateWorkspace(svc))
+	huma.Get(api, "/deletion-impact", getDeletionImpact(svc))
 	huma.Delete(api, "/", deleteWorkspace(svc))
 }
 
+// requireOwner ensures the caller owns the workspace. Deleting it destroys
+// everyone's data, so admins cannot.
+func requireOwner(ctx context.Context) error {
+	role, ok := appMiddleware.GetRole(ctx)
+	if !ok || role != "owner" {
+		return huma.Error403Forbidden("only the workspace owner can delete the workspace")
+	}
+	return nil
+}
+
 // listWorkspaces lists the authenticated user's workspaces.
 func listWorkspaces(svc ServiceInterface) func(context.Context, *struct{}) (*ListWorkspacesOutput, error) {
 	return func(ctx context.Context, input *struct{}) (*ListWorkspacesOutput, error) {
@@ -153,15 +164,49 @@ func updateWorkspace(svc ServiceInterface) func(context.Context, *UpdateWorkspac
 	}
 }
 
-// deleteWorkspace deletes the current workspace-scoped workspace.
-func deleteWorkspace(svc ServiceInterface) func(context.Context, *struct{}) (*struct{}, error) {
-	return func(ctx context.Context, input *struct{}) (*struct{}, error) {
+// getDeletionImpact reports what deleting the current workspace would
+// destroy, shown to the owner before they confirm.
+func getDeletionImpact(svc ServiceInterface) func(context.Context, *struct{}) (*GetDeletionImpactOutput, error) {
+	return func(ctx context.Context, input *struct{}) (*GetDeletionImpactOutput, error) {
+		workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+		if !ok {
+			return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+		}
+		if err := requireOwner(ctx); err != nil {
+			return nil, err
+		}
+
+		impact, err := svc.WorkspaceDeletionImpact(ctx, workspaceID)
+		if err != nil {
+			if errors.Is(err, ErrWorkspaceNotFound) {
+				return nil, huma.Error404NotFound(msgWorkspaceNotFound)
+			}
+			return nil, appMiddleware.MapDomainError(err)
+		}
+
+		return &GetDeletionImpactOutput{
+			Body: DeletionImpactResponse{
+				Counts:       impact.Counts,
+				StorageFiles: impact.StorageFiles,
+				StorageBytes: impact.StorageBytes,
+			},
+		}, nil
+	}
+}
+
+// deleteWorkspace permanently deletes the current workspace-scoped workspace.
+// The caller must be the owner and confirm with the workspace name.
+func deleteWorkspace(svc ServiceInterface) func(context.Context, *DeleteWorkspaceInput) (*struct{}, error) {
+	return func(ctx context.Context, input *DeleteWorkspaceInput) (*struct{}, error) {
 		workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
 		if !ok {
 			return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
 		}
+		if err := requireOwner(ctx); err != nil {
+			return nil, err
+		}
 
-		err := svc.Delete(ctx, workspaceID)
+		err := svc.Delete(ctx, workspaceID, input.Confirm)
 		if err != nil {
 			if errors.Is(err, ErrWorkspaceNotFound) {
 				return nil, huma.Error404NotFound(msgWorkspaceNotFound)
@@ -169,6 +214,9 @@ func deleteWorkspace(svc ServiceInterface) func(context.Context, *struct{}) (*st
 			if errors.Is(err, ErrCannotDeletePersonal) {
 				return nil, huma.Error400BadRequest("cannot delete personal workspace")
 			}
+			if errors.Is(err, ErrDeleteConfirmationMismatch) {
+				return nil, huma.Error400BadRequest("confirm must match the workspace name")
+			}
 			return nil, appMiddleware.MapDomainError(err)
 		}
 
@@ -256,6 +304,21 @@ type UpdateWorkspaceOutput struct {
 	Body WorkspaceResponse
 }
 
+// DeleteWorkspaceInput confirms a workspace delete.
+type DeleteWorkspaceInput struct {
+	Confirm string `query:"confirm" required:"true" doc:"The workspace name, confirming the delete"`
+}
+
+type GetDeletionImpactOutput struct {
+	Body DeletionImpactResponse
+}
+
+type DeletionImpactResponse struct {
+	Counts       map[string]int64 `json:"counts" doc:"Rows that would be deleted, per entity"`
+	StorageFiles int64            `json:"storage_files" doc:"Uploaded photos and attachments that would be removed"`
+	StorageBytes int64            `json:"storage_bytes" doc:"Storage freed by removing them, in bytes"`
+}
+
 type WorkspaceResponse struct {
 	ID          uuid.UUID `json:"id"`
 	Name        string    `json:"name"`
@@ -2,11 +2,14 @@ package workspace_test
 
 import (
 	"context"
+	"encoding/json"
 	"net/http"
 	"testing"
 
 	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/antti/home-warehouse/go-backend/internal/domain/auth/workspace"
 	"github.com/antti/home-warehouse/go-backend/internal/testutil"
@@ -57,8 +60,16 @@ func (m *MockService) Update(ctx context.Context, id uuid.UUID, input workspace.
 	return args.Get(0).(*workspace.Workspace), args.Error(1)
 }
 
-func (m *MockService) Delete(ctx context.Context, id uuid.UUID) error {
+func (m *MockService) WorkspaceDeletionImpact(ctx context.Context, id uuid.UUID) (*workspace.DeletionImpact, error) {
 	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*workspace.DeletionImpact), args.Error(1)
+}
+
+func (m *MockService) Delete(ctx context.Context, id uuid.UUID, confirmation string) error {
+	args := m.Called(ctx, id, confirmation)
 	return args.Error(0)
 }
 
@@ -265,32 +276,90 @@ func TestWorkspaceHandler_Delete(t *testing.T) {
 	workspace.RegisterWorkspaceScopedRoutes(setup.API, mockSvc)
 
 	t.Run("deletes workspace successfully", func(t *testing.T) {
-		mockSvc.On("Delete", mock.Anything, setup.WorkspaceID).
+		mockSvc.On("Delete", mock.Anything, setup.WorkspaceID, "My Workspace").
 			Return(nil).Once()
 
-		rec := setup.Delete("/")
+		rec := setup.Delete("/?confirm=My%20Workspace")
 
 		testutil.AssertStatus(t, rec, http.StatusNoContent)
 		mockSvc.AssertExpectations(t)
 	})
 
+	t.Run("returns 400 when confirmation does not match", func(t *testing.T) {
+		mockSvc.On("Delete", mock.Anything, setup.WorkspaceID, "wrong").
+			Return(workspace.ErrDeleteConfirmationMismatch).Once()
+
+		rec := setup.Delete("/?confirm=wrong")
+
+		testutil.AssertStatus(t, rec, http.StatusBadRequest)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("requires confirmation", func(t *testing.T) {
+		rec := setup.Delete("/")
+
+		testutil.AssertStatus(t, rec, http.StatusUnprocessableEntity)
+	})
+
 	t.Run("returns 400 when trying to delete personal workspace", func(t *testing.T) {
-		mockSvc.On("Delete", mock.Anything, setup.WorkspaceID).
+		mockSvc.On("Delete", mock.Anything, setup.WorkspaceID, "Personal").
 			Return(workspace.ErrCannotDeletePersonal).Once()
 
-		rec := setup.Delete("/")
+		rec := setup.Delete("/?confirm=Personal")
 
 		testutil.AssertStatus(t, rec, http.StatusBadRequest)
 		mockSvc.AssertExpectations(t)
 	})
 
 	t.Run("returns 404 when workspace not found", func(t *testing.T) {
-		mockSvc.On("Delete", mock.Anything, setup.WorkspaceID).
+		mockSvc.On("Delete", mock.Anything, setup.WorkspaceID, "Gone").
 			Return(workspace.ErrWorkspaceNotFound).Once()
 
-		rec := setup.Delete("/")
+		rec := setup.Delete("/?confirm=Gone")
 
 		testutil.AssertStatus(t, rec, http.StatusNotFound)
 		mockSvc.AssertExpectations(t)
 	})
+
+	t.Run("only the owner can delete", func(t *testing.T) {
+		setup.SetRole("admin")
+		defer setup.SetRole("owner")
+
+		rec := setup.Delete("/?confirm=My%20Workspace")
+
+		testutil.AssertStatus(t, rec, http.StatusForbidden)
+	})
+}
+
+func TestWorkspaceHandler_DeletionImpact(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	workspace.RegisterWorkspaceScopedRoutes(setup.API, mockSvc)
+
+	t.Run("returns counts and storage", func(t *testing.T) {
+		mockSvc.On("WorkspaceDeletionImpact", mock.Anything, setup.WorkspaceID).
+			Return(&workspace.DeletionImpact{
+				Counts:       map[string]int64{"items": 12, "locations": 2},
+				StorageFiles: 3,
+				StorageBytes: 2048,
+			}, nil).Once()
+
+		rec := setup.Get("/deletion-impact")
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		var body workspace.DeletionImpactResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, int64(12), body.Counts["items"])
+		assert.Equal(t, int64(3), body.StorageFiles)
+		assert.Equal(t, int64(2048), body.StorageBytes)
+	})
+
+	t.Run("only the owner can view", func(t *testing.T) {
+		setup.SetRole("admin")
+		defer setup.SetRole("owner")
+
+		rec := setup.Get("/deletion-impact")
+
+		testutil.AssertStatus(t, rec, http.StatusForbidden)
+	})
 }
@@ -20,9 +20,17 @@ type Repository interface {
 	// FindByUserID retrieves all workspaces for a user with their role.
 	FindByUserID(ctx context.Context, userID uuid.UUID) ([]*WorkspaceWithRole, error)
 
-	// Delete removes a workspace by ID.
+	// Delete removes a workspace and all of its data, dependents first so
+	// no foreign key blocks the delete.
 	Delete(ctx context.Context, id uuid.UUID) error
 
+	// DeletionImpact counts the workspace's data and stored files.
+	DeletionImpact(ctx context.Context, id uuid.UUID) (*DeletionImpact, error)
+
+	// StoredFilePaths lists the storage paths of the workspace's photos,
+	// thumbnails and attachments.
+	StoredFilePaths(ctx context.Context, id uuid.UUID) ([]string, error)
+
 	// ExistsBySlug checks if a workspace with the given slug exists.
 	ExistsBySlug(ctx context.Context, slug string) (bool, error)
 }
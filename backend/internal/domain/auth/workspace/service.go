@@ -2,10 +2,14 @@ package workspace
 
 import (
 	"context"
+	"errors"
+	"log"
+	"strings"
 
 	"github.com/google/uuid"
 
 	"github.com/antti/home-warehouse/go-backend/internal/domain/auth/member"
+	"github.com/antti/home-warehouse/go-backend/internal/infra/storage"
 )
 
 // MemberAdder is an interface for adding members (to avoid circular dependencies).
@@ -20,13 +24,37 @@ type ServiceInterface interface {
 	GetBySlug(ctx context.Context, slug string) (*Workspace, error)
 	GetUserWorkspaces(ctx context.Context, userID uuid.UUID) ([]*WorkspaceWithRole, error)
 	Update(ctx context.Context, id uuid.UUID, input UpdateWorkspaceInput) (*Workspace, error)
-	Delete(ctx context.Context, id uuid.UUID) error
+	WorkspaceDeletionImpact(ctx context.Context, id uuid.UUID) (*DeletionImpact, error)
+	Delete(ctx context.Context, id uuid.UUID, confirmation string) error
+}
+
+// Transactor runs a function inside a single database transaction. It is a
+// port implemented by infra/postgres.TxManager, keeping this package free of
+// infra imports.
+type Transactor interface {
+	WithTx(ctx context.Context, fn func(context.Context) error) error
+}
+
+// noopTransactor executes the function without a surrounding transaction. It
+// is the fallback when no Transactor is wired (unit tests with mocked
+// repositories).
+type noopTransactor struct{}
+
+func (noopTransactor) WithTx(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+// FileRemover deletes stored files (photos, attachments) by storage path.
+type FileRemover interface {
+	Delete(ctx context.Context, path string) error
 }
 
 // Service handles workspace business logic.
 type Service struct {
 	repo       Repository
 	memberRepo MemberAdder
+	tx         Transactor
+	files      FileRemover
 }
 
 // NewService creates a new workspace service.
@@ -34,9 +62,27 @@ func NewService(repo Repository, memberRepo MemberAdder) *Service {
 	return &Service{
 		repo:       repo,
 		memberRepo: memberRepo,
+		tx:         noopTransactor{},
 	}
 }
 
+// SetTransactor wires the transaction runner used by Delete so all of the
+// workspace's rows go together. Optional — without it the deletes run
+// unwrapped (unit tests with mocked repositories).
+func (s *Service) SetTransactor(tx Transactor) {
+	if tx == nil {
+		tx = noopTransactor{}
+	}
+	s.tx = tx
+}
+
+// SetFileStorage wires the storage Delete removes the workspace's files
+// from. Optional — without it the files are left on disk for photo-admin
+// cleanup to find.
+func (s *Service) SetFileStorage(files FileRemover) {
+	s.files = files
+}
+
 // CreateWorkspaceInput holds the input for creating a workspace.
 type CreateWorkspaceInput struct {
 	Name        string
@@ -139,8 +185,20 @@ func (s *Service) Update(ctx context.Context, id uuid.UUID, input UpdateWorkspac
 	return workspace, nil
 }
 
-// Delete deletes a workspace.
-func (s *Service) Delete(ctx context.Context, id uuid.UUID) error {
+// WorkspaceDeletionImpact reports everything deleting the workspace would
+// destroy, for the confirmation step before Delete.
+func (s *Service) WorkspaceDeletionImpact(ctx context.Context, id uuid.UUID) (*DeletionImpact, error) {
+	if _, err := s.GetByID(ctx, id); err != nil {
+		return nil, err
+	}
+	return s.repo.DeletionImpact(ctx, id)
+}
+
+// Delete permanently deletes a workspace with all of its data and stored
+// files. confirmation must be the workspace's name, guarding against deleting
+// the wrong one. The rows are deleted in one transaction; the files are
+// removed after it commits, so a failed delete never loses files.
+func (s *Service) Delete(ctx context.Context, id uuid.UUID, confirmation string) error {
 	workspace, err := s.GetByID(ctx, id)
 	if err != nil {
 		return err
@@ -149,6 +207,39 @@ func (s *Service) Delete(ctx context.Context, id uuid.UUID) error {
 	if workspace.IsPersonal() {
 		return ErrCannotDeletePersonal
 	}
+	if strings.TrimSpace(confirmation) != workspace.Name() {
+		return ErrDeleteConfirmationMismatch
+	}
+
+	var paths []string
+	err = s.tx.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		if paths, err = s.repo.StoredFilePaths(ctx, id); err != nil {
+			return err
+		}
+		return s.repo.Delete(ctx, id)
+	})
+	if err != nil {
+		return err
+	}
+
+	s.removeFiles(ctx, id, paths)
+	return nil
+}
 
-	return s.repo.Delete(ctx, id)
+// removeFiles deletes a deleted workspace's files. Failures are logged, not
+// returned: the workspace is already gone.
+func (s *Service) removeFiles(ctx context.Context, id uuid.UUID, paths []string) {
+	if s.files == nil {
+		return
+	}
+	failed := 0
+	for _, path := range paths {
+		if err := s.files.Delete(ctx, path); err != nil && !errors.Is(err, storage.ErrFileNotFound) {
+			failed++
+		}
+	}
+	if failed > 0 {
+		log.Printf("workspace %s deleted but %d of %d stored files could not be removed", id, failed, len(paths))
+	}
 }
@@ -2,6 +2,7 @@ package workspace
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"testing"
 	"time"
@@ -9,6 +10,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/antti/home-warehouse/go-backend/internal/shared"
 )
@@ -52,6 +54,22 @@ func (m *MockRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	return args.Error(0)
 }
 
+func (m *MockRepository) DeletionImpact(ctx context.Context, id uuid.UUID) (*DeletionImpact, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*DeletionImpact), args.Error(1)
+}
+
+func (m *MockRepository) StoredFilePaths(ctx context.Context, id uuid.UUID) ([]string, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
 func (m *MockRepository) ExistsBySlug(ctx context.Context, slug string) (bool, error) {
 	args := m.Called(ctx, slug)
 	return args.Bool(0), args.Error(1)
@@ -525,30 +543,77 @@ func TestService_Update(t *testing.T) {
 	}
 }
 
+// fakeFileRemover records removed paths.
+type fakeFileRemover struct {
+	removed []string
+}
+
+func (f *fakeFileRemover) Delete(ctx context.Context, path string) error {
+	f.removed = append(f.removed, path)
+	return nil
+}
+
+// failingTransactor fails without running the function, like a transaction
+// that could not begin.
+type failingTransactor struct{}
+
+func (failingTransactor) WithTx(ctx context.Context, fn func(context.Context) error) error {
+	return fmt.Errorf("begin failed")
+}
+
 func TestService_Delete(t *testing.T) {
 	ctx := context.Background()
 	workspaceID := uuid.New()
+	paths := []string{"ws/item/a.jpg", "ws/item/a_thumb.jpg"}
 
 	tests := []struct {
-		name        string
-		workspaceID uuid.UUID
-		setupMock   func(*MockRepository)
-		expectError bool
-		errorType   error
+		name         string
+		workspaceID  uuid.UUID
+		confirmation string
+		setupMock    func(*MockRepository)
+		expectError  bool
+		errorType    error
+		wantRemoved  []string
 	}{
 		{
-			name:        "successful deletion",
-			workspaceID: workspaceID,
+			name:         "successful deletion",
+			workspaceID:  workspaceID,
+			confirmation: "Test",
 			setupMock: func(m *MockRepository) {
 				workspace, _ := NewWorkspace("Test", "test", nil, false)
 				m.On("FindByID", ctx, workspaceID).Return(workspace, nil)
+				m.On("StoredFilePaths", ctx, workspaceID).Return(paths, nil)
 				m.On("Delete", ctx, workspaceID).Return(nil)
 			},
 			expectError: false,
+			wantRemoved: paths,
 		},
 		{
-			name:        "cannot delete personal workspace",
-			workspaceID: workspaceID,
+			name:         "confirmation mismatch",
+			workspaceID:  workspaceID,
+			confirmation: "test",
+			setupMock: func(m *MockRepository) {
+				workspace, _ := NewWorkspace("Test", "test", nil, false)
+				m.On("FindByID", ctx, workspaceID).Return(workspace, nil)
+			},
+			expectError: true,
+			errorType:   ErrDeleteConfirmationMismatch,
+		},
+		{
+			name:         "empty confirmation",
+			workspaceID:  workspaceID,
+			confirmation: "",
+			setupMock: func(m *MockRepository) {
+				workspace, _ := NewWorkspace("Test", "test", nil, false)
+				m.On("FindByID", ctx, workspaceID).Return(workspace, nil)
+			},
+			expectError: true,
+			errorType:   ErrDeleteConfirmationMismatch,
+		},
+		{
+			name:         "cannot delete personal workspace",
+			workspaceID:  workspaceID,
+			confirmation: "Personal",
 			setupMock: func(m *MockRepository) {
 				workspace, _ := NewWorkspace("Personal", "personal", nil, true)
 				m.On("FindByID", ctx, workspaceID).Return(workspace, nil)
@@ -557,33 +622,97 @@ func TestService_Delete(t *testing.T) {
 			errorType:   ErrCannotDeletePersonal,
 		},
 		{
-			name:        "workspace not found",
-			workspaceID: uuid.New(),
+			name:         "workspace not found",
+			workspaceID:  uuid.New(),
+			confirmation: "Test",
 			setupMock: func(m *MockRepository) {
 				m.On("FindByID", ctx, mock.Anything).Return(nil, nil)
 			},
 			expectError: true,
 			errorType:   ErrWorkspaceNotFound,
 		},
+		{
+			name:         "delete failure keeps files",
+			workspaceID:  workspaceID,
+			confirmation: "Test",
+			setupMock: func(m *MockRepository) {
+				workspace, _ := NewWorkspace("Test", "test", nil, false)
+				m.On("FindByID", ctx, workspaceID).Return(workspace, nil)
+				m.On("StoredFilePaths", ctx, workspaceID).Return(paths, nil)
+				m.On("Delete", ctx, workspaceID).Return(fmt.Errorf("database error"))
+			},
+			expectError: true,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(MockRepository)
+			files := &fakeFileRemover{}
 			svc := NewService(mockRepo, nil)
+			svc.SetFileStorage(files)
 
 			tt.setupMock(mockRepo)
 
-			err := svc.Delete(ctx, tt.workspaceID)
+			err := svc.Delete(ctx, tt.workspaceID, tt.confirmation)
 
 			if tt.expectError {
 				assert.Error(t, err)
-				assert.Equal(t, tt.errorType, err)
+				if tt.errorType != nil {
+					assert.Equal(t, tt.errorType, err)
+				}
 			} else {
 				assert.NoError(t, err)
 			}
+			assert.Equal(t, tt.wantRemoved, files.removed)
 
 			mockRepo.AssertExpectations(t)
+			if errors.Is(tt.errorType, ErrDeleteConfirmationMismatch) {
+				mockRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+			}
 		})
 	}
+
+	t.Run("runs inside the transactor", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		files := &fakeFileRemover{}
+		workspace, _ := NewWorkspace("Test", "test", nil, false)
+		mockRepo.On("FindByID", ctx, workspaceID).Return(workspace, nil)
+		svc := NewService(mockRepo, nil)
+		svc.SetTransactor(failingTransactor{})
+		svc.SetFileStorage(files)
+
+		err := svc.Delete(ctx, workspaceID, "Test")
+
+		assert.Error(t, err)
+		assert.Empty(t, files.removed)
+		mockRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
+
+func TestService_WorkspaceDeletionImpact(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+
+	t.Run("returns the repository's counts", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		workspace, _ := NewWorkspace("Test", "test", nil, false)
+		impact := &DeletionImpact{Counts: map[string]int64{"items": 12, "item_photos": 3}, StorageFiles: 3, StorageBytes: 4096}
+		mockRepo.On("FindByID", ctx, workspaceID).Return(workspace, nil)
+		mockRepo.On("DeletionImpact", ctx, workspaceID).Return(impact, nil)
+
+		got, err := NewService(mockRepo, nil).WorkspaceDeletionImpact(ctx, workspaceID)
+
+		require.NoError(t, err)
+		assert.Equal(t, impact, got)
+	})
+
+	t.Run("workspace not found", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("FindByID", ctx, workspaceID).Return(nil, nil)
+
+		_, err := NewService(mockRepo, nil).WorkspaceDeletionImpact(ctx, workspaceID)
+
+		assert.ErrorIs(t, err, ErrWorkspaceNotFound)
+	})
 }
@@ -264,7 +264,7 @@ func TestMarshalEntity_DomainEntities(t *testing.T) {
 				id, workspaceID, "SKU-001", "Item Name",
 				nil, nil, nil, nil, nil, nil, nil, nil,
 				ptrBool(false), ptrBool(false), ptrBool(false), nil, nil,
-				5, "SHORT1", nil, nil, ptrBool(false), now, now,
+				5, "SHORT1", nil, nil, ptrBool(false), true, now, now,
 			),
 			expectedName: "Item Name",
 		},
@@ -896,12 +896,16 @@ func (m *MockItemRepository) FindByShortCode(ctx context.Context, workspaceID uu
 	return args.Get(0).(*item.Item), args.Error(1)
 }
 
-func (m *MockItemRepository) FindByBarcode(ctx context.Context, workspaceID uuid.UUID, barcode string) (*item.Item, error) {
+func (m *MockItemRepository) FindByBarcode(ctx context.Context, workspaceID uuid.UUID, barcode string) (*item.Item, *item.Identifier, error) {
 	args := m.Called(ctx, workspaceID, barcode)
+	var identifier *item.Identifier
+	if args.Get(1) != nil {
+		identifier = args.Get(1).(*item.Identifier)
+	}
 	if args.Get(0) == nil {
-		return nil, args.Error(1)
+		return nil, identifier, args.Error(2)
 	}
-	return args.Get(0).(*item.Item), args.Error(1)
+	return args.Get(0).(*item.Item), identifier, args.Error(2)
 }
 
 func (m *MockItemRepository) FindByWorkspace(ctx context.Context, workspaceID uuid.UUID, pagination shared.Pagination) ([]*item.Item, int, error) {
@@ -939,6 +943,14 @@ func (m *MockItemRepository) FindNeedingReview(ctx context.Context, workspaceID
 	return mockSliceIntErr[item.Item](args)
 }
 
+func (m *MockItemRepository) FindByIDs(ctx context.Context, workspaceID uuid.UUID, ids []uuid.UUID) ([]*item.Item, error) {
+	args := m.Called(ctx, workspaceID, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*item.Item), args.Error(1)
+}
+
 func (m *MockItemRepository) FindByCategory(ctx context.Context, workspaceID, categoryID uuid.UUID, pagination shared.Pagination) ([]*item.Item, error) {
 	args := m.Called(ctx, workspaceID, categoryID, pagination)
 	if args.Get(0) == nil {
@@ -970,6 +982,14 @@ func (m *MockItemRepository) ShortCodeExists(ctx context.Context, shortCode stri
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *MockItemRepository) ReassignCategory(ctx context.Context, workspaceID uuid.UUID, itemIDs []uuid.UUID, categoryID *uuid.UUID) ([]item.ReassignedItem, error) {
+	args := m.Called(ctx, workspaceID, itemIDs, categoryID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]item.ReassignedItem), args.Error(1)
+}
+
 func (m *MockItemRepository) AttachLabel(ctx context.Context, itemID, labelID uuid.UUID) error {
 	args := m.Called(ctx, itemID, labelID)
 	return args.Error(0)
@@ -987,6 +1007,54 @@ func (m *MockItemRepository) GetItemLabels(ctx context.Context, itemID uuid.UUID
 	return args.Get(0).([]uuid.UUID), args.Error(1)
 }
 
+func (m *MockItemRepository) ListIdentifiers(ctx context.Context, workspaceID, itemID uuid.UUID) ([]*item.Identifier, error) {
+	args := m.Called(ctx, workspaceID, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*item.Identifier), args.Error(1)
+}
+
+func (m *MockItemRepository) SaveIdentifier(ctx context.Context, identifier *item.Identifier) error {
+	return m.Called(ctx, identifier).Error(0)
+}
+
+func (m *MockItemRepository) DeleteIdentifier(ctx context.Context, workspaceID, itemID, identifierID uuid.UUID) error {
+	return m.Called(ctx, workspaceID, itemID, identifierID).Error(0)
+}
+
+func (m *MockItemRepository) FindSimilar(ctx context.Context, workspaceID uuid.UUID, threshold float64, limit int) ([]item.SimilarPair, error) {
+	args := m.Called(ctx, workspaceID, threshold, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]item.SimilarPair), args.Error(1)
+}
+
+func (m *MockItemRepository) CountLoans(ctx context.Context, workspaceID, itemID uuid.UUID) (int, error) {
+	args := m.Called(ctx, workspaceID, itemID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockItemRepository) MoveInventory(ctx context.Context, workspaceID, fromItemID, toItemID uuid.UUID) (int, error) {
+	args := m.Called(ctx, workspaceID, fromItemID, toItemID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockItemRepository) MoveLabels(ctx context.Context, workspaceID, fromItemID, toItemID uuid.UUID) (int, error) {
+	args := m.Called(ctx, workspaceID, fromItemID, toItemID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockItemRepository) MovePhotos(ctx context.Context, workspaceID, fromItemID, toItemID uuid.UUID) (int, error) {
+	args := m.Called(ctx, workspaceID, fromItemID, toItemID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockItemRepository) UpdateSKU(ctx context.Context, workspaceID, itemID uuid.UUID, sku string) error {
+	return m.Called(ctx, workspaceID, itemID, sku).Error(0)
+}
+
 // MockLocationRepository is a mock implementation of the location.Repository interface
 type MockLocationRepository struct {
 	mock.Mock
@@ -1031,6 +1099,16 @@ func (m *MockLocationRepository) Search(ctx context.Context, workspaceID uuid.UU
 	return args.Get(0).([]*location.Location), args.Error(1)
 }
 
+func (m *MockLocationRepository) MoveInventory(ctx context.Context, workspaceID, fromLocationID, toLocationID uuid.UUID, keepContainers bool) (int, error) {
+	args := m.Called(ctx, workspaceID, fromLocationID, toLocationID, keepContainers)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockLocationRepository) MoveContainers(ctx context.Context, workspaceID, fromLocationID, toLocationID uuid.UUID) (int, error) {
+	args := m.Called(ctx, workspaceID, fromLocationID, toLocationID)
+	return args.Int(0), args.Error(1)
+}
+
 func (m *MockLocationRepository) FindByShortCode(ctx context.Context, workspaceID uuid.UUID, shortCode string) (*location.Location, error) {
 	args := m.Called(ctx, workspaceID, shortCode)
 	if args.Get(0) == nil {
@@ -1091,6 +1169,19 @@ func (m *MockContainerRepository) Search(ctx context.Context, workspaceID uuid.U
 	return args.Get(0).([]*container.Container), args.Error(1)
 }
 
+func (m *MockContainerRepository) FindContents(ctx context.Context, workspaceID, containerID uuid.UUID, pagination shared.Pagination) ([]container.ContentsEntry, error) {
+	args := m.Called(ctx, workspaceID, containerID, pagination)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]container.ContentsEntry), args.Error(1)
+}
+
+func (m *MockContainerRepository) GetContentsSummary(ctx context.Context, workspaceID, containerID uuid.UUID) (container.ContentsSummary, error) {
+	args := m.Called(ctx, workspaceID, containerID)
+	return args.Get(0).(container.ContentsSummary), args.Error(1)
+}
+
 func (m *MockContainerRepository) FindByLocation(ctx context.Context, workspaceID, locationID uuid.UUID) ([]*container.Container, error) {
 	args := m.Called(ctx, workspaceID, locationID)
 	if args.Get(0) == nil {
@@ -1156,6 +1247,16 @@ func (m *MockCategoryRepository) HasChildren(ctx context.Context, workspaceID, p
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *MockCategoryRepository) MoveItems(ctx context.Context, workspaceID, fromCategoryID, toCategoryID uuid.UUID) (int, error) {
+	args := m.Called(ctx, fromCategoryID, toCategoryID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockCategoryRepository) MoveChildren(ctx context.Context, workspaceID, fromCategoryID, toCategoryID uuid.UUID) (int, error) {
+	args := m.Called(ctx, fromCategoryID, toCategoryID)
+	return args.Int(0), args.Error(1)
+}
+
 // MockLabelRepository is a mock implementation of the label.Repository interface
 type MockLabelRepository struct {
 	mock.Mock
@@ -1290,7 +1391,7 @@ func TestProcessBatch_ItemUpdate_Success(t *testing.T) {
 		itemID, workspaceID, "SKU-001", "Original Name",
 		nil, nil, nil, nil, nil, nil, nil, nil,
 		ptrBool(false), ptrBool(false), ptrBool(false), nil, nil,
-		5, "SHORT1", nil, nil, ptrBool(false), now, now,
+		5, "SHORT1", nil, nil, ptrBool(false), true, now, now,
 	)
 
 	mockItemRepo.On("FindByID", ctx, itemID, workspaceID).Return(existingItem, nil)
@@ -1370,7 +1471,7 @@ func TestProcessBatch_ItemUpdate_Conflict(t *testing.T) {
 		itemID, workspaceID, "SKU-001", "Server Name",
 		nil, nil, nil, nil, nil, nil, nil, nil,
 		ptrBool(false), ptrBool(false), ptrBool(false), nil, nil,
-		5, "SHORT1", nil, nil, ptrBool(false), serverTime, serverTime,
+		5, "SHORT1", nil, nil, ptrBool(false), true, serverTime, serverTime,
 	)
 
 	mockItemRepo.On("FindByID", ctx, itemID, workspaceID).Return(existingItem, nil)
@@ -1421,7 +1522,7 @@ func TestProcessBatch_ItemUpdate_Conflict_ServerDataHasRealFields(t *testing.T)
 		itemID, workspaceID, "SKU-001", "Server Name",
 		nil, nil, nil, nil, nil, nil, nil, nil,
 		ptrBool(false), ptrBool(false), ptrBool(false), nil, nil,
-		5, "SHORT1", nil, nil, ptrBool(false), serverTime, serverTime,
+		5, "SHORT1", nil, nil, ptrBool(false), true, serverTime, serverTime,
 	)
 
 	mockItemRepo.On("FindByID", ctx, itemID, workspaceID).Return(existingItem, nil)
@@ -1521,7 +1622,7 @@ func TestProcessBatch_ItemUpdate_InvalidData(t *testing.T) {
 		itemID, workspaceID, "SKU-001", "Original Name",
 		nil, nil, nil, nil, nil, nil, nil, nil,
 		ptrBool(false), ptrBool(false), ptrBool(false), nil, nil,
-		5, "SHORT1", nil, nil, ptrBool(false), now, now,
+		5, "SHORT1", nil, nil, ptrBool(false), true, now, now,
 	)
 
 	mockItemRepo.On("FindByID", ctx, itemID, workspaceID).Return(existingItem, nil)
@@ -1562,7 +1663,7 @@ func TestProcessBatch_ItemDelete_Success(t *testing.T) {
 		itemID, workspaceID, "SKU-001", "Test Item",
 		nil, nil, nil, nil, nil, nil, nil, nil,
 		ptrBool(false), ptrBool(false), ptrBool(false), nil, nil,
-		5, "SHORT1", nil, nil, ptrBool(false), now, now,
+		5, "SHORT1", nil, nil, ptrBool(false), true, now, now,
 	)
 
 	mockItemRepo.On("FindByID", ctx, itemID, workspaceID).Return(existingItem, nil)
@@ -2065,7 +2166,7 @@ func TestProcessBatch_MixedOperations_PartialSuccess(t *testing.T) {
 		itemID, workspaceID, "SKU-001", "Original Item",
 		nil, nil, nil, nil, nil, nil, nil, nil,
 		ptrBool(false), ptrBool(false), ptrBool(false), nil, nil,
-		5, "SHORT1", nil, nil, ptrBool(false), now, now,
+		5, "SHORT1", nil, nil, ptrBool(false), true, now, now,
 	)
 	mockItemRepo.On("FindByID", ctx, itemID, workspaceID).Return(existingItem, nil)
 	mockItemRepo.On("Save", ctx, mock.AnythingOfType("*item.Item")).Return(nil)
@@ -2120,7 +2221,7 @@ func TestProcessBatch_MixedOperations_AllSuccess(t *testing.T) {
 		itemID, workspaceID, "SKU-001", "Original Item",
 		nil, nil, nil, nil, nil, nil, nil, nil,
 		ptrBool(false), ptrBool(false), ptrBool(false), nil, nil,
-		5, "SHORT1", nil, nil, ptrBool(false), now, now,
+		5, "SHORT1", nil, nil, ptrBool(false), true, now, now,
 	)
 	existingLocation := location.Reconstruct(
 		locationID, workspaceID, "Original Room", nil, nil, "LOC001", false, now, now,
@@ -2223,7 +2324,7 @@ func TestProcessBatch_MixedOperations_WithConflicts(t *testing.T) {
 		itemID, workspaceID, "SKU-001", "Server Item",
 		nil, nil, nil, nil, nil, nil, nil, nil,
 		ptrBool(false), ptrBool(false), ptrBool(false), nil, nil,
-		5, "SHORT1", nil, nil, ptrBool(false), serverTime, serverTime,
+		5, "SHORT1", nil, nil, ptrBool(false), true, serverTime, serverTime,
 	)
 	existingLocation := location.Reconstruct(
 		locationID, workspaceID, "Server Room", nil, nil, "LOC001", false, serverTime, serverTime,
@@ -2281,11 +2382,11 @@ func TestProcessBatch_LargeBatch_AllSuccess(t *testing.T) {
 	id1, id2, id3, id4, id5 := uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()
 
 	// Set up mocks for each
-	item1 := item.Reconstruct(id1, workspaceID, "SKU-001", "Item 1", nil, nil, nil, nil, nil, nil, nil, nil, ptrBool(false), ptrBool(false), ptrBool(false), nil, nil, 5, "SHORT1", nil, nil, ptrBool(false), now, now)
-	item2 := item.Reconstruct(id2, workspaceID, "SKU-002", "Item 2", nil, nil, nil, nil, nil, nil, nil, nil, ptrBool(false), ptrBool(false), ptrBool(false), nil, nil, 5, "SHORT2", nil, nil, ptrBool(false), now, now)
-	item3 := item.Reconstruct(id3, workspaceID, "SKU-003", "Item 3", nil, nil, nil, nil, nil, nil, nil, nil, ptrBool(false), ptrBool(false), ptrBool(false), nil, nil, 5, "SHORT3", nil, nil, ptrBool(false), now, now)
-	item4 := item.Reconstruct(id4, workspaceID, "SKU-004", "Item 4", nil, nil, nil, nil, nil, nil, nil, nil, ptrBool(false), ptrBool(false), ptrBool(false), nil, nil, 5, "SHORT4", nil, nil, ptrBool(false), now, now)
-	item5 := item.Reconstruct(id5, workspaceID, "SKU-005", "Item 5", nil, nil, nil, nil, nil, nil, nil, nil, ptrBool(false), ptrBool(false), ptrBool(false), nil, nil, 5, "SHORT5", nil, nil, ptrBool(false), now, now)
+	item1 := item.Reconstruct(id1, workspaceID, "SKU-001", "Item 1", nil, nil, nil, nil, nil, nil, nil, nil, ptrBool(false), ptrBool(false), ptrBool(false), nil, nil, 5, "SHORT1", nil, nil, ptrBool(false), true, now, now)
+	item2 := item.Reconstruct(id2, workspaceID, "SKU-002", "Item 2", nil, nil, nil, nil, nil, nil, nil, nil, ptrBool(false), ptrBool(false), ptrBool(false), nil, nil, 5, "SHORT2", nil, nil, ptrBool(false), true, now, now)
+	item3 := item.Reconstruct(id3, workspaceID, "SKU-003", "Item 3", nil, nil, nil, nil, nil, nil, nil, nil, ptrBool(false), ptrBool(false), ptrBool(false), nil, nil, 5, "SHORT3", nil, nil, ptrBool(false), true, now, now)
+	item4 := item.Reconstruct(id4, workspaceID, "SKU-004", "Item 4", nil, nil, nil, nil, nil, nil, nil, nil, ptrBool(false), ptrBool(false), ptrBool(false), nil, nil, 5, "SHORT4", nil, nil, ptrBool(false), true, now, now)
+	item5 := item.Reconstruct(id5, workspaceID, "SKU-005", "Item 5", nil, nil, nil, nil, nil, nil, nil, nil, ptrBool(false), ptrBool(false), ptrBool(false), nil, nil, 5, "SHORT5", nil, nil, ptrBool(false), true, now, now)
 
 	mockItemRepo.On("FindByID", ctx, id1, workspaceID).Return(item1, nil)
 	mockItemRepo.On("FindByID", ctx, id2, workspaceID).Return(item2, nil)
@@ -2359,7 +2460,7 @@ func TestProcessBatch_NilUpdatedAt_NoConflictCheck(t *testing.T) {
 		itemID, workspaceID, "SKU-001", "Original Name",
 		nil, nil, nil, nil, nil, nil, nil, nil,
 		ptrBool(false), ptrBool(false), ptrBool(false), nil, nil,
-		5, "SHORT1", nil, nil, ptrBool(false), now, now,
+		5, "SHORT1", nil, nil, ptrBool(false), true, now, now,
 	)
 
 	mockItemRepo.On("FindByID", ctx, itemID, workspaceID).Return(existingItem, nil)
@@ -2400,7 +2501,7 @@ func TestProcessBatch_UpdateFailure(t *testing.T) {
 		itemID, workspaceID, "SKU-001", "Original Name",
 		nil, nil, nil, nil, nil, nil, nil, nil,
 		ptrBool(false), ptrBool(false), ptrBool(false), nil, nil,
-		5, "SHORT1", nil, nil, ptrBool(false), now, now,
+		5, "SHORT1", nil, nil, ptrBool(false), true, now, now,
 	)
 
 	mockItemRepo.On("FindByID", ctx, itemID, workspaceID).Return(existingItem, nil)
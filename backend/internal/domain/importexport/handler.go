@@ -1,14 +1,19 @@
 package importexport
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
+	"errors"
 	"fmt"
 	"net/http"
 
 	"github.com/danielgtaylor/huma/v2"
+	"github.com/google/uuid"
 
 	appMiddleware "github.com/antti/home-warehouse/go-backend/internal/api/middleware"
+	"github.com/antti/home-warehouse/go-backend/internal/infra/events"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
 )
 
 // maxImportPayloadBytes caps the size of the base64-encoded import payload
@@ -33,8 +38,9 @@ func requireAdminRole(ctx context.Context) error {
 
 // Handler handles import/export HTTP requests
 type Handler struct {
-	svc       ServiceInterface
-	backupSvc *WorkspaceBackupService
+	svc         ServiceInterface
+	backupSvc   *WorkspaceBackupService
+	broadcaster *events.Broadcaster
 }
 
 // NewHandler creates a new import/export handler
@@ -45,6 +51,12 @@ func NewHandler(svc ServiceInterface, backupSvc *WorkspaceBackupService) *Handle
 	}
 }
 
+// SetBroadcaster wires SSE publishing for item transfers, which also records
+// them in both workspaces' activity logs. Optional.
+func (h *Handler) SetBroadcaster(broadcaster *events.Broadcaster) {
+	h.broadcaster = broadcaster
+}
+
 // ExportRequest is the input for export
 type ExportRequest struct {
 	EntityType      string `path:"entity_type" doc:"Entity type to export (item, location, container, category, label, company, borrower)"`
@@ -114,6 +126,45 @@ func (h *Handler) RegisterRoutes(api huma.API) {
 		Tags:          []string{tagImportExport, "Workspace Backup"},
 		DefaultStatus: http.StatusOK,
 	}, h.ImportWorkspaceFull)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "preview-import-workspace",
+		Method:        http.MethodPost,
+		Path:          "/import/workspace/preview",
+		Summary:       "Preview workspace import",
+		Description:   "Dry-runs a workspace backup import without writing anything. Reports per entity type how many records are new and how many match an existing record by SKU, short code or name.",
+		Tags:          []string{tagImportExport, "Workspace Backup"},
+		DefaultStatus: http.StatusOK,
+	}, h.PreviewImportWorkspace)
+
+	huma.Register(api, huma.Operation{
+		OperationID: "export-item-bundle",
+		Method:      http.MethodGet,
+		Path:        "/export/items/{item_id}",
+		Summary:     "Export item bundle",
+		Description: "Exports a single item with its inventory records and photo metadata as a self-contained JSON bundle.",
+		Tags:        []string{tagImportExport},
+	}, h.ExportItemBundle)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "import-item-bundle",
+		Method:        http.MethodPost,
+		Path:          "/import/item-bundle",
+		Summary:       "Import item bundle",
+		Description:   "Recreates a bundled item in this workspace with new IDs. Category, location and container references are matched by name; set create_missing to create the ones that do not exist. Photo files are copied into the new item; photos whose file no longer exists, or lies in a workspace you are not a member of, are skipped and listed in skipped_photos.",
+		Tags:          []string{tagImportExport},
+		DefaultStatus: http.StatusCreated,
+	}, h.ImportItemBundle)
+
+	huma.Register(api, huma.Operation{
+		OperationID:   "transfer-item",
+		Method:        http.MethodPost,
+		Path:          "/items/{item_id}/transfer",
+		Summary:       "Transfer item to another workspace",
+		Description:   "Moves an item with its inventory and photos to another workspace. The item is recreated there with new IDs, creating missing categories, locations and containers by name, and archived here. Requires owner or admin in both workspaces.",
+		Tags:          []string{tagImportExport},
+		DefaultStatus: http.StatusOK,
+	}, h.TransferItem)
 }
 
 // Export handles the export request
@@ -300,3 +351,190 @@ func (h *Handler) ImportWorkspaceFull(ctx context.Context, input *WorkspaceImpor
 
 	return &ImportResponse{Body: *result}, nil
 }
+
+// ImportPlanResponse is the response for a workspace import preview
+type ImportPlanResponse struct {
+	Body ImportPlan
+}
+
+// PreviewImportWorkspace handles the dry-run of a full workspace backup
+// import. It takes the same payload as ImportWorkspaceFull so clients can
+// preview and then confirm with one request body.
+func (h *Handler) PreviewImportWorkspace(ctx context.Context, input *WorkspaceImportRequest) (*ImportPlanResponse, error) {
+	workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+	if !ok {
+		return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+	}
+
+	if err := requireAdminRole(ctx); err != nil {
+		return nil, err
+	}
+
+	format := Format(input.Body.Format)
+	if format != FormatExcel && format != FormatJSON {
+		return nil, huma.Error400BadRequest(fmt.Sprintf("invalid format: %s. Supported formats: xlsx, json", input.Body.Format))
+	}
+
+	if len(input.Body.Data) > maxImportPayloadBytes {
+		return nil, huma.NewError(http.StatusRequestEntityTooLarge, "import payload too large")
+	}
+
+	data, err := base64.StdEncoding.DecodeString(input.Body.Data)
+	if err != nil {
+		return nil, huma.Error400BadRequest("invalid base64 encoding in data field")
+	}
+
+	if len(data) == 0 {
+		return nil, huma.Error400BadRequest("data field is empty")
+	}
+
+	plan, err := h.backupSvc.PreviewImportWorkspace(ctx, workspaceID, bytes.NewReader(data))
+	if err != nil {
+		return nil, huma.Error500InternalServerError("failed to preview workspace import", err)
+	}
+
+	return &ImportPlanResponse{Body: *plan}, nil
+}
+
+// ExportItemBundleRequest is the input for single-item bundle export
+type ExportItemBundleRequest struct {
+	ItemID uuid.UUID `path:"item_id" doc:"Item ID"`
+}
+
+// ExportItemBundleResponse is the response for single-item bundle export
+type ExportItemBundleResponse struct {
+	Body *ItemBundle
+}
+
+// ExportItemBundle exports one item as a JSON bundle
+func (h *Handler) ExportItemBundle(ctx context.Context, input *ExportItemBundleRequest) (*ExportItemBundleResponse, error) {
+	workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+	if !ok {
+		return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+	}
+
+	bundle, err := h.svc.ExportItem(ctx, workspaceID, input.ItemID)
+	if err != nil {
+		if shared.IsNotFound(err) {
+			return nil, huma.Error404NotFound("item not found")
+		}
+		return nil, huma.Error500InternalServerError("failed to export item", err)
+	}
+
+	return &ExportItemBundleResponse{Body: bundle}, nil
+}
+
+// ImportItemBundleRequest is the input for single-item bundle import
+type ImportItemBundleRequest struct {
+	Body struct {
+		Bundle        ItemBundle `json:"bundle" doc:"Item bundle as returned by the item bundle export"`
+		CreateMissing bool       `json:"create_missing,omitempty" doc:"Create categories, locations and containers missing in this workspace"`
+	}
+}
+
+// ImportItemBundleResponse is the response for single-item bundle import
+type ImportItemBundleResponse struct {
+	Body *ItemImportResult
+}
+
+// ImportItemBundle recreates a bundled item in the current workspace
+func (h *Handler) ImportItemBundle(ctx context.Context, input *ImportItemBundleRequest) (*ImportItemBundleResponse, error) {
+	workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+	if !ok {
+		return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+	}
+
+	if err := requireAdminRole(ctx); err != nil {
+		return nil, err
+	}
+	authUser, ok := appMiddleware.GetAuthUser(ctx)
+	if !ok {
+		return nil, huma.Error401Unauthorized("not authenticated")
+	}
+
+	result, err := h.svc.ImportItem(ctx, workspaceID, &input.Body.Bundle, ItemImportOptions{
+		CreateMissing: input.Body.CreateMissing,
+		UserID:        authUser.ID,
+	})
+	if err != nil {
+		if shared.IsInvalidInput(err) {
+			return nil, appMiddleware.MapDomainError(err)
+		}
+		return nil, huma.Error500InternalServerError("failed to import item", err)
+	}
+
+	return &ImportItemBundleResponse{Body: result}, nil
+}
+
+// TransferItemRequest is the input for moving an item to another workspace
+type TransferItemRequest struct {
+	ItemID uuid.UUID `path:"item_id" doc:"Item ID"`
+	Body   struct {
+		TargetWorkspaceID uuid.UUID `json:"target_workspace_id" doc:"Workspace to move the item to"`
+	}
+}
+
+// TransferItemResponse is the response for an item transfer
+type TransferItemResponse struct {
+	Body *ItemImportResult
+}
+
+// TransferItem moves an item from the current workspace to another one
+func (h *Handler) TransferItem(ctx context.Context, input *TransferItemRequest) (*TransferItemResponse, error) {
+	workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+	if !ok {
+		return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+	}
+
+	// The service checks the target workspace; this fails fast on the source.
+	if err := requireAdminRole(ctx); err != nil {
+		return nil, err
+	}
+	authUser, ok := appMiddleware.GetAuthUser(ctx)
+	if !ok {
+		return nil, huma.Error401Unauthorized("not authenticated")
+	}
+
+	targetWorkspaceID := input.Body.TargetWorkspaceID
+	result, err := h.svc.TransferItemToWorkspace(ctx, workspaceID, input.ItemID, targetWorkspaceID, authUser.ID)
+	if err != nil {
+		if shared.IsNotFound(err) {
+			return nil, huma.Error404NotFound("item not found")
+		}
+		if shared.IsInvalidInput(err) || errors.Is(err, shared.ErrForbidden) {
+			return nil, appMiddleware.MapDomainError(err)
+		}
+		return nil, huma.Error500InternalServerError("failed to transfer item", err)
+	}
+
+	// One event per workspace; the activity tap turns each into a log entry.
+	if h.broadcaster != nil {
+		userName := appMiddleware.GetUserDisplayName(ctx)
+		h.broadcaster.Publish(workspaceID, events.Event{
+			Type:       "item.deleted",
+			EntityID:   input.ItemID.String(),
+			EntityType: "item",
+			UserID:     authUser.ID,
+			Data: map[string]any{
+				"name":                        result.ItemName,
+				"transferred_to_workspace_id": targetWorkspaceID,
+				"transferred_to_item_id":      result.ItemID,
+				"user_name":                   userName,
+			},
+		})
+		h.broadcaster.Publish(targetWorkspaceID, events.Event{
+			Type:       "item.created",
+			EntityID:   result.ItemID.String(),
+			EntityType: "item",
+			UserID:     authUser.ID,
+			Data: map[string]any{
+				"name":                          result.ItemName,
+				"transferred_from_workspace_id": workspaceID,
+				"transferred_from_item_id":      input.ItemID,
+				"user_name":                     userName,
+			},
+		})
+	}
+
+	return &TransferItemResponse{Body: result}, nil
+}
@@ -5,6 +5,8 @@ import (
 	"net/http"
 	"testing"
 
+	"github.com/google/uuid"
+
 	"github.com/antti/home-warehouse/go-backend/internal/domain/importexport"
 	"github.com/antti/home-warehouse/go-backend/internal/testutil"
 )
@@ -30,6 +32,21 @@ func TestImportExportHandler_MemberForbidden(t *testing.T) {
 		testutil.AssertStatus(t, rec, http.StatusForbidden)
 	})
 
+	t.Run("workspace import preview is forbidden", func(t *testing.T) {
+		rec := setup.Post("/import/workspace/preview", `{"format":"json","data":"`+payload+`"}`)
+		testutil.AssertStatus(t, rec, http.StatusForbidden)
+	})
+
+	t.Run("item bundle import is forbidden", func(t *testing.T) {
+		rec := setup.Post("/import/item-bundle", `{"bundle":{"version":1,"item":{"name":"Widget"}}}`)
+		testutil.AssertStatus(t, rec, http.StatusForbidden)
+	})
+
+	t.Run("item transfer is forbidden", func(t *testing.T) {
+		rec := setup.Post("/items/"+uuid.NewString()+"/transfer", `{"target_workspace_id":"`+uuid.NewString()+`"}`)
+		testutil.AssertStatus(t, rec, http.StatusForbidden)
+	})
+
 	t.Run("workspace export is forbidden", func(t *testing.T) {
 		rec := setup.Get("/export/workspace")
 		testutil.AssertStatus(t, rec, http.StatusForbidden)
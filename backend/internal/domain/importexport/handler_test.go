@@ -9,9 +9,11 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
 	"github.com/antti/home-warehouse/go-backend/internal/domain/importexport"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
 	"github.com/antti/home-warehouse/go-backend/internal/testutil"
 )
 
@@ -36,6 +38,30 @@ func (m *MockService) Import(ctx context.Context, workspaceID uuid.UUID, entityT
 	return args.Get(0).(*importexport.ImportResult), args.Error(1)
 }
 
+func (m *MockService) ExportItem(ctx context.Context, workspaceID, itemID uuid.UUID) (*importexport.ItemBundle, error) {
+	args := m.Called(ctx, workspaceID, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*importexport.ItemBundle), args.Error(1)
+}
+
+func (m *MockService) ImportItem(ctx context.Context, targetWorkspaceID uuid.UUID, bundle *importexport.ItemBundle, opts importexport.ItemImportOptions) (*importexport.ItemImportResult, error) {
+	args := m.Called(ctx, targetWorkspaceID, bundle, opts)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*importexport.ItemImportResult), args.Error(1)
+}
+
+func (m *MockService) TransferItemToWorkspace(ctx context.Context, srcWorkspaceID, itemID, dstWorkspaceID, userID uuid.UUID) (*importexport.ItemImportResult, error) {
+	args := m.Called(ctx, srcWorkspaceID, itemID, dstWorkspaceID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*importexport.ItemImportResult), args.Error(1)
+}
+
 // Tests
 
 func TestImportExportHandler_Export(t *testing.T) {
@@ -434,3 +460,144 @@ func TestImportExportHandler_WorkspaceImport_Validation(t *testing.T) {
 		testutil.AssertStatus(t, rec, http.StatusBadRequest)
 	})
 }
+
+func TestImportExportHandler_WorkspaceImportPreview_Validation(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	// Pass nil for backupSvc - validation happens before it's used
+	handler := importexport.NewHandler(mockSvc, nil)
+	handler.RegisterRoutes(setup.API)
+
+	t.Run("returns 400 for invalid format", func(t *testing.T) {
+		body := `{
+			"format": "csv",
+			"data": "dGVzdA=="
+		}`
+
+		rec := setup.Post("/import/workspace/preview", body)
+
+		testutil.AssertStatus(t, rec, http.StatusBadRequest)
+	})
+
+	t.Run("returns 400 for empty data", func(t *testing.T) {
+		body := `{
+			"format": "json",
+			"data": ""
+		}`
+
+		rec := setup.Post("/import/workspace/preview", body)
+
+		testutil.AssertStatus(t, rec, http.StatusBadRequest)
+	})
+}
+
+func TestImportExportHandler_ExportItemBundle(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	handler := importexport.NewHandler(mockSvc, nil)
+	handler.RegisterRoutes(setup.API)
+
+	t.Run("exports item bundle", func(t *testing.T) {
+		itemID := uuid.New()
+		bundle := &importexport.ItemBundle{
+			Version:           importexport.ItemBundleVersion,
+			SourceWorkspaceID: setup.WorkspaceID,
+			Item:              importexport.ItemBundleItem{ID: itemID, Name: "Drill"},
+		}
+
+		mockSvc.On("ExportItem", mock.Anything, setup.WorkspaceID, itemID).
+			Return(bundle, nil).Once()
+
+		rec := setup.Get(fmt.Sprintf("/export/items/%s", itemID))
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("returns 404 for unknown item", func(t *testing.T) {
+		itemID := uuid.New()
+
+		mockSvc.On("ExportItem", mock.Anything, setup.WorkspaceID, itemID).
+			Return(nil, shared.ErrNotFound).Once()
+
+		rec := setup.Get(fmt.Sprintf("/export/items/%s", itemID))
+
+		testutil.AssertStatus(t, rec, http.StatusNotFound)
+		mockSvc.AssertExpectations(t)
+	})
+}
+
+func TestImportExportHandler_ImportItemBundle(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	handler := importexport.NewHandler(mockSvc, nil)
+	handler.RegisterRoutes(setup.API)
+
+	t.Run("imports item bundle", func(t *testing.T) {
+		result := &importexport.ItemImportResult{ItemID: uuid.New()}
+
+		mockSvc.On("ImportItem", mock.Anything, setup.WorkspaceID, mock.MatchedBy(func(b *importexport.ItemBundle) bool {
+			return b.Item.Name == "Drill"
+		}), importexport.ItemImportOptions{CreateMissing: true, UserID: setup.UserID}).
+			Return(result, nil).Once()
+
+		rec := setup.Post("/import/item-bundle", `{"bundle":{"version":1,"item":{"name":"Drill"}},"create_missing":true}`)
+
+		testutil.AssertStatus(t, rec, http.StatusCreated)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("returns 400 for invalid bundle", func(t *testing.T) {
+		mockSvc.On("ImportItem", mock.Anything, setup.WorkspaceID, mock.Anything, importexport.ItemImportOptions{UserID: setup.UserID}).
+			Return(nil, shared.NewFieldError(shared.ErrInvalidInput, "version", "unsupported bundle version 2")).Once()
+
+		rec := setup.Post("/import/item-bundle", `{"bundle":{"version":2,"item":{"name":"Drill"}}}`)
+
+		testutil.AssertStatus(t, rec, http.StatusBadRequest)
+		mockSvc.AssertExpectations(t)
+	})
+}
+
+func TestImportExportHandler_TransferItem(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	handler := importexport.NewHandler(mockSvc, nil)
+	handler.RegisterRoutes(setup.API)
+
+	itemID := uuid.New()
+	targetWS := uuid.New()
+	body := `{"target_workspace_id":"` + targetWS.String() + `"}`
+
+	t.Run("transfers item", func(t *testing.T) {
+		result := &importexport.ItemImportResult{ItemID: uuid.New(), ItemName: "Drill"}
+		mockSvc.On("TransferItemToWorkspace", mock.Anything, setup.WorkspaceID, itemID, targetWS, setup.UserID).
+			Return(result, nil).Once()
+
+		rec := setup.Post("/items/"+itemID.String()+"/transfer", body)
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		got := testutil.ParseJSONResponse[importexport.ItemImportResult](t, rec)
+		assert.Equal(t, result.ItemID, got.ItemID)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("returns 403 when not admin of the target workspace", func(t *testing.T) {
+		mockSvc.On("TransferItemToWorkspace", mock.Anything, setup.WorkspaceID, itemID, targetWS, setup.UserID).
+			Return(nil, importexport.ErrTransferForbidden).Once()
+
+		rec := setup.Post("/items/"+itemID.String()+"/transfer", body)
+
+		testutil.AssertStatus(t, rec, http.StatusForbidden)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("returns 404 for unknown item", func(t *testing.T) {
+		mockSvc.On("TransferItemToWorkspace", mock.Anything, setup.WorkspaceID, itemID, targetWS, setup.UserID).
+			Return(nil, shared.ErrNotFound).Once()
+
+		rec := setup.Post("/items/"+itemID.String()+"/transfer", body)
+
+		testutil.AssertStatus(t, rec, http.StatusNotFound)
+		mockSvc.AssertExpectations(t)
+	})
+}
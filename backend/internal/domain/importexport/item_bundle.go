@@ -0,0 +1,724 @@
+package importexport
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/inventory"
+	"github.com/antti/home-warehouse/go-backend/internal/infra/queries"
+	"github.com/antti/home-warehouse/go-backend/internal/infra/storage"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// ItemBundleVersion is the current item bundle format version. ImportItem
+// rejects bundles with any other version.
+const ItemBundleVersion = 1
+
+// ErrPhotoCopyUnavailable is returned when a bundle with photos is imported
+// but no PhotoStore is wired to copy their files.
+var ErrPhotoCopyUnavailable = shared.NewDomainError(shared.ErrInternal, "photo copying is not configured")
+
+// PhotoStore reads and writes photo files by storage path. It is the subset of
+// storage.Storage ImportItem needs to copy bundle photos.
+type PhotoStore interface {
+	Get(ctx context.Context, path string) (io.ReadCloser, error)
+	Save(ctx context.Context, workspaceID, itemID, filename string, reader io.Reader) (string, error)
+	Delete(ctx context.Context, path string) error
+}
+
+// SetPhotoStorage wires the store ImportItem copies bundle photo files with.
+// Optional - without it bundles with photos can only be imported with
+// KeepStoragePaths.
+func (s *Service) SetPhotoStorage(store PhotoStore) {
+	s.photos = store
+}
+
+// ItemBundle is a self-contained JSON snapshot of a single item, its inventory
+// records and its photo metadata. Cross-entity references are carried by name
+// (category, location, container) so the bundle can be recreated in another
+// workspace; the original IDs are informational only and never reused.
+type ItemBundle struct {
+	Version           int                   `json:"version"`
+	SourceWorkspaceID uuid.UUID             `json:"source_workspace_id" required:"false"`
+	ExportedAt        time.Time             `json:"exported_at" required:"false"`
+	Item              ItemBundleItem        `json:"item"`
+	Inventory         []ItemBundleInventory `json:"inventory" required:"false"`
+	Photos            []ItemBundlePhoto     `json:"photos" required:"false"`
+}
+
+// ItemBundleItem holds the item's own fields.
+type ItemBundleItem struct {
+	ID               uuid.UUID `json:"id" required:"false"`
+	SKU              string    `json:"sku" required:"false"`
+	Name             string    `json:"name"`
+	Description      string    `json:"description,omitempty"`
+	CategoryName     string    `json:"category_name,omitempty"`
+	Brand            string    `json:"brand,omitempty"`
+	Model            string    `json:"model,omitempty"`
+	SerialNumber     string    `json:"serial_number,omitempty"`
+	Manufacturer     string    `json:"manufacturer,omitempty"`
+	Barcode          string    `json:"barcode,omitempty"`
+	IsInsured        bool      `json:"is_insured" required:"false"`
+	LifetimeWarranty bool      `json:"lifetime_warranty" required:"false"`
+	WarrantyDetails  string    `json:"warranty_details,omitempty"`
+	MinStockLevel    int32     `json:"min_stock_level" required:"false"`
+}
+
+// ItemBundleInventory is one inventory record of the bundled item.
+type ItemBundleInventory struct {
+	ID              uuid.UUID `json:"id" required:"false"`
+	LocationName    string    `json:"location_name"`
+	ContainerName   string    `json:"container_name,omitempty"`
+	Quantity        int32     `json:"quantity"`
+	Condition       string    `json:"condition,omitempty"`
+	Status          string    `json:"status,omitempty"`
+	DateAcquired    string    `json:"date_acquired,omitempty"`
+	PurchasePrice   *int32    `json:"purchase_price,omitempty"`
+	CurrencyCode    string    `json:"currency_code,omitempty"`
+	WarrantyExpires string    `json:"warranty_expires,omitempty"`
+	ExpirationDate  string    `json:"expiration_date,omitempty"`
+	Notes           string    `json:"notes,omitempty"`
+}
+
+// ItemBundlePhoto is the metadata of one photo of the bundled item. The file
+// itself is not embedded; StoragePath points at the source object, which
+// ImportItem copies into the new item's storage.
+type ItemBundlePhoto struct {
+	ID           uuid.UUID `json:"id" required:"false"`
+	Filename     string    `json:"filename"`
+	StoragePath  string    `json:"storage_path"`
+	FileSize     int64     `json:"file_size" required:"false"`
+	MimeType     string    `json:"mime_type" required:"false"`
+	Width        int32     `json:"width" required:"false"`
+	Height       int32     `json:"height" required:"false"`
+	DisplayOrder int32     `json:"display_order" required:"false"`
+	IsPrimary    bool      `json:"is_primary" required:"false"`
+	Caption      string    `json:"caption,omitempty"`
+}
+
+// ItemImportOptions controls how ImportItem resolves name references.
+type ItemImportOptions struct {
+	// CreateMissing creates categories, locations and containers that do not
+	// exist in the target workspace. When false, an unresolved name fails the
+	// import before anything is written.
+	CreateMissing bool
+	// KeepStoragePaths points the new photo rows at the bundle's own storage
+	// paths instead of copying the files. Only for transfers, which remove
+	// the source rows in the same step.
+	KeepStoragePaths bool
+	// UserID is the importing user. Photo files in a workspace other than
+	// the target are only copied when this user is a member of it.
+	UserID uuid.UUID
+}
+
+// ItemImportResult reports what ImportItem created.
+type ItemImportResult struct {
+	ItemID            uuid.UUID   `json:"item_id"`
+	ItemName          string      `json:"item_name"`
+	InventoryIDs      []uuid.UUID `json:"inventory_ids"`
+	PhotoIDs          []uuid.UUID `json:"photo_ids"`
+	CreatedCategories []string    `json:"created_categories"`
+	CreatedLocations  []string    `json:"created_locations"`
+	CreatedContainers []string    `json:"created_containers"`
+	// SkippedPhotos are the filenames of bundled photos whose file could not
+	// be found, so no photo was created for them.
+	SkippedPhotos []string `json:"skipped_photos"`
+}
+
+// ExportItem builds a self-contained bundle of one item with its inventory
+// records and photo metadata.
+func (s *Service) ExportItem(ctx context.Context, workspaceID, itemID uuid.UUID) (*ItemBundle, error) {
+	itm, err := s.repo.GetItem(ctx, workspaceID, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	bundle := &ItemBundle{
+		Version:           ItemBundleVersion,
+		SourceWorkspaceID: workspaceID,
+		ExportedAt:        time.Now().UTC(),
+		Item: ItemBundleItem{
+			ID:               itm.ID,
+			SKU:              itm.Sku,
+			Name:             itm.Name,
+			Description:      ptrToString(itm.Description),
+			Brand:            ptrToString(itm.Brand),
+			Model:            ptrToString(itm.Model),
+			SerialNumber:     ptrToString(itm.SerialNumber),
+			Manufacturer:     ptrToString(itm.Manufacturer),
+			Barcode:          ptrToString(itm.Barcode),
+			IsInsured:        itm.IsInsured,
+			LifetimeWarranty: ptrToBool(itm.LifetimeWarranty),
+			WarrantyDetails:  ptrToString(itm.WarrantyDetails),
+			MinStockLevel:    itm.MinStockLevel,
+		},
+		Inventory: make([]ItemBundleInventory, 0),
+		Photos:    make([]ItemBundlePhoto, 0),
+	}
+
+	if itm.CategoryID.Valid {
+		cat, err := s.repo.GetCategory(ctx, workspaceID, uuid.UUID(itm.CategoryID.Bytes))
+		if err != nil {
+			return nil, fmt.Errorf("failed to load category: %w", err)
+		}
+		bundle.Item.CategoryName = cat.Name
+	}
+
+	invs, err := s.repo.ListInventoryByItem(ctx, workspaceID, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list inventory: %w", err)
+	}
+
+	locationNames := make(map[uuid.UUID]string)
+	containerNames := make(map[uuid.UUID]string)
+	for _, inv := range invs {
+		locName, ok := locationNames[inv.LocationID]
+		if !ok {
+			loc, err := s.repo.GetLocation(ctx, workspaceID, inv.LocationID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to load location: %w", err)
+			}
+			locName = loc.Name
+			locationNames[inv.LocationID] = locName
+		}
+
+		var containerName string
+		if inv.ContainerID.Valid {
+			containerID := uuid.UUID(inv.ContainerID.Bytes)
+			name, ok := containerNames[containerID]
+			if !ok {
+				con, err := s.repo.GetContainer(ctx, workspaceID, containerID)
+				if err != nil {
+					return nil, fmt.Errorf("failed to load container: %w", err)
+				}
+				name = con.Name
+				containerNames[containerID] = name
+			}
+			containerName = name
+		}
+
+		entry := ItemBundleInventory{
+			ID:              inv.ID,
+			LocationName:    locName,
+			ContainerName:   containerName,
+			Quantity:        inv.Quantity,
+			DateAcquired:    formatDate(inv.DateAcquired),
+			PurchasePrice:   inv.PurchasePrice,
+			CurrencyCode:    ptrToString(inv.CurrencyCode),
+			WarrantyExpires: formatDate(inv.WarrantyExpires),
+			ExpirationDate:  formatDate(inv.ExpirationDate),
+			Notes:           ptrToString(inv.Notes),
+		}
+		if inv.Condition.Valid {
+			entry.Condition = string(inv.Condition.WarehouseItemConditionEnum)
+		}
+		if inv.Status.Valid {
+			entry.Status = string(inv.Status.WarehouseItemStatusEnum)
+		}
+		bundle.Inventory = append(bundle.Inventory, entry)
+	}
+
+	photos, err := s.repo.ListItemPhotos(ctx, workspaceID, itemID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list photos: %w", err)
+	}
+	for _, p := range photos {
+		bundle.Photos = append(bundle.Photos, ItemBundlePhoto{
+			ID:           p.ID,
+			Filename:     p.Filename,
+			StoragePath:  p.StoragePath,
+			FileSize:     p.FileSize,
+			MimeType:     p.MimeType,
+			Width:        p.Width,
+			Height:       p.Height,
+			DisplayOrder: p.DisplayOrder,
+			IsPrimary:    p.IsPrimary,
+			Caption:      ptrToString(p.Caption),
+		})
+	}
+
+	return bundle, nil
+}
+
+// ImportItem recreates a bundled item in targetWorkspaceID with fresh IDs.
+// Category, location and container references are resolved by name in the
+// target workspace (and created when opts.CreateMissing is set). The bundle is
+// validated and every reference resolved before the first write, and the
+// writes run in one transaction, so a failed import leaves the target
+// workspace untouched.
+//
+// Photo files are copied into the new item's storage before the transaction
+// starts, and removed again if it fails. A photo whose file is missing is
+// reported in SkippedPhotos instead of failing the import.
+func (s *Service) ImportItem(ctx context.Context, targetWorkspaceID uuid.UUID, bundle *ItemBundle, opts ItemImportOptions) (*ItemImportResult, error) {
+	if err := validateItemBundle(bundle, s.pathLayouts()...); err != nil {
+		return nil, err
+	}
+
+	itemID := uuid.New()
+	photos, skipped := bundle.Photos, make([]string, 0)
+	if !opts.KeepStoragePaths {
+		var err error
+		photos, skipped, err = s.copyBundlePhotos(ctx, targetWorkspaceID, itemID, bundle, opts.UserID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var result *ItemImportResult
+	err := s.tx.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.importItemBundle(ctx, targetWorkspaceID, itemID, bundle, photos, opts)
+		return err
+	})
+	if err != nil {
+		if !opts.KeepStoragePaths {
+			s.deletePhotoCopies(ctx, photos)
+		}
+		return nil, err
+	}
+	result.SkippedPhotos = skipped
+	return result, nil
+}
+
+// copyBundlePhotos copies the bundle's photo files into itemID's storage in
+// targetWorkspaceID and returns the photos with their new storage paths,
+// along with the filenames of photos whose file is missing. Files in another
+// workspace are only read for a member of it; for anyone else they count as
+// missing, so a bundle cannot be used to copy another tenant's photos.
+func (s *Service) copyBundlePhotos(ctx context.Context, targetWorkspaceID, itemID uuid.UUID, bundle *ItemBundle, userID uuid.UUID) ([]ItemBundlePhoto, []string, error) {
+	skipped := make([]string, 0)
+	if len(bundle.Photos) == 0 {
+		return nil, skipped, nil
+	}
+	if s.photos == nil {
+		return nil, nil, ErrPhotoCopyUnavailable
+	}
+
+	readable, err := s.canReadPhotos(ctx, bundle.SourceWorkspaceID, targetWorkspaceID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !readable {
+		for _, p := range bundle.Photos {
+			skipped = append(skipped, p.Filename)
+		}
+		return nil, skipped, nil
+	}
+
+	copies := make([]ItemBundlePhoto, 0, len(bundle.Photos))
+	for _, p := range bundle.Photos {
+		targetPath, err := s.copyPhoto(ctx, targetWorkspaceID, itemID, p)
+		if errors.Is(err, storage.ErrFileNotFound) {
+			skipped = append(skipped, p.Filename)
+			continue
+		}
+		if err != nil {
+			s.deletePhotoCopies(ctx, copies)
+			return nil, nil, err
+		}
+		p.StoragePath = targetPath
+		copies = append(copies, p)
+	}
+	return copies, skipped, nil
+}
+
+// canReadPhotos reports whether userID may read photo files stored in
+// sourceWorkspaceID. The target workspace's own files are always readable:
+// the caller has already been let into it.
+func (s *Service) canReadPhotos(ctx context.Context, sourceWorkspaceID, targetWorkspaceID, userID uuid.UUID) (bool, error) {
+	if sourceWorkspaceID == targetWorkspaceID {
+		return true, nil
+	}
+	if s.roles == nil || userID == uuid.Nil {
+		return false, nil
+	}
+	if _, err := s.roles.GetUserRole(ctx, sourceWorkspaceID, userID); err != nil {
+		if shared.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// copyPhoto stores a copy of p's file under itemID and returns its path.
+func (s *Service) copyPhoto(ctx context.Context, targetWorkspaceID, itemID uuid.UUID, p ItemBundlePhoto) (string, error) {
+	src, err := s.photos.Get(ctx, p.StoragePath)
+	if err != nil {
+		return "", err
+	}
+	defer src.Close()
+
+	targetPath, err := s.photos.Save(ctx, targetWorkspaceID.String(), itemID.String(), p.Filename, src)
+	if err != nil {
+		return "", fmt.Errorf("failed to copy photo %q: %w", p.Filename, err)
+	}
+	return targetPath, nil
+}
+
+// deletePhotoCopies removes files copied for an import that did not complete.
+func (s *Service) deletePhotoCopies(ctx context.Context, copies []ItemBundlePhoto) {
+	for _, p := range copies {
+		if err := s.photos.Delete(ctx, p.StoragePath); err != nil {
+			slog.WarnContext(ctx, "failed to remove copied photo", "path", p.StoragePath, "error", err)
+		}
+	}
+}
+
+// importItemBundle writes a validated bundle into targetWorkspaceID as item
+// itemID, creating a photo row for each of photos.
+func (s *Service) importItemBundle(ctx context.Context, targetWorkspaceID, itemID uuid.UUID, bundle *ItemBundle, photos []ItemBundlePhoto, opts ItemImportOptions) (*ItemImportResult, error) {
+	plan, err := s.resolveItemBundleRefs(ctx, targetWorkspaceID, bundle, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ItemImportResult{
+		InventoryIDs:      make([]uuid.UUID, 0, len(bundle.Inventory)),
+		PhotoIDs:          make([]uuid.UUID, 0, len(bundle.Photos)),
+		CreatedCategories: make([]string, 0),
+		CreatedLocations:  make([]string, 0),
+		CreatedContainers: make([]string, 0),
+	}
+
+	// Create whatever the plan could not resolve.
+	categoryID := pgtype.UUID{}
+	if bundle.Item.CategoryName != "" {
+		if plan.categoryID == nil {
+			cat, err := s.repo.CreateCategory(ctx, queries.CreateCategoryParams{
+				ID:          uuid.New(),
+				WorkspaceID: targetWorkspaceID,
+				Name:        bundle.Item.CategoryName,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to create category %q: %w", bundle.Item.CategoryName, err)
+			}
+			plan.categoryID = &cat.ID
+			result.CreatedCategories = append(result.CreatedCategories, cat.Name)
+		}
+		categoryID = pgtype.UUID{Bytes: *plan.categoryID, Valid: true}
+	}
+
+	for _, name := range plan.missingLocations {
+		loc, err := s.repo.CreateLocation(ctx, queries.CreateLocationParams{
+			ID:          uuid.New(),
+			WorkspaceID: targetWorkspaceID,
+			Name:        name,
+			ShortCode:   generateShortCode(),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create location %q: %w", name, err)
+		}
+		plan.locations[name] = loc.ID
+		result.CreatedLocations = append(result.CreatedLocations, loc.Name)
+	}
+
+	for _, key := range plan.missingContainers {
+		con, err := s.repo.CreateContainer(ctx, queries.CreateContainerParams{
+			ID:          uuid.New(),
+			WorkspaceID: targetWorkspaceID,
+			Name:        key.container,
+			LocationID:  plan.locations[key.location],
+			ShortCode:   generateShortCode(),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create container %q: %w", key.container, err)
+		}
+		plan.containers[key] = con.ID
+		result.CreatedContainers = append(result.CreatedContainers, con.Name)
+	}
+
+	lifetimeWarranty := bundle.Item.LifetimeWarranty
+	itm, err := s.repo.CreateItem(ctx, queries.CreateItemParams{
+		ID:               itemID,
+		WorkspaceID:      targetWorkspaceID,
+		Sku:              plan.sku,
+		Name:             bundle.Item.Name,
+		Description:      stringToPtr(bundle.Item.Description),
+		CategoryID:       categoryID,
+		Brand:            stringToPtr(bundle.Item.Brand),
+		Model:            stringToPtr(bundle.Item.Model),
+		SerialNumber:     stringToPtr(bundle.Item.SerialNumber),
+		Manufacturer:     stringToPtr(bundle.Item.Manufacturer),
+		Barcode:          stringToPtr(bundle.Item.Barcode),
+		IsInsured:        bundle.Item.IsInsured,
+		LifetimeWarranty: &lifetimeWarranty,
+		WarrantyDetails:  stringToPtr(bundle.Item.WarrantyDetails),
+		MinStockLevel:    bundle.Item.MinStockLevel,
+		ShortCode:        generateShortCode(),
+		Loanable:         true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create item: %w", err)
+	}
+	result.ItemID = itm.ID
+	result.ItemName = itm.Name
+
+	for _, entry := range bundle.Inventory {
+		containerID := pgtype.UUID{}
+		if entry.ContainerName != "" {
+			containerID = pgtype.UUID{Bytes: plan.containers[containerKey{entry.LocationName, entry.ContainerName}], Valid: true}
+		}
+
+		params := queries.CreateInventoryParams{
+			ID:              uuid.New(),
+			WorkspaceID:     targetWorkspaceID,
+			ItemID:          itm.ID,
+			LocationID:      plan.locations[entry.LocationName],
+			ContainerID:     containerID,
+			Quantity:        entry.Quantity,
+			DateAcquired:    parseDateCell(entry.DateAcquired),
+			PurchasePrice:   entry.PurchasePrice,
+			CurrencyCode:    stringToPtr(entry.CurrencyCode),
+			WarrantyExpires: parseDateCell(entry.WarrantyExpires),
+			ExpirationDate:  parseDateCell(entry.ExpirationDate),
+			Notes:           stringToPtr(entry.Notes),
+		}
+		if entry.Condition != "" {
+			params.Condition = queries.NullWarehouseItemConditionEnum{WarehouseItemConditionEnum: queries.WarehouseItemConditionEnum(entry.Condition), Valid: true}
+		}
+		if entry.Status != "" {
+			params.Status = queries.NullWarehouseItemStatusEnum{WarehouseItemStatusEnum: queries.WarehouseItemStatusEnum(entry.Status), Valid: true}
+		}
+
+		inv, err := s.repo.CreateInventory(ctx, params)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create inventory: %w", err)
+		}
+		result.InventoryIDs = append(result.InventoryIDs, inv.ID)
+	}
+
+	for _, p := range photos {
+		// ThumbnailPath stays empty: thumbnails are regenerated from the
+		// original by the usual pending-thumbnail processing.
+		photo, err := s.repo.CreateItemPhoto(ctx, queries.CreateItemPhotoParams{
+			ID:           uuid.New(),
+			ItemID:       itm.ID,
+			WorkspaceID:  targetWorkspaceID,
+			Filename:     p.Filename,
+			StoragePath:  p.StoragePath,
+			FileSize:     p.FileSize,
+			MimeType:     p.MimeType,
+			Width:        p.Width,
+			Height:       p.Height,
+			DisplayOrder: p.DisplayOrder,
+			IsPrimary:    p.IsPrimary,
+			Caption:      stringToPtr(p.Caption),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create photo %q: %w", p.Filename, err)
+		}
+		result.PhotoIDs = append(result.PhotoIDs, photo.ID)
+	}
+
+	return result, nil
+}
+
+// containerKey identifies a container by name within a named location.
+type containerKey struct {
+	location  string
+	container string
+}
+
+// itemBundlePlan holds the target-workspace IDs resolved for a bundle's name
+// references, plus the names that still have to be created.
+type itemBundlePlan struct {
+	sku               string
+	categoryID        *uuid.UUID
+	locations         map[string]uuid.UUID
+	containers        map[containerKey]uuid.UUID
+	missingLocations  []string
+	missingContainers []containerKey
+}
+
+// resolveItemBundleRefs looks up every name reference of the bundle in the
+// target workspace without writing anything. Unresolved names are collected
+// for creation when opts.CreateMissing is set and rejected otherwise.
+func (s *Service) resolveItemBundleRefs(ctx context.Context, workspaceID uuid.UUID, bundle *ItemBundle, opts ItemImportOptions) (*itemBundlePlan, error) {
+	plan := &itemBundlePlan{
+		locations:  make(map[string]uuid.UUID),
+		containers: make(map[containerKey]uuid.UUID),
+	}
+
+	// Keep the SKU when it is free in the target workspace, otherwise
+	// generate one the same way row imports do.
+	plan.sku = bundle.Item.SKU
+	if plan.sku != "" {
+		exists, err := s.repo.ItemSKUExists(ctx, workspaceID, plan.sku)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check SKU: %w", err)
+		}
+		if exists {
+			plan.sku = ""
+		}
+	}
+	if plan.sku == "" {
+		plan.sku = fmt.Sprintf("SKU-%s", uuid.New().String()[:8])
+	}
+
+	if name := bundle.Item.CategoryName; name != "" {
+		cat, err := s.repo.GetCategoryByName(ctx, workspaceID, name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find category %q: %w", name, err)
+		}
+		switch {
+		case cat != nil:
+			plan.categoryID = &cat.ID
+		case !opts.CreateMissing:
+			return nil, shared.NewFieldError(shared.ErrInvalidInput, "item.category_name", fmt.Sprintf("category %q does not exist in the target workspace", name))
+		}
+	}
+
+	var containers []queries.WarehouseContainer
+	containersLoaded := false
+
+	for i, entry := range bundle.Inventory {
+		if _, seen := plan.locations[entry.LocationName]; !seen && !slices.Contains(plan.missingLocations, entry.LocationName) {
+			loc, err := s.repo.GetLocationByName(ctx, workspaceID, entry.LocationName)
+			if err != nil {
+				return nil, fmt.Errorf("failed to find location %q: %w", entry.LocationName, err)
+			}
+			switch {
+			case loc != nil:
+				plan.locations[entry.LocationName] = loc.ID
+			case opts.CreateMissing:
+				plan.missingLocations = append(plan.missingLocations, entry.LocationName)
+			default:
+				return nil, shared.NewFieldError(shared.ErrInvalidInput, fmt.Sprintf("inventory[%d].location_name", i), fmt.Sprintf("location %q does not exist in the target workspace", entry.LocationName))
+			}
+		}
+
+		if entry.ContainerName == "" {
+			continue
+		}
+		key := containerKey{entry.LocationName, entry.ContainerName}
+		if _, seen := plan.containers[key]; seen || slices.Contains(plan.missingContainers, key) {
+			continue
+		}
+
+		// A container can only already exist when its location does.
+		if locID, ok := plan.locations[entry.LocationName]; ok {
+			if !containersLoaded {
+				var err error
+				containers, err = s.repo.ListAllContainers(ctx, workspaceID, false)
+				if err != nil {
+					return nil, fmt.Errorf("failed to list containers: %w", err)
+				}
+				containersLoaded = true
+			}
+			if id, found := findContainer(containers, locID, entry.ContainerName); found {
+				plan.containers[key] = id
+				continue
+			}
+		}
+
+		if !opts.CreateMissing {
+			return nil, shared.NewFieldError(shared.ErrInvalidInput, fmt.Sprintf("inventory[%d].container_name", i), fmt.Sprintf("container %q does not exist in location %q of the target workspace", entry.ContainerName, entry.LocationName))
+		}
+		plan.missingContainers = append(plan.missingContainers, key)
+	}
+
+	return plan, nil
+}
+
+// validateItemBundle checks the bundle's internal consistency: supported
+// version, required fields, valid enum values and dates, and photo storage
+// paths that stay inside the bundled item's own storage. A path must follow
+// one of layouts (the default layout when none are given) and name the
+// bundled item and its workspace.
+func validateItemBundle(bundle *ItemBundle, layouts ...*storage.PathLayout) error {
+	if bundle == nil {
+		return shared.NewDomainError(shared.ErrInvalidInput, "bundle is required")
+	}
+	if bundle.Version != ItemBundleVersion {
+		return shared.NewFieldError(shared.ErrInvalidInput, "version", fmt.Sprintf("unsupported bundle version %d", bundle.Version))
+	}
+	if strings.TrimSpace(bundle.Item.Name) == "" {
+		return shared.NewFieldError(shared.ErrInvalidInput, "item.name", msgNameIsRequired)
+	}
+	if bundle.Item.MinStockLevel < 0 {
+		return shared.NewFieldError(shared.ErrInvalidInput, "item.min_stock_level", "min_stock_level must be non-negative")
+	}
+
+	for i, entry := range bundle.Inventory {
+		field := fmt.Sprintf("inventory[%d]", i)
+		if strings.TrimSpace(entry.LocationName) == "" {
+			return shared.NewFieldError(shared.ErrInvalidInput, field+".location_name", "location_name is required")
+		}
+		if entry.Quantity < 0 {
+			return shared.NewFieldError(shared.ErrInvalidInput, field+".quantity", "quantity must be non-negative")
+		}
+		if entry.Condition != "" && !inventory.Condition(entry.Condition).IsValid() {
+			return shared.NewFieldError(shared.ErrInvalidInput, field+".condition", fmt.Sprintf("invalid condition %q", entry.Condition))
+		}
+		if entry.Status != "" && !inventory.Status(entry.Status).IsValid() {
+			return shared.NewFieldError(shared.ErrInvalidInput, field+".status", fmt.Sprintf("invalid status %q", entry.Status))
+		}
+		for name, value := range map[string]string{
+			"date_acquired":    entry.DateAcquired,
+			"warranty_expires": entry.WarrantyExpires,
+			"expiration_date":  entry.ExpirationDate,
+		} {
+			if value != "" && !parseDateCell(value).Valid {
+				return shared.NewFieldError(shared.ErrInvalidInput, field+"."+name, fmt.Sprintf("invalid date %q, expected YYYY-MM-DD", value))
+			}
+		}
+	}
+
+	// Photos must live in the source item's own storage; anything else could
+	// make the caller copy another item's (or tenant's) files.
+	if len(layouts) == 0 {
+		layouts = []*storage.PathLayout{storage.DefaultLayout()}
+	}
+	primaries := 0
+	for i, p := range bundle.Photos {
+		field := fmt.Sprintf("photos[%d]", i)
+		if p.Filename == "" {
+			return shared.NewFieldError(shared.ErrInvalidInput, field+".filename", "filename is required")
+		}
+		owner, ok := storage.ParseStoragePath(p.StoragePath, layouts...)
+		if !ok || strings.Contains(p.StoragePath, "..") ||
+			owner.WorkspaceID != bundle.SourceWorkspaceID.String() || owner.ItemID != bundle.Item.ID.String() {
+			return shared.NewFieldError(shared.ErrInvalidInput, field+".storage_path", "storage_path must point inside the bundled item's storage")
+		}
+		if p.IsPrimary {
+			primaries++
+		}
+	}
+	if primaries > 1 {
+		return shared.NewFieldError(shared.ErrInvalidInput, "photos", "at most one photo can be primary")
+	}
+
+	return nil
+}
+
+// findContainer returns the ID of the container named name in locationID.
+func findContainer(containers []queries.WarehouseContainer, locationID uuid.UUID, name string) (uuid.UUID, bool) {
+	for _, c := range containers {
+		if c.LocationID == locationID && c.Name == name {
+			return c.ID, true
+		}
+	}
+	return uuid.Nil, false
+}
+
+// generateShortCode generates a random 8-character lowercase hex short code.
+// Bundled short codes are never reused: the registry makes them globally
+// unique, so the source item still owns its code.
+func generateShortCode() string {
+	b := make([]byte, 4)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
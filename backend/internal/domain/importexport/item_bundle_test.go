@@ -0,0 +1,416 @@
+package importexport
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/auth/member"
+	"github.com/antti/home-warehouse/go-backend/internal/infra/queries"
+	"github.com/antti/home-warehouse/go-backend/internal/infra/storage"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+func TestService_ExportItem(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+	itemID := uuid.New()
+	categoryID := uuid.New()
+	locationID := uuid.New()
+	containerID := uuid.New()
+
+	t.Run("bundles item with inventory and photos", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		svc := NewService(mockRepo)
+
+		mockRepo.On("GetItem", ctx, workspaceID, itemID).Return(&queries.WarehouseItem{
+			ID:            itemID,
+			WorkspaceID:   workspaceID,
+			Sku:           "SKU-1",
+			Name:          "Drill",
+			Brand:         ptrString("Makita"),
+			CategoryID:    pgtype.UUID{Bytes: categoryID, Valid: true},
+			MinStockLevel: 1,
+		}, nil)
+		mockRepo.On("GetCategory", ctx, workspaceID, categoryID).Return(&queries.WarehouseCategory{ID: categoryID, Name: "Tools"}, nil)
+		mockRepo.On("ListInventoryByItem", ctx, workspaceID, itemID).Return([]queries.WarehouseInventory{
+			{
+				ID:          uuid.New(),
+				ItemID:      itemID,
+				LocationID:  locationID,
+				ContainerID: pgtype.UUID{Bytes: containerID, Valid: true},
+				Quantity:    2,
+				Condition:   queries.NullWarehouseItemConditionEnum{WarehouseItemConditionEnum: queries.WarehouseItemConditionEnumGOOD, Valid: true},
+				Status:      queries.NullWarehouseItemStatusEnum{WarehouseItemStatusEnum: queries.WarehouseItemStatusEnumAVAILABLE, Valid: true},
+			},
+			{
+				ID:         uuid.New(),
+				ItemID:     itemID,
+				LocationID: locationID,
+				Quantity:   1,
+			},
+		}, nil)
+		mockRepo.On("GetLocation", ctx, workspaceID, locationID).Return(&queries.WarehouseLocation{ID: locationID, Name: "Garage"}, nil).Once()
+		mockRepo.On("GetContainer", ctx, workspaceID, containerID).Return(&queries.WarehouseContainer{ID: containerID, Name: "Toolbox"}, nil).Once()
+		mockRepo.On("ListItemPhotos", ctx, workspaceID, itemID).Return([]queries.WarehouseItemPhoto{
+			{ID: uuid.New(), Filename: "drill.jpg", StoragePath: workspaceID.String() + "/" + itemID.String() + "/drill.jpg", IsPrimary: true},
+		}, nil)
+
+		bundle, err := svc.ExportItem(ctx, workspaceID, itemID)
+
+		require.NoError(t, err)
+		assert.Equal(t, ItemBundleVersion, bundle.Version)
+		assert.Equal(t, workspaceID, bundle.SourceWorkspaceID)
+		assert.Equal(t, "Drill", bundle.Item.Name)
+		assert.Equal(t, "Makita", bundle.Item.Brand)
+		assert.Equal(t, "Tools", bundle.Item.CategoryName)
+		require.Len(t, bundle.Inventory, 2)
+		assert.Equal(t, "Garage", bundle.Inventory[0].LocationName)
+		assert.Equal(t, "Toolbox", bundle.Inventory[0].ContainerName)
+		assert.Equal(t, "GOOD", bundle.Inventory[0].Condition)
+		assert.Equal(t, "AVAILABLE", bundle.Inventory[0].Status)
+		assert.Empty(t, bundle.Inventory[1].ContainerName)
+		require.Len(t, bundle.Photos, 1)
+		assert.True(t, bundle.Photos[0].IsPrimary)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("returns not found for unknown item", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		svc := NewService(mockRepo)
+
+		mockRepo.On("GetItem", ctx, workspaceID, itemID).Return(nil, shared.ErrNotFound)
+
+		bundle, err := svc.ExportItem(ctx, workspaceID, itemID)
+
+		assert.Nil(t, bundle)
+		assert.ErrorIs(t, err, shared.ErrNotFound)
+	})
+}
+
+func newTestBundle() *ItemBundle {
+	sourceWS := uuid.New()
+	itemID := uuid.New()
+	return &ItemBundle{
+		Version:           ItemBundleVersion,
+		SourceWorkspaceID: sourceWS,
+		Item: ItemBundleItem{
+			ID:           itemID,
+			SKU:          "SKU-1",
+			Name:         "Drill",
+			CategoryName: "Tools",
+		},
+		Inventory: []ItemBundleInventory{
+			{LocationName: "Garage", ContainerName: "Toolbox", Quantity: 2, Condition: "GOOD", Status: "AVAILABLE", DateAcquired: "2024-03-01"},
+		},
+		Photos: []ItemBundlePhoto{
+			{Filename: "drill.jpg", StoragePath: sourceWS.String() + "/" + itemID.String() + "/abc_drill.jpg", MimeType: "image/jpeg", IsPrimary: true},
+		},
+	}
+}
+
+// fakePhotoStore keeps photo files in memory, keyed by storage path.
+type fakePhotoStore struct {
+	files     map[string][]byte
+	saveError error
+	deleted   []string
+}
+
+func newFakePhotoStore(files map[string][]byte) *fakePhotoStore {
+	return &fakePhotoStore{files: files}
+}
+
+func (f *fakePhotoStore) Get(ctx context.Context, path string) (io.ReadCloser, error) {
+	data, ok := f.files[path]
+	if !ok {
+		return nil, storage.ErrFileNotFound
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+func (f *fakePhotoStore) Save(ctx context.Context, workspaceID, itemID, filename string, reader io.Reader) (string, error) {
+	if f.saveError != nil {
+		return "", f.saveError
+	}
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return "", err
+	}
+	path := workspaceID + "/" + itemID + "/copy_" + filename
+	f.files[path] = data
+	return path, nil
+}
+
+func (f *fakePhotoStore) Delete(ctx context.Context, path string) error {
+	delete(f.files, path)
+	f.deleted = append(f.deleted, path)
+	return nil
+}
+
+// fakeRoleLookup reports the workspaces a user is a member of.
+type fakeRoleLookup map[uuid.UUID]member.Role
+
+func (f fakeRoleLookup) GetUserRole(ctx context.Context, workspaceID, userID uuid.UUID) (member.Role, error) {
+	role, ok := f[workspaceID]
+	if !ok {
+		return "", member.ErrMemberNotFound
+	}
+	return role, nil
+}
+
+func TestService_ImportItem(t *testing.T) {
+	ctx := context.Background()
+	targetWS := uuid.New()
+
+	t.Run("recreates item against existing references", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		svc := NewService(mockRepo)
+		bundle := newTestBundle()
+		userID := uuid.New()
+		store := newFakePhotoStore(map[string][]byte{bundle.Photos[0].StoragePath: []byte("jpeg")})
+		svc.SetPhotoStorage(store)
+		svc.SetRoleLookup(fakeRoleLookup{bundle.SourceWorkspaceID: member.RoleViewer})
+
+		categoryID := uuid.New()
+		locationID := uuid.New()
+		containerID := uuid.New()
+		newItemID := uuid.New()
+
+		mockRepo.On("ItemSKUExists", ctx, targetWS, "SKU-1").Return(false, nil)
+		mockRepo.On("GetCategoryByName", ctx, targetWS, "Tools").Return(&queries.WarehouseCategory{ID: categoryID, Name: "Tools"}, nil)
+		mockRepo.On("GetLocationByName", ctx, targetWS, "Garage").Return(&queries.WarehouseLocation{ID: locationID, Name: "Garage"}, nil)
+		mockRepo.On("ListAllContainers", ctx, targetWS, false).Return([]queries.WarehouseContainer{
+			{ID: uuid.New(), LocationID: uuid.New(), Name: "Toolbox"}, // same name, other location
+			{ID: containerID, LocationID: locationID, Name: "Toolbox"},
+		}, nil)
+		var copiedPath string
+		mockRepo.On("CreateItem", ctx, mock.MatchedBy(func(p queries.CreateItemParams) bool {
+			copiedPath = targetWS.String() + "/" + p.ID.String() + "/copy_drill.jpg"
+			return p.WorkspaceID == targetWS && p.ID != bundle.Item.ID && p.Sku == "SKU-1" &&
+				p.CategoryID.Valid && uuid.UUID(p.CategoryID.Bytes) == categoryID && len(p.ShortCode) == 8
+		})).Return(queries.WarehouseItem{ID: newItemID, Name: "Drill"}, nil)
+		mockRepo.On("CreateInventory", ctx, mock.MatchedBy(func(p queries.CreateInventoryParams) bool {
+			return p.ItemID == newItemID && p.LocationID == locationID &&
+				p.ContainerID.Valid && uuid.UUID(p.ContainerID.Bytes) == containerID &&
+				p.Condition.WarehouseItemConditionEnum == queries.WarehouseItemConditionEnumGOOD &&
+				p.DateAcquired.Valid
+		})).Return(queries.WarehouseInventory{ID: uuid.New()}, nil)
+		mockRepo.On("CreateItemPhoto", ctx, mock.MatchedBy(func(p queries.CreateItemPhotoParams) bool {
+			return p.ItemID == newItemID && p.WorkspaceID == targetWS &&
+				p.StoragePath == copiedPath && p.Filename == "drill.jpg" && p.IsPrimary
+		})).Return(queries.WarehouseItemPhoto{ID: uuid.New()}, nil)
+
+		result, err := svc.ImportItem(ctx, targetWS, bundle, ItemImportOptions{UserID: userID})
+
+		require.NoError(t, err)
+		assert.Equal(t, newItemID, result.ItemID)
+		assert.Len(t, result.InventoryIDs, 1)
+		assert.Len(t, result.PhotoIDs, 1)
+		assert.Empty(t, result.SkippedPhotos)
+		assert.Empty(t, result.CreatedCategories)
+		assert.Empty(t, result.CreatedLocations)
+		assert.Equal(t, []byte("jpeg"), store.files[copiedPath], "the file is copied to the new item's path")
+		assert.Contains(t, store.files, bundle.Photos[0].StoragePath, "the source file is kept")
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("creates missing references when allowed", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		svc := NewService(mockRepo)
+		bundle := newTestBundle()
+		userID := uuid.New()
+		svc.SetPhotoStorage(newFakePhotoStore(map[string][]byte{}))
+		svc.SetRoleLookup(fakeRoleLookup{bundle.SourceWorkspaceID: member.RoleOwner})
+
+		locationID := uuid.New()
+
+		mockRepo.On("ItemSKUExists", ctx, targetWS, "SKU-1").Return(true, nil)
+		mockRepo.On("GetCategoryByName", ctx, targetWS, "Tools").Return(nil, nil)
+		mockRepo.On("GetLocationByName", ctx, targetWS, "Garage").Return(nil, nil)
+		mockRepo.On("CreateCategory", ctx, mock.MatchedBy(func(p queries.CreateCategoryParams) bool {
+			return p.Name == "Tools" && p.WorkspaceID == targetWS
+		})).Return(queries.WarehouseCategory{ID: uuid.New(), Name: "Tools"}, nil)
+		mockRepo.On("CreateLocation", ctx, mock.MatchedBy(func(p queries.CreateLocationParams) bool {
+			return p.Name == "Garage" && p.WorkspaceID == targetWS
+		})).Return(queries.WarehouseLocation{ID: locationID, Name: "Garage"}, nil)
+		mockRepo.On("CreateContainer", ctx, mock.MatchedBy(func(p queries.CreateContainerParams) bool {
+			return p.Name == "Toolbox" && p.LocationID == locationID
+		})).Return(queries.WarehouseContainer{ID: uuid.New(), Name: "Toolbox"}, nil)
+		mockRepo.On("CreateItem", ctx, mock.MatchedBy(func(p queries.CreateItemParams) bool {
+			// SKU was taken in the target workspace, so a fresh one is generated.
+			return p.Sku != "SKU-1" && p.Sku != ""
+		})).Return(queries.WarehouseItem{ID: uuid.New()}, nil)
+		mockRepo.On("CreateInventory", ctx, mock.Anything).Return(queries.WarehouseInventory{ID: uuid.New()}, nil)
+
+		result, err := svc.ImportItem(ctx, targetWS, bundle, ItemImportOptions{CreateMissing: true, UserID: userID})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"drill.jpg"}, result.SkippedPhotos, "the source file no longer exists")
+		assert.Equal(t, []string{"Tools"}, result.CreatedCategories)
+		assert.Equal(t, []string{"Garage"}, result.CreatedLocations)
+		assert.Equal(t, []string{"Toolbox"}, result.CreatedContainers)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "ListAllContainers", mock.Anything, mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "CreateItemPhoto", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects missing references without writing", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		svc := NewService(mockRepo)
+		bundle := newTestBundle()
+		bundle.Photos = nil
+
+		mockRepo.On("ItemSKUExists", ctx, targetWS, "SKU-1").Return(false, nil)
+		mockRepo.On("GetCategoryByName", ctx, targetWS, "Tools").Return(&queries.WarehouseCategory{ID: uuid.New()}, nil)
+		mockRepo.On("GetLocationByName", ctx, targetWS, "Garage").Return(nil, nil)
+
+		result, err := svc.ImportItem(ctx, targetWS, bundle, ItemImportOptions{})
+
+		assert.Nil(t, result)
+		assert.True(t, shared.IsInvalidInput(err))
+		assert.Contains(t, err.Error(), "Garage")
+		mockRepo.AssertNotCalled(t, "CreateItem", mock.Anything, mock.Anything)
+	})
+
+	t.Run("propagates write errors from the transaction", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		tx := &recordingTx{}
+		svc := NewService(mockRepo)
+		svc.SetTransactor(tx)
+		bundle := newTestBundle()
+		bundle.SourceWorkspaceID = targetWS
+		bundle.Photos[0].StoragePath = targetWS.String() + "/" + bundle.Item.ID.String() + "/abc_drill.jpg"
+		bundle.Item.CategoryName = ""
+		bundle.Inventory = nil
+		store := newFakePhotoStore(map[string][]byte{bundle.Photos[0].StoragePath: []byte("jpeg")})
+		svc.SetPhotoStorage(store)
+
+		mockRepo.On("ItemSKUExists", ctx, targetWS, "SKU-1").Return(false, nil)
+		mockRepo.On("CreateItem", ctx, mock.Anything).Return(queries.WarehouseItem{}, errors.New("db down"))
+
+		result, err := svc.ImportItem(ctx, targetWS, bundle, ItemImportOptions{})
+
+		assert.Nil(t, result)
+		assert.ErrorContains(t, err, "db down")
+		assert.Equal(t, 1, tx.calls, "the writes run in one transaction")
+		require.Len(t, store.deleted, 1, "the copied file is removed again")
+		assert.True(t, strings.HasPrefix(store.deleted[0], targetWS.String()+"/"))
+		assert.Len(t, store.files, 1, "only the source file is left")
+	})
+
+	t.Run("skips photos in a workspace the user is not a member of", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		svc := NewService(mockRepo)
+		bundle := newTestBundle()
+		bundle.Item.CategoryName = ""
+		bundle.Inventory = nil
+		store := newFakePhotoStore(map[string][]byte{bundle.Photos[0].StoragePath: []byte("jpeg")})
+		svc.SetPhotoStorage(store)
+		svc.SetRoleLookup(fakeRoleLookup{})
+
+		mockRepo.On("ItemSKUExists", ctx, targetWS, "SKU-1").Return(false, nil)
+		mockRepo.On("CreateItem", ctx, mock.Anything).Return(queries.WarehouseItem{ID: uuid.New()}, nil)
+
+		result, err := svc.ImportItem(ctx, targetWS, bundle, ItemImportOptions{UserID: uuid.New()})
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"drill.jpg"}, result.SkippedPhotos)
+		assert.Len(t, store.files, 1, "nothing is copied")
+		mockRepo.AssertNotCalled(t, "CreateItemPhoto", mock.Anything, mock.Anything)
+	})
+
+	t.Run("fails without writing when a copy fails", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		svc := NewService(mockRepo)
+		bundle := newTestBundle()
+		bundle.Photos = append(bundle.Photos, ItemBundlePhoto{
+			Filename:    "drill-side.jpg",
+			StoragePath: bundle.SourceWorkspaceID.String() + "/" + bundle.Item.ID.String() + "/def_drill-side.jpg",
+			MimeType:    "image/jpeg",
+		})
+		store := newFakePhotoStore(map[string][]byte{
+			bundle.Photos[0].StoragePath: []byte("jpeg"),
+			bundle.Photos[1].StoragePath: []byte("jpeg"),
+		})
+		store.saveError = errors.New("disk full")
+		svc.SetPhotoStorage(store)
+		svc.SetRoleLookup(fakeRoleLookup{bundle.SourceWorkspaceID: member.RoleMember})
+
+		result, err := svc.ImportItem(ctx, targetWS, bundle, ItemImportOptions{UserID: uuid.New()})
+
+		assert.Nil(t, result)
+		assert.ErrorContains(t, err, "disk full")
+		mockRepo.AssertNotCalled(t, "CreateItem", mock.Anything, mock.Anything)
+	})
+
+	t.Run("requires photo storage for bundles with photos", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		svc := NewService(mockRepo)
+
+		result, err := svc.ImportItem(ctx, targetWS, newTestBundle(), ItemImportOptions{})
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, ErrPhotoCopyUnavailable)
+		mockRepo.AssertNotCalled(t, "CreateItem", mock.Anything, mock.Anything)
+	})
+}
+
+func TestValidateItemBundle(t *testing.T) {
+	tests := []struct {
+		name   string
+		mutate func(b *ItemBundle)
+		field  string
+	}{
+		{"unsupported version", func(b *ItemBundle) { b.Version = 99 }, "version"},
+		{"missing name", func(b *ItemBundle) { b.Item.Name = " " }, "item.name"},
+		{"missing location", func(b *ItemBundle) { b.Inventory[0].LocationName = "" }, "inventory[0].location_name"},
+		{"negative quantity", func(b *ItemBundle) { b.Inventory[0].Quantity = -1 }, "inventory[0].quantity"},
+		{"invalid condition", func(b *ItemBundle) { b.Inventory[0].Condition = "SHINY" }, "inventory[0].condition"},
+		{"invalid status", func(b *ItemBundle) { b.Inventory[0].Status = "LOST" }, "inventory[0].status"},
+		{"invalid date", func(b *ItemBundle) { b.Inventory[0].DateAcquired = "01/03/2024" }, "inventory[0].date_acquired"},
+		{"photo outside item storage", func(b *ItemBundle) {
+			b.Photos[0].StoragePath = uuid.New().String() + "/" + uuid.New().String() + "/x.jpg"
+		}, "photos[0].storage_path"},
+		{"photo path traversal", func(b *ItemBundle) {
+			b.Photos[0].StoragePath = b.SourceWorkspaceID.String() + "/" + b.Item.ID.String() + "/../../x.jpg"
+		}, "photos[0].storage_path"},
+		{"two primary photos", func(b *ItemBundle) {
+			b.Photos = append(b.Photos, b.Photos[0])
+		}, "photos"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bundle := newTestBundle()
+			tt.mutate(bundle)
+
+			err := validateItemBundle(bundle)
+
+			var domainErr *shared.DomainError
+			require.ErrorAs(t, err, &domainErr)
+			assert.Equal(t, tt.field, domainErr.Field)
+		})
+	}
+
+	t.Run("valid bundle", func(t *testing.T) {
+		assert.NoError(t, validateItemBundle(newTestBundle()))
+	})
+
+	t.Run("photo under a configured layout", func(t *testing.T) {
+		layout := storage.MustParsePathLayout("{yyyy}/{mm}/{workspace}/{item}/{filename}")
+		bundle := newTestBundle()
+		bundle.Photos[0].StoragePath = "2024/03/" + bundle.SourceWorkspaceID.String() + "/" + bundle.Item.ID.String() + "/a.jpg"
+
+		assert.NoError(t, validateItemBundle(bundle, layout, storage.DefaultLayout()))
+		assert.Error(t, validateItemBundle(bundle), "the default layout alone does not match")
+	})
+}
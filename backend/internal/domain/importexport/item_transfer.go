@@ -0,0 +1,157 @@
+package importexport
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/auth/member"
+	"github.com/antti/home-warehouse/go-backend/internal/infra/storage"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// Item transfer errors.
+var (
+	ErrTransferUnavailable   = shared.NewDomainError(shared.ErrInternal, "item transfer is not configured")
+	ErrTransferForbidden     = shared.NewDomainError(shared.ErrForbidden, "only owners and admins of both workspaces can transfer items")
+	ErrTransferSameWorkspace = shared.NewFieldError(shared.ErrInvalidInput, "target_workspace_id", "target workspace must differ from the item's workspace")
+	ErrTransferArchivedItem  = shared.NewDomainError(shared.ErrInvalidInput, "archived items cannot be transferred")
+)
+
+// Transactor runs a function inside a single database transaction. It is a
+// port implemented by infra/postgres.TxManager, keeping this package free of
+// infra imports.
+type Transactor interface {
+	WithTx(ctx context.Context, fn func(context.Context) error) error
+}
+
+// noopTransactor executes the function without a surrounding transaction. It
+// is the fallback when no Transactor is wired (unit tests with mocked
+// repositories).
+type noopTransactor struct{}
+
+func (noopTransactor) WithTx(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+// RoleLookup returns a user's role in a workspace. Implemented by
+// member.Service.
+type RoleLookup interface {
+	GetUserRole(ctx context.Context, workspaceID, userID uuid.UUID) (member.Role, error)
+}
+
+// SetTransactor wires the transaction runner used by TransferItemToWorkspace
+// so the copy and the source soft-delete commit together. Optional — without
+// it the steps run unwrapped (unit tests with mocked repositories).
+func (s *Service) SetTransactor(tx Transactor) {
+	if tx == nil {
+		tx = noopTransactor{}
+	}
+	s.tx = tx
+}
+
+// SetPathLayout sets the photo storage layout bundle photo paths are checked
+// against, matching the photo storage's. Optional - without it
+// storage.DefaultPathLayout is used.
+func (s *Service) SetPathLayout(layout *storage.PathLayout) {
+	s.layout = layout
+}
+
+// pathLayouts returns the layout photos are currently stored under, followed
+// by the default layout that photos stored before a layout change still use.
+func (s *Service) pathLayouts() []*storage.PathLayout {
+	if s.layout == nil || s.layout.String() == storage.DefaultPathLayout {
+		return []*storage.PathLayout{storage.DefaultLayout()}
+	}
+	return []*storage.PathLayout{s.layout, storage.DefaultLayout()}
+}
+
+// SetRoleLookup wires the membership check for item transfers. Required for
+// TransferItemToWorkspace, which needs the caller's role in the destination
+// workspace as well as the source.
+func (s *Service) SetRoleLookup(roles RoleLookup) {
+	s.roles = roles
+}
+
+// TransferItemToWorkspace moves an item to another workspace. The item and
+// its inventory are recreated in dstWorkspaceID with new IDs, resolving the
+// category, locations and containers by name and creating the missing ones.
+// The source item and its inventory are then archived.
+//
+// Photos move rather than copy: the new rows reuse the stored files' paths and
+// the source rows are deleted, so the files keep exactly one owner.
+// Thumbnails are regenerated for the new rows.
+//
+// userID must be an owner or admin of both workspaces.
+func (s *Service) TransferItemToWorkspace(ctx context.Context, srcWorkspaceID, itemID, dstWorkspaceID, userID uuid.UUID) (*ItemImportResult, error) {
+	if s.roles == nil {
+		return nil, ErrTransferUnavailable
+	}
+	if srcWorkspaceID == dstWorkspaceID {
+		return nil, ErrTransferSameWorkspace
+	}
+	for _, workspaceID := range []uuid.UUID{srcWorkspaceID, dstWorkspaceID} {
+		if err := s.requireManager(ctx, workspaceID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	itm, err := s.repo.GetItem(ctx, srcWorkspaceID, itemID)
+	if err != nil {
+		return nil, err
+	}
+	if itm.IsArchived {
+		return nil, ErrTransferArchivedItem
+	}
+
+	bundle, err := s.ExportItem(ctx, srcWorkspaceID, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	var result *ItemImportResult
+	err = s.tx.WithTx(ctx, func(ctx context.Context) error {
+		var err error
+		result, err = s.ImportItem(ctx, dstWorkspaceID, bundle, ItemImportOptions{
+			CreateMissing:    true,
+			KeepStoragePaths: true,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, inv := range bundle.Inventory {
+			if err := s.repo.ArchiveInventory(ctx, srcWorkspaceID, inv.ID); err != nil {
+				return fmt.Errorf("failed to archive inventory: %w", err)
+			}
+		}
+		if err := s.repo.DeleteItemPhotos(ctx, srcWorkspaceID, itemID); err != nil {
+			return fmt.Errorf("failed to detach photos: %w", err)
+		}
+		if err := s.repo.ArchiveItem(ctx, srcWorkspaceID, itemID); err != nil {
+			return fmt.Errorf("failed to archive item: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// requireManager ensures userID is an owner or admin of workspaceID. Not being
+// a member at all is reported the same way.
+func (s *Service) requireManager(ctx context.Context, workspaceID, userID uuid.UUID) error {
+	role, err := s.roles.GetUserRole(ctx, workspaceID, userID)
+	if err != nil {
+		if shared.IsNotFound(err) {
+			return ErrTransferForbidden
+		}
+		return err
+	}
+	if role != member.RoleOwner && role != member.RoleAdmin {
+		return ErrTransferForbidden
+	}
+	return nil
+}
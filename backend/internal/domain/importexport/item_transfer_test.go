@@ -0,0 +1,168 @@
+package importexport
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/auth/member"
+	"github.com/antti/home-warehouse/go-backend/internal/infra/queries"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// fakeRoles is a RoleLookup over a fixed workspace → role map; workspaces
+// missing from it report member not found.
+type fakeRoles map[uuid.UUID]member.Role
+
+func (f fakeRoles) GetUserRole(_ context.Context, workspaceID, _ uuid.UUID) (member.Role, error) {
+	role, ok := f[workspaceID]
+	if !ok {
+		return "", member.ErrMemberNotFound
+	}
+	return role, nil
+}
+
+// recordingTx counts WithTx calls and runs fn directly.
+type recordingTx struct {
+	calls int
+}
+
+func (r *recordingTx) WithTx(ctx context.Context, fn func(context.Context) error) error {
+	r.calls++
+	return fn(ctx)
+}
+
+func TestService_TransferItemToWorkspace(t *testing.T) {
+	ctx := context.Background()
+	srcWS := uuid.New()
+	dstWS := uuid.New()
+	itemID := uuid.New()
+	userID := uuid.New()
+	locationID := uuid.New()
+	inventoryID := uuid.New()
+	photoPath := srcWS.String() + "/" + itemID.String() + "/abc_drill.jpg"
+
+	// expectSource sets up the reads of the source item bundle.
+	expectSource := func(mockRepo *MockRepository) {
+		mockRepo.On("GetItem", ctx, srcWS, itemID).Return(&queries.WarehouseItem{ID: itemID, WorkspaceID: srcWS, Sku: "SKU-1", Name: "Drill"}, nil)
+		mockRepo.On("ListInventoryByItem", ctx, srcWS, itemID).Return([]queries.WarehouseInventory{
+			{ID: inventoryID, ItemID: itemID, LocationID: locationID, Quantity: 2},
+		}, nil)
+		mockRepo.On("GetLocation", ctx, srcWS, locationID).Return(&queries.WarehouseLocation{ID: locationID, Name: "Garage"}, nil)
+		mockRepo.On("ListItemPhotos", ctx, srcWS, itemID).Return([]queries.WarehouseItemPhoto{
+			{ID: uuid.New(), Filename: "drill.jpg", StoragePath: photoPath, IsPrimary: true},
+		}, nil)
+	}
+
+	t.Run("recreates the item in the target and archives the source", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		tx := &recordingTx{}
+		svc := NewService(mockRepo)
+		svc.SetTransactor(tx)
+		svc.SetRoleLookup(fakeRoles{srcWS: member.RoleOwner, dstWS: member.RoleAdmin})
+
+		newItemID := uuid.New()
+		expectSource(mockRepo)
+		mockRepo.On("ItemSKUExists", ctx, dstWS, "SKU-1").Return(false, nil)
+		mockRepo.On("GetLocationByName", ctx, dstWS, "Garage").Return(nil, nil)
+		mockRepo.On("CreateLocation", ctx, mock.MatchedBy(func(p queries.CreateLocationParams) bool {
+			return p.WorkspaceID == dstWS && p.Name == "Garage"
+		})).Return(queries.WarehouseLocation{ID: uuid.New(), Name: "Garage"}, nil)
+		mockRepo.On("CreateItem", ctx, mock.MatchedBy(func(p queries.CreateItemParams) bool {
+			return p.WorkspaceID == dstWS && p.ID != itemID
+		})).Return(queries.WarehouseItem{ID: newItemID, Name: "Drill"}, nil)
+		mockRepo.On("CreateInventory", ctx, mock.MatchedBy(func(p queries.CreateInventoryParams) bool {
+			return p.WorkspaceID == dstWS && p.ItemID == newItemID && p.ID != inventoryID && p.Quantity == 2
+		})).Return(queries.WarehouseInventory{ID: uuid.New()}, nil)
+		mockRepo.On("CreateItemPhoto", ctx, mock.MatchedBy(func(p queries.CreateItemPhotoParams) bool {
+			return p.WorkspaceID == dstWS && p.ItemID == newItemID && p.StoragePath == photoPath
+		})).Return(queries.WarehouseItemPhoto{ID: uuid.New()}, nil)
+		mockRepo.On("ArchiveInventory", ctx, srcWS, inventoryID).Return(nil)
+		mockRepo.On("DeleteItemPhotos", ctx, srcWS, itemID).Return(nil)
+		mockRepo.On("ArchiveItem", ctx, srcWS, itemID).Return(nil)
+
+		result, err := svc.TransferItemToWorkspace(ctx, srcWS, itemID, dstWS, userID)
+
+		require.NoError(t, err)
+		assert.Equal(t, newItemID, result.ItemID)
+		assert.Equal(t, "Drill", result.ItemName)
+		assert.Equal(t, []string{"Garage"}, result.CreatedLocations)
+		assert.Len(t, result.PhotoIDs, 1)
+		assert.Empty(t, result.SkippedPhotos, "photos keep their storage paths")
+		assert.Equal(t, 2, tx.calls, "the import joins the transfer's transaction")
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("requires owner or admin in the target workspace", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		svc := NewService(mockRepo)
+		svc.SetRoleLookup(fakeRoles{srcWS: member.RoleOwner, dstWS: member.RoleMember})
+
+		result, err := svc.TransferItemToWorkspace(ctx, srcWS, itemID, dstWS, userID)
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, ErrTransferForbidden)
+		mockRepo.AssertNotCalled(t, "GetItem", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("requires membership of the target workspace", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		svc := NewService(mockRepo)
+		svc.SetRoleLookup(fakeRoles{srcWS: member.RoleAdmin})
+
+		_, err := svc.TransferItemToWorkspace(ctx, srcWS, itemID, dstWS, userID)
+
+		assert.ErrorIs(t, err, ErrTransferForbidden)
+	})
+
+	t.Run("rejects the same workspace", func(t *testing.T) {
+		svc := NewService(new(MockRepository))
+		svc.SetRoleLookup(fakeRoles{srcWS: member.RoleOwner})
+
+		_, err := svc.TransferItemToWorkspace(ctx, srcWS, itemID, srcWS, userID)
+
+		assert.ErrorIs(t, err, ErrTransferSameWorkspace)
+	})
+
+	t.Run("rejects archived items", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		svc := NewService(mockRepo)
+		svc.SetRoleLookup(fakeRoles{srcWS: member.RoleOwner, dstWS: member.RoleOwner})
+
+		mockRepo.On("GetItem", ctx, srcWS, itemID).Return(&queries.WarehouseItem{ID: itemID, Name: "Drill", IsArchived: true}, nil)
+
+		_, err := svc.TransferItemToWorkspace(ctx, srcWS, itemID, dstWS, userID)
+
+		assert.ErrorIs(t, err, ErrTransferArchivedItem)
+	})
+
+	t.Run("leaves the source alone when the copy fails", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		svc := NewService(mockRepo)
+		svc.SetRoleLookup(fakeRoles{srcWS: member.RoleOwner, dstWS: member.RoleOwner})
+
+		expectSource(mockRepo)
+		mockRepo.On("ItemSKUExists", ctx, dstWS, "SKU-1").Return(false, nil)
+		mockRepo.On("GetLocationByName", ctx, dstWS, "Garage").Return(&queries.WarehouseLocation{ID: uuid.New(), Name: "Garage"}, nil)
+		mockRepo.On("CreateItem", ctx, mock.Anything).Return(queries.WarehouseItem{}, errors.New("db down"))
+
+		_, err := svc.TransferItemToWorkspace(ctx, srcWS, itemID, dstWS, userID)
+
+		assert.ErrorContains(t, err, "db down")
+		mockRepo.AssertNotCalled(t, "ArchiveItem", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("is unavailable without a role lookup", func(t *testing.T) {
+		svc := NewService(new(MockRepository))
+
+		_, err := svc.TransferItemToWorkspace(ctx, srcWS, itemID, dstWS, userID)
+
+		assert.ErrorIs(t, err, ErrTransferUnavailable)
+		assert.False(t, shared.IsNotFound(err))
+	})
+}
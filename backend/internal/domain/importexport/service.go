@@ -15,6 +15,7 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 
 	"github.com/antti/home-warehouse/go-backend/internal/infra/queries"
+	"github.com/antti/home-warehouse/go-backend/internal/infra/storage"
 )
 
 const msgNameIsRequired = "name is required"
@@ -23,6 +24,9 @@ const msgNameIsRequired = "name is required"
 type ServiceInterface interface {
 	Export(ctx context.Context, opts ExportOptions) ([]byte, *ExportMetadata, error)
 	Import(ctx context.Context, workspaceID uuid.UUID, entityType EntityType, format Format, data []byte) (*ImportResult, error)
+	ExportItem(ctx context.Context, workspaceID, itemID uuid.UUID) (*ItemBundle, error)
+	ImportItem(ctx context.Context, targetWorkspaceID uuid.UUID, bundle *ItemBundle, opts ItemImportOptions) (*ItemImportResult, error)
+	TransferItemToWorkspace(ctx context.Context, srcWorkspaceID, itemID, dstWorkspaceID, userID uuid.UUID) (*ItemImportResult, error)
 }
 
 // Repository defines the interface for import/export data access
@@ -56,16 +60,36 @@ type Repository interface {
 	// Borrowers
 	ListAllBorrowers(ctx context.Context, workspaceID uuid.UUID, includeArchived bool) ([]queries.WarehouseBorrower, error)
 	CreateBorrower(ctx context.Context, params queries.CreateBorrowerParams) (queries.WarehouseBorrower, error)
+
+	// Single-item bundles
+	GetItem(ctx context.Context, workspaceID, itemID uuid.UUID) (*queries.WarehouseItem, error)
+	ItemSKUExists(ctx context.Context, workspaceID uuid.UUID, sku string) (bool, error)
+	GetCategory(ctx context.Context, workspaceID, categoryID uuid.UUID) (*queries.WarehouseCategory, error)
+	GetLocation(ctx context.Context, workspaceID, locationID uuid.UUID) (*queries.WarehouseLocation, error)
+	GetContainer(ctx context.Context, workspaceID, containerID uuid.UUID) (*queries.WarehouseContainer, error)
+	ListInventoryByItem(ctx context.Context, workspaceID, itemID uuid.UUID) ([]queries.WarehouseInventory, error)
+	CreateInventory(ctx context.Context, params queries.CreateInventoryParams) (queries.WarehouseInventory, error)
+	ListItemPhotos(ctx context.Context, workspaceID, itemID uuid.UUID) ([]queries.WarehouseItemPhoto, error)
+	CreateItemPhoto(ctx context.Context, params queries.CreateItemPhotoParams) (queries.WarehouseItemPhoto, error)
+
+	// Item transfers
+	ArchiveItem(ctx context.Context, workspaceID, itemID uuid.UUID) error
+	ArchiveInventory(ctx context.Context, workspaceID, inventoryID uuid.UUID) error
+	DeleteItemPhotos(ctx context.Context, workspaceID, itemID uuid.UUID) error
 }
 
 // Service handles import/export operations
 type Service struct {
-	repo Repository
+	repo   Repository
+	tx     Transactor
+	roles  RoleLookup
+	layout *storage.PathLayout
+	photos PhotoStore
 }
 
 // NewService creates a new import/export service
 func NewService(repo Repository) *Service {
-	return &Service{repo: repo}
+	return &Service{repo: repo, tx: noopTransactor{}}
 }
 
 // Export exports entities to the specified format
@@ -625,12 +649,13 @@ func (s *Service) importBorrower(ctx context.Context, workspaceID uuid.UUID, row
 	}
 
 	_, err := s.repo.CreateBorrower(ctx, queries.CreateBorrowerParams{
-		ID:          uuid.New(),
-		WorkspaceID: workspaceID,
-		Name:        name,
-		Email:       stringToPtr(row["email"]),
-		Phone:       stringToPtr(row["phone"]),
-		Notes:       stringToPtr(row["notes"]),
+		ID:              uuid.New(),
+		WorkspaceID:     workspaceID,
+		Name:            name,
+		Email:           stringToPtr(row["email"]),
+		Phone:           stringToPtr(row["phone"]),
+		Notes:           stringToPtr(row["notes"]),
+		ReminderChannel: "email",
 	})
 	return err
 }
@@ -697,6 +722,7 @@ func (s *Service) importItem(ctx context.Context, workspaceID uuid.UUID, row map
 		Manufacturer: stringToPtr(row["manufacturer"]),
 		Barcode:      stringToPtr(row["barcode"]),
 		ShortCode:    row["short_code"],
+		Loanable:     true,
 	})
 	return err
 }
@@ -128,6 +128,84 @@ func (m *MockRepository) CreateBorrower(ctx context.Context, params queries.Crea
 	return args.Get(0).(queries.WarehouseBorrower), args.Error(1)
 }
 
+func (m *MockRepository) GetItem(ctx context.Context, workspaceID, itemID uuid.UUID) (*queries.WarehouseItem, error) {
+	args := m.Called(ctx, workspaceID, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*queries.WarehouseItem), args.Error(1)
+}
+
+func (m *MockRepository) ItemSKUExists(ctx context.Context, workspaceID uuid.UUID, sku string) (bool, error) {
+	args := m.Called(ctx, workspaceID, sku)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRepository) GetCategory(ctx context.Context, workspaceID, categoryID uuid.UUID) (*queries.WarehouseCategory, error) {
+	args := m.Called(ctx, workspaceID, categoryID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*queries.WarehouseCategory), args.Error(1)
+}
+
+func (m *MockRepository) GetLocation(ctx context.Context, workspaceID, locationID uuid.UUID) (*queries.WarehouseLocation, error) {
+	args := m.Called(ctx, workspaceID, locationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*queries.WarehouseLocation), args.Error(1)
+}
+
+func (m *MockRepository) GetContainer(ctx context.Context, workspaceID, containerID uuid.UUID) (*queries.WarehouseContainer, error) {
+	args := m.Called(ctx, workspaceID, containerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*queries.WarehouseContainer), args.Error(1)
+}
+
+func (m *MockRepository) ListInventoryByItem(ctx context.Context, workspaceID, itemID uuid.UUID) ([]queries.WarehouseInventory, error) {
+	args := m.Called(ctx, workspaceID, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]queries.WarehouseInventory), args.Error(1)
+}
+
+func (m *MockRepository) CreateInventory(ctx context.Context, params queries.CreateInventoryParams) (queries.WarehouseInventory, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).(queries.WarehouseInventory), args.Error(1)
+}
+
+func (m *MockRepository) ListItemPhotos(ctx context.Context, workspaceID, itemID uuid.UUID) ([]queries.WarehouseItemPhoto, error) {
+	args := m.Called(ctx, workspaceID, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]queries.WarehouseItemPhoto), args.Error(1)
+}
+
+func (m *MockRepository) CreateItemPhoto(ctx context.Context, params queries.CreateItemPhotoParams) (queries.WarehouseItemPhoto, error) {
+	args := m.Called(ctx, params)
+	return args.Get(0).(queries.WarehouseItemPhoto), args.Error(1)
+}
+
+func (m *MockRepository) ArchiveItem(ctx context.Context, workspaceID, itemID uuid.UUID) error {
+	args := m.Called(ctx, workspaceID, itemID)
+	return args.Error(0)
+}
+
+func (m *MockRepository) ArchiveInventory(ctx context.Context, workspaceID, inventoryID uuid.UUID) error {
+	args := m.Called(ctx, workspaceID, inventoryID)
+	return args.Error(0)
+}
+
+func (m *MockRepository) DeleteItemPhotos(ctx context.Context, workspaceID, itemID uuid.UUID) error {
+	args := m.Called(ctx, workspaceID, itemID)
+	return args.Error(0)
+}
+
 // Helper functions for creating test data
 func ptrString(s string) *string {
 	return &s
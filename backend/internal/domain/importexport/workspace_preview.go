@@ -0,0 +1,193 @@
+package importexport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/antti/home-warehouse/go-backend/internal/infra/queries"
+)
+
+// maxPlanConflicts caps how many conflicts an entity plan lists by name;
+// the counts always cover every record.
+const maxPlanConflicts = 20
+
+// xlsxMagic is the zip local-file-header signature every xlsx starts with.
+var xlsxMagic = []byte("PK\x03\x04")
+
+// ImportPlan is a dry-run of a workspace import: what the backup holds and
+// how much of it already exists in the target workspace.
+type ImportPlan struct {
+	Format           Format             `json:"format"`
+	TotalNew         int                `json:"total_new"`
+	TotalConflicting int                `json:"total_conflicting"`
+	Entities         []EntityImportPlan `json:"entities"`
+}
+
+// EntityImportPlan summarizes one entity type of an ImportPlan. New records
+// would be created; conflicting ones match an existing record by natural key
+// and would fail or duplicate on import. Inventory, loans and attachments
+// have no natural key and are always counted as new.
+type EntityImportPlan struct {
+	EntityType  string           `json:"entity_type"`
+	Total       int              `json:"total"`
+	New         int              `json:"new"`
+	Conflicting int              `json:"conflicting"`
+	Conflicts   []ImportConflict `json:"conflicts,omitempty"`
+}
+
+// ImportConflict is an incoming record that matches an existing one.
+type ImportConflict struct {
+	Name       string    `json:"name"`
+	MatchedBy  string    `json:"matched_by" enum:"sku,short_code,name"`
+	Value      string    `json:"value"`
+	ExistingID uuid.UUID `json:"existing_id"`
+}
+
+// naturalKey is one field a record can be matched on.
+type naturalKey struct {
+	field string
+	value string
+}
+
+func skuKey(sku string) naturalKey        { return naturalKey{"sku", strings.TrimSpace(sku)} }
+func shortCodeKey(code string) naturalKey { return naturalKey{"short_code", strings.TrimSpace(code)} }
+
+// nameKey matches names case-insensitively so "Garage" and "garage " count
+// as the same location.
+func nameKey(name string) naturalKey {
+	return naturalKey{"name", strings.ToLower(strings.TrimSpace(name))}
+}
+
+// PreviewImportWorkspace reads a workspace backup (xlsx or JSON, detected from
+// its content) and reports, without writing anything, how many records of
+// each entity type are new to the target workspace and how many conflict
+// with existing ones. Items match on SKU then short code; locations and
+// containers on short code then name; everything else on name.
+func (s *WorkspaceBackupService) PreviewImportWorkspace(ctx context.Context, targetWorkspaceID uuid.UUID, r io.Reader) (*ImportPlan, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read import file: %w", err)
+	}
+
+	format := FormatJSON
+	if bytes.HasPrefix(data, xlsxMagic) {
+		format = FormatExcel
+	}
+
+	var incoming *WorkspaceData
+	switch format {
+	case FormatExcel:
+		incoming, err = s.parseExcel(data)
+	default:
+		err = json.Unmarshal(data, &incoming)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse import file: %w", err)
+	}
+	if incoming == nil {
+		incoming = &WorkspaceData{}
+	}
+
+	existing, err := s.fetchAllData(ctx, targetWorkspaceID, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch workspace data: %w", err)
+	}
+
+	plan := &ImportPlan{
+		Format: format,
+		Entities: []EntityImportPlan{
+			planEntities("categories", incoming.Categories, existing.Categories,
+				func(c queries.WarehouseCategory) (uuid.UUID, string, []naturalKey) {
+					return c.ID, c.Name, []naturalKey{nameKey(c.Name)}
+				}),
+			planEntities("labels", incoming.Labels, existing.Labels,
+				func(l queries.WarehouseLabel) (uuid.UUID, string, []naturalKey) {
+					return l.ID, l.Name, []naturalKey{nameKey(l.Name)}
+				}),
+			planEntities("companies", incoming.Companies, existing.Companies,
+				func(c queries.WarehouseCompany) (uuid.UUID, string, []naturalKey) {
+					return c.ID, c.Name, []naturalKey{nameKey(c.Name)}
+				}),
+			planEntities("locations", incoming.Locations, existing.Locations,
+				func(l queries.WarehouseLocation) (uuid.UUID, string, []naturalKey) {
+					return l.ID, l.Name, []naturalKey{shortCodeKey(l.ShortCode), nameKey(l.Name)}
+				}),
+			planEntities("borrowers", incoming.Borrowers, existing.Borrowers,
+				func(b queries.WarehouseBorrower) (uuid.UUID, string, []naturalKey) {
+					return b.ID, b.Name, []naturalKey{nameKey(b.Name)}
+				}),
+			planEntities("items", incoming.Items, existing.Items,
+				func(i queries.WarehouseItem) (uuid.UUID, string, []naturalKey) {
+					return i.ID, i.Name, []naturalKey{skuKey(i.Sku), shortCodeKey(i.ShortCode)}
+				}),
+			planEntities("containers", incoming.Containers, existing.Containers,
+				func(c queries.WarehouseContainer) (uuid.UUID, string, []naturalKey) {
+					return c.ID, c.Name, []naturalKey{shortCodeKey(c.ShortCode), nameKey(c.Name)}
+				}),
+			newOnlyPlan("inventory", len(incoming.Inventory)),
+			newOnlyPlan("loans", len(incoming.Loans)),
+			newOnlyPlan("attachments", len(incoming.Attachments)),
+		},
+	}
+	for _, e := range plan.Entities {
+		plan.TotalNew += e.New
+		plan.TotalConflicting += e.Conflicting
+	}
+
+	return plan, nil
+}
+
+// planEntities classifies each incoming record as new or conflicting. keys
+// returns a record's ID, display name and natural keys in match priority
+// order; blank key values never match.
+func planEntities[T any](entityType string, incoming, existing []T, keys func(T) (uuid.UUID, string, []naturalKey)) EntityImportPlan {
+	index := make(map[naturalKey]uuid.UUID)
+	for _, e := range existing {
+		id, _, ks := keys(e)
+		for _, k := range ks {
+			if k.value == "" {
+				continue
+			}
+			if _, taken := index[k]; !taken {
+				index[k] = id
+			}
+		}
+	}
+
+	plan := EntityImportPlan{EntityType: entityType, Total: len(incoming)}
+	for _, rec := range incoming {
+		_, name, ks := keys(rec)
+		conflict, ok := matchNaturalKeys(index, name, ks)
+		if !ok {
+			plan.New++
+			continue
+		}
+		plan.Conflicting++
+		if len(plan.Conflicts) < maxPlanConflicts {
+			plan.Conflicts = append(plan.Conflicts, conflict)
+		}
+	}
+	return plan
+}
+
+func matchNaturalKeys(index map[naturalKey]uuid.UUID, name string, keys []naturalKey) (ImportConflict, bool) {
+	for _, k := range keys {
+		if k.value == "" {
+			continue
+		}
+		if id, ok := index[k]; ok {
+			return ImportConflict{Name: name, MatchedBy: k.field, Value: k.value, ExistingID: id}, true
+		}
+	}
+	return ImportConflict{}, false
+}
+
+func newOnlyPlan(entityType string, n int) EntityImportPlan {
+	return EntityImportPlan{EntityType: entityType, Total: n, New: n}
+}
@@ -0,0 +1,175 @@
+package importexport
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/infra/queries"
+)
+
+// expectExistingData sets up fetchAllData for the target workspace with
+// archived records included.
+func expectExistingData(m *MockWorkspaceBackupQueries, workspaceID uuid.UUID, data WorkspaceData) {
+	m.On("ListAllCategories", mock.Anything, queries.ListAllCategoriesParams{WorkspaceID: workspaceID, IncludeArchived: true}).Return(data.Categories, nil)
+	m.On("ListAllLabels", mock.Anything, queries.ListAllLabelsParams{WorkspaceID: workspaceID, IncludeArchived: true}).Return(data.Labels, nil)
+	m.On("ListAllCompanies", mock.Anything, queries.ListAllCompaniesParams{WorkspaceID: workspaceID, IncludeArchived: true}).Return(data.Companies, nil)
+	m.On("ListAllLocations", mock.Anything, queries.ListAllLocationsParams{WorkspaceID: workspaceID, IncludeArchived: true}).Return(data.Locations, nil)
+	m.On("ListAllBorrowers", mock.Anything, queries.ListAllBorrowersParams{WorkspaceID: workspaceID, IncludeArchived: true}).Return(data.Borrowers, nil)
+	m.On("ListAllItems", mock.Anything, queries.ListAllItemsParams{WorkspaceID: workspaceID, IncludeArchived: true}).Return(data.Items, nil)
+	m.On("ListAllContainers", mock.Anything, queries.ListAllContainersParams{WorkspaceID: workspaceID, IncludeArchived: true}).Return(data.Containers, nil)
+	m.On("ListAllInventory", mock.Anything, workspaceID).Return(data.Inventory, nil)
+	m.On("ListAllLoans", mock.Anything, workspaceID).Return(data.Loans, nil)
+	m.On("ListAllAttachments", mock.Anything, workspaceID).Return(data.Attachments, nil)
+}
+
+func findEntityPlan(t *testing.T, plan *ImportPlan, entityType string) EntityImportPlan {
+	t.Helper()
+	for _, e := range plan.Entities {
+		if e.EntityType == entityType {
+			return e
+		}
+	}
+	t.Fatalf("no plan for entity type %q", entityType)
+	return EntityImportPlan{}
+}
+
+func TestPreviewImportWorkspace_JSON(t *testing.T) {
+	ctx := context.Background()
+	targetWS := uuid.New()
+	sourceWS := uuid.New()
+
+	existingLocation := makeTestLocation(targetWS, "Garage")
+	existingItem := makeTestItem(targetWS, "Drill", "SKU-001")
+	existingCategory := makeTestCategory(targetWS, "Tools")
+
+	mockQueries := new(MockWorkspaceBackupQueries)
+	expectExistingData(mockQueries, targetWS, WorkspaceData{
+		Categories: []queries.WarehouseCategory{existingCategory},
+		Locations:  []queries.WarehouseLocation{existingLocation},
+		Items:      []queries.WarehouseItem{existingItem},
+	})
+
+	// Incoming records: one item matches by SKU, one by short code, one is new;
+	// the location matches by name despite different case and short code.
+	skuMatch := makeTestItem(sourceWS, "Drill (old)", "SKU-001")
+	skuMatch.ShortCode = "OTHER-1"
+	codeMatch := makeTestItem(sourceWS, "Renamed", "SKU-999")
+	codeMatch.ShortCode = existingItem.ShortCode
+	newItem := makeTestItem(sourceWS, "Saw", "SKU-002")
+	newItem.ShortCode = "ITM-777"
+	location := makeTestLocation(sourceWS, " garage")
+	location.ShortCode = "LOC-999"
+
+	backup := WorkspaceData{
+		Categories: []queries.WarehouseCategory{makeTestCategory(sourceWS, "Kitchen")},
+		Locations:  []queries.WarehouseLocation{location},
+		Items:      []queries.WarehouseItem{skuMatch, codeMatch, newItem},
+		Inventory:  []queries.WarehouseInventory{makeTestInventory(sourceWS, newItem.ID, location.ID)},
+	}
+	data, err := json.Marshal(backup)
+	require.NoError(t, err)
+
+	plan, err := NewWorkspaceBackupService(mockQueries).PreviewImportWorkspace(ctx, targetWS, bytes.NewReader(data))
+
+	require.NoError(t, err)
+	assert.Equal(t, FormatJSON, plan.Format)
+
+	items := findEntityPlan(t, plan, "items")
+	assert.Equal(t, 3, items.Total)
+	assert.Equal(t, 1, items.New)
+	assert.Equal(t, 2, items.Conflicting)
+	require.Len(t, items.Conflicts, 2)
+	assert.Equal(t, "sku", items.Conflicts[0].MatchedBy)
+	assert.Equal(t, existingItem.ID, items.Conflicts[0].ExistingID)
+	assert.Equal(t, "short_code", items.Conflicts[1].MatchedBy)
+
+	locations := findEntityPlan(t, plan, "locations")
+	assert.Equal(t, 1, locations.Conflicting)
+	assert.Equal(t, "name", locations.Conflicts[0].MatchedBy)
+	assert.Equal(t, existingLocation.ID, locations.Conflicts[0].ExistingID)
+
+	categories := findEntityPlan(t, plan, "categories")
+	assert.Equal(t, 1, categories.New)
+	assert.Zero(t, categories.Conflicting)
+
+	inventory := findEntityPlan(t, plan, "inventory")
+	assert.Equal(t, 1, inventory.New)
+
+	assert.Equal(t, 3, plan.TotalNew)
+	assert.Equal(t, 3, plan.TotalConflicting)
+
+	// Preview never writes.
+	mockQueries.AssertNotCalled(t, "CreateItem", mock.Anything, mock.Anything)
+	mockQueries.AssertNotCalled(t, "CreateLocation", mock.Anything, mock.Anything)
+	mockQueries.AssertNotCalled(t, "CreateCategory", mock.Anything, mock.Anything)
+}
+
+func TestPreviewImportWorkspace_DetectsExcel(t *testing.T) {
+	ctx := context.Background()
+	targetWS := uuid.New()
+
+	mockQueries := new(MockWorkspaceBackupQueries)
+	expectExistingData(mockQueries, targetWS, WorkspaceData{
+		Labels: []queries.WarehouseLabel{makeTestLabel(targetWS, "Fragile")},
+	})
+
+	excelData := createTestExcelFile(t, map[string][][]string{
+		"Labels": {
+			{"ID", "Name", "Color", "Description"},
+			{uuid.New().String(), "Fragile", "#FF0000", ""},
+			{uuid.New().String(), "Heavy", "#00FF00", ""},
+		},
+	})
+
+	plan, err := NewWorkspaceBackupService(mockQueries).PreviewImportWorkspace(ctx, targetWS, bytes.NewReader(excelData))
+
+	require.NoError(t, err)
+	assert.Equal(t, FormatExcel, plan.Format)
+	labels := findEntityPlan(t, plan, "labels")
+	assert.Equal(t, 2, labels.Total)
+	assert.Equal(t, 1, labels.New)
+	assert.Equal(t, 1, labels.Conflicting)
+}
+
+func TestPreviewImportWorkspace_InvalidFile(t *testing.T) {
+	mockQueries := new(MockWorkspaceBackupQueries)
+
+	_, err := NewWorkspaceBackupService(mockQueries).PreviewImportWorkspace(context.Background(), uuid.New(), bytes.NewReader([]byte("not json")))
+
+	assert.ErrorContains(t, err, "failed to parse import file")
+	mockQueries.AssertNotCalled(t, "ListAllItems", mock.Anything, mock.Anything)
+}
+
+func TestPreviewImportWorkspace_FetchError(t *testing.T) {
+	targetWS := uuid.New()
+	mockQueries := new(MockWorkspaceBackupQueries)
+	mockQueries.On("ListAllCategories", mock.Anything, mock.Anything).Return(nil, errors.New("db down"))
+
+	_, err := NewWorkspaceBackupService(mockQueries).PreviewImportWorkspace(context.Background(), targetWS, bytes.NewReader([]byte("{}")))
+
+	assert.ErrorContains(t, err, "failed to fetch workspace data")
+}
+
+func TestPlanEntities_CapsListedConflicts(t *testing.T) {
+	ws := uuid.New()
+	existing := []queries.WarehouseLabel{makeTestLabel(ws, "Dup")}
+	incoming := make([]queries.WarehouseLabel, maxPlanConflicts+5)
+	for i := range incoming {
+		incoming[i] = makeTestLabel(ws, "dup")
+	}
+
+	plan := planEntities("labels", incoming, existing, func(l queries.WarehouseLabel) (uuid.UUID, string, []naturalKey) {
+		return l.ID, l.Name, []naturalKey{nameKey(l.Name)}
+	})
+
+	assert.Equal(t, maxPlanConflicts+5, plan.Conflicting)
+	assert.Len(t, plan.Conflicts, maxPlanConflicts)
+}
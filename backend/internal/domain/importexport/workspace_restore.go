@@ -731,14 +731,22 @@ func (s *WorkspaceBackupService) importBorrowers(ctx context.Context, workspaceI
 	errors := make([]ImportError, 0)
 
 	for _, borrower := range borrowers {
+		// Spreadsheet backups and backups from before reminder channels
+		// carry none; those borrowers get the default.
+		reminderChannel := borrower.ReminderChannel
+		if reminderChannel == "" {
+			reminderChannel = "email"
+		}
+
 		newID := uuid.New()
 		_, err := s.queries.CreateBorrower(ctx, queries.CreateBorrowerParams{
-			ID:          newID,
-			WorkspaceID: workspaceID,
-			Name:        borrower.Name,
-			Email:       borrower.Email,
-			Phone:       borrower.Phone,
-			Notes:       borrower.Notes,
+			ID:              newID,
+			WorkspaceID:     workspaceID,
+			Name:            borrower.Name,
+			Email:           borrower.Email,
+			Phone:           borrower.Phone,
+			Notes:           borrower.Notes,
+			ReminderChannel: reminderChannel,
 		})
 
 		if err != nil {
@@ -792,6 +800,7 @@ func (s *WorkspaceBackupService) importItems(ctx context.Context, workspaceID uu
 			Barcode:       item.Barcode,
 			ShortCode:     item.ShortCode,
 			MinStockLevel: item.MinStockLevel,
+			Loanable:      true,
 		})
 
 		if err != nil {
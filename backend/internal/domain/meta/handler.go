@@ -0,0 +1,60 @@
+// Package meta serves server metadata the frontend would otherwise hardcode,
+// such as the enum vocabularies.
+package meta
+
+import (
+	"context"
+	"strings"
+
+	"github.com/danielgtaylor/huma/v2"
+
+	appMiddleware "github.com/antti/home-warehouse/go-backend/internal/api/middleware"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/auth/member"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/inventory"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/pendingchange"
+)
+
+// RegisterRoutes registers the metadata routes.
+func RegisterRoutes(api huma.API) {
+	enums := Enums()
+	etag := `"` + enums.Version + `"`
+
+	huma.Get(api, "/meta/enums", func(ctx context.Context, input *GetEnumsInput) (*GetEnumsOutput, error) {
+		if err := appMiddleware.NotModified(input.IfNoneMatch, etag); err != nil {
+			return nil, err
+		}
+		return &GetEnumsOutput{ETag: etag, Body: enums}, nil
+	})
+}
+
+// Enums builds the enum vocabularies from the domain constants. Version is
+// a hash of the lists, so it only changes when a list does.
+func Enums() EnumsResponse {
+	enums := EnumsResponse{
+		Conditions:         inventory.Conditions(),
+		Statuses:           inventory.Statuses(),
+		MemberRoles:        member.Roles(),
+		PendingActions:     pendingchange.Actions(),
+		PendingEntityTypes: pendingchange.EntityTypes(),
+	}
+	enums.Version = strings.Trim(appMiddleware.ETag(enums), `"`)
+	return enums
+}
+
+type GetEnumsInput struct {
+	IfNoneMatch string `header:"If-None-Match" doc:"ETag from an earlier response; a 304 with no body is returned while it still matches"`
+}
+
+type GetEnumsOutput struct {
+	ETag string `header:"ETag"`
+	Body EnumsResponse
+}
+
+type EnumsResponse struct {
+	Version            string                 `json:"version" doc:"Changes whenever any list changes; cache the lists under it"`
+	Conditions         []inventory.Condition  `json:"conditions" doc:"Inventory conditions, best first"`
+	Statuses           []inventory.Status     `json:"statuses" doc:"Inventory statuses"`
+	MemberRoles        []member.Role          `json:"member_roles" doc:"Workspace member roles, most privileged first"`
+	PendingActions     []pendingchange.Action `json:"pending_change_actions" doc:"Actions a pending change can request"`
+	PendingEntityTypes []string               `json:"entity_types" doc:"Entity types routed through the approval pipeline"`
+}
@@ -0,0 +1,49 @@
+package meta_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/auth/member"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/meta"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/inventory"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/pendingchange"
+	"github.com/antti/home-warehouse/go-backend/internal/testutil"
+)
+
+func TestEnumsHandler(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	meta.RegisterRoutes(setup.API)
+
+	t.Run("returns the domain vocabularies", func(t *testing.T) {
+		rec := setup.Get("/meta/enums")
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		var body meta.EnumsResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, inventory.Conditions(), body.Conditions)
+		assert.Equal(t, inventory.Statuses(), body.Statuses)
+		assert.Equal(t, member.Roles(), body.MemberRoles)
+		assert.Equal(t, pendingchange.Actions(), body.PendingActions)
+		assert.Equal(t, pendingchange.EntityTypes(), body.PendingEntityTypes)
+		assert.NotEmpty(t, body.Version)
+		assert.Equal(t, `"`+body.Version+`"`, rec.Header().Get("ETag"))
+	})
+
+	t.Run("returns 304 while the version matches", func(t *testing.T) {
+		etag := `"` + meta.Enums().Version + `"`
+
+		rec := setup.GetWithHeader("/meta/enums", "If-None-Match", etag)
+
+		testutil.AssertStatus(t, rec, http.StatusNotModified)
+		assert.Empty(t, rec.Body.Bytes())
+	})
+}
+
+func TestEnums_VersionIsStable(t *testing.T) {
+	assert.Equal(t, meta.Enums().Version, meta.Enums().Version)
+}
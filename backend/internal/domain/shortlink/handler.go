@@ -144,24 +144,36 @@ func claimPath(locale, code string) string {
 	return "/" + locale + "/dashboard/claim/" + code
 }
 
-// entityPath maps a single match to its dashboard destination. Containers and
+// entityPath maps a single match to its dashboard destination.
+func entityPath(locale string, m Match) string {
+	return "/" + locale + dashboardPath(m)
+}
+
+// dashboardPath is the locale-less dashboard path for a match. Containers and
 // locations have no [id] detail route (list-only), so they deep-link via a
 // focus query param the list page reads; items have a real detail route.
-func entityPath(locale string, m Match) string {
+func dashboardPath(m Match) string {
 	switch m.Type {
 	case TypeItem:
-		return "/" + locale + "/dashboard/items/" + m.ID.String()
+		return "/dashboard/items/" + m.ID.String()
 	case TypeContainer:
-		return "/" + locale + "/dashboard/containers?focus=" + m.ID.String()
+		return "/dashboard/containers?focus=" + m.ID.String()
 	case TypeLocation:
-		return "/" + locale + "/dashboard/locations?focus=" + m.ID.String()
+		return "/dashboard/locations?focus=" + m.ID.String()
 	default:
 		// Unknown type should never happen (repo emits only the three tags);
 		// fall back to the claim wizard rather than a broken link.
-		return "/" + locale + "/dashboard/claim/"
+		return "/dashboard/claim/"
 	}
 }
 
+// AppLink is the absolute URL under appURL (the frontend base URL) that opens
+// the entity in the app, for printing into QR labels. Unlike /r/{code} it
+// names the entity directly rather than going through the scan resolver.
+func AppLink(appURL string, entityType string, id uuid.UUID) string {
+	return strings.TrimRight(appURL, "/") + dashboardPath(Match{Type: entityType, ID: id})
+}
+
 // resolveLocale picks the redirect locale: NEXT_LOCALE cookie -> first
 // Accept-Language tag -> "en".
 func resolveLocale(r *http.Request) string {
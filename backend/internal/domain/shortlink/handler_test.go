@@ -251,3 +251,20 @@ func TestRedirect_WorkspaceLookupError_RedirectsToClaim(t *testing.T) {
 		t.Fatalf("Location = %q, want claim fallback", got)
 	}
 }
+
+func TestAppLink(t *testing.T) {
+	id := uuid.New()
+
+	tests := []struct {
+		appURL, entityType, want string
+	}{
+		{"https://app.example.com", TypeLocation, "https://app.example.com/dashboard/locations?focus=" + id.String()},
+		{"https://app.example.com/", TypeContainer, "https://app.example.com/dashboard/containers?focus=" + id.String()},
+		{"http://localhost:3000", TypeItem, "http://localhost:3000/dashboard/items/" + id.String()},
+	}
+	for _, tt := range tests {
+		if got := AppLink(tt.appURL, tt.entityType, id); got != tt.want {
+			t.Errorf("AppLink(%q, %q) = %q, want %q", tt.appURL, tt.entityType, got, tt.want)
+		}
+	}
+}
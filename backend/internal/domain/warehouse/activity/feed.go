@@ -0,0 +1,147 @@
+package activity
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// FeedFilter narrows the workspace activity feed. Nil fields do not filter.
+type FeedFilter struct {
+	EntityType *EntityType
+	UserID     *uuid.UUID
+}
+
+// FeedEntry is one row of the activity feed: the log entry and the name of
+// the member who made the change, empty for system changes or removed users.
+type FeedEntry struct {
+	Log       *ActivityLog
+	ActorName string
+}
+
+// ListFeed returns the workspace's activity newest first, with the total
+// number of entries matching filter.
+func (s *Service) ListFeed(ctx context.Context, workspaceID uuid.UUID, filter FeedFilter, pagination shared.Pagination) ([]FeedEntry, int, error) {
+	return s.repo.FindFeed(ctx, workspaceID, filter, pagination)
+}
+
+// sensitiveFields are the change and metadata keys holding prices and values.
+// Viewers do not see them (see RedactFor).
+var sensitiveFields = map[string]bool{
+	"purchase_price":   true,
+	"unit_price":       true,
+	"price_estimate":   true,
+	"cost":             true,
+	"total_cost_cents": true,
+	"total_value":      true,
+}
+
+// RedactFor returns the changes and metadata of the entry that a member with
+// role may see. Viewers get copies without price and value fields; other
+// roles get the maps unchanged.
+func (e FeedEntry) RedactFor(role string) (changes, metadata map[string]interface{}) {
+	changes, metadata = e.Log.Changes(), e.Log.Metadata()
+	if role != "viewer" {
+		return changes, metadata
+	}
+	return withoutSensitiveFields(changes), withoutSensitiveFields(metadata)
+}
+
+func withoutSensitiveFields(fields map[string]interface{}) map[string]interface{} {
+	if fields == nil {
+		return nil
+	}
+	out := make(map[string]interface{}, len(fields))
+	for k, v := range fields {
+		if !sensitiveFields[k] {
+			out[k] = v
+		}
+	}
+	return out
+}
+
+// actionVerbs are the past-tense verbs used in feed summaries.
+var actionVerbs = map[Action]string{
+	ActionCreate: "created",
+	ActionUpdate: "updated",
+	ActionDelete: "deleted",
+	ActionMove:   "moved",
+	ActionLoan:   "started",
+	ActionReturn: "returned",
+}
+
+// entityNouns name each entity type in feed summaries.
+var entityNouns = map[EntityType]string{
+	EntityItem:      "item",
+	EntityInventory: "inventory entry",
+	EntityLocation:  "location",
+	EntityContainer: "container",
+	EntityCategory:  "category",
+	EntityLabel:     "label",
+	EntityLoan:      "loan",
+	EntityBorrower:  "borrower",
+	EntityCompany:   "company",
+}
+
+// Summary is a one-line description of the entry, e.g.
+// `Alice updated item "Cordless drill"`.
+func (e FeedEntry) Summary() string {
+	actor := e.ActorName
+	if actor == "" {
+		// Events published by a signed-in user carry their display name.
+		actor, _ = e.Log.Metadata()["user_name"].(string)
+	}
+	if actor == "" {
+		if e.Log.UserID() == nil {
+			actor = "System"
+		} else {
+			actor = "Someone"
+		}
+	}
+
+	verb, ok := actionVerbs[e.Log.Action()]
+	if !ok {
+		verb = strings.ToLower(string(e.Log.Action()))
+	}
+	noun, ok := entityNouns[e.Log.EntityType()]
+	if !ok {
+		noun = strings.ToLower(string(e.Log.EntityType()))
+	}
+
+	if name := e.Log.EntityName(); name != "" {
+		return fmt.Sprintf("%s %s %s %q", actor, verb, noun, name)
+	}
+	article := "a"
+	if strings.ContainsRune("aeiou", rune(noun[0])) {
+		article = "an"
+	}
+	return fmt.Sprintf("%s %s %s %s", actor, verb, article, noun)
+}
+
+// Link is the app path showing the entry's entity, or "" when there is
+// nothing to open (the entity was deleted or has no page of its own).
+func (e FeedEntry) Link() string {
+	if e.Log.Action() == ActionDelete {
+		return ""
+	}
+	id := e.Log.EntityID()
+	switch e.Log.EntityType() {
+	case EntityItem:
+		return "/items/" + id.String()
+	case EntityInventory:
+		return "/inventory/" + id.String() + "/edit"
+	case EntityCategory:
+		return "/taxonomy/categories/" + id.String() + "/edit"
+	case EntityLocation, EntityContainer, EntityLabel:
+		return "/taxonomy"
+	case EntityLoan:
+		return "/loans"
+	case EntityBorrower:
+		return "/borrowers/" + id.String()
+	}
+	return ""
+}
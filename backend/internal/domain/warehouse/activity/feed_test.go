@@ -0,0 +1,122 @@
+package activity
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+func TestService_ListFeed(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+	entityType := EntityItem
+	filter := FeedFilter{EntityType: &entityType}
+	pagination := shared.Pagination{Page: 1, PageSize: 20}
+
+	repo := new(MockRepository)
+	entries := []FeedEntry{{Log: Reconstruct(uuid.New(), workspaceID, nil, ActionCreate, EntityItem, uuid.New(), "Drill", nil, nil, time.Now())}}
+	repo.On("FindFeed", ctx, workspaceID, filter, pagination).Return(entries, 41, nil)
+
+	got, total, err := NewService(repo).ListFeed(ctx, workspaceID, filter, pagination)
+
+	require.NoError(t, err)
+	assert.Equal(t, entries, got)
+	assert.Equal(t, 41, total)
+}
+
+func TestFeedEntry_Summary(t *testing.T) {
+	userID := uuid.New()
+	entry := func(userID *uuid.UUID, action Action, entityType EntityType, name string, metadata map[string]interface{}) FeedEntry {
+		return FeedEntry{Log: Reconstruct(uuid.New(), uuid.New(), userID, action, entityType, uuid.New(), name, nil, metadata, time.Now())}
+	}
+
+	tests := []struct {
+		name  string
+		entry FeedEntry
+		want  string
+	}{
+		{
+			name:  "actor and entity name",
+			entry: FeedEntry{Log: entry(&userID, ActionUpdate, EntityItem, "Cordless drill", nil).Log, ActorName: "Alice"},
+			want:  `Alice updated item "Cordless drill"`,
+		},
+		{
+			name:  "falls back to the event's user name",
+			entry: entry(&userID, ActionMove, EntityInventory, "", map[string]interface{}{"user_name": "Bob"}),
+			want:  "Bob moved an inventory entry",
+		},
+		{
+			name:  "loan",
+			entry: FeedEntry{Log: entry(&userID, ActionLoan, EntityLoan, "", nil).Log, ActorName: "Alice"},
+			want:  "Alice started a loan",
+		},
+		{
+			name:  "system change",
+			entry: entry(nil, ActionDelete, EntityLocation, "Attic", nil),
+			want:  `System deleted location "Attic"`,
+		},
+		{
+			name:  "unknown member",
+			entry: entry(&userID, ActionCreate, EntityLabel, "Fragile", nil),
+			want:  `Someone created label "Fragile"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.entry.Summary())
+		})
+	}
+}
+
+func TestFeedEntry_Link(t *testing.T) {
+	entityID := uuid.New()
+	link := func(action Action, entityType EntityType) string {
+		return FeedEntry{Log: Reconstruct(uuid.New(), uuid.New(), nil, action, entityType, entityID, "", nil, nil, time.Now())}.Link()
+	}
+
+	assert.Equal(t, "/items/"+entityID.String(), link(ActionUpdate, EntityItem))
+	assert.Equal(t, "/inventory/"+entityID.String()+"/edit", link(ActionMove, EntityInventory))
+	assert.Equal(t, "/taxonomy/categories/"+entityID.String()+"/edit", link(ActionCreate, EntityCategory))
+	assert.Equal(t, "/taxonomy", link(ActionUpdate, EntityContainer))
+	assert.Equal(t, "/borrowers/"+entityID.String(), link(ActionUpdate, EntityBorrower))
+	assert.Equal(t, "/loans", link(ActionReturn, EntityLoan))
+	assert.Empty(t, link(ActionDelete, EntityItem))
+	assert.Empty(t, link(ActionUpdate, EntityCompany))
+}
+
+func TestFeedEntry_RedactFor(t *testing.T) {
+	changes := map[string]interface{}{"purchase_price": 1999, "total_value": 5000, "name": "Drill"}
+	metadata := map[string]interface{}{"unit_price": 12, "user_name": "Alice"}
+	entry := FeedEntry{Log: Reconstruct(uuid.New(), uuid.New(), nil, ActionUpdate, EntityItem, uuid.New(), "Drill", changes, metadata, time.Now())}
+
+	t.Run("viewers do not see prices", func(t *testing.T) {
+		gotChanges, gotMetadata := entry.RedactFor("viewer")
+
+		assert.Equal(t, map[string]interface{}{"name": "Drill"}, gotChanges)
+		assert.Equal(t, map[string]interface{}{"user_name": "Alice"}, gotMetadata)
+		assert.Contains(t, changes, "purchase_price", "the stored log must not be modified")
+	})
+
+	t.Run("members see everything", func(t *testing.T) {
+		gotChanges, gotMetadata := entry.RedactFor("member")
+
+		assert.Equal(t, changes, gotChanges)
+		assert.Equal(t, metadata, gotMetadata)
+	})
+
+	t.Run("nil maps stay nil", func(t *testing.T) {
+		bare := FeedEntry{Log: Reconstruct(uuid.New(), uuid.New(), nil, ActionCreate, EntityItem, uuid.New(), "", nil, nil, time.Now())}
+
+		gotChanges, gotMetadata := bare.RedactFor("viewer")
+
+		assert.Nil(t, gotChanges)
+		assert.Nil(t, gotMetadata)
+	})
+}
@@ -23,34 +23,52 @@ func RegisterRoutes(api huma.API, svc ServiceInterface) {
 	huma.Get(api, "/activity/recent", listRecentActivity(svc))
 }
 
-// listActivity lists workspace activity (optionally filtered by user_id).
-func listActivity(svc ServiceInterface) func(context.Context, *ListActivityInput) (*ListActivityOutput, error) {
-	return func(ctx context.Context, input *ListActivityInput) (*ListActivityOutput, error) {
+// listActivity is the workspace activity feed: newest first, optionally
+// narrowed to one entity type and/or actor, with a summary and app link per
+// entry. Viewers do not see price and value fields.
+func listActivity(svc ServiceInterface) func(context.Context, *ListActivityInput) (*ListActivityFeedOutput, error) {
+	return func(ctx context.Context, input *ListActivityInput) (*ListActivityFeedOutput, error) {
 		workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
 		if !ok {
 			return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
 		}
 
-		pagination := shared.Pagination{Page: input.Page, PageSize: input.Limit}
-
-		var logs []*ActivityLog
-		var err error
-
+		var filter FeedFilter
+		if input.EntityType != "" {
+			entityType := EntityType(input.EntityType)
+			if !entityType.IsValid() {
+				return nil, huma.Error400BadRequest("invalid entity type")
+			}
+			filter.EntityType = &entityType
+		}
 		if input.UserID != "" {
 			userID, parseErr := uuid.Parse(input.UserID)
 			if parseErr != nil {
 				return nil, huma.Error400BadRequest("invalid user_id format")
 			}
-			logs, err = svc.ListByUser(ctx, workspaceID, userID, pagination)
-		} else {
-			logs, err = svc.ListByWorkspace(ctx, workspaceID, pagination)
+			filter.UserID = &userID
 		}
 
+		pagination := shared.Pagination{Page: input.Page, PageSize: input.Limit}
+		entries, total, err := svc.ListFeed(ctx, workspaceID, filter, pagination)
 		if err != nil {
 			return nil, huma.Error500InternalServerError("failed to list activity")
 		}
 
-		return activityListOutput(logs), nil
+		role, _ := appMiddleware.GetRole(ctx)
+		items := make([]ActivityFeedItemResponse, len(entries))
+		for i, entry := range entries {
+			items[i] = toActivityFeedItemResponse(entry, role)
+		}
+
+		return &ListActivityFeedOutput{
+			Body: ActivityFeedResponse{
+				Items:      items,
+				Total:      total,
+				Page:       input.Page,
+				TotalPages: (total + input.Limit - 1) / input.Limit,
+			},
+		}, nil
 	}
 }
 
@@ -130,12 +148,24 @@ func toActivityLogResponse(log *ActivityLog) ActivityLogResponse {
 	}
 }
 
+func toActivityFeedItemResponse(entry FeedEntry, role string) ActivityFeedItemResponse {
+	item := ActivityFeedItemResponse{
+		ActivityLogResponse: toActivityLogResponse(entry.Log),
+		ActorName:           entry.ActorName,
+		Summary:             entry.Summary(),
+		Link:                entry.Link(),
+	}
+	item.Changes, item.Metadata = entry.RedactFor(role)
+	return item
+}
+
 // Request/Response types
 
 type ListActivityInput struct {
-	Page   int    `query:"page" default:"1" minimum:"1"`
-	Limit  int    `query:"limit" default:"50" minimum:"1" maximum:"100"`
-	UserID string `query:"user_id" doc:"Optional user ID to filter by"`
+	Page       int    `query:"page" default:"1" minimum:"1"`
+	Limit      int    `query:"limit" default:"50" minimum:"1" maximum:"100"`
+	UserID     string `query:"user_id" doc:"Optional actor (user ID) to filter by"`
+	EntityType string `query:"entity_type" doc:"Optional entity type to filter by (ITEM, INVENTORY, LOCATION, CONTAINER, CATEGORY, LABEL, LOAN, BORROWER, COMPANY)"`
 }
 
 type ListByEntityInput struct {
@@ -169,3 +199,21 @@ type ActivityLogResponse struct {
 	Metadata    map[string]interface{} `json:"metadata,omitempty"`
 	OccurredAt  time.Time              `json:"occurred_at"`
 }
+
+type ListActivityFeedOutput struct {
+	Body ActivityFeedResponse
+}
+
+type ActivityFeedResponse struct {
+	Items      []ActivityFeedItemResponse `json:"items"`
+	Total      int                        `json:"total"`
+	Page       int                        `json:"page"`
+	TotalPages int                        `json:"total_pages"`
+}
+
+type ActivityFeedItemResponse struct {
+	ActivityLogResponse
+	ActorName string `json:"actor_name,omitempty" doc:"Name of the member who made the change"`
+	Summary   string `json:"summary" doc:"Human-readable one-line description"`
+	Link      string `json:"link,omitempty" doc:"App path of the affected entity; empty when it was deleted or has no page"`
+}
@@ -2,6 +2,7 @@ package activity_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"net/url"
@@ -9,7 +10,9 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/activity"
 	"github.com/antti/home-warehouse/go-backend/internal/shared"
@@ -46,14 +49,19 @@ func (m *MockService) GetRecentActivity(ctx context.Context, workspaceID uuid.UU
 	return args.Get(0).([]*activity.ActivityLog), args.Error(1)
 }
 
+func (m *MockService) ListFeed(ctx context.Context, workspaceID uuid.UUID, filter activity.FeedFilter, pagination shared.Pagination) ([]activity.FeedEntry, int, error) {
+	args := m.Called(ctx, workspaceID, filter, pagination)
+	return args.Get(0).([]activity.FeedEntry), args.Int(1), args.Error(2)
+}
+
 // Tests
 
-func TestActivityHandler_ListByWorkspace(t *testing.T) {
+func TestActivityHandler_ListFeed(t *testing.T) {
 	setup := testutil.NewHandlerTestSetup()
 	mockSvc := new(MockService)
 	activity.RegisterRoutes(setup.API, mockSvc)
 
-	t.Run("lists workspace activity successfully", func(t *testing.T) {
+	t.Run("lists workspace activity with summaries and links", func(t *testing.T) {
 		entityID := uuid.New()
 		userID := uuid.New()
 		log1, _ := activity.NewActivityLog(
@@ -66,73 +74,95 @@ func TestActivityHandler_ListByWorkspace(t *testing.T) {
 			nil,
 			nil,
 		)
-		logs := []*activity.ActivityLog{log1}
+		entries := []activity.FeedEntry{{Log: log1, ActorName: "Alice"}}
 
-		mockSvc.On("ListByWorkspace", mock.Anything, setup.WorkspaceID, mock.MatchedBy(func(p shared.Pagination) bool {
+		mockSvc.On("ListFeed", mock.Anything, setup.WorkspaceID, activity.FeedFilter{}, mock.MatchedBy(func(p shared.Pagination) bool {
 			return p.Page == 1 && p.PageSize == 50
-		})).Return(logs, nil).Once()
+		})).Return(entries, 1, nil).Once()
 
 		rec := setup.Get("/activity")
 
 		testutil.AssertStatus(t, rec, http.StatusOK)
+		var resp activity.ActivityFeedResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		require.Len(t, resp.Items, 1)
+		assert.Equal(t, `Alice created item "Test Item"`, resp.Items[0].Summary)
+		assert.Equal(t, "/items/"+entityID.String(), resp.Items[0].Link)
+		assert.Equal(t, 1, resp.Total)
+		assert.Equal(t, 1, resp.TotalPages)
 		mockSvc.AssertExpectations(t)
 	})
 
 	t.Run("handles pagination", func(t *testing.T) {
-		mockSvc.On("ListByWorkspace", mock.Anything, setup.WorkspaceID, mock.MatchedBy(func(p shared.Pagination) bool {
+		mockSvc.On("ListFeed", mock.Anything, setup.WorkspaceID, activity.FeedFilter{}, mock.MatchedBy(func(p shared.Pagination) bool {
 			return p.Page == 2 && p.PageSize == 10
-		})).Return([]*activity.ActivityLog{}, nil).Once()
+		})).Return([]activity.FeedEntry{}, 25, nil).Once()
 
 		rec := setup.Get("/activity?page=2&limit=10")
 
 		testutil.AssertStatus(t, rec, http.StatusOK)
+		var resp activity.ActivityFeedResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, 3, resp.TotalPages)
 		mockSvc.AssertExpectations(t)
 	})
 
-	t.Run("returns empty list when no activity", func(t *testing.T) {
-		mockSvc.On("ListByWorkspace", mock.Anything, setup.WorkspaceID, mock.Anything).
-			Return([]*activity.ActivityLog{}, nil).Once()
+	t.Run("filters by actor and entity type", func(t *testing.T) {
+		userID := uuid.New()
+		entityType := activity.EntityInventory
 
-		rec := setup.Get("/activity")
+		mockSvc.On("ListFeed", mock.Anything, setup.WorkspaceID, activity.FeedFilter{EntityType: &entityType, UserID: &userID}, mock.Anything).
+			Return([]activity.FeedEntry{}, 0, nil).Once()
+
+		rec := setup.Get(fmt.Sprintf("/activity?user_id=%s&entity_type=INVENTORY", userID))
 
 		testutil.AssertStatus(t, rec, http.StatusOK)
 		mockSvc.AssertExpectations(t)
 	})
-}
 
-func TestActivityHandler_ListByUser(t *testing.T) {
-	setup := testutil.NewHandlerTestSetup()
-	mockSvc := new(MockService)
-	activity.RegisterRoutes(setup.API, mockSvc)
+	t.Run("returns 400 for invalid user_id format", func(t *testing.T) {
+		rec := setup.Get("/activity?user_id=invalid")
+
+		testutil.AssertStatus(t, rec, http.StatusBadRequest)
+	})
+
+	t.Run("returns 400 for invalid entity type", func(t *testing.T) {
+		rec := setup.Get("/activity?entity_type=WIDGET")
 
-	t.Run("lists activity by user successfully", func(t *testing.T) {
+		testutil.AssertStatus(t, rec, http.StatusBadRequest)
+	})
+
+	t.Run("hides price changes from viewers", func(t *testing.T) {
 		userID := uuid.New()
-		entityID := uuid.New()
 		log1, _ := activity.NewActivityLog(
 			setup.WorkspaceID,
 			&userID,
 			activity.ActionUpdate,
-			activity.EntityItem,
-			entityID,
-			"Test Item",
-			nil,
-			nil,
+			activity.EntityInventory,
+			uuid.New(),
+			"",
+			map[string]interface{}{"purchase_price": 1999, "quantity": 3},
+			map[string]interface{}{"purchase_price": 1999, "user_name": "Bob"},
 		)
-		logs := []*activity.ActivityLog{log1}
+		mockSvc.On("ListFeed", mock.Anything, setup.WorkspaceID, activity.FeedFilter{}, mock.Anything).
+			Return([]activity.FeedEntry{{Log: log1}}, 1, nil).Twice()
 
-		mockSvc.On("ListByUser", mock.Anything, setup.WorkspaceID, userID, mock.Anything).
-			Return(logs, nil).Once()
-
-		rec := setup.Get(fmt.Sprintf("/activity?user_id=%s", userID))
+		setup.SetRole("viewer")
+		rec := setup.Get("/activity")
+		setup.SetRole("owner")
 
 		testutil.AssertStatus(t, rec, http.StatusOK)
-		mockSvc.AssertExpectations(t)
-	})
-
-	t.Run("returns 400 for invalid user_id format", func(t *testing.T) {
-		rec := setup.Get("/activity?user_id=invalid")
-
-		testutil.AssertStatus(t, rec, http.StatusBadRequest)
+		var resp activity.ActivityFeedResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		require.Len(t, resp.Items, 1)
+		assert.NotContains(t, resp.Items[0].Changes, "purchase_price")
+		assert.Contains(t, resp.Items[0].Changes, "quantity")
+		assert.NotContains(t, resp.Items[0].Metadata, "purchase_price")
+		assert.Equal(t, "Bob updated an inventory entry", resp.Items[0].Summary)
+
+		rec = setup.Get("/activity")
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Contains(t, resp.Items[0].Changes, "purchase_price")
 	})
 }
 
@@ -15,4 +15,6 @@ type Repository interface {
 	FindByEntity(ctx context.Context, workspaceID uuid.UUID, entityType EntityType, entityID uuid.UUID, pagination shared.Pagination) ([]*ActivityLog, error)
 	FindByUser(ctx context.Context, workspaceID, userID uuid.UUID, pagination shared.Pagination) ([]*ActivityLog, error)
 	FindRecentActivity(ctx context.Context, workspaceID uuid.UUID, since time.Time) ([]*ActivityLog, error)
+	// FindFeed returns matching entries newest first and the total count.
+	FindFeed(ctx context.Context, workspaceID uuid.UUID, filter FeedFilter, pagination shared.Pagination) ([]FeedEntry, int, error)
 }
@@ -16,6 +16,7 @@ type ServiceInterface interface {
 	ListByEntity(ctx context.Context, workspaceID uuid.UUID, entityType EntityType, entityID uuid.UUID, pagination shared.Pagination) ([]*ActivityLog, error)
 	ListByUser(ctx context.Context, workspaceID, userID uuid.UUID, pagination shared.Pagination) ([]*ActivityLog, error)
 	GetRecentActivity(ctx context.Context, workspaceID uuid.UUID, since time.Time) ([]*ActivityLog, error)
+	ListFeed(ctx context.Context, workspaceID uuid.UUID, filter FeedFilter, pagination shared.Pagination) ([]FeedEntry, int, error)
 }
 
 type Service struct {
@@ -55,6 +55,14 @@ func (m *MockRepository) FindRecentActivity(ctx context.Context, workspaceID uui
 	return args.Get(0).([]*ActivityLog), args.Error(1)
 }
 
+func (m *MockRepository) FindFeed(ctx context.Context, workspaceID uuid.UUID, filter FeedFilter, pagination shared.Pagination) ([]FeedEntry, int, error) {
+	args := m.Called(ctx, workspaceID, filter, pagination)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]FeedEntry), args.Int(1), args.Error(2)
+}
+
 // Helper functions
 func ptrUUID(u uuid.UUID) *uuid.UUID {
 	return &u
@@ -48,7 +48,7 @@ var tapActions = map[string]Action{
 }
 
 // NewEventTap returns a Broadcaster tap that mirrors entity SSE events into the
-// activity log. Wire it once at startup: broadcaster.SetTap(activity.NewEventTap(svc, logger)).
+// activity log. Wire it once at startup: broadcaster.AddTap(activity.NewEventTap(svc, logger)).
 func NewEventTap(svc ServiceInterface, logger *slog.Logger) func(uuid.UUID, events.Event) {
 	return func(workspaceID uuid.UUID, e events.Event) {
 		input, ok := eventToLogInput(workspaceID, e)
@@ -0,0 +1,166 @@
+package borrower
+
+import (
+	"net/mail"
+	"strings"
+
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// phoneRegion is what's needed to turn a national number into E.164: the
+// country calling code and the trunk prefix dialled before national numbers.
+type phoneRegion struct {
+	callingCode string
+	trunk       string
+}
+
+// phoneRegions are the regions a national (no country code) phone number
+// can be interpreted in. Numbers written with a country code work for any
+// country.
+var phoneRegions = map[string]phoneRegion{
+	"AT": {"43", "0"},
+	"AU": {"61", "0"},
+	"BE": {"32", "0"},
+	"CA": {"1", "1"},
+	"CH": {"41", "0"},
+	"DE": {"49", "0"},
+	"DK": {"45", ""},
+	"EE": {"372", ""},
+	"ES": {"34", ""},
+	"FI": {"358", "0"},
+	"FR": {"33", "0"},
+	"GB": {"44", "0"},
+	"IE": {"353", "0"},
+	"IT": {"39", ""},
+	"NL": {"31", "0"},
+	"NO": {"47", ""},
+	"NZ": {"64", "0"},
+	"PL": {"48", ""},
+	"SE": {"46", "0"},
+	"US": {"1", "1"},
+}
+
+const (
+	minPhoneDigits = 7
+	maxPhoneDigits = 15 // E.164 limit, country code included
+)
+
+// SupportedPhoneRegion reports whether national phone numbers can be
+// normalized for region (an ISO 3166-1 alpha-2 code).
+func SupportedPhoneRegion(region string) bool {
+	_, ok := phoneRegions[strings.ToUpper(region)]
+	return ok
+}
+
+// NormalizeEmail trims email and lowercases its domain. Display names
+// ("Jane <jane@example.com>") and addresses without a dotted domain are
+// rejected.
+func NormalizeEmail(email string) (string, error) {
+	email = strings.TrimSpace(email)
+	addr, err := mail.ParseAddress(email)
+	if err != nil || addr.Address != email {
+		return "", shared.NewFieldError(shared.ErrInvalidInput, "email", "invalid email address")
+	}
+
+	at := strings.LastIndex(email, "@")
+	local, domain := email[:at], strings.ToLower(email[at+1:])
+	if !strings.Contains(domain, ".") || strings.HasPrefix(domain, ".") || strings.HasSuffix(domain, ".") {
+		return "", shared.NewFieldError(shared.ErrInvalidInput, "email", "invalid email address")
+	}
+	return local + "@" + domain, nil
+}
+
+// NormalizePhone converts phone to E.164 ("+358401234567"). Spaces, dashes,
+// dots and parentheses are ignored and a "00" prefix is read as "+". A
+// number without a country code is taken to be a national number in
+// defaultRegion; it is rejected when no region is configured.
+func NormalizePhone(phone, defaultRegion string) (string, error) {
+	phone = strings.TrimSpace(phone)
+
+	var digits strings.Builder
+	for i, r := range phone {
+		switch {
+		case r >= '0' && r <= '9':
+			digits.WriteRune(r)
+		case r == '+' && i == 0:
+		case r == ' ', r == '-', r == '.', r == '(', r == ')':
+		default:
+			return "", shared.NewFieldError(shared.ErrInvalidInput, "phone", "phone number contains invalid characters")
+		}
+	}
+
+	number := digits.String()
+	switch {
+	case strings.HasPrefix(phone, "+"):
+	case strings.HasPrefix(number, "00"):
+		number = number[2:]
+	default:
+		region, ok := phoneRegions[strings.ToUpper(defaultRegion)]
+		if !ok {
+			return "", shared.NewFieldError(shared.ErrInvalidInput, "phone", "phone number must include a country code")
+		}
+		number = nationalToInternational(number, region)
+		if number == "" {
+			return "", shared.NewFieldError(shared.ErrInvalidInput, "phone", "invalid phone number")
+		}
+	}
+
+	if len(number) < minPhoneDigits || len(number) > maxPhoneDigits || number[0] == '0' {
+		return "", shared.NewFieldError(shared.ErrInvalidInput, "phone", "invalid phone number")
+	}
+	return "+" + number, nil
+}
+
+// nationalToInternational prefixes a national number with region's calling
+// code after dropping its trunk prefix, or returns "" if it cannot be a
+// number in region.
+func nationalToInternational(number string, region phoneRegion) string {
+	// North American numbers are exactly ten digits, optionally dialled
+	// with a leading 1.
+	if region.callingCode == "1" {
+		if len(number) == 11 && number[0] == '1' {
+			number = number[1:]
+		}
+		if len(number) != 10 {
+			return ""
+		}
+		return "1" + number
+	}
+
+	if region.trunk != "" {
+		number = strings.TrimPrefix(number, region.trunk)
+	}
+	if number == "" || number[0] == '0' {
+		return ""
+	}
+	return region.callingCode + number
+}
+
+// ContactIssue is a stored borrower email or phone that is not in its
+// normalized form. Normalized is the value it would be stored as today, or
+// empty when the value is rejected outright (Err says why).
+type ContactIssue struct {
+	Field      string
+	Value      string
+	Normalized string
+	Err        error
+}
+
+// CheckContact reports the contact details of b that would not be stored
+// as-is by the service, for flagging data saved before validation existed.
+func CheckContact(b *Borrower, defaultRegion string) []ContactIssue {
+	var issues []ContactIssue
+	if email := b.Email(); email != nil && *email != "" {
+		normalized, err := NormalizeEmail(*email)
+		if normalized != *email {
+			issues = append(issues, ContactIssue{Field: "email", Value: *email, Normalized: normalized, Err: err})
+		}
+	}
+	if phone := b.Phone(); phone != nil && *phone != "" {
+		normalized, err := NormalizePhone(*phone, defaultRegion)
+		if normalized != *phone {
+			issues = append(issues, ContactIssue{Field: "phone", Value: *phone, Normalized: normalized, Err: err})
+		}
+	}
+	return issues
+}
@@ -0,0 +1,164 @@
+package borrower
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+func TestNormalizeEmail(t *testing.T) {
+	tests := []struct {
+		email string
+		want  string
+	}{
+		{"jane@example.com", "jane@example.com"},
+		{"  jane@example.com  ", "jane@example.com"},
+		{"Jane.Doe@Example.COM", "Jane.Doe@example.com"},
+		{"jane+loans@mail.example.co.uk", "jane+loans@mail.example.co.uk"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.email, func(t *testing.T) {
+			got, err := NormalizeEmail(tt.email)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNormalizeEmail_Invalid(t *testing.T) {
+	for _, email := range []string{
+		"jane",
+		"jane@",
+		"@example.com",
+		"jane@example",
+		"jane@example.",
+		"jane@.example.com",
+		"jane@@example.com",
+		"jane doe@example.com",
+		"Jane <jane@example.com>",
+		"jane@example.com, joe@example.com",
+	} {
+		t.Run(email, func(t *testing.T) {
+			_, err := NormalizeEmail(email)
+			require.Error(t, err)
+			assert.ErrorIs(t, err, shared.ErrInvalidInput)
+			var domainErr *shared.DomainError
+			require.ErrorAs(t, err, &domainErr)
+			assert.Equal(t, "email", domainErr.Field)
+		})
+	}
+}
+
+func TestNormalizePhone(t *testing.T) {
+	tests := []struct {
+		name   string
+		phone  string
+		region string
+		want   string
+	}{
+		{"E.164", "+358401234567", "FI", "+358401234567"},
+		{"international with spaces", "+358 40 123 4567", "FI", "+358401234567"},
+		{"international with 00 prefix", "00358 40 123 4567", "FI", "+358401234567"},
+		{"national with trunk prefix", "040 123 4567", "FI", "+358401234567"},
+		{"national with dashes", "040-123-4567", "FI", "+358401234567"},
+		{"national in lowercase region", "040 1234567", "fi", "+358401234567"},
+		{"other country code ignores region", "+44 20 7946 0958", "FI", "+442079460958"},
+		{"UK national", "020 7946 0958", "GB", "+442079460958"},
+		{"US formatted", "(415) 555-0132", "US", "+14155550132"},
+		{"US with dots", "415.555.0132", "US", "+14155550132"},
+		{"US with leading 1", "1 415 555 0132", "US", "+14155550132"},
+		{"no trunk prefix region", "22 12 34 56", "DK", "+4522123456"},
+		{"international without region", "+1 415 555 0132", "", "+14155550132"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizePhone(tt.phone, tt.region)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNormalizePhone_Invalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		phone  string
+		region string
+	}{
+		{"letters", "040 CALL ME", "FI"},
+		{"extension", "+358401234567 ext 12", "FI"},
+		{"plus in the middle", "040+1234567", "FI"},
+		{"too short", "+35812", "FI"},
+		{"too long", "+3584012345678901", "FI"},
+		{"country code starting with 0", "+0401234567", "FI"},
+		{"national without region", "040 123 4567", ""},
+		{"national in unsupported region", "040 123 4567", "ZZ"},
+		{"US number with wrong length", "555-0132", "US"},
+		{"only trunk prefix", "0", "FI"},
+		{"only separators", "--", "FI"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NormalizePhone(tt.phone, tt.region)
+			require.Error(t, err)
+			assert.ErrorIs(t, err, shared.ErrInvalidInput)
+			var domainErr *shared.DomainError
+			require.ErrorAs(t, err, &domainErr)
+			assert.Equal(t, "phone", domainErr.Field)
+		})
+	}
+}
+
+func TestSupportedPhoneRegion(t *testing.T) {
+	assert.True(t, SupportedPhoneRegion("FI"))
+	assert.True(t, SupportedPhoneRegion("us"))
+	assert.False(t, SupportedPhoneRegion("ZZ"))
+	assert.False(t, SupportedPhoneRegion(""))
+}
+
+func TestCheckContact(t *testing.T) {
+	now := time.Now()
+	reconstruct := func(email, phone *string) *Borrower {
+		return Reconstruct(uuid.New(), uuid.New(), "Jane Doe", email, phone, nil, ReminderChannelEmail, false, now, now)
+	}
+
+	t.Run("normalized contact has no issues", func(t *testing.T) {
+		b := reconstruct(ptrString("jane@example.com"), ptrString("+358401234567"))
+		assert.Empty(t, CheckContact(b, "FI"))
+	})
+
+	t.Run("missing contact has no issues", func(t *testing.T) {
+		assert.Empty(t, CheckContact(reconstruct(nil, nil), "FI"))
+	})
+
+	t.Run("flags values that need normalizing", func(t *testing.T) {
+		b := reconstruct(ptrString("Jane@Example.com"), ptrString("040 123 4567"))
+
+		issues := CheckContact(b, "FI")
+
+		require.Len(t, issues, 2)
+		assert.Equal(t, ContactIssue{Field: "email", Value: "Jane@Example.com", Normalized: "Jane@example.com"}, issues[0])
+		assert.Equal(t, ContactIssue{Field: "phone", Value: "040 123 4567", Normalized: "+358401234567"}, issues[1])
+	})
+
+	t.Run("flags invalid values", func(t *testing.T) {
+		b := reconstruct(ptrString("jane at example"), ptrString("call after 5"))
+
+		issues := CheckContact(b, "FI")
+
+		require.Len(t, issues, 2)
+		assert.Equal(t, "email", issues[0].Field)
+		assert.Empty(t, issues[0].Normalized)
+		assert.Error(t, issues[0].Err)
+		assert.Equal(t, "phone", issues[1].Field)
+		assert.Error(t, issues[1].Err)
+	})
+}
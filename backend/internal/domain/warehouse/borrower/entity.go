@@ -8,16 +8,36 @@ import (
 	"github.com/antti/home-warehouse/go-backend/internal/shared"
 )
 
+// ReminderChannel is how a borrower is sent loan reminders. Each borrower
+// has exactly one so a reminder is never sent twice.
+type ReminderChannel string
+
+const (
+	ReminderChannelEmail ReminderChannel = "email"
+	ReminderChannelSMS   ReminderChannel = "sms"
+	ReminderChannelNone  ReminderChannel = "none"
+)
+
+// ParseReminderChannel validates a reminder channel name.
+func ParseReminderChannel(s string) (ReminderChannel, error) {
+	switch ch := ReminderChannel(s); ch {
+	case ReminderChannelEmail, ReminderChannelSMS, ReminderChannelNone:
+		return ch, nil
+	}
+	return "", shared.NewFieldError(shared.ErrInvalidInput, "reminder_channel", "reminder channel must be one of email, sms, none")
+}
+
 type Borrower struct {
-	id          uuid.UUID
-	workspaceID uuid.UUID
-	name        string
-	email       *string
-	phone       *string
-	notes       *string
-	isArchived  bool
-	createdAt   time.Time
-	updatedAt   time.Time
+	id              uuid.UUID
+	workspaceID     uuid.UUID
+	name            string
+	email           *string
+	phone           *string
+	notes           *string
+	reminderChannel ReminderChannel
+	isArchived      bool
+	createdAt       time.Time
+	updatedAt       time.Time
 }
 
 func NewBorrower(workspaceID uuid.UUID, name string, email, phone, notes *string) (*Borrower, error) {
@@ -30,15 +50,16 @@ func NewBorrower(workspaceID uuid.UUID, name string, email, phone, notes *string
 
 	now := time.Now()
 	return &Borrower{
-		id:          shared.NewUUID(),
-		workspaceID: workspaceID,
-		name:        name,
-		email:       email,
-		phone:       phone,
-		notes:       notes,
-		isArchived:  false,
-		createdAt:   now,
-		updatedAt:   now,
+		id:              shared.NewUUID(),
+		workspaceID:     workspaceID,
+		name:            name,
+		email:           email,
+		phone:           phone,
+		notes:           notes,
+		reminderChannel: ReminderChannelEmail,
+		isArchived:      false,
+		createdAt:       now,
+		updatedAt:       now,
 	}, nil
 }
 
@@ -46,19 +67,21 @@ func Reconstruct(
 	id, workspaceID uuid.UUID,
 	name string,
 	email, phone, notes *string,
+	reminderChannel ReminderChannel,
 	isArchived bool,
 	createdAt, updatedAt time.Time,
 ) *Borrower {
 	return &Borrower{
-		id:          id,
-		workspaceID: workspaceID,
-		name:        name,
-		email:       email,
-		phone:       phone,
-		notes:       notes,
-		isArchived:  isArchived,
-		createdAt:   createdAt,
-		updatedAt:   updatedAt,
+		id:              id,
+		workspaceID:     workspaceID,
+		name:            name,
+		email:           email,
+		phone:           phone,
+		notes:           notes,
+		reminderChannel: reminderChannel,
+		isArchived:      isArchived,
+		createdAt:       createdAt,
+		updatedAt:       updatedAt,
 	}
 }
 
@@ -73,26 +96,66 @@ func (b *Borrower) IsArchived() bool       { return b.isArchived }
 func (b *Borrower) CreatedAt() time.Time   { return b.createdAt }
 func (b *Borrower) UpdatedAt() time.Time   { return b.updatedAt }
 
+func (b *Borrower) ReminderChannel() ReminderChannel { return b.reminderChannel }
+
 type UpdateInput struct {
 	Name  string
 	Email *string
 	Phone *string
 	Notes *string
+	// ReminderChannel replaces the borrower's reminder channel; empty keeps
+	// the current one. Tagged so approved pending changes, which store the
+	// request body, decode it.
+	ReminderChannel ReminderChannel `json:"reminder_channel"`
 }
 
 func (b *Borrower) Update(input UpdateInput) error {
 	if input.Name == "" {
 		return shared.NewFieldError(shared.ErrInvalidInput, "name", "borrower name is required")
 	}
+	channel := b.reminderChannel
+	if input.ReminderChannel != "" {
+		if _, err := ParseReminderChannel(string(input.ReminderChannel)); err != nil {
+			return err
+		}
+		channel = input.ReminderChannel
+	}
+	if err := checkReminderReachable(channel, input.Phone); err != nil {
+		return err
+	}
 
 	b.name = input.Name
 	b.email = input.Email
 	b.phone = input.Phone
 	b.notes = input.Notes
+	b.reminderChannel = channel
 	b.updatedAt = time.Now()
 	return nil
 }
 
+// SetReminderChannel changes how the borrower is sent loan reminders. SMS
+// reminders need a phone number.
+func (b *Borrower) SetReminderChannel(channel ReminderChannel) error {
+	if _, err := ParseReminderChannel(string(channel)); err != nil {
+		return err
+	}
+	if err := checkReminderReachable(channel, b.phone); err != nil {
+		return err
+	}
+	b.reminderChannel = channel
+	b.updatedAt = time.Now()
+	return nil
+}
+
+// checkReminderReachable rejects an SMS reminder channel for a borrower
+// without a phone number.
+func checkReminderReachable(channel ReminderChannel, phone *string) error {
+	if channel == ReminderChannelSMS && (phone == nil || *phone == "") {
+		return shared.NewFieldError(shared.ErrInvalidInput, "reminder_channel", "SMS reminders need a phone number")
+	}
+	return nil
+}
+
 func (b *Borrower) Archive() {
 	b.isArchived = true
 	b.updatedAt = time.Now()
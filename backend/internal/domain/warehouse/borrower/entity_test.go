@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/borrower"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
 )
 
 func TestNewBorrower(t *testing.T) {
@@ -109,6 +110,64 @@ func TestBorrower_Update_EmptyName(t *testing.T) {
 	assert.Contains(t, err.Error(), "name")
 }
 
+func TestBorrower_ReminderChannel(t *testing.T) {
+	phone := "+358401234567"
+
+	t.Run("defaults to email", func(t *testing.T) {
+		b, err := borrower.NewBorrower(uuid.New(), "John Doe", nil, nil, nil)
+		assert.NoError(t, err)
+		assert.Equal(t, borrower.ReminderChannelEmail, b.ReminderChannel())
+	})
+
+	t.Run("sms with a phone number", func(t *testing.T) {
+		b, err := borrower.NewBorrower(uuid.New(), "John Doe", nil, &phone, nil)
+		assert.NoError(t, err)
+
+		assert.NoError(t, b.SetReminderChannel(borrower.ReminderChannelSMS))
+		assert.Equal(t, borrower.ReminderChannelSMS, b.ReminderChannel())
+	})
+
+	t.Run("sms needs a phone number", func(t *testing.T) {
+		b, err := borrower.NewBorrower(uuid.New(), "John Doe", nil, nil, nil)
+		assert.NoError(t, err)
+
+		err = b.SetReminderChannel(borrower.ReminderChannelSMS)
+		assert.ErrorIs(t, err, shared.ErrInvalidInput)
+		assert.Equal(t, borrower.ReminderChannelEmail, b.ReminderChannel())
+	})
+
+	t.Run("rejects unknown channels", func(t *testing.T) {
+		b, err := borrower.NewBorrower(uuid.New(), "John Doe", nil, &phone, nil)
+		assert.NoError(t, err)
+
+		err = b.SetReminderChannel("pigeon")
+		assert.ErrorIs(t, err, shared.ErrInvalidInput)
+		assert.Contains(t, err.Error(), "reminder_channel")
+	})
+
+	t.Run("update keeps the channel unless given", func(t *testing.T) {
+		b, err := borrower.NewBorrower(uuid.New(), "John Doe", nil, &phone, nil)
+		assert.NoError(t, err)
+		assert.NoError(t, b.SetReminderChannel(borrower.ReminderChannelSMS))
+
+		assert.NoError(t, b.Update(borrower.UpdateInput{Name: "John Doe", Phone: &phone}))
+		assert.Equal(t, borrower.ReminderChannelSMS, b.ReminderChannel())
+
+		assert.NoError(t, b.Update(borrower.UpdateInput{Name: "John Doe", Phone: &phone, ReminderChannel: borrower.ReminderChannelNone}))
+		assert.Equal(t, borrower.ReminderChannelNone, b.ReminderChannel())
+	})
+
+	t.Run("update cannot drop the phone of an sms borrower", func(t *testing.T) {
+		b, err := borrower.NewBorrower(uuid.New(), "John Doe", nil, &phone, nil)
+		assert.NoError(t, err)
+		assert.NoError(t, b.SetReminderChannel(borrower.ReminderChannelSMS))
+
+		err = b.Update(borrower.UpdateInput{Name: "John Doe"})
+		assert.ErrorIs(t, err, shared.ErrInvalidInput)
+		assert.Equal(t, &phone, b.Phone())
+	})
+}
+
 func TestBorrower_Archive(t *testing.T) {
 	workspaceID := uuid.New()
 	b, err := borrower.NewBorrower(workspaceID, "Test", nil, nil, nil)
@@ -149,6 +208,7 @@ func TestBorrower_Reconstruct(t *testing.T) {
 		&email,
 		&phone,
 		&notes,
+		borrower.ReminderChannelNone,
 		false,
 		now,
 		now,
@@ -161,5 +221,6 @@ func TestBorrower_Reconstruct(t *testing.T) {
 	assert.Equal(t, &email, b.Email())
 	assert.Equal(t, &phone, b.Phone())
 	assert.Equal(t, &notes, b.Notes())
+	assert.Equal(t, borrower.ReminderChannelNone, b.ReminderChannel())
 	assert.False(t, b.IsArchived())
 }
@@ -88,11 +88,12 @@ func createBorrower(svc ServiceInterface, broadcaster *events.Broadcaster) func(
 		authUser, _ := appMiddleware.GetAuthUser(ctx)
 
 		borrower, err := svc.Create(ctx, CreateInput{
-			WorkspaceID: workspaceID,
-			Name:        input.Body.Name,
-			Email:       input.Body.Email,
-			Phone:       input.Body.Phone,
-			Notes:       input.Body.Notes,
+			WorkspaceID:     workspaceID,
+			Name:            input.Body.Name,
+			Email:           input.Body.Email,
+			Phone:           input.Body.Phone,
+			Notes:           input.Body.Notes,
+			ReminderChannel: ReminderChannel(input.Body.ReminderChannel),
 		})
 		if err != nil {
 			return nil, appMiddleware.MapDomainError(err)
@@ -131,9 +132,10 @@ func updateBorrower(svc ServiceInterface, broadcaster *events.Broadcaster) func(
 		authUser, _ := appMiddleware.GetAuthUser(ctx)
 
 		updateInput := UpdateInput{
-			Email: input.Body.Email,
-			Phone: input.Body.Phone,
-			Notes: input.Body.Notes,
+			Email:           input.Body.Email,
+			Phone:           input.Body.Phone,
+			Notes:           input.Body.Notes,
+			ReminderChannel: ReminderChannel(input.Body.ReminderChannel),
 		}
 		if input.Body.Name != nil {
 			updateInput.Name = *input.Body.Name
@@ -271,15 +273,16 @@ func publishBorrowerLifecycleEvent(ctx context.Context, broadcaster *events.Broa
 
 func toBorrowerResponse(b *Borrower) BorrowerResponse {
 	return BorrowerResponse{
-		ID:          b.ID(),
-		WorkspaceID: b.WorkspaceID(),
-		Name:        b.Name(),
-		Email:       b.Email(),
-		Phone:       b.Phone(),
-		Notes:       b.Notes(),
-		IsArchived:  b.IsArchived(),
-		CreatedAt:   b.CreatedAt(),
-		UpdatedAt:   b.UpdatedAt(),
+		ID:              b.ID(),
+		WorkspaceID:     b.WorkspaceID(),
+		Name:            b.Name(),
+		Email:           b.Email(),
+		Phone:           b.Phone(),
+		Notes:           b.Notes(),
+		IsArchived:      b.IsArchived(),
+		CreatedAt:       b.CreatedAt(),
+		UpdatedAt:       b.UpdatedAt(),
+		ReminderChannel: string(b.ReminderChannel()),
 	}
 }
 
@@ -309,10 +312,11 @@ type GetBorrowerOutput struct {
 
 type CreateBorrowerInput struct {
 	Body struct {
-		Name  string  `json:"name" minLength:"1" maxLength:"255"`
-		Email *string `json:"email,omitempty" format:"email"`
-		Phone *string `json:"phone,omitempty"`
-		Notes *string `json:"notes,omitempty"`
+		Name            string  `json:"name" minLength:"1" maxLength:"255"`
+		Email           *string `json:"email,omitempty" format:"email"`
+		Phone           *string `json:"phone,omitempty"`
+		Notes           *string `json:"notes,omitempty"`
+		ReminderChannel string  `json:"reminder_channel,omitempty" enum:"email,sms,none" doc:"How loan reminders reach the borrower (default email); sms needs a phone number"`
 	}
 }
 
@@ -323,10 +327,11 @@ type CreateBorrowerOutput struct {
 type UpdateBorrowerInput struct {
 	ID   uuid.UUID `path:"id"`
 	Body struct {
-		Name  *string `json:"name,omitempty" minLength:"1" maxLength:"255"`
-		Email *string `json:"email,omitempty" format:"email"`
-		Phone *string `json:"phone,omitempty"`
-		Notes *string `json:"notes,omitempty"`
+		Name            *string `json:"name,omitempty" minLength:"1" maxLength:"255"`
+		Email           *string `json:"email,omitempty" format:"email"`
+		Phone           *string `json:"phone,omitempty"`
+		Notes           *string `json:"notes,omitempty"`
+		ReminderChannel string  `json:"reminder_channel,omitempty" enum:"email,sms,none" doc:"How loan reminders reach the borrower; omit to keep the current channel"`
 	}
 }
 
@@ -339,15 +344,16 @@ type DeleteBorrowerInput struct {
 }
 
 type BorrowerResponse struct {
-	ID          uuid.UUID `json:"id"`
-	WorkspaceID uuid.UUID `json:"workspace_id"`
-	Name        string    `json:"name"`
-	Email       *string   `json:"email,omitempty"`
-	Phone       *string   `json:"phone,omitempty"`
-	Notes       *string   `json:"notes,omitempty"`
-	IsArchived  bool      `json:"is_archived"`
-	CreatedAt   time.Time `json:"created_at"`
-	UpdatedAt   time.Time `json:"updated_at"`
+	ID              uuid.UUID `json:"id"`
+	WorkspaceID     uuid.UUID `json:"workspace_id"`
+	Name            string    `json:"name"`
+	Email           *string   `json:"email,omitempty"`
+	Phone           *string   `json:"phone,omitempty"`
+	Notes           *string   `json:"notes,omitempty"`
+	IsArchived      bool      `json:"is_archived"`
+	CreatedAt       time.Time `json:"created_at"`
+	UpdatedAt       time.Time `json:"updated_at"`
+	ReminderChannel string    `json:"reminder_channel" doc:"How loan reminders reach the borrower: email, sms or none"`
 }
 
 type SearchBorrowersInput struct {
@@ -108,6 +108,29 @@ func TestBorrowerHandler_Create(t *testing.T) {
 		mockSvc.AssertExpectations(t)
 	})
 
+	t.Run("passes the reminder channel", func(t *testing.T) {
+		phone := "+358401234567"
+		testBorrower, _ := borrower.NewBorrower(setup.WorkspaceID, "Jane Doe", nil, &phone, nil)
+		_ = testBorrower.SetReminderChannel(borrower.ReminderChannelSMS)
+
+		mockSvc.On("Create", mock.Anything, mock.MatchedBy(func(input borrower.CreateInput) bool {
+			return input.Name == "Jane Doe" && input.ReminderChannel == borrower.ReminderChannelSMS
+		})).Return(testBorrower, nil).Once()
+
+		body := `{"name":"Jane Doe","phone":"+358401234567","reminder_channel":"sms"}`
+		rec := setup.Post("/borrowers", body)
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		assert.Contains(t, rec.Body.String(), `"reminder_channel":"sms"`)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("returns 422 for unknown reminder channel", func(t *testing.T) {
+		rec := setup.Post("/borrowers", `{"name":"Jane Doe","reminder_channel":"pigeon"}`)
+
+		testutil.AssertStatus(t, rec, http.StatusUnprocessableEntity)
+	})
+
 	t.Run("returns 422 for empty name", func(t *testing.T) {
 		// Validation happens at HTTP layer, so service is never called
 		body := `{"name":""}`
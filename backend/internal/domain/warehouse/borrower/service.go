@@ -2,6 +2,7 @@ package borrower
 
 import (
 	"context"
+	"strings"
 
 	"github.com/google/uuid"
 
@@ -21,27 +22,68 @@ type ServiceInterface interface {
 }
 
 type Service struct {
-	repo Repository
+	repo        Repository
+	phoneRegion string
 }
 
 func NewService(repo Repository) *Service {
 	return &Service{repo: repo}
 }
 
+// SetDefaultPhoneRegion sets the region (ISO 3166-1 alpha-2, e.g. "FI")
+// phone numbers written without a country code are read in. Without it
+// such numbers are rejected.
+func (s *Service) SetDefaultPhoneRegion(region string) {
+	s.phoneRegion = region
+}
+
+// normalizeContact validates email and phone and returns them normalized
+// (see NormalizeEmail and NormalizePhone). Blank values become nil.
+func (s *Service) normalizeContact(email, phone *string) (*string, *string, error) {
+	var normalizedEmail, normalizedPhone *string
+	if email != nil && strings.TrimSpace(*email) != "" {
+		normalized, err := NormalizeEmail(*email)
+		if err != nil {
+			return nil, nil, err
+		}
+		normalizedEmail = &normalized
+	}
+	if phone != nil && strings.TrimSpace(*phone) != "" {
+		normalized, err := NormalizePhone(*phone, s.phoneRegion)
+		if err != nil {
+			return nil, nil, err
+		}
+		normalizedPhone = &normalized
+	}
+	return normalizedEmail, normalizedPhone, nil
+}
+
 type CreateInput struct {
 	WorkspaceID uuid.UUID
 	Name        string
 	Email       *string
 	Phone       *string
 	Notes       *string
+	// ReminderChannel defaults to email when empty.
+	ReminderChannel ReminderChannel
 }
 
 func (s *Service) Create(ctx context.Context, input CreateInput) (*Borrower, error) {
-	borrower, err := NewBorrower(input.WorkspaceID, input.Name, input.Email, input.Phone, input.Notes)
+	email, phone, err := s.normalizeContact(input.Email, input.Phone)
 	if err != nil {
 		return nil, err
 	}
 
+	borrower, err := NewBorrower(input.WorkspaceID, input.Name, email, phone, input.Notes)
+	if err != nil {
+		return nil, err
+	}
+	if input.ReminderChannel != "" {
+		if err := borrower.SetReminderChannel(input.ReminderChannel); err != nil {
+			return nil, err
+		}
+	}
+
 	if err := s.repo.Create(ctx, borrower); err != nil {
 		return nil, err
 	}
@@ -61,6 +103,12 @@ func (s *Service) GetByID(ctx context.Context, id, workspaceID uuid.UUID) (*Borr
 }
 
 func (s *Service) Update(ctx context.Context, id, workspaceID uuid.UUID, input UpdateInput) (*Borrower, error) {
+	email, phone, err := s.normalizeContact(input.Email, input.Phone)
+	if err != nil {
+		return nil, err
+	}
+	input.Email, input.Phone = email, phone
+
 	borrower, err := s.GetByID(ctx, id, workspaceID)
 	if err != nil {
 		return nil, err
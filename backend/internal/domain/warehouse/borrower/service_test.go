@@ -181,6 +181,7 @@ func TestReconstruct(t *testing.T) {
 		ptrString("john@example.com"),
 		ptrString("+1234567890"),
 		ptrString("Notes"),
+		ReminderChannelSMS,
 		false,
 		now,
 		now,
@@ -192,6 +193,7 @@ func TestReconstruct(t *testing.T) {
 	assert.Equal(t, "john@example.com", *borrower.Email())
 	assert.Equal(t, "+1234567890", *borrower.Phone())
 	assert.Equal(t, "Notes", *borrower.Notes())
+	assert.Equal(t, ReminderChannelSMS, borrower.ReminderChannel())
 	assert.False(t, borrower.IsArchived())
 	assert.Equal(t, now, borrower.CreatedAt())
 	assert.Equal(t, now, borrower.UpdatedAt())
@@ -209,6 +211,7 @@ func TestReconstruct_MinimalFields(t *testing.T) {
 		nil,
 		nil,
 		nil,
+		ReminderChannelEmail,
 		true,
 		now,
 		now,
@@ -413,6 +416,117 @@ func TestService_Create(t *testing.T) {
 	}
 }
 
+func TestService_Create_NormalizesContact(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(MockRepository)
+	svc := NewService(mockRepo)
+	svc.SetDefaultPhoneRegion("FI")
+
+	mockRepo.On("Create", ctx, mock.AnythingOfType("*borrower.Borrower")).Return(nil)
+
+	borrower, err := svc.Create(ctx, CreateInput{
+		WorkspaceID: uuid.New(),
+		Name:        "John Doe",
+		Email:       ptrString(" John@Example.COM "),
+		Phone:       ptrString("040 123 4567"),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "John@example.com", *borrower.Email())
+	assert.Equal(t, "+358401234567", *borrower.Phone())
+	mockRepo.AssertExpectations(t)
+}
+
+func TestService_Create_ReminderChannel(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("sms with a phone number", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		svc := NewService(mockRepo)
+		mockRepo.On("Create", ctx, mock.AnythingOfType("*borrower.Borrower")).Return(nil)
+
+		borrower, err := svc.Create(ctx, CreateInput{
+			WorkspaceID:     uuid.New(),
+			Name:            "John Doe",
+			Phone:           ptrString("+358401234567"),
+			ReminderChannel: ReminderChannelSMS,
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, ReminderChannelSMS, borrower.ReminderChannel())
+	})
+
+	t.Run("sms without a phone number", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		svc := NewService(mockRepo)
+
+		borrower, err := svc.Create(ctx, CreateInput{
+			WorkspaceID:     uuid.New(),
+			Name:            "John Doe",
+			Email:           ptrString("john@example.com"),
+			ReminderChannel: ReminderChannelSMS,
+		})
+
+		assert.Nil(t, borrower)
+		assert.ErrorIs(t, err, shared.ErrInvalidInput)
+		mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+}
+
+func TestService_Create_BlankContactIsCleared(t *testing.T) {
+	ctx := context.Background()
+	mockRepo := new(MockRepository)
+	svc := NewService(mockRepo)
+
+	mockRepo.On("Create", ctx, mock.AnythingOfType("*borrower.Borrower")).Return(nil)
+
+	borrower, err := svc.Create(ctx, CreateInput{
+		WorkspaceID: uuid.New(),
+		Name:        "John Doe",
+		Email:       ptrString(""),
+		Phone:       ptrString("  "),
+	})
+
+	assert.NoError(t, err)
+	assert.Nil(t, borrower.Email())
+	assert.Nil(t, borrower.Phone())
+}
+
+func TestService_Create_InvalidContact(t *testing.T) {
+	tests := []struct {
+		testName string
+		email    *string
+		phone    *string
+		field    string
+	}{
+		{"invalid email", ptrString("john.example.com"), nil, "email"},
+		{"invalid phone", nil, ptrString("ask at the desk"), "phone"},
+		{"national phone without region", nil, ptrString("040 123 4567"), "phone"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.testName, func(t *testing.T) {
+			mockRepo := new(MockRepository)
+			svc := NewService(mockRepo)
+
+			borrower, err := svc.Create(context.Background(), CreateInput{
+				WorkspaceID: uuid.New(),
+				Name:        "John Doe",
+				Email:       tt.email,
+				Phone:       tt.phone,
+			})
+
+			assert.Nil(t, borrower)
+			assert.ErrorIs(t, err, shared.ErrInvalidInput)
+			var domainErr *shared.DomainError
+			if assert.ErrorAs(t, err, &domainErr) {
+				assert.Equal(t, tt.field, domainErr.Field)
+			}
+			mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
+
 func TestService_GetByID(t *testing.T) {
 	ctx := context.Background()
 	borrowerID := uuid.New()
@@ -597,6 +711,44 @@ func TestService_Update(t *testing.T) {
 	}
 }
 
+func TestService_Update_NormalizesContact(t *testing.T) {
+	ctx := context.Background()
+	borrowerID := uuid.New()
+	workspaceID := uuid.New()
+	mockRepo := new(MockRepository)
+	svc := NewService(mockRepo)
+	svc.SetDefaultPhoneRegion("US")
+
+	existing := &Borrower{id: borrowerID, workspaceID: workspaceID, name: "Original Name"}
+	mockRepo.On("FindByID", ctx, borrowerID, workspaceID).Return(existing, nil)
+	mockRepo.On("Save", ctx, existing).Return(nil)
+
+	borrower, err := svc.Update(ctx, borrowerID, workspaceID, UpdateInput{
+		Name:  "Updated Name",
+		Email: ptrString("updated@EXAMPLE.com"),
+		Phone: ptrString("(415) 555-0132"),
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "updated@example.com", *borrower.Email())
+	assert.Equal(t, "+14155550132", *borrower.Phone())
+	mockRepo.AssertExpectations(t)
+}
+
+func TestService_Update_InvalidContact(t *testing.T) {
+	mockRepo := new(MockRepository)
+	svc := NewService(mockRepo)
+
+	borrower, err := svc.Update(context.Background(), uuid.New(), uuid.New(), UpdateInput{
+		Name:  "Updated Name",
+		Email: ptrString("updated@"),
+	})
+
+	assert.Nil(t, borrower)
+	assert.ErrorIs(t, err, shared.ErrInvalidInput)
+	mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+}
+
 func TestService_Archive(t *testing.T) {
 	ctx := context.Background()
 	borrowerID := uuid.New()
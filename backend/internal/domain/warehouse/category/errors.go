@@ -9,4 +9,7 @@ var (
 	ErrCategoryNotFound = shared.NewDomainError(shared.ErrNotFound, "category not found")
 	ErrCyclicParent     = shared.NewDomainError(shared.ErrInvalidInput, "cyclic parent reference not allowed")
 	ErrHasChildren      = shared.NewDomainError(shared.ErrConflict, "category has child categories")
+
+	ErrMergeIntoSelf       = shared.NewFieldError(shared.ErrInvalidInput, "merge_category_id", "cannot merge a category into itself")
+	ErrMergeIntoDescendant = shared.NewFieldError(shared.ErrInvalidInput, "merge_category_id", "cannot merge a category into one of its own descendants")
 )
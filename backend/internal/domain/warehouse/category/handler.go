@@ -31,6 +31,7 @@ func RegisterRoutes(api huma.API, svc ServiceInterface, broadcaster *events.Broa
 	huma.Post(api, "/categories/{id}/restore", restoreCategory(svc, broadcaster))
 	huma.Delete(api, routeCategoryByID, deleteCategory(svc, broadcaster))
 	huma.Get(api, "/categories/{id}/breadcrumb", getCategoryBreadcrumb(svc))
+	huma.Post(api, "/categories/{id}/merge", mergeCategories(svc, broadcaster))
 }
 
 // listCategories lists all categories in the workspace.
@@ -300,6 +301,51 @@ func deleteCategory(svc ServiceInterface, broadcaster *events.Broadcaster) func(
 	}
 }
 
+// mergeCategories folds another category into this one: its items and child
+// categories move here and it is deleted.
+func mergeCategories(svc ServiceInterface, broadcaster *events.Broadcaster) func(context.Context, *MergeCategoriesInput) (*MergeCategoriesOutput, error) {
+	return func(ctx context.Context, input *MergeCategoriesInput) (*MergeCategoriesOutput, error) {
+		workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+		if !ok {
+			return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+		}
+		// Merges apply immediately rather than through the approval queue.
+		if role, ok := appMiddleware.GetRole(ctx); !ok || (role != "owner" && role != "admin") {
+			return nil, huma.Error403Forbidden("only workspace owners and admins can merge categories")
+		}
+
+		result, err := svc.MergeCategories(ctx, workspaceID, input.ID, input.Body.MergeCategoryID)
+		if err != nil {
+			return nil, appMiddleware.MapDomainError(err)
+		}
+
+		// Publish event
+		authUser, _ := appMiddleware.GetAuthUser(ctx)
+		if broadcaster != nil && authUser != nil {
+			userName := appMiddleware.GetUserDisplayName(ctx)
+			broadcaster.Publish(workspaceID, events.Event{
+				Type:       "category.deleted",
+				EntityID:   input.Body.MergeCategoryID.String(),
+				EntityType: "category",
+				UserID:     authUser.ID,
+				Data: map[string]any{
+					"merged_into_id": input.ID,
+					"items_moved":    result.ItemsMoved,
+					"children_moved": result.ChildrenMoved,
+					"user_name":      userName,
+				},
+			})
+		}
+
+		return &MergeCategoriesOutput{
+			Body: MergeCategoriesResponse{
+				ItemsMoved:    result.ItemsMoved,
+				ChildrenMoved: result.ChildrenMoved,
+			},
+		}, nil
+	}
+}
+
 // getCategoryBreadcrumb returns the breadcrumb trail for a category.
 func getCategoryBreadcrumb(svc ServiceInterface) func(context.Context, *GetCategoryInput) (*BreadcrumbOutput, error) {
 	return func(ctx context.Context, input *GetCategoryInput) (*BreadcrumbOutput, error) {
@@ -376,6 +422,22 @@ type UpdateCategoryOutput struct {
 	Body CategoryResponse
 }
 
+type MergeCategoriesInput struct {
+	ID   uuid.UUID `path:"id"`
+	Body struct {
+		MergeCategoryID uuid.UUID `json:"merge_category_id" doc:"Category to fold into this one; it is deleted after the merge"`
+	}
+}
+
+type MergeCategoriesOutput struct {
+	Body MergeCategoriesResponse
+}
+
+type MergeCategoriesResponse struct {
+	ItemsMoved    int `json:"items_moved" doc:"Number of items re-pointed to this category"`
+	ChildrenMoved int `json:"children_moved" doc:"Number of child categories re-parented onto this category"`
+}
+
 type BreadcrumbOutput struct {
 	Body []BreadcrumbItem `json:"breadcrumb"`
 }
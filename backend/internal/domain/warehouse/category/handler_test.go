@@ -99,6 +99,14 @@ func (m *MockService) GetBreadcrumb(ctx context.Context, categoryID, workspaceID
 	return args.Get(0).([]BreadcrumbItem), args.Error(1)
 }
 
+func (m *MockService) MergeCategories(ctx context.Context, workspaceID, keepID, mergeID uuid.UUID) (*MergeResult, error) {
+	args := m.Called(ctx, workspaceID, keepID, mergeID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*MergeResult), args.Error(1)
+}
+
 // Test helpers
 func setupTestRouter(svc *MockService) (http.Handler, *chi.Mux) {
 	r := chi.NewRouter()
@@ -108,6 +116,7 @@ func setupTestRouter(svc *MockService) (http.Handler, *chi.Mux) {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			workspaceID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
 			ctx := context.WithValue(r.Context(), appMiddleware.WorkspaceContextKey, workspaceID)
+			ctx = context.WithValue(ctx, appMiddleware.RoleContextKey, "owner")
 			next.ServeHTTP(w, r.WithContext(ctx))
 		})
 	})
@@ -630,3 +639,64 @@ func TestCategoryHandler_Create_NilBroadcaster_NoError(t *testing.T) {
 	testutil.AssertStatus(t, rec, http.StatusCreated)
 	mockSvc.AssertExpectations(t)
 }
+
+func TestHandler_MergeCategories(t *testing.T) {
+	t.Run("merges category successfully", func(t *testing.T) {
+		mockSvc := new(MockService)
+		router, _ := setupTestRouter(mockSvc)
+
+		workspaceID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+		keepID := uuid.New()
+		mergeID := uuid.New()
+
+		mockSvc.On("MergeCategories", mock.Anything, workspaceID, keepID, mergeID).
+			Return(&MergeResult{ItemsMoved: 4, ChildrenMoved: 2}, nil)
+
+		body := `{"merge_category_id":"` + mergeID.String() + `"}`
+		req := httptest.NewRequest("POST", "/categories/"+keepID.String()+"/merge", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Contains(t, w.Body.String(), `"items_moved":4`)
+		assert.Contains(t, w.Body.String(), `"children_moved":2`)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("returns 400 when merging into a descendant", func(t *testing.T) {
+		mockSvc := new(MockService)
+		router, _ := setupTestRouter(mockSvc)
+
+		workspaceID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+		keepID := uuid.New()
+		mergeID := uuid.New()
+
+		mockSvc.On("MergeCategories", mock.Anything, workspaceID, keepID, mergeID).
+			Return(nil, ErrMergeIntoDescendant)
+
+		body := `{"merge_category_id":"` + mergeID.String() + `"}`
+		req := httptest.NewRequest("POST", "/categories/"+keepID.String()+"/merge", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("requires owner or admin", func(t *testing.T) {
+		setup := testutil.NewHandlerTestSetup()
+		setup.SetRole("member")
+		mockSvc := new(MockService)
+		RegisterRoutes(setup.API, mockSvc, nil)
+
+		body := `{"merge_category_id":"` + uuid.New().String() + `"}`
+		rec := setup.Post("/categories/"+uuid.New().String()+"/merge", body)
+
+		testutil.AssertStatus(t, rec, http.StatusForbidden)
+		mockSvc.AssertNotCalled(t, "MergeCategories", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -28,4 +28,12 @@ type Repository interface {
 
 	// HasChildren checks if a category has children.
 	HasChildren(ctx context.Context, workspaceID, parentID uuid.UUID) (bool, error)
+
+	// MoveItems re-points every item in fromCategoryID to toCategoryID and
+	// returns the number of items moved.
+	MoveItems(ctx context.Context, workspaceID, fromCategoryID, toCategoryID uuid.UUID) (int, error)
+
+	// MoveChildren re-parents every direct child of fromCategoryID onto
+	// toCategoryID and returns the number of categories moved.
+	MoveChildren(ctx context.Context, workspaceID, fromCategoryID, toCategoryID uuid.UUID) (int, error)
 }
@@ -2,8 +2,12 @@ package category
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/google/uuid"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/deletionimpact"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
 )
 
 // ServiceInterface defines the category service operations.
@@ -18,16 +22,58 @@ type ServiceInterface interface {
 	ListByParent(ctx context.Context, workspaceID, parentID uuid.UUID) ([]*Category, error)
 	ListRootCategories(ctx context.Context, workspaceID uuid.UUID) ([]*Category, error)
 	GetBreadcrumb(ctx context.Context, categoryID, workspaceID uuid.UUID) ([]BreadcrumbItem, error)
+	MergeCategories(ctx context.Context, workspaceID, keepID, mergeID uuid.UUID) (*MergeResult, error)
+}
+
+// Transactor runs a function inside a single database transaction. It is a
+// port implemented by infra/postgres.TxManager, keeping this package free of
+// infra imports.
+type Transactor interface {
+	WithTx(ctx context.Context, fn func(context.Context) error) error
+}
+
+// noopTransactor executes the function without a surrounding transaction. It
+// is the fallback when no Transactor is wired (unit tests with mocked
+// repositories).
+type noopTransactor struct{}
+
+func (noopTransactor) WithTx(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+// DeletionGuard decides whether an entity may be deleted. Implemented by
+// deletionimpact.Service so Delete refuses on exactly what the
+// deletion-impact preview reports.
+type DeletionGuard interface {
+	CheckDeletable(ctx context.Context, workspaceID uuid.UUID, entityType deletionimpact.EntityType, id uuid.UUID) error
 }
 
 // Service handles category business logic.
 type Service struct {
-	repo Repository
+	repo  Repository
+	tx    Transactor
+	guard DeletionGuard
 }
 
 // NewService creates a new category service.
 func NewService(repo Repository) *Service {
-	return &Service{repo: repo}
+	return &Service{repo: repo, tx: noopTransactor{}}
+}
+
+// SetTransactor wires the transaction runner used by MergeCategories so the
+// item moves, re-parenting and delete commit together. Optional — without it
+// the steps run unwrapped (unit tests with mocked repositories).
+func (s *Service) SetTransactor(tx Transactor) {
+	if tx == nil {
+		tx = noopTransactor{}
+	}
+	s.tx = tx
+}
+
+// SetDeletionGuard wires the shared deletion rules used by Delete. Optional —
+// without it Delete falls back to the child-category check alone.
+func (s *Service) SetDeletionGuard(guard DeletionGuard) {
+	s.guard = guard
 }
 
 // CreateInput holds the input for creating a category.
@@ -188,6 +234,13 @@ func (s *Service) Delete(ctx context.Context, id, workspaceID uuid.UUID) error {
 		return err
 	}
 
+	if s.guard != nil {
+		if err := s.guard.CheckDeletable(ctx, workspaceID, deletionimpact.EntityCategory, category.ID()); err != nil {
+			return err
+		}
+		return s.repo.Delete(ctx, id, workspaceID)
+	}
+
 	// Check if category has children
 	hasChildren, err := s.repo.HasChildren(ctx, workspaceID, category.ID())
 	if err != nil {
@@ -200,6 +253,95 @@ func (s *Service) Delete(ctx context.Context, id, workspaceID uuid.UUID) error {
 	return s.repo.Delete(ctx, id, workspaceID)
 }
 
+// MergeResult reports what was moved onto the kept category by a merge.
+type MergeResult struct {
+	ItemsMoved    int
+	ChildrenMoved int
+}
+
+// MergeCategories folds mergeID into keepID: every item in mergeID is
+// re-pointed to keepID, every direct child of mergeID is re-parented onto
+// keepID, and mergeID is then deleted. Everything happens in one transaction.
+//
+// keepID must not be mergeID or one of its descendants, since re-parenting
+// mergeID's children onto keepID would then create a cycle.
+func (s *Service) MergeCategories(ctx context.Context, workspaceID, keepID, mergeID uuid.UUID) (*MergeResult, error) {
+	if keepID == mergeID {
+		return nil, ErrMergeIntoSelf
+	}
+
+	if _, err := s.GetByID(ctx, keepID, workspaceID); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.repo.FindByID(ctx, mergeID, workspaceID); err != nil {
+		if shared.IsNotFound(err) {
+			return nil, shared.NewFieldError(shared.ErrNotFound, "merge_category_id", fmt.Sprintf("category %s not found in this workspace", mergeID))
+		}
+		return nil, err
+	}
+
+	isDescendant, err := s.isDescendant(ctx, workspaceID, keepID, mergeID)
+	if err != nil {
+		return nil, err
+	}
+	if isDescendant {
+		return nil, ErrMergeIntoDescendant
+	}
+
+	result := &MergeResult{}
+	err = s.tx.WithTx(ctx, func(txCtx context.Context) error {
+		moved, err := s.repo.MoveItems(txCtx, workspaceID, mergeID, keepID)
+		if err != nil {
+			return err
+		}
+		result.ItemsMoved = moved
+
+		moved, err = s.repo.MoveChildren(txCtx, workspaceID, mergeID, keepID)
+		if err != nil {
+			return err
+		}
+		result.ChildrenMoved = moved
+
+		return s.repo.Delete(txCtx, mergeID, workspaceID)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// isDescendant reports whether categoryID sits anywhere below ancestorID by
+// walking the parent chain upwards.
+func (s *Service) isDescendant(ctx context.Context, workspaceID, categoryID, ancestorID uuid.UUID) (bool, error) {
+	visited := make(map[uuid.UUID]bool) // Prevent infinite loops from bad data
+	currentID := &categoryID
+
+	for currentID != nil && !visited[*currentID] {
+		visited[*currentID] = true
+
+		category, err := s.repo.FindByID(ctx, *currentID, workspaceID)
+		if err != nil {
+			if shared.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		if category == nil {
+			return false, nil
+		}
+
+		parentID := category.ParentCategoryID()
+		if parentID != nil && *parentID == ancestorID {
+			return true, nil
+		}
+		currentID = parentID
+	}
+
+	return false, nil
+}
+
 // BreadcrumbItem represents a single item in a breadcrumb trail.
 type BreadcrumbItem struct {
 	ID   uuid.UUID
@@ -10,6 +10,9 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/deletionimpact"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
 )
 
 // MockRepository is a mock implementation of Repository for testing.
@@ -68,6 +71,16 @@ func (m *MockRepository) HasChildren(ctx context.Context, workspaceID, parentID
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *MockRepository) MoveItems(ctx context.Context, workspaceID, fromCategoryID, toCategoryID uuid.UUID) (int, error) {
+	args := m.Called(ctx, workspaceID, fromCategoryID, toCategoryID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockRepository) MoveChildren(ctx context.Context, workspaceID, fromCategoryID, toCategoryID uuid.UUID) (int, error) {
+	args := m.Called(ctx, workspaceID, fromCategoryID, toCategoryID)
+	return args.Int(0), args.Error(1)
+}
+
 func TestService_Create(t *testing.T) {
 	ctx := context.Background()
 	workspaceID := uuid.New()
@@ -633,6 +646,59 @@ func TestService_Restore_ErrorPaths(t *testing.T) {
 	})
 }
 
+// MockDeletionGuard is a mock implementation of DeletionGuard for testing.
+type MockDeletionGuard struct {
+	mock.Mock
+}
+
+func (m *MockDeletionGuard) CheckDeletable(ctx context.Context, workspaceID uuid.UUID, entityType deletionimpact.EntityType, id uuid.UUID) error {
+	args := m.Called(ctx, workspaceID, entityType, id)
+	return args.Error(0)
+}
+
+func TestService_Delete_WithDeletionGuard(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+	categoryID := uuid.New()
+
+	t.Run("deletes when guard allows", func(t *testing.T) {
+		repo := new(MockRepository)
+		guard := new(MockDeletionGuard)
+		svc := NewService(repo)
+		svc.SetDeletionGuard(guard)
+
+		existingCat, _ := NewCategory(workspaceID, "Electronics", nil, nil)
+		repo.On("FindByID", ctx, categoryID, workspaceID).Return(existingCat, nil)
+		guard.On("CheckDeletable", ctx, workspaceID, deletionimpact.EntityCategory, existingCat.ID()).Return(nil)
+		repo.On("Delete", ctx, categoryID).Return(nil)
+
+		err := svc.Delete(ctx, categoryID, workspaceID)
+
+		require.NoError(t, err)
+		repo.AssertNotCalled(t, "HasChildren", mock.Anything, mock.Anything)
+		repo.AssertExpectations(t)
+		guard.AssertExpectations(t)
+	})
+
+	t.Run("refuses when guard blocks", func(t *testing.T) {
+		repo := new(MockRepository)
+		guard := new(MockDeletionGuard)
+		svc := NewService(repo)
+		svc.SetDeletionGuard(guard)
+
+		blocked := shared.NewDomainError(shared.ErrConflict, "category cannot be deleted: it has 2 child categories")
+		existingCat, _ := NewCategory(workspaceID, "Electronics", nil, nil)
+		repo.On("FindByID", ctx, categoryID, workspaceID).Return(existingCat, nil)
+		guard.On("CheckDeletable", ctx, workspaceID, deletionimpact.EntityCategory, existingCat.ID()).Return(blocked)
+
+		err := svc.Delete(ctx, categoryID, workspaceID)
+
+		assert.ErrorIs(t, err, shared.ErrConflict)
+		repo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+		guard.AssertExpectations(t)
+	})
+}
+
 func TestService_Delete_ErrorPaths(t *testing.T) {
 	ctx := context.Background()
 	workspaceID := uuid.New()
@@ -905,3 +971,108 @@ func TestService_ValidateNoCyclicParent_NilParent(t *testing.T) {
 		repo.AssertExpectations(t)
 	})
 }
+
+// countingTransactor runs fn inline and records how often WithTx was used.
+type countingTransactor struct{ calls int }
+
+func (c *countingTransactor) WithTx(ctx context.Context, fn func(context.Context) error) error {
+	c.calls++
+	return fn(ctx)
+}
+
+func TestService_MergeCategories(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+
+	// Hierarchy: merge -> child -> grandchild; keep is a separate root
+	keepID := uuid.New()
+	mergeID := uuid.New()
+	childID := uuid.New()
+	grandchildID := uuid.New()
+
+	keep := Reconstruct(keepID, workspaceID, "Tools", nil, nil, false, time.Now(), time.Now())
+	merged := Reconstruct(mergeID, workspaceID, "Hand Tools", nil, nil, false, time.Now(), time.Now())
+	child := Reconstruct(childID, workspaceID, "Screwdrivers", &mergeID, nil, false, time.Now(), time.Now())
+	grandchild := Reconstruct(grandchildID, workspaceID, "Torx", &childID, nil, false, time.Now(), time.Now())
+
+	t.Run("re-points items, re-parents children and deletes the merged category", func(t *testing.T) {
+		repo := new(MockRepository)
+		tx := &countingTransactor{}
+		svc := NewService(repo)
+		svc.SetTransactor(tx)
+
+		repo.On("FindByID", ctx, keepID, workspaceID).Return(keep, nil)
+		repo.On("FindByID", ctx, mergeID, workspaceID).Return(merged, nil)
+		repo.On("MoveItems", ctx, workspaceID, mergeID, keepID).Return(5, nil)
+		repo.On("MoveChildren", ctx, workspaceID, mergeID, keepID).Return(1, nil)
+		repo.On("Delete", ctx, mergeID).Return(nil)
+
+		result, err := svc.MergeCategories(ctx, workspaceID, keepID, mergeID)
+
+		require.NoError(t, err)
+		assert.Equal(t, 5, result.ItemsMoved)
+		assert.Equal(t, 1, result.ChildrenMoved)
+		assert.Equal(t, 1, tx.calls)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("rejects merging a category into itself", func(t *testing.T) {
+		repo := new(MockRepository)
+		svc := NewService(repo)
+
+		result, err := svc.MergeCategories(ctx, workspaceID, mergeID, mergeID)
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, ErrMergeIntoSelf)
+		repo.AssertNotCalled(t, "MoveItems", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects merging into a descendant", func(t *testing.T) {
+		repo := new(MockRepository)
+		svc := NewService(repo)
+
+		repo.On("FindByID", ctx, grandchildID, workspaceID).Return(grandchild, nil)
+		repo.On("FindByID", ctx, mergeID, workspaceID).Return(merged, nil)
+		repo.On("FindByID", ctx, childID, workspaceID).Return(child, nil)
+
+		result, err := svc.MergeCategories(ctx, workspaceID, grandchildID, mergeID)
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, ErrMergeIntoDescendant)
+		repo.AssertNotCalled(t, "MoveItems", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		repo.AssertNotCalled(t, "MoveChildren", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		repo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("returns field error when merged category is missing", func(t *testing.T) {
+		repo := new(MockRepository)
+		svc := NewService(repo)
+
+		repo.On("FindByID", ctx, keepID, workspaceID).Return(keep, nil)
+		repo.On("FindByID", ctx, mergeID, workspaceID).Return(nil, shared.ErrNotFound)
+
+		result, err := svc.MergeCategories(ctx, workspaceID, keepID, mergeID)
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, shared.ErrNotFound)
+		var domainErr *shared.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, "merge_category_id", domainErr.Field)
+	})
+
+	t.Run("stops before deleting when re-parenting fails", func(t *testing.T) {
+		repo := new(MockRepository)
+		svc := NewService(repo)
+
+		repo.On("FindByID", ctx, keepID, workspaceID).Return(keep, nil)
+		repo.On("FindByID", ctx, mergeID, workspaceID).Return(merged, nil)
+		repo.On("MoveItems", ctx, workspaceID, mergeID, keepID).Return(2, nil)
+		repo.On("MoveChildren", ctx, workspaceID, mergeID, keepID).Return(0, errors.New("db error"))
+
+		result, err := svc.MergeCategories(ctx, workspaceID, keepID, mergeID)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+		repo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,70 @@
+package container
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// ContentsEntry is one inventory record stored in a container, with the item
+// it is stock of.
+type ContentsEntry struct {
+	InventoryID   uuid.UUID
+	ItemID        uuid.UUID
+	ItemName      string
+	ItemSKU       string
+	Quantity      int
+	Condition     string
+	Status        string
+	PurchasePrice *int // per unit, in cents of CurrencyCode
+	CurrencyCode  *string
+	// Value is Quantity * PurchasePrice in the workspace base currency (cents),
+	// nil when the record is unpriced or its currency has no exchange rate.
+	Value *int64
+}
+
+// ContentsSummary totals all of a container's contents, not just one page.
+type ContentsSummary struct {
+	Entries       int
+	TotalQuantity int64
+	// TotalValue is the summed Value of the entries, in cents of Currency.
+	TotalValue int64
+	Currency   string
+	// UnpricedEntries and UnconvertedEntries count the records left out of
+	// TotalValue for having no purchase price or no exchange rate.
+	UnpricedEntries    int
+	UnconvertedEntries int
+}
+
+// Contents is a page of a container's inventory and the container totals.
+type Contents struct {
+	Entries shared.PagedResult[ContentsEntry]
+	Summary ContentsSummary
+}
+
+// ContainerContents returns the inventory stored in the container with its
+// estimated value in the workspace base currency, converted with the
+// workspace exchange rates. Containers do not nest, so this is only what is
+// stored directly in the container.
+func (s *Service) ContainerContents(ctx context.Context, workspaceID, containerID uuid.UUID, pagination shared.Pagination) (*Contents, error) {
+	if _, err := s.GetByID(ctx, containerID, workspaceID); err != nil {
+		return nil, err
+	}
+
+	summary, err := s.repo.GetContentsSummary(ctx, workspaceID, containerID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := s.repo.FindContents(ctx, workspaceID, containerID, pagination)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Contents{
+		Entries: shared.NewPagedResult(entries, summary.Entries, pagination),
+		Summary: summary,
+	}, nil
+}
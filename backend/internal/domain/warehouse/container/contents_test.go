@@ -0,0 +1,51 @@
+package container
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+func TestService_ContainerContents(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+	pagination := shared.Pagination{Page: 2, PageSize: 2}
+	box := Reconstruct(uuid.New(), workspaceID, uuid.New(), "Box 1", nil, nil, "BOX1", false, time.Now(), time.Now())
+
+	t.Run("returns a page of entries with the container totals", func(t *testing.T) {
+		repo := new(MockRepository)
+		value := int64(15000)
+		entries := []ContentsEntry{{InventoryID: uuid.New(), ItemName: "Drill", Quantity: 3, Value: &value}}
+		summary := ContentsSummary{Entries: 3, TotalQuantity: 9, TotalValue: 15000, Currency: "EUR", UnpricedEntries: 2}
+		repo.On("FindByID", ctx, box.ID(), workspaceID).Return(box, nil)
+		repo.On("GetContentsSummary", ctx, workspaceID, box.ID()).Return(summary, nil)
+		repo.On("FindContents", ctx, workspaceID, box.ID(), pagination).Return(entries, nil)
+
+		got, err := NewService(repo, nil).ContainerContents(ctx, workspaceID, box.ID(), pagination)
+
+		require.NoError(t, err)
+		assert.Equal(t, entries, got.Entries.Items)
+		assert.Equal(t, 3, got.Entries.Total)
+		assert.Equal(t, 2, got.Entries.Page)
+		assert.Equal(t, 2, got.Entries.TotalPages)
+		assert.Equal(t, summary, got.Summary)
+	})
+
+	t.Run("returns not found for an unknown container", func(t *testing.T) {
+		repo := new(MockRepository)
+		id := uuid.New()
+		repo.On("FindByID", ctx, id, workspaceID).Return(nil, nil)
+
+		_, err := NewService(repo, nil).ContainerContents(ctx, workspaceID, id, pagination)
+
+		assert.ErrorIs(t, err, ErrContainerNotFound)
+		repo.AssertNotCalled(t, "FindContents", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -30,6 +30,7 @@ func RegisterRoutes(api huma.API, svc ServiceInterface, broadcaster *events.Broa
 	huma.Post(api, "/containers/{id}/restore", restoreContainer(svc, broadcaster))
 	huma.Delete(api, routeContainerByID, deleteContainer(svc, broadcaster))
 	huma.Get(api, "/containers/search", searchContainers(svc))
+	huma.Get(api, "/containers/{id}/contents", containerContents(svc))
 }
 
 // listContainers lists containers in the workspace.
@@ -296,6 +297,54 @@ func searchContainers(svc ServiceInterface) func(context.Context, *SearchContain
 	}
 }
 
+// containerContents lists the inventory stored in a container with its total
+// estimated value.
+func containerContents(svc ServiceInterface) func(context.Context, *ContainerContentsInput) (*ContainerContentsOutput, error) {
+	return func(ctx context.Context, input *ContainerContentsInput) (*ContainerContentsOutput, error) {
+		workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+		if !ok {
+			return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+		}
+
+		pagination := shared.Pagination{Page: input.Page, PageSize: input.Limit}
+		contents, err := svc.ContainerContents(ctx, workspaceID, input.ID, pagination)
+		if err != nil {
+			return nil, appMiddleware.MapDomainError(err)
+		}
+
+		items := make([]ContainerContentsItem, len(contents.Entries.Items))
+		for i, e := range contents.Entries.Items {
+			items[i] = ContainerContentsItem{
+				InventoryID:   e.InventoryID,
+				ItemID:        e.ItemID,
+				ItemName:      e.ItemName,
+				ItemSKU:       e.ItemSKU,
+				Quantity:      e.Quantity,
+				Condition:     e.Condition,
+				Status:        e.Status,
+				PurchasePrice: e.PurchasePrice,
+				CurrencyCode:  e.CurrencyCode,
+				Value:         e.Value,
+			}
+		}
+
+		summary := contents.Summary
+		return &ContainerContentsOutput{
+			Body: ContainerContentsResponse{
+				Items:              items,
+				Total:              contents.Entries.Total,
+				Page:               contents.Entries.Page,
+				TotalPages:         contents.Entries.TotalPages,
+				TotalQuantity:      summary.TotalQuantity,
+				TotalValue:         summary.TotalValue,
+				Currency:           summary.Currency,
+				UnpricedEntries:    summary.UnpricedEntries,
+				UnconvertedEntries: summary.UnconvertedEntries,
+			},
+		}, nil
+	}
+}
+
 func toContainerResponse(c *Container) ContainerResponse {
 	return ContainerResponse{
 		ID:          c.ID(),
@@ -390,3 +439,38 @@ type SearchContainersInput struct {
 type SearchContainersOutput struct {
 	Body ContainerListResponse
 }
+
+type ContainerContentsInput struct {
+	ID    uuid.UUID `path:"id"`
+	Page  int       `query:"page" default:"1" minimum:"1"`
+	Limit int       `query:"limit" default:"50" minimum:"1" maximum:"100"`
+}
+
+type ContainerContentsOutput struct {
+	Body ContainerContentsResponse
+}
+
+type ContainerContentsItem struct {
+	InventoryID   uuid.UUID `json:"inventory_id"`
+	ItemID        uuid.UUID `json:"item_id"`
+	ItemName      string    `json:"item_name"`
+	ItemSKU       string    `json:"item_sku"`
+	Quantity      int       `json:"quantity"`
+	Condition     string    `json:"condition,omitempty"`
+	Status        string    `json:"status,omitempty"`
+	PurchasePrice *int      `json:"purchase_price,omitempty" doc:"Unit price in cents of currency_code"`
+	CurrencyCode  *string   `json:"currency_code,omitempty"`
+	Value         *int64    `json:"value,omitempty" doc:"quantity * purchase_price in cents of the workspace base currency; absent when unpriced or no exchange rate is configured"`
+}
+
+type ContainerContentsResponse struct {
+	Items              []ContainerContentsItem `json:"items"`
+	Total              int                     `json:"total"`
+	Page               int                     `json:"page"`
+	TotalPages         int                     `json:"total_pages"`
+	TotalQuantity      int64                   `json:"total_quantity" doc:"Units stored in the container across all pages"`
+	TotalValue         int64                   `json:"total_value" doc:"Summed value of all entries in cents of currency"`
+	Currency           string                  `json:"currency" doc:"Workspace base currency"`
+	UnpricedEntries    int                     `json:"unpriced_entries" doc:"Entries left out of total_value for having no purchase price"`
+	UnconvertedEntries int                     `json:"unconverted_entries" doc:"Entries left out of total_value for being priced in a currency with no exchange rate"`
+}
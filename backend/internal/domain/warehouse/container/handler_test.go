@@ -74,6 +74,14 @@ func (m *MockService) Search(ctx context.Context, workspaceID uuid.UUID, query s
 	return args.Get(0).([]*container.Container), args.Error(1)
 }
 
+func (m *MockService) ContainerContents(ctx context.Context, workspaceID, containerID uuid.UUID, pagination shared.Pagination) (*container.Contents, error) {
+	args := m.Called(ctx, workspaceID, containerID, pagination)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*container.Contents), args.Error(1)
+}
+
 // Tests
 
 func TestContainerHandler_Create(t *testing.T) {
@@ -187,6 +195,38 @@ func TestContainerHandler_List(t *testing.T) {
 	})
 }
 
+func TestContainerHandler_Label(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	container.RegisterLabelRoutes(setup.API, mockSvc, "https://app.example.com/")
+
+	testContainer, _ := container.NewContainer(setup.WorkspaceID, uuid.New(), "Tool Box", nil, nil, "BOX01")
+	containerID := testContainer.ID()
+
+	t.Run("encodes a deep link by default", func(t *testing.T) {
+		mockSvc.On("GetByID", mock.Anything, containerID, setup.WorkspaceID).Return(testContainer, nil).Once()
+
+		rec := setup.Get(fmt.Sprintf("/containers/%s/label", containerID))
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		body := testutil.ParseJSONResponse[container.ContainerLabelResponse](t, rec)
+		assert.Equal(t, container.LabelTargetApp, body.Target)
+		assert.Equal(t, "https://app.example.com/dashboard/containers?focus="+containerID.String(), body.QRContent)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("encodes the short code", func(t *testing.T) {
+		mockSvc.On("GetByID", mock.Anything, containerID, setup.WorkspaceID).Return(testContainer, nil).Once()
+
+		rec := setup.Get(fmt.Sprintf("/containers/%s/label?target=code", containerID))
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		body := testutil.ParseJSONResponse[container.ContainerLabelResponse](t, rec)
+		assert.Equal(t, "BOX01", body.QRContent)
+		mockSvc.AssertExpectations(t)
+	})
+}
+
 func TestContainerHandler_Get(t *testing.T) {
 	setup := testutil.NewHandlerTestSetup()
 	mockSvc := new(MockService)
@@ -369,6 +409,56 @@ func TestContainerHandler_Delete(t *testing.T) {
 	})
 }
 
+func TestContainerHandler_Contents(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	container.RegisterRoutes(setup.API, mockSvc, nil)
+
+	t.Run("lists contents with totals", func(t *testing.T) {
+		containerID := uuid.New()
+		price := 10000
+		usd := "USD"
+		value := int64(15000)
+		pagination := shared.Pagination{Page: 2, PageSize: 1}
+		contents := &container.Contents{
+			Entries: shared.NewPagedResult([]container.ContentsEntry{{
+				InventoryID: uuid.New(), ItemID: uuid.New(), ItemName: "Drill", ItemSKU: "DR-1",
+				Quantity: 3, PurchasePrice: &price, CurrencyCode: &usd, Value: &value,
+			}}, 2, pagination),
+			Summary: container.ContentsSummary{Entries: 2, TotalQuantity: 4, TotalValue: 105000, Currency: "EUR", UnpricedEntries: 1},
+		}
+
+		mockSvc.On("ContainerContents", mock.Anything, setup.WorkspaceID, containerID, pagination).
+			Return(contents, nil).Once()
+
+		rec := setup.Get(fmt.Sprintf("/containers/%s/contents?page=2&limit=1", containerID))
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		body := testutil.ParseJSONResponse[container.ContainerContentsResponse](t, rec)
+		assert.Len(t, body.Items, 1)
+		assert.Equal(t, "Drill", body.Items[0].ItemName)
+		assert.Equal(t, &value, body.Items[0].Value)
+		assert.Equal(t, 2, body.Total)
+		assert.Equal(t, 2, body.TotalPages)
+		assert.EqualValues(t, 105000, body.TotalValue)
+		assert.Equal(t, "EUR", body.Currency)
+		assert.Equal(t, 1, body.UnpricedEntries)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("returns 404 when container not found", func(t *testing.T) {
+		containerID := uuid.New()
+
+		mockSvc.On("ContainerContents", mock.Anything, setup.WorkspaceID, containerID, mock.Anything).
+			Return(nil, container.ErrContainerNotFound).Once()
+
+		rec := setup.Get(fmt.Sprintf("/containers/%s/contents", containerID))
+
+		testutil.AssertStatus(t, rec, http.StatusNotFound)
+		mockSvc.AssertExpectations(t)
+	})
+}
+
 // Event Publishing Tests
 
 func TestContainerHandler_Create_PublishesEvent(t *testing.T) {
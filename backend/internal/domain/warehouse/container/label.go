@@ -0,0 +1,74 @@
+package container
+
+import (
+	"context"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/google/uuid"
+
+	appMiddleware "github.com/antti/home-warehouse/go-backend/internal/api/middleware"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/shortlink"
+)
+
+// QR label targets: what a container's label QR code encodes.
+const (
+	// LabelTargetApp encodes a deep link that opens the container's contents
+	// in the app.
+	LabelTargetApp = "app"
+	// LabelTargetCode encodes the bare short code for the scan resolver.
+	LabelTargetCode = "code"
+)
+
+// RegisterLabelRoutes registers the container label endpoint. appURL is the
+// frontend base URL deep links are built on.
+func RegisterLabelRoutes(api huma.API, svc ServiceInterface, appURL string) {
+	huma.Get(api, "/containers/{id}/label", getContainerLabel(svc, appURL))
+}
+
+// getContainerLabel returns what to print on a container's label, including
+// the QR code content for the requested target.
+func getContainerLabel(svc ServiceInterface, appURL string) func(context.Context, *GetContainerLabelInput) (*GetContainerLabelOutput, error) {
+	return func(ctx context.Context, input *GetContainerLabelInput) (*GetContainerLabelOutput, error) {
+		workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+		if !ok {
+			return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+		}
+
+		container, err := svc.GetByID(ctx, input.ID, workspaceID)
+		if err != nil || container == nil {
+			return nil, huma.Error404NotFound("container not found")
+		}
+
+		qr := container.ShortCode()
+		if input.Target == LabelTargetApp {
+			qr = shortlink.AppLink(appURL, shortlink.TypeContainer, container.ID())
+		}
+
+		return &GetContainerLabelOutput{
+			Body: ContainerLabelResponse{
+				ID:        container.ID(),
+				Name:      container.Name(),
+				ShortCode: container.ShortCode(),
+				Target:    input.Target,
+				QRContent: qr,
+			},
+		}, nil
+	}
+}
+
+type GetContainerLabelInput struct {
+	ID     uuid.UUID `path:"id"`
+	Target string    `query:"target" enum:"app,code" default:"app" doc:"What the QR code encodes: app for a link that opens the container's contents, code for the bare short code"`
+}
+
+type GetContainerLabelOutput struct {
+	Body ContainerLabelResponse
+}
+
+type ContainerLabelResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	ShortCode string    `json:"short_code"`
+	Target    string    `json:"target" enum:"app,code"`
+	QRContent string    `json:"qr_content" doc:"Text to encode in the QR code"`
+}
@@ -20,4 +20,8 @@ type Repository interface {
 	// since migration 005, not per-workspace).
 	ShortCodeExists(ctx context.Context, shortCode string) (bool, error)
 	Search(ctx context.Context, workspaceID uuid.UUID, query string, limit int) ([]*Container, error)
+	// FindContents returns a page of the non-archived inventory stored in the
+	// container, ordered by item name.
+	FindContents(ctx context.Context, workspaceID, containerID uuid.UUID, pagination shared.Pagination) ([]ContentsEntry, error)
+	GetContentsSummary(ctx context.Context, workspaceID, containerID uuid.UUID) (ContentsSummary, error)
 }
@@ -30,6 +30,7 @@ type ServiceInterface interface {
 	Restore(ctx context.Context, id, workspaceID uuid.UUID) error
 	Delete(ctx context.Context, id, workspaceID uuid.UUID) error
 	Search(ctx context.Context, workspaceID uuid.UUID, query string, limit int) ([]*Container, error)
+	ContainerContents(ctx context.Context, workspaceID, containerID uuid.UUID, pagination shared.Pagination) (*Contents, error)
 }
 
 type Service struct {
@@ -73,6 +73,19 @@ func (m *MockRepository) Search(ctx context.Context, workspaceID uuid.UUID, quer
 	return args.Get(0).([]*Container), args.Error(1)
 }
 
+func (m *MockRepository) FindContents(ctx context.Context, workspaceID, containerID uuid.UUID, pagination shared.Pagination) ([]ContentsEntry, error) {
+	args := m.Called(ctx, workspaceID, containerID, pagination)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]ContentsEntry), args.Error(1)
+}
+
+func (m *MockRepository) GetContentsSummary(ctx context.Context, workspaceID, containerID uuid.UUID) (ContentsSummary, error) {
+	args := m.Called(ctx, workspaceID, containerID)
+	return args.Get(0).(ContentsSummary), args.Error(1)
+}
+
 // MockLocationRepository is a mock implementation of the location.Repository interface
 type MockLocationRepository struct {
 	mock.Mock
@@ -133,6 +146,16 @@ func (m *MockLocationRepository) Search(ctx context.Context, workspaceID uuid.UU
 	return args.Get(0).([]*location.Location), args.Error(1)
 }
 
+func (m *MockLocationRepository) MoveInventory(ctx context.Context, workspaceID, fromLocationID, toLocationID uuid.UUID, keepContainers bool) (int, error) {
+	args := m.Called(ctx, workspaceID, fromLocationID, toLocationID, keepContainers)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockLocationRepository) MoveContainers(ctx context.Context, workspaceID, fromLocationID, toLocationID uuid.UUID) (int, error) {
+	args := m.Called(ctx, workspaceID, fromLocationID, toLocationID)
+	return args.Int(0), args.Error(1)
+}
+
 func ptrString(s string) *string {
 	return &s
 }
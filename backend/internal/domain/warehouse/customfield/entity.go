@@ -0,0 +1,200 @@
+package customfield
+
+import (
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// FieldType is the kind of value a custom field holds.
+type FieldType string
+
+const (
+	FieldTypeText   FieldType = "text"
+	FieldTypeNumber FieldType = "number"
+	FieldTypeDate   FieldType = "date"
+	FieldTypeBool   FieldType = "bool"
+)
+
+const (
+	// MaxNameLength matches the custom_fields.name column.
+	MaxNameLength = 100
+	// MaxTextLength bounds text values.
+	MaxTextLength = 1000
+	// DateLayout is the format date values are accepted and stored in.
+	DateLayout = "2006-01-02"
+)
+
+func (t FieldType) IsValid() bool {
+	switch t {
+	case FieldTypeText, FieldTypeNumber, FieldTypeDate, FieldTypeBool:
+		return true
+	}
+	return false
+}
+
+// Field is a workspace-defined item attribute. Its type is fixed at creation
+// so stored values always stay valid; only the name can change.
+type Field struct {
+	id          uuid.UUID
+	workspaceID uuid.UUID
+	name        string
+	fieldType   FieldType
+	createdAt   time.Time
+	updatedAt   time.Time
+}
+
+func NewField(workspaceID uuid.UUID, name string, fieldType FieldType) (*Field, error) {
+	if err := shared.ValidateUUID(workspaceID, "workspace_id"); err != nil {
+		return nil, err
+	}
+	name, err := validateName(name)
+	if err != nil {
+		return nil, err
+	}
+	if !fieldType.IsValid() {
+		return nil, ErrInvalidFieldType
+	}
+
+	now := time.Now()
+	return &Field{
+		id:          shared.NewUUID(),
+		workspaceID: workspaceID,
+		name:        name,
+		fieldType:   fieldType,
+		createdAt:   now,
+		updatedAt:   now,
+	}, nil
+}
+
+func Reconstruct(id, workspaceID uuid.UUID, name string, fieldType FieldType, createdAt, updatedAt time.Time) *Field {
+	return &Field{id, workspaceID, name, fieldType, createdAt, updatedAt}
+}
+
+func (f *Field) ID() uuid.UUID          { return f.id }
+func (f *Field) WorkspaceID() uuid.UUID { return f.workspaceID }
+func (f *Field) Name() string           { return f.name }
+func (f *Field) Type() FieldType        { return f.fieldType }
+func (f *Field) CreatedAt() time.Time   { return f.createdAt }
+func (f *Field) UpdatedAt() time.Time   { return f.updatedAt }
+
+func (f *Field) Rename(name string) error {
+	name, err := validateName(name)
+	if err != nil {
+		return err
+	}
+	f.name = name
+	f.updatedAt = time.Now()
+	return nil
+}
+
+func validateName(name string) (string, error) {
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return "", shared.NewFieldError(shared.ErrInvalidInput, "name", "custom field name is required")
+	}
+	if utf8.RuneCountInString(name) > MaxNameLength {
+		return "", shared.NewFieldError(shared.ErrInvalidInput, "name", "custom field name must be at most 100 characters")
+	}
+	return name, nil
+}
+
+// NormalizeValue validates a decoded JSON value against the field type and
+// returns its canonical stored form. Numbers, dates and booleans may also be
+// given as strings ("12.5", "2024-05-01", "true").
+func (t FieldType) NormalizeValue(raw any) (string, error) {
+	switch t {
+	case FieldTypeText:
+		s, ok := raw.(string)
+		if !ok || strings.TrimSpace(s) == "" {
+			return "", valueError("must be a non-empty string")
+		}
+		if utf8.RuneCountInString(s) > MaxTextLength {
+			return "", valueError("must be at most 1000 characters")
+		}
+		return s, nil
+
+	case FieldTypeNumber:
+		var n float64
+		switch v := raw.(type) {
+		case float64:
+			n = v
+		case string:
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+			if err != nil {
+				return "", valueError("must be a number")
+			}
+			n = parsed
+		default:
+			return "", valueError("must be a number")
+		}
+		if math.IsNaN(n) || math.IsInf(n, 0) {
+			return "", valueError("must be a finite number")
+		}
+		return strconv.FormatFloat(n, 'f', -1, 64), nil
+
+	case FieldTypeDate:
+		s, ok := raw.(string)
+		if !ok {
+			return "", valueError("must be a date in YYYY-MM-DD format")
+		}
+		d, err := time.Parse(DateLayout, strings.TrimSpace(s))
+		if err != nil {
+			return "", valueError("must be a date in YYYY-MM-DD format")
+		}
+		return d.Format(DateLayout), nil
+
+	case FieldTypeBool:
+		switch v := raw.(type) {
+		case bool:
+			return strconv.FormatBool(v), nil
+		case string:
+			switch strings.TrimSpace(v) {
+			case "true":
+				return "true", nil
+			case "false":
+				return "false", nil
+			}
+		}
+		return "", valueError("must be true or false")
+	}
+	return "", ErrInvalidFieldType
+}
+
+// DecodeValue converts a stored value back to its JSON type: float64 for
+// numbers, bool for booleans and string otherwise. Values that no longer
+// parse are returned as stored.
+func (t FieldType) DecodeValue(stored string) any {
+	switch t {
+	case FieldTypeNumber:
+		if n, err := strconv.ParseFloat(stored, 64); err == nil {
+			return n
+		}
+	case FieldTypeBool:
+		if b, err := strconv.ParseBool(stored); err == nil {
+			return b
+		}
+	}
+	return stored
+}
+
+func valueError(msg string) error {
+	return shared.NewFieldError(shared.ErrInvalidInput, "value", "value "+msg)
+}
+
+// ItemValue is an item's value for a custom field, joined with the field
+// definition.
+type ItemValue struct {
+	ItemID    uuid.UUID
+	FieldID   uuid.UUID
+	FieldName string
+	FieldType FieldType
+	// Value is the canonical stored form; see FieldType.DecodeValue.
+	Value string
+}
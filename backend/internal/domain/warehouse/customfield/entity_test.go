@@ -0,0 +1,94 @@
+package customfield
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+func TestNewField(t *testing.T) {
+	workspaceID := uuid.New()
+
+	f, err := NewField(workspaceID, "  Voltage ", FieldTypeNumber)
+	require.NoError(t, err)
+	assert.Equal(t, "Voltage", f.Name())
+	assert.Equal(t, FieldTypeNumber, f.Type())
+	assert.Equal(t, workspaceID, f.WorkspaceID())
+
+	_, err = NewField(workspaceID, "   ", FieldTypeText)
+	assert.ErrorIs(t, err, shared.ErrInvalidInput)
+
+	_, err = NewField(workspaceID, strings.Repeat("x", MaxNameLength+1), FieldTypeText)
+	assert.ErrorIs(t, err, shared.ErrInvalidInput)
+
+	_, err = NewField(workspaceID, "Size", FieldType("color"))
+	assert.ErrorIs(t, err, ErrInvalidFieldType)
+
+	_, err = NewField(uuid.Nil, "Size", FieldTypeText)
+	assert.Error(t, err)
+}
+
+func TestField_Rename(t *testing.T) {
+	f, err := NewField(uuid.New(), "Caliber", FieldTypeText)
+	require.NoError(t, err)
+
+	require.NoError(t, f.Rename("Calibre"))
+	assert.Equal(t, "Calibre", f.Name())
+	assert.ErrorIs(t, f.Rename(""), shared.ErrInvalidInput)
+	assert.Equal(t, "Calibre", f.Name())
+}
+
+func TestFieldType_NormalizeValue(t *testing.T) {
+	tests := []struct {
+		name    string
+		typ     FieldType
+		raw     any
+		want    string
+		wantErr bool
+	}{
+		{"text", FieldTypeText, "9mm", "9mm", false},
+		{"text rejects blank", FieldTypeText, "  ", "", true},
+		{"text rejects number", FieldTypeText, 9.0, "", true},
+		{"text rejects overlong", FieldTypeText, strings.Repeat("x", MaxTextLength+1), "", true},
+		{"number", FieldTypeNumber, 230.0, "230", false},
+		{"number fraction", FieldTypeNumber, 12.5, "12.5", false},
+		{"number from string", FieldTypeNumber, " 1.5e3 ", "1500", false},
+		{"number rejects text", FieldTypeNumber, "high", "", true},
+		{"number rejects bool", FieldTypeNumber, true, "", true},
+		{"number rejects infinity", FieldTypeNumber, "Inf", "", true},
+		{"date", FieldTypeDate, "2024-05-01", "2024-05-01", false},
+		{"date rejects other layout", FieldTypeDate, "01.05.2024", "", true},
+		{"date rejects impossible date", FieldTypeDate, "2024-02-30", "", true},
+		{"date rejects number", FieldTypeDate, 20240501.0, "", true},
+		{"bool", FieldTypeBool, true, "true", false},
+		{"bool false", FieldTypeBool, false, "false", false},
+		{"bool from string", FieldTypeBool, "false", "false", false},
+		{"bool rejects yes", FieldTypeBool, "yes", "", true},
+		{"bool rejects number", FieldTypeBool, 1.0, "", true},
+		{"nil", FieldTypeText, nil, "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.typ.NormalizeValue(tt.raw)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, shared.ErrInvalidInput)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestFieldType_DecodeValue(t *testing.T) {
+	assert.Equal(t, 12.5, FieldTypeNumber.DecodeValue("12.5"))
+	assert.Equal(t, true, FieldTypeBool.DecodeValue("true"))
+	assert.Equal(t, "2024-05-01", FieldTypeDate.DecodeValue("2024-05-01"))
+	assert.Equal(t, "9mm", FieldTypeText.DecodeValue("9mm"))
+	assert.Equal(t, "oops", FieldTypeNumber.DecodeValue("oops"))
+}
@@ -0,0 +1,10 @@
+package customfield
+
+import "github.com/antti/home-warehouse/go-backend/internal/shared"
+
+var (
+	ErrFieldNotFound    = shared.NewDomainError(shared.ErrNotFound, "custom field not found")
+	ErrItemNotFound     = shared.NewDomainError(shared.ErrNotFound, "item not found")
+	ErrNameTaken        = shared.NewDomainError(shared.ErrAlreadyExists, "custom field name is already taken")
+	ErrInvalidFieldType = shared.NewFieldError(shared.ErrInvalidInput, "type", "type must be one of text, number, date, bool")
+)
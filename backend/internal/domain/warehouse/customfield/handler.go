@@ -0,0 +1,284 @@
+package customfield
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/google/uuid"
+
+	appMiddleware "github.com/antti/home-warehouse/go-backend/internal/api/middleware"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+const (
+	msgWorkspaceContextRequired = "workspace context required"
+	msgAdminRequired            = "only workspace owners and admins can manage custom fields"
+	routeFieldByID              = "/custom-fields/{id}"
+	routeItemValue              = "/items/{item_id}/custom-fields/{field_id}"
+)
+
+// RegisterRoutes registers custom field definition and item value routes.
+// Defining fields is limited to owners and admins; any member who can edit
+// items can set values.
+func RegisterRoutes(api huma.API, svc ServiceInterface) {
+	huma.Get(api, "/custom-fields", listFields(svc))
+	huma.Post(api, "/custom-fields", createField(svc))
+	huma.Patch(api, routeFieldByID, renameField(svc))
+	huma.Delete(api, routeFieldByID, deleteField(svc))
+
+	huma.Get(api, "/items/{item_id}/custom-fields", listItemValues(svc))
+	huma.Put(api, routeItemValue, setItemValue(svc))
+	huma.Delete(api, routeItemValue, deleteItemValue(svc))
+}
+
+// listFields lists the workspace's custom field definitions by name.
+func listFields(svc ServiceInterface) func(context.Context, *struct{}) (*ListFieldsOutput, error) {
+	return func(ctx context.Context, _ *struct{}) (*ListFieldsOutput, error) {
+		workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+		if !ok {
+			return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+		}
+
+		fields, err := svc.ListByWorkspace(ctx, workspaceID)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to list custom fields")
+		}
+
+		items := make([]FieldResponse, len(fields))
+		for i, f := range fields {
+			items[i] = toFieldResponse(f)
+		}
+		return &ListFieldsOutput{Body: FieldListResponse{Items: items}}, nil
+	}
+}
+
+// createField defines a new custom field.
+func createField(svc ServiceInterface) func(context.Context, *CreateFieldInput) (*FieldOutput, error) {
+	return func(ctx context.Context, input *CreateFieldInput) (*FieldOutput, error) {
+		workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+		if !ok {
+			return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+		}
+		if !isAdmin(ctx) {
+			return nil, huma.Error403Forbidden(msgAdminRequired)
+		}
+
+		field, err := svc.Create(ctx, CreateInput{
+			WorkspaceID: workspaceID,
+			Name:        input.Body.Name,
+			Type:        FieldType(input.Body.Type),
+		})
+		if err != nil {
+			return nil, mapError(err, "failed to create custom field")
+		}
+		return &FieldOutput{Body: toFieldResponse(field)}, nil
+	}
+}
+
+// renameField renames a custom field. The type cannot be changed.
+func renameField(svc ServiceInterface) func(context.Context, *RenameFieldInput) (*FieldOutput, error) {
+	return func(ctx context.Context, input *RenameFieldInput) (*FieldOutput, error) {
+		workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+		if !ok {
+			return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+		}
+		if !isAdmin(ctx) {
+			return nil, huma.Error403Forbidden(msgAdminRequired)
+		}
+
+		field, err := svc.Rename(ctx, input.ID, workspaceID, input.Body.Name)
+		if err != nil {
+			return nil, mapError(err, "failed to update custom field")
+		}
+		return &FieldOutput{Body: toFieldResponse(field)}, nil
+	}
+}
+
+// deleteField deletes a custom field and all item values for it.
+func deleteField(svc ServiceInterface) func(context.Context, *FieldIDInput) (*struct{}, error) {
+	return func(ctx context.Context, input *FieldIDInput) (*struct{}, error) {
+		workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+		if !ok {
+			return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+		}
+		if !isAdmin(ctx) {
+			return nil, huma.Error403Forbidden(msgAdminRequired)
+		}
+
+		if err := svc.Delete(ctx, input.ID, workspaceID); err != nil {
+			return nil, mapError(err, "failed to delete custom field")
+		}
+		return nil, nil
+	}
+}
+
+// listItemValues lists the custom values set on an item.
+func listItemValues(svc ServiceInterface) func(context.Context, *ItemValuesInput) (*ItemValuesOutput, error) {
+	return func(ctx context.Context, input *ItemValuesInput) (*ItemValuesOutput, error) {
+		workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+		if !ok {
+			return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+		}
+
+		values, err := svc.ListItemValues(ctx, workspaceID, input.ItemID)
+		if err != nil {
+			return nil, mapError(err, "failed to list custom values")
+		}
+		return &ItemValuesOutput{Body: ItemValueListResponse{Items: ToValueResponses(values)}}, nil
+	}
+}
+
+// setItemValue sets an item's value for a custom field.
+func setItemValue(svc ServiceInterface) func(context.Context, *SetItemValueInput) (*ItemValueOutput, error) {
+	return func(ctx context.Context, input *SetItemValueInput) (*ItemValueOutput, error) {
+		workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+		if !ok {
+			return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+		}
+
+		value, err := svc.SetItemValue(ctx, workspaceID, input.ItemID, input.FieldID, input.Body.Value)
+		if err != nil {
+			return nil, mapError(err, "failed to set custom value")
+		}
+		return &ItemValueOutput{Body: ToValueResponse(*value)}, nil
+	}
+}
+
+// deleteItemValue clears an item's value for a custom field.
+func deleteItemValue(svc ServiceInterface) func(context.Context, *ItemValueInput) (*struct{}, error) {
+	return func(ctx context.Context, input *ItemValueInput) (*struct{}, error) {
+		workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+		if !ok {
+			return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+		}
+
+		if err := svc.DeleteItemValue(ctx, workspaceID, input.ItemID, input.FieldID); err != nil {
+			return nil, mapError(err, "failed to clear custom value")
+		}
+		return nil, nil
+	}
+}
+
+func isAdmin(ctx context.Context) bool {
+	role, ok := appMiddleware.GetRole(ctx)
+	return ok && (role == "owner" || role == "admin")
+}
+
+// mapError maps domain errors to their HTTP status and anything else to a
+// 500 with msg.
+func mapError(err error, msg string) error {
+	var domainErr *shared.DomainError
+	if errors.As(err, &domainErr) {
+		return appMiddleware.MapDomainError(err)
+	}
+	return huma.Error500InternalServerError(msg)
+}
+
+func toFieldResponse(f *Field) FieldResponse {
+	return FieldResponse{
+		ID:        f.ID(),
+		Name:      f.Name(),
+		Type:      string(f.Type()),
+		CreatedAt: f.CreatedAt(),
+		UpdatedAt: f.UpdatedAt(),
+	}
+}
+
+// ToValueResponse converts an item value to its API form with the value
+// decoded to its JSON type. The item handlers use it to embed custom values
+// in item responses.
+func ToValueResponse(v ItemValue) ValueResponse {
+	return ValueResponse{
+		FieldID: v.FieldID,
+		Name:    v.FieldName,
+		Type:    string(v.FieldType),
+		Value:   v.FieldType.DecodeValue(v.Value),
+	}
+}
+
+// ToValueResponses converts item values with ToValueResponse.
+func ToValueResponses(values []ItemValue) []ValueResponse {
+	out := make([]ValueResponse, len(values))
+	for i, v := range values {
+		out[i] = ToValueResponse(v)
+	}
+	return out
+}
+
+// Request/Response types
+
+type FieldIDInput struct {
+	ID uuid.UUID `path:"id"`
+}
+
+type CreateFieldInput struct {
+	Body struct {
+		Name string `json:"name" minLength:"1" maxLength:"100" doc:"Field name, unique within the workspace"`
+		Type string `json:"type" enum:"text,number,date,bool" doc:"Value type. Cannot be changed after creation."`
+	}
+}
+
+type RenameFieldInput struct {
+	ID   uuid.UUID `path:"id"`
+	Body struct {
+		Name string `json:"name" minLength:"1" maxLength:"100"`
+	}
+}
+
+type FieldOutput struct {
+	Body FieldResponse
+}
+
+type ListFieldsOutput struct {
+	Body FieldListResponse
+}
+
+type FieldListResponse struct {
+	Items []FieldResponse `json:"items"`
+}
+
+type FieldResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	Type      string    `json:"type" enum:"text,number,date,bool"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type ItemValuesInput struct {
+	ItemID uuid.UUID `path:"item_id"`
+}
+
+type ItemValueInput struct {
+	ItemID  uuid.UUID `path:"item_id"`
+	FieldID uuid.UUID `path:"field_id"`
+}
+
+type SetItemValueInput struct {
+	ItemID  uuid.UUID `path:"item_id"`
+	FieldID uuid.UUID `path:"field_id"`
+	Body    struct {
+		Value any `json:"value" required:"true" doc:"A string for text fields, a number for number fields, YYYY-MM-DD for date fields and true/false for bool fields"`
+	}
+}
+
+type ItemValueOutput struct {
+	Body ValueResponse
+}
+
+type ItemValuesOutput struct {
+	Body ItemValueListResponse
+}
+
+type ItemValueListResponse struct {
+	Items []ValueResponse `json:"items"`
+}
+
+type ValueResponse struct {
+	FieldID uuid.UUID `json:"field_id"`
+	Name    string    `json:"name"`
+	Type    string    `json:"type" enum:"text,number,date,bool"`
+	Value   any       `json:"value" doc:"String, number or boolean depending on the field type"`
+}
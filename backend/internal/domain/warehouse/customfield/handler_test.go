@@ -0,0 +1,229 @@
+package customfield_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/customfield"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+	"github.com/antti/home-warehouse/go-backend/internal/testutil"
+)
+
+// MockService implements customfield.ServiceInterface
+type MockService struct {
+	mock.Mock
+}
+
+func (m *MockService) Create(ctx context.Context, input customfield.CreateInput) (*customfield.Field, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*customfield.Field), args.Error(1)
+}
+
+func (m *MockService) GetByID(ctx context.Context, id, workspaceID uuid.UUID) (*customfield.Field, error) {
+	args := m.Called(ctx, id, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*customfield.Field), args.Error(1)
+}
+
+func (m *MockService) ListByWorkspace(ctx context.Context, workspaceID uuid.UUID) ([]*customfield.Field, error) {
+	args := m.Called(ctx, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*customfield.Field), args.Error(1)
+}
+
+func (m *MockService) Rename(ctx context.Context, id, workspaceID uuid.UUID, name string) (*customfield.Field, error) {
+	args := m.Called(ctx, id, workspaceID, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*customfield.Field), args.Error(1)
+}
+
+func (m *MockService) Delete(ctx context.Context, id, workspaceID uuid.UUID) error {
+	return m.Called(ctx, id, workspaceID).Error(0)
+}
+
+func (m *MockService) ListItemValues(ctx context.Context, workspaceID, itemID uuid.UUID) ([]customfield.ItemValue, error) {
+	args := m.Called(ctx, workspaceID, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]customfield.ItemValue), args.Error(1)
+}
+
+func (m *MockService) SetItemValue(ctx context.Context, workspaceID, itemID, fieldID uuid.UUID, raw any) (*customfield.ItemValue, error) {
+	args := m.Called(ctx, workspaceID, itemID, fieldID, raw)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*customfield.ItemValue), args.Error(1)
+}
+
+func (m *MockService) DeleteItemValue(ctx context.Context, workspaceID, itemID, fieldID uuid.UUID) error {
+	return m.Called(ctx, workspaceID, itemID, fieldID).Error(0)
+}
+
+func (m *MockService) ValuesByItemIDs(ctx context.Context, workspaceID uuid.UUID, itemIDs []uuid.UUID) (map[uuid.UUID][]customfield.ItemValue, error) {
+	args := m.Called(ctx, workspaceID, itemIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID][]customfield.ItemValue), args.Error(1)
+}
+
+func TestCustomFieldHandler_Create(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	customfield.RegisterRoutes(setup.API, mockSvc)
+
+	t.Run("creates field", func(t *testing.T) {
+		f, _ := customfield.NewField(setup.WorkspaceID, "Voltage", customfield.FieldTypeNumber)
+		mockSvc.On("Create", mock.Anything, customfield.CreateInput{
+			WorkspaceID: setup.WorkspaceID,
+			Name:        "Voltage",
+			Type:        customfield.FieldTypeNumber,
+		}).Return(f, nil).Once()
+
+		rec := setup.Post("/custom-fields", `{"name":"Voltage","type":"number"}`)
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		testutil.AssertJSON(t, rec, map[string]any{"name": "Voltage", "type": "number"})
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("returns 422 for unknown type", func(t *testing.T) {
+		rec := setup.Post("/custom-fields", `{"name":"Voltage","type":"float"}`)
+		testutil.AssertStatus(t, rec, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("returns 409 for taken name", func(t *testing.T) {
+		mockSvc.On("Create", mock.Anything, mock.Anything).Return(nil, customfield.ErrNameTaken).Once()
+
+		rec := setup.Post("/custom-fields", `{"name":"Voltage","type":"number"}`)
+		testutil.AssertStatus(t, rec, http.StatusConflict)
+	})
+
+	t.Run("returns 403 for members", func(t *testing.T) {
+		setup.SetRole("member")
+		defer setup.SetRole("owner")
+
+		rec := setup.Post("/custom-fields", `{"name":"Voltage","type":"number"}`)
+		testutil.AssertStatus(t, rec, http.StatusForbidden)
+	})
+}
+
+func TestCustomFieldHandler_List(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	customfield.RegisterRoutes(setup.API, mockSvc)
+
+	a, _ := customfield.NewField(setup.WorkspaceID, "Caliber", customfield.FieldTypeText)
+	b, _ := customfield.NewField(setup.WorkspaceID, "Voltage", customfield.FieldTypeNumber)
+	mockSvc.On("ListByWorkspace", mock.Anything, setup.WorkspaceID).Return([]*customfield.Field{a, b}, nil)
+
+	rec := setup.Get("/custom-fields")
+
+	testutil.AssertStatus(t, rec, http.StatusOK)
+	resp := testutil.ParseJSONResponse[customfield.FieldListResponse](t, rec)
+	assert.Len(t, resp.Items, 2)
+}
+
+func TestCustomFieldHandler_RenameAndDelete(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	customfield.RegisterRoutes(setup.API, mockSvc)
+	f, _ := customfield.NewField(setup.WorkspaceID, "Shoe size", customfield.FieldTypeText)
+
+	t.Run("renames field", func(t *testing.T) {
+		mockSvc.On("Rename", mock.Anything, f.ID(), setup.WorkspaceID, "Shoe size").Return(f, nil).Once()
+
+		rec := setup.Patch(fmt.Sprintf("/custom-fields/%s", f.ID()), `{"name":"Shoe size"}`)
+		testutil.AssertStatus(t, rec, http.StatusOK)
+	})
+
+	t.Run("returns 404 for unknown field", func(t *testing.T) {
+		id := uuid.New()
+		mockSvc.On("Delete", mock.Anything, id, setup.WorkspaceID).Return(customfield.ErrFieldNotFound).Once()
+
+		rec := setup.Delete(fmt.Sprintf("/custom-fields/%s", id))
+		testutil.AssertStatus(t, rec, http.StatusNotFound)
+	})
+
+	t.Run("deletes field", func(t *testing.T) {
+		mockSvc.On("Delete", mock.Anything, f.ID(), setup.WorkspaceID).Return(nil).Once()
+
+		rec := setup.Delete(fmt.Sprintf("/custom-fields/%s", f.ID()))
+		testutil.AssertStatus(t, rec, http.StatusNoContent)
+	})
+}
+
+func TestCustomFieldHandler_ItemValues(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	customfield.RegisterRoutes(setup.API, mockSvc)
+	itemID := uuid.New()
+	fieldID := uuid.New()
+	path := fmt.Sprintf("/items/%s/custom-fields/%s", itemID, fieldID)
+
+	t.Run("sets a value and returns it typed", func(t *testing.T) {
+		setup.SetRole("member")
+		defer setup.SetRole("owner")
+		mockSvc.On("SetItemValue", mock.Anything, setup.WorkspaceID, itemID, fieldID, 230.0).Return(&customfield.ItemValue{
+			ItemID: itemID, FieldID: fieldID, FieldName: "Voltage", FieldType: customfield.FieldTypeNumber, Value: "230",
+		}, nil).Once()
+
+		rec := setup.Put(path, `{"value":230}`)
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		testutil.AssertJSON(t, rec, map[string]any{"name": "Voltage", "type": "number", "value": 230.0})
+	})
+
+	t.Run("returns 400 for a value of the wrong type", func(t *testing.T) {
+		mockSvc.On("SetItemValue", mock.Anything, setup.WorkspaceID, itemID, fieldID, "high").
+			Return(nil, shared.NewFieldError(shared.ErrInvalidInput, "value", "value must be a number")).Once()
+
+		rec := setup.Put(path, `{"value":"high"}`)
+		testutil.AssertStatus(t, rec, http.StatusBadRequest)
+	})
+
+	t.Run("lists item values", func(t *testing.T) {
+		mockSvc.On("ListItemValues", mock.Anything, setup.WorkspaceID, itemID).Return([]customfield.ItemValue{
+			{ItemID: itemID, FieldID: fieldID, FieldName: "In use", FieldType: customfield.FieldTypeBool, Value: "true"},
+		}, nil).Once()
+
+		rec := setup.Get(fmt.Sprintf("/items/%s/custom-fields", itemID))
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		resp := testutil.ParseJSONResponse[customfield.ItemValueListResponse](t, rec)
+		if assert.Len(t, resp.Items, 1) {
+			assert.Equal(t, true, resp.Items[0].Value)
+		}
+	})
+
+	t.Run("returns 404 for unknown item", func(t *testing.T) {
+		mockSvc.On("ListItemValues", mock.Anything, setup.WorkspaceID, itemID).Return(nil, customfield.ErrItemNotFound).Once()
+
+		rec := setup.Get(fmt.Sprintf("/items/%s/custom-fields", itemID))
+		testutil.AssertStatus(t, rec, http.StatusNotFound)
+	})
+
+	t.Run("clears a value", func(t *testing.T) {
+		mockSvc.On("DeleteItemValue", mock.Anything, setup.WorkspaceID, itemID, fieldID).Return(nil).Once()
+
+		rec := setup.Delete(path)
+		testutil.AssertStatus(t, rec, http.StatusNoContent)
+	})
+}
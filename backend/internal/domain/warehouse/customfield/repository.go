@@ -0,0 +1,22 @@
+package customfield
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	Save(ctx context.Context, field *Field) error
+	FindByID(ctx context.Context, id, workspaceID uuid.UUID) (*Field, error)
+	FindByWorkspace(ctx context.Context, workspaceID uuid.UUID) ([]*Field, error)
+	Delete(ctx context.Context, id, workspaceID uuid.UUID) error
+	NameExists(ctx context.Context, workspaceID uuid.UUID, name string) (bool, error)
+
+	ItemExists(ctx context.Context, itemID, workspaceID uuid.UUID) (bool, error)
+	SetValue(ctx context.Context, workspaceID, itemID, fieldID uuid.UUID, value string) error
+	DeleteValue(ctx context.Context, workspaceID, itemID, fieldID uuid.UUID) error
+	// ListValues returns the values of the given items ordered by item and
+	// field name.
+	ListValues(ctx context.Context, workspaceID uuid.UUID, itemIDs []uuid.UUID) ([]ItemValue, error)
+}
@@ -0,0 +1,185 @@
+package customfield
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// ServiceInterface defines the custom field service operations.
+type ServiceInterface interface {
+	Create(ctx context.Context, input CreateInput) (*Field, error)
+	GetByID(ctx context.Context, id, workspaceID uuid.UUID) (*Field, error)
+	ListByWorkspace(ctx context.Context, workspaceID uuid.UUID) ([]*Field, error)
+	Rename(ctx context.Context, id, workspaceID uuid.UUID, name string) (*Field, error)
+	Delete(ctx context.Context, id, workspaceID uuid.UUID) error
+
+	ListItemValues(ctx context.Context, workspaceID, itemID uuid.UUID) ([]ItemValue, error)
+	SetItemValue(ctx context.Context, workspaceID, itemID, fieldID uuid.UUID, raw any) (*ItemValue, error)
+	DeleteItemValue(ctx context.Context, workspaceID, itemID, fieldID uuid.UUID) error
+	ValuesByItemIDs(ctx context.Context, workspaceID uuid.UUID, itemIDs []uuid.UUID) (map[uuid.UUID][]ItemValue, error)
+}
+
+type Service struct {
+	repo Repository
+}
+
+func NewService(repo Repository) *Service {
+	return &Service{repo: repo}
+}
+
+type CreateInput struct {
+	WorkspaceID uuid.UUID
+	Name        string
+	Type        FieldType
+}
+
+func (s *Service) Create(ctx context.Context, input CreateInput) (*Field, error) {
+	field, err := NewField(input.WorkspaceID, input.Name, input.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := s.repo.NameExists(ctx, input.WorkspaceID, field.Name())
+	if err != nil {
+		return nil, err
+	}
+	if exists {
+		return nil, ErrNameTaken
+	}
+
+	if err := s.repo.Save(ctx, field); err != nil {
+		return nil, err
+	}
+	return field, nil
+}
+
+func (s *Service) GetByID(ctx context.Context, id, workspaceID uuid.UUID) (*Field, error) {
+	field, err := s.repo.FindByID(ctx, id, workspaceID)
+	if errors.Is(err, shared.ErrNotFound) || (err == nil && field == nil) {
+		return nil, ErrFieldNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return field, nil
+}
+
+func (s *Service) ListByWorkspace(ctx context.Context, workspaceID uuid.UUID) ([]*Field, error) {
+	return s.repo.FindByWorkspace(ctx, workspaceID)
+}
+
+func (s *Service) Rename(ctx context.Context, id, workspaceID uuid.UUID, name string) (*Field, error) {
+	field, err := s.GetByID(ctx, id, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	oldName := field.Name()
+	if err := field.Rename(name); err != nil {
+		return nil, err
+	}
+	if field.Name() != oldName {
+		exists, err := s.repo.NameExists(ctx, workspaceID, field.Name())
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			return nil, ErrNameTaken
+		}
+	}
+
+	if err := s.repo.Save(ctx, field); err != nil {
+		return nil, err
+	}
+	return field, nil
+}
+
+// Delete removes the field together with every item's value for it.
+func (s *Service) Delete(ctx context.Context, id, workspaceID uuid.UUID) error {
+	field, err := s.GetByID(ctx, id, workspaceID)
+	if err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, field.ID(), workspaceID)
+}
+
+// ListItemValues returns the custom values set on an item, ordered by field
+// name.
+func (s *Service) ListItemValues(ctx context.Context, workspaceID, itemID uuid.UUID) ([]ItemValue, error) {
+	if err := s.requireItem(ctx, workspaceID, itemID); err != nil {
+		return nil, err
+	}
+	return s.repo.ListValues(ctx, workspaceID, []uuid.UUID{itemID})
+}
+
+// SetItemValue validates raw against the field type and stores it on the
+// item, replacing any previous value.
+func (s *Service) SetItemValue(ctx context.Context, workspaceID, itemID, fieldID uuid.UUID, raw any) (*ItemValue, error) {
+	field, err := s.GetByID(ctx, fieldID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.requireItem(ctx, workspaceID, itemID); err != nil {
+		return nil, err
+	}
+
+	value, err := field.Type().NormalizeValue(raw)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.SetValue(ctx, workspaceID, itemID, fieldID, value); err != nil {
+		return nil, err
+	}
+
+	return &ItemValue{
+		ItemID:    itemID,
+		FieldID:   fieldID,
+		FieldName: field.Name(),
+		FieldType: field.Type(),
+		Value:     value,
+	}, nil
+}
+
+// DeleteItemValue clears the item's value for the field. Clearing a value
+// that was never set is not an error.
+func (s *Service) DeleteItemValue(ctx context.Context, workspaceID, itemID, fieldID uuid.UUID) error {
+	if _, err := s.GetByID(ctx, fieldID, workspaceID); err != nil {
+		return err
+	}
+	if err := s.requireItem(ctx, workspaceID, itemID); err != nil {
+		return err
+	}
+	return s.repo.DeleteValue(ctx, workspaceID, itemID, fieldID)
+}
+
+// ValuesByItemIDs returns the custom values of a batch of items keyed by item
+// ID. Items without values are absent from the map.
+func (s *Service) ValuesByItemIDs(ctx context.Context, workspaceID uuid.UUID, itemIDs []uuid.UUID) (map[uuid.UUID][]ItemValue, error) {
+	out := make(map[uuid.UUID][]ItemValue)
+	if len(itemIDs) == 0 {
+		return out, nil
+	}
+	values, err := s.repo.ListValues(ctx, workspaceID, itemIDs)
+	if err != nil {
+		return nil, err
+	}
+	for _, v := range values {
+		out[v.ItemID] = append(out[v.ItemID], v)
+	}
+	return out, nil
+}
+
+func (s *Service) requireItem(ctx context.Context, workspaceID, itemID uuid.UUID) error {
+	exists, err := s.repo.ItemExists(ctx, itemID, workspaceID)
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return ErrItemNotFound
+	}
+	return nil
+}
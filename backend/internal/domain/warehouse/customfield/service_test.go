@@ -0,0 +1,238 @@
+package customfield
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// MockRepository is a mock implementation of the Repository interface
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) Save(ctx context.Context, field *Field) error {
+	return m.Called(ctx, field).Error(0)
+}
+
+func (m *MockRepository) FindByID(ctx context.Context, id, workspaceID uuid.UUID) (*Field, error) {
+	args := m.Called(ctx, id, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Field), args.Error(1)
+}
+
+func (m *MockRepository) FindByWorkspace(ctx context.Context, workspaceID uuid.UUID) ([]*Field, error) {
+	args := m.Called(ctx, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*Field), args.Error(1)
+}
+
+func (m *MockRepository) Delete(ctx context.Context, id, workspaceID uuid.UUID) error {
+	return m.Called(ctx, id, workspaceID).Error(0)
+}
+
+func (m *MockRepository) NameExists(ctx context.Context, workspaceID uuid.UUID, name string) (bool, error) {
+	args := m.Called(ctx, workspaceID, name)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRepository) ItemExists(ctx context.Context, itemID, workspaceID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, itemID, workspaceID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRepository) SetValue(ctx context.Context, workspaceID, itemID, fieldID uuid.UUID, value string) error {
+	return m.Called(ctx, workspaceID, itemID, fieldID, value).Error(0)
+}
+
+func (m *MockRepository) DeleteValue(ctx context.Context, workspaceID, itemID, fieldID uuid.UUID) error {
+	return m.Called(ctx, workspaceID, itemID, fieldID).Error(0)
+}
+
+func (m *MockRepository) ListValues(ctx context.Context, workspaceID uuid.UUID, itemIDs []uuid.UUID) ([]ItemValue, error) {
+	args := m.Called(ctx, workspaceID, itemIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]ItemValue), args.Error(1)
+}
+
+func TestService_Create(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+
+	t.Run("creates field", func(t *testing.T) {
+		repo := new(MockRepository)
+		svc := NewService(repo)
+		repo.On("NameExists", ctx, workspaceID, "Voltage").Return(false, nil)
+		repo.On("Save", ctx, mock.AnythingOfType("*customfield.Field")).Return(nil)
+
+		f, err := svc.Create(ctx, CreateInput{WorkspaceID: workspaceID, Name: "Voltage", Type: FieldTypeNumber})
+		require.NoError(t, err)
+		assert.Equal(t, "Voltage", f.Name())
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("rejects taken name", func(t *testing.T) {
+		repo := new(MockRepository)
+		svc := NewService(repo)
+		repo.On("NameExists", ctx, workspaceID, "Voltage").Return(true, nil)
+
+		_, err := svc.Create(ctx, CreateInput{WorkspaceID: workspaceID, Name: "Voltage", Type: FieldTypeNumber})
+		assert.ErrorIs(t, err, ErrNameTaken)
+		repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects invalid type before touching the repository", func(t *testing.T) {
+		repo := new(MockRepository)
+		svc := NewService(repo)
+
+		_, err := svc.Create(ctx, CreateInput{WorkspaceID: workspaceID, Name: "Voltage", Type: "float"})
+		assert.ErrorIs(t, err, ErrInvalidFieldType)
+		repo.AssertExpectations(t)
+	})
+}
+
+func TestService_Rename(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+
+	t.Run("renames field", func(t *testing.T) {
+		repo := new(MockRepository)
+		svc := NewService(repo)
+		f, _ := NewField(workspaceID, "Size", FieldTypeText)
+		repo.On("FindByID", ctx, f.ID(), workspaceID).Return(f, nil)
+		repo.On("NameExists", ctx, workspaceID, "Shoe size").Return(false, nil)
+		repo.On("Save", ctx, f).Return(nil)
+
+		got, err := svc.Rename(ctx, f.ID(), workspaceID, "Shoe size")
+		require.NoError(t, err)
+		assert.Equal(t, "Shoe size", got.Name())
+	})
+
+	t.Run("rejects taken name", func(t *testing.T) {
+		repo := new(MockRepository)
+		svc := NewService(repo)
+		f, _ := NewField(workspaceID, "Size", FieldTypeText)
+		repo.On("FindByID", ctx, f.ID(), workspaceID).Return(f, nil)
+		repo.On("NameExists", ctx, workspaceID, "Color").Return(true, nil)
+
+		_, err := svc.Rename(ctx, f.ID(), workspaceID, "Color")
+		assert.ErrorIs(t, err, ErrNameTaken)
+	})
+
+	t.Run("returns not found", func(t *testing.T) {
+		repo := new(MockRepository)
+		svc := NewService(repo)
+		id := uuid.New()
+		repo.On("FindByID", ctx, id, workspaceID).Return(nil, shared.ErrNotFound)
+
+		_, err := svc.Rename(ctx, id, workspaceID, "Color")
+		assert.ErrorIs(t, err, ErrFieldNotFound)
+	})
+}
+
+func TestService_SetItemValue(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+	itemID := uuid.New()
+
+	t.Run("stores the normalized value", func(t *testing.T) {
+		repo := new(MockRepository)
+		svc := NewService(repo)
+		f, _ := NewField(workspaceID, "Voltage", FieldTypeNumber)
+		repo.On("FindByID", ctx, f.ID(), workspaceID).Return(f, nil)
+		repo.On("ItemExists", ctx, itemID, workspaceID).Return(true, nil)
+		repo.On("SetValue", ctx, workspaceID, itemID, f.ID(), "230").Return(nil)
+
+		v, err := svc.SetItemValue(ctx, workspaceID, itemID, f.ID(), "230.0")
+		require.NoError(t, err)
+		assert.Equal(t, "230", v.Value)
+		assert.Equal(t, "Voltage", v.FieldName)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("rejects a value of the wrong type", func(t *testing.T) {
+		repo := new(MockRepository)
+		svc := NewService(repo)
+		f, _ := NewField(workspaceID, "Warranty until", FieldTypeDate)
+		repo.On("FindByID", ctx, f.ID(), workspaceID).Return(f, nil)
+		repo.On("ItemExists", ctx, itemID, workspaceID).Return(true, nil)
+
+		_, err := svc.SetItemValue(ctx, workspaceID, itemID, f.ID(), true)
+		assert.ErrorIs(t, err, shared.ErrInvalidInput)
+		repo.AssertNotCalled(t, "SetValue", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects an item outside the workspace", func(t *testing.T) {
+		repo := new(MockRepository)
+		svc := NewService(repo)
+		f, _ := NewField(workspaceID, "Voltage", FieldTypeNumber)
+		repo.On("FindByID", ctx, f.ID(), workspaceID).Return(f, nil)
+		repo.On("ItemExists", ctx, itemID, workspaceID).Return(false, nil)
+
+		_, err := svc.SetItemValue(ctx, workspaceID, itemID, f.ID(), 230.0)
+		assert.ErrorIs(t, err, ErrItemNotFound)
+	})
+
+	t.Run("rejects an unknown field", func(t *testing.T) {
+		repo := new(MockRepository)
+		svc := NewService(repo)
+		fieldID := uuid.New()
+		repo.On("FindByID", ctx, fieldID, workspaceID).Return(nil, shared.ErrNotFound)
+
+		_, err := svc.SetItemValue(ctx, workspaceID, itemID, fieldID, 230.0)
+		assert.ErrorIs(t, err, ErrFieldNotFound)
+	})
+}
+
+func TestService_ValuesByItemIDs(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+	a, b := uuid.New(), uuid.New()
+
+	t.Run("groups values by item", func(t *testing.T) {
+		repo := new(MockRepository)
+		svc := NewService(repo)
+		repo.On("ListValues", ctx, workspaceID, []uuid.UUID{a, b}).Return([]ItemValue{
+			{ItemID: a, FieldName: "Size", Value: "M"},
+			{ItemID: a, FieldName: "Voltage", Value: "12"},
+			{ItemID: b, FieldName: "Size", Value: "L"},
+		}, nil)
+
+		got, err := svc.ValuesByItemIDs(ctx, workspaceID, []uuid.UUID{a, b})
+		require.NoError(t, err)
+		assert.Len(t, got[a], 2)
+		assert.Len(t, got[b], 1)
+	})
+
+	t.Run("skips the query for no items", func(t *testing.T) {
+		repo := new(MockRepository)
+		svc := NewService(repo)
+
+		got, err := svc.ValuesByItemIDs(ctx, workspaceID, nil)
+		require.NoError(t, err)
+		assert.Empty(t, got)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("returns repository error", func(t *testing.T) {
+		repo := new(MockRepository)
+		svc := NewService(repo)
+		repo.On("ListValues", ctx, workspaceID, []uuid.UUID{a}).Return(nil, errors.New("db down"))
+
+		_, err := svc.ValuesByItemIDs(ctx, workspaceID, []uuid.UUID{a})
+		assert.Error(t, err)
+	})
+}
@@ -36,6 +36,30 @@ func (m *MockService) CleanupOld(ctx context.Context, before time.Time) error {
 	return args.Error(0)
 }
 
+func (m *MockService) GetSettings(ctx context.Context, workspaceID uuid.UUID) (*deleted.Settings, error) {
+	args := m.Called(ctx, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*deleted.Settings), args.Error(1)
+}
+
+func (m *MockService) UpdateSettings(ctx context.Context, workspaceID uuid.UUID, settings deleted.Settings) (*deleted.Settings, error) {
+	args := m.Called(ctx, workspaceID, settings)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*deleted.Settings), args.Error(1)
+}
+
+func (m *MockService) ResetSettings(ctx context.Context, workspaceID uuid.UUID) (*deleted.Settings, error) {
+	args := m.Called(ctx, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*deleted.Settings), args.Error(1)
+}
+
 // Tests
 
 func TestDeletedHandler_GetDeletedSince(t *testing.T) {
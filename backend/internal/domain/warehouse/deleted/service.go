@@ -14,10 +14,15 @@ type ServiceInterface interface {
 	RecordDeletion(ctx context.Context, workspaceID uuid.UUID, entityType activity.EntityType, entityID uuid.UUID, deletedBy *uuid.UUID) error
 	GetDeletedSince(ctx context.Context, workspaceID uuid.UUID, since time.Time) ([]*DeletedRecord, error)
 	CleanupOld(ctx context.Context, before time.Time) error
+	GetSettings(ctx context.Context, workspaceID uuid.UUID) (*Settings, error)
+	UpdateSettings(ctx context.Context, workspaceID uuid.UUID, settings Settings) (*Settings, error)
+	ResetSettings(ctx context.Context, workspaceID uuid.UUID) (*Settings, error)
 }
 
 type Service struct {
-	repo Repository
+	repo                 Repository
+	settings             SettingsRepository
+	defaultRetentionDays int
 }
 
 func NewService(repo Repository) *Service {
@@ -0,0 +1,184 @@
+package deleted
+
+import (
+	"context"
+	"errors"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/google/uuid"
+
+	appMiddleware "github.com/antti/home-warehouse/go-backend/internal/api/middleware"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+const (
+	// MinTrashRetentionDays and MaxTrashRetentionDays bound
+	// Settings.TrashRetentionDays, matching the check constraint on
+	// warehouse.trash_settings.
+	MinTrashRetentionDays = 1
+	MaxTrashRetentionDays = 3650
+)
+
+// Settings is the workspace trash policy.
+type Settings struct {
+	// TrashRetentionDays is how long deleted records are kept before the
+	// cleanup job removes them for good.
+	TrashRetentionDays int
+	// IsDefault reports that the workspace has no setting of its own and
+	// uses the server-wide retention.
+	IsDefault bool
+}
+
+// SettingsRepository persists trash settings per workspace. Get returns
+// shared.ErrNotFound when the workspace has never saved any.
+type SettingsRepository interface {
+	Get(ctx context.Context, workspaceID uuid.UUID) (*Settings, error)
+	Upsert(ctx context.Context, workspaceID uuid.UUID, settings Settings) (*Settings, error)
+	Delete(ctx context.Context, workspaceID uuid.UUID) error
+}
+
+// SetSettingsRepository wires trash settings storage. defaultRetentionDays is
+// the server-wide retention the cleanup job applies to workspaces without
+// settings. Without a repository every workspace uses it and it cannot be
+// changed.
+func (s *Service) SetSettingsRepository(repo SettingsRepository, defaultRetentionDays int) {
+	s.settings = repo
+	s.defaultRetentionDays = defaultRetentionDays
+}
+
+// GetSettings returns the workspace trash settings, falling back to the
+// server-wide retention when none have been saved.
+func (s *Service) GetSettings(ctx context.Context, workspaceID uuid.UUID) (*Settings, error) {
+	if s.settings == nil {
+		return s.defaultSettings(), nil
+	}
+	settings, err := s.settings.Get(ctx, workspaceID)
+	if errors.Is(err, shared.ErrNotFound) {
+		return s.defaultSettings(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// UpdateSettings replaces the workspace trash settings. A shorter retention
+// takes effect at the next cleanup run, so records past it are purged then.
+func (s *Service) UpdateSettings(ctx context.Context, workspaceID uuid.UUID, settings Settings) (*Settings, error) {
+	if settings.TrashRetentionDays < MinTrashRetentionDays || settings.TrashRetentionDays > MaxTrashRetentionDays {
+		return nil, shared.NewFieldError(shared.ErrInvalidInput, "trash_retention_days", "must be between 1 and 3650")
+	}
+	if s.settings == nil {
+		return nil, errors.New("trash settings storage is not configured")
+	}
+	return s.settings.Upsert(ctx, workspaceID, settings)
+}
+
+// ResetSettings removes the workspace trash settings so the server-wide
+// retention applies again.
+func (s *Service) ResetSettings(ctx context.Context, workspaceID uuid.UUID) (*Settings, error) {
+	if s.settings == nil {
+		return s.defaultSettings(), nil
+	}
+	if err := s.settings.Delete(ctx, workspaceID); err != nil {
+		return nil, err
+	}
+	return s.defaultSettings(), nil
+}
+
+func (s *Service) defaultSettings() *Settings {
+	return &Settings{TrashRetentionDays: s.defaultRetentionDays, IsDefault: true}
+}
+
+// RegisterSettingsRoutes registers the workspace trash settings endpoints.
+func RegisterSettingsRoutes(api huma.API, svc ServiceInterface) {
+	huma.Get(api, "/trash-settings", getTrashSettings(svc))
+	huma.Put(api, "/trash-settings", updateTrashSettings(svc))
+	huma.Delete(api, "/trash-settings", resetTrashSettings(svc))
+}
+
+func getTrashSettings(svc ServiceInterface) func(context.Context, *struct{}) (*TrashSettingsOutput, error) {
+	return func(ctx context.Context, _ *struct{}) (*TrashSettingsOutput, error) {
+		workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+		if !ok {
+			return nil, huma.Error401Unauthorized("workspace context required")
+		}
+
+		settings, err := svc.GetSettings(ctx, workspaceID)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to fetch trash settings")
+		}
+
+		return &TrashSettingsOutput{Body: toTrashSettingsResponse(settings)}, nil
+	}
+}
+
+func updateTrashSettings(svc ServiceInterface) func(context.Context, *UpdateTrashSettingsInput) (*TrashSettingsOutput, error) {
+	return func(ctx context.Context, input *UpdateTrashSettingsInput) (*TrashSettingsOutput, error) {
+		workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+		if !ok {
+			return nil, huma.Error401Unauthorized("workspace context required")
+		}
+		if err := requireSettingsRole(ctx); err != nil {
+			return nil, err
+		}
+
+		settings, err := svc.UpdateSettings(ctx, workspaceID, Settings{
+			TrashRetentionDays: input.Body.TrashRetentionDays,
+		})
+		var domainErr *shared.DomainError
+		if errors.As(err, &domainErr) {
+			return nil, appMiddleware.MapDomainError(err)
+		}
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to update trash settings")
+		}
+
+		return &TrashSettingsOutput{Body: toTrashSettingsResponse(settings)}, nil
+	}
+}
+
+func resetTrashSettings(svc ServiceInterface) func(context.Context, *struct{}) (*TrashSettingsOutput, error) {
+	return func(ctx context.Context, _ *struct{}) (*TrashSettingsOutput, error) {
+		workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+		if !ok {
+			return nil, huma.Error401Unauthorized("workspace context required")
+		}
+		if err := requireSettingsRole(ctx); err != nil {
+			return nil, err
+		}
+
+		settings, err := svc.ResetSettings(ctx, workspaceID)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to reset trash settings")
+		}
+
+		return &TrashSettingsOutput{Body: toTrashSettingsResponse(settings)}, nil
+	}
+}
+
+func requireSettingsRole(ctx context.Context) error {
+	if role, ok := appMiddleware.GetRole(ctx); !ok || (role != "owner" && role != "admin") {
+		return huma.Error403Forbidden("only workspace owners and admins can change trash settings")
+	}
+	return nil
+}
+
+func toTrashSettingsResponse(s *Settings) TrashSettingsResponse {
+	return TrashSettingsResponse{TrashRetentionDays: s.TrashRetentionDays, IsDefault: s.IsDefault}
+}
+
+type UpdateTrashSettingsInput struct {
+	Body struct {
+		TrashRetentionDays int `json:"trash_retention_days" minimum:"1" maximum:"3650" doc:"Days deleted records are kept before they are removed for good"`
+	}
+}
+
+type TrashSettingsOutput struct {
+	Body TrashSettingsResponse
+}
+
+type TrashSettingsResponse struct {
+	TrashRetentionDays int  `json:"trash_retention_days" doc:"Days deleted records are kept before they are removed for good"`
+	IsDefault          bool `json:"is_default" doc:"True when the workspace uses the server-wide retention"`
+}
@@ -0,0 +1,122 @@
+package deleted
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+type mockSettingsRepository struct {
+	mock.Mock
+}
+
+func (m *mockSettingsRepository) Get(ctx context.Context, workspaceID uuid.UUID) (*Settings, error) {
+	args := m.Called(ctx, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Settings), args.Error(1)
+}
+
+func (m *mockSettingsRepository) Upsert(ctx context.Context, workspaceID uuid.UUID, settings Settings) (*Settings, error) {
+	args := m.Called(ctx, workspaceID, settings)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Settings), args.Error(1)
+}
+
+func (m *mockSettingsRepository) Delete(ctx context.Context, workspaceID uuid.UUID) error {
+	args := m.Called(ctx, workspaceID)
+	return args.Error(0)
+}
+
+func TestService_GetSettings(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+
+	t.Run("defaults when nothing is saved", func(t *testing.T) {
+		repo := new(mockSettingsRepository)
+		svc := NewService(new(MockRepository))
+		svc.SetSettingsRepository(repo, 90)
+		repo.On("Get", ctx, workspaceID).Return(nil, shared.ErrNotFound)
+
+		settings, err := svc.GetSettings(ctx, workspaceID)
+		require.NoError(t, err)
+		assert.Equal(t, 90, settings.TrashRetentionDays)
+		assert.True(t, settings.IsDefault)
+	})
+
+	t.Run("returns saved settings", func(t *testing.T) {
+		repo := new(mockSettingsRepository)
+		svc := NewService(new(MockRepository))
+		svc.SetSettingsRepository(repo, 90)
+		repo.On("Get", ctx, workspaceID).Return(&Settings{TrashRetentionDays: 14}, nil)
+
+		settings, err := svc.GetSettings(ctx, workspaceID)
+		require.NoError(t, err)
+		assert.Equal(t, 14, settings.TrashRetentionDays)
+		assert.False(t, settings.IsDefault)
+	})
+
+	t.Run("returns repository error", func(t *testing.T) {
+		repo := new(mockSettingsRepository)
+		svc := NewService(new(MockRepository))
+		svc.SetSettingsRepository(repo, 90)
+		repo.On("Get", ctx, workspaceID).Return(nil, errors.New("db down"))
+
+		_, err := svc.GetSettings(ctx, workspaceID)
+		assert.Error(t, err)
+	})
+}
+
+func TestService_UpdateSettings(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+
+	t.Run("saves valid settings", func(t *testing.T) {
+		repo := new(mockSettingsRepository)
+		svc := NewService(new(MockRepository))
+		svc.SetSettingsRepository(repo, 90)
+		repo.On("Upsert", ctx, workspaceID, Settings{TrashRetentionDays: 30}).Return(&Settings{TrashRetentionDays: 30}, nil)
+
+		settings, err := svc.UpdateSettings(ctx, workspaceID, Settings{TrashRetentionDays: 30})
+		require.NoError(t, err)
+		assert.Equal(t, 30, settings.TrashRetentionDays)
+		repo.AssertExpectations(t)
+	})
+
+	for _, days := range []int{0, MaxTrashRetentionDays + 1} {
+		t.Run("rejects out of range retention", func(t *testing.T) {
+			repo := new(mockSettingsRepository)
+			svc := NewService(new(MockRepository))
+			svc.SetSettingsRepository(repo, 90)
+
+			_, err := svc.UpdateSettings(ctx, workspaceID, Settings{TrashRetentionDays: days})
+			assert.ErrorIs(t, err, shared.ErrInvalidInput)
+			repo.AssertNotCalled(t, "Upsert", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestService_ResetSettings(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+	repo := new(mockSettingsRepository)
+	svc := NewService(new(MockRepository))
+	svc.SetSettingsRepository(repo, 90)
+	repo.On("Delete", ctx, workspaceID).Return(nil)
+
+	settings, err := svc.ResetSettings(ctx, workspaceID)
+	require.NoError(t, err)
+	assert.Equal(t, 90, settings.TrashRetentionDays)
+	assert.True(t, settings.IsDefault)
+	repo.AssertExpectations(t)
+}
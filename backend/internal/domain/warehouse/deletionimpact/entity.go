@@ -0,0 +1,92 @@
+package deletionimpact
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// EntityType identifies which kind of entity a deletion impact describes.
+type EntityType string
+
+const (
+	EntityCategory EntityType = "category"
+	EntityLocation EntityType = "location"
+)
+
+// IsValid reports whether the entity type supports deletion impact checks.
+func (t EntityType) IsValid() bool {
+	switch t {
+	case EntityCategory, EntityLocation:
+		return true
+	}
+	return false
+}
+
+// Impact counts the dependents of a category or location that deleting it
+// would either be blocked by or cascade to. Fields that don't apply to the
+// entity type stay zero.
+type Impact struct {
+	EntityType EntityType
+	EntityID   uuid.UUID
+
+	// Category dependents
+	ChildCategories int // Active children; block the delete
+	Items           int // Become uncategorized
+
+	// Location dependents
+	ChildLocations int // Become top-level locations
+	Inventory      int // Block the delete (evacuate first)
+	Containers     int // Deleted along with the location
+	ActiveLoans    int // Loans on inventory stored here; block the delete
+}
+
+// Blocker is a dependent that prevents the delete from going ahead.
+type Blocker struct {
+	Reason string
+	Count  int
+}
+
+// Blockers lists what prevents the delete, in a stable order. Empty when the
+// delete can proceed.
+func (i *Impact) Blockers() []Blocker {
+	var blockers []Blocker
+	add := func(reason string, count int) {
+		if count > 0 {
+			blockers = append(blockers, Blocker{Reason: reason, Count: count})
+		}
+	}
+
+	switch i.EntityType {
+	case EntityCategory:
+		add("child_categories", i.ChildCategories)
+	case EntityLocation:
+		add("inventory", i.Inventory)
+		add("active_loans", i.ActiveLoans)
+	}
+	return blockers
+}
+
+// CanDelete reports whether nothing blocks the delete.
+func (i *Impact) CanDelete() bool {
+	return len(i.Blockers()) == 0
+}
+
+// Err returns a conflict error describing the blockers, or nil when the
+// delete can proceed. Delete paths return this so the refusal matches what
+// the preview reported.
+func (i *Impact) Err() error {
+	blockers := i.Blockers()
+	if len(blockers) == 0 {
+		return nil
+	}
+
+	parts := make([]string, len(blockers))
+	for n, b := range blockers {
+		parts[n] = fmt.Sprintf("%d %s", b.Count, strings.ReplaceAll(b.Reason, "_", " "))
+	}
+	return shared.NewDomainError(shared.ErrConflict, fmt.Sprintf("%s cannot be deleted: it has %s", i.EntityType, strings.Join(parts, ", ")))
+}
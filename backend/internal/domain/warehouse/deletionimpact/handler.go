@@ -0,0 +1,85 @@
+package deletionimpact
+
+import (
+	"context"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/google/uuid"
+
+	appMiddleware "github.com/antti/home-warehouse/go-backend/internal/api/middleware"
+)
+
+const msgWorkspaceContextRequired = "workspace context required"
+
+// RegisterRoutes registers the deletion impact preview routes. They sit under
+// the category and location paths so clients can ask before calling DELETE.
+func RegisterRoutes(api huma.API, svc ServiceInterface) {
+	huma.Get(api, "/categories/{id}/deletion-impact", getDeletionImpact(svc, EntityCategory))
+	huma.Get(api, "/locations/{id}/deletion-impact", getDeletionImpact(svc, EntityLocation))
+}
+
+// getDeletionImpact returns what deleting the entity would block on or cascade to.
+func getDeletionImpact(svc ServiceInterface, entityType EntityType) func(context.Context, *GetDeletionImpactInput) (*GetDeletionImpactOutput, error) {
+	return func(ctx context.Context, input *GetDeletionImpactInput) (*GetDeletionImpactOutput, error) {
+		workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+		if !ok {
+			return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+		}
+
+		impact, err := svc.DeletionImpact(ctx, workspaceID, entityType, input.ID)
+		if err != nil {
+			return nil, appMiddleware.MapDomainError(err)
+		}
+
+		return &GetDeletionImpactOutput{Body: toDeletionImpactResponse(impact)}, nil
+	}
+}
+
+func toDeletionImpactResponse(impact *Impact) DeletionImpactResponse {
+	blockers := impact.Blockers()
+	blockedBy := make([]BlockerResponse, len(blockers))
+	for i, b := range blockers {
+		blockedBy[i] = BlockerResponse{Reason: b.Reason, Count: b.Count}
+	}
+
+	return DeletionImpactResponse{
+		EntityType:      string(impact.EntityType),
+		EntityID:        impact.EntityID,
+		CanDelete:       len(blockers) == 0,
+		BlockedBy:       blockedBy,
+		ChildCategories: impact.ChildCategories,
+		Items:           impact.Items,
+		ChildLocations:  impact.ChildLocations,
+		Inventory:       impact.Inventory,
+		Containers:      impact.Containers,
+		ActiveLoans:     impact.ActiveLoans,
+	}
+}
+
+// Request/Response types
+
+type GetDeletionImpactInput struct {
+	ID uuid.UUID `path:"id"`
+}
+
+type GetDeletionImpactOutput struct {
+	Body DeletionImpactResponse
+}
+
+type DeletionImpactResponse struct {
+	EntityType      string            `json:"entity_type" enum:"category,location"`
+	EntityID        uuid.UUID         `json:"entity_id"`
+	CanDelete       bool              `json:"can_delete" doc:"False when any dependent blocks the delete"`
+	BlockedBy       []BlockerResponse `json:"blocked_by" doc:"Dependents that prevent the delete"`
+	ChildCategories int               `json:"child_categories" doc:"Active child categories (category only; blocks delete)"`
+	Items           int               `json:"items" doc:"Items that become uncategorized (category only)"`
+	ChildLocations  int               `json:"child_locations" doc:"Child locations that become top-level (location only)"`
+	Inventory       int               `json:"inventory" doc:"Inventory entries stored here (location only; blocks delete)"`
+	Containers      int               `json:"containers" doc:"Containers deleted along with the location (location only)"`
+	ActiveLoans     int               `json:"active_loans" doc:"Active loans on inventory stored here (location only; blocks delete)"`
+}
+
+type BlockerResponse struct {
+	Reason string `json:"reason" enum:"child_categories,inventory,active_loans"`
+	Count  int    `json:"count"`
+}
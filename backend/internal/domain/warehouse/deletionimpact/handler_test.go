@@ -0,0 +1,101 @@
+package deletionimpact_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/deletionimpact"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+	"github.com/antti/home-warehouse/go-backend/internal/testutil"
+)
+
+// MockService implements deletionimpact.ServiceInterface
+type MockService struct {
+	mock.Mock
+}
+
+func (m *MockService) DeletionImpact(ctx context.Context, workspaceID uuid.UUID, entityType deletionimpact.EntityType, id uuid.UUID) (*deletionimpact.Impact, error) {
+	args := m.Called(ctx, workspaceID, entityType, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*deletionimpact.Impact), args.Error(1)
+}
+
+func (m *MockService) CheckDeletable(ctx context.Context, workspaceID uuid.UUID, entityType deletionimpact.EntityType, id uuid.UUID) error {
+	return m.Called(ctx, workspaceID, entityType, id).Error(0)
+}
+
+func TestDeletionImpactHandler_Category(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	deletionimpact.RegisterRoutes(setup.API, mockSvc)
+
+	t.Run("reports blockers", func(t *testing.T) {
+		categoryID := uuid.New()
+		impact := &deletionimpact.Impact{
+			EntityType:      deletionimpact.EntityCategory,
+			EntityID:        categoryID,
+			ChildCategories: 2,
+			Items:           7,
+		}
+		mockSvc.On("DeletionImpact", mock.Anything, setup.WorkspaceID, deletionimpact.EntityCategory, categoryID).
+			Return(impact, nil).Once()
+
+		rec := setup.Get(fmt.Sprintf("/categories/%s/deletion-impact", categoryID))
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		resp := testutil.ParseJSONResponse[deletionimpact.DeletionImpactResponse](t, rec)
+		assert.Equal(t, "category", resp.EntityType)
+		assert.False(t, resp.CanDelete)
+		assert.Equal(t, []deletionimpact.BlockerResponse{{Reason: "child_categories", Count: 2}}, resp.BlockedBy)
+		assert.Equal(t, 7, resp.Items)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		categoryID := uuid.New()
+		mockSvc.On("DeletionImpact", mock.Anything, setup.WorkspaceID, deletionimpact.EntityCategory, categoryID).
+			Return(nil, shared.NewDomainError(shared.ErrNotFound, "category not found")).Once()
+
+		rec := setup.Get(fmt.Sprintf("/categories/%s/deletion-impact", categoryID))
+
+		testutil.AssertStatus(t, rec, http.StatusNotFound)
+	})
+}
+
+func TestDeletionImpactHandler_Location(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	deletionimpact.RegisterRoutes(setup.API, mockSvc)
+
+	t.Run("deletable location", func(t *testing.T) {
+		locationID := uuid.New()
+		impact := &deletionimpact.Impact{
+			EntityType:     deletionimpact.EntityLocation,
+			EntityID:       locationID,
+			ChildLocations: 1,
+			Containers:     3,
+		}
+		mockSvc.On("DeletionImpact", mock.Anything, setup.WorkspaceID, deletionimpact.EntityLocation, locationID).
+			Return(impact, nil).Once()
+
+		rec := setup.Get(fmt.Sprintf("/locations/%s/deletion-impact", locationID))
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		resp := testutil.ParseJSONResponse[deletionimpact.DeletionImpactResponse](t, rec)
+		assert.Equal(t, "location", resp.EntityType)
+		assert.Equal(t, locationID, resp.EntityID)
+		assert.True(t, resp.CanDelete)
+		assert.Empty(t, resp.BlockedBy)
+		assert.Equal(t, 1, resp.ChildLocations)
+		assert.Equal(t, 3, resp.Containers)
+		mockSvc.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,14 @@
+package deletionimpact
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Repository counts the dependents of deletable entities. Each method returns
+// shared.ErrNotFound when the entity doesn't exist in the workspace.
+type Repository interface {
+	CategoryImpact(ctx context.Context, workspaceID, categoryID uuid.UUID) (*Impact, error)
+	LocationImpact(ctx context.Context, workspaceID, locationID uuid.UUID) (*Impact, error)
+}
@@ -0,0 +1,68 @@
+package deletionimpact
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// ServiceInterface defines the deletion impact operations.
+type ServiceInterface interface {
+	DeletionImpact(ctx context.Context, workspaceID uuid.UUID, entityType EntityType, id uuid.UUID) (*Impact, error)
+	CheckDeletable(ctx context.Context, workspaceID uuid.UUID, entityType EntityType, id uuid.UUID) error
+}
+
+// Service computes what deleting a category or location would affect. The
+// category and location services call CheckDeletable from their Delete paths,
+// so the preview and the actual delete apply the same rules.
+type Service struct {
+	repo Repository
+}
+
+// Ensure Service implements ServiceInterface
+var _ ServiceInterface = (*Service)(nil)
+
+// NewService creates a new deletion impact service.
+func NewService(repo Repository) *Service {
+	return &Service{repo: repo}
+}
+
+// DeletionImpact counts the dependents that would block or cascade from
+// deleting the given entity.
+func (s *Service) DeletionImpact(ctx context.Context, workspaceID uuid.UUID, entityType EntityType, id uuid.UUID) (*Impact, error) {
+	var (
+		impact *Impact
+		err    error
+	)
+	switch entityType {
+	case EntityCategory:
+		impact, err = s.repo.CategoryImpact(ctx, workspaceID, id)
+	case EntityLocation:
+		impact, err = s.repo.LocationImpact(ctx, workspaceID, id)
+	default:
+		return nil, shared.NewFieldError(shared.ErrInvalidInput, "entity_type", fmt.Sprintf("unsupported entity type %q", entityType))
+	}
+	if err != nil {
+		if shared.IsNotFound(err) {
+			return nil, shared.NewDomainError(shared.ErrNotFound, fmt.Sprintf("%s not found", entityType))
+		}
+		return nil, err
+	}
+
+	impact.EntityType = entityType
+	impact.EntityID = id
+	return impact, nil
+}
+
+// CheckDeletable returns the impact's blocking error (a conflict), or nil when
+// the entity can be deleted.
+func (s *Service) CheckDeletable(ctx context.Context, workspaceID uuid.UUID, entityType EntityType, id uuid.UUID) error {
+	impact, err := s.DeletionImpact(ctx, workspaceID, entityType, id)
+	if err != nil {
+		return err
+	}
+	return impact.Err()
+}
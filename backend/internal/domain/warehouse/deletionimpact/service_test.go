@@ -0,0 +1,170 @@
+package deletionimpact
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// MockRepository is a mock implementation of Repository for testing.
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) CategoryImpact(ctx context.Context, workspaceID, categoryID uuid.UUID) (*Impact, error) {
+	args := m.Called(ctx, workspaceID, categoryID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Impact), args.Error(1)
+}
+
+func (m *MockRepository) LocationImpact(ctx context.Context, workspaceID, locationID uuid.UUID) (*Impact, error) {
+	args := m.Called(ctx, workspaceID, locationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Impact), args.Error(1)
+}
+
+func TestService_DeletionImpact(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+	id := uuid.New()
+
+	t.Run("category impact", func(t *testing.T) {
+		repo := new(MockRepository)
+		svc := NewService(repo)
+		repo.On("CategoryImpact", ctx, workspaceID, id).Return(&Impact{ChildCategories: 2, Items: 5}, nil)
+
+		impact, err := svc.DeletionImpact(ctx, workspaceID, EntityCategory, id)
+
+		require.NoError(t, err)
+		assert.Equal(t, EntityCategory, impact.EntityType)
+		assert.Equal(t, id, impact.EntityID)
+		assert.Equal(t, 2, impact.ChildCategories)
+		assert.Equal(t, 5, impact.Items)
+		assert.False(t, impact.CanDelete())
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("location impact", func(t *testing.T) {
+		repo := new(MockRepository)
+		svc := NewService(repo)
+		repo.On("LocationImpact", ctx, workspaceID, id).Return(&Impact{ChildLocations: 1, Containers: 3}, nil)
+
+		impact, err := svc.DeletionImpact(ctx, workspaceID, EntityLocation, id)
+
+		require.NoError(t, err)
+		assert.Equal(t, EntityLocation, impact.EntityType)
+		assert.Equal(t, 1, impact.ChildLocations)
+		assert.Equal(t, 3, impact.Containers)
+		assert.True(t, impact.CanDelete())
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		repo := new(MockRepository)
+		svc := NewService(repo)
+		repo.On("LocationImpact", ctx, workspaceID, id).Return(nil, shared.ErrNotFound)
+
+		impact, err := svc.DeletionImpact(ctx, workspaceID, EntityLocation, id)
+
+		assert.Nil(t, impact)
+		assert.ErrorIs(t, err, shared.ErrNotFound)
+	})
+
+	t.Run("unsupported entity type", func(t *testing.T) {
+		repo := new(MockRepository)
+		svc := NewService(repo)
+
+		impact, err := svc.DeletionImpact(ctx, workspaceID, EntityType("item"), id)
+
+		assert.Nil(t, impact)
+		assert.ErrorIs(t, err, shared.ErrInvalidInput)
+		repo.AssertNotCalled(t, "CategoryImpact", mock.Anything, mock.Anything, mock.Anything)
+		repo.AssertNotCalled(t, "LocationImpact", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestService_CheckDeletable(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+	id := uuid.New()
+
+	t.Run("allows delete without blockers", func(t *testing.T) {
+		repo := new(MockRepository)
+		svc := NewService(repo)
+		repo.On("LocationImpact", ctx, workspaceID, id).Return(&Impact{ChildLocations: 4, Containers: 2}, nil)
+
+		assert.NoError(t, svc.CheckDeletable(ctx, workspaceID, EntityLocation, id))
+	})
+
+	t.Run("refuses delete with blockers", func(t *testing.T) {
+		repo := new(MockRepository)
+		svc := NewService(repo)
+		repo.On("LocationImpact", ctx, workspaceID, id).Return(&Impact{Inventory: 3, ActiveLoans: 1}, nil)
+
+		err := svc.CheckDeletable(ctx, workspaceID, EntityLocation, id)
+
+		assert.ErrorIs(t, err, shared.ErrConflict)
+		assert.Contains(t, err.Error(), "3 inventory, 1 active loans")
+	})
+
+	t.Run("propagates not found", func(t *testing.T) {
+		repo := new(MockRepository)
+		svc := NewService(repo)
+		repo.On("CategoryImpact", ctx, workspaceID, id).Return(nil, shared.ErrNotFound)
+
+		err := svc.CheckDeletable(ctx, workspaceID, EntityCategory, id)
+
+		assert.ErrorIs(t, err, shared.ErrNotFound)
+	})
+}
+
+func TestImpact_Blockers(t *testing.T) {
+	tests := []struct {
+		name   string
+		impact Impact
+		want   []Blocker
+	}{
+		{
+			name:   "category with children",
+			impact: Impact{EntityType: EntityCategory, ChildCategories: 2, Items: 10},
+			want:   []Blocker{{Reason: "child_categories", Count: 2}},
+		},
+		{
+			name:   "category with only items",
+			impact: Impact{EntityType: EntityCategory, Items: 10},
+			want:   nil,
+		},
+		{
+			name:   "location with inventory and loans",
+			impact: Impact{EntityType: EntityLocation, Inventory: 4, ActiveLoans: 1, Containers: 2},
+			want:   []Blocker{{Reason: "inventory", Count: 4}, {Reason: "active_loans", Count: 1}},
+		},
+		{
+			name:   "location with only cascading dependents",
+			impact: Impact{EntityType: EntityLocation, ChildLocations: 3, Containers: 2},
+			want:   nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.impact.Blockers())
+			assert.Equal(t, len(tt.want) == 0, tt.impact.CanDelete())
+			if len(tt.want) == 0 {
+				assert.NoError(t, tt.impact.Err())
+			} else {
+				assert.ErrorIs(t, tt.impact.Err(), shared.ErrConflict)
+			}
+		})
+	}
+}
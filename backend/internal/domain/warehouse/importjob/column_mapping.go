@@ -0,0 +1,109 @@
+package importjob
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// ColumnMapping maps source file headers to the canonical column names the
+// importer reads, e.g. {"Product": "name", "Maker": "brand"}. Headers without
+// an entry are matched case-insensitively against the canonical names.
+type ColumnMapping map[string]string
+
+// requiredColumns are the canonical columns each entity type cannot import
+// a row without.
+var requiredColumns = map[EntityType][]string{
+	EntityTypeItems:      {"name"},
+	EntityTypeLocations:  {"name"},
+	EntityTypeContainers: {"name", "location"},
+	EntityTypeCategories: {"name"},
+	EntityTypeBorrowers:  {"name"},
+	EntityTypeInventory:  {"item", "location"},
+}
+
+// ParseColumnMapping parses the column_mapping upload field, a JSON object of
+// source header to canonical column. Empty input means no mapping. Headers
+// and column names are normalized to lowercase.
+func ParseColumnMapping(raw string) (ColumnMapping, error) {
+	if strings.TrimSpace(raw) == "" {
+		return nil, nil
+	}
+
+	var m map[string]string
+	if err := json.Unmarshal([]byte(raw), &m); err != nil {
+		return nil, shared.NewFieldError(shared.ErrInvalidInput, "column_mapping", "must be a JSON object of source header to column name")
+	}
+	return normalizeColumnMapping(m)
+}
+
+// ColumnMappingFromPayload reads a mapping back out of a queue payload, where
+// JSON decoding has turned it into a map[string]any. A missing value means
+// no mapping.
+func ColumnMappingFromPayload(v any) (ColumnMapping, error) {
+	switch raw := v.(type) {
+	case nil:
+		return nil, nil
+	case map[string]string:
+		return normalizeColumnMapping(raw)
+	case map[string]any:
+		m := make(map[string]string, len(raw))
+		for from, to := range raw {
+			s, ok := to.(string)
+			if !ok {
+				return nil, fmt.Errorf("column_mapping value for %q is not a string", from)
+			}
+			m[from] = s
+		}
+		return normalizeColumnMapping(m)
+	default:
+		return nil, fmt.Errorf("column_mapping has unexpected type %T", v)
+	}
+}
+
+func normalizeColumnMapping(m map[string]string) (ColumnMapping, error) {
+	if len(m) == 0 {
+		return nil, nil
+	}
+
+	mapping := make(ColumnMapping, len(m))
+	targets := make(map[string]string, len(m))
+	for from, to := range m {
+		from = strings.ToLower(strings.TrimSpace(from))
+		to = strings.ToLower(strings.TrimSpace(to))
+		if from == "" || to == "" {
+			return nil, shared.NewFieldError(shared.ErrInvalidInput, "column_mapping", "headers and column names must not be blank")
+		}
+		if other, ok := targets[to]; ok && other != from {
+			return nil, shared.NewFieldError(shared.ErrInvalidInput, "column_mapping",
+				fmt.Sprintf("maps both %q and %q to %q", other, from, to))
+		}
+		targets[to] = from
+		mapping[from] = to
+	}
+	return mapping, nil
+}
+
+// RequiredColumns returns the canonical columns an entity type needs.
+func RequiredColumns(entityType EntityType) []string {
+	return requiredColumns[entityType]
+}
+
+// MissingColumns returns the required columns of entityType that headers
+// (already normalized and mapped) do not provide.
+func MissingColumns(entityType EntityType, headers []string) []string {
+	present := make(map[string]bool, len(headers))
+	for _, h := range headers {
+		present[h] = true
+	}
+
+	var missing []string
+	for _, col := range requiredColumns[entityType] {
+		if !present[col] {
+			missing = append(missing, col)
+		}
+	}
+	return missing
+}
@@ -0,0 +1,74 @@
+package importjob_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/importjob"
+)
+
+func TestParseColumnMapping(t *testing.T) {
+	tests := []struct {
+		name    string
+		raw     string
+		want    importjob.ColumnMapping
+		wantErr bool
+	}{
+		{name: "empty means no mapping", raw: "", want: nil},
+		{name: "empty object means no mapping", raw: "{}", want: nil},
+		{
+			name: "normalizes headers and columns",
+			raw:  `{" Product ": "Name", "Maker": "brand"}`,
+			want: importjob.ColumnMapping{"product": "name", "maker": "brand"},
+		},
+		{name: "rejects non-object", raw: `["name"]`, wantErr: true},
+		{name: "rejects non-string values", raw: `{"Product": 1}`, wantErr: true},
+		{name: "rejects blank column", raw: `{"Product": " "}`, wantErr: true},
+		{name: "rejects two headers mapped to one column", raw: `{"Product": "name", "Title": "name"}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := importjob.ParseColumnMapping(tt.raw)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestColumnMappingFromPayload(t *testing.T) {
+	t.Run("nil means no mapping", func(t *testing.T) {
+		got, err := importjob.ColumnMappingFromPayload(nil)
+		require.NoError(t, err)
+		assert.Nil(t, got)
+	})
+
+	t.Run("decodes JSON-decoded payload map", func(t *testing.T) {
+		got, err := importjob.ColumnMappingFromPayload(map[string]any{"Product": "name"})
+		require.NoError(t, err)
+		assert.Equal(t, importjob.ColumnMapping{"product": "name"}, got)
+	})
+
+	t.Run("rejects non-string values", func(t *testing.T) {
+		_, err := importjob.ColumnMappingFromPayload(map[string]any{"Product": 3})
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects unexpected type", func(t *testing.T) {
+		_, err := importjob.ColumnMappingFromPayload("product=name")
+		assert.Error(t, err)
+	})
+}
+
+func TestMissingColumns(t *testing.T) {
+	assert.Empty(t, importjob.MissingColumns(importjob.EntityTypeItems, []string{"sku", "name"}))
+	assert.Equal(t, []string{"name"}, importjob.MissingColumns(importjob.EntityTypeItems, []string{"product", "sku"}))
+	assert.Equal(t, []string{"item", "location"}, importjob.MissingColumns(importjob.EntityTypeInventory, []string{"quantity"}))
+	assert.Equal(t, []string{"name", "location"}, importjob.RequiredColumns(importjob.EntityTypeContainers))
+}
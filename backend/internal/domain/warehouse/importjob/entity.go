@@ -267,3 +267,130 @@ func (e *ImportError) FieldName() *string      { return e.fieldName }
 func (e *ImportError) ErrorMessage() string    { return e.errorMsg }
 func (e *ImportError) RowData() map[string]any { return e.rowData }
 func (e *ImportError) CreatedAt() time.Time    { return e.createdAt }
+
+// ConflictPolicy controls what an import does with a row whose natural key
+// (e.g. an item's SKU) already exists in the workspace.
+type ConflictPolicy string
+
+const (
+	// ConflictError fails the row (the default, and the pre-existing behavior).
+	ConflictError ConflictPolicy = "error"
+	// ConflictSkip leaves the existing entity untouched.
+	ConflictSkip ConflictPolicy = "skip"
+	// ConflictUpdate patches the existing entity's mutable fields from the row.
+	ConflictUpdate ConflictPolicy = "update"
+)
+
+// ParseConflictPolicy validates an on_conflict value. Empty means ConflictError.
+func ParseConflictPolicy(s string) (ConflictPolicy, error) {
+	switch p := ConflictPolicy(s); p {
+	case "":
+		return ConflictError, nil
+	case ConflictError, ConflictSkip, ConflictUpdate:
+		return p, nil
+	}
+	return "", shared.NewFieldError(shared.ErrInvalidInput, "on_conflict", "on_conflict must be one of: skip, update, error")
+}
+
+// PrimaryPhotoPreference chooses which of an item's imported photos becomes
+// its primary photo when the item has no primary of its own.
+type PrimaryPhotoPreference string
+
+const (
+	// PrimaryPhotoFirst picks the first photo, in row and cell order, that
+	// was fetched successfully.
+	PrimaryPhotoFirst PrimaryPhotoPreference = "first"
+	// PrimaryPhotoLargest picks the fetched photo with the most pixels.
+	PrimaryPhotoLargest PrimaryPhotoPreference = "largest"
+)
+
+// ParsePrimaryPhotoPreference validates a primary_photo value. Empty means
+// PrimaryPhotoFirst.
+func ParsePrimaryPhotoPreference(s string) (PrimaryPhotoPreference, error) {
+	switch p := PrimaryPhotoPreference(s); p {
+	case "":
+		return PrimaryPhotoFirst, nil
+	case PrimaryPhotoFirst, PrimaryPhotoLargest:
+		return p, nil
+	}
+	return "", shared.NewFieldError(shared.ErrInvalidInput, "primary_photo", "primary_photo must be one of: first, largest")
+}
+
+// RowAction records what an import did with a row that didn't fail.
+type RowAction string
+
+const (
+	RowActionCreated RowAction = "created"
+	RowActionUpdated RowAction = "updated"
+	RowActionSkipped RowAction = "skipped"
+)
+
+// ImportRowResult is the outcome of a successfully handled import row.
+// Failed rows are recorded as ImportError instead.
+type ImportRowResult struct {
+	id              uuid.UUID
+	importJobID     uuid.UUID
+	rowNumber       int
+	action          RowAction
+	entityID        *uuid.UUID
+	autoCategorized bool
+	createdAt       time.Time
+}
+
+func NewImportRowResult(importJobID uuid.UUID, rowNumber int, action RowAction, entityID *uuid.UUID) (*ImportRowResult, error) {
+	if err := shared.ValidateUUID(importJobID, "import_job_id"); err != nil {
+		return nil, err
+	}
+	if rowNumber < 0 {
+		return nil, shared.NewFieldError(shared.ErrInvalidInput, "row_number", "row number must be non-negative")
+	}
+	switch action {
+	case RowActionCreated, RowActionUpdated, RowActionSkipped:
+	default:
+		return nil, shared.NewFieldError(shared.ErrInvalidInput, "action", "invalid row action")
+	}
+
+	return &ImportRowResult{
+		id:          shared.NewUUID(),
+		importJobID: importJobID,
+		rowNumber:   rowNumber,
+		action:      action,
+		entityID:    entityID,
+		createdAt:   time.Now(),
+	}, nil
+}
+
+func ReconstructImportRowResult(
+	id uuid.UUID,
+	importJobID uuid.UUID,
+	rowNumber int,
+	action RowAction,
+	entityID *uuid.UUID,
+	autoCategorized bool,
+	createdAt time.Time,
+) *ImportRowResult {
+	return &ImportRowResult{
+		id:              id,
+		importJobID:     importJobID,
+		rowNumber:       rowNumber,
+		action:          action,
+		entityID:        entityID,
+		autoCategorized: autoCategorized,
+		createdAt:       createdAt,
+	}
+}
+
+// Getters
+func (r *ImportRowResult) ID() uuid.UUID          { return r.id }
+func (r *ImportRowResult) ImportJobID() uuid.UUID { return r.importJobID }
+func (r *ImportRowResult) RowNumber() int         { return r.rowNumber }
+func (r *ImportRowResult) Action() RowAction      { return r.action }
+func (r *ImportRowResult) EntityID() *uuid.UUID   { return r.entityID }
+func (r *ImportRowResult) AutoCategorized() bool  { return r.autoCategorized }
+func (r *ImportRowResult) CreatedAt() time.Time   { return r.createdAt }
+
+// MarkAutoCategorized records that the item the row created got its category
+// from a category rule.
+func (r *ImportRowResult) MarkAutoCategorized() {
+	r.autoCategorized = true
+}
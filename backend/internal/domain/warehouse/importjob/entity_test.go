@@ -753,3 +753,117 @@ func TestImportError_ReconstructWithNilFields(t *testing.T) {
 func intPtr(i int) *int {
 	return &i
 }
+
+func TestParseConflictPolicy(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    importjob.ConflictPolicy
+		wantErr bool
+	}{
+		{in: "", want: importjob.ConflictError},
+		{in: "error", want: importjob.ConflictError},
+		{in: "skip", want: importjob.ConflictSkip},
+		{in: "update", want: importjob.ConflictUpdate},
+		{in: "overwrite", wantErr: true},
+		{in: "SKIP", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := importjob.ParseConflictPolicy(tt.in)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParsePrimaryPhotoPreference(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    importjob.PrimaryPhotoPreference
+		wantErr bool
+	}{
+		{in: "", want: importjob.PrimaryPhotoFirst},
+		{in: "first", want: importjob.PrimaryPhotoFirst},
+		{in: "largest", want: importjob.PrimaryPhotoLargest},
+		{in: "biggest", wantErr: true},
+		{in: "LARGEST", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := importjob.ParsePrimaryPhotoPreference(tt.in)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestNewImportRowResult(t *testing.T) {
+	importJobID := uuid.New()
+	entityID := uuid.New()
+
+	t.Run("valid row result", func(t *testing.T) {
+		result, err := importjob.NewImportRowResult(importJobID, 3, importjob.RowActionUpdated, &entityID)
+
+		assert.NoError(t, err)
+		assert.NotEqual(t, uuid.Nil, result.ID())
+		assert.Equal(t, importJobID, result.ImportJobID())
+		assert.Equal(t, 3, result.RowNumber())
+		assert.Equal(t, importjob.RowActionUpdated, result.Action())
+		assert.Equal(t, &entityID, result.EntityID())
+		assert.WithinDuration(t, time.Now(), result.CreatedAt(), time.Second)
+	})
+
+	t.Run("rejects nil job ID", func(t *testing.T) {
+		_, err := importjob.NewImportRowResult(uuid.Nil, 1, importjob.RowActionCreated, &entityID)
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects negative row number", func(t *testing.T) {
+		_, err := importjob.NewImportRowResult(importJobID, -1, importjob.RowActionCreated, &entityID)
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects unknown action", func(t *testing.T) {
+		_, err := importjob.NewImportRowResult(importJobID, 1, importjob.RowAction("error"), nil)
+		assert.Error(t, err)
+	})
+}
+
+func TestNewImportPhotoResult(t *testing.T) {
+	importJobID := uuid.New()
+	itemID := uuid.New()
+
+	t.Run("valid photo result", func(t *testing.T) {
+		result, err := importjob.NewImportPhotoResult(importJobID, 2, itemID, "https://example.com/a.jpg", importjob.PhotoStatusPending, nil)
+
+		assert.NoError(t, err)
+		assert.NotEqual(t, uuid.Nil, result.ID())
+		assert.Equal(t, importJobID, result.ImportJobID())
+		assert.Equal(t, 2, result.RowNumber())
+		assert.Equal(t, itemID, result.ItemID())
+		assert.Equal(t, "https://example.com/a.jpg", result.URL())
+		assert.Equal(t, importjob.PhotoStatusPending, result.Status())
+		assert.Nil(t, result.PhotoID())
+		assert.Nil(t, result.Message())
+	})
+
+	t.Run("rejects nil item ID", func(t *testing.T) {
+		_, err := importjob.NewImportPhotoResult(importJobID, 1, uuid.Nil, "https://example.com/a.jpg", importjob.PhotoStatusPending, nil)
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects unknown status", func(t *testing.T) {
+		_, err := importjob.NewImportPhotoResult(importJobID, 1, itemID, "https://example.com/a.jpg", importjob.PhotoStatus("done"), nil)
+		assert.Error(t, err)
+	})
+}
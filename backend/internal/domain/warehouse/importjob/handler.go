@@ -5,6 +5,7 @@ import (
 	"errors"
 	"log"
 	"os"
+	"sort"
 	"time"
 
 	"github.com/danielgtaylor/huma/v2"
@@ -17,8 +18,9 @@ import (
 )
 
 const (
-	MaxFileSize   = 10 * 1024 * 1024 // 10MB
-	AllowedCSVExt = ".csv"
+	MaxFileSize    = 10 * 1024 * 1024 // 10MB
+	AllowedCSVExt  = ".csv"
+	AllowedJSONExt = ".json" // items only: an array of item objects
 )
 
 const (
@@ -73,6 +75,22 @@ type GetImportJobErrorsOutput struct {
 	Body ImportJobErrorListResponse
 }
 
+type GetImportJobRowsInput struct {
+	ID uuid.UUID `path:"id"`
+}
+
+type GetImportJobRowsOutput struct {
+	Body ImportJobRowListResponse
+}
+
+type GetImportJobPhotosInput struct {
+	ID uuid.UUID `path:"id"`
+}
+
+type GetImportJobPhotosOutput struct {
+	Body ImportJobPhotoListResponse
+}
+
 type DeleteImportJobInput struct {
 	ID uuid.UUID `path:"id"`
 }
@@ -125,6 +143,44 @@ type ImportJobErrorListResponse struct {
 	Total  int                   `json:"total"`
 }
 
+// rowActionError is the action reported for rows recorded in import_errors.
+const rowActionError = "error"
+
+type ImportRowResponse struct {
+	RowNumber       int        `json:"row_number"`
+	Action          string     `json:"action" enum:"created,updated,skipped,error"`
+	EntityID        *uuid.UUID `json:"entity_id,omitempty"`
+	AutoCategorized bool       `json:"auto_categorized,omitempty" doc:"The created item got its category from a category rule"`
+	ErrorMessage    *string    `json:"error_message,omitempty"`
+}
+
+type ImportJobRowListResponse struct {
+	Rows            []ImportRowResponse `json:"rows"`
+	Created         int                 `json:"created"`
+	Updated         int                 `json:"updated"`
+	Skipped         int                 `json:"skipped"`
+	Errors          int                 `json:"errors"`
+	AutoCategorized int                 `json:"auto_categorized" doc:"Created items whose category was assigned by a category rule"`
+}
+
+type ImportPhotoResponse struct {
+	RowNumber int        `json:"row_number"`
+	ItemID    uuid.UUID  `json:"item_id"`
+	URL       string     `json:"url"`
+	Status    string     `json:"status" enum:"pending,attached,failed,skipped"`
+	PhotoID   *uuid.UUID `json:"photo_id,omitempty"`
+	Message   *string    `json:"message,omitempty"`
+	Primary   bool       `json:"primary" doc:"Whether this photo was made the item's primary photo"`
+}
+
+type ImportJobPhotoListResponse struct {
+	Photos   []ImportPhotoResponse `json:"photos"`
+	Pending  int                   `json:"pending"`
+	Attached int                   `json:"attached"`
+	Failed   int                   `json:"failed"`
+	Skipped  int                   `json:"skipped"`
+}
+
 // RegisterRoutes registers import job routes.
 // Each handler is a package factory func (see below) so this stays a flat list
 // of registrations rather than a single god-function of inline closures.
@@ -132,6 +188,8 @@ func RegisterRoutes(api huma.API, repo Repository, importQueue *queue.Queue, bro
 	huma.Get(api, "/imports/jobs", listImportJobs(repo))
 	huma.Get(api, "/imports/jobs/{id}", getImportJob(repo))
 	huma.Get(api, "/imports/jobs/{id}/errors", getImportJobErrors(repo))
+	huma.Get(api, "/imports/jobs/{id}/rows", getImportJobRows(repo))
+	huma.Get(api, "/imports/jobs/{id}/photos", getImportJobPhotos(repo))
 	huma.Delete(api, "/imports/jobs/{id}", deleteImportJob(repo))
 
 	// Note: SSE streaming for import progress is handled via the global /sse endpoint
@@ -226,6 +284,120 @@ func getImportJobErrors(repo Repository) func(context.Context, *GetImportJobErro
 	}
 }
 
+// getImportJobRows reports the action taken for every processed row: created,
+// updated, skipped, or error, ordered by row number.
+func getImportJobRows(repo Repository) func(context.Context, *GetImportJobRowsInput) (*GetImportJobRowsOutput, error) {
+	return func(ctx context.Context, input *GetImportJobRowsInput) (*GetImportJobRowsOutput, error) {
+		workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+		if !ok {
+			return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+		}
+
+		// Verify job exists and belongs to workspace
+		_, err := repo.FindJobByID(ctx, input.ID, workspaceID)
+		if err != nil {
+			if errors.Is(err, ErrImportJobNotFound) {
+				return nil, huma.Error404NotFound(msgImportJobNotFound)
+			}
+			return nil, huma.Error500InternalServerError(msgFailedToGetImportJob)
+		}
+
+		results, err := repo.FindRowResultsByJobID(ctx, input.ID)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to get import row results")
+		}
+		importErrors, err := repo.FindErrorsByJobID(ctx, input.ID)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to get import errors")
+		}
+
+		body := ImportJobRowListResponse{Rows: make([]ImportRowResponse, 0, len(results)+len(importErrors))}
+		for _, r := range results {
+			body.Rows = append(body.Rows, ImportRowResponse{
+				RowNumber:       r.RowNumber(),
+				Action:          string(r.Action()),
+				EntityID:        r.EntityID(),
+				AutoCategorized: r.AutoCategorized(),
+			})
+			if r.AutoCategorized() {
+				body.AutoCategorized++
+			}
+			switch r.Action() {
+			case RowActionCreated:
+				body.Created++
+			case RowActionUpdated:
+				body.Updated++
+			case RowActionSkipped:
+				body.Skipped++
+			}
+		}
+		for _, e := range importErrors {
+			msg := e.ErrorMessage()
+			body.Rows = append(body.Rows, ImportRowResponse{
+				RowNumber:    e.RowNumber(),
+				Action:       rowActionError,
+				ErrorMessage: &msg,
+			})
+			body.Errors++
+		}
+		sort.SliceStable(body.Rows, func(i, j int) bool {
+			return body.Rows[i].RowNumber < body.Rows[j].RowNumber
+		})
+
+		return &GetImportJobRowsOutput{Body: body}, nil
+	}
+}
+
+// getImportJobPhotos reports the photos fetched from the photo_url column,
+// which are downloaded in the background after their rows are imported.
+func getImportJobPhotos(repo Repository) func(context.Context, *GetImportJobPhotosInput) (*GetImportJobPhotosOutput, error) {
+	return func(ctx context.Context, input *GetImportJobPhotosInput) (*GetImportJobPhotosOutput, error) {
+		workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+		if !ok {
+			return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+		}
+
+		// Verify job exists and belongs to workspace
+		_, err := repo.FindJobByID(ctx, input.ID, workspaceID)
+		if err != nil {
+			if errors.Is(err, ErrImportJobNotFound) {
+				return nil, huma.Error404NotFound(msgImportJobNotFound)
+			}
+			return nil, huma.Error500InternalServerError(msgFailedToGetImportJob)
+		}
+
+		results, err := repo.FindPhotoResultsByJobID(ctx, input.ID)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to get import photo results")
+		}
+
+		body := ImportJobPhotoListResponse{Photos: make([]ImportPhotoResponse, len(results))}
+		for i, r := range results {
+			body.Photos[i] = ImportPhotoResponse{
+				RowNumber: r.RowNumber(),
+				ItemID:    r.ItemID(),
+				URL:       r.URL(),
+				Status:    string(r.Status()),
+				PhotoID:   r.PhotoID(),
+				Message:   r.Message(),
+				Primary:   r.IsPrimary(),
+			}
+			switch r.Status() {
+			case PhotoStatusPending:
+				body.Pending++
+			case PhotoStatusAttached:
+				body.Attached++
+			case PhotoStatusFailed:
+				body.Failed++
+			case PhotoStatusSkipped:
+				body.Skipped++
+			}
+		}
+
+		return &GetImportJobPhotosOutput{Body: body}, nil
+	}
+}
+
 // deleteImportJob deletes an import job, its errors, and its uploaded file.
 func deleteImportJob(repo Repository) func(context.Context, *DeleteImportJobInput) (*DeleteImportJobOutput, error) {
 	return func(ctx context.Context, input *DeleteImportJobInput) (*DeleteImportJobOutput, error) {
@@ -9,7 +9,9 @@ import (
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/importjob"
 	"github.com/antti/home-warehouse/go-backend/internal/shared"
@@ -57,6 +59,11 @@ func (m *MockRepository) SaveError(ctx context.Context, importError *importjob.I
 	return args.Error(0)
 }
 
+func (m *MockRepository) SaveErrors(ctx context.Context, errors []*importjob.ImportError) error {
+	args := m.Called(ctx, errors)
+	return args.Error(0)
+}
+
 func (m *MockRepository) FindErrorsByJobID(ctx context.Context, jobID uuid.UUID) ([]*importjob.ImportError, error) {
 	args := m.Called(ctx, jobID)
 	if args.Get(0) == nil {
@@ -70,6 +77,55 @@ func (m *MockRepository) DeleteErrorsByJobID(ctx context.Context, jobID uuid.UUI
 	return args.Error(0)
 }
 
+func (m *MockRepository) SaveRowResult(ctx context.Context, result *importjob.ImportRowResult) error {
+	args := m.Called(ctx, result)
+	return args.Error(0)
+}
+
+func (m *MockRepository) SaveRowResults(ctx context.Context, results []*importjob.ImportRowResult) error {
+	args := m.Called(ctx, results)
+	return args.Error(0)
+}
+
+func (m *MockRepository) FindRowResultsByJobID(ctx context.Context, jobID uuid.UUID) ([]*importjob.ImportRowResult, error) {
+	args := m.Called(ctx, jobID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*importjob.ImportRowResult), args.Error(1)
+}
+
+func (m *MockRepository) SavePhotoResult(ctx context.Context, result *importjob.ImportPhotoResult) error {
+	args := m.Called(ctx, result)
+	return args.Error(0)
+}
+
+func (m *MockRepository) UpdatePhotoResult(ctx context.Context, id uuid.UUID, status importjob.PhotoStatus, photoID *uuid.UUID, message *string) error {
+	args := m.Called(ctx, id, status, photoID, message)
+	return args.Error(0)
+}
+
+func (m *MockRepository) FindPhotoResultsByJobID(ctx context.Context, jobID uuid.UUID) ([]*importjob.ImportPhotoResult, error) {
+	args := m.Called(ctx, jobID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*importjob.ImportPhotoResult), args.Error(1)
+}
+
+func (m *MockRepository) FindPhotoResultsByItem(ctx context.Context, jobID, itemID uuid.UUID) ([]*importjob.ImportPhotoResult, error) {
+	args := m.Called(ctx, jobID, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*importjob.ImportPhotoResult), args.Error(1)
+}
+
+func (m *MockRepository) MarkPhotoResultPrimary(ctx context.Context, jobID, itemID, resultID uuid.UUID) error {
+	args := m.Called(ctx, jobID, itemID, resultID)
+	return args.Error(0)
+}
+
 // Helper function to create a test import job
 func createTestJob(workspaceID, userID uuid.UUID, entityType importjob.EntityType) *importjob.ImportJob {
 	job, _ := importjob.NewImportJob(
@@ -278,6 +334,150 @@ func TestHandler_GetImportJobErrors(t *testing.T) {
 
 // Tests for DeleteImportJob handler
 
+func TestHandler_GetImportJobRows(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockRepo := new(MockRepository)
+	importjob.RegisterRoutes(setup.API, mockRepo, nil, nil)
+
+	t.Run("merges row results and errors by row number", func(t *testing.T) {
+		testJob := createTestJob(setup.WorkspaceID, setup.UserID, importjob.EntityTypeItems)
+		jobID := testJob.ID()
+		createdID, updatedID := uuid.New(), uuid.New()
+
+		created, _ := importjob.NewImportRowResult(jobID, 1, importjob.RowActionCreated, &createdID)
+		updated, _ := importjob.NewImportRowResult(jobID, 3, importjob.RowActionUpdated, &updatedID)
+		skipped, _ := importjob.NewImportRowResult(jobID, 4, importjob.RowActionSkipped, nil)
+		rowErr, _ := importjob.NewImportError(jobID, 2, nil, "SKU already exists in workspace", nil)
+
+		mockRepo.On("FindJobByID", mock.Anything, jobID, setup.WorkspaceID).
+			Return(testJob, nil).Once()
+		mockRepo.On("FindRowResultsByJobID", mock.Anything, jobID).
+			Return([]*importjob.ImportRowResult{created, updated, skipped}, nil).Once()
+		mockRepo.On("FindErrorsByJobID", mock.Anything, jobID).
+			Return([]*importjob.ImportError{rowErr}, nil).Once()
+
+		rec := setup.Get(fmt.Sprintf("/imports/jobs/%s/rows", jobID))
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		resp := testutil.ParseJSONResponse[importjob.ImportJobRowListResponse](t, rec)
+		assert.Equal(t, 1, resp.Created)
+		assert.Equal(t, 1, resp.Updated)
+		assert.Equal(t, 1, resp.Skipped)
+		assert.Equal(t, 1, resp.Errors)
+		require.Len(t, resp.Rows, 4)
+		assert.Equal(t, "created", resp.Rows[0].Action)
+		assert.Equal(t, &createdID, resp.Rows[0].EntityID)
+		assert.Equal(t, "error", resp.Rows[1].Action)
+		require.NotNil(t, resp.Rows[1].ErrorMessage)
+		assert.Equal(t, "SKU already exists in workspace", *resp.Rows[1].ErrorMessage)
+		assert.Equal(t, "updated", resp.Rows[2].Action)
+		assert.Equal(t, "skipped", resp.Rows[3].Action)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("counts rows categorized by a category rule", func(t *testing.T) {
+		testJob := createTestJob(setup.WorkspaceID, setup.UserID, importjob.EntityTypeItems)
+		jobID := testJob.ID()
+		firstID, secondID := uuid.New(), uuid.New()
+
+		first, _ := importjob.NewImportRowResult(jobID, 1, importjob.RowActionCreated, &firstID)
+		first.MarkAutoCategorized()
+		second, _ := importjob.NewImportRowResult(jobID, 2, importjob.RowActionCreated, &secondID)
+
+		mockRepo.On("FindJobByID", mock.Anything, jobID, setup.WorkspaceID).
+			Return(testJob, nil).Once()
+		mockRepo.On("FindRowResultsByJobID", mock.Anything, jobID).
+			Return([]*importjob.ImportRowResult{first, second}, nil).Once()
+		mockRepo.On("FindErrorsByJobID", mock.Anything, jobID).
+			Return([]*importjob.ImportError{}, nil).Once()
+
+		rec := setup.Get(fmt.Sprintf("/imports/jobs/%s/rows", jobID))
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		resp := testutil.ParseJSONResponse[importjob.ImportJobRowListResponse](t, rec)
+		assert.Equal(t, 2, resp.Created)
+		assert.Equal(t, 1, resp.AutoCategorized)
+		require.Len(t, resp.Rows, 2)
+		assert.True(t, resp.Rows[0].AutoCategorized)
+		assert.False(t, resp.Rows[1].AutoCategorized)
+	})
+
+	t.Run("returns 404 when job not found", func(t *testing.T) {
+		jobID := uuid.New()
+
+		mockRepo.On("FindJobByID", mock.Anything, jobID, setup.WorkspaceID).
+			Return(nil, importjob.ErrImportJobNotFound).Once()
+
+		rec := setup.Get(fmt.Sprintf("/imports/jobs/%s/rows", jobID))
+
+		testutil.AssertStatus(t, rec, http.StatusNotFound)
+	})
+
+	t.Run("returns 500 on row results error", func(t *testing.T) {
+		testJob := createTestJob(setup.WorkspaceID, setup.UserID, importjob.EntityTypeItems)
+		jobID := testJob.ID()
+
+		mockRepo.On("FindJobByID", mock.Anything, jobID, setup.WorkspaceID).
+			Return(testJob, nil).Once()
+		mockRepo.On("FindRowResultsByJobID", mock.Anything, jobID).
+			Return(nil, errors.New("database error")).Once()
+
+		rec := setup.Get(fmt.Sprintf("/imports/jobs/%s/rows", jobID))
+
+		testutil.AssertStatus(t, rec, http.StatusInternalServerError)
+	})
+}
+
+func TestHandler_GetImportJobPhotos(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockRepo := new(MockRepository)
+	importjob.RegisterRoutes(setup.API, mockRepo, nil, nil)
+
+	t.Run("lists photo outcomes with counts", func(t *testing.T) {
+		testJob := createTestJob(setup.WorkspaceID, setup.UserID, importjob.EntityTypeItems)
+		jobID := testJob.ID()
+		itemID, photoID := uuid.New(), uuid.New()
+		msg := "url must be an absolute http or https URL"
+
+		attached := importjob.ReconstructImportPhotoResult(uuid.New(), jobID, 1, itemID, "https://example.com/a.jpg",
+			importjob.PhotoStatusAttached, &photoID, nil, true, time.Now(), time.Now())
+		skipped, _ := importjob.NewImportPhotoResult(jobID, 2, itemID, "not a url", importjob.PhotoStatusSkipped, &msg)
+		pending, _ := importjob.NewImportPhotoResult(jobID, 3, itemID, "https://example.com/b.jpg", importjob.PhotoStatusPending, nil)
+
+		mockRepo.On("FindJobByID", mock.Anything, jobID, setup.WorkspaceID).
+			Return(testJob, nil).Once()
+		mockRepo.On("FindPhotoResultsByJobID", mock.Anything, jobID).
+			Return([]*importjob.ImportPhotoResult{attached, skipped, pending}, nil).Once()
+
+		rec := setup.Get(fmt.Sprintf("/imports/jobs/%s/photos", jobID))
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		resp := testutil.ParseJSONResponse[importjob.ImportJobPhotoListResponse](t, rec)
+		assert.Equal(t, 1, resp.Attached)
+		assert.Equal(t, 1, resp.Skipped)
+		assert.Equal(t, 1, resp.Pending)
+		assert.Equal(t, 0, resp.Failed)
+		require.Len(t, resp.Photos, 3)
+		assert.Equal(t, &photoID, resp.Photos[0].PhotoID)
+		assert.True(t, resp.Photos[0].Primary)
+		assert.False(t, resp.Photos[2].Primary)
+		require.NotNil(t, resp.Photos[1].Message)
+		assert.Equal(t, msg, *resp.Photos[1].Message)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("returns 404 when job not found", func(t *testing.T) {
+		jobID := uuid.New()
+
+		mockRepo.On("FindJobByID", mock.Anything, jobID, setup.WorkspaceID).
+			Return(nil, importjob.ErrImportJobNotFound).Once()
+
+		rec := setup.Get(fmt.Sprintf("/imports/jobs/%s/photos", jobID))
+
+		testutil.AssertStatus(t, rec, http.StatusNotFound)
+	})
+}
+
 func TestHandler_DeleteImportJob(t *testing.T) {
 	setup := testutil.NewHandlerTestSetup()
 	mockRepo := new(MockRepository)
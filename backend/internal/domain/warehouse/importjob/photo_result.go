@@ -0,0 +1,119 @@
+package importjob
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// PhotoStatus is where the photo of an imported row stands. Photos are
+// fetched after the row is imported, so their outcome never changes the
+// row's own result.
+type PhotoStatus string
+
+const (
+	// PhotoStatusPending means the download is queued.
+	PhotoStatusPending PhotoStatus = "pending"
+	// PhotoStatusAttached means the photo was downloaded and added to the item.
+	PhotoStatusAttached PhotoStatus = "attached"
+	// PhotoStatusFailed means the download or image processing failed.
+	PhotoStatusFailed PhotoStatus = "failed"
+	// PhotoStatusSkipped means the URL was not fetched at all, e.g. because it
+	// was malformed. The message says why.
+	PhotoStatusSkipped PhotoStatus = "skipped"
+)
+
+func (s PhotoStatus) IsValid() bool {
+	switch s {
+	case PhotoStatusPending, PhotoStatusAttached, PhotoStatusFailed, PhotoStatusSkipped:
+		return true
+	}
+	return false
+}
+
+// ImportPhotoResult tracks one URL from the photo_url cell of an imported
+// row.
+type ImportPhotoResult struct {
+	id          uuid.UUID
+	importJobID uuid.UUID
+	rowNumber   int
+	itemID      uuid.UUID
+	url         string
+	status      PhotoStatus
+	photoID     *uuid.UUID
+	message     *string
+	isPrimary   bool
+	createdAt   time.Time
+	updatedAt   time.Time
+}
+
+func NewImportPhotoResult(importJobID uuid.UUID, rowNumber int, itemID uuid.UUID, url string, status PhotoStatus, message *string) (*ImportPhotoResult, error) {
+	if err := shared.ValidateUUID(importJobID, "import_job_id"); err != nil {
+		return nil, err
+	}
+	if err := shared.ValidateUUID(itemID, "item_id"); err != nil {
+		return nil, err
+	}
+	if rowNumber < 0 {
+		return nil, shared.NewFieldError(shared.ErrInvalidInput, "row_number", "row number must be non-negative")
+	}
+	if !status.IsValid() {
+		return nil, shared.NewFieldError(shared.ErrInvalidInput, "status", "invalid photo status")
+	}
+
+	now := time.Now()
+	return &ImportPhotoResult{
+		id:          shared.NewUUID(),
+		importJobID: importJobID,
+		rowNumber:   rowNumber,
+		itemID:      itemID,
+		url:         url,
+		status:      status,
+		message:     message,
+		createdAt:   now,
+		updatedAt:   now,
+	}, nil
+}
+
+func ReconstructImportPhotoResult(
+	id uuid.UUID,
+	importJobID uuid.UUID,
+	rowNumber int,
+	itemID uuid.UUID,
+	url string,
+	status PhotoStatus,
+	photoID *uuid.UUID,
+	message *string,
+	isPrimary bool,
+	createdAt time.Time,
+	updatedAt time.Time,
+) *ImportPhotoResult {
+	return &ImportPhotoResult{
+		id:          id,
+		importJobID: importJobID,
+		rowNumber:   rowNumber,
+		itemID:      itemID,
+		url:         url,
+		status:      status,
+		photoID:     photoID,
+		message:     message,
+		isPrimary:   isPrimary,
+		createdAt:   createdAt,
+		updatedAt:   updatedAt,
+	}
+}
+
+// Getters
+func (r *ImportPhotoResult) ID() uuid.UUID          { return r.id }
+func (r *ImportPhotoResult) ImportJobID() uuid.UUID { return r.importJobID }
+func (r *ImportPhotoResult) RowNumber() int         { return r.rowNumber }
+func (r *ImportPhotoResult) ItemID() uuid.UUID      { return r.itemID }
+func (r *ImportPhotoResult) URL() string            { return r.url }
+func (r *ImportPhotoResult) Status() PhotoStatus    { return r.status }
+func (r *ImportPhotoResult) PhotoID() *uuid.UUID    { return r.photoID }
+func (r *ImportPhotoResult) Message() *string       { return r.message }
+func (r *ImportPhotoResult) IsPrimary() bool        { return r.isPrimary }
+func (r *ImportPhotoResult) CreatedAt() time.Time   { return r.createdAt }
+func (r *ImportPhotoResult) UpdatedAt() time.Time   { return r.updatedAt }
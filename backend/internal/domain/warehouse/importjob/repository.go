@@ -18,6 +18,25 @@ type Repository interface {
 
 	// ImportError operations
 	SaveError(ctx context.Context, error *ImportError) error
+	// SaveErrors inserts a batch of errors in a single round trip.
+	SaveErrors(ctx context.Context, errors []*ImportError) error
 	FindErrorsByJobID(ctx context.Context, jobID uuid.UUID) ([]*ImportError, error)
 	DeleteErrorsByJobID(ctx context.Context, jobID uuid.UUID) error
+
+	// ImportRowResult operations
+	SaveRowResult(ctx context.Context, result *ImportRowResult) error
+	// SaveRowResults inserts a batch of row results in a single round trip.
+	SaveRowResults(ctx context.Context, results []*ImportRowResult) error
+	FindRowResultsByJobID(ctx context.Context, jobID uuid.UUID) ([]*ImportRowResult, error)
+
+	// ImportPhotoResult operations
+	SavePhotoResult(ctx context.Context, result *ImportPhotoResult) error
+	UpdatePhotoResult(ctx context.Context, id uuid.UUID, status PhotoStatus, photoID *uuid.UUID, message *string) error
+	FindPhotoResultsByJobID(ctx context.Context, jobID uuid.UUID) ([]*ImportPhotoResult, error)
+	// FindPhotoResultsByItem returns the job's photo results for one item in
+	// row and cell order.
+	FindPhotoResultsByItem(ctx context.Context, jobID, itemID uuid.UUID) ([]*ImportPhotoResult, error)
+	// MarkPhotoResultPrimary flags resultID as the item's primary photo and
+	// clears the flag on the item's other results in the job.
+	MarkPhotoResultPrimary(ctx context.Context, jobID, itemID, resultID uuid.UUID) error
 }
@@ -82,6 +82,28 @@ func (h *UploadHandler) HandleUpload(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// What to do with rows whose SKU already exists (items only; other
+	// entity types ignore it)
+	onConflict, err := ParseConflictPolicy(r.FormValue("on_conflict"))
+	if err != nil {
+		http.Error(w, "on_conflict must be one of: skip, update, error", http.StatusBadRequest)
+		return
+	}
+
+	// Which imported photo becomes an item's primary photo (items only)
+	primaryPhoto, err := ParsePrimaryPhotoPreference(r.FormValue("primary_photo"))
+	if err != nil {
+		http.Error(w, "primary_photo must be one of: first, largest", http.StatusBadRequest)
+		return
+	}
+
+	// Optional mapping of the file's headers to canonical column names
+	columnMapping, err := ParseColumnMapping(r.FormValue("column_mapping"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	// Get file from form
 	file, header, err := r.FormFile("file")
 	if err != nil {
@@ -92,8 +114,14 @@ func (h *UploadHandler) HandleUpload(w http.ResponseWriter, r *http.Request) {
 
 	// Validate file extension
 	ext := strings.ToLower(filepath.Ext(header.Filename))
-	if ext != AllowedCSVExt {
-		http.Error(w, "only CSV files are supported", http.StatusBadRequest)
+	switch {
+	case ext == AllowedCSVExt:
+	case ext == AllowedJSONExt && entityType == EntityTypeItems:
+	case ext == AllowedJSONExt:
+		http.Error(w, "JSON files are only supported for items", http.StatusBadRequest)
+		return
+	default:
+		http.Error(w, "only CSV and JSON files are supported", http.StatusBadRequest)
 		return
 	}
 
@@ -143,10 +171,16 @@ func (h *UploadHandler) HandleUpload(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Enqueue job for processing
-	_, err = h.queue.Enqueue(r.Context(), "import.process", map[string]any{
+	payload := map[string]any{
 		"import_job_id": job.ID().String(),
 		"workspace_id":  workspaceID.String(),
-	})
+		"on_conflict":   string(onConflict),
+		"primary_photo": string(primaryPhoto),
+	}
+	if columnMapping != nil {
+		payload["column_mapping"] = map[string]string(columnMapping)
+	}
+	_, err = h.queue.Enqueue(r.Context(), "import.process", payload)
 	if err != nil {
 		http.Error(w, "failed to enqueue import job", http.StatusInternalServerError)
 		return
@@ -7,6 +7,7 @@ import (
 	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/go-chi/chi/v5"
@@ -247,7 +248,6 @@ func TestUploadHandler_InvalidFileExtension(t *testing.T) {
 	}{
 		{".txt", "test.txt"},
 		{".xlsx", "spreadsheet.xlsx"},
-		{".json", "data.json"},
 		{".xml", "data.xml"},
 		{".pdf", "document.pdf"},
 		{".doc", "document.doc"},
@@ -269,11 +269,167 @@ func TestUploadHandler_InvalidFileExtension(t *testing.T) {
 			setup.Router.ServeHTTP(rec, req)
 
 			assert.Equal(t, http.StatusBadRequest, rec.Code)
-			assert.Contains(t, rec.Body.String(), "only CSV files are supported")
+			assert.Contains(t, rec.Body.String(), "only CSV and JSON files are supported")
 		})
 	}
 }
 
+// Tests for Upload Handler - JSON Files
+
+func TestUploadHandler_JSONFile(t *testing.T) {
+	upload := func(t *testing.T, mockRepo *MockRepository, entityType string) *httptest.ResponseRecorder {
+		setup := NewUploadTestSetup()
+		handler := importjob.NewUploadHandler(mockRepo, nil)
+		handler.RegisterUploadRoutes(setup.Router)
+
+		req := createUploadRequest(t, entityType, "export.json", []byte(`[{"name": "Drill"}]`))
+		ctx := context.WithValue(req.Context(), appMiddleware.WorkspaceContextKey, setup.WorkspaceID)
+		ctx = context.WithValue(ctx, appMiddleware.UserContextKey, setup.authUser)
+		ctx = context.WithValue(ctx, appMiddleware.RoleContextKey, "owner")
+		req = req.WithContext(ctx)
+
+		rec := httptest.NewRecorder()
+		setup.Router.ServeHTTP(rec, req)
+		return rec
+	}
+
+	t.Run("accepts JSON for items", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		// Mock SaveJob to prove validation passed
+		mockRepo.On("SaveJob", mock.Anything, mock.MatchedBy(func(job *importjob.ImportJob) bool {
+			return strings.HasSuffix(job.FilePath(), ".json")
+		})).Return(errors.New("mock error")).Once()
+
+		upload(t, mockRepo, "items")
+
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects JSON for other entity types", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+
+		rec := upload(t, mockRepo, "locations")
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "JSON files are only supported for items")
+		mockRepo.AssertNotCalled(t, "SaveJob", mock.Anything, mock.Anything)
+	})
+}
+
+// Tests for Upload Handler - Invalid Conflict Policy
+
+func TestUploadHandler_InvalidConflictPolicy(t *testing.T) {
+	setup := NewUploadTestSetup()
+	mockRepo := new(MockRepository)
+
+	handler := importjob.NewUploadHandler(mockRepo, nil)
+	handler.RegisterUploadRoutes(setup.Router)
+
+	t.Run("rejects unknown on_conflict value", func(t *testing.T) {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		assert.NoError(t, writer.WriteField("entity_type", "items"))
+		assert.NoError(t, writer.WriteField("on_conflict", "overwrite"))
+		part, err := writer.CreateFormFile("file", "items.csv")
+		assert.NoError(t, err)
+		_, err = part.Write([]byte("name,sku\nDrill,DRILL-001"))
+		assert.NoError(t, err)
+		assert.NoError(t, writer.Close())
+
+		req := httptest.NewRequest("POST", "/imports/upload", body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+
+		// Inject context
+		ctx := context.WithValue(req.Context(), appMiddleware.WorkspaceContextKey, setup.WorkspaceID)
+		ctx = context.WithValue(ctx, appMiddleware.UserContextKey, setup.authUser)
+		ctx = context.WithValue(ctx, appMiddleware.RoleContextKey, "owner")
+		req = req.WithContext(ctx)
+
+		rec := httptest.NewRecorder()
+		setup.Router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "on_conflict must be one of")
+		mockRepo.AssertNotCalled(t, "SaveJob")
+	})
+}
+
+// Tests for Upload Handler - Invalid Primary Photo Preference
+
+func TestUploadHandler_InvalidPrimaryPhoto(t *testing.T) {
+	setup := NewUploadTestSetup()
+	mockRepo := new(MockRepository)
+
+	handler := importjob.NewUploadHandler(mockRepo, nil)
+	handler.RegisterUploadRoutes(setup.Router)
+
+	t.Run("rejects unknown primary_photo value", func(t *testing.T) {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		assert.NoError(t, writer.WriteField("entity_type", "items"))
+		assert.NoError(t, writer.WriteField("primary_photo", "biggest"))
+		part, err := writer.CreateFormFile("file", "items.csv")
+		assert.NoError(t, err)
+		_, err = part.Write([]byte("name,sku,photo_url\nDrill,DRILL-001,https://example.com/a.jpg"))
+		assert.NoError(t, err)
+		assert.NoError(t, writer.Close())
+
+		req := httptest.NewRequest("POST", "/imports/upload", body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+
+		// Inject context
+		ctx := context.WithValue(req.Context(), appMiddleware.WorkspaceContextKey, setup.WorkspaceID)
+		ctx = context.WithValue(ctx, appMiddleware.UserContextKey, setup.authUser)
+		ctx = context.WithValue(ctx, appMiddleware.RoleContextKey, "owner")
+		req = req.WithContext(ctx)
+
+		rec := httptest.NewRecorder()
+		setup.Router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "primary_photo must be one of")
+		mockRepo.AssertNotCalled(t, "SaveJob")
+	})
+}
+
+// Tests for Upload Handler - Invalid Column Mapping
+
+func TestUploadHandler_InvalidColumnMapping(t *testing.T) {
+	setup := NewUploadTestSetup()
+	mockRepo := new(MockRepository)
+
+	handler := importjob.NewUploadHandler(mockRepo, nil)
+	handler.RegisterUploadRoutes(setup.Router)
+
+	t.Run("rejects malformed column_mapping", func(t *testing.T) {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		assert.NoError(t, writer.WriteField("entity_type", "items"))
+		assert.NoError(t, writer.WriteField("column_mapping", `{"Product": "name", "Title": "name"}`))
+		part, err := writer.CreateFormFile("file", "items.csv")
+		assert.NoError(t, err)
+		_, err = part.Write([]byte("Product,Title\nDrill,Drill"))
+		assert.NoError(t, err)
+		assert.NoError(t, writer.Close())
+
+		req := httptest.NewRequest("POST", "/imports/upload", body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+
+		// Inject context
+		ctx := context.WithValue(req.Context(), appMiddleware.WorkspaceContextKey, setup.WorkspaceID)
+		ctx = context.WithValue(ctx, appMiddleware.UserContextKey, setup.authUser)
+		ctx = context.WithValue(ctx, appMiddleware.RoleContextKey, "owner")
+		req = req.WithContext(ctx)
+
+		rec := httptest.NewRecorder()
+		setup.Router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		assert.Contains(t, rec.Body.String(), "column_mapping")
+		mockRepo.AssertNotCalled(t, "SaveJob")
+	})
+}
+
 // Tests for Upload Handler - Missing Workspace Context
 
 func TestUploadHandler_MissingWorkspaceContext(t *testing.T) {
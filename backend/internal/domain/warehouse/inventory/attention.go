@@ -0,0 +1,225 @@
+package inventory
+
+import (
+	"context"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/google/uuid"
+
+	appMiddleware "github.com/antti/home-warehouse/go-backend/internal/api/middleware"
+)
+
+const (
+	// AttentionExpiringDays is the window of the expiring and warranty
+	// categories of NeedsAttention, matching the /inventory/expiring default
+	// and the widest expiry reminder window.
+	AttentionExpiringDays = 30
+
+	// attentionTopEntries is how many entries each category lists.
+	attentionTopEntries = 5
+)
+
+// AttentionCategory is one kind of warning in NeedsAttention.
+type AttentionCategory string
+
+// Needs-attention categories, in the order NeedsAttention returns them.
+const (
+	AttentionExpiring         AttentionCategory = "expiring"
+	AttentionWarrantyExpiring AttentionCategory = "warranty_expiring"
+	AttentionLowStock         AttentionCategory = "low_stock"
+	AttentionOverdueLoans     AttentionCategory = "overdue_loans"
+	AttentionForRepair        AttentionCategory = "for_repair"
+)
+
+// AttentionEntry is one thing needing attention. Fields that do not apply to
+// its category are nil: low stock is reported per item (and location), not
+// per inventory entry, and only overdue loans have a LoanID and borrower.
+type AttentionEntry struct {
+	ItemID      uuid.UUID
+	ItemName    string
+	InventoryID *uuid.UUID
+	LoanID      *uuid.UUID
+	Quantity    int
+	// Date is the expiration date, warranty end, loan due date or, for
+	// repairs, when the entry was last updated.
+	Date          *time.Time
+	BorrowerName  *string
+	LocationName  *string
+	MinStockLevel *int
+}
+
+// AttentionGroup is a category's total count and its most urgent entries.
+type AttentionGroup struct {
+	Category AttentionCategory
+	Count    int
+	Top      []AttentionEntry
+}
+
+// NeedsAttention collects the dashboard's warnings for a workspace.
+type NeedsAttention struct {
+	Groups             []AttentionGroup
+	ExpiringWithinDays int
+}
+
+// AttentionRepository finds the needs-attention entries that have no report
+// of their own in this service.
+type AttentionRepository interface {
+	// FindOverdueLoans returns loans past their due date plus the workspace's
+	// overdue grace period, oldest due date first.
+	FindOverdueLoans(ctx context.Context, workspaceID uuid.UUID) ([]AttentionEntry, error)
+	// FindForRepair returns inventory in FOR_REPAIR condition, longest
+	// waiting first.
+	FindForRepair(ctx context.Context, workspaceID uuid.UUID) ([]AttentionEntry, error)
+}
+
+// SetAttentionRepository wires the overdue-loan and repair lookups of
+// NeedsAttention. Without it those categories are always empty.
+func (s *Service) SetAttentionRepository(repo AttentionRepository) {
+	s.attention = repo
+}
+
+// NeedsAttention combines the expiring, warranty-expiring, low-stock,
+// overdue-loan and for-repair reports into one response with each category's
+// count and its first few entries. Each category uses the same rules as its
+// own report: low stock honours per-location minimums and overdue loans the
+// workspace's grace period.
+func (s *Service) NeedsAttention(ctx context.Context, workspaceID uuid.UUID) (*NeedsAttention, error) {
+	expiring, err := s.ListExpiring(ctx, workspaceID, AttentionExpiringDays)
+	if err != nil {
+		return nil, err
+	}
+	lowStock, err := s.ListLowStock(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	var overdue, forRepair []AttentionEntry
+	if s.attention != nil {
+		if overdue, err = s.attention.FindOverdueLoans(ctx, workspaceID); err != nil {
+			return nil, err
+		}
+		if forRepair, err = s.attention.FindForRepair(ctx, workspaceID); err != nil {
+			return nil, err
+		}
+	}
+
+	var expirations, warranties []AttentionEntry
+	for _, e := range expiring {
+		entry := AttentionEntry{ItemID: e.ItemID, ItemName: e.ItemName, InventoryID: &e.InventoryID, Quantity: e.Quantity, Date: &e.Date}
+		if e.Kind == ExpiringKindWarranty {
+			warranties = append(warranties, entry)
+		} else {
+			expirations = append(expirations, entry)
+		}
+	}
+	low := make([]AttentionEntry, len(lowStock))
+	for i, e := range lowStock {
+		low[i] = AttentionEntry{
+			ItemID: e.ItemID, ItemName: e.ItemName, Quantity: e.CurrentStock,
+			LocationName: e.LocationName, MinStockLevel: &e.MinStockLevel,
+		}
+	}
+
+	return &NeedsAttention{
+		Groups: []AttentionGroup{
+			newAttentionGroup(AttentionExpiring, expirations),
+			newAttentionGroup(AttentionWarrantyExpiring, warranties),
+			newAttentionGroup(AttentionLowStock, low),
+			newAttentionGroup(AttentionOverdueLoans, overdue),
+			newAttentionGroup(AttentionForRepair, forRepair),
+		},
+		ExpiringWithinDays: AttentionExpiringDays,
+	}, nil
+}
+
+func newAttentionGroup(category AttentionCategory, entries []AttentionEntry) AttentionGroup {
+	top := entries
+	if len(top) > attentionTopEntries {
+		top = top[:attentionTopEntries]
+	}
+	if top == nil {
+		top = []AttentionEntry{}
+	}
+	return AttentionGroup{Category: category, Count: len(entries), Top: top}
+}
+
+// RegisterAttentionRoutes registers the needs-attention endpoint.
+func RegisterAttentionRoutes(api huma.API, svc ServiceInterface) {
+	huma.Get(api, "/inventory/needs-attention", getNeedsAttention(svc))
+}
+
+func getNeedsAttention(svc ServiceInterface) func(context.Context, *struct{}) (*NeedsAttentionOutput, error) {
+	return func(ctx context.Context, _ *struct{}) (*NeedsAttentionOutput, error) {
+		workspaceID, err := appMiddleware.RequireWorkspaceID(ctx)
+		if err != nil {
+			return nil, huma.Error401Unauthorized(err.Error())
+		}
+
+		result, err := svc.NeedsAttention(ctx, workspaceID)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to list inventory needing attention")
+		}
+
+		body := NeedsAttentionResponse{
+			Categories:         make([]AttentionCategoryResponse, len(result.Groups)),
+			ExpiringWithinDays: result.ExpiringWithinDays,
+		}
+		for i, g := range result.Groups {
+			items := make([]AttentionEntryResponse, len(g.Top))
+			for j, e := range g.Top {
+				items[j] = toAttentionEntryResponse(e)
+			}
+			body.Categories[i] = AttentionCategoryResponse{Category: string(g.Category), Count: g.Count, Items: items}
+			body.Total += g.Count
+		}
+		return &NeedsAttentionOutput{Body: body}, nil
+	}
+}
+
+func toAttentionEntryResponse(e AttentionEntry) AttentionEntryResponse {
+	resp := AttentionEntryResponse{
+		ItemID:        e.ItemID,
+		ItemName:      e.ItemName,
+		InventoryID:   e.InventoryID,
+		LoanID:        e.LoanID,
+		Quantity:      e.Quantity,
+		BorrowerName:  e.BorrowerName,
+		LocationName:  e.LocationName,
+		MinStockLevel: e.MinStockLevel,
+	}
+	if e.Date != nil {
+		date := e.Date.Format("2006-01-02")
+		resp.Date = &date
+	}
+	return resp
+}
+
+// Types for the needs-attention endpoint.
+
+type NeedsAttentionOutput struct {
+	Body NeedsAttentionResponse
+}
+
+type NeedsAttentionResponse struct {
+	Categories         []AttentionCategoryResponse `json:"categories"`
+	Total              int                         `json:"total" doc:"Sum of the category counts"`
+	ExpiringWithinDays int                         `json:"expiring_within_days" doc:"Window of the expiring and warranty_expiring categories"`
+}
+
+type AttentionCategoryResponse struct {
+	Category string                   `json:"category" enum:"expiring,warranty_expiring,low_stock,overdue_loans,for_repair"`
+	Count    int                      `json:"count"`
+	Items    []AttentionEntryResponse `json:"items" doc:"The most urgent entries, at most 5"`
+}
+
+type AttentionEntryResponse struct {
+	ItemID        uuid.UUID  `json:"item_id"`
+	ItemName      string     `json:"item_name"`
+	InventoryID   *uuid.UUID `json:"inventory_id,omitempty"`
+	LoanID        *uuid.UUID `json:"loan_id,omitempty"`
+	Quantity      int        `json:"quantity"`
+	Date          *string    `json:"date,omitempty" doc:"Expiration, warranty end, loan due or last update date (YYYY-MM-DD)"`
+	BorrowerName  *string    `json:"borrower_name,omitempty"`
+	LocationName  *string    `json:"location_name,omitempty" doc:"Location of a per-location minimum stock level"`
+	MinStockLevel *int       `json:"min_stock_level,omitempty"`
+}
@@ -0,0 +1,117 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type mockAttentionRepo struct {
+	mock.Mock
+}
+
+func (m *mockAttentionRepo) FindOverdueLoans(ctx context.Context, workspaceID uuid.UUID) ([]AttentionEntry, error) {
+	args := m.Called(ctx, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]AttentionEntry), args.Error(1)
+}
+
+func (m *mockAttentionRepo) FindForRepair(ctx context.Context, workspaceID uuid.UUID) ([]AttentionEntry, error) {
+	args := m.Called(ctx, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]AttentionEntry), args.Error(1)
+}
+
+func TestService_NeedsAttention(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+	now := time.Now()
+
+	expiring := []ExpiringInventory{
+		{InventoryID: uuid.New(), ItemName: "Milk", Kind: ExpiringKindExpiration, Date: now.AddDate(0, 0, 2)},
+		{InventoryID: uuid.New(), ItemName: "Drill", Kind: ExpiringKindWarranty, Date: now.AddDate(0, 0, 10)},
+	}
+	for i := 0; i < 6; i++ {
+		expiring = append(expiring, ExpiringInventory{InventoryID: uuid.New(), ItemName: fmt.Sprintf("Yogurt %d", i), Kind: ExpiringKindExpiration, Date: now.AddDate(0, 0, 3+i)})
+	}
+	garage := "Garage"
+	lowStock := []LowStockEntry{{ItemID: uuid.New(), ItemName: "Batteries", LocationName: &garage, CurrentStock: 1, MinStockLevel: 4}}
+	borrower := "Bob"
+	overdue := []AttentionEntry{{ItemName: "Ladder", BorrowerName: &borrower}}
+
+	t.Run("groups every category with counts and the top entries", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("FindExpiring", ctx, workspaceID, AttentionExpiringDays).Return(expiring, nil)
+		stockLevels := new(mockStockLevelRepo)
+		stockLevels.On("FindLowStock", ctx, workspaceID).Return(lowStock, nil)
+		attention := new(mockAttentionRepo)
+		attention.On("FindOverdueLoans", ctx, workspaceID).Return(overdue, nil)
+		attention.On("FindForRepair", ctx, workspaceID).Return([]AttentionEntry{}, nil)
+
+		svc := newTestService(repo)
+		svc.SetStockLevelRepository(stockLevels)
+		svc.SetAttentionRepository(attention)
+		got, err := svc.NeedsAttention(ctx, workspaceID)
+
+		require.NoError(t, err)
+		assert.Equal(t, AttentionExpiringDays, got.ExpiringWithinDays)
+		require.Len(t, got.Groups, 5)
+
+		expirations := got.Groups[0]
+		assert.Equal(t, AttentionExpiring, expirations.Category)
+		assert.Equal(t, 7, expirations.Count)
+		require.Len(t, expirations.Top, attentionTopEntries)
+		assert.Equal(t, "Milk", expirations.Top[0].ItemName)
+		assert.Equal(t, expiring[0].InventoryID, *expirations.Top[0].InventoryID)
+
+		warranties := got.Groups[1]
+		assert.Equal(t, AttentionWarrantyExpiring, warranties.Category)
+		assert.Equal(t, 1, warranties.Count)
+		assert.Equal(t, "Drill", warranties.Top[0].ItemName)
+
+		low := got.Groups[2]
+		assert.Equal(t, AttentionLowStock, low.Category)
+		assert.Equal(t, 1, low.Top[0].Quantity)
+		assert.Equal(t, 4, *low.Top[0].MinStockLevel)
+		assert.Equal(t, &garage, low.Top[0].LocationName)
+
+		assert.Equal(t, AttentionGroup{Category: AttentionOverdueLoans, Count: 1, Top: overdue}, got.Groups[3])
+		assert.Equal(t, AttentionGroup{Category: AttentionForRepair, Count: 0, Top: []AttentionEntry{}}, got.Groups[4])
+	})
+
+	t.Run("loans and repairs are empty without an attention repository", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("FindExpiring", ctx, workspaceID, AttentionExpiringDays).Return([]ExpiringInventory{}, nil)
+
+		got, err := newTestService(repo).NeedsAttention(ctx, workspaceID)
+
+		require.NoError(t, err)
+		for _, g := range got.Groups {
+			assert.Zero(t, g.Count, g.Category)
+			assert.Empty(t, g.Top, g.Category)
+		}
+	})
+
+	t.Run("fails when a report fails", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("FindExpiring", ctx, workspaceID, AttentionExpiringDays).Return([]ExpiringInventory{}, nil)
+		attention := new(mockAttentionRepo)
+		attention.On("FindOverdueLoans", ctx, workspaceID).Return(nil, assert.AnError)
+
+		svc := newTestService(repo)
+		svc.SetAttentionRepository(attention)
+		_, err := svc.NeedsAttention(ctx, workspaceID)
+
+		assert.ErrorIs(t, err, assert.AnError)
+	})
+}
@@ -0,0 +1,246 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/google/uuid"
+
+	appMiddleware "github.com/antti/home-warehouse/go-backend/internal/api/middleware"
+	"github.com/antti/home-warehouse/go-backend/internal/infra/events"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// MaxBulkStatusEntries caps how many entries one bulk status update may touch.
+const MaxBulkStatusEntries = 200
+
+var (
+	ErrBulkStatusNoChange = shared.NewDomainError(shared.ErrInvalidInput, "status or condition is required")
+	ErrBulkStatusTooMany  = shared.NewFieldError(shared.ErrInvalidInput, "inventory_ids", "at most 200 entries can be updated at once")
+	// ErrBulkStatusAborted is reported for entries that were valid but left
+	// unchanged because another entry of an atomic batch failed.
+	ErrBulkStatusAborted = errors.New("not applied: another entry in the batch failed")
+)
+
+// Transactor runs a function inside a single database transaction. It is a
+// port implemented by infra/postgres.TxManager, keeping this package free of
+// infra imports.
+type Transactor interface {
+	WithTx(ctx context.Context, fn func(context.Context) error) error
+}
+
+// noopTransactor executes the function without a surrounding transaction. It
+// is the fallback when no Transactor is wired (unit tests with mocked
+// repositories).
+type noopTransactor struct{}
+
+func (noopTransactor) WithTx(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+// SetTransactor wires the transaction runner used by BulkUpdateStatus so a
+// batch is saved all at once, and by Move and ConsumeInventory to save the
+// entry with its movement record. Optional — without it the saves run unwrapped
+// (unit tests with mocked repositories).
+func (s *Service) SetTransactor(tx Transactor) {
+	if tx == nil {
+		tx = noopTransactor{}
+	}
+	s.tx = tx
+}
+
+// BulkStatusInput changes the status and/or condition of many entries at
+// once. Note is not stored on the entries; it travels with the activity log
+// of each change.
+type BulkStatusInput struct {
+	InventoryIDs []uuid.UUID
+	Status       *Status
+	Condition    *Condition
+	Note         *string
+	// Atomic applies nothing unless every entry can be changed. Otherwise
+	// the entries that can be changed are, and the rest report their error.
+	Atomic bool
+}
+
+// BulkStatusResult is the outcome for one entry of a bulk status update.
+// Inventory is the updated entry, or nil when Err is set.
+type BulkStatusResult struct {
+	InventoryID uuid.UUID
+	Inventory   *Inventory
+	Err         error
+}
+
+// BulkUpdateStatus applies a status and/or condition to each entry, checking
+// every status change against the status machine. Per-entry problems (not
+// found, invalid transition) are reported in the results; the returned error
+// is for the request as a whole. All changes are saved in one transaction.
+func (s *Service) BulkUpdateStatus(ctx context.Context, workspaceID uuid.UUID, input BulkStatusInput) ([]BulkStatusResult, error) {
+	if input.Status == nil && input.Condition == nil {
+		return nil, ErrBulkStatusNoChange
+	}
+	if input.Status != nil && !input.Status.IsValid() {
+		return nil, shared.NewFieldError(shared.ErrInvalidInput, "status", ErrInvalidStatus.Error())
+	}
+	if input.Condition != nil && !input.Condition.IsValid() {
+		return nil, shared.NewFieldError(shared.ErrInvalidInput, "condition", ErrInvalidCondition.Error())
+	}
+	if len(input.InventoryIDs) == 0 {
+		return nil, shared.NewFieldError(shared.ErrInvalidInput, "inventory_ids", "at least one entry is required")
+	}
+	if len(input.InventoryIDs) > MaxBulkStatusEntries {
+		return nil, ErrBulkStatusTooMany
+	}
+
+	results := make([]BulkStatusResult, 0, len(input.InventoryIDs))
+	seen := make(map[uuid.UUID]bool, len(input.InventoryIDs))
+	failed := false
+	for _, id := range input.InventoryIDs {
+		if seen[id] {
+			continue
+		}
+		seen[id] = true
+
+		inv, err := s.applyBulkStatus(ctx, workspaceID, id, input)
+		if err != nil {
+			if !shared.IsNotFound(err) && !errors.Is(err, ErrInvalidTransition) {
+				return nil, err
+			}
+			failed = true
+			results = append(results, BulkStatusResult{InventoryID: id, Err: err})
+			continue
+		}
+		results = append(results, BulkStatusResult{InventoryID: id, Inventory: inv})
+	}
+
+	if failed && input.Atomic {
+		for i := range results {
+			if results[i].Err == nil {
+				results[i].Inventory = nil
+				results[i].Err = ErrBulkStatusAborted
+			}
+		}
+		return results, nil
+	}
+
+	err := s.tx.WithTx(ctx, func(ctx context.Context) error {
+		for _, r := range results {
+			if r.Inventory == nil {
+				continue
+			}
+			if err := s.repo.Save(ctx, r.Inventory); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// applyBulkStatus loads one entry and applies the changes in memory.
+func (s *Service) applyBulkStatus(ctx context.Context, workspaceID, id uuid.UUID, input BulkStatusInput) (*Inventory, error) {
+	inv, err := s.repo.FindByID(ctx, id, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if input.Status != nil {
+		if err := inv.UpdateStatus(*input.Status); err != nil {
+			return nil, err
+		}
+	}
+	if input.Condition != nil {
+		if err := inv.UpdateCondition(*input.Condition); err != nil {
+			return nil, err
+		}
+	}
+	return inv, nil
+}
+
+// bulkUpdateStatus changes the status and/or condition of many entries and
+// publishes inventory.updated for each entry changed, so every change gets
+// its own activity log row.
+func bulkUpdateStatus(svc ServiceInterface, broadcaster *events.Broadcaster) func(context.Context, *BulkStatusRequest) (*BulkStatusOutput, error) {
+	return func(ctx context.Context, input *BulkStatusRequest) (*BulkStatusOutput, error) {
+		workspaceID, err := appMiddleware.RequireWorkspaceID(ctx)
+		if err != nil {
+			return nil, huma.Error401Unauthorized(err.Error())
+		}
+		// Bulk changes apply immediately rather than through the approval
+		// queue.
+		if role, ok := appMiddleware.GetRole(ctx); !ok || (role != "owner" && role != "admin") {
+			return nil, huma.Error403Forbidden("only workspace owners and admins can change inventory in bulk")
+		}
+
+		results, err := svc.BulkUpdateStatus(ctx, workspaceID, BulkStatusInput{
+			InventoryIDs: input.Body.InventoryIDs,
+			Status:       input.Body.Status,
+			Condition:    input.Body.Condition,
+			Note:         input.Body.Note,
+			Atomic:       input.Body.Atomic,
+		})
+		if err != nil {
+			return nil, appMiddleware.MapDomainError(err)
+		}
+
+		out := &BulkStatusOutput{}
+		out.Body.Results = make([]BulkStatusItemResponse, len(results))
+		for i, r := range results {
+			item := BulkStatusItemResponse{InventoryID: r.InventoryID}
+			switch {
+			case r.Err == nil:
+				item.Success = true
+				item.Status = r.Inventory.Status()
+				item.Condition = r.Inventory.Condition()
+				out.Body.Updated++
+
+				data := map[string]any{
+					"id":        r.Inventory.ID(),
+					"status":    r.Inventory.Status(),
+					"condition": r.Inventory.Condition(),
+					"bulk":      true,
+				}
+				if input.Body.Note != nil {
+					data["note"] = *input.Body.Note
+				}
+				publishInventoryEvent(ctx, broadcaster, workspaceID, eventInventoryUpdated, r.Inventory.ID().String(), data)
+			case shared.IsNotFound(r.Err):
+				item.Error = msgInventoryNotFound
+				out.Body.Failed++
+			default:
+				item.Error = r.Err.Error()
+				out.Body.Failed++
+			}
+			out.Body.Results[i] = item
+		}
+		return out, nil
+	}
+}
+
+type BulkStatusRequest struct {
+	Body struct {
+		InventoryIDs []uuid.UUID `json:"inventory_ids" minItems:"1" maxItems:"200" doc:"Entries to update"`
+		Status       *Status     `json:"status,omitempty" enum:"AVAILABLE,IN_USE,RESERVED,ON_LOAN,IN_TRANSIT,DISPOSED,MISSING" doc:"New status; each change must be allowed from the entry's current status"`
+		Condition    *Condition  `json:"condition,omitempty" enum:"NEW,EXCELLENT,GOOD,FAIR,POOR,DAMAGED,FOR_REPAIR" doc:"New condition"`
+		Note         *string     `json:"note,omitempty" maxLength:"500" doc:"Recorded in the activity log of each change"`
+		Atomic       bool        `json:"atomic,omitempty" doc:"Apply nothing unless every entry can be changed"`
+	}
+}
+
+type BulkStatusOutput struct {
+	Body struct {
+		Results []BulkStatusItemResponse `json:"results"`
+		Updated int                      `json:"updated"`
+		Failed  int                      `json:"failed"`
+	}
+}
+
+type BulkStatusItemResponse struct {
+	InventoryID uuid.UUID `json:"inventory_id"`
+	Success     bool      `json:"success"`
+	Status      Status    `json:"status,omitempty"`
+	Condition   Condition `json:"condition,omitempty"`
+	Error       string    `json:"error,omitempty"`
+}
@@ -0,0 +1,141 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// countingTx counts WithTx calls and runs fn directly.
+type countingTx struct {
+	calls int
+}
+
+func (c *countingTx) WithTx(ctx context.Context, fn func(context.Context) error) error {
+	c.calls++
+	return fn(ctx)
+}
+
+func TestService_BulkUpdateStatus(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+	damaged := ConditionDamaged
+	missing := StatusMissing
+
+	newEntry := func(status Status) *Inventory {
+		return &Inventory{id: uuid.New(), workspaceID: workspaceID, quantity: 1, condition: ConditionGood, status: status}
+	}
+
+	t.Run("updates valid entries and reports the rest", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		tx := &countingTx{}
+		svc := newTestService(mockRepo)
+		svc.SetTransactor(tx)
+
+		available := newEntry(StatusAvailable)
+		disposed := newEntry(StatusDisposed)
+		unknownID := uuid.New()
+		mockRepo.On("FindByID", ctx, available.ID(), workspaceID).Return(available, nil)
+		mockRepo.On("FindByID", ctx, disposed.ID(), workspaceID).Return(disposed, nil)
+		mockRepo.On("FindByID", ctx, unknownID, workspaceID).Return(nil, shared.ErrNotFound)
+		mockRepo.On("Save", mock.Anything, available).Return(nil).Once()
+
+		results, err := svc.BulkUpdateStatus(ctx, workspaceID, BulkStatusInput{
+			InventoryIDs: []uuid.UUID{available.ID(), disposed.ID(), unknownID, available.ID()},
+			Status:       &missing,
+			Condition:    &damaged,
+		})
+
+		require.NoError(t, err)
+		require.Len(t, results, 3, "duplicate ids are updated once")
+		assert.NoError(t, results[0].Err)
+		assert.Equal(t, StatusMissing, results[0].Inventory.Status())
+		assert.Equal(t, ConditionDamaged, results[0].Inventory.Condition())
+		assert.ErrorIs(t, results[1].Err, ErrInvalidTransition)
+		assert.True(t, shared.IsNotFound(results[2].Err))
+		assert.Equal(t, 1, tx.calls)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("atomic batch applies nothing when an entry fails", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		svc := newTestService(mockRepo)
+
+		available := newEntry(StatusAvailable)
+		disposed := newEntry(StatusDisposed)
+		mockRepo.On("FindByID", ctx, available.ID(), workspaceID).Return(available, nil)
+		mockRepo.On("FindByID", ctx, disposed.ID(), workspaceID).Return(disposed, nil)
+
+		results, err := svc.BulkUpdateStatus(ctx, workspaceID, BulkStatusInput{
+			InventoryIDs: []uuid.UUID{available.ID(), disposed.ID()},
+			Status:       &missing,
+			Atomic:       true,
+		})
+
+		require.NoError(t, err)
+		assert.ErrorIs(t, results[0].Err, ErrBulkStatusAborted)
+		assert.Nil(t, results[0].Inventory)
+		assert.ErrorIs(t, results[1].Err, ErrInvalidTransition)
+		mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("condition alone skips the status machine", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		svc := newTestService(mockRepo)
+
+		disposed := newEntry(StatusDisposed)
+		mockRepo.On("FindByID", ctx, disposed.ID(), workspaceID).Return(disposed, nil)
+		mockRepo.On("Save", mock.Anything, disposed).Return(nil)
+
+		results, err := svc.BulkUpdateStatus(ctx, workspaceID, BulkStatusInput{
+			InventoryIDs: []uuid.UUID{disposed.ID()},
+			Condition:    &damaged,
+		})
+
+		require.NoError(t, err)
+		assert.NoError(t, results[0].Err)
+		assert.Equal(t, StatusDisposed, results[0].Inventory.Status())
+	})
+
+	t.Run("requires a status or condition", func(t *testing.T) {
+		svc := newTestService(new(MockRepository))
+
+		_, err := svc.BulkUpdateStatus(ctx, workspaceID, BulkStatusInput{InventoryIDs: []uuid.UUID{uuid.New()}})
+
+		assert.ErrorIs(t, err, ErrBulkStatusNoChange)
+	})
+
+	t.Run("rejects oversized batches", func(t *testing.T) {
+		svc := newTestService(new(MockRepository))
+
+		_, err := svc.BulkUpdateStatus(ctx, workspaceID, BulkStatusInput{
+			InventoryIDs: make([]uuid.UUID, MaxBulkStatusEntries+1),
+			Status:       &missing,
+		})
+
+		assert.ErrorIs(t, err, shared.ErrInvalidInput)
+	})
+
+	t.Run("fails the request on a save error", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		svc := newTestService(mockRepo)
+
+		available := newEntry(StatusAvailable)
+		mockRepo.On("FindByID", ctx, available.ID(), workspaceID).Return(available, nil)
+		mockRepo.On("Save", mock.Anything, available).Return(errors.New("db down"))
+
+		_, err := svc.BulkUpdateStatus(ctx, workspaceID, BulkStatusInput{
+			InventoryIDs: []uuid.UUID{available.ID()},
+			Status:       &missing,
+		})
+
+		assert.ErrorContains(t, err, "db down")
+	})
+}
@@ -0,0 +1,259 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/google/uuid"
+
+	appMiddleware "github.com/antti/home-warehouse/go-backend/internal/api/middleware"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/item"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// The condition and status of new inventory when neither the request nor
+// the item's category says otherwise.
+const (
+	DefaultCondition = ConditionNew
+	DefaultStatus    = StatusAvailable
+)
+
+// CategoryDefaults pre-fill the condition and status of new inventory for
+// items in a category. A nil field falls back to the global default.
+type CategoryDefaults struct {
+	CategoryID uuid.UUID
+	Condition  *Condition
+	Status     *Status
+	UpdatedAt  *time.Time // nil until defaults are saved
+}
+
+// CategoryDefaultsRepository persists category defaults. Get returns
+// shared.ErrNotFound when the category has none; Upsert returns it when the
+// category is not in the workspace.
+type CategoryDefaultsRepository interface {
+	Get(ctx context.Context, workspaceID, categoryID uuid.UUID) (*CategoryDefaults, error)
+	List(ctx context.Context, workspaceID uuid.UUID) ([]*CategoryDefaults, error)
+	Upsert(ctx context.Context, workspaceID uuid.UUID, defaults CategoryDefaults) (*CategoryDefaults, error)
+	Delete(ctx context.Context, workspaceID, categoryID uuid.UUID) error
+}
+
+// SetCategoryDefaultsRepository wires category defaults storage. Without it
+// new inventory always falls back to DefaultCondition and DefaultStatus.
+func (s *Service) SetCategoryDefaultsRepository(repo CategoryDefaultsRepository) {
+	s.categoryDefaults = repo
+}
+
+// ListCategoryDefaults returns the defaults of every category that has any.
+func (s *Service) ListCategoryDefaults(ctx context.Context, workspaceID uuid.UUID) ([]*CategoryDefaults, error) {
+	if s.categoryDefaults == nil {
+		return []*CategoryDefaults{}, nil
+	}
+	return s.categoryDefaults.List(ctx, workspaceID)
+}
+
+// GetCategoryDefaults returns the category's defaults, with nil fields when
+// it has none.
+func (s *Service) GetCategoryDefaults(ctx context.Context, workspaceID, categoryID uuid.UUID) (*CategoryDefaults, error) {
+	if s.categoryDefaults == nil {
+		return &CategoryDefaults{CategoryID: categoryID}, nil
+	}
+	defaults, err := s.categoryDefaults.Get(ctx, workspaceID, categoryID)
+	if errors.Is(err, shared.ErrNotFound) {
+		return &CategoryDefaults{CategoryID: categoryID}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return defaults, nil
+}
+
+// SetCategoryDefaults replaces the category's defaults. Setting both fields
+// to nil is the same as ClearCategoryDefaults.
+func (s *Service) SetCategoryDefaults(ctx context.Context, workspaceID uuid.UUID, defaults CategoryDefaults) (*CategoryDefaults, error) {
+	if defaults.Condition != nil && !defaults.Condition.IsValid() {
+		return nil, shared.NewFieldError(shared.ErrInvalidInput, "condition", ErrInvalidCondition.Error())
+	}
+	if defaults.Status != nil && !defaults.Status.IsValid() {
+		return nil, shared.NewFieldError(shared.ErrInvalidInput, "status", ErrInvalidStatus.Error())
+	}
+	if s.categoryDefaults == nil {
+		return nil, errors.New("category defaults storage is not configured")
+	}
+	if defaults.Condition == nil && defaults.Status == nil {
+		if err := s.categoryDefaults.Delete(ctx, workspaceID, defaults.CategoryID); err != nil {
+			return nil, err
+		}
+		return &CategoryDefaults{CategoryID: defaults.CategoryID}, nil
+	}
+	return s.categoryDefaults.Upsert(ctx, workspaceID, defaults)
+}
+
+// ClearCategoryDefaults removes the category's defaults.
+func (s *Service) ClearCategoryDefaults(ctx context.Context, workspaceID, categoryID uuid.UUID) error {
+	if s.categoryDefaults == nil {
+		return nil
+	}
+	return s.categoryDefaults.Delete(ctx, workspaceID, categoryID)
+}
+
+// applyCreateDefaults fills in the condition and/or status a create request
+// left empty: an explicit value wins, then the item category's default, then
+// DefaultCondition / DefaultStatus.
+func (s *Service) applyCreateDefaults(ctx context.Context, input *CreateInput, it *item.Item) error {
+	if input.Condition != "" && input.Status != "" {
+		return nil
+	}
+
+	if s.categoryDefaults != nil && it.CategoryID() != nil {
+		defaults, err := s.categoryDefaults.Get(ctx, input.WorkspaceID, *it.CategoryID())
+		if err != nil && !errors.Is(err, shared.ErrNotFound) {
+			return err
+		}
+		if defaults != nil {
+			if input.Condition == "" && defaults.Condition != nil {
+				input.Condition = *defaults.Condition
+			}
+			if input.Status == "" && defaults.Status != nil {
+				input.Status = *defaults.Status
+			}
+		}
+	}
+
+	if input.Condition == "" {
+		input.Condition = DefaultCondition
+	}
+	if input.Status == "" {
+		input.Status = DefaultStatus
+	}
+	return nil
+}
+
+// RegisterCategoryDefaultsRoutes registers the category inventory defaults
+// endpoints. They live outside /categories so a member's change is not
+// queued for approval as a category update; like the inventory settings,
+// only owners and admins can change them.
+func RegisterCategoryDefaultsRoutes(api huma.API, svc ServiceInterface) {
+	huma.Get(api, "/category-inventory-defaults", listCategoryDefaults(svc))
+	huma.Get(api, "/category-inventory-defaults/{category_id}", getCategoryDefaults(svc))
+	huma.Put(api, "/category-inventory-defaults/{category_id}", setCategoryDefaults(svc))
+	huma.Delete(api, "/category-inventory-defaults/{category_id}", clearCategoryDefaults(svc))
+}
+
+func listCategoryDefaults(svc ServiceInterface) func(context.Context, *struct{}) (*ListCategoryDefaultsOutput, error) {
+	return func(ctx context.Context, _ *struct{}) (*ListCategoryDefaultsOutput, error) {
+		workspaceID, err := appMiddleware.RequireWorkspaceID(ctx)
+		if err != nil {
+			return nil, huma.Error401Unauthorized(err.Error())
+		}
+
+		all, err := svc.ListCategoryDefaults(ctx, workspaceID)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to list category defaults")
+		}
+
+		items := make([]CategoryDefaultsResponse, len(all))
+		for i, d := range all {
+			items[i] = toCategoryDefaultsResponse(d)
+		}
+		return &ListCategoryDefaultsOutput{Body: CategoryDefaultsListResponse{Items: items}}, nil
+	}
+}
+
+func getCategoryDefaults(svc ServiceInterface) func(context.Context, *CategoryDefaultsInput) (*CategoryDefaultsOutput, error) {
+	return func(ctx context.Context, input *CategoryDefaultsInput) (*CategoryDefaultsOutput, error) {
+		workspaceID, err := appMiddleware.RequireWorkspaceID(ctx)
+		if err != nil {
+			return nil, huma.Error401Unauthorized(err.Error())
+		}
+
+		defaults, err := svc.GetCategoryDefaults(ctx, workspaceID, input.CategoryID)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to fetch category defaults")
+		}
+		return &CategoryDefaultsOutput{Body: toCategoryDefaultsResponse(defaults)}, nil
+	}
+}
+
+func setCategoryDefaults(svc ServiceInterface) func(context.Context, *SetCategoryDefaultsInput) (*CategoryDefaultsOutput, error) {
+	return func(ctx context.Context, input *SetCategoryDefaultsInput) (*CategoryDefaultsOutput, error) {
+		workspaceID, err := appMiddleware.RequireWorkspaceID(ctx)
+		if err != nil {
+			return nil, huma.Error401Unauthorized(err.Error())
+		}
+		if role, ok := appMiddleware.GetRole(ctx); !ok || (role != "owner" && role != "admin") {
+			return nil, huma.Error403Forbidden("only workspace owners and admins can change category defaults")
+		}
+
+		defaults, err := svc.SetCategoryDefaults(ctx, workspaceID, CategoryDefaults{
+			CategoryID: input.CategoryID,
+			Condition:  input.Body.Condition,
+			Status:     input.Body.Status,
+		})
+		if err != nil {
+			if shared.IsNotFound(err) {
+				return nil, huma.Error404NotFound("category not found")
+			}
+			return nil, appMiddleware.MapDomainError(err)
+		}
+		return &CategoryDefaultsOutput{Body: toCategoryDefaultsResponse(defaults)}, nil
+	}
+}
+
+func clearCategoryDefaults(svc ServiceInterface) func(context.Context, *CategoryDefaultsInput) (*struct{}, error) {
+	return func(ctx context.Context, input *CategoryDefaultsInput) (*struct{}, error) {
+		workspaceID, err := appMiddleware.RequireWorkspaceID(ctx)
+		if err != nil {
+			return nil, huma.Error401Unauthorized(err.Error())
+		}
+		if role, ok := appMiddleware.GetRole(ctx); !ok || (role != "owner" && role != "admin") {
+			return nil, huma.Error403Forbidden("only workspace owners and admins can change category defaults")
+		}
+
+		if err := svc.ClearCategoryDefaults(ctx, workspaceID, input.CategoryID); err != nil {
+			return nil, huma.Error500InternalServerError("failed to clear category defaults")
+		}
+		return nil, nil
+	}
+}
+
+func toCategoryDefaultsResponse(d *CategoryDefaults) CategoryDefaultsResponse {
+	return CategoryDefaultsResponse{
+		CategoryID: d.CategoryID,
+		Condition:  d.Condition,
+		Status:     d.Status,
+		UpdatedAt:  d.UpdatedAt,
+	}
+}
+
+type CategoryDefaultsInput struct {
+	CategoryID uuid.UUID `path:"category_id"`
+}
+
+type SetCategoryDefaultsInput struct {
+	CategoryID uuid.UUID `path:"category_id"`
+	Body       struct {
+		Condition *Condition `json:"condition,omitempty" enum:"NEW,EXCELLENT,GOOD,FAIR,POOR,DAMAGED,FOR_REPAIR" doc:"Condition for new inventory of items in the category. Omit to use NEW"`
+		Status    *Status    `json:"status,omitempty" enum:"AVAILABLE,IN_USE,RESERVED,ON_LOAN,IN_TRANSIT,DISPOSED,MISSING" doc:"Status for new inventory of items in the category. Omit to use AVAILABLE"`
+	}
+}
+
+type CategoryDefaultsOutput struct {
+	Body CategoryDefaultsResponse
+}
+
+type ListCategoryDefaultsOutput struct {
+	Body CategoryDefaultsListResponse
+}
+
+type CategoryDefaultsListResponse struct {
+	Items []CategoryDefaultsResponse `json:"items"`
+}
+
+type CategoryDefaultsResponse struct {
+	CategoryID uuid.UUID  `json:"category_id"`
+	Condition  *Condition `json:"condition,omitempty" doc:"Default condition; absent uses NEW"`
+	Status     *Status    `json:"status,omitempty" doc:"Default status; absent uses AVAILABLE"`
+	UpdatedAt  *time.Time `json:"updated_at,omitempty"`
+}
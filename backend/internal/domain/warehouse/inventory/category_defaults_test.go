@@ -0,0 +1,231 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/item"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+type mockCategoryDefaultsRepository struct {
+	mock.Mock
+}
+
+func (m *mockCategoryDefaultsRepository) Get(ctx context.Context, workspaceID, categoryID uuid.UUID) (*CategoryDefaults, error) {
+	args := m.Called(ctx, workspaceID, categoryID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*CategoryDefaults), args.Error(1)
+}
+
+func (m *mockCategoryDefaultsRepository) List(ctx context.Context, workspaceID uuid.UUID) ([]*CategoryDefaults, error) {
+	args := m.Called(ctx, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*CategoryDefaults), args.Error(1)
+}
+
+func (m *mockCategoryDefaultsRepository) Upsert(ctx context.Context, workspaceID uuid.UUID, defaults CategoryDefaults) (*CategoryDefaults, error) {
+	args := m.Called(ctx, workspaceID, defaults)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*CategoryDefaults), args.Error(1)
+}
+
+func (m *mockCategoryDefaultsRepository) Delete(ctx context.Context, workspaceID, categoryID uuid.UUID) error {
+	args := m.Called(ctx, workspaceID, categoryID)
+	return args.Error(0)
+}
+
+// newCategoryDefaultsTestService returns a service whose items all belong
+// to categoryID, with defaults stored in the returned repository.
+func newCategoryDefaultsTestService(categoryID *uuid.UUID) (*Service, *MockRepository, *mockCategoryDefaultsRepository) {
+	now := time.Now()
+	inventoryRepo := new(MockRepository)
+	_, locR, contR := newPermissiveFKRepos()
+	itemR := new(mockItemRepo)
+	itemR.On("FindByID", mock.Anything, mock.Anything, mock.Anything).Return(
+		item.Reconstruct(uuid.New(), uuid.New(), "SKU", "item", nil, categoryID, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0, "SC", nil, nil, nil, true, now, now),
+		nil,
+	)
+
+	defaultsRepo := new(mockCategoryDefaultsRepository)
+	svc := NewService(inventoryRepo, nil, itemR, locR, contR)
+	svc.SetCategoryDefaultsRepository(defaultsRepo)
+	return svc, inventoryRepo, defaultsRepo
+}
+
+func TestService_Create_CategoryDefaults(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+	categoryID := uuid.New()
+	fair := ConditionFair
+	inUse := StatusInUse
+
+	input := func(condition Condition, status Status) CreateInput {
+		return CreateInput{
+			WorkspaceID: workspaceID,
+			ItemID:      uuid.New(),
+			LocationID:  uuid.New(),
+			Quantity:    1,
+			Condition:   condition,
+			Status:      status,
+		}
+	}
+
+	t.Run("uses the category defaults for omitted fields", func(t *testing.T) {
+		svc, repo, defaultsRepo := newCategoryDefaultsTestService(&categoryID)
+		defaultsRepo.On("Get", ctx, workspaceID, categoryID).
+			Return(&CategoryDefaults{CategoryID: categoryID, Condition: &fair, Status: &inUse}, nil).Once()
+		repo.On("Save", ctx, mock.AnythingOfType("*inventory.Inventory")).Return(nil)
+
+		inv, err := svc.Create(ctx, input("", ""))
+
+		require.NoError(t, err)
+		assert.Equal(t, ConditionFair, inv.Condition())
+		assert.Equal(t, StatusInUse, inv.Status())
+	})
+
+	t.Run("explicit values win over the category defaults", func(t *testing.T) {
+		svc, repo, defaultsRepo := newCategoryDefaultsTestService(&categoryID)
+		defaultsRepo.On("Get", ctx, workspaceID, categoryID).
+			Return(&CategoryDefaults{CategoryID: categoryID, Condition: &fair, Status: &inUse}, nil).Once()
+		repo.On("Save", ctx, mock.AnythingOfType("*inventory.Inventory")).Return(nil)
+
+		inv, err := svc.Create(ctx, input(ConditionPoor, ""))
+
+		require.NoError(t, err)
+		assert.Equal(t, ConditionPoor, inv.Condition())
+		assert.Equal(t, StatusInUse, inv.Status())
+	})
+
+	t.Run("falls back to the global defaults for unset category fields", func(t *testing.T) {
+		svc, repo, defaultsRepo := newCategoryDefaultsTestService(&categoryID)
+		defaultsRepo.On("Get", ctx, workspaceID, categoryID).
+			Return(&CategoryDefaults{CategoryID: categoryID, Status: &inUse}, nil).Once()
+		repo.On("Save", ctx, mock.AnythingOfType("*inventory.Inventory")).Return(nil)
+
+		inv, err := svc.Create(ctx, input("", ""))
+
+		require.NoError(t, err)
+		assert.Equal(t, DefaultCondition, inv.Condition())
+		assert.Equal(t, StatusInUse, inv.Status())
+	})
+
+	t.Run("falls back to the global defaults when the category has none", func(t *testing.T) {
+		svc, repo, defaultsRepo := newCategoryDefaultsTestService(&categoryID)
+		defaultsRepo.On("Get", ctx, workspaceID, categoryID).Return(nil, shared.ErrNotFound).Once()
+		repo.On("Save", ctx, mock.AnythingOfType("*inventory.Inventory")).Return(nil)
+
+		inv, err := svc.Create(ctx, input("", ""))
+
+		require.NoError(t, err)
+		assert.Equal(t, DefaultCondition, inv.Condition())
+		assert.Equal(t, DefaultStatus, inv.Status())
+	})
+
+	t.Run("does not look up defaults for uncategorized items", func(t *testing.T) {
+		svc, repo, defaultsRepo := newCategoryDefaultsTestService(nil)
+		repo.On("Save", ctx, mock.AnythingOfType("*inventory.Inventory")).Return(nil)
+
+		inv, err := svc.Create(ctx, input("", ""))
+
+		require.NoError(t, err)
+		assert.Equal(t, DefaultCondition, inv.Condition())
+		defaultsRepo.AssertNotCalled(t, "Get", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("returns repository error", func(t *testing.T) {
+		svc, _, defaultsRepo := newCategoryDefaultsTestService(&categoryID)
+		defaultsRepo.On("Get", ctx, workspaceID, categoryID).Return(nil, errors.New("db down")).Once()
+
+		_, err := svc.Create(ctx, input("", ""))
+
+		assert.Error(t, err)
+	})
+}
+
+func TestService_SetCategoryDefaults(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+	categoryID := uuid.New()
+
+	t.Run("saves the defaults", func(t *testing.T) {
+		svc, _, repo := newCategoryDefaultsTestService(nil)
+		condition := ConditionGood
+		defaults := CategoryDefaults{CategoryID: categoryID, Condition: &condition}
+		repo.On("Upsert", ctx, workspaceID, defaults).Return(&defaults, nil).Once()
+
+		saved, err := svc.SetCategoryDefaults(ctx, workspaceID, defaults)
+
+		require.NoError(t, err)
+		assert.Equal(t, ConditionGood, *saved.Condition)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("deletes the defaults when both fields are empty", func(t *testing.T) {
+		svc, _, repo := newCategoryDefaultsTestService(nil)
+		repo.On("Delete", ctx, workspaceID, categoryID).Return(nil).Once()
+
+		saved, err := svc.SetCategoryDefaults(ctx, workspaceID, CategoryDefaults{CategoryID: categoryID})
+
+		require.NoError(t, err)
+		assert.Nil(t, saved.Condition)
+		assert.Nil(t, saved.Status)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("rejects an invalid condition", func(t *testing.T) {
+		svc, _, _ := newCategoryDefaultsTestService(nil)
+		condition := Condition("SHINY")
+
+		_, err := svc.SetCategoryDefaults(ctx, workspaceID, CategoryDefaults{CategoryID: categoryID, Condition: &condition})
+
+		assert.ErrorIs(t, err, shared.ErrInvalidInput)
+	})
+
+	t.Run("rejects an invalid status", func(t *testing.T) {
+		svc, _, _ := newCategoryDefaultsTestService(nil)
+		status := Status("LOST")
+
+		_, err := svc.SetCategoryDefaults(ctx, workspaceID, CategoryDefaults{CategoryID: categoryID, Status: &status})
+
+		assert.ErrorIs(t, err, shared.ErrInvalidInput)
+	})
+}
+
+func TestService_GetCategoryDefaults(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+	categoryID := uuid.New()
+
+	t.Run("empty defaults when the category has none", func(t *testing.T) {
+		svc, _, repo := newCategoryDefaultsTestService(nil)
+		repo.On("Get", ctx, workspaceID, categoryID).Return(nil, shared.ErrNotFound).Once()
+
+		defaults, err := svc.GetCategoryDefaults(ctx, workspaceID, categoryID)
+
+		require.NoError(t, err)
+		assert.Equal(t, categoryID, defaults.CategoryID)
+		assert.Nil(t, defaults.Condition)
+		assert.Nil(t, defaults.Status)
+	})
+
+	t.Run("empty defaults without a repository", func(t *testing.T) {
+		defaults, err := newTestService(new(MockRepository)).GetCategoryDefaults(ctx, workspaceID, categoryID)
+
+		require.NoError(t, err)
+		assert.Nil(t, defaults.Condition)
+	})
+}
@@ -0,0 +1,225 @@
+package inventory
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/google/uuid"
+
+	appMiddleware "github.com/antti/home-warehouse/go-backend/internal/api/middleware"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/movement"
+	"github.com/antti/home-warehouse/go-backend/internal/infra/events"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// EmptyAction is what happens to an inventory entry once consumption has
+// used it up.
+type EmptyAction string
+
+const (
+	// EmptyActionKeep leaves the entry in place at quantity 0.
+	EmptyActionKeep EmptyAction = "keep"
+	// EmptyActionDispose marks the entry DISPOSED. This is the default.
+	EmptyActionDispose EmptyAction = "dispose"
+	// EmptyActionArchive archives the entry, hiding it from listings while
+	// keeping its consumption history.
+	EmptyActionArchive EmptyAction = "archive"
+)
+
+func (a EmptyAction) IsValid() bool {
+	switch a {
+	case EmptyActionKeep, EmptyActionDispose, EmptyActionArchive:
+		return true
+	}
+	return false
+}
+
+// ConsumeReason is the movement reason recorded for consumption, followed by
+// the caller's note when there is one.
+const ConsumeReason = "consumed"
+
+var (
+	// ErrNotConsumable is returned when consuming from an entry that is not
+	// on hand, e.g. ON_LOAN or MISSING.
+	ErrNotConsumable = shared.NewDomainError(shared.ErrConflict, "only AVAILABLE or IN_USE stock can be consumed")
+	// ErrNothingToConsume is returned by ConsumeItem when the item has no
+	// available stock.
+	ErrNothingToConsume = shared.NewDomainError(shared.ErrNotFound, "item has no available stock to consume")
+)
+
+// SetEmptyAction sets what happens to an entry consumed down to zero. Invalid
+// actions are ignored, keeping the default of EmptyActionDispose.
+func (s *Service) SetEmptyAction(action EmptyAction) {
+	if action.IsValid() {
+		s.emptyAction = action
+	}
+}
+
+// ConsumeInventory uses up quantity units of an entry. The consumption is
+// recorded as a movement out of the entry's location with no destination,
+// attributed to userID. When the entry reaches zero it is handled according
+// to the configured EmptyAction.
+func (s *Service) ConsumeInventory(ctx context.Context, workspaceID, inventoryID uuid.UUID, quantity int, note *string, userID *uuid.UUID) (*Inventory, error) {
+	if quantity <= 0 {
+		return nil, shared.NewFieldError(shared.ErrInvalidInput, "quantity", ErrInsufficientQuantity.Error())
+	}
+
+	inv, err := s.GetByID(ctx, inventoryID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if inv.IsArchived() || (inv.Status() != StatusAvailable && inv.Status() != StatusInUse) {
+		return nil, ErrNotConsumable
+	}
+	if quantity > inv.Quantity() {
+		return nil, shared.NewFieldError(shared.ErrInvalidInput, "quantity",
+			fmt.Sprintf("cannot consume %d: only %d left", quantity, inv.Quantity()))
+	}
+
+	if err := inv.UpdateQuantity(inv.Quantity() - quantity); err != nil {
+		return nil, err
+	}
+	empty := inv.Quantity() == 0
+	if empty && s.emptyAction == EmptyActionDispose {
+		if err := inv.UpdateStatus(StatusDisposed); err != nil {
+			return nil, err
+		}
+	}
+
+	err = s.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.Save(ctx, inv); err != nil {
+			return err
+		}
+		if empty && s.emptyAction == EmptyActionArchive {
+			if err := s.repo.Delete(ctx, inv.ID(), workspaceID); err != nil {
+				return err
+			}
+			inv.Archive()
+		}
+		if s.movementSvc == nil {
+			return nil
+		}
+		// The movement row is the only record of the consumption, so
+		// failing to write it fails the whole operation.
+		locationID := inv.LocationID()
+		_, err := s.movementSvc.RecordMovement(ctx, movement.RecordMovementInput{
+			WorkspaceID:     workspaceID,
+			InventoryID:     inv.ID(),
+			FromLocationID:  &locationID,
+			FromContainerID: inv.ContainerID(),
+			Quantity:        quantity,
+			MovedBy:         userID,
+			Reason:          consumeReason(note),
+		})
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return inv, nil
+}
+
+// ConsumeItem consumes from the item's entry that should be used first (see
+// FIFOSuggestion). It does not spread the quantity over several entries.
+func (s *Service) ConsumeItem(ctx context.Context, workspaceID, itemID uuid.UUID, quantity int, note *string, userID *uuid.UUID) (*Inventory, error) {
+	fifo, err := s.FIFOSuggestion(ctx, workspaceID, itemID)
+	if err != nil {
+		return nil, err
+	}
+	next := fifo.Suggested()
+	if next == nil {
+		return nil, ErrNothingToConsume
+	}
+	return s.ConsumeInventory(ctx, workspaceID, next.ID(), quantity, note, userID)
+}
+
+func consumeReason(note *string) *string {
+	reason := ConsumeReason
+	if note != nil && *note != "" {
+		reason += ": " + *note
+	}
+	return &reason
+}
+
+// consumeInventory consumes from one entry.
+func consumeInventory(svc ServiceInterface, broadcaster *events.Broadcaster) func(context.Context, *ConsumeInventoryInput) (*UpdateInventoryOutput, error) {
+	return func(ctx context.Context, input *ConsumeInventoryInput) (*UpdateInventoryOutput, error) {
+		return consume(ctx, svc, broadcaster, input.Body.Quantity, input.Body.Note,
+			func(ctx context.Context, workspaceID uuid.UUID, userID *uuid.UUID) (*Inventory, error) {
+				return svc.ConsumeInventory(ctx, workspaceID, input.ID, input.Body.Quantity, input.Body.Note, userID)
+			})
+	}
+}
+
+// consumeItem consumes from the item's soonest-expiring available entry.
+func consumeItem(svc ServiceInterface, broadcaster *events.Broadcaster) func(context.Context, *ConsumeItemInput) (*UpdateInventoryOutput, error) {
+	return func(ctx context.Context, input *ConsumeItemInput) (*UpdateInventoryOutput, error) {
+		return consume(ctx, svc, broadcaster, input.Body.Quantity, input.Body.Note,
+			func(ctx context.Context, workspaceID uuid.UUID, userID *uuid.UUID) (*Inventory, error) {
+				return svc.ConsumeItem(ctx, workspaceID, input.ItemID, input.Body.Quantity, input.Body.Note, userID)
+			})
+	}
+}
+
+// consume runs a consumption as the signed-in user, publishes
+// inventory.updated and checks the item's minimum stock level. Consumption
+// applies immediately rather than through the approval queue, so it is
+// owner/admin-only.
+func consume(
+	ctx context.Context,
+	svc ServiceInterface,
+	broadcaster *events.Broadcaster,
+	quantity int,
+	note *string,
+	run func(ctx context.Context, workspaceID uuid.UUID, userID *uuid.UUID) (*Inventory, error),
+) (*UpdateInventoryOutput, error) {
+	if role, ok := appMiddleware.GetRole(ctx); !ok || (role != "owner" && role != "admin") {
+		return nil, huma.Error403Forbidden("only workspace owners and admins can consume inventory")
+	}
+
+	var workspaceID uuid.UUID
+	out, err := mutateInventory(ctx, broadcaster, eventInventoryUpdated,
+		func(ctx context.Context, wsID uuid.UUID) (*Inventory, error) {
+			workspaceID = wsID
+			var userID *uuid.UUID
+			if authUser, ok := appMiddleware.GetAuthUser(ctx); ok {
+				userID = &authUser.ID
+			}
+			return run(ctx, wsID, userID)
+		},
+		func(inv *Inventory) map[string]any {
+			data := map[string]any{
+				"id":       inv.ID(),
+				"quantity": inv.Quantity(),
+				"status":   inv.Status(),
+				"consumed": quantity,
+			}
+			if note != nil {
+				data["note"] = *note
+			}
+			return data
+		},
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	publishLowStock(ctx, svc, broadcaster, workspaceID, out.Body.ItemID, out.Body.Quantity+quantity, out.Body.Quantity)
+	return out, nil
+}
+
+type ConsumeInventoryInput struct {
+	ID   uuid.UUID `path:"id"`
+	Body ConsumeBody
+}
+
+type ConsumeItemInput struct {
+	ItemID uuid.UUID `path:"item_id"`
+	Body   ConsumeBody
+}
+
+type ConsumeBody struct {
+	Quantity int     `json:"quantity" minimum:"1" doc:"Units used up; cannot exceed the entry's quantity"`
+	Note     *string `json:"note,omitempty" maxLength:"500" doc:"Optional note stored with the consumption record"`
+}
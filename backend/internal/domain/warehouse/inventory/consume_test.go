@@ -0,0 +1,201 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/movement"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// recordingMovementSvc captures recorded movements.
+type recordingMovementSvc struct {
+	movement.ServiceInterface
+	recorded []movement.RecordMovementInput
+	err      error
+}
+
+func (r *recordingMovementSvc) RecordMovement(_ context.Context, input movement.RecordMovementInput) (*movement.InventoryMovement, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	r.recorded = append(r.recorded, input)
+	return nil, nil
+}
+
+func TestService_ConsumeInventory(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+	itemID := uuid.New()
+	locationID := uuid.New()
+	userID := uuid.New()
+
+	entry := func(quantity int, status Status) *Inventory {
+		return &Inventory{
+			id: uuid.New(), workspaceID: workspaceID, itemID: itemID, locationID: locationID,
+			quantity: quantity, status: status, condition: ConditionGood,
+		}
+	}
+	newService := func(repo *MockRepository, moves *recordingMovementSvc) *Service {
+		itemR, locR, contR := newPermissiveFKRepos()
+		return NewService(repo, moves, itemR, locR, contR)
+	}
+
+	t.Run("decrements and records the consumption", func(t *testing.T) {
+		repo := new(MockRepository)
+		moves := &recordingMovementSvc{}
+		inv := entry(10, StatusAvailable)
+		note := "kitchen"
+		repo.On("FindByID", ctx, inv.ID(), workspaceID).Return(inv, nil)
+		repo.On("Save", ctx, inv).Return(nil)
+
+		got, err := newService(repo, moves).ConsumeInventory(ctx, workspaceID, inv.ID(), 3, &note, &userID)
+
+		require.NoError(t, err)
+		assert.Equal(t, 7, got.Quantity())
+		assert.Equal(t, StatusAvailable, got.Status())
+		require.Len(t, moves.recorded, 1)
+		rec := moves.recorded[0]
+		assert.Equal(t, 3, rec.Quantity)
+		assert.Equal(t, &locationID, rec.FromLocationID)
+		assert.Nil(t, rec.ToLocationID)
+		assert.Equal(t, &userID, rec.MovedBy)
+		assert.Equal(t, "consumed: kitchen", *rec.Reason)
+	})
+
+	t.Run("disposes the entry when used up by default", func(t *testing.T) {
+		repo := new(MockRepository)
+		inv := entry(2, StatusInUse)
+		repo.On("FindByID", ctx, inv.ID(), workspaceID).Return(inv, nil)
+		repo.On("Save", ctx, inv).Return(nil)
+
+		got, err := newService(repo, &recordingMovementSvc{}).ConsumeInventory(ctx, workspaceID, inv.ID(), 2, nil, &userID)
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, got.Quantity())
+		assert.Equal(t, StatusDisposed, got.Status())
+		repo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("archives the entry when configured", func(t *testing.T) {
+		repo := new(MockRepository)
+		inv := entry(2, StatusAvailable)
+		repo.On("FindByID", ctx, inv.ID(), workspaceID).Return(inv, nil)
+		repo.On("Save", ctx, inv).Return(nil)
+		repo.On("Delete", ctx, inv.ID()).Return(nil).Once()
+
+		svc := newService(repo, &recordingMovementSvc{})
+		svc.SetEmptyAction(EmptyActionArchive)
+		got, err := svc.ConsumeInventory(ctx, workspaceID, inv.ID(), 2, nil, &userID)
+
+		require.NoError(t, err)
+		assert.True(t, got.IsArchived())
+		assert.Equal(t, StatusAvailable, got.Status())
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("keeps the empty entry when configured", func(t *testing.T) {
+		repo := new(MockRepository)
+		inv := entry(2, StatusAvailable)
+		repo.On("FindByID", ctx, inv.ID(), workspaceID).Return(inv, nil)
+		repo.On("Save", ctx, inv).Return(nil)
+
+		svc := newService(repo, &recordingMovementSvc{})
+		svc.SetEmptyAction(EmptyActionKeep)
+		got, err := svc.ConsumeInventory(ctx, workspaceID, inv.ID(), 2, nil, &userID)
+
+		require.NoError(t, err)
+		assert.Equal(t, 0, got.Quantity())
+		assert.Equal(t, StatusAvailable, got.Status())
+		assert.False(t, got.IsArchived())
+	})
+
+	t.Run("rejects consuming more than the entry holds", func(t *testing.T) {
+		repo := new(MockRepository)
+		inv := entry(2, StatusAvailable)
+		repo.On("FindByID", ctx, inv.ID(), workspaceID).Return(inv, nil)
+
+		_, err := newService(repo, &recordingMovementSvc{}).ConsumeInventory(ctx, workspaceID, inv.ID(), 3, nil, &userID)
+
+		require.ErrorIs(t, err, shared.ErrInvalidInput)
+		assert.Contains(t, err.Error(), "only 2 left")
+		assert.Equal(t, 2, inv.Quantity())
+		repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects a non-positive quantity", func(t *testing.T) {
+		_, err := newService(new(MockRepository), &recordingMovementSvc{}).ConsumeInventory(ctx, workspaceID, uuid.New(), 0, nil, &userID)
+
+		assert.ErrorIs(t, err, shared.ErrInvalidInput)
+	})
+
+	t.Run("rejects stock that is on loan", func(t *testing.T) {
+		repo := new(MockRepository)
+		inv := entry(5, StatusOnLoan)
+		repo.On("FindByID", ctx, inv.ID(), workspaceID).Return(inv, nil)
+
+		_, err := newService(repo, &recordingMovementSvc{}).ConsumeInventory(ctx, workspaceID, inv.ID(), 1, nil, &userID)
+
+		assert.ErrorIs(t, err, ErrNotConsumable)
+	})
+
+	t.Run("fails when the consumption cannot be recorded", func(t *testing.T) {
+		repo := new(MockRepository)
+		inv := entry(5, StatusAvailable)
+		repo.On("FindByID", ctx, inv.ID(), workspaceID).Return(inv, nil)
+		repo.On("Save", ctx, inv).Return(nil)
+
+		moves := &recordingMovementSvc{err: errors.New("database error")}
+		_, err := newService(repo, moves).ConsumeInventory(ctx, workspaceID, inv.ID(), 1, nil, &userID)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestService_ConsumeItem(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+	itemID := uuid.New()
+	now := time.Now()
+
+	dated := func(quantity, days int) *Inventory {
+		expires := now.AddDate(0, 0, days)
+		return &Inventory{
+			id: uuid.New(), workspaceID: workspaceID, itemID: itemID, locationID: uuid.New(),
+			quantity: quantity, status: StatusAvailable, expirationDate: &expires, createdAt: now,
+		}
+	}
+
+	t.Run("consumes from the soonest-expiring entry", func(t *testing.T) {
+		repo := new(MockRepository)
+		later := dated(5, 20)
+		sooner := dated(5, 2)
+		repo.On("FindAvailable", ctx, workspaceID, itemID, false).Return([]*Inventory{later, sooner}, nil)
+		repo.On("FindByID", ctx, sooner.ID(), workspaceID).Return(sooner, nil)
+		repo.On("Save", ctx, sooner).Return(nil)
+
+		got, err := newTestService(repo).ConsumeItem(ctx, workspaceID, itemID, 1, nil, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, sooner.ID(), got.ID())
+		assert.Equal(t, 4, got.Quantity())
+		assert.Equal(t, 5, later.Quantity())
+	})
+
+	t.Run("returns not found without available stock", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("FindAvailable", ctx, workspaceID, itemID, false).Return([]*Inventory{}, nil)
+
+		_, err := newTestService(repo).ConsumeItem(ctx, workspaceID, itemID, 1, nil, nil)
+
+		assert.ErrorIs(t, err, ErrNothingToConsume)
+		assert.True(t, shared.IsNotFound(err))
+	})
+}
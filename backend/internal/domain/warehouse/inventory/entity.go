@@ -1,6 +1,8 @@
 package inventory
 
 import (
+	"fmt"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
@@ -20,13 +22,18 @@ const (
 	ConditionForRepair Condition = "FOR_REPAIR"
 )
 
+var conditions = []Condition{
+	ConditionNew, ConditionExcellent, ConditionGood, ConditionFair,
+	ConditionPoor, ConditionDamaged, ConditionForRepair,
+}
+
+// Conditions returns every condition, best first.
+func Conditions() []Condition {
+	return slices.Clone(conditions)
+}
+
 func (c Condition) IsValid() bool {
-	switch c {
-	case ConditionNew, ConditionExcellent, ConditionGood, ConditionFair,
-		ConditionPoor, ConditionDamaged, ConditionForRepair:
-		return true
-	}
-	return false
+	return slices.Contains(conditions, c)
 }
 
 type Status string
@@ -41,12 +48,55 @@ const (
 	StatusMissing   Status = "MISSING"
 )
 
+var statuses = []Status{
+	StatusAvailable, StatusInUse, StatusReserved, StatusOnLoan,
+	StatusInTransit, StatusDisposed, StatusMissing,
+}
+
+// Statuses returns every inventory status.
+func Statuses() []Status {
+	return slices.Clone(statuses)
+}
+
 func (s Status) IsValid() bool {
-	switch s {
-	case StatusAvailable, StatusInUse, StatusReserved, StatusOnLoan,
-		StatusInTransit, StatusDisposed, StatusMissing:
+	return slices.Contains(statuses, s)
+}
+
+// statusTransitions lists the statuses reachable from each status. Any
+// non-terminal status can become DISPOSED or MISSING; DISPOSED is terminal;
+// MISSING can only be found (AVAILABLE) or written off (DISPOSED). Loans
+// start from AVAILABLE, or from RESERVED when the workspace counts reserved
+// stock as available (see Settings and loan.Service.Create).
+var statusTransitions = map[Status][]Status{
+	StatusAvailable: {StatusInUse, StatusReserved, StatusOnLoan, StatusInTransit, StatusDisposed, StatusMissing},
+	StatusInUse:     {StatusAvailable, StatusReserved, StatusInTransit, StatusDisposed, StatusMissing},
+	StatusReserved:  {StatusAvailable, StatusInUse, StatusOnLoan, StatusInTransit, StatusDisposed, StatusMissing},
+	StatusOnLoan:    {StatusAvailable, StatusDisposed, StatusMissing},
+	StatusInTransit: {StatusAvailable, StatusInUse, StatusDisposed, StatusMissing},
+	StatusMissing:   {StatusAvailable, StatusDisposed},
+	StatusDisposed:  {},
+}
+
+// NextStatuses returns the statuses an entry in status s may move to, for
+// the UI to offer only valid options. Unknown statuses have none.
+func (s Status) NextStatuses() []Status {
+	next := statusTransitions[s]
+	out := make([]Status, len(next))
+	copy(out, next)
+	return out
+}
+
+// CanTransitionTo reports whether moving from s to next is allowed. Staying
+// in the same status is always allowed (a no-op).
+func (s Status) CanTransitionTo(next Status) bool {
+	if s == next {
 		return true
 	}
+	for _, allowed := range statusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
 	return false
 }
 
@@ -206,15 +256,30 @@ func (inv *Inventory) Update(input UpdateInput) error {
 	return nil
 }
 
+// UpdateStatus moves the entry to status, rejecting moves the status
+// machine does not allow with ErrInvalidTransition.
 func (inv *Inventory) UpdateStatus(status Status) error {
 	if !status.IsValid() {
 		return ErrInvalidStatus
 	}
+	if !inv.status.CanTransitionTo(status) {
+		return fmt.Errorf("%w: cannot change status from %s to %s", ErrInvalidTransition, inv.status, status)
+	}
 	inv.status = status
 	inv.updatedAt = time.Now()
 	return nil
 }
 
+// UpdateCondition sets the entry's condition.
+func (inv *Inventory) UpdateCondition(condition Condition) error {
+	if !condition.IsValid() {
+		return ErrInvalidCondition
+	}
+	inv.condition = condition
+	inv.updatedAt = time.Now()
+	return nil
+}
+
 func (inv *Inventory) UpdateQuantity(quantity int) error {
 	if quantity < 0 {
 		return ErrInsufficientQuantity
@@ -470,6 +470,73 @@ func TestInventory_UpdateStatus(t *testing.T) {
 	}
 }
 
+func TestInventory_UpdateStatus_Transitions(t *testing.T) {
+	all := []inventory.Status{
+		inventory.StatusAvailable,
+		inventory.StatusInUse,
+		inventory.StatusReserved,
+		inventory.StatusOnLoan,
+		inventory.StatusInTransit,
+		inventory.StatusDisposed,
+		inventory.StatusMissing,
+	}
+
+	// Every (from, to) pair not listed here must be rejected, except staying
+	// in the same status which is always a no-op.
+	allowed := map[inventory.Status][]inventory.Status{
+		inventory.StatusAvailable: {inventory.StatusInUse, inventory.StatusReserved, inventory.StatusOnLoan, inventory.StatusInTransit, inventory.StatusDisposed, inventory.StatusMissing},
+		inventory.StatusInUse:     {inventory.StatusAvailable, inventory.StatusReserved, inventory.StatusInTransit, inventory.StatusDisposed, inventory.StatusMissing},
+		inventory.StatusReserved:  {inventory.StatusAvailable, inventory.StatusInUse, inventory.StatusOnLoan, inventory.StatusInTransit, inventory.StatusDisposed, inventory.StatusMissing},
+		inventory.StatusOnLoan:    {inventory.StatusAvailable, inventory.StatusDisposed, inventory.StatusMissing},
+		inventory.StatusInTransit: {inventory.StatusAvailable, inventory.StatusInUse, inventory.StatusDisposed, inventory.StatusMissing},
+		inventory.StatusMissing:   {inventory.StatusAvailable, inventory.StatusDisposed},
+		inventory.StatusDisposed:  {},
+	}
+
+	for _, from := range all {
+		for _, to := range all {
+			want := from == to
+			for _, a := range allowed[from] {
+				if a == to {
+					want = true
+				}
+			}
+
+			t.Run(string(from)+"->"+string(to), func(t *testing.T) {
+				inv, err := inventory.NewInventory(uuid.New(), uuid.New(), uuid.New(), nil, 1,
+					inventory.ConditionGood, from, nil)
+				assert.NoError(t, err)
+
+				assert.Equal(t, want, from.CanTransitionTo(to))
+
+				err = inv.UpdateStatus(to)
+				if want {
+					assert.NoError(t, err)
+					assert.Equal(t, to, inv.Status())
+				} else {
+					assert.ErrorIs(t, err, inventory.ErrInvalidTransition)
+					assert.Contains(t, err.Error(), string(from))
+					assert.Contains(t, err.Error(), string(to))
+					assert.Equal(t, from, inv.Status(), "status must be unchanged after a rejected transition")
+				}
+			})
+		}
+	}
+}
+
+func TestStatus_NextStatuses(t *testing.T) {
+	assert.ElementsMatch(t,
+		[]inventory.Status{inventory.StatusAvailable, inventory.StatusDisposed, inventory.StatusMissing},
+		inventory.StatusOnLoan.NextStatuses())
+	assert.Empty(t, inventory.StatusDisposed.NextStatuses())
+	assert.Empty(t, inventory.Status("INVALID").NextStatuses())
+
+	// The returned slice is a copy; mutating it must not change the machine.
+	next := inventory.StatusMissing.NextStatuses()
+	next[0] = inventory.StatusOnLoan
+	assert.False(t, inventory.StatusMissing.CanTransitionTo(inventory.StatusOnLoan))
+}
+
 func TestInventory_UpdateQuantity(t *testing.T) {
 	workspaceID := uuid.New()
 	itemID := uuid.New()
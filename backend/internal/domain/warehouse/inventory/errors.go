@@ -8,4 +8,10 @@ var (
 	ErrInvalidCondition     = errors.New("invalid condition")
 	ErrInvalidStatus        = errors.New("invalid status")
 	ErrAlreadyOnLoan        = errors.New("inventory is already on loan")
+	// ErrInvalidTransition is returned when a status change is not allowed
+	// from the current status (see statusTransitions).
+	ErrInvalidTransition = errors.New("invalid status transition")
+	// ErrItemPhotoRequired is returned by Create when the workspace requires
+	// items to have a photo before they get inventory (see PhotoRequirement).
+	ErrItemPhotoRequired = errors.New("item needs a photo before inventory can be added")
 )
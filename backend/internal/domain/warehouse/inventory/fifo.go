@@ -0,0 +1,72 @@
+package inventory
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// FIFOSuggestion orders an item's usable stock so the oldest is used first.
+// Records are the available (see Settings.IsAvailable), non-empty entries
+// sorted by soonest expiration; entries without an expiration date come
+// last, oldest first.
+type FIFOSuggestion struct {
+	Records []*Inventory
+	// Expired is true when the suggested record is already past its
+	// expiration date, so it should be checked or discarded before use.
+	Expired bool
+}
+
+// Suggested is the record to take from next, or nil when there is no usable
+// stock.
+func (f *FIFOSuggestion) Suggested() *Inventory {
+	if len(f.Records) == 0 {
+		return nil
+	}
+	return f.Records[0]
+}
+
+// FIFOSuggestion returns the item's usable inventory in the order it should
+// be consumed, for the checkout and consume flows.
+func (s *Service) FIFOSuggestion(ctx context.Context, workspaceID, itemID uuid.UUID) (*FIFOSuggestion, error) {
+	available, err := s.findAvailable(ctx, workspaceID, itemID)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]*Inventory, 0, len(available))
+	for _, inv := range available {
+		if inv.Quantity() > 0 {
+			records = append(records, inv)
+		}
+	}
+	sort.SliceStable(records, func(i, j int) bool {
+		a, b := records[i].ExpirationDate(), records[j].ExpirationDate()
+		switch {
+		case a != nil && b != nil && !a.Equal(*b):
+			return a.Before(*b)
+		case a != nil && b == nil:
+			return true
+		case a == nil && b != nil:
+			return false
+		}
+		return records[i].CreatedAt().Before(records[j].CreatedAt())
+	})
+
+	suggestion := &FIFOSuggestion{Records: records}
+	if next := suggestion.Suggested(); next != nil && next.ExpirationDate() != nil {
+		suggestion.Expired = isExpired(*next.ExpirationDate(), s.now())
+	}
+	return suggestion, nil
+}
+
+// isExpired reports whether an expiration date has passed. Expiration dates
+// are calendar days, so stock expiring today is still usable.
+func isExpired(expiration, now time.Time) bool {
+	y, m, d := now.Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	y, m, d = expiration.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC).Before(today)
+}
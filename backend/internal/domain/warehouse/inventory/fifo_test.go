@@ -0,0 +1,118 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestService_FIFOSuggestion(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+	itemID := uuid.New()
+	now := time.Date(2026, 3, 10, 15, 0, 0, 0, time.UTC)
+	day := func(offset int) *time.Time {
+		d := time.Date(2026, 3, 10+offset, 0, 0, 0, 0, time.UTC)
+		return &d
+	}
+	record := func(quantity int, expiration *time.Time, createdAt time.Time) *Inventory {
+		return &Inventory{
+			id: uuid.New(), workspaceID: workspaceID, itemID: itemID,
+			status: StatusAvailable, quantity: quantity,
+			expirationDate: expiration, createdAt: createdAt,
+		}
+	}
+	newService := func(repo *MockRepository) *Service {
+		svc := newTestService(repo)
+		svc.now = func() time.Time { return now }
+		return svc
+	}
+
+	t.Run("orders by soonest expiration with undated stock last", func(t *testing.T) {
+		repo := new(MockRepository)
+		undatedOld := record(1, nil, now.Add(-48*time.Hour))
+		undatedNew := record(1, nil, now.Add(-24*time.Hour))
+		later := record(3, day(30), now)
+		sooner := record(2, day(5), now)
+		repo.On("FindAvailable", ctx, workspaceID, itemID, false).
+			Return([]*Inventory{undatedNew, later, undatedOld, sooner}, nil)
+
+		fifo, err := newService(repo).FIFOSuggestion(ctx, workspaceID, itemID)
+
+		require.NoError(t, err)
+		assert.Equal(t, []*Inventory{sooner, later, undatedOld, undatedNew}, fifo.Records)
+		assert.Same(t, sooner, fifo.Suggested())
+		assert.False(t, fifo.Expired)
+	})
+
+	t.Run("breaks expiration ties by age", func(t *testing.T) {
+		repo := new(MockRepository)
+		newer := record(1, day(3), now)
+		older := record(1, day(3), now.Add(-time.Hour))
+		repo.On("FindAvailable", ctx, workspaceID, itemID, false).Return([]*Inventory{newer, older}, nil)
+
+		fifo, err := newService(repo).FIFOSuggestion(ctx, workspaceID, itemID)
+
+		require.NoError(t, err)
+		assert.Equal(t, []*Inventory{older, newer}, fifo.Records)
+	})
+
+	t.Run("skips empty records", func(t *testing.T) {
+		repo := new(MockRepository)
+		empty := record(0, day(1), now)
+		stocked := record(4, day(9), now)
+		repo.On("FindAvailable", ctx, workspaceID, itemID, false).Return([]*Inventory{empty, stocked}, nil)
+
+		fifo, err := newService(repo).FIFOSuggestion(ctx, workspaceID, itemID)
+
+		require.NoError(t, err)
+		assert.Equal(t, []*Inventory{stocked}, fifo.Records)
+	})
+
+	t.Run("flags an already expired suggestion", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("FindAvailable", ctx, workspaceID, itemID, false).
+			Return([]*Inventory{record(1, day(4), now), record(1, day(-1), now)}, nil)
+
+		fifo, err := newService(repo).FIFOSuggestion(ctx, workspaceID, itemID)
+
+		require.NoError(t, err)
+		assert.True(t, fifo.Expired)
+	})
+
+	t.Run("stock expiring today is not expired", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("FindAvailable", ctx, workspaceID, itemID, false).Return([]*Inventory{record(1, day(0), now)}, nil)
+
+		fifo, err := newService(repo).FIFOSuggestion(ctx, workspaceID, itemID)
+
+		require.NoError(t, err)
+		assert.False(t, fifo.Expired)
+	})
+
+	t.Run("no usable stock", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("FindAvailable", ctx, workspaceID, itemID, false).Return([]*Inventory{}, nil)
+
+		fifo, err := newService(repo).FIFOSuggestion(ctx, workspaceID, itemID)
+
+		require.NoError(t, err)
+		assert.Nil(t, fifo.Suggested())
+		assert.False(t, fifo.Expired)
+	})
+
+	t.Run("repository error", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("FindAvailable", ctx, workspaceID, itemID, false).Return(nil, errors.New("database error"))
+
+		fifo, err := newService(repo).FIFOSuggestion(ctx, workspaceID, itemID)
+
+		assert.Error(t, err)
+		assert.Nil(t, fifo)
+	})
+}
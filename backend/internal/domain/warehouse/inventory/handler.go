@@ -3,6 +3,10 @@ package inventory
 import (
 	"context"
 	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/danielgtaylor/huma/v2"
@@ -17,7 +21,10 @@ const (
 	eventInventoryUpdated = "inventory.updated"
 	// A move is audited as a MOVE action rather than a generic UPDATE (see
 	// activity.NewEventTap), so it needs its own event name.
-	eventInventoryMoved      = "inventory.moved"
+	eventInventoryMoved = "inventory.moved"
+	// Published when a quantity change takes an item below its minimum stock
+	// level; outgoing webhooks subscribe to it.
+	eventItemLowStock        = "item.low_stock"
 	msgFailedToListInventory = "failed to list inventory"
 	msgInventoryNotFound     = "inventory not found"
 )
@@ -54,6 +61,9 @@ func registerActionRoutes(api huma.API, svc ServiceInterface, broadcaster *event
 	huma.Post(api, "/inventory/{id}/move", moveInventory(svc, broadcaster))
 	huma.Post(api, "/inventory/{id}/archive", archiveInventory(svc, broadcaster))
 	huma.Post(api, "/inventory/{id}/restore", restoreInventory(svc, broadcaster))
+	huma.Post(api, "/inventory/bulk-status", bulkUpdateStatus(svc, broadcaster))
+	huma.Post(api, "/inventory/{id}/consume", consumeInventory(svc, broadcaster))
+	huma.Post(api, "/inventory/by-item/{item_id}/consume", consumeItem(svc, broadcaster))
 }
 
 // publishInventoryEvent emits an SSE event for an inventory mutation, mirroring
@@ -82,6 +92,17 @@ func listInventory(svc ServiceInterface) func(context.Context, *ListInventoryInp
 			return nil, huma.Error401Unauthorized(err.Error())
 		}
 
+		filters := ListFilters{
+			ItemID:     parseOptionalUUID(input.ItemID),
+			LocationID: parseOptionalUUID(input.LocationID),
+		}
+		if filters.Conditions, err = parseConditionFilter(input.Condition); err != nil {
+			return nil, huma.Error400BadRequest(err.Error())
+		}
+		if filters.Statuses, err = parseStatusFilter(input.Status); err != nil {
+			return nil, huma.Error400BadRequest(err.Error())
+		}
+
 		// If a valid container filter is supplied, delegate to the container-scoped
 		// path. A malformed UUID is silently treated as no filter (mirrors the
 		// item handler's category_id parsing).
@@ -92,6 +113,9 @@ func listInventory(svc ServiceInterface) func(context.Context, *ListInventoryInp
 					return nil, huma.Error500InternalServerError(msgFailedToListInventory)
 				}
 
+				// A container holds few entries, so the other filters are
+				// applied here rather than in the query.
+				items = slices.DeleteFunc(items, func(inv *Inventory) bool { return !filters.Matches(inv) })
 				responses := toInventoryResponses(items)
 				return &ListInventoryOutput{
 					Body: InventoryListResponse{
@@ -104,15 +128,42 @@ func listInventory(svc ServiceInterface) func(context.Context, *ListInventoryInp
 			}
 		}
 
-		pagination := shared.Pagination{Page: input.Page, PageSize: input.Limit}
-		inventories, total, err := svc.List(ctx, workspaceID, pagination)
+		expandItem, expandLocation, err := parseListExpand(input.Expand)
 		if err != nil {
-			return nil, huma.Error500InternalServerError(msgFailedToListInventory)
+			return nil, huma.Error400BadRequest(err.Error())
 		}
 
-		responses := make([]InventoryResponse, len(inventories))
-		for i, inv := range inventories {
-			responses[i] = toInventoryResponse(inv)
+		pagination := shared.Pagination{Page: input.Page, PageSize: input.Limit}
+
+		var responses []InventoryResponse
+		var total int
+		if expandItem || expandLocation {
+			rows, n, err := svc.ListWithItemDetails(ctx, workspaceID, pagination, filters)
+			if err != nil {
+				return nil, huma.Error500InternalServerError(msgFailedToListInventory)
+			}
+			responses = make([]InventoryResponse, len(rows))
+			for i, row := range rows {
+				responses[i] = toInventoryResponse(row.Inventory)
+				if expandItem {
+					responses[i].Item = &InventoryItemSummary{
+						Name:  row.ItemName,
+						Brand: row.ItemBrand,
+						SKU:   row.ItemSKU,
+					}
+				}
+				if expandLocation {
+					responses[i].Location = &InventoryLocationSummary{Name: row.LocationName}
+				}
+			}
+			total = n
+		} else {
+			inventories, n, err := svc.List(ctx, workspaceID, pagination, filters)
+			if err != nil {
+				return nil, huma.Error500InternalServerError(msgFailedToListInventory)
+			}
+			responses = toInventoryResponses(inventories)
+			total = n
 		}
 
 		return &ListInventoryOutput{
@@ -126,6 +177,78 @@ func listInventory(svc ServiceInterface) func(context.Context, *ListInventoryInp
 	}
 }
 
+// parseListExpand reads the list endpoint's comma-separated expand parameter.
+func parseListExpand(expand string) (item, location bool, err error) {
+	if expand == "" {
+		return false, false, nil
+	}
+	for _, part := range strings.Split(expand, ",") {
+		switch strings.TrimSpace(part) {
+		case "item":
+			item = true
+		case "location":
+			location = true
+		case "":
+		default:
+			return false, false, fmt.Errorf("unknown expand value %q (allowed: item, location)", strings.TrimSpace(part))
+		}
+	}
+	return item, location, nil
+}
+
+// parseConditionFilter reads a multi-valued condition filter. Values may be
+// repeated or comma-separated.
+func parseConditionFilter(values []string) ([]Condition, error) {
+	var conditions []Condition
+	for _, v := range splitFilterValues(values) {
+		c := Condition(v)
+		if !c.IsValid() {
+			return nil, fmt.Errorf("unknown condition %q", v)
+		}
+		conditions = append(conditions, c)
+	}
+	return conditions, nil
+}
+
+// parseStatusFilter reads a multi-valued status filter. Values may be
+// repeated or comma-separated.
+func parseStatusFilter(values []string) ([]Status, error) {
+	var statuses []Status
+	for _, v := range splitFilterValues(values) {
+		st := Status(v)
+		if !st.IsValid() {
+			return nil, fmt.Errorf("unknown status %q", v)
+		}
+		statuses = append(statuses, st)
+	}
+	return statuses, nil
+}
+
+func splitFilterValues(values []string) []string {
+	var out []string
+	for _, v := range values {
+		for _, part := range strings.Split(v, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				out = append(out, part)
+			}
+		}
+	}
+	return out
+}
+
+// parseOptionalUUID returns nil for an empty or malformed value, matching how
+// container_id is treated.
+func parseOptionalUUID(value string) *uuid.UUID {
+	if value == "" {
+		return nil
+	}
+	id, err := uuid.Parse(value)
+	if err != nil {
+		return nil
+	}
+	return &id
+}
+
 // getInventory returns a single inventory entry by ID.
 func getInventory(svc ServiceInterface) func(context.Context, *GetInventoryInput) (*GetInventoryOutput, error) {
 	return func(ctx context.Context, input *GetInventoryInput) (*GetInventoryOutput, error) {
@@ -142,7 +265,12 @@ func getInventory(svc ServiceInterface) func(context.Context, *GetInventoryInput
 			return nil, huma.Error500InternalServerError("failed to get inventory")
 		}
 
-		return &GetInventoryOutput{Body: toInventoryResponse(inv)}, nil
+		resp := toInventoryResponse(inv)
+		etag := appMiddleware.ETag(resp)
+		if err := appMiddleware.NotModified(input.IfNoneMatch, etag); err != nil {
+			return nil, err
+		}
+		return &GetInventoryOutput{ETag: etag, Body: resp}, nil
 	}
 }
 
@@ -159,7 +287,15 @@ func listInventoryByItem(svc ServiceInterface) func(context.Context, *GetByItemI
 			return nil, huma.Error500InternalServerError(msgFailedToListInventory)
 		}
 
-		return &ListInventoryOutput{Body: InventoryListResponse{Items: toInventoryResponses(items)}}, nil
+		fifo, err := svc.FIFOSuggestion(ctx, workspaceID, input.ItemID)
+		if err != nil {
+			return nil, huma.Error500InternalServerError(msgFailedToListInventory)
+		}
+
+		return &ListInventoryOutput{Body: InventoryListResponse{
+			Items: toInventoryResponses(items),
+			FIFO:  toFIFOSuggestionResponse(fifo),
+		}}, nil
 	}
 }
 
@@ -361,6 +497,9 @@ func mutateInventory(
 		if errors.Is(err, ErrInventoryNotFound) {
 			return nil, huma.Error404NotFound(msgInventoryNotFound)
 		}
+		if errors.Is(err, ErrInvalidTransition) {
+			return nil, huma.Error409Conflict(err.Error())
+		}
 		return nil, appMiddleware.MapDomainError(err)
 	}
 
@@ -383,18 +522,66 @@ func updateInventoryStatus(svc ServiceInterface, broadcaster *events.Broadcaster
 	}
 }
 
-// updateInventoryQuantity updates an inventory entry's quantity.
+// updateInventoryQuantity updates an inventory entry's quantity, and publishes
+// item.low_stock when the change drops the item below its minimum stock level.
 func updateInventoryQuantity(svc ServiceInterface, broadcaster *events.Broadcaster) func(context.Context, *UpdateQuantityInput) (*UpdateInventoryOutput, error) {
 	return func(ctx context.Context, input *UpdateQuantityInput) (*UpdateInventoryOutput, error) {
-		return mutateInventory(ctx, broadcaster, eventInventoryUpdated,
-			func(ctx context.Context, workspaceID uuid.UUID) (*Inventory, error) {
-				return svc.UpdateQuantity(ctx, input.ID, workspaceID, input.Body.Quantity)
+		var workspaceID uuid.UUID
+		previousQuantity := 0
+		out, err := mutateInventory(ctx, broadcaster, eventInventoryUpdated,
+			func(ctx context.Context, wsID uuid.UUID) (*Inventory, error) {
+				workspaceID = wsID
+				current, err := svc.GetByID(ctx, input.ID, wsID)
+				if err != nil {
+					return nil, err
+				}
+				previousQuantity = current.Quantity()
+				return svc.UpdateQuantity(ctx, input.ID, wsID, input.Body.Quantity)
 			},
 			func(inv *Inventory) map[string]any {
 				return map[string]any{"id": inv.ID(), "quantity": inv.Quantity()}
 			},
 		)
+		if err != nil {
+			return nil, err
+		}
+
+		publishLowStock(ctx, svc, broadcaster, workspaceID, out.Body.ItemID, previousQuantity, out.Body.Quantity)
+		return out, nil
+	}
+}
+
+// publishLowStock emits item.low_stock when a quantity change crossed the
+// item's minimum stock level. Best-effort: the update has already succeeded,
+// so a failed check is logged rather than returned.
+func publishLowStock(ctx context.Context, svc ServiceInterface, broadcaster *events.Broadcaster, workspaceID, itemID uuid.UUID, previousQuantity, newQuantity int) {
+	if broadcaster == nil {
+		return
+	}
+	alert, err := svc.CheckLowStock(ctx, workspaceID, itemID, previousQuantity, newQuantity)
+	if err != nil {
+		log.Printf("Failed to check low stock for item %s: %v", itemID, err)
+		return
 	}
+	if alert == nil {
+		return
+	}
+
+	var userID uuid.UUID
+	if authUser, ok := appMiddleware.GetAuthUser(ctx); ok {
+		userID = authUser.ID
+	}
+	broadcaster.Publish(workspaceID, events.Event{
+		Type:       eventItemLowStock,
+		EntityID:   alert.ItemID.String(),
+		EntityType: "item",
+		UserID:     userID,
+		Data: map[string]any{
+			"name":            alert.ItemName,
+			"total_quantity":  alert.TotalQuantity,
+			"min_stock_level": alert.MinStockLevel,
+		},
+	})
 }
 
 // moveInventory moves an inventory entry to a new location/container.
@@ -472,6 +659,7 @@ func toInventoryResponse(inv *Inventory) InventoryResponse {
 		Quantity:        inv.Quantity(),
 		Condition:       inv.Condition(),
 		Status:          inv.Status(),
+		NextStatuses:    inv.Status().NextStatuses(),
 		DateAcquired:    inv.DateAcquired(),
 		PurchasePrice:   inv.PurchasePrice(),
 		CurrencyCode:    inv.CurrencyCode(),
@@ -484,19 +672,41 @@ func toInventoryResponse(inv *Inventory) InventoryResponse {
 	}
 }
 
+func toFIFOSuggestionResponse(f *FIFOSuggestion) *FIFOSuggestionResponse {
+	resp := &FIFOSuggestionResponse{
+		Expired: f.Expired,
+		Order:   make([]uuid.UUID, len(f.Records)),
+	}
+	for i, inv := range f.Records {
+		resp.Order[i] = inv.ID()
+	}
+	if next := f.Suggested(); next != nil {
+		id := next.ID()
+		resp.SuggestedID = &id
+	}
+	return resp
+}
+
 // Request/Response types
 
 type ListInventoryInput struct {
-	Page        int    `query:"page" default:"1" minimum:"1"`
-	Limit       int    `query:"limit" default:"50" minimum:"1" maximum:"100"`
-	ContainerID string `query:"container_id,omitempty" doc:"Optional: narrow results to inventory in a specific container (UUID)"`
+	Page        int      `query:"page" default:"1" minimum:"1"`
+	Limit       int      `query:"limit" default:"50" minimum:"1" maximum:"100"`
+	ContainerID string   `query:"container_id,omitempty" doc:"Optional: narrow results to inventory in a specific container (UUID)"`
+	ItemID      string   `query:"item_id,omitempty" doc:"Optional: narrow results to inventory of a specific item (UUID)"`
+	LocationID  string   `query:"location_id,omitempty" doc:"Optional: narrow results to inventory at a specific location (UUID)"`
+	Condition   []string `query:"condition,omitempty,explode" doc:"Optional: only entries in any of these conditions (repeat or comma-separate, e.g. FAIR,POOR)"`
+	Status      []string `query:"status,omitempty,explode" doc:"Optional: only entries with any of these statuses (repeat or comma-separate, e.g. IN_USE,MISSING)"`
+	Expand      string   `query:"expand,omitempty" doc:"Optional: comma-separated related records to embed in each entry (item, location)"`
 }
 
 type GetInventoryInput struct {
-	ID uuid.UUID `path:"id"`
+	ID          uuid.UUID `path:"id"`
+	IfNoneMatch string    `header:"If-None-Match" doc:"ETag from an earlier response; a 304 with no body is returned while it still matches"`
 }
 
 type GetInventoryOutput struct {
+	ETag string `header:"ETag"`
 	Body InventoryResponse
 }
 
@@ -521,6 +731,14 @@ type InventoryListResponse struct {
 	Total      int                 `json:"total"`
 	Page       int                 `json:"page"`
 	TotalPages int                 `json:"total_pages"`
+	// FIFO is only set on the by-item view.
+	FIFO *FIFOSuggestionResponse `json:"fifo,omitempty"`
+}
+
+type FIFOSuggestionResponse struct {
+	SuggestedID *uuid.UUID  `json:"suggested_id,omitempty" doc:"Entry to consume next: the available entry expiring soonest"`
+	Expired     bool        `json:"expired" doc:"True when the suggested entry is already past its expiration date"`
+	Order       []uuid.UUID `json:"order" doc:"Available, non-empty entries in the order they should be used, soonest expiration first"`
 }
 
 type GetTotalQuantityOutput struct {
@@ -542,8 +760,8 @@ type CreateInventoryInput struct {
 		LocationID      uuid.UUID  `json:"location_id" doc:"Location where inventory is stored"`
 		ContainerID     *uuid.UUID `json:"container_id,omitempty" doc:"Optional container ID"`
 		Quantity        int        `json:"quantity" minimum:"1" doc:"Quantity of items"`
-		Condition       Condition  `json:"condition" enum:"NEW,EXCELLENT,GOOD,FAIR,POOR,DAMAGED,FOR_REPAIR" doc:"Item condition"`
-		Status          Status     `json:"status" enum:"AVAILABLE,IN_USE,RESERVED,ON_LOAN,IN_TRANSIT,DISPOSED,MISSING" doc:"Item status"`
+		Condition       Condition  `json:"condition,omitempty" enum:"NEW,EXCELLENT,GOOD,FAIR,POOR,DAMAGED,FOR_REPAIR" doc:"Item condition. Defaults to the item category's default condition, then NEW"`
+		Status          Status     `json:"status,omitempty" enum:"AVAILABLE,IN_USE,RESERVED,ON_LOAN,IN_TRANSIT,DISPOSED,MISSING" doc:"Item status. Defaults to the item category's default status, then AVAILABLE"`
 		DateAcquired    *time.Time `json:"date_acquired,omitempty" doc:"Date item was acquired"`
 		PurchasePrice   *int       `json:"purchase_price,omitempty" doc:"Purchase price in cents"`
 		CurrencyCode    *string    `json:"currency_code,omitempty" maxLength:"3" doc:"ISO currency code (e.g., USD, EUR)"`
@@ -608,6 +826,7 @@ type InventoryResponse struct {
 	Quantity        int        `json:"quantity"`
 	Condition       Condition  `json:"condition"`
 	Status          Status     `json:"status"`
+	NextStatuses    []Status   `json:"next_statuses" doc:"Statuses this entry may move to from its current status"`
 	DateAcquired    *time.Time `json:"date_acquired,omitempty"`
 	PurchasePrice   *int       `json:"purchase_price,omitempty"`
 	CurrencyCode    *string    `json:"currency_code,omitempty"`
@@ -617,6 +836,22 @@ type InventoryResponse struct {
 	IsArchived      bool       `json:"is_archived"`
 	CreatedAt       time.Time  `json:"created_at"`
 	UpdatedAt       time.Time  `json:"updated_at"`
+
+	// Set only on list responses requested with expand=item / expand=location.
+	Item     *InventoryItemSummary     `json:"item,omitempty"`
+	Location *InventoryLocationSummary `json:"location,omitempty"`
+}
+
+// InventoryItemSummary is the item embedded by expand=item.
+type InventoryItemSummary struct {
+	Name  string  `json:"name"`
+	Brand *string `json:"brand,omitempty"`
+	SKU   string  `json:"sku"`
+}
+
+// InventoryLocationSummary is the location embedded by expand=location.
+type InventoryLocationSummary struct {
+	Name string `json:"name"`
 }
 
 // Types for the expiring inventory endpoint.
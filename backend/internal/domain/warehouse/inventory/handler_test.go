@@ -2,6 +2,7 @@ package inventory_test
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"testing"
@@ -10,6 +11,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/inventory"
 	"github.com/antti/home-warehouse/go-backend/internal/shared"
@@ -107,6 +109,14 @@ func (m *MockService) GetTotalQuantity(ctx context.Context, workspaceID, itemID
 	return args.Int(0), args.Error(1)
 }
 
+func (m *MockService) CheckLowStock(ctx context.Context, workspaceID, itemID uuid.UUID, previousQuantity, newQuantity int) (*inventory.LowStockAlert, error) {
+	args := m.Called(ctx, workspaceID, itemID, previousQuantity, newQuantity)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*inventory.LowStockAlert), args.Error(1)
+}
+
 func (m *MockService) ListExpiring(ctx context.Context, workspaceID uuid.UUID, withinDays int) ([]inventory.ExpiringInventory, error) {
 	args := m.Called(ctx, workspaceID, withinDays)
 	if args.Get(0) == nil {
@@ -115,11 +125,165 @@ func (m *MockService) ListExpiring(ctx context.Context, workspaceID uuid.UUID, w
 	return args.Get(0).([]inventory.ExpiringInventory), args.Error(1)
 }
 
-func (m *MockService) List(ctx context.Context, workspaceID uuid.UUID, pagination shared.Pagination) ([]*inventory.Inventory, int, error) {
-	args := m.Called(ctx, workspaceID, pagination)
+func (m *MockService) FIFOSuggestion(ctx context.Context, workspaceID, itemID uuid.UUID) (*inventory.FIFOSuggestion, error) {
+	args := m.Called(ctx, workspaceID, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*inventory.FIFOSuggestion), args.Error(1)
+}
+
+func (m *MockService) ConsumeInventory(ctx context.Context, workspaceID, inventoryID uuid.UUID, quantity int, note *string, userID *uuid.UUID) (*inventory.Inventory, error) {
+	args := m.Called(ctx, workspaceID, inventoryID, quantity, note, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*inventory.Inventory), args.Error(1)
+}
+
+func (m *MockService) ConsumeItem(ctx context.Context, workspaceID, itemID uuid.UUID, quantity int, note *string, userID *uuid.UUID) (*inventory.Inventory, error) {
+	args := m.Called(ctx, workspaceID, itemID, quantity, note, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*inventory.Inventory), args.Error(1)
+}
+
+func (m *MockService) NeedsAttention(ctx context.Context, workspaceID uuid.UUID) (*inventory.NeedsAttention, error) {
+	args := m.Called(ctx, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*inventory.NeedsAttention), args.Error(1)
+}
+
+func (m *MockService) ListStockLevels(ctx context.Context, workspaceID, itemID uuid.UUID) ([]inventory.LocationStockLevel, error) {
+	args := m.Called(ctx, workspaceID, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]inventory.LocationStockLevel), args.Error(1)
+}
+
+func (m *MockService) SetStockLevel(ctx context.Context, workspaceID, itemID, locationID uuid.UUID, minStockLevel int) (*inventory.LocationStockLevel, error) {
+	args := m.Called(ctx, workspaceID, itemID, locationID, minStockLevel)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*inventory.LocationStockLevel), args.Error(1)
+}
+
+func (m *MockService) DeleteStockLevel(ctx context.Context, workspaceID, itemID, locationID uuid.UUID) error {
+	args := m.Called(ctx, workspaceID, itemID, locationID)
+	return args.Error(0)
+}
+
+func (m *MockService) ListLowStock(ctx context.Context, workspaceID uuid.UUID) ([]inventory.LowStockEntry, error) {
+	args := m.Called(ctx, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]inventory.LowStockEntry), args.Error(1)
+}
+
+func (m *MockService) BulkUpdateStatus(ctx context.Context, workspaceID uuid.UUID, input inventory.BulkStatusInput) ([]inventory.BulkStatusResult, error) {
+	args := m.Called(ctx, workspaceID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]inventory.BulkStatusResult), args.Error(1)
+}
+
+func (m *MockService) List(ctx context.Context, workspaceID uuid.UUID, pagination shared.Pagination, filters inventory.ListFilters) ([]*inventory.Inventory, int, error) {
+	args := m.Called(ctx, workspaceID, pagination, filters)
 	return args.Get(0).([]*inventory.Inventory), args.Int(1), args.Error(2)
 }
 
+func (m *MockService) ListWithItemDetails(ctx context.Context, workspaceID uuid.UUID, pagination shared.Pagination, filters inventory.ListFilters) ([]*inventory.InventoryWithDetails, int, error) {
+	args := m.Called(ctx, workspaceID, pagination, filters)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]*inventory.InventoryWithDetails), args.Int(1), args.Error(2)
+}
+
+func (m *MockService) GetSettings(ctx context.Context, workspaceID uuid.UUID) (*inventory.Settings, error) {
+	args := m.Called(ctx, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*inventory.Settings), args.Error(1)
+}
+
+func (m *MockService) UpdateSettings(ctx context.Context, workspaceID uuid.UUID, settings inventory.Settings) (*inventory.Settings, error) {
+	args := m.Called(ctx, workspaceID, settings)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*inventory.Settings), args.Error(1)
+}
+
+func (m *MockService) ListCategoryDefaults(ctx context.Context, workspaceID uuid.UUID) ([]*inventory.CategoryDefaults, error) {
+	args := m.Called(ctx, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*inventory.CategoryDefaults), args.Error(1)
+}
+
+func (m *MockService) GetCategoryDefaults(ctx context.Context, workspaceID, categoryID uuid.UUID) (*inventory.CategoryDefaults, error) {
+	args := m.Called(ctx, workspaceID, categoryID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*inventory.CategoryDefaults), args.Error(1)
+}
+
+func (m *MockService) SetCategoryDefaults(ctx context.Context, workspaceID uuid.UUID, defaults inventory.CategoryDefaults) (*inventory.CategoryDefaults, error) {
+	args := m.Called(ctx, workspaceID, defaults)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*inventory.CategoryDefaults), args.Error(1)
+}
+
+func (m *MockService) ClearCategoryDefaults(ctx context.Context, workspaceID, categoryID uuid.UUID) error {
+	args := m.Called(ctx, workspaceID, categoryID)
+	return args.Error(0)
+}
+
+func (m *MockService) CreateSnapshot(ctx context.Context, workspaceID uuid.UUID, label string) (*inventory.Snapshot, error) {
+	args := m.Called(ctx, workspaceID, label)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*inventory.Snapshot), args.Error(1)
+}
+
+func (m *MockService) ListSnapshots(ctx context.Context, workspaceID uuid.UUID) ([]*inventory.Snapshot, error) {
+	args := m.Called(ctx, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*inventory.Snapshot), args.Error(1)
+}
+
+func (m *MockService) GetSnapshot(ctx context.Context, workspaceID, id uuid.UUID) (*inventory.Snapshot, error) {
+	args := m.Called(ctx, workspaceID, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*inventory.Snapshot), args.Error(1)
+}
+
+func (m *MockService) DiffSnapshots(ctx context.Context, workspaceID, fromID, toID uuid.UUID) (*inventory.SnapshotDiff, error) {
+	args := m.Called(ctx, workspaceID, fromID, toID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*inventory.SnapshotDiff), args.Error(1)
+}
+
 // Tests
 
 func TestInventoryHandler_Create(t *testing.T) {
@@ -241,6 +405,30 @@ func TestInventoryHandler_Get(t *testing.T) {
 		testutil.AssertStatus(t, rec, http.StatusNotFound)
 		mockSvc.AssertExpectations(t)
 	})
+
+	t.Run("honors If-None-Match", func(t *testing.T) {
+		testInv, _ := inventory.NewInventory(setup.WorkspaceID, uuid.New(), uuid.New(), nil, 5,
+			inventory.ConditionGood, inventory.StatusAvailable, nil)
+		path := fmt.Sprintf("/inventory/%s", testInv.ID())
+
+		mockSvc.On("GetByID", mock.Anything, testInv.ID(), setup.WorkspaceID).Return(testInv, nil).Once()
+		rec := setup.Get(path)
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		etag := rec.Header().Get("ETag")
+		require.NotEmpty(t, etag)
+
+		mockSvc.On("GetByID", mock.Anything, testInv.ID(), setup.WorkspaceID).Return(testInv, nil).Once()
+		rec = setup.GetWithHeader(path, "If-None-Match", etag)
+		testutil.AssertStatus(t, rec, http.StatusNotModified)
+		assert.Empty(t, rec.Body.String())
+
+		require.NoError(t, testInv.UpdateQuantity(4))
+		mockSvc.On("GetByID", mock.Anything, testInv.ID(), setup.WorkspaceID).Return(testInv, nil).Once()
+		rec = setup.GetWithHeader(path, "If-None-Match", etag)
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		assert.NotEqual(t, etag, rec.Header().Get("ETag"))
+		mockSvc.AssertExpectations(t)
+	})
 }
 
 func TestInventoryHandler_Update(t *testing.T) {
@@ -354,6 +542,35 @@ func TestInventoryHandler_UpdateStatus(t *testing.T) {
 
 		testutil.AssertStatus(t, rec, http.StatusUnprocessableEntity)
 	})
+
+	t.Run("returns 409 for a disallowed transition", func(t *testing.T) {
+		invID := uuid.New()
+
+		mockSvc.On("UpdateStatus", mock.Anything, invID, setup.WorkspaceID, inventory.StatusAvailable).
+			Return(nil, fmt.Errorf("%w: cannot change status from DISPOSED to AVAILABLE", inventory.ErrInvalidTransition)).Once()
+
+		body := `{"status":"AVAILABLE"}`
+		rec := setup.Patch(fmt.Sprintf("/inventory/%s/status", invID), body)
+
+		testutil.AssertStatus(t, rec, http.StatusConflict)
+		assert.Contains(t, rec.Body.String(), "cannot change status from DISPOSED to AVAILABLE")
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("response lists the next allowed statuses", func(t *testing.T) {
+		testInv, _ := inventory.NewInventory(setup.WorkspaceID, uuid.New(), uuid.New(), nil, 1,
+			inventory.ConditionGood, inventory.StatusMissing, nil)
+		invID := testInv.ID()
+
+		mockSvc.On("UpdateStatus", mock.Anything, invID, setup.WorkspaceID, inventory.StatusMissing).
+			Return(testInv, nil).Once()
+
+		rec := setup.Patch(fmt.Sprintf("/inventory/%s/status", invID), `{"status":"MISSING"}`)
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		resp := testutil.ParseJSONResponse[inventory.InventoryResponse](t, rec)
+		assert.ElementsMatch(t, []inventory.Status{inventory.StatusAvailable, inventory.StatusDisposed}, resp.NextStatuses)
+	})
 }
 
 func TestInventoryHandler_UpdateQuantity(t *testing.T) {
@@ -376,6 +593,8 @@ func TestInventoryHandler_UpdateQuantity(t *testing.T) {
 		)
 		invID := testInv.ID()
 
+		mockSvc.On("GetByID", mock.Anything, invID, setup.WorkspaceID).
+			Return(testInv, nil).Once()
 		mockSvc.On("UpdateQuantity", mock.Anything, invID, setup.WorkspaceID, 20).
 			Return(testInv, nil).Once()
 
@@ -387,8 +606,20 @@ func TestInventoryHandler_UpdateQuantity(t *testing.T) {
 	})
 
 	t.Run("returns 400 for invalid quantity", func(t *testing.T) {
-		invID := uuid.New()
+		testInv, _ := inventory.NewInventory(
+			setup.WorkspaceID,
+			uuid.New(),
+			uuid.New(),
+			nil,
+			3,
+			inventory.ConditionGood,
+			inventory.StatusAvailable,
+			nil,
+		)
+		invID := testInv.ID()
 
+		mockSvc.On("GetByID", mock.Anything, invID, setup.WorkspaceID).
+			Return(testInv, nil).Once()
 		mockSvc.On("UpdateQuantity", mock.Anything, invID, setup.WorkspaceID, 0).
 			Return(nil, inventory.ErrInsufficientQuantity).Once()
 
@@ -398,6 +629,18 @@ func TestInventoryHandler_UpdateQuantity(t *testing.T) {
 		testutil.AssertStatus(t, rec, http.StatusBadRequest)
 		mockSvc.AssertExpectations(t)
 	})
+
+	t.Run("returns 404 when inventory not found", func(t *testing.T) {
+		invID := uuid.New()
+
+		mockSvc.On("GetByID", mock.Anything, invID, setup.WorkspaceID).
+			Return(nil, inventory.ErrInventoryNotFound).Once()
+
+		rec := setup.Patch(fmt.Sprintf("/inventory/%s/quantity", invID), `{"quantity":5}`)
+
+		testutil.AssertStatus(t, rec, http.StatusNotFound)
+		mockSvc.AssertNotCalled(t, "UpdateQuantity", mock.Anything, invID, mock.Anything, mock.Anything)
+	})
 }
 
 func TestInventoryHandler_Move(t *testing.T) {
@@ -458,12 +701,55 @@ func TestInventoryHandler_ListByItem(t *testing.T) {
 
 		mockSvc.On("ListByItem", mock.Anything, setup.WorkspaceID, itemID).
 			Return(inventories, nil).Once()
+		mockSvc.On("FIFOSuggestion", mock.Anything, setup.WorkspaceID, itemID).
+			Return(&inventory.FIFOSuggestion{Records: inventories}, nil).Once()
 
 		rec := setup.Get(fmt.Sprintf("/inventory/by-item/%s", itemID))
 
 		testutil.AssertStatus(t, rec, http.StatusOK)
 		mockSvc.AssertExpectations(t)
 	})
+
+	t.Run("includes the FIFO suggestion", func(t *testing.T) {
+		itemID := uuid.New()
+		locationID := uuid.New()
+		older, _ := inventory.NewInventory(setup.WorkspaceID, itemID, locationID, nil, 2, inventory.ConditionGood, inventory.StatusAvailable, nil)
+		newer, _ := inventory.NewInventory(setup.WorkspaceID, itemID, locationID, nil, 4, inventory.ConditionGood, inventory.StatusAvailable, nil)
+
+		mockSvc.On("ListByItem", mock.Anything, setup.WorkspaceID, itemID).
+			Return([]*inventory.Inventory{newer, older}, nil).Once()
+		mockSvc.On("FIFOSuggestion", mock.Anything, setup.WorkspaceID, itemID).
+			Return(&inventory.FIFOSuggestion{Records: []*inventory.Inventory{older, newer}, Expired: true}, nil).Once()
+
+		rec := setup.Get(fmt.Sprintf("/inventory/by-item/%s", itemID))
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		var resp inventory.InventoryListResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		require.NotNil(t, resp.FIFO)
+		require.NotNil(t, resp.FIFO.SuggestedID)
+		assert.Equal(t, older.ID(), *resp.FIFO.SuggestedID)
+		assert.True(t, resp.FIFO.Expired)
+		assert.Equal(t, []uuid.UUID{older.ID(), newer.ID()}, resp.FIFO.Order)
+	})
+
+	t.Run("omits the suggestion when there is no usable stock", func(t *testing.T) {
+		itemID := uuid.New()
+
+		mockSvc.On("ListByItem", mock.Anything, setup.WorkspaceID, itemID).
+			Return([]*inventory.Inventory{}, nil).Once()
+		mockSvc.On("FIFOSuggestion", mock.Anything, setup.WorkspaceID, itemID).
+			Return(&inventory.FIFOSuggestion{}, nil).Once()
+
+		rec := setup.Get(fmt.Sprintf("/inventory/by-item/%s", itemID))
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		var resp inventory.InventoryListResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		require.NotNil(t, resp.FIFO)
+		assert.Nil(t, resp.FIFO.SuggestedID)
+		assert.Empty(t, resp.FIFO.Order)
+	})
 }
 
 func TestInventoryHandler_GetAvailable(t *testing.T) {
@@ -505,6 +791,159 @@ func TestInventoryHandler_GetTotalQuantity(t *testing.T) {
 	})
 }
 
+func TestInventoryHandler_NeedsAttention(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	inventory.RegisterAttentionRoutes(setup.API, mockSvc)
+
+	t.Run("returns the categories with a total", func(t *testing.T) {
+		invID := uuid.New()
+		due := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+		borrower := "Bob"
+		mockSvc.On("NeedsAttention", mock.Anything, setup.WorkspaceID).Return(&inventory.NeedsAttention{
+			Groups: []inventory.AttentionGroup{
+				{Category: inventory.AttentionExpiring, Count: 0, Top: []inventory.AttentionEntry{}},
+				{Category: inventory.AttentionOverdueLoans, Count: 7, Top: []inventory.AttentionEntry{
+					{ItemID: uuid.New(), ItemName: "Ladder", InventoryID: &invID, Quantity: 1, Date: &due, BorrowerName: &borrower},
+				}},
+			},
+			ExpiringWithinDays: 30,
+		}, nil).Once()
+
+		rec := setup.Get("/inventory/needs-attention")
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		var body inventory.NeedsAttentionResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, 7, body.Total)
+		assert.Equal(t, 30, body.ExpiringWithinDays)
+		require.Len(t, body.Categories, 2)
+		assert.Empty(t, body.Categories[0].Items)
+		overdue := body.Categories[1]
+		assert.Equal(t, "overdue_loans", overdue.Category)
+		require.Len(t, overdue.Items, 1)
+		assert.Equal(t, "2026-03-01", *overdue.Items[0].Date)
+		assert.Equal(t, &borrower, overdue.Items[0].BorrowerName)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("returns 500 when a report fails", func(t *testing.T) {
+		mockSvc.On("NeedsAttention", mock.Anything, setup.WorkspaceID).Return(nil, assert.AnError).Once()
+
+		rec := setup.Get("/inventory/needs-attention")
+
+		testutil.AssertStatus(t, rec, http.StatusInternalServerError)
+	})
+}
+
+func TestInventoryHandler_StockLevels(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	inventory.RegisterStockLevelRoutes(setup.API, mockSvc)
+
+	t.Run("lists low stock with reorder quantities", func(t *testing.T) {
+		locationID := uuid.New()
+		locationName := "Garage"
+		mockSvc.On("ListLowStock", mock.Anything, setup.WorkspaceID).Return([]inventory.LowStockEntry{
+			{ItemID: uuid.New(), ItemName: "Screws", LocationID: &locationID, LocationName: &locationName, CurrentStock: 3, MinStockLevel: 5},
+		}, nil).Once()
+
+		rec := setup.Get("/inventory/low-stock")
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		var body inventory.LowStockListResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		require.Len(t, body.Items, 1)
+		assert.Equal(t, 2, body.Items[0].ReorderQuantity)
+		assert.Equal(t, &locationID, body.Items[0].LocationID)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("sets a per-location minimum", func(t *testing.T) {
+		itemID := uuid.New()
+		locationID := uuid.New()
+		mockSvc.On("SetStockLevel", mock.Anything, setup.WorkspaceID, itemID, locationID, 5).
+			Return(&inventory.LocationStockLevel{ItemID: itemID, LocationID: locationID, LocationName: "Garage", MinStockLevel: 5}, nil).Once()
+
+		rec := setup.Put(fmt.Sprintf("/items/%s/stock-levels/%s", itemID, locationID), `{"min_stock_level":5}`)
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("rejects a zero minimum", func(t *testing.T) {
+		rec := setup.Put(fmt.Sprintf("/items/%s/stock-levels/%s", uuid.New(), uuid.New()), `{"min_stock_level":0}`)
+
+		testutil.AssertStatus(t, rec, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("returns 404 when removing a missing minimum", func(t *testing.T) {
+		itemID := uuid.New()
+		locationID := uuid.New()
+		mockSvc.On("DeleteStockLevel", mock.Anything, setup.WorkspaceID, itemID, locationID).
+			Return(inventory.ErrStockLevelNotFound).Once()
+
+		rec := setup.Delete(fmt.Sprintf("/items/%s/stock-levels/%s", itemID, locationID))
+
+		testutil.AssertStatus(t, rec, http.StatusNotFound)
+		mockSvc.AssertExpectations(t)
+	})
+}
+
+func TestInventoryHandler_BulkUpdateStatus(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	inventory.RegisterRoutes(setup.API, mockSvc, nil)
+
+	t.Run("reports per-entry results", func(t *testing.T) {
+		updated, _ := inventory.NewInventory(setup.WorkspaceID, uuid.New(), uuid.New(), nil, 1, inventory.ConditionDamaged, inventory.StatusAvailable, nil)
+		missingID := uuid.New()
+
+		mockSvc.On("BulkUpdateStatus", mock.Anything, setup.WorkspaceID, mock.MatchedBy(func(input inventory.BulkStatusInput) bool {
+			return len(input.InventoryIDs) == 2 && input.Status == nil &&
+				input.Condition != nil && *input.Condition == inventory.ConditionDamaged &&
+				input.Note != nil && *input.Note == "flood" && !input.Atomic
+		})).Return([]inventory.BulkStatusResult{
+			{InventoryID: updated.ID(), Inventory: updated},
+			{InventoryID: missingID, Err: shared.ErrNotFound},
+		}, nil).Once()
+
+		body := fmt.Sprintf(`{"inventory_ids":["%s","%s"],"condition":"DAMAGED","note":"flood"}`, updated.ID(), missingID)
+		rec := setup.Post("/inventory/bulk-status", body)
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		var out struct {
+			Results []inventory.BulkStatusItemResponse `json:"results"`
+			Updated int                                `json:"updated"`
+			Failed  int                                `json:"failed"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &out))
+		assert.Equal(t, 1, out.Updated)
+		assert.Equal(t, 1, out.Failed)
+		assert.True(t, out.Results[0].Success)
+		assert.Equal(t, "inventory not found", out.Results[1].Error)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("returns 400 without a status or condition", func(t *testing.T) {
+		mockSvc.On("BulkUpdateStatus", mock.Anything, setup.WorkspaceID, mock.Anything).
+			Return(nil, inventory.ErrBulkStatusNoChange).Once()
+
+		rec := setup.Post("/inventory/bulk-status", fmt.Sprintf(`{"inventory_ids":["%s"]}`, uuid.New()))
+
+		testutil.AssertStatus(t, rec, http.StatusBadRequest)
+	})
+
+	t.Run("requires owner or admin", func(t *testing.T) {
+		setup.SetRole("member")
+		defer setup.SetRole("owner")
+
+		rec := setup.Post("/inventory/bulk-status", fmt.Sprintf(`{"inventory_ids":["%s"],"status":"IN_USE"}`, uuid.New()))
+
+		testutil.AssertStatus(t, rec, http.StatusForbidden)
+	})
+}
+
 func TestInventoryHandler_Archive(t *testing.T) {
 	setup := testutil.NewHandlerTestSetup()
 	mockSvc := new(MockService)
@@ -550,7 +989,7 @@ func TestInventoryHandler_List(t *testing.T) {
 
 		mockSvc.On("List", mock.Anything, setup.WorkspaceID, mock.MatchedBy(func(p shared.Pagination) bool {
 			return p.Page == 1 && p.PageSize == 50
-		})).Return([]*inventory.Inventory{inv1, inv2}, 2, nil).Once()
+		}), inventory.ListFilters{}).Return([]*inventory.Inventory{inv1, inv2}, 2, nil).Once()
 
 		rec := setup.Get("/inventory")
 
@@ -566,7 +1005,7 @@ func TestInventoryHandler_List(t *testing.T) {
 
 		mockSvc.On("List", mock.Anything, setup.WorkspaceID, mock.MatchedBy(func(p shared.Pagination) bool {
 			return p.Page == 2 && p.PageSize == 10
-		})).Return([]*inventory.Inventory{inv1}, 25, nil).Once()
+		}), inventory.ListFilters{}).Return([]*inventory.Inventory{inv1}, 25, nil).Once()
 
 		rec := setup.Get("/inventory?page=2&limit=10")
 
@@ -575,7 +1014,7 @@ func TestInventoryHandler_List(t *testing.T) {
 	})
 
 	t.Run("returns empty list when no inventory found", func(t *testing.T) {
-		mockSvc.On("List", mock.Anything, setup.WorkspaceID, mock.Anything).
+		mockSvc.On("List", mock.Anything, setup.WorkspaceID, mock.Anything, mock.Anything).
 			Return([]*inventory.Inventory{}, 0, nil).Once()
 
 		rec := setup.Get("/inventory")
@@ -587,7 +1026,7 @@ func TestInventoryHandler_List(t *testing.T) {
 	t.Run("handles page beyond total pages", func(t *testing.T) {
 		mockSvc.On("List", mock.Anything, setup.WorkspaceID, mock.MatchedBy(func(p shared.Pagination) bool {
 			return p.Page == 999
-		})).Return([]*inventory.Inventory{}, 10, nil).Once()
+		}), mock.Anything).Return([]*inventory.Inventory{}, 10, nil).Once()
 
 		rec := setup.Get("/inventory?page=999")
 
@@ -617,7 +1056,7 @@ func TestInventoryHandler_List(t *testing.T) {
 	})
 
 	t.Run("returns 500 on service error", func(t *testing.T) {
-		mockSvc.On("List", mock.Anything, setup.WorkspaceID, mock.Anything).
+		mockSvc.On("List", mock.Anything, setup.WorkspaceID, mock.Anything, mock.Anything).
 			Return([]*inventory.Inventory{}, 0, fmt.Errorf("database error")).Once()
 
 		rec := setup.Get("/inventory")
@@ -650,10 +1089,135 @@ func TestInventoryHandler_List(t *testing.T) {
 		testutil.AssertStatus(t, rec, http.StatusOK)
 		// The container path must NOT invoke the unfiltered List path.
 		localMock.AssertExpectations(t)
-		localMock.AssertNotCalled(t, "List", mock.Anything, mock.Anything, mock.Anything)
+		localMock.AssertNotCalled(t, "List", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("passes item and location filters", func(t *testing.T) {
+		itemID := uuid.New()
+		locationID := uuid.New()
+
+		mockSvc.On("List", mock.Anything, setup.WorkspaceID, mock.Anything, inventory.ListFilters{
+			ItemID:     &itemID,
+			LocationID: &locationID,
+		}).Return([]*inventory.Inventory{}, 0, nil).Once()
+
+		rec := setup.Get(fmt.Sprintf("/inventory?item_id=%s&location_id=%s", itemID, locationID))
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("expand embeds item and location details", func(t *testing.T) {
+		itemID := uuid.New()
+		locationID := uuid.New()
+		brand := "Makita"
+		inv, _ := inventory.NewInventory(setup.WorkspaceID, itemID, locationID, nil, 2, inventory.ConditionGood, inventory.StatusAvailable, nil)
+
+		mockSvc.On("ListWithItemDetails", mock.Anything, setup.WorkspaceID, mock.Anything, inventory.ListFilters{}).
+			Return([]*inventory.InventoryWithDetails{{
+				Inventory:    inv,
+				ItemName:     "Drill",
+				ItemBrand:    &brand,
+				ItemSKU:      "DRL-1",
+				LocationName: "Garage",
+			}}, 1, nil).Once()
+
+		rec := setup.Get("/inventory?expand=item,location")
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		resp := testutil.ParseJSONResponse[inventory.InventoryListResponse](t, rec)
+		require.Len(t, resp.Items, 1)
+		require.NotNil(t, resp.Items[0].Item)
+		assert.Equal(t, "Drill", resp.Items[0].Item.Name)
+		assert.Equal(t, "Makita", *resp.Items[0].Item.Brand)
+		assert.Equal(t, "DRL-1", resp.Items[0].Item.SKU)
+		require.NotNil(t, resp.Items[0].Location)
+		assert.Equal(t, "Garage", resp.Items[0].Location.Name)
+		assert.Equal(t, 1, resp.Total)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("expand=item omits location", func(t *testing.T) {
+		inv, _ := inventory.NewInventory(setup.WorkspaceID, uuid.New(), uuid.New(), nil, 1, inventory.ConditionGood, inventory.StatusAvailable, nil)
+
+		mockSvc.On("ListWithItemDetails", mock.Anything, setup.WorkspaceID, mock.Anything, mock.Anything).
+			Return([]*inventory.InventoryWithDetails{{Inventory: inv, ItemName: "Saw", LocationName: "Shed"}}, 1, nil).Once()
+
+		rec := setup.Get("/inventory?expand=item")
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		resp := testutil.ParseJSONResponse[inventory.InventoryListResponse](t, rec)
+		require.Len(t, resp.Items, 1)
+		assert.NotNil(t, resp.Items[0].Item)
+		assert.Nil(t, resp.Items[0].Location)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("passes condition and status filters", func(t *testing.T) {
+		mockSvc.On("List", mock.Anything, setup.WorkspaceID, mock.Anything, inventory.ListFilters{
+			Conditions: []inventory.Condition{inventory.ConditionForRepair, inventory.ConditionDamaged},
+			Statuses:   []inventory.Status{inventory.StatusInUse},
+		}).Return([]*inventory.Inventory{}, 0, nil).Once()
+
+		rec := setup.Get("/inventory?condition=FOR_REPAIR&condition=DAMAGED&status=IN_USE")
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("accepts comma-separated condition values", func(t *testing.T) {
+		mockSvc.On("List", mock.Anything, setup.WorkspaceID, mock.Anything, inventory.ListFilters{
+			Conditions: []inventory.Condition{inventory.ConditionFair, inventory.ConditionPoor},
+		}).Return([]*inventory.Inventory{}, 0, nil).Once()
+
+		rec := setup.Get("/inventory?condition=FAIR,POOR")
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("returns 400 for unknown condition", func(t *testing.T) {
+		rec := setup.Get("/inventory?condition=BROKEN")
+
+		testutil.AssertStatus(t, rec, http.StatusBadRequest)
+	})
+
+	t.Run("returns 400 for unknown status", func(t *testing.T) {
+		rec := setup.Get("/inventory?status=AVAILABLE,LOST")
+
+		testutil.AssertStatus(t, rec, http.StatusBadRequest)
+	})
+
+	t.Run("returns 400 for unknown expand value", func(t *testing.T) {
+		rec := setup.Get("/inventory?expand=item,owner")
+
+		testutil.AssertStatus(t, rec, http.StatusBadRequest)
 	})
 }
 
+func TestInventoryHandler_List_ContainerWithStatusFilter(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	inventory.RegisterRoutes(setup.API, mockSvc, nil)
+
+	containerID := uuid.New()
+	locationID := uuid.New()
+	available, _ := inventory.NewInventory(setup.WorkspaceID, uuid.New(), locationID, &containerID, 1, inventory.ConditionGood, inventory.StatusAvailable, nil)
+	missing, _ := inventory.NewInventory(setup.WorkspaceID, uuid.New(), locationID, &containerID, 1, inventory.ConditionGood, inventory.StatusMissing, nil)
+
+	mockSvc.On("ListByContainer", mock.Anything, setup.WorkspaceID, containerID).
+		Return([]*inventory.Inventory{available, missing}, nil).Once()
+
+	rec := setup.Get(fmt.Sprintf("/inventory?container_id=%s&status=MISSING", containerID))
+
+	testutil.AssertStatus(t, rec, http.StatusOK)
+	resp := testutil.ParseJSONResponse[inventory.InventoryListResponse](t, rec)
+	require.Len(t, resp.Items, 1)
+	assert.Equal(t, missing.ID(), resp.Items[0].ID)
+	assert.Equal(t, 1, resp.Total)
+	mockSvc.AssertExpectations(t)
+}
+
 // Event Publishing Tests
 
 func TestInventoryHandler_Create_PublishesEvent(t *testing.T) {
@@ -817,8 +1381,12 @@ func TestInventoryHandler_UpdateQuantity_PublishesEvent(t *testing.T) {
 	)
 	invID := testInv.ID()
 
+	mockSvc.On("GetByID", mock.Anything, invID, setup.WorkspaceID).
+		Return(testInv, nil).Once()
 	mockSvc.On("UpdateQuantity", mock.Anything, invID, setup.WorkspaceID, 25).
 		Return(testInv, nil).Once()
+	mockSvc.On("CheckLowStock", mock.Anything, setup.WorkspaceID, itemID, 25, 25).
+		Return(nil, nil).Once()
 
 	body := `{"quantity":25}`
 	rec := setup.Patch(fmt.Sprintf("/inventory/%s/quantity", invID), body)
@@ -840,6 +1408,45 @@ func TestInventoryHandler_UpdateQuantity_PublishesEvent(t *testing.T) {
 	assert.Equal(t, 25, event.Data["quantity"])
 }
 
+func TestInventoryHandler_UpdateQuantity_PublishesLowStock(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	capture := testutil.NewEventCapture(setup.WorkspaceID, setup.UserID)
+	capture.Start()
+	defer capture.Stop()
+
+	inventory.RegisterRoutes(setup.API, mockSvc, capture.GetBroadcaster())
+
+	itemID := uuid.New()
+	inv, _ := inventory.NewInventory(setup.WorkspaceID, itemID, uuid.New(), nil, 10,
+		inventory.ConditionGood, inventory.StatusAvailable, nil)
+	invID := inv.ID()
+
+	mockSvc.On("GetByID", mock.Anything, invID, setup.WorkspaceID).Return(inv, nil).Once()
+	mockSvc.On("UpdateQuantity", mock.Anything, invID, setup.WorkspaceID, 2).
+		Run(func(mock.Arguments) { _ = inv.UpdateQuantity(2) }).
+		Return(inv, nil).Once()
+	mockSvc.On("CheckLowStock", mock.Anything, setup.WorkspaceID, itemID, 10, 2).Return(&inventory.LowStockAlert{
+		ItemID:        itemID,
+		ItemName:      "Batteries",
+		TotalQuantity: 2,
+		MinStockLevel: 5,
+	}, nil).Once()
+
+	rec := setup.Patch(fmt.Sprintf("/inventory/%s/quantity", invID), `{"quantity":2}`)
+
+	testutil.AssertStatus(t, rec, http.StatusOK)
+	mockSvc.AssertExpectations(t)
+
+	require.True(t, capture.WaitForEvents(2, 500*time.Millisecond), "update and low-stock events should be published")
+	event := capture.GetLastEvent()
+	assert.Equal(t, "item.low_stock", event.Type)
+	assert.Equal(t, "item", event.EntityType)
+	assert.Equal(t, itemID.String(), event.EntityID)
+	assert.Equal(t, "Batteries", event.Data["name"])
+	assert.Equal(t, 5, event.Data["min_stock_level"])
+}
+
 func TestInventoryHandler_Move_PublishesEvent(t *testing.T) {
 	setup := testutil.NewHandlerTestSetup()
 	mockSvc := new(MockService)
@@ -980,3 +1587,317 @@ func TestInventoryHandler_Create_NilBroadcaster_NoError(t *testing.T) {
 	testutil.AssertStatus(t, rec, http.StatusOK)
 	mockSvc.AssertExpectations(t)
 }
+
+func TestInventoryHandler_Consume(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	capture := testutil.NewEventCapture(setup.WorkspaceID, setup.UserID)
+	capture.Start()
+	defer capture.Stop()
+
+	inventory.RegisterRoutes(setup.API, mockSvc, capture.GetBroadcaster())
+
+	t.Run("consumes from an entry as the signed-in user", func(t *testing.T) {
+		itemID := uuid.New()
+		testInv, _ := inventory.NewInventory(setup.WorkspaceID, itemID, uuid.New(), nil, 4, inventory.ConditionGood, inventory.StatusAvailable, nil)
+		note := "used for repairs"
+
+		mockSvc.On("ConsumeInventory", mock.Anything, setup.WorkspaceID, testInv.ID(), 2, &note, &setup.UserID).
+			Return(testInv, nil).Once()
+		mockSvc.On("CheckLowStock", mock.Anything, setup.WorkspaceID, itemID, 6, 4).
+			Return(nil, nil).Once()
+
+		rec := setup.Post(fmt.Sprintf("/inventory/%s/consume", testInv.ID()), `{"quantity":2,"note":"used for repairs"}`)
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		mockSvc.AssertExpectations(t)
+
+		require.True(t, capture.WaitForEvents(1, 500*time.Millisecond))
+		event := capture.GetLastEvent()
+		assert.Equal(t, "inventory.updated", event.Type)
+		assert.Equal(t, 2, event.Data["consumed"])
+		assert.Equal(t, note, event.Data["note"])
+	})
+
+	t.Run("consumes from an item", func(t *testing.T) {
+		itemID := uuid.New()
+		testInv, _ := inventory.NewInventory(setup.WorkspaceID, itemID, uuid.New(), nil, 1, inventory.ConditionGood, inventory.StatusAvailable, nil)
+
+		mockSvc.On("ConsumeItem", mock.Anything, setup.WorkspaceID, itemID, 1, (*string)(nil), &setup.UserID).
+			Return(testInv, nil).Once()
+		mockSvc.On("CheckLowStock", mock.Anything, setup.WorkspaceID, itemID, 2, 1).
+			Return(nil, nil).Once()
+
+		rec := setup.Post(fmt.Sprintf("/inventory/by-item/%s/consume", itemID), `{"quantity":1}`)
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("returns 400 when consuming more than is left", func(t *testing.T) {
+		invID := uuid.New()
+
+		mockSvc.On("ConsumeInventory", mock.Anything, setup.WorkspaceID, invID, 9, (*string)(nil), &setup.UserID).
+			Return(nil, shared.NewFieldError(shared.ErrInvalidInput, "quantity", "cannot consume 9: only 4 left")).Once()
+
+		rec := setup.Post(fmt.Sprintf("/inventory/%s/consume", invID), `{"quantity":9}`)
+
+		testutil.AssertStatus(t, rec, http.StatusBadRequest)
+	})
+
+	t.Run("returns 409 for stock that is not on hand", func(t *testing.T) {
+		invID := uuid.New()
+
+		mockSvc.On("ConsumeInventory", mock.Anything, setup.WorkspaceID, invID, 1, (*string)(nil), &setup.UserID).
+			Return(nil, inventory.ErrNotConsumable).Once()
+
+		rec := setup.Post(fmt.Sprintf("/inventory/%s/consume", invID), `{"quantity":1}`)
+
+		testutil.AssertStatus(t, rec, http.StatusConflict)
+	})
+
+	t.Run("returns 404 when the item has no available stock", func(t *testing.T) {
+		itemID := uuid.New()
+
+		mockSvc.On("ConsumeItem", mock.Anything, setup.WorkspaceID, itemID, 1, (*string)(nil), &setup.UserID).
+			Return(nil, inventory.ErrNothingToConsume).Once()
+
+		rec := setup.Post(fmt.Sprintf("/inventory/by-item/%s/consume", itemID), `{"quantity":1}`)
+
+		testutil.AssertStatus(t, rec, http.StatusNotFound)
+	})
+
+	t.Run("rejects a zero quantity", func(t *testing.T) {
+		rec := setup.Post(fmt.Sprintf("/inventory/%s/consume", uuid.New()), `{"quantity":0}`)
+
+		testutil.AssertStatus(t, rec, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("requires owner or admin", func(t *testing.T) {
+		setup.SetRole("member")
+		defer setup.SetRole("owner")
+
+		rec := setup.Post(fmt.Sprintf("/inventory/%s/consume", uuid.New()), `{"quantity":1}`)
+		testutil.AssertStatus(t, rec, http.StatusForbidden)
+
+		rec = setup.Post(fmt.Sprintf("/inventory/by-item/%s/consume", uuid.New()), `{"quantity":1}`)
+		testutil.AssertStatus(t, rec, http.StatusForbidden)
+	})
+}
+
+func TestInventoryHandler_Settings(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	inventory.RegisterSettingsRoutes(setup.API, mockSvc)
+
+	t.Run("returns the settings", func(t *testing.T) {
+		mockSvc.On("GetSettings", mock.Anything, setup.WorkspaceID).Return(&inventory.Settings{}, nil).Once()
+
+		rec := setup.Get("/inventory-settings")
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		var body inventory.InventorySettingsResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.False(t, body.ReservedIsAvailable)
+	})
+
+	t.Run("updates the settings", func(t *testing.T) {
+		saved := inventory.Settings{ReservedIsAvailable: true}
+		mockSvc.On("UpdateSettings", mock.Anything, setup.WorkspaceID, saved).Return(&saved, nil).Once()
+
+		rec := setup.Put("/inventory-settings", `{"reserved_is_available":true}`)
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		var body inventory.InventorySettingsResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.True(t, body.ReservedIsAvailable)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("updates the expired status", func(t *testing.T) {
+		disposed := inventory.StatusDisposed
+		saved := inventory.Settings{ExpiredStatus: &disposed}
+		mockSvc.On("UpdateSettings", mock.Anything, setup.WorkspaceID, saved).Return(&saved, nil).Once()
+
+		rec := setup.Put("/inventory-settings", `{"reserved_is_available":false,"expired_status":"DISPOSED"}`)
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		var body inventory.InventorySettingsResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		require.NotNil(t, body.ExpiredStatus)
+		assert.Equal(t, inventory.StatusDisposed, *body.ExpiredStatus)
+	})
+
+	t.Run("rejects an invalid expired status", func(t *testing.T) {
+		inUse := inventory.StatusInUse
+		mockSvc.On("UpdateSettings", mock.Anything, setup.WorkspaceID, inventory.Settings{ExpiredStatus: &inUse}).
+			Return(nil, shared.NewFieldError(shared.ErrInvalidInput, "expired_status", "expired status must be DISPOSED or MISSING")).Once()
+
+		rec := setup.Put("/inventory-settings", `{"reserved_is_available":false,"expired_status":"IN_USE"}`)
+
+		testutil.AssertStatus(t, rec, http.StatusBadRequest)
+	})
+
+	t.Run("members cannot change the settings", func(t *testing.T) {
+		setup.SetRole("member")
+		defer setup.SetRole("owner")
+
+		rec := setup.Put("/inventory-settings", `{"reserved_is_available":true}`)
+
+		testutil.AssertStatus(t, rec, http.StatusForbidden)
+	})
+}
+
+func TestInventoryHandler_CategoryDefaults(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	inventory.RegisterCategoryDefaultsRoutes(setup.API, mockSvc)
+
+	t.Run("returns the defaults of a category", func(t *testing.T) {
+		categoryID := uuid.New()
+		condition := inventory.ConditionGood
+		mockSvc.On("GetCategoryDefaults", mock.Anything, setup.WorkspaceID, categoryID).
+			Return(&inventory.CategoryDefaults{CategoryID: categoryID, Condition: &condition}, nil).Once()
+
+		rec := setup.Get(fmt.Sprintf("/category-inventory-defaults/%s", categoryID))
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		var body inventory.CategoryDefaultsResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		require.NotNil(t, body.Condition)
+		assert.Equal(t, inventory.ConditionGood, *body.Condition)
+		assert.Nil(t, body.Status)
+	})
+
+	t.Run("sets the defaults of a category", func(t *testing.T) {
+		categoryID := uuid.New()
+		condition := inventory.ConditionFair
+		status := inventory.StatusInUse
+		defaults := inventory.CategoryDefaults{CategoryID: categoryID, Condition: &condition, Status: &status}
+		mockSvc.On("SetCategoryDefaults", mock.Anything, setup.WorkspaceID, defaults).Return(&defaults, nil).Once()
+
+		rec := setup.Put(fmt.Sprintf("/category-inventory-defaults/%s", categoryID), `{"condition":"FAIR","status":"IN_USE"}`)
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("returns 404 for a category outside the workspace", func(t *testing.T) {
+		categoryID := uuid.New()
+		mockSvc.On("SetCategoryDefaults", mock.Anything, setup.WorkspaceID, mock.Anything).Return(nil, shared.ErrNotFound).Once()
+
+		rec := setup.Put(fmt.Sprintf("/category-inventory-defaults/%s", categoryID), `{"condition":"FAIR"}`)
+
+		testutil.AssertStatus(t, rec, http.StatusNotFound)
+	})
+
+	t.Run("rejects an unknown condition", func(t *testing.T) {
+		rec := setup.Put(fmt.Sprintf("/category-inventory-defaults/%s", uuid.New()), `{"condition":"SHINY"}`)
+
+		testutil.AssertStatus(t, rec, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("members cannot change the defaults", func(t *testing.T) {
+		setup.SetRole("member")
+		defer setup.SetRole("owner")
+
+		rec := setup.Put(fmt.Sprintf("/category-inventory-defaults/%s", uuid.New()), `{"condition":"FAIR"}`)
+		testutil.AssertStatus(t, rec, http.StatusForbidden)
+
+		rec = setup.Delete(fmt.Sprintf("/category-inventory-defaults/%s", uuid.New()))
+		testutil.AssertStatus(t, rec, http.StatusForbidden)
+	})
+}
+
+func TestInventoryHandler_Snapshots(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	inventory.RegisterSnapshotRoutes(setup.API, mockSvc)
+
+	snapshot := &inventory.Snapshot{
+		ID:            uuid.New(),
+		Label:         "Year end 2025",
+		CurrencyCode:  "EUR",
+		TotalQuantity: 3,
+		TotalValue:    15000,
+		Items: []inventory.SnapshotItem{{
+			ItemID:     uuid.New(),
+			ItemName:   "Drill",
+			Quantity:   3,
+			Conditions: map[inventory.Condition]int{inventory.ConditionGood: 3},
+			TotalValue: 15000,
+		}},
+		CreatedAt: time.Now(),
+	}
+
+	t.Run("takes a snapshot", func(t *testing.T) {
+		mockSvc.On("CreateSnapshot", mock.Anything, setup.WorkspaceID, "Year end 2025").Return(snapshot, nil).Once()
+
+		rec := setup.Post("/inventory-snapshots", `{"label":"Year end 2025"}`)
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		var body inventory.SnapshotResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, snapshot.ID, body.ID)
+		require.Len(t, body.Items, 1)
+		assert.Equal(t, map[string]int{"GOOD": 3}, body.Items[0].Conditions)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("members cannot take snapshots", func(t *testing.T) {
+		setup.SetRole("member")
+		defer setup.SetRole("owner")
+
+		rec := setup.Post("/inventory-snapshots", `{"label":"Year end 2025"}`)
+
+		testutil.AssertStatus(t, rec, http.StatusForbidden)
+	})
+
+	t.Run("members can list snapshots", func(t *testing.T) {
+		setup.SetRole("member")
+		defer setup.SetRole("owner")
+		mockSvc.On("ListSnapshots", mock.Anything, setup.WorkspaceID).Return([]*inventory.Snapshot{snapshot}, nil).Once()
+
+		rec := setup.Get("/inventory-snapshots")
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		var body inventory.SnapshotListResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		require.Len(t, body.Items, 1)
+		assert.Equal(t, "Year end 2025", body.Items[0].Label)
+	})
+
+	t.Run("returns 404 for an unknown snapshot", func(t *testing.T) {
+		id := uuid.New()
+		mockSvc.On("GetSnapshot", mock.Anything, setup.WorkspaceID, id).Return(nil, shared.ErrNotFound).Once()
+
+		rec := setup.Get(fmt.Sprintf("/inventory-snapshots/%s", id))
+
+		testutil.AssertStatus(t, rec, http.StatusNotFound)
+	})
+
+	t.Run("diffs two snapshots", func(t *testing.T) {
+		later := *snapshot
+		later.ID = uuid.New()
+		itemID := snapshot.Items[0].ItemID
+		mockSvc.On("DiffSnapshots", mock.Anything, setup.WorkspaceID, snapshot.ID, later.ID).Return(&inventory.SnapshotDiff{
+			From:           snapshot,
+			To:             &later,
+			QuantityChange: -1,
+			ValueChange:    -5000,
+			Items: []inventory.SnapshotItemDiff{{
+				ItemID: itemID, ItemName: "Drill", FromQuantity: 3, ToQuantity: 2, FromValue: 15000, ToValue: 10000,
+			}},
+		}, nil).Once()
+
+		rec := setup.Get(fmt.Sprintf("/inventory-snapshots/%s/diff/%s", snapshot.ID, later.ID))
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		var body inventory.SnapshotDiffResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, -1, body.QuantityChange)
+		require.Len(t, body.Items, 1)
+		assert.Equal(t, -1, body.Items[0].QuantityChange)
+		assert.Equal(t, int64(-5000), body.Items[0].ValueChange)
+	})
+}
@@ -2,6 +2,7 @@ package inventory
 
 import (
 	"context"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
@@ -12,12 +13,20 @@ import (
 type Repository interface {
 	Save(ctx context.Context, inventory *Inventory) error
 	FindByID(ctx context.Context, id, workspaceID uuid.UUID) (*Inventory, error)
-	List(ctx context.Context, workspaceID uuid.UUID, pagination shared.Pagination) ([]*Inventory, int, error)
+	List(ctx context.Context, workspaceID uuid.UUID, pagination shared.Pagination, filters ListFilters) ([]*Inventory, int, error)
+	// ListWithItemDetails is List with each row's item and location fields
+	// joined in, so callers avoid fetching every item separately.
+	ListWithItemDetails(ctx context.Context, workspaceID uuid.UUID, pagination shared.Pagination, filters ListFilters) ([]*InventoryWithDetails, int, error)
 	FindByItem(ctx context.Context, workspaceID, itemID uuid.UUID) ([]*Inventory, error)
 	FindByLocation(ctx context.Context, workspaceID, locationID uuid.UUID) ([]*Inventory, error)
 	FindByContainer(ctx context.Context, workspaceID, containerID uuid.UUID) ([]*Inventory, error)
-	FindAvailable(ctx context.Context, workspaceID, itemID uuid.UUID) ([]*Inventory, error)
+	// FindAvailable returns the item's AVAILABLE entries, plus its RESERVED
+	// ones when includeReserved is set.
+	FindAvailable(ctx context.Context, workspaceID, itemID uuid.UUID, includeReserved bool) ([]*Inventory, error)
 	GetTotalQuantity(ctx context.Context, workspaceID, itemID uuid.UUID) (int, error)
+	// AddQuantity adds amount to an entry's stored quantity in one statement
+	// and returns the updated entry, so concurrent additions are not lost.
+	AddQuantity(ctx context.Context, id, workspaceID uuid.UUID, amount int) (*Inventory, error)
 	Delete(ctx context.Context, id, workspaceID uuid.UUID) error
 
 	// FindExpiring returns inventory whose expiration_date and/or
@@ -27,6 +36,43 @@ type Repository interface {
 	FindExpiring(ctx context.Context, workspaceID uuid.UUID, withinDays int) ([]ExpiringInventory, error)
 }
 
+// ListFilters narrows an inventory listing. Nil or empty fields match
+// everything; Conditions and Statuses match any of their values.
+type ListFilters struct {
+	ItemID     *uuid.UUID
+	LocationID *uuid.UUID
+	Conditions []Condition
+	Statuses   []Status
+}
+
+// Matches reports whether inv passes the filters, for listings that are not
+// filtered in the query.
+func (f ListFilters) Matches(inv *Inventory) bool {
+	if f.ItemID != nil && inv.ItemID() != *f.ItemID {
+		return false
+	}
+	if f.LocationID != nil && inv.LocationID() != *f.LocationID {
+		return false
+	}
+	if len(f.Conditions) > 0 && !slices.Contains(f.Conditions, inv.Condition()) {
+		return false
+	}
+	if len(f.Statuses) > 0 && !slices.Contains(f.Statuses, inv.Status()) {
+		return false
+	}
+	return true
+}
+
+// InventoryWithDetails is a read model for the inventory list page: an
+// inventory row plus the item and location fields it displays.
+type InventoryWithDetails struct {
+	Inventory    *Inventory
+	ItemName     string
+	ItemBrand    *string
+	ItemSKU      string
+	LocationName string
+}
+
 // Expiring inventory kinds.
 const (
 	// ExpiringKindExpiration marks an entry produced by expiration_date.
@@ -2,8 +2,8 @@ package inventory
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"log/slog"
 	"time"
 
 	"github.com/google/uuid"
@@ -26,13 +26,34 @@ type ServiceInterface interface {
 	Move(ctx context.Context, id, workspaceID, locationID uuid.UUID, containerID *uuid.UUID) (*Inventory, error)
 	Archive(ctx context.Context, id, workspaceID uuid.UUID) error
 	Restore(ctx context.Context, id, workspaceID uuid.UUID) error
-	List(ctx context.Context, workspaceID uuid.UUID, pagination shared.Pagination) ([]*Inventory, int, error)
+	List(ctx context.Context, workspaceID uuid.UUID, pagination shared.Pagination, filters ListFilters) ([]*Inventory, int, error)
+	ListWithItemDetails(ctx context.Context, workspaceID uuid.UUID, pagination shared.Pagination, filters ListFilters) ([]*InventoryWithDetails, int, error)
 	ListByItem(ctx context.Context, workspaceID, itemID uuid.UUID) ([]*Inventory, error)
 	ListByLocation(ctx context.Context, workspaceID, locationID uuid.UUID) ([]*Inventory, error)
 	ListByContainer(ctx context.Context, workspaceID, containerID uuid.UUID) ([]*Inventory, error)
 	GetAvailable(ctx context.Context, workspaceID, itemID uuid.UUID) ([]*Inventory, error)
 	GetTotalQuantity(ctx context.Context, workspaceID, itemID uuid.UUID) (int, error)
+	CheckLowStock(ctx context.Context, workspaceID, itemID uuid.UUID, previousQuantity, newQuantity int) (*LowStockAlert, error)
 	ListExpiring(ctx context.Context, workspaceID uuid.UUID, withinDays int) ([]ExpiringInventory, error)
+	ListStockLevels(ctx context.Context, workspaceID, itemID uuid.UUID) ([]LocationStockLevel, error)
+	SetStockLevel(ctx context.Context, workspaceID, itemID, locationID uuid.UUID, minStockLevel int) (*LocationStockLevel, error)
+	DeleteStockLevel(ctx context.Context, workspaceID, itemID, locationID uuid.UUID) error
+	ListLowStock(ctx context.Context, workspaceID uuid.UUID) ([]LowStockEntry, error)
+	BulkUpdateStatus(ctx context.Context, workspaceID uuid.UUID, input BulkStatusInput) ([]BulkStatusResult, error)
+	FIFOSuggestion(ctx context.Context, workspaceID, itemID uuid.UUID) (*FIFOSuggestion, error)
+	ConsumeInventory(ctx context.Context, workspaceID, inventoryID uuid.UUID, quantity int, note *string, userID *uuid.UUID) (*Inventory, error)
+	ConsumeItem(ctx context.Context, workspaceID, itemID uuid.UUID, quantity int, note *string, userID *uuid.UUID) (*Inventory, error)
+	NeedsAttention(ctx context.Context, workspaceID uuid.UUID) (*NeedsAttention, error)
+	GetSettings(ctx context.Context, workspaceID uuid.UUID) (*Settings, error)
+	UpdateSettings(ctx context.Context, workspaceID uuid.UUID, settings Settings) (*Settings, error)
+	ListCategoryDefaults(ctx context.Context, workspaceID uuid.UUID) ([]*CategoryDefaults, error)
+	GetCategoryDefaults(ctx context.Context, workspaceID, categoryID uuid.UUID) (*CategoryDefaults, error)
+	SetCategoryDefaults(ctx context.Context, workspaceID uuid.UUID, defaults CategoryDefaults) (*CategoryDefaults, error)
+	ClearCategoryDefaults(ctx context.Context, workspaceID, categoryID uuid.UUID) error
+	CreateSnapshot(ctx context.Context, workspaceID uuid.UUID, label string) (*Snapshot, error)
+	ListSnapshots(ctx context.Context, workspaceID uuid.UUID) ([]*Snapshot, error)
+	GetSnapshot(ctx context.Context, workspaceID, id uuid.UUID) (*Snapshot, error)
+	DiffSnapshots(ctx context.Context, workspaceID, fromID, toID uuid.UUID) (*SnapshotDiff, error)
 }
 
 type Service struct {
@@ -42,6 +63,24 @@ type Service struct {
 	locationRepo  location.Repository
 	containerRepo container.Repository
 	idemStore     idempotency.Store
+	stockLevels   StockLevelRepository
+	attention     AttentionRepository
+	settings      SettingsRepository
+	tx            Transactor
+	now           func() time.Time
+	emptyAction   EmptyAction
+
+	// categoryDefaults pre-fill condition and status on Create (see
+	// SetCategoryDefaultsRepository).
+	categoryDefaults CategoryDefaultsRepository
+
+	// photoRule refuses Create for items without a photo (see
+	// SetPhotoRequirement).
+	photoRule PhotoRequirement
+
+	// snapshots stores point-in-time inventory summaries (see
+	// SetSnapshotRepository).
+	snapshots SnapshotRepository
 }
 
 func NewService(repo Repository, movementSvc movement.ServiceInterface, itemRepo item.Repository, locationRepo location.Repository, containerRepo container.Repository) *Service {
@@ -51,6 +90,9 @@ func NewService(repo Repository, movementSvc movement.ServiceInterface, itemRepo
 		itemRepo:      itemRepo,
 		locationRepo:  locationRepo,
 		containerRepo: containerRepo,
+		tx:            noopTransactor{},
+		now:           time.Now,
+		emptyAction:   EmptyActionDispose,
 	}
 }
 
@@ -61,14 +103,26 @@ func (s *Service) SetIdempotencyStore(store idempotency.Store) {
 	s.idemStore = store
 }
 
+// PhotoRequirement reports whether the workspace's photo rule refuses new
+// inventory for an item. Implemented by the itemphoto service.
+type PhotoRequirement interface {
+	BlocksInventory(ctx context.Context, workspaceID, itemID uuid.UUID) (bool, error)
+}
+
+// SetPhotoRequirement wires the workspace require-photo rule into Create.
+// Optional — without it inventory can be added to any item.
+func (s *Service) SetPhotoRequirement(rule PhotoRequirement) {
+	s.photoRule = rule
+}
+
 type CreateInput struct {
 	WorkspaceID     uuid.UUID
 	ItemID          uuid.UUID
 	LocationID      uuid.UUID
 	ContainerID     *uuid.UUID
 	Quantity        int
-	Condition       Condition
-	Status          Status
+	Condition       Condition // Optional - empty uses the item category's default, then DefaultCondition
+	Status          Status    // Optional - empty uses the item category's default, then DefaultStatus
 	DateAcquired    *time.Time
 	PurchasePrice   *int
 	CurrencyCode    *string
@@ -88,12 +142,22 @@ func (s *Service) Create(ctx context.Context, input CreateInput) (*Inventory, er
 	}
 
 	// Validate item belongs to the same workspace
-	if _, err := s.itemRepo.FindByID(ctx, input.ItemID, input.WorkspaceID); err != nil {
+	it, err := s.itemRepo.FindByID(ctx, input.ItemID, input.WorkspaceID)
+	if err != nil {
 		if shared.IsNotFound(err) {
 			return nil, shared.NewFieldError(shared.ErrNotFound, "item_id", fmt.Sprintf("item %s not found in this workspace", input.ItemID))
 		}
 		return nil, err
 	}
+	if s.photoRule != nil {
+		blocked, err := s.photoRule.BlocksInventory(ctx, input.WorkspaceID, input.ItemID)
+		if err != nil {
+			return nil, err
+		}
+		if blocked {
+			return nil, ErrItemPhotoRequired
+		}
+	}
 
 	// Validate location belongs to the same workspace
 	if _, err := s.locationRepo.FindByID(ctx, input.LocationID, input.WorkspaceID); err != nil {
@@ -113,6 +177,10 @@ func (s *Service) Create(ctx context.Context, input CreateInput) (*Inventory, er
 		}
 	}
 
+	if err := s.applyCreateDefaults(ctx, &input, it); err != nil {
+		return nil, err
+	}
+
 	inv, err := NewInventory(
 		input.WorkspaceID,
 		input.ItemID,
@@ -240,6 +308,16 @@ func (s *Service) UpdateQuantity(ctx context.Context, id, workspaceID uuid.UUID,
 	return inv, nil
 }
 
+// AddQuantity adds amount to an entry's quantity. Unlike UpdateQuantity it
+// does not read the entry first, so a concurrent addition cannot be
+// overwritten.
+func (s *Service) AddQuantity(ctx context.Context, id, workspaceID uuid.UUID, amount int) (*Inventory, error) {
+	if amount <= 0 {
+		return nil, ErrInsufficientQuantity
+	}
+	return s.repo.AddQuantity(ctx, id, workspaceID, amount)
+}
+
 func (s *Service) Move(ctx context.Context, id, workspaceID, locationID uuid.UUID, containerID *uuid.UUID) (*Inventory, error) {
 	inv, err := s.GetByID(ctx, id, workspaceID)
 	if err != nil {
@@ -272,12 +350,16 @@ func (s *Service) Move(ctx context.Context, id, workspaceID, locationID uuid.UUI
 		return nil, err
 	}
 
-	if err := s.repo.Save(ctx, inv); err != nil {
-		return nil, err
-	}
-
-	// Record the movement if movementSvc is available
-	if s.movementSvc != nil {
+	// The new location and its movement row are saved together: a move
+	// that cannot be recorded is rolled back rather than leaving the
+	// movement history with a gap.
+	err = s.tx.WithTx(ctx, func(ctx context.Context) error {
+		if err := s.repo.Save(ctx, inv); err != nil {
+			return err
+		}
+		if s.movementSvc == nil {
+			return nil
+		}
 		_, err := s.movementSvc.RecordMovement(ctx, movement.RecordMovementInput{
 			WorkspaceID:     workspaceID,
 			InventoryID:     id,
@@ -289,14 +371,10 @@ func (s *Service) Move(ctx context.Context, id, workspaceID, locationID uuid.UUI
 			MovedBy:         nil, // NOTE: deferred - Inventory Movement Audit Trail (tracked in the backend backlog doc)
 			Reason:          nil,
 		})
-		if err != nil {
-			// Movement tracking is supplementary: log but don't fail the move.
-			// Service has no injected logger, so use the process default.
-			slog.Warn("recording inventory movement failed; move succeeded",
-				"inventory_id", id,
-				"workspace_id", workspaceID,
-				"error", err)
-		}
+		return err
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	return inv, nil
@@ -322,8 +400,12 @@ func (s *Service) Restore(ctx context.Context, id, workspaceID uuid.UUID) error
 	return s.repo.Save(ctx, inv)
 }
 
-func (s *Service) List(ctx context.Context, workspaceID uuid.UUID, pagination shared.Pagination) ([]*Inventory, int, error) {
-	return s.repo.List(ctx, workspaceID, pagination)
+func (s *Service) List(ctx context.Context, workspaceID uuid.UUID, pagination shared.Pagination, filters ListFilters) ([]*Inventory, int, error) {
+	return s.repo.List(ctx, workspaceID, pagination, filters)
+}
+
+func (s *Service) ListWithItemDetails(ctx context.Context, workspaceID uuid.UUID, pagination shared.Pagination, filters ListFilters) ([]*InventoryWithDetails, int, error) {
+	return s.repo.ListWithItemDetails(ctx, workspaceID, pagination, filters)
 }
 
 func (s *Service) ListByItem(ctx context.Context, workspaceID, itemID uuid.UUID) ([]*Inventory, error) {
@@ -338,14 +420,69 @@ func (s *Service) ListByContainer(ctx context.Context, workspaceID, containerID
 	return s.repo.FindByContainer(ctx, workspaceID, containerID)
 }
 
+// GetAvailable returns the item's stock available for loan. RESERVED entries
+// are included only when the workspace settings count them as available.
+// Items that are not loanable have none: their AVAILABLE entries are only
+// present, not lendable.
 func (s *Service) GetAvailable(ctx context.Context, workspaceID, itemID uuid.UUID) ([]*Inventory, error) {
-	return s.repo.FindAvailable(ctx, workspaceID, itemID)
+	it, err := s.itemRepo.FindByID(ctx, itemID, workspaceID)
+	if err != nil && !errors.Is(err, shared.ErrNotFound) {
+		return nil, err
+	}
+	if it != nil && !it.IsLoanable() {
+		return []*Inventory{}, nil
+	}
+	return s.findAvailable(ctx, workspaceID, itemID)
 }
 
 func (s *Service) GetTotalQuantity(ctx context.Context, workspaceID, itemID uuid.UUID) (int, error) {
 	return s.repo.GetTotalQuantity(ctx, workspaceID, itemID)
 }
 
+// LowStockAlert reports an item whose total stock fell below its minimum
+// stock level.
+type LowStockAlert struct {
+	ItemID        uuid.UUID
+	ItemName      string
+	TotalQuantity int
+	MinStockLevel int
+}
+
+// CheckLowStock reports whether changing one of the item's entries from
+// previousQuantity to newQuantity took the item's total stock from at or
+// above its minimum stock level to below it. It returns nil when it did not,
+// including when the item has no minimum, so an alert fires once per drop
+// rather than on every change while stock stays low.
+func (s *Service) CheckLowStock(ctx context.Context, workspaceID, itemID uuid.UUID, previousQuantity, newQuantity int) (*LowStockAlert, error) {
+	if newQuantity >= previousQuantity {
+		return nil, nil
+	}
+
+	it, err := s.itemRepo.FindByID(ctx, itemID, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if it == nil || it.MinStockLevel() == 0 {
+		return nil, nil
+	}
+
+	total, err := s.repo.GetTotalQuantity(ctx, workspaceID, itemID)
+	if err != nil {
+		return nil, err
+	}
+	previousTotal := total - newQuantity + previousQuantity
+	if total >= it.MinStockLevel() || previousTotal < it.MinStockLevel() {
+		return nil, nil
+	}
+
+	return &LowStockAlert{
+		ItemID:        itemID,
+		ItemName:      it.Name(),
+		TotalQuantity: total,
+		MinStockLevel: it.MinStockLevel(),
+	}, nil
+}
+
 // ListExpiring returns inventory entries whose expiration date or warranty
 // end date falls within the next withinDays days (lifetime-warranty items
 // excluded from warranty entries).
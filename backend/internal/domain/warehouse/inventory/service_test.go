@@ -3,12 +3,14 @@ package inventory
 import (
 	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/container"
 	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/item"
@@ -73,8 +75,8 @@ func mockSliceErrGuarded[T any](args mock.Arguments) ([]T, error) {
 	return args.Get(0).([]T), args.Error(1)
 }
 
-func (m *MockRepository) FindAvailable(ctx context.Context, workspaceID, itemID uuid.UUID) ([]*Inventory, error) {
-	args := m.Called(ctx, workspaceID, itemID)
+func (m *MockRepository) FindAvailable(ctx context.Context, workspaceID, itemID uuid.UUID, includeReserved bool) ([]*Inventory, error) {
+	args := m.Called(ctx, workspaceID, itemID, includeReserved)
 	return mockSliceErrGuarded[*Inventory](args)
 }
 
@@ -83,19 +85,35 @@ func (m *MockRepository) GetTotalQuantity(ctx context.Context, workspaceID, item
 	return args.Int(0), args.Error(1)
 }
 
+func (m *MockRepository) AddQuantity(ctx context.Context, id, workspaceID uuid.UUID, amount int) (*Inventory, error) {
+	args := m.Called(ctx, id, workspaceID, amount)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Inventory), args.Error(1)
+}
+
 func (m *MockRepository) Delete(ctx context.Context, id, workspaceID uuid.UUID) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
-func (m *MockRepository) List(ctx context.Context, workspaceID uuid.UUID, pagination shared.Pagination) ([]*Inventory, int, error) {
-	args := m.Called(ctx, workspaceID, pagination)
+func (m *MockRepository) List(ctx context.Context, workspaceID uuid.UUID, pagination shared.Pagination, filters ListFilters) ([]*Inventory, int, error) {
+	args := m.Called(ctx, workspaceID, pagination, filters)
 	if args.Get(0) == nil {
 		return nil, args.Int(1), args.Error(2)
 	}
 	return args.Get(0).([]*Inventory), args.Int(1), args.Error(2)
 }
 
+func (m *MockRepository) ListWithItemDetails(ctx context.Context, workspaceID uuid.UUID, pagination shared.Pagination, filters ListFilters) ([]*InventoryWithDetails, int, error) {
+	args := m.Called(ctx, workspaceID, pagination, filters)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]*InventoryWithDetails), args.Int(1), args.Error(2)
+}
+
 // mockItemRepo is a permissive mock that returns a valid item for any FindByID call.
 type mockItemRepo struct{ mock.Mock }
 
@@ -107,14 +125,17 @@ func (m *mockItemRepo) FindByID(ctx context.Context, id, wsID uuid.UUID) (*item.
 	}
 	return args.Get(0).(*item.Item), args.Error(1)
 }
+func (m *mockItemRepo) FindByIDs(ctx context.Context, wsID uuid.UUID, ids []uuid.UUID) ([]*item.Item, error) {
+	return nil, nil
+}
 func (m *mockItemRepo) FindBySKU(ctx context.Context, wsID uuid.UUID, sku string) (*item.Item, error) {
 	return nil, nil
 }
 func (m *mockItemRepo) FindByShortCode(ctx context.Context, wsID uuid.UUID, sc string) (*item.Item, error) {
 	return nil, nil
 }
-func (m *mockItemRepo) FindByBarcode(ctx context.Context, wsID uuid.UUID, bc string) (*item.Item, error) {
-	return nil, nil
+func (m *mockItemRepo) FindByBarcode(ctx context.Context, wsID uuid.UUID, bc string) (*item.Item, *item.Identifier, error) {
+	return nil, nil, nil
 }
 func (m *mockItemRepo) FindByWorkspace(ctx context.Context, wsID uuid.UUID, p shared.Pagination) ([]*item.Item, int, error) {
 	return nil, 0, nil
@@ -138,11 +159,41 @@ func (m *mockItemRepo) SKUExists(ctx context.Context, wsID uuid.UUID, sku string
 func (m *mockItemRepo) ShortCodeExists(ctx context.Context, sc string) (bool, error) {
 	return false, nil
 }
+func (m *mockItemRepo) ReassignCategory(ctx context.Context, wsID uuid.UUID, ids []uuid.UUID, categoryID *uuid.UUID) ([]item.ReassignedItem, error) {
+	return nil, nil
+}
 func (m *mockItemRepo) AttachLabel(ctx context.Context, itemID, labelID uuid.UUID) error { return nil }
 func (m *mockItemRepo) DetachLabel(ctx context.Context, itemID, labelID uuid.UUID) error { return nil }
 func (m *mockItemRepo) GetItemLabels(ctx context.Context, itemID uuid.UUID) ([]uuid.UUID, error) {
 	return nil, nil
 }
+func (m *mockItemRepo) ListIdentifiers(ctx context.Context, wsID, itemID uuid.UUID) ([]*item.Identifier, error) {
+	return nil, nil
+}
+func (m *mockItemRepo) SaveIdentifier(ctx context.Context, identifier *item.Identifier) error {
+	return nil
+}
+func (m *mockItemRepo) DeleteIdentifier(ctx context.Context, wsID, itemID, identifierID uuid.UUID) error {
+	return nil
+}
+func (m *mockItemRepo) FindSimilar(ctx context.Context, wsID uuid.UUID, threshold float64, limit int) ([]item.SimilarPair, error) {
+	return nil, nil
+}
+func (m *mockItemRepo) CountLoans(ctx context.Context, wsID, itemID uuid.UUID) (int, error) {
+	return 0, nil
+}
+func (m *mockItemRepo) MoveInventory(ctx context.Context, wsID, fromItemID, toItemID uuid.UUID) (int, error) {
+	return 0, nil
+}
+func (m *mockItemRepo) MoveLabels(ctx context.Context, wsID, fromItemID, toItemID uuid.UUID) (int, error) {
+	return 0, nil
+}
+func (m *mockItemRepo) MovePhotos(ctx context.Context, wsID, fromItemID, toItemID uuid.UUID) (int, error) {
+	return 0, nil
+}
+func (m *mockItemRepo) UpdateSKU(ctx context.Context, wsID, itemID uuid.UUID, sku string) error {
+	return nil
+}
 
 // mockLocationRepo is a permissive mock that returns a valid location for any FindByID call.
 type mockLocationRepo struct{ mock.Mock }
@@ -171,6 +222,12 @@ func (m *mockLocationRepo) ShortCodeExists(ctx context.Context, sc string) (bool
 func (m *mockLocationRepo) Search(ctx context.Context, wsID uuid.UUID, q string, l int) ([]*location.Location, error) {
 	return nil, nil
 }
+func (m *mockLocationRepo) MoveInventory(ctx context.Context, wsID, from, to uuid.UUID, keep bool) (int, error) {
+	return 0, nil
+}
+func (m *mockLocationRepo) MoveContainers(ctx context.Context, wsID, from, to uuid.UUID) (int, error) {
+	return 0, nil
+}
 
 // mockContainerRepo is a permissive mock that returns a valid container for any FindByID call.
 type mockContainerRepo struct{ mock.Mock }
@@ -199,6 +256,12 @@ func (m *mockContainerRepo) ShortCodeExists(ctx context.Context, sc string) (boo
 func (m *mockContainerRepo) Search(ctx context.Context, wsID uuid.UUID, q string, l int) ([]*container.Container, error) {
 	return nil, nil
 }
+func (m *mockContainerRepo) FindContents(ctx context.Context, wsID, id uuid.UUID, p shared.Pagination) ([]container.ContentsEntry, error) {
+	return nil, nil
+}
+func (m *mockContainerRepo) GetContentsSummary(ctx context.Context, wsID, id uuid.UUID) (container.ContentsSummary, error) {
+	return container.ContentsSummary{}, nil
+}
 
 // newTestService creates a Service with the given inventory repo and permissive FK validation repos.
 func newTestService(mockRepo *MockRepository) *Service {
@@ -214,7 +277,7 @@ func newPermissiveFKRepos() (*mockItemRepo, *mockLocationRepo, *mockContainerRep
 	contR := new(mockContainerRepo)
 
 	itemR.On("FindByID", mock.Anything, mock.Anything, mock.Anything).Return(
-		item.Reconstruct(uuid.New(), uuid.New(), "SKU", "item", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0, "SC", nil, nil, nil, now, now),
+		item.Reconstruct(uuid.New(), uuid.New(), "SKU", "item", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0, "SC", nil, nil, nil, true, now, now),
 		nil,
 	).Maybe()
 	locR.On("FindByID", mock.Anything, mock.Anything, mock.Anything).Return(
@@ -991,6 +1054,58 @@ func TestService_Create(t *testing.T) {
 	}
 }
 
+// photoRule is a PhotoRequirement that blocks the items in blocked.
+type photoRule struct {
+	blocked map[uuid.UUID]bool
+}
+
+func (r photoRule) BlocksInventory(ctx context.Context, workspaceID, itemID uuid.UUID) (bool, error) {
+	return r.blocked[itemID], nil
+}
+
+func TestService_Create_PhotoRequirement(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+	photoless := uuid.New()
+
+	input := func(itemID uuid.UUID) CreateInput {
+		return CreateInput{WorkspaceID: workspaceID, ItemID: itemID, LocationID: uuid.New(), Quantity: 1}
+	}
+
+	t.Run("refuses inventory for an item the rule blocks", func(t *testing.T) {
+		repo := new(MockRepository)
+		svc := newTestService(repo)
+		svc.SetPhotoRequirement(photoRule{blocked: map[uuid.UUID]bool{photoless: true}})
+
+		_, err := svc.Create(ctx, input(photoless))
+
+		assert.ErrorIs(t, err, ErrItemPhotoRequired)
+		repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("creates inventory for an item the rule allows", func(t *testing.T) {
+		repo := new(MockRepository)
+		svc := newTestService(repo)
+		svc.SetPhotoRequirement(photoRule{blocked: map[uuid.UUID]bool{photoless: true}})
+		repo.On("Save", ctx, mock.AnythingOfType("*inventory.Inventory")).Return(nil).Once()
+
+		_, err := svc.Create(ctx, input(uuid.New()))
+
+		require.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("creates inventory without a rule", func(t *testing.T) {
+		repo := new(MockRepository)
+		svc := newTestService(repo)
+		repo.On("Save", ctx, mock.AnythingOfType("*inventory.Inventory")).Return(nil).Once()
+
+		_, err := svc.Create(ctx, input(photoless))
+
+		require.NoError(t, err)
+	})
+}
+
 func TestService_GetByID(t *testing.T) {
 	ctx := context.Background()
 	invID := uuid.New()
@@ -1372,6 +1487,51 @@ func TestService_UpdateQuantity(t *testing.T) {
 	}
 }
 
+func TestService_AddQuantity(t *testing.T) {
+	ctx := context.Background()
+	invID := uuid.New()
+	workspaceID := uuid.New()
+
+	t.Run("adds in the repository without reading the entry", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		svc := newTestService(mockRepo)
+		updated := &Inventory{id: invID, workspaceID: workspaceID, quantity: 15, condition: ConditionNew, status: StatusAvailable}
+		mockRepo.On("AddQuantity", ctx, invID, workspaceID, 5).Return(updated, nil)
+
+		inv, err := svc.AddQuantity(ctx, invID, workspaceID, 5)
+
+		require.NoError(t, err)
+		assert.Equal(t, 15, inv.Quantity())
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("inventory not found", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		svc := newTestService(mockRepo)
+		mockRepo.On("AddQuantity", ctx, invID, workspaceID, 5).Return(nil, shared.ErrNotFound)
+
+		inv, err := svc.AddQuantity(ctx, invID, workspaceID, 5)
+
+		assert.Nil(t, inv)
+		assert.ErrorIs(t, err, shared.ErrNotFound)
+	})
+
+	for _, amount := range []int{0, -3} {
+		t.Run(fmt.Sprintf("rejects amount %d", amount), func(t *testing.T) {
+			mockRepo := new(MockRepository)
+			svc := newTestService(mockRepo)
+
+			inv, err := svc.AddQuantity(ctx, invID, workspaceID, amount)
+
+			assert.Nil(t, inv)
+			assert.Equal(t, ErrInsufficientQuantity, err)
+			mockRepo.AssertNotCalled(t, "AddQuantity", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
 func TestService_Move(t *testing.T) {
 	ctx := context.Background()
 	invID := uuid.New()
@@ -1679,6 +1839,29 @@ func TestService_ListByItem(t *testing.T) {
 	}
 }
 
+func TestService_ListWithItemDetails(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+	locationID := uuid.New()
+	pagination := shared.Pagination{Page: 1, PageSize: 20}
+	filters := ListFilters{LocationID: &locationID}
+
+	mockRepo := new(MockRepository)
+	svc := newTestService(mockRepo)
+
+	rows := []*InventoryWithDetails{
+		{Inventory: &Inventory{id: uuid.New(), workspaceID: workspaceID, locationID: locationID}, ItemName: "Drill", LocationName: "Garage"},
+	}
+	mockRepo.On("ListWithItemDetails", ctx, workspaceID, pagination, filters).Return(rows, 1, nil)
+
+	got, total, err := svc.ListWithItemDetails(ctx, workspaceID, pagination, filters)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, rows, got)
+	mockRepo.AssertExpectations(t)
+}
+
 func TestService_ListByLocation(t *testing.T) {
 	ctx := context.Background()
 	workspaceID := uuid.New()
@@ -1807,7 +1990,7 @@ func TestService_GetAvailable(t *testing.T) {
 					{id: uuid.New(), workspaceID: workspaceID, itemID: itemID, status: StatusAvailable, quantity: 10},
 					{id: uuid.New(), workspaceID: workspaceID, itemID: itemID, status: StatusAvailable, quantity: 5},
 				}
-				m.On("FindAvailable", ctx, workspaceID, itemID).Return(invs, nil)
+				m.On("FindAvailable", ctx, workspaceID, itemID, false).Return(invs, nil)
 			},
 			expectLen:   2,
 			expectError: false,
@@ -1815,7 +1998,7 @@ func TestService_GetAvailable(t *testing.T) {
 		{
 			testName: "no available items",
 			setupMock: func(m *MockRepository) {
-				m.On("FindAvailable", ctx, workspaceID, itemID).Return([]*Inventory{}, nil)
+				m.On("FindAvailable", ctx, workspaceID, itemID, false).Return([]*Inventory{}, nil)
 			},
 			expectLen:   0,
 			expectError: false,
@@ -1823,7 +2006,7 @@ func TestService_GetAvailable(t *testing.T) {
 		{
 			testName: "repository returns error",
 			setupMock: func(m *MockRepository) {
-				m.On("FindAvailable", ctx, workspaceID, itemID).Return(nil, errors.New("database error"))
+				m.On("FindAvailable", ctx, workspaceID, itemID, false).Return(nil, errors.New("database error"))
 			},
 			expectLen:   0,
 			expectError: true,
@@ -1852,6 +2035,27 @@ func TestService_GetAvailable(t *testing.T) {
 	}
 }
 
+func TestService_GetAvailable_NotLoanable(t *testing.T) {
+	ctx := context.Background()
+	workspaceID, itemID := uuid.New(), uuid.New()
+	now := time.Now()
+
+	repo := new(MockRepository)
+	itemR := new(mockItemRepo)
+	itemR.On("FindByID", ctx, itemID, workspaceID).Return(
+		item.Reconstruct(itemID, workspaceID, "SHELF-1", "Wall shelf", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0, "SHELF1", nil, nil, nil, false, now, now),
+		nil,
+	)
+	_, locR, contR := newPermissiveFKRepos()
+	svc := NewService(repo, nil, itemR, locR, contR)
+
+	invs, err := svc.GetAvailable(ctx, workspaceID, itemID)
+
+	require.NoError(t, err)
+	assert.Empty(t, invs, "a fixed asset has nothing available for loan")
+	repo.AssertNotCalled(t, "FindAvailable", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestService_GetTotalQuantity(t *testing.T) {
 	ctx := context.Background()
 	workspaceID := uuid.New()
@@ -1993,3 +2197,103 @@ func TestService_Move_SaveError(t *testing.T) {
 	assert.Nil(t, result)
 	assert.Equal(t, repoErr, err)
 }
+
+func TestService_CheckLowStock(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+	itemID := uuid.New()
+	now := time.Now()
+
+	newService := func(minStock int, mockRepo *MockRepository) *Service {
+		itemR := new(mockItemRepo)
+		itemR.On("FindByID", mock.Anything, itemID, workspaceID).Return(
+			item.Reconstruct(itemID, workspaceID, "SKU", "Batteries", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, minStock, "SC", nil, nil, nil, true, now, now),
+			nil,
+		)
+		_, locR, contR := newPermissiveFKRepos()
+		return NewService(mockRepo, nil, itemR, locR, contR)
+	}
+
+	t.Run("alerts when total crosses below minimum", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("GetTotalQuantity", ctx, workspaceID, itemID).Return(3, nil)
+
+		alert, err := newService(5, mockRepo).CheckLowStock(ctx, workspaceID, itemID, 8, 3)
+
+		require.NoError(t, err)
+		require.NotNil(t, alert)
+		assert.Equal(t, "Batteries", alert.ItemName)
+		assert.Equal(t, 3, alert.TotalQuantity)
+		assert.Equal(t, 5, alert.MinStockLevel)
+	})
+
+	t.Run("no alert when stock was already low", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockRepo.On("GetTotalQuantity", ctx, workspaceID, itemID).Return(2, nil)
+
+		alert, err := newService(5, mockRepo).CheckLowStock(ctx, workspaceID, itemID, 4, 2)
+
+		require.NoError(t, err)
+		assert.Nil(t, alert)
+	})
+
+	t.Run("no alert when item has no minimum", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+
+		alert, err := newService(0, mockRepo).CheckLowStock(ctx, workspaceID, itemID, 8, 0)
+
+		require.NoError(t, err)
+		assert.Nil(t, alert)
+		mockRepo.AssertNotCalled(t, "GetTotalQuantity", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("no alert when quantity increased", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+
+		alert, err := newService(5, mockRepo).CheckLowStock(ctx, workspaceID, itemID, 1, 2)
+
+		require.NoError(t, err)
+		assert.Nil(t, alert)
+	})
+}
+
+func TestService_Move_RecordsMovementInTransaction(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+	oldLocationID := uuid.New()
+	newLocationID := uuid.New()
+
+	newMove := func(moves *recordingMovementSvc) (*Service, *MockRepository, *countingTx, *Inventory) {
+		mockRepo := new(MockRepository)
+		itemR, locR, contR := newPermissiveFKRepos()
+		svc := NewService(mockRepo, moves, itemR, locR, contR)
+		tx := &countingTx{}
+		svc.SetTransactor(tx)
+		inv := &Inventory{id: uuid.New(), workspaceID: workspaceID, locationID: oldLocationID, quantity: 2, condition: ConditionGood, status: StatusAvailable}
+		mockRepo.On("FindByID", ctx, inv.ID(), workspaceID).Return(inv, nil)
+		mockRepo.On("Save", ctx, inv).Return(nil)
+		return svc, mockRepo, tx, inv
+	}
+
+	t.Run("saves the move and its movement together", func(t *testing.T) {
+		moves := &recordingMovementSvc{}
+		svc, _, tx, inv := newMove(moves)
+
+		_, err := svc.Move(ctx, inv.ID(), workspaceID, newLocationID, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, tx.calls)
+		require.Len(t, moves.recorded, 1)
+		assert.Equal(t, oldLocationID, *moves.recorded[0].FromLocationID)
+		assert.Equal(t, newLocationID, *moves.recorded[0].ToLocationID)
+	})
+
+	t.Run("fails the move when the movement cannot be recorded", func(t *testing.T) {
+		svc, _, _, inv := newMove(&recordingMovementSvc{err: errors.New("db down")})
+
+		result, err := svc.Move(ctx, inv.ID(), workspaceID, newLocationID, nil)
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
@@ -0,0 +1,181 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/google/uuid"
+
+	appMiddleware "github.com/antti/home-warehouse/go-backend/internal/api/middleware"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// Settings is the workspace inventory policy. The zero value is the default.
+type Settings struct {
+	// ReservedIsAvailable counts RESERVED entries as available stock: they
+	// are listed by GetAvailable and FIFOSuggestion and can be loaned out.
+	// Off by default, so reserved stock is unavailable.
+	ReservedIsAvailable bool
+	// ExpiredStatus is the status the daily expiry job moves entries past
+	// their expiration date to (see IsExpiredStatus), unless they are on
+	// loan. Nil (the default) leaves expired entries alone, for workspaces
+	// that track expiry for information only.
+	ExpiredStatus *Status
+}
+
+// Validate checks the settings before they are saved. The expired status
+// must be one every entry the expiry job touches (any status but ON_LOAN and
+// DISPOSED) can move to, which leaves DISPOSED and MISSING.
+func (s Settings) Validate() error {
+	if s.ExpiredStatus == nil {
+		return nil
+	}
+	if !IsExpiredStatus(*s.ExpiredStatus) {
+		return shared.NewFieldError(shared.ErrInvalidInput, "expired_status", "expired status must be DISPOSED or MISSING")
+	}
+	return nil
+}
+
+// IsExpiredStatus reports whether status can be a workspace's expired status:
+// it takes entries out of available stock, and every status the expiry job
+// moves entries from can transition to it.
+func IsExpiredStatus(status Status) bool {
+	if !status.IsValid() || status == StatusAvailable {
+		return false
+	}
+	for _, from := range statuses {
+		if from == StatusOnLoan || from == StatusDisposed {
+			continue
+		}
+		if !from.CanTransitionTo(status) {
+			return false
+		}
+	}
+	return true
+}
+
+// IsAvailable reports whether an entry in status counts as available stock
+// under this policy.
+func (s Settings) IsAvailable(status Status) bool {
+	return status == StatusAvailable || (s.ReservedIsAvailable && status == StatusReserved)
+}
+
+// SettingsRepository persists inventory settings per workspace. Get returns
+// shared.ErrNotFound when the workspace has never saved any.
+type SettingsRepository interface {
+	Get(ctx context.Context, workspaceID uuid.UUID) (*Settings, error)
+	Upsert(ctx context.Context, workspaceID uuid.UUID, settings Settings) (*Settings, error)
+}
+
+// SetSettingsRepository wires inventory settings storage. Without it every
+// workspace uses the default settings and they cannot be changed.
+func (s *Service) SetSettingsRepository(repo SettingsRepository) {
+	s.settings = repo
+}
+
+// GetSettings returns the workspace inventory settings, defaulting to the
+// zero Settings when none have been saved.
+func (s *Service) GetSettings(ctx context.Context, workspaceID uuid.UUID) (*Settings, error) {
+	if s.settings == nil {
+		return &Settings{}, nil
+	}
+	settings, err := s.settings.Get(ctx, workspaceID)
+	if errors.Is(err, shared.ErrNotFound) {
+		return &Settings{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// UpdateSettings replaces the workspace inventory settings.
+func (s *Service) UpdateSettings(ctx context.Context, workspaceID uuid.UUID, settings Settings) (*Settings, error) {
+	if err := settings.Validate(); err != nil {
+		return nil, err
+	}
+	if s.settings == nil {
+		return nil, errors.New("inventory settings storage is not configured")
+	}
+	return s.settings.Upsert(ctx, workspaceID, settings)
+}
+
+// findAvailable loads the item's available stock under the workspace policy.
+func (s *Service) findAvailable(ctx context.Context, workspaceID, itemID uuid.UUID) ([]*Inventory, error) {
+	settings, err := s.GetSettings(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	return s.repo.FindAvailable(ctx, workspaceID, itemID, settings.ReservedIsAvailable)
+}
+
+// RegisterSettingsRoutes registers the workspace inventory settings endpoints.
+func RegisterSettingsRoutes(api huma.API, svc ServiceInterface) {
+	huma.Get(api, "/inventory-settings", getInventorySettings(svc))
+	huma.Put(api, "/inventory-settings", updateInventorySettings(svc))
+}
+
+func getInventorySettings(svc ServiceInterface) func(context.Context, *struct{}) (*InventorySettingsOutput, error) {
+	return func(ctx context.Context, _ *struct{}) (*InventorySettingsOutput, error) {
+		workspaceID, err := appMiddleware.RequireWorkspaceID(ctx)
+		if err != nil {
+			return nil, huma.Error401Unauthorized(err.Error())
+		}
+
+		settings, err := svc.GetSettings(ctx, workspaceID)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to fetch inventory settings")
+		}
+
+		return &InventorySettingsOutput{Body: toInventorySettingsResponse(settings)}, nil
+	}
+}
+
+func updateInventorySettings(svc ServiceInterface) func(context.Context, *UpdateInventorySettingsInput) (*InventorySettingsOutput, error) {
+	return func(ctx context.Context, input *UpdateInventorySettingsInput) (*InventorySettingsOutput, error) {
+		workspaceID, err := appMiddleware.RequireWorkspaceID(ctx)
+		if err != nil {
+			return nil, huma.Error401Unauthorized(err.Error())
+		}
+		if role, ok := appMiddleware.GetRole(ctx); !ok || (role != "owner" && role != "admin") {
+			return nil, huma.Error403Forbidden("only workspace owners and admins can change inventory settings")
+		}
+
+		settings, err := svc.UpdateSettings(ctx, workspaceID, Settings{
+			ReservedIsAvailable: input.Body.ReservedIsAvailable,
+			ExpiredStatus:       input.Body.ExpiredStatus,
+		})
+		if err != nil {
+			if errors.Is(err, shared.ErrInvalidInput) {
+				return nil, appMiddleware.MapDomainError(err)
+			}
+			return nil, huma.Error500InternalServerError("failed to update inventory settings")
+		}
+
+		return &InventorySettingsOutput{Body: toInventorySettingsResponse(settings)}, nil
+	}
+}
+
+func toInventorySettingsResponse(s *Settings) InventorySettingsResponse {
+	return InventorySettingsResponse{
+		ReservedIsAvailable: s.ReservedIsAvailable,
+		ExpiredStatus:       s.ExpiredStatus,
+	}
+}
+
+type UpdateInventorySettingsInput struct {
+	Body struct {
+		ReservedIsAvailable bool    `json:"reserved_is_available" doc:"Count RESERVED stock as available, so it can be loaned out. Defaults to false: reserved stock is unavailable."`
+		ExpiredStatus       *Status `json:"expired_status,omitempty" doc:"Status the daily expiry job moves non-loaned entries past their expiration date to: DISPOSED or MISSING. Omit to leave expired entries alone."`
+	}
+}
+
+type InventorySettingsOutput struct {
+	Body InventorySettingsResponse
+}
+
+type InventorySettingsResponse struct {
+	ReservedIsAvailable bool    `json:"reserved_is_available" doc:"Whether RESERVED stock counts as available"`
+	ExpiredStatus       *Status `json:"expired_status,omitempty" doc:"Status expired entries are moved to daily; absent while the expiry job is off"`
+}
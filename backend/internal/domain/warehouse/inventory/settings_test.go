@@ -0,0 +1,177 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+type mockSettingsRepository struct {
+	mock.Mock
+}
+
+func (m *mockSettingsRepository) Get(ctx context.Context, workspaceID uuid.UUID) (*Settings, error) {
+	args := m.Called(ctx, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Settings), args.Error(1)
+}
+
+func (m *mockSettingsRepository) Upsert(ctx context.Context, workspaceID uuid.UUID, settings Settings) (*Settings, error) {
+	args := m.Called(ctx, workspaceID, settings)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Settings), args.Error(1)
+}
+
+func TestSettings_IsAvailable(t *testing.T) {
+	tests := []struct {
+		status             Status
+		byDefault, relaxed bool
+	}{
+		{StatusAvailable, true, true},
+		{StatusReserved, false, true},
+		{StatusInUse, false, false},
+		{StatusOnLoan, false, false},
+		{StatusInTransit, false, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			assert.Equal(t, tt.byDefault, Settings{}.IsAvailable(tt.status))
+			assert.Equal(t, tt.relaxed, Settings{ReservedIsAvailable: true}.IsAvailable(tt.status))
+		})
+	}
+}
+
+func TestService_GetSettings(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+
+	t.Run("defaults without a repository", func(t *testing.T) {
+		settings, err := newTestService(new(MockRepository)).GetSettings(ctx, workspaceID)
+		require.NoError(t, err)
+		assert.False(t, settings.ReservedIsAvailable)
+	})
+
+	t.Run("defaults when nothing is saved", func(t *testing.T) {
+		repo := new(mockSettingsRepository)
+		svc := newTestService(new(MockRepository))
+		svc.SetSettingsRepository(repo)
+		repo.On("Get", ctx, workspaceID).Return(nil, shared.ErrNotFound)
+
+		settings, err := svc.GetSettings(ctx, workspaceID)
+		require.NoError(t, err)
+		assert.False(t, settings.ReservedIsAvailable)
+	})
+
+	t.Run("returns repository error", func(t *testing.T) {
+		repo := new(mockSettingsRepository)
+		svc := newTestService(new(MockRepository))
+		svc.SetSettingsRepository(repo)
+		repo.On("Get", ctx, workspaceID).Return(nil, errors.New("db down"))
+
+		_, err := svc.GetSettings(ctx, workspaceID)
+		assert.Error(t, err)
+	})
+}
+
+func TestService_UpdateSettings(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+
+	t.Run("saves settings", func(t *testing.T) {
+		repo := new(mockSettingsRepository)
+		svc := newTestService(new(MockRepository))
+		svc.SetSettingsRepository(repo)
+		saved := Settings{ReservedIsAvailable: true}
+		repo.On("Upsert", ctx, workspaceID, saved).Return(&saved, nil)
+
+		settings, err := svc.UpdateSettings(ctx, workspaceID, saved)
+		require.NoError(t, err)
+		assert.True(t, settings.ReservedIsAvailable)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("fails without a repository", func(t *testing.T) {
+		_, err := newTestService(new(MockRepository)).UpdateSettings(ctx, workspaceID, Settings{})
+		assert.Error(t, err)
+	})
+
+	t.Run("rejects an expired status entries cannot all move to", func(t *testing.T) {
+		repo := new(mockSettingsRepository)
+		svc := newTestService(new(MockRepository))
+		svc.SetSettingsRepository(repo)
+		inUse := StatusInUse
+
+		_, err := svc.UpdateSettings(ctx, workspaceID, Settings{ExpiredStatus: &inUse})
+
+		assert.ErrorIs(t, err, shared.ErrInvalidInput)
+		repo.AssertNotCalled(t, "Upsert", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestIsExpiredStatus(t *testing.T) {
+	tests := []struct {
+		status Status
+		want   bool
+	}{
+		{StatusDisposed, true},
+		{StatusMissing, true},
+		{StatusAvailable, false},
+		{StatusInUse, false},
+		{StatusReserved, false},
+		{StatusOnLoan, false},
+		{StatusInTransit, false},
+		{Status("EXPIRED"), false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.status), func(t *testing.T) {
+			assert.Equal(t, tt.want, IsExpiredStatus(tt.status))
+		})
+	}
+}
+
+func TestService_Availability_ReservedPolicy(t *testing.T) {
+	ctx := context.Background()
+	workspaceID, itemID := uuid.New(), uuid.New()
+	available := &Inventory{id: uuid.New(), workspaceID: workspaceID, itemID: itemID, status: StatusAvailable, quantity: 2}
+	reserved := &Inventory{id: uuid.New(), workspaceID: workspaceID, itemID: itemID, status: StatusReserved, quantity: 3}
+
+	tests := []struct {
+		name     string
+		settings Settings
+		stock    []*Inventory
+	}{
+		{"reserved is unavailable by default", Settings{}, []*Inventory{available}},
+		{"reserved counts when the policy allows", Settings{ReservedIsAvailable: true}, []*Inventory{available, reserved}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo, settingsRepo := new(MockRepository), new(mockSettingsRepository)
+			svc := newTestService(repo)
+			svc.SetSettingsRepository(settingsRepo)
+			settingsRepo.On("Get", ctx, workspaceID).Return(&tt.settings, nil)
+			repo.On("FindAvailable", ctx, workspaceID, itemID, tt.settings.ReservedIsAvailable).Return(tt.stock, nil)
+
+			got, err := svc.GetAvailable(ctx, workspaceID, itemID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.stock, got)
+
+			fifo, err := svc.FIFOSuggestion(ctx, workspaceID, itemID)
+			require.NoError(t, err)
+			assert.Len(t, fifo.Records, len(tt.stock))
+			repo.AssertExpectations(t)
+		})
+	}
+}
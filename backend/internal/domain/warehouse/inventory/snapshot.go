@@ -0,0 +1,441 @@
+package inventory
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/google/uuid"
+
+	appMiddleware "github.com/antti/home-warehouse/go-backend/internal/api/middleware"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// MaxSnapshotLabelLength is the longest snapshot label, in characters.
+const MaxSnapshotLabelLength = 200
+
+// SnapshotItem is one item's line in a snapshot: its unarchived inventory
+// summed across locations.
+type SnapshotItem struct {
+	ItemID   uuid.UUID
+	ItemName string
+	SKU      string
+	Quantity int
+	// Conditions is the quantity per condition. Inventory without a
+	// condition counts towards Quantity only.
+	Conditions map[Condition]int
+	// TotalValue is purchase_price * quantity in the snapshot's currency.
+	TotalValue int64
+	// Unvalued counts the inventory rows left out of TotalValue: unpriced,
+	// or priced in a currency with no exchange rate.
+	Unvalued int
+}
+
+// Snapshot is a frozen summary of a workspace's inventory. It is computed
+// when taken and never updated, so it keeps describing the inventory as it
+// was then.
+type Snapshot struct {
+	ID            uuid.UUID
+	Label         string
+	CurrencyCode  string // the workspace base currency when taken
+	TotalQuantity int
+	TotalValue    int64
+	Unvalued      int
+	Items         []SnapshotItem // nil in listings
+	CreatedAt     time.Time
+}
+
+// SnapshotItemDiff is how one item changed between two snapshots. An item
+// missing from a snapshot has zero quantity and value on that side.
+type SnapshotItemDiff struct {
+	ItemID         uuid.UUID
+	ItemName       string
+	SKU            string
+	FromQuantity   int
+	ToQuantity     int
+	FromValue      int64
+	ToValue        int64
+	FromConditions map[Condition]int
+	ToConditions   map[Condition]int
+}
+
+// SnapshotDiff compares two snapshots. Items lists only the items whose
+// quantity, conditions or value changed. Values are compared as recorded,
+// so they only add up when both snapshots have the same currency.
+type SnapshotDiff struct {
+	From           *Snapshot // without Items
+	To             *Snapshot // without Items
+	QuantityChange int
+	ValueChange    int64
+	Items          []SnapshotItemDiff
+}
+
+// SnapshotRepository computes and stores inventory snapshots. Summarize
+// returns the workspace's current per-item summary and base currency; Get
+// returns shared.ErrNotFound for a snapshot outside the workspace.
+type SnapshotRepository interface {
+	Summarize(ctx context.Context, workspaceID uuid.UUID) (currencyCode string, items []SnapshotItem, err error)
+	Create(ctx context.Context, workspaceID uuid.UUID, snapshot Snapshot) (*Snapshot, error)
+	List(ctx context.Context, workspaceID uuid.UUID) ([]*Snapshot, error)
+	Get(ctx context.Context, workspaceID, id uuid.UUID) (*Snapshot, error)
+}
+
+// SetSnapshotRepository wires snapshot storage. Without it no snapshots can
+// be taken and none are listed.
+func (s *Service) SetSnapshotRepository(repo SnapshotRepository) {
+	s.snapshots = repo
+}
+
+// CreateSnapshot records the workspace's current inventory under label.
+func (s *Service) CreateSnapshot(ctx context.Context, workspaceID uuid.UUID, label string) (*Snapshot, error) {
+	label = strings.TrimSpace(label)
+	if label == "" {
+		return nil, shared.NewFieldError(shared.ErrInvalidInput, "label", "label is required")
+	}
+	if utf8.RuneCountInString(label) > MaxSnapshotLabelLength {
+		return nil, shared.NewFieldError(shared.ErrInvalidInput, "label", "label is too long")
+	}
+	if s.snapshots == nil {
+		return nil, errors.New("inventory snapshot storage is not configured")
+	}
+
+	currencyCode, items, err := s.snapshots.Summarize(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshot := Snapshot{
+		ID:           shared.NewUUID(),
+		Label:        label,
+		CurrencyCode: currencyCode,
+		Items:        items,
+	}
+	for _, line := range items {
+		snapshot.TotalQuantity += line.Quantity
+		snapshot.TotalValue += line.TotalValue
+		snapshot.Unvalued += line.Unvalued
+	}
+	return s.snapshots.Create(ctx, workspaceID, snapshot)
+}
+
+// ListSnapshots returns the workspace's snapshots, newest first, without
+// their items.
+func (s *Service) ListSnapshots(ctx context.Context, workspaceID uuid.UUID) ([]*Snapshot, error) {
+	if s.snapshots == nil {
+		return []*Snapshot{}, nil
+	}
+	return s.snapshots.List(ctx, workspaceID)
+}
+
+// GetSnapshot returns a snapshot with its items.
+func (s *Service) GetSnapshot(ctx context.Context, workspaceID, id uuid.UUID) (*Snapshot, error) {
+	if s.snapshots == nil {
+		return nil, shared.ErrNotFound
+	}
+	return s.snapshots.Get(ctx, workspaceID, id)
+}
+
+// DiffSnapshots compares snapshot fromID with the (usually later) snapshot
+// toID.
+func (s *Service) DiffSnapshots(ctx context.Context, workspaceID, fromID, toID uuid.UUID) (*SnapshotDiff, error) {
+	from, err := s.GetSnapshot(ctx, workspaceID, fromID)
+	if err != nil {
+		return nil, err
+	}
+	to, err := s.GetSnapshot(ctx, workspaceID, toID)
+	if err != nil {
+		return nil, err
+	}
+	return diffSnapshots(from, to), nil
+}
+
+func diffSnapshots(from, to *Snapshot) *SnapshotDiff {
+	diff := &SnapshotDiff{
+		QuantityChange: to.TotalQuantity - from.TotalQuantity,
+		ValueChange:    to.TotalValue - from.TotalValue,
+		Items:          []SnapshotItemDiff{},
+	}
+
+	lines := make(map[uuid.UUID]*SnapshotItemDiff)
+	for _, line := range from.Items {
+		lines[line.ItemID] = &SnapshotItemDiff{
+			ItemID:         line.ItemID,
+			ItemName:       line.ItemName,
+			SKU:            line.SKU,
+			FromQuantity:   line.Quantity,
+			FromValue:      line.TotalValue,
+			FromConditions: line.Conditions,
+		}
+	}
+	for _, line := range to.Items {
+		d, ok := lines[line.ItemID]
+		if !ok {
+			d = &SnapshotItemDiff{ItemID: line.ItemID}
+			lines[line.ItemID] = d
+		}
+		d.ItemName = line.ItemName
+		d.SKU = line.SKU
+		d.ToQuantity = line.Quantity
+		d.ToValue = line.TotalValue
+		d.ToConditions = line.Conditions
+	}
+
+	for _, d := range lines {
+		if d.FromQuantity == d.ToQuantity && d.FromValue == d.ToValue && maps.Equal(d.FromConditions, d.ToConditions) {
+			continue
+		}
+		diff.Items = append(diff.Items, *d)
+	}
+	slices.SortFunc(diff.Items, func(a, b SnapshotItemDiff) int {
+		return cmp.Or(strings.Compare(a.ItemName, b.ItemName), strings.Compare(a.ItemID.String(), b.ItemID.String()))
+	})
+
+	fromHeader, toHeader := *from, *to
+	fromHeader.Items, toHeader.Items = nil, nil
+	diff.From, diff.To = &fromHeader, &toHeader
+	return diff
+}
+
+// RegisterSnapshotRoutes registers the inventory snapshot endpoints. Any
+// member can read snapshots; only owners and admins can take them.
+func RegisterSnapshotRoutes(api huma.API, svc ServiceInterface) {
+	huma.Get(api, "/inventory-snapshots", listSnapshots(svc))
+	huma.Post(api, "/inventory-snapshots", createSnapshot(svc))
+	huma.Get(api, "/inventory-snapshots/{id}", getSnapshot(svc))
+	huma.Get(api, "/inventory-snapshots/{id}/diff/{other_id}", diffSnapshotsHandler(svc))
+}
+
+func listSnapshots(svc ServiceInterface) func(context.Context, *struct{}) (*ListSnapshotsOutput, error) {
+	return func(ctx context.Context, _ *struct{}) (*ListSnapshotsOutput, error) {
+		workspaceID, err := appMiddleware.RequireWorkspaceID(ctx)
+		if err != nil {
+			return nil, huma.Error401Unauthorized(err.Error())
+		}
+
+		all, err := svc.ListSnapshots(ctx, workspaceID)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to list inventory snapshots")
+		}
+
+		items := make([]SnapshotSummaryResponse, len(all))
+		for i, snapshot := range all {
+			items[i] = toSnapshotSummaryResponse(snapshot)
+		}
+		return &ListSnapshotsOutput{Body: SnapshotListResponse{Items: items}}, nil
+	}
+}
+
+func createSnapshot(svc ServiceInterface) func(context.Context, *CreateSnapshotInput) (*SnapshotOutput, error) {
+	return func(ctx context.Context, input *CreateSnapshotInput) (*SnapshotOutput, error) {
+		workspaceID, err := appMiddleware.RequireWorkspaceID(ctx)
+		if err != nil {
+			return nil, huma.Error401Unauthorized(err.Error())
+		}
+		if role, ok := appMiddleware.GetRole(ctx); !ok || (role != "owner" && role != "admin") {
+			return nil, huma.Error403Forbidden("only workspace owners and admins can take inventory snapshots")
+		}
+
+		snapshot, err := svc.CreateSnapshot(ctx, workspaceID, input.Body.Label)
+		if err != nil {
+			if errors.Is(err, shared.ErrInvalidInput) {
+				return nil, appMiddleware.MapDomainError(err)
+			}
+			return nil, huma.Error500InternalServerError("failed to take inventory snapshot")
+		}
+		return &SnapshotOutput{Body: toSnapshotResponse(snapshot)}, nil
+	}
+}
+
+func getSnapshot(svc ServiceInterface) func(context.Context, *GetSnapshotInput) (*SnapshotOutput, error) {
+	return func(ctx context.Context, input *GetSnapshotInput) (*SnapshotOutput, error) {
+		workspaceID, err := appMiddleware.RequireWorkspaceID(ctx)
+		if err != nil {
+			return nil, huma.Error401Unauthorized(err.Error())
+		}
+
+		snapshot, err := svc.GetSnapshot(ctx, workspaceID, input.ID)
+		if err != nil {
+			if shared.IsNotFound(err) {
+				return nil, huma.Error404NotFound("inventory snapshot not found")
+			}
+			return nil, huma.Error500InternalServerError("failed to fetch inventory snapshot")
+		}
+		return &SnapshotOutput{Body: toSnapshotResponse(snapshot)}, nil
+	}
+}
+
+func diffSnapshotsHandler(svc ServiceInterface) func(context.Context, *DiffSnapshotsInput) (*SnapshotDiffOutput, error) {
+	return func(ctx context.Context, input *DiffSnapshotsInput) (*SnapshotDiffOutput, error) {
+		workspaceID, err := appMiddleware.RequireWorkspaceID(ctx)
+		if err != nil {
+			return nil, huma.Error401Unauthorized(err.Error())
+		}
+
+		diff, err := svc.DiffSnapshots(ctx, workspaceID, input.ID, input.OtherID)
+		if err != nil {
+			if shared.IsNotFound(err) {
+				return nil, huma.Error404NotFound("inventory snapshot not found")
+			}
+			return nil, huma.Error500InternalServerError("failed to compare inventory snapshots")
+		}
+
+		items := make([]SnapshotItemDiffResponse, len(diff.Items))
+		for i, d := range diff.Items {
+			items[i] = SnapshotItemDiffResponse{
+				ItemID:         d.ItemID,
+				ItemName:       d.ItemName,
+				SKU:            d.SKU,
+				FromQuantity:   d.FromQuantity,
+				ToQuantity:     d.ToQuantity,
+				QuantityChange: d.ToQuantity - d.FromQuantity,
+				FromValue:      d.FromValue,
+				ToValue:        d.ToValue,
+				ValueChange:    d.ToValue - d.FromValue,
+				FromConditions: toConditionCounts(d.FromConditions),
+				ToConditions:   toConditionCounts(d.ToConditions),
+			}
+		}
+		return &SnapshotDiffOutput{Body: SnapshotDiffResponse{
+			From:           toSnapshotSummaryResponse(diff.From),
+			To:             toSnapshotSummaryResponse(diff.To),
+			QuantityChange: diff.QuantityChange,
+			ValueChange:    diff.ValueChange,
+			Items:          items,
+		}}, nil
+	}
+}
+
+func toSnapshotSummaryResponse(s *Snapshot) SnapshotSummaryResponse {
+	return SnapshotSummaryResponse{
+		ID:            s.ID,
+		Label:         s.Label,
+		CurrencyCode:  s.CurrencyCode,
+		TotalQuantity: s.TotalQuantity,
+		TotalValue:    s.TotalValue,
+		UnvaluedCount: s.Unvalued,
+		CreatedAt:     s.CreatedAt,
+	}
+}
+
+func toSnapshotResponse(s *Snapshot) SnapshotResponse {
+	items := make([]SnapshotItemResponse, len(s.Items))
+	for i, line := range s.Items {
+		items[i] = SnapshotItemResponse{
+			ItemID:        line.ItemID,
+			ItemName:      line.ItemName,
+			SKU:           line.SKU,
+			Quantity:      line.Quantity,
+			Conditions:    toConditionCounts(line.Conditions),
+			TotalValue:    line.TotalValue,
+			UnvaluedCount: line.Unvalued,
+		}
+	}
+	return SnapshotResponse{
+		ID:            s.ID,
+		Label:         s.Label,
+		CurrencyCode:  s.CurrencyCode,
+		TotalQuantity: s.TotalQuantity,
+		TotalValue:    s.TotalValue,
+		UnvaluedCount: s.Unvalued,
+		Items:         items,
+		CreatedAt:     s.CreatedAt,
+	}
+}
+
+func toConditionCounts(conditions map[Condition]int) map[string]int {
+	counts := make(map[string]int, len(conditions))
+	for condition, quantity := range conditions {
+		counts[string(condition)] = quantity
+	}
+	return counts
+}
+
+type CreateSnapshotInput struct {
+	Body struct {
+		Label string `json:"label" minLength:"1" maxLength:"200" doc:"Name of the snapshot, e.g. 'Year end 2026'"`
+	}
+}
+
+type GetSnapshotInput struct {
+	ID uuid.UUID `path:"id"`
+}
+
+type DiffSnapshotsInput struct {
+	ID      uuid.UUID `path:"id" doc:"The earlier snapshot"`
+	OtherID uuid.UUID `path:"other_id" doc:"The snapshot to compare it with"`
+}
+
+type SnapshotOutput struct {
+	Body SnapshotResponse
+}
+
+type ListSnapshotsOutput struct {
+	Body SnapshotListResponse
+}
+
+type SnapshotDiffOutput struct {
+	Body SnapshotDiffResponse
+}
+
+type SnapshotListResponse struct {
+	Items []SnapshotSummaryResponse `json:"items"`
+}
+
+type SnapshotSummaryResponse struct {
+	ID            uuid.UUID `json:"id"`
+	Label         string    `json:"label"`
+	CurrencyCode  string    `json:"currency_code" doc:"Workspace base currency when the snapshot was taken; values are in it"`
+	TotalQuantity int       `json:"total_quantity"`
+	TotalValue    int64     `json:"total_value"`
+	UnvaluedCount int       `json:"unvalued_count" doc:"Inventory rows left out of total_value: unpriced, or in a currency with no exchange rate"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+type SnapshotResponse struct {
+	ID            uuid.UUID              `json:"id"`
+	Label         string                 `json:"label"`
+	CurrencyCode  string                 `json:"currency_code" doc:"Workspace base currency when the snapshot was taken; values are in it"`
+	TotalQuantity int                    `json:"total_quantity"`
+	TotalValue    int64                  `json:"total_value"`
+	UnvaluedCount int                    `json:"unvalued_count" doc:"Inventory rows left out of total_value: unpriced, or in a currency with no exchange rate"`
+	Items         []SnapshotItemResponse `json:"items"`
+	CreatedAt     time.Time              `json:"created_at"`
+}
+
+type SnapshotItemResponse struct {
+	ItemID        uuid.UUID      `json:"item_id"`
+	ItemName      string         `json:"item_name"`
+	SKU           string         `json:"sku"`
+	Quantity      int            `json:"quantity"`
+	Conditions    map[string]int `json:"conditions" doc:"Quantity per condition"`
+	TotalValue    int64          `json:"total_value"`
+	UnvaluedCount int            `json:"unvalued_count"`
+}
+
+type SnapshotDiffResponse struct {
+	From           SnapshotSummaryResponse    `json:"from"`
+	To             SnapshotSummaryResponse    `json:"to"`
+	QuantityChange int                        `json:"quantity_change"`
+	ValueChange    int64                      `json:"value_change"`
+	Items          []SnapshotItemDiffResponse `json:"items" doc:"Items whose quantity, conditions or value changed"`
+}
+
+type SnapshotItemDiffResponse struct {
+	ItemID         uuid.UUID      `json:"item_id"`
+	ItemName       string         `json:"item_name"`
+	SKU            string         `json:"sku"`
+	FromQuantity   int            `json:"from_quantity"`
+	ToQuantity     int            `json:"to_quantity"`
+	QuantityChange int            `json:"quantity_change"`
+	FromValue      int64          `json:"from_value"`
+	ToValue        int64          `json:"to_value"`
+	ValueChange    int64          `json:"value_change"`
+	FromConditions map[string]int `json:"from_conditions"`
+	ToConditions   map[string]int `json:"to_conditions"`
+}
@@ -0,0 +1,142 @@
+package inventory
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// fakeSnapshotRepository summarizes current and keeps created snapshots in
+// memory.
+type fakeSnapshotRepository struct {
+	currency string
+	current  []SnapshotItem
+	created  []Snapshot
+}
+
+func (f *fakeSnapshotRepository) Summarize(_ context.Context, _ uuid.UUID) (string, []SnapshotItem, error) {
+	return f.currency, f.current, nil
+}
+
+func (f *fakeSnapshotRepository) Create(_ context.Context, _ uuid.UUID, snapshot Snapshot) (*Snapshot, error) {
+	f.created = append(f.created, snapshot)
+	return &snapshot, nil
+}
+
+func (f *fakeSnapshotRepository) List(_ context.Context, _ uuid.UUID) ([]*Snapshot, error) {
+	all := make([]*Snapshot, len(f.created))
+	for i := range f.created {
+		all[i] = &f.created[i]
+	}
+	return all, nil
+}
+
+func (f *fakeSnapshotRepository) Get(_ context.Context, _ uuid.UUID, id uuid.UUID) (*Snapshot, error) {
+	for i := range f.created {
+		if f.created[i].ID == id {
+			return &f.created[i], nil
+		}
+	}
+	return nil, shared.ErrNotFound
+}
+
+func TestService_CreateSnapshot(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+	drill := SnapshotItem{
+		ItemID: uuid.New(), ItemName: "Drill", Quantity: 3,
+		Conditions: map[Condition]int{ConditionGood: 2, ConditionFair: 1}, TotalValue: 15000,
+	}
+	cable := SnapshotItem{
+		ItemID: uuid.New(), ItemName: "Cable", Quantity: 10,
+		Conditions: map[Condition]int{ConditionNew: 10}, TotalValue: 500, Unvalued: 1,
+	}
+
+	t.Run("totals the items", func(t *testing.T) {
+		repo := &fakeSnapshotRepository{currency: "EUR", current: []SnapshotItem{drill, cable}}
+		svc := NewService(nil, nil, nil, nil, nil)
+		svc.SetSnapshotRepository(repo)
+
+		snapshot, err := svc.CreateSnapshot(ctx, workspaceID, "  Year end 2025 ")
+
+		require.NoError(t, err)
+		assert.Equal(t, "Year end 2025", snapshot.Label)
+		assert.Equal(t, "EUR", snapshot.CurrencyCode)
+		assert.Equal(t, 13, snapshot.TotalQuantity)
+		assert.Equal(t, int64(15500), snapshot.TotalValue)
+		assert.Equal(t, 1, snapshot.Unvalued)
+		assert.Len(t, snapshot.Items, 2)
+		assert.NotEqual(t, uuid.Nil, snapshot.ID)
+	})
+
+	t.Run("rejects a bad label", func(t *testing.T) {
+		svc := NewService(nil, nil, nil, nil, nil)
+		svc.SetSnapshotRepository(&fakeSnapshotRepository{})
+
+		for _, label := range []string{"", "   ", strings.Repeat("x", MaxSnapshotLabelLength+1)} {
+			_, err := svc.CreateSnapshot(ctx, workspaceID, label)
+			assert.ErrorIs(t, err, shared.ErrInvalidInput)
+		}
+	})
+
+	t.Run("fails without storage", func(t *testing.T) {
+		svc := NewService(nil, nil, nil, nil, nil)
+
+		_, err := svc.CreateSnapshot(ctx, workspaceID, "Year end")
+		assert.Error(t, err)
+	})
+}
+
+func TestService_DiffSnapshots(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+	drillID, cableID, lampID, tapeID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	repo := &fakeSnapshotRepository{currency: "EUR", current: []SnapshotItem{
+		{ItemID: drillID, ItemName: "Drill", Quantity: 2, Conditions: map[Condition]int{ConditionGood: 2}, TotalValue: 10000},
+		{ItemID: cableID, ItemName: "Cable", Quantity: 10, Conditions: map[Condition]int{ConditionNew: 10}, TotalValue: 500},
+		{ItemID: tapeID, ItemName: "Tape", Quantity: 4, Conditions: map[Condition]int{ConditionNew: 4}},
+	}}
+	svc := NewService(nil, nil, nil, nil, nil)
+	svc.SetSnapshotRepository(repo)
+
+	before, err := svc.CreateSnapshot(ctx, workspaceID, "Before")
+	require.NoError(t, err)
+
+	repo.current = []SnapshotItem{
+		// One drill broke.
+		{ItemID: drillID, ItemName: "Drill", Quantity: 2, Conditions: map[Condition]int{ConditionGood: 1, ConditionDamaged: 1}, TotalValue: 10000},
+		{ItemID: lampID, ItemName: "Lamp", Quantity: 1, Conditions: map[Condition]int{ConditionNew: 1}, TotalValue: 2500},
+		{ItemID: tapeID, ItemName: "Tape", Quantity: 4, Conditions: map[Condition]int{ConditionNew: 4}},
+	}
+	after, err := svc.CreateSnapshot(ctx, workspaceID, "After")
+	require.NoError(t, err)
+
+	diff, err := svc.DiffSnapshots(ctx, workspaceID, before.ID, after.ID)
+
+	require.NoError(t, err)
+	assert.Equal(t, "Before", diff.From.Label)
+	assert.Nil(t, diff.From.Items)
+	assert.Equal(t, -9, diff.QuantityChange)
+	assert.Equal(t, int64(2000), diff.ValueChange)
+
+	require.Len(t, diff.Items, 3, "unchanged tape is left out")
+	assert.Equal(t, "Cable", diff.Items[0].ItemName)
+	assert.Equal(t, 10, diff.Items[0].FromQuantity)
+	assert.Equal(t, 0, diff.Items[0].ToQuantity)
+	assert.Equal(t, "Drill", diff.Items[1].ItemName)
+	assert.Equal(t, map[Condition]int{ConditionGood: 1, ConditionDamaged: 1}, diff.Items[1].ToConditions)
+	assert.Equal(t, "Lamp", diff.Items[2].ItemName)
+	assert.Equal(t, int64(2500), diff.Items[2].ToValue)
+
+	t.Run("unknown snapshot is not found", func(t *testing.T) {
+		_, err := svc.DiffSnapshots(ctx, workspaceID, before.ID, uuid.New())
+		assert.ErrorIs(t, err, shared.ErrNotFound)
+	})
+}
@@ -0,0 +1,285 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/google/uuid"
+
+	appMiddleware "github.com/antti/home-warehouse/go-backend/internal/api/middleware"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// ErrStockLevelNotFound is returned when removing a per-location minimum that
+// was never set.
+var ErrStockLevelNotFound = shared.NewDomainError(shared.ErrNotFound, "no minimum stock level is set for this item at this location")
+
+// LocationStockLevel is an item's minimum stock at one location. Once an item
+// has any, low-stock reporting checks the stock at each of those locations
+// instead of comparing the item's total with its own min_stock_level.
+type LocationStockLevel struct {
+	ItemID        uuid.UUID
+	LocationID    uuid.UUID
+	LocationName  string
+	MinStockLevel int
+}
+
+// LowStockEntry is one line of the low-stock report. LocationID is nil when
+// the item has no per-location minimums and its total stock is below the
+// item-level minimum.
+type LowStockEntry struct {
+	ItemID        uuid.UUID
+	ItemName      string
+	LocationID    *uuid.UUID
+	LocationName  *string
+	CurrentStock  int
+	MinStockLevel int
+}
+
+// ReorderQuantity is how many units bring the stock back up to the minimum.
+func (e LowStockEntry) ReorderQuantity() int {
+	return e.MinStockLevel - e.CurrentStock
+}
+
+// StockLevelRepository persists per-location minimum stock levels and runs
+// the low-stock report over them.
+type StockLevelRepository interface {
+	ListByItem(ctx context.Context, workspaceID, itemID uuid.UUID) ([]LocationStockLevel, error)
+	Upsert(ctx context.Context, workspaceID uuid.UUID, level LocationStockLevel) error
+	// Delete returns shared.ErrNotFound when no level was set.
+	Delete(ctx context.Context, workspaceID, itemID, locationID uuid.UUID) error
+	FindLowStock(ctx context.Context, workspaceID uuid.UUID) ([]LowStockEntry, error)
+}
+
+// SetStockLevelRepository wires per-location minimum stock storage. Without
+// it items have no per-location minimums and the low-stock report is empty.
+func (s *Service) SetStockLevelRepository(repo StockLevelRepository) {
+	s.stockLevels = repo
+}
+
+// ListStockLevels returns the item's per-location minimums, by location name.
+func (s *Service) ListStockLevels(ctx context.Context, workspaceID, itemID uuid.UUID) ([]LocationStockLevel, error) {
+	if err := s.requireItem(ctx, workspaceID, itemID); err != nil {
+		return nil, err
+	}
+	if s.stockLevels == nil {
+		return []LocationStockLevel{}, nil
+	}
+	return s.stockLevels.ListByItem(ctx, workspaceID, itemID)
+}
+
+// SetStockLevel sets the item's minimum stock at a location, replacing any
+// earlier one.
+func (s *Service) SetStockLevel(ctx context.Context, workspaceID, itemID, locationID uuid.UUID, minStockLevel int) (*LocationStockLevel, error) {
+	if minStockLevel < 1 {
+		return nil, shared.NewFieldError(shared.ErrInvalidInput, "min_stock_level", "must be at least 1")
+	}
+	if s.stockLevels == nil {
+		return nil, errors.New("stock level storage is not configured")
+	}
+	if err := s.requireItem(ctx, workspaceID, itemID); err != nil {
+		return nil, err
+	}
+	loc, err := s.locationRepo.FindByID(ctx, locationID, workspaceID)
+	if err != nil {
+		if shared.IsNotFound(err) {
+			return nil, shared.NewFieldError(shared.ErrNotFound, "location_id", fmt.Sprintf("location %s not found in this workspace", locationID))
+		}
+		return nil, err
+	}
+
+	level := LocationStockLevel{
+		ItemID:        itemID,
+		LocationID:    locationID,
+		LocationName:  loc.Name(),
+		MinStockLevel: minStockLevel,
+	}
+	if err := s.stockLevels.Upsert(ctx, workspaceID, level); err != nil {
+		return nil, err
+	}
+	return &level, nil
+}
+
+// DeleteStockLevel removes the item's minimum at a location. When it was the
+// last one the item falls back to its item-level minimum.
+func (s *Service) DeleteStockLevel(ctx context.Context, workspaceID, itemID, locationID uuid.UUID) error {
+	if s.stockLevels == nil {
+		return ErrStockLevelNotFound
+	}
+	err := s.stockLevels.Delete(ctx, workspaceID, itemID, locationID)
+	if errors.Is(err, shared.ErrNotFound) {
+		return ErrStockLevelNotFound
+	}
+	return err
+}
+
+// ListLowStock returns the workspace's low-stock report: per-location
+// minimums where an item has them, the item-level minimum otherwise.
+func (s *Service) ListLowStock(ctx context.Context, workspaceID uuid.UUID) ([]LowStockEntry, error) {
+	if s.stockLevels == nil {
+		return []LowStockEntry{}, nil
+	}
+	return s.stockLevels.FindLowStock(ctx, workspaceID)
+}
+
+// requireItem checks the item exists in the workspace.
+func (s *Service) requireItem(ctx context.Context, workspaceID, itemID uuid.UUID) error {
+	if _, err := s.itemRepo.FindByID(ctx, itemID, workspaceID); err != nil {
+		if shared.IsNotFound(err) {
+			return shared.NewFieldError(shared.ErrNotFound, "item_id", fmt.Sprintf("item %s not found in this workspace", itemID))
+		}
+		return err
+	}
+	return nil
+}
+
+// RegisterStockLevelRoutes registers the per-location minimum stock and
+// low-stock report endpoints.
+func RegisterStockLevelRoutes(api huma.API, svc ServiceInterface) {
+	huma.Get(api, "/inventory/low-stock", listLowStock(svc))
+	huma.Get(api, "/items/{item_id}/stock-levels", listStockLevels(svc))
+	huma.Put(api, "/items/{item_id}/stock-levels/{location_id}", setStockLevel(svc))
+	huma.Delete(api, "/items/{item_id}/stock-levels/{location_id}", deleteStockLevel(svc))
+}
+
+func listLowStock(svc ServiceInterface) func(context.Context, *struct{}) (*ListLowStockOutput, error) {
+	return func(ctx context.Context, _ *struct{}) (*ListLowStockOutput, error) {
+		workspaceID, err := appMiddleware.RequireWorkspaceID(ctx)
+		if err != nil {
+			return nil, huma.Error401Unauthorized(err.Error())
+		}
+
+		entries, err := svc.ListLowStock(ctx, workspaceID)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to list low stock")
+		}
+
+		responses := make([]LowStockResponse, len(entries))
+		for i, e := range entries {
+			responses[i] = LowStockResponse{
+				ItemID:          e.ItemID,
+				ItemName:        e.ItemName,
+				LocationID:      e.LocationID,
+				LocationName:    e.LocationName,
+				CurrentStock:    e.CurrentStock,
+				MinStockLevel:   e.MinStockLevel,
+				ReorderQuantity: e.ReorderQuantity(),
+			}
+		}
+
+		return &ListLowStockOutput{
+			Body: LowStockListResponse{Items: responses, Total: len(responses)},
+		}, nil
+	}
+}
+
+func listStockLevels(svc ServiceInterface) func(context.Context, *GetByItemInput) (*ListStockLevelsOutput, error) {
+	return func(ctx context.Context, input *GetByItemInput) (*ListStockLevelsOutput, error) {
+		workspaceID, err := appMiddleware.RequireWorkspaceID(ctx)
+		if err != nil {
+			return nil, huma.Error401Unauthorized(err.Error())
+		}
+
+		levels, err := svc.ListStockLevels(ctx, workspaceID, input.ItemID)
+		if err != nil {
+			return nil, appMiddleware.MapDomainError(err)
+		}
+
+		responses := make([]StockLevelResponse, len(levels))
+		for i := range levels {
+			responses[i] = toStockLevelResponse(&levels[i])
+		}
+		return &ListStockLevelsOutput{Body: StockLevelListResponse{Items: responses}}, nil
+	}
+}
+
+func setStockLevel(svc ServiceInterface) func(context.Context, *SetStockLevelInput) (*StockLevelOutput, error) {
+	return func(ctx context.Context, input *SetStockLevelInput) (*StockLevelOutput, error) {
+		workspaceID, err := appMiddleware.RequireWorkspaceID(ctx)
+		if err != nil {
+			return nil, huma.Error401Unauthorized(err.Error())
+		}
+
+		level, err := svc.SetStockLevel(ctx, workspaceID, input.ItemID, input.LocationID, input.Body.MinStockLevel)
+		if err != nil {
+			return nil, appMiddleware.MapDomainError(err)
+		}
+		return &StockLevelOutput{Body: toStockLevelResponse(level)}, nil
+	}
+}
+
+func deleteStockLevel(svc ServiceInterface) func(context.Context, *StockLevelPathInput) (*struct{}, error) {
+	return func(ctx context.Context, input *StockLevelPathInput) (*struct{}, error) {
+		workspaceID, err := appMiddleware.RequireWorkspaceID(ctx)
+		if err != nil {
+			return nil, huma.Error401Unauthorized(err.Error())
+		}
+
+		if err := svc.DeleteStockLevel(ctx, workspaceID, input.ItemID, input.LocationID); err != nil {
+			return nil, appMiddleware.MapDomainError(err)
+		}
+		return nil, nil
+	}
+}
+
+func toStockLevelResponse(l *LocationStockLevel) StockLevelResponse {
+	return StockLevelResponse{
+		LocationID:    l.LocationID,
+		LocationName:  l.LocationName,
+		MinStockLevel: l.MinStockLevel,
+	}
+}
+
+// Types for the stock level endpoints.
+
+type StockLevelPathInput struct {
+	ItemID     uuid.UUID `path:"item_id"`
+	LocationID uuid.UUID `path:"location_id"`
+}
+
+type SetStockLevelInput struct {
+	ItemID     uuid.UUID `path:"item_id"`
+	LocationID uuid.UUID `path:"location_id"`
+	Body       struct {
+		MinStockLevel int `json:"min_stock_level" minimum:"1" doc:"The item is low at this location when its stock there falls below this"`
+	}
+}
+
+type StockLevelOutput struct {
+	Body StockLevelResponse
+}
+
+type ListStockLevelsOutput struct {
+	Body StockLevelListResponse
+}
+
+type StockLevelListResponse struct {
+	Items []StockLevelResponse `json:"items"`
+}
+
+type StockLevelResponse struct {
+	LocationID    uuid.UUID `json:"location_id"`
+	LocationName  string    `json:"location_name"`
+	MinStockLevel int       `json:"min_stock_level"`
+}
+
+type ListLowStockOutput struct {
+	Body LowStockListResponse
+}
+
+type LowStockListResponse struct {
+	Items []LowStockResponse `json:"items"`
+	Total int                `json:"total"`
+}
+
+type LowStockResponse struct {
+	ItemID          uuid.UUID  `json:"item_id"`
+	ItemName        string     `json:"item_name"`
+	LocationID      *uuid.UUID `json:"location_id,omitempty" doc:"Set when the minimum is per-location; absent for the item-level minimum on total stock"`
+	LocationName    *string    `json:"location_name,omitempty"`
+	CurrentStock    int        `json:"current_stock"`
+	MinStockLevel   int        `json:"min_stock_level"`
+	ReorderQuantity int        `json:"reorder_quantity" doc:"Units needed to reach the minimum"`
+}
@@ -0,0 +1,126 @@
+package inventory
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/location"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+type mockStockLevelRepo struct {
+	mock.Mock
+}
+
+func (m *mockStockLevelRepo) ListByItem(ctx context.Context, workspaceID, itemID uuid.UUID) ([]LocationStockLevel, error) {
+	args := m.Called(ctx, workspaceID, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]LocationStockLevel), args.Error(1)
+}
+
+func (m *mockStockLevelRepo) Upsert(ctx context.Context, workspaceID uuid.UUID, level LocationStockLevel) error {
+	args := m.Called(ctx, workspaceID, level)
+	return args.Error(0)
+}
+
+func (m *mockStockLevelRepo) Delete(ctx context.Context, workspaceID, itemID, locationID uuid.UUID) error {
+	args := m.Called(ctx, workspaceID, itemID, locationID)
+	return args.Error(0)
+}
+
+func (m *mockStockLevelRepo) FindLowStock(ctx context.Context, workspaceID uuid.UUID) ([]LowStockEntry, error) {
+	args := m.Called(ctx, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]LowStockEntry), args.Error(1)
+}
+
+func TestService_SetStockLevel(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+	itemID := uuid.New()
+	locationID := uuid.New()
+
+	t.Run("saves the level with the location name", func(t *testing.T) {
+		repo := new(mockStockLevelRepo)
+		itemR, _, contR := newPermissiveFKRepos()
+		locR := new(mockLocationRepo)
+		locR.On("FindByID", ctx, locationID, workspaceID).Return(
+			location.Reconstruct(locationID, workspaceID, "Garage", nil, nil, "LC", false, time.Now(), time.Now()), nil)
+		svc := NewService(new(MockRepository), nil, itemR, locR, contR)
+		svc.SetStockLevelRepository(repo)
+
+		want := LocationStockLevel{ItemID: itemID, LocationID: locationID, LocationName: "Garage", MinStockLevel: 5}
+		repo.On("Upsert", ctx, workspaceID, want).Return(nil)
+
+		level, err := svc.SetStockLevel(ctx, workspaceID, itemID, locationID, 5)
+		require.NoError(t, err)
+		assert.Equal(t, want, *level)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("rejects a minimum below one", func(t *testing.T) {
+		repo := new(mockStockLevelRepo)
+		svc := newTestService(new(MockRepository))
+		svc.SetStockLevelRepository(repo)
+
+		_, err := svc.SetStockLevel(ctx, workspaceID, itemID, locationID, 0)
+		assert.ErrorIs(t, err, shared.ErrInvalidInput)
+		repo.AssertNotCalled(t, "Upsert", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects a location outside the workspace", func(t *testing.T) {
+		repo := new(mockStockLevelRepo)
+		itemR, _, contR := newPermissiveFKRepos()
+		locR := new(mockLocationRepo)
+		locR.On("FindByID", ctx, locationID, workspaceID).Return(nil, shared.ErrNotFound)
+		svc := NewService(new(MockRepository), nil, itemR, locR, contR)
+		svc.SetStockLevelRepository(repo)
+
+		_, err := svc.SetStockLevel(ctx, workspaceID, itemID, locationID, 5)
+		assert.True(t, shared.IsNotFound(err))
+		repo.AssertNotCalled(t, "Upsert", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestService_DeleteStockLevel(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+	itemID := uuid.New()
+	locationID := uuid.New()
+
+	t.Run("reports a missing level", func(t *testing.T) {
+		repo := new(mockStockLevelRepo)
+		svc := newTestService(new(MockRepository))
+		svc.SetStockLevelRepository(repo)
+		repo.On("Delete", ctx, workspaceID, itemID, locationID).Return(shared.ErrNotFound)
+
+		err := svc.DeleteStockLevel(ctx, workspaceID, itemID, locationID)
+		assert.ErrorIs(t, err, ErrStockLevelNotFound)
+	})
+
+	t.Run("passes through repository errors", func(t *testing.T) {
+		repo := new(mockStockLevelRepo)
+		svc := newTestService(new(MockRepository))
+		svc.SetStockLevelRepository(repo)
+		repo.On("Delete", ctx, workspaceID, itemID, locationID).Return(errors.New("db down"))
+
+		err := svc.DeleteStockLevel(ctx, workspaceID, itemID, locationID)
+		assert.ErrorContains(t, err, "db down")
+	})
+}
+
+func TestLowStockEntry_ReorderQuantity(t *testing.T) {
+	entry := LowStockEntry{CurrentStock: 3, MinStockLevel: 5}
+	assert.Equal(t, 2, entry.ReorderQuantity())
+}
@@ -0,0 +1,418 @@
+package item
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/google/uuid"
+
+	appMiddleware "github.com/antti/home-warehouse/go-backend/internal/api/middleware"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// MatchType says how a category rule's pattern is matched against an item
+// name. Both kinds ignore case.
+type MatchType string
+
+const (
+	MatchSubstring MatchType = "substring"
+	MatchRegex     MatchType = "regex"
+)
+
+func (m MatchType) IsValid() bool {
+	return m == MatchSubstring || m == MatchRegex
+}
+
+const (
+	// MaxCategoryRules caps the rules of a workspace: every item created
+	// without a category is tried against all of them.
+	MaxCategoryRules = 200
+	// MaxCategoryRulePatternLength matches category_rules.pattern.
+	MaxCategoryRulePatternLength = 200
+)
+
+// CategoryRule assigns CategoryID to new items created without a category
+// whose name matches Pattern. A workspace's rules are tried in Position
+// order and the first match wins.
+type CategoryRule struct {
+	ID         uuid.UUID
+	CategoryID uuid.UUID
+	Pattern    string
+	MatchType  MatchType
+	Position   int
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// Matches reports whether name matches the rule, ignoring case. A regex that
+// does not compile never matches; patterns are validated when saved.
+func (r *CategoryRule) Matches(name string) bool {
+	if r.MatchType == MatchRegex {
+		re, err := regexp.Compile("(?i)" + r.Pattern)
+		return err == nil && re.MatchString(name)
+	}
+	return strings.Contains(strings.ToLower(name), strings.ToLower(r.Pattern))
+}
+
+// CategoryRuleInput is the editable part of a category rule.
+type CategoryRuleInput struct {
+	CategoryID uuid.UUID
+	Pattern    string
+	MatchType  MatchType
+}
+
+// CategoryRuleRepository persists category rules. List returns a
+// workspace's rules in position order; Update returns shared.ErrNotFound for
+// a rule that is not in the workspace.
+type CategoryRuleRepository interface {
+	List(ctx context.Context, workspaceID uuid.UUID) ([]*CategoryRule, error)
+	Create(ctx context.Context, workspaceID uuid.UUID, rule CategoryRule) (*CategoryRule, error)
+	Update(ctx context.Context, workspaceID uuid.UUID, rule CategoryRule) (*CategoryRule, error)
+	UpdatePosition(ctx context.Context, workspaceID, id uuid.UUID, position int) error
+	Delete(ctx context.Context, workspaceID, id uuid.UUID) error
+}
+
+// SetCategoryRuleRepository wires category rule storage. Without it items
+// created without a category stay uncategorized.
+func (s *Service) SetCategoryRuleRepository(repo CategoryRuleRepository) {
+	s.categoryRules = repo
+}
+
+// ListCategoryRules returns the workspace's rules in the order they are
+// tried.
+func (s *Service) ListCategoryRules(ctx context.Context, workspaceID uuid.UUID) ([]*CategoryRule, error) {
+	if s.categoryRules == nil {
+		return []*CategoryRule{}, nil
+	}
+	return s.categoryRules.List(ctx, workspaceID)
+}
+
+// CreateCategoryRule adds a rule after the workspace's existing ones.
+func (s *Service) CreateCategoryRule(ctx context.Context, workspaceID uuid.UUID, input CategoryRuleInput) (*CategoryRule, error) {
+	input, err := s.validateCategoryRule(ctx, workspaceID, input)
+	if err != nil {
+		return nil, err
+	}
+	if s.categoryRules == nil {
+		return nil, errors.New("category rule storage is not configured")
+	}
+
+	rules, err := s.categoryRules.List(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if len(rules) >= MaxCategoryRules {
+		return nil, ErrTooManyCategoryRules
+	}
+	position := 0
+	if len(rules) > 0 {
+		position = rules[len(rules)-1].Position + 1
+	}
+
+	return s.categoryRules.Create(ctx, workspaceID, CategoryRule{
+		ID:         shared.NewUUID(),
+		CategoryID: input.CategoryID,
+		Pattern:    input.Pattern,
+		MatchType:  input.MatchType,
+		Position:   position,
+	})
+}
+
+// UpdateCategoryRule replaces a rule's category and pattern, keeping its
+// position.
+func (s *Service) UpdateCategoryRule(ctx context.Context, workspaceID, id uuid.UUID, input CategoryRuleInput) (*CategoryRule, error) {
+	input, err := s.validateCategoryRule(ctx, workspaceID, input)
+	if err != nil {
+		return nil, err
+	}
+	if s.categoryRules == nil {
+		return nil, ErrCategoryRuleNotFound
+	}
+	rule, err := s.categoryRules.Update(ctx, workspaceID, CategoryRule{
+		ID:         id,
+		CategoryID: input.CategoryID,
+		Pattern:    input.Pattern,
+		MatchType:  input.MatchType,
+	})
+	if errors.Is(err, shared.ErrNotFound) {
+		return nil, ErrCategoryRuleNotFound
+	}
+	return rule, err
+}
+
+// DeleteCategoryRule removes a rule. Removing a rule that does not exist is
+// not an error.
+func (s *Service) DeleteCategoryRule(ctx context.Context, workspaceID, id uuid.UUID) error {
+	if s.categoryRules == nil {
+		return nil
+	}
+	return s.categoryRules.Delete(ctx, workspaceID, id)
+}
+
+// ReorderCategoryRules sets the order rules are tried in. ruleIDs must list
+// every rule of the workspace exactly once.
+func (s *Service) ReorderCategoryRules(ctx context.Context, workspaceID uuid.UUID, ruleIDs []uuid.UUID) ([]*CategoryRule, error) {
+	rules, err := s.ListCategoryRules(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	known := make(map[uuid.UUID]bool, len(rules))
+	for _, r := range rules {
+		known[r.ID] = true
+	}
+	if len(ruleIDs) != len(rules) {
+		return nil, ErrCategoryRuleOrder
+	}
+	for _, id := range ruleIDs {
+		if !known[id] {
+			return nil, ErrCategoryRuleOrder
+		}
+		delete(known, id) // a repeated ID fails the lookup above
+	}
+	if len(rules) == 0 {
+		return rules, nil
+	}
+
+	err = s.tx.WithTx(ctx, func(txCtx context.Context) error {
+		for position, id := range ruleIDs {
+			if err := s.categoryRules.UpdatePosition(txCtx, workspaceID, id, position); err != nil {
+				return fmt.Errorf("failed to move category rule %s: %w", id, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.categoryRules.List(ctx, workspaceID)
+}
+
+// MatchCategoryRule returns the first of the workspace's rules matching
+// name, or nil when none does.
+func (s *Service) MatchCategoryRule(ctx context.Context, workspaceID uuid.UUID, name string) (*CategoryRule, error) {
+	if s.categoryRules == nil {
+		return nil, nil
+	}
+	rules, err := s.categoryRules.List(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	for _, r := range rules {
+		if r.Matches(name) {
+			return r, nil
+		}
+	}
+	return nil, nil
+}
+
+// validateCategoryRule trims the pattern and checks it, the match type and
+// that the category is in the workspace.
+func (s *Service) validateCategoryRule(ctx context.Context, workspaceID uuid.UUID, input CategoryRuleInput) (CategoryRuleInput, error) {
+	input.Pattern = strings.TrimSpace(input.Pattern)
+	if input.Pattern == "" {
+		return input, shared.NewFieldError(shared.ErrInvalidInput, "pattern", "pattern is required")
+	}
+	if utf8.RuneCountInString(input.Pattern) > MaxCategoryRulePatternLength {
+		return input, shared.NewFieldError(shared.ErrInvalidInput, "pattern", fmt.Sprintf("pattern must be at most %d characters", MaxCategoryRulePatternLength))
+	}
+	if input.MatchType == "" {
+		input.MatchType = MatchSubstring
+	}
+	if !input.MatchType.IsValid() {
+		return input, shared.NewFieldError(shared.ErrInvalidInput, "match_type", "match_type must be one of: substring, regex")
+	}
+	if input.MatchType == MatchRegex {
+		if _, err := regexp.Compile("(?i)" + input.Pattern); err != nil {
+			return input, shared.NewFieldError(shared.ErrInvalidInput, "pattern", fmt.Sprintf("invalid regular expression: %v", err))
+		}
+	}
+	if err := s.validateCategory(ctx, &input.CategoryID, workspaceID); err != nil {
+		return input, err
+	}
+	return input, nil
+}
+
+// RegisterCategoryRuleRoutes registers the category rule endpoints. Like the
+// category inventory defaults they live outside /categories, so a change is
+// not queued for approval as a category update; only owners and admins can
+// make one.
+func RegisterCategoryRuleRoutes(api huma.API, svc ServiceInterface) {
+	huma.Get(api, "/category-rules", listCategoryRules(svc))
+	huma.Post(api, "/category-rules", createCategoryRule(svc))
+	huma.Put(api, "/category-rules/order", reorderCategoryRules(svc))
+	huma.Put(api, "/category-rules/{id}", updateCategoryRule(svc))
+	huma.Delete(api, "/category-rules/{id}", deleteCategoryRule(svc))
+}
+
+// requireRuleEditor rejects members and viewers: rules affect every item
+// created in the workspace.
+func requireRuleEditor(ctx context.Context) error {
+	if role, ok := appMiddleware.GetRole(ctx); !ok || (role != "owner" && role != "admin") {
+		return huma.Error403Forbidden("only workspace owners and admins can change category rules")
+	}
+	return nil
+}
+
+func listCategoryRules(svc ServiceInterface) func(context.Context, *struct{}) (*CategoryRuleListOutput, error) {
+	return func(ctx context.Context, _ *struct{}) (*CategoryRuleListOutput, error) {
+		workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+		if !ok {
+			return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+		}
+
+		rules, err := svc.ListCategoryRules(ctx, workspaceID)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to list category rules")
+		}
+		return &CategoryRuleListOutput{Body: toCategoryRuleListResponse(rules)}, nil
+	}
+}
+
+func createCategoryRule(svc ServiceInterface) func(context.Context, *CreateCategoryRuleInput) (*CategoryRuleOutput, error) {
+	return func(ctx context.Context, input *CreateCategoryRuleInput) (*CategoryRuleOutput, error) {
+		workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+		if !ok {
+			return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+		}
+		if err := requireRuleEditor(ctx); err != nil {
+			return nil, err
+		}
+
+		rule, err := svc.CreateCategoryRule(ctx, workspaceID, input.Body.toInput())
+		if err != nil {
+			return nil, appMiddleware.MapDomainError(err)
+		}
+		return &CategoryRuleOutput{Body: toCategoryRuleResponse(rule)}, nil
+	}
+}
+
+func updateCategoryRule(svc ServiceInterface) func(context.Context, *UpdateCategoryRuleInput) (*CategoryRuleOutput, error) {
+	return func(ctx context.Context, input *UpdateCategoryRuleInput) (*CategoryRuleOutput, error) {
+		workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+		if !ok {
+			return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+		}
+		if err := requireRuleEditor(ctx); err != nil {
+			return nil, err
+		}
+
+		rule, err := svc.UpdateCategoryRule(ctx, workspaceID, input.ID, input.Body.toInput())
+		if err != nil {
+			return nil, appMiddleware.MapDomainError(err)
+		}
+		return &CategoryRuleOutput{Body: toCategoryRuleResponse(rule)}, nil
+	}
+}
+
+func reorderCategoryRules(svc ServiceInterface) func(context.Context, *ReorderCategoryRulesInput) (*CategoryRuleListOutput, error) {
+	return func(ctx context.Context, input *ReorderCategoryRulesInput) (*CategoryRuleListOutput, error) {
+		workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+		if !ok {
+			return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+		}
+		if err := requireRuleEditor(ctx); err != nil {
+			return nil, err
+		}
+
+		rules, err := svc.ReorderCategoryRules(ctx, workspaceID, input.Body.RuleIDs)
+		if err != nil {
+			return nil, appMiddleware.MapDomainError(err)
+		}
+		return &CategoryRuleListOutput{Body: toCategoryRuleListResponse(rules)}, nil
+	}
+}
+
+func deleteCategoryRule(svc ServiceInterface) func(context.Context, *CategoryRuleIDInput) (*struct{}, error) {
+	return func(ctx context.Context, input *CategoryRuleIDInput) (*struct{}, error) {
+		workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+		if !ok {
+			return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+		}
+		if err := requireRuleEditor(ctx); err != nil {
+			return nil, err
+		}
+
+		if err := svc.DeleteCategoryRule(ctx, workspaceID, input.ID); err != nil {
+			return nil, huma.Error500InternalServerError("failed to delete category rule")
+		}
+		return nil, nil
+	}
+}
+
+func toCategoryRuleResponse(r *CategoryRule) CategoryRuleResponse {
+	return CategoryRuleResponse{
+		ID:         r.ID,
+		CategoryID: r.CategoryID,
+		Pattern:    r.Pattern,
+		MatchType:  r.MatchType,
+		Position:   r.Position,
+		CreatedAt:  r.CreatedAt,
+		UpdatedAt:  r.UpdatedAt,
+	}
+}
+
+func toCategoryRuleListResponse(rules []*CategoryRule) CategoryRuleListResponse {
+	items := make([]CategoryRuleResponse, len(rules))
+	for i, r := range rules {
+		items[i] = toCategoryRuleResponse(r)
+	}
+	return CategoryRuleListResponse{Items: items}
+}
+
+type CategoryRuleBody struct {
+	CategoryID uuid.UUID `json:"category_id" doc:"Category assigned to matching items"`
+	Pattern    string    `json:"pattern" minLength:"1" maxLength:"200" doc:"Text matched against the item name, ignoring case"`
+	MatchType  MatchType `json:"match_type,omitempty" enum:"substring,regex" default:"substring" doc:"substring matches the pattern anywhere in the name; regex treats it as an RE2 regular expression"`
+}
+
+func (b CategoryRuleBody) toInput() CategoryRuleInput {
+	return CategoryRuleInput{CategoryID: b.CategoryID, Pattern: b.Pattern, MatchType: b.MatchType}
+}
+
+type CategoryRuleIDInput struct {
+	ID uuid.UUID `path:"id"`
+}
+
+type CreateCategoryRuleInput struct {
+	Body CategoryRuleBody
+}
+
+type UpdateCategoryRuleInput struct {
+	ID   uuid.UUID `path:"id"`
+	Body CategoryRuleBody
+}
+
+type ReorderCategoryRulesInput struct {
+	Body struct {
+		RuleIDs []uuid.UUID `json:"rule_ids" maxItems:"200" doc:"Every rule of the workspace, in the order they should be tried"`
+	}
+}
+
+type CategoryRuleOutput struct {
+	Body CategoryRuleResponse
+}
+
+type CategoryRuleListOutput struct {
+	Body CategoryRuleListResponse
+}
+
+type CategoryRuleListResponse struct {
+	Items []CategoryRuleResponse `json:"items"`
+}
+
+type CategoryRuleResponse struct {
+	ID         uuid.UUID `json:"id"`
+	CategoryID uuid.UUID `json:"category_id"`
+	Pattern    string    `json:"pattern"`
+	MatchType  MatchType `json:"match_type"`
+	Position   int       `json:"position" doc:"Rules are tried lowest position first"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
@@ -0,0 +1,287 @@
+package item
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/category"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// fakeCategoryRuleRepository keeps one workspace's rules in memory, listing
+// them in position order like the database does.
+type fakeCategoryRuleRepository struct {
+	rules []*CategoryRule
+}
+
+func (f *fakeCategoryRuleRepository) List(ctx context.Context, workspaceID uuid.UUID) ([]*CategoryRule, error) {
+	out := make([]*CategoryRule, len(f.rules))
+	copy(out, f.rules)
+	sort.SliceStable(out, func(i, j int) bool { return out[i].Position < out[j].Position })
+	return out, nil
+}
+
+func (f *fakeCategoryRuleRepository) Create(ctx context.Context, workspaceID uuid.UUID, rule CategoryRule) (*CategoryRule, error) {
+	f.rules = append(f.rules, &rule)
+	return &rule, nil
+}
+
+func (f *fakeCategoryRuleRepository) Update(ctx context.Context, workspaceID uuid.UUID, rule CategoryRule) (*CategoryRule, error) {
+	for _, r := range f.rules {
+		if r.ID == rule.ID {
+			r.CategoryID, r.Pattern, r.MatchType = rule.CategoryID, rule.Pattern, rule.MatchType
+			return r, nil
+		}
+	}
+	return nil, shared.ErrNotFound
+}
+
+func (f *fakeCategoryRuleRepository) UpdatePosition(ctx context.Context, workspaceID, id uuid.UUID, position int) error {
+	for _, r := range f.rules {
+		if r.ID == id {
+			r.Position = position
+		}
+	}
+	return nil
+}
+
+func (f *fakeCategoryRuleRepository) Delete(ctx context.Context, workspaceID, id uuid.UUID) error {
+	for i, r := range f.rules {
+		if r.ID == id {
+			f.rules = append(f.rules[:i], f.rules[i+1:]...)
+			return nil
+		}
+	}
+	return nil
+}
+
+// addRule stores a rule at the given position.
+func (f *fakeCategoryRuleRepository) addRule(categoryID uuid.UUID, pattern string, matchType MatchType, position int) *CategoryRule {
+	r := &CategoryRule{ID: uuid.New(), CategoryID: categoryID, Pattern: pattern, MatchType: matchType, Position: position}
+	f.rules = append(f.rules, r)
+	return r
+}
+
+func newCategoryRuleTestService() (*Service, *MockRepository, *MockCategoryRepository, *fakeCategoryRuleRepository) {
+	repo := new(MockRepository)
+	catRepo := new(MockCategoryRepository)
+	rules := &fakeCategoryRuleRepository{}
+	svc := NewService(repo, catRepo)
+	svc.SetCategoryRuleRepository(rules)
+	return svc, repo, catRepo, rules
+}
+
+func TestCategoryRule_Matches(t *testing.T) {
+	tests := []struct {
+		name      string
+		pattern   string
+		matchType MatchType
+		itemName  string
+		want      bool
+	}{
+		{"substring anywhere in the name", "drill", MatchSubstring, "Cordless Drill 18V", true},
+		{"substring ignores case", "USB", MatchSubstring, "usb-c charger", true},
+		{"substring without a match", "drill", MatchSubstring, "Hammer", false},
+		{"regex", `^usb-[ac]\b`, MatchRegex, "USB-C cable 2m", true},
+		{"regex without a match", `^usb-[ac]\b`, MatchRegex, "Old USB-B cable", false},
+		{"regex special characters are literal for substring", "c++", MatchSubstring, "C++ Primer", true},
+		{"an invalid regex never matches", "(", MatchRegex, "(", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &CategoryRule{Pattern: tt.pattern, MatchType: tt.matchType}
+			assert.Equal(t, tt.want, r.Matches(tt.itemName))
+		})
+	}
+}
+
+func TestService_Create_CategoryRules(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+	tools, cables := uuid.New(), uuid.New()
+
+	create := func(t *testing.T, svc *Service, repo *MockRepository, input CreateInput) *Item {
+		t.Helper()
+		repo.On("SKUExists", ctx, workspaceID, mock.Anything).Return(false, nil)
+		repo.On("ShortCodeExists", ctx, mock.AnythingOfType("string")).Return(false, nil)
+		repo.On("Save", ctx, mock.AnythingOfType("*item.Item")).Return(nil)
+		input.WorkspaceID = workspaceID
+		input.SKU = "SKU-" + strings.ReplaceAll(input.Name, " ", "-")
+		created, err := svc.Create(ctx, input)
+		require.NoError(t, err)
+		return created
+	}
+
+	t.Run("the first matching rule by position wins", func(t *testing.T) {
+		svc, repo, _, rules := newCategoryRuleTestService()
+		// Added out of order: position, not insertion order, decides.
+		rules.addRule(tools, "cable", MatchSubstring, 1)
+		rules.addRule(cables, `^usb`, MatchRegex, 0)
+
+		created := create(t, svc, repo, CreateInput{Name: "USB cable"})
+
+		require.NotNil(t, created.CategoryID())
+		assert.Equal(t, cables, *created.CategoryID())
+	})
+
+	t.Run("a later rule applies when earlier ones do not match", func(t *testing.T) {
+		svc, repo, _, rules := newCategoryRuleTestService()
+		rules.addRule(cables, `^usb`, MatchRegex, 0)
+		rules.addRule(tools, "drill", MatchSubstring, 1)
+
+		created := create(t, svc, repo, CreateInput{Name: "Cordless Drill"})
+
+		require.NotNil(t, created.CategoryID())
+		assert.Equal(t, tools, *created.CategoryID())
+	})
+
+	t.Run("no matching rule leaves the item uncategorized", func(t *testing.T) {
+		svc, repo, _, rules := newCategoryRuleTestService()
+		rules.addRule(tools, "drill", MatchSubstring, 0)
+
+		created := create(t, svc, repo, CreateInput{Name: "Garden hose"})
+
+		assert.Nil(t, created.CategoryID())
+	})
+
+	t.Run("an explicit category wins over the rules", func(t *testing.T) {
+		svc, repo, catRepo, rules := newCategoryRuleTestService()
+		rules.addRule(tools, "drill", MatchSubstring, 0)
+		chosen := uuid.New()
+		catRepo.On("FindByID", ctx, chosen, workspaceID).Return(&category.Category{}, nil)
+
+		created := create(t, svc, repo, CreateInput{Name: "Cordless Drill", CategoryID: &chosen})
+
+		require.NotNil(t, created.CategoryID())
+		assert.Equal(t, chosen, *created.CategoryID())
+	})
+
+	t.Run("without rule storage items stay uncategorized", func(t *testing.T) {
+		repo := new(MockRepository)
+		svc := NewService(repo, new(MockCategoryRepository))
+
+		created := create(t, svc, repo, CreateInput{Name: "Cordless Drill"})
+
+		assert.Nil(t, created.CategoryID())
+	})
+}
+
+func TestService_CreateCategoryRule(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+	categoryID := uuid.New()
+
+	t.Run("appends after the existing rules", func(t *testing.T) {
+		svc, _, catRepo, rules := newCategoryRuleTestService()
+		catRepo.On("FindByID", ctx, categoryID, workspaceID).Return(&category.Category{}, nil)
+		rules.addRule(categoryID, "drill", MatchSubstring, 4)
+
+		rule, err := svc.CreateCategoryRule(ctx, workspaceID, CategoryRuleInput{CategoryID: categoryID, Pattern: "  saw  "})
+
+		require.NoError(t, err)
+		assert.Equal(t, 5, rule.Position)
+		assert.Equal(t, "saw", rule.Pattern)
+		assert.Equal(t, MatchSubstring, rule.MatchType, "match type defaults to substring")
+	})
+
+	t.Run("rejects invalid input", func(t *testing.T) {
+		svc, _, catRepo, _ := newCategoryRuleTestService()
+		catRepo.On("FindByID", ctx, categoryID, workspaceID).Return(&category.Category{}, nil)
+
+		for name, input := range map[string]CategoryRuleInput{
+			"blank pattern":    {CategoryID: categoryID, Pattern: "   "},
+			"long pattern":     {CategoryID: categoryID, Pattern: strings.Repeat("x", MaxCategoryRulePatternLength+1)},
+			"invalid regex":    {CategoryID: categoryID, Pattern: "(", MatchType: MatchRegex},
+			"unknown matching": {CategoryID: categoryID, Pattern: "x", MatchType: "glob"},
+		} {
+			_, err := svc.CreateCategoryRule(ctx, workspaceID, input)
+			assert.ErrorIs(t, err, shared.ErrInvalidInput, name)
+		}
+	})
+
+	t.Run("rejects a category outside the workspace", func(t *testing.T) {
+		svc, _, catRepo, rules := newCategoryRuleTestService()
+		catRepo.On("FindByID", ctx, categoryID, workspaceID).Return(nil, shared.ErrNotFound)
+
+		_, err := svc.CreateCategoryRule(ctx, workspaceID, CategoryRuleInput{CategoryID: categoryID, Pattern: "drill"})
+
+		assert.ErrorIs(t, err, shared.ErrNotFound)
+		assert.Empty(t, rules.rules)
+	})
+
+	t.Run("caps the rules of a workspace", func(t *testing.T) {
+		svc, _, catRepo, rules := newCategoryRuleTestService()
+		catRepo.On("FindByID", ctx, categoryID, workspaceID).Return(&category.Category{}, nil)
+		for i := 0; i < MaxCategoryRules; i++ {
+			rules.addRule(categoryID, "x", MatchSubstring, i)
+		}
+
+		_, err := svc.CreateCategoryRule(ctx, workspaceID, CategoryRuleInput{CategoryID: categoryID, Pattern: "drill"})
+
+		assert.ErrorIs(t, err, ErrTooManyCategoryRules)
+	})
+}
+
+func TestService_UpdateCategoryRule(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+	categoryID := uuid.New()
+
+	svc, _, catRepo, rules := newCategoryRuleTestService()
+	catRepo.On("FindByID", ctx, categoryID, workspaceID).Return(&category.Category{}, nil)
+	existing := rules.addRule(uuid.New(), "drill", MatchSubstring, 3)
+
+	updated, err := svc.UpdateCategoryRule(ctx, workspaceID, existing.ID, CategoryRuleInput{CategoryID: categoryID, Pattern: `drills?$`, MatchType: MatchRegex})
+	require.NoError(t, err)
+	assert.Equal(t, categoryID, updated.CategoryID)
+	assert.Equal(t, 3, updated.Position, "editing keeps the position")
+
+	_, err = svc.UpdateCategoryRule(ctx, workspaceID, uuid.New(), CategoryRuleInput{CategoryID: categoryID, Pattern: "saw"})
+	assert.ErrorIs(t, err, ErrCategoryRuleNotFound)
+}
+
+func TestService_ReorderCategoryRules(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+	tools, cables := uuid.New(), uuid.New()
+
+	t.Run("changes which rule matches first", func(t *testing.T) {
+		svc, _, _, rules := newCategoryRuleTestService()
+		byName := rules.addRule(tools, "cable", MatchSubstring, 0)
+		byPrefix := rules.addRule(cables, "usb", MatchSubstring, 1)
+
+		reordered, err := svc.ReorderCategoryRules(ctx, workspaceID, []uuid.UUID{byPrefix.ID, byName.ID})
+
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{byPrefix.ID, byName.ID}, []uuid.UUID{reordered[0].ID, reordered[1].ID})
+		match, err := svc.MatchCategoryRule(ctx, workspaceID, "USB cable")
+		require.NoError(t, err)
+		assert.Equal(t, cables, match.CategoryID)
+	})
+
+	t.Run("rejects a list that is not every rule exactly once", func(t *testing.T) {
+		svc, _, _, rules := newCategoryRuleTestService()
+		a := rules.addRule(tools, "a", MatchSubstring, 0)
+		b := rules.addRule(tools, "b", MatchSubstring, 1)
+
+		for name, ids := range map[string][]uuid.UUID{
+			"missing":   {a.ID},
+			"duplicate": {a.ID, a.ID},
+			"unknown":   {a.ID, uuid.New()},
+			"extra":     {a.ID, b.ID, uuid.New()},
+		} {
+			_, err := svc.ReorderCategoryRules(ctx, workspaceID, ids)
+			assert.ErrorIs(t, err, ErrCategoryRuleOrder, name)
+		}
+		assert.Equal(t, 0, a.Position)
+		assert.Equal(t, 1, b.Position)
+	})
+}
@@ -27,6 +27,7 @@ type Item struct {
 	isArchived        *bool
 	lifetimeWarranty  *bool
 	needsReview       *bool
+	loanable          bool
 	warrantyDetails   *string
 	purchasedFrom     *uuid.UUID
 	minStockLevel     int
@@ -63,6 +64,7 @@ func NewItem(workspaceID uuid.UUID, name, sku string, minStockLevel int) (*Item,
 		isArchived:       &falseVal,
 		lifetimeWarranty: &falseVal,
 		needsReview:      &falseVal,
+		loanable:         true,
 		createdAt:        now,
 		updatedAt:        now,
 	}, nil
@@ -82,6 +84,7 @@ func Reconstruct(
 	shortCode string,
 	obsidianVaultPath, obsidianNotePath *string,
 	needsReview *bool,
+	loanable bool,
 	createdAt, updatedAt time.Time,
 ) *Item {
 	return &Item{
@@ -107,6 +110,7 @@ func Reconstruct(
 		obsidianVaultPath: obsidianVaultPath,
 		obsidianNotePath:  obsidianNotePath,
 		needsReview:       needsReview,
+		loanable:          loanable,
 		createdAt:         createdAt,
 		updatedAt:         updatedAt,
 	}
@@ -138,6 +142,11 @@ func (i *Item) ObsidianNotePath() *string  { return i.obsidianNotePath }
 func (i *Item) CreatedAt() time.Time       { return i.createdAt }
 func (i *Item) UpdatedAt() time.Time       { return i.updatedAt }
 
+// IsLoanable reports whether the item can be lent out. Fixed assets (a
+// mounted shelf, a built-in appliance) are not: their inventory can still be
+// AVAILABLE, meaning present, but is never offered for loan.
+func (i *Item) IsLoanable() bool { return i.loanable }
+
 type UpdateInput struct {
 	Name              string
 	Description       *string
@@ -156,6 +165,7 @@ type UpdateInput struct {
 	ObsidianVaultPath *string
 	ObsidianNotePath  *string
 	NeedsReview       *bool
+	Loanable          *bool
 }
 
 func (i *Item) Update(input UpdateInput) error {
@@ -185,6 +195,9 @@ func (i *Item) Update(input UpdateInput) error {
 	if input.NeedsReview != nil {
 		i.needsReview = input.NeedsReview
 	}
+	if input.Loanable != nil {
+		i.loanable = *input.Loanable
+	}
 	i.updatedAt = time.Now()
 	return nil
 }
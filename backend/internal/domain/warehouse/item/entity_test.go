@@ -446,6 +446,7 @@ func TestItem_Reconstruct(t *testing.T) {
 		&vaultPath,
 		&notePath,
 		&falseVal,
+		false,
 		now,
 		now,
 	)
@@ -467,6 +468,7 @@ func TestItem_Reconstruct(t *testing.T) {
 	assert.Equal(t, &falseVal, reconstructed.IsArchived())
 	assert.Equal(t, &trueVal, reconstructed.LifetimeWarranty())
 	assert.Equal(t, &warrantyDetails, reconstructed.WarrantyDetails())
+	assert.False(t, reconstructed.IsLoanable())
 	assert.Equal(t, &purchasedFrom, reconstructed.PurchasedFrom())
 	assert.Equal(t, 5, reconstructed.MinStockLevel())
 	assert.Equal(t, shortCode, reconstructed.ShortCode())
@@ -536,6 +538,35 @@ func TestItem_Update_WithNeedsReview(t *testing.T) {
 	})
 }
 
+func TestItem_Loanable(t *testing.T) {
+	workspaceID := uuid.New()
+
+	t.Run("new items are loanable", func(t *testing.T) {
+		testItem, err := item.NewItem(workspaceID, "Test Item", "TEST-001", 0)
+		assert.NoError(t, err)
+		assert.True(t, testItem.IsLoanable())
+	})
+
+	t.Run("update with Loanable=false marks a fixed asset", func(t *testing.T) {
+		testItem, _ := item.NewItem(workspaceID, "Wall shelf", "TEST-001", 0)
+
+		falseVal := false
+		err := testItem.Update(item.UpdateInput{Name: "Wall shelf", Loanable: &falseVal})
+		assert.NoError(t, err)
+		assert.False(t, testItem.IsLoanable())
+	})
+
+	t.Run("update with Loanable=nil preserves value", func(t *testing.T) {
+		testItem, _ := item.NewItem(workspaceID, "Wall shelf", "TEST-001", 0)
+		falseVal := false
+		_ = testItem.Update(item.UpdateInput{Name: "Wall shelf", Loanable: &falseVal})
+
+		err := testItem.Update(item.UpdateInput{Name: "Wall shelf"})
+		assert.NoError(t, err)
+		assert.False(t, testItem.IsLoanable())
+	})
+}
+
 // Helper function
 func strPtr(s string) *string {
 	return &s
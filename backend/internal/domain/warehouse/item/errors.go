@@ -1,10 +1,28 @@
 package item
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
 
 var (
 	ErrItemNotFound    = errors.New("item not found")
 	ErrSKUTaken        = errors.New("SKU already exists in workspace")
 	ErrShortCodeTaken  = errors.New("short code already exists in workspace")
 	ErrInvalidMinStock = errors.New("minimum stock level must be non-negative")
+	ErrInvalidSort     = errors.New("invalid sort field or direction")
+	ErrTooManyIDs      = errors.New("too many item ids requested")
+
+	ErrInvalidIdentifierType = errors.New("invalid identifier type")
+	ErrIdentifierTaken       = errors.New("code already identifies an item in workspace")
+	ErrIdentifierNotFound    = errors.New("identifier not found")
+
+	ErrMergeIntoSelf    = shared.NewFieldError(shared.ErrInvalidInput, "merge_item_id", "cannot merge an item into itself")
+	ErrInvalidThreshold = shared.NewFieldError(shared.ErrInvalidInput, "threshold", "similarity threshold must be above 0 and at most 1")
+
+	ErrCategoryRuleNotFound = shared.NewDomainError(shared.ErrNotFound, "category rule not found")
+	ErrTooManyCategoryRules = shared.NewDomainError(shared.ErrConflict, fmt.Sprintf("a workspace can have at most %d category rules", MaxCategoryRules))
+	ErrCategoryRuleOrder    = shared.NewFieldError(shared.ErrInvalidInput, "rule_ids", "rule_ids must list every category rule exactly once")
 )
@@ -3,13 +3,16 @@ package item
 import (
 	"context"
 	"errors"
+	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/danielgtaylor/huma/v2"
 	"github.com/google/uuid"
 
 	appMiddleware "github.com/antti/home-warehouse/go-backend/internal/api/middleware"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/customfield"
 	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/itemphoto"
 	"github.com/antti/home-warehouse/go-backend/internal/infra/events"
 	"github.com/antti/home-warehouse/go-backend/internal/shared"
@@ -32,7 +35,13 @@ type PrimaryPhotoLookup interface {
 
 // PrimaryPhotoURLGenerator mirrors itemphoto.PhotoURLGenerator so the item
 // handler can emit the same URL shape when decorating ItemResponse.
-type PrimaryPhotoURLGenerator func(workspaceID, itemID, photoID uuid.UUID, isThumbnail bool) string
+type PrimaryPhotoURLGenerator func(ctx context.Context, workspaceID, itemID, photoID uuid.UUID, isThumbnail bool) string
+
+// CustomValueLookup is the narrow interface the item handler needs from the
+// customfield service to embed custom field values in ItemResponse.
+type CustomValueLookup interface {
+	ValuesByItemIDs(ctx context.Context, workspaceID uuid.UUID, itemIDs []uuid.UUID) (map[uuid.UUID][]customfield.ItemValue, error)
+}
 
 // stringPtrOrNil returns a pointer to s, or nil if s is empty.
 func stringPtrOrNil(s string) *string {
@@ -81,20 +90,31 @@ func patchOrCurrent[T any](patch, current *T) *T {
 //
 // photoURLGen is optional — required only when photos is non-nil. Generates the
 // same URL shape as itemphoto.RegisterRoutes for consistent URLs across endpoints.
-func RegisterRoutes(api huma.API, svc ServiceInterface, broadcaster *events.Broadcaster, photos PrimaryPhotoLookup, photoURLGen PrimaryPhotoURLGenerator) {
-	huma.Get(api, "/items", listItems(svc, photos, photoURLGen))
+//
+// customValues is optional — when non-nil, the same handlers embed the item's
+// custom field values. Pass nil to skip them.
+func RegisterRoutes(api huma.API, svc ServiceInterface, broadcaster *events.Broadcaster, photos PrimaryPhotoLookup, photoURLGen PrimaryPhotoURLGenerator, customValues CustomValueLookup) {
+	huma.Get(api, "/items", listItems(svc, photos, photoURLGen, customValues))
 	huma.Get(api, "/items/search", searchItems(svc, photoURLGen))
-	huma.Get(api, "/items/by-barcode/{code}", lookupItemByBarcode(svc, photos, photoURLGen))
-	huma.Get(api, routeItemByID, getItem(svc, photos, photoURLGen))
-	huma.Get(api, "/items/by-category/{category_id}", listItemsByCategory(svc, photos, photoURLGen))
+	huma.Get(api, "/items/by-barcode/{code}", lookupItemByBarcode(svc, photos, photoURLGen, customValues))
+	huma.Get(api, "/items/recent", listRecentItems(svc, photos, photoURLGen))
+	huma.Get(api, "/items/similar", listSimilarItems(svc))
+	huma.Get(api, routeItemByID, getItem(svc, photos, photoURLGen, customValues))
+	huma.Get(api, "/items/by-category/{category_id}", listItemsByCategory(svc, photos, photoURLGen, customValues))
 	huma.Post(api, "/items", createItem(svc, broadcaster, photoURLGen))
-	huma.Patch(api, routeItemByID, updateItem(svc, broadcaster, photos, photoURLGen))
+	huma.Patch(api, routeItemByID, updateItem(svc, broadcaster, photos, photoURLGen, customValues))
+	huma.Post(api, "/items/{id}/duplicate", duplicateItem(svc, broadcaster, photoURLGen))
+	huma.Post(api, "/items/reassign-category", reassignCategory(svc, broadcaster))
+	huma.Post(api, "/items/{id}/merge", mergeItems(svc, broadcaster))
 	huma.Post(api, "/items/{id}/archive", archiveItem(svc, broadcaster))
 	huma.Post(api, "/items/{id}/restore", restoreItem(svc, broadcaster))
 	huma.Delete(api, routeItemByID, deleteItem(svc, broadcaster))
 	huma.Get(api, "/items/{id}/labels", getItemLabels(svc))
 	huma.Post(api, "/items/{id}/labels/{label_id}", attachItemLabel(svc))
 	huma.Delete(api, "/items/{id}/labels/{label_id}", detachItemLabel(svc))
+	huma.Get(api, "/items/{id}/identifiers", listItemIdentifiers(svc))
+	huma.Post(api, "/items/{id}/identifiers", addItemIdentifier(svc))
+	huma.Delete(api, "/items/{id}/identifiers/{identifier_id}", removeItemIdentifier(svc))
 }
 
 // lookupSinglePrimary fetches the primary photo for one item, best-effort: a
@@ -121,14 +141,32 @@ func trueOrNil(v bool) *bool {
 	return nil
 }
 
+// parseOptionalBool maps a tri-state "true"/"false" query param to a filter
+// pointer; an absent param → nil → no filter on this dimension.
+func parseOptionalBool(v string) *bool {
+	switch v {
+	case "true":
+		b := true
+		return &b
+	case "false":
+		b := false
+		return &b
+	}
+	return nil
+}
+
 // listItems returns the handler for GET /items.
-func listItems(svc ServiceInterface, photos PrimaryPhotoLookup, photoURLGen PrimaryPhotoURLGenerator) func(context.Context, *ListItemsInput) (*ListItemsOutput, error) {
+func listItems(svc ServiceInterface, photos PrimaryPhotoLookup, photoURLGen PrimaryPhotoURLGenerator, customValues CustomValueLookup) func(context.Context, *ListItemsInput) (*ListItemsOutput, error) {
 	return func(ctx context.Context, input *ListItemsInput) (*ListItemsOutput, error) {
 		workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
 		if !ok {
 			return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
 		}
 
+		if input.IDs != "" {
+			return listItemsByIDs(ctx, svc, photos, photoURLGen, customValues, workspaceID, input.IDs)
+		}
+
 		pagination := shared.Pagination{Page: input.Page, PageSize: input.Limit}
 
 		// Parse CategoryID — malformed UUID is silently treated as no filter
@@ -151,21 +189,28 @@ func listItems(svc ServiceInterface, photos PrimaryPhotoLookup, photoURLGen Prim
 			CategoryID:      categoryID,
 			IsInsured:       trueOrNil(input.IsInsured),
 			NeedsReview:     trueOrNil(input.NeedsReview),
-			IncludeArchived: input.Archived,
+			Brand:           stringPtrOrNil(input.Brand),
+			HasInventory:    parseOptionalBool(input.HasInventory),
+			IncludeArchived: input.Archived || input.Include == "archived",
 			Sort:            input.Sort,
 			SortDir:         input.SortDir,
 		}
 
 		items, total, err := svc.ListFiltered(ctx, workspaceID, filters, pagination)
 		if err != nil {
+			if errors.Is(err, ErrInvalidSort) {
+				return nil, huma.Error400BadRequest("invalid sort field or direction")
+			}
 			return nil, huma.Error500InternalServerError("failed to list items")
 		}
 
 		primaryByItem := lookupPrimaryPhotos(ctx, photos, workspaceID, items)
+		valuesByItem := lookupCustomValues(ctx, customValues, workspaceID, items)
 
 		responses := make([]ItemResponse, len(items))
 		for i, item := range items {
-			responses[i] = toItemResponse(item, primaryByItem[item.ID()], photoURLGen)
+			responses[i] = toItemResponse(ctx, item, primaryByItem[item.ID()], photoURLGen)
+			responses[i].CustomFields = customfield.ToValueResponses(valuesByItem[item.ID()])
 		}
 
 		totalPages := 1
@@ -184,6 +229,47 @@ func listItems(svc ServiceInterface, photos PrimaryPhotoLookup, photoURLGen Prim
 	}
 }
 
+// listItemsByIDs serves GET /items?ids=a,b,c: the listed items in the
+// requested order, in one query. Unknown IDs are left out; filters and
+// pagination do not apply.
+func listItemsByIDs(ctx context.Context, svc ServiceInterface, photos PrimaryPhotoLookup, photoURLGen PrimaryPhotoURLGenerator, customValues CustomValueLookup, workspaceID uuid.UUID, rawIDs string) (*ListItemsOutput, error) {
+	parts := strings.Split(rawIDs, ",")
+	ids := make([]uuid.UUID, 0, len(parts))
+	for _, part := range parts {
+		id, err := uuid.Parse(strings.TrimSpace(part))
+		if err != nil {
+			return nil, huma.Error400BadRequest(fmt.Sprintf("invalid item id %q", part))
+		}
+		ids = append(ids, id)
+	}
+
+	items, err := svc.GetByIDs(ctx, workspaceID, ids)
+	if err != nil {
+		if errors.Is(err, ErrTooManyIDs) {
+			return nil, huma.Error400BadRequest(fmt.Sprintf("at most %d ids can be fetched at once", MaxItemsByIDs))
+		}
+		return nil, huma.Error500InternalServerError("failed to list items")
+	}
+
+	primaryByItem := lookupPrimaryPhotos(ctx, photos, workspaceID, items)
+	valuesByItem := lookupCustomValues(ctx, customValues, workspaceID, items)
+
+	responses := make([]ItemResponse, len(items))
+	for i, item := range items {
+		responses[i] = toItemResponse(ctx, item, primaryByItem[item.ID()], photoURLGen)
+		responses[i].CustomFields = customfield.ToValueResponses(valuesByItem[item.ID()])
+	}
+
+	return &ListItemsOutput{
+		Body: ItemListResponse{
+			Items:      responses,
+			Total:      len(responses),
+			Page:       1,
+			TotalPages: 1,
+		},
+	}, nil
+}
+
 // searchItems returns the handler for GET /items/search.
 func searchItems(svc ServiceInterface, photoURLGen PrimaryPhotoURLGenerator) func(context.Context, *SearchItemsInput) (*SearchItemsOutput, error) {
 	return func(ctx context.Context, input *SearchItemsInput) (*SearchItemsOutput, error) {
@@ -201,7 +287,7 @@ func searchItems(svc ServiceInterface, photoURLGen PrimaryPhotoURLGenerator) fun
 		// callers don't render thumbnails.
 		responses := make([]ItemResponse, len(items))
 		for i, item := range items {
-			responses[i] = toItemResponse(item, nil, photoURLGen)
+			responses[i] = toItemResponse(ctx, item, nil, photoURLGen)
 		}
 
 		// svc.Search exposes no total-count query (limit-capped autocomplete
@@ -225,7 +311,9 @@ func searchItems(svc ServiceInterface, photoURLGen PrimaryPhotoURLGenerator) fun
 // covers name/brand/model/description ONLY — barcode + sku are NOT
 // indexed by the generated tsvector (see
 // backend/db/migrations/001_initial_schema.sql:495-500). This handler
-// uses repo.FindByBarcode which hits the ix_items_barcode btree index.
+// uses repo.FindByBarcode which hits the ix_items_barcode btree index,
+// falling back to the item's additional identifiers. The response reports
+// which code matched in matched_identifier.
 //
 // Case-sensitivity: Postgres text = operator is byte-wise, so upstream
 // callers (frontend itemsApi.lookupByBarcode) inherit D-07 case-sensitive
@@ -235,14 +323,14 @@ func searchItems(svc ServiceInterface, photoURLGen PrimaryPhotoURLGenerator) fun
 // workspace_id = $1 and the appMiddleware.GetWorkspaceID(ctx) value
 // comes from the route-mounting workspace-context middleware — so a
 // call for workspace A never leaks a match from workspace B.
-func lookupItemByBarcode(svc ServiceInterface, photos PrimaryPhotoLookup, photoURLGen PrimaryPhotoURLGenerator) func(context.Context, *LookupItemByBarcodeInput) (*LookupItemByBarcodeOutput, error) {
+func lookupItemByBarcode(svc ServiceInterface, photos PrimaryPhotoLookup, photoURLGen PrimaryPhotoURLGenerator, customValues CustomValueLookup) func(context.Context, *LookupItemByBarcodeInput) (*LookupItemByBarcodeOutput, error) {
 	return func(ctx context.Context, input *LookupItemByBarcodeInput) (*LookupItemByBarcodeOutput, error) {
 		workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
 		if !ok {
 			return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
 		}
 
-		itm, err := svc.LookupByBarcode(ctx, workspaceID, input.Code)
+		itm, identifier, err := svc.LookupByBarcode(ctx, workspaceID, input.Code)
 		if err != nil {
 			if errors.Is(err, ErrItemNotFound) {
 				return nil, huma.Error404NotFound(msgItemNotFound)
@@ -255,14 +343,23 @@ func lookupItemByBarcode(svc ServiceInterface, photos PrimaryPhotoLookup, photoU
 		// thumbnail if one exists).
 		primary := lookupSinglePrimary(ctx, photos, itm.ID(), workspaceID, "item lookup-by-barcode")
 
+		resp := toItemResponse(ctx, itm, primary, photoURLGen)
+		resp.CustomFields = customfield.ToValueResponses(lookupCustomValues(ctx, customValues, workspaceID, []*Item{itm})[itm.ID()])
+
+		matched := MatchedIdentifierResponse{Type: "barcode", Value: input.Code, Primary: true}
+		if identifier != nil {
+			id := identifier.ID()
+			matched = MatchedIdentifierResponse{ID: &id, Type: string(identifier.Type()), Value: identifier.Value()}
+		}
+
 		return &LookupItemByBarcodeOutput{
-			Body: toItemResponse(itm, primary, photoURLGen),
+			Body: BarcodeLookupResponse{ItemResponse: resp, MatchedIdentifier: matched},
 		}, nil
 	}
 }
 
 // getItem returns the handler for GET /items/{id}.
-func getItem(svc ServiceInterface, photos PrimaryPhotoLookup, photoURLGen PrimaryPhotoURLGenerator) func(context.Context, *GetItemInput) (*GetItemOutput, error) {
+func getItem(svc ServiceInterface, photos PrimaryPhotoLookup, photoURLGen PrimaryPhotoURLGenerator, customValues CustomValueLookup) func(context.Context, *GetItemInput) (*GetItemOutput, error) {
 	return func(ctx context.Context, input *GetItemInput) (*GetItemOutput, error) {
 		workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
 		if !ok {
@@ -281,14 +378,100 @@ func getItem(svc ServiceInterface, photos PrimaryPhotoLookup, photoURLGen Primar
 		// degrade to no thumbnail rather than failing the whole request).
 		primary := lookupSinglePrimary(ctx, photos, input.ID, workspaceID, "item detail")
 
+		resp := toItemResponse(ctx, item, primary, photoURLGen)
+		resp.CustomFields = customfield.ToValueResponses(lookupCustomValues(ctx, customValues, workspaceID, []*Item{item})[item.ID()])
+
+		// Recording the view is best-effort: a Redis hiccup must not fail the
+		// detail fetch. A 304 still counts as a view.
+		if authUser, ok := appMiddleware.GetAuthUser(ctx); ok {
+			if err := svc.RecordView(ctx, workspaceID, authUser.ID, item.ID()); err != nil {
+				log.Printf("item detail: failed to record view of item %s: %v", item.ID(), err)
+			}
+		}
+
+		etag := appMiddleware.ETag(resp)
+		if err := appMiddleware.NotModified(input.IfNoneMatch, etag); err != nil {
+			return nil, err
+		}
 		return &GetItemOutput{
-			Body: toItemResponse(item, primary, photoURLGen),
+			ETag: etag,
+			Body: resp,
 		}, nil
 	}
 }
 
+// listRecentItems returns the handler for GET /items/recent: the caller's
+// most recently viewed items in the workspace, newest first.
+func listRecentItems(svc ServiceInterface, photos PrimaryPhotoLookup, photoURLGen PrimaryPhotoURLGenerator) func(context.Context, *ListRecentItemsInput) (*ListItemsOutput, error) {
+	return func(ctx context.Context, input *ListRecentItemsInput) (*ListItemsOutput, error) {
+		workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+		if !ok {
+			return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+		}
+		authUser, ok := appMiddleware.GetAuthUser(ctx)
+		if !ok {
+			return nil, huma.Error401Unauthorized("authentication required")
+		}
+
+		items, err := svc.ListRecentlyViewed(ctx, workspaceID, authUser.ID, input.Limit)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to list recently viewed items")
+		}
+
+		primaryByItem := lookupPrimaryPhotos(ctx, photos, workspaceID, items)
+
+		responses := make([]ItemResponse, len(items))
+		for i, item := range items {
+			responses[i] = toItemResponse(ctx, item, primaryByItem[item.ID()], photoURLGen)
+		}
+
+		return &ListItemsOutput{
+			Body: ItemListResponse{
+				Items:      responses,
+				Total:      len(responses),
+				Page:       1,
+				TotalPages: 1,
+			},
+		}, nil
+	}
+}
+
+// listSimilarItems returns the handler for GET /items/similar: pairs of
+// items that look like duplicates, as merge suggestions.
+func listSimilarItems(svc ServiceInterface) func(context.Context, *ListSimilarItemsInput) (*ListSimilarItemsOutput, error) {
+	return func(ctx context.Context, input *ListSimilarItemsInput) (*ListSimilarItemsOutput, error) {
+		workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+		if !ok {
+			return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+		}
+
+		pairs, err := svc.FindSimilarItems(ctx, workspaceID, input.Threshold)
+		if err != nil {
+			if errors.Is(err, shared.ErrInvalidInput) {
+				return nil, appMiddleware.MapDomainError(err)
+			}
+			return nil, huma.Error500InternalServerError("failed to find similar items")
+		}
+
+		responses := make([]SimilarPairResponse, len(pairs))
+		for i, pair := range pairs {
+			responses[i] = SimilarPairResponse{
+				Item:       toSimilarItemResponse(pair.Item),
+				Match:      toSimilarItemResponse(pair.Match),
+				Similarity: pair.Similarity,
+			}
+		}
+
+		return &ListSimilarItemsOutput{Body: SimilarItemsResponse{Pairs: responses}}, nil
+	}
+}
+
+func toSimilarItemResponse(i SimilarItem) SimilarItemResponse {
+	return SimilarItemResponse{ID: i.ID, Name: i.Name, SKU: i.SKU, Brand: i.Brand}
+}
+
 // listItemsByCategory returns the handler for GET /items/by-category/{category_id}.
-func listItemsByCategory(svc ServiceInterface, photos PrimaryPhotoLookup, photoURLGen PrimaryPhotoURLGenerator) func(context.Context, *ListItemsByCategoryInput) (*ListItemsOutput, error) {
+func listItemsByCategory(svc ServiceInterface, photos PrimaryPhotoLookup, photoURLGen PrimaryPhotoURLGenerator, customValues CustomValueLookup) func(context.Context, *ListItemsByCategoryInput) (*ListItemsOutput, error) {
 	return func(ctx context.Context, input *ListItemsByCategoryInput) (*ListItemsOutput, error) {
 		workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
 		if !ok {
@@ -302,10 +485,12 @@ func listItemsByCategory(svc ServiceInterface, photos PrimaryPhotoLookup, photoU
 		}
 
 		primaryByItem := lookupPrimaryPhotos(ctx, photos, workspaceID, items)
+		valuesByItem := lookupCustomValues(ctx, customValues, workspaceID, items)
 
 		responses := make([]ItemResponse, len(items))
 		for i, item := range items {
-			responses[i] = toItemResponse(item, primaryByItem[item.ID()], photoURLGen)
+			responses[i] = toItemResponse(ctx, item, primaryByItem[item.ID()], photoURLGen)
+			responses[i].CustomFields = customfield.ToValueResponses(valuesByItem[item.ID()])
 		}
 
 		// svc.ListByCategory exposes no total-count query, so Total reflects
@@ -358,6 +543,7 @@ func createItem(svc ServiceInterface, broadcaster *events.Broadcaster, photoURLG
 			ObsidianVaultPath: input.Body.ObsidianVaultPath,
 			ObsidianNotePath:  input.Body.ObsidianNotePath,
 			NeedsReview:       input.Body.NeedsReview,
+			Loanable:          input.Body.Loanable,
 			IdempotencyKey:    input.IdempotencyKey,
 		})
 		if err != nil {
@@ -392,7 +578,134 @@ func createItem(svc ServiceInterface, broadcaster *events.Broadcaster, photoURLG
 
 		// Newly-created items have no photos yet — pass nil primary.
 		return &CreateItemOutput{
-			Body: toItemResponse(item, nil, photoURLGen),
+			Body: toItemResponse(ctx, item, nil, photoURLGen),
+		}, nil
+	}
+}
+
+// duplicateItem returns the handler for POST /items/{id}/duplicate.
+func duplicateItem(svc ServiceInterface, broadcaster *events.Broadcaster, photoURLGen PrimaryPhotoURLGenerator) func(context.Context, *GetItemInput) (*CreateItemOutput, error) {
+	return func(ctx context.Context, input *GetItemInput) (*CreateItemOutput, error) {
+		workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+		if !ok {
+			return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+		}
+
+		// Duplicates are created immediately rather than through the approval
+		// queue.
+		if role, ok := appMiddleware.GetRole(ctx); !ok || (role != "owner" && role != "admin") {
+			return nil, huma.Error403Forbidden("only workspace owners and admins can duplicate items")
+		}
+
+		authUser, _ := appMiddleware.GetAuthUser(ctx)
+
+		item, err := svc.DuplicateItem(ctx, workspaceID, input.ID)
+		if err != nil {
+			if errors.Is(err, ErrItemNotFound) {
+				return nil, huma.Error404NotFound(msgItemNotFound)
+			}
+			if errors.Is(err, ErrSKUTaken) {
+				return nil, huma.Error409Conflict("no free SKU for the copy; rename existing copies first")
+			}
+			return nil, appMiddleware.MapDomainError(err)
+		}
+
+		publishItemLifecycleEvent(ctx, broadcaster, authUser, workspaceID, "item.created", item.ID())
+
+		// Photos are not copied — pass nil primary.
+		return &CreateItemOutput{
+			Body: toItemResponse(ctx, item, nil, photoURLGen),
+		}, nil
+	}
+}
+
+// reassignCategory returns the handler for POST /items/reassign-category:
+// moves many items to one category (or clears it) at once, e.g. when merging
+// or splitting categories. Each moved item publishes item.updated, which the
+// activity tap records.
+func reassignCategory(svc ServiceInterface, broadcaster *events.Broadcaster) func(context.Context, *ReassignCategoryInput) (*ReassignCategoryOutput, error) {
+	return func(ctx context.Context, input *ReassignCategoryInput) (*ReassignCategoryOutput, error) {
+		workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+		if !ok {
+			return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+		}
+
+		authUser, _ := appMiddleware.GetAuthUser(ctx)
+
+		updated, err := svc.ReassignCategory(ctx, workspaceID, input.Body.ItemIDs, input.Body.CategoryID)
+		if err != nil {
+			return nil, appMiddleware.MapDomainError(err)
+		}
+
+		if broadcaster != nil && authUser != nil {
+			userName := appMiddleware.GetUserDisplayName(ctx)
+			for _, it := range updated {
+				broadcaster.Publish(workspaceID, events.Event{
+					Type:       "item.updated",
+					EntityID:   it.ID.String(),
+					EntityType: "item",
+					UserID:     authUser.ID,
+					Data: map[string]any{
+						"id":          it.ID,
+						"name":        it.Name,
+						"category_id": input.Body.CategoryID,
+						"user_name":   userName,
+					},
+				})
+			}
+		}
+
+		return &ReassignCategoryOutput{Body: ReassignCategoryResponse{Updated: len(updated)}}, nil
+	}
+}
+
+// mergeItems returns the handler for POST /items/{id}/merge: folds another
+// item into this one. Its inventory, loans, labels and photos move here and
+// it is deleted.
+func mergeItems(svc ServiceInterface, broadcaster *events.Broadcaster) func(context.Context, *MergeItemsInput) (*MergeItemsOutput, error) {
+	return func(ctx context.Context, input *MergeItemsInput) (*MergeItemsOutput, error) {
+		workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+		if !ok {
+			return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+		}
+
+		result, err := svc.MergeItems(ctx, workspaceID, input.ID, input.Body.MergeItemID, input.Body.SKU)
+		if err != nil {
+			if errors.Is(err, ErrItemNotFound) {
+				return nil, huma.Error404NotFound(msgItemNotFound)
+			}
+			if errors.Is(err, ErrSKUTaken) {
+				return nil, huma.Error409Conflict("SKU already exists in workspace")
+			}
+			return nil, appMiddleware.MapDomainError(err)
+		}
+
+		authUser, _ := appMiddleware.GetAuthUser(ctx)
+		if broadcaster != nil && authUser != nil {
+			userName := appMiddleware.GetUserDisplayName(ctx)
+			broadcaster.Publish(workspaceID, events.Event{
+				Type:       "item.deleted",
+				EntityID:   input.Body.MergeItemID.String(),
+				EntityType: "item",
+				UserID:     authUser.ID,
+				Data: map[string]any{
+					"merged_into_id":  input.ID,
+					"inventory_moved": result.InventoryMoved,
+					"loans_moved":     result.LoansMoved,
+					"labels_moved":    result.LabelsMoved,
+					"photos_moved":    result.PhotosMoved,
+					"user_name":       userName,
+				},
+			})
+		}
+
+		return &MergeItemsOutput{
+			Body: MergeItemsResponse{
+				InventoryMoved: result.InventoryMoved,
+				LoansMoved:     result.LoansMoved,
+				LabelsMoved:    result.LabelsMoved,
+				PhotosMoved:    result.PhotosMoved,
+			},
 		}, nil
 	}
 }
@@ -419,7 +732,7 @@ func createItem(svc ServiceInterface, broadcaster *events.Broadcaster, photoURLG
 // is a no-op — to clear a string field a client must send "" explicitly.
 // That trade-off is deliberate: a partial PATCH must never silently
 // destroy data it did not mention.
-func updateItem(svc ServiceInterface, broadcaster *events.Broadcaster, photos PrimaryPhotoLookup, photoURLGen PrimaryPhotoURLGenerator) func(context.Context, *UpdateItemInput) (*UpdateItemOutput, error) {
+func updateItem(svc ServiceInterface, broadcaster *events.Broadcaster, photos PrimaryPhotoLookup, photoURLGen PrimaryPhotoURLGenerator, customValues CustomValueLookup) func(context.Context, *UpdateItemInput) (*UpdateItemOutput, error) {
 	return func(ctx context.Context, input *UpdateItemInput) (*UpdateItemOutput, error) {
 		workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
 		if !ok {
@@ -457,6 +770,7 @@ func updateItem(svc ServiceInterface, broadcaster *events.Broadcaster, photos Pr
 			ObsidianVaultPath: patchString(input.Body.ObsidianVaultPath, currentItem.ObsidianVaultPath()),
 			ObsidianNotePath:  patchString(input.Body.ObsidianNotePath, currentItem.ObsidianNotePath()),
 			NeedsReview:       patchOrCurrent(input.Body.NeedsReview, currentItem.NeedsReview()),
+			Loanable:          input.Body.Loanable,
 		}
 
 		if input.Body.Name != nil {
@@ -491,8 +805,11 @@ func updateItem(svc ServiceInterface, broadcaster *events.Broadcaster, photos Pr
 		// callers that re-render the detail view after PATCH.
 		primary := lookupSinglePrimary(ctx, photos, input.ID, workspaceID, "item update")
 
+		resp := toItemResponse(ctx, item, primary, photoURLGen)
+		resp.CustomFields = customfield.ToValueResponses(lookupCustomValues(ctx, customValues, workspaceID, []*Item{item})[item.ID()])
+
 		return &UpdateItemOutput{
-			Body: toItemResponse(item, primary, photoURLGen),
+			Body: resp,
 		}, nil
 	}
 }
@@ -651,6 +968,77 @@ func detachItemLabel(svc ServiceInterface) func(context.Context, *ItemLabelInput
 	}
 }
 
+// listItemIdentifiers returns the handler for GET /items/{id}/identifiers.
+func listItemIdentifiers(svc ServiceInterface) func(context.Context, *GetItemInput) (*ListItemIdentifiersOutput, error) {
+	return func(ctx context.Context, input *GetItemInput) (*ListItemIdentifiersOutput, error) {
+		workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+		if !ok {
+			return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+		}
+
+		identifiers, err := svc.ListIdentifiers(ctx, workspaceID, input.ID)
+		if err != nil {
+			if errors.Is(err, ErrItemNotFound) {
+				return nil, huma.Error404NotFound(msgItemNotFound)
+			}
+			return nil, huma.Error500InternalServerError("failed to list item identifiers")
+		}
+
+		resp := make([]ItemIdentifierResponse, len(identifiers))
+		for i, identifier := range identifiers {
+			resp[i] = toIdentifierResponse(identifier)
+		}
+		return &ListItemIdentifiersOutput{
+			Body: ItemIdentifiersResponse{Identifiers: resp},
+		}, nil
+	}
+}
+
+// addItemIdentifier returns the handler for POST /items/{id}/identifiers.
+func addItemIdentifier(svc ServiceInterface) func(context.Context, *AddItemIdentifierInput) (*ItemIdentifierOutput, error) {
+	return func(ctx context.Context, input *AddItemIdentifierInput) (*ItemIdentifierOutput, error) {
+		workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+		if !ok {
+			return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+		}
+
+		identifier, err := svc.AddIdentifier(ctx, workspaceID, input.ID, IdentifierType(input.Body.Type), input.Body.Value)
+		if err != nil {
+			switch {
+			case errors.Is(err, ErrItemNotFound):
+				return nil, huma.Error404NotFound(msgItemNotFound)
+			case errors.Is(err, ErrIdentifierTaken):
+				return nil, huma.Error409Conflict(err.Error())
+			case errors.Is(err, ErrInvalidIdentifierType):
+				return nil, huma.Error400BadRequest(err.Error())
+			}
+			return nil, appMiddleware.MapDomainError(err)
+		}
+
+		return &ItemIdentifierOutput{Body: toIdentifierResponse(identifier)}, nil
+	}
+}
+
+// removeItemIdentifier returns the handler for
+// DELETE /items/{id}/identifiers/{identifier_id}.
+func removeItemIdentifier(svc ServiceInterface) func(context.Context, *ItemIdentifierInput) (*struct{}, error) {
+	return func(ctx context.Context, input *ItemIdentifierInput) (*struct{}, error) {
+		workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+		if !ok {
+			return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+		}
+
+		if err := svc.RemoveIdentifier(ctx, workspaceID, input.ID, input.IdentifierID); err != nil {
+			if errors.Is(err, ErrIdentifierNotFound) {
+				return nil, huma.Error404NotFound("identifier not found")
+			}
+			return nil, huma.Error500InternalServerError("failed to remove item identifier")
+		}
+
+		return nil, nil
+	}
+}
+
 // lookupPrimaryPhotos wraps the batched primary-photo fetch with graceful
 // degradation: nil photos source or zero items returns empty map; errors log
 // but do not fail the caller (primary photos are decorative on list pages).
@@ -670,7 +1058,26 @@ func lookupPrimaryPhotos(ctx context.Context, photos PrimaryPhotoLookup, workspa
 	return primaryByItem
 }
 
-func toItemResponse(i *Item, primary *itemphoto.ItemPhoto, photoURLGen PrimaryPhotoURLGenerator) ItemResponse {
+// lookupCustomValues batch-fetches custom field values with the same
+// graceful degradation as lookupPrimaryPhotos: a nil source, zero items or a
+// lookup error yields no values rather than failing the caller.
+func lookupCustomValues(ctx context.Context, customValues CustomValueLookup, workspaceID uuid.UUID, items []*Item) map[uuid.UUID][]customfield.ItemValue {
+	if customValues == nil || len(items) == 0 {
+		return nil
+	}
+	itemIDs := make([]uuid.UUID, 0, len(items))
+	for _, it := range items {
+		itemIDs = append(itemIDs, it.ID())
+	}
+	valuesByItem, err := customValues.ValuesByItemIDs(ctx, workspaceID, itemIDs)
+	if err != nil {
+		log.Printf("item: custom value lookup failed for workspace %s: %v", workspaceID, err)
+		return nil
+	}
+	return valuesByItem
+}
+
+func toItemResponse(ctx context.Context, i *Item, primary *itemphoto.ItemPhoto, photoURLGen PrimaryPhotoURLGenerator) ItemResponse {
 	resp := ItemResponse{
 		ID:                i.ID(),
 		WorkspaceID:       i.WorkspaceID(),
@@ -688,6 +1095,7 @@ func toItemResponse(i *Item, primary *itemphoto.ItemPhoto, photoURLGen PrimaryPh
 		IsArchived:        i.IsArchived(),
 		LifetimeWarranty:  i.LifetimeWarranty(),
 		NeedsReview:       i.NeedsReview(),
+		Loanable:          i.IsLoanable(),
 		WarrantyDetails:   i.WarrantyDetails(),
 		PurchasedFrom:     i.PurchasedFrom(),
 		MinStockLevel:     i.MinStockLevel(),
@@ -700,8 +1108,8 @@ func toItemResponse(i *Item, primary *itemphoto.ItemPhoto, photoURLGen PrimaryPh
 	}
 
 	if primary != nil && photoURLGen != nil {
-		thumbnailURL := photoURLGen(primary.WorkspaceID, primary.ItemID, primary.ID, true)
-		fullURL := photoURLGen(primary.WorkspaceID, primary.ItemID, primary.ID, false)
+		thumbnailURL := photoURLGen(ctx, primary.WorkspaceID, primary.ItemID, primary.ID, true)
+		fullURL := photoURLGen(ctx, primary.WorkspaceID, primary.ItemID, primary.ID, false)
 		resp.PrimaryPhotoThumbnailURL = stringPtrOrNil(thumbnailURL)
 		resp.PrimaryPhotoURL = stringPtrOrNil(fullURL)
 	}
@@ -712,15 +1120,19 @@ func toItemResponse(i *Item, primary *itemphoto.ItemPhoto, photoURLGen PrimaryPh
 // Request/Response types
 
 type ListItemsInput struct {
-	Page        int    `query:"page" default:"1" minimum:"1"`
-	Limit       int    `query:"limit" default:"25" minimum:"1" maximum:"100"`
-	Search      string `query:"search,omitempty" maxLength:"200" doc:"Full-text search over name, brand, model, and description"`
-	CategoryID  string `query:"category_id,omitempty" doc:"Filter by category UUID"`
-	IsInsured   bool   `query:"is_insured,omitempty" doc:"When true, only insured items"`
-	Archived    bool   `query:"archived" default:"false" doc:"When true, include archived items in the list"`
-	Sort        string `query:"sort" default:"name" enum:"name,sku,created_at,updated_at" doc:"Sort field"`
-	SortDir     string `query:"sort_dir" default:"asc" enum:"asc,desc" doc:"Sort direction"`
-	NeedsReview bool   `query:"needs_review,omitempty" doc:"When true, only items flagged needs_review"`
+	Page         int    `query:"page" default:"1" minimum:"1"`
+	Limit        int    `query:"limit" default:"25" minimum:"1" maximum:"100"`
+	Search       string `query:"search,omitempty" maxLength:"200" doc:"Full-text search over name, brand, model, and description"`
+	CategoryID   string `query:"category_id,omitempty" doc:"Filter by category UUID"`
+	IsInsured    bool   `query:"is_insured,omitempty" doc:"When true, only insured items"`
+	Archived     bool   `query:"archived" default:"false" doc:"When true, include archived items in the list"`
+	Include      string `query:"include,omitempty" enum:"archived" doc:"archived: include archived items in the list (same as archived=true)"`
+	Sort         string `query:"sort" default:"name" enum:"name,sku,created_at,updated_at" doc:"Sort field"`
+	SortDir      string `query:"sort_dir" default:"asc" enum:"asc,desc" doc:"Sort direction"`
+	NeedsReview  bool   `query:"needs_review,omitempty" doc:"When true, only items flagged needs_review"`
+	Brand        string `query:"brand,omitempty" maxLength:"100" doc:"Filter by brand (case-insensitive exact match)"`
+	HasInventory string `query:"has_inventory,omitempty" enum:"true,false" doc:"When true, only items with non-archived stock; when false, only items without"`
+	IDs          string `query:"ids,omitempty" doc:"Comma-separated item UUIDs (at most 100). Returns just these items in the given order, skipping unknown IDs; other filters and pagination are ignored"`
 }
 
 type ListItemsOutput struct {
@@ -743,11 +1155,76 @@ type SearchItemsOutput struct {
 	Body ItemListResponse
 }
 
+type ListRecentItemsInput struct {
+	Limit int `query:"limit" default:"10" minimum:"1" maximum:"20"`
+}
+
+type ReassignCategoryInput struct {
+	Body struct {
+		ItemIDs    []uuid.UUID `json:"item_ids" minItems:"1" maxItems:"500" doc:"Items to move"`
+		CategoryID *uuid.UUID  `json:"category_id,omitempty" doc:"Target category. Omit or null to clear the items' category."`
+	}
+}
+
+type ReassignCategoryOutput struct {
+	Body ReassignCategoryResponse
+}
+
+type ReassignCategoryResponse struct {
+	Updated int `json:"updated" doc:"Number of items whose category was set"`
+}
+
+type ListSimilarItemsInput struct {
+	Threshold float64 `query:"threshold" default:"0.5" exclusiveMinimum:"0" maximum:"1" doc:"Minimum trigram similarity of name and brand, 0-1. Lower finds more, looser matches."`
+}
+
+type ListSimilarItemsOutput struct {
+	Body SimilarItemsResponse
+}
+
+type SimilarItemsResponse struct {
+	Pairs []SimilarPairResponse `json:"pairs" doc:"Likely duplicates, most similar first"`
+}
+
+type SimilarPairResponse struct {
+	Item       SimilarItemResponse `json:"item"`
+	Match      SimilarItemResponse `json:"match"`
+	Similarity float64             `json:"similarity" doc:"Trigram similarity of the two items' name and brand, 0-1"`
+}
+
+type SimilarItemResponse struct {
+	ID    uuid.UUID `json:"id"`
+	Name  string    `json:"name"`
+	SKU   string    `json:"sku"`
+	Brand *string   `json:"brand,omitempty"`
+}
+
+type MergeItemsInput struct {
+	ID   uuid.UUID `path:"id"`
+	Body struct {
+		MergeItemID uuid.UUID `json:"merge_item_id" doc:"Item to fold into this one; it is deleted after the merge"`
+		SKU         string    `json:"sku,omitempty" maxLength:"50" doc:"New SKU for this item, e.g. the merged item's. Omit to keep the current one."`
+	}
+}
+
+type MergeItemsOutput struct {
+	Body MergeItemsResponse
+}
+
+type MergeItemsResponse struct {
+	InventoryMoved int `json:"inventory_moved" doc:"Number of inventory records re-pointed to this item"`
+	LoansMoved     int `json:"loans_moved" doc:"Number of loans that came along with the moved inventory"`
+	LabelsMoved    int `json:"labels_moved" doc:"Number of labels newly attached to this item"`
+	PhotosMoved    int `json:"photos_moved" doc:"Number of photos re-pointed to this item"`
+}
+
 type GetItemInput struct {
-	ID uuid.UUID `path:"id"`
+	ID          uuid.UUID `path:"id"`
+	IfNoneMatch string    `header:"If-None-Match" doc:"ETag from an earlier response; a 304 with no body is returned while it still matches"`
 }
 
 type GetItemOutput struct {
+	ETag string `header:"ETag"`
 	Body ItemResponse
 }
 
@@ -765,7 +1242,20 @@ type LookupItemByBarcodeInput struct {
 }
 
 type LookupItemByBarcodeOutput struct {
-	Body ItemResponse
+	Body BarcodeLookupResponse
+}
+
+// BarcodeLookupResponse is the matched item plus the code that matched it.
+type BarcodeLookupResponse struct {
+	ItemResponse
+	MatchedIdentifier MatchedIdentifierResponse `json:"matched_identifier" doc:"The code the scan matched"`
+}
+
+type MatchedIdentifierResponse struct {
+	ID      *uuid.UUID `json:"id,omitempty" doc:"Identifier ID; absent when the primary barcode matched"`
+	Type    string     `json:"type" doc:"Identifier type, or barcode for the primary barcode"`
+	Value   string     `json:"value"`
+	Primary bool       `json:"primary" doc:"Whether the item's primary barcode matched"`
 }
 
 type ListItemsByCategoryInput struct {
@@ -799,6 +1289,7 @@ type CreateItemInput struct {
 		ObsidianVaultPath *string    `json:"obsidian_vault_path,omitempty" doc:"Obsidian vault path"`
 		ObsidianNotePath  *string    `json:"obsidian_note_path,omitempty" doc:"Obsidian note path"`
 		NeedsReview       *bool      `json:"needs_review,omitempty" doc:"Whether the item needs review"`
+		Loanable          *bool      `json:"loanable,omitempty" doc:"Whether the item can be lent out; false for fixed assets such as a mounted shelf"`
 	}
 }
 
@@ -826,6 +1317,7 @@ type UpdateItemInput struct {
 		ObsidianVaultPath *string    `json:"obsidian_vault_path,omitempty" doc:"Obsidian vault path"`
 		ObsidianNotePath  *string    `json:"obsidian_note_path,omitempty" doc:"Obsidian note path"`
 		NeedsReview       *bool      `json:"needs_review,omitempty" doc:"Whether the item needs review"`
+		Loanable          *bool      `json:"loanable,omitempty" doc:"Whether the item can be lent out; false for fixed assets such as a mounted shelf"`
 	}
 }
 
@@ -834,37 +1326,80 @@ type UpdateItemOutput struct {
 }
 
 type ItemResponse struct {
-	ID                       uuid.UUID  `json:"id"`
-	WorkspaceID              uuid.UUID  `json:"workspace_id"`
-	SKU                      string     `json:"sku"`
-	Name                     string     `json:"name"`
-	Description              *string    `json:"description,omitempty"`
-	CategoryID               *uuid.UUID `json:"category_id,omitempty"`
-	Brand                    *string    `json:"brand,omitempty"`
-	Model                    *string    `json:"model,omitempty"`
-	ImageURL                 *string    `json:"image_url,omitempty"`
-	SerialNumber             *string    `json:"serial_number,omitempty"`
-	Manufacturer             *string    `json:"manufacturer,omitempty"`
-	Barcode                  *string    `json:"barcode,omitempty"`
-	IsInsured                *bool      `json:"is_insured,omitempty"`
-	IsArchived               *bool      `json:"is_archived,omitempty"`
-	LifetimeWarranty         *bool      `json:"lifetime_warranty,omitempty"`
-	NeedsReview              *bool      `json:"needs_review,omitempty"`
-	WarrantyDetails          *string    `json:"warranty_details,omitempty"`
-	PurchasedFrom            *uuid.UUID `json:"purchased_from,omitempty"`
-	MinStockLevel            int        `json:"min_stock_level"`
-	ShortCode                string     `json:"short_code"`
-	ObsidianVaultPath        *string    `json:"obsidian_vault_path,omitempty"`
-	ObsidianNotePath         *string    `json:"obsidian_note_path,omitempty"`
-	ObsidianURI              *string    `json:"obsidian_uri,omitempty" doc:"Generated Obsidian deep link URI"`
-	PrimaryPhotoThumbnailURL *string    `json:"primary_photo_thumbnail_url,omitempty" doc:"Thumbnail URL of the primary photo (omitted when no primary exists)"`
-	PrimaryPhotoURL          *string    `json:"primary_photo_url,omitempty" doc:"Full-size URL of the primary photo (omitted when no primary exists)"`
-	CreatedAt                time.Time  `json:"created_at"`
-	UpdatedAt                time.Time  `json:"updated_at"`
+	ID                       uuid.UUID                   `json:"id"`
+	WorkspaceID              uuid.UUID                   `json:"workspace_id"`
+	SKU                      string                      `json:"sku"`
+	Name                     string                      `json:"name"`
+	Description              *string                     `json:"description,omitempty"`
+	CategoryID               *uuid.UUID                  `json:"category_id,omitempty"`
+	Brand                    *string                     `json:"brand,omitempty"`
+	Model                    *string                     `json:"model,omitempty"`
+	ImageURL                 *string                     `json:"image_url,omitempty"`
+	SerialNumber             *string                     `json:"serial_number,omitempty"`
+	Manufacturer             *string                     `json:"manufacturer,omitempty"`
+	Barcode                  *string                     `json:"barcode,omitempty"`
+	IsInsured                *bool                       `json:"is_insured,omitempty"`
+	IsArchived               *bool                       `json:"is_archived,omitempty"`
+	LifetimeWarranty         *bool                       `json:"lifetime_warranty,omitempty"`
+	NeedsReview              *bool                       `json:"needs_review,omitempty"`
+	Loanable                 bool                        `json:"loanable" doc:"Whether the item can be lent out"`
+	WarrantyDetails          *string                     `json:"warranty_details,omitempty"`
+	PurchasedFrom            *uuid.UUID                  `json:"purchased_from,omitempty"`
+	MinStockLevel            int                         `json:"min_stock_level"`
+	ShortCode                string                      `json:"short_code"`
+	ObsidianVaultPath        *string                     `json:"obsidian_vault_path,omitempty"`
+	ObsidianNotePath         *string                     `json:"obsidian_note_path,omitempty"`
+	ObsidianURI              *string                     `json:"obsidian_uri,omitempty" doc:"Generated Obsidian deep link URI"`
+	PrimaryPhotoThumbnailURL *string                     `json:"primary_photo_thumbnail_url,omitempty" doc:"Thumbnail URL of the primary photo (omitted when no primary exists)"`
+	PrimaryPhotoURL          *string                     `json:"primary_photo_url,omitempty" doc:"Full-size URL of the primary photo (omitted when no primary exists)"`
+	CustomFields             []customfield.ValueResponse `json:"custom_fields,omitempty" doc:"Custom field values set on the item, by field name (omitted when none)"`
+	CreatedAt                time.Time                   `json:"created_at"`
+	UpdatedAt                time.Time                   `json:"updated_at"`
 }
 
 // Label management types
 
+type AddItemIdentifierInput struct {
+	ID   uuid.UUID `path:"id"`
+	Body struct {
+		Type  string `json:"type" enum:"upc,ean,isbn,asset_tag,other" doc:"Kind of code"`
+		Value string `json:"value" minLength:"1" maxLength:"50" doc:"The code, matched exactly when scanning"`
+	}
+}
+
+type ItemIdentifierInput struct {
+	ID           uuid.UUID `path:"id"`
+	IdentifierID uuid.UUID `path:"identifier_id"`
+}
+
+type ListItemIdentifiersOutput struct {
+	Body ItemIdentifiersResponse
+}
+
+type ItemIdentifiersResponse struct {
+	Identifiers []ItemIdentifierResponse `json:"identifiers"`
+}
+
+type ItemIdentifierOutput struct {
+	Body ItemIdentifierResponse
+}
+
+type ItemIdentifierResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Type      string    `json:"type"`
+	Value     string    `json:"value"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func toIdentifierResponse(identifier *Identifier) ItemIdentifierResponse {
+	return ItemIdentifierResponse{
+		ID:        identifier.ID(),
+		Type:      string(identifier.Type()),
+		Value:     identifier.Value(),
+		CreatedAt: identifier.CreatedAt(),
+	}
+}
+
 type ItemLabelInput struct {
 	ID      uuid.UUID `path:"id"`
 	LabelID uuid.UUID `path:"label_id"`
@@ -79,7 +79,7 @@ func TestItemHandler_LookupByBarcode_Integration(t *testing.T) {
 		})
 		config := huma.DefaultConfig("Integration Test API", "1.0.0")
 		api := humachi.New(r, config)
-		item.RegisterRoutes(api, svc, nil, nil, nil)
+		item.RegisterRoutes(api, svc, nil, nil, nil, nil)
 		return api, r
 	}
 
@@ -13,7 +13,9 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/customfield"
 	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/item"
 	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/itemphoto"
 	"github.com/antti/home-warehouse/go-backend/internal/shared"
@@ -86,17 +88,49 @@ func (m *MockService) Search(ctx context.Context, workspaceID uuid.UUID, query s
 	return args.Get(0).([]*item.Item), args.Error(1)
 }
 
+func (m *MockService) GetByIDs(ctx context.Context, workspaceID uuid.UUID, ids []uuid.UUID) ([]*item.Item, error) {
+	args := m.Called(ctx, workspaceID, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*item.Item), args.Error(1)
+}
+
 func (m *MockService) ListByCategory(ctx context.Context, workspaceID, categoryID uuid.UUID, pagination shared.Pagination) ([]*item.Item, error) {
 	args := m.Called(ctx, workspaceID, categoryID, pagination)
 	return args.Get(0).([]*item.Item), args.Error(1)
 }
 
-func (m *MockService) LookupByBarcode(ctx context.Context, workspaceID uuid.UUID, code string) (*item.Item, error) {
+func (m *MockService) LookupByBarcode(ctx context.Context, workspaceID uuid.UUID, code string) (*item.Item, *item.Identifier, error) {
 	args := m.Called(ctx, workspaceID, code)
+	var identifier *item.Identifier
+	if args.Get(1) != nil {
+		identifier = args.Get(1).(*item.Identifier)
+	}
+	if args.Get(0) == nil {
+		return nil, identifier, args.Error(2)
+	}
+	return args.Get(0).(*item.Item), identifier, args.Error(2)
+}
+
+func (m *MockService) ListIdentifiers(ctx context.Context, workspaceID, itemID uuid.UUID) ([]*item.Identifier, error) {
+	args := m.Called(ctx, workspaceID, itemID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
-	return args.Get(0).(*item.Item), args.Error(1)
+	return args.Get(0).([]*item.Identifier), args.Error(1)
+}
+
+func (m *MockService) AddIdentifier(ctx context.Context, workspaceID, itemID uuid.UUID, identifierType item.IdentifierType, value string) (*item.Identifier, error) {
+	args := m.Called(ctx, workspaceID, itemID, identifierType, value)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*item.Identifier), args.Error(1)
+}
+
+func (m *MockService) RemoveIdentifier(ctx context.Context, workspaceID, itemID, identifierID uuid.UUID) error {
+	return m.Called(ctx, workspaceID, itemID, identifierID).Error(0)
 }
 
 func (m *MockService) AttachLabel(ctx context.Context, itemID, labelID, workspaceID uuid.UUID) error {
@@ -108,6 +142,87 @@ func (m *MockService) DetachLabel(ctx context.Context, itemID, labelID, workspac
 	return m.Called(ctx, itemID, labelID, workspaceID).Error(0)
 }
 
+func (m *MockService) DuplicateItem(ctx context.Context, workspaceID, itemID uuid.UUID) (*item.Item, error) {
+	args := m.Called(ctx, workspaceID, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*item.Item), args.Error(1)
+}
+
+func (m *MockService) ReassignCategory(ctx context.Context, workspaceID uuid.UUID, itemIDs []uuid.UUID, newCategoryID *uuid.UUID) ([]item.ReassignedItem, error) {
+	args := m.Called(ctx, workspaceID, itemIDs, newCategoryID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]item.ReassignedItem), args.Error(1)
+}
+
+func (m *MockService) RecordView(ctx context.Context, workspaceID, userID, itemID uuid.UUID) error {
+	return m.Called(ctx, workspaceID, userID, itemID).Error(0)
+}
+
+func (m *MockService) ListRecentlyViewed(ctx context.Context, workspaceID, userID uuid.UUID, limit int) ([]*item.Item, error) {
+	args := m.Called(ctx, workspaceID, userID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*item.Item), args.Error(1)
+}
+
+func (m *MockService) FindSimilarItems(ctx context.Context, workspaceID uuid.UUID, threshold float64) ([]item.SimilarPair, error) {
+	args := m.Called(ctx, workspaceID, threshold)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]item.SimilarPair), args.Error(1)
+}
+
+func (m *MockService) MergeItems(ctx context.Context, workspaceID, keepID, mergeID uuid.UUID, sku string) (*item.MergeResult, error) {
+	args := m.Called(ctx, workspaceID, keepID, mergeID, sku)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*item.MergeResult), args.Error(1)
+}
+
+func (m *MockService) ListCategoryRules(ctx context.Context, workspaceID uuid.UUID) ([]*item.CategoryRule, error) {
+	args := m.Called(ctx, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*item.CategoryRule), args.Error(1)
+}
+
+func (m *MockService) CreateCategoryRule(ctx context.Context, workspaceID uuid.UUID, input item.CategoryRuleInput) (*item.CategoryRule, error) {
+	args := m.Called(ctx, workspaceID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*item.CategoryRule), args.Error(1)
+}
+
+func (m *MockService) UpdateCategoryRule(ctx context.Context, workspaceID, id uuid.UUID, input item.CategoryRuleInput) (*item.CategoryRule, error) {
+	args := m.Called(ctx, workspaceID, id, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*item.CategoryRule), args.Error(1)
+}
+
+func (m *MockService) DeleteCategoryRule(ctx context.Context, workspaceID, id uuid.UUID) error {
+	args := m.Called(ctx, workspaceID, id)
+	return args.Error(0)
+}
+
+func (m *MockService) ReorderCategoryRules(ctx context.Context, workspaceID uuid.UUID, ruleIDs []uuid.UUID) ([]*item.CategoryRule, error) {
+	args := m.Called(ctx, workspaceID, ruleIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*item.CategoryRule), args.Error(1)
+}
+
 func (m *MockService) GetItemLabels(ctx context.Context, itemID, workspaceID uuid.UUID) ([]uuid.UUID, error) {
 	args := m.Called(ctx, itemID, workspaceID)
 	if args.Get(0) == nil {
@@ -121,7 +236,7 @@ func (m *MockService) GetItemLabels(ctx context.Context, itemID, workspaceID uui
 func TestItemHandler_Create(t *testing.T) {
 	setup := testutil.NewHandlerTestSetup()
 	mockSvc := new(MockService)
-	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil)
+	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil, nil)
 
 	t.Run("creates item successfully", func(t *testing.T) {
 		testItem, _ := item.NewItem(setup.WorkspaceID, "Laptop", "LAP-001", 0)
@@ -171,7 +286,7 @@ func TestItemHandler_Create(t *testing.T) {
 func TestItemHandler_List(t *testing.T) {
 	setup := testutil.NewHandlerTestSetup()
 	mockSvc := new(MockService)
-	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil)
+	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil, nil)
 
 	t.Run("lists items successfully", func(t *testing.T) {
 		item1, _ := item.NewItem(setup.WorkspaceID, "Item 1", "IT-001", 0)
@@ -210,10 +325,53 @@ func TestItemHandler_List(t *testing.T) {
 	})
 }
 
+func TestItemHandler_ListByIDs(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil, nil)
+
+	t.Run("returns the requested items in order", func(t *testing.T) {
+		item1, _ := item.NewItem(setup.WorkspaceID, "Item 1", "IT-001", 0)
+		item2, _ := item.NewItem(setup.WorkspaceID, "Item 2", "IT-002", 0)
+		missing := uuid.New()
+
+		mockSvc.On("GetByIDs", mock.Anything, setup.WorkspaceID, []uuid.UUID{item2.ID(), missing, item1.ID()}).
+			Return([]*item.Item{item2, item1}, nil).Once()
+
+		rec := setup.Get(fmt.Sprintf("/items?ids=%s,%s,%s", item2.ID(), missing, item1.ID()))
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		var body item.ItemListResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		require.Len(t, body.Items, 2)
+		assert.Equal(t, item2.ID(), body.Items[0].ID)
+		assert.Equal(t, item1.ID(), body.Items[1].ID)
+		assert.Equal(t, 2, body.Total)
+		mockSvc.AssertNotCalled(t, "ListFiltered", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("rejects a malformed id", func(t *testing.T) {
+		rec := setup.Get("/items?ids=" + uuid.NewString() + ",nope")
+
+		testutil.AssertStatus(t, rec, http.StatusBadRequest)
+	})
+
+	t.Run("rejects too many ids", func(t *testing.T) {
+		mockSvc.On("GetByIDs", mock.Anything, setup.WorkspaceID, mock.Anything).
+			Return(nil, item.ErrTooManyIDs).Once()
+
+		rec := setup.Get("/items?ids=" + uuid.NewString())
+
+		testutil.AssertStatus(t, rec, http.StatusBadRequest)
+		assert.Contains(t, rec.Body.String(), "at most 100 ids")
+	})
+}
+
 func TestItemHandler_Get(t *testing.T) {
 	setup := testutil.NewHandlerTestSetup()
 	mockSvc := new(MockService)
-	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil)
+	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil, nil)
 
 	t.Run("gets item by ID", func(t *testing.T) {
 		testItem, _ := item.NewItem(setup.WorkspaceID, "Laptop", "LAP-001", 0)
@@ -221,6 +379,23 @@ func TestItemHandler_Get(t *testing.T) {
 
 		mockSvc.On("GetByID", mock.Anything, itemID, setup.WorkspaceID).
 			Return(testItem, nil).Once()
+		mockSvc.On("RecordView", mock.Anything, setup.WorkspaceID, setup.UserID, itemID).
+			Return(nil).Once()
+
+		rec := setup.Get(fmt.Sprintf("/items/%s", itemID))
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("view recording failure does not fail the request", func(t *testing.T) {
+		testItem, _ := item.NewItem(setup.WorkspaceID, "Tablet", "TAB-001", 0)
+		itemID := testItem.ID()
+
+		mockSvc.On("GetByID", mock.Anything, itemID, setup.WorkspaceID).
+			Return(testItem, nil).Once()
+		mockSvc.On("RecordView", mock.Anything, setup.WorkspaceID, setup.UserID, itemID).
+			Return(errors.New("redis down")).Once()
 
 		rec := setup.Get(fmt.Sprintf("/items/%s", itemID))
 
@@ -239,12 +414,39 @@ func TestItemHandler_Get(t *testing.T) {
 		testutil.AssertStatus(t, rec, http.StatusNotFound)
 		mockSvc.AssertExpectations(t)
 	})
+
+	t.Run("honors If-None-Match", func(t *testing.T) {
+		testItem, _ := item.NewItem(setup.WorkspaceID, "Drill", "DRL-001", 0)
+		itemID := testItem.ID()
+		path := fmt.Sprintf("/items/%s", itemID)
+		mockSvc.On("RecordView", mock.Anything, setup.WorkspaceID, setup.UserID, itemID).Return(nil)
+
+		mockSvc.On("GetByID", mock.Anything, itemID, setup.WorkspaceID).Return(testItem, nil).Once()
+		rec := setup.Get(path)
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		etag := rec.Header().Get("ETag")
+		require.NotEmpty(t, etag)
+
+		mockSvc.On("GetByID", mock.Anything, itemID, setup.WorkspaceID).Return(testItem, nil).Once()
+		rec = setup.GetWithHeader(path, "If-None-Match", etag)
+		testutil.AssertStatus(t, rec, http.StatusNotModified)
+		assert.Empty(t, rec.Body.String())
+		assert.Equal(t, etag, rec.Header().Get("ETag"))
+
+		require.NoError(t, testItem.Update(item.UpdateInput{Name: "Cordless drill"}))
+		mockSvc.On("GetByID", mock.Anything, itemID, setup.WorkspaceID).Return(testItem, nil).Once()
+		rec = setup.GetWithHeader(path, "If-None-Match", etag)
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		assert.NotEmpty(t, rec.Header().Get("ETag"))
+		assert.NotEqual(t, etag, rec.Header().Get("ETag"))
+		mockSvc.AssertExpectations(t)
+	})
 }
 
 func TestItemHandler_Update(t *testing.T) {
 	setup := testutil.NewHandlerTestSetup()
 	mockSvc := new(MockService)
-	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil)
+	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil, nil)
 
 	t.Run("updates item successfully", func(t *testing.T) {
 		testItem, _ := item.NewItem(setup.WorkspaceID, "Updated Laptop", "LAP-001", 0)
@@ -265,6 +467,21 @@ func TestItemHandler_Update(t *testing.T) {
 		mockSvc.AssertExpectations(t)
 	})
 
+	t.Run("marks an item as not loanable", func(t *testing.T) {
+		currentItem, _ := item.NewItem(setup.WorkspaceID, "Wall shelf", "SHELF-1", 0)
+		itemID := currentItem.ID()
+		mockSvc.On("GetByID", mock.Anything, itemID, setup.WorkspaceID).
+			Return(currentItem, nil).Once()
+		mockSvc.On("Update", mock.Anything, itemID, setup.WorkspaceID, mock.MatchedBy(func(in item.UpdateInput) bool {
+			return in.Loanable != nil && !*in.Loanable
+		})).Return(currentItem, nil).Once()
+
+		rec := setup.Patch(fmt.Sprintf("/items/%s", itemID), `{"loanable":false}`)
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		mockSvc.AssertExpectations(t)
+	})
+
 	t.Run("returns 404 when item not found", func(t *testing.T) {
 		itemID := uuid.New()
 
@@ -292,7 +509,7 @@ func TestItemHandler_Update(t *testing.T) {
 func TestItemHandler_Archive(t *testing.T) {
 	setup := testutil.NewHandlerTestSetup()
 	mockSvc := new(MockService)
-	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil)
+	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil, nil)
 
 	t.Run("archives item successfully", func(t *testing.T) {
 		itemID := uuid.New()
@@ -322,7 +539,7 @@ func TestItemHandler_Archive(t *testing.T) {
 func TestItemHandler_Restore(t *testing.T) {
 	setup := testutil.NewHandlerTestSetup()
 	mockSvc := new(MockService)
-	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil)
+	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil, nil)
 
 	t.Run("restores item successfully", func(t *testing.T) {
 		itemID := uuid.New()
@@ -352,7 +569,7 @@ func TestItemHandler_Restore(t *testing.T) {
 func TestItemHandler_Search(t *testing.T) {
 	setup := testutil.NewHandlerTestSetup()
 	mockSvc := new(MockService)
-	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil)
+	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil, nil)
 
 	t.Run("searches items successfully", func(t *testing.T) {
 		testItem, _ := item.NewItem(setup.WorkspaceID, "Laptop", "LAP-001", 0)
@@ -391,7 +608,7 @@ func TestItemHandler_Search(t *testing.T) {
 func TestItemHandler_ListByCategory(t *testing.T) {
 	setup := testutil.NewHandlerTestSetup()
 	mockSvc := new(MockService)
-	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil)
+	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil, nil)
 
 	t.Run("lists items by category successfully", func(t *testing.T) {
 		categoryID := uuid.New()
@@ -420,10 +637,154 @@ func TestItemHandler_ListByCategory(t *testing.T) {
 	})
 }
 
+func TestItemHandler_DuplicateItem(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil, nil)
+
+	t.Run("returns the new item", func(t *testing.T) {
+		sourceID := uuid.New()
+		dup, _ := item.NewItem(setup.WorkspaceID, "Drill (copy)", "DRL-001-COPY", 2)
+
+		mockSvc.On("DuplicateItem", mock.Anything, setup.WorkspaceID, sourceID).
+			Return(dup, nil).Once()
+
+		rec := setup.Post(fmt.Sprintf("/items/%s/duplicate", sourceID), "")
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		resp := testutil.ParseJSONResponse[item.ItemResponse](t, rec)
+		assert.Equal(t, dup.ID(), resp.ID)
+		assert.Equal(t, "Drill (copy)", resp.Name)
+		assert.Equal(t, "DRL-001-COPY", resp.SKU)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("returns 404 when source item not found", func(t *testing.T) {
+		sourceID := uuid.New()
+		mockSvc.On("DuplicateItem", mock.Anything, setup.WorkspaceID, sourceID).
+			Return(nil, item.ErrItemNotFound).Once()
+
+		rec := setup.Post(fmt.Sprintf("/items/%s/duplicate", sourceID), "")
+
+		testutil.AssertStatus(t, rec, http.StatusNotFound)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("returns 409 when no free copy SKU", func(t *testing.T) {
+		sourceID := uuid.New()
+		mockSvc.On("DuplicateItem", mock.Anything, setup.WorkspaceID, sourceID).
+			Return(nil, item.ErrSKUTaken).Once()
+
+		rec := setup.Post(fmt.Sprintf("/items/%s/duplicate", sourceID), "")
+
+		testutil.AssertStatus(t, rec, http.StatusConflict)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("requires owner or admin", func(t *testing.T) {
+		setup.SetRole("member")
+		defer setup.SetRole("owner")
+
+		rec := setup.Post(fmt.Sprintf("/items/%s/duplicate", uuid.New()), "")
+
+		testutil.AssertStatus(t, rec, http.StatusForbidden)
+	})
+}
+
+func TestItemHandler_ListRecent(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil, nil)
+
+	t.Run("returns recently viewed items in order", func(t *testing.T) {
+		newer, _ := item.NewItem(setup.WorkspaceID, "Newer", "REC-002", 0)
+		older, _ := item.NewItem(setup.WorkspaceID, "Older", "REC-001", 0)
+
+		mockSvc.On("ListRecentlyViewed", mock.Anything, setup.WorkspaceID, setup.UserID, 5).
+			Return([]*item.Item{newer, older}, nil).Once()
+
+		rec := setup.Get("/items/recent?limit=5")
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		resp := testutil.ParseJSONResponse[item.ItemListResponse](t, rec)
+		if assert.Len(t, resp.Items, 2) {
+			assert.Equal(t, newer.ID(), resp.Items[0].ID)
+			assert.Equal(t, older.ID(), resp.Items[1].ID)
+		}
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("defaults limit", func(t *testing.T) {
+		mockSvc.On("ListRecentlyViewed", mock.Anything, setup.WorkspaceID, setup.UserID, 10).
+			Return([]*item.Item{}, nil).Once()
+
+		rec := setup.Get("/items/recent")
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("rejects limit above cap", func(t *testing.T) {
+		rec := setup.Get("/items/recent?limit=50")
+
+		testutil.AssertStatus(t, rec, http.StatusUnprocessableEntity)
+	})
+}
+
+func TestItemHandler_ReassignCategory(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil, nil)
+
+	itemIDs := []uuid.UUID{uuid.New(), uuid.New()}
+
+	t.Run("returns the updated count", func(t *testing.T) {
+		categoryID := uuid.New()
+		mockSvc.On("ReassignCategory", mock.Anything, setup.WorkspaceID, itemIDs, &categoryID).
+			Return([]item.ReassignedItem{{ID: itemIDs[0]}, {ID: itemIDs[1]}}, nil).Once()
+
+		body := fmt.Sprintf(`{"item_ids":["%s","%s"],"category_id":"%s"}`, itemIDs[0], itemIDs[1], categoryID)
+		rec := setup.Post("/items/reassign-category", body)
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		resp := testutil.ParseJSONResponse[item.ReassignCategoryResponse](t, rec)
+		assert.Equal(t, 2, resp.Updated)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("omitted category clears", func(t *testing.T) {
+		mockSvc.On("ReassignCategory", mock.Anything, setup.WorkspaceID, itemIDs, (*uuid.UUID)(nil)).
+			Return([]item.ReassignedItem{{ID: itemIDs[0]}}, nil).Once()
+
+		body := fmt.Sprintf(`{"item_ids":["%s","%s"]}`, itemIDs[0], itemIDs[1])
+		rec := setup.Post("/items/reassign-category", body)
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("unknown category returns 404", func(t *testing.T) {
+		categoryID := uuid.New()
+		mockSvc.On("ReassignCategory", mock.Anything, setup.WorkspaceID, itemIDs, &categoryID).
+			Return(nil, shared.NewFieldError(shared.ErrNotFound, "category_id", "category not found in this workspace")).Once()
+
+		body := fmt.Sprintf(`{"item_ids":["%s","%s"],"category_id":"%s"}`, itemIDs[0], itemIDs[1], categoryID)
+		rec := setup.Post("/items/reassign-category", body)
+
+		testutil.AssertStatus(t, rec, http.StatusNotFound)
+	})
+
+	t.Run("rejects an empty item list", func(t *testing.T) {
+		rec := setup.Post("/items/reassign-category", `{"item_ids":[]}`)
+
+		testutil.AssertStatus(t, rec, http.StatusUnprocessableEntity)
+	})
+}
+
 func TestItemHandler_GetItemLabels(t *testing.T) {
 	setup := testutil.NewHandlerTestSetup()
 	mockSvc := new(MockService)
-	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil)
+	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil, nil)
 
 	t.Run("gets item labels successfully", func(t *testing.T) {
 		itemID := uuid.New()
@@ -454,7 +815,7 @@ func TestItemHandler_GetItemLabels(t *testing.T) {
 func TestItemHandler_AttachLabel(t *testing.T) {
 	setup := testutil.NewHandlerTestSetup()
 	mockSvc := new(MockService)
-	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil)
+	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil, nil)
 
 	t.Run("attaches label successfully", func(t *testing.T) {
 		itemID := uuid.New()
@@ -486,7 +847,7 @@ func TestItemHandler_AttachLabel(t *testing.T) {
 func TestItemHandler_DetachLabel(t *testing.T) {
 	setup := testutil.NewHandlerTestSetup()
 	mockSvc := new(MockService)
-	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil)
+	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil, nil)
 
 	t.Run("detaches label successfully", func(t *testing.T) {
 		itemID := uuid.New()
@@ -520,7 +881,7 @@ func TestItemHandler_DetachLabel(t *testing.T) {
 func TestItemHandler_ListItems_FilterByNeedsReview(t *testing.T) {
 	setup := testutil.NewHandlerTestSetup()
 	mockSvc := new(MockService)
-	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil)
+	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil, nil)
 
 	t.Run("needs_review=true composes as a ListFiltered filter", func(t *testing.T) {
 		item1, _ := item.NewItem(setup.WorkspaceID, "Review Item", "REV-001", 0)
@@ -581,7 +942,7 @@ func TestItemHandler_ListItems_FilterByNeedsReview(t *testing.T) {
 func TestItemHandler_CreateItem_WithNeedsReview(t *testing.T) {
 	setup := testutil.NewHandlerTestSetup()
 	mockSvc := new(MockService)
-	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil)
+	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil, nil)
 
 	testItem, _ := item.NewItem(setup.WorkspaceID, "Quick Capture", "QC-001", 0)
 	testItem.SetNeedsReview(true)
@@ -601,7 +962,7 @@ func TestItemHandler_CreateItem_WithNeedsReview(t *testing.T) {
 func TestItemHandler_UpdateItem_ClearNeedsReview(t *testing.T) {
 	setup := testutil.NewHandlerTestSetup()
 	mockSvc := new(MockService)
-	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil)
+	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil, nil)
 
 	currentItem, _ := item.NewItem(setup.WorkspaceID, "Review Item", "REV-001", 0)
 	currentItem.SetNeedsReview(true)
@@ -632,7 +993,7 @@ func TestItemHandler_Create_PublishesEvent(t *testing.T) {
 	capture.Start()
 	defer capture.Stop()
 
-	item.RegisterRoutes(setup.API, mockSvc, capture.GetBroadcaster(), nil, nil)
+	item.RegisterRoutes(setup.API, mockSvc, capture.GetBroadcaster(), nil, nil, nil)
 
 	testItem, _ := item.NewItem(setup.WorkspaceID, "Test Item", "TEST-001", 0)
 
@@ -669,7 +1030,7 @@ func TestItemHandler_Update_PublishesEvent(t *testing.T) {
 	capture.Start()
 	defer capture.Stop()
 
-	item.RegisterRoutes(setup.API, mockSvc, capture.GetBroadcaster(), nil, nil)
+	item.RegisterRoutes(setup.API, mockSvc, capture.GetBroadcaster(), nil, nil, nil)
 
 	testItem, _ := item.NewItem(setup.WorkspaceID, "Updated Item", "TEST-001", 0)
 	itemID := testItem.ID()
@@ -707,7 +1068,7 @@ func TestItemHandler_Archive_PublishesEvent(t *testing.T) {
 	capture.Start()
 	defer capture.Stop()
 
-	item.RegisterRoutes(setup.API, mockSvc, capture.GetBroadcaster(), nil, nil)
+	item.RegisterRoutes(setup.API, mockSvc, capture.GetBroadcaster(), nil, nil, nil)
 
 	itemID := uuid.New()
 
@@ -735,7 +1096,7 @@ func TestItemHandler_Create_NilBroadcaster_NoError(t *testing.T) {
 	setup := testutil.NewHandlerTestSetup()
 	mockSvc := new(MockService)
 	// Register with nil broadcaster
-	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil)
+	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil, nil)
 
 	testItem, _ := item.NewItem(setup.WorkspaceID, "Test Item", "TEST-001", 0)
 
@@ -760,7 +1121,7 @@ func TestItemHandler_Delete_Success(t *testing.T) {
 	capture.Start()
 	defer capture.Stop()
 
-	item.RegisterRoutes(setup.API, mockSvc, capture.GetBroadcaster(), nil, nil)
+	item.RegisterRoutes(setup.API, mockSvc, capture.GetBroadcaster(), nil, nil, nil)
 
 	itemID := uuid.New()
 
@@ -792,7 +1153,7 @@ func TestItemHandler_Delete_Success(t *testing.T) {
 func TestItemHandler_Delete_CrossWorkspace_Returns404(t *testing.T) {
 	setup := testutil.NewHandlerTestSetup()
 	mockSvc := new(MockService)
-	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil)
+	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil, nil)
 
 	itemID := uuid.New()
 	mockSvc.On("Delete", mock.Anything, itemID, setup.WorkspaceID).
@@ -807,7 +1168,7 @@ func TestItemHandler_Delete_CrossWorkspace_Returns404(t *testing.T) {
 func TestItemHandler_List_Search_ForwardsToService(t *testing.T) {
 	setup := testutil.NewHandlerTestSetup()
 	mockSvc := new(MockService)
-	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil)
+	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil, nil)
 
 	mockSvc.On("ListFiltered", mock.Anything, setup.WorkspaceID,
 		mock.MatchedBy(func(f item.ListFilters) bool { return f.Search == "drill" }),
@@ -822,7 +1183,7 @@ func TestItemHandler_List_Search_ForwardsToService(t *testing.T) {
 func TestItemHandler_List_ArchivedTrue_ForwardsToService(t *testing.T) {
 	setup := testutil.NewHandlerTestSetup()
 	mockSvc := new(MockService)
-	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil)
+	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil, nil)
 
 	mockSvc.On("ListFiltered", mock.Anything, setup.WorkspaceID,
 		mock.MatchedBy(func(f item.ListFilters) bool { return f.IncludeArchived }),
@@ -834,10 +1195,44 @@ func TestItemHandler_List_ArchivedTrue_ForwardsToService(t *testing.T) {
 	mockSvc.AssertExpectations(t)
 }
 
+func TestItemHandler_List_IncludeArchived(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil, nil)
+
+	t.Run("archived items are hidden by default", func(t *testing.T) {
+		mockSvc.On("ListFiltered", mock.Anything, setup.WorkspaceID,
+			mock.MatchedBy(func(f item.ListFilters) bool { return !f.IncludeArchived }),
+			mock.Anything).Return([]*item.Item{}, 0, nil).Once()
+
+		rec := setup.Get("/items")
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("include=archived lists them", func(t *testing.T) {
+		mockSvc.On("ListFiltered", mock.Anything, setup.WorkspaceID,
+			mock.MatchedBy(func(f item.ListFilters) bool { return f.IncludeArchived }),
+			mock.Anything).Return([]*item.Item{}, 0, nil).Once()
+
+		rec := setup.Get("/items?include=archived")
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("rejects unknown include", func(t *testing.T) {
+		rec := setup.Get("/items?include=deleted")
+
+		testutil.AssertStatus(t, rec, http.StatusUnprocessableEntity)
+	})
+}
+
 func TestItemHandler_List_Sort_ForwardsToService(t *testing.T) {
 	setup := testutil.NewHandlerTestSetup()
 	mockSvc := new(MockService)
-	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil)
+	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil, nil)
 
 	mockSvc.On("ListFiltered", mock.Anything, setup.WorkspaceID,
 		mock.MatchedBy(func(f item.ListFilters) bool {
@@ -854,7 +1249,7 @@ func TestItemHandler_List_Sort_ForwardsToService(t *testing.T) {
 func TestItemHandler_List_Sort_ValidatesEnum(t *testing.T) {
 	setup := testutil.NewHandlerTestSetup()
 	mockSvc := new(MockService)
-	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil)
+	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil, nil)
 
 	rec := setup.Get("/items?sort=bogus")
 
@@ -866,10 +1261,57 @@ func TestItemHandler_List_Sort_ValidatesEnum(t *testing.T) {
 	mockSvc.AssertNotCalled(t, "ListFiltered", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 }
 
+func TestItemHandler_List_BrandAndHasInventory_ForwardsToService(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil, nil)
+
+	mockSvc.On("ListFiltered", mock.Anything, setup.WorkspaceID,
+		mock.MatchedBy(func(f item.ListFilters) bool {
+			return f.Brand != nil && *f.Brand == "Makita" &&
+				f.HasInventory != nil && !*f.HasInventory
+		}),
+		mock.Anything).Return([]*item.Item{}, 0, nil).Once()
+
+	rec := setup.Get("/items?brand=Makita&has_inventory=false")
+
+	testutil.AssertStatus(t, rec, http.StatusOK)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestItemHandler_List_BrandAndHasInventory_AbsentMeansNoFilter(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil, nil)
+
+	mockSvc.On("ListFiltered", mock.Anything, setup.WorkspaceID,
+		mock.MatchedBy(func(f item.ListFilters) bool {
+			return f.Brand == nil && f.HasInventory == nil
+		}),
+		mock.Anything).Return([]*item.Item{}, 0, nil).Once()
+
+	rec := setup.Get("/items")
+
+	testutil.AssertStatus(t, rec, http.StatusOK)
+	mockSvc.AssertExpectations(t)
+}
+
+func TestItemHandler_List_HasInventory_ValidatesEnum(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil, nil)
+
+	rec := setup.Get("/items?has_inventory=maybe")
+
+	assert.Contains(t, []int{http.StatusBadRequest, http.StatusUnprocessableEntity}, rec.Code,
+		"expected 400 or 422 for invalid has_inventory, got %d", rec.Code)
+	mockSvc.AssertNotCalled(t, "ListFiltered", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestItemHandler_List_Category_InvalidUUID_IgnoredNotErrored(t *testing.T) {
 	setup := testutil.NewHandlerTestSetup()
 	mockSvc := new(MockService)
-	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil)
+	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil, nil)
 
 	mockSvc.On("ListFiltered", mock.Anything, setup.WorkspaceID,
 		mock.MatchedBy(func(f item.ListFilters) bool { return f.CategoryID == nil }),
@@ -885,7 +1327,7 @@ func TestItemHandler_List_Category_InvalidUUID_IgnoredNotErrored(t *testing.T) {
 func TestItemHandler_List_Category_ValidUUID_ForwardsPointer(t *testing.T) {
 	setup := testutil.NewHandlerTestSetup()
 	mockSvc := new(MockService)
-	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil)
+	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil, nil)
 
 	catID := uuid.New()
 	mockSvc.On("ListFiltered", mock.Anything, setup.WorkspaceID,
@@ -901,7 +1343,7 @@ func TestItemHandler_List_Category_ValidUUID_ForwardsPointer(t *testing.T) {
 func TestItemHandler_List_TotalComputedCorrectly(t *testing.T) {
 	setup := testutil.NewHandlerTestSetup()
 	mockSvc := new(MockService)
-	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil)
+	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil, nil)
 
 	// Two rows on the page, 47 total — with limit=25 expect TotalPages=2.
 	it1, _ := item.NewItem(setup.WorkspaceID, "A", "TP-001", 0)
@@ -948,7 +1390,7 @@ func (m *mockPrimaryPhotoLookup) ListPrimaryByItemIDs(ctx context.Context, works
 	return args.Get(0).(map[uuid.UUID]*itemphoto.ItemPhoto), args.Error(1)
 }
 
-func testPhotoURLGen(workspaceID, itemID, photoID uuid.UUID, isThumbnail bool) string {
+func testPhotoURLGen(_ context.Context, workspaceID, itemID, photoID uuid.UUID, isThumbnail bool) string {
 	if isThumbnail {
 		return fmt.Sprintf("/ws/%s/items/%s/photos/%s/thumbnail", workspaceID, itemID, photoID)
 	}
@@ -959,7 +1401,7 @@ func TestItemHandler_List_IncludesPrimaryPhotoThumbnailURL(t *testing.T) {
 	setup := testutil.NewHandlerTestSetup()
 	mockSvc := new(MockService)
 	mockPhotos := new(mockPrimaryPhotoLookup)
-	item.RegisterRoutes(setup.API, mockSvc, nil, mockPhotos, testPhotoURLGen)
+	item.RegisterRoutes(setup.API, mockSvc, nil, mockPhotos, testPhotoURLGen, nil)
 
 	testItem, _ := item.NewItem(setup.WorkspaceID, "HasPhoto", "PH-001", 0)
 	photoID := uuid.New()
@@ -993,7 +1435,7 @@ func TestItemHandler_List_OmitsPrimaryPhotoFieldsWhenNoPrimary(t *testing.T) {
 	setup := testutil.NewHandlerTestSetup()
 	mockSvc := new(MockService)
 	mockPhotos := new(mockPrimaryPhotoLookup)
-	item.RegisterRoutes(setup.API, mockSvc, nil, mockPhotos, testPhotoURLGen)
+	item.RegisterRoutes(setup.API, mockSvc, nil, mockPhotos, testPhotoURLGen, nil)
 
 	testItem, _ := item.NewItem(setup.WorkspaceID, "NoPhoto", "NP-001", 0)
 
@@ -1017,7 +1459,7 @@ func TestItemHandler_Detail_IncludesPrimaryPhotoURL(t *testing.T) {
 	setup := testutil.NewHandlerTestSetup()
 	mockSvc := new(MockService)
 	mockPhotos := new(mockPrimaryPhotoLookup)
-	item.RegisterRoutes(setup.API, mockSvc, nil, mockPhotos, testPhotoURLGen)
+	item.RegisterRoutes(setup.API, mockSvc, nil, mockPhotos, testPhotoURLGen, nil)
 
 	testItem, _ := item.NewItem(setup.WorkspaceID, "DetailItem", "DT-001", 0)
 	itemID := testItem.ID()
@@ -1033,6 +1475,7 @@ func TestItemHandler_Detail_IncludesPrimaryPhotoURL(t *testing.T) {
 
 	mockSvc.On("GetByID", mock.Anything, itemID, setup.WorkspaceID).
 		Return(testItem, nil).Once()
+	mockSvc.On("RecordView", mock.Anything, setup.WorkspaceID, setup.UserID, itemID).Return(nil).Maybe()
 	mockPhotos.On("GetPrimary", mock.Anything, itemID, setup.WorkspaceID).
 		Return(primaryPhoto, nil).Once()
 
@@ -1049,7 +1492,7 @@ func TestItemHandler_List_PhotoLookupErrorDegradesToNoThumbnail(t *testing.T) {
 	setup := testutil.NewHandlerTestSetup()
 	mockSvc := new(MockService)
 	mockPhotos := new(mockPrimaryPhotoLookup)
-	item.RegisterRoutes(setup.API, mockSvc, nil, mockPhotos, testPhotoURLGen)
+	item.RegisterRoutes(setup.API, mockSvc, nil, mockPhotos, testPhotoURLGen, nil)
 
 	testItem, _ := item.NewItem(setup.WorkspaceID, "ErrItem", "ER-001", 0)
 
@@ -1067,6 +1510,85 @@ func TestItemHandler_List_PhotoLookupErrorDegradesToNoThumbnail(t *testing.T) {
 	mockPhotos.AssertExpectations(t)
 }
 
+// mockCustomValueLookup implements item.CustomValueLookup.
+type mockCustomValueLookup struct {
+	mock.Mock
+}
+
+func (m *mockCustomValueLookup) ValuesByItemIDs(ctx context.Context, workspaceID uuid.UUID, itemIDs []uuid.UUID) (map[uuid.UUID][]customfield.ItemValue, error) {
+	args := m.Called(ctx, workspaceID, itemIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID][]customfield.ItemValue), args.Error(1)
+}
+
+func TestItemHandler_IncludesCustomFieldValues(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	mockValues := new(mockCustomValueLookup)
+	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil, mockValues)
+
+	withValues, _ := item.NewItem(setup.WorkspaceID, "Drill", "CF-001", 0)
+	withoutValues, _ := item.NewItem(setup.WorkspaceID, "Hammer", "CF-002", 0)
+	values := map[uuid.UUID][]customfield.ItemValue{
+		withValues.ID(): {
+			{ItemID: withValues.ID(), FieldID: uuid.New(), FieldName: "Cordless", FieldType: customfield.FieldTypeBool, Value: "true"},
+			{ItemID: withValues.ID(), FieldID: uuid.New(), FieldName: "Voltage", FieldType: customfield.FieldTypeNumber, Value: "18"},
+		},
+	}
+
+	t.Run("list", func(t *testing.T) {
+		mockSvc.On("ListFiltered", mock.Anything, setup.WorkspaceID, mock.Anything, mock.Anything).
+			Return([]*item.Item{withValues, withoutValues}, 2, nil).Once()
+		mockValues.On("ValuesByItemIDs", mock.Anything, setup.WorkspaceID, []uuid.UUID{withValues.ID(), withoutValues.ID()}).
+			Return(values, nil).Once()
+
+		rec := setup.Get("/items")
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		var body struct {
+			Items []item.ItemResponse `json:"items"`
+		}
+		assert.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		if assert.Len(t, body.Items, 2) {
+			if assert.Len(t, body.Items[0].CustomFields, 2) {
+				assert.Equal(t, "Cordless", body.Items[0].CustomFields[0].Name)
+				assert.Equal(t, true, body.Items[0].CustomFields[0].Value)
+				assert.Equal(t, 18.0, body.Items[0].CustomFields[1].Value)
+			}
+			assert.Empty(t, body.Items[1].CustomFields)
+		}
+	})
+
+	mockSvc.On("RecordView", mock.Anything, setup.WorkspaceID, setup.UserID, withValues.ID()).Return(nil).Maybe()
+
+	t.Run("detail", func(t *testing.T) {
+		mockSvc.On("GetByID", mock.Anything, withValues.ID(), setup.WorkspaceID).Return(withValues, nil).Once()
+		mockValues.On("ValuesByItemIDs", mock.Anything, setup.WorkspaceID, []uuid.UUID{withValues.ID()}).
+			Return(values, nil).Once()
+
+		rec := setup.Get(fmt.Sprintf("/items/%s", withValues.ID()))
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		assert.Contains(t, rec.Body.String(), `"custom_fields"`)
+	})
+
+	t.Run("lookup error degrades to no values", func(t *testing.T) {
+		mockSvc.On("GetByID", mock.Anything, withValues.ID(), setup.WorkspaceID).Return(withValues, nil).Once()
+		mockValues.On("ValuesByItemIDs", mock.Anything, setup.WorkspaceID, mock.Anything).
+			Return(nil, errors.New("db down")).Once()
+
+		rec := setup.Get(fmt.Sprintf("/items/%s", withValues.ID()))
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		assert.NotContains(t, rec.Body.String(), `"custom_fields"`)
+	})
+
+	mockSvc.AssertExpectations(t)
+	mockValues.AssertExpectations(t)
+}
+
 func TestItemHandler_Restore_PublishesEvent(t *testing.T) {
 	setup := testutil.NewHandlerTestSetup()
 	mockSvc := new(MockService)
@@ -1074,7 +1596,7 @@ func TestItemHandler_Restore_PublishesEvent(t *testing.T) {
 	capture.Start()
 	defer capture.Stop()
 
-	item.RegisterRoutes(setup.API, mockSvc, capture.GetBroadcaster(), nil, nil)
+	item.RegisterRoutes(setup.API, mockSvc, capture.GetBroadcaster(), nil, nil, nil)
 
 	itemID := uuid.New()
 
@@ -1116,7 +1638,7 @@ func TestItemHandler_Restore_PublishesEvent(t *testing.T) {
 func TestItemHandler_LookupByBarcode(t *testing.T) {
 	setup := testutil.NewHandlerTestSetup()
 	mockSvc := new(MockService)
-	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil)
+	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil, nil)
 
 	t.Run("returns 200 with item on exact-barcode match (G-65-01 happy path)", func(t *testing.T) {
 		// NewItem(workspaceID, name, sku, minStockLevel) — does NOT accept
@@ -1128,20 +1650,45 @@ func TestItemHandler_LookupByBarcode(t *testing.T) {
 		testItem, _ := item.NewItem(setup.WorkspaceID, "Coca-Cola Original Taste", "ITEM-1", 0)
 
 		mockSvc.On("LookupByBarcode", mock.Anything, setup.WorkspaceID, "5449000000996").
-			Return(testItem, nil).Once()
+			Return(testItem, nil, nil).Once()
 
 		rec := setup.Get("/items/by-barcode/5449000000996")
 
 		testutil.AssertStatus(t, rec, http.StatusOK)
 		mockSvc.AssertExpectations(t)
 
-		var body item.ItemResponse
+		var body item.BarcodeLookupResponse
 		err := json.Unmarshal(rec.Body.Bytes(), &body)
 		assert.NoError(t, err)
 		assert.Equal(t, testItem.ID(), body.ID)
 		assert.Equal(t, setup.WorkspaceID, body.WorkspaceID)
 		assert.Equal(t, "Coca-Cola Original Taste", body.Name)
 		assert.Equal(t, "ITEM-1", body.SKU)
+		assert.True(t, body.MatchedIdentifier.Primary)
+		assert.Equal(t, "barcode", body.MatchedIdentifier.Type)
+		assert.Equal(t, "5449000000996", body.MatchedIdentifier.Value)
+		assert.Nil(t, body.MatchedIdentifier.ID)
+	})
+
+	t.Run("reports the additional identifier that matched", func(t *testing.T) {
+		testItem, _ := item.NewItem(setup.WorkspaceID, "Cordless Drill", "ITEM-2", 0)
+		identifier, err := item.NewIdentifier(setup.WorkspaceID, testItem.ID(), item.IdentifierTypeAssetTag, "TAG-0042")
+		require.NoError(t, err)
+
+		mockSvc.On("LookupByBarcode", mock.Anything, setup.WorkspaceID, "TAG-0042").
+			Return(testItem, identifier, nil).Once()
+
+		rec := setup.Get("/items/by-barcode/TAG-0042")
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		var body item.BarcodeLookupResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, testItem.ID(), body.ID)
+		assert.False(t, body.MatchedIdentifier.Primary)
+		assert.Equal(t, "asset_tag", body.MatchedIdentifier.Type)
+		assert.Equal(t, "TAG-0042", body.MatchedIdentifier.Value)
+		require.NotNil(t, body.MatchedIdentifier.ID)
+		assert.Equal(t, identifier.ID(), *body.MatchedIdentifier.ID)
 	})
 
 	t.Run("returns 404 when no item matches (covers not-exists and cross-workspace cases at this layer)", func(t *testing.T) {
@@ -1151,7 +1698,7 @@ func TestItemHandler_LookupByBarcode(t *testing.T) {
 		// this at the DB layer, and svc.LookupByBarcode surfaces ErrItemNotFound
 		// in both cases. The handler test asserts the same 404 surface holds.
 		mockSvc.On("LookupByBarcode", mock.Anything, setup.WorkspaceID, "UNKNOWN-CODE").
-			Return(nil, item.ErrItemNotFound).Once()
+			Return(nil, nil, item.ErrItemNotFound).Once()
 
 		rec := setup.Get("/items/by-barcode/UNKNOWN-CODE")
 
@@ -1161,7 +1708,7 @@ func TestItemHandler_LookupByBarcode(t *testing.T) {
 
 	t.Run("returns 500 on unexpected service error", func(t *testing.T) {
 		mockSvc.On("LookupByBarcode", mock.Anything, setup.WorkspaceID, "OPAQUE").
-			Return(nil, errors.New("db connection reset")).Once()
+			Return(nil, nil, errors.New("db connection reset")).Once()
 
 		rec := setup.Get("/items/by-barcode/OPAQUE")
 
@@ -1190,9 +1737,9 @@ func TestItemHandler_LookupByBarcode(t *testing.T) {
 		// guard that the handler does not lowercase the path param before
 		// passing to the service.
 		mockSvc.On("LookupByBarcode", mock.Anything, setup.WorkspaceID, "ABC-123").
-			Return(nil, item.ErrItemNotFound).Once()
+			Return(nil, nil, item.ErrItemNotFound).Once()
 		mockSvc.On("LookupByBarcode", mock.Anything, setup.WorkspaceID, "abc-123").
-			Return(nil, item.ErrItemNotFound).Once()
+			Return(nil, nil, item.ErrItemNotFound).Once()
 
 		rec1 := setup.Get("/items/by-barcode/ABC-123")
 		rec2 := setup.Get("/items/by-barcode/abc-123")
@@ -1203,6 +1750,76 @@ func TestItemHandler_LookupByBarcode(t *testing.T) {
 	})
 }
 
+func TestItemHandler_Identifiers(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil, nil)
+
+	itemID := uuid.New()
+	identifier, err := item.NewIdentifier(setup.WorkspaceID, itemID, item.IdentifierTypeUPC, "036000291452")
+	require.NoError(t, err)
+
+	t.Run("lists identifiers", func(t *testing.T) {
+		mockSvc.On("ListIdentifiers", mock.Anything, setup.WorkspaceID, itemID).
+			Return([]*item.Identifier{identifier}, nil).Once()
+
+		rec := setup.Get(fmt.Sprintf("/items/%s/identifiers", itemID))
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		var body item.ItemIdentifiersResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		require.Len(t, body.Identifiers, 1)
+		assert.Equal(t, "upc", body.Identifiers[0].Type)
+		assert.Equal(t, "036000291452", body.Identifiers[0].Value)
+	})
+
+	t.Run("adds an identifier", func(t *testing.T) {
+		mockSvc.On("AddIdentifier", mock.Anything, setup.WorkspaceID, itemID, item.IdentifierTypeUPC, "036000291452").
+			Return(identifier, nil).Once()
+
+		rec := setup.Post(fmt.Sprintf("/items/%s/identifiers", itemID), `{"type":"upc","value":"036000291452"}`)
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		var body item.ItemIdentifierResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, identifier.ID(), body.ID)
+	})
+
+	t.Run("taken code is 409", func(t *testing.T) {
+		mockSvc.On("AddIdentifier", mock.Anything, setup.WorkspaceID, itemID, item.IdentifierTypeEAN, "TAKEN").
+			Return(nil, item.ErrIdentifierTaken).Once()
+
+		rec := setup.Post(fmt.Sprintf("/items/%s/identifiers", itemID), `{"type":"ean","value":"TAKEN"}`)
+
+		testutil.AssertStatus(t, rec, http.StatusConflict)
+	})
+
+	t.Run("unknown type is rejected before the service", func(t *testing.T) {
+		rec := setup.Post(fmt.Sprintf("/items/%s/identifiers", itemID), `{"type":"qr","value":"X"}`)
+
+		assert.Contains(t, []int{http.StatusBadRequest, http.StatusUnprocessableEntity}, rec.Code)
+		mockSvc.AssertNotCalled(t, "AddIdentifier", mock.Anything, mock.Anything, mock.Anything, item.IdentifierType("qr"), mock.Anything)
+	})
+
+	t.Run("removes an identifier", func(t *testing.T) {
+		mockSvc.On("RemoveIdentifier", mock.Anything, setup.WorkspaceID, itemID, identifier.ID()).Return(nil).Once()
+
+		rec := setup.Delete(fmt.Sprintf("/items/%s/identifiers/%s", itemID, identifier.ID()))
+
+		testutil.AssertStatus(t, rec, http.StatusNoContent)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("removing an unknown identifier is 404", func(t *testing.T) {
+		otherID := uuid.New()
+		mockSvc.On("RemoveIdentifier", mock.Anything, setup.WorkspaceID, itemID, otherID).Return(item.ErrIdentifierNotFound).Once()
+
+		rec := setup.Delete(fmt.Sprintf("/items/%s/identifiers/%s", itemID, otherID))
+
+		testutil.AssertStatus(t, rec, http.StatusNotFound)
+	})
+}
+
 // TestItemHandler_Update_PatchMergeSemantics guards the PATCH merge contract
 // (regression for the bug where every field omitted from a PATCH body was
 // wiped to NULL by the full-state entity Update):
@@ -1215,7 +1832,7 @@ func TestItemHandler_LookupByBarcode(t *testing.T) {
 func TestItemHandler_Update_PatchMergeSemantics(t *testing.T) {
 	setup := testutil.NewHandlerTestSetup()
 	mockSvc := new(MockService)
-	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil)
+	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil, nil)
 
 	strPtr := func(s string) *string { return &s }
 	boolPtr := func(b bool) *bool { return &b }
@@ -1249,6 +1866,7 @@ func TestItemHandler_Update_PatchMergeSemantics(t *testing.T) {
 			strPtr("MainVault"),             // obsidianVaultPath
 			strPtr("Items/laptop.md"),       // obsidianNotePath
 			boolPtr(true),                   // needsReview
+			true,                            // loanable
 			now, now,
 		)
 	}
@@ -1390,3 +2008,185 @@ func TestItemHandler_Update_PatchMergeSemantics(t *testing.T) {
 		})
 	}
 }
+
+func TestItemHandler_ListSimilar(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil, nil)
+
+	t.Run("lists pairs with the default threshold", func(t *testing.T) {
+		pair := item.SimilarPair{
+			Item:       item.SimilarItem{ID: uuid.New(), Name: "Cordless Drill", SKU: "DRL-1"},
+			Match:      item.SimilarItem{ID: uuid.New(), Name: "Cordless Drill 18V", SKU: "DRL-2"},
+			Similarity: 0.8,
+		}
+		mockSvc.On("FindSimilarItems", mock.Anything, setup.WorkspaceID, 0.5).
+			Return([]item.SimilarPair{pair}, nil).Once()
+
+		rec := setup.Get("/items/similar")
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		resp := testutil.ParseJSONResponse[item.SimilarItemsResponse](t, rec)
+		if assert.Len(t, resp.Pairs, 1) {
+			assert.Equal(t, pair.Item.ID, resp.Pairs[0].Item.ID)
+			assert.Equal(t, "DRL-2", resp.Pairs[0].Match.SKU)
+			assert.Equal(t, 0.8, resp.Pairs[0].Similarity)
+		}
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("forwards the threshold", func(t *testing.T) {
+		mockSvc.On("FindSimilarItems", mock.Anything, setup.WorkspaceID, 0.3).
+			Return([]item.SimilarPair{}, nil).Once()
+
+		rec := setup.Get("/items/similar?threshold=0.3")
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("rejects out-of-range threshold", func(t *testing.T) {
+		rec := setup.Get("/items/similar?threshold=1.5")
+
+		testutil.AssertStatus(t, rec, http.StatusUnprocessableEntity)
+	})
+}
+
+func TestItemHandler_Merge(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	item.RegisterRoutes(setup.API, mockSvc, nil, nil, nil, nil)
+
+	keepID, mergeID := uuid.New(), uuid.New()
+	body := `{"merge_item_id":"` + mergeID.String() + `"}`
+
+	t.Run("merges and reports moved records", func(t *testing.T) {
+		mockSvc.On("MergeItems", mock.Anything, setup.WorkspaceID, keepID, mergeID, "").
+			Return(&item.MergeResult{InventoryMoved: 3, LoansMoved: 2, LabelsMoved: 1, PhotosMoved: 4}, nil).Once()
+
+		rec := setup.Post("/items/"+keepID.String()+"/merge", body)
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		resp := testutil.ParseJSONResponse[item.MergeItemsResponse](t, rec)
+		assert.Equal(t, item.MergeItemsResponse{InventoryMoved: 3, LoansMoved: 2, LabelsMoved: 1, PhotosMoved: 4}, resp)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("forwards the SKU", func(t *testing.T) {
+		mockSvc.On("MergeItems", mock.Anything, setup.WorkspaceID, keepID, mergeID, "DRL-2").
+			Return(&item.MergeResult{}, nil).Once()
+
+		rec := setup.Post("/items/"+keepID.String()+"/merge",
+			`{"merge_item_id":"`+mergeID.String()+`","sku":"DRL-2"}`)
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("returns 409 when the SKU is taken", func(t *testing.T) {
+		mockSvc.On("MergeItems", mock.Anything, setup.WorkspaceID, keepID, mergeID, "OTHER").
+			Return(nil, item.ErrSKUTaken).Once()
+
+		rec := setup.Post("/items/"+keepID.String()+"/merge",
+			`{"merge_item_id":"`+mergeID.String()+`","sku":"OTHER"}`)
+
+		testutil.AssertStatus(t, rec, http.StatusConflict)
+	})
+
+	t.Run("returns 404 when the item is missing", func(t *testing.T) {
+		mockSvc.On("MergeItems", mock.Anything, setup.WorkspaceID, keepID, mergeID, "").
+			Return(nil, item.ErrItemNotFound).Once()
+
+		rec := setup.Post("/items/"+keepID.String()+"/merge", body)
+
+		testutil.AssertStatus(t, rec, http.StatusNotFound)
+	})
+
+	t.Run("returns 400 when merging an item into itself", func(t *testing.T) {
+		mockSvc.On("MergeItems", mock.Anything, setup.WorkspaceID, keepID, keepID, "").
+			Return(nil, item.ErrMergeIntoSelf).Once()
+
+		rec := setup.Post("/items/"+keepID.String()+"/merge", `{"merge_item_id":"`+keepID.String()+`"}`)
+
+		testutil.AssertStatus(t, rec, http.StatusBadRequest)
+	})
+}
+
+func TestItemHandler_CategoryRules(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	item.RegisterCategoryRuleRoutes(setup.API, mockSvc)
+
+	categoryID := uuid.New()
+	rule := &item.CategoryRule{ID: uuid.New(), CategoryID: categoryID, Pattern: "drill", MatchType: item.MatchSubstring}
+
+	t.Run("lists the rules in order", func(t *testing.T) {
+		second := &item.CategoryRule{ID: uuid.New(), CategoryID: categoryID, Pattern: "^saw", MatchType: item.MatchRegex, Position: 1}
+		mockSvc.On("ListCategoryRules", mock.Anything, setup.WorkspaceID).Return([]*item.CategoryRule{rule, second}, nil).Once()
+
+		rec := setup.Get("/category-rules")
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		var body item.CategoryRuleListResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		require.Len(t, body.Items, 2)
+		assert.Equal(t, item.MatchRegex, body.Items[1].MatchType)
+	})
+
+	t.Run("creates a substring rule by default", func(t *testing.T) {
+		input := item.CategoryRuleInput{CategoryID: categoryID, Pattern: "drill", MatchType: item.MatchSubstring}
+		mockSvc.On("CreateCategoryRule", mock.Anything, setup.WorkspaceID, input).Return(rule, nil).Once()
+
+		rec := setup.Post("/category-rules", fmt.Sprintf(`{"category_id":%q,"pattern":"drill"}`, categoryID))
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("returns 400 for an invalid pattern", func(t *testing.T) {
+		mockSvc.On("CreateCategoryRule", mock.Anything, setup.WorkspaceID, mock.Anything).
+			Return(nil, shared.NewFieldError(shared.ErrInvalidInput, "pattern", "invalid regular expression")).Once()
+
+		rec := setup.Post("/category-rules", fmt.Sprintf(`{"category_id":%q,"pattern":"(","match_type":"regex"}`, categoryID))
+
+		testutil.AssertStatus(t, rec, http.StatusBadRequest)
+	})
+
+	t.Run("returns 404 for an unknown rule", func(t *testing.T) {
+		mockSvc.On("UpdateCategoryRule", mock.Anything, setup.WorkspaceID, rule.ID, mock.Anything).
+			Return(nil, item.ErrCategoryRuleNotFound).Once()
+
+		rec := setup.Put(fmt.Sprintf("/category-rules/%s", rule.ID), fmt.Sprintf(`{"category_id":%q,"pattern":"saw"}`, categoryID))
+
+		testutil.AssertStatus(t, rec, http.StatusNotFound)
+	})
+
+	t.Run("reorders the rules", func(t *testing.T) {
+		ids := []uuid.UUID{uuid.New(), rule.ID}
+		mockSvc.On("ReorderCategoryRules", mock.Anything, setup.WorkspaceID, ids).Return([]*item.CategoryRule{rule}, nil).Once()
+
+		rec := setup.Put("/category-rules/order", fmt.Sprintf(`{"rule_ids":[%q,%q]}`, ids[0], ids[1]))
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("rejects an incomplete order", func(t *testing.T) {
+		mockSvc.On("ReorderCategoryRules", mock.Anything, setup.WorkspaceID, mock.Anything).Return(nil, item.ErrCategoryRuleOrder).Once()
+
+		rec := setup.Put("/category-rules/order", `{"rule_ids":[]}`)
+
+		testutil.AssertStatus(t, rec, http.StatusBadRequest)
+	})
+
+	t.Run("members cannot change the rules", func(t *testing.T) {
+		setup.SetRole("member")
+		defer setup.SetRole("owner")
+
+		rec := setup.Post("/category-rules", fmt.Sprintf(`{"category_id":%q,"pattern":"drill"}`, categoryID))
+		testutil.AssertStatus(t, rec, http.StatusForbidden)
+
+		rec = setup.Delete(fmt.Sprintf("/category-rules/%s", rule.ID))
+		testutil.AssertStatus(t, rec, http.StatusForbidden)
+	})
+}
@@ -52,7 +52,7 @@ func newIdempotencyTestAPI(svc item.ServiceInterface, wsID uuid.UUID, userID uui
 	})
 	config := huma.DefaultConfig("Integration Test API", "1.0.0")
 	api := humachi.New(r, config)
-	item.RegisterRoutes(api, svc, nil, nil, nil)
+	item.RegisterRoutes(api, svc, nil, nil, nil, nil)
 	return r
 }
 
@@ -0,0 +1,83 @@
+package item
+
+import (
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// IdentifierType is the kind of code an identifier holds.
+type IdentifierType string
+
+const (
+	IdentifierTypeUPC      IdentifierType = "upc"
+	IdentifierTypeEAN      IdentifierType = "ean"
+	IdentifierTypeISBN     IdentifierType = "isbn"
+	IdentifierTypeAssetTag IdentifierType = "asset_tag"
+	IdentifierTypeOther    IdentifierType = "other"
+)
+
+// MaxIdentifierLength matches the item_identifiers.value column.
+const MaxIdentifierLength = 50
+
+func (t IdentifierType) IsValid() bool {
+	switch t {
+	case IdentifierTypeUPC, IdentifierTypeEAN, IdentifierTypeISBN, IdentifierTypeAssetTag, IdentifierTypeOther:
+		return true
+	}
+	return false
+}
+
+// Identifier is an extra code an item can be scanned by, next to its primary
+// barcode. Values are unique per workspace across both.
+type Identifier struct {
+	id             uuid.UUID
+	workspaceID    uuid.UUID
+	itemID         uuid.UUID
+	identifierType IdentifierType
+	value          string
+	createdAt      time.Time
+}
+
+func NewIdentifier(workspaceID, itemID uuid.UUID, identifierType IdentifierType, value string) (*Identifier, error) {
+	if err := shared.ValidateUUID(workspaceID, "workspace_id"); err != nil {
+		return nil, err
+	}
+	if err := shared.ValidateUUID(itemID, "item_id"); err != nil {
+		return nil, err
+	}
+	if !identifierType.IsValid() {
+		return nil, ErrInvalidIdentifierType
+	}
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return nil, shared.NewFieldError(shared.ErrInvalidInput, "value", "identifier value is required")
+	}
+	if utf8.RuneCountInString(value) > MaxIdentifierLength {
+		return nil, shared.NewFieldError(shared.ErrInvalidInput, "value", "identifier value is too long")
+	}
+
+	return &Identifier{
+		id:             shared.NewUUID(),
+		workspaceID:    workspaceID,
+		itemID:         itemID,
+		identifierType: identifierType,
+		value:          value,
+		createdAt:      time.Now(),
+	}, nil
+}
+
+func ReconstructIdentifier(id, workspaceID, itemID uuid.UUID, identifierType IdentifierType, value string, createdAt time.Time) *Identifier {
+	return &Identifier{id, workspaceID, itemID, identifierType, value, createdAt}
+}
+
+func (i *Identifier) ID() uuid.UUID          { return i.id }
+func (i *Identifier) WorkspaceID() uuid.UUID { return i.workspaceID }
+func (i *Identifier) ItemID() uuid.UUID      { return i.itemID }
+func (i *Identifier) Type() IdentifierType   { return i.identifierType }
+func (i *Identifier) Value() string          { return i.value }
+func (i *Identifier) CreatedAt() time.Time   { return i.createdAt }
@@ -0,0 +1,156 @@
+package item
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+const (
+	// DefaultSimilarityThreshold is the trigram similarity FindSimilarItems
+	// uses when the caller passes none.
+	DefaultSimilarityThreshold = 0.5
+	// MaxSimilarPairs caps how many pairs FindSimilarItems returns.
+	MaxSimilarPairs = 50
+)
+
+// Transactor runs a function inside a single database transaction. It is a
+// port implemented by infra/postgres.TxManager, keeping this package free of
+// infra imports.
+type Transactor interface {
+	WithTx(ctx context.Context, fn func(context.Context) error) error
+}
+
+// noopTransactor executes the function without a surrounding transaction. It
+// is the fallback when no Transactor is wired (unit tests with mocked
+// repositories).
+type noopTransactor struct{}
+
+func (noopTransactor) WithTx(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+// SetTransactor wires the transaction runner used by MergeItems so the moves
+// and the delete commit together. Optional — without it the steps run
+// unwrapped (unit tests with mocked repositories).
+func (s *Service) SetTransactor(tx Transactor) {
+	if tx == nil {
+		tx = noopTransactor{}
+	}
+	s.tx = tx
+}
+
+// SimilarItem is one side of a SimilarPair.
+type SimilarItem struct {
+	ID    uuid.UUID
+	Name  string
+	SKU   string
+	Brand *string
+}
+
+// SimilarPair is two items that look like duplicates of each other.
+// Similarity is the trigram similarity of their "name brand" text, 0–1.
+type SimilarPair struct {
+	Item       SimilarItem
+	Match      SimilarItem
+	Similarity float64
+}
+
+// FindSimilarItems suggests likely duplicate items to merge: pairs of active
+// items whose name and brand are at least threshold similar (0 < threshold
+// <= 1), most similar first. A zero threshold uses DefaultSimilarityThreshold.
+func (s *Service) FindSimilarItems(ctx context.Context, workspaceID uuid.UUID, threshold float64) ([]SimilarPair, error) {
+	if threshold == 0 {
+		threshold = DefaultSimilarityThreshold
+	}
+	if threshold < 0 || threshold > 1 {
+		return nil, ErrInvalidThreshold
+	}
+	return s.repo.FindSimilar(ctx, workspaceID, threshold, MaxSimilarPairs)
+}
+
+// MergeResult reports what was moved onto the kept item by a merge.
+type MergeResult struct {
+	InventoryMoved int
+	// LoansMoved counts the loans that came along with the moved inventory.
+	LoansMoved  int
+	LabelsMoved int
+	PhotosMoved int
+}
+
+// MergeItems folds mergeID into keepID: its inventory (and with it its
+// loans), labels and photos are re-pointed to keepID, and mergeID is then
+// deleted. Everything happens in one transaction.
+//
+// A non-empty sku replaces keepID's SKU, e.g. to keep the merged item's. It
+// is rejected with ErrSKUTaken when any item other than the two being merged
+// already uses it.
+func (s *Service) MergeItems(ctx context.Context, workspaceID, keepID, mergeID uuid.UUID, sku string) (*MergeResult, error) {
+	if keepID == mergeID {
+		return nil, ErrMergeIntoSelf
+	}
+
+	keep, err := s.GetByID(ctx, keepID, workspaceID)
+	if err != nil {
+		if errors.Is(err, shared.ErrNotFound) {
+			return nil, ErrItemNotFound
+		}
+		return nil, err
+	}
+
+	merge, err := s.repo.FindByID(ctx, mergeID, workspaceID)
+	if err != nil {
+		if errors.Is(err, shared.ErrNotFound) {
+			return nil, shared.NewFieldError(shared.ErrNotFound, "merge_item_id", fmt.Sprintf("item %s not found in this workspace", mergeID))
+		}
+		return nil, err
+	}
+
+	changeSKU := sku != "" && sku != keep.SKU()
+	if changeSKU && sku != merge.SKU() {
+		exists, err := s.repo.SKUExists(ctx, workspaceID, sku)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			return nil, ErrSKUTaken
+		}
+	}
+
+	result := &MergeResult{}
+	err = s.tx.WithTx(ctx, func(txCtx context.Context) error {
+		loans, err := s.repo.CountLoans(txCtx, workspaceID, mergeID)
+		if err != nil {
+			return err
+		}
+		result.LoansMoved = loans
+
+		if result.InventoryMoved, err = s.repo.MoveInventory(txCtx, workspaceID, mergeID, keepID); err != nil {
+			return err
+		}
+		if result.LabelsMoved, err = s.repo.MoveLabels(txCtx, workspaceID, mergeID, keepID); err != nil {
+			return err
+		}
+		if result.PhotosMoved, err = s.repo.MovePhotos(txCtx, workspaceID, mergeID, keepID); err != nil {
+			return err
+		}
+
+		// Delete before taking over the SKU, which may be the merged item's.
+		if err := s.repo.Delete(txCtx, mergeID, workspaceID); err != nil {
+			return err
+		}
+		if changeSKU {
+			return s.repo.UpdateSKU(txCtx, workspaceID, keepID, sku)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
@@ -0,0 +1,208 @@
+package item
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// countingTransactor runs fn inline and records how often WithTx was used.
+type countingTransactor struct{ calls int }
+
+func (c *countingTransactor) WithTx(ctx context.Context, fn func(context.Context) error) error {
+	c.calls++
+	return fn(ctx)
+}
+
+func TestService_FindSimilarItems(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+
+	t.Run("passes the threshold and cap to the repository", func(t *testing.T) {
+		repo := new(MockRepository)
+		svc := NewService(repo, nil)
+		pairs := []SimilarPair{{
+			Item:       SimilarItem{ID: uuid.New(), Name: "Cordless Drill", SKU: "DRL-1"},
+			Match:      SimilarItem{ID: uuid.New(), Name: "Cordless Drill 18V", SKU: "DRL-2"},
+			Similarity: 0.72,
+		}}
+		repo.On("FindSimilar", ctx, workspaceID, 0.7, MaxSimilarPairs).Return(pairs, nil)
+
+		got, err := svc.FindSimilarItems(ctx, workspaceID, 0.7)
+
+		require.NoError(t, err)
+		assert.Equal(t, pairs, got)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("zero threshold uses the default", func(t *testing.T) {
+		repo := new(MockRepository)
+		svc := NewService(repo, nil)
+		repo.On("FindSimilar", ctx, workspaceID, DefaultSimilarityThreshold, MaxSimilarPairs).Return([]SimilarPair{}, nil)
+
+		_, err := svc.FindSimilarItems(ctx, workspaceID, 0)
+
+		require.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	for _, threshold := range []float64{-0.1, 1.5} {
+		t.Run("rejects out-of-range threshold", func(t *testing.T) {
+			repo := new(MockRepository)
+			svc := NewService(repo, nil)
+
+			_, err := svc.FindSimilarItems(ctx, workspaceID, threshold)
+
+			assert.ErrorIs(t, err, ErrInvalidThreshold)
+			repo.AssertNotCalled(t, "FindSimilar", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestService_MergeItems(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+
+	newItems := func(t *testing.T) (*Item, *Item) {
+		t.Helper()
+		keep, err := NewItem(workspaceID, "Cordless Drill", "DRL-001", 0)
+		require.NoError(t, err)
+		merge, err := NewItem(workspaceID, "Cordless drill 18V", "DRL-002", 0)
+		require.NoError(t, err)
+		return keep, merge
+	}
+
+	// expectMerge stubs the lookups and every move, returning the repo.
+	expectMerge := func(keep, merge *Item) *MockRepository {
+		repo := new(MockRepository)
+		repo.On("FindByID", ctx, keep.ID(), workspaceID).Return(keep, nil)
+		repo.On("FindByID", ctx, merge.ID(), workspaceID).Return(merge, nil)
+		repo.On("CountLoans", ctx, workspaceID, merge.ID()).Return(2, nil)
+		repo.On("MoveInventory", ctx, workspaceID, merge.ID(), keep.ID()).Return(3, nil)
+		repo.On("MoveLabels", ctx, workspaceID, merge.ID(), keep.ID()).Return(1, nil)
+		repo.On("MovePhotos", ctx, workspaceID, merge.ID(), keep.ID()).Return(4, nil)
+		repo.On("Delete", ctx, merge.ID()).Return(nil)
+		return repo
+	}
+
+	t.Run("re-points inventory, loans, labels and photos and deletes the merged item", func(t *testing.T) {
+		keep, merge := newItems(t)
+		repo := expectMerge(keep, merge)
+		tx := &countingTransactor{}
+		svc := NewService(repo, nil)
+		svc.SetTransactor(tx)
+
+		result, err := svc.MergeItems(ctx, workspaceID, keep.ID(), merge.ID(), "")
+
+		require.NoError(t, err)
+		assert.Equal(t, &MergeResult{InventoryMoved: 3, LoansMoved: 2, LabelsMoved: 1, PhotosMoved: 4}, result)
+		assert.Equal(t, 1, tx.calls)
+		repo.AssertExpectations(t)
+		repo.AssertNotCalled(t, "UpdateSKU", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("takes over the merged item's SKU", func(t *testing.T) {
+		keep, merge := newItems(t)
+		repo := expectMerge(keep, merge)
+		repo.On("UpdateSKU", ctx, workspaceID, keep.ID(), "DRL-002").Return(nil)
+		svc := NewService(repo, nil)
+
+		_, err := svc.MergeItems(ctx, workspaceID, keep.ID(), merge.ID(), "DRL-002")
+
+		require.NoError(t, err)
+		repo.AssertExpectations(t)
+		repo.AssertNotCalled(t, "SKUExists", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("sets a free new SKU", func(t *testing.T) {
+		keep, merge := newItems(t)
+		repo := expectMerge(keep, merge)
+		repo.On("SKUExists", ctx, workspaceID, "DRL-100").Return(false, nil)
+		repo.On("UpdateSKU", ctx, workspaceID, keep.ID(), "DRL-100").Return(nil)
+		svc := NewService(repo, nil)
+
+		_, err := svc.MergeItems(ctx, workspaceID, keep.ID(), merge.ID(), "DRL-100")
+
+		require.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("rejects a SKU used by another item", func(t *testing.T) {
+		keep, merge := newItems(t)
+		repo := new(MockRepository)
+		repo.On("FindByID", ctx, keep.ID(), workspaceID).Return(keep, nil)
+		repo.On("FindByID", ctx, merge.ID(), workspaceID).Return(merge, nil)
+		repo.On("SKUExists", ctx, workspaceID, "OTHER-1").Return(true, nil)
+		svc := NewService(repo, nil)
+
+		result, err := svc.MergeItems(ctx, workspaceID, keep.ID(), merge.ID(), "OTHER-1")
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, ErrSKUTaken)
+		repo.AssertNotCalled(t, "MoveInventory", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		repo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects merging an item into itself", func(t *testing.T) {
+		keep, _ := newItems(t)
+		repo := new(MockRepository)
+		svc := NewService(repo, nil)
+
+		result, err := svc.MergeItems(ctx, workspaceID, keep.ID(), keep.ID(), "")
+
+		assert.Nil(t, result)
+		assert.ErrorIs(t, err, ErrMergeIntoSelf)
+		repo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("returns ErrItemNotFound when the kept item is missing", func(t *testing.T) {
+		keep, merge := newItems(t)
+		repo := new(MockRepository)
+		repo.On("FindByID", ctx, keep.ID(), workspaceID).Return(nil, shared.ErrNotFound)
+		svc := NewService(repo, nil)
+
+		_, err := svc.MergeItems(ctx, workspaceID, keep.ID(), merge.ID(), "")
+
+		assert.ErrorIs(t, err, ErrItemNotFound)
+	})
+
+	t.Run("returns field error when the merged item is missing", func(t *testing.T) {
+		keep, merge := newItems(t)
+		repo := new(MockRepository)
+		repo.On("FindByID", ctx, keep.ID(), workspaceID).Return(keep, nil)
+		repo.On("FindByID", ctx, merge.ID(), workspaceID).Return(nil, shared.ErrNotFound)
+		svc := NewService(repo, nil)
+
+		_, err := svc.MergeItems(ctx, workspaceID, keep.ID(), merge.ID(), "")
+
+		assert.ErrorIs(t, err, shared.ErrNotFound)
+		var domainErr *shared.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, "merge_item_id", domainErr.Field)
+	})
+
+	t.Run("stops at the first failed move", func(t *testing.T) {
+		keep, merge := newItems(t)
+		repo := new(MockRepository)
+		repo.On("FindByID", ctx, keep.ID(), workspaceID).Return(keep, nil)
+		repo.On("FindByID", ctx, merge.ID(), workspaceID).Return(merge, nil)
+		repo.On("CountLoans", ctx, workspaceID, merge.ID()).Return(0, nil)
+		repo.On("MoveInventory", ctx, workspaceID, merge.ID(), keep.ID()).Return(0, nil)
+		repo.On("MoveLabels", ctx, workspaceID, merge.ID(), keep.ID()).Return(0, errors.New("db down"))
+		svc := NewService(repo, nil)
+
+		result, err := svc.MergeItems(ctx, workspaceID, keep.ID(), merge.ID(), "")
+
+		assert.Nil(t, result)
+		assert.Error(t, err)
+		repo.AssertNotCalled(t, "MovePhotos", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		repo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
@@ -17,17 +17,47 @@ type ListFilters struct {
 	CategoryID      *uuid.UUID // filter by category (exact id, no descendants); nil → no filter
 	IsInsured       *bool      // filter by is_insured; nil → no filter
 	NeedsReview     *bool      // filter by needs_review; nil → no filter
+	Brand           *string    // case-insensitive exact brand match; nil → no filter
+	HasInventory    *bool      // true → has non-archived stock; false → has none; nil → no filter
 	IncludeArchived bool       // true → include is_archived=true rows; false → active only
 	Sort            string     // one of: name, sku, created_at, updated_at
 	SortDir         string     // one of: asc, desc
 }
 
+// validSortFields is the allowlist of sortable columns. The SQL orders via a
+// CASE per field, so an unlisted value would silently fall back to an
+// unordered result instead of reaching the query as a column name.
+var validSortFields = map[string]bool{
+	"name":       true,
+	"sku":        true,
+	"created_at": true,
+	"updated_at": true,
+}
+
+// Validate rejects sort fields and directions outside the allowlist. Empty
+// values are allowed and default to name ascending in the repository.
+func (f ListFilters) Validate() error {
+	if f.Sort != "" && !validSortFields[f.Sort] {
+		return ErrInvalidSort
+	}
+	if f.SortDir != "" && f.SortDir != "asc" && f.SortDir != "desc" {
+		return ErrInvalidSort
+	}
+	return nil
+}
+
 type Repository interface {
 	Save(ctx context.Context, item *Item) error
 	FindByID(ctx context.Context, id, workspaceID uuid.UUID) (*Item, error)
+	// FindByIDs returns the workspace's items with the given IDs in the order
+	// the IDs are listed, in one query. IDs without an item are omitted.
+	FindByIDs(ctx context.Context, workspaceID uuid.UUID, ids []uuid.UUID) ([]*Item, error)
 	FindBySKU(ctx context.Context, workspaceID uuid.UUID, sku string) (*Item, error)
 	FindByShortCode(ctx context.Context, workspaceID uuid.UUID, shortCode string) (*Item, error)
-	FindByBarcode(ctx context.Context, workspaceID uuid.UUID, barcode string) (*Item, error)
+	// FindByBarcode resolves a scanned code: the item whose primary barcode is
+	// the code, else the item holding an identifier with that value. The
+	// returned identifier is nil when the primary barcode matched.
+	FindByBarcode(ctx context.Context, workspaceID uuid.UUID, barcode string) (*Item, *Identifier, error)
 	FindByWorkspace(ctx context.Context, workspaceID uuid.UUID, pagination shared.Pagination) ([]*Item, int, error)
 	// FindByWorkspaceFiltered returns filtered, sorted, paginated items plus the
 	// TRUE total count matching the filter (independent of LIMIT/OFFSET).
@@ -41,9 +71,39 @@ type Repository interface {
 	// global warehouse.short_codes registry (codes are globally unique
 	// since migration 005, not per-workspace).
 	ShortCodeExists(ctx context.Context, shortCode string) (bool, error)
+	// ReassignCategory sets category_id (nil clears it) on the listed items in
+	// one statement and returns the items it updated. IDs outside the
+	// workspace are ignored.
+	ReassignCategory(ctx context.Context, workspaceID uuid.UUID, itemIDs []uuid.UUID, categoryID *uuid.UUID) ([]ReassignedItem, error)
 
 	// Label associations
 	AttachLabel(ctx context.Context, itemID, labelID uuid.UUID) error
 	DetachLabel(ctx context.Context, itemID, labelID uuid.UUID) error
 	GetItemLabels(ctx context.Context, itemID uuid.UUID) ([]uuid.UUID, error)
+
+	// Identifiers
+	ListIdentifiers(ctx context.Context, workspaceID, itemID uuid.UUID) ([]*Identifier, error)
+	// SaveIdentifier inserts an identifier, returning ErrIdentifierTaken when
+	// another identifier in the workspace already has its value.
+	SaveIdentifier(ctx context.Context, identifier *Identifier) error
+	DeleteIdentifier(ctx context.Context, workspaceID, itemID, identifierID uuid.UUID) error
+
+	// Duplicates and merging. The Move/Count/UpdateSKU methods and Delete use
+	// the transaction in ctx (if any).
+	//
+	// FindSimilar returns up to limit pairs of active items whose name and
+	// brand are trigram-similar at or above threshold, most similar first.
+	FindSimilar(ctx context.Context, workspaceID uuid.UUID, threshold float64, limit int) ([]SimilarPair, error)
+	// CountLoans counts the loans, open or returned, against the item's
+	// inventory.
+	CountLoans(ctx context.Context, workspaceID, itemID uuid.UUID) (int, error)
+	// MoveInventory re-points every inventory row of fromItemID to toItemID.
+	MoveInventory(ctx context.Context, workspaceID, fromItemID, toItemID uuid.UUID) (int, error)
+	// MoveLabels attaches fromItemID's labels to toItemID and returns how
+	// many toItemID did not already have.
+	MoveLabels(ctx context.Context, workspaceID, fromItemID, toItemID uuid.UUID) (int, error)
+	// MovePhotos re-points every photo of fromItemID to toItemID, keeping
+	// toItemID's primary photo when it has one.
+	MovePhotos(ctx context.Context, workspaceID, fromItemID, toItemID uuid.UUID) (int, error)
+	UpdateSKU(ctx context.Context, workspaceID, itemID uuid.UUID, sku string) error
 }
@@ -29,6 +29,7 @@ func generateShortCode() string {
 type ServiceInterface interface {
 	Create(ctx context.Context, input CreateInput) (*Item, error)
 	GetByID(ctx context.Context, id, workspaceID uuid.UUID) (*Item, error)
+	GetByIDs(ctx context.Context, workspaceID uuid.UUID, ids []uuid.UUID) ([]*Item, error)
 	List(ctx context.Context, workspaceID uuid.UUID, pagination shared.Pagination) ([]*Item, int, error)
 	ListFiltered(ctx context.Context, workspaceID uuid.UUID, filters ListFilters, pagination shared.Pagination) ([]*Item, int, error)
 	ListNeedingReview(ctx context.Context, workspaceID uuid.UUID, pagination shared.Pagination) ([]*Item, int, error)
@@ -38,20 +39,45 @@ type ServiceInterface interface {
 	Delete(ctx context.Context, id, workspaceID uuid.UUID) error
 	Search(ctx context.Context, workspaceID uuid.UUID, query string, limit int) ([]*Item, error)
 	ListByCategory(ctx context.Context, workspaceID, categoryID uuid.UUID, pagination shared.Pagination) ([]*Item, error)
-	LookupByBarcode(ctx context.Context, workspaceID uuid.UUID, code string) (*Item, error)
+	LookupByBarcode(ctx context.Context, workspaceID uuid.UUID, code string) (*Item, *Identifier, error)
+	ListIdentifiers(ctx context.Context, workspaceID, itemID uuid.UUID) ([]*Identifier, error)
+	AddIdentifier(ctx context.Context, workspaceID, itemID uuid.UUID, identifierType IdentifierType, value string) (*Identifier, error)
+	RemoveIdentifier(ctx context.Context, workspaceID, itemID, identifierID uuid.UUID) error
 	AttachLabel(ctx context.Context, itemID, labelID, workspaceID uuid.UUID) error
 	DetachLabel(ctx context.Context, itemID, labelID, workspaceID uuid.UUID) error
 	GetItemLabels(ctx context.Context, itemID, workspaceID uuid.UUID) ([]uuid.UUID, error)
+	DuplicateItem(ctx context.Context, workspaceID, itemID uuid.UUID) (*Item, error)
+	ReassignCategory(ctx context.Context, workspaceID uuid.UUID, itemIDs []uuid.UUID, newCategoryID *uuid.UUID) ([]ReassignedItem, error)
+	RecordView(ctx context.Context, workspaceID, userID, itemID uuid.UUID) error
+	ListRecentlyViewed(ctx context.Context, workspaceID, userID uuid.UUID, limit int) ([]*Item, error)
+	FindSimilarItems(ctx context.Context, workspaceID uuid.UUID, threshold float64) ([]SimilarPair, error)
+	MergeItems(ctx context.Context, workspaceID, keepID, mergeID uuid.UUID, sku string) (*MergeResult, error)
+	ListCategoryRules(ctx context.Context, workspaceID uuid.UUID) ([]*CategoryRule, error)
+	CreateCategoryRule(ctx context.Context, workspaceID uuid.UUID, input CategoryRuleInput) (*CategoryRule, error)
+	UpdateCategoryRule(ctx context.Context, workspaceID, id uuid.UUID, input CategoryRuleInput) (*CategoryRule, error)
+	DeleteCategoryRule(ctx context.Context, workspaceID, id uuid.UUID) error
+	ReorderCategoryRules(ctx context.Context, workspaceID uuid.UUID, ruleIDs []uuid.UUID) ([]*CategoryRule, error)
+}
+
+// RecentViewStore keeps a short, most-recent-first list of the items a user
+// has opened in a workspace. Viewing an item again moves it to the front
+// rather than adding a second entry.
+type RecentViewStore interface {
+	Push(ctx context.Context, workspaceID, userID, itemID uuid.UUID) error
+	List(ctx context.Context, workspaceID, userID uuid.UUID, limit int) ([]uuid.UUID, error)
 }
 
 type Service struct {
-	repo         Repository
-	categoryRepo category.Repository
-	idemStore    idempotency.Store
+	repo          Repository
+	categoryRepo  category.Repository
+	categoryRules CategoryRuleRepository
+	idemStore     idempotency.Store
+	recentViews   RecentViewStore
+	tx            Transactor
 }
 
 func NewService(repo Repository, categoryRepo category.Repository) *Service {
-	return &Service{repo: repo, categoryRepo: categoryRepo}
+	return &Service{repo: repo, categoryRepo: categoryRepo, tx: noopTransactor{}}
 }
 
 // SetIdempotencyStore wires the shared idempotency dedup store used by
@@ -61,12 +87,18 @@ func (s *Service) SetIdempotencyStore(store idempotency.Store) {
 	s.idemStore = store
 }
 
+// SetRecentViewStore wires the per-user recently viewed list. Optional — if
+// not set, RecordView is a no-op and ListRecentlyViewed returns nothing.
+func (s *Service) SetRecentViewStore(store RecentViewStore) {
+	s.recentViews = store
+}
+
 type CreateInput struct {
 	WorkspaceID       uuid.UUID
 	SKU               string
 	Name              string
 	Description       *string
-	CategoryID        *uuid.UUID
+	CategoryID        *uuid.UUID // Optional - the first matching category rule applies when nil
 	Brand             *string
 	Model             *string
 	ImageURL          *string
@@ -82,6 +114,7 @@ type CreateInput struct {
 	ObsidianVaultPath *string
 	ObsidianNotePath  *string
 	NeedsReview       *bool
+	Loanable          *bool  // Optional - defaults to true
 	IdempotencyKey    string // Optional - offline-queued creates dedupe on this (see idempotency package)
 }
 
@@ -109,6 +142,16 @@ func (s *Service) Create(ctx context.Context, input CreateInput) (*Item, error)
 	if err := s.validateCategory(ctx, input.CategoryID, input.WorkspaceID); err != nil {
 		return nil, err
 	}
+	categoryID := input.CategoryID
+	if categoryID == nil {
+		rule, err := s.MatchCategoryRule(ctx, input.WorkspaceID, input.Name)
+		if err != nil {
+			return nil, err
+		}
+		if rule != nil {
+			categoryID = &rule.CategoryID
+		}
+	}
 
 	item, err := NewItem(input.WorkspaceID, input.Name, input.SKU, input.MinStockLevel)
 	if err != nil {
@@ -117,7 +160,7 @@ func (s *Service) Create(ctx context.Context, input CreateInput) (*Item, error)
 
 	// Set optional fields
 	item.description = input.Description
-	item.categoryID = input.CategoryID
+	item.categoryID = categoryID
 	item.brand = input.Brand
 	item.model = input.Model
 	item.imageURL = input.ImageURL
@@ -134,6 +177,9 @@ func (s *Service) Create(ctx context.Context, input CreateInput) (*Item, error)
 	if input.NeedsReview != nil && *input.NeedsReview {
 		item.SetNeedsReview(true)
 	}
+	if input.Loanable != nil {
+		item.loanable = *input.Loanable
+	}
 
 	if err := s.repo.Save(ctx, item); err != nil {
 		return nil, err
@@ -233,6 +279,22 @@ func (s *Service) GetByID(ctx context.Context, id, workspaceID uuid.UUID) (*Item
 	return item, nil
 }
 
+// MaxItemsByIDs caps how many items GetByIDs loads in one call.
+const MaxItemsByIDs = 100
+
+// GetByIDs returns the workspace's items with the given IDs in the order
+// listed. IDs that do not match an item are omitted rather than failing the
+// batch.
+func (s *Service) GetByIDs(ctx context.Context, workspaceID uuid.UUID, ids []uuid.UUID) ([]*Item, error) {
+	if len(ids) == 0 {
+		return []*Item{}, nil
+	}
+	if len(ids) > MaxItemsByIDs {
+		return nil, ErrTooManyIDs
+	}
+	return s.repo.FindByIDs(ctx, workspaceID, ids)
+}
+
 func (s *Service) Update(ctx context.Context, id, workspaceID uuid.UUID, input UpdateInput) (*Item, error) {
 	item, err := s.GetByID(ctx, id, workspaceID)
 	if err != nil {
@@ -282,9 +344,13 @@ func (s *Service) List(ctx context.Context, workspaceID uuid.UUID, pagination sh
 }
 
 // ListFiltered returns items matching the filter/sort/pagination params plus
-// the true total count. Pass-through to Repository.FindByWorkspaceFiltered;
-// the count is COUNT(*) not len(page).
+// the true total count. Sort params are checked against the allowlist before
+// reaching Repository.FindByWorkspaceFiltered; the count is COUNT(*) not
+// len(page).
 func (s *Service) ListFiltered(ctx context.Context, workspaceID uuid.UUID, filters ListFilters, pagination shared.Pagination) ([]*Item, int, error) {
+	if err := filters.Validate(); err != nil {
+		return nil, 0, err
+	}
 	return s.repo.FindByWorkspaceFiltered(ctx, workspaceID, filters, pagination)
 }
 
@@ -310,24 +376,84 @@ func (s *Service) ListByCategory(ctx context.Context, workspaceID, categoryID uu
 	return s.repo.FindByCategory(ctx, workspaceID, categoryID, pagination)
 }
 
-// LookupByBarcode returns the workspace item whose barcode column equals
-// `code` exactly (case-sensitive via Postgres text equality; see G-65-01
-// root cause — the FTS search_vector column does NOT cover barcode, so
-// this path uses the dedicated FindByBarcode repo method which hits the
-// ix_items_barcode btree index).
+// LookupByBarcode returns the workspace item a scanned code belongs to. The
+// primary barcode column is matched first, then the item's additional
+// identifiers; both are exact, case-sensitive matches (see G-65-01 root
+// cause — the FTS search_vector column does NOT cover barcode, so this path
+// uses the dedicated FindByBarcode repo method which hits the btree indexes).
+// The returned identifier is nil when the primary barcode matched.
 //
-// Returns ErrItemNotFound when no row matches — the handler layer maps
+// Returns ErrItemNotFound when nothing matches — the handler layer maps
 // this sentinel to HTTP 404 (matches the GetByID convention). Normalises
 // shared.ErrNotFound from the repo the same way Service.Delete does.
-func (s *Service) LookupByBarcode(ctx context.Context, workspaceID uuid.UUID, code string) (*Item, error) {
-	item, err := s.repo.FindByBarcode(ctx, workspaceID, code)
+func (s *Service) LookupByBarcode(ctx context.Context, workspaceID uuid.UUID, code string) (*Item, *Identifier, error) {
+	item, identifier, err := s.repo.FindByBarcode(ctx, workspaceID, code)
 	if err != nil {
 		if errors.Is(err, shared.ErrNotFound) {
-			return nil, ErrItemNotFound
+			return nil, nil, ErrItemNotFound
 		}
+		return nil, nil, err
+	}
+	return item, identifier, nil
+}
+
+// ListIdentifiers returns the item's additional identifiers, oldest first.
+func (s *Service) ListIdentifiers(ctx context.Context, workspaceID, itemID uuid.UUID) ([]*Identifier, error) {
+	if err := s.requireItem(ctx, workspaceID, itemID); err != nil {
 		return nil, err
 	}
-	return item, nil
+	return s.repo.ListIdentifiers(ctx, workspaceID, itemID)
+}
+
+// AddIdentifier gives the item another code it can be scanned by. The value
+// must not already resolve to an item in the workspace, whether as a primary
+// barcode or as an identifier, so every scan has exactly one answer.
+func (s *Service) AddIdentifier(ctx context.Context, workspaceID, itemID uuid.UUID, identifierType IdentifierType, value string) (*Identifier, error) {
+	if err := s.requireItem(ctx, workspaceID, itemID); err != nil {
+		return nil, err
+	}
+
+	identifier, err := NewIdentifier(workspaceID, itemID, identifierType, value)
+	if err != nil {
+		return nil, err
+	}
+
+	_, _, err = s.repo.FindByBarcode(ctx, workspaceID, identifier.Value())
+	if err == nil {
+		return nil, ErrIdentifierTaken
+	}
+	if !errors.Is(err, shared.ErrNotFound) {
+		return nil, err
+	}
+
+	if err := s.repo.SaveIdentifier(ctx, identifier); err != nil {
+		return nil, err
+	}
+	return identifier, nil
+}
+
+// requireItem checks the item exists in the workspace, reporting a missing
+// one as ErrItemNotFound.
+func (s *Service) requireItem(ctx context.Context, workspaceID, itemID uuid.UUID) error {
+	if _, err := s.repo.FindByID(ctx, itemID, workspaceID); err != nil {
+		if errors.Is(err, shared.ErrNotFound) {
+			return ErrItemNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// RemoveIdentifier deletes one of the item's additional identifiers. The
+// primary barcode is changed through Update instead.
+func (s *Service) RemoveIdentifier(ctx context.Context, workspaceID, itemID, identifierID uuid.UUID) error {
+	if err := s.repo.DeleteIdentifier(ctx, workspaceID, itemID, identifierID); err != nil {
+		if errors.Is(err, shared.ErrNotFound) {
+			return ErrIdentifierNotFound
+		}
+		return err
+	}
+	return nil
 }
 
 // AttachLabel attaches a label to an item.
@@ -362,3 +488,144 @@ func (s *Service) GetItemLabels(ctx context.Context, itemID, workspaceID uuid.UU
 
 	return s.repo.GetItemLabels(ctx, itemID)
 }
+
+const (
+	duplicateNameSuffix = " (copy)"
+	duplicateSKUSuffix  = "-COPY"
+	maxNameLength       = 200 // warehouse.items.name is varchar(200)
+	maxSKULength        = 50  // warehouse.items.sku is varchar(50)
+)
+
+// DuplicateItem clones an item as a starting point for a variant. Name
+// (suffixed " (copy)"), description, brand, category, min_stock_level and
+// labels carry over; the copy gets a fresh SKU and short code and no
+// inventory.
+func (s *Service) DuplicateItem(ctx context.Context, workspaceID, itemID uuid.UUID) (*Item, error) {
+	source, err := s.GetByID(ctx, itemID, workspaceID)
+	if err != nil {
+		if errors.Is(err, shared.ErrNotFound) {
+			return nil, ErrItemNotFound
+		}
+		return nil, err
+	}
+
+	sku, err := s.duplicateSKU(ctx, workspaceID, source.SKU())
+	if err != nil {
+		return nil, err
+	}
+
+	shortCode, err := s.resolveShortCode(ctx, "")
+	if err != nil {
+		return nil, err
+	}
+
+	dup, err := NewItem(workspaceID, withSuffix(source.Name(), duplicateNameSuffix, maxNameLength), sku, source.MinStockLevel())
+	if err != nil {
+		return nil, err
+	}
+	dup.description = source.Description()
+	dup.brand = source.Brand()
+	dup.categoryID = source.CategoryID()
+	dup.shortCode = shortCode
+
+	if err := s.repo.Save(ctx, dup); err != nil {
+		return nil, err
+	}
+
+	labelIDs, err := s.repo.GetItemLabels(ctx, source.ID())
+	if err != nil {
+		return nil, err
+	}
+	for _, labelID := range labelIDs {
+		if err := s.repo.AttachLabel(ctx, dup.ID(), labelID); err != nil {
+			return nil, err
+		}
+	}
+
+	return dup, nil
+}
+
+// duplicateSKU picks the first free SKU of "<sku>-COPY", "<sku>-COPY-2", …
+// (bounded retries, same shape as resolveShortCode).
+func (s *Service) duplicateSKU(ctx context.Context, workspaceID uuid.UUID, sku string) (string, error) {
+	const maxRetries = 5
+	for i := 1; i <= maxRetries; i++ {
+		suffix := duplicateSKUSuffix
+		if i > 1 {
+			suffix = fmt.Sprintf("%s-%d", duplicateSKUSuffix, i)
+		}
+		candidate := withSuffix(sku, suffix, maxSKULength)
+		exists, err := s.repo.SKUExists(ctx, workspaceID, candidate)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			return candidate, nil
+		}
+	}
+	return "", ErrSKUTaken
+}
+
+// withSuffix appends suffix to s, trimming s (by rune) so the result fits in
+// maxLen characters.
+func withSuffix(s, suffix string, maxLen int) string {
+	base := []rune(s)
+	if room := maxLen - len([]rune(suffix)); len(base) > room {
+		base = base[:room]
+	}
+	return string(base) + suffix
+}
+
+// ReassignedItem is an item moved by ReassignCategory.
+type ReassignedItem struct {
+	ID   uuid.UUID
+	Name string
+}
+
+// ReassignCategory moves the given items to newCategoryID, or clears their
+// category when it is nil, in a single update. The category must belong to
+// the workspace; item IDs that don't are skipped rather than failing the
+// batch, so the result may be shorter than itemIDs.
+func (s *Service) ReassignCategory(ctx context.Context, workspaceID uuid.UUID, itemIDs []uuid.UUID, newCategoryID *uuid.UUID) ([]ReassignedItem, error) {
+	if len(itemIDs) == 0 {
+		return []ReassignedItem{}, nil
+	}
+	if err := s.validateCategory(ctx, newCategoryID, workspaceID); err != nil {
+		return nil, err
+	}
+	return s.repo.ReassignCategory(ctx, workspaceID, itemIDs, newCategoryID)
+}
+
+// RecordView moves itemID to the front of the user's recently viewed list.
+func (s *Service) RecordView(ctx context.Context, workspaceID, userID, itemID uuid.UUID) error {
+	if s.recentViews == nil {
+		return nil
+	}
+	return s.recentViews.Push(ctx, workspaceID, userID, itemID)
+}
+
+// ListRecentlyViewed returns up to limit of the user's most recently viewed
+// items, newest first. Items deleted since they were viewed are skipped.
+func (s *Service) ListRecentlyViewed(ctx context.Context, workspaceID, userID uuid.UUID, limit int) ([]*Item, error) {
+	if s.recentViews == nil {
+		return []*Item{}, nil
+	}
+
+	ids, err := s.recentViews.List(ctx, workspaceID, userID, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*Item, 0, len(ids))
+	for _, id := range ids {
+		item, err := s.repo.FindByID(ctx, id, workspaceID)
+		if err != nil {
+			if errors.Is(err, shared.ErrNotFound) || errors.Is(err, ErrItemNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/category"
 	"github.com/antti/home-warehouse/go-backend/internal/shared"
@@ -48,12 +49,16 @@ func (m *MockRepository) FindByShortCode(ctx context.Context, workspaceID uuid.U
 	return args.Get(0).(*Item), args.Error(1)
 }
 
-func (m *MockRepository) FindByBarcode(ctx context.Context, workspaceID uuid.UUID, barcode string) (*Item, error) {
+func (m *MockRepository) FindByBarcode(ctx context.Context, workspaceID uuid.UUID, barcode string) (*Item, *Identifier, error) {
 	args := m.Called(ctx, workspaceID, barcode)
+	var identifier *Identifier
+	if args.Get(1) != nil {
+		identifier = args.Get(1).(*Identifier)
+	}
 	if args.Get(0) == nil {
-		return nil, args.Error(1)
+		return nil, identifier, args.Error(2)
 	}
-	return args.Get(0).(*Item), args.Error(1)
+	return args.Get(0).(*Item), identifier, args.Error(2)
 }
 
 func (m *MockRepository) FindByWorkspace(ctx context.Context, workspaceID uuid.UUID, pagination shared.Pagination) ([]*Item, int, error) {
@@ -91,6 +96,14 @@ func (m *MockRepository) FindNeedingReview(ctx context.Context, workspaceID uuid
 	return mockSliceIntErrGuarded[*Item](args)
 }
 
+func (m *MockRepository) FindByIDs(ctx context.Context, workspaceID uuid.UUID, ids []uuid.UUID) ([]*Item, error) {
+	args := m.Called(ctx, workspaceID, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*Item), args.Error(1)
+}
+
 func (m *MockRepository) FindByCategory(ctx context.Context, workspaceID, categoryID uuid.UUID, pagination shared.Pagination) ([]*Item, error) {
 	args := m.Called(ctx, workspaceID, categoryID, pagination)
 	if args.Get(0) == nil {
@@ -122,6 +135,14 @@ func (m *MockRepository) ShortCodeExists(ctx context.Context, shortCode string)
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *MockRepository) ReassignCategory(ctx context.Context, workspaceID uuid.UUID, itemIDs []uuid.UUID, categoryID *uuid.UUID) ([]ReassignedItem, error) {
+	args := m.Called(ctx, workspaceID, itemIDs, categoryID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]ReassignedItem), args.Error(1)
+}
+
 func (m *MockRepository) AttachLabel(ctx context.Context, itemID, labelID uuid.UUID) error {
 	args := m.Called(ctx, itemID, labelID)
 	return args.Error(0)
@@ -139,6 +160,54 @@ func (m *MockRepository) GetItemLabels(ctx context.Context, itemID uuid.UUID) ([
 	return args.Get(0).([]uuid.UUID), args.Error(1)
 }
 
+func (m *MockRepository) ListIdentifiers(ctx context.Context, workspaceID, itemID uuid.UUID) ([]*Identifier, error) {
+	args := m.Called(ctx, workspaceID, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*Identifier), args.Error(1)
+}
+
+func (m *MockRepository) SaveIdentifier(ctx context.Context, identifier *Identifier) error {
+	return m.Called(ctx, identifier).Error(0)
+}
+
+func (m *MockRepository) DeleteIdentifier(ctx context.Context, workspaceID, itemID, identifierID uuid.UUID) error {
+	return m.Called(ctx, workspaceID, itemID, identifierID).Error(0)
+}
+
+func (m *MockRepository) FindSimilar(ctx context.Context, workspaceID uuid.UUID, threshold float64, limit int) ([]SimilarPair, error) {
+	args := m.Called(ctx, workspaceID, threshold, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]SimilarPair), args.Error(1)
+}
+
+func (m *MockRepository) CountLoans(ctx context.Context, workspaceID, itemID uuid.UUID) (int, error) {
+	args := m.Called(ctx, workspaceID, itemID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockRepository) MoveInventory(ctx context.Context, workspaceID, fromItemID, toItemID uuid.UUID) (int, error) {
+	args := m.Called(ctx, workspaceID, fromItemID, toItemID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockRepository) MoveLabels(ctx context.Context, workspaceID, fromItemID, toItemID uuid.UUID) (int, error) {
+	args := m.Called(ctx, workspaceID, fromItemID, toItemID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockRepository) MovePhotos(ctx context.Context, workspaceID, fromItemID, toItemID uuid.UUID) (int, error) {
+	args := m.Called(ctx, workspaceID, fromItemID, toItemID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockRepository) UpdateSKU(ctx context.Context, workspaceID, itemID uuid.UUID, sku string) error {
+	return m.Called(ctx, workspaceID, itemID, sku).Error(0)
+}
+
 // MockCategoryRepository is a mock implementation of the category.Repository interface
 type MockCategoryRepository struct {
 	mock.Mock
@@ -188,6 +257,16 @@ func (m *MockCategoryRepository) HasChildren(ctx context.Context, workspaceID, p
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *MockCategoryRepository) MoveItems(ctx context.Context, workspaceID, fromCategoryID, toCategoryID uuid.UUID) (int, error) {
+	args := m.Called(ctx, fromCategoryID, toCategoryID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockCategoryRepository) MoveChildren(ctx context.Context, workspaceID, fromCategoryID, toCategoryID uuid.UUID) (int, error) {
+	args := m.Called(ctx, fromCategoryID, toCategoryID)
+	return args.Int(0), args.Error(1)
+}
+
 // Helper functions
 func ptrString(s string) *string {
 	return &s
@@ -355,6 +434,7 @@ func TestReconstruct(t *testing.T) {
 		ptrString("/vault/path"),
 		ptrString("/note/path"),
 		ptrBool(false),
+		true,
 		now,
 		now,
 	)
@@ -398,6 +478,7 @@ func TestReconstruct_MinimalFields(t *testing.T) {
 		nil, nil, nil, nil, nil,
 		0,
 		"", nil, nil, nil,
+		true,
 		now,
 		now,
 	)
@@ -1560,6 +1641,7 @@ func TestItem_ObsidianURI(t *testing.T) {
 				tt.vaultPath,
 				tt.notePath,
 				nil,
+				true,
 				now,
 				now,
 			)
@@ -1588,7 +1670,7 @@ func TestService_AttachLabel(t *testing.T) {
 	now := time.Now()
 
 	createItem := func() *Item {
-		return Reconstruct(itemID, workspaceID, "SKU-001", "Test Item", nil, nil, nil, nil, nil, nil, nil, nil, ptrBool(false), ptrBool(false), ptrBool(false), nil, nil, 0, "ABC123", nil, nil, ptrBool(false), now, now)
+		return Reconstruct(itemID, workspaceID, "SKU-001", "Test Item", nil, nil, nil, nil, nil, nil, nil, nil, ptrBool(false), ptrBool(false), ptrBool(false), nil, nil, 0, "ABC123", nil, nil, ptrBool(false), true, now, now)
 	}
 
 	t.Run("successful attach", func(t *testing.T) {
@@ -1652,7 +1734,7 @@ func TestService_DetachLabel(t *testing.T) {
 	now := time.Now()
 
 	createItem := func() *Item {
-		return Reconstruct(itemID, workspaceID, "SKU-001", "Test Item", nil, nil, nil, nil, nil, nil, nil, nil, ptrBool(false), ptrBool(false), ptrBool(false), nil, nil, 0, "ABC123", nil, nil, ptrBool(false), now, now)
+		return Reconstruct(itemID, workspaceID, "SKU-001", "Test Item", nil, nil, nil, nil, nil, nil, nil, nil, ptrBool(false), ptrBool(false), ptrBool(false), nil, nil, 0, "ABC123", nil, nil, ptrBool(false), true, now, now)
 	}
 
 	t.Run("successful detach", func(t *testing.T) {
@@ -1702,7 +1784,7 @@ func TestService_GetItemLabels(t *testing.T) {
 	now := time.Now()
 
 	createItem := func() *Item {
-		return Reconstruct(itemID, workspaceID, "SKU-001", "Test Item", nil, nil, nil, nil, nil, nil, nil, nil, ptrBool(false), ptrBool(false), ptrBool(false), nil, nil, 0, "ABC123", nil, nil, ptrBool(false), now, now)
+		return Reconstruct(itemID, workspaceID, "SKU-001", "Test Item", nil, nil, nil, nil, nil, nil, nil, nil, ptrBool(false), ptrBool(false), ptrBool(false), nil, nil, 0, "ABC123", nil, nil, ptrBool(false), true, now, now)
 	}
 
 	t.Run("successful get labels", func(t *testing.T) {
@@ -1883,6 +1965,41 @@ func TestService_ListFiltered_ForwardsAllFilterFields(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 }
 
+func TestService_ListFiltered_RejectsUnknownSort(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+
+	tests := []struct {
+		name    string
+		filters ListFilters
+	}{
+		{"unknown field", ListFilters{Sort: "name; DROP TABLE items", SortDir: "asc"}},
+		{"unlisted column", ListFilters{Sort: "description", SortDir: "asc"}},
+		{"unknown direction", ListFilters{Sort: "name", SortDir: "sideways"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockRepository)
+			svc := NewService(mockRepo, nil)
+
+			items, total, err := svc.ListFiltered(ctx, workspaceID, tt.filters, shared.Pagination{Page: 1, PageSize: 25})
+
+			assert.ErrorIs(t, err, ErrInvalidSort)
+			assert.Nil(t, items)
+			assert.Zero(t, total)
+			mockRepo.AssertNotCalled(t, "FindByWorkspaceFiltered", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestListFilters_Validate_AllowsDefaults(t *testing.T) {
+	assert.NoError(t, ListFilters{}.Validate())
+	for _, field := range []string{"name", "sku", "created_at", "updated_at"} {
+		assert.NoError(t, ListFilters{Sort: field, SortDir: "desc"}.Validate(), field)
+	}
+}
+
 func TestService_ListNeedingReview(t *testing.T) {
 	ctx := context.Background()
 	workspaceID := uuid.New()
@@ -1927,23 +2044,41 @@ func TestService_LookupByBarcode(t *testing.T) {
 		code := "5449000000996"
 		existing, _ := NewItem(workspaceID, "Cola", "SKU-1", 0)
 		mockRepo.On("FindByBarcode", ctx, workspaceID, code).
-			Return(existing, nil).Once()
+			Return(existing, nil, nil).Once()
 
-		got, err := svc.LookupByBarcode(ctx, workspaceID, code)
+		got, matched, err := svc.LookupByBarcode(ctx, workspaceID, code)
 		assert.NoError(t, err)
 		assert.Equal(t, existing, got)
+		assert.Nil(t, matched)
 		mockRepo.AssertExpectations(t)
 	})
 
+	t.Run("returns the identifier that matched", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		svc := NewService(mockRepo, nil)
+
+		code := "9780262033848"
+		existing, _ := NewItem(workspaceID, "Algorithms", "SKU-2", 0)
+		identifier, err := NewIdentifier(workspaceID, existing.ID(), IdentifierTypeISBN, code)
+		require.NoError(t, err)
+		mockRepo.On("FindByBarcode", ctx, workspaceID, code).
+			Return(existing, identifier, nil).Once()
+
+		got, matched, err := svc.LookupByBarcode(ctx, workspaceID, code)
+		assert.NoError(t, err)
+		assert.Equal(t, existing, got)
+		assert.Equal(t, identifier, matched)
+	})
+
 	t.Run("normalises shared.ErrNotFound to ErrItemNotFound", func(t *testing.T) {
 		mockRepo := new(MockRepository)
 		svc := NewService(mockRepo, nil)
 
 		code := "DOES-NOT-EXIST"
 		mockRepo.On("FindByBarcode", ctx, workspaceID, code).
-			Return(nil, shared.ErrNotFound).Once()
+			Return(nil, nil, shared.ErrNotFound).Once()
 
-		got, err := svc.LookupByBarcode(ctx, workspaceID, code)
+		got, _, err := svc.LookupByBarcode(ctx, workspaceID, code)
 		assert.Nil(t, got)
 		assert.ErrorIs(t, err, ErrItemNotFound)
 		mockRepo.AssertExpectations(t)
@@ -1956,11 +2091,366 @@ func TestService_LookupByBarcode(t *testing.T) {
 		code := "OPAQUE"
 		sentinel := errors.New("db down")
 		mockRepo.On("FindByBarcode", ctx, workspaceID, code).
-			Return(nil, sentinel).Once()
+			Return(nil, nil, sentinel).Once()
 
-		got, err := svc.LookupByBarcode(ctx, workspaceID, code)
+		got, _, err := svc.LookupByBarcode(ctx, workspaceID, code)
 		assert.Nil(t, got)
 		assert.ErrorIs(t, err, sentinel)
 		mockRepo.AssertExpectations(t)
 	})
 }
+
+func TestService_AddIdentifier(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+
+	newItem := func(t *testing.T) *Item {
+		t.Helper()
+		itm, err := NewItem(workspaceID, "Cordless Drill", "DRL-001", 0)
+		require.NoError(t, err)
+		return itm
+	}
+
+	t.Run("adds a free code", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		svc := NewService(mockRepo, nil)
+		itm := newItem(t)
+
+		mockRepo.On("FindByID", ctx, itm.ID(), workspaceID).Return(itm, nil).Once()
+		mockRepo.On("FindByBarcode", ctx, workspaceID, "4006381333931").Return(nil, nil, shared.ErrNotFound).Once()
+		mockRepo.On("SaveIdentifier", ctx, mock.AnythingOfType("*item.Identifier")).Return(nil).Once()
+
+		identifier, err := svc.AddIdentifier(ctx, workspaceID, itm.ID(), IdentifierTypeEAN, " 4006381333931 ")
+
+		require.NoError(t, err)
+		assert.Equal(t, itm.ID(), identifier.ItemID())
+		assert.Equal(t, IdentifierTypeEAN, identifier.Type())
+		assert.Equal(t, "4006381333931", identifier.Value())
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects a code that already resolves to an item", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		svc := NewService(mockRepo, nil)
+		itm, other := newItem(t), newItem(t)
+
+		mockRepo.On("FindByID", ctx, itm.ID(), workspaceID).Return(itm, nil).Once()
+		mockRepo.On("FindByBarcode", ctx, workspaceID, "TAG-1").Return(other, nil, nil).Once()
+
+		_, err := svc.AddIdentifier(ctx, workspaceID, itm.ID(), IdentifierTypeAssetTag, "TAG-1")
+
+		assert.ErrorIs(t, err, ErrIdentifierTaken)
+		mockRepo.AssertNotCalled(t, "SaveIdentifier", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects an unknown type", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		svc := NewService(mockRepo, nil)
+		itm := newItem(t)
+
+		mockRepo.On("FindByID", ctx, itm.ID(), workspaceID).Return(itm, nil).Once()
+
+		_, err := svc.AddIdentifier(ctx, workspaceID, itm.ID(), IdentifierType("qr"), "X")
+
+		assert.ErrorIs(t, err, ErrInvalidIdentifierType)
+	})
+
+	t.Run("missing item", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		svc := NewService(mockRepo, nil)
+		itemID := uuid.New()
+
+		mockRepo.On("FindByID", ctx, itemID, workspaceID).Return(nil, shared.ErrNotFound).Once()
+
+		_, err := svc.AddIdentifier(ctx, workspaceID, itemID, IdentifierTypeUPC, "036000291452")
+
+		assert.ErrorIs(t, err, ErrItemNotFound)
+	})
+}
+
+func TestService_RemoveIdentifier(t *testing.T) {
+	ctx := context.Background()
+	workspaceID, itemID, identifierID := uuid.New(), uuid.New(), uuid.New()
+
+	t.Run("removes", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		svc := NewService(mockRepo, nil)
+		mockRepo.On("DeleteIdentifier", ctx, workspaceID, itemID, identifierID).Return(nil).Once()
+
+		assert.NoError(t, svc.RemoveIdentifier(ctx, workspaceID, itemID, identifierID))
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		svc := NewService(mockRepo, nil)
+		mockRepo.On("DeleteIdentifier", ctx, workspaceID, itemID, identifierID).Return(shared.ErrNotFound).Once()
+
+		err := svc.RemoveIdentifier(ctx, workspaceID, itemID, identifierID)
+
+		assert.ErrorIs(t, err, ErrIdentifierNotFound)
+	})
+}
+
+func TestService_DuplicateItem(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+	categoryID := uuid.New()
+
+	newSource := func(t *testing.T) *Item {
+		t.Helper()
+		source, err := NewItem(workspaceID, "Cordless Drill", "DRL-001", 3)
+		require.NoError(t, err)
+		desc, brand, model := "18V", "Makita", "DHP482"
+		source.description = &desc
+		source.brand = &brand
+		source.model = &model
+		source.categoryID = &categoryID
+		source.shortCode = "abcd1234"
+		return source
+	}
+
+	t.Run("copies fields and labels with fresh SKU and short code", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		svc := NewService(mockRepo, nil)
+		source := newSource(t)
+		labelIDs := []uuid.UUID{uuid.New(), uuid.New()}
+
+		mockRepo.On("FindByID", ctx, source.ID(), workspaceID).Return(source, nil)
+		mockRepo.On("SKUExists", ctx, workspaceID, "DRL-001-COPY").Return(true, nil)
+		mockRepo.On("SKUExists", ctx, workspaceID, "DRL-001-COPY-2").Return(false, nil)
+		mockRepo.On("ShortCodeExists", ctx, mock.Anything).Return(false, nil)
+		mockRepo.On("Save", ctx, mock.AnythingOfType("*item.Item")).Return(nil)
+		mockRepo.On("GetItemLabels", ctx, source.ID()).Return(labelIDs, nil)
+		mockRepo.On("AttachLabel", ctx, mock.Anything, labelIDs[0]).Return(nil)
+		mockRepo.On("AttachLabel", ctx, mock.Anything, labelIDs[1]).Return(nil)
+
+		dup, err := svc.DuplicateItem(ctx, workspaceID, source.ID())
+
+		require.NoError(t, err)
+		assert.NotEqual(t, source.ID(), dup.ID())
+		assert.Equal(t, "Cordless Drill (copy)", dup.Name())
+		assert.Equal(t, "DRL-001-COPY-2", dup.SKU())
+		assert.NotEmpty(t, dup.ShortCode())
+		assert.NotEqual(t, source.ShortCode(), dup.ShortCode())
+		assert.Equal(t, source.Description(), dup.Description())
+		assert.Equal(t, source.Brand(), dup.Brand())
+		assert.Equal(t, source.CategoryID(), dup.CategoryID())
+		assert.Equal(t, 3, dup.MinStockLevel())
+		assert.Nil(t, dup.Model(), "fields outside the copied set are left empty")
+		mockRepo.AssertCalled(t, "AttachLabel", ctx, dup.ID(), labelIDs[0])
+		mockRepo.AssertCalled(t, "AttachLabel", ctx, dup.ID(), labelIDs[1])
+	})
+
+	t.Run("source not found", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		svc := NewService(mockRepo, nil)
+		id := uuid.New()
+
+		mockRepo.On("FindByID", ctx, id, workspaceID).Return(nil, shared.ErrNotFound)
+
+		dup, err := svc.DuplicateItem(ctx, workspaceID, id)
+
+		assert.Nil(t, dup)
+		assert.ErrorIs(t, err, ErrItemNotFound)
+		mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("all copy SKUs taken", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		svc := NewService(mockRepo, nil)
+		source := newSource(t)
+
+		mockRepo.On("FindByID", ctx, source.ID(), workspaceID).Return(source, nil)
+		mockRepo.On("SKUExists", ctx, workspaceID, mock.Anything).Return(true, nil)
+
+		dup, err := svc.DuplicateItem(ctx, workspaceID, source.ID())
+
+		assert.Nil(t, dup)
+		assert.ErrorIs(t, err, ErrSKUTaken)
+		mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+}
+
+func TestWithSuffix_TrimsToFit(t *testing.T) {
+	assert.Equal(t, "abc-COPY", withSuffix("abc", "-COPY", 50))
+	assert.Equal(t, "abcde-COPY", withSuffix("abcdefgh", "-COPY", 10))
+	assert.Equal(t, "äö (copy)", withSuffix("äöü", " (copy)", 9))
+}
+
+func TestService_GetByIDs(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+
+	t.Run("loads the items in one call", func(t *testing.T) {
+		repo := new(MockRepository)
+		ids := []uuid.UUID{uuid.New(), uuid.New()}
+		want := []*Item{{id: ids[1]}, {id: ids[0]}}
+		repo.On("FindByIDs", ctx, workspaceID, ids).Return(want, nil).Once()
+
+		got, err := NewService(repo, nil).GetByIDs(ctx, workspaceID, ids)
+
+		require.NoError(t, err)
+		assert.Equal(t, want, got)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("no ids", func(t *testing.T) {
+		repo := new(MockRepository)
+
+		got, err := NewService(repo, nil).GetByIDs(ctx, workspaceID, nil)
+
+		require.NoError(t, err)
+		assert.Empty(t, got)
+		repo.AssertNotCalled(t, "FindByIDs", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects more than the cap", func(t *testing.T) {
+		repo := new(MockRepository)
+		ids := make([]uuid.UUID, MaxItemsByIDs+1)
+		for i := range ids {
+			ids[i] = uuid.New()
+		}
+
+		_, err := NewService(repo, nil).GetByIDs(ctx, workspaceID, ids)
+
+		assert.ErrorIs(t, err, ErrTooManyIDs)
+		repo.AssertNotCalled(t, "FindByIDs", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestService_ReassignCategory(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+	categoryID := uuid.New()
+	itemIDs := []uuid.UUID{uuid.New(), uuid.New()}
+
+	t.Run("moves items to a workspace category", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockCatRepo := new(MockCategoryRepository)
+		svc := NewService(mockRepo, mockCatRepo)
+		now := time.Now()
+
+		mockCatRepo.On("FindByID", ctx, categoryID, workspaceID).Return(
+			category.Reconstruct(categoryID, workspaceID, "Tools", nil, nil, false, now, now), nil)
+		mockRepo.On("ReassignCategory", ctx, workspaceID, itemIDs, &categoryID).
+			Return([]ReassignedItem{{ID: itemIDs[0], Name: "Drill"}, {ID: itemIDs[1], Name: "Saw"}}, nil)
+
+		updated, err := svc.ReassignCategory(ctx, workspaceID, itemIDs, &categoryID)
+
+		require.NoError(t, err)
+		assert.Len(t, updated, 2)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("nil category clears without a lookup", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockCatRepo := new(MockCategoryRepository)
+		svc := NewService(mockRepo, mockCatRepo)
+
+		mockRepo.On("ReassignCategory", ctx, workspaceID, itemIDs, (*uuid.UUID)(nil)).
+			Return([]ReassignedItem{{ID: itemIDs[0]}}, nil)
+
+		updated, err := svc.ReassignCategory(ctx, workspaceID, itemIDs, nil)
+
+		require.NoError(t, err)
+		assert.Len(t, updated, 1)
+		mockCatRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects a category from another workspace", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		mockCatRepo := new(MockCategoryRepository)
+		svc := NewService(mockRepo, mockCatRepo)
+
+		mockCatRepo.On("FindByID", ctx, categoryID, workspaceID).Return(nil, shared.ErrNotFound)
+
+		_, err := svc.ReassignCategory(ctx, workspaceID, itemIDs, &categoryID)
+
+		assert.ErrorIs(t, err, shared.ErrNotFound)
+		mockRepo.AssertNotCalled(t, "ReassignCategory", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("empty list is a no-op", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		svc := NewService(mockRepo, new(MockCategoryRepository))
+
+		updated, err := svc.ReassignCategory(ctx, workspaceID, nil, &categoryID)
+
+		require.NoError(t, err)
+		assert.Empty(t, updated)
+		mockRepo.AssertNotCalled(t, "ReassignCategory", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+// fakeRecentViews is an in-memory RecentViewStore.
+type fakeRecentViews struct {
+	ids []uuid.UUID
+	err error
+}
+
+func (f *fakeRecentViews) Push(ctx context.Context, workspaceID, userID, itemID uuid.UUID) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.ids = append([]uuid.UUID{itemID}, f.ids...)
+	return nil
+}
+
+func (f *fakeRecentViews) List(ctx context.Context, workspaceID, userID uuid.UUID, limit int) ([]uuid.UUID, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	if len(f.ids) > limit {
+		return f.ids[:limit], nil
+	}
+	return f.ids, nil
+}
+
+func TestService_RecentlyViewed(t *testing.T) {
+	ctx := context.Background()
+	workspaceID, userID := uuid.New(), uuid.New()
+
+	t.Run("no store is a no-op", func(t *testing.T) {
+		svc := NewService(new(MockRepository), nil)
+
+		require.NoError(t, svc.RecordView(ctx, workspaceID, userID, uuid.New()))
+		items, err := svc.ListRecentlyViewed(ctx, workspaceID, userID, 10)
+		require.NoError(t, err)
+		assert.Empty(t, items)
+	})
+
+	t.Run("lists in store order and skips deleted items", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		svc := NewService(mockRepo, nil)
+		store := &fakeRecentViews{}
+		svc.SetRecentViewStore(store)
+
+		older, _ := NewItem(workspaceID, "Older", "RV-001", 0)
+		newer, _ := NewItem(workspaceID, "Newer", "RV-002", 0)
+		deleted := uuid.New()
+		for _, id := range []uuid.UUID{older.ID(), deleted, newer.ID()} {
+			require.NoError(t, svc.RecordView(ctx, workspaceID, userID, id))
+		}
+
+		mockRepo.On("FindByID", ctx, newer.ID(), workspaceID).Return(newer, nil)
+		mockRepo.On("FindByID", ctx, deleted, workspaceID).Return(nil, shared.ErrNotFound)
+		mockRepo.On("FindByID", ctx, older.ID(), workspaceID).Return(older, nil)
+
+		items, err := svc.ListRecentlyViewed(ctx, workspaceID, userID, 10)
+
+		require.NoError(t, err)
+		if assert.Len(t, items, 2) {
+			assert.Equal(t, newer.ID(), items[0].ID())
+			assert.Equal(t, older.ID(), items[1].ID())
+		}
+	})
+
+	t.Run("store error is returned", func(t *testing.T) {
+		svc := NewService(new(MockRepository), nil)
+		svc.SetRecentViewStore(&fakeRecentViews{err: errors.New("redis down")})
+
+		_, err := svc.ListRecentlyViewed(ctx, workspaceID, userID, 10)
+		assert.Error(t, err)
+	})
+}
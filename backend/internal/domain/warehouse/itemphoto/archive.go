@@ -0,0 +1,152 @@
+package itemphoto
+
+import (
+	"archive/zip"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"path/filepath"
+	"strings"
+	"unicode"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	appMiddleware "github.com/antti/home-warehouse/go-backend/internal/api/middleware"
+)
+
+const (
+	// archiveMissingNote is the entry listing photos whose files could not be
+	// added to the archive.
+	archiveMissingNote = "MISSING.txt"
+	// maxArchiveCaptionLen caps the caption part of an archive entry name.
+	maxArchiveCaptionLen = 60
+)
+
+// HandleArchive streams every photo of an item as a zip of the original
+// files, in gallery order. Entries are named by position and caption, e.g.
+// "01-front-view.jpg". Files are copied straight from storage into the
+// response, so memory use does not grow with the number of photos.
+//
+// Photos whose files are missing or fail to copy are left out and listed in
+// a MISSING.txt entry instead of failing the whole download.
+func (h *BulkPhotoHandler) HandleArchive(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+	if !ok {
+		http.Error(w, msgWorkspaceContextRequired, http.StatusUnauthorized)
+		return
+	}
+
+	itemID, err := uuid.Parse(chi.URLParam(r, "item_id"))
+	if err != nil {
+		http.Error(w, msgInvalidItemID, http.StatusBadRequest)
+		return
+	}
+
+	photos, err := h.svc.GetPhotosForDownload(ctx, itemID, workspaceID)
+	if err != nil {
+		http.Error(w, "failed to get photos", http.StatusInternalServerError)
+		return
+	}
+	if len(photos) == 0 {
+		http.Error(w, "no photos found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set(headerContentType, "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"item-%s-photos.zip\"", itemID.String()[:8]))
+
+	zipWriter := zip.NewWriter(w)
+	defer zipWriter.Close()
+
+	missing := h.writeArchiveEntries(ctx, zipWriter, photos)
+	if len(missing) > 0 {
+		note, err := zipWriter.Create(archiveMissingNote)
+		if err != nil {
+			return
+		}
+		fmt.Fprintln(note, "These photos could not be read from storage and are not included:")
+		for _, line := range missing {
+			fmt.Fprintln(note, line)
+		}
+	}
+}
+
+// writeArchiveEntries copies each photo into the archive and returns a line
+// describing every photo that was left out.
+func (h *BulkPhotoHandler) writeArchiveEntries(ctx context.Context, zipWriter *zip.Writer, photos []*ItemPhoto) []string {
+	storage := h.storageGetter.GetStorage()
+	width := len(fmt.Sprint(len(photos)))
+	if width < 2 {
+		width = 2
+	}
+
+	var missing []string
+	for i, photo := range photos {
+		name := archiveEntryName(i+1, width, photo)
+
+		reader, err := storage.Get(ctx, photo.StoragePath)
+		if err != nil {
+			missing = append(missing, fmt.Sprintf("%s (%s)", name, photo.ID))
+			continue
+		}
+
+		entry, err := zipWriter.Create(name)
+		if err == nil {
+			_, err = io.Copy(entry, reader)
+		}
+		_ = reader.Close()
+		if err != nil {
+			// The entry may already be partly written; record it so the
+			// truncated file is not mistaken for a good copy.
+			missing = append(missing, fmt.Sprintf("%s (%s, incomplete)", name, photo.ID))
+		}
+	}
+	return missing
+}
+
+// archiveEntryName names a photo inside the archive: its 1-based position,
+// zero-padded to width, then the caption (or the original filename when there
+// is none) reduced to a safe slug, then the original extension.
+func archiveEntryName(position, width int, photo *ItemPhoto) string {
+	filename := sanitizeUploadFilename(photo.Filename)
+	ext := strings.ToLower(filepath.Ext(filename))
+
+	label := ""
+	if photo.Caption != nil {
+		label = archiveSlug(*photo.Caption)
+	}
+	if label == "" {
+		label = archiveSlug(strings.TrimSuffix(filename, filepath.Ext(filename)))
+	}
+	if label == "" {
+		label = "photo"
+	}
+	return fmt.Sprintf("%0*d-%s%s", width, position, label, ext)
+}
+
+// archiveSlug lowercases s and collapses everything but letters and digits
+// into single dashes, so captions can never introduce path separators.
+func archiveSlug(s string) string {
+	var b strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+			dash = false
+			continue
+		}
+		if !dash && b.Len() > 0 {
+			b.WriteByte('-')
+			dash = true
+		}
+	}
+	slug := strings.TrimSuffix(b.String(), "-")
+	if runes := []rune(slug); len(runes) > maxArchiveCaptionLen {
+		slug = strings.TrimSuffix(string(runes[:maxArchiveCaptionLen]), "-")
+	}
+	return slug
+}
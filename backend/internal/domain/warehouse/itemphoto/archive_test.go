@@ -0,0 +1,136 @@
+package itemphoto_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/itemphoto"
+)
+
+// readZip returns the archive's entries as name → content, in archive order.
+func readZip(t *testing.T, body []byte) ([]string, map[string]string) {
+	t.Helper()
+	zr, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	require.NoError(t, err)
+
+	var names []string
+	contents := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		data, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+		names = append(names, f.Name)
+		contents[f.Name] = string(data)
+	}
+	return names, contents
+}
+
+func TestBulkPhotoHandler_HandleArchive(t *testing.T) {
+	workspaceID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+
+	urlGen := func(_ context.Context, wsID, itemID, photoID uuid.UUID, isThumbnail bool) string {
+		return fmt.Sprintf("/photos/%s", photoID)
+	}
+
+	t.Run("names entries by position and caption", func(t *testing.T) {
+		mockSvc := new(MockService)
+		mockStorage := new(HandlerMockStorage)
+		storageGetter := &MockStorageGetter{storage: mockStorage}
+
+		itemID := uuid.New()
+		caption := "Front view / with box!"
+		front := createTestPhoto(itemID)
+		front.Filename = "IMG_0001.JPG"
+		front.StoragePath = "ws/item/front.jpg"
+		front.Caption = &caption
+		back := createTestPhoto(itemID)
+		back.Filename = "back side.png"
+		back.StoragePath = "ws/item/back.png"
+
+		mockSvc.On("GetPhotosForDownload", mock.Anything, itemID, workspaceID).
+			Return([]*itemphoto.ItemPhoto{front, back}, nil).Once()
+		mockStorage.On("Get", mock.Anything, front.StoragePath).
+			Return(io.NopCloser(strings.NewReader("front data")), nil).Once()
+		mockStorage.On("Get", mock.Anything, back.StoragePath).
+			Return(io.NopCloser(strings.NewReader("back data")), nil).Once()
+
+		req := createChiRequest("GET", "/items/"+itemID.String()+"/photos.zip", nil, workspaceID, userID)
+		rr := executeBulkHandlerRequest(t, mockSvc, storageGetter, nil, urlGen, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "application/zip", rr.Header().Get("Content-Type"))
+		assert.Equal(t, `attachment; filename="item-`+itemID.String()[:8]+`-photos.zip"`, rr.Header().Get("Content-Disposition"))
+
+		names, contents := readZip(t, rr.Body.Bytes())
+		assert.Equal(t, []string{"01-front-view-with-box.jpg", "02-back-side.png"}, names)
+		assert.Equal(t, "front data", contents["01-front-view-with-box.jpg"])
+		mockSvc.AssertExpectations(t)
+		mockStorage.AssertExpectations(t)
+	})
+
+	t.Run("skips missing files and lists them", func(t *testing.T) {
+		mockSvc := new(MockService)
+		mockStorage := new(HandlerMockStorage)
+		storageGetter := &MockStorageGetter{storage: mockStorage}
+
+		itemID := uuid.New()
+		kept := createTestPhoto(itemID)
+		kept.StoragePath = "ws/item/kept.jpg"
+		lost := createTestPhoto(itemID)
+		lost.Filename = "lost.jpg"
+		lost.StoragePath = "ws/item/lost.jpg"
+
+		mockSvc.On("GetPhotosForDownload", mock.Anything, itemID, workspaceID).
+			Return([]*itemphoto.ItemPhoto{kept, lost}, nil).Once()
+		mockStorage.On("Get", mock.Anything, kept.StoragePath).
+			Return(io.NopCloser(strings.NewReader("kept data")), nil).Once()
+		mockStorage.On("Get", mock.Anything, lost.StoragePath).
+			Return(nil, errors.New("file not found")).Once()
+
+		req := createChiRequest("GET", "/items/"+itemID.String()+"/photos.zip", nil, workspaceID, userID)
+		rr := executeBulkHandlerRequest(t, mockSvc, storageGetter, nil, urlGen, req)
+
+		require.Equal(t, http.StatusOK, rr.Code)
+		names, contents := readZip(t, rr.Body.Bytes())
+		assert.Equal(t, []string{"01-test.jpg", "MISSING.txt"}, names)
+		assert.Contains(t, contents["MISSING.txt"], "02-lost.jpg ("+lost.ID.String()+")")
+	})
+
+	t.Run("returns 404 when the item has no photos", func(t *testing.T) {
+		mockSvc := new(MockService)
+		storageGetter := &MockStorageGetter{storage: new(HandlerMockStorage)}
+
+		itemID := uuid.New()
+		mockSvc.On("GetPhotosForDownload", mock.Anything, itemID, workspaceID).
+			Return([]*itemphoto.ItemPhoto{}, nil).Once()
+
+		req := createChiRequest("GET", "/items/"+itemID.String()+"/photos.zip", nil, workspaceID, userID)
+		rr := executeBulkHandlerRequest(t, mockSvc, storageGetter, nil, urlGen, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+	})
+
+	t.Run("returns 400 for an invalid item ID", func(t *testing.T) {
+		storageGetter := &MockStorageGetter{storage: new(HandlerMockStorage)}
+
+		req := createChiRequest("GET", "/items/not-a-uuid/photos.zip", nil, workspaceID, userID)
+		rr := executeBulkHandlerRequest(t, new(MockService), storageGetter, nil, urlGen, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+	})
+}
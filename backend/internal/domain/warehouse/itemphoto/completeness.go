@@ -0,0 +1,111 @@
+package itemphoto
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/google/uuid"
+
+	appMiddleware "github.com/antti/home-warehouse/go-backend/internal/api/middleware"
+)
+
+// IncompleteItem is an active item without any photo.
+type IncompleteItem struct {
+	ItemID    uuid.UUID
+	SKU       string
+	Name      string
+	ShortCode string
+}
+
+// IncompleteItemsReport lists the items the workspace's require-photo rule
+// flags as incomplete. Items is empty while the rule is off.
+type IncompleteItemsReport struct {
+	RequirePhoto bool
+	Items        []IncompleteItem
+}
+
+// ListIncompleteItems reports the workspace's items that still need a photo
+// to count as cataloged under Settings.RequirePhoto.
+func (s *Service) ListIncompleteItems(ctx context.Context, workspaceID uuid.UUID) (*IncompleteItemsReport, error) {
+	settings, err := s.GetSettings(ctx, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load photo settings: %w", err)
+	}
+	report := &IncompleteItemsReport{RequirePhoto: settings.RequirePhoto, Items: []IncompleteItem{}}
+	if !settings.RequirePhoto {
+		return report, nil
+	}
+
+	items, err := s.repo.ListItemsWithoutPhotos(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	report.Items = items
+	return report, nil
+}
+
+// BlocksInventory reports whether the workspace's photo rule refuses new
+// inventory for the item: Settings.BlockInventoryWithoutPhoto is on and the
+// item has no photo yet. Used by the inventory service before Create.
+func (s *Service) BlocksInventory(ctx context.Context, workspaceID, itemID uuid.UUID) (bool, error) {
+	settings, err := s.GetSettings(ctx, workspaceID)
+	if err != nil {
+		return false, fmt.Errorf("failed to load photo settings: %w", err)
+	}
+	if !settings.RequirePhoto || !settings.BlockInventoryWithoutPhoto {
+		return false, nil
+	}
+
+	count, err := s.repo.CountByItem(ctx, itemID, workspaceID)
+	if err != nil {
+		return false, err
+	}
+	return count == 0, nil
+}
+
+func listIncompleteItems(svc ServiceInterface) func(context.Context, *struct{}) (*IncompleteItemsOutput, error) {
+	return func(ctx context.Context, _ *struct{}) (*IncompleteItemsOutput, error) {
+		workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+		if !ok {
+			return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+		}
+
+		report, err := svc.ListIncompleteItems(ctx, workspaceID)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to list incomplete items")
+		}
+
+		items := make([]IncompleteItemResponse, len(report.Items))
+		for i, it := range report.Items {
+			items[i] = IncompleteItemResponse{
+				ItemID:    it.ItemID,
+				SKU:       it.SKU,
+				Name:      it.Name,
+				ShortCode: it.ShortCode,
+			}
+		}
+		return &IncompleteItemsOutput{Body: IncompleteItemsResponse{
+			RequirePhoto: report.RequirePhoto,
+			Items:        items,
+			Total:        len(items),
+		}}, nil
+	}
+}
+
+type IncompleteItemsOutput struct {
+	Body IncompleteItemsResponse
+}
+
+type IncompleteItemsResponse struct {
+	RequirePhoto bool                     `json:"require_photo" doc:"Whether the workspace requires a photo per item; the list is empty while it does not"`
+	Items        []IncompleteItemResponse `json:"items"`
+	Total        int                      `json:"total"`
+}
+
+type IncompleteItemResponse struct {
+	ItemID    uuid.UUID `json:"item_id"`
+	SKU       string    `json:"sku"`
+	Name      string    `json:"name"`
+	ShortCode string    `json:"short_code"`
+}
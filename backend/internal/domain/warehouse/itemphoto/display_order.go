@@ -0,0 +1,160 @@
+package itemphoto
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	"github.com/google/uuid"
+)
+
+// DisplayOrderGap is the spacing between consecutive display_order values.
+// Leaving room between neighbours lets a single photo be moved or inserted by
+// rewriting only its own row; the gallery is renumbered only once a gap is
+// used up.
+const DisplayOrderGap int32 = 1024
+
+// MovePhoto moves a photo within its item's gallery so it sits directly
+// before beforePhotoID, or at the end when beforePhotoID is nil. Normally
+// only the moved photo's display order changes; when there is no free value
+// between its new neighbours the whole gallery is renumbered.
+func (s *Service) MovePhoto(ctx context.Context, photoID, workspaceID uuid.UUID, beforePhotoID *uuid.UUID) error {
+	photo, err := s.fetchPhoto(ctx, photoID)
+	if err != nil {
+		return err
+	}
+	if photo.WorkspaceID != workspaceID {
+		return ErrUnauthorized
+	}
+	if beforePhotoID != nil && *beforePhotoID == photoID {
+		return ErrInvalidDisplayOrder
+	}
+
+	photos, err := s.repo.GetByItem(ctx, photo.ItemID, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get existing photos: %w", err)
+	}
+
+	// The gallery without the moved photo, and where it goes back in.
+	others := make([]*ItemPhoto, 0, len(photos))
+	current := -1
+	for i, p := range photos {
+		if p.ID == photoID {
+			current = i
+			continue
+		}
+		others = append(others, p)
+	}
+	if current < 0 {
+		return ErrPhotoNotFound
+	}
+	pos := len(others)
+	if beforePhotoID != nil {
+		pos = -1
+		for i, p := range others {
+			if p.ID == *beforePhotoID {
+				pos = i
+				break
+			}
+		}
+		if pos < 0 {
+			return ErrInvalidDisplayOrder
+		}
+	}
+	if pos == current {
+		return nil
+	}
+
+	var prev, next *int32
+	if pos > 0 {
+		prev = &others[pos-1].DisplayOrder
+	}
+	if pos < len(others) {
+		next = &others[pos].DisplayOrder
+	}
+
+	if order, ok := orderBetween(prev, next); ok {
+		if order == photo.DisplayOrder {
+			return nil
+		}
+		if err := s.repo.UpdateDisplayOrder(ctx, photoID, order); err != nil {
+			return fmt.Errorf("failed to update display order: %w", err)
+		}
+		return nil
+	}
+
+	ordered := make([]*ItemPhoto, 0, len(photos))
+	ordered = append(ordered, others[:pos]...)
+	ordered = append(ordered, photo)
+	ordered = append(ordered, others[pos:]...)
+	return s.renumberPhotos(ctx, ordered)
+}
+
+// orderBetween picks a display order strictly between prev and next, where
+// nil means the start or end of the gallery. It reports false when no such
+// value is left and the gallery must be renumbered.
+func orderBetween(prev, next *int32) (int32, bool) {
+	switch {
+	case prev == nil && next == nil:
+		return 0, true
+	case prev == nil:
+		if *next >= DisplayOrderGap {
+			return *next - DisplayOrderGap, true
+		}
+		if *next > 0 {
+			return *next / 2, true
+		}
+		return 0, false
+	case next == nil:
+		if *prev <= math.MaxInt32-DisplayOrderGap {
+			return *prev + DisplayOrderGap, true
+		}
+		return 0, false
+	default:
+		if *next-*prev >= 2 {
+			return *prev + (*next-*prev)/2, true
+		}
+		return 0, false
+	}
+}
+
+// renumberPhotos rewrites the gallery's display orders to multiples of
+// DisplayOrderGap in the given order, skipping photos already in place.
+func (s *Service) renumberPhotos(ctx context.Context, ordered []*ItemPhoto) error {
+	for i, p := range ordered {
+		order := int32(i) * DisplayOrderGap
+		if p.DisplayOrder == order {
+			continue
+		}
+		if err := s.repo.UpdateDisplayOrder(ctx, p.ID, order); err != nil {
+			return fmt.Errorf("failed to update display order: %w", err)
+		}
+		p.DisplayOrder = order
+	}
+	return nil
+}
+
+// nextDisplayOrder returns the display order for a photo appended to an item
+// that already has existingCount photos, renumbering the gallery first if the
+// last photo sits too close to the top of the range.
+func (s *Service) nextDisplayOrder(ctx context.Context, itemID, workspaceID uuid.UUID, existingCount int64) (int32, error) {
+	if existingCount == 0 {
+		return 0, nil
+	}
+	last, err := s.repo.MaxDisplayOrder(ctx, itemID, workspaceID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get display order: %w", err)
+	}
+	if order, ok := orderBetween(&last, nil); ok {
+		return order, nil
+	}
+
+	photos, err := s.repo.GetByItem(ctx, itemID, workspaceID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get existing photos: %w", err)
+	}
+	if err := s.renumberPhotos(ctx, photos); err != nil {
+		return 0, err
+	}
+	return int32(len(photos)) * DisplayOrderGap, nil
+}
@@ -0,0 +1,196 @@
+package itemphoto_test
+
+import (
+	"bytes"
+	"context"
+	"math"
+	"mime/multipart"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/itemphoto"
+)
+
+// galleryPhotos returns test photos for one item with the given display
+// orders, in that order.
+func galleryPhotos(t *testing.T, itemID, workspaceID uuid.UUID, orders ...int32) []*itemphoto.ItemPhoto {
+	photos := make([]*itemphoto.ItemPhoto, len(orders))
+	for i, order := range orders {
+		photos[i] = createServiceTestPhoto(t, itemID, workspaceID)
+		photos[i].DisplayOrder = order
+	}
+	return photos
+}
+
+func TestService_MovePhoto(t *testing.T) {
+	ctx := context.Background()
+	itemID := uuid.New()
+	workspaceID := uuid.New()
+	gap := itemphoto.DisplayOrderGap
+
+	newService := func(repo *MockRepository) *itemphoto.Service {
+		return itemphoto.NewService(repo, new(MockStorage), new(MockImageProcessor), os.TempDir())
+	}
+
+	t.Run("inserts between neighbours by updating only the moved photo", func(t *testing.T) {
+		repo := new(MockRepository)
+		photos := galleryPhotos(t, itemID, workspaceID, 0, gap, 2*gap)
+		moved := photos[2]
+
+		repo.On("GetByID", ctx, moved.ID).Return(moved, nil)
+		repo.On("GetByItem", ctx, itemID, workspaceID).Return(photos, nil)
+		repo.On("UpdateDisplayOrder", ctx, moved.ID, gap/2).Return(nil).Once()
+
+		err := newService(repo).MovePhoto(ctx, moved.ID, workspaceID, &photos[1].ID)
+
+		require.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("moves to the front one gap before the first photo", func(t *testing.T) {
+		repo := new(MockRepository)
+		photos := galleryPhotos(t, itemID, workspaceID, gap, 2*gap, 3*gap)
+		moved := photos[2]
+
+		repo.On("GetByID", ctx, moved.ID).Return(moved, nil)
+		repo.On("GetByItem", ctx, itemID, workspaceID).Return(photos, nil)
+		repo.On("UpdateDisplayOrder", ctx, moved.ID, int32(0)).Return(nil).Once()
+
+		err := newService(repo).MovePhoto(ctx, moved.ID, workspaceID, &photos[0].ID)
+
+		require.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("moves to the end one gap after the last photo", func(t *testing.T) {
+		repo := new(MockRepository)
+		photos := galleryPhotos(t, itemID, workspaceID, 0, gap, 2*gap)
+		moved := photos[0]
+
+		repo.On("GetByID", ctx, moved.ID).Return(moved, nil)
+		repo.On("GetByItem", ctx, itemID, workspaceID).Return(photos, nil)
+		repo.On("UpdateDisplayOrder", ctx, moved.ID, 3*gap).Return(nil).Once()
+
+		err := newService(repo).MovePhoto(ctx, moved.ID, workspaceID, nil)
+
+		require.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("renumbers the gallery when the gap is used up", func(t *testing.T) {
+		repo := new(MockRepository)
+		photos := galleryPhotos(t, itemID, workspaceID, 0, 1, 2*gap)
+		moved := photos[2]
+
+		repo.On("GetByID", ctx, moved.ID).Return(moved, nil)
+		repo.On("GetByItem", ctx, itemID, workspaceID).Return(photos, nil)
+		// New order: photos[0], moved, photos[1]. photos[0] is already at 0.
+		repo.On("UpdateDisplayOrder", ctx, moved.ID, gap).Return(nil).Once()
+		repo.On("UpdateDisplayOrder", ctx, photos[1].ID, 2*gap).Return(nil).Once()
+
+		err := newService(repo).MovePhoto(ctx, moved.ID, workspaceID, &photos[1].ID)
+
+		require.NoError(t, err)
+		repo.AssertExpectations(t)
+		repo.AssertNotCalled(t, "UpdateDisplayOrder", ctx, photos[0].ID, mock.Anything)
+	})
+
+	t.Run("renumbers legacy galleries with duplicate orders", func(t *testing.T) {
+		repo := new(MockRepository)
+		photos := galleryPhotos(t, itemID, workspaceID, 0, 0, 0)
+		moved := photos[0]
+
+		repo.On("GetByID", ctx, moved.ID).Return(moved, nil)
+		repo.On("GetByItem", ctx, itemID, workspaceID).Return(photos, nil)
+		// New order: photos[1], moved, photos[2].
+		repo.On("UpdateDisplayOrder", ctx, moved.ID, gap).Return(nil).Once()
+		repo.On("UpdateDisplayOrder", ctx, photos[2].ID, 2*gap).Return(nil).Once()
+
+		err := newService(repo).MovePhoto(ctx, moved.ID, workspaceID, &photos[2].ID)
+
+		require.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("does nothing when the photo is already in place", func(t *testing.T) {
+		repo := new(MockRepository)
+		photos := galleryPhotos(t, itemID, workspaceID, 0, gap, 2*gap)
+		moved := photos[0]
+
+		repo.On("GetByID", ctx, moved.ID).Return(moved, nil)
+		repo.On("GetByItem", ctx, itemID, workspaceID).Return(photos, nil)
+
+		err := newService(repo).MovePhoto(ctx, moved.ID, workspaceID, &photos[1].ID)
+
+		require.NoError(t, err)
+		repo.AssertNotCalled(t, "UpdateDisplayOrder", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects a target from another item", func(t *testing.T) {
+		repo := new(MockRepository)
+		photos := galleryPhotos(t, itemID, workspaceID, 0, gap)
+		moved := photos[0]
+		otherID := uuid.New()
+
+		repo.On("GetByID", ctx, moved.ID).Return(moved, nil)
+		repo.On("GetByItem", ctx, itemID, workspaceID).Return(photos, nil)
+
+		err := newService(repo).MovePhoto(ctx, moved.ID, workspaceID, &otherID)
+
+		assert.ErrorIs(t, err, itemphoto.ErrInvalidDisplayOrder)
+	})
+
+	t.Run("rejects a photo from another workspace", func(t *testing.T) {
+		repo := new(MockRepository)
+		photo := createServiceTestPhoto(t, itemID, uuid.New())
+
+		repo.On("GetByID", ctx, photo.ID).Return(photo, nil)
+
+		err := newService(repo).MovePhoto(ctx, photo.ID, workspaceID, nil)
+
+		assert.ErrorIs(t, err, itemphoto.ErrUnauthorized)
+		repo.AssertNotCalled(t, "GetByItem", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestService_UploadPhoto_RenumbersFullGallery(t *testing.T) {
+	ctx := context.Background()
+	itemID := uuid.New()
+	workspaceID := uuid.New()
+	gap := itemphoto.DisplayOrderGap
+
+	repo := new(MockRepository)
+	storage := new(MockStorage)
+	processor := new(MockImageProcessor)
+	photos := galleryPhotos(t, itemID, workspaceID, 0, math.MaxInt32-10)
+
+	processor.On("Validate", ctx, mock.AnythingOfType("string")).Return(nil)
+	processor.On("GetDimensions", ctx, mock.AnythingOfType("string")).Return(800, 600, nil)
+	storage.On("Save", ctx, workspaceID.String(), itemID.String(), "photo.jpg", mock.Anything).Return("photos/photo.jpg", nil)
+	repo.On("CountByItem", ctx, itemID, workspaceID).Return(int64(2), nil)
+	repo.On("MaxDisplayOrder", ctx, itemID, workspaceID).Return(int32(math.MaxInt32-10), nil)
+	repo.On("GetByItem", ctx, itemID, workspaceID).Return(photos, nil)
+	repo.On("UpdateDisplayOrder", ctx, photos[1].ID, gap).Return(nil).Once()
+	repo.On("Create", ctx, mock.MatchedBy(func(p *itemphoto.ItemPhoto) bool {
+		return p.DisplayOrder == 2*gap
+	})).Return(&itemphoto.ItemPhoto{ID: uuid.New(), ItemID: itemID}, nil)
+
+	service := itemphoto.NewService(repo, storage, processor, t.TempDir())
+	content := []byte("fake jpeg image content")
+	header := &multipart.FileHeader{
+		Filename: "photo.jpg",
+		Size:     int64(len(content)),
+		Header:   make(map[string][]string),
+	}
+	header.Header.Set("Content-Type", "image/jpeg")
+	file := &mockFile{bytes.NewReader(content)}
+	_, err := service.UploadPhoto(ctx, itemID, workspaceID, uuid.New(), nil, file, header, nil)
+
+	require.NoError(t, err)
+	repo.AssertExpectations(t)
+}
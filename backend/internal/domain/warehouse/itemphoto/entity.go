@@ -12,6 +12,10 @@ const (
 	MimeTypeJPEG = "image/jpeg"
 	MimeTypePNG  = "image/png"
 	MimeTypeWEBP = "image/webp"
+
+	// HEIC/HEIF uploads are converted to JPEG on ingest and never stored.
+	MimeTypeHEIC = "image/heic"
+	MimeTypeHEIF = "image/heif"
 )
 
 // ThumbnailStatus represents the processing state of photo thumbnails
@@ -38,7 +42,9 @@ func (s ThumbnailStatus) String() string {
 	return string(s)
 }
 
-// AllowedMimeTypes contains all supported image MIME types
+// AllowedMimeTypes contains the MIME types a stored photo can have. It is
+// also the default set of accepted upload types (see
+// Service.SetAllowedMimeTypes).
 var AllowedMimeTypes = []string{
 	MimeTypeJPEG,
 	MimeTypePNG,
@@ -50,6 +56,7 @@ type ItemPhoto struct {
 	ID            uuid.UUID
 	ItemID        uuid.UUID
 	WorkspaceID   uuid.UUID
+	InventoryID   *uuid.UUID // Physical unit the photo shows; nil for the item in general
 	Filename      string
 	StoragePath   string
 	ThumbnailPath string // Legacy thumbnail path (for backward compatibility)
@@ -65,15 +72,20 @@ type ItemPhoto struct {
 	UpdatedAt     time.Time
 
 	// Thumbnail processing fields
-	ThumbnailStatus     ThumbnailStatus // Current processing status (pending/processing/complete/failed)
-	ThumbnailSmallPath  *string         // 150px thumbnail path
-	ThumbnailMediumPath *string         // 400px thumbnail path
-	ThumbnailLargePath  *string         // 800px thumbnail path
-	ThumbnailAttempts   int32           // Number of processing attempts
-	ThumbnailError      *string         // Last error message if failed
+	ThumbnailStatus        ThumbnailStatus // Current processing status (pending/processing/complete/failed)
+	ThumbnailSmallPath     *string         // 150px thumbnail path
+	ThumbnailMediumPath    *string         // 400px thumbnail path
+	ThumbnailLargePath     *string         // 800px thumbnail path
+	ThumbnailAttempts      int32           // Number of processing attempts
+	ThumbnailError         *string         // Last error message if failed
+	ThumbnailConfigVersion *string         // imageprocessor.Config.ThumbnailVersion the thumbnails were made with
 
 	// Duplicate detection
-	PerceptualHash *int64 // dHash for finding similar images
+	PerceptualHash *int64  // dHash for finding similar images
+	ContentHash    *string // Hex SHA-256 of the original file, for exact duplicates
+
+	// Placeholder rendering
+	BlurHash *string // Compact blurred preview shown while thumbnails load
 }
 
 // Validate checks if the item photo data is valid
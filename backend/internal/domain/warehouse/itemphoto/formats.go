@@ -0,0 +1,127 @@
+package itemphoto
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// HEICConverter converts HEIC/HEIF images to JPEG. Implemented by
+// imageprocessor.Processor.
+type HEICConverter interface {
+	ConvertToJPEG(ctx context.Context, sourcePath, destPath string) error
+}
+
+// FileTypeError reports an upload whose type is not in the accepted set.
+// It matches ErrInvalidFileType via errors.Is.
+type FileTypeError struct {
+	MimeType string
+	Allowed  []string
+}
+
+func (e *FileTypeError) Error() string {
+	labels := make([]string, len(e.Allowed))
+	for i, m := range e.Allowed {
+		labels[i] = mimeTypeLabel(m)
+	}
+	return "invalid file type: only " + joinLabels(labels) + " allowed"
+}
+
+func (e *FileTypeError) Is(target error) bool {
+	return target == ErrInvalidFileType
+}
+
+// SetAllowedMimeTypes restricts the accepted upload types. HEIC/HEIF are only
+// accepted when a converter is also set (see SetHEICConverter). An empty list
+// restores the default, AllowedMimeTypes.
+func (s *Service) SetAllowedMimeTypes(types []string) {
+	s.allowedTypes = slices.Clone(types)
+}
+
+// SetHEICConverter enables HEIC/HEIF uploads, which are converted to JPEG
+// before being stored. This is optional - without it HEIC uploads are
+// rejected even if listed in the allowed types.
+func (s *Service) SetHEICConverter(converter HEICConverter) {
+	s.heic = converter
+}
+
+// AcceptedMimeTypes returns the upload types the service currently accepts.
+func (s *Service) AcceptedMimeTypes() []string {
+	types := s.allowedTypes
+	if len(types) == 0 {
+		types = AllowedMimeTypes
+	}
+	accepted := make([]string, 0, len(types))
+	for _, m := range types {
+		if isHEIC(m) && s.heic == nil {
+			continue
+		}
+		accepted = append(accepted, m)
+	}
+	return accepted
+}
+
+// acceptsMimeType reports whether an upload of mimeType is accepted.
+func (s *Service) acceptsMimeType(mimeType string) bool {
+	return slices.Contains(s.AcceptedMimeTypes(), mimeType)
+}
+
+// fileTypeError returns the error for a rejected upload of mimeType.
+func (s *Service) fileTypeError(mimeType string) error {
+	return &FileTypeError{MimeType: mimeType, Allowed: s.AcceptedMimeTypes()}
+}
+
+// convertHEIC converts the HEIC file at path to a JPEG next to it and returns
+// the new file's path. The caller removes it.
+func (s *Service) convertHEIC(ctx context.Context, path string) (string, error) {
+	jpegPath := strings.TrimSuffix(path, filepath.Ext(path)) + ".jpg"
+	if err := s.heic.ConvertToJPEG(ctx, path, jpegPath); err != nil {
+		os.Remove(jpegPath)
+		return "", fmt.Errorf("invalid image: %w", err)
+	}
+	return jpegPath, nil
+}
+
+// isHEIC reports whether mimeType is HEIC or HEIF.
+func isHEIC(mimeType string) bool {
+	return mimeType == MimeTypeHEIC || mimeType == MimeTypeHEIF
+}
+
+// jpegFilename swaps the extension of a converted upload's filename for .jpg.
+func jpegFilename(filename string) string {
+	return strings.TrimSuffix(filename, filepath.Ext(filename)) + ".jpg"
+}
+
+// mimeTypeLabel is the short display name of a MIME type.
+func mimeTypeLabel(mimeType string) string {
+	switch mimeType {
+	case MimeTypeJPEG:
+		return "JPEG"
+	case MimeTypePNG:
+		return "PNG"
+	case MimeTypeWEBP:
+		return "WebP"
+	case MimeTypeHEIC:
+		return "HEIC"
+	case MimeTypeHEIF:
+		return "HEIF"
+	}
+	return mimeType
+}
+
+// joinLabels lists labels in prose: "JPEG is", "JPEG and PNG are",
+// "JPEG, PNG, and WebP are".
+func joinLabels(labels []string) string {
+	switch len(labels) {
+	case 0:
+		return "no types are"
+	case 1:
+		return labels[0] + " is"
+	case 2:
+		return labels[0] + " and " + labels[1] + " are"
+	}
+	return strings.Join(labels[:len(labels)-1], ", ") + ", and " + labels[len(labels)-1] + " are"
+}
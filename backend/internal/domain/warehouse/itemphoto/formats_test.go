@@ -0,0 +1,172 @@
+package itemphoto_test
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"mime/multipart"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/itemphoto"
+)
+
+// fakeHEICConverter writes fixed bytes as the converted JPEG.
+type fakeHEICConverter struct {
+	sources []string
+}
+
+func (f *fakeHEICConverter) ConvertToJPEG(_ context.Context, sourcePath, destPath string) error {
+	f.sources = append(f.sources, sourcePath)
+	return os.WriteFile(destPath, []byte("converted jpeg"), 0o600)
+}
+
+func newUploadFile(filename, contentType string, content []byte) (multipart.File, *multipart.FileHeader) {
+	header := &multipart.FileHeader{
+		Filename: filename,
+		Size:     int64(len(content)),
+		Header:   make(map[string][]string),
+	}
+	header.Header.Set("Content-Type", contentType)
+	return &mockFile{bytes.NewReader(content)}, header
+}
+
+func pngBytes(t *testing.T) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 4, 4))))
+	return buf.Bytes()
+}
+
+func TestService_UploadPhoto_AllowedMimeTypes(t *testing.T) {
+	itemID := uuid.New()
+	workspaceID := uuid.New()
+	userID := uuid.New()
+	jpegAndWebP := []string{itemphoto.MimeTypeJPEG, itemphoto.MimeTypeWEBP}
+
+	t.Run("rejects PNG when the allowed list excludes it", func(t *testing.T) {
+		ctx := context.Background()
+		repo := new(MockRepository)
+
+		service := itemphoto.NewService(repo, new(MockStorage), new(MockImageProcessor), t.TempDir())
+		service.SetAllowedMimeTypes(jpegAndWebP)
+		file, header := newUploadFile("photo.png", itemphoto.MimeTypePNG, pngBytes(t))
+		_, err := service.UploadPhoto(ctx, itemID, workspaceID, userID, nil, file, header, nil)
+
+		require.ErrorIs(t, err, itemphoto.ErrInvalidFileType)
+		assert.EqualError(t, err, "invalid file type: only JPEG and WebP are allowed")
+		var typeErr *itemphoto.FileTypeError
+		require.ErrorAs(t, err, &typeErr)
+		assert.Equal(t, itemphoto.MimeTypePNG, typeErr.MimeType)
+		assert.Equal(t, jpegAndWebP, typeErr.Allowed)
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects a PNG labelled as JPEG", func(t *testing.T) {
+		ctx := context.Background()
+		repo := new(MockRepository)
+		processor := new(MockImageProcessor)
+
+		repo.On("CountByItem", ctx, itemID, workspaceID).Return(int64(0), nil)
+		processor.On("Validate", ctx, mock.AnythingOfType("string")).Return(nil)
+
+		service := itemphoto.NewService(repo, new(MockStorage), processor, t.TempDir())
+		service.SetAllowedMimeTypes(jpegAndWebP)
+		file, header := newUploadFile("photo.jpg", itemphoto.MimeTypeJPEG, pngBytes(t))
+		_, err := service.UploadPhoto(ctx, itemID, workspaceID, userID, nil, file, header, nil)
+
+		require.ErrorIs(t, err, itemphoto.ErrInvalidFileType)
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("accepts JPEG from the allowed list", func(t *testing.T) {
+		ctx := context.Background()
+		repo := new(MockRepository)
+		storage := new(MockStorage)
+		processor := new(MockImageProcessor)
+
+		repo.On("CountByItem", ctx, itemID, workspaceID).Return(int64(0), nil)
+		processor.On("Validate", ctx, mock.AnythingOfType("string")).Return(nil)
+		processor.On("GetDimensions", ctx, mock.AnythingOfType("string")).Return(800, 600, nil)
+		storage.On("Save", ctx, workspaceID.String(), itemID.String(), "photo.jpg", mock.Anything).Return("photos/photo.jpg", nil)
+		repo.On("Create", ctx, mock.Anything).Return(&itemphoto.ItemPhoto{ID: uuid.New(), ItemID: itemID}, nil)
+
+		service := itemphoto.NewService(repo, storage, processor, t.TempDir())
+		service.SetAllowedMimeTypes(jpegAndWebP)
+		file, header := newUploadFile("photo.jpg", itemphoto.MimeTypeJPEG, []byte("fake jpeg image content"))
+		_, err := service.UploadPhoto(ctx, itemID, workspaceID, userID, nil, file, header, nil)
+
+		require.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("lists the default types", func(t *testing.T) {
+		service := itemphoto.NewService(new(MockRepository), new(MockStorage), new(MockImageProcessor), t.TempDir())
+
+		file, header := newUploadFile("doc.pdf", "application/pdf", []byte("%PDF"))
+		_, err := service.UploadPhoto(context.Background(), itemID, workspaceID, userID, nil, file, header, nil)
+
+		assert.EqualError(t, err, "invalid file type: only JPEG, PNG, and WebP are allowed")
+	})
+}
+
+func TestService_UploadPhoto_HEIC(t *testing.T) {
+	itemID := uuid.New()
+	workspaceID := uuid.New()
+	userID := uuid.New()
+	withHEIC := append([]string{itemphoto.MimeTypeHEIC, itemphoto.MimeTypeHEIF}, itemphoto.AllowedMimeTypes...)
+
+	t.Run("converts HEIC to JPEG on ingest", func(t *testing.T) {
+		ctx := context.Background()
+		repo := new(MockRepository)
+		storage := new(MockStorage)
+		processor := new(MockImageProcessor)
+		converter := &fakeHEICConverter{}
+
+		repo.On("CountByItem", ctx, itemID, workspaceID).Return(int64(0), nil)
+		processor.On("Validate", ctx, mock.AnythingOfType("string")).Return(nil)
+		processor.On("GetDimensions", ctx, mock.AnythingOfType("string")).Return(4032, 3024, nil)
+		storage.On("Save", ctx, workspaceID.String(), itemID.String(), "IMG_0042.jpg", mock.Anything).Return("photos/IMG_0042.jpg", nil)
+		repo.On("Create", ctx, mock.MatchedBy(func(p *itemphoto.ItemPhoto) bool {
+			return p.Filename == "IMG_0042.jpg" && p.MimeType == itemphoto.MimeTypeJPEG
+		})).Return(&itemphoto.ItemPhoto{ID: uuid.New(), ItemID: itemID}, nil)
+
+		service := itemphoto.NewService(repo, storage, processor, t.TempDir())
+		service.SetAllowedMimeTypes(withHEIC)
+		service.SetHEICConverter(converter)
+		file, header := newUploadFile("IMG_0042.HEIC", itemphoto.MimeTypeHEIC, []byte("fake heic"))
+		_, err := service.UploadPhoto(ctx, itemID, workspaceID, userID, nil, file, header, nil)
+
+		require.NoError(t, err)
+		assert.Len(t, converter.sources, 1)
+		repo.AssertExpectations(t)
+		storage.AssertExpectations(t)
+	})
+
+	t.Run("rejects HEIC without a converter", func(t *testing.T) {
+		service := itemphoto.NewService(new(MockRepository), new(MockStorage), new(MockImageProcessor), t.TempDir())
+		service.SetAllowedMimeTypes(withHEIC)
+
+		file, header := newUploadFile("IMG_0042.HEIC", itemphoto.MimeTypeHEIC, []byte("fake heic"))
+		_, err := service.UploadPhoto(context.Background(), itemID, workspaceID, userID, nil, file, header, nil)
+
+		require.ErrorIs(t, err, itemphoto.ErrInvalidFileType)
+		assert.NotContains(t, service.AcceptedMimeTypes(), itemphoto.MimeTypeHEIC)
+	})
+
+	t.Run("rejects HEIC not in the allowed list", func(t *testing.T) {
+		service := itemphoto.NewService(new(MockRepository), new(MockStorage), new(MockImageProcessor), t.TempDir())
+		service.SetHEICConverter(&fakeHEICConverter{})
+
+		file, header := newUploadFile("IMG_0042.HEIC", itemphoto.MimeTypeHEIC, []byte("fake heic"))
+		_, err := service.UploadPhoto(context.Background(), itemID, workspaceID, userID, nil, file, header, nil)
+
+		require.ErrorIs(t, err, itemphoto.ErrInvalidFileType)
+	})
+}
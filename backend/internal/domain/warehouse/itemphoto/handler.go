@@ -7,6 +7,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"log"
 	"mime"
 	"net/http"
 	"os"
@@ -31,7 +32,7 @@ const (
 )
 
 // PhotoURLGenerator is a function that generates URLs for photos
-type PhotoURLGenerator func(workspaceID, itemID, photoID uuid.UUID, isThumbnail bool) string
+type PhotoURLGenerator func(ctx context.Context, workspaceID, itemID, photoID uuid.UUID, isThumbnail bool) string
 
 // StorageGetter is an interface for getting storage instances
 type StorageGetter interface {
@@ -43,11 +44,15 @@ type StorageGetter interface {
 // of registrations rather than a single god-function of inline closures.
 func RegisterRoutes(api huma.API, svc ServiceInterface, broadcaster *events.Broadcaster, urlGenerator PhotoURLGenerator) {
 	huma.Get(api, "/items/{item_id}/photos/list", listPhotos(svc, urlGenerator))
+	huma.Get(api, "/inventory/{inventory_id}/photos", listInventoryPhotos(svc, urlGenerator))
 	huma.Get(api, "/photos/{id}", getPhoto(svc, urlGenerator))
 	huma.Put(api, "/photos/{id}/primary", setPrimaryPhoto(svc, broadcaster))
 	huma.Put(api, "/photos/{id}/caption", updateCaption(svc, broadcaster, urlGenerator))
 	huma.Put(api, "/items/{item_id}/photos/order", reorderPhotos(svc, broadcaster))
+	huma.Put(api, "/photos/{id}/position", movePhoto(svc, broadcaster))
+	huma.Post(api, "/items/{item_id}/photos/move", movePhotosToItem(svc, broadcaster))
 	huma.Delete(api, "/photos/{id}", deletePhoto(svc, broadcaster))
+	huma.Post(api, "/items/{item_id}/photos/{photo_id}/regenerate", regenerateThumbnails(svc, urlGenerator))
 }
 
 // listPhotos lists photos for an item.
@@ -65,7 +70,31 @@ func listPhotos(svc ServiceInterface, urlGenerator PhotoURLGenerator) func(conte
 
 		items := make([]PhotoResponse, len(photos))
 		for i, photo := range photos {
-			items[i] = toPhotoResponse(photo, urlGenerator)
+			items[i] = toPhotoResponse(ctx, photo, urlGenerator)
+		}
+
+		return &ListPhotosOutput{
+			Body: PhotoListResponse{Items: items},
+		}, nil
+	}
+}
+
+// listInventoryPhotos lists the photos tagged with one inventory record.
+func listInventoryPhotos(svc ServiceInterface, urlGenerator PhotoURLGenerator) func(context.Context, *ListInventoryPhotosInput) (*ListPhotosOutput, error) {
+	return func(ctx context.Context, input *ListInventoryPhotosInput) (*ListPhotosOutput, error) {
+		workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+		if !ok {
+			return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+		}
+
+		photos, err := svc.ListInventoryPhotos(ctx, input.InventoryID, workspaceID)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to list photos")
+		}
+
+		items := make([]PhotoResponse, len(photos))
+		for i, photo := range photos {
+			items[i] = toPhotoResponse(ctx, photo, urlGenerator)
 		}
 
 		return &ListPhotosOutput{
@@ -96,7 +125,7 @@ func getPhoto(svc ServiceInterface, urlGenerator PhotoURLGenerator) func(context
 		}
 
 		return &GetPhotoOutput{
-			Body: toPhotoResponse(photo, urlGenerator),
+			Body: toPhotoResponse(ctx, photo, urlGenerator),
 		}, nil
 	}
 }
@@ -186,7 +215,7 @@ func updateCaption(svc ServiceInterface, broadcaster *events.Broadcaster, urlGen
 		}
 
 		return &UpdateCaptionOutput{
-			Body: toPhotoResponse(photo, urlGenerator),
+			Body: toPhotoResponse(ctx, photo, urlGenerator),
 		}, nil
 	}
 }
@@ -228,6 +257,96 @@ func reorderPhotos(svc ServiceInterface, broadcaster *events.Broadcaster) func(c
 	}
 }
 
+// movePhoto moves a single photo within its item's gallery.
+func movePhoto(svc ServiceInterface, broadcaster *events.Broadcaster) func(context.Context, *MovePhotoInput) (*struct{}, error) {
+	return func(ctx context.Context, input *MovePhotoInput) (*struct{}, error) {
+		workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+		if !ok {
+			return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+		}
+
+		authUser, _ := appMiddleware.GetAuthUser(ctx)
+
+		err := svc.MovePhoto(ctx, input.ID, workspaceID, input.Body.BeforePhotoID)
+		if err != nil {
+			if errors.Is(err, ErrPhotoNotFound) {
+				return nil, huma.Error404NotFound(msgPhotoNotFound)
+			}
+			if errors.Is(err, ErrUnauthorized) {
+				return nil, huma.Error403Forbidden(msgPhotoNotInWorkspace)
+			}
+			if errors.Is(err, ErrInvalidDisplayOrder) {
+				return nil, huma.Error400BadRequest("invalid photo position: before_photo_id must be another photo of the same item")
+			}
+			return nil, huma.Error500InternalServerError("failed to move photo")
+		}
+
+		// Publish event
+		if broadcaster != nil && authUser != nil {
+			userName := appMiddleware.GetUserDisplayName(ctx)
+			broadcaster.Publish(workspaceID, events.Event{
+				Type:       "item_photo.updated",
+				EntityID:   input.ID.String(),
+				EntityType: "item_photo",
+				UserID:     authUser.ID,
+				Data: map[string]any{
+					"id":              input.ID,
+					"before_photo_id": input.Body.BeforePhotoID,
+					"user_name":       userName,
+				},
+			})
+		}
+
+		return nil, nil
+	}
+}
+
+// movePhotosToItem moves photos from other items of the workspace to the item
+// in the path.
+func movePhotosToItem(svc ServiceInterface, broadcaster *events.Broadcaster) func(context.Context, *MovePhotosToItemInput) (*struct{}, error) {
+	return func(ctx context.Context, input *MovePhotosToItemInput) (*struct{}, error) {
+		workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+		if !ok {
+			return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+		}
+
+		authUser, _ := appMiddleware.GetAuthUser(ctx)
+
+		err := svc.MovePhotosToItem(ctx, workspaceID, input.Body.PhotoIDs, input.ItemID)
+		if err != nil {
+			var limitErr *PhotoLimitError
+			if errors.As(err, &limitErr) {
+				return nil, huma.Error409Conflict(limitErr.Error())
+			}
+			if errors.Is(err, ErrItemNotFound) {
+				return nil, huma.Error404NotFound("item not found")
+			}
+			if errors.Is(err, ErrPhotoNotFound) {
+				return nil, huma.Error404NotFound(msgPhotoNotFound)
+			}
+			return nil, huma.Error500InternalServerError("failed to move photos")
+		}
+
+		// Publish event
+		if broadcaster != nil && authUser != nil {
+			userName := appMiddleware.GetUserDisplayName(ctx)
+			broadcaster.Publish(workspaceID, events.Event{
+				Type:       "item_photo.moved",
+				EntityID:   input.ItemID.String(),
+				EntityType: "item",
+				UserID:     authUser.ID,
+				Data: map[string]any{
+					"item_id":   input.ItemID,
+					"photo_ids": input.Body.PhotoIDs,
+					"user_name": userName,
+				},
+			})
+		}
+
+		return nil, nil
+	}
+}
+
 // deletePhoto deletes a photo.
 func deletePhoto(svc ServiceInterface, broadcaster *events.Broadcaster) func(context.Context, *GetPhotoInput) (*struct{}, error) {
 	return func(ctx context.Context, input *GetPhotoInput) (*struct{}, error) {
@@ -272,6 +391,36 @@ func deletePhoto(svc ServiceInterface, broadcaster *events.Broadcaster) func(con
 	}
 }
 
+// regenerateThumbnails queues a fresh set of thumbnails for one photo. The
+// response carries the photo in processing state; the thumbnail job publishes
+// photo.thumbnail_ready or photo.thumbnail_failed when it is done.
+func regenerateThumbnails(svc ServiceInterface, urlGenerator PhotoURLGenerator) func(context.Context, *RegenerateThumbnailsInput) (*GetPhotoOutput, error) {
+	return func(ctx context.Context, input *RegenerateThumbnailsInput) (*GetPhotoOutput, error) {
+		workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+		if !ok {
+			return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+		}
+		if role, ok := appMiddleware.GetRole(ctx); !ok || role == "viewer" {
+			return nil, huma.Error403Forbidden("only members who can edit items can regenerate thumbnails")
+		}
+
+		photo, err := svc.RegenerateThumbnails(ctx, input.ItemID, input.PhotoID, workspaceID)
+		if err != nil {
+			if errors.Is(err, ErrPhotoNotFound) {
+				return nil, huma.Error404NotFound(msgPhotoNotFound)
+			}
+			if errors.Is(err, ErrThumbnailRegenerationUnavailable) {
+				return nil, huma.Error503ServiceUnavailable("thumbnail regeneration is not available")
+			}
+			return nil, huma.Error500InternalServerError("failed to regenerate thumbnails")
+		}
+
+		return &GetPhotoOutput{
+			Body: toPhotoResponse(ctx, photo, urlGenerator),
+		}, nil
+	}
+}
+
 // RegisterUploadHandler registers the multipart upload handler on a Chi router
 func RegisterUploadHandler(r chi.Router, svc ServiceInterface, broadcaster *events.Broadcaster, urlGenerator PhotoURLGenerator) {
 	handler := &UploadHandler{
@@ -294,6 +443,7 @@ func RegisterBulkHandler(r chi.Router, svc ServiceInterface, storageGetter Stora
 	r.Post("/items/{item_id}/photos/bulk-delete", handler.HandleBulkDelete)
 	r.Post("/items/{item_id}/photos/bulk-caption", handler.HandleBulkCaption)
 	r.Get("/items/{item_id}/photos/download", handler.HandleDownload)
+	r.Get("/items/{item_id}/photos.zip", handler.HandleArchive)
 	r.Post("/items/{item_id}/photos/check-duplicate", handler.HandleCheckDuplicate)
 }
 
@@ -633,7 +783,7 @@ func (h *BulkPhotoHandler) HandleCheckDuplicate(w http.ResponseWriter, r *http.R
 			ItemID:        c.ItemID,
 			Filename:      c.Filename,
 			SimilarityPct: c.SimilarityPct,
-			ThumbnailURL:  h.urlGenerator(workspaceID, c.ItemID, c.PhotoID, true),
+			ThumbnailURL:  h.urlGenerator(r.Context(), workspaceID, c.ItemID, c.PhotoID, true),
 		}
 	}
 
@@ -665,11 +815,17 @@ func removeTempFile(path string) {
 	os.Remove(path)
 }
 
-// RegisterServeHandler registers the photo serving handlers on a Chi router
-func RegisterServeHandler(r chi.Router, svc ServiceInterface, storageGetter StorageGetter) {
+// RegisterServeHandler registers the photo serving handlers on a Chi router.
+// placeholders are served for thumbnails that cannot be served; nil uses
+// DefaultPlaceholders.
+func RegisterServeHandler(r chi.Router, svc ServiceInterface, storageGetter StorageGetter, placeholders *Placeholders) {
+	if placeholders == nil {
+		placeholders = DefaultPlaceholders()
+	}
 	handler := &ServePhotoHandler{
 		svc:           svc,
 		storageGetter: storageGetter,
+		placeholders:  placeholders,
 	}
 	r.Get("/items/{item_id}/photos/{photo_id}", handler.HandleServe)
 	r.Get("/items/{item_id}/photos/{photo_id}/thumbnail", handler.HandleServeThumbnail)
@@ -727,14 +883,32 @@ func (h *UploadHandler) HandleUpload(w http.ResponseWriter, r *http.Request) {
 		caption = &c
 	}
 
+	// Get optional inventory record the photo shows
+	var inventoryID *uuid.UUID
+	if v := r.FormValue("inventory_id"); v != "" {
+		id, err := uuid.Parse(v)
+		if err != nil {
+			http.Error(w, "invalid inventory_id", http.StatusBadRequest)
+			return
+		}
+		inventoryID = &id
+	}
+
 	// Upload photo
-	photo, err := h.svc.UploadPhoto(ctx, itemID, workspaceID, authUser.ID, file, header, caption)
+	photo, err := h.svc.UploadPhoto(ctx, itemID, workspaceID, authUser.ID, inventoryID, file, header, caption)
 	if err != nil {
-		switch err {
-		case ErrFileTooLarge:
+		var limitErr *PhotoLimitError
+		if errors.As(err, &limitErr) {
+			http.Error(w, limitErr.Error(), http.StatusConflict)
+			return
+		}
+		switch {
+		case errors.Is(err, ErrFileTooLarge):
 			http.Error(w, "file too large: maximum size is 10MB", http.StatusRequestEntityTooLarge)
-		case ErrInvalidFileType:
-			http.Error(w, "invalid file type: only JPEG, PNG, and WebP are allowed", http.StatusBadRequest)
+		case errors.Is(err, ErrInvalidFileType):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		case errors.Is(err, ErrInventoryNotFound):
+			http.Error(w, "inventory not found for this item", http.StatusNotFound)
 		default:
 			http.Error(w, fmt.Sprintf("failed to upload photo: %v", err), http.StatusInternalServerError)
 		}
@@ -750,16 +924,17 @@ func (h *UploadHandler) HandleUpload(w http.ResponseWriter, r *http.Request) {
 			EntityType: "item_photo",
 			UserID:     authUser.ID,
 			Data: map[string]any{
-				"id":         photo.ID,
-				"item_id":    photo.ItemID,
-				"is_primary": photo.IsPrimary,
-				"user_name":  userName,
+				"id":           photo.ID,
+				"item_id":      photo.ItemID,
+				"inventory_id": photo.InventoryID,
+				"is_primary":   photo.IsPrimary,
+				"user_name":    userName,
 			},
 		})
 	}
 
 	// Return response
-	response := toPhotoResponse(photo, h.urlGenerator)
+	response := toPhotoResponse(r.Context(), photo, h.urlGenerator)
 	w.Header().Set(headerContentType, "application/json")
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(response)
@@ -769,19 +944,26 @@ func (h *UploadHandler) HandleUpload(w http.ResponseWriter, r *http.Request) {
 type ServePhotoHandler struct {
 	svc           ServiceInterface
 	storageGetter StorageGetter
+	placeholders  *Placeholders
 }
 
-// HandleServe serves the full-size photo
+// HandleServe serves the variant selected by the size query parameter
+// (small, medium, large or original), defaulting to the full-size photo.
 func (h *ServePhotoHandler) HandleServe(w http.ResponseWriter, r *http.Request) {
-	h.servePhoto(w, r, false)
+	variant, err := ParsePhotoVariant(r.URL.Query().Get("size"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	h.servePhoto(w, r, variant)
 }
 
-// HandleServeThumbnail serves the thumbnail
+// HandleServeThumbnail serves the medium thumbnail
 func (h *ServePhotoHandler) HandleServeThumbnail(w http.ResponseWriter, r *http.Request) {
-	h.servePhoto(w, r, true)
+	h.servePhoto(w, r, PhotoVariantMedium)
 }
 
-func (h *ServePhotoHandler) servePhoto(w http.ResponseWriter, r *http.Request, thumbnail bool) {
+func (h *ServePhotoHandler) servePhoto(w http.ResponseWriter, r *http.Request, variant PhotoVariant) {
 	ctx := r.Context()
 
 	// Get workspace from context (for authorization)
@@ -816,27 +998,35 @@ func (h *ServePhotoHandler) servePhoto(w http.ResponseWriter, r *http.Request, t
 		return
 	}
 
-	// Get storage path
-	storagePath := photo.StoragePath
-	if thumbnail {
-		storagePath = photo.GetBestThumbnail()
-		if storagePath == "" {
-			// No thumbnail available, fall back to original
-			storagePath = photo.StoragePath
-		}
-	}
-
 	// Get file from storage
 	storage := h.storageGetter.GetStorage()
-	reader, err := storage.Get(ctx, storagePath)
+	storagePath := photo.StoragePath
+	exact := true
+	var reader io.ReadCloser
+	if variant != PhotoVariantOriginal {
+		reader, storagePath, exact, err = h.openThumbnail(ctx, storage, photo, variant)
+	} else {
+		reader, err = storage.Get(ctx, storagePath)
+	}
 	if err != nil {
+		if variant != PhotoVariantOriginal {
+			h.servePlaceholder(w, photo)
+			return
+		}
 		http.Error(w, "photo file not found", http.StatusNotFound)
 		return
 	}
 	defer reader.Close()
 
-	// Set content type
+	// Set content type. Thumbnails are re-encoded in the configured
+	// thumbnail format, so their type follows the thumbnail's extension
+	// rather than the original upload's.
 	mimeType := photo.MimeType
+	if storagePath != photo.StoragePath {
+		if thumbType := mime.TypeByExtension(strings.ToLower(filepath.Ext(storagePath))); thumbType != "" {
+			mimeType = thumbType
+		}
+	}
 	if mimeType == "" {
 		// Fallback: detect from extension
 		ext := strings.ToLower(filepath.Ext(photo.Filename))
@@ -847,8 +1037,14 @@ func (h *ServePhotoHandler) servePhoto(w http.ResponseWriter, r *http.Request, t
 	}
 	w.Header().Set(headerContentType, mimeType)
 
-	// Set cache headers (1 year)
-	w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	// Stored variants never change, so cache them for a year. A substitute
+	// for a size that does not exist yet is only cached briefly, so clients
+	// pick up the real one once it has been generated.
+	if exact {
+		w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	} else {
+		w.Header().Set("Cache-Control", "public, max-age=300")
+	}
 
 	// Security headers for serving user-uploaded content
 	w.Header().Set("Content-Disposition", fmt.Sprintf("inline; filename=%q", sanitizeUploadFilename(photo.Filename)))
@@ -859,12 +1055,60 @@ func (h *ServePhotoHandler) servePhoto(w http.ResponseWriter, r *http.Request, t
 	io.Copy(w, reader)
 }
 
+// servePlaceholder answers a thumbnail request that has no image to serve
+// with the placeholder for the photo's thumbnail status. It is never cached,
+// and X-Thumbnail-Placeholder ("pending" or "failed") tells clients it is
+// not the photo.
+func (h *ServePhotoHandler) servePlaceholder(w http.ResponseWriter, photo *ItemPhoto) {
+	placeholder, kind := h.placeholders.For(photo)
+	w.Header().Set(headerContentType, placeholder.ContentType)
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("X-Thumbnail-Placeholder", kind)
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; img-src 'self'; style-src 'unsafe-inline'")
+	w.WriteHeader(http.StatusOK)
+	w.Write(placeholder.Data)
+}
+
+// openThumbnail opens the photo's thumbnail for variant and returns its
+// storage path and whether it is the requested size (see
+// GetVariantThumbnail). A thumbnail that was never made, failed or whose file
+// is gone is generated on demand; when that is not possible the original is
+// served instead, and failing that a placeholder (see servePlaceholder).
+// Photos still queued for the background job are left to it.
+func (h *ServePhotoHandler) openThumbnail(ctx context.Context, storage Storage, photo *ItemPhoto, variant PhotoVariant) (io.ReadCloser, string, bool, error) {
+	if path, exact := photo.GetVariantThumbnail(variant); path != "" {
+		if reader, err := storage.Get(ctx, path); err == nil {
+			return reader, path, exact, nil
+		}
+	}
+
+	if !photo.IsThumbnailPending() {
+		path, err := h.svc.GenerateThumbnail(ctx, photo)
+		if err == nil {
+			// path is the medium thumbnail; the other sizes are set on photo.
+			exact := variant == PhotoVariantMedium
+			if p, ok := photo.GetVariantThumbnail(variant); ok && !exact {
+				path, exact = p, true
+			}
+			if reader, err := storage.Get(ctx, path); err == nil {
+				return reader, path, exact, nil
+			}
+		} else if !errors.Is(err, ErrThumbnailGenerationUnavailable) {
+			log.Printf("On-demand thumbnail generation for photo %s failed: %v", photo.ID, err)
+		}
+	}
+
+	reader, err := storage.Get(ctx, photo.StoragePath)
+	return reader, photo.StoragePath, false, err
+}
+
 // Helper function to convert entity to response
-func toPhotoResponse(p *ItemPhoto, urlGenerator PhotoURLGenerator) PhotoResponse {
+func toPhotoResponse(ctx context.Context, p *ItemPhoto, urlGenerator PhotoURLGenerator) PhotoResponse {
 	return PhotoResponse{
 		ID:              p.ID,
 		ItemID:          p.ItemID,
 		WorkspaceID:     p.WorkspaceID,
+		InventoryID:     p.InventoryID,
 		Filename:        p.Filename,
 		FileSize:        p.FileSize,
 		MimeType:        p.MimeType,
@@ -873,9 +1117,10 @@ func toPhotoResponse(p *ItemPhoto, urlGenerator PhotoURLGenerator) PhotoResponse
 		DisplayOrder:    p.DisplayOrder,
 		IsPrimary:       p.IsPrimary,
 		Caption:         p.Caption,
-		URL:             urlGenerator(p.WorkspaceID, p.ItemID, p.ID, false),
-		ThumbnailURL:    urlGenerator(p.WorkspaceID, p.ItemID, p.ID, true),
+		URL:             urlGenerator(ctx, p.WorkspaceID, p.ItemID, p.ID, false),
+		ThumbnailURL:    urlGenerator(ctx, p.WorkspaceID, p.ItemID, p.ID, true),
 		ThumbnailStatus: string(p.ThumbnailStatus),
+		BlurHash:        p.BlurHash,
 		CreatedAt:       p.CreatedAt,
 		UpdatedAt:       p.UpdatedAt,
 	}
@@ -887,6 +1132,10 @@ type ListPhotosInput struct {
 	ItemID uuid.UUID `path:"item_id"`
 }
 
+type ListInventoryPhotosInput struct {
+	InventoryID uuid.UUID `path:"inventory_id"`
+}
+
 type ListPhotosOutput struct {
 	Body PhotoListResponse
 }
@@ -925,21 +1174,42 @@ type ReorderPhotosInput struct {
 	}
 }
 
+type RegenerateThumbnailsInput struct {
+	ItemID  uuid.UUID `path:"item_id"`
+	PhotoID uuid.UUID `path:"photo_id"`
+}
+
+type MovePhotoInput struct {
+	ID   uuid.UUID `path:"id"`
+	Body struct {
+		BeforePhotoID *uuid.UUID `json:"before_photo_id,omitempty" doc:"Photo to place this one before; omit to move it to the end"`
+	}
+}
+
+type MovePhotosToItemInput struct {
+	ItemID uuid.UUID `path:"item_id" doc:"Item to move the photos to"`
+	Body   struct {
+		PhotoIDs []uuid.UUID `json:"photo_ids" minItems:"1" maxItems:"100" doc:"Photos to move, in the order they are appended to the item's gallery"`
+	}
+}
+
 type PhotoResponse struct {
-	ID              uuid.UUID `json:"id"`
-	ItemID          uuid.UUID `json:"item_id"`
-	WorkspaceID     uuid.UUID `json:"workspace_id"`
-	Filename        string    `json:"filename"`
-	FileSize        int64     `json:"file_size"`
-	MimeType        string    `json:"mime_type"`
-	Width           int32     `json:"width"`
-	Height          int32     `json:"height"`
-	DisplayOrder    int32     `json:"display_order"`
-	IsPrimary       bool      `json:"is_primary"`
-	Caption         *string   `json:"caption,omitempty"`
-	URL             string    `json:"url" doc:"Full-size photo URL"`
-	ThumbnailURL    string    `json:"thumbnail_url" doc:"Thumbnail photo URL"`
-	ThumbnailStatus string    `json:"thumbnail_status" doc:"Thumbnail processing status: pending|processing|complete|failed"`
-	CreatedAt       time.Time `json:"created_at"`
-	UpdatedAt       time.Time `json:"updated_at"`
+	ID              uuid.UUID  `json:"id"`
+	ItemID          uuid.UUID  `json:"item_id"`
+	WorkspaceID     uuid.UUID  `json:"workspace_id"`
+	InventoryID     *uuid.UUID `json:"inventory_id,omitempty" doc:"Inventory record the photo shows, when it is of one specific unit"`
+	Filename        string     `json:"filename"`
+	FileSize        int64      `json:"file_size"`
+	MimeType        string     `json:"mime_type"`
+	Width           int32      `json:"width"`
+	Height          int32      `json:"height"`
+	DisplayOrder    int32      `json:"display_order"`
+	IsPrimary       bool       `json:"is_primary"`
+	Caption         *string    `json:"caption,omitempty"`
+	URL             string     `json:"url" doc:"Full-size photo URL"`
+	ThumbnailURL    string     `json:"thumbnail_url" doc:"Thumbnail photo URL"`
+	ThumbnailStatus string     `json:"thumbnail_status" doc:"Thumbnail processing status: pending|processing|complete|failed"`
+	BlurHash        *string    `json:"blurhash,omitempty" doc:"BlurHash placeholder to render while the thumbnail loads"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
 }
@@ -29,8 +29,8 @@ type MockService struct {
 	mock.Mock
 }
 
-func (m *MockService) UploadPhoto(ctx context.Context, itemID, workspaceID, userID uuid.UUID, file multipart.File, header *multipart.FileHeader, caption *string) (*itemphoto.ItemPhoto, error) {
-	args := m.Called(ctx, itemID, workspaceID, userID, file, header, caption)
+func (m *MockService) UploadPhoto(ctx context.Context, itemID, workspaceID, userID uuid.UUID, inventoryID *uuid.UUID, file multipart.File, header *multipart.FileHeader, caption *string) (*itemphoto.ItemPhoto, error) {
+	args := m.Called(ctx, itemID, workspaceID, userID, inventoryID, file, header, caption)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -45,6 +45,14 @@ func (m *MockService) ListPhotos(ctx context.Context, itemID, workspaceID uuid.U
 	return args.Get(0).([]*itemphoto.ItemPhoto), args.Error(1)
 }
 
+func (m *MockService) ListInventoryPhotos(ctx context.Context, inventoryID, workspaceID uuid.UUID) ([]*itemphoto.ItemPhoto, error) {
+	args := m.Called(ctx, inventoryID, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*itemphoto.ItemPhoto), args.Error(1)
+}
+
 func (m *MockService) GetPhoto(ctx context.Context, id uuid.UUID) (*itemphoto.ItemPhoto, error) {
 	args := m.Called(ctx, id)
 	if args.Get(0) == nil {
@@ -68,6 +76,16 @@ func (m *MockService) ReorderPhotos(ctx context.Context, itemID, workspaceID uui
 	return args.Error(0)
 }
 
+func (m *MockService) MovePhoto(ctx context.Context, photoID, workspaceID uuid.UUID, beforePhotoID *uuid.UUID) error {
+	args := m.Called(ctx, photoID, workspaceID, beforePhotoID)
+	return args.Error(0)
+}
+
+func (m *MockService) MovePhotosToItem(ctx context.Context, workspaceID uuid.UUID, photoIDs []uuid.UUID, targetItemID uuid.UUID) error {
+	args := m.Called(ctx, workspaceID, photoIDs, targetItemID)
+	return args.Error(0)
+}
+
 func (m *MockService) DeletePhoto(ctx context.Context, id, workspaceID uuid.UUID) error {
 	args := m.Called(ctx, id, workspaceID)
 	return args.Error(0)
@@ -110,6 +128,43 @@ func (m *MockService) CheckDuplicates(ctx context.Context, workspaceID uuid.UUID
 	return args.Get(0).([]itemphoto.DuplicateCandidate), args.Error(1)
 }
 
+func (m *MockService) GenerateThumbnail(ctx context.Context, photo *itemphoto.ItemPhoto) (string, error) {
+	args := m.Called(ctx, photo)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockService) RegenerateThumbnails(ctx context.Context, itemID, photoID, workspaceID uuid.UUID) (*itemphoto.ItemPhoto, error) {
+	args := m.Called(ctx, itemID, photoID, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*itemphoto.ItemPhoto), args.Error(1)
+}
+
+func (m *MockService) GetSettings(ctx context.Context, workspaceID uuid.UUID) (*itemphoto.Settings, error) {
+	args := m.Called(ctx, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*itemphoto.Settings), args.Error(1)
+}
+
+func (m *MockService) UpdateSettings(ctx context.Context, workspaceID uuid.UUID, settings itemphoto.Settings) (*itemphoto.Settings, error) {
+	args := m.Called(ctx, workspaceID, settings)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*itemphoto.Settings), args.Error(1)
+}
+
+func (m *MockService) ListIncompleteItems(ctx context.Context, workspaceID uuid.UUID) (*itemphoto.IncompleteItemsReport, error) {
+	args := m.Called(ctx, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*itemphoto.IncompleteItemsReport), args.Error(1)
+}
+
 func (m *MockService) GetPrimary(ctx context.Context, itemID, workspaceID uuid.UUID) (*itemphoto.ItemPhoto, error) {
 	args := m.Called(ctx, itemID, workspaceID)
 	if args.Get(0) == nil {
@@ -154,7 +209,7 @@ func TestPhotoHandler_PhotoResponseIncludesThumbnailStatus(t *testing.T) {
 	setup := testutil.NewHandlerTestSetup()
 	mockSvc := new(MockService)
 
-	urlGen := func(workspaceID, itemID, photoID uuid.UUID, isThumbnail bool) string {
+	urlGen := func(_ context.Context, workspaceID, itemID, photoID uuid.UUID, isThumbnail bool) string {
 		return fmt.Sprintf("/photos/%s", photoID)
 	}
 
@@ -198,7 +253,7 @@ func TestPhotoHandler_ListPhotos(t *testing.T) {
 	setup := testutil.NewHandlerTestSetup()
 	mockSvc := new(MockService)
 
-	urlGen := func(workspaceID, itemID, photoID uuid.UUID, isThumbnail bool) string {
+	urlGen := func(_ context.Context, workspaceID, itemID, photoID uuid.UUID, isThumbnail bool) string {
 		if isThumbnail {
 			return fmt.Sprintf("/workspaces/%s/items/%s/photos/%s/thumbnail", workspaceID, itemID, photoID)
 		}
@@ -238,11 +293,51 @@ func TestPhotoHandler_ListPhotos(t *testing.T) {
 	})
 }
 
+func TestPhotoHandler_ListInventoryPhotos(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+
+	urlGen := func(_ context.Context, workspaceID, itemID, photoID uuid.UUID, isThumbnail bool) string {
+		return fmt.Sprintf("/photos/%s", photoID)
+	}
+
+	itemphoto.RegisterRoutes(setup.API, mockSvc, nil, urlGen)
+
+	t.Run("lists photos for an inventory record", func(t *testing.T) {
+		inventoryID := uuid.New()
+		photo := createTestPhoto(uuid.New())
+		photo.InventoryID = &inventoryID
+
+		mockSvc.On("ListInventoryPhotos", mock.Anything, inventoryID, setup.WorkspaceID).
+			Return([]*itemphoto.ItemPhoto{photo}, nil).Once()
+
+		rec := setup.Get(fmt.Sprintf("/inventory/%s/photos", inventoryID))
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		var body itemphoto.PhotoListResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		require.Len(t, body.Items, 1)
+		assert.Equal(t, &inventoryID, body.Items[0].InventoryID)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("returns 500 on service error", func(t *testing.T) {
+		inventoryID := uuid.New()
+
+		mockSvc.On("ListInventoryPhotos", mock.Anything, inventoryID, setup.WorkspaceID).
+			Return(nil, errors.New("database error")).Once()
+
+		rec := setup.Get(fmt.Sprintf("/inventory/%s/photos", inventoryID))
+
+		testutil.AssertStatus(t, rec, http.StatusInternalServerError)
+	})
+}
+
 func TestPhotoHandler_GetPhoto(t *testing.T) {
 	setup := testutil.NewHandlerTestSetup()
 	mockSvc := new(MockService)
 
-	urlGen := func(workspaceID, itemID, photoID uuid.UUID, isThumbnail bool) string {
+	urlGen := func(_ context.Context, workspaceID, itemID, photoID uuid.UUID, isThumbnail bool) string {
 		return fmt.Sprintf("/photos/%s", photoID)
 	}
 
@@ -292,7 +387,7 @@ func TestPhotoHandler_SetPrimary(t *testing.T) {
 	setup := testutil.NewHandlerTestSetup()
 	mockSvc := new(MockService)
 
-	urlGen := func(workspaceID, itemID, photoID uuid.UUID, isThumbnail bool) string {
+	urlGen := func(_ context.Context, workspaceID, itemID, photoID uuid.UUID, isThumbnail bool) string {
 		return fmt.Sprintf("/photos/%s", photoID)
 	}
 
@@ -339,7 +434,7 @@ func TestPhotoHandler_UpdateCaption(t *testing.T) {
 	setup := testutil.NewHandlerTestSetup()
 	mockSvc := new(MockService)
 
-	urlGen := func(workspaceID, itemID, photoID uuid.UUID, isThumbnail bool) string {
+	urlGen := func(_ context.Context, workspaceID, itemID, photoID uuid.UUID, isThumbnail bool) string {
 		return fmt.Sprintf("/photos/%s", photoID)
 	}
 
@@ -388,7 +483,7 @@ func TestPhotoHandler_ReorderPhotos(t *testing.T) {
 	setup := testutil.NewHandlerTestSetup()
 	mockSvc := new(MockService)
 
-	urlGen := func(workspaceID, itemID, photoID uuid.UUID, isThumbnail bool) string {
+	urlGen := func(_ context.Context, workspaceID, itemID, photoID uuid.UUID, isThumbnail bool) string {
 		return fmt.Sprintf("/items/%s/photos/%s", itemID, photoID)
 	}
 
@@ -426,11 +521,131 @@ func TestPhotoHandler_ReorderPhotos(t *testing.T) {
 	})
 }
 
+func TestPhotoHandler_MovePhoto(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+
+	urlGen := func(_ context.Context, workspaceID, itemID, photoID uuid.UUID, isThumbnail bool) string {
+		return fmt.Sprintf("/photos/%s", photoID)
+	}
+
+	itemphoto.RegisterRoutes(setup.API, mockSvc, nil, urlGen)
+
+	t.Run("moves photo before another", func(t *testing.T) {
+		photoID := uuid.New()
+		beforeID := uuid.New()
+
+		mockSvc.On("MovePhoto", mock.Anything, photoID, setup.WorkspaceID, &beforeID).
+			Return(nil).Once()
+
+		rec := setup.Put(fmt.Sprintf("/photos/%s/position", photoID),
+			fmt.Sprintf(`{"before_photo_id":"%s"}`, beforeID))
+
+		testutil.AssertStatus(t, rec, http.StatusNoContent)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("moves photo to the end without before_photo_id", func(t *testing.T) {
+		photoID := uuid.New()
+
+		mockSvc.On("MovePhoto", mock.Anything, photoID, setup.WorkspaceID, (*uuid.UUID)(nil)).
+			Return(nil).Once()
+
+		rec := setup.Put(fmt.Sprintf("/photos/%s/position", photoID), `{}`)
+
+		testutil.AssertStatus(t, rec, http.StatusNoContent)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("returns 400 when the target is not in the gallery", func(t *testing.T) {
+		photoID := uuid.New()
+		beforeID := uuid.New()
+
+		mockSvc.On("MovePhoto", mock.Anything, photoID, setup.WorkspaceID, &beforeID).
+			Return(itemphoto.ErrInvalidDisplayOrder).Once()
+
+		rec := setup.Put(fmt.Sprintf("/photos/%s/position", photoID),
+			fmt.Sprintf(`{"before_photo_id":"%s"}`, beforeID))
+
+		testutil.AssertStatus(t, rec, http.StatusBadRequest)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("returns 404 when photo not found", func(t *testing.T) {
+		photoID := uuid.New()
+
+		mockSvc.On("MovePhoto", mock.Anything, photoID, setup.WorkspaceID, (*uuid.UUID)(nil)).
+			Return(itemphoto.ErrPhotoNotFound).Once()
+
+		rec := setup.Put(fmt.Sprintf("/photos/%s/position", photoID), `{}`)
+
+		testutil.AssertStatus(t, rec, http.StatusNotFound)
+		mockSvc.AssertExpectations(t)
+	})
+}
+
+func TestPhotoHandler_MovePhotosToItem(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+
+	urlGen := func(_ context.Context, workspaceID, itemID, photoID uuid.UUID, isThumbnail bool) string {
+		return fmt.Sprintf("/photos/%s", photoID)
+	}
+
+	itemphoto.RegisterRoutes(setup.API, mockSvc, nil, urlGen)
+
+	t.Run("moves photos to the item", func(t *testing.T) {
+		itemID := uuid.New()
+		photoIDs := []uuid.UUID{uuid.New(), uuid.New()}
+
+		mockSvc.On("MovePhotosToItem", mock.Anything, setup.WorkspaceID, photoIDs, itemID).
+			Return(nil).Once()
+
+		rec := setup.Post(fmt.Sprintf("/items/%s/photos/move", itemID),
+			fmt.Sprintf(`{"photo_ids":["%s","%s"]}`, photoIDs[0], photoIDs[1]))
+
+		testutil.AssertStatus(t, rec, http.StatusNoContent)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("returns 404 when the item does not exist", func(t *testing.T) {
+		itemID := uuid.New()
+		photoID := uuid.New()
+
+		mockSvc.On("MovePhotosToItem", mock.Anything, setup.WorkspaceID, []uuid.UUID{photoID}, itemID).
+			Return(itemphoto.ErrItemNotFound).Once()
+
+		rec := setup.Post(fmt.Sprintf("/items/%s/photos/move", itemID), fmt.Sprintf(`{"photo_ids":["%s"]}`, photoID))
+
+		testutil.AssertStatus(t, rec, http.StatusNotFound)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("returns 409 when the item would exceed the photo limit", func(t *testing.T) {
+		itemID := uuid.New()
+		photoID := uuid.New()
+
+		mockSvc.On("MovePhotosToItem", mock.Anything, setup.WorkspaceID, []uuid.UUID{photoID}, itemID).
+			Return(&itemphoto.PhotoLimitError{Current: 20, Limit: 20}).Once()
+
+		rec := setup.Post(fmt.Sprintf("/items/%s/photos/move", itemID), fmt.Sprintf(`{"photo_ids":["%s"]}`, photoID))
+
+		testutil.AssertStatus(t, rec, http.StatusConflict)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("rejects an empty photo list", func(t *testing.T) {
+		rec := setup.Post(fmt.Sprintf("/items/%s/photos/move", uuid.New()), `{"photo_ids":[]}`)
+
+		testutil.AssertStatus(t, rec, http.StatusUnprocessableEntity)
+	})
+}
+
 func TestPhotoHandler_DeletePhoto(t *testing.T) {
 	setup := testutil.NewHandlerTestSetup()
 	mockSvc := new(MockService)
 
-	urlGen := func(workspaceID, itemID, photoID uuid.UUID, isThumbnail bool) string {
+	urlGen := func(_ context.Context, workspaceID, itemID, photoID uuid.UUID, isThumbnail bool) string {
 		return fmt.Sprintf("/photos/%s", photoID)
 	}
 
@@ -563,11 +778,87 @@ func createChiRequest(method, path string, body io.Reader, workspaceID, userID u
 	return req.WithContext(ctx)
 }
 
+func TestPhotoHandler_RegenerateThumbnails(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+
+	urlGen := func(_ context.Context, workspaceID, itemID, photoID uuid.UUID, isThumbnail bool) string {
+		return fmt.Sprintf("/photos/%s", photoID)
+	}
+
+	itemphoto.RegisterRoutes(setup.API, mockSvc, nil, urlGen)
+
+	path := func(itemID, photoID uuid.UUID) string {
+		return fmt.Sprintf("/items/%s/photos/%s/regenerate", itemID, photoID)
+	}
+
+	t.Run("returns the photo in processing state", func(t *testing.T) {
+		itemID := uuid.New()
+		photo := createTestPhoto(itemID)
+		photo.ThumbnailStatus = itemphoto.ThumbnailStatusProcessing
+
+		mockSvc.On("RegenerateThumbnails", mock.Anything, itemID, photo.ID, setup.WorkspaceID).
+			Return(photo, nil).Once()
+
+		rec := setup.Post(path(itemID, photo.ID), "")
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		assert.Contains(t, rec.Body.String(), `"thumbnail_status":"processing"`)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("members can regenerate", func(t *testing.T) {
+		setup.SetRole("member")
+		defer setup.SetRole("owner")
+		itemID := uuid.New()
+		photo := createTestPhoto(itemID)
+
+		mockSvc.On("RegenerateThumbnails", mock.Anything, itemID, photo.ID, setup.WorkspaceID).
+			Return(photo, nil).Once()
+
+		rec := setup.Post(path(itemID, photo.ID), "")
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("viewers cannot regenerate", func(t *testing.T) {
+		setup.SetRole("viewer")
+		defer setup.SetRole("owner")
+
+		rec := setup.Post(path(uuid.New(), uuid.New()), "")
+
+		testutil.AssertStatus(t, rec, http.StatusForbidden)
+	})
+
+	t.Run("returns 404 when photo not found", func(t *testing.T) {
+		itemID, photoID := uuid.New(), uuid.New()
+
+		mockSvc.On("RegenerateThumbnails", mock.Anything, itemID, photoID, setup.WorkspaceID).
+			Return(nil, itemphoto.ErrPhotoNotFound).Once()
+
+		rec := setup.Post(path(itemID, photoID), "")
+
+		testutil.AssertStatus(t, rec, http.StatusNotFound)
+	})
+
+	t.Run("returns 503 without a thumbnail queue", func(t *testing.T) {
+		itemID, photoID := uuid.New(), uuid.New()
+
+		mockSvc.On("RegenerateThumbnails", mock.Anything, itemID, photoID, setup.WorkspaceID).
+			Return(nil, itemphoto.ErrThumbnailRegenerationUnavailable).Once()
+
+		rec := setup.Post(path(itemID, photoID), "")
+
+		testutil.AssertStatus(t, rec, http.StatusServiceUnavailable)
+	})
+}
+
 func TestBulkPhotoHandler_HandleBulkDelete(t *testing.T) {
 	workspaceID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
 	userID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
 
-	urlGen := func(wsID, itemID, photoID uuid.UUID, isThumbnail bool) string {
+	urlGen := func(_ context.Context, wsID, itemID, photoID uuid.UUID, isThumbnail bool) string {
 		return fmt.Sprintf("/photos/%s", photoID)
 	}
 
@@ -633,7 +924,7 @@ func TestBulkPhotoHandler_HandleBulkCaption(t *testing.T) {
 	workspaceID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
 	userID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
 
-	urlGen := func(wsID, itemID, photoID uuid.UUID, isThumbnail bool) string {
+	urlGen := func(_ context.Context, wsID, itemID, photoID uuid.UUID, isThumbnail bool) string {
 		return fmt.Sprintf("/photos/%s", photoID)
 	}
 
@@ -677,7 +968,7 @@ func TestBulkPhotoHandler_HandleDownload(t *testing.T) {
 	workspaceID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
 	userID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
 
-	urlGen := func(wsID, itemID, photoID uuid.UUID, isThumbnail bool) string {
+	urlGen := func(_ context.Context, wsID, itemID, photoID uuid.UUID, isThumbnail bool) string {
 		return fmt.Sprintf("/photos/%s", photoID)
 	}
 
@@ -753,7 +1044,7 @@ func TestBulkPhotoHandler_HandleCheckDuplicate(t *testing.T) {
 	workspaceID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
 	userID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
 
-	urlGen := func(wsID, itemID, photoID uuid.UUID, isThumbnail bool) string {
+	urlGen := func(_ context.Context, wsID, itemID, photoID uuid.UUID, isThumbnail bool) string {
 		return fmt.Sprintf("/photos/%s/thumbnail", photoID)
 	}
 
@@ -850,13 +1141,35 @@ func TestServePhotoHandler_HandleServe(t *testing.T) {
 		assert.Equal(t, http.StatusNotFound, rr.Code)
 		mockSvc.AssertExpectations(t)
 	})
-}
 
-func TestServePhotoHandler_HandleServeThumbnail(t *testing.T) {
-	workspaceID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
-	userID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	t.Run("serves the requested size", func(t *testing.T) {
+		mockSvc := new(MockService)
+		mockStorage := new(HandlerMockStorage)
+		storageGetter := &MockStorageGetter{storage: mockStorage}
 
-	t.Run("serves thumbnail successfully", func(t *testing.T) {
+		itemID := uuid.New()
+		photo := createTestPhoto(itemID)
+		photo.WorkspaceID = workspaceID
+		large := "thumbs/large.webp"
+		photo.ThumbnailLargePath = &large
+
+		req := createChiRequest("GET", "/items/"+itemID.String()+"/photos/"+photo.ID.String()+"?size=large",
+			nil, workspaceID, userID)
+
+		mockSvc.On("GetPhoto", mock.Anything, photo.ID).Return(photo, nil).Once()
+		mockStorage.On("Get", mock.Anything, large).
+			Return(io.NopCloser(strings.NewReader("large thumbnail")), nil).Once()
+
+		rr := executeServeHandlerRequest(t, mockSvc, storageGetter, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "image/webp", rr.Header().Get("Content-Type"))
+		assert.Equal(t, "public, max-age=31536000, immutable", rr.Header().Get("Cache-Control"))
+		assert.Equal(t, "large thumbnail", rr.Body.String())
+		mockStorage.AssertExpectations(t)
+	})
+
+	t.Run("falls back to the next-best size with a short cache", func(t *testing.T) {
 		mockSvc := new(MockService)
 		mockStorage := new(HandlerMockStorage)
 		storageGetter := &MockStorageGetter{storage: mockStorage}
@@ -865,7 +1178,7 @@ func TestServePhotoHandler_HandleServeThumbnail(t *testing.T) {
 		photo := createTestPhoto(itemID)
 		photo.WorkspaceID = workspaceID
 
-		req := createChiRequest("GET", "/items/"+itemID.String()+"/photos/"+photo.ID.String()+"/thumbnail",
+		req := createChiRequest("GET", "/items/"+itemID.String()+"/photos/"+photo.ID.String()+"?size=small",
 			nil, workspaceID, userID)
 
 		mockSvc.On("GetPhoto", mock.Anything, photo.ID).Return(photo, nil).Once()
@@ -875,55 +1188,459 @@ func TestServePhotoHandler_HandleServeThumbnail(t *testing.T) {
 		rr := executeServeHandlerRequest(t, mockSvc, storageGetter, req)
 
 		assert.Equal(t, http.StatusOK, rr.Code)
-		mockSvc.AssertExpectations(t)
+		assert.Equal(t, "public, max-age=300", rr.Header().Get("Cache-Control"))
 		mockStorage.AssertExpectations(t)
 	})
-}
-
-func TestUploadHandler_HandleUpload(t *testing.T) {
-	workspaceID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
-	userID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
-
-	urlGen := func(wsID, itemID, photoID uuid.UUID, isThumbnail bool) string {
-		return fmt.Sprintf("/photos/%s", photoID)
-	}
 
-	t.Run("uploads photo successfully", func(t *testing.T) {
+	t.Run("generates a missing size on demand", func(t *testing.T) {
 		mockSvc := new(MockService)
+		mockStorage := new(HandlerMockStorage)
+		storageGetter := &MockStorageGetter{storage: mockStorage}
 
 		itemID := uuid.New()
 		photo := createTestPhoto(itemID)
 		photo.WorkspaceID = workspaceID
+		photo.ThumbnailPath = ""
+		photo.ThumbnailStatus = itemphoto.ThumbnailStatusFailed
 
-		// Create multipart form
-		body := &bytes.Buffer{}
-		writer := multipart.NewWriter(body)
-		part, _ := writer.CreateFormFile("photo", "test.jpg")
-		part.Write([]byte("fake jpeg content"))
-		writer.Close()
-
-		req := createChiRequest("POST", "/items/"+itemID.String()+"/photos",
-			body, workspaceID, userID)
-		req.Header.Set("Content-Type", writer.FormDataContentType())
+		req := createChiRequest("GET", "/items/"+itemID.String()+"/photos/"+photo.ID.String()+"?size=small",
+			nil, workspaceID, userID)
 
-		mockSvc.On("UploadPhoto", mock.Anything, itemID, workspaceID, userID, mock.Anything, mock.Anything, mock.Anything).
-			Return(photo, nil).Once()
+		small := "thumbs/small.webp"
+		mockSvc.On("GetPhoto", mock.Anything, photo.ID).Return(photo, nil).Once()
+		mockSvc.On("GenerateThumbnail", mock.Anything, photo).
+			Run(func(args mock.Arguments) {
+				args.Get(1).(*itemphoto.ItemPhoto).ThumbnailSmallPath = &small
+			}).
+			Return("thumbs/medium.webp", nil).Once()
+		mockStorage.On("Get", mock.Anything, small).
+			Return(io.NopCloser(strings.NewReader("small thumbnail")), nil).Once()
 
-		rr := executeUploadHandlerRequest(t, mockSvc, urlGen, req)
+		rr := executeServeHandlerRequest(t, mockSvc, storageGetter, req)
 
-		assert.Equal(t, http.StatusCreated, rr.Code)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "public, max-age=31536000, immutable", rr.Header().Get("Cache-Control"))
+		assert.Equal(t, "small thumbnail", rr.Body.String())
 		mockSvc.AssertExpectations(t)
+		mockStorage.AssertExpectations(t)
 	})
 
-	t.Run("returns 400 for missing photo file", func(t *testing.T) {
+	t.Run("serves the original for size=original", func(t *testing.T) {
 		mockSvc := new(MockService)
+		mockStorage := new(HandlerMockStorage)
+		storageGetter := &MockStorageGetter{storage: mockStorage}
 
 		itemID := uuid.New()
+		photo := createTestPhoto(itemID)
+		photo.WorkspaceID = workspaceID
 
-		// Create multipart form without photo
-		body := &bytes.Buffer{}
-		writer := multipart.NewWriter(body)
-		writer.WriteField("caption", "test")
+		req := createChiRequest("GET", "/items/"+itemID.String()+"/photos/"+photo.ID.String()+"?size=original",
+			nil, workspaceID, userID)
+
+		mockSvc.On("GetPhoto", mock.Anything, photo.ID).Return(photo, nil).Once()
+		mockStorage.On("Get", mock.Anything, photo.StoragePath).
+			Return(io.NopCloser(strings.NewReader("original")), nil).Once()
+
+		rr := executeServeHandlerRequest(t, mockSvc, storageGetter, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "image/jpeg", rr.Header().Get("Content-Type"))
+		mockStorage.AssertExpectations(t)
+	})
+
+	t.Run("rejects an unknown size", func(t *testing.T) {
+		mockSvc := new(MockService)
+		storageGetter := &MockStorageGetter{storage: new(HandlerMockStorage)}
+
+		itemID := uuid.New()
+		photoID := uuid.New()
+
+		req := createChiRequest("GET", "/items/"+itemID.String()+"/photos/"+photoID.String()+"?size=huge",
+			nil, workspaceID, userID)
+
+		rr := executeServeHandlerRequest(t, mockSvc, storageGetter, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockSvc.AssertNotCalled(t, "GetPhoto", mock.Anything, mock.Anything)
+	})
+}
+
+func TestServePhotoHandler_HandleServeThumbnail(t *testing.T) {
+	workspaceID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+
+	t.Run("serves thumbnail successfully", func(t *testing.T) {
+		mockSvc := new(MockService)
+		mockStorage := new(HandlerMockStorage)
+		storageGetter := &MockStorageGetter{storage: mockStorage}
+
+		itemID := uuid.New()
+		photo := createTestPhoto(itemID)
+		photo.WorkspaceID = workspaceID
+
+		req := createChiRequest("GET", "/items/"+itemID.String()+"/photos/"+photo.ID.String()+"/thumbnail",
+			nil, workspaceID, userID)
+
+		mockSvc.On("GetPhoto", mock.Anything, photo.ID).Return(photo, nil).Once()
+		mockStorage.On("Get", mock.Anything, photo.ThumbnailPath).
+			Return(io.NopCloser(strings.NewReader("fake thumbnail data")), nil).Once()
+
+		rr := executeServeHandlerRequest(t, mockSvc, storageGetter, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockSvc.AssertExpectations(t)
+		mockStorage.AssertExpectations(t)
+	})
+
+	t.Run("generates a missing thumbnail and serves it", func(t *testing.T) {
+		mockSvc := new(MockService)
+		mockStorage := new(HandlerMockStorage)
+		storageGetter := &MockStorageGetter{storage: mockStorage}
+
+		itemID := uuid.New()
+		photo := createTestPhoto(itemID)
+		photo.WorkspaceID = workspaceID
+		photo.ThumbnailPath = ""
+		photo.ThumbnailStatus = itemphoto.ThumbnailStatusFailed
+
+		req := createChiRequest("GET", "/items/"+itemID.String()+"/photos/"+photo.ID.String()+"/thumbnail",
+			nil, workspaceID, userID)
+
+		mockSvc.On("GetPhoto", mock.Anything, photo.ID).Return(photo, nil).Once()
+		mockSvc.On("GenerateThumbnail", mock.Anything, photo).Return("thumbs/medium.webp", nil).Once()
+		mockStorage.On("Get", mock.Anything, "thumbs/medium.webp").
+			Return(io.NopCloser(strings.NewReader("generated thumbnail")), nil).Once()
+
+		rr := executeServeHandlerRequest(t, mockSvc, storageGetter, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "image/webp", rr.Header().Get("Content-Type"))
+		assert.Equal(t, "generated thumbnail", rr.Body.String())
+		mockSvc.AssertExpectations(t)
+		mockStorage.AssertExpectations(t)
+	})
+
+	t.Run("regenerates a thumbnail whose file is gone", func(t *testing.T) {
+		mockSvc := new(MockService)
+		mockStorage := new(HandlerMockStorage)
+		storageGetter := &MockStorageGetter{storage: mockStorage}
+
+		itemID := uuid.New()
+		photo := createTestPhoto(itemID)
+		photo.WorkspaceID = workspaceID
+		photo.ThumbnailStatus = itemphoto.ThumbnailStatusComplete
+
+		req := createChiRequest("GET", "/items/"+itemID.String()+"/photos/"+photo.ID.String()+"/thumbnail",
+			nil, workspaceID, userID)
+
+		mockSvc.On("GetPhoto", mock.Anything, photo.ID).Return(photo, nil).Once()
+		mockStorage.On("Get", mock.Anything, photo.ThumbnailPath).Return(nil, errors.New("file not found")).Once()
+		mockSvc.On("GenerateThumbnail", mock.Anything, photo).Return("thumbs/medium.webp", nil).Once()
+		mockStorage.On("Get", mock.Anything, "thumbs/medium.webp").
+			Return(io.NopCloser(strings.NewReader("generated thumbnail")), nil).Once()
+
+		rr := executeServeHandlerRequest(t, mockSvc, storageGetter, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockSvc.AssertExpectations(t)
+		mockStorage.AssertExpectations(t)
+	})
+
+	t.Run("serves the original when generation is unavailable", func(t *testing.T) {
+		mockSvc := new(MockService)
+		mockStorage := new(HandlerMockStorage)
+		storageGetter := &MockStorageGetter{storage: mockStorage}
+
+		itemID := uuid.New()
+		photo := createTestPhoto(itemID)
+		photo.WorkspaceID = workspaceID
+		photo.ThumbnailPath = ""
+		photo.ThumbnailStatus = itemphoto.ThumbnailStatusFailed
+
+		req := createChiRequest("GET", "/items/"+itemID.String()+"/photos/"+photo.ID.String()+"/thumbnail",
+			nil, workspaceID, userID)
+
+		mockSvc.On("GetPhoto", mock.Anything, photo.ID).Return(photo, nil).Once()
+		mockSvc.On("GenerateThumbnail", mock.Anything, photo).
+			Return("", itemphoto.ErrThumbnailGenerationUnavailable).Once()
+		mockStorage.On("Get", mock.Anything, photo.StoragePath).
+			Return(io.NopCloser(strings.NewReader("original")), nil).Once()
+
+		rr := executeServeHandlerRequest(t, mockSvc, storageGetter, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "image/jpeg", rr.Header().Get("Content-Type"))
+		mockSvc.AssertExpectations(t)
+		mockStorage.AssertExpectations(t)
+	})
+
+	t.Run("leaves queued photos to the background job", func(t *testing.T) {
+		mockSvc := new(MockService)
+		mockStorage := new(HandlerMockStorage)
+		storageGetter := &MockStorageGetter{storage: mockStorage}
+
+		itemID := uuid.New()
+		photo := createTestPhoto(itemID)
+		photo.WorkspaceID = workspaceID
+		photo.ThumbnailPath = ""
+
+		req := createChiRequest("GET", "/items/"+itemID.String()+"/photos/"+photo.ID.String()+"/thumbnail",
+			nil, workspaceID, userID)
+
+		mockSvc.On("GetPhoto", mock.Anything, photo.ID).Return(photo, nil).Once()
+		mockStorage.On("Get", mock.Anything, photo.StoragePath).
+			Return(io.NopCloser(strings.NewReader("original")), nil).Once()
+
+		rr := executeServeHandlerRequest(t, mockSvc, storageGetter, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		mockSvc.AssertNotCalled(t, "GenerateThumbnail", mock.Anything, mock.Anything)
+	})
+}
+
+func TestServePhotoHandler_Placeholder(t *testing.T) {
+	workspaceID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+	defaults := itemphoto.DefaultPlaceholders()
+
+	t.Run("serves the failed placeholder when nothing else can be served", func(t *testing.T) {
+		mockSvc := new(MockService)
+		mockStorage := new(HandlerMockStorage)
+		storageGetter := &MockStorageGetter{storage: mockStorage}
+
+		itemID := uuid.New()
+		photo := createTestPhoto(itemID)
+		photo.WorkspaceID = workspaceID
+		photo.ThumbnailPath = ""
+		photo.ThumbnailStatus = itemphoto.ThumbnailStatusFailed
+
+		req := createChiRequest("GET", "/items/"+itemID.String()+"/photos/"+photo.ID.String()+"/thumbnail",
+			nil, workspaceID, userID)
+
+		mockSvc.On("GetPhoto", mock.Anything, photo.ID).Return(photo, nil).Once()
+		mockSvc.On("GenerateThumbnail", mock.Anything, photo).
+			Return("", errors.New("corrupt image")).Once()
+		mockStorage.On("Get", mock.Anything, photo.StoragePath).
+			Return(nil, errors.New("file not found")).Once()
+
+		rr := executeServeHandlerRequest(t, mockSvc, storageGetter, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "image/svg+xml", rr.Header().Get("Content-Type"))
+		assert.Equal(t, "no-store", rr.Header().Get("Cache-Control"))
+		assert.Equal(t, "failed", rr.Header().Get("X-Thumbnail-Placeholder"))
+		assert.Equal(t, defaults.Failed.Data, rr.Body.Bytes())
+		mockSvc.AssertExpectations(t)
+		mockStorage.AssertExpectations(t)
+	})
+
+	t.Run("serves the pending placeholder for queued photos", func(t *testing.T) {
+		mockSvc := new(MockService)
+		mockStorage := new(HandlerMockStorage)
+		storageGetter := &MockStorageGetter{storage: mockStorage}
+
+		itemID := uuid.New()
+		photo := createTestPhoto(itemID)
+		photo.WorkspaceID = workspaceID
+		photo.ThumbnailPath = ""
+		photo.ThumbnailStatus = itemphoto.ThumbnailStatusProcessing
+
+		req := createChiRequest("GET", "/items/"+itemID.String()+"/photos/"+photo.ID.String()+"?size=small",
+			nil, workspaceID, userID)
+
+		mockSvc.On("GetPhoto", mock.Anything, photo.ID).Return(photo, nil).Once()
+		mockStorage.On("Get", mock.Anything, photo.StoragePath).
+			Return(nil, errors.New("file not found")).Once()
+
+		rr := executeServeHandlerRequest(t, mockSvc, storageGetter, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "pending", rr.Header().Get("X-Thumbnail-Placeholder"))
+		assert.Equal(t, defaults.Pending.Data, rr.Body.Bytes())
+		mockSvc.AssertNotCalled(t, "GenerateThumbnail", mock.Anything, mock.Anything)
+	})
+
+	t.Run("serves custom placeholders", func(t *testing.T) {
+		mockSvc := new(MockService)
+		mockStorage := new(HandlerMockStorage)
+		storageGetter := &MockStorageGetter{storage: mockStorage}
+		placeholders := &itemphoto.Placeholders{
+			Pending: itemphoto.Placeholder{Data: []byte("pending png"), ContentType: "image/png"},
+			Failed:  itemphoto.Placeholder{Data: []byte("failed png"), ContentType: "image/png"},
+		}
+
+		itemID := uuid.New()
+		photo := createTestPhoto(itemID)
+		photo.WorkspaceID = workspaceID
+		photo.ThumbnailStatus = itemphoto.ThumbnailStatusComplete
+
+		req := createChiRequest("GET", "/items/"+itemID.String()+"/photos/"+photo.ID.String()+"/thumbnail",
+			nil, workspaceID, userID)
+
+		mockSvc.On("GetPhoto", mock.Anything, photo.ID).Return(photo, nil).Once()
+		mockSvc.On("GenerateThumbnail", mock.Anything, photo).
+			Return("", itemphoto.ErrThumbnailGenerationUnavailable).Once()
+		mockStorage.On("Get", mock.Anything, mock.Anything).
+			Return(nil, errors.New("file not found"))
+
+		r := chi.NewRouter()
+		itemphoto.RegisterServeHandler(r, mockSvc, storageGetter, placeholders)
+		rr := httptest.NewRecorder()
+		r.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "image/png", rr.Header().Get("Content-Type"))
+		assert.Equal(t, "failed png", rr.Body.String())
+	})
+
+	t.Run("original photos still return 404", func(t *testing.T) {
+		mockSvc := new(MockService)
+		mockStorage := new(HandlerMockStorage)
+		storageGetter := &MockStorageGetter{storage: mockStorage}
+
+		itemID := uuid.New()
+		photo := createTestPhoto(itemID)
+		photo.WorkspaceID = workspaceID
+		photo.ThumbnailStatus = itemphoto.ThumbnailStatusFailed
+
+		req := createChiRequest("GET", "/items/"+itemID.String()+"/photos/"+photo.ID.String()+"?size=original",
+			nil, workspaceID, userID)
+
+		mockSvc.On("GetPhoto", mock.Anything, photo.ID).Return(photo, nil).Once()
+		mockStorage.On("Get", mock.Anything, photo.StoragePath).
+			Return(nil, errors.New("file not found")).Once()
+
+		rr := executeServeHandlerRequest(t, mockSvc, storageGetter, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		assert.Empty(t, rr.Header().Get("X-Thumbnail-Placeholder"))
+	})
+}
+
+func TestUploadHandler_HandleUpload(t *testing.T) {
+	workspaceID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	userID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
+
+	urlGen := func(_ context.Context, wsID, itemID, photoID uuid.UUID, isThumbnail bool) string {
+		return fmt.Sprintf("/photos/%s", photoID)
+	}
+
+	t.Run("uploads photo successfully", func(t *testing.T) {
+		mockSvc := new(MockService)
+
+		itemID := uuid.New()
+		photo := createTestPhoto(itemID)
+		photo.WorkspaceID = workspaceID
+
+		// Create multipart form
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		part, _ := writer.CreateFormFile("photo", "test.jpg")
+		part.Write([]byte("fake jpeg content"))
+		writer.Close()
+
+		req := createChiRequest("POST", "/items/"+itemID.String()+"/photos",
+			body, workspaceID, userID)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+
+		mockSvc.On("UploadPhoto", mock.Anything, itemID, workspaceID, userID, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(photo, nil).Once()
+
+		rr := executeUploadHandlerRequest(t, mockSvc, urlGen, req)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("uploads to an inventory record", func(t *testing.T) {
+		mockSvc := new(MockService)
+
+		itemID := uuid.New()
+		inventoryID := uuid.New()
+		photo := createTestPhoto(itemID)
+		photo.WorkspaceID = workspaceID
+		photo.InventoryID = &inventoryID
+
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		part, _ := writer.CreateFormFile("photo", "test.jpg")
+		part.Write([]byte("fake jpeg content"))
+		writer.WriteField("inventory_id", inventoryID.String())
+		writer.Close()
+
+		req := createChiRequest("POST", "/items/"+itemID.String()+"/photos",
+			body, workspaceID, userID)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+
+		mockSvc.On("UploadPhoto", mock.Anything, itemID, workspaceID, userID, &inventoryID, mock.Anything, mock.Anything, mock.Anything).
+			Return(photo, nil).Once()
+
+		rr := executeUploadHandlerRequest(t, mockSvc, urlGen, req)
+
+		assert.Equal(t, http.StatusCreated, rr.Code)
+		var result itemphoto.PhotoResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &result))
+		assert.Equal(t, &inventoryID, result.InventoryID)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("returns 400 for invalid inventory_id", func(t *testing.T) {
+		mockSvc := new(MockService)
+
+		itemID := uuid.New()
+
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		part, _ := writer.CreateFormFile("photo", "test.jpg")
+		part.Write([]byte("fake jpeg content"))
+		writer.WriteField("inventory_id", "not-a-uuid")
+		writer.Close()
+
+		req := createChiRequest("POST", "/items/"+itemID.String()+"/photos",
+			body, workspaceID, userID)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+
+		rr := executeUploadHandlerRequest(t, mockSvc, urlGen, req)
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		mockSvc.AssertNotCalled(t, "UploadPhoto")
+	})
+
+	t.Run("returns 404 for inventory of another item", func(t *testing.T) {
+		mockSvc := new(MockService)
+
+		itemID := uuid.New()
+		inventoryID := uuid.New()
+
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		part, _ := writer.CreateFormFile("photo", "test.jpg")
+		part.Write([]byte("fake jpeg content"))
+		writer.WriteField("inventory_id", inventoryID.String())
+		writer.Close()
+
+		req := createChiRequest("POST", "/items/"+itemID.String()+"/photos",
+			body, workspaceID, userID)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+
+		mockSvc.On("UploadPhoto", mock.Anything, itemID, workspaceID, userID, &inventoryID, mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, itemphoto.ErrInventoryNotFound).Once()
+
+		rr := executeUploadHandlerRequest(t, mockSvc, urlGen, req)
+
+		assert.Equal(t, http.StatusNotFound, rr.Code)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("returns 400 for missing photo file", func(t *testing.T) {
+		mockSvc := new(MockService)
+
+		itemID := uuid.New()
+
+		// Create multipart form without photo
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		writer.WriteField("caption", "test")
 		writer.Close()
 
 		req := createChiRequest("POST", "/items/"+itemID.String()+"/photos",
@@ -951,7 +1668,7 @@ func TestUploadHandler_HandleUpload(t *testing.T) {
 			body, workspaceID, userID)
 		req.Header.Set("Content-Type", writer.FormDataContentType())
 
-		mockSvc.On("UploadPhoto", mock.Anything, itemID, workspaceID, userID, mock.Anything, mock.Anything, mock.Anything).
+		mockSvc.On("UploadPhoto", mock.Anything, itemID, workspaceID, userID, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 			Return(nil, itemphoto.ErrFileTooLarge).Once()
 
 		rr := executeUploadHandlerRequest(t, mockSvc, urlGen, req)
@@ -976,7 +1693,7 @@ func TestUploadHandler_HandleUpload(t *testing.T) {
 			body, workspaceID, userID)
 		req.Header.Set("Content-Type", writer.FormDataContentType())
 
-		mockSvc.On("UploadPhoto", mock.Anything, itemID, workspaceID, userID, mock.Anything, mock.Anything, mock.Anything).
+		mockSvc.On("UploadPhoto", mock.Anything, itemID, workspaceID, userID, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 			Return(nil, itemphoto.ErrInvalidFileType).Once()
 
 		rr := executeUploadHandlerRequest(t, mockSvc, urlGen, req)
@@ -1000,7 +1717,7 @@ func executeBulkHandlerRequest(t *testing.T, svc itemphoto.ServiceInterface, sto
 func executeServeHandlerRequest(t *testing.T, svc itemphoto.ServiceInterface, storageGetter itemphoto.StorageGetter, req *http.Request) *httptest.ResponseRecorder {
 	t.Helper()
 	r := chi.NewRouter()
-	itemphoto.RegisterServeHandler(r, svc, storageGetter)
+	itemphoto.RegisterServeHandler(r, svc, storageGetter, nil)
 	rr := httptest.NewRecorder()
 	r.ServeHTTP(rr, req)
 	return rr
@@ -1020,7 +1737,7 @@ func TestHandler_ListPhotos_ServerError(t *testing.T) {
 	setup := testutil.NewHandlerTestSetup()
 	mockSvc := new(MockService)
 
-	urlGen := func(workspaceID, itemID, photoID uuid.UUID, isThumbnail bool) string {
+	urlGen := func(_ context.Context, workspaceID, itemID, photoID uuid.UUID, isThumbnail bool) string {
 		return fmt.Sprintf("/photos/%s", photoID)
 	}
 
@@ -1043,7 +1760,7 @@ func TestHandler_GetPhoto_ServerError(t *testing.T) {
 	setup := testutil.NewHandlerTestSetup()
 	mockSvc := new(MockService)
 
-	urlGen := func(workspaceID, itemID, photoID uuid.UUID, isThumbnail bool) string {
+	urlGen := func(_ context.Context, workspaceID, itemID, photoID uuid.UUID, isThumbnail bool) string {
 		return fmt.Sprintf("/photos/%s", photoID)
 	}
 
@@ -1066,7 +1783,7 @@ func TestHandler_SetPrimary_ServerError(t *testing.T) {
 	setup := testutil.NewHandlerTestSetup()
 	mockSvc := new(MockService)
 
-	urlGen := func(workspaceID, itemID, photoID uuid.UUID, isThumbnail bool) string {
+	urlGen := func(_ context.Context, workspaceID, itemID, photoID uuid.UUID, isThumbnail bool) string {
 		return fmt.Sprintf("/photos/%s", photoID)
 	}
 
@@ -1089,7 +1806,7 @@ func TestHandler_UpdateCaption_Errors(t *testing.T) {
 	setup := testutil.NewHandlerTestSetup()
 	mockSvc := new(MockService)
 
-	urlGen := func(workspaceID, itemID, photoID uuid.UUID, isThumbnail bool) string {
+	urlGen := func(_ context.Context, workspaceID, itemID, photoID uuid.UUID, isThumbnail bool) string {
 		return fmt.Sprintf("/photos/%s", photoID)
 	}
 
@@ -1139,7 +1856,7 @@ func TestHandler_ReorderPhotos_ServerError(t *testing.T) {
 	setup := testutil.NewHandlerTestSetup()
 	mockSvc := new(MockService)
 
-	urlGen := func(workspaceID, itemID, photoID uuid.UUID, isThumbnail bool) string {
+	urlGen := func(_ context.Context, workspaceID, itemID, photoID uuid.UUID, isThumbnail bool) string {
 		return fmt.Sprintf("/photos/%s", photoID)
 	}
 
@@ -1164,7 +1881,7 @@ func TestHandler_DeletePhoto_ServerError(t *testing.T) {
 	setup := testutil.NewHandlerTestSetup()
 	mockSvc := new(MockService)
 
-	urlGen := func(workspaceID, itemID, photoID uuid.UUID, isThumbnail bool) string {
+	urlGen := func(_ context.Context, workspaceID, itemID, photoID uuid.UUID, isThumbnail bool) string {
 		return fmt.Sprintf("/photos/%s", photoID)
 	}
 
@@ -1189,7 +1906,7 @@ func TestHandler_GetUpdatedPhoto_Error(t *testing.T) {
 	setup := testutil.NewHandlerTestSetup()
 	mockSvc := new(MockService)
 
-	urlGen := func(workspaceID, itemID, photoID uuid.UUID, isThumbnail bool) string {
+	urlGen := func(_ context.Context, workspaceID, itemID, photoID uuid.UUID, isThumbnail bool) string {
 		return fmt.Sprintf("/photos/%s", photoID)
 	}
 
@@ -1235,7 +1952,7 @@ func TestUploadHandler_HandleUpload_GenericError(t *testing.T) {
 	workspaceID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
 	userID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
 
-	urlGen := func(wsID, itemID, photoID uuid.UUID, isThumbnail bool) string {
+	urlGen := func(_ context.Context, wsID, itemID, photoID uuid.UUID, isThumbnail bool) string {
 		return fmt.Sprintf("/photos/%s", photoID)
 	}
 
@@ -1255,7 +1972,7 @@ func TestUploadHandler_HandleUpload_GenericError(t *testing.T) {
 			body, workspaceID, userID)
 		req.Header.Set("Content-Type", writer.FormDataContentType())
 
-		mockSvc.On("UploadPhoto", mock.Anything, itemID, workspaceID, userID, mock.Anything, mock.Anything, mock.Anything).
+		mockSvc.On("UploadPhoto", mock.Anything, itemID, workspaceID, userID, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
 			Return(nil, errors.New("database error")).Once()
 
 		rr := executeUploadHandlerRequest(t, mockSvc, urlGen, req)
@@ -1263,6 +1980,31 @@ func TestUploadHandler_HandleUpload_GenericError(t *testing.T) {
 		assert.Equal(t, http.StatusInternalServerError, rr.Code)
 		mockSvc.AssertExpectations(t)
 	})
+
+	t.Run("returns 409 with current and limit when the item is full", func(t *testing.T) {
+		mockSvc := new(MockService)
+
+		itemID := uuid.New()
+
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		part, _ := writer.CreateFormFile("photo", "test.jpg")
+		part.Write([]byte("fake jpeg"))
+		writer.Close()
+
+		req := createChiRequest("POST", "/items/"+itemID.String()+"/photos",
+			body, workspaceID, userID)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+
+		mockSvc.On("UploadPhoto", mock.Anything, itemID, workspaceID, userID, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+			Return(nil, &itemphoto.PhotoLimitError{Current: 20, Limit: 20}).Once()
+
+		rr := executeUploadHandlerRequest(t, mockSvc, urlGen, req)
+
+		assert.Equal(t, http.StatusConflict, rr.Code)
+		assert.Contains(t, rr.Body.String(), "item has 20 photos, the workspace allows 20")
+		mockSvc.AssertExpectations(t)
+	})
 }
 
 func TestServePhoto_StorageError(t *testing.T) {
@@ -1352,7 +2094,7 @@ func TestBulkHandler_InvalidItemID(t *testing.T) {
 	workspaceID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
 	userID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
 
-	urlGen := func(wsID, itemID, photoID uuid.UUID, isThumbnail bool) string {
+	urlGen := func(_ context.Context, wsID, itemID, photoID uuid.UUID, isThumbnail bool) string {
 		return fmt.Sprintf("/photos/%s", photoID)
 	}
 
@@ -1375,7 +2117,7 @@ func TestBulkHandler_ServiceErrors(t *testing.T) {
 	workspaceID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
 	userID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
 
-	urlGen := func(wsID, itemID, photoID uuid.UUID, isThumbnail bool) string {
+	urlGen := func(_ context.Context, wsID, itemID, photoID uuid.UUID, isThumbnail bool) string {
 		return fmt.Sprintf("/photos/%s", photoID)
 	}
 
@@ -1422,7 +2164,7 @@ func TestBulkHandler_InvalidBody(t *testing.T) {
 	workspaceID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
 	userID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
 
-	urlGen := func(wsID, itemID, photoID uuid.UUID, isThumbnail bool) string {
+	urlGen := func(_ context.Context, wsID, itemID, photoID uuid.UUID, isThumbnail bool) string {
 		return fmt.Sprintf("/photos/%s", photoID)
 	}
 
@@ -1459,7 +2201,7 @@ func TestBulkDownload_InvalidPhotoID(t *testing.T) {
 	workspaceID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
 	userID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
 
-	urlGen := func(wsID, itemID, photoID uuid.UUID, isThumbnail bool) string {
+	urlGen := func(_ context.Context, wsID, itemID, photoID uuid.UUID, isThumbnail bool) string {
 		return fmt.Sprintf("/photos/%s", photoID)
 	}
 
@@ -1482,7 +2224,7 @@ func TestBulkDownload_GetPhotosByIDsError(t *testing.T) {
 	workspaceID := uuid.MustParse("00000000-0000-0000-0000-000000000001")
 	userID := uuid.MustParse("00000000-0000-0000-0000-000000000002")
 
-	urlGen := func(wsID, itemID, photoID uuid.UUID, isThumbnail bool) string {
+	urlGen := func(_ context.Context, wsID, itemID, photoID uuid.UUID, isThumbnail bool) string {
 		return fmt.Sprintf("/photos/%s", photoID)
 	}
 
@@ -1505,3 +2247,38 @@ func TestBulkDownload_GetPhotosByIDsError(t *testing.T) {
 		mockSvc.AssertExpectations(t)
 	})
 }
+
+func TestPhotoHandler_ListIncompleteItems(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	itemphoto.RegisterSettingsRoutes(setup.API, mockSvc)
+
+	t.Run("lists items without photos", func(t *testing.T) {
+		itemID := uuid.New()
+		mockSvc.On("ListIncompleteItems", mock.Anything, setup.WorkspaceID).
+			Return(&itemphoto.IncompleteItemsReport{
+				RequirePhoto: true,
+				Items:        []itemphoto.IncompleteItem{{ItemID: itemID, SKU: "SKU-1", Name: "Drill", ShortCode: "DR1"}},
+			}, nil).Once()
+
+		rec := setup.Get("/items/incomplete")
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		var body itemphoto.IncompleteItemsResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.True(t, body.RequirePhoto)
+		require.Len(t, body.Items, 1)
+		assert.Equal(t, itemID, body.Items[0].ItemID)
+		assert.Equal(t, 1, body.Total)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("returns 500 on service error", func(t *testing.T) {
+		mockSvc.On("ListIncompleteItems", mock.Anything, setup.WorkspaceID).
+			Return(nil, errors.New("db down")).Once()
+
+		rec := setup.Get("/items/incomplete")
+
+		testutil.AssertStatus(t, rec, http.StatusInternalServerError)
+	})
+}
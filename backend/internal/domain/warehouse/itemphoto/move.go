@@ -0,0 +1,160 @@
+package itemphoto
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+)
+
+// Transactor runs a function inside a single database transaction. It is a
+// port implemented by infra/postgres.TxManager, keeping this package free of
+// infra imports.
+type Transactor interface {
+	WithTx(ctx context.Context, fn func(context.Context) error) error
+}
+
+// noopTransactor executes the function without a surrounding transaction. It
+// is the fallback when no Transactor is wired (unit tests with mocked
+// repositories).
+type noopTransactor struct{}
+
+func (noopTransactor) WithTx(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+// SetTransactor wires the transaction runner used by MovePhotosToItem so the
+// moves and primary photo changes commit together. Optional — without it the
+// steps run unwrapped (unit tests with mocked repositories).
+func (s *Service) SetTransactor(tx Transactor) {
+	if tx == nil {
+		tx = noopTransactor{}
+	}
+	s.tx = tx
+}
+
+// MovePhotosToItem re-points photos of the workspace to targetItemID, e.g.
+// after duplicating or merging items. They are appended to the target's
+// gallery in the given order and lose their inventory tag, since that unit
+// belongs to the old item. Photos already on the target stay where they are.
+//
+// The target keeps its primary photo, or gets its first photo as primary if
+// it had none; a source item whose primary photo left promotes its first
+// remaining photo.
+func (s *Service) MovePhotosToItem(ctx context.Context, workspaceID uuid.UUID, photoIDs []uuid.UUID, targetItemID uuid.UUID) error {
+	if len(photoIDs) == 0 {
+		return nil
+	}
+
+	exists, err := s.repo.ItemExists(ctx, targetItemID, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to check target item: %w", err)
+	}
+	if !exists {
+		return ErrItemNotFound
+	}
+
+	// GetByIDs is workspace-scoped, so a photo of another workspace is
+	// reported as not found rather than revealed.
+	found, err := s.repo.GetByIDs(ctx, photoIDs, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get photos: %w", err)
+	}
+	byID := make(map[uuid.UUID]*ItemPhoto, len(found))
+	for _, p := range found {
+		byID[p.ID] = p
+	}
+
+	var moving []*ItemPhoto
+	seen := make(map[uuid.UUID]bool, len(photoIDs))
+	sourceItems := make(map[uuid.UUID]bool)
+	for _, id := range photoIDs {
+		photo, ok := byID[id]
+		if !ok {
+			return ErrPhotoNotFound
+		}
+		if seen[id] || photo.ItemID == targetItemID {
+			continue
+		}
+		seen[id] = true
+		moving = append(moving, photo)
+		sourceItems[photo.ItemID] = true
+	}
+	if len(moving) == 0 {
+		return nil
+	}
+
+	gallery, err := s.repo.GetByItem(ctx, targetItemID, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get target photos: %w", err)
+	}
+	if err := s.checkPhotoLimit(ctx, workspaceID, int64(len(gallery)+len(moving)-1)); err != nil {
+		return err
+	}
+
+	// Append after the target's last photo; renumber the whole gallery if the
+	// top of the range is used up.
+	orders := make([]int32, len(moving))
+	renumber := false
+	var prev *int32
+	if len(gallery) > 0 {
+		prev = &gallery[len(gallery)-1].DisplayOrder
+	}
+	for i := range moving {
+		order, ok := orderBetween(prev, nil)
+		if !ok {
+			renumber = true
+			break
+		}
+		orders[i] = order
+		prev = &orders[i]
+	}
+
+	return s.tx.WithTx(ctx, func(txCtx context.Context) error {
+		for i, photo := range moving {
+			if err := s.repo.MoveToItem(txCtx, photo.ID, workspaceID, targetItemID, orders[i]); err != nil {
+				return fmt.Errorf("failed to move photo %s: %w", photo.ID, err)
+			}
+			photo.ItemID = targetItemID
+			photo.DisplayOrder = orders[i]
+			photo.IsPrimary = false
+			photo.InventoryID = nil
+		}
+		if renumber {
+			if err := s.renumberPhotos(txCtx, append(gallery, moving...)); err != nil {
+				return err
+			}
+		}
+
+		if err := s.ensurePrimary(txCtx, targetItemID, workspaceID); err != nil {
+			return err
+		}
+		for itemID := range sourceItems {
+			if err := s.ensurePrimary(txCtx, itemID, workspaceID); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ensurePrimary makes the item's first photo primary when it has photos but
+// none flagged primary.
+func (s *Service) ensurePrimary(ctx context.Context, itemID, workspaceID uuid.UUID) error {
+	photos, err := s.repo.GetByItem(ctx, itemID, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to get photos: %w", err)
+	}
+	if len(photos) == 0 {
+		return nil
+	}
+	for _, p := range photos {
+		if p.IsPrimary {
+			return nil
+		}
+	}
+	if err := s.repo.SetPrimary(ctx, photos[0].ID); err != nil {
+		return fmt.Errorf("failed to set primary photo: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,164 @@
+package itemphoto_test
+
+import (
+	"context"
+	"math"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/itemphoto"
+)
+
+// withPrimary flags the photo at index primary (-1 for none) of a gallery.
+func withPrimary(photos []*itemphoto.ItemPhoto, primary int) []*itemphoto.ItemPhoto {
+	for i, p := range photos {
+		p.IsPrimary = i == primary
+	}
+	return photos
+}
+
+// movedCopy is how a photo reads back after MoveToItem re-pointed it.
+func movedCopy(p *itemphoto.ItemPhoto, itemID uuid.UUID, order int32) *itemphoto.ItemPhoto {
+	c := *p
+	c.ItemID = itemID
+	c.DisplayOrder = order
+	c.IsPrimary = false
+	c.InventoryID = nil
+	return &c
+}
+
+func TestService_MovePhotosToItem(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+	sourceID := uuid.New()
+	targetID := uuid.New()
+	gap := itemphoto.DisplayOrderGap
+
+	newService := func(repo *MockRepository) *itemphoto.Service {
+		return itemphoto.NewService(repo, new(MockStorage), new(MockImageProcessor), os.TempDir())
+	}
+
+	t.Run("source promotes its next photo and the target gets its first primary", func(t *testing.T) {
+		repo := new(MockRepository)
+		source := withPrimary(galleryPhotos(t, sourceID, workspaceID, 0, gap), 0)
+		moved, remaining := source[0], source[1]
+		inventoryID := uuid.New()
+		moved.InventoryID = &inventoryID
+
+		repo.On("ItemExists", ctx, targetID, workspaceID).Return(true, nil)
+		repo.On("GetByIDs", ctx, []uuid.UUID{moved.ID}, workspaceID).Return([]*itemphoto.ItemPhoto{moved}, nil)
+		repo.On("GetByItem", ctx, targetID, workspaceID).Return([]*itemphoto.ItemPhoto{}, nil).Once()
+		repo.On("MoveToItem", ctx, moved.ID, workspaceID, targetID, int32(0)).Return(nil).Once()
+		repo.On("GetByItem", ctx, targetID, workspaceID).Return([]*itemphoto.ItemPhoto{movedCopy(moved, targetID, 0)}, nil).Once()
+		repo.On("SetPrimary", ctx, moved.ID).Return(nil).Once()
+		repo.On("GetByItem", ctx, sourceID, workspaceID).Return([]*itemphoto.ItemPhoto{remaining}, nil).Once()
+		repo.On("SetPrimary", ctx, remaining.ID).Return(nil).Once()
+
+		err := newService(repo).MovePhotosToItem(ctx, workspaceID, []uuid.UUID{moved.ID}, targetID)
+
+		require.NoError(t, err)
+		assert.Nil(t, moved.InventoryID, "the unit tag belongs to the old item")
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("appends after the target's photos and keeps its primary", func(t *testing.T) {
+		repo := new(MockRepository)
+		target := withPrimary(galleryPhotos(t, targetID, workspaceID, 0, gap), 0)
+		source := withPrimary(galleryPhotos(t, sourceID, workspaceID, 0, gap, 2*gap), 0)
+		first, second := source[2], source[1]
+
+		repo.On("ItemExists", ctx, targetID, workspaceID).Return(true, nil)
+		repo.On("GetByIDs", ctx, []uuid.UUID{first.ID, second.ID}, workspaceID).Return([]*itemphoto.ItemPhoto{second, first}, nil)
+		repo.On("GetByItem", ctx, targetID, workspaceID).Return(target, nil).Once()
+		repo.On("MoveToItem", ctx, first.ID, workspaceID, targetID, 2*gap).Return(nil).Once()
+		repo.On("MoveToItem", ctx, second.ID, workspaceID, targetID, 3*gap).Return(nil).Once()
+		repo.On("GetByItem", ctx, targetID, workspaceID).Return(append(target, movedCopy(first, targetID, 2*gap), movedCopy(second, targetID, 3*gap)), nil).Once()
+		repo.On("GetByItem", ctx, sourceID, workspaceID).Return(source[:1], nil).Once()
+
+		err := newService(repo).MovePhotosToItem(ctx, workspaceID, []uuid.UUID{first.ID, second.ID}, targetID)
+
+		require.NoError(t, err)
+		repo.AssertExpectations(t)
+		repo.AssertNotCalled(t, "SetPrimary", mock.Anything, mock.Anything)
+	})
+
+	t.Run("renumbers the target when its display orders are used up", func(t *testing.T) {
+		repo := new(MockRepository)
+		target := withPrimary(galleryPhotos(t, targetID, workspaceID, math.MaxInt32-1), 0)
+		moved := withPrimary(galleryPhotos(t, sourceID, workspaceID, 0), -1)[0]
+
+		repo.On("ItemExists", ctx, targetID, workspaceID).Return(true, nil)
+		repo.On("GetByIDs", ctx, []uuid.UUID{moved.ID}, workspaceID).Return([]*itemphoto.ItemPhoto{moved}, nil)
+		repo.On("GetByItem", ctx, targetID, workspaceID).Return(target, nil).Once()
+		repo.On("MoveToItem", ctx, moved.ID, workspaceID, targetID, int32(0)).Return(nil).Once()
+		repo.On("UpdateDisplayOrder", ctx, target[0].ID, int32(0)).Return(nil).Once()
+		repo.On("UpdateDisplayOrder", ctx, moved.ID, gap).Return(nil).Once()
+		repo.On("GetByItem", ctx, targetID, workspaceID).Return(target, nil).Once()
+		repo.On("GetByItem", ctx, sourceID, workspaceID).Return([]*itemphoto.ItemPhoto{}, nil).Once()
+
+		err := newService(repo).MovePhotosToItem(ctx, workspaceID, []uuid.UUID{moved.ID}, targetID)
+
+		require.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("skips photos already on the target", func(t *testing.T) {
+		repo := new(MockRepository)
+		photo := withPrimary(galleryPhotos(t, targetID, workspaceID, 0), 0)[0]
+
+		repo.On("ItemExists", ctx, targetID, workspaceID).Return(true, nil)
+		repo.On("GetByIDs", ctx, []uuid.UUID{photo.ID}, workspaceID).Return([]*itemphoto.ItemPhoto{photo}, nil)
+
+		err := newService(repo).MovePhotosToItem(ctx, workspaceID, []uuid.UUID{photo.ID}, targetID)
+
+		require.NoError(t, err)
+		repo.AssertNotCalled(t, "MoveToItem", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects a missing target item", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("ItemExists", ctx, targetID, workspaceID).Return(false, nil)
+
+		err := newService(repo).MovePhotosToItem(ctx, workspaceID, []uuid.UUID{uuid.New()}, targetID)
+
+		assert.ErrorIs(t, err, itemphoto.ErrItemNotFound)
+	})
+
+	t.Run("rejects a photo outside the workspace", func(t *testing.T) {
+		repo := new(MockRepository)
+		photo := galleryPhotos(t, sourceID, workspaceID, 0)[0]
+		foreign := uuid.New()
+
+		repo.On("ItemExists", ctx, targetID, workspaceID).Return(true, nil)
+		repo.On("GetByIDs", ctx, []uuid.UUID{photo.ID, foreign}, workspaceID).Return([]*itemphoto.ItemPhoto{photo}, nil)
+
+		err := newService(repo).MovePhotosToItem(ctx, workspaceID, []uuid.UUID{photo.ID, foreign}, targetID)
+
+		assert.ErrorIs(t, err, itemphoto.ErrPhotoNotFound)
+		repo.AssertNotCalled(t, "MoveToItem", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("respects the target's photo limit", func(t *testing.T) {
+		repo := new(MockRepository)
+		orders := make([]int32, itemphoto.DefaultMaxPhotosPerItem)
+		for i := range orders {
+			orders[i] = int32(i) * gap
+		}
+		target := galleryPhotos(t, targetID, workspaceID, orders...)
+		photo := galleryPhotos(t, sourceID, workspaceID, 0)[0]
+
+		repo.On("ItemExists", ctx, targetID, workspaceID).Return(true, nil)
+		repo.On("GetByIDs", ctx, []uuid.UUID{photo.ID}, workspaceID).Return([]*itemphoto.ItemPhoto{photo}, nil)
+		repo.On("GetByItem", ctx, targetID, workspaceID).Return(target, nil).Once()
+
+		err := newService(repo).MovePhotosToItem(ctx, workspaceID, []uuid.UUID{photo.ID}, targetID)
+
+		assert.ErrorIs(t, err, itemphoto.ErrPhotoLimitReached)
+		repo.AssertNotCalled(t, "MoveToItem", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,125 @@
+package itemphoto
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/antti/home-warehouse/go-backend/internal/infra/imageprocessor"
+	"github.com/antti/home-warehouse/go-backend/internal/jobs"
+)
+
+// ErrThumbnailGenerationUnavailable is returned by GenerateThumbnail when
+// on-demand generation is disabled, all generation slots are busy or the
+// photo's thumbnails are already being generated.
+var ErrThumbnailGenerationUnavailable = errors.New("on-demand thumbnail generation unavailable")
+
+// ThumbnailGenerator creates, stores and records all thumbnail sizes for a
+// photo, returning their storage paths by size. Implemented by
+// jobs.ThumbnailProcessor.
+type ThumbnailGenerator interface {
+	Generate(ctx context.Context, payload jobs.ThumbnailPayload) (map[imageprocessor.ThumbnailSize]string, error)
+}
+
+// onDemandThumbnails bounds synchronous thumbnail generation while serving.
+type onDemandThumbnails struct {
+	generator ThumbnailGenerator
+	timeout   time.Duration
+	slots     chan struct{}
+
+	mu       sync.Mutex
+	inFlight map[uuid.UUID]bool
+}
+
+// acquire claims a generation slot for the photo without waiting. It fails
+// when all slots are taken or the photo is already being generated.
+func (o *onDemandThumbnails) acquire(photoID uuid.UUID) bool {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.inFlight[photoID] {
+		return false
+	}
+	select {
+	case o.slots <- struct{}{}:
+		o.inFlight[photoID] = true
+		return true
+	default:
+		return false
+	}
+}
+
+func (o *onDemandThumbnails) release(photoID uuid.UUID) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	delete(o.inFlight, photoID)
+	<-o.slots
+}
+
+// SetOnDemandThumbnails enables generating a missing thumbnail while it is
+// being served, with at most maxConcurrent generations running at once, each
+// limited to timeout (zero means no limit). This is optional - if not set, or
+// maxConcurrent is not positive, photos without a thumbnail are served in
+// full size until the background job has made one.
+func (s *Service) SetOnDemandThumbnails(generator ThumbnailGenerator, maxConcurrent int, timeout time.Duration) {
+	if generator == nil || maxConcurrent <= 0 {
+		s.onDemand = nil
+		return
+	}
+	s.onDemand = &onDemandThumbnails{
+		generator: generator,
+		timeout:   timeout,
+		slots:     make(chan struct{}, maxConcurrent),
+		inFlight:  make(map[uuid.UUID]bool),
+	}
+}
+
+// GenerateThumbnail synchronously generates and stores the photo's
+// thumbnails, updating photo with the new paths, and returns the path of the
+// one served as "the" thumbnail (see GetBestThumbnail). Callers should fall
+// back to the original on ErrThumbnailGenerationUnavailable rather than wait.
+func (s *Service) GenerateThumbnail(ctx context.Context, photo *ItemPhoto) (string, error) {
+	od := s.onDemand
+	if od == nil || !od.acquire(photo.ID) {
+		return "", ErrThumbnailGenerationUnavailable
+	}
+	defer od.release(photo.ID)
+
+	if od.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, od.timeout)
+		defer cancel()
+	}
+
+	paths, err := od.generator.Generate(ctx, jobs.ThumbnailPayload{
+		PhotoID:     photo.ID,
+		WorkspaceID: photo.WorkspaceID,
+		ItemID:      photo.ItemID,
+		StoragePath: photo.StoragePath,
+	})
+	if err != nil {
+		return "", fmt.Errorf("generate thumbnails: %w", err)
+	}
+
+	photo.ThumbnailSmallPath = thumbnailPath(paths, imageprocessor.ThumbnailSizeSmall)
+	photo.ThumbnailMediumPath = thumbnailPath(paths, imageprocessor.ThumbnailSizeMedium)
+	photo.ThumbnailLargePath = thumbnailPath(paths, imageprocessor.ThumbnailSizeLarge)
+	photo.ThumbnailStatus = ThumbnailStatusComplete
+	photo.ThumbnailError = nil
+
+	best := photo.GetBestThumbnail()
+	if best == "" {
+		return "", fmt.Errorf("generate thumbnails: no %s thumbnail produced", imageprocessor.ThumbnailSizeMedium)
+	}
+	return best, nil
+}
+
+func thumbnailPath(paths map[imageprocessor.ThumbnailSize]string, size imageprocessor.ThumbnailSize) *string {
+	if p, ok := paths[size]; ok {
+		return &p
+	}
+	return nil
+}
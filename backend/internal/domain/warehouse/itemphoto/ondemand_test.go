@@ -0,0 +1,129 @@
+package itemphoto_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/itemphoto"
+	"github.com/antti/home-warehouse/go-backend/internal/infra/imageprocessor"
+	"github.com/antti/home-warehouse/go-backend/internal/jobs"
+)
+
+// fakeThumbnailGenerator returns fixed paths, optionally blocking until
+// release is closed.
+type fakeThumbnailGenerator struct {
+	started  chan struct{}
+	release  chan struct{}
+	err      error
+	payloads []jobs.ThumbnailPayload
+}
+
+func (f *fakeThumbnailGenerator) Generate(ctx context.Context, payload jobs.ThumbnailPayload) (map[imageprocessor.ThumbnailSize]string, error) {
+	f.payloads = append(f.payloads, payload)
+	if f.started != nil {
+		close(f.started)
+	}
+	if f.release != nil {
+		select {
+		case <-f.release:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+	if f.err != nil {
+		return nil, f.err
+	}
+	return map[imageprocessor.ThumbnailSize]string{
+		imageprocessor.ThumbnailSizeSmall:  "thumbs/small.webp",
+		imageprocessor.ThumbnailSizeMedium: "thumbs/medium.webp",
+		imageprocessor.ThumbnailSizeLarge:  "thumbs/large.webp",
+	}, nil
+}
+
+func TestService_GenerateThumbnail(t *testing.T) {
+	ctx := context.Background()
+	newService := func() *itemphoto.Service {
+		return itemphoto.NewService(new(MockRepository), new(MockStorage), new(MockImageProcessor), t.TempDir())
+	}
+	newPhoto := func() *itemphoto.ItemPhoto {
+		photo := createServiceTestPhoto(t, uuid.New(), uuid.New())
+		photo.ThumbnailStatus = itemphoto.ThumbnailStatusFailed
+		return photo
+	}
+
+	t.Run("generates and records the thumbnails", func(t *testing.T) {
+		gen := &fakeThumbnailGenerator{}
+		svc := newService()
+		svc.SetOnDemandThumbnails(gen, 1, time.Second)
+		photo := newPhoto()
+
+		path, err := svc.GenerateThumbnail(ctx, photo)
+
+		require.NoError(t, err)
+		assert.Equal(t, "thumbs/medium.webp", path)
+		assert.Equal(t, itemphoto.ThumbnailStatusComplete, photo.ThumbnailStatus)
+		assert.Equal(t, "thumbs/small.webp", photo.GetSmallThumbnail())
+		assert.Equal(t, "thumbs/large.webp", photo.GetLargeThumbnail())
+		require.Len(t, gen.payloads, 1)
+		assert.Equal(t, jobs.ThumbnailPayload{
+			PhotoID: photo.ID, WorkspaceID: photo.WorkspaceID, ItemID: photo.ItemID, StoragePath: photo.StoragePath,
+		}, gen.payloads[0])
+	})
+
+	t.Run("disabled by default", func(t *testing.T) {
+		_, err := newService().GenerateThumbnail(ctx, newPhoto())
+
+		assert.ErrorIs(t, err, itemphoto.ErrThumbnailGenerationUnavailable)
+	})
+
+	t.Run("does not wait for a busy slot", func(t *testing.T) {
+		gen := &fakeThumbnailGenerator{started: make(chan struct{}), release: make(chan struct{})}
+		svc := newService()
+		svc.SetOnDemandThumbnails(gen, 1, time.Second)
+
+		done := make(chan error)
+		go func() {
+			_, err := svc.GenerateThumbnail(ctx, newPhoto())
+			done <- err
+		}()
+		<-gen.started
+
+		_, err := svc.GenerateThumbnail(ctx, newPhoto())
+		assert.ErrorIs(t, err, itemphoto.ErrThumbnailGenerationUnavailable)
+
+		close(gen.release)
+		require.NoError(t, <-done)
+
+		// The slot is free again once the first generation has finished.
+		gen.started = nil
+		_, err = svc.GenerateThumbnail(ctx, newPhoto())
+		assert.NoError(t, err)
+	})
+
+	t.Run("gives up after the timeout", func(t *testing.T) {
+		gen := &fakeThumbnailGenerator{release: make(chan struct{})}
+		svc := newService()
+		svc.SetOnDemandThumbnails(gen, 1, 10*time.Millisecond)
+
+		_, err := svc.GenerateThumbnail(ctx, newPhoto())
+
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+
+	t.Run("generation error", func(t *testing.T) {
+		svc := newService()
+		svc.SetOnDemandThumbnails(&fakeThumbnailGenerator{err: errors.New("corrupt image")}, 1, time.Second)
+		photo := newPhoto()
+
+		_, err := svc.GenerateThumbnail(ctx, photo)
+
+		assert.Error(t, err)
+		assert.Equal(t, itemphoto.ThumbnailStatusFailed, photo.ThumbnailStatus)
+	})
+}
@@ -0,0 +1,89 @@
+package itemphoto
+
+import (
+	"embed"
+	"fmt"
+	"mime"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+//go:embed placeholders/pending.svg placeholders/failed.svg
+var builtinPlaceholders embed.FS
+
+// Placeholder is an image served in place of a thumbnail that cannot be
+// served, so clients get an image rather than a 404.
+type Placeholder struct {
+	Data        []byte
+	ContentType string
+}
+
+// Placeholders holds the image shown while a photo's thumbnails are still
+// being generated (Pending) and the one shown when they could not be
+// (Failed).
+type Placeholders struct {
+	Pending Placeholder
+	Failed  Placeholder
+}
+
+// DefaultPlaceholders returns the built-in SVG placeholders.
+func DefaultPlaceholders() *Placeholders {
+	pending, _ := builtinPlaceholders.ReadFile("placeholders/pending.svg")
+	failed, _ := builtinPlaceholders.ReadFile("placeholders/failed.svg")
+	return &Placeholders{
+		Pending: Placeholder{Data: pending, ContentType: "image/svg+xml"},
+		Failed:  Placeholder{Data: failed, ContentType: "image/svg+xml"},
+	}
+}
+
+// LoadPlaceholders reads custom placeholders from dir: an image named
+// "pending" and one named "failed", with any image extension (pending.png,
+// failed.svg...). A kind with no file in dir keeps the built-in image; an
+// empty dir returns the built-in placeholders.
+func LoadPlaceholders(dir string) (*Placeholders, error) {
+	placeholders := DefaultPlaceholders()
+	if dir == "" {
+		return placeholders, nil
+	}
+	if _, err := os.Stat(dir); err != nil {
+		return nil, fmt.Errorf("placeholder directory: %w", err)
+	}
+
+	for name, dst := range map[string]*Placeholder{
+		"pending": &placeholders.Pending,
+		"failed":  &placeholders.Failed,
+	} {
+		matches, err := filepath.Glob(filepath.Join(dir, name+".*"))
+		if err != nil {
+			return nil, err
+		}
+		if len(matches) == 0 {
+			continue
+		}
+		if len(matches) > 1 {
+			return nil, fmt.Errorf("more than one %s placeholder in %s", name, dir)
+		}
+
+		path := matches[0]
+		contentType := mime.TypeByExtension(strings.ToLower(filepath.Ext(path)))
+		if !strings.HasPrefix(contentType, "image/") {
+			return nil, fmt.Errorf("placeholder %s is not an image", path)
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("read placeholder: %w", err)
+		}
+		*dst = Placeholder{Data: data, ContentType: contentType}
+	}
+	return placeholders, nil
+}
+
+// For returns the placeholder matching photo's thumbnail status: Pending
+// while it is queued or processing, Failed otherwise.
+func (p *Placeholders) For(photo *ItemPhoto) (Placeholder, string) {
+	if photo.IsThumbnailPending() {
+		return p.Pending, "pending"
+	}
+	return p.Failed, "failed"
+}
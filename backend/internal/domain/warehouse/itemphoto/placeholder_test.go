@@ -0,0 +1,64 @@
+package itemphoto_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/itemphoto"
+)
+
+func TestDefaultPlaceholders(t *testing.T) {
+	p := itemphoto.DefaultPlaceholders()
+
+	assert.Equal(t, "image/svg+xml", p.Pending.ContentType)
+	assert.Contains(t, string(p.Pending.Data), "<svg")
+	assert.Equal(t, "image/svg+xml", p.Failed.ContentType)
+	assert.Contains(t, string(p.Failed.Data), "<svg")
+	assert.NotEqual(t, p.Pending.Data, p.Failed.Data)
+}
+
+func TestLoadPlaceholders(t *testing.T) {
+	defaults := itemphoto.DefaultPlaceholders()
+
+	t.Run("empty dir uses the built-in images", func(t *testing.T) {
+		p, err := itemphoto.LoadPlaceholders("")
+		require.NoError(t, err)
+		assert.Equal(t, defaults, p)
+	})
+
+	t.Run("overrides the kinds found in dir", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "failed.png"), []byte("png bytes"), 0o644))
+
+		p, err := itemphoto.LoadPlaceholders(dir)
+		require.NoError(t, err)
+		assert.Equal(t, itemphoto.Placeholder{Data: []byte("png bytes"), ContentType: "image/png"}, p.Failed)
+		assert.Equal(t, defaults.Pending, p.Pending)
+	})
+
+	t.Run("rejects files that are not images", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "pending.txt"), []byte("text"), 0o644))
+
+		_, err := itemphoto.LoadPlaceholders(dir)
+		assert.ErrorContains(t, err, "not an image")
+	})
+
+	t.Run("rejects two images for one kind", func(t *testing.T) {
+		dir := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "pending.png"), []byte("a"), 0o644))
+		require.NoError(t, os.WriteFile(filepath.Join(dir, "pending.gif"), []byte("b"), 0o644))
+
+		_, err := itemphoto.LoadPlaceholders(dir)
+		assert.ErrorContains(t, err, "more than one pending placeholder")
+	})
+
+	t.Run("rejects a missing dir", func(t *testing.T) {
+		_, err := itemphoto.LoadPlaceholders(filepath.Join(t.TempDir(), "missing"))
+		assert.Error(t, err)
+	})
+}
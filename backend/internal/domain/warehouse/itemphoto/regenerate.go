@@ -0,0 +1,51 @@
+package itemphoto
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+
+	"github.com/google/uuid"
+
+	"github.com/antti/home-warehouse/go-backend/internal/jobs"
+)
+
+// ErrThumbnailRegenerationUnavailable is returned by RegenerateThumbnails
+// when no background queue is configured to run the job.
+var ErrThumbnailRegenerationUnavailable = errors.New("thumbnail regeneration unavailable")
+
+// RegenerateThumbnails queues generation of all thumbnail sizes for one
+// photo, e.g. to replace a bad or failed thumbnail. The photo is marked
+// processing and returned; the job publishes photo.thumbnail_ready (or
+// photo.thumbnail_failed) when it finishes.
+func (s *Service) RegenerateThumbnails(ctx context.Context, itemID, photoID, workspaceID uuid.UUID) (*ItemPhoto, error) {
+	photo, err := s.fetchPhoto(ctx, photoID)
+	if err != nil {
+		return nil, err
+	}
+	if photo.WorkspaceID != workspaceID || photo.ItemID != itemID {
+		return nil, ErrPhotoNotFound
+	}
+	if s.asynqClient == nil {
+		return nil, ErrThumbnailRegenerationUnavailable
+	}
+
+	if err := s.repo.UpdateThumbnailStatus(ctx, photo.ID, ThumbnailStatusProcessing, nil); err != nil {
+		return nil, fmt.Errorf("failed to update thumbnail status: %w", err)
+	}
+
+	task := jobs.NewThumbnailGenerationTask(photo.ID, workspaceID, itemID, photo.StoragePath)
+	if _, err := s.asynqClient.Enqueue(task); err != nil {
+		// Don't leave the photo processing with no job to finish it.
+		msg := fmt.Sprintf("failed to queue regeneration: %v", err)
+		if err := s.repo.UpdateThumbnailStatus(ctx, photo.ID, ThumbnailStatusFailed, &msg); err != nil {
+			log.Printf("Failed to mark photo %s failed: %v", photo.ID, err)
+		}
+		return nil, fmt.Errorf("failed to enqueue thumbnail job: %w", err)
+	}
+
+	photo.ThumbnailStatus = ThumbnailStatusProcessing
+	photo.ThumbnailError = nil
+	return photo, nil
+}
@@ -0,0 +1,127 @@
+package itemphoto_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/itemphoto"
+	"github.com/antti/home-warehouse/go-backend/internal/jobs"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// fakeTaskQueue records enqueued tasks, failing them all when err is set.
+type fakeTaskQueue struct {
+	tasks []*asynq.Task
+	err   error
+}
+
+func (f *fakeTaskQueue) Enqueue(task *asynq.Task, opts ...asynq.Option) (*asynq.TaskInfo, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.tasks = append(f.tasks, task)
+	return &asynq.TaskInfo{}, nil
+}
+
+func TestService_RegenerateThumbnails(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+	itemID := uuid.New()
+
+	newService := func(repo *MockRepository, queue *fakeTaskQueue) *itemphoto.Service {
+		svc := itemphoto.NewService(repo, new(MockStorage), new(MockImageProcessor), t.TempDir())
+		if queue != nil {
+			svc.SetAsynqClient(queue)
+		}
+		return svc
+	}
+	newPhoto := func() *itemphoto.ItemPhoto {
+		photo := createServiceTestPhoto(t, itemID, workspaceID)
+		photo.ThumbnailStatus = itemphoto.ThumbnailStatusFailed
+		msg := "decode failed"
+		photo.ThumbnailError = &msg
+		return photo
+	}
+
+	t.Run("marks the photo processing and queues all sizes", func(t *testing.T) {
+		repo := new(MockRepository)
+		queue := &fakeTaskQueue{}
+		photo := newPhoto()
+		repo.On("GetByID", ctx, photo.ID).Return(photo, nil)
+		repo.On("UpdateThumbnailStatus", ctx, photo.ID, itemphoto.ThumbnailStatusProcessing, (*string)(nil)).Return(nil)
+
+		got, err := newService(repo, queue).RegenerateThumbnails(ctx, itemID, photo.ID, workspaceID)
+
+		require.NoError(t, err)
+		assert.Equal(t, itemphoto.ThumbnailStatusProcessing, got.ThumbnailStatus)
+		assert.Nil(t, got.ThumbnailError)
+		require.Len(t, queue.tasks, 1)
+		assert.Equal(t, jobs.TypeThumbnailGeneration, queue.tasks[0].Type())
+		var payload jobs.ThumbnailPayload
+		require.NoError(t, json.Unmarshal(queue.tasks[0].Payload(), &payload))
+		assert.Equal(t, jobs.ThumbnailPayload{
+			PhotoID: photo.ID, WorkspaceID: workspaceID, ItemID: itemID, StoragePath: photo.StoragePath,
+		}, payload)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("photo of another item or workspace is not found", func(t *testing.T) {
+		repo := new(MockRepository)
+		queue := &fakeTaskQueue{}
+		photo := newPhoto()
+		repo.On("GetByID", ctx, photo.ID).Return(photo, nil)
+		svc := newService(repo, queue)
+
+		_, err := svc.RegenerateThumbnails(ctx, uuid.New(), photo.ID, workspaceID)
+		assert.ErrorIs(t, err, itemphoto.ErrPhotoNotFound)
+
+		_, err = svc.RegenerateThumbnails(ctx, itemID, photo.ID, uuid.New())
+		assert.ErrorIs(t, err, itemphoto.ErrPhotoNotFound)
+
+		assert.Empty(t, queue.tasks)
+		repo.AssertNotCalled(t, "UpdateThumbnailStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("missing photo is not found", func(t *testing.T) {
+		repo := new(MockRepository)
+		photoID := uuid.New()
+		repo.On("GetByID", ctx, photoID).Return(nil, shared.ErrNotFound)
+
+		_, err := newService(repo, &fakeTaskQueue{}).RegenerateThumbnails(ctx, itemID, photoID, workspaceID)
+
+		assert.ErrorIs(t, err, itemphoto.ErrPhotoNotFound)
+	})
+
+	t.Run("unavailable without a queue", func(t *testing.T) {
+		repo := new(MockRepository)
+		photo := newPhoto()
+		repo.On("GetByID", ctx, photo.ID).Return(photo, nil)
+
+		_, err := newService(repo, nil).RegenerateThumbnails(ctx, itemID, photo.ID, workspaceID)
+
+		assert.ErrorIs(t, err, itemphoto.ErrThumbnailRegenerationUnavailable)
+		repo.AssertNotCalled(t, "UpdateThumbnailStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("enqueue failure marks the photo failed", func(t *testing.T) {
+		repo := new(MockRepository)
+		photo := newPhoto()
+		repo.On("GetByID", ctx, photo.ID).Return(photo, nil)
+		repo.On("UpdateThumbnailStatus", ctx, photo.ID, itemphoto.ThumbnailStatusProcessing, (*string)(nil)).Return(nil)
+		repo.On("UpdateThumbnailStatus", ctx, photo.ID, itemphoto.ThumbnailStatusFailed, mock.AnythingOfType("*string")).Return(nil)
+
+		_, err := newService(repo, &fakeTaskQueue{err: errors.New("redis down")}).RegenerateThumbnails(ctx, itemID, photo.ID, workspaceID)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "redis down")
+		repo.AssertExpectations(t)
+	})
+}
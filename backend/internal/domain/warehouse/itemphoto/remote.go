@@ -0,0 +1,83 @@
+package itemphoto
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/google/uuid"
+)
+
+// ErrRemoteFetchUnavailable is returned by UploadPhotoFromURL when no
+// RemoteFetcher is wired.
+var ErrRemoteFetchUnavailable = errors.New("photo download from URL is not configured")
+
+// RemoteFetcher downloads a user-supplied URL into w and returns the
+// response's Content-Type. Implementations must refuse internal network
+// addresses and bodies larger than MaxFileSize (see infra/urlfetch).
+type RemoteFetcher interface {
+	Fetch(ctx context.Context, rawURL string, w io.Writer) (string, error)
+}
+
+// SetRemoteFetcher enables UploadPhotoFromURL.
+func (s *Service) SetRemoteFetcher(fetcher RemoteFetcher) {
+	s.fetcher = fetcher
+}
+
+// UploadPhotoFromURL downloads an image and adds it to the item exactly as
+// an uploaded file would be: same validation, hashing and thumbnails. The
+// type is sniffed from the content, falling back to the response header.
+func (s *Service) UploadPhotoFromURL(ctx context.Context, itemID, workspaceID, userID uuid.UUID, rawURL string, caption *string) (*ItemPhoto, error) {
+	if s.fetcher == nil {
+		return nil, ErrRemoteFetchUnavailable
+	}
+
+	filename := remoteFilename(rawURL)
+	tempFile, err := os.CreateTemp(s.uploadDir, "url-*"+filepath.Ext(filename))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create temp file: %w", err)
+	}
+	tempPath := tempFile.Name()
+	defer os.Remove(tempPath)
+
+	contentType, err := s.fetcher.Fetch(ctx, rawURL, tempFile)
+	tempFile.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to download photo: %w", err)
+	}
+
+	mimeType := detectImageMimeType(tempPath)
+	if mimeType == "" {
+		mimeType, _, _ = mime.ParseMediaType(contentType)
+	}
+	if !s.acceptsMimeType(mimeType) {
+		return nil, s.fileTypeError(mimeType)
+	}
+	if isHEIC(mimeType) {
+		jpegPath, err := s.convertHEIC(ctx, tempPath)
+		if err != nil {
+			return nil, err
+		}
+		defer os.Remove(jpegPath)
+		tempPath, filename, mimeType = jpegPath, jpegFilename(filename), MimeTypeJPEG
+	}
+
+	return s.storeUpload(ctx, itemID, workspaceID, userID, nil, tempPath, filename, mimeType, caption)
+}
+
+// remoteFilename names a downloaded photo after the last segment of its URL
+// path.
+func remoteFilename(rawURL string) string {
+	if u, err := url.Parse(rawURL); err == nil {
+		if name := path.Base(u.Path); name != "." && name != "/" {
+			return name
+		}
+	}
+	return "photo"
+}
@@ -0,0 +1,87 @@
+package itemphoto_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/itemphoto"
+)
+
+// fakeFetcher serves a fixed body instead of downloading.
+type fakeFetcher struct {
+	body        []byte
+	contentType string
+	err         error
+}
+
+func (f fakeFetcher) Fetch(_ context.Context, _ string, w io.Writer) (string, error) {
+	if f.err != nil {
+		return "", f.err
+	}
+	_, err := w.Write(f.body)
+	return f.contentType, err
+}
+
+var pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+
+func TestService_UploadPhotoFromURL(t *testing.T) {
+	ctx := context.Background()
+	itemID := uuid.New()
+	workspaceID := uuid.New()
+	userID := uuid.New()
+
+	t.Run("downloads and stores photo", func(t *testing.T) {
+		repo := new(MockRepository)
+		storage := new(MockStorage)
+		processor := new(MockImageProcessor)
+
+		processor.On("Validate", ctx, mock.AnythingOfType("string")).Return(nil)
+		processor.On("GetDimensions", ctx, mock.AnythingOfType("string")).Return(640, 480, nil)
+		storage.On("Save", ctx, workspaceID.String(), itemID.String(), "drill.png", mock.Anything).Return("ws/item/drill.png", nil)
+		repo.On("CountByItem", ctx, itemID, workspaceID).Return(int64(0), nil)
+		repo.On("Create", ctx, mock.MatchedBy(func(p *itemphoto.ItemPhoto) bool {
+			return p.MimeType == itemphoto.MimeTypePNG && p.Filename == "drill.png" && p.IsPrimary
+		})).Return(&itemphoto.ItemPhoto{ID: uuid.New(), ItemID: itemID}, nil)
+
+		service := itemphoto.NewService(repo, storage, processor, os.TempDir())
+		service.SetRemoteFetcher(fakeFetcher{body: pngHeader, contentType: "application/octet-stream"})
+
+		photo, err := service.UploadPhotoFromURL(ctx, itemID, workspaceID, userID, "https://cdn.example.com/img/drill.png?v=2", nil)
+		require.NoError(t, err)
+		assert.Equal(t, itemID, photo.ItemID)
+		repo.AssertExpectations(t)
+		storage.AssertExpectations(t)
+	})
+
+	t.Run("rejects non-image content", func(t *testing.T) {
+		service := itemphoto.NewService(new(MockRepository), new(MockStorage), new(MockImageProcessor), os.TempDir())
+		service.SetRemoteFetcher(fakeFetcher{body: []byte("<html></html>"), contentType: "text/html"})
+
+		_, err := service.UploadPhotoFromURL(ctx, itemID, workspaceID, userID, "https://example.com/page", nil)
+		assert.ErrorIs(t, err, itemphoto.ErrInvalidFileType)
+	})
+
+	t.Run("returns download error", func(t *testing.T) {
+		fetchErr := errors.New("blocked")
+		service := itemphoto.NewService(new(MockRepository), new(MockStorage), new(MockImageProcessor), os.TempDir())
+		service.SetRemoteFetcher(fakeFetcher{err: fetchErr})
+
+		_, err := service.UploadPhotoFromURL(ctx, itemID, workspaceID, userID, "https://example.com/a.jpg", nil)
+		assert.ErrorIs(t, err, fetchErr)
+	})
+
+	t.Run("requires a fetcher", func(t *testing.T) {
+		service := itemphoto.NewService(new(MockRepository), new(MockStorage), new(MockImageProcessor), os.TempDir())
+
+		_, err := service.UploadPhotoFromURL(ctx, itemID, workspaceID, userID, "https://example.com/a.jpg", nil)
+		assert.ErrorIs(t, err, itemphoto.ErrRemoteFetchUnavailable)
+	})
+}
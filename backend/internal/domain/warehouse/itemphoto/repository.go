@@ -17,10 +17,28 @@ type Repository interface {
 	// GetByItem retrieves all photos for an item, ordered by display_order
 	GetByItem(ctx context.Context, itemID, workspaceID uuid.UUID) ([]*ItemPhoto, error)
 
+	// GetByInventory retrieves the photos tagged with an inventory record,
+	// ordered by display_order
+	GetByInventory(ctx context.Context, inventoryID, workspaceID uuid.UUID) ([]*ItemPhoto, error)
+
+	// ItemHasInventory reports whether the inventory record is a unit of the item
+	ItemHasInventory(ctx context.Context, itemID, inventoryID, workspaceID uuid.UUID) (bool, error)
+
+	// ItemExists reports whether the item exists in the workspace
+	ItemExists(ctx context.Context, itemID, workspaceID uuid.UUID) (bool, error)
+
 	// CountByItem returns the number of photos for an item (cheaper than
-	// GetByItem when only the count is needed, e.g. next display order).
+	// GetByItem when only the count is needed, e.g. the photo limit).
 	CountByItem(ctx context.Context, itemID, workspaceID uuid.UUID) (int64, error)
 
+	// ListItemsWithoutPhotos returns the workspace's active items that have
+	// no photo, ordered by name
+	ListItemsWithoutPhotos(ctx context.Context, workspaceID uuid.UUID) ([]IncompleteItem, error)
+
+	// MaxDisplayOrder returns the highest display_order among the item's
+	// photos, or 0 when it has none
+	MaxDisplayOrder(ctx context.Context, itemID, workspaceID uuid.UUID) (int32, error)
+
 	// GetPrimary retrieves the primary photo for an item
 	GetPrimary(ctx context.Context, itemID, workspaceID uuid.UUID) (*ItemPhoto, error)
 
@@ -35,6 +53,10 @@ type Repository interface {
 	// UpdateDisplayOrder updates the display order of a photo
 	UpdateDisplayOrder(ctx context.Context, photoID uuid.UUID, order int32) error
 
+	// MoveToItem re-points a photo to another item of the workspace at the
+	// given display order. The photo loses its primary flag and inventory tag.
+	MoveToItem(ctx context.Context, photoID, workspaceID, itemID uuid.UUID, order int32) error
+
 	// SetPrimary sets a photo as primary and unsets all other primary photos for the item
 	SetPrimary(ctx context.Context, photoID uuid.UUID) error
 
@@ -61,6 +83,16 @@ type Repository interface {
 	// GetItemPhotosWithHashes retrieves photos with hashes for a specific item
 	GetItemPhotosWithHashes(ctx context.Context, itemID, workspaceID uuid.UUID) ([]*ItemPhoto, error)
 
+	// FindByContentHash retrieves the item's oldest photo with the given
+	// SHA-256 content hash, or shared.ErrNotFound
+	FindByContentHash(ctx context.Context, itemID, workspaceID uuid.UUID, contentHash string) (*ItemPhoto, error)
+
 	// UpdatePerceptualHash sets the perceptual hash for a photo
 	UpdatePerceptualHash(ctx context.Context, id uuid.UUID, hash int64) error
+
+	// UpdateBlurHash sets the blurhash placeholder for a photo
+	UpdateBlurHash(ctx context.Context, id uuid.UUID, blurHash string) error
+
+	// UpdateThumbnailStatus sets a photo's thumbnail status and error
+	UpdateThumbnailStatus(ctx context.Context, id uuid.UUID, status ThumbnailStatus, errMsg *string) error
 }
@@ -2,6 +2,8 @@ package itemphoto
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
@@ -59,11 +61,12 @@ const (
 
 var (
 	ErrPhotoNotFound       = errors.New("photo not found")
-	ErrInvalidFileType     = errors.New("invalid file type: only JPEG, PNG, and WebP are allowed")
+	ErrInvalidFileType     = errors.New("invalid file type")
 	ErrFileTooLarge        = errors.New("file too large: maximum size is 10MB")
 	ErrItemNotFound        = errors.New("item not found")
 	ErrUnauthorized        = errors.New("unauthorized")
 	ErrInvalidDisplayOrder = errors.New("invalid display order")
+	ErrInventoryNotFound   = errors.New("inventory not found")
 )
 
 // Storage defines the interface for file storage operations
@@ -90,14 +93,22 @@ type Hasher interface {
 	GetDistance(hash1, hash2 int64) int
 }
 
+// BlurHasher defines the interface for computing blurhash placeholders
+type BlurHasher interface {
+	GenerateBlurHash(ctx context.Context, imagePath string) (string, error)
+}
+
 // ServiceInterface defines the public interface for item photo operations
 type ServiceInterface interface {
-	UploadPhoto(ctx context.Context, itemID, workspaceID, userID uuid.UUID, file multipart.File, header *multipart.FileHeader, caption *string) (*ItemPhoto, error)
+	UploadPhoto(ctx context.Context, itemID, workspaceID, userID uuid.UUID, inventoryID *uuid.UUID, file multipart.File, header *multipart.FileHeader, caption *string) (*ItemPhoto, error)
 	ListPhotos(ctx context.Context, itemID, workspaceID uuid.UUID) ([]*ItemPhoto, error)
+	ListInventoryPhotos(ctx context.Context, inventoryID, workspaceID uuid.UUID) ([]*ItemPhoto, error)
 	GetPhoto(ctx context.Context, id uuid.UUID) (*ItemPhoto, error)
 	SetPrimaryPhoto(ctx context.Context, photoID, workspaceID uuid.UUID) error
 	UpdateCaption(ctx context.Context, photoID, workspaceID uuid.UUID, caption *string) error
 	ReorderPhotos(ctx context.Context, itemID, workspaceID uuid.UUID, photoIDs []uuid.UUID) error
+	MovePhoto(ctx context.Context, photoID, workspaceID uuid.UUID, beforePhotoID *uuid.UUID) error
+	MovePhotosToItem(ctx context.Context, workspaceID uuid.UUID, photoIDs []uuid.UUID, targetItemID uuid.UUID) error
 	DeletePhoto(ctx context.Context, id, workspaceID uuid.UUID) error
 
 	// Primary-photo lookups (used by item handlers to decorate ItemResponse)
@@ -110,6 +121,15 @@ type ServiceInterface interface {
 	GetPhotosForDownload(ctx context.Context, itemID, workspaceID uuid.UUID) ([]*ItemPhoto, error)
 	GetPhotosByIDs(ctx context.Context, photoIDs []uuid.UUID, workspaceID uuid.UUID) ([]*ItemPhoto, error)
 	CheckDuplicates(ctx context.Context, workspaceID uuid.UUID, hash int64) ([]DuplicateCandidate, error)
+
+	// On-demand thumbnail generation (used when serving a missing thumbnail)
+	GenerateThumbnail(ctx context.Context, photo *ItemPhoto) (string, error)
+	RegenerateThumbnails(ctx context.Context, itemID, photoID, workspaceID uuid.UUID) (*ItemPhoto, error)
+
+	// Workspace photo settings
+	GetSettings(ctx context.Context, workspaceID uuid.UUID) (*Settings, error)
+	UpdateSettings(ctx context.Context, workspaceID uuid.UUID, settings Settings) (*Settings, error)
+	ListIncompleteItems(ctx context.Context, workspaceID uuid.UUID) (*IncompleteItemsReport, error)
 }
 
 // CaptionUpdate represents a caption update for a single photo
@@ -133,8 +153,24 @@ type Service struct {
 	storage     Storage
 	processor   ImageProcessor
 	hasher      Hasher
-	asynqClient *asynq.Client
+	blurHasher  BlurHasher
+	asynqClient TaskEnqueuer
+	fetcher     RemoteFetcher
+	settings    SettingsRepository
+	tx          Transactor
 	uploadDir   string // Base directory for temporary uploads
+
+	// dedupUploads makes re-uploading a file an item already has return the
+	// existing photo instead of storing a second copy.
+	dedupUploads bool
+
+	// allowedTypes are the accepted upload MIME types (nil means
+	// AllowedMimeTypes); heic converts HEIC/HEIF uploads to JPEG.
+	allowedTypes []string
+	heic         HEICConverter
+
+	// onDemand generates missing thumbnails while serving; nil disables it.
+	onDemand *onDemandThumbnails
 }
 
 // NewService creates a new item photo service
@@ -143,13 +179,19 @@ func NewService(repo Repository, storage Storage, processor ImageProcessor, uplo
 		repo:      repo,
 		storage:   storage,
 		processor: processor,
+		tx:        noopTransactor{},
 		uploadDir: uploadDir,
 	}
 }
 
+// TaskEnqueuer queues background tasks; *asynq.Client satisfies it.
+type TaskEnqueuer interface {
+	Enqueue(task *asynq.Task, opts ...asynq.Option) (*asynq.TaskInfo, error)
+}
+
 // SetAsynqClient sets the asynq client for background job enqueuing.
 // This is optional - if not set, thumbnails will not be generated in background.
-func (s *Service) SetAsynqClient(client *asynq.Client) {
+func (s *Service) SetAsynqClient(client TaskEnqueuer) {
 	s.asynqClient = client
 }
 
@@ -159,8 +201,22 @@ func (s *Service) SetHasher(hasher Hasher) {
 	s.hasher = hasher
 }
 
-// UploadPhoto uploads a new photo for an item
-func (s *Service) UploadPhoto(ctx context.Context, itemID, workspaceID, userID uuid.UUID, file multipart.File, header *multipart.FileHeader, caption *string) (*ItemPhoto, error) {
+// SetBlurHasher sets the blurhash encoder used for placeholder generation.
+// This is optional - if not set, photos are stored without a blurhash.
+func (s *Service) SetBlurHasher(blurHasher BlurHasher) {
+	s.blurHasher = blurHasher
+}
+
+// SetDeduplicateUploads enables or disables returning the existing photo
+// when identical file content is uploaded to the same item again.
+// Disabled by default.
+func (s *Service) SetDeduplicateUploads(enabled bool) {
+	s.dedupUploads = enabled
+}
+
+// UploadPhoto uploads a new photo for an item. A non-nil inventoryID tags the
+// photo with that unit of the item; it still joins the item's gallery.
+func (s *Service) UploadPhoto(ctx context.Context, itemID, workspaceID, userID uuid.UUID, inventoryID *uuid.UUID, file multipart.File, header *multipart.FileHeader, caption *string) (*ItemPhoto, error) {
 	// Validate file size
 	if header.Size > MaxFileSize {
 		return nil, ErrFileTooLarge
@@ -168,8 +224,8 @@ func (s *Service) UploadPhoto(ctx context.Context, itemID, workspaceID, userID u
 
 	// Validate MIME type
 	mimeType := header.Header.Get("Content-Type")
-	if !isValidMimeType(mimeType) {
-		return nil, ErrInvalidFileType
+	if !s.acceptsMimeType(mimeType) {
+		return nil, s.fileTypeError(mimeType)
 	}
 
 	// Create temporary file for image processing
@@ -187,13 +243,68 @@ func (s *Service) UploadPhoto(ctx context.Context, itemID, workspaceID, userID u
 	}
 	tempFile.Close()
 
+	filename := header.Filename
+	if isHEIC(mimeType) {
+		jpegPath, err := s.convertHEIC(ctx, tempPath)
+		if err != nil {
+			return nil, err
+		}
+		defer os.Remove(jpegPath)
+		tempPath, filename, mimeType = jpegPath, jpegFilename(filename), MimeTypeJPEG
+	}
+
+	return s.storeUpload(ctx, itemID, workspaceID, userID, inventoryID, tempPath, filename, mimeType, caption)
+}
+
+// storeUpload validates the image at tempPath and saves it as a new photo of
+// the item. The caller owns tempPath and removes it afterwards.
+func (s *Service) storeUpload(ctx context.Context, itemID, workspaceID, userID uuid.UUID, inventoryID *uuid.UUID, tempPath, filename, mimeType string, caption *string) (*ItemPhoto, error) {
+	if inventoryID != nil {
+		ok, err := s.repo.ItemHasInventory(ctx, itemID, *inventoryID, workspaceID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check inventory: %w", err)
+		}
+		if !ok {
+			return nil, ErrInventoryNotFound
+		}
+	}
+
+	contentHash, err := hashFileContent(tempPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash upload: %w", err)
+	}
+	if s.dedupUploads {
+		existing, err := s.repo.FindByContentHash(ctx, itemID, workspaceID, contentHash)
+		if err == nil {
+			return existing, nil
+		}
+		if !errors.Is(err, shared.ErrNotFound) {
+			return nil, fmt.Errorf("failed to check for duplicate upload: %w", err)
+		}
+	}
+
+	// Count once up front: the same number gates the workspace photo limit
+	// and decides primary status. COUNT instead of loading full photo rows —
+	// it counts exactly what ListPhotos returns.
+	existingCount, err := s.repo.CountByItem(ctx, itemID, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count existing photos: %w", err)
+	}
+	if err := s.checkPhotoLimit(ctx, workspaceID, existingCount); err != nil {
+		return nil, err
+	}
+
 	// Validate image
 	if err := s.processor.Validate(ctx, tempPath); err != nil {
 		return nil, fmt.Errorf("invalid image: %w", err)
 	}
 
-	// Prefer the detected content type over the client-supplied header
+	// Prefer the detected content type over the client-supplied header, but
+	// do not let a mislabelled file slip past the allowed set
 	if detected := detectImageMimeType(tempPath); detected != "" {
+		if detected != mimeType && !s.acceptsMimeType(detected) {
+			return nil, s.fileTypeError(detected)
+		}
 		mimeType = detected
 	}
 
@@ -210,7 +321,7 @@ func (s *Service) UploadPhoto(ctx context.Context, itemID, workspaceID, userID u
 	}
 	defer fileReader.Close()
 
-	storagePath, err := s.storage.Save(ctx, workspaceID.String(), itemID.String(), header.Filename, fileReader)
+	storagePath, err := s.storage.Save(ctx, workspaceID.String(), itemID.String(), filename, fileReader)
 	if err != nil {
 		return nil, fmt.Errorf("failed to save file: %w", err)
 	}
@@ -222,15 +333,12 @@ func (s *Service) UploadPhoto(ctx context.Context, itemID, workspaceID, userID u
 		return nil, fmt.Errorf("failed to get file info: %w", err)
 	}
 
-	// Get the next display order (append to end). COUNT instead of loading
-	// full photo rows — only the count is needed here.
-	existingCount, err := s.repo.CountByItem(ctx, itemID, workspaceID)
+	// Append to the end of the gallery.
+	displayOrder, err := s.nextDisplayOrder(ctx, itemID, workspaceID, existingCount)
 	if err != nil {
 		s.storage.Delete(ctx, storagePath)
-		return nil, fmt.Errorf("failed to count existing photos: %w", err)
+		return nil, err
 	}
-
-	displayOrder := int32(existingCount)
 	isPrimary := existingCount == 0 // First photo is primary by default
 
 	// Create photo record with pending thumbnail status
@@ -239,7 +347,8 @@ func (s *Service) UploadPhoto(ctx context.Context, itemID, workspaceID, userID u
 		ID:              uuid.New(),
 		ItemID:          itemID,
 		WorkspaceID:     workspaceID,
-		Filename:        sanitizeUploadFilename(header.Filename),
+		InventoryID:     inventoryID,
+		Filename:        sanitizeUploadFilename(filename),
 		StoragePath:     storagePath,
 		ThumbnailPath:   "", // Legacy field - empty for async processing
 		FileSize:        fileInfo.Size(),
@@ -251,6 +360,7 @@ func (s *Service) UploadPhoto(ctx context.Context, itemID, workspaceID, userID u
 		Caption:         caption,
 		UploadedBy:      userID,
 		ThumbnailStatus: ThumbnailStatusPending,
+		ContentHash:     &contentHash,
 	}
 
 	// Validate photo entity
@@ -266,14 +376,31 @@ func (s *Service) UploadPhoto(ctx context.Context, itemID, workspaceID, userID u
 		return nil, fmt.Errorf("failed to save photo to database: %w", err)
 	}
 
-	// Compute the perceptual hash (sync, before temp cleanup) and enqueue async
-	// thumbnail generation; both are best-effort and never fail the upload.
+	// Compute the perceptual hash and blurhash (sync, before temp cleanup) and
+	// enqueue async thumbnail generation; all are best-effort and never fail
+	// the upload.
 	s.generatePerceptualHash(ctx, createdPhoto, tempPath)
+	s.generateBlurHash(ctx, createdPhoto, tempPath)
 	s.enqueueThumbnailJob(createdPhoto, workspaceID, itemID, storagePath)
 
 	return createdPhoto, nil
 }
 
+// hashFileContent returns the hex SHA-256 of the file at path.
+func hashFileContent(path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(h.Sum(nil)), nil
+}
+
 // generatePerceptualHash computes and stores the photo's perceptual hash for
 // duplicate detection. Best-effort: failures are logged, not fatal.
 func (s *Service) generatePerceptualHash(ctx context.Context, photo *ItemPhoto, tempPath string) {
@@ -292,6 +419,24 @@ func (s *Service) generatePerceptualHash(ctx context.Context, photo *ItemPhoto,
 	photo.PerceptualHash = &hash
 }
 
+// generateBlurHash computes and stores the photo's blurhash placeholder.
+// Best-effort: failures are logged, not fatal.
+func (s *Service) generateBlurHash(ctx context.Context, photo *ItemPhoto, tempPath string) {
+	if s.blurHasher == nil {
+		return
+	}
+	blurHash, err := s.blurHasher.GenerateBlurHash(ctx, tempPath)
+	if err != nil {
+		log.Printf("Failed to generate blurhash for photo %s: %v", photo.ID, err)
+		return
+	}
+	if err := s.repo.UpdateBlurHash(ctx, photo.ID, blurHash); err != nil {
+		log.Printf("Failed to save blurhash for photo %s: %v", photo.ID, err)
+		return
+	}
+	photo.BlurHash = &blurHash
+}
+
 // enqueueThumbnailJob schedules async thumbnail generation. Best-effort: a
 // failure is logged but the photo is still usable, so the upload succeeds.
 func (s *Service) enqueueThumbnailJob(photo *ItemPhoto, workspaceID, itemID uuid.UUID, storagePath string) {
@@ -313,6 +458,17 @@ func (s *Service) ListPhotos(ctx context.Context, itemID, workspaceID uuid.UUID)
 	return photos, nil
 }
 
+// ListInventoryPhotos returns the photos tagged with an inventory record.
+// They are also part of the item's gallery, so primary status still refers to
+// the item.
+func (s *Service) ListInventoryPhotos(ctx context.Context, inventoryID, workspaceID uuid.UUID) ([]*ItemPhoto, error) {
+	photos, err := s.repo.GetByInventory(ctx, inventoryID, workspaceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list inventory photos: %w", err)
+	}
+	return photos, nil
+}
+
 // fetchPhoto loads a photo by ID, translating the repository's generic not-found
 // (shared.ErrNotFound, or a nil row) into the domain-level ErrPhotoNotFound the
 // handlers map to 404. Without this translation the raw shared.ErrNotFound leaks
@@ -376,7 +532,8 @@ func (s *Service) UpdateCaption(ctx context.Context, photoID, workspaceID uuid.U
 	return nil
 }
 
-// ReorderPhotos updates the display order of photos for an item
+// ReorderPhotos sets the full display order of an item's photos, spacing
+// them DisplayOrderGap apart. Use MovePhoto to move a single photo.
 func (s *Service) ReorderPhotos(ctx context.Context, itemID, workspaceID uuid.UUID, photoIDs []uuid.UUID) error {
 	// Get all photos for the item
 	existingPhotos, err := s.repo.GetByItem(ctx, itemID, workspaceID)
@@ -401,14 +558,16 @@ func (s *Service) ReorderPhotos(ctx context.Context, itemID, workspaceID uuid.UU
 		return ErrInvalidDisplayOrder
 	}
 
-	// Update display order for each photo
+	byID := make(map[uuid.UUID]*ItemPhoto, len(existingPhotos))
+	for _, photo := range existingPhotos {
+		byID[photo.ID] = photo
+	}
+	ordered := make([]*ItemPhoto, len(photoIDs))
 	for i, photoID := range photoIDs {
-		if err := s.repo.UpdateDisplayOrder(ctx, photoID, int32(i)); err != nil {
-			return fmt.Errorf("failed to update display order: %w", err)
-		}
+		ordered[i] = byID[photoID]
 	}
 
-	return nil
+	return s.renumberPhotos(ctx, ordered)
 }
 
 // DeletePhoto deletes a photo and its files from storage
@@ -545,16 +704,7 @@ func (s *Service) deletePhotoFiles(ctx context.Context, photo *ItemPhoto) {
 // reassignPrimaryIfNeeded promotes the first remaining photo to primary when a
 // delete left the item with photos but none flagged primary. Best-effort.
 func (s *Service) reassignPrimaryIfNeeded(ctx context.Context, itemID, workspaceID uuid.UUID) {
-	remainingPhotos, err := s.repo.GetByItem(ctx, itemID, workspaceID)
-	if err != nil || len(remainingPhotos) == 0 {
-		return
-	}
-	for _, p := range remainingPhotos {
-		if p.IsPrimary {
-			return
-		}
-	}
-	_ = s.repo.SetPrimary(ctx, remainingPhotos[0].ID)
+	_ = s.ensurePrimary(ctx, itemID, workspaceID)
 }
 
 // BulkUpdateCaptions updates captions for multiple photos
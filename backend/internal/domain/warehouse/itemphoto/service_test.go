@@ -3,6 +3,8 @@ package itemphoto_test
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"io"
 	"mime/multipart"
@@ -16,6 +18,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/itemphoto"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
 )
 
 // MockRepository implements itemphoto.Repository for testing
@@ -47,6 +50,24 @@ func (m *MockRepository) GetByItem(ctx context.Context, itemID, workspaceID uuid
 	return args.Get(0).([]*itemphoto.ItemPhoto), args.Error(1)
 }
 
+func (m *MockRepository) GetByInventory(ctx context.Context, inventoryID, workspaceID uuid.UUID) ([]*itemphoto.ItemPhoto, error) {
+	args := m.Called(ctx, inventoryID, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*itemphoto.ItemPhoto), args.Error(1)
+}
+
+func (m *MockRepository) ItemHasInventory(ctx context.Context, itemID, inventoryID, workspaceID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, itemID, inventoryID, workspaceID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockRepository) ItemExists(ctx context.Context, itemID, workspaceID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, itemID, workspaceID)
+	return args.Bool(0), args.Error(1)
+}
+
 func (m *MockRepository) CountByItem(ctx context.Context, itemID, workspaceID uuid.UUID) (int64, error) {
 	args := m.Called(ctx, itemID, workspaceID)
 	if args.Get(0) == nil {
@@ -55,6 +76,22 @@ func (m *MockRepository) CountByItem(ctx context.Context, itemID, workspaceID uu
 	return args.Get(0).(int64), args.Error(1)
 }
 
+func (m *MockRepository) ListItemsWithoutPhotos(ctx context.Context, workspaceID uuid.UUID) ([]itemphoto.IncompleteItem, error) {
+	args := m.Called(ctx, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]itemphoto.IncompleteItem), args.Error(1)
+}
+
+func (m *MockRepository) MaxDisplayOrder(ctx context.Context, itemID, workspaceID uuid.UUID) (int32, error) {
+	args := m.Called(ctx, itemID, workspaceID)
+	if args.Get(0) == nil {
+		return 0, args.Error(1)
+	}
+	return args.Get(0).(int32), args.Error(1)
+}
+
 func (m *MockRepository) GetPrimary(ctx context.Context, itemID, workspaceID uuid.UUID) (*itemphoto.ItemPhoto, error) {
 	args := m.Called(ctx, itemID, workspaceID)
 	if args.Get(0) == nil {
@@ -81,6 +118,11 @@ func (m *MockRepository) UpdateDisplayOrder(ctx context.Context, id uuid.UUID, o
 	return args.Error(0)
 }
 
+func (m *MockRepository) MoveToItem(ctx context.Context, photoID, workspaceID, itemID uuid.UUID, order int32) error {
+	args := m.Called(ctx, photoID, workspaceID, itemID, order)
+	return args.Error(0)
+}
+
 func (m *MockRepository) SetPrimary(ctx context.Context, id uuid.UUID) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
@@ -126,11 +168,29 @@ func (m *MockRepository) GetItemPhotosWithHashes(ctx context.Context, itemID, wo
 	return mockSliceErrGuarded[*itemphoto.ItemPhoto](args)
 }
 
+func (m *MockRepository) FindByContentHash(ctx context.Context, itemID, workspaceID uuid.UUID, contentHash string) (*itemphoto.ItemPhoto, error) {
+	args := m.Called(ctx, itemID, workspaceID, contentHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*itemphoto.ItemPhoto), args.Error(1)
+}
+
 func (m *MockRepository) UpdatePerceptualHash(ctx context.Context, id uuid.UUID, hash int64) error {
 	args := m.Called(ctx, id, hash)
 	return args.Error(0)
 }
 
+func (m *MockRepository) UpdateBlurHash(ctx context.Context, id uuid.UUID, blurHash string) error {
+	args := m.Called(ctx, id, blurHash)
+	return args.Error(0)
+}
+
+func (m *MockRepository) UpdateThumbnailStatus(ctx context.Context, id uuid.UUID, status itemphoto.ThumbnailStatus, errMsg *string) error {
+	args := m.Called(ctx, id, status, errMsg)
+	return args.Error(0)
+}
+
 // MockStorage implements itemphoto.Storage for testing
 type MockStorage struct {
 	mock.Mock
@@ -263,6 +323,40 @@ func TestService_ListPhotos(t *testing.T) {
 	})
 }
 
+func TestService_ListInventoryPhotos(t *testing.T) {
+	ctx := context.Background()
+	itemID := uuid.New()
+	workspaceID := uuid.New()
+	inventoryID := uuid.New()
+
+	t.Run("returns only the unit's photos", func(t *testing.T) {
+		repo := new(MockRepository)
+
+		photo := createServiceTestPhoto(t, itemID, workspaceID)
+		photo.InventoryID = &inventoryID
+		repo.On("GetByInventory", ctx, inventoryID, workspaceID).Return([]*itemphoto.ItemPhoto{photo}, nil)
+
+		service := itemphoto.NewService(repo, new(MockStorage), new(MockImageProcessor), os.TempDir())
+		result, err := service.ListInventoryPhotos(ctx, inventoryID, workspaceID)
+
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.Equal(t, &inventoryID, result[0].InventoryID)
+		repo.AssertNotCalled(t, "GetByItem", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("returns error when repository fails", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("GetByInventory", ctx, inventoryID, workspaceID).Return(nil, errors.New("database error"))
+
+		service := itemphoto.NewService(repo, new(MockStorage), new(MockImageProcessor), os.TempDir())
+		result, err := service.ListInventoryPhotos(ctx, inventoryID, workspaceID)
+
+		require.Error(t, err)
+		assert.Nil(t, result)
+	})
+}
+
 func TestService_GetPhoto(t *testing.T) {
 	ctx := context.Background()
 	itemID := uuid.New()
@@ -453,8 +547,8 @@ func TestService_ReorderPhotos(t *testing.T) {
 
 		repo.On("GetByItem", ctx, itemID, workspaceID).Return(existingPhotos, nil)
 		repo.On("UpdateDisplayOrder", ctx, photo3.ID, int32(0)).Return(nil)
-		repo.On("UpdateDisplayOrder", ctx, photo1.ID, int32(1)).Return(nil)
-		repo.On("UpdateDisplayOrder", ctx, photo2.ID, int32(2)).Return(nil)
+		repo.On("UpdateDisplayOrder", ctx, photo1.ID, itemphoto.DisplayOrderGap).Return(nil)
+		repo.On("UpdateDisplayOrder", ctx, photo2.ID, 2*itemphoto.DisplayOrderGap).Return(nil)
 
 		service := itemphoto.NewService(repo, storage, processor, os.TempDir())
 		err := service.ReorderPhotos(ctx, itemID, workspaceID, newOrder)
@@ -639,7 +733,7 @@ func TestService_UploadPhoto_Validation(t *testing.T) {
 		header.Header.Set("Content-Type", "image/jpeg")
 
 		service := itemphoto.NewService(repo, storage, processor, os.TempDir())
-		result, err := service.UploadPhoto(ctx, itemID, workspaceID, userID, nil, header, nil)
+		result, err := service.UploadPhoto(ctx, itemID, workspaceID, userID, nil, nil, header, nil)
 
 		require.Error(t, err)
 		assert.Equal(t, itemphoto.ErrFileTooLarge, err)
@@ -659,10 +753,10 @@ func TestService_UploadPhoto_Validation(t *testing.T) {
 		header.Header.Set("Content-Type", "application/pdf")
 
 		service := itemphoto.NewService(repo, storage, processor, os.TempDir())
-		result, err := service.UploadPhoto(ctx, itemID, workspaceID, userID, nil, header, nil)
+		result, err := service.UploadPhoto(ctx, itemID, workspaceID, userID, nil, nil, header, nil)
 
 		require.Error(t, err)
-		assert.Equal(t, itemphoto.ErrInvalidFileType, err)
+		assert.ErrorIs(t, err, itemphoto.ErrInvalidFileType)
 		assert.Nil(t, result)
 	})
 }
@@ -1293,6 +1387,7 @@ func TestService_ReorderPhotos_Errors(t *testing.T) {
 		processor := new(MockImageProcessor)
 
 		photo1 := createServiceTestPhoto(t, itemID, workspaceID)
+		photo1.DisplayOrder = 5
 		existingPhotos := []*itemphoto.ItemPhoto{photo1}
 		newOrder := []uuid.UUID{photo1.ID}
 
@@ -1493,7 +1588,7 @@ func TestService_UploadPhoto_FullFlow(t *testing.T) {
 		}, nil)
 
 		service := itemphoto.NewService(repo, storage, processor, tmpDir)
-		result, err := service.UploadPhoto(ctx, itemID, workspaceID, userID, file, header, nil)
+		result, err := service.UploadPhoto(ctx, itemID, workspaceID, userID, nil, file, header, nil)
 
 		require.NoError(t, err)
 		require.NotNil(t, result)
@@ -1525,29 +1620,30 @@ func TestService_UploadPhoto_FullFlow(t *testing.T) {
 		processor.On("Validate", ctx, mock.AnythingOfType("string")).Return(nil)
 		processor.On("GetDimensions", ctx, mock.AnythingOfType("string")).Return(800, 600, nil)
 		storage.On("Save", ctx, workspaceID.String(), itemID.String(), "second-image.jpg", mock.Anything).Return("photos/second.jpg", nil)
-		// One existing photo -> next display order 1, not primary.
+		// One existing photo -> appended one gap after it, not primary.
 		repo.On("CountByItem", ctx, itemID, workspaceID).Return(int64(1), nil)
+		repo.On("MaxDisplayOrder", ctx, itemID, workspaceID).Return(int32(0), nil)
 		repo.On("Create", ctx, mock.MatchedBy(func(p *itemphoto.ItemPhoto) bool {
 			return p.IsPrimary == false && // Second photo should NOT be primary
-				p.DisplayOrder == 1 && // Should be after first photo
+				p.DisplayOrder == itemphoto.DisplayOrderGap && // Should be after first photo
 				p.ThumbnailStatus == itemphoto.ThumbnailStatusPending // Async status
 		})).Return(&itemphoto.ItemPhoto{
 			ID:              uuid.New(),
 			ItemID:          itemID,
 			WorkspaceID:     workspaceID,
 			IsPrimary:       false,
-			DisplayOrder:    1,
+			DisplayOrder:    itemphoto.DisplayOrderGap,
 			ThumbnailStatus: itemphoto.ThumbnailStatusPending,
 			UploadedBy:      userID,
 		}, nil)
 
 		service := itemphoto.NewService(repo, storage, processor, tmpDir)
-		result, err := service.UploadPhoto(ctx, itemID, workspaceID, userID, file, header, nil)
+		result, err := service.UploadPhoto(ctx, itemID, workspaceID, userID, nil, file, header, nil)
 
 		require.NoError(t, err)
 		require.NotNil(t, result)
 		assert.False(t, result.IsPrimary)
-		assert.Equal(t, int32(1), result.DisplayOrder)
+		assert.Equal(t, itemphoto.DisplayOrderGap, result.DisplayOrder)
 	})
 
 	t.Run("upload with caption", func(t *testing.T) {
@@ -1585,7 +1681,7 @@ func TestService_UploadPhoto_FullFlow(t *testing.T) {
 		}, nil)
 
 		service := itemphoto.NewService(repo, storage, processor, tmpDir)
-		result, err := service.UploadPhoto(ctx, itemID, workspaceID, userID, file, header, &caption)
+		result, err := service.UploadPhoto(ctx, itemID, workspaceID, userID, nil, file, header, &caption)
 
 		require.NoError(t, err)
 		require.NotNil(t, result)
@@ -1593,6 +1689,80 @@ func TestService_UploadPhoto_FullFlow(t *testing.T) {
 		assert.Equal(t, caption, *result.Caption)
 	})
 
+	t.Run("upload to an inventory record", func(t *testing.T) {
+		ctx := context.Background()
+		repo := new(MockRepository)
+		storage := new(MockStorage)
+		processor := new(MockImageProcessor)
+		inventoryID := uuid.New()
+
+		testContent := []byte("photo of the scratched unit")
+		file := &mockFile{bytes.NewReader(testContent)}
+
+		header := &multipart.FileHeader{
+			Filename: "scratch.jpg",
+			Size:     int64(len(testContent)),
+			Header:   make(map[string][]string),
+		}
+		header.Header.Set("Content-Type", "image/jpeg")
+
+		repo.On("ItemHasInventory", ctx, itemID, inventoryID, workspaceID).Return(true, nil)
+		processor.On("Validate", ctx, mock.AnythingOfType("string")).Return(nil)
+		processor.On("GetDimensions", ctx, mock.AnythingOfType("string")).Return(640, 480, nil)
+		storage.On("Save", ctx, workspaceID.String(), itemID.String(), "scratch.jpg", mock.Anything).Return("photos/scratch.jpg", nil)
+		// Primary status is still decided by the item's whole gallery.
+		repo.On("CountByItem", ctx, itemID, workspaceID).Return(int64(1), nil)
+		repo.On("MaxDisplayOrder", ctx, itemID, workspaceID).Return(int32(0), nil)
+		repo.On("Create", ctx, mock.MatchedBy(func(p *itemphoto.ItemPhoto) bool {
+			return p.ItemID == itemID &&
+				p.InventoryID != nil && *p.InventoryID == inventoryID &&
+				!p.IsPrimary
+		})).Return(&itemphoto.ItemPhoto{
+			ID:              uuid.New(),
+			ItemID:          itemID,
+			WorkspaceID:     workspaceID,
+			InventoryID:     &inventoryID,
+			ThumbnailStatus: itemphoto.ThumbnailStatusPending,
+			UploadedBy:      userID,
+		}, nil)
+
+		service := itemphoto.NewService(repo, storage, processor, tmpDir)
+		result, err := service.UploadPhoto(ctx, itemID, workspaceID, userID, &inventoryID, file, header, nil)
+
+		require.NoError(t, err)
+		require.NotNil(t, result)
+		assert.Equal(t, &inventoryID, result.InventoryID)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("rejects an inventory record of another item", func(t *testing.T) {
+		ctx := context.Background()
+		repo := new(MockRepository)
+		storage := new(MockStorage)
+		processor := new(MockImageProcessor)
+		inventoryID := uuid.New()
+
+		testContent := []byte("photo of some other unit")
+		file := &mockFile{bytes.NewReader(testContent)}
+
+		header := &multipart.FileHeader{
+			Filename: "other.jpg",
+			Size:     int64(len(testContent)),
+			Header:   make(map[string][]string),
+		}
+		header.Header.Set("Content-Type", "image/jpeg")
+
+		repo.On("ItemHasInventory", ctx, itemID, inventoryID, workspaceID).Return(false, nil)
+
+		service := itemphoto.NewService(repo, storage, processor, tmpDir)
+		result, err := service.UploadPhoto(ctx, itemID, workspaceID, userID, &inventoryID, file, header, nil)
+
+		assert.ErrorIs(t, err, itemphoto.ErrInventoryNotFound)
+		assert.Nil(t, result)
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		storage.AssertNotCalled(t, "Save", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
 	t.Run("fails when image validation fails", func(t *testing.T) {
 		ctx := context.Background()
 		repo := new(MockRepository)
@@ -1609,10 +1779,11 @@ func TestService_UploadPhoto_FullFlow(t *testing.T) {
 		}
 		header.Header.Set("Content-Type", "image/jpeg")
 
+		repo.On("CountByItem", ctx, itemID, workspaceID).Return(int64(0), nil)
 		processor.On("Validate", ctx, mock.AnythingOfType("string")).Return(errors.New("invalid image format"))
 
 		service := itemphoto.NewService(repo, storage, processor, tmpDir)
-		result, err := service.UploadPhoto(ctx, itemID, workspaceID, userID, file, header, nil)
+		result, err := service.UploadPhoto(ctx, itemID, workspaceID, userID, nil, file, header, nil)
 
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "invalid image")
@@ -1635,11 +1806,12 @@ func TestService_UploadPhoto_FullFlow(t *testing.T) {
 		}
 		header.Header.Set("Content-Type", "image/jpeg")
 
+		repo.On("CountByItem", ctx, itemID, workspaceID).Return(int64(0), nil)
 		processor.On("Validate", ctx, mock.AnythingOfType("string")).Return(nil)
 		processor.On("GetDimensions", ctx, mock.AnythingOfType("string")).Return(0, 0, errors.New("failed to read dimensions"))
 
 		service := itemphoto.NewService(repo, storage, processor, tmpDir)
-		result, err := service.UploadPhoto(ctx, itemID, workspaceID, userID, file, header, nil)
+		result, err := service.UploadPhoto(ctx, itemID, workspaceID, userID, nil, file, header, nil)
 
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to get image dimensions")
@@ -1662,12 +1834,13 @@ func TestService_UploadPhoto_FullFlow(t *testing.T) {
 		}
 		header.Header.Set("Content-Type", "image/jpeg")
 
+		repo.On("CountByItem", ctx, itemID, workspaceID).Return(int64(0), nil)
 		processor.On("Validate", ctx, mock.AnythingOfType("string")).Return(nil)
 		processor.On("GetDimensions", ctx, mock.AnythingOfType("string")).Return(800, 600, nil)
 		storage.On("Save", ctx, workspaceID.String(), itemID.String(), "test.jpg", mock.Anything).Return("", errors.New("storage full"))
 
 		service := itemphoto.NewService(repo, storage, processor, tmpDir)
-		result, err := service.UploadPhoto(ctx, itemID, workspaceID, userID, file, header, nil)
+		result, err := service.UploadPhoto(ctx, itemID, workspaceID, userID, nil, file, header, nil)
 
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to save file")
@@ -1677,7 +1850,7 @@ func TestService_UploadPhoto_FullFlow(t *testing.T) {
 	// Note: "cleans up original when thumbnail generation fails" and "cleans up files when thumbnail storage fails"
 	// test cases removed - thumbnails are now generated asynchronously, so these failures don't happen during upload
 
-	t.Run("cleans up files when CountByItem fails", func(t *testing.T) {
+	t.Run("stores nothing when CountByItem fails", func(t *testing.T) {
 		ctx := context.Background()
 		repo := new(MockRepository)
 		storage := new(MockStorage)
@@ -1693,21 +1866,16 @@ func TestService_UploadPhoto_FullFlow(t *testing.T) {
 		}
 		header.Header.Set("Content-Type", "image/jpeg")
 
-		originalPath := "photos/original.jpg"
-
-		// Note: No thumbnail mocks - thumbnails are generated asynchronously
-		processor.On("Validate", ctx, mock.AnythingOfType("string")).Return(nil)
-		processor.On("GetDimensions", ctx, mock.AnythingOfType("string")).Return(800, 600, nil)
-		storage.On("Save", ctx, workspaceID.String(), itemID.String(), "test.jpg", mock.Anything).Return(originalPath, nil)
+		// The count runs before the image is validated or stored.
 		repo.On("CountByItem", ctx, itemID, workspaceID).Return(nil, errors.New("database error"))
-		storage.On("Delete", ctx, originalPath).Return(nil)
 
 		service := itemphoto.NewService(repo, storage, processor, tmpDir)
-		result, err := service.UploadPhoto(ctx, itemID, workspaceID, userID, file, header, nil)
+		result, err := service.UploadPhoto(ctx, itemID, workspaceID, userID, nil, file, header, nil)
 
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to count existing photos")
 		assert.Nil(t, result)
+		storage.AssertNotCalled(t, "Save", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
 	})
 
 	t.Run("cleans up files when repository Create fails", func(t *testing.T) {
@@ -1737,7 +1905,7 @@ func TestService_UploadPhoto_FullFlow(t *testing.T) {
 		storage.On("Delete", ctx, originalPath).Return(nil)
 
 		service := itemphoto.NewService(repo, storage, processor, tmpDir)
-		result, err := service.UploadPhoto(ctx, itemID, workspaceID, userID, file, header, nil)
+		result, err := service.UploadPhoto(ctx, itemID, workspaceID, userID, nil, file, header, nil)
 
 		require.Error(t, err)
 		assert.Contains(t, err.Error(), "failed to save photo to database")
@@ -1776,7 +1944,7 @@ func TestService_UploadPhoto_FullFlow(t *testing.T) {
 		}, nil)
 
 		service := itemphoto.NewService(repo, storage, processor, tmpDir)
-		result, err := service.UploadPhoto(ctx, itemID, workspaceID, userID, file, header, nil)
+		result, err := service.UploadPhoto(ctx, itemID, workspaceID, userID, nil, file, header, nil)
 
 		require.NoError(t, err)
 		require.NotNil(t, result)
@@ -1814,7 +1982,7 @@ func TestService_UploadPhoto_FullFlow(t *testing.T) {
 		}, nil)
 
 		service := itemphoto.NewService(repo, storage, processor, tmpDir)
-		result, err := service.UploadPhoto(ctx, itemID, workspaceID, userID, file, header, nil)
+		result, err := service.UploadPhoto(ctx, itemID, workspaceID, userID, nil, file, header, nil)
 
 		require.NoError(t, err)
 		require.NotNil(t, result)
@@ -1834,14 +2002,163 @@ func TestService_UploadPhoto_FullFlow(t *testing.T) {
 		header.Header.Set("Content-Type", "image/gif")
 
 		service := itemphoto.NewService(repo, storage, processor, tmpDir)
-		result, err := service.UploadPhoto(ctx, itemID, workspaceID, userID, nil, header, nil)
+		result, err := service.UploadPhoto(ctx, itemID, workspaceID, userID, nil, nil, header, nil)
 
 		require.Error(t, err)
-		assert.Equal(t, itemphoto.ErrInvalidFileType, err)
+		assert.ErrorIs(t, err, itemphoto.ErrInvalidFileType)
 		assert.Nil(t, result)
 	})
 }
 
+func TestService_UploadPhoto_RemovesTempFileOnFailure(t *testing.T) {
+	ctx := context.Background()
+	itemID, workspaceID, userID := uuid.New(), uuid.New(), uuid.New()
+
+	upload := func() (multipart.File, *multipart.FileHeader) {
+		content := []byte("fake jpeg image content")
+		header := &multipart.FileHeader{
+			Filename: "broken.jpg",
+			Size:     int64(len(content)),
+			Header:   make(map[string][]string),
+		}
+		header.Header.Set("Content-Type", "image/jpeg")
+		return &mockFile{bytes.NewReader(content)}, header
+	}
+
+	t.Run("invalid image", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		repo, processor := new(MockRepository), new(MockImageProcessor)
+		repo.On("CountByItem", ctx, itemID, workspaceID).Return(int64(0), nil)
+		processor.On("Validate", ctx, mock.AnythingOfType("string")).Return(errors.New("corrupt image"))
+		service := itemphoto.NewService(repo, new(MockStorage), processor, tmpDir)
+
+		file, header := upload()
+		_, err := service.UploadPhoto(ctx, itemID, workspaceID, userID, nil, file, header, nil)
+
+		require.Error(t, err)
+		entries, err := os.ReadDir(tmpDir)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("storage failure", func(t *testing.T) {
+		tmpDir := t.TempDir()
+		repo, processor, storage := new(MockRepository), new(MockImageProcessor), new(MockStorage)
+		repo.On("CountByItem", ctx, itemID, workspaceID).Return(int64(0), nil)
+		processor.On("Validate", ctx, mock.AnythingOfType("string")).Return(nil)
+		processor.On("GetDimensions", ctx, mock.AnythingOfType("string")).Return(640, 480, nil)
+		storage.On("Save", ctx, workspaceID.String(), itemID.String(), "broken.jpg", mock.Anything).Return("", errors.New("disk full"))
+		service := itemphoto.NewService(repo, storage, processor, tmpDir)
+
+		file, header := upload()
+		_, err := service.UploadPhoto(ctx, itemID, workspaceID, userID, nil, file, header, nil)
+
+		require.Error(t, err)
+		entries, err := os.ReadDir(tmpDir)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+}
+
+func TestService_UploadPhoto_Deduplication(t *testing.T) {
+	itemID := uuid.New()
+	workspaceID := uuid.New()
+	userID := uuid.New()
+
+	testContent := []byte("fake jpeg image content for dedup")
+	sum := sha256.Sum256(testContent)
+	contentHash := hex.EncodeToString(sum[:])
+
+	newUpload := func() (multipart.File, *multipart.FileHeader) {
+		header := &multipart.FileHeader{
+			Filename: "drill.jpg",
+			Size:     int64(len(testContent)),
+			Header:   make(map[string][]string),
+		}
+		header.Header.Set("Content-Type", "image/jpeg")
+		return &mockFile{bytes.NewReader(testContent)}, header
+	}
+
+	t.Run("returns existing photo for identical content", func(t *testing.T) {
+		ctx := context.Background()
+		repo := new(MockRepository)
+		storage := new(MockStorage)
+		processor := new(MockImageProcessor)
+
+		existing := &itemphoto.ItemPhoto{ID: uuid.New(), ItemID: itemID, WorkspaceID: workspaceID, ContentHash: &contentHash}
+		repo.On("FindByContentHash", ctx, itemID, workspaceID, contentHash).Return(existing, nil)
+
+		service := itemphoto.NewService(repo, storage, processor, t.TempDir())
+		service.SetDeduplicateUploads(true)
+		file, header := newUpload()
+		result, err := service.UploadPhoto(ctx, itemID, workspaceID, userID, nil, file, header, nil)
+
+		require.NoError(t, err)
+		assert.Equal(t, existing.ID, result.ID)
+		storage.AssertNotCalled(t, "Save", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("stores new content with its hash", func(t *testing.T) {
+		ctx := context.Background()
+		repo := new(MockRepository)
+		storage := new(MockStorage)
+		processor := new(MockImageProcessor)
+
+		repo.On("FindByContentHash", ctx, itemID, workspaceID, contentHash).Return(nil, shared.ErrNotFound)
+		processor.On("Validate", ctx, mock.AnythingOfType("string")).Return(nil)
+		processor.On("GetDimensions", ctx, mock.AnythingOfType("string")).Return(800, 600, nil)
+		storage.On("Save", ctx, workspaceID.String(), itemID.String(), "drill.jpg", mock.Anything).Return("photos/drill.jpg", nil)
+		repo.On("CountByItem", ctx, itemID, workspaceID).Return(int64(0), nil)
+		repo.On("Create", ctx, mock.MatchedBy(func(p *itemphoto.ItemPhoto) bool {
+			return p.ContentHash != nil && *p.ContentHash == contentHash
+		})).Return(&itemphoto.ItemPhoto{ID: uuid.New(), ItemID: itemID}, nil)
+
+		service := itemphoto.NewService(repo, storage, processor, t.TempDir())
+		service.SetDeduplicateUploads(true)
+		file, header := newUpload()
+		_, err := service.UploadPhoto(ctx, itemID, workspaceID, userID, nil, file, header, nil)
+
+		require.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("stores a second copy when disabled", func(t *testing.T) {
+		ctx := context.Background()
+		repo := new(MockRepository)
+		storage := new(MockStorage)
+		processor := new(MockImageProcessor)
+
+		processor.On("Validate", ctx, mock.AnythingOfType("string")).Return(nil)
+		processor.On("GetDimensions", ctx, mock.AnythingOfType("string")).Return(800, 600, nil)
+		storage.On("Save", ctx, workspaceID.String(), itemID.String(), "drill.jpg", mock.Anything).Return("photos/drill.jpg", nil)
+		repo.On("CountByItem", ctx, itemID, workspaceID).Return(int64(1), nil)
+		repo.On("MaxDisplayOrder", ctx, itemID, workspaceID).Return(int32(0), nil)
+		repo.On("Create", ctx, mock.Anything).Return(&itemphoto.ItemPhoto{ID: uuid.New(), ItemID: itemID}, nil)
+
+		service := itemphoto.NewService(repo, storage, processor, t.TempDir())
+		file, header := newUpload()
+		_, err := service.UploadPhoto(ctx, itemID, workspaceID, userID, nil, file, header, nil)
+
+		require.NoError(t, err)
+		repo.AssertNotCalled(t, "FindByContentHash", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("fails when the lookup fails", func(t *testing.T) {
+		ctx := context.Background()
+		repo := new(MockRepository)
+
+		repo.On("FindByContentHash", ctx, itemID, workspaceID, contentHash).Return(nil, errors.New("db down"))
+
+		service := itemphoto.NewService(repo, new(MockStorage), new(MockImageProcessor), t.TempDir())
+		service.SetDeduplicateUploads(true)
+		file, header := newUpload()
+		_, err := service.UploadPhoto(ctx, itemID, workspaceID, userID, nil, file, header, nil)
+
+		assert.ErrorContains(t, err, "failed to check for duplicate upload")
+	})
+}
+
 func TestIsValidMimeType(t *testing.T) {
 	t.Run("JPEG is valid", func(t *testing.T) {
 		photo := &itemphoto.ItemPhoto{MimeType: "image/jpeg"}
@@ -0,0 +1,190 @@
+package itemphoto
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/google/uuid"
+
+	appMiddleware "github.com/antti/home-warehouse/go-backend/internal/api/middleware"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+const (
+	// DefaultMaxPhotosPerItem applies to workspaces that never saved photo
+	// settings.
+	DefaultMaxPhotosPerItem = 20
+	// MaxPhotosPerItemCeiling bounds Settings.MaxPhotosPerItem, matching the
+	// check constraint on warehouse.photo_settings.
+	MaxPhotosPerItemCeiling = 500
+)
+
+// ErrPhotoLimitReached is matched (via errors.Is) by the *PhotoLimitError an
+// upload returns when the item already has the maximum number of photos.
+var ErrPhotoLimitReached = errors.New("photo limit reached")
+
+// PhotoLimitError reports an upload rejected by the workspace's
+// max_photos_per_item setting.
+type PhotoLimitError struct {
+	Current int
+	Limit   int
+}
+
+func (e *PhotoLimitError) Error() string {
+	return fmt.Sprintf("photo limit reached: item has %d photos, the workspace allows %d", e.Current, e.Limit)
+}
+
+func (e *PhotoLimitError) Is(target error) bool {
+	return target == ErrPhotoLimitReached
+}
+
+// Settings is the workspace photo policy.
+type Settings struct {
+	// MaxPhotosPerItem is how many photos a single item may have.
+	MaxPhotosPerItem int
+	// RequirePhoto makes an item count as cataloged only once it has a
+	// photo; items without one are listed by ListIncompleteItems.
+	RequirePhoto bool
+	// BlockInventoryWithoutPhoto refuses new inventory for items without a
+	// photo. Only valid together with RequirePhoto.
+	BlockInventoryWithoutPhoto bool
+}
+
+func defaultSettings() *Settings {
+	return &Settings{MaxPhotosPerItem: DefaultMaxPhotosPerItem}
+}
+
+// SettingsRepository persists photo settings per workspace. Get returns
+// shared.ErrNotFound when the workspace has never saved any.
+type SettingsRepository interface {
+	Get(ctx context.Context, workspaceID uuid.UUID) (*Settings, error)
+	Upsert(ctx context.Context, workspaceID uuid.UUID, settings Settings) (*Settings, error)
+}
+
+// SetSettingsRepository wires photo settings storage. Without it every
+// workspace uses the default settings and they cannot be changed.
+func (s *Service) SetSettingsRepository(repo SettingsRepository) {
+	s.settings = repo
+}
+
+// GetSettings returns the workspace photo settings, defaulting to
+// DefaultMaxPhotosPerItem when none have been saved.
+func (s *Service) GetSettings(ctx context.Context, workspaceID uuid.UUID) (*Settings, error) {
+	if s.settings == nil {
+		return defaultSettings(), nil
+	}
+	settings, err := s.settings.Get(ctx, workspaceID)
+	if errors.Is(err, shared.ErrNotFound) {
+		return defaultSettings(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// UpdateSettings replaces the workspace photo settings. Lowering the limit
+// does not remove photos; items already over it just cannot gain more.
+func (s *Service) UpdateSettings(ctx context.Context, workspaceID uuid.UUID, settings Settings) (*Settings, error) {
+	if settings.MaxPhotosPerItem < 1 || settings.MaxPhotosPerItem > MaxPhotosPerItemCeiling {
+		return nil, shared.NewFieldError(shared.ErrInvalidInput, "max_photos_per_item", "must be between 1 and 500")
+	}
+	if settings.BlockInventoryWithoutPhoto && !settings.RequirePhoto {
+		return nil, shared.NewFieldError(shared.ErrInvalidInput, "block_inventory_without_photo", "requires require_photo")
+	}
+	if s.settings == nil {
+		return nil, errors.New("photo settings storage is not configured")
+	}
+	return s.settings.Upsert(ctx, workspaceID, settings)
+}
+
+// checkPhotoLimit rejects adding a photo to an item that already has count
+// photos when that reaches the workspace limit.
+func (s *Service) checkPhotoLimit(ctx context.Context, workspaceID uuid.UUID, count int64) error {
+	settings, err := s.GetSettings(ctx, workspaceID)
+	if err != nil {
+		return fmt.Errorf("failed to load photo settings: %w", err)
+	}
+	if count >= int64(settings.MaxPhotosPerItem) {
+		return &PhotoLimitError{Current: int(count), Limit: settings.MaxPhotosPerItem}
+	}
+	return nil
+}
+
+// RegisterSettingsRoutes registers the workspace photo settings endpoints.
+func RegisterSettingsRoutes(api huma.API, svc ServiceInterface) {
+	huma.Get(api, "/photo-settings", getPhotoSettings(svc))
+	huma.Put(api, "/photo-settings", updatePhotoSettings(svc))
+	huma.Get(api, "/items/incomplete", listIncompleteItems(svc))
+}
+
+func getPhotoSettings(svc ServiceInterface) func(context.Context, *struct{}) (*PhotoSettingsOutput, error) {
+	return func(ctx context.Context, _ *struct{}) (*PhotoSettingsOutput, error) {
+		workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+		if !ok {
+			return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+		}
+
+		settings, err := svc.GetSettings(ctx, workspaceID)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to fetch photo settings")
+		}
+
+		return &PhotoSettingsOutput{Body: toPhotoSettingsResponse(settings)}, nil
+	}
+}
+
+func updatePhotoSettings(svc ServiceInterface) func(context.Context, *UpdatePhotoSettingsInput) (*PhotoSettingsOutput, error) {
+	return func(ctx context.Context, input *UpdatePhotoSettingsInput) (*PhotoSettingsOutput, error) {
+		workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+		if !ok {
+			return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+		}
+		if role, ok := appMiddleware.GetRole(ctx); !ok || (role != "owner" && role != "admin") {
+			return nil, huma.Error403Forbidden("only workspace owners and admins can change photo settings")
+		}
+
+		settings, err := svc.UpdateSettings(ctx, workspaceID, Settings{
+			MaxPhotosPerItem:           input.Body.MaxPhotosPerItem,
+			RequirePhoto:               input.Body.RequirePhoto,
+			BlockInventoryWithoutPhoto: input.Body.BlockInventoryWithoutPhoto,
+		})
+		var domainErr *shared.DomainError
+		if errors.As(err, &domainErr) {
+			return nil, appMiddleware.MapDomainError(err)
+		}
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to update photo settings")
+		}
+
+		return &PhotoSettingsOutput{Body: toPhotoSettingsResponse(settings)}, nil
+	}
+}
+
+func toPhotoSettingsResponse(s *Settings) PhotoSettingsResponse {
+	return PhotoSettingsResponse{
+		MaxPhotosPerItem:           s.MaxPhotosPerItem,
+		RequirePhoto:               s.RequirePhoto,
+		BlockInventoryWithoutPhoto: s.BlockInventoryWithoutPhoto,
+	}
+}
+
+type UpdatePhotoSettingsInput struct {
+	Body struct {
+		MaxPhotosPerItem           int  `json:"max_photos_per_item" minimum:"1" maximum:"500" doc:"Maximum number of photos a single item may have"`
+		RequirePhoto               bool `json:"require_photo,omitempty" doc:"Items need at least one photo to count as cataloged; items without one are listed as incomplete"`
+		BlockInventoryWithoutPhoto bool `json:"block_inventory_without_photo,omitempty" doc:"Refuse new inventory for items without a photo (requires require_photo)"`
+	}
+}
+
+type PhotoSettingsOutput struct {
+	Body PhotoSettingsResponse
+}
+
+type PhotoSettingsResponse struct {
+	MaxPhotosPerItem           int  `json:"max_photos_per_item" doc:"Maximum number of photos a single item may have"`
+	RequirePhoto               bool `json:"require_photo" doc:"Items need at least one photo to count as cataloged"`
+	BlockInventoryWithoutPhoto bool `json:"block_inventory_without_photo" doc:"New inventory is refused for items without a photo"`
+}
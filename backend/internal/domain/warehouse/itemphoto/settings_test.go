@@ -0,0 +1,255 @@
+package itemphoto_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"mime/multipart"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/itemphoto"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+type mockSettingsRepository struct {
+	mock.Mock
+}
+
+func (m *mockSettingsRepository) Get(ctx context.Context, workspaceID uuid.UUID) (*itemphoto.Settings, error) {
+	args := m.Called(ctx, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*itemphoto.Settings), args.Error(1)
+}
+
+func (m *mockSettingsRepository) Upsert(ctx context.Context, workspaceID uuid.UUID, settings itemphoto.Settings) (*itemphoto.Settings, error) {
+	args := m.Called(ctx, workspaceID, settings)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*itemphoto.Settings), args.Error(1)
+}
+
+func TestService_GetPhotoSettings(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+
+	t.Run("defaults without a repository", func(t *testing.T) {
+		svc := itemphoto.NewService(new(MockRepository), nil, nil, "")
+
+		settings, err := svc.GetSettings(ctx, workspaceID)
+		require.NoError(t, err)
+		assert.Equal(t, itemphoto.DefaultMaxPhotosPerItem, settings.MaxPhotosPerItem)
+	})
+
+	t.Run("defaults when nothing is saved", func(t *testing.T) {
+		repo := new(mockSettingsRepository)
+		svc := itemphoto.NewService(new(MockRepository), nil, nil, "")
+		svc.SetSettingsRepository(repo)
+		repo.On("Get", ctx, workspaceID).Return(nil, shared.ErrNotFound)
+
+		settings, err := svc.GetSettings(ctx, workspaceID)
+		require.NoError(t, err)
+		assert.Equal(t, itemphoto.DefaultMaxPhotosPerItem, settings.MaxPhotosPerItem)
+	})
+
+	t.Run("returns saved settings", func(t *testing.T) {
+		repo := new(mockSettingsRepository)
+		svc := itemphoto.NewService(new(MockRepository), nil, nil, "")
+		svc.SetSettingsRepository(repo)
+		repo.On("Get", ctx, workspaceID).Return(&itemphoto.Settings{MaxPhotosPerItem: 5}, nil)
+
+		settings, err := svc.GetSettings(ctx, workspaceID)
+		require.NoError(t, err)
+		assert.Equal(t, 5, settings.MaxPhotosPerItem)
+	})
+}
+
+func TestService_UpdatePhotoSettings(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+
+	t.Run("rejects out of range limits", func(t *testing.T) {
+		repo := new(mockSettingsRepository)
+		svc := itemphoto.NewService(new(MockRepository), nil, nil, "")
+		svc.SetSettingsRepository(repo)
+
+		for _, limit := range []int{0, -1, itemphoto.MaxPhotosPerItemCeiling + 1} {
+			_, err := svc.UpdateSettings(ctx, workspaceID, itemphoto.Settings{MaxPhotosPerItem: limit})
+			assert.ErrorIs(t, err, shared.ErrInvalidInput)
+		}
+		repo.AssertNotCalled(t, "Upsert", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects blocking inventory without requiring a photo", func(t *testing.T) {
+		repo := new(mockSettingsRepository)
+		svc := itemphoto.NewService(new(MockRepository), nil, nil, "")
+		svc.SetSettingsRepository(repo)
+
+		_, err := svc.UpdateSettings(ctx, workspaceID, itemphoto.Settings{MaxPhotosPerItem: 20, BlockInventoryWithoutPhoto: true})
+		assert.ErrorIs(t, err, shared.ErrInvalidInput)
+		repo.AssertNotCalled(t, "Upsert", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("saves valid settings", func(t *testing.T) {
+		repo := new(mockSettingsRepository)
+		svc := itemphoto.NewService(new(MockRepository), nil, nil, "")
+		svc.SetSettingsRepository(repo)
+		want := itemphoto.Settings{MaxPhotosPerItem: 50}
+		repo.On("Upsert", ctx, workspaceID, want).Return(&want, nil)
+
+		settings, err := svc.UpdateSettings(ctx, workspaceID, want)
+		require.NoError(t, err)
+		assert.Equal(t, 50, settings.MaxPhotosPerItem)
+	})
+}
+
+func TestService_ListIncompleteItems(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+
+	t.Run("lists items without photos when the rule is on", func(t *testing.T) {
+		repo := new(MockRepository)
+		settingsRepo := new(mockSettingsRepository)
+		svc := itemphoto.NewService(repo, nil, nil, "")
+		svc.SetSettingsRepository(settingsRepo)
+		settingsRepo.On("Get", ctx, workspaceID).Return(&itemphoto.Settings{MaxPhotosPerItem: 20, RequirePhoto: true}, nil)
+		missing := []itemphoto.IncompleteItem{
+			{ItemID: uuid.New(), SKU: "SKU-1", Name: "Drill", ShortCode: "DR1"},
+			{ItemID: uuid.New(), SKU: "SKU-2", Name: "Saw", ShortCode: "SW2"},
+		}
+		repo.On("ListItemsWithoutPhotos", ctx, workspaceID).Return(missing, nil)
+
+		report, err := svc.ListIncompleteItems(ctx, workspaceID)
+		require.NoError(t, err)
+		assert.True(t, report.RequirePhoto)
+		assert.Equal(t, missing, report.Items)
+	})
+
+	t.Run("lists nothing while the rule is off", func(t *testing.T) {
+		repo := new(MockRepository)
+		svc := itemphoto.NewService(repo, nil, nil, "")
+
+		report, err := svc.ListIncompleteItems(ctx, workspaceID)
+		require.NoError(t, err)
+		assert.False(t, report.RequirePhoto)
+		assert.Empty(t, report.Items)
+		repo.AssertNotCalled(t, "ListItemsWithoutPhotos", mock.Anything, mock.Anything)
+	})
+}
+
+func TestService_BlocksInventory(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+	itemID := uuid.New()
+
+	tests := []struct {
+		name     string
+		settings itemphoto.Settings
+		photos   int64
+		want     bool
+	}{
+		{"rule off", itemphoto.Settings{MaxPhotosPerItem: 20}, 0, false},
+		{"photo required without the block", itemphoto.Settings{MaxPhotosPerItem: 20, RequirePhoto: true}, 0, false},
+		{"blocks an item without photos", itemphoto.Settings{MaxPhotosPerItem: 20, RequirePhoto: true, BlockInventoryWithoutPhoto: true}, 0, true},
+		{"allows an item with a photo", itemphoto.Settings{MaxPhotosPerItem: 20, RequirePhoto: true, BlockInventoryWithoutPhoto: true}, 1, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := new(MockRepository)
+			settingsRepo := new(mockSettingsRepository)
+			svc := itemphoto.NewService(repo, nil, nil, "")
+			svc.SetSettingsRepository(settingsRepo)
+			settings := tt.settings
+			settingsRepo.On("Get", ctx, workspaceID).Return(&settings, nil)
+			repo.On("CountByItem", ctx, itemID, workspaceID).Return(tt.photos, nil).Maybe()
+
+			blocked, err := svc.BlocksInventory(ctx, workspaceID, itemID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, blocked)
+		})
+	}
+}
+
+func TestService_UploadPhoto_PhotoLimit(t *testing.T) {
+	itemID := uuid.New()
+	workspaceID := uuid.New()
+	userID := uuid.New()
+
+	newUpload := func() (multipart.File, *multipart.FileHeader) {
+		content := []byte("fake jpeg image content")
+		header := &multipart.FileHeader{
+			Filename: "photo.jpg",
+			Size:     int64(len(content)),
+			Header:   make(map[string][]string),
+		}
+		header.Header.Set("Content-Type", "image/jpeg")
+		return &mockFile{bytes.NewReader(content)}, header
+	}
+
+	t.Run("accepts the last photo under the limit", func(t *testing.T) {
+		ctx := context.Background()
+		repo := new(MockRepository)
+		storage := new(MockStorage)
+		processor := new(MockImageProcessor)
+
+		repo.On("CountByItem", ctx, itemID, workspaceID).Return(int64(itemphoto.DefaultMaxPhotosPerItem-1), nil)
+		repo.On("MaxDisplayOrder", ctx, itemID, workspaceID).Return(int32(itemphoto.DefaultMaxPhotosPerItem-2)*itemphoto.DisplayOrderGap, nil)
+		processor.On("Validate", ctx, mock.AnythingOfType("string")).Return(nil)
+		processor.On("GetDimensions", ctx, mock.AnythingOfType("string")).Return(800, 600, nil)
+		storage.On("Save", ctx, workspaceID.String(), itemID.String(), "photo.jpg", mock.Anything).Return("photos/photo.jpg", nil)
+		repo.On("Create", ctx, mock.MatchedBy(func(p *itemphoto.ItemPhoto) bool {
+			return p.DisplayOrder == int32(itemphoto.DefaultMaxPhotosPerItem-1)*itemphoto.DisplayOrderGap
+		})).Return(&itemphoto.ItemPhoto{ID: uuid.New(), ItemID: itemID}, nil)
+
+		service := itemphoto.NewService(repo, storage, processor, t.TempDir())
+		file, header := newUpload()
+		_, err := service.UploadPhoto(ctx, itemID, workspaceID, userID, nil, file, header, nil)
+
+		require.NoError(t, err)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("rejects the upload at the limit", func(t *testing.T) {
+		ctx := context.Background()
+		repo := new(MockRepository)
+		storage := new(MockStorage)
+		processor := new(MockImageProcessor)
+
+		repo.On("CountByItem", ctx, itemID, workspaceID).Return(int64(itemphoto.DefaultMaxPhotosPerItem), nil)
+
+		service := itemphoto.NewService(repo, storage, processor, t.TempDir())
+		file, header := newUpload()
+		_, err := service.UploadPhoto(ctx, itemID, workspaceID, userID, nil, file, header, nil)
+
+		require.ErrorIs(t, err, itemphoto.ErrPhotoLimitReached)
+		var limitErr *itemphoto.PhotoLimitError
+		require.True(t, errors.As(err, &limitErr))
+		assert.Equal(t, itemphoto.DefaultMaxPhotosPerItem, limitErr.Current)
+		assert.Equal(t, itemphoto.DefaultMaxPhotosPerItem, limitErr.Limit)
+		storage.AssertNotCalled(t, "Save", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		repo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+	})
+
+	t.Run("uses the workspace limit", func(t *testing.T) {
+		ctx := context.Background()
+		repo := new(MockRepository)
+		settingsRepo := new(mockSettingsRepository)
+
+		repo.On("CountByItem", ctx, itemID, workspaceID).Return(int64(3), nil)
+		settingsRepo.On("Get", ctx, workspaceID).Return(&itemphoto.Settings{MaxPhotosPerItem: 3}, nil)
+
+		service := itemphoto.NewService(repo, new(MockStorage), new(MockImageProcessor), t.TempDir())
+		service.SetSettingsRepository(settingsRepo)
+		file, header := newUpload()
+		_, err := service.UploadPhoto(ctx, itemID, workspaceID, userID, nil, file, header, nil)
+
+		assert.EqualError(t, err, "photo limit reached: item has 3 photos, the workspace allows 3")
+	})
+}
@@ -0,0 +1,54 @@
+package itemphoto
+
+import (
+	"fmt"
+)
+
+// PhotoVariant names a stored rendition of a photo, selected with the size
+// query parameter when serving it.
+type PhotoVariant string
+
+const (
+	PhotoVariantSmall    PhotoVariant = "small"
+	PhotoVariantMedium   PhotoVariant = "medium"
+	PhotoVariantLarge    PhotoVariant = "large"
+	PhotoVariantOriginal PhotoVariant = "original"
+)
+
+// ParsePhotoVariant parses a size query parameter. An empty value selects the
+// original.
+func ParsePhotoVariant(s string) (PhotoVariant, error) {
+	switch v := PhotoVariant(s); v {
+	case "":
+		return PhotoVariantOriginal, nil
+	case PhotoVariantSmall, PhotoVariantMedium, PhotoVariantLarge, PhotoVariantOriginal:
+		return v, nil
+	}
+	return "", fmt.Errorf("size must be one of: small, medium, large, original")
+}
+
+// GetVariantThumbnail returns the path of the thumbnail to serve for v and
+// whether it is the requested size (legacy thumbnails count as medium). A
+// missing size falls back to the best thumbnail (see GetBestThumbnail), then
+// to the large and small ones. It returns "" when the photo has no thumbnail.
+func (p *ItemPhoto) GetVariantThumbnail(v PhotoVariant) (path string, exact bool) {
+	var requested string
+	switch v {
+	case PhotoVariantSmall:
+		requested = p.GetSmallThumbnail()
+	case PhotoVariantMedium:
+		requested = p.GetMediumThumbnail()
+	case PhotoVariantLarge:
+		requested = p.GetLargeThumbnail()
+	}
+	if requested != "" {
+		return requested, true
+	}
+
+	for _, fallback := range []string{p.GetBestThumbnail(), p.GetLargeThumbnail(), p.GetSmallThumbnail()} {
+		if fallback != "" {
+			return fallback, false
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,85 @@
+package itemphoto_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/itemphoto"
+)
+
+func TestParsePhotoVariant(t *testing.T) {
+	tests := []struct {
+		input string
+		want  itemphoto.PhotoVariant
+	}{
+		{"", itemphoto.PhotoVariantOriginal},
+		{"original", itemphoto.PhotoVariantOriginal},
+		{"small", itemphoto.PhotoVariantSmall},
+		{"medium", itemphoto.PhotoVariantMedium},
+		{"large", itemphoto.PhotoVariantLarge},
+	}
+	for _, tt := range tests {
+		got, err := itemphoto.ParsePhotoVariant(tt.input)
+		require.NoError(t, err, tt.input)
+		assert.Equal(t, tt.want, got)
+	}
+
+	for _, invalid := range []string{"huge", "Small", "thumbnail"} {
+		_, err := itemphoto.ParsePhotoVariant(invalid)
+		assert.Error(t, err, invalid)
+	}
+}
+
+func TestItemPhoto_GetVariantThumbnail(t *testing.T) {
+	small, medium, large := "thumbs/small.webp", "thumbs/medium.webp", "thumbs/large.webp"
+
+	t.Run("returns the requested size", func(t *testing.T) {
+		photo := &itemphoto.ItemPhoto{ThumbnailSmallPath: &small, ThumbnailMediumPath: &medium, ThumbnailLargePath: &large}
+
+		for variant, want := range map[itemphoto.PhotoVariant]string{
+			itemphoto.PhotoVariantSmall:  small,
+			itemphoto.PhotoVariantMedium: medium,
+			itemphoto.PhotoVariantLarge:  large,
+		} {
+			path, exact := photo.GetVariantThumbnail(variant)
+			assert.Equal(t, want, path, variant)
+			assert.True(t, exact, variant)
+		}
+	})
+
+	t.Run("falls back to the best thumbnail", func(t *testing.T) {
+		photo := &itemphoto.ItemPhoto{ThumbnailMediumPath: &medium}
+
+		path, exact := photo.GetVariantThumbnail(itemphoto.PhotoVariantLarge)
+		assert.Equal(t, medium, path)
+		assert.False(t, exact)
+	})
+
+	t.Run("falls back to another size without a medium one", func(t *testing.T) {
+		photo := &itemphoto.ItemPhoto{ThumbnailSmallPath: &small}
+
+		path, exact := photo.GetVariantThumbnail(itemphoto.PhotoVariantMedium)
+		assert.Equal(t, small, path)
+		assert.False(t, exact)
+	})
+
+	t.Run("treats the legacy thumbnail as medium", func(t *testing.T) {
+		photo := &itemphoto.ItemPhoto{ThumbnailPath: "thumbs/legacy.jpg"}
+
+		path, exact := photo.GetVariantThumbnail(itemphoto.PhotoVariantMedium)
+		assert.Equal(t, "thumbs/legacy.jpg", path)
+		assert.True(t, exact)
+
+		path, exact = photo.GetVariantThumbnail(itemphoto.PhotoVariantSmall)
+		assert.Equal(t, "thumbs/legacy.jpg", path)
+		assert.False(t, exact)
+	})
+
+	t.Run("empty without thumbnails", func(t *testing.T) {
+		path, exact := (&itemphoto.ItemPhoto{}).GetVariantThumbnail(itemphoto.PhotoVariantSmall)
+		assert.Empty(t, path)
+		assert.False(t, exact)
+	})
+}
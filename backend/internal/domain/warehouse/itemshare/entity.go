@@ -0,0 +1,100 @@
+// Package itemshare manages public read-only links to a single item, e.g. to
+// show an item to a buyer who is not a workspace member.
+package itemshare
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// Share is a public link to one item. The link token is random and says
+// nothing about the workspace or the item; only its hash is kept.
+type Share struct {
+	id            uuid.UUID
+	workspaceID   uuid.UUID
+	itemID        uuid.UUID
+	tokenHash     string
+	includePrices bool
+	expiresAt     *time.Time
+	revokedAt     *time.Time
+	createdBy     *uuid.UUID
+	createdAt     time.Time
+}
+
+// NewShare creates a link to the item and returns it with the link token,
+// which is not kept and cannot be shown again. A nil expiresAt never expires.
+func NewShare(workspaceID, itemID uuid.UUID, includePrices bool, expiresAt *time.Time, createdBy *uuid.UUID) (*Share, string, error) {
+	if err := shared.ValidateUUID(workspaceID, "workspace_id"); err != nil {
+		return nil, "", err
+	}
+	if err := shared.ValidateUUID(itemID, "item_id"); err != nil {
+		return nil, "", err
+	}
+	now := time.Now()
+	if expiresAt != nil && !expiresAt.After(now) {
+		return nil, "", shared.NewFieldError(shared.ErrInvalidInput, "expires_at", "expiry must be in the future")
+	}
+
+	token, err := generateToken()
+	if err != nil {
+		return nil, "", err
+	}
+
+	return &Share{
+		id:            shared.NewUUID(),
+		workspaceID:   workspaceID,
+		itemID:        itemID,
+		tokenHash:     HashToken(token),
+		includePrices: includePrices,
+		expiresAt:     expiresAt,
+		createdBy:     createdBy,
+		createdAt:     now,
+	}, token, nil
+}
+
+// Reconstruct rebuilds a share from persistence.
+func Reconstruct(id, workspaceID, itemID uuid.UUID, tokenHash string, includePrices bool, expiresAt, revokedAt *time.Time, createdBy *uuid.UUID, createdAt time.Time) *Share {
+	return &Share{id, workspaceID, itemID, tokenHash, includePrices, expiresAt, revokedAt, createdBy, createdAt}
+}
+
+func (s *Share) ID() uuid.UUID          { return s.id }
+func (s *Share) WorkspaceID() uuid.UUID { return s.workspaceID }
+func (s *Share) ItemID() uuid.UUID      { return s.itemID }
+func (s *Share) TokenHash() string      { return s.tokenHash }
+func (s *Share) IncludePrices() bool    { return s.includePrices }
+func (s *Share) ExpiresAt() *time.Time  { return s.expiresAt }
+func (s *Share) RevokedAt() *time.Time  { return s.revokedAt }
+func (s *Share) CreatedBy() *uuid.UUID  { return s.createdBy }
+func (s *Share) CreatedAt() time.Time   { return s.createdAt }
+
+// IsActive reports whether the link opened at now: neither revoked nor
+// expired.
+func (s *Share) IsActive(now time.Time) bool {
+	if s.revokedAt != nil {
+		return false
+	}
+	return s.expiresAt == nil || now.Before(*s.expiresAt)
+}
+
+// HashToken is how link tokens are stored and looked up: SHA-256, hex.
+func HashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// generateToken returns 32 random bytes, URL-safe base64 so the token can go
+// straight into a link.
+func generateToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
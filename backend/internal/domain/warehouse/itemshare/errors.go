@@ -0,0 +1,11 @@
+package itemshare
+
+import (
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// Domain-specific errors for the item share domain.
+var (
+	ErrShareNotFound = shared.NewDomainError(shared.ErrNotFound, "share not found")
+	ErrItemNotFound  = shared.NewDomainError(shared.ErrNotFound, "item not found")
+)
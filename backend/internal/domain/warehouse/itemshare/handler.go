@@ -0,0 +1,231 @@
+package itemshare
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/google/uuid"
+
+	appMiddleware "github.com/antti/home-warehouse/go-backend/internal/api/middleware"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// RegisterRoutes registers the share management endpoints
+// (workspace-scoped). A share publishes the item outside the workspace, so
+// only owners and admins manage them.
+func RegisterRoutes(api huma.API, svc ServiceInterface) {
+	huma.Get(api, "/items/{item_id}/shares", listShares(svc))
+	huma.Post(api, "/items/{item_id}/shares", createShare(svc))
+	huma.Delete(api, "/items/{item_id}/shares/{id}", revokeShare(svc))
+}
+
+// RegisterPublicRoutes registers the unauthenticated endpoint a share link
+// opens. The caller is expected to rate-limit it.
+func RegisterPublicRoutes(api huma.API, svc ServiceInterface) {
+	huma.Get(api, "/shared/{token}", viewShared(svc))
+}
+
+// requireManager returns the workspace of an owner or admin request.
+func requireManager(ctx context.Context) (uuid.UUID, error) {
+	workspaceID, err := appMiddleware.RequireWorkspaceID(ctx)
+	if err != nil {
+		return uuid.Nil, huma.Error401Unauthorized(err.Error())
+	}
+	if role, ok := appMiddleware.GetRole(ctx); !ok || (role != "owner" && role != "admin") {
+		return uuid.Nil, huma.Error403Forbidden("only workspace owners and admins can share items")
+	}
+	return workspaceID, nil
+}
+
+func listShares(svc ServiceInterface) func(context.Context, *ListSharesInput) (*ListSharesOutput, error) {
+	return func(ctx context.Context, input *ListSharesInput) (*ListSharesOutput, error) {
+		workspaceID, err := requireManager(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		shares, err := svc.List(ctx, workspaceID, input.ItemID)
+		if err != nil {
+			if shared.IsNotFound(err) {
+				return nil, huma.Error404NotFound("item not found")
+			}
+			return nil, huma.Error500InternalServerError("failed to list shares")
+		}
+
+		items := make([]ShareResponse, len(shares))
+		for i, s := range shares {
+			items[i] = toShareResponse(s)
+		}
+		return &ListSharesOutput{Body: ShareListResponse{Items: items}}, nil
+	}
+}
+
+func createShare(svc ServiceInterface) func(context.Context, *CreateShareInput) (*CreateShareOutput, error) {
+	return func(ctx context.Context, input *CreateShareInput) (*CreateShareOutput, error) {
+		workspaceID, err := requireManager(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		var createdBy *uuid.UUID
+		if authUser, ok := appMiddleware.GetAuthUser(ctx); ok {
+			createdBy = &authUser.ID
+		}
+
+		share, token, err := svc.Create(ctx, CreateInput{
+			WorkspaceID:   workspaceID,
+			ItemID:        input.ItemID,
+			IncludePrices: input.Body.IncludePrices,
+			ExpiresAt:     input.Body.ExpiresAt,
+			CreatedBy:     createdBy,
+		})
+		if err != nil {
+			if shared.IsNotFound(err) {
+				return nil, huma.Error404NotFound("item not found")
+			}
+			if errors.Is(err, shared.ErrInvalidInput) {
+				return nil, appMiddleware.MapDomainError(err)
+			}
+			return nil, huma.Error500InternalServerError("failed to create share")
+		}
+
+		return &CreateShareOutput{Body: CreatedShareResponse{
+			ShareResponse: toShareResponse(share),
+			Token:         token,
+		}}, nil
+	}
+}
+
+func revokeShare(svc ServiceInterface) func(context.Context, *RevokeShareInput) (*struct{}, error) {
+	return func(ctx context.Context, input *RevokeShareInput) (*struct{}, error) {
+		workspaceID, err := requireManager(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := svc.Revoke(ctx, workspaceID, input.ItemID, input.ID); err != nil {
+			if shared.IsNotFound(err) {
+				return nil, huma.Error404NotFound("share not found")
+			}
+			return nil, huma.Error500InternalServerError("failed to revoke share")
+		}
+		return nil, nil
+	}
+}
+
+func viewShared(svc ServiceInterface) func(context.Context, *ViewSharedInput) (*ViewSharedOutput, error) {
+	return func(ctx context.Context, input *ViewSharedInput) (*ViewSharedOutput, error) {
+		view, err := svc.View(ctx, input.Token)
+		if err != nil {
+			if shared.IsNotFound(err) {
+				return nil, huma.Error404NotFound("share not found")
+			}
+			return nil, huma.Error500InternalServerError("failed to load shared item")
+		}
+
+		resp := SharedItemResponse{
+			Name:             view.Name,
+			Description:      view.Description,
+			Brand:            view.Brand,
+			Model:            view.Model,
+			Manufacturer:     view.Manufacturer,
+			LifetimeWarranty: view.LifetimeWarranty,
+			WarrantyDetails:  view.WarrantyDetails,
+			ExpiresAt:        view.ExpiresAt,
+		}
+		if view.Prices != nil {
+			resp.Prices = make([]SharedPriceResponse, len(view.Prices))
+			for i, p := range view.Prices {
+				resp.Prices[i] = SharedPriceResponse{Amount: p.Amount, CurrencyCode: p.CurrencyCode, Condition: p.Condition}
+			}
+		}
+		return &ViewSharedOutput{CacheControl: "no-store", Body: resp}, nil
+	}
+}
+
+func toShareResponse(s *Share) ShareResponse {
+	return ShareResponse{
+		ID:            s.ID(),
+		IncludePrices: s.IncludePrices(),
+		ExpiresAt:     s.ExpiresAt(),
+		RevokedAt:     s.RevokedAt(),
+		CreatedBy:     s.CreatedBy(),
+		CreatedAt:     s.CreatedAt(),
+	}
+}
+
+// Request/Response types
+
+type ListSharesInput struct {
+	ItemID uuid.UUID `path:"item_id"`
+}
+
+type CreateShareInput struct {
+	ItemID uuid.UUID `path:"item_id"`
+	Body   struct {
+		IncludePrices bool       `json:"include_prices,omitempty" doc:"Show the purchase prices of the item's inventory. Off by default"`
+		ExpiresAt     *time.Time `json:"expires_at,omitempty" doc:"When the link stops working. Omit for a link that never expires"`
+	}
+}
+
+type RevokeShareInput struct {
+	ItemID uuid.UUID `path:"item_id"`
+	ID     uuid.UUID `path:"id"`
+}
+
+type ViewSharedInput struct {
+	Token string `path:"token" maxLength:"100"`
+}
+
+type ListSharesOutput struct {
+	Body ShareListResponse
+}
+
+type CreateShareOutput struct {
+	Body CreatedShareResponse
+}
+
+type ViewSharedOutput struct {
+	CacheControl string `header:"Cache-Control"`
+	Body         SharedItemResponse
+}
+
+type ShareListResponse struct {
+	Items []ShareResponse `json:"items"`
+}
+
+type ShareResponse struct {
+	ID            uuid.UUID  `json:"id"`
+	IncludePrices bool       `json:"include_prices"`
+	ExpiresAt     *time.Time `json:"expires_at,omitempty"`
+	RevokedAt     *time.Time `json:"revoked_at,omitempty"`
+	CreatedBy     *uuid.UUID `json:"created_by,omitempty"`
+	CreatedAt     time.Time  `json:"created_at"`
+}
+
+type CreatedShareResponse struct {
+	ShareResponse
+	Token string `json:"token" doc:"The link token; the item is at /shared/{token}. It is shown only this once"`
+}
+
+// SharedItemResponse is the public view of a shared item. It deliberately has
+// no IDs: the link must not reveal anything about the workspace.
+type SharedItemResponse struct {
+	Name             string                `json:"name"`
+	Description      *string               `json:"description,omitempty"`
+	Brand            *string               `json:"brand,omitempty"`
+	Model            *string               `json:"model,omitempty"`
+	Manufacturer     *string               `json:"manufacturer,omitempty"`
+	LifetimeWarranty *bool                 `json:"lifetime_warranty,omitempty"`
+	WarrantyDetails  *string               `json:"warranty_details,omitempty"`
+	Prices           []SharedPriceResponse `json:"prices,omitempty" doc:"Purchase prices, only when the share includes them"`
+	ExpiresAt        *time.Time            `json:"expires_at,omitempty" doc:"When the link stops working"`
+}
+
+type SharedPriceResponse struct {
+	Amount       int    `json:"amount" doc:"Purchase price in cents"`
+	CurrencyCode string `json:"currency_code"`
+	Condition    string `json:"condition"`
+}
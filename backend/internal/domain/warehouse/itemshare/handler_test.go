@@ -0,0 +1,247 @@
+package itemshare_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/inventory"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/item"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/itemshare"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+	"github.com/antti/home-warehouse/go-backend/internal/testutil"
+)
+
+// MockService implements itemshare.ServiceInterface
+type MockService struct {
+	mock.Mock
+}
+
+func (m *MockService) Create(ctx context.Context, input itemshare.CreateInput) (*itemshare.Share, string, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, "", args.Error(2)
+	}
+	return args.Get(0).(*itemshare.Share), args.String(1), args.Error(2)
+}
+
+func (m *MockService) List(ctx context.Context, workspaceID, itemID uuid.UUID) ([]*itemshare.Share, error) {
+	args := m.Called(ctx, workspaceID, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*itemshare.Share), args.Error(1)
+}
+
+func (m *MockService) Revoke(ctx context.Context, workspaceID, itemID, id uuid.UUID) error {
+	args := m.Called(ctx, workspaceID, itemID, id)
+	return args.Error(0)
+}
+
+func (m *MockService) View(ctx context.Context, token string) (*itemshare.SharedItem, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*itemshare.SharedItem), args.Error(1)
+}
+
+func TestItemShareHandler_Create(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	itemshare.RegisterRoutes(setup.API, mockSvc)
+	itemID := uuid.New()
+
+	t.Run("returns the token once", func(t *testing.T) {
+		setup.SetRole("admin")
+		share, token, err := itemshare.NewShare(setup.WorkspaceID, itemID, true, nil, &setup.UserID)
+		require.NoError(t, err)
+		mockSvc.On("Create", mock.Anything, mock.MatchedBy(func(in itemshare.CreateInput) bool {
+			return in.WorkspaceID == setup.WorkspaceID && in.ItemID == itemID && in.IncludePrices &&
+				in.ExpiresAt == nil && in.CreatedBy != nil && *in.CreatedBy == setup.UserID
+		})).Return(share, token, nil).Once()
+
+		rec := setup.Post("/items/"+itemID.String()+"/shares", `{"include_prices":true}`)
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		body := testutil.ParseJSONResponse[itemshare.CreatedShareResponse](t, rec)
+		assert.Equal(t, token, body.Token)
+		assert.Equal(t, share.ID(), body.ID)
+		assert.True(t, body.IncludePrices)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("returns 404 for an unknown item", func(t *testing.T) {
+		setup.SetRole("owner")
+		mockSvc.On("Create", mock.Anything, mock.Anything).Return(nil, "", itemshare.ErrItemNotFound).Once()
+
+		rec := setup.Post("/items/"+uuid.NewString()+"/shares", `{}`)
+
+		testutil.AssertStatus(t, rec, http.StatusNotFound)
+	})
+
+	for _, role := range []string{"member", "viewer"} {
+		t.Run(role+" cannot share", func(t *testing.T) {
+			setup.SetRole(role)
+
+			rec := setup.Post("/items/"+itemID.String()+"/shares", `{}`)
+
+			testutil.AssertStatus(t, rec, http.StatusForbidden)
+		})
+	}
+}
+
+func TestItemShareHandler_ListAndRevoke(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	itemshare.RegisterRoutes(setup.API, mockSvc)
+	setup.SetRole("owner")
+	itemID := uuid.New()
+
+	t.Run("lists shares without tokens", func(t *testing.T) {
+		share := itemshare.Reconstruct(uuid.New(), setup.WorkspaceID, itemID, "secret-hash", false, nil, nil, nil, time.Now())
+		mockSvc.On("List", mock.Anything, setup.WorkspaceID, itemID).Return([]*itemshare.Share{share}, nil).Once()
+
+		rec := setup.Get("/items/" + itemID.String() + "/shares")
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		assert.NotContains(t, rec.Body.String(), "secret-hash")
+		body := testutil.ParseJSONResponse[itemshare.ShareListResponse](t, rec)
+		require.Len(t, body.Items, 1)
+		assert.Equal(t, share.ID(), body.Items[0].ID)
+	})
+
+	t.Run("revokes a share", func(t *testing.T) {
+		id := uuid.New()
+		mockSvc.On("Revoke", mock.Anything, setup.WorkspaceID, itemID, id).Return(nil).Once()
+
+		rec := setup.Delete("/items/" + itemID.String() + "/shares/" + id.String())
+
+		testutil.AssertStatus(t, rec, http.StatusNoContent)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("returns 404 for an unknown or revoked share", func(t *testing.T) {
+		id := uuid.New()
+		mockSvc.On("Revoke", mock.Anything, setup.WorkspaceID, itemID, id).Return(itemshare.ErrShareNotFound).Once()
+
+		rec := setup.Delete("/items/" + itemID.String() + "/shares/" + id.String())
+
+		testutil.AssertStatus(t, rec, http.StatusNotFound)
+	})
+}
+
+// memoryShares is an in-memory itemshare.Repository.
+type memoryShares struct {
+	shares []*itemshare.Share
+}
+
+func (m *memoryShares) Save(_ context.Context, s *itemshare.Share) error {
+	m.shares = append(m.shares, s)
+	return nil
+}
+
+func (m *memoryShares) FindByTokenHash(_ context.Context, tokenHash string) (*itemshare.Share, error) {
+	for _, s := range m.shares {
+		if s.TokenHash() == tokenHash {
+			return s, nil
+		}
+	}
+	return nil, shared.ErrNotFound
+}
+
+func (m *memoryShares) FindByItem(context.Context, uuid.UUID, uuid.UUID) ([]*itemshare.Share, error) {
+	return m.shares, nil
+}
+
+func (m *memoryShares) Revoke(context.Context, uuid.UUID, uuid.UUID, uuid.UUID) error {
+	return nil
+}
+
+type singleItem struct{ item *item.Item }
+
+func (s singleItem) FindByID(context.Context, uuid.UUID, uuid.UUID) (*item.Item, error) {
+	return s.item, nil
+}
+
+type inventoryOf []*inventory.Inventory
+
+func (i inventoryOf) FindByItem(context.Context, uuid.UUID, uuid.UUID) ([]*inventory.Inventory, error) {
+	return i, nil
+}
+
+func TestItemShareHandler_ViewShared(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+	strPtr := func(s string) *string { return &s }
+	price := 129900
+
+	it, err := item.NewItem(workspaceID, "Road bike", "SKU-0042", 0)
+	require.NoError(t, err)
+	require.NoError(t, it.Update(item.UpdateInput{
+		Name:         "Road bike",
+		Brand:        strPtr("Canyon"),
+		SerialNumber: strPtr("WTU123456"),
+		Barcode:      strPtr("4006381333931"),
+	}))
+	now := time.Now()
+	inv := inventory.Reconstruct(uuid.New(), workspaceID, it.ID(), uuid.New(), nil, 1, inventory.ConditionGood,
+		inventory.StatusAvailable, nil, &price, strPtr("EUR"), nil, nil, strPtr("garage"), false, now, now)
+
+	setup := testutil.NewHandlerTestSetup()
+	svc := itemshare.NewService(&memoryShares{}, singleItem{it}, inventoryOf{inv})
+	itemshare.RegisterPublicRoutes(setup.API, svc)
+
+	t.Run("omits sensitive fields and prices", func(t *testing.T) {
+		_, token, err := svc.Create(ctx, itemshare.CreateInput{WorkspaceID: workspaceID, ItemID: it.ID()})
+		require.NoError(t, err)
+
+		rec := setup.Get("/shared/" + token)
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		assert.Equal(t, "no-store", rec.Header().Get("Cache-Control"))
+		raw := rec.Body.String()
+		for _, secret := range []string{workspaceID.String(), it.ID().String(), "SKU-0042", "WTU123456", "4006381333931", "garage", "129900", "prices"} {
+			assert.NotContains(t, raw, secret)
+		}
+		body := testutil.ParseJSONResponse[itemshare.SharedItemResponse](t, rec)
+		assert.Equal(t, "Road bike", body.Name)
+		assert.Equal(t, "Canyon", *body.Brand)
+	})
+
+	t.Run("shows prices when the share allows them", func(t *testing.T) {
+		_, token, err := svc.Create(ctx, itemshare.CreateInput{WorkspaceID: workspaceID, ItemID: it.ID(), IncludePrices: true})
+		require.NoError(t, err)
+
+		rec := setup.Get("/shared/" + token)
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		body := testutil.ParseJSONResponse[itemshare.SharedItemResponse](t, rec)
+		require.Len(t, body.Prices, 1)
+		assert.Equal(t, 129900, body.Prices[0].Amount)
+		assert.Equal(t, "EUR", body.Prices[0].CurrencyCode)
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		rec := setup.Get("/shared/not-a-token")
+
+		testutil.AssertStatus(t, rec, http.StatusNotFound)
+	})
+
+	t.Run("expired link", func(t *testing.T) {
+		mockSvc := new(MockService)
+		expiredSetup := testutil.NewHandlerTestSetup()
+		itemshare.RegisterPublicRoutes(expiredSetup.API, mockSvc)
+		mockSvc.On("View", mock.Anything, "expired").Return(nil, itemshare.ErrShareNotFound).Once()
+
+		rec := expiredSetup.Get("/shared/expired")
+
+		testutil.AssertStatus(t, rec, http.StatusNotFound)
+	})
+}
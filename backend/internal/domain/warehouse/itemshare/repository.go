@@ -0,0 +1,24 @@
+package itemshare
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+// Repository defines item share persistence.
+type Repository interface {
+	// Save persists a new share.
+	Save(ctx context.Context, share *Share) error
+
+	// FindByTokenHash retrieves the share with the token hash, revoked and
+	// expired ones included. Returns shared.ErrNotFound when there is none.
+	FindByTokenHash(ctx context.Context, tokenHash string) (*Share, error)
+
+	// FindByItem retrieves an item's shares, newest first.
+	FindByItem(ctx context.Context, workspaceID, itemID uuid.UUID) ([]*Share, error)
+
+	// Revoke marks an unrevoked share of the item revoked. Returns
+	// shared.ErrNotFound when there is no such share or it is already revoked.
+	Revoke(ctx context.Context, workspaceID, itemID, id uuid.UUID) error
+}
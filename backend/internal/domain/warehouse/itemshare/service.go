@@ -0,0 +1,176 @@
+package itemshare
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/inventory"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/item"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// ItemLookup finds the shared item. Implemented by the postgres item
+// repository.
+type ItemLookup interface {
+	FindByID(ctx context.Context, id, workspaceID uuid.UUID) (*item.Item, error)
+}
+
+// InventoryLookup finds the item's inventory, for the prices a share may
+// show. Implemented by the postgres inventory repository.
+type InventoryLookup interface {
+	FindByItem(ctx context.Context, workspaceID, itemID uuid.UUID) ([]*inventory.Inventory, error)
+}
+
+// ServiceInterface defines item share service operations.
+type ServiceInterface interface {
+	Create(ctx context.Context, input CreateInput) (*Share, string, error)
+	List(ctx context.Context, workspaceID, itemID uuid.UUID) ([]*Share, error)
+	Revoke(ctx context.Context, workspaceID, itemID, id uuid.UUID) error
+	View(ctx context.Context, token string) (*SharedItem, error)
+}
+
+// CreateInput describes a new share. A nil ExpiresAt never expires.
+type CreateInput struct {
+	WorkspaceID   uuid.UUID
+	ItemID        uuid.UUID
+	IncludePrices bool
+	ExpiresAt     *time.Time
+	CreatedBy     *uuid.UUID
+}
+
+// SharedItem is what a share link shows: the item's descriptive fields only.
+// IDs, SKU, serial number, barcode, location, supplier and notes are never
+// included, and Prices only when the share allows them.
+type SharedItem struct {
+	Name             string
+	Description      *string
+	Brand            *string
+	Model            *string
+	Manufacturer     *string
+	LifetimeWarranty *bool
+	WarrantyDetails  *string
+	Prices           []Price
+	ExpiresAt        *time.Time
+}
+
+// Price is the purchase price of one unarchived inventory entry.
+type Price struct {
+	Amount       int // cents
+	CurrencyCode string
+	Condition    string
+}
+
+// Service handles item share business logic.
+type Service struct {
+	repo      Repository
+	items     ItemLookup
+	inventory InventoryLookup
+	now       func() time.Time
+}
+
+// NewService creates a new item share service.
+func NewService(repo Repository, items ItemLookup, inventory InventoryLookup) *Service {
+	return &Service{repo: repo, items: items, inventory: inventory, now: time.Now}
+}
+
+// Create makes a new link to an item in the workspace and returns it with
+// the link token.
+func (s *Service) Create(ctx context.Context, input CreateInput) (*Share, string, error) {
+	if err := s.checkItem(ctx, input.WorkspaceID, input.ItemID); err != nil {
+		return nil, "", err
+	}
+	share, token, err := NewShare(input.WorkspaceID, input.ItemID, input.IncludePrices, input.ExpiresAt, input.CreatedBy)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := s.repo.Save(ctx, share); err != nil {
+		return nil, "", err
+	}
+	return share, token, nil
+}
+
+// List returns an item's shares, revoked and expired ones included.
+func (s *Service) List(ctx context.Context, workspaceID, itemID uuid.UUID) ([]*Share, error) {
+	if err := s.checkItem(ctx, workspaceID, itemID); err != nil {
+		return nil, err
+	}
+	return s.repo.FindByItem(ctx, workspaceID, itemID)
+}
+
+// Revoke stops a link from working. Revoking is permanent.
+func (s *Service) Revoke(ctx context.Context, workspaceID, itemID, id uuid.UUID) error {
+	err := s.repo.Revoke(ctx, workspaceID, itemID, id)
+	if shared.IsNotFound(err) {
+		return ErrShareNotFound
+	}
+	return err
+}
+
+// View returns the item a link token opens. Unknown, revoked and expired
+// tokens, and links to an archived item, all fail with ErrShareNotFound, so
+// the link reveals nothing about why it stopped working.
+func (s *Service) View(ctx context.Context, token string) (*SharedItem, error) {
+	share, err := s.repo.FindByTokenHash(ctx, HashToken(token))
+	if err != nil {
+		if shared.IsNotFound(err) {
+			return nil, ErrShareNotFound
+		}
+		return nil, err
+	}
+	if !share.IsActive(s.now()) {
+		return nil, ErrShareNotFound
+	}
+
+	it, err := s.items.FindByID(ctx, share.ItemID(), share.WorkspaceID())
+	if err != nil {
+		if shared.IsNotFound(err) {
+			return nil, ErrShareNotFound
+		}
+		return nil, err
+	}
+	if archived := it.IsArchived(); archived != nil && *archived {
+		return nil, ErrShareNotFound
+	}
+
+	view := &SharedItem{
+		Name:             it.Name(),
+		Description:      it.Description(),
+		Brand:            it.Brand(),
+		Model:            it.Model(),
+		Manufacturer:     it.Manufacturer(),
+		LifetimeWarranty: it.LifetimeWarranty(),
+		WarrantyDetails:  it.WarrantyDetails(),
+		ExpiresAt:        share.ExpiresAt(),
+	}
+	if share.IncludePrices() {
+		entries, err := s.inventory.FindByItem(ctx, share.WorkspaceID(), share.ItemID())
+		if err != nil {
+			return nil, err
+		}
+		view.Prices = []Price{}
+		for _, inv := range entries {
+			if inv.IsArchived() || inv.PurchasePrice() == nil || inv.CurrencyCode() == nil {
+				continue
+			}
+			view.Prices = append(view.Prices, Price{
+				Amount:       *inv.PurchasePrice(),
+				CurrencyCode: *inv.CurrencyCode(),
+				Condition:    string(inv.Condition()),
+			})
+		}
+	}
+	return view, nil
+}
+
+// checkItem reports ErrItemNotFound when the item is not in the workspace.
+func (s *Service) checkItem(ctx context.Context, workspaceID, itemID uuid.UUID) error {
+	if _, err := s.items.FindByID(ctx, itemID, workspaceID); err != nil {
+		if shared.IsNotFound(err) {
+			return ErrItemNotFound
+		}
+		return err
+	}
+	return nil
+}
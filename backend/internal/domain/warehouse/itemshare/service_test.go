@@ -0,0 +1,269 @@
+package itemshare
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/inventory"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/item"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// MockRepository is a mock implementation of the Repository interface.
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) Save(ctx context.Context, share *Share) error {
+	args := m.Called(ctx, share)
+	return args.Error(0)
+}
+
+func (m *MockRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*Share, error) {
+	args := m.Called(ctx, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Share), args.Error(1)
+}
+
+func (m *MockRepository) FindByItem(ctx context.Context, workspaceID, itemID uuid.UUID) ([]*Share, error) {
+	args := m.Called(ctx, workspaceID, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*Share), args.Error(1)
+}
+
+func (m *MockRepository) Revoke(ctx context.Context, workspaceID, itemID, id uuid.UUID) error {
+	args := m.Called(ctx, workspaceID, itemID, id)
+	return args.Error(0)
+}
+
+// fakeItems holds one item; every other lookup is not found.
+type fakeItems struct {
+	item *item.Item
+}
+
+func (f fakeItems) FindByID(_ context.Context, id, workspaceID uuid.UUID) (*item.Item, error) {
+	if f.item == nil || f.item.ID() != id || f.item.WorkspaceID() != workspaceID {
+		return nil, shared.ErrNotFound
+	}
+	return f.item, nil
+}
+
+type fakeInventory struct {
+	entries []*inventory.Inventory
+	calls   int
+}
+
+func (f *fakeInventory) FindByItem(_ context.Context, _, _ uuid.UUID) ([]*inventory.Inventory, error) {
+	f.calls++
+	return f.entries, nil
+}
+
+func strPtr(s string) *string { return &s }
+func intPtr(i int) *int       { return &i }
+
+func newTestItem(t *testing.T, workspaceID uuid.UUID) *item.Item {
+	t.Helper()
+	it, err := item.NewItem(workspaceID, "Road bike", "SKU-0042", 0)
+	require.NoError(t, err)
+	require.NoError(t, it.Update(item.UpdateInput{
+		Name:         "Road bike",
+		Description:  strPtr("54 cm frame"),
+		Brand:        strPtr("Canyon"),
+		Model:        strPtr("Endurace"),
+		SerialNumber: strPtr("WTU123456"),
+		Barcode:      strPtr("4006381333931"),
+	}))
+	return it
+}
+
+func newTestInventory(workspaceID, itemID uuid.UUID, price *int, currency *string, archived bool) *inventory.Inventory {
+	now := time.Now()
+	return inventory.Reconstruct(uuid.New(), workspaceID, itemID, uuid.New(), nil, 1,
+		inventory.ConditionGood, inventory.StatusAvailable, nil, price, currency, nil, nil,
+		strPtr("garage, behind the door"), archived, now, now)
+}
+
+func TestShare_IsActive(t *testing.T) {
+	now := time.Now()
+	past, future := now.Add(-time.Minute), now.Add(time.Minute)
+
+	assert.True(t, Reconstruct(uuid.New(), uuid.New(), uuid.New(), "h", false, nil, nil, nil, now).IsActive(now))
+	assert.True(t, Reconstruct(uuid.New(), uuid.New(), uuid.New(), "h", false, &future, nil, nil, now).IsActive(now))
+	assert.False(t, Reconstruct(uuid.New(), uuid.New(), uuid.New(), "h", false, &past, nil, nil, now).IsActive(now), "expired")
+	assert.False(t, Reconstruct(uuid.New(), uuid.New(), uuid.New(), "h", false, &future, &past, nil, now).IsActive(now), "revoked")
+}
+
+func TestNewShare(t *testing.T) {
+	workspaceID, itemID := uuid.New(), uuid.New()
+
+	t.Run("token is URL-safe and only its hash is kept", func(t *testing.T) {
+		share, token, err := NewShare(workspaceID, itemID, false, nil, nil)
+
+		require.NoError(t, err)
+		assert.Len(t, token, 43)
+		assert.NotContains(t, token, workspaceID.String())
+		assert.Equal(t, HashToken(token), share.TokenHash())
+	})
+
+	t.Run("rejects an expiry in the past", func(t *testing.T) {
+		past := time.Now().Add(-time.Hour)
+
+		_, _, err := NewShare(workspaceID, itemID, false, &past, nil)
+
+		assert.ErrorIs(t, err, shared.ErrInvalidInput)
+	})
+}
+
+func TestService_View(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+	it := newTestItem(t, workspaceID)
+	token := "test-token"
+	now := time.Date(2026, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	newShare := func(includePrices bool, expiresAt, revokedAt *time.Time) *Share {
+		return Reconstruct(uuid.New(), workspaceID, it.ID(), HashToken(token), includePrices, expiresAt, revokedAt, nil, now.Add(-time.Hour))
+	}
+	newService := func(repo *MockRepository, inv *fakeInventory) *Service {
+		svc := NewService(repo, fakeItems{item: it}, inv)
+		svc.now = func() time.Time { return now }
+		return svc
+	}
+
+	t.Run("shows the item without prices by default", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("FindByTokenHash", ctx, HashToken(token)).Return(newShare(false, nil, nil), nil)
+		inv := &fakeInventory{}
+
+		view, err := newService(repo, inv).View(ctx, token)
+
+		require.NoError(t, err)
+		assert.Equal(t, "Road bike", view.Name)
+		assert.Equal(t, "Canyon", *view.Brand)
+		assert.Nil(t, view.Prices)
+		assert.Zero(t, inv.calls, "prices are not even loaded")
+	})
+
+	t.Run("includes unarchived purchase prices when allowed", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("FindByTokenHash", ctx, HashToken(token)).Return(newShare(true, nil, nil), nil)
+		inv := &fakeInventory{entries: []*inventory.Inventory{
+			newTestInventory(workspaceID, it.ID(), intPtr(129900), strPtr("EUR"), false),
+			newTestInventory(workspaceID, it.ID(), nil, nil, false),
+			newTestInventory(workspaceID, it.ID(), intPtr(5000), strPtr("EUR"), true),
+		}}
+
+		view, err := newService(repo, inv).View(ctx, token)
+
+		require.NoError(t, err)
+		require.Len(t, view.Prices, 1)
+		assert.Equal(t, Price{Amount: 129900, CurrencyCode: "EUR", Condition: "GOOD"}, view.Prices[0])
+	})
+
+	t.Run("expired link", func(t *testing.T) {
+		repo := new(MockRepository)
+		expired := now.Add(-time.Second)
+		repo.On("FindByTokenHash", ctx, HashToken(token)).Return(newShare(false, &expired, nil), nil)
+
+		_, err := newService(repo, &fakeInventory{}).View(ctx, token)
+
+		assert.ErrorIs(t, err, ErrShareNotFound)
+	})
+
+	t.Run("link that has not expired yet", func(t *testing.T) {
+		repo := new(MockRepository)
+		expiresAt := now.Add(time.Second)
+		repo.On("FindByTokenHash", ctx, HashToken(token)).Return(newShare(false, &expiresAt, nil), nil)
+
+		view, err := newService(repo, &fakeInventory{}).View(ctx, token)
+
+		require.NoError(t, err)
+		assert.Equal(t, &expiresAt, view.ExpiresAt)
+	})
+
+	t.Run("revoked link", func(t *testing.T) {
+		repo := new(MockRepository)
+		revoked := now.Add(-time.Minute)
+		repo.On("FindByTokenHash", ctx, HashToken(token)).Return(newShare(false, nil, &revoked), nil)
+
+		_, err := newService(repo, &fakeInventory{}).View(ctx, token)
+
+		assert.ErrorIs(t, err, ErrShareNotFound)
+	})
+
+	t.Run("archived item", func(t *testing.T) {
+		archived := newTestItem(t, workspaceID)
+		archived.Archive()
+		share := Reconstruct(uuid.New(), workspaceID, archived.ID(), HashToken(token), false, nil, nil, nil, now.Add(-time.Hour))
+		repo := new(MockRepository)
+		repo.On("FindByTokenHash", ctx, HashToken(token)).Return(share, nil)
+		svc := NewService(repo, fakeItems{item: archived}, &fakeInventory{})
+		svc.now = func() time.Time { return now }
+
+		_, err := svc.View(ctx, token)
+
+		assert.ErrorIs(t, err, ErrShareNotFound)
+	})
+
+	t.Run("unknown token", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("FindByTokenHash", ctx, HashToken("guess")).Return(nil, shared.ErrNotFound)
+
+		_, err := newService(repo, &fakeInventory{}).View(ctx, "guess")
+
+		assert.ErrorIs(t, err, ErrShareNotFound)
+	})
+}
+
+func TestService_Create(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+	it := newTestItem(t, workspaceID)
+
+	t.Run("saves a share of an item in the workspace", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("Save", ctx, mock.AnythingOfType("*itemshare.Share")).Return(nil)
+
+		share, token, err := NewService(repo, fakeItems{item: it}, &fakeInventory{}).Create(ctx, CreateInput{
+			WorkspaceID: workspaceID, ItemID: it.ID(), IncludePrices: true,
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, HashToken(token), share.TokenHash())
+		assert.True(t, share.IncludePrices())
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("item in another workspace", func(t *testing.T) {
+		repo := new(MockRepository)
+
+		_, _, err := NewService(repo, fakeItems{item: it}, &fakeInventory{}).Create(ctx, CreateInput{
+			WorkspaceID: uuid.New(), ItemID: it.ID(),
+		})
+
+		assert.ErrorIs(t, err, ErrItemNotFound)
+		repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+}
+
+func TestService_Revoke(t *testing.T) {
+	ctx := context.Background()
+	workspaceID, itemID, id := uuid.New(), uuid.New(), uuid.New()
+
+	repo := new(MockRepository)
+	repo.On("Revoke", ctx, workspaceID, itemID, id).Return(shared.ErrNotFound)
+
+	err := NewService(repo, fakeItems{}, &fakeInventory{}).Revoke(ctx, workspaceID, itemID, id)
+
+	assert.ErrorIs(t, err, ErrShareNotFound)
+}
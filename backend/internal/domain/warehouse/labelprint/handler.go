@@ -0,0 +1,244 @@
+package labelprint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/google/uuid"
+
+	appMiddleware "github.com/antti/home-warehouse/go-backend/internal/api/middleware"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/item"
+)
+
+const (
+	msgWorkspaceContextRequired = "workspace context required"
+	msgAuthenticationRequired   = "authentication required"
+)
+
+// RegisterRoutes registers the print queue routes. Every queue route works on
+// the caller's own queue.
+func RegisterRoutes(api huma.API, svc ServiceInterface) {
+	huma.Get(api, "/short-codes", listShortCodes(svc))
+	huma.Get(api, "/print-queue", listQueue(svc))
+	huma.Post(api, "/print-queue", addToQueue(svc))
+	huma.Delete(api, "/print-queue", clearQueue(svc))
+	huma.Delete(api, "/print-queue/{item_id}", removeFromQueue(svc))
+	huma.Post(api, "/print-queue/sheet", printSheet(svc))
+}
+
+// queueOwner returns the workspace and user whose queue a request works on.
+func queueOwner(ctx context.Context) (workspaceID, userID uuid.UUID, err error) {
+	workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+	if !ok {
+		return uuid.Nil, uuid.Nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+	}
+	authUser, ok := appMiddleware.GetAuthUser(ctx)
+	if !ok {
+		return uuid.Nil, uuid.Nil, huma.Error401Unauthorized(msgAuthenticationRequired)
+	}
+	return workspaceID, authUser.ID, nil
+}
+
+// listQueue returns the caller's queued items, oldest first.
+func listQueue(svc ServiceInterface) func(context.Context, *struct{}) (*QueueOutput, error) {
+	return func(ctx context.Context, input *struct{}) (*QueueOutput, error) {
+		workspaceID, userID, err := queueOwner(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		items, err := svc.List(ctx, workspaceID, userID)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to list print queue")
+		}
+		return toQueueOutput(items), nil
+	}
+}
+
+// addToQueue queues items for the next label sheet.
+func addToQueue(svc ServiceInterface) func(context.Context, *AddToQueueInput) (*QueueOutput, error) {
+	return func(ctx context.Context, input *AddToQueueInput) (*QueueOutput, error) {
+		workspaceID, userID, err := queueOwner(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		items, err := svc.Add(ctx, workspaceID, userID, input.Body.ItemIDs)
+		if err != nil {
+			if errors.Is(err, ErrItemNotFound) {
+				return nil, huma.Error404NotFound("item not found")
+			}
+			if errors.Is(err, ErrQueueFull) || errors.Is(err, ErrNoItemsToQueue) {
+				return nil, huma.Error400BadRequest(err.Error())
+			}
+			return nil, appMiddleware.MapDomainError(err)
+		}
+		return toQueueOutput(items), nil
+	}
+}
+
+// removeFromQueue takes one item off the caller's queue.
+func removeFromQueue(svc ServiceInterface) func(context.Context, *RemoveFromQueueInput) (*struct{}, error) {
+	return func(ctx context.Context, input *RemoveFromQueueInput) (*struct{}, error) {
+		workspaceID, userID, err := queueOwner(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := svc.Remove(ctx, workspaceID, userID, input.ItemID); err != nil {
+			return nil, huma.Error500InternalServerError("failed to remove from print queue")
+		}
+		return nil, nil
+	}
+}
+
+// clearQueue empties the caller's queue without printing.
+func clearQueue(svc ServiceInterface) func(context.Context, *struct{}) (*struct{}, error) {
+	return func(ctx context.Context, input *struct{}) (*struct{}, error) {
+		workspaceID, userID, err := queueOwner(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := svc.Clear(ctx, workspaceID, userID); err != nil {
+			return nil, huma.Error500InternalServerError("failed to clear print queue")
+		}
+		return nil, nil
+	}
+}
+
+// printSheet renders the caller's queue as a PDF label sheet and empties
+// the queue.
+func printSheet(svc ServiceInterface) func(context.Context, *struct{}) (*LabelSheetOutput, error) {
+	return func(ctx context.Context, input *struct{}) (*LabelSheetOutput, error) {
+		workspaceID, userID, err := queueOwner(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		sheet, err := svc.PrintSheet(ctx, workspaceID, userID)
+		if err != nil {
+			if errors.Is(err, ErrQueueEmpty) {
+				return nil, huma.Error400BadRequest("print queue is empty")
+			}
+			return nil, huma.Error500InternalServerError("failed to print label sheet")
+		}
+
+		return &LabelSheetOutput{
+			ContentType:        "application/pdf",
+			ContentDisposition: fmt.Sprintf("inline; filename=\"labels-%s.pdf\"", time.Now().Format("2006-01-02")),
+			Body:               sheet,
+		}, nil
+	}
+}
+
+// listShortCodes returns every short code in the workspace and the ones a
+// scanner could confuse, for checking labels before printing them.
+func listShortCodes(svc ServiceInterface) func(context.Context, *struct{}) (*ShortCodeRegistryOutput, error) {
+	return func(ctx context.Context, input *struct{}) (*ShortCodeRegistryOutput, error) {
+		workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+		if !ok {
+			return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+		}
+
+		registry, err := svc.ShortCodeRegistry(ctx, workspaceID)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to list short codes")
+		}
+
+		codes := make([]ShortCodeResponse, len(registry.Codes))
+		for i, c := range registry.Codes {
+			codes[i] = toShortCodeResponse(c)
+		}
+		conflicts := make([]ShortCodeConflictResponse, len(registry.Conflicts))
+		for i, conflict := range registry.Conflicts {
+			entries := make([]ShortCodeResponse, len(conflict.Codes))
+			for j, c := range conflict.Codes {
+				entries[j] = toShortCodeResponse(c)
+			}
+			conflicts[i] = ShortCodeConflictResponse{Codes: entries, CrossEntity: conflict.CrossEntity}
+		}
+
+		return &ShortCodeRegistryOutput{Body: ShortCodeRegistryResponse{
+			Items:     codes,
+			Total:     len(codes),
+			Conflicts: conflicts,
+		}}, nil
+	}
+}
+
+func toShortCodeResponse(c ShortCode) ShortCodeResponse {
+	return ShortCodeResponse{Code: c.Code, EntityType: c.EntityType, EntityID: c.EntityID}
+}
+
+func toQueueOutput(items []*item.Item) *QueueOutput {
+	entries := make([]QueuedItemResponse, len(items))
+	for i, itm := range items {
+		entries[i] = QueuedItemResponse{
+			ItemID:    itm.ID(),
+			Name:      itm.Name(),
+			SKU:       itm.SKU(),
+			ShortCode: itm.ShortCode(),
+		}
+	}
+	return &QueueOutput{Body: QueueResponse{Items: entries, Total: len(entries)}}
+}
+
+// Request/Response types
+
+type AddToQueueInput struct {
+	Body struct {
+		ItemIDs []uuid.UUID `json:"item_ids" minItems:"1" maxItems:"100" doc:"Items to queue for labeling"`
+	}
+}
+
+type RemoveFromQueueInput struct {
+	ItemID uuid.UUID `path:"item_id"`
+}
+
+type QueueOutput struct {
+	Body QueueResponse
+}
+
+type QueueResponse struct {
+	Items []QueuedItemResponse `json:"items"`
+	Total int                  `json:"total"`
+}
+
+type QueuedItemResponse struct {
+	ItemID    uuid.UUID `json:"item_id"`
+	Name      string    `json:"name"`
+	SKU       string    `json:"sku"`
+	ShortCode string    `json:"short_code"`
+}
+
+type ShortCodeRegistryOutput struct {
+	Body ShortCodeRegistryResponse
+}
+
+type ShortCodeRegistryResponse struct {
+	Items     []ShortCodeResponse         `json:"items"`
+	Total     int                         `json:"total"`
+	Conflicts []ShortCodeConflictResponse `json:"conflicts" doc:"Groups of codes that differ only in case"`
+}
+
+type ShortCodeResponse struct {
+	Code       string    `json:"code"`
+	EntityType string    `json:"entity_type" enum:"item,location,container"`
+	EntityID   uuid.UUID `json:"entity_id"`
+}
+
+type ShortCodeConflictResponse struct {
+	Codes       []ShortCodeResponse `json:"codes"`
+	CrossEntity bool                `json:"cross_entity" doc:"The codes belong to different entity types"`
+}
+
+// LabelSheetOutput is the PDF response of the sheet endpoint.
+type LabelSheetOutput struct {
+	ContentType        string `header:"Content-Type"`
+	ContentDisposition string `header:"Content-Disposition"`
+	Body               []byte
+}
@@ -0,0 +1,156 @@
+package labelprint_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/item"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/labelprint"
+	"github.com/antti/home-warehouse/go-backend/internal/testutil"
+)
+
+// MockService implements labelprint.ServiceInterface
+type MockService struct {
+	mock.Mock
+}
+
+func (m *MockService) List(ctx context.Context, workspaceID, userID uuid.UUID) ([]*item.Item, error) {
+	args := m.Called(ctx, workspaceID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*item.Item), args.Error(1)
+}
+
+func (m *MockService) Add(ctx context.Context, workspaceID, userID uuid.UUID, itemIDs []uuid.UUID) ([]*item.Item, error) {
+	args := m.Called(ctx, workspaceID, userID, itemIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*item.Item), args.Error(1)
+}
+
+func (m *MockService) Remove(ctx context.Context, workspaceID, userID, itemID uuid.UUID) error {
+	return m.Called(ctx, workspaceID, userID, itemID).Error(0)
+}
+
+func (m *MockService) Clear(ctx context.Context, workspaceID, userID uuid.UUID) error {
+	return m.Called(ctx, workspaceID, userID).Error(0)
+}
+
+func (m *MockService) PrintSheet(ctx context.Context, workspaceID, userID uuid.UUID) ([]byte, error) {
+	args := m.Called(ctx, workspaceID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (m *MockService) ShortCodeRegistry(ctx context.Context, workspaceID uuid.UUID) (*labelprint.ShortCodeRegistry, error) {
+	args := m.Called(ctx, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*labelprint.ShortCodeRegistry), args.Error(1)
+}
+
+func TestPrintQueueHandler(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	labelprint.RegisterRoutes(setup.API, mockSvc)
+
+	_, items := newCatalog(t, setup.WorkspaceID, "Drill")
+
+	t.Run("lists the caller's queue", func(t *testing.T) {
+		mockSvc.On("List", mock.Anything, setup.WorkspaceID, setup.UserID).Return(items, nil).Once()
+
+		rec := setup.Get("/print-queue")
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		var body labelprint.QueueResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		require.Len(t, body.Items, 1)
+		assert.Equal(t, "SCDrill", body.Items[0].ShortCode)
+	})
+
+	t.Run("adds items", func(t *testing.T) {
+		ids := itemIDs(items)
+		mockSvc.On("Add", mock.Anything, setup.WorkspaceID, setup.UserID, ids).Return(items, nil).Once()
+
+		rec := setup.Post("/print-queue", `{"item_ids":["`+ids[0].String()+`"]}`)
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("unknown item is 404", func(t *testing.T) {
+		id := uuid.New()
+		mockSvc.On("Add", mock.Anything, setup.WorkspaceID, setup.UserID, []uuid.UUID{id}).
+			Return(nil, labelprint.ErrItemNotFound).Once()
+
+		rec := setup.Post("/print-queue", `{"item_ids":["`+id.String()+`"]}`)
+
+		testutil.AssertStatus(t, rec, http.StatusNotFound)
+	})
+
+	t.Run("removes an item", func(t *testing.T) {
+		mockSvc.On("Remove", mock.Anything, setup.WorkspaceID, setup.UserID, items[0].ID()).Return(nil).Once()
+
+		rec := setup.Delete("/print-queue/" + items[0].ID().String())
+
+		testutil.AssertStatus(t, rec, http.StatusNoContent)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("clears the queue", func(t *testing.T) {
+		mockSvc.On("Clear", mock.Anything, setup.WorkspaceID, setup.UserID).Return(nil).Once()
+
+		rec := setup.Delete("/print-queue")
+
+		testutil.AssertStatus(t, rec, http.StatusNoContent)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("prints the sheet as a PDF", func(t *testing.T) {
+		mockSvc.On("PrintSheet", mock.Anything, setup.WorkspaceID, setup.UserID).Return([]byte("%PDF-1.4"), nil).Once()
+
+		rec := setup.Post("/print-queue/sheet", "")
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		assert.Equal(t, "application/pdf", rec.Header().Get("Content-Type"))
+		assert.Equal(t, "%PDF-1.4", rec.Body.String())
+	})
+
+	t.Run("empty queue is 400", func(t *testing.T) {
+		mockSvc.On("PrintSheet", mock.Anything, setup.WorkspaceID, setup.UserID).Return(nil, labelprint.ErrQueueEmpty).Once()
+
+		rec := setup.Post("/print-queue/sheet", "")
+
+		testutil.AssertStatus(t, rec, http.StatusBadRequest)
+	})
+	t.Run("lists short codes with conflicts", func(t *testing.T) {
+		item := labelprint.ShortCode{Code: "AB12", EntityType: "item", EntityID: uuid.New()}
+		location := labelprint.ShortCode{Code: "ab12", EntityType: "location", EntityID: uuid.New()}
+		mockSvc.On("ShortCodeRegistry", mock.Anything, setup.WorkspaceID).Return(&labelprint.ShortCodeRegistry{
+			Codes:     []labelprint.ShortCode{item, location},
+			Conflicts: []labelprint.ShortCodeConflict{{Codes: []labelprint.ShortCode{item, location}, CrossEntity: true}},
+		}, nil).Once()
+
+		rec := setup.Get("/short-codes")
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		var body labelprint.ShortCodeRegistryResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		assert.Equal(t, 2, body.Total)
+		require.Len(t, body.Conflicts, 1)
+		assert.True(t, body.Conflicts[0].CrossEntity)
+		assert.Equal(t, "location", body.Conflicts[0].Codes[1].EntityType)
+	})
+}
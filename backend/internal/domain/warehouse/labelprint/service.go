@@ -0,0 +1,168 @@
+// Package labelprint is the per-user label print queue: items are queued as
+// they are tagged during the day and printed together on one label sheet.
+package labelprint
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/item"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// MaxQueueSize caps a queue. It matches how many items one lookup loads.
+const MaxQueueSize = item.MaxItemsByIDs
+
+var (
+	ErrQueueEmpty     = shared.NewDomainError(shared.ErrInvalidInput, "print queue is empty")
+	ErrQueueFull      = shared.NewDomainError(shared.ErrInvalidInput, fmt.Sprintf("print queue holds at most %d items", MaxQueueSize))
+	ErrItemNotFound   = shared.NewDomainError(shared.ErrNotFound, "item not found")
+	ErrNoItemsToQueue = shared.NewDomainError(shared.ErrInvalidInput, "no items to queue")
+)
+
+// QueueStore holds each user's queued item IDs per workspace, oldest first.
+// Queues are ephemeral: they expire if left unprinted.
+type QueueStore interface {
+	Add(ctx context.Context, workspaceID, userID uuid.UUID, itemIDs []uuid.UUID) error
+	Remove(ctx context.Context, workspaceID, userID uuid.UUID, itemIDs []uuid.UUID) error
+	List(ctx context.Context, workspaceID, userID uuid.UUID) ([]uuid.UUID, error)
+	Clear(ctx context.Context, workspaceID, userID uuid.UUID) error
+}
+
+// ItemLookup loads the items to print. Implemented by item.Service.
+type ItemLookup interface {
+	GetByIDs(ctx context.Context, workspaceID uuid.UUID, ids []uuid.UUID) ([]*item.Item, error)
+}
+
+// ServiceInterface defines the print queue operations.
+type ServiceInterface interface {
+	List(ctx context.Context, workspaceID, userID uuid.UUID) ([]*item.Item, error)
+	Add(ctx context.Context, workspaceID, userID uuid.UUID, itemIDs []uuid.UUID) ([]*item.Item, error)
+	Remove(ctx context.Context, workspaceID, userID, itemID uuid.UUID) error
+	Clear(ctx context.Context, workspaceID, userID uuid.UUID) error
+	PrintSheet(ctx context.Context, workspaceID, userID uuid.UUID) ([]byte, error)
+	ShortCodeRegistry(ctx context.Context, workspaceID uuid.UUID) (*ShortCodeRegistry, error)
+}
+
+// Service manages print queues.
+type Service struct {
+	store      QueueStore
+	items      ItemLookup
+	shortCodes ShortCodeLister
+}
+
+// NewService creates a new print queue service.
+func NewService(store QueueStore, items ItemLookup) *Service {
+	return &Service{store: store, items: items}
+}
+
+// List returns the user's queued items, oldest first. Items deleted since
+// they were queued are left out.
+func (s *Service) List(ctx context.Context, workspaceID, userID uuid.UUID) ([]*item.Item, error) {
+	items, _, err := s.queued(ctx, workspaceID, userID)
+	return items, err
+}
+
+// Add queues items for printing and returns the whole queue. Every item
+// must exist in the workspace; an item already queued moves to the end.
+func (s *Service) Add(ctx context.Context, workspaceID, userID uuid.UUID, itemIDs []uuid.UUID) ([]*item.Item, error) {
+	if len(itemIDs) == 0 {
+		return nil, ErrNoItemsToQueue
+	}
+
+	queued, err := s.store.List(ctx, workspaceID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(union(queued, itemIDs)) > MaxQueueSize {
+		return nil, ErrQueueFull
+	}
+
+	found, err := s.items.GetByIDs(ctx, workspaceID, itemIDs)
+	if err != nil {
+		return nil, err
+	}
+	if len(found) != len(union(nil, itemIDs)) {
+		return nil, ErrItemNotFound
+	}
+
+	if err := s.store.Add(ctx, workspaceID, userID, itemIDs); err != nil {
+		return nil, err
+	}
+	return s.List(ctx, workspaceID, userID)
+}
+
+// Remove takes an item off the user's queue. Removing an item that is not
+// queued is a no-op.
+func (s *Service) Remove(ctx context.Context, workspaceID, userID, itemID uuid.UUID) error {
+	return s.store.Remove(ctx, workspaceID, userID, []uuid.UUID{itemID})
+}
+
+// Clear empties the user's queue without printing it.
+func (s *Service) Clear(ctx context.Context, workspaceID, userID uuid.UUID) error {
+	return s.store.Clear(ctx, workspaceID, userID)
+}
+
+// PrintSheet renders the user's queued items as a label sheet and takes them
+// off the queue. Only the printed entries are removed, so items queued while
+// the sheet was rendering stay for the next one.
+func (s *Service) PrintSheet(ctx context.Context, workspaceID, userID uuid.UUID) ([]byte, error) {
+	items, ids, err := s.queued(ctx, workspaceID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(items) == 0 {
+		return nil, ErrQueueEmpty
+	}
+
+	sheet := RenderSheet(items)
+
+	// Entries for deleted items go too; they can never be printed.
+	if err := s.store.Remove(ctx, workspaceID, userID, ids); err != nil {
+		return nil, err
+	}
+	return sheet, nil
+}
+
+// queued loads the queue: the items still present, in queue order, and
+// every queued ID.
+func (s *Service) queued(ctx context.Context, workspaceID, userID uuid.UUID) ([]*item.Item, []uuid.UUID, error) {
+	ids, err := s.store.List(ctx, workspaceID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if len(ids) == 0 {
+		return []*item.Item{}, ids, nil
+	}
+
+	found, err := s.items.GetByIDs(ctx, workspaceID, ids)
+	if err != nil {
+		return nil, nil, err
+	}
+	byID := make(map[uuid.UUID]*item.Item, len(found))
+	for _, itm := range found {
+		byID[itm.ID()] = itm
+	}
+
+	items := make([]*item.Item, 0, len(ids))
+	for _, id := range ids {
+		if itm, ok := byID[id]; ok {
+			items = append(items, itm)
+		}
+	}
+	return items, ids, nil
+}
+
+// union returns the distinct IDs of a and b.
+func union(a, b []uuid.UUID) map[uuid.UUID]struct{} {
+	set := make(map[uuid.UUID]struct{}, len(a)+len(b))
+	for _, id := range a {
+		set[id] = struct{}{}
+	}
+	for _, id := range b {
+		set[id] = struct{}{}
+	}
+	return set
+}
@@ -0,0 +1,277 @@
+package labelprint_test
+
+import (
+	"bytes"
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/item"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/labelprint"
+)
+
+// memoryStore is an in-memory QueueStore with the Redis store's semantics.
+type memoryStore struct {
+	queues map[string][]uuid.UUID
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{queues: make(map[string][]uuid.UUID)}
+}
+
+func queueKey(workspaceID, userID uuid.UUID) string {
+	return workspaceID.String() + ":" + userID.String()
+}
+
+func (m *memoryStore) Add(ctx context.Context, workspaceID, userID uuid.UUID, itemIDs []uuid.UUID) error {
+	k := queueKey(workspaceID, userID)
+	for _, id := range itemIDs {
+		m.queues[k] = slices.DeleteFunc(m.queues[k], func(q uuid.UUID) bool { return q == id })
+		m.queues[k] = append(m.queues[k], id)
+	}
+	return nil
+}
+
+func (m *memoryStore) Remove(ctx context.Context, workspaceID, userID uuid.UUID, itemIDs []uuid.UUID) error {
+	k := queueKey(workspaceID, userID)
+	m.queues[k] = slices.DeleteFunc(m.queues[k], func(q uuid.UUID) bool { return slices.Contains(itemIDs, q) })
+	return nil
+}
+
+func (m *memoryStore) List(ctx context.Context, workspaceID, userID uuid.UUID) ([]uuid.UUID, error) {
+	return slices.Clone(m.queues[queueKey(workspaceID, userID)]), nil
+}
+
+func (m *memoryStore) Clear(ctx context.Context, workspaceID, userID uuid.UUID) error {
+	delete(m.queues, queueKey(workspaceID, userID))
+	return nil
+}
+
+// itemCatalog is an ItemLookup over a fixed set of items.
+type itemCatalog map[uuid.UUID]*item.Item
+
+func (c itemCatalog) GetByIDs(ctx context.Context, workspaceID uuid.UUID, ids []uuid.UUID) ([]*item.Item, error) {
+	var found []*item.Item
+	for _, id := range ids {
+		if itm, ok := c[id]; ok && itm.WorkspaceID() == workspaceID {
+			found = append(found, itm)
+		}
+	}
+	return found, nil
+}
+
+func newCatalog(t *testing.T, workspaceID uuid.UUID, names ...string) (itemCatalog, []*item.Item) {
+	t.Helper()
+	catalog := itemCatalog{}
+	items := make([]*item.Item, len(names))
+	for i, name := range names {
+		itm, err := item.NewItem(workspaceID, name, "SKU-"+name, 0)
+		require.NoError(t, err)
+		itm.SetShortCode("SC" + name)
+		catalog[itm.ID()] = itm
+		items[i] = itm
+	}
+	return catalog, items
+}
+
+func itemIDs(items []*item.Item) []uuid.UUID {
+	ids := make([]uuid.UUID, len(items))
+	for i, itm := range items {
+		ids[i] = itm.ID()
+	}
+	return ids
+}
+
+func TestService_Queue(t *testing.T) {
+	ctx := context.Background()
+	workspaceID, userID := uuid.New(), uuid.New()
+
+	t.Run("add, list and remove", func(t *testing.T) {
+		catalog, items := newCatalog(t, workspaceID, "A", "B", "C")
+		svc := labelprint.NewService(newMemoryStore(), catalog)
+
+		_, err := svc.Add(ctx, workspaceID, userID, itemIDs(items[:2]))
+		require.NoError(t, err)
+		queued, err := svc.Add(ctx, workspaceID, userID, itemIDs(items[2:]))
+		require.NoError(t, err)
+		assert.Equal(t, itemIDs(items), itemIDs(queued))
+
+		require.NoError(t, svc.Remove(ctx, workspaceID, userID, items[1].ID()))
+		queued, err = svc.List(ctx, workspaceID, userID)
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{items[0].ID(), items[2].ID()}, itemIDs(queued))
+	})
+
+	t.Run("queues are per user", func(t *testing.T) {
+		catalog, items := newCatalog(t, workspaceID, "A")
+		svc := labelprint.NewService(newMemoryStore(), catalog)
+
+		_, err := svc.Add(ctx, workspaceID, userID, itemIDs(items))
+		require.NoError(t, err)
+
+		queued, err := svc.List(ctx, workspaceID, uuid.New())
+		require.NoError(t, err)
+		assert.Empty(t, queued)
+	})
+
+	t.Run("rejects items outside the workspace", func(t *testing.T) {
+		catalog, _ := newCatalog(t, workspaceID, "A")
+		_, other := newCatalog(t, uuid.New(), "B")
+		store := newMemoryStore()
+		svc := labelprint.NewService(store, catalog)
+
+		_, err := svc.Add(ctx, workspaceID, userID, itemIDs(other))
+
+		assert.ErrorIs(t, err, labelprint.ErrItemNotFound)
+		assert.Empty(t, store.queues)
+	})
+
+	t.Run("rejects a full queue", func(t *testing.T) {
+		names := make([]string, labelprint.MaxQueueSize+1)
+		for i := range names {
+			names[i] = uuid.NewString()[:6]
+		}
+		catalog, items := newCatalog(t, workspaceID, names...)
+		svc := labelprint.NewService(newMemoryStore(), catalog)
+
+		_, err := svc.Add(ctx, workspaceID, userID, itemIDs(items[:labelprint.MaxQueueSize]))
+		require.NoError(t, err)
+
+		_, err = svc.Add(ctx, workspaceID, userID, itemIDs(items[labelprint.MaxQueueSize:]))
+		assert.ErrorIs(t, err, labelprint.ErrQueueFull)
+
+		// Re-queueing an item already in a full queue is fine.
+		_, err = svc.Add(ctx, workspaceID, userID, itemIDs(items[:1]))
+		assert.NoError(t, err)
+	})
+
+	t.Run("deleted items drop out of the list", func(t *testing.T) {
+		catalog, items := newCatalog(t, workspaceID, "A", "B")
+		svc := labelprint.NewService(newMemoryStore(), catalog)
+		_, err := svc.Add(ctx, workspaceID, userID, itemIDs(items))
+		require.NoError(t, err)
+
+		delete(catalog, items[0].ID())
+
+		queued, err := svc.List(ctx, workspaceID, userID)
+		require.NoError(t, err)
+		assert.Equal(t, itemIDs(items[1:]), itemIDs(queued))
+	})
+}
+
+func TestService_PrintSheet(t *testing.T) {
+	ctx := context.Background()
+	workspaceID, userID := uuid.New(), uuid.New()
+
+	t.Run("prints and consumes the queue", func(t *testing.T) {
+		catalog, items := newCatalog(t, workspaceID, "Drill", "Saw")
+		store := newMemoryStore()
+		svc := labelprint.NewService(store, catalog)
+		_, err := svc.Add(ctx, workspaceID, userID, itemIDs(items))
+		require.NoError(t, err)
+
+		sheet, err := svc.PrintSheet(ctx, workspaceID, userID)
+
+		require.NoError(t, err)
+		assert.True(t, bytes.HasPrefix(sheet, []byte("%PDF-")))
+		assert.Contains(t, string(sheet), "(Drill) Tj")
+		assert.Contains(t, string(sheet), "(SCSaw) Tj")
+		queued, err := svc.List(ctx, workspaceID, userID)
+		require.NoError(t, err)
+		assert.Empty(t, queued)
+	})
+
+	t.Run("empty queue", func(t *testing.T) {
+		catalog, _ := newCatalog(t, workspaceID)
+		svc := labelprint.NewService(newMemoryStore(), catalog)
+
+		_, err := svc.PrintSheet(ctx, workspaceID, userID)
+
+		assert.ErrorIs(t, err, labelprint.ErrQueueEmpty)
+	})
+}
+
+func TestRenderSheet_Pages(t *testing.T) {
+	names := make([]string, labelprint.LabelsPerSheet+1)
+	for i := range names {
+		names[i] = uuid.NewString()[:6]
+	}
+	_, items := newCatalog(t, uuid.New(), names...)
+
+	sheet := labelprint.RenderSheet(items)
+
+	assert.Contains(t, string(sheet), "/Count 2")
+}
+
+// shortCodeList is a ShortCodeLister over a fixed registry.
+type shortCodeList []labelprint.ShortCode
+
+func (l shortCodeList) ListShortCodes(ctx context.Context, workspaceID uuid.UUID) ([]labelprint.ShortCode, error) {
+	return slices.Clone(l), nil
+}
+
+func TestService_ShortCodeRegistry(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+
+	t.Run("detects a cross-entity duplicate code", func(t *testing.T) {
+		svc := labelprint.NewService(newMemoryStore(), itemCatalog{})
+		svc.SetShortCodeLister(shortCodeList{
+			{Code: "x9k2", EntityType: "item", EntityID: uuid.New()},
+			{Code: "AB12", EntityType: "item", EntityID: uuid.New()},
+			{Code: "ab12", EntityType: "container", EntityID: uuid.New()},
+		})
+
+		registry, err := svc.ShortCodeRegistry(ctx, workspaceID)
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"AB12", "ab12", "x9k2"}, shortCodes(registry.Codes))
+		require.Len(t, registry.Conflicts, 1)
+		assert.True(t, registry.Conflicts[0].CrossEntity)
+		assert.Equal(t, []string{"AB12", "ab12"}, shortCodes(registry.Conflicts[0].Codes))
+	})
+
+	t.Run("no conflicts between distinct codes", func(t *testing.T) {
+		svc := labelprint.NewService(newMemoryStore(), itemCatalog{})
+		svc.SetShortCodeLister(shortCodeList{
+			{Code: "AB12", EntityType: "item", EntityID: uuid.New()},
+			{Code: "AB13", EntityType: "location", EntityID: uuid.New()},
+		})
+
+		registry, err := svc.ShortCodeRegistry(ctx, workspaceID)
+
+		require.NoError(t, err)
+		assert.Len(t, registry.Codes, 2)
+		assert.Empty(t, registry.Conflicts)
+	})
+
+	t.Run("fails without a registry", func(t *testing.T) {
+		svc := labelprint.NewService(newMemoryStore(), itemCatalog{})
+
+		_, err := svc.ShortCodeRegistry(ctx, workspaceID)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestFindShortCodeConflicts_SameEntityType(t *testing.T) {
+	conflicts := labelprint.FindShortCodeConflicts([]labelprint.ShortCode{
+		{Code: "Shelf1", EntityType: "location", EntityID: uuid.New()},
+		{Code: "SHELF1", EntityType: "location", EntityID: uuid.New()},
+	})
+
+	require.Len(t, conflicts, 1)
+	assert.False(t, conflicts[0].CrossEntity)
+}
+
+func shortCodes(codes []labelprint.ShortCode) []string {
+	out := make([]string, len(codes))
+	for i, c := range codes {
+		out[i] = c.Code
+	}
+	return out
+}
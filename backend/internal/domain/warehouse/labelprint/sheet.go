@@ -0,0 +1,73 @@
+package labelprint
+
+import (
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/item"
+	"github.com/antti/home-warehouse/go-backend/internal/infra/pdf"
+)
+
+// Sheet layout, in points: A4 with 3 x 8 labels of 70 x 37 mm (Avery 3474
+// and compatible), centered vertically.
+const (
+	sheetColumns   = 3
+	sheetRows      = 8
+	labelWidth     = 198.43
+	labelHeight    = 104.88
+	labelPadding   = 12.0
+	labelNameRunes = 30 // what fits on one line at 10pt bold
+
+	barcodeHeight = 40.0
+	barcodeModule = 1.0
+)
+
+// LabelsPerSheet is how many labels fit on one page.
+const LabelsPerSheet = sheetColumns * sheetRows
+
+// RenderSheet renders one label per item, filling pages row by row. Each
+// label shows the item name and SKU and its short code as a Code 128
+// barcode, which the scan page resolves like any other item code.
+func RenderSheet(items []*item.Item) []byte {
+	doc := pdf.New()
+	top := (pdf.PageHeight - sheetRows*labelHeight) / 2
+
+	var page *pdf.Page
+	for i, itm := range items {
+		slot := i % LabelsPerSheet
+		if slot == 0 {
+			page = doc.AddPage()
+		}
+		x := float64(slot%sheetColumns) * labelWidth
+		y := top + float64(slot/sheetColumns)*labelHeight
+		renderLabel(page, x, y, itm)
+	}
+	return doc.Bytes()
+}
+
+// renderLabel draws one label whose top-left corner is (x, y).
+func renderLabel(page *pdf.Page, x, y float64, itm *item.Item) {
+	left := x + labelPadding
+	page.Text(left, y+labelPadding+10, pdf.Bold, 10, truncate(itm.Name(), labelNameRunes))
+	page.Text(left, y+labelPadding+22, pdf.Regular, 8, "SKU "+itm.SKU())
+
+	code := itm.ShortCode()
+	barcodeTop := y + labelPadding + 28
+	// The barcode's quiet zone spans the padding, so it may use the full
+	// label width; long codes get narrower bars.
+	module := barcodeModule
+	if w := pdf.Code128Width(code, module); w > labelWidth {
+		module *= labelWidth / w
+	}
+	// Codes with characters Code 128 cannot carry are printed as text only.
+	if err := page.Barcode128(x, barcodeTop, module, barcodeHeight, code); err != nil {
+		barcodeTop -= barcodeHeight / 2
+	}
+	page.Text(left, barcodeTop+barcodeHeight+12, pdf.Regular, 9, code)
+}
+
+// truncate shortens s to at most n runes, marking the cut with an ellipsis.
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n-1]) + "…"
+}
@@ -0,0 +1,93 @@
+package labelprint
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// ShortCode is one entry of the workspace's short code registry: the code
+// printed on a label and the item, location or container it resolves to.
+type ShortCode struct {
+	Code       string
+	EntityType string // "item", "location" or "container"
+	EntityID   uuid.UUID
+}
+
+// ShortCodeConflict groups codes a scanner cannot tell apart. Codes are
+// already unique across items, locations and containers (one global
+// registry), but only case-sensitively: "AB12" and "ab12" are different
+// codes that a scanner in the wrong case mode, or someone typing the code,
+// would mix up.
+type ShortCodeConflict struct {
+	Codes       []ShortCode
+	CrossEntity bool // the codes belong to more than one entity type
+}
+
+// ShortCodeRegistry is every short code in a workspace, sorted by code, with
+// the ambiguous ones grouped in Conflicts.
+type ShortCodeRegistry struct {
+	Codes     []ShortCode
+	Conflicts []ShortCodeConflict
+}
+
+// ShortCodeLister lists a workspace's short codes. Implemented by the
+// postgres shortlink repository over warehouse.short_codes.
+type ShortCodeLister interface {
+	ListShortCodes(ctx context.Context, workspaceID uuid.UUID) ([]ShortCode, error)
+}
+
+// SetShortCodeLister wires the short code registry. Without it
+// ShortCodeRegistry fails.
+func (s *Service) SetShortCodeLister(lister ShortCodeLister) {
+	s.shortCodes = lister
+}
+
+// ShortCodeRegistry returns the workspace's short codes for checking a batch
+// of labels for codes a scanner could confuse before printing them.
+func (s *Service) ShortCodeRegistry(ctx context.Context, workspaceID uuid.UUID) (*ShortCodeRegistry, error) {
+	if s.shortCodes == nil {
+		return nil, errors.New("short code registry is not configured")
+	}
+	codes, err := s.shortCodes.ListShortCodes(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(codes, func(i, j int) bool { return codes[i].Code < codes[j].Code })
+	return &ShortCodeRegistry{Codes: codes, Conflicts: FindShortCodeConflicts(codes)}, nil
+}
+
+// FindShortCodeConflicts groups codes that are equal ignoring case. Groups
+// come out in the order their first code appears in codes.
+func FindShortCodeConflicts(codes []ShortCode) []ShortCodeConflict {
+	groups := make(map[string][]ShortCode)
+	var keys []string
+	for _, c := range codes {
+		key := strings.ToLower(c.Code)
+		if _, seen := groups[key]; !seen {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], c)
+	}
+
+	conflicts := []ShortCodeConflict{}
+	for _, key := range keys {
+		group := groups[key]
+		if len(group) < 2 {
+			continue
+		}
+		conflict := ShortCodeConflict{Codes: group}
+		for _, c := range group[1:] {
+			if c.EntityType != group[0].EntityType {
+				conflict.CrossEntity = true
+				break
+			}
+		}
+		conflicts = append(conflicts, conflict)
+	}
+	return conflicts
+}
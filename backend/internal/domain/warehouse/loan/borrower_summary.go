@@ -0,0 +1,300 @@
+package loan
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/google/uuid"
+
+	appMiddleware "github.com/antti/home-warehouse/go-backend/internal/api/middleware"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// ItemNameLookup resolves the items behind inventory rows. It is the subset
+// of DecorationLookup the borrower summary needs.
+type ItemNameLookup interface {
+	ItemsByInventoryIDs(ctx context.Context, workspaceID uuid.UUID, inventoryIDs []uuid.UUID) (map[uuid.UUID]ItemLookupRow, error)
+}
+
+// SetItemNameLookup wires the lookup used to put item names on borrower
+// summaries. Without it the names are left empty.
+func (s *Service) SetItemNameLookup(lookup ItemNameLookup) {
+	s.itemNames = lookup
+}
+
+// BorrowerLoanSummary lists everything a borrower has had on loan.
+type BorrowerLoanSummary struct {
+	BorrowerID uuid.UUID
+	// Active loans, soonest due first; loans without a due date come last.
+	Active []BorrowerLoanEntry
+	// Returned loans, most recently returned first.
+	History      []BorrowerLoanEntry
+	OverdueCount int
+}
+
+// BorrowerLoanEntry is one loan of a BorrowerLoanSummary.
+type BorrowerLoanEntry struct {
+	Loan     *Loan
+	ItemID   uuid.UUID
+	ItemName string
+	Overdue  bool
+}
+
+// borrowerSummaryPageSize is the page size used to walk FindByBorrower.
+const borrowerSummaryPageSize = shared.MaxPageSize
+
+// BorrowerLoanSummary returns the borrower's active and returned loans with
+// item names and overdue flags. Overdue honours the workspace grace period.
+func (s *Service) BorrowerLoanSummary(ctx context.Context, workspaceID, borrowerID uuid.UUID) (*BorrowerLoanSummary, error) {
+	var loans []*Loan
+	for page := 1; ; page++ {
+		batch, err := s.repo.FindByBorrower(ctx, workspaceID, borrowerID, shared.Pagination{Page: page, PageSize: borrowerSummaryPageSize})
+		if err != nil {
+			return nil, err
+		}
+		loans = append(loans, batch...)
+		if len(batch) < borrowerSummaryPageSize {
+			break
+		}
+	}
+
+	settings, err := s.GetSettings(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := map[uuid.UUID]ItemLookupRow{}
+	if s.itemNames != nil && len(loans) > 0 {
+		seen := map[uuid.UUID]struct{}{}
+		inventoryIDs := make([]uuid.UUID, 0, len(loans))
+		for _, l := range loans {
+			if _, ok := seen[l.InventoryID()]; !ok {
+				seen[l.InventoryID()] = struct{}{}
+				inventoryIDs = append(inventoryIDs, l.InventoryID())
+			}
+		}
+		items, err = s.itemNames.ItemsByInventoryIDs(ctx, workspaceID, inventoryIDs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	summary := &BorrowerLoanSummary{
+		BorrowerID: borrowerID,
+		Active:     []BorrowerLoanEntry{},
+		History:    []BorrowerLoanEntry{},
+	}
+	now := time.Now()
+	for _, l := range loans {
+		item := items[l.InventoryID()]
+		entry := BorrowerLoanEntry{
+			Loan:     l,
+			ItemID:   item.ItemID,
+			ItemName: item.ItemName,
+			Overdue:  l.IsOverdueAt(now, settings.OverdueGraceDays),
+		}
+		if entry.Overdue {
+			summary.OverdueCount++
+		}
+		if l.IsActive() {
+			summary.Active = append(summary.Active, entry)
+		} else {
+			summary.History = append(summary.History, entry)
+		}
+	}
+
+	sort.SliceStable(summary.Active, func(i, j int) bool {
+		a, b := summary.Active[i].Loan.DueDate(), summary.Active[j].Loan.DueDate()
+		switch {
+		case a == nil:
+			return false
+		case b == nil:
+			return true
+		default:
+			return a.Before(*b)
+		}
+	})
+	sort.SliceStable(summary.History, func(i, j int) bool {
+		return summary.History[i].Loan.ReturnedAt().After(*summary.History[j].Loan.ReturnedAt())
+	})
+
+	return summary, nil
+}
+
+// RegisterBorrowerSummaryRoutes registers the borrower loan summary and its
+// CSV export.
+func RegisterBorrowerSummaryRoutes(api huma.API, svc ServiceInterface) {
+	huma.Get(api, "/borrowers/{borrower_id}/loan-summary", getBorrowerLoanSummary(svc))
+	huma.Get(api, "/borrowers/{borrower_id}/loan-summary.csv", exportBorrowerLoanSummary(svc))
+}
+
+// getBorrowerLoanSummary returns a borrower's active and past loans.
+func getBorrowerLoanSummary(svc ServiceInterface) func(context.Context, *BorrowerLoanSummaryInput) (*BorrowerLoanSummaryOutput, error) {
+	return func(ctx context.Context, input *BorrowerLoanSummaryInput) (*BorrowerLoanSummaryOutput, error) {
+		workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+		if !ok {
+			return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+		}
+
+		summary, err := svc.BorrowerLoanSummary(ctx, workspaceID, input.BorrowerID)
+		if err != nil {
+			return nil, huma.Error500InternalServerError(msgFailedToListLoans)
+		}
+
+		return &BorrowerLoanSummaryOutput{Body: toBorrowerLoanSummaryResponse(summary)}, nil
+	}
+}
+
+// exportBorrowerLoanSummary returns the borrower summary as a CSV file,
+// active loans first.
+func exportBorrowerLoanSummary(svc ServiceInterface) func(context.Context, *BorrowerLoanSummaryInput) (*BorrowerLoanSummaryCSVOutput, error) {
+	return func(ctx context.Context, input *BorrowerLoanSummaryInput) (*BorrowerLoanSummaryCSVOutput, error) {
+		workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+		if !ok {
+			return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+		}
+
+		summary, err := svc.BorrowerLoanSummary(ctx, workspaceID, input.BorrowerID)
+		if err != nil {
+			return nil, huma.Error500InternalServerError(msgFailedToListLoans)
+		}
+
+		body, err := borrowerLoanSummaryCSV(summary)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to write CSV")
+		}
+
+		return &BorrowerLoanSummaryCSVOutput{
+			ContentType:        "text/csv",
+			ContentDisposition: fmt.Sprintf("attachment; filename=\"borrower-loans-%s.csv\"", input.BorrowerID.String()[:8]),
+			Body:               body,
+		}, nil
+	}
+}
+
+func borrowerLoanSummaryCSV(summary *BorrowerLoanSummary) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write([]string{"status", "item_name", "quantity", "loaned_at", "due_date", "returned_at", "overdue", "notes"}); err != nil {
+		return nil, err
+	}
+
+	date := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.Format("2006-01-02")
+	}
+	write := func(status string, entries []BorrowerLoanEntry) error {
+		for _, e := range entries {
+			notes := ""
+			if e.Loan.Notes() != nil {
+				notes = *e.Loan.Notes()
+			}
+			loanedAt := e.Loan.LoanedAt()
+			row := []string{
+				status,
+				sanitizeCSVCell(e.ItemName),
+				strconv.Itoa(e.Loan.Quantity()),
+				date(&loanedAt),
+				date(e.Loan.DueDate()),
+				date(e.Loan.ReturnedAt()),
+				strconv.FormatBool(e.Overdue),
+				sanitizeCSVCell(notes),
+			}
+			if err := w.Write(row); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if err := write("active", summary.Active); err != nil {
+		return nil, err
+	}
+	if err := write("returned", summary.History); err != nil {
+		return nil, err
+	}
+
+	w.Flush()
+	return buf.Bytes(), w.Error()
+}
+
+// sanitizeCSVCell neutralizes spreadsheet formula injection by prefixing a
+// single quote when a cell starts with a formula-trigger character.
+func sanitizeCSVCell(cell string) string {
+	if cell == "" {
+		return cell
+	}
+	switch cell[0] {
+	case '=', '+', '-', '@', '\t', '\r':
+		return "'" + cell
+	}
+	return cell
+}
+
+func toBorrowerLoanSummaryResponse(summary *BorrowerLoanSummary) BorrowerLoanSummaryResponse {
+	toEntries := func(entries []BorrowerLoanEntry) []BorrowerLoanEntryResponse {
+		out := make([]BorrowerLoanEntryResponse, len(entries))
+		for i, e := range entries {
+			out[i] = BorrowerLoanEntryResponse{
+				ID:          e.Loan.ID(),
+				InventoryID: e.Loan.InventoryID(),
+				ItemID:      e.ItemID,
+				ItemName:    e.ItemName,
+				Quantity:    e.Loan.Quantity(),
+				LoanedAt:    e.Loan.LoanedAt(),
+				DueDate:     e.Loan.DueDate(),
+				ReturnedAt:  e.Loan.ReturnedAt(),
+				IsOverdue:   e.Overdue,
+				Notes:       e.Loan.Notes(),
+			}
+		}
+		return out
+	}
+	return BorrowerLoanSummaryResponse{
+		BorrowerID:   summary.BorrowerID,
+		Active:       toEntries(summary.Active),
+		History:      toEntries(summary.History),
+		OverdueCount: summary.OverdueCount,
+	}
+}
+
+type BorrowerLoanSummaryInput struct {
+	BorrowerID uuid.UUID `path:"borrower_id"`
+}
+
+type BorrowerLoanSummaryOutput struct {
+	Body BorrowerLoanSummaryResponse
+}
+
+type BorrowerLoanSummaryResponse struct {
+	BorrowerID   uuid.UUID                   `json:"borrower_id"`
+	Active       []BorrowerLoanEntryResponse `json:"active" doc:"Loans still out, soonest due first"`
+	History      []BorrowerLoanEntryResponse `json:"history" doc:"Returned loans, most recent first"`
+	OverdueCount int                         `json:"overdue_count"`
+}
+
+type BorrowerLoanEntryResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	InventoryID uuid.UUID  `json:"inventory_id"`
+	ItemID      uuid.UUID  `json:"item_id"`
+	ItemName    string     `json:"item_name"`
+	Quantity    int        `json:"quantity"`
+	LoanedAt    time.Time  `json:"loaned_at"`
+	DueDate     *time.Time `json:"due_date,omitempty"`
+	ReturnedAt  *time.Time `json:"returned_at,omitempty"`
+	IsOverdue   bool       `json:"is_overdue"`
+	Notes       *string    `json:"notes,omitempty"`
+}
+
+type BorrowerLoanSummaryCSVOutput struct {
+	ContentType        string `header:"Content-Type"`
+	ContentDisposition string `header:"Content-Disposition"`
+	Body               []byte
+}
@@ -0,0 +1,152 @@
+package loan
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+type mockItemNameLookup struct {
+	mock.Mock
+}
+
+func (m *mockItemNameLookup) ItemsByInventoryIDs(ctx context.Context, workspaceID uuid.UUID, inventoryIDs []uuid.UUID) (map[uuid.UUID]ItemLookupRow, error) {
+	args := m.Called(ctx, workspaceID, inventoryIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[uuid.UUID]ItemLookupRow), args.Error(1)
+}
+
+func summaryLoan(workspaceID, borrowerID, inventoryID uuid.UUID, loanedAt time.Time, dueDate, returnedAt *time.Time) *Loan {
+	return Reconstruct(uuid.New(), workspaceID, inventoryID, borrowerID, 1, loanedAt, dueDate, returnedAt, nil, loanedAt, loanedAt)
+}
+
+func TestService_BorrowerLoanSummary(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+	borrowerID := uuid.New()
+	inventoryID := uuid.New()
+	itemID := uuid.New()
+	now := time.Now()
+	day := 24 * time.Hour
+
+	past := now.Add(-2 * day)
+	soon := now.Add(3 * day)
+	later := now.Add(10 * day)
+	returnedOld := now.Add(-20 * day)
+	returnedRecent := now.Add(-5 * day)
+
+	dueLater := summaryLoan(workspaceID, borrowerID, inventoryID, now.Add(-day), &later, nil)
+	noDue := summaryLoan(workspaceID, borrowerID, inventoryID, now.Add(-day), nil, nil)
+	overdue := summaryLoan(workspaceID, borrowerID, inventoryID, now.Add(-9*day), &past, nil)
+	dueSoon := summaryLoan(workspaceID, borrowerID, inventoryID, now.Add(-day), &soon, nil)
+	oldReturn := summaryLoan(workspaceID, borrowerID, inventoryID, now.Add(-30*day), nil, &returnedOld)
+	recentReturn := summaryLoan(workspaceID, borrowerID, inventoryID, now.Add(-8*day), nil, &returnedRecent)
+
+	t.Run("splits, sorts and names loans", func(t *testing.T) {
+		repo := new(MockRepository)
+		lookup := new(mockItemNameLookup)
+		svc := NewService(repo, nil, nil)
+		svc.SetItemNameLookup(lookup)
+
+		repo.On("FindByBorrower", ctx, workspaceID, borrowerID, shared.Pagination{Page: 1, PageSize: shared.MaxPageSize}).
+			Return([]*Loan{dueLater, noDue, oldReturn, overdue, recentReturn, dueSoon}, nil)
+		lookup.On("ItemsByInventoryIDs", ctx, workspaceID, []uuid.UUID{inventoryID}).
+			Return(map[uuid.UUID]ItemLookupRow{inventoryID: {ItemID: itemID, ItemName: "Ladder"}}, nil)
+
+		summary, err := svc.BorrowerLoanSummary(ctx, workspaceID, borrowerID)
+		require.NoError(t, err)
+
+		require.Len(t, summary.Active, 4)
+		assert.Equal(t, overdue.ID(), summary.Active[0].Loan.ID())
+		assert.Equal(t, dueSoon.ID(), summary.Active[1].Loan.ID())
+		assert.Equal(t, dueLater.ID(), summary.Active[2].Loan.ID())
+		assert.Equal(t, noDue.ID(), summary.Active[3].Loan.ID())
+		assert.True(t, summary.Active[0].Overdue)
+		assert.False(t, summary.Active[1].Overdue)
+		assert.Equal(t, 1, summary.OverdueCount)
+		assert.Equal(t, "Ladder", summary.Active[0].ItemName)
+		assert.Equal(t, itemID, summary.Active[0].ItemID)
+
+		require.Len(t, summary.History, 2)
+		assert.Equal(t, recentReturn.ID(), summary.History[0].Loan.ID())
+		assert.Equal(t, oldReturn.ID(), summary.History[1].Loan.ID())
+	})
+
+	t.Run("honours the overdue grace period", func(t *testing.T) {
+		repo := new(MockRepository)
+		settings := new(mockSettingsRepository)
+		svc := NewService(repo, nil, nil)
+		svc.SetSettingsRepository(settings)
+
+		repo.On("FindByBorrower", ctx, workspaceID, borrowerID, shared.Pagination{Page: 1, PageSize: shared.MaxPageSize}).
+			Return([]*Loan{overdue}, nil)
+		settings.On("Get", ctx, workspaceID).Return(&Settings{OverdueGraceDays: 3}, nil)
+
+		summary, err := svc.BorrowerLoanSummary(ctx, workspaceID, borrowerID)
+		require.NoError(t, err)
+		require.Len(t, summary.Active, 1)
+		assert.False(t, summary.Active[0].Overdue)
+		assert.Equal(t, 0, summary.OverdueCount)
+	})
+
+	t.Run("walks every page of loans", func(t *testing.T) {
+		repo := new(MockRepository)
+		svc := NewService(repo, nil, nil)
+
+		full := make([]*Loan, shared.MaxPageSize)
+		for i := range full {
+			full[i] = summaryLoan(workspaceID, borrowerID, inventoryID, now, nil, nil)
+		}
+		repo.On("FindByBorrower", ctx, workspaceID, borrowerID, shared.Pagination{Page: 1, PageSize: shared.MaxPageSize}).Return(full, nil)
+		repo.On("FindByBorrower", ctx, workspaceID, borrowerID, shared.Pagination{Page: 2, PageSize: shared.MaxPageSize}).Return([]*Loan{noDue}, nil)
+
+		summary, err := svc.BorrowerLoanSummary(ctx, workspaceID, borrowerID)
+		require.NoError(t, err)
+		assert.Len(t, summary.Active, shared.MaxPageSize+1)
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("returns repository error", func(t *testing.T) {
+		repo := new(MockRepository)
+		svc := NewService(repo, nil, nil)
+		repo.On("FindByBorrower", ctx, workspaceID, borrowerID, mock.Anything).Return(nil, errors.New("db down"))
+
+		_, err := svc.BorrowerLoanSummary(ctx, workspaceID, borrowerID)
+		assert.Error(t, err)
+	})
+}
+
+func TestBorrowerLoanSummaryCSV(t *testing.T) {
+	now := time.Date(2026, 4, 1, 12, 0, 0, 0, time.UTC)
+	due := time.Date(2026, 4, 10, 0, 0, 0, 0, time.UTC)
+	returned := time.Date(2026, 3, 20, 0, 0, 0, 0, time.UTC)
+	notes := "=HYPERLINK(\"x\")"
+
+	active := Reconstruct(uuid.New(), uuid.New(), uuid.New(), uuid.New(), 2, now, &due, nil, &notes, now, now)
+	past := Reconstruct(uuid.New(), uuid.New(), uuid.New(), uuid.New(), 1, now.AddDate(0, -1, 0), nil, &returned, nil, now, now)
+
+	out, err := borrowerLoanSummaryCSV(&BorrowerLoanSummary{
+		Active:  []BorrowerLoanEntry{{Loan: active, ItemName: "Drill", Overdue: true}},
+		History: []BorrowerLoanEntry{{Loan: past, ItemName: "Saw"}},
+	})
+	require.NoError(t, err)
+
+	records, err := csv.NewReader(strings.NewReader(string(out))).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, []string{"status", "item_name", "quantity", "loaned_at", "due_date", "returned_at", "overdue", "notes"}, records[0])
+	assert.Equal(t, []string{"active", "Drill", "2", "2026-04-01", "2026-04-10", "", "true", "'" + notes}, records[1])
+	assert.Equal(t, []string{"returned", "Saw", "1", "2026-03-01", "", "2026-03-20", "false", ""}, records[2])
+}
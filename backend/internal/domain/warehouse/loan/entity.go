@@ -99,10 +99,17 @@ func (l *Loan) IsActive() bool {
 }
 
 func (l *Loan) IsOverdue() bool {
+	return l.IsOverdueAt(time.Now(), 0)
+}
+
+// IsOverdueAt reports whether the loan is overdue at now when loans get
+// graceDays past their due date before counting as overdue. A loan exactly
+// at the end of its grace period is not yet overdue.
+func (l *Loan) IsOverdueAt(now time.Time, graceDays int) bool {
 	if l.returnedAt != nil || l.dueDate == nil {
 		return false
 	}
-	return time.Now().After(*l.dueDate)
+	return now.After(l.dueDate.AddDate(0, 0, graceDays))
 }
 
 func (l *Loan) Return() error {
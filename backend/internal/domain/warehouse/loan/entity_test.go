@@ -256,6 +256,36 @@ func TestLoan_IsOverdue(t *testing.T) {
 	}
 }
 
+func TestLoan_IsOverdueAt(t *testing.T) {
+	due := time.Date(2026, 5, 10, 0, 0, 0, 0, time.UTC)
+	loanItem := loan.Reconstruct(uuid.New(), uuid.New(), uuid.New(), uuid.New(), 1, due.AddDate(0, 0, -7), &due, nil, nil, due, due)
+
+	tests := []struct {
+		name      string
+		now       time.Time
+		graceDays int
+		want      bool
+	}{
+		{"no grace, at due date", due, 0, false},
+		{"no grace, one day past", due.AddDate(0, 0, 1), 0, true},
+		{"within grace", due.AddDate(0, 0, 2), 3, false},
+		{"exactly at grace", due.AddDate(0, 0, 3), 3, false},
+		{"one day past grace", due.AddDate(0, 0, 4), 3, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, loanItem.IsOverdueAt(tt.now, tt.graceDays))
+		})
+	}
+
+	t.Run("returned loan is never overdue", func(t *testing.T) {
+		returned := due
+		returnedLoan := loan.Reconstruct(uuid.New(), uuid.New(), uuid.New(), uuid.New(), 1, due.AddDate(0, 0, -7), &due, &returned, nil, due, due)
+		assert.False(t, returnedLoan.IsOverdueAt(due.AddDate(0, 0, 30), 3))
+	})
+}
+
 func TestLoan_Return(t *testing.T) {
 	workspaceID := uuid.New()
 	inventoryID := uuid.New()
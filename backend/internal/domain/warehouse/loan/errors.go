@@ -9,5 +9,6 @@ var (
 	ErrQuantityExceedsAvailable = errors.New("loan quantity exceeds available inventory")
 	ErrInventoryNotAvailable    = errors.New("inventory is not available for loan")
 	ErrInventoryOnLoan          = errors.New("inventory is currently on loan")
+	ErrItemNotLoanable          = errors.New("item is not loanable")
 	ErrInvalidDueDate           = errors.New("due date must be after loaned date")
 )
@@ -10,6 +10,7 @@ import (
 	"github.com/google/uuid"
 
 	appMiddleware "github.com/antti/home-warehouse/go-backend/internal/api/middleware"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/inventory"
 	"github.com/antti/home-warehouse/go-backend/internal/infra/events"
 	"github.com/antti/home-warehouse/go-backend/internal/shared"
 )
@@ -287,6 +288,8 @@ func mapCreateLoanError(err error) error {
 		return huma.Error400BadRequest("requested quantity exceeds available quantity")
 	case errors.Is(err, ErrInventoryOnLoan):
 		return huma.Error400BadRequest("inventory already has an active loan")
+	case errors.Is(err, ErrItemNotLoanable):
+		return huma.Error400BadRequest("item is marked as not loanable (a fixed asset) and cannot be loaned out")
 	default:
 		return appMiddleware.MapDomainError(err)
 	}
@@ -354,7 +357,16 @@ func returnLoan(svc ServiceInterface, broadcaster *events.Broadcaster, lookup De
 			return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
 		}
 
-		loan, err := svc.Return(ctx, input.ID, workspaceID)
+		var returnInput ReturnInput
+		if input.Body != nil {
+			returnInput = ReturnInput{
+				Condition:     input.Body.ReturnCondition,
+				Note:          input.Body.ReturnNote,
+				MarkForRepair: input.Body.MarkForRepair,
+			}
+		}
+
+		loan, change, err := svc.Return(ctx, input.ID, workspaceID, returnInput)
 		if err != nil {
 			if errors.Is(err, ErrLoanNotFound) {
 				return nil, huma.Error404NotFound(msgLoanNotFound)
@@ -374,10 +386,30 @@ func returnLoan(svc ServiceInterface, broadcaster *events.Broadcaster, lookup De
 				EntityID:   input.ID.String(),
 				EntityType: "loan",
 				UserID:     authUser.ID,
-				Data: map[string]any{
-					"user_name": userName,
-				},
+				Data:       returnEventData(userName, returnInput),
 			})
+
+			// The condition change gets its own inventory activity row so
+			// it shows in the entry's condition history.
+			if change != nil {
+				data := map[string]any{
+					"id":                 change.InventoryID,
+					"condition":          change.To,
+					"previous_condition": change.From,
+					"loan_id":            loan.ID(),
+					"user_name":          userName,
+				}
+				if change.Note != nil {
+					data["note"] = *change.Note
+				}
+				broadcaster.Publish(workspaceID, events.Event{
+					Type:       "inventory.updated",
+					EntityID:   change.InventoryID.String(),
+					EntityType: "inventory",
+					UserID:     authUser.ID,
+					Data:       data,
+				})
+			}
 		}
 
 		decorated, err := decorateOneLoan(ctx, lookup, workspaceID, loan)
@@ -391,6 +423,21 @@ func returnLoan(svc ServiceInterface, broadcaster *events.Broadcaster, lookup De
 	}
 }
 
+// returnEventData is the loan.returned payload: the actor plus whatever was
+// recorded about the state the entry came back in.
+func returnEventData(userName string, input ReturnInput) map[string]any {
+	data := map[string]any{
+		"user_name": userName,
+	}
+	if input.Condition != nil {
+		data["return_condition"] = *input.Condition
+	}
+	if input.Note != nil {
+		data["return_note"] = *input.Note
+	}
+	return data
+}
+
 // extendLoan returns the handler for PATCH /loans/{id}/extend (legacy
 // single-purpose endpoint; retained for back-compat — the Phase 62 edit flow
 // uses PATCH /loans/{id} instead, per D-01).
@@ -609,7 +656,7 @@ type CreateLoanInput struct {
 		BorrowerID  uuid.UUID  `json:"borrower_id" doc:"ID of the borrower"`
 		Quantity    int        `json:"quantity" minimum:"1" doc:"Quantity to loan"`
 		LoanedAt    *time.Time `json:"loaned_at,omitempty" doc:"Loan date (defaults to now)"`
-		DueDate     *time.Time `json:"due_date,omitempty" doc:"Due date for return"`
+		DueDate     *time.Time `json:"due_date,omitempty" doc:"Due date for return (defaults to loaned_at plus the workspace default loan period)"`
 		Notes       *string    `json:"notes,omitempty" maxLength:"1000"`
 	}
 }
@@ -619,7 +666,12 @@ type CreateLoanOutput struct {
 }
 
 type ReturnLoanInput struct {
-	ID uuid.UUID `path:"id"`
+	ID   uuid.UUID `path:"id"`
+	Body *struct {
+		ReturnCondition *inventory.Condition `json:"return_condition,omitempty" enum:"NEW,EXCELLENT,GOOD,FAIR,POOR,DAMAGED,FOR_REPAIR" doc:"Condition the entry came back in; replaces its current condition"`
+		ReturnNote      *string              `json:"return_note,omitempty" doc:"Note on the returned condition, kept in the activity log"`
+		MarkForRepair   bool                 `json:"mark_for_repair,omitempty" doc:"Record a DAMAGED return as FOR_REPAIR"`
+	}
 }
 
 type ReturnLoanOutput struct {
@@ -9,7 +9,9 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/inventory"
 	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/loan"
 	"github.com/antti/home-warehouse/go-backend/internal/shared"
 	"github.com/antti/home-warehouse/go-backend/internal/testutil"
@@ -48,9 +50,17 @@ func mockSliceErr[T any](args mock.Arguments) ([]T, error) {
 	return args.Get(0).([]T), args.Error(1)
 }
 
-func (m *MockService) Return(ctx context.Context, id, workspaceID uuid.UUID) (*loan.Loan, error) {
-	args := m.Called(ctx, id, workspaceID)
-	return mockPtrErr[loan.Loan](args)
+func (m *MockService) Return(ctx context.Context, id, workspaceID uuid.UUID, input loan.ReturnInput) (*loan.Loan, *loan.ConditionChange, error) {
+	args := m.Called(ctx, id, workspaceID, input)
+	var l *loan.Loan
+	if v := args.Get(0); v != nil {
+		l = v.(*loan.Loan)
+	}
+	var change *loan.ConditionChange
+	if v := args.Get(1); v != nil {
+		change = v.(*loan.ConditionChange)
+	}
+	return l, change, args.Error(2)
 }
 
 func (m *MockService) ExtendDueDate(ctx context.Context, id, workspaceID uuid.UUID, newDueDate time.Time) (*loan.Loan, error) {
@@ -94,6 +104,21 @@ func (m *MockService) GetOverdueLoans(ctx context.Context, workspaceID uuid.UUID
 	return mockSliceErr[*loan.Loan](args)
 }
 
+func (m *MockService) BorrowerLoanSummary(ctx context.Context, workspaceID, borrowerID uuid.UUID) (*loan.BorrowerLoanSummary, error) {
+	args := m.Called(ctx, workspaceID, borrowerID)
+	return mockPtrErr[loan.BorrowerLoanSummary](args)
+}
+
+func (m *MockService) GetSettings(ctx context.Context, workspaceID uuid.UUID) (*loan.Settings, error) {
+	args := m.Called(ctx, workspaceID)
+	return mockPtrErr[loan.Settings](args)
+}
+
+func (m *MockService) UpdateSettings(ctx context.Context, workspaceID uuid.UUID, settings loan.Settings) (*loan.Settings, error) {
+	args := m.Called(ctx, workspaceID, settings)
+	return mockPtrErr[loan.Settings](args)
+}
+
 func (m *MockService) ListByItem(ctx context.Context, workspaceID, itemID uuid.UUID) ([]*loan.Loan, error) {
 	args := m.Called(ctx, workspaceID, itemID)
 	return args.Get(0).([]*loan.Loan), args.Error(1)
@@ -166,6 +191,18 @@ func TestLoanHandler_Create(t *testing.T) {
 		testutil.AssertStatus(t, rec, http.StatusBadRequest)
 		mockSvc.AssertExpectations(t)
 	})
+
+	t.Run("returns 400 for an item that is not loanable", func(t *testing.T) {
+		mockSvc.On("Create", mock.Anything, mock.Anything).
+			Return(nil, loan.ErrItemNotLoanable).Once()
+
+		body := `{"inventory_id":"00000000-0000-0000-0000-000000000000","borrower_id":"00000000-0000-0000-0000-000000000000","quantity":1,"loaned_at":"2024-01-01T00:00:00Z","due_date":"2024-01-08T00:00:00Z"}`
+		rec := setup.Post("/loans", body)
+
+		testutil.AssertStatus(t, rec, http.StatusBadRequest)
+		assert.Contains(t, rec.Body.String(), "not loanable")
+		mockSvc.AssertExpectations(t)
+	})
 }
 
 func TestLoanHandler_List(t *testing.T) {
@@ -332,8 +369,8 @@ func TestLoanHandler_Return(t *testing.T) {
 		testLoan, _ := loan.NewLoan(setup.WorkspaceID, inventoryID, borrowerID, 1, loanedAt, &dueDate, nil)
 		loanID := testLoan.ID()
 
-		mockSvc.On("Return", mock.Anything, loanID, setup.WorkspaceID).
-			Return(testLoan, nil).Once()
+		mockSvc.On("Return", mock.Anything, loanID, setup.WorkspaceID, loan.ReturnInput{}).
+			Return(testLoan, nil, nil).Once()
 
 		rec := setup.Post(fmt.Sprintf("/loans/%s/return", loanID), "")
 
@@ -344,8 +381,8 @@ func TestLoanHandler_Return(t *testing.T) {
 	t.Run("returns 404 when loan not found", func(t *testing.T) {
 		loanID := uuid.New()
 
-		mockSvc.On("Return", mock.Anything, loanID, setup.WorkspaceID).
-			Return(nil, loan.ErrLoanNotFound).Once()
+		mockSvc.On("Return", mock.Anything, loanID, setup.WorkspaceID, loan.ReturnInput{}).
+			Return(nil, nil, loan.ErrLoanNotFound).Once()
 
 		rec := setup.Post(fmt.Sprintf("/loans/%s/return", loanID), "")
 
@@ -356,8 +393,8 @@ func TestLoanHandler_Return(t *testing.T) {
 	t.Run("returns 400 when loan already returned", func(t *testing.T) {
 		loanID := uuid.New()
 
-		mockSvc.On("Return", mock.Anything, loanID, setup.WorkspaceID).
-			Return(nil, loan.ErrAlreadyReturned).Once()
+		mockSvc.On("Return", mock.Anything, loanID, setup.WorkspaceID, loan.ReturnInput{}).
+			Return(nil, nil, loan.ErrAlreadyReturned).Once()
 
 		rec := setup.Post(fmt.Sprintf("/loans/%s/return", loanID), "")
 
@@ -570,8 +607,8 @@ func TestLoanHandler_Return_PublishesEvent(t *testing.T) {
 	testLoan, _ := loan.NewLoan(setup.WorkspaceID, inventoryID, borrowerID, 1, loanedAt, &dueDate, nil)
 	loanID := testLoan.ID()
 
-	mockSvc.On("Return", mock.Anything, loanID, setup.WorkspaceID).
-		Return(testLoan, nil).Once()
+	mockSvc.On("Return", mock.Anything, loanID, setup.WorkspaceID, loan.ReturnInput{}).
+		Return(testLoan, nil, nil).Once()
 
 	rec := setup.Post(fmt.Sprintf("/loans/%s/return", loanID), "")
 
@@ -588,6 +625,75 @@ func TestLoanHandler_Return_PublishesEvent(t *testing.T) {
 	assert.Equal(t, loanID.String(), event.EntityID)
 }
 
+func TestLoanHandler_Return_Condition(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	capture := testutil.NewEventCapture(setup.WorkspaceID, setup.UserID)
+	capture.Start()
+	defer capture.Stop()
+
+	loan.RegisterRoutes(setup.API, mockSvc, capture.GetBroadcaster(), nil)
+
+	inventoryID := uuid.New()
+	testLoan, _ := loan.NewLoan(setup.WorkspaceID, inventoryID, uuid.New(), 1, time.Now(), nil, nil)
+	loanID := testLoan.ID()
+
+	t.Run("logs the condition change to the inventory history", func(t *testing.T) {
+		condition := inventory.ConditionDamaged
+		note := "cracked handle"
+		change := &loan.ConditionChange{
+			InventoryID: inventoryID,
+			From:        inventory.ConditionGood,
+			To:          inventory.ConditionForRepair,
+			Note:        &note,
+		}
+		mockSvc.On("Return", mock.Anything, loanID, setup.WorkspaceID, loan.ReturnInput{
+			Condition:     &condition,
+			Note:          &note,
+			MarkForRepair: true,
+		}).Return(testLoan, change, nil).Once()
+
+		rec := setup.Post(fmt.Sprintf("/loans/%s/return", loanID),
+			`{"return_condition":"DAMAGED","return_note":"cracked handle","mark_for_repair":true}`)
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		mockSvc.AssertExpectations(t)
+		require.True(t, capture.WaitForEvents(2, 500*time.Millisecond), "loan and inventory events should be published")
+
+		all := capture.GetAllEvents()
+		returned, updated := all[len(all)-2], all[len(all)-1]
+		assert.Equal(t, "loan.returned", returned.Type)
+		assert.Equal(t, inventory.ConditionDamaged, returned.Data["return_condition"])
+		assert.Equal(t, "inventory.updated", updated.Type)
+		assert.Equal(t, "inventory", updated.EntityType)
+		assert.Equal(t, inventoryID.String(), updated.EntityID)
+		assert.Equal(t, inventory.ConditionForRepair, updated.Data["condition"])
+		assert.Equal(t, inventory.ConditionGood, updated.Data["previous_condition"])
+		assert.Equal(t, "cracked handle", updated.Data["note"])
+	})
+
+	t.Run("unchanged condition publishes only the return", func(t *testing.T) {
+		condition := inventory.ConditionGood
+		mockSvc.On("Return", mock.Anything, loanID, setup.WorkspaceID, loan.ReturnInput{Condition: &condition}).
+			Return(testLoan, nil, nil).Once()
+		before := capture.GetEventCount()
+
+		rec := setup.Post(fmt.Sprintf("/loans/%s/return", loanID), `{"return_condition":"GOOD"}`)
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		mockSvc.AssertExpectations(t)
+		require.True(t, capture.WaitForEvents(before+1, 500*time.Millisecond))
+		assert.False(t, capture.WaitForEvents(before+2, 100*time.Millisecond), "no inventory event expected")
+		assert.Equal(t, "loan.returned", capture.GetLastEvent().Type)
+	})
+
+	t.Run("rejects an unknown condition", func(t *testing.T) {
+		rec := setup.Post(fmt.Sprintf("/loans/%s/return", loanID), `{"return_condition":"BROKEN"}`)
+
+		testutil.AssertStatus(t, rec, http.StatusUnprocessableEntity)
+	})
+}
+
 func TestLoanHandler_Create_NilBroadcaster_NoError(t *testing.T) {
 	setup := testutil.NewHandlerTestSetup()
 	mockSvc := new(MockService)
@@ -837,3 +943,114 @@ func TestHandler_GetLoanByID_IncludesEmbeds(t *testing.T) {
 	assert.Contains(t, body, `"name":"Bob"`)
 	mockSvc.AssertExpectations(t)
 }
+
+func TestLoanHandler_BorrowerLoanSummary(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	loan.RegisterBorrowerSummaryRoutes(setup.API, mockSvc)
+
+	borrowerID := uuid.New()
+	dueDate := time.Now().Add(-24 * time.Hour)
+	active, _ := loan.NewLoan(setup.WorkspaceID, uuid.New(), borrowerID, 1, time.Now().Add(-72*time.Hour), &dueDate, nil)
+	summary := &loan.BorrowerLoanSummary{
+		BorrowerID:   borrowerID,
+		Active:       []loan.BorrowerLoanEntry{{Loan: active, ItemName: "Ladder", Overdue: true}},
+		History:      []loan.BorrowerLoanEntry{},
+		OverdueCount: 1,
+	}
+
+	t.Run("returns summary", func(t *testing.T) {
+		mockSvc.On("BorrowerLoanSummary", mock.Anything, setup.WorkspaceID, borrowerID).Return(summary, nil).Once()
+
+		rec := setup.Get(fmt.Sprintf("/borrowers/%s/loan-summary", borrowerID))
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		resp := testutil.ParseJSONResponse[loan.BorrowerLoanSummaryResponse](t, rec)
+		assert.Equal(t, 1, resp.OverdueCount)
+		if assert.Len(t, resp.Active, 1) {
+			assert.Equal(t, "Ladder", resp.Active[0].ItemName)
+			assert.True(t, resp.Active[0].IsOverdue)
+		}
+		assert.Empty(t, resp.History)
+	})
+
+	t.Run("exports CSV", func(t *testing.T) {
+		mockSvc.On("BorrowerLoanSummary", mock.Anything, setup.WorkspaceID, borrowerID).Return(summary, nil).Once()
+
+		rec := setup.Get(fmt.Sprintf("/borrowers/%s/loan-summary.csv", borrowerID))
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		assert.Equal(t, "text/csv", rec.Header().Get("Content-Type"))
+		assert.Contains(t, rec.Header().Get("Content-Disposition"), "borrower-loans-")
+		assert.Contains(t, rec.Body.String(), "active,Ladder,1,")
+	})
+
+	t.Run("returns 500 on service error", func(t *testing.T) {
+		mockSvc.On("BorrowerLoanSummary", mock.Anything, setup.WorkspaceID, borrowerID).Return(nil, fmt.Errorf("db down")).Once()
+
+		rec := setup.Get(fmt.Sprintf("/borrowers/%s/loan-summary", borrowerID))
+
+		testutil.AssertStatus(t, rec, http.StatusInternalServerError)
+	})
+}
+
+func TestLoanHandler_Settings(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	loan.RegisterSettingsRoutes(setup.API, mockSvc)
+
+	t.Run("returns settings", func(t *testing.T) {
+		mockSvc.On("GetSettings", mock.Anything, setup.WorkspaceID).Return(&loan.Settings{OverdueGraceDays: 3}, nil).Once()
+
+		rec := setup.Get("/loan-settings")
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		resp := testutil.ParseJSONResponse[loan.LoanSettingsResponse](t, rec)
+		assert.Equal(t, 3, resp.OverdueGraceDays)
+	})
+
+	t.Run("updates settings", func(t *testing.T) {
+		mockSvc.On("UpdateSettings", mock.Anything, setup.WorkspaceID, loan.Settings{OverdueGraceDays: 5, DefaultLoanDays: 30}).
+			Return(&loan.Settings{OverdueGraceDays: 5, DefaultLoanDays: 30}, nil).Once()
+
+		rec := setup.Put("/loan-settings", `{"overdue_grace_days":5,"default_loan_days":30}`)
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		resp := testutil.ParseJSONResponse[loan.LoanSettingsResponse](t, rec)
+		assert.Equal(t, 5, resp.OverdueGraceDays)
+		assert.Equal(t, 30, resp.DefaultLoanDays)
+	})
+
+	t.Run("omitted loan period resets to the default", func(t *testing.T) {
+		mockSvc.On("UpdateSettings", mock.Anything, setup.WorkspaceID, loan.Settings{OverdueGraceDays: 2, DefaultLoanDays: loan.DefaultLoanDays}).
+			Return(&loan.Settings{OverdueGraceDays: 2, DefaultLoanDays: loan.DefaultLoanDays}, nil).Once()
+
+		rec := setup.Put("/loan-settings", `{"overdue_grace_days":2}`)
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("rejects a zero loan period", func(t *testing.T) {
+		rec := setup.Put("/loan-settings", `{"overdue_grace_days":2,"default_loan_days":0}`)
+
+		testutil.AssertStatus(t, rec, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("rejects out of range grace", func(t *testing.T) {
+		rec := setup.Put("/loan-settings", `{"overdue_grace_days":400}`)
+
+		testutil.AssertStatus(t, rec, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("requires owner or admin to update", func(t *testing.T) {
+		setup.SetRole("member")
+		defer setup.SetRole("owner")
+
+		rec := setup.Put("/loan-settings", `{"overdue_grace_days":5}`)
+
+		testutil.AssertStatus(t, rec, http.StatusForbidden)
+	})
+
+	mockSvc.AssertExpectations(t)
+}
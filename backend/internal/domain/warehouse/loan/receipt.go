@@ -0,0 +1,164 @@
+package loan
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/google/uuid"
+
+	appMiddleware "github.com/antti/home-warehouse/go-backend/internal/api/middleware"
+	"github.com/antti/home-warehouse/go-backend/internal/infra/pdf"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// receiptDateFormat is how dates are printed on loan receipts.
+const receiptDateFormat = "2006-01-02"
+
+// ReceiptDetails is what a printed loan receipt shows besides the loan
+// itself: the workspace header, the lent item and the borrower.
+type ReceiptDetails struct {
+	WorkspaceName    string
+	ItemName         string
+	ItemSKU          string
+	ItemBrand        *string
+	ItemModel        *string
+	ItemSerialNumber *string
+	Condition        *string
+	BorrowerName     string
+	BorrowerEmail    *string
+	BorrowerPhone    *string
+}
+
+// ReceiptLookup loads the receipt details of a loan in one read. It must
+// scope the read by workspace_id and return shared.ErrNotFound when the loan
+// does not exist there.
+type ReceiptLookup interface {
+	LoanReceiptDetails(ctx context.Context, workspaceID, loanID uuid.UUID) (*ReceiptDetails, error)
+}
+
+// Receipt layout, in points.
+const (
+	receiptMargin     = 56.0
+	receiptLabelWidth = 110.0
+	receiptLineHeight = 18.0
+)
+
+// RenderReceipt renders a one-page printable receipt for l with a signature
+// line for the borrower. A returned loan is stamped RETURNED with its return
+// date.
+func RenderReceipt(l *Loan, d *ReceiptDetails) []byte {
+	doc := pdf.New()
+	page := doc.AddPage()
+	right := pdf.PageWidth - receiptMargin
+
+	y := 72.0
+	page.Text(receiptMargin, y, pdf.Bold, 20, d.WorkspaceName)
+	y += 24
+	page.Text(receiptMargin, y, pdf.Regular, 13, "Loan receipt")
+	y += 12
+	page.Line(receiptMargin, y, right, y, 1)
+	y += 30
+
+	field := func(label, value string) {
+		page.Text(receiptMargin, y, pdf.Bold, 10, label)
+		page.Text(receiptMargin+receiptLabelWidth, y, pdf.Regular, 10, value)
+		y += receiptLineHeight
+	}
+	optional := func(label string, value *string) {
+		if value != nil && *value != "" {
+			field(label, *value)
+		}
+	}
+	section := func(title string) {
+		page.Text(receiptMargin, y, pdf.Bold, 12, title)
+		y += receiptLineHeight + 4
+	}
+
+	section("Item")
+	field("Name", d.ItemName)
+	field("SKU", d.ItemSKU)
+	optional("Brand", d.ItemBrand)
+	optional("Model", d.ItemModel)
+	optional("Serial number", d.ItemSerialNumber)
+	optional("Condition", d.Condition)
+	field("Quantity", strconv.Itoa(l.Quantity()))
+	y += 12
+
+	section("Borrower")
+	field("Name", d.BorrowerName)
+	optional("Email", d.BorrowerEmail)
+	optional("Phone", d.BorrowerPhone)
+	y += 12
+
+	section("Loan")
+	field("Loaned on", l.LoanedAt().Format(receiptDateFormat))
+	if l.DueDate() != nil {
+		field("Due date", l.DueDate().Format(receiptDateFormat))
+	} else {
+		field("Due date", "No due date")
+	}
+	optional("Notes", l.Notes())
+	y += 60
+
+	page.Line(receiptMargin, y, receiptMargin+240, y, 0.75)
+	page.Line(receiptMargin+290, y, right, y, 0.75)
+	y += 14
+	page.Text(receiptMargin, y, pdf.Regular, 9, "Borrower signature")
+	page.Text(receiptMargin+290, y, pdf.Regular, 9, "Date")
+
+	page.Text(receiptMargin, pdf.PageHeight-40, pdf.Regular, 8, "Loan ID "+l.ID().String())
+
+	if l.ReturnedAt() != nil {
+		page.SetColor(0.8, 0.1, 0.1)
+		page.Rect(right-190, 52, 190, 62, 2)
+		page.Text(right-174, 86, pdf.Bold, 28, "RETURNED")
+		page.Text(right-174, 104, pdf.Regular, 10, fmt.Sprintf("on %s", l.ReturnedAt().Format(receiptDateFormat)))
+	}
+
+	return doc.Bytes()
+}
+
+// RegisterReceiptRoutes registers the printable loan receipt. It is separate
+// from RegisterRoutes because it needs its own lookup.
+func RegisterReceiptRoutes(api huma.API, svc ServiceInterface, receipts ReceiptLookup) {
+	huma.Get(api, "/loans/{id}/receipt.pdf", getLoanReceipt(svc, receipts))
+}
+
+// getLoanReceipt renders a loan's PDF receipt for the borrower to sign.
+func getLoanReceipt(svc ServiceInterface, receipts ReceiptLookup) func(context.Context, *GetLoanInput) (*LoanReceiptOutput, error) {
+	return func(ctx context.Context, input *GetLoanInput) (*LoanReceiptOutput, error) {
+		workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+		if !ok {
+			return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+		}
+
+		loan, err := svc.GetByID(ctx, input.ID, workspaceID)
+		if err != nil || loan == nil {
+			return nil, huma.Error404NotFound(msgLoanNotFound)
+		}
+
+		details, err := receipts.LoanReceiptDetails(ctx, workspaceID, loan.ID())
+		if err != nil {
+			if errors.Is(err, shared.ErrNotFound) {
+				return nil, huma.Error404NotFound(msgLoanNotFound)
+			}
+			return nil, huma.Error500InternalServerError("failed to load receipt details")
+		}
+
+		return &LoanReceiptOutput{
+			ContentType:        "application/pdf",
+			ContentDisposition: fmt.Sprintf("inline; filename=\"loan-receipt-%s.pdf\"", loan.ID().String()[:8]),
+			Body:               RenderReceipt(loan, details),
+		}, nil
+	}
+}
+
+// LoanReceiptOutput is the PDF response of the receipt endpoint.
+type LoanReceiptOutput struct {
+	ContentType        string `header:"Content-Type"`
+	ContentDisposition string `header:"Content-Disposition"`
+	Body               []byte
+}
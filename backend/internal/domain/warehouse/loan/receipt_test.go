@@ -0,0 +1,126 @@
+package loan_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/loan"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+	"github.com/antti/home-warehouse/go-backend/internal/testutil"
+)
+
+type MockReceiptLookup struct {
+	mock.Mock
+}
+
+func (m *MockReceiptLookup) LoanReceiptDetails(ctx context.Context, workspaceID, loanID uuid.UUID) (*loan.ReceiptDetails, error) {
+	args := m.Called(ctx, workspaceID, loanID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*loan.ReceiptDetails), args.Error(1)
+}
+
+func receiptDetails() *loan.ReceiptDetails {
+	brand := "Makita"
+	email := "alice@example.com"
+	return &loan.ReceiptDetails{
+		WorkspaceName: "Tool Library",
+		ItemName:      "Cordless Drill",
+		ItemSKU:       "DRL-001",
+		ItemBrand:     &brand,
+		BorrowerName:  "Alice (Unit 4)",
+		BorrowerEmail: &email,
+	}
+}
+
+func TestRenderReceipt(t *testing.T) {
+	loanedAt := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	dueDate := time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)
+
+	t.Run("active loan", func(t *testing.T) {
+		l := loan.Reconstruct(uuid.New(), uuid.New(), uuid.New(), uuid.New(), 2, loanedAt, &dueDate, nil, nil, loanedAt, loanedAt)
+
+		out := string(loan.RenderReceipt(l, receiptDetails()))
+
+		assert.Contains(t, out, "%PDF-")
+		assert.Contains(t, out, "(Tool Library)")
+		assert.Contains(t, out, "(Cordless Drill)")
+		assert.Contains(t, out, "(DRL-001)")
+		assert.Contains(t, out, "(Makita)")
+		assert.Contains(t, out, `(Alice \(Unit 4\))`)
+		assert.Contains(t, out, "(2026-03-01)")
+		assert.Contains(t, out, "(2026-03-15)")
+		assert.Contains(t, out, "(Borrower signature)")
+		assert.NotContains(t, out, "RETURNED")
+	})
+
+	t.Run("returned loan is stamped", func(t *testing.T) {
+		returnedAt := time.Date(2026, 3, 10, 9, 0, 0, 0, time.UTC)
+		l := loan.Reconstruct(uuid.New(), uuid.New(), uuid.New(), uuid.New(), 1, loanedAt, nil, &returnedAt, nil, loanedAt, returnedAt)
+
+		out := string(loan.RenderReceipt(l, receiptDetails()))
+
+		assert.Contains(t, out, "(RETURNED)")
+		assert.Contains(t, out, "(on 2026-03-10)")
+		assert.Contains(t, out, "(No due date)")
+	})
+}
+
+func TestLoanHandler_Receipt(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	mockLookup := new(MockReceiptLookup)
+	loan.RegisterReceiptRoutes(setup.API, mockSvc, mockLookup)
+
+	t.Run("renders PDF", func(t *testing.T) {
+		l, _ := loan.NewLoan(setup.WorkspaceID, uuid.New(), uuid.New(), 1, time.Now(), nil, nil)
+		mockSvc.On("GetByID", mock.Anything, l.ID(), setup.WorkspaceID).Return(l, nil).Once()
+		mockLookup.On("LoanReceiptDetails", mock.Anything, setup.WorkspaceID, l.ID()).Return(receiptDetails(), nil).Once()
+
+		rec := setup.Get(fmt.Sprintf("/loans/%s/receipt.pdf", l.ID()))
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		assert.Equal(t, "application/pdf", rec.Header().Get("Content-Type"))
+		assert.Contains(t, rec.Header().Get("Content-Disposition"), "loan-receipt-")
+		assert.Contains(t, rec.Body.String(), "%PDF-")
+		mockSvc.AssertExpectations(t)
+		mockLookup.AssertExpectations(t)
+	})
+
+	t.Run("returns 404 for unknown loan", func(t *testing.T) {
+		id := uuid.New()
+		mockSvc.On("GetByID", mock.Anything, id, setup.WorkspaceID).Return(nil, loan.ErrLoanNotFound).Once()
+
+		rec := setup.Get(fmt.Sprintf("/loans/%s/receipt.pdf", id))
+
+		testutil.AssertStatus(t, rec, http.StatusNotFound)
+	})
+
+	t.Run("returns 404 when receipt details are missing", func(t *testing.T) {
+		l, _ := loan.NewLoan(setup.WorkspaceID, uuid.New(), uuid.New(), 1, time.Now(), nil, nil)
+		mockSvc.On("GetByID", mock.Anything, l.ID(), setup.WorkspaceID).Return(l, nil).Once()
+		mockLookup.On("LoanReceiptDetails", mock.Anything, setup.WorkspaceID, l.ID()).Return(nil, shared.ErrNotFound).Once()
+
+		rec := setup.Get(fmt.Sprintf("/loans/%s/receipt.pdf", l.ID()))
+
+		testutil.AssertStatus(t, rec, http.StatusNotFound)
+	})
+
+	t.Run("returns 500 on lookup error", func(t *testing.T) {
+		l, _ := loan.NewLoan(setup.WorkspaceID, uuid.New(), uuid.New(), 1, time.Now(), nil, nil)
+		mockSvc.On("GetByID", mock.Anything, l.ID(), setup.WorkspaceID).Return(l, nil).Once()
+		mockLookup.On("LoanReceiptDetails", mock.Anything, setup.WorkspaceID, l.ID()).Return(nil, fmt.Errorf("db down")).Once()
+
+		rec := setup.Get(fmt.Sprintf("/loans/%s/receipt.pdf", l.ID()))
+
+		testutil.AssertStatus(t, rec, http.StatusInternalServerError)
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/google/uuid"
 
 	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/inventory"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/item"
 	"github.com/antti/home-warehouse/go-backend/internal/shared"
 )
 
@@ -15,7 +16,9 @@ import (
 type ServiceInterface interface {
 	Create(ctx context.Context, input CreateInput) (*Loan, error)
 	GetByID(ctx context.Context, id, workspaceID uuid.UUID) (*Loan, error)
-	Return(ctx context.Context, id, workspaceID uuid.UUID) (*Loan, error)
+	// Return checks a loan back in. The ConditionChange is nil unless
+	// input recorded a new condition for the loaned entry.
+	Return(ctx context.Context, id, workspaceID uuid.UUID, input ReturnInput) (*Loan, *ConditionChange, error)
 	ExtendDueDate(ctx context.Context, id, workspaceID uuid.UUID, newDueDate time.Time) (*Loan, error)
 	// Update applies a partial update (due_date and/or notes) to a non-returned
 	// loan. Nil pointers mean "unchanged"; non-nil pointers overwrite. Returns
@@ -27,6 +30,9 @@ type ServiceInterface interface {
 	ListByItem(ctx context.Context, workspaceID, itemID uuid.UUID) ([]*Loan, error)
 	GetActiveLoans(ctx context.Context, workspaceID uuid.UUID) ([]*Loan, error)
 	GetOverdueLoans(ctx context.Context, workspaceID uuid.UUID) ([]*Loan, error)
+	BorrowerLoanSummary(ctx context.Context, workspaceID, borrowerID uuid.UUID) (*BorrowerLoanSummary, error)
+	GetSettings(ctx context.Context, workspaceID uuid.UUID) (*Settings, error)
+	UpdateSettings(ctx context.Context, workspaceID uuid.UUID, settings Settings) (*Settings, error)
 }
 
 // Transactor runs a function inside a single database transaction. It is a
@@ -48,10 +54,26 @@ func (noopTransactor) WithTx(ctx context.Context, fn func(context.Context) error
 	return fn(ctx)
 }
 
+// AvailabilityPolicy reports the workspace inventory policy that decides
+// which statuses can be loaned out. Implemented by inventory.Service.
+type AvailabilityPolicy interface {
+	GetSettings(ctx context.Context, workspaceID uuid.UUID) (*inventory.Settings, error)
+}
+
+// ItemLookup finds the item behind an inventory entry, to check it can be
+// lent out. Implemented by the postgres item repository.
+type ItemLookup interface {
+	FindByID(ctx context.Context, id, workspaceID uuid.UUID) (*item.Item, error)
+}
+
 type Service struct {
 	repo          Repository
 	inventoryRepo inventory.Repository
 	tx            Transactor
+	itemNames     ItemNameLookup
+	settings      SettingsRepository
+	availability  AvailabilityPolicy
+	items         ItemLookup
 }
 
 // NewService creates a loan service. tx may be nil (falls back to a
@@ -67,6 +89,18 @@ func NewService(repo Repository, inventoryRepo inventory.Repository, tx Transact
 	}
 }
 
+// SetAvailabilityPolicy wires the workspace inventory policy. Without it
+// only AVAILABLE inventory can be loaned out, the policy default.
+func (s *Service) SetAvailabilityPolicy(policy AvailabilityPolicy) {
+	s.availability = policy
+}
+
+// SetItemLookup wires the item lookup Create uses to refuse fixed assets
+// (items that are not loanable). Without it every item can be loaned.
+func (s *Service) SetItemLookup(lookup ItemLookup) {
+	s.items = lookup
+}
+
 type CreateInput struct {
 	WorkspaceID uuid.UUID
 	InventoryID uuid.UUID
@@ -85,8 +119,28 @@ func (s *Service) Create(ctx context.Context, input CreateInput) (*Loan, error)
 		return nil, err
 	}
 
-	// Check if inventory is available
-	if inv.Status() != inventory.StatusAvailable {
+	// Fixed assets can be AVAILABLE (present) but are never lent out.
+	if s.items != nil {
+		it, err := s.items.FindByID(ctx, inv.ItemID(), input.WorkspaceID)
+		if err != nil {
+			return nil, err
+		}
+		if !it.IsLoanable() {
+			return nil, ErrItemNotLoanable
+		}
+	}
+
+	// Check if inventory is available; RESERVED counts only when the
+	// workspace policy says so.
+	policy := inventory.Settings{}
+	if s.availability != nil {
+		settings, err := s.availability.GetSettings(ctx, input.WorkspaceID)
+		if err != nil {
+			return nil, err
+		}
+		policy = *settings
+	}
+	if !policy.IsAvailable(inv.Status()) {
 		return nil, ErrInventoryNotAvailable
 	}
 
@@ -104,6 +158,17 @@ func (s *Service) Create(ctx context.Context, input CreateInput) (*Loan, error)
 		return nil, ErrInventoryOnLoan
 	}
 
+	// Loans created without a due date get the workspace loan period.
+	dueDate := input.DueDate
+	if dueDate == nil {
+		settings, err := s.GetSettings(ctx, input.WorkspaceID)
+		if err != nil {
+			return nil, err
+		}
+		due := input.LoanedAt.AddDate(0, 0, settings.DefaultLoanDays)
+		dueDate = &due
+	}
+
 	// Create the loan
 	loan, err := NewLoan(
 		input.WorkspaceID,
@@ -111,7 +176,7 @@ func (s *Service) Create(ctx context.Context, input CreateInput) (*Loan, error)
 		input.BorrowerID,
 		input.Quantity,
 		input.LoanedAt,
-		input.DueDate,
+		dueDate,
 		input.Notes,
 	)
 	if err != nil {
@@ -147,34 +212,78 @@ func (s *Service) GetByID(ctx context.Context, id, workspaceID uuid.UUID) (*Loan
 	return loan, nil
 }
 
-func (s *Service) Return(ctx context.Context, id, workspaceID uuid.UUID) (*Loan, error) {
+// ReturnInput is what staff record when a loan comes back. All fields are
+// optional; the zero value is a plain return.
+type ReturnInput struct {
+	// Condition is the condition the entry came back in. It replaces the
+	// entry's condition as part of the return.
+	Condition *inventory.Condition
+	// Note explains the condition. Like bulk status notes it is not stored
+	// on the entry; it travels with the activity log of the change.
+	Note *string
+	// MarkForRepair sends an entry returned DAMAGED straight to FOR_REPAIR,
+	// putting it in the repair queue. Other conditions are unaffected.
+	MarkForRepair bool
+}
+
+// ConditionChange is a condition recorded on the loaned entry at return.
+type ConditionChange struct {
+	InventoryID uuid.UUID
+	From        inventory.Condition
+	To          inventory.Condition
+	Note        *string
+}
+
+func (s *Service) Return(ctx context.Context, id, workspaceID uuid.UUID, input ReturnInput) (*Loan, *ConditionChange, error) {
+	if input.Condition != nil && !input.Condition.IsValid() {
+		return nil, nil, shared.NewFieldError(shared.ErrInvalidInput, "return_condition", inventory.ErrInvalidCondition.Error())
+	}
+
 	loan, err := s.GetByID(ctx, id, workspaceID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	if err := loan.Return(); err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	// Update inventory status back to AVAILABLE.
 	inv, err := s.inventoryRepo.FindByID(ctx, loan.InventoryID(), workspaceID)
 	if err != nil {
 		if !errors.Is(err, shared.ErrNotFound) {
-			return nil, err
+			return nil, nil, err
 		}
 		// Inventory was deleted; still persist the return — the loan record
 		// is authoritative. Skip the inventory status update.
 		inv = nil
+	} else if !inv.Status().CanTransitionTo(inventory.StatusAvailable) {
+		// Disposed while on loan: the return does not bring it back.
+		inv = nil
 	} else {
 		if err := inv.UpdateStatus(inventory.StatusAvailable); err != nil {
-			return nil, err
+			return nil, nil, err
+		}
+	}
+
+	var change *ConditionChange
+	if inv != nil && input.Condition != nil {
+		condition := *input.Condition
+		if condition == inventory.ConditionDamaged && input.MarkForRepair {
+			condition = inventory.ConditionForRepair
+		}
+		if condition != inv.Condition() {
+			change = &ConditionChange{InventoryID: inv.ID(), From: inv.Condition(), To: condition, Note: input.Note}
+			if err := inv.UpdateCondition(condition); err != nil {
+				return nil, nil, err
+			}
 		}
 	}
 
 	// WR-01: the AVAILABLE flip and the returned-loan save are atomic — a
 	// failed loan save rolls back the inventory status instead of leaving the
-	// loan ON_LOAN with inventory already AVAILABLE (or vice versa).
+	// loan ON_LOAN with inventory already AVAILABLE (or vice versa). The
+	// return condition is saved with the status.
 	err = s.tx.WithTx(ctx, func(ctx context.Context) error {
 		if inv != nil {
 			if err := s.inventoryRepo.Save(ctx, inv); err != nil {
@@ -184,10 +293,10 @@ func (s *Service) Return(ctx context.Context, id, workspaceID uuid.UUID) (*Loan,
 		return s.repo.Save(ctx, loan)
 	})
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	return loan, nil
+	return loan, change, nil
 }
 
 func (s *Service) ExtendDueDate(ctx context.Context, id, workspaceID uuid.UUID, newDueDate time.Time) (*Loan, error) {
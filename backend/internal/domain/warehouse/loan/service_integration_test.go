@@ -97,7 +97,7 @@ func TestLoanCreate_FailedSaveRollsBackInventoryStatus(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, inventory.StatusOnLoan, reloaded.Status())
 
-	_, err = realSvc.Return(ctx, created.ID(), workspaceID)
+	_, _, err = realSvc.Return(ctx, created.ID(), workspaceID, loan.ReturnInput{})
 	require.NoError(t, err)
 
 	reloaded, err = inventoryRepo.FindByID(ctx, inv.ID(), workspaceID)
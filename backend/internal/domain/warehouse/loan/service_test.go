@@ -9,8 +9,10 @@ import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 
 	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/inventory"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/item"
 	"github.com/antti/home-warehouse/go-backend/internal/shared"
 )
 
@@ -157,8 +159,8 @@ func (m *MockInventoryRepository) FindExpiring(ctx context.Context, workspaceID
 	return args.Get(0).([]inventory.ExpiringInventory), args.Error(1)
 }
 
-func (m *MockInventoryRepository) FindAvailable(ctx context.Context, workspaceID, itemID uuid.UUID) ([]*inventory.Inventory, error) {
-	args := m.Called(ctx, workspaceID, itemID)
+func (m *MockInventoryRepository) FindAvailable(ctx context.Context, workspaceID, itemID uuid.UUID, includeReserved bool) ([]*inventory.Inventory, error) {
+	args := m.Called(ctx, workspaceID, itemID, includeReserved)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -170,19 +172,35 @@ func (m *MockInventoryRepository) GetTotalQuantity(ctx context.Context, workspac
 	return args.Int(0), args.Error(1)
 }
 
+func (m *MockInventoryRepository) AddQuantity(ctx context.Context, id, workspaceID uuid.UUID, amount int) (*inventory.Inventory, error) {
+	args := m.Called(ctx, id, workspaceID, amount)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*inventory.Inventory), args.Error(1)
+}
+
 func (m *MockInventoryRepository) Delete(ctx context.Context, id, workspaceID uuid.UUID) error {
 	args := m.Called(ctx, id)
 	return args.Error(0)
 }
 
-func (m *MockInventoryRepository) List(ctx context.Context, workspaceID uuid.UUID, pagination shared.Pagination) ([]*inventory.Inventory, int, error) {
-	args := m.Called(ctx, workspaceID, pagination)
+func (m *MockInventoryRepository) List(ctx context.Context, workspaceID uuid.UUID, pagination shared.Pagination, filters inventory.ListFilters) ([]*inventory.Inventory, int, error) {
+	args := m.Called(ctx, workspaceID, pagination, filters)
 	if args.Get(0) == nil {
 		return nil, args.Int(1), args.Error(2)
 	}
 	return args.Get(0).([]*inventory.Inventory), args.Int(1), args.Error(2)
 }
 
+func (m *MockInventoryRepository) ListWithItemDetails(ctx context.Context, workspaceID uuid.UUID, pagination shared.Pagination, filters inventory.ListFilters) ([]*inventory.InventoryWithDetails, int, error) {
+	args := m.Called(ctx, workspaceID, pagination, filters)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]*inventory.InventoryWithDetails), args.Int(1), args.Error(2)
+}
+
 // Helper functions
 func ptrString(s string) *string {
 	return &s
@@ -731,6 +749,119 @@ func TestService_Create(t *testing.T) {
 	}
 }
 
+// availabilityPolicy is a fixed AvailabilityPolicy.
+type availabilityPolicy inventory.Settings
+
+func (p availabilityPolicy) GetSettings(ctx context.Context, workspaceID uuid.UUID) (*inventory.Settings, error) {
+	settings := inventory.Settings(p)
+	return &settings, nil
+}
+
+func TestService_Create_ReservedPolicy(t *testing.T) {
+	ctx := context.Background()
+	workspaceID, inventoryID := uuid.New(), uuid.New()
+
+	newService := func(policy AvailabilityPolicy) (*Service, *inventory.Inventory) {
+		loanRepo, invRepo := new(MockRepository), new(MockInventoryRepository)
+		inv := createTestInventory(inventoryID, workspaceID, uuid.New(), uuid.New(), 1, inventory.StatusReserved)
+		invRepo.On("FindByID", ctx, inventoryID, workspaceID).Return(inv, nil)
+		invRepo.On("Save", ctx, mock.Anything).Return(nil)
+		loanRepo.On("FindActiveLoanForInventory", ctx, inventoryID).Return(nil, nil)
+		loanRepo.On("Save", ctx, mock.Anything).Return(nil)
+		svc := NewService(loanRepo, invRepo, nil)
+		if policy != nil {
+			svc.SetAvailabilityPolicy(policy)
+		}
+		return svc, inv
+	}
+	input := CreateInput{
+		WorkspaceID: workspaceID,
+		InventoryID: inventoryID,
+		BorrowerID:  uuid.New(),
+		Quantity:    1,
+		LoanedAt:    time.Now(),
+	}
+
+	t.Run("reserved is unavailable without a policy", func(t *testing.T) {
+		svc, _ := newService(nil)
+
+		_, err := svc.Create(ctx, input)
+		assert.ErrorIs(t, err, ErrInventoryNotAvailable)
+	})
+
+	t.Run("reserved is unavailable by default", func(t *testing.T) {
+		svc, _ := newService(availabilityPolicy{})
+
+		_, err := svc.Create(ctx, input)
+		assert.ErrorIs(t, err, ErrInventoryNotAvailable)
+	})
+
+	t.Run("reserved can be loaned when the policy allows", func(t *testing.T) {
+		svc, inv := newService(availabilityPolicy{ReservedIsAvailable: true})
+
+		_, err := svc.Create(ctx, input)
+		require.NoError(t, err)
+		assert.Equal(t, inventory.StatusOnLoan, inv.Status())
+	})
+}
+
+// itemLookup is an ItemLookup over a fixed set of items.
+type itemLookup map[uuid.UUID]*item.Item
+
+func (l itemLookup) FindByID(ctx context.Context, id, workspaceID uuid.UUID) (*item.Item, error) {
+	if it, ok := l[id]; ok {
+		return it, nil
+	}
+	return nil, shared.ErrNotFound
+}
+
+func TestService_Create_NotLoanable(t *testing.T) {
+	ctx := context.Background()
+	workspaceID, inventoryID, itemID := uuid.New(), uuid.New(), uuid.New()
+	now := time.Now()
+
+	newService := func(loanable bool) (*Service, *MockRepository, *inventory.Inventory) {
+		loanRepo, invRepo := new(MockRepository), new(MockInventoryRepository)
+		inv := createTestInventory(inventoryID, workspaceID, itemID, uuid.New(), 1, inventory.StatusAvailable)
+		invRepo.On("FindByID", ctx, inventoryID, workspaceID).Return(inv, nil)
+		invRepo.On("Save", ctx, mock.Anything).Return(nil).Maybe()
+		loanRepo.On("FindActiveLoanForInventory", ctx, inventoryID).Return(nil, nil).Maybe()
+		loanRepo.On("Save", ctx, mock.Anything).Return(nil).Maybe()
+
+		svc := NewService(loanRepo, invRepo, nil)
+		svc.SetItemLookup(itemLookup{
+			itemID: item.Reconstruct(itemID, workspaceID, "SHELF-1", "Wall shelf", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 0, "SHELF1", nil, nil, nil, loanable, now, now),
+		})
+		return svc, loanRepo, inv
+	}
+	input := CreateInput{
+		WorkspaceID: workspaceID,
+		InventoryID: inventoryID,
+		BorrowerID:  uuid.New(),
+		Quantity:    1,
+		LoanedAt:    now,
+	}
+
+	t.Run("rejects an item that is not loanable", func(t *testing.T) {
+		svc, loanRepo, inv := newService(false)
+
+		_, err := svc.Create(ctx, input)
+
+		assert.ErrorIs(t, err, ErrItemNotLoanable)
+		assert.Equal(t, inventory.StatusAvailable, inv.Status(), "a fixed asset stays available")
+		loanRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("loans a loanable item", func(t *testing.T) {
+		svc, _, inv := newService(true)
+
+		_, err := svc.Create(ctx, input)
+
+		require.NoError(t, err)
+		assert.Equal(t, inventory.StatusOnLoan, inv.Status())
+	})
+}
+
 func TestService_GetByID(t *testing.T) {
 	ctx := context.Background()
 	loanID := uuid.New()
@@ -878,7 +1009,7 @@ func TestService_Return(t *testing.T) {
 
 			tt.setupMock(mockLoanRepo, mockInvRepo)
 
-			loan, err := svc.Return(ctx, loanID, workspaceID)
+			loan, _, err := svc.Return(ctx, loanID, workspaceID, ReturnInput{})
 
 			if tt.expectError {
 				assert.Error(t, err)
@@ -1362,7 +1493,7 @@ func TestService_Return_SaveErrors(t *testing.T) {
 		mockInvRepo.On("FindByID", ctx, inventoryID, workspaceID).Return(inv, nil)
 		mockInvRepo.On("Save", ctx, mock.Anything).Return(repoErr)
 
-		result, err := svc.Return(ctx, loanID, workspaceID)
+		result, _, err := svc.Return(ctx, loanID, workspaceID, ReturnInput{})
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -1382,7 +1513,7 @@ func TestService_Return_SaveErrors(t *testing.T) {
 		mockInvRepo.On("Save", ctx, mock.Anything).Return(nil)
 		mockLoanRepo.On("Save", ctx, mock.AnythingOfType("*loan.Loan")).Return(repoErr)
 
-		result, err := svc.Return(ctx, loanID, workspaceID)
+		result, _, err := svc.Return(ctx, loanID, workspaceID, ReturnInput{})
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -1437,7 +1568,7 @@ func TestService_Return_InventoryDeleted(t *testing.T) {
 	mockInvRepo.On("FindByID", ctx, inventoryID, workspaceID).Return(nil, shared.ErrNotFound)
 	mockLoanRepo.On("Save", ctx, mock.AnythingOfType("*loan.Loan")).Return(nil)
 
-	result, err := svc.Return(ctx, loanID, workspaceID)
+	result, _, err := svc.Return(ctx, loanID, workspaceID, ReturnInput{})
 
 	// Return succeeds despite the missing inventory; the loan is marked returned.
 	assert.NoError(t, err)
@@ -1447,6 +1578,37 @@ func TestService_Return_InventoryDeleted(t *testing.T) {
 	mockLoanRepo.AssertExpectations(t)
 }
 
+func TestService_Return_InventoryDisposed(t *testing.T) {
+	ctx := context.Background()
+	loanID := uuid.New()
+	workspaceID := uuid.New()
+	inventoryID := uuid.New()
+	borrowerID := uuid.New()
+	now := time.Now()
+
+	mockLoanRepo := new(MockRepository)
+	mockInvRepo := new(MockInventoryRepository)
+	svc := NewService(mockLoanRepo, mockInvRepo, nil)
+
+	loan := Reconstruct(loanID, workspaceID, inventoryID, borrowerID, 1, now, nil, nil, nil, now, now)
+	inv, err := inventory.NewInventory(workspaceID, uuid.New(), uuid.New(), nil, 1,
+		inventory.ConditionGood, inventory.StatusDisposed, nil)
+	require.NoError(t, err)
+
+	mockLoanRepo.On("FindByID", ctx, loanID, workspaceID).Return(loan, nil)
+	mockInvRepo.On("FindByID", ctx, inventoryID, workspaceID).Return(inv, nil)
+	mockLoanRepo.On("Save", ctx, mock.AnythingOfType("*loan.Loan")).Return(nil)
+
+	result, _, err := svc.Return(ctx, loanID, workspaceID, ReturnInput{})
+
+	// DISPOSED is terminal: the loan is returned but the inventory stays put.
+	assert.NoError(t, err)
+	assert.NotNil(t, result.ReturnedAt())
+	assert.Equal(t, inventory.StatusDisposed, inv.Status())
+	mockInvRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	mockLoanRepo.AssertExpectations(t)
+}
+
 func TestService_Return_InventoryFindError(t *testing.T) {
 	ctx := context.Background()
 	loanID := uuid.New()
@@ -1465,13 +1627,119 @@ func TestService_Return_InventoryFindError(t *testing.T) {
 	mockLoanRepo.On("FindByID", ctx, loanID, workspaceID).Return(loan, nil)
 	mockInvRepo.On("FindByID", ctx, inventoryID, workspaceID).Return(nil, repoErr)
 
-	result, err := svc.Return(ctx, loanID, workspaceID)
+	result, _, err := svc.Return(ctx, loanID, workspaceID, ReturnInput{})
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
 	assert.Equal(t, repoErr, err)
 }
 
+func TestService_Return_Condition(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+	inventoryID := uuid.New()
+	now := time.Now()
+
+	setup := func() (*Service, *MockRepository, *MockInventoryRepository, *Loan, *inventory.Inventory) {
+		mockLoanRepo := new(MockRepository)
+		mockInvRepo := new(MockInventoryRepository)
+		svc := NewService(mockLoanRepo, mockInvRepo, nil)
+
+		loan := Reconstruct(uuid.New(), workspaceID, inventoryID, uuid.New(), 1, now, nil, nil, nil, now, now)
+		inv := createTestInventory(inventoryID, workspaceID, uuid.New(), uuid.New(), 1, inventory.StatusOnLoan)
+		mockLoanRepo.On("FindByID", ctx, loan.ID(), workspaceID).Return(loan, nil)
+		mockInvRepo.On("FindByID", ctx, inventoryID, workspaceID).Return(inv, nil)
+		mockInvRepo.On("Save", ctx, inv).Return(nil)
+		mockLoanRepo.On("Save", ctx, loan).Return(nil)
+		return svc, mockLoanRepo, mockInvRepo, loan, inv
+	}
+
+	t.Run("records the returned condition", func(t *testing.T) {
+		svc, mockLoanRepo, mockInvRepo, loan, inv := setup()
+		condition := inventory.ConditionDamaged
+		note := "cracked handle"
+
+		result, change, err := svc.Return(ctx, loan.ID(), workspaceID, ReturnInput{Condition: &condition, Note: &note})
+
+		require.NoError(t, err)
+		assert.NotNil(t, result.ReturnedAt())
+		assert.Equal(t, inventory.StatusAvailable, inv.Status())
+		assert.Equal(t, inventory.ConditionDamaged, inv.Condition())
+		require.NotNil(t, change)
+		assert.Equal(t, inventoryID, change.InventoryID)
+		assert.Equal(t, inventory.ConditionGood, change.From)
+		assert.Equal(t, inventory.ConditionDamaged, change.To)
+		assert.Equal(t, &note, change.Note)
+		mockInvRepo.AssertExpectations(t)
+		mockLoanRepo.AssertExpectations(t)
+	})
+
+	t.Run("marks a damaged return for repair", func(t *testing.T) {
+		svc, _, _, loan, inv := setup()
+		condition := inventory.ConditionDamaged
+
+		_, change, err := svc.Return(ctx, loan.ID(), workspaceID, ReturnInput{Condition: &condition, MarkForRepair: true})
+
+		require.NoError(t, err)
+		assert.Equal(t, inventory.ConditionForRepair, inv.Condition())
+		require.NotNil(t, change)
+		assert.Equal(t, inventory.ConditionForRepair, change.To)
+	})
+
+	t.Run("repair flag ignores undamaged returns", func(t *testing.T) {
+		svc, _, _, loan, inv := setup()
+		condition := inventory.ConditionFair
+
+		_, change, err := svc.Return(ctx, loan.ID(), workspaceID, ReturnInput{Condition: &condition, MarkForRepair: true})
+
+		require.NoError(t, err)
+		assert.Equal(t, inventory.ConditionFair, inv.Condition())
+		require.NotNil(t, change)
+		assert.Equal(t, inventory.ConditionFair, change.To)
+	})
+
+	t.Run("unchanged condition records no change", func(t *testing.T) {
+		svc, _, mockInvRepo, loan, inv := setup()
+		condition := inventory.ConditionGood
+
+		result, change, err := svc.Return(ctx, loan.ID(), workspaceID, ReturnInput{Condition: &condition})
+
+		require.NoError(t, err)
+		assert.NotNil(t, result.ReturnedAt())
+		assert.Nil(t, change)
+		assert.Equal(t, inventory.ConditionGood, inv.Condition())
+		mockInvRepo.AssertExpectations(t)
+	})
+
+	t.Run("no condition leaves the entry's condition alone", func(t *testing.T) {
+		svc, _, _, loan, inv := setup()
+
+		result, change, err := svc.Return(ctx, loan.ID(), workspaceID, ReturnInput{})
+
+		require.NoError(t, err)
+		assert.NotNil(t, result.ReturnedAt())
+		assert.Nil(t, change)
+		assert.Equal(t, inventory.ConditionGood, inv.Condition())
+		assert.Equal(t, inventory.StatusAvailable, inv.Status())
+	})
+
+	t.Run("rejects an unknown condition before returning", func(t *testing.T) {
+		mockLoanRepo := new(MockRepository)
+		mockInvRepo := new(MockInventoryRepository)
+		svc := NewService(mockLoanRepo, mockInvRepo, nil)
+		condition := inventory.Condition("BROKEN")
+
+		result, change, err := svc.Return(ctx, uuid.New(), workspaceID, ReturnInput{Condition: &condition})
+
+		var domainErr *shared.DomainError
+		require.ErrorAs(t, err, &domainErr)
+		assert.Equal(t, "return_condition", domainErr.Field)
+		assert.Nil(t, result)
+		assert.Nil(t, change)
+		mockLoanRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
 func TestService_Create_FindActiveLoanError(t *testing.T) {
 	ctx := context.Background()
 	workspaceID := uuid.New()
@@ -0,0 +1,152 @@
+package loan
+
+import (
+	"context"
+	"errors"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/google/uuid"
+
+	appMiddleware "github.com/antti/home-warehouse/go-backend/internal/api/middleware"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// MaxOverdueGraceDays bounds Settings.OverdueGraceDays, matching the check
+// constraint on warehouse.loan_settings.
+const MaxOverdueGraceDays = 365
+
+// DefaultLoanDays is the loan period of workspaces that have not set one.
+// MaxDefaultLoanDays bounds Settings.DefaultLoanDays like the check
+// constraint does.
+const (
+	DefaultLoanDays    = 14
+	MaxDefaultLoanDays = 365
+)
+
+// Settings is the workspace loan policy.
+type Settings struct {
+	// OverdueGraceDays is how many days past its due date a loan may run
+	// before it is reported and reminded as overdue.
+	OverdueGraceDays int
+	// DefaultLoanDays is how many days after it was loaned out a loan
+	// created without a due date is due.
+	DefaultLoanDays int
+}
+
+func defaultSettings() *Settings {
+	return &Settings{DefaultLoanDays: DefaultLoanDays}
+}
+
+// SettingsRepository persists loan settings per workspace. Get returns
+// shared.ErrNotFound when the workspace has never saved any.
+type SettingsRepository interface {
+	Get(ctx context.Context, workspaceID uuid.UUID) (*Settings, error)
+	Upsert(ctx context.Context, workspaceID uuid.UUID, settings Settings) (*Settings, error)
+}
+
+// SetSettingsRepository wires loan settings storage. Without it every
+// workspace uses the default settings and they cannot be changed.
+func (s *Service) SetSettingsRepository(repo SettingsRepository) {
+	s.settings = repo
+}
+
+// GetSettings returns the workspace loan settings, defaulting to no grace
+// period and a DefaultLoanDays loan period when none have been saved.
+func (s *Service) GetSettings(ctx context.Context, workspaceID uuid.UUID) (*Settings, error) {
+	if s.settings == nil {
+		return defaultSettings(), nil
+	}
+	settings, err := s.settings.Get(ctx, workspaceID)
+	if errors.Is(err, shared.ErrNotFound) {
+		return defaultSettings(), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return settings, nil
+}
+
+// UpdateSettings replaces the workspace loan settings.
+func (s *Service) UpdateSettings(ctx context.Context, workspaceID uuid.UUID, settings Settings) (*Settings, error) {
+	if settings.OverdueGraceDays < 0 || settings.OverdueGraceDays > MaxOverdueGraceDays {
+		return nil, shared.NewFieldError(shared.ErrInvalidInput, "overdue_grace_days", "must be between 0 and 365")
+	}
+	if settings.DefaultLoanDays < 1 || settings.DefaultLoanDays > MaxDefaultLoanDays {
+		return nil, shared.NewFieldError(shared.ErrInvalidInput, "default_loan_days", "must be between 1 and 365")
+	}
+	if s.settings == nil {
+		return nil, errors.New("loan settings storage is not configured")
+	}
+	return s.settings.Upsert(ctx, workspaceID, settings)
+}
+
+// RegisterSettingsRoutes registers the workspace loan settings endpoints.
+func RegisterSettingsRoutes(api huma.API, svc ServiceInterface) {
+	huma.Get(api, "/loan-settings", getLoanSettings(svc))
+	huma.Put(api, "/loan-settings", updateLoanSettings(svc))
+}
+
+func getLoanSettings(svc ServiceInterface) func(context.Context, *struct{}) (*LoanSettingsOutput, error) {
+	return func(ctx context.Context, _ *struct{}) (*LoanSettingsOutput, error) {
+		workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+		if !ok {
+			return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+		}
+
+		settings, err := svc.GetSettings(ctx, workspaceID)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to fetch loan settings")
+		}
+
+		return &LoanSettingsOutput{Body: toLoanSettingsResponse(settings)}, nil
+	}
+}
+
+func updateLoanSettings(svc ServiceInterface) func(context.Context, *UpdateLoanSettingsInput) (*LoanSettingsOutput, error) {
+	return func(ctx context.Context, input *UpdateLoanSettingsInput) (*LoanSettingsOutput, error) {
+		workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+		if !ok {
+			return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+		}
+		if role, ok := appMiddleware.GetRole(ctx); !ok || (role != "owner" && role != "admin") {
+			return nil, huma.Error403Forbidden("only workspace owners and admins can change loan settings")
+		}
+
+		settings, err := svc.UpdateSettings(ctx, workspaceID, Settings{
+			OverdueGraceDays: input.Body.OverdueGraceDays,
+			DefaultLoanDays:  input.Body.DefaultLoanDays,
+		})
+		var domainErr *shared.DomainError
+		if errors.As(err, &domainErr) {
+			return nil, appMiddleware.MapDomainError(err)
+		}
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to update loan settings")
+		}
+
+		return &LoanSettingsOutput{Body: toLoanSettingsResponse(settings)}, nil
+	}
+}
+
+func toLoanSettingsResponse(s *Settings) LoanSettingsResponse {
+	return LoanSettingsResponse{
+		OverdueGraceDays: s.OverdueGraceDays,
+		DefaultLoanDays:  s.DefaultLoanDays,
+	}
+}
+
+type UpdateLoanSettingsInput struct {
+	Body struct {
+		OverdueGraceDays int `json:"overdue_grace_days" minimum:"0" maximum:"365" doc:"Days past the due date before a loan counts as overdue"`
+		DefaultLoanDays  int `json:"default_loan_days,omitempty" minimum:"1" maximum:"365" default:"14" doc:"Loan period in days for loans created without a due date"`
+	}
+}
+
+type LoanSettingsOutput struct {
+	Body LoanSettingsResponse
+}
+
+type LoanSettingsResponse struct {
+	OverdueGraceDays int `json:"overdue_grace_days" doc:"Days past the due date before a loan counts as overdue"`
+	DefaultLoanDays  int `json:"default_loan_days" doc:"Loan period in days for loans created without a due date"`
+}
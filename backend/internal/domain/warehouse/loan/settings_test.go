@@ -0,0 +1,185 @@
+package loan
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/inventory"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+type mockSettingsRepository struct {
+	mock.Mock
+}
+
+func (m *mockSettingsRepository) Get(ctx context.Context, workspaceID uuid.UUID) (*Settings, error) {
+	args := m.Called(ctx, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Settings), args.Error(1)
+}
+
+func (m *mockSettingsRepository) Upsert(ctx context.Context, workspaceID uuid.UUID, settings Settings) (*Settings, error) {
+	args := m.Called(ctx, workspaceID, settings)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Settings), args.Error(1)
+}
+
+func TestService_GetSettings(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+
+	t.Run("defaults without a repository", func(t *testing.T) {
+		svc := NewService(new(MockRepository), nil, nil)
+
+		settings, err := svc.GetSettings(ctx, workspaceID)
+		require.NoError(t, err)
+		assert.Equal(t, 0, settings.OverdueGraceDays)
+		assert.Equal(t, DefaultLoanDays, settings.DefaultLoanDays)
+	})
+
+	t.Run("defaults when nothing is saved", func(t *testing.T) {
+		repo := new(mockSettingsRepository)
+		svc := NewService(new(MockRepository), nil, nil)
+		svc.SetSettingsRepository(repo)
+		repo.On("Get", ctx, workspaceID).Return(nil, shared.ErrNotFound)
+
+		settings, err := svc.GetSettings(ctx, workspaceID)
+		require.NoError(t, err)
+		assert.Equal(t, 0, settings.OverdueGraceDays)
+		assert.Equal(t, DefaultLoanDays, settings.DefaultLoanDays)
+	})
+
+	t.Run("returns saved settings", func(t *testing.T) {
+		repo := new(mockSettingsRepository)
+		svc := NewService(new(MockRepository), nil, nil)
+		svc.SetSettingsRepository(repo)
+		repo.On("Get", ctx, workspaceID).Return(&Settings{OverdueGraceDays: 4}, nil)
+
+		settings, err := svc.GetSettings(ctx, workspaceID)
+		require.NoError(t, err)
+		assert.Equal(t, 4, settings.OverdueGraceDays)
+	})
+
+	t.Run("returns repository error", func(t *testing.T) {
+		repo := new(mockSettingsRepository)
+		svc := NewService(new(MockRepository), nil, nil)
+		svc.SetSettingsRepository(repo)
+		repo.On("Get", ctx, workspaceID).Return(nil, errors.New("db down"))
+
+		_, err := svc.GetSettings(ctx, workspaceID)
+		assert.Error(t, err)
+	})
+}
+
+func TestService_UpdateSettings(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+
+	t.Run("saves valid settings", func(t *testing.T) {
+		repo := new(mockSettingsRepository)
+		svc := NewService(new(MockRepository), nil, nil)
+		svc.SetSettingsRepository(repo)
+		saved := Settings{OverdueGraceDays: 7, DefaultLoanDays: 21}
+		repo.On("Upsert", ctx, workspaceID, saved).Return(&saved, nil)
+
+		settings, err := svc.UpdateSettings(ctx, workspaceID, saved)
+		require.NoError(t, err)
+		assert.Equal(t, 7, settings.OverdueGraceDays)
+		repo.AssertExpectations(t)
+	})
+
+	for _, days := range []int{-1, MaxOverdueGraceDays + 1} {
+		t.Run("rejects out of range grace", func(t *testing.T) {
+			repo := new(mockSettingsRepository)
+			svc := NewService(new(MockRepository), nil, nil)
+			svc.SetSettingsRepository(repo)
+
+			_, err := svc.UpdateSettings(ctx, workspaceID, Settings{OverdueGraceDays: days, DefaultLoanDays: DefaultLoanDays})
+			assert.ErrorIs(t, err, shared.ErrInvalidInput)
+			repo.AssertNotCalled(t, "Upsert", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+
+	for _, days := range []int{0, -3, MaxDefaultLoanDays + 1} {
+		t.Run("rejects a non-positive or too long loan period", func(t *testing.T) {
+			repo := new(mockSettingsRepository)
+			svc := NewService(new(MockRepository), nil, nil)
+			svc.SetSettingsRepository(repo)
+
+			_, err := svc.UpdateSettings(ctx, workspaceID, Settings{DefaultLoanDays: days})
+			assert.ErrorIs(t, err, shared.ErrInvalidInput)
+			repo.AssertNotCalled(t, "Upsert", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestService_Create_DefaultDueDate(t *testing.T) {
+	ctx := context.Background()
+	workspaceID, inventoryID, borrowerID := uuid.New(), uuid.New(), uuid.New()
+	loanedAt := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	newService := func(settings SettingsRepository) *Service {
+		loanRepo, invRepo := new(MockRepository), new(MockInventoryRepository)
+		inv := createTestInventory(inventoryID, workspaceID, uuid.New(), uuid.New(), 1, inventory.StatusAvailable)
+		invRepo.On("FindByID", ctx, inventoryID, workspaceID).Return(inv, nil)
+		invRepo.On("Save", ctx, mock.Anything).Return(nil)
+		loanRepo.On("FindActiveLoanForInventory", ctx, inventoryID).Return(nil, nil)
+		loanRepo.On("Save", ctx, mock.Anything).Return(nil)
+		svc := NewService(loanRepo, invRepo, nil)
+		if settings != nil {
+			svc.SetSettingsRepository(settings)
+		}
+		return svc
+	}
+	input := func(dueDate *time.Time) CreateInput {
+		return CreateInput{
+			WorkspaceID: workspaceID,
+			InventoryID: inventoryID,
+			BorrowerID:  borrowerID,
+			Quantity:    1,
+			LoanedAt:    loanedAt,
+			DueDate:     dueDate,
+		}
+	}
+
+	t.Run("uses the workspace loan period", func(t *testing.T) {
+		repo := new(mockSettingsRepository)
+		repo.On("Get", ctx, workspaceID).Return(&Settings{DefaultLoanDays: 21}, nil)
+
+		created, err := newService(repo).Create(ctx, input(nil))
+
+		require.NoError(t, err)
+		require.NotNil(t, created.DueDate())
+		assert.Equal(t, loanedAt.AddDate(0, 0, 21), *created.DueDate())
+	})
+
+	t.Run("falls back to the default period", func(t *testing.T) {
+		created, err := newService(nil).Create(ctx, input(nil))
+
+		require.NoError(t, err)
+		require.NotNil(t, created.DueDate())
+		assert.Equal(t, loanedAt.AddDate(0, 0, DefaultLoanDays), *created.DueDate())
+	})
+
+	t.Run("keeps an explicit due date", func(t *testing.T) {
+		repo := new(mockSettingsRepository)
+		due := loanedAt.AddDate(0, 0, 3)
+
+		created, err := newService(repo).Create(ctx, input(&due))
+
+		require.NoError(t, err)
+		assert.Equal(t, due, *created.DueDate())
+		repo.AssertNotCalled(t, "Get", mock.Anything, mock.Anything)
+	})
+}
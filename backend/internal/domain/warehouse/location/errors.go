@@ -9,4 +9,7 @@ var (
 	ErrShortCodeTaken   = shared.NewDomainError(shared.ErrAlreadyExists, "short code is already taken")
 	ErrCyclicParent     = shared.NewDomainError(shared.ErrInvalidInput, "cyclic parent reference not allowed")
 	ErrHasContainers    = shared.NewDomainError(shared.ErrConflict, "location has containers")
+
+	ErrEvacuateToSelf       = shared.NewFieldError(shared.ErrInvalidInput, "target_location_id", "target location must differ from the location being evacuated")
+	ErrEvacuateToDescendant = shared.NewFieldError(shared.ErrInvalidInput, "target_location_id", "target location cannot be a descendant of the location being evacuated")
 )
@@ -29,6 +29,7 @@ func RegisterRoutes(api huma.API, svc ServiceInterface, broadcaster *events.Broa
 	huma.Post(api, "/locations/{id}/archive", archiveLocation(svc, broadcaster))
 	huma.Post(api, "/locations/{id}/restore", restoreLocation(svc, broadcaster))
 	huma.Delete(api, routeLocationByID, deleteLocation(svc, broadcaster))
+	huma.Post(api, "/locations/{id}/evacuate", evacuateLocation(svc, broadcaster))
 	huma.Get(api, "/locations/{id}/breadcrumb", getBreadcrumb(svc))
 	huma.Get(api, "/locations/search", searchLocations(svc))
 }
@@ -246,6 +247,51 @@ func deleteLocation(svc ServiceInterface, broadcaster *events.Broadcaster) func(
 	}
 }
 
+// evacuateLocation moves all inventory (and optionally containers) out of a
+// location so it can be deleted.
+func evacuateLocation(svc ServiceInterface, broadcaster *events.Broadcaster) func(context.Context, *EvacuateLocationInput) (*EvacuateLocationOutput, error) {
+	return func(ctx context.Context, input *EvacuateLocationInput) (*EvacuateLocationOutput, error) {
+		workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+		if !ok {
+			return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+		}
+		// Evacuations apply immediately rather than through the approval queue.
+		if role, ok := appMiddleware.GetRole(ctx); !ok || (role != "owner" && role != "admin") {
+			return nil, huma.Error403Forbidden("only workspace owners and admins can evacuate locations")
+		}
+
+		result, err := svc.EvacuateLocation(ctx, workspaceID, input.ID, input.Body.TargetLocationID, input.Body.IncludeContainers)
+		if err != nil {
+			return nil, appMiddleware.MapDomainError(err)
+		}
+
+		// Publish event
+		authUser, _ := appMiddleware.GetAuthUser(ctx)
+		if broadcaster != nil && authUser != nil {
+			userName := appMiddleware.GetUserDisplayName(ctx)
+			broadcaster.Publish(workspaceID, events.Event{
+				Type:       "location.evacuated",
+				EntityID:   input.ID.String(),
+				EntityType: "location",
+				UserID:     authUser.ID,
+				Data: map[string]any{
+					"target_location_id": input.Body.TargetLocationID,
+					"inventory_moved":    result.InventoryMoved,
+					"containers_moved":   result.ContainersMoved,
+					"user_name":          userName,
+				},
+			})
+		}
+
+		return &EvacuateLocationOutput{
+			Body: EvacuateLocationResponse{
+				InventoryMoved:  result.InventoryMoved,
+				ContainersMoved: result.ContainersMoved,
+			},
+		}, nil
+	}
+}
+
 // publishLocationLifecycleEvent publishes a lifecycle event (archive/restore/
 // delete) carrying only the acting user's display name, matching the original
 // per-handler publish blocks verbatim.
@@ -418,6 +464,23 @@ type BreadcrumbResponse struct {
 	ShortCode string    `json:"short_code"`
 }
 
+type EvacuateLocationInput struct {
+	ID   uuid.UUID `path:"id"`
+	Body struct {
+		TargetLocationID  uuid.UUID `json:"target_location_id" doc:"Location to move the inventory into"`
+		IncludeContainers bool      `json:"include_containers,omitempty" doc:"Also move the location's containers (with their contents); otherwise contained inventory is moved loose"`
+	}
+}
+
+type EvacuateLocationOutput struct {
+	Body EvacuateLocationResponse
+}
+
+type EvacuateLocationResponse struct {
+	InventoryMoved  int `json:"inventory_moved" doc:"Number of inventory entries moved to the target location"`
+	ContainersMoved int `json:"containers_moved" doc:"Number of containers moved to the target location"`
+}
+
 type SearchLocationsInput struct {
 	Query string `query:"q" minLength:"1" doc:"Search query"`
 	Limit int    `query:"limit" default:"50" minimum:"1" maximum:"100"`
@@ -82,6 +82,14 @@ func (m *MockService) Search(ctx context.Context, workspaceID uuid.UUID, query s
 	return args.Get(0).([]*location.Location), args.Error(1)
 }
 
+func (m *MockService) EvacuateLocation(ctx context.Context, workspaceID, locationID, targetLocationID uuid.UUID, includeContainers bool) (*location.EvacuateResult, error) {
+	args := m.Called(ctx, workspaceID, locationID, targetLocationID, includeContainers)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*location.EvacuateResult), args.Error(1)
+}
+
 // Tests
 
 func TestLocationHandler_Create(t *testing.T) {
@@ -180,6 +188,53 @@ func TestLocationHandler_Get(t *testing.T) {
 	})
 }
 
+func TestLocationHandler_Label(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	location.RegisterLabelRoutes(setup.API, mockSvc, "https://app.example.com")
+
+	testLoc, _ := location.NewLocation(setup.WorkspaceID, "Garage Shelf", nil, nil, "SHELF1")
+	locID := testLoc.ID()
+
+	tests := []struct {
+		query, target, qr string
+	}{
+		{"", location.LabelTargetApp, "https://app.example.com/dashboard/locations?focus=" + locID.String()},
+		{"?target=app", location.LabelTargetApp, "https://app.example.com/dashboard/locations?focus=" + locID.String()},
+		{"?target=code", location.LabelTargetCode, "SHELF1"},
+	}
+	for _, tt := range tests {
+		t.Run("target "+tt.target+tt.query, func(t *testing.T) {
+			mockSvc.On("GetByID", mock.Anything, locID, setup.WorkspaceID).Return(testLoc, nil).Once()
+
+			rec := setup.Get(fmt.Sprintf("/locations/%s/label%s", locID, tt.query))
+
+			testutil.AssertStatus(t, rec, http.StatusOK)
+			body := testutil.ParseJSONResponse[location.LocationLabelResponse](t, rec)
+			assert.Equal(t, "SHELF1", body.ShortCode)
+			assert.Equal(t, tt.target, body.Target)
+			assert.Equal(t, tt.qr, body.QRContent)
+			mockSvc.AssertExpectations(t)
+		})
+	}
+
+	t.Run("rejects unknown target", func(t *testing.T) {
+		rec := setup.Get(fmt.Sprintf("/locations/%s/label?target=sms", locID))
+
+		testutil.AssertStatus(t, rec, http.StatusUnprocessableEntity)
+	})
+
+	t.Run("returns 404 when location not found", func(t *testing.T) {
+		missingID := uuid.New()
+		mockSvc.On("GetByID", mock.Anything, missingID, setup.WorkspaceID).
+			Return(nil, location.ErrLocationNotFound).Once()
+
+		rec := setup.Get(fmt.Sprintf("/locations/%s/label", missingID))
+
+		testutil.AssertStatus(t, rec, http.StatusNotFound)
+	})
+}
+
 func TestLocationHandler_Update(t *testing.T) {
 	setup := testutil.NewHandlerTestSetup()
 	mockSvc := new(MockService)
@@ -354,6 +409,79 @@ func TestLocationHandler_GetBreadcrumb(t *testing.T) {
 	})
 }
 
+func TestLocationHandler_Evacuate(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	location.RegisterRoutes(setup.API, mockSvc, nil)
+
+	t.Run("evacuates location successfully", func(t *testing.T) {
+		locID := uuid.New()
+		targetID := uuid.New()
+
+		mockSvc.On("EvacuateLocation", mock.Anything, setup.WorkspaceID, locID, targetID, true).
+			Return(&location.EvacuateResult{InventoryMoved: 4, ContainersMoved: 1}, nil).Once()
+
+		body := fmt.Sprintf(`{"target_location_id":"%s","include_containers":true}`, targetID)
+		rec := setup.Post(fmt.Sprintf("/locations/%s/evacuate", locID), body)
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		assert.Contains(t, rec.Body.String(), `"inventory_moved":4`)
+		assert.Contains(t, rec.Body.String(), `"containers_moved":1`)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("include_containers defaults to false", func(t *testing.T) {
+		locID := uuid.New()
+		targetID := uuid.New()
+
+		mockSvc.On("EvacuateLocation", mock.Anything, setup.WorkspaceID, locID, targetID, false).
+			Return(&location.EvacuateResult{InventoryMoved: 2}, nil).Once()
+
+		body := fmt.Sprintf(`{"target_location_id":"%s"}`, targetID)
+		rec := setup.Post(fmt.Sprintf("/locations/%s/evacuate", locID), body)
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("returns 400 for descendant target", func(t *testing.T) {
+		locID := uuid.New()
+		targetID := uuid.New()
+
+		mockSvc.On("EvacuateLocation", mock.Anything, setup.WorkspaceID, locID, targetID, false).
+			Return(nil, location.ErrEvacuateToDescendant).Once()
+
+		body := fmt.Sprintf(`{"target_location_id":"%s"}`, targetID)
+		rec := setup.Post(fmt.Sprintf("/locations/%s/evacuate", locID), body)
+
+		testutil.AssertStatus(t, rec, http.StatusBadRequest)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("returns 404 when location not found", func(t *testing.T) {
+		locID := uuid.New()
+		targetID := uuid.New()
+
+		mockSvc.On("EvacuateLocation", mock.Anything, setup.WorkspaceID, locID, targetID, false).
+			Return(nil, location.ErrLocationNotFound).Once()
+
+		body := fmt.Sprintf(`{"target_location_id":"%s"}`, targetID)
+		rec := setup.Post(fmt.Sprintf("/locations/%s/evacuate", locID), body)
+
+		testutil.AssertStatus(t, rec, http.StatusNotFound)
+		mockSvc.AssertExpectations(t)
+	})
+	t.Run("requires owner or admin", func(t *testing.T) {
+		setup.SetRole("member")
+		defer setup.SetRole("owner")
+
+		body := fmt.Sprintf(`{"target_location_id":"%s"}`, uuid.New())
+		rec := setup.Post(fmt.Sprintf("/locations/%s/evacuate", uuid.New()), body)
+
+		testutil.AssertStatus(t, rec, http.StatusForbidden)
+	})
+}
+
 // Event Publishing Tests
 
 func TestLocationHandler_Create_PublishesEvent(t *testing.T) {
@@ -0,0 +1,74 @@
+package location
+
+import (
+	"context"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/google/uuid"
+
+	appMiddleware "github.com/antti/home-warehouse/go-backend/internal/api/middleware"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/shortlink"
+)
+
+// QR label targets: what a location's label QR code encodes.
+const (
+	// LabelTargetApp encodes a deep link that opens the location's contents
+	// in the app.
+	LabelTargetApp = "app"
+	// LabelTargetCode encodes the bare short code for the scan resolver.
+	LabelTargetCode = "code"
+)
+
+// RegisterLabelRoutes registers the location label endpoint. appURL is the
+// frontend base URL deep links are built on.
+func RegisterLabelRoutes(api huma.API, svc ServiceInterface, appURL string) {
+	huma.Get(api, "/locations/{id}/label", getLocationLabel(svc, appURL))
+}
+
+// getLocationLabel returns what to print on a location's label, including
+// the QR code content for the requested target.
+func getLocationLabel(svc ServiceInterface, appURL string) func(context.Context, *GetLocationLabelInput) (*GetLocationLabelOutput, error) {
+	return func(ctx context.Context, input *GetLocationLabelInput) (*GetLocationLabelOutput, error) {
+		workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+		if !ok {
+			return nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+		}
+
+		location, err := svc.GetByID(ctx, input.ID, workspaceID)
+		if err != nil || location == nil {
+			return nil, huma.Error404NotFound("location not found")
+		}
+
+		qr := location.ShortCode()
+		if input.Target == LabelTargetApp {
+			qr = shortlink.AppLink(appURL, shortlink.TypeLocation, location.ID())
+		}
+
+		return &GetLocationLabelOutput{
+			Body: LocationLabelResponse{
+				ID:        location.ID(),
+				Name:      location.Name(),
+				ShortCode: location.ShortCode(),
+				Target:    input.Target,
+				QRContent: qr,
+			},
+		}, nil
+	}
+}
+
+type GetLocationLabelInput struct {
+	ID     uuid.UUID `path:"id"`
+	Target string    `query:"target" enum:"app,code" default:"app" doc:"What the QR code encodes: app for a link that opens the location's contents, code for the bare short code"`
+}
+
+type GetLocationLabelOutput struct {
+	Body LocationLabelResponse
+}
+
+type LocationLabelResponse struct {
+	ID        uuid.UUID `json:"id"`
+	Name      string    `json:"name"`
+	ShortCode string    `json:"short_code"`
+	Target    string    `json:"target" enum:"app,code"`
+	QRContent string    `json:"qr_content" doc:"Text to encode in the QR code"`
+}
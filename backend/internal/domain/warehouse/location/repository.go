@@ -20,4 +20,12 @@ type Repository interface {
 	// since migration 005, not per-workspace).
 	ShortCodeExists(ctx context.Context, shortCode string) (bool, error)
 	Search(ctx context.Context, workspaceID uuid.UUID, query string, limit int) ([]*Location, error)
+	// MoveInventory relocates every inventory row at fromLocationID to
+	// toLocationID, recording a movement for each, and returns the number of
+	// rows moved. When keepContainers is false the rows are taken out of
+	// their containers (container_id cleared).
+	MoveInventory(ctx context.Context, workspaceID, fromLocationID, toLocationID uuid.UUID, keepContainers bool) (int, error)
+	// MoveContainers relocates every container at fromLocationID to
+	// toLocationID and returns the number moved.
+	MoveContainers(ctx context.Context, workspaceID, fromLocationID, toLocationID uuid.UUID) (int, error)
 }
@@ -4,9 +4,11 @@ import (
 	"context"
 	"crypto/rand"
 	"encoding/hex"
+	"fmt"
 
 	"github.com/google/uuid"
 
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/deletionimpact"
 	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/idempotency"
 	"github.com/antti/home-warehouse/go-backend/internal/shared"
 )
@@ -29,15 +31,41 @@ type ServiceInterface interface {
 	Delete(ctx context.Context, id, workspaceID uuid.UUID) error
 	GetBreadcrumb(ctx context.Context, locationID, workspaceID uuid.UUID) ([]BreadcrumbItem, error)
 	Search(ctx context.Context, workspaceID uuid.UUID, query string, limit int) ([]*Location, error)
+	EvacuateLocation(ctx context.Context, workspaceID, locationID, targetLocationID uuid.UUID, includeContainers bool) (*EvacuateResult, error)
+}
+
+// Transactor runs a function inside a single database transaction. It is a
+// port implemented by infra/postgres.TxManager — same convention as the loan
+// and maintenance domains, keeping this package free of infra imports.
+type Transactor interface {
+	WithTx(ctx context.Context, fn func(context.Context) error) error
+}
+
+// noopTransactor executes the function without a surrounding transaction. It
+// is the fallback when no Transactor is wired (unit tests with mocked
+// repositories).
+type noopTransactor struct{}
+
+func (noopTransactor) WithTx(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+// DeletionGuard decides whether an entity may be deleted. Implemented by
+// deletionimpact.Service so Delete refuses on exactly what the
+// deletion-impact preview reports.
+type DeletionGuard interface {
+	CheckDeletable(ctx context.Context, workspaceID uuid.UUID, entityType deletionimpact.EntityType, id uuid.UUID) error
 }
 
 type Service struct {
 	repo      Repository
 	idemStore idempotency.Store
+	tx        Transactor
+	guard     DeletionGuard
 }
 
 func NewService(repo Repository) *Service {
-	return &Service{repo: repo}
+	return &Service{repo: repo, tx: noopTransactor{}}
 }
 
 // SetIdempotencyStore wires the shared idempotency dedup store used by
@@ -47,6 +75,22 @@ func (s *Service) SetIdempotencyStore(store idempotency.Store) {
 	s.idemStore = store
 }
 
+// SetTransactor wires the transaction runner used by EvacuateLocation so the
+// inventory and container moves commit together. Optional — without it the
+// moves run unwrapped (unit tests with mocked repositories).
+func (s *Service) SetTransactor(tx Transactor) {
+	if tx == nil {
+		tx = noopTransactor{}
+	}
+	s.tx = tx
+}
+
+// SetDeletionGuard wires the shared deletion rules used by Delete. Optional —
+// without it Delete relies on the database constraints alone.
+func (s *Service) SetDeletionGuard(guard DeletionGuard) {
+	s.guard = guard
+}
+
 type CreateInput struct {
 	WorkspaceID    uuid.UUID
 	Name           string
@@ -214,9 +258,108 @@ func (s *Service) Delete(ctx context.Context, id, workspaceID uuid.UUID) error {
 		return err
 	}
 
+	if s.guard != nil {
+		if err := s.guard.CheckDeletable(ctx, workspaceID, deletionimpact.EntityLocation, location.ID()); err != nil {
+			return err
+		}
+	}
+
 	return s.repo.Delete(ctx, location.ID(), workspaceID)
 }
 
+// EvacuateResult reports how much was moved out of an evacuated location.
+type EvacuateResult struct {
+	InventoryMoved  int
+	ContainersMoved int
+}
+
+// EvacuateLocation moves all inventory out of locationID into targetLocationID
+// so the location can then be deleted (the inventory foreign key otherwise
+// blocks the delete). With includeContainers the location's containers move
+// too and keep their contents; without it, inventory inside those containers
+// is moved loose to the target and the containers stay behind. Everything
+// happens in one transaction.
+//
+// The target must differ from the source and must not be one of its
+// descendants, since those lose their parent when the source is deleted.
+func (s *Service) EvacuateLocation(ctx context.Context, workspaceID, locationID, targetLocationID uuid.UUID, includeContainers bool) (*EvacuateResult, error) {
+	if locationID == targetLocationID {
+		return nil, ErrEvacuateToSelf
+	}
+
+	if _, err := s.GetByID(ctx, locationID, workspaceID); err != nil {
+		return nil, err
+	}
+
+	if _, err := s.repo.FindByID(ctx, targetLocationID, workspaceID); err != nil {
+		if shared.IsNotFound(err) {
+			return nil, shared.NewFieldError(shared.ErrNotFound, "target_location_id", fmt.Sprintf("location %s not found in this workspace", targetLocationID))
+		}
+		return nil, err
+	}
+
+	isDescendant, err := s.isDescendant(ctx, workspaceID, targetLocationID, locationID)
+	if err != nil {
+		return nil, err
+	}
+	if isDescendant {
+		return nil, ErrEvacuateToDescendant
+	}
+
+	result := &EvacuateResult{}
+	err = s.tx.WithTx(ctx, func(txCtx context.Context) error {
+		moved, err := s.repo.MoveInventory(txCtx, workspaceID, locationID, targetLocationID, includeContainers)
+		if err != nil {
+			return err
+		}
+		result.InventoryMoved = moved
+
+		if includeContainers {
+			moved, err := s.repo.MoveContainers(txCtx, workspaceID, locationID, targetLocationID)
+			if err != nil {
+				return err
+			}
+			result.ContainersMoved = moved
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// isDescendant reports whether locationID sits anywhere below ancestorID by
+// walking the parent chain upwards.
+func (s *Service) isDescendant(ctx context.Context, workspaceID, locationID, ancestorID uuid.UUID) (bool, error) {
+	visited := make(map[uuid.UUID]bool) // Prevent infinite loops from bad data
+	currentID := &locationID
+
+	for currentID != nil && !visited[*currentID] {
+		visited[*currentID] = true
+
+		location, err := s.repo.FindByID(ctx, *currentID, workspaceID)
+		if err != nil {
+			if shared.IsNotFound(err) {
+				return false, nil
+			}
+			return false, err
+		}
+		if location == nil {
+			return false, nil
+		}
+
+		parentID := location.ParentLocation()
+		if parentID != nil && *parentID == ancestorID {
+			return true, nil
+		}
+		currentID = parentID
+	}
+
+	return false, nil
+}
+
 // BreadcrumbItem represents a single item in a breadcrumb trail.
 type BreadcrumbItem struct {
 	ID        uuid.UUID
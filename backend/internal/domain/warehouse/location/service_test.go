@@ -9,6 +9,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/deletionimpact"
 	"github.com/antti/home-warehouse/go-backend/internal/shared"
 )
 
@@ -72,6 +73,16 @@ func (m *MockRepository) Search(ctx context.Context, workspaceID uuid.UUID, quer
 	return args.Get(0).([]*Location), args.Error(1)
 }
 
+func (m *MockRepository) MoveInventory(ctx context.Context, workspaceID, fromLocationID, toLocationID uuid.UUID, keepContainers bool) (int, error) {
+	args := m.Called(ctx, workspaceID, fromLocationID, toLocationID, keepContainers)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockRepository) MoveContainers(ctx context.Context, workspaceID, fromLocationID, toLocationID uuid.UUID) (int, error) {
+	args := m.Called(ctx, workspaceID, fromLocationID, toLocationID)
+	return args.Int(0), args.Error(1)
+}
+
 func ptrString(s string) *string {
 	return &s
 }
@@ -935,3 +946,178 @@ func TestService_GetBreadcrumb_RepoError(t *testing.T) {
 	assert.Equal(t, repoErr, err)
 	mockRepo.AssertExpectations(t)
 }
+
+// countingTransactor runs fn inline and records how often WithTx was used.
+type countingTransactor struct{ calls int }
+
+func (c *countingTransactor) WithTx(ctx context.Context, fn func(context.Context) error) error {
+	c.calls++
+	return fn(ctx)
+}
+
+func TestService_EvacuateLocation(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+
+	// Hierarchy: source -> child; target is a separate root
+	sourceID := uuid.New()
+	childID := uuid.New()
+	targetID := uuid.New()
+
+	source := &Location{id: sourceID, workspaceID: workspaceID, name: "Garage"}
+	child := &Location{id: childID, workspaceID: workspaceID, name: "Shelf", parentLocation: &sourceID}
+	target := &Location{id: targetID, workspaceID: workspaceID, name: "Basement"}
+
+	t.Run("moves inventory only inside a transaction", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		tx := &countingTransactor{}
+		svc := NewService(mockRepo)
+		svc.SetTransactor(tx)
+
+		mockRepo.On("FindByID", ctx, sourceID, workspaceID).Return(source, nil)
+		mockRepo.On("FindByID", ctx, targetID, workspaceID).Return(target, nil)
+		mockRepo.On("MoveInventory", ctx, workspaceID, sourceID, targetID, false).Return(7, nil)
+
+		result, err := svc.EvacuateLocation(ctx, workspaceID, sourceID, targetID, false)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 7, result.InventoryMoved)
+		assert.Equal(t, 0, result.ContainersMoved)
+		assert.Equal(t, 1, tx.calls)
+		mockRepo.AssertNotCalled(t, "MoveContainers", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("moves containers when requested", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		svc := NewService(mockRepo)
+
+		mockRepo.On("FindByID", ctx, sourceID, workspaceID).Return(source, nil)
+		mockRepo.On("FindByID", ctx, targetID, workspaceID).Return(target, nil)
+		mockRepo.On("MoveInventory", ctx, workspaceID, sourceID, targetID, true).Return(3, nil)
+		mockRepo.On("MoveContainers", ctx, workspaceID, sourceID, targetID).Return(2, nil)
+
+		result, err := svc.EvacuateLocation(ctx, workspaceID, sourceID, targetID, true)
+
+		assert.NoError(t, err)
+		assert.Equal(t, 3, result.InventoryMoved)
+		assert.Equal(t, 2, result.ContainersMoved)
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("rejects target equal to source", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		svc := NewService(mockRepo)
+
+		result, err := svc.EvacuateLocation(ctx, workspaceID, sourceID, sourceID, false)
+
+		assert.ErrorIs(t, err, ErrEvacuateToSelf)
+		assert.ErrorIs(t, err, shared.ErrInvalidInput)
+		assert.Nil(t, result)
+		mockRepo.AssertNotCalled(t, "MoveInventory", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects descendant target", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		svc := NewService(mockRepo)
+
+		mockRepo.On("FindByID", ctx, sourceID, workspaceID).Return(source, nil)
+		mockRepo.On("FindByID", ctx, childID, workspaceID).Return(child, nil)
+
+		result, err := svc.EvacuateLocation(ctx, workspaceID, sourceID, childID, false)
+
+		assert.ErrorIs(t, err, ErrEvacuateToDescendant)
+		assert.Nil(t, result)
+		mockRepo.AssertNotCalled(t, "MoveInventory", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("rejects missing target", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		svc := NewService(mockRepo)
+
+		mockRepo.On("FindByID", ctx, sourceID, workspaceID).Return(source, nil)
+		mockRepo.On("FindByID", ctx, targetID, workspaceID).Return(nil, shared.ErrNotFound)
+
+		result, err := svc.EvacuateLocation(ctx, workspaceID, sourceID, targetID, false)
+
+		assert.ErrorIs(t, err, shared.ErrNotFound)
+		assert.Nil(t, result)
+	})
+
+	t.Run("returns not found for missing source", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		svc := NewService(mockRepo)
+
+		mockRepo.On("FindByID", ctx, sourceID, workspaceID).Return(nil, nil)
+
+		result, err := svc.EvacuateLocation(ctx, workspaceID, sourceID, targetID, false)
+
+		assert.ErrorIs(t, err, ErrLocationNotFound)
+		assert.Nil(t, result)
+	})
+
+	t.Run("propagates move error", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		svc := NewService(mockRepo)
+
+		mockRepo.On("FindByID", ctx, sourceID, workspaceID).Return(source, nil)
+		mockRepo.On("FindByID", ctx, targetID, workspaceID).Return(target, nil)
+		mockRepo.On("MoveInventory", ctx, workspaceID, sourceID, targetID, true).Return(0, assert.AnError)
+
+		result, err := svc.EvacuateLocation(ctx, workspaceID, sourceID, targetID, true)
+
+		assert.ErrorIs(t, err, assert.AnError)
+		assert.Nil(t, result)
+		mockRepo.AssertNotCalled(t, "MoveContainers", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+// MockDeletionGuard is a mock implementation of DeletionGuard for testing.
+type MockDeletionGuard struct {
+	mock.Mock
+}
+
+func (m *MockDeletionGuard) CheckDeletable(ctx context.Context, workspaceID uuid.UUID, entityType deletionimpact.EntityType, id uuid.UUID) error {
+	args := m.Called(ctx, workspaceID, entityType, id)
+	return args.Error(0)
+}
+
+func TestService_Delete_WithDeletionGuard(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+	locationID := uuid.New()
+	loc := &Location{id: locationID, workspaceID: workspaceID, name: "Garage"}
+
+	t.Run("deletes when guard allows", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		guard := new(MockDeletionGuard)
+		svc := NewService(mockRepo)
+		svc.SetDeletionGuard(guard)
+
+		mockRepo.On("FindByID", ctx, locationID, workspaceID).Return(loc, nil)
+		guard.On("CheckDeletable", ctx, workspaceID, deletionimpact.EntityLocation, locationID).Return(nil)
+		mockRepo.On("Delete", ctx, locationID).Return(nil)
+
+		err := svc.Delete(ctx, locationID, workspaceID)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		guard.AssertExpectations(t)
+	})
+
+	t.Run("refuses when guard blocks", func(t *testing.T) {
+		mockRepo := new(MockRepository)
+		guard := new(MockDeletionGuard)
+		svc := NewService(mockRepo)
+		svc.SetDeletionGuard(guard)
+
+		blocked := shared.NewDomainError(shared.ErrConflict, "location cannot be deleted: it has 3 inventory")
+		mockRepo.On("FindByID", ctx, locationID, workspaceID).Return(loc, nil)
+		guard.On("CheckDeletable", ctx, workspaceID, deletionimpact.EntityLocation, locationID).Return(blocked)
+
+		err := svc.Delete(ctx, locationID, workspaceID)
+
+		assert.ErrorIs(t, err, shared.ErrConflict)
+		mockRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+	})
+}
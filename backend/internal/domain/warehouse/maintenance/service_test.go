@@ -83,11 +83,19 @@ func (m *MockInventoryRepository) FindByID(ctx context.Context, id, workspaceID
 	return args.Get(0).(*inventory.Inventory), args.Error(1)
 }
 
-func (m *MockInventoryRepository) List(ctx context.Context, workspaceID uuid.UUID, pagination shared.Pagination) ([]*inventory.Inventory, int, error) {
-	args := m.Called(ctx, workspaceID, pagination)
+func (m *MockInventoryRepository) List(ctx context.Context, workspaceID uuid.UUID, pagination shared.Pagination, filters inventory.ListFilters) ([]*inventory.Inventory, int, error) {
+	args := m.Called(ctx, workspaceID, pagination, filters)
 	return args.Get(0).([]*inventory.Inventory), args.Int(1), args.Error(2)
 }
 
+func (m *MockInventoryRepository) ListWithItemDetails(ctx context.Context, workspaceID uuid.UUID, pagination shared.Pagination, filters inventory.ListFilters) ([]*inventory.InventoryWithDetails, int, error) {
+	args := m.Called(ctx, workspaceID, pagination, filters)
+	if args.Get(0) == nil {
+		return nil, args.Int(1), args.Error(2)
+	}
+	return args.Get(0).([]*inventory.InventoryWithDetails), args.Int(1), args.Error(2)
+}
+
 func (m *MockInventoryRepository) FindByItem(ctx context.Context, workspaceID, itemID uuid.UUID) ([]*inventory.Inventory, error) {
 	args := m.Called(ctx, workspaceID, itemID)
 	return args.Get(0).([]*inventory.Inventory), args.Error(1)
@@ -103,8 +111,8 @@ func (m *MockInventoryRepository) FindByContainer(ctx context.Context, workspace
 	return args.Get(0).([]*inventory.Inventory), args.Error(1)
 }
 
-func (m *MockInventoryRepository) FindAvailable(ctx context.Context, workspaceID, itemID uuid.UUID) ([]*inventory.Inventory, error) {
-	args := m.Called(ctx, workspaceID, itemID)
+func (m *MockInventoryRepository) FindAvailable(ctx context.Context, workspaceID, itemID uuid.UUID, includeReserved bool) ([]*inventory.Inventory, error) {
+	args := m.Called(ctx, workspaceID, itemID, includeReserved)
 	return args.Get(0).([]*inventory.Inventory), args.Error(1)
 }
 
@@ -113,6 +121,14 @@ func (m *MockInventoryRepository) GetTotalQuantity(ctx context.Context, workspac
 	return args.Int(0), args.Error(1)
 }
 
+func (m *MockInventoryRepository) AddQuantity(ctx context.Context, id, workspaceID uuid.UUID, amount int) (*inventory.Inventory, error) {
+	args := m.Called(ctx, id, workspaceID, amount)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*inventory.Inventory), args.Error(1)
+}
+
 func (m *MockInventoryRepository) Delete(ctx context.Context, id, workspaceID uuid.UUID) error {
 	args := m.Called(ctx, id, workspaceID)
 	return args.Error(0)
@@ -22,6 +22,7 @@ package pendingchange
 
 import (
 	"encoding/json"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
@@ -205,14 +206,54 @@ func (p *PendingChange) IsRejected() bool {
 	return p.status == StatusRejected
 }
 
+var actions = []Action{ActionCreate, ActionUpdate, ActionDelete}
+
+// Actions returns every pending change action.
+func Actions() []Action {
+	return slices.Clone(actions)
+}
+
+// entityTypes are the entity types routed through the approval pipeline.
+//
+// This set must stay in sync with the approval middleware's extractEntityType
+// (internal/api/middleware/approval_middleware.go). Entity types not listed here
+// are deliberately NOT routed through the approval pipeline — see
+// docs/APPROVAL_PIPELINE.md ("Entity coverage and deliberate exclusions") for the
+// rationale on which member-mutable resources are gated and which are applied
+// atomically with their parent and therefore intentionally excluded.
+//
+// "quick_add" is the one exception: the middleware never sees it, because the
+// quick add handler receives multipart and creates the change itself (see
+// QuickAddApplier).
+var entityTypes = []string{
+	"item", "category", "location", "container", "inventory",
+	"borrower", "loan", "label", "maintenance", "wishlist", "quick_add",
+}
+
+// EntityTypes returns the entity types a pending change can target.
+func EntityTypes() []string {
+	return slices.Clone(entityTypes)
+}
+
+// InventoryTransferPayload is the payload of an inventory update change that
+// moves the entry to another location or container instead of editing it.
+// The approval middleware builds it from a member's
+// POST /inventory/{id}/move request by wrapping the request body:
+//
+//	{"transfer": {"location_id": "...", "container_id": "..."}}
+type InventoryTransferPayload struct {
+	Transfer *InventoryTransfer `json:"transfer"`
+}
+
+// InventoryTransfer is the destination of a requested inventory transfer.
+type InventoryTransfer struct {
+	LocationID  uuid.UUID  `json:"location_id"`
+	ContainerID *uuid.UUID `json:"container_id"`
+}
+
 // Helper functions
 func isValidAction(action Action) bool {
-	switch action {
-	case ActionCreate, ActionUpdate, ActionDelete:
-		return true
-	default:
-		return false
-	}
+	return slices.Contains(actions, action)
 }
 
 func isValidStatus(status Status) bool {
@@ -11,6 +11,7 @@ import (
 
 	appMiddleware "github.com/antti/home-warehouse/go-backend/internal/api/middleware"
 	"github.com/antti/home-warehouse/go-backend/internal/domain/auth/user"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
 )
 
 const (
@@ -23,9 +24,11 @@ const (
 
 // ServiceInterface defines the interface for pending change operations
 type ServiceInterface interface {
-	ListPendingForWorkspace(ctx context.Context, workspaceID uuid.UUID) ([]*PendingChange, error)
+	ListPendingForWorkspace(ctx context.Context, workspaceID uuid.UUID, filters ListFilters, pagination shared.Pagination) ([]*PendingChange, int, error)
 	ApproveChange(ctx context.Context, changeID, workspaceID uuid.UUID, reviewerID uuid.UUID) error
 	RejectChange(ctx context.Context, changeID, workspaceID uuid.UUID, reviewerID uuid.UUID, reason string) error
+	MyChangeCounts(ctx context.Context, workspaceID, requesterID uuid.UUID) (ChangeCounts, error)
+	ReviewerStats(ctx context.Context, workspaceID uuid.UUID, from, to time.Time) ([]ReviewerStat, error)
 }
 
 // RegisterRoutes registers pending change management routes
@@ -33,8 +36,10 @@ func RegisterRoutes(api huma.API, svc *Service, userRepo user.Repository) {
 	huma.Get(api, "/pending-changes", listPendingChanges(svc, userRepo))
 	huma.Get(api, "/pending-changes/{id}", getPendingChange(svc, userRepo))
 	huma.Get(api, "/my-pending-changes", listMyPendingChanges(svc, userRepo))
+	huma.Get(api, "/pending-changes/my/counts", myChangeCounts(svc))
 	huma.Post(api, "/pending-changes/{id}/approve", approvePendingChange(svc, userRepo))
 	huma.Post(api, "/pending-changes/{id}/reject", rejectPendingChange(svc, userRepo))
+	huma.Get(api, "/reports/reviewers", reviewerStatsReport(svc, userRepo))
 }
 
 // requireWorkspaceAndUser resolves the workspace and authenticated user from
@@ -66,10 +71,24 @@ func parseStatusFilter(raw string) (*Status, error) {
 	return &status, nil
 }
 
+// parseActionFilter resolves the optional action query param, mirroring
+// parseStatusFilter.
+func parseActionFilter(raw string) (*Action, error) {
+	if raw == "" {
+		return nil, nil
+	}
+	action, err := ParseAction(raw)
+	if err != nil {
+		return nil, huma.Error400BadRequest("invalid action filter")
+	}
+	return &action, nil
+}
+
 // enrichChanges converts a change slice into the list response envelope using
 // a memoized user lookup (collapsing the per-change requester/reviewer fetches
-// into one query per distinct user in the page).
-func enrichChanges(ctx context.Context, userRepo user.Repository, changes []*PendingChange) (*ListPendingChangesOutput, error) {
+// into one query per distinct user in the page). total is the match count
+// across all pages.
+func enrichChanges(ctx context.Context, userRepo user.Repository, changes []*PendingChange, total int) (*ListPendingChangesOutput, error) {
 	users := newUserLookup(userRepo)
 	responses := make([]PendingChangeResponse, len(changes))
 	for i, change := range changes {
@@ -82,7 +101,7 @@ func enrichChanges(ctx context.Context, userRepo user.Repository, changes []*Pen
 	return &ListPendingChangesOutput{
 		Body: PendingChangeListResponse{
 			Changes: responses,
-			Total:   len(responses),
+			Total:   total,
 		},
 	}, nil
 }
@@ -108,14 +127,31 @@ func listPendingChanges(svc *Service, userRepo user.Repository) func(context.Con
 		if err != nil {
 			return nil, err
 		}
+		actionFilter, err := parseActionFilter(input.Action)
+		if err != nil {
+			return nil, err
+		}
+		filters := ListFilters{
+			Status:     statusFilter,
+			EntityType: input.EntityType,
+			Action:     actionFilter,
+		}
+		if input.RequesterID != "" {
+			requesterID, parseErr := uuid.Parse(input.RequesterID)
+			if parseErr != nil {
+				return nil, huma.Error400BadRequest("invalid requester_id format")
+			}
+			filters.RequesterID = &requesterID
+		}
 
 		// Fetch pending changes
-		changes, err := svc.repo.FindByWorkspace(ctx, workspaceID, statusFilter)
+		pagination := shared.Pagination{Page: input.Page, PageSize: input.Limit}
+		changes, total, err := svc.repo.FindByWorkspace(ctx, workspaceID, filters, pagination)
 		if err != nil {
 			return nil, huma.Error500InternalServerError("failed to list pending changes")
 		}
 
-		return enrichChanges(ctx, userRepo, changes)
+		return enrichChanges(ctx, userRepo, changes, total)
 	}
 }
 
@@ -188,7 +224,31 @@ func listMyPendingChanges(svc *Service, userRepo user.Repository) func(context.C
 			}
 		}
 
-		return enrichChanges(ctx, userRepo, filteredChanges)
+		return enrichChanges(ctx, userRepo, filteredChanges, len(filteredChanges))
+	}
+}
+
+// myChangeCounts returns the handler for GET /pending-changes/my/counts.
+func myChangeCounts(svc ServiceInterface) func(context.Context, *struct{}) (*MyChangeCountsOutput, error) {
+	return func(ctx context.Context, _ *struct{}) (*MyChangeCountsOutput, error) {
+		workspaceID, authUser, err := requireWorkspaceAndUser(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		counts, err := svc.MyChangeCounts(ctx, workspaceID, authUser.ID)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to count pending changes")
+		}
+
+		return &MyChangeCountsOutput{
+			Body: MyChangeCountsResponse{
+				Pending:  counts.Pending,
+				Approved: counts.Approved,
+				Rejected: counts.Rejected,
+				Total:    counts.Total(),
+			},
+		}, nil
 	}
 }
 
@@ -370,8 +430,12 @@ func toPendingChangeResponse(ctx context.Context, change *PendingChange, findUse
 // Request/Response types
 
 type ListPendingChangesInput struct {
-	Status     string `query:"status" enum:"pending,approved,rejected" doc:"Filter by status (pending/approved/rejected)"`
-	EntityType string `query:"entity_type" doc:"Filter by entity type (item/category/location/etc)"`
+	Page        int    `query:"page" default:"1" minimum:"1"`
+	Limit       int    `query:"limit" default:"50" minimum:"1" maximum:"100"`
+	Status      string `query:"status" enum:"pending,approved,rejected" doc:"Filter by status (pending/approved/rejected)"`
+	EntityType  string `query:"entity_type" doc:"Filter by entity type (item/category/location/etc)"`
+	Action      string `query:"action" enum:"create,update,delete" doc:"Filter by action (create/update/delete)"`
+	RequesterID string `query:"requester_id" doc:"Filter by the user who submitted the change"`
 }
 
 type ListPendingChangesOutput struct {
@@ -380,7 +444,7 @@ type ListPendingChangesOutput struct {
 
 type PendingChangeListResponse struct {
 	Changes []PendingChangeResponse `json:"changes"`
-	Total   int                     `json:"total"`
+	Total   int                     `json:"total" doc:"Number of matching changes across all pages"`
 }
 
 type GetPendingChangeInput struct {
@@ -395,6 +459,17 @@ type ListMyPendingChangesInput struct {
 	Status string `query:"status" enum:"pending,approved,rejected" doc:"Filter by status (pending/approved/rejected)"`
 }
 
+type MyChangeCountsOutput struct {
+	Body MyChangeCountsResponse
+}
+
+type MyChangeCountsResponse struct {
+	Pending  int `json:"pending"`
+	Approved int `json:"approved"`
+	Rejected int `json:"rejected"`
+	Total    int `json:"total"`
+}
+
 type ApprovePendingChangeInput struct {
 	ID uuid.UUID `path:"id" doc:"Pending change ID"`
 }
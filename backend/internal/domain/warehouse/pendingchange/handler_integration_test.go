@@ -280,6 +280,33 @@ func TestPendingChangeHandler_ListPendingChanges(t *testing.T) {
 			assert.Equal(t, "pending", change.Status)
 		}
 	})
+
+	t.Run("filter by entity type and action with pagination", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/pending-changes?entity_type=item&action=create&page=1&limit=1", nil)
+		req = req.WithContext(addAuthContext(ctx, workspaceID, users.ownerID, "owner"))
+
+		resp := httptest.NewRecorder()
+		api.Adapter().ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusOK, resp.Code)
+
+		var result pendingchange.PendingChangeListResponse
+		err := json.NewDecoder(resp.Body).Decode(&result)
+		require.NoError(t, err)
+		require.Len(t, result.Changes, 1)
+		assert.Equal(t, change1.ID(), result.Changes[0].ID)
+		assert.Equal(t, 1, result.Total)
+	})
+
+	t.Run("rejects invalid requester_id", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/pending-changes?requester_id=not-a-uuid", nil)
+		req = req.WithContext(addAuthContext(ctx, workspaceID, users.ownerID, "owner"))
+
+		resp := httptest.NewRecorder()
+		api.Adapter().ServeHTTP(resp, req)
+
+		assert.Equal(t, http.StatusBadRequest, resp.Code)
+	})
 }
 
 func TestPendingChangeHandler_GetPendingChange(t *testing.T) {
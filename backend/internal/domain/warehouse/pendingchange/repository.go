@@ -2,10 +2,22 @@ package pendingchange
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
+
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
 )
 
+// ListFilters narrows a workspace's pending-change listing. Nil/empty fields
+// don't filter.
+type ListFilters struct {
+	Status      *Status
+	EntityType  string
+	Action      *Action
+	RequesterID *uuid.UUID
+}
+
 // Repository defines the interface for pending change persistence
 type Repository interface {
 	// Save creates or updates a pending change
@@ -14,14 +26,22 @@ type Repository interface {
 	// FindByID retrieves a pending change by its ID, scoped to the workspace
 	FindByID(ctx context.Context, id, workspaceID uuid.UUID) (*PendingChange, error)
 
-	// FindByWorkspace retrieves pending changes for a workspace, optionally filtered by status
-	// If status is nil, returns all pending changes regardless of status
-	FindByWorkspace(ctx context.Context, workspaceID uuid.UUID, status *Status) ([]*PendingChange, error)
+	// FindByWorkspace retrieves a page of a workspace's changes matching filters,
+	// newest first, plus the total number of matches across all pages
+	FindByWorkspace(ctx context.Context, workspaceID uuid.UUID, filters ListFilters, pagination shared.Pagination) ([]*PendingChange, int, error)
 
 	// FindByRequester retrieves pending changes created by a specific user
 	// If status is nil, returns all changes regardless of status
 	FindByRequester(ctx context.Context, requesterID uuid.UUID, status *Status) ([]*PendingChange, error)
 
+	// CountByRequesterStatus counts a requester's changes in a workspace per status.
+	// Statuses with no changes are absent from the map
+	CountByRequesterStatus(ctx context.Context, workspaceID, requesterID uuid.UUID) (map[Status]int, error)
+
+	// ReviewerStats aggregates the changes each reviewer approved or rejected
+	// with reviewed_at in [from, to), busiest reviewer first
+	ReviewerStats(ctx context.Context, workspaceID uuid.UUID, from, to time.Time) ([]ReviewerStat, error)
+
 	// FindByEntity retrieves pending changes for a specific entity, scoped to the workspace
 	FindByEntity(ctx context.Context, workspaceID uuid.UUID, entityType string, entityID uuid.UUID) ([]*PendingChange, error)
 
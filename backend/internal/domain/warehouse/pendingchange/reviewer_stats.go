@@ -0,0 +1,135 @@
+package pendingchange
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/google/uuid"
+
+	appMiddleware "github.com/antti/home-warehouse/go-backend/internal/api/middleware"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/auth/user"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// defaultReviewerStatsWindow is the report period when no from is given.
+const defaultReviewerStatsWindow = 30 * 24 * time.Hour
+
+// ReviewerStat is one reviewer's workload over a period.
+type ReviewerStat struct {
+	ReviewerID uuid.UUID
+	Approved   int
+	Rejected   int
+	// AvgTimeToReview is the mean of reviewed_at - created_at over the
+	// reviewer's changes.
+	AvgTimeToReview time.Duration
+}
+
+// Total is the number of changes the reviewer decided.
+func (s ReviewerStat) Total() int {
+	return s.Approved + s.Rejected
+}
+
+// ReviewerStats returns how many changes each reviewer approved and rejected
+// with reviewed_at in [from, to), and how long they took on average. Used by
+// owners to spread review duties fairly.
+func (s *Service) ReviewerStats(ctx context.Context, workspaceID uuid.UUID, from, to time.Time) ([]ReviewerStat, error) {
+	if !from.Before(to) {
+		return nil, shared.NewFieldError(shared.ErrInvalidInput, "from", "from must be before to")
+	}
+	stats, err := s.repo.ReviewerStats(ctx, workspaceID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to aggregate reviewer stats: %w", err)
+	}
+	return stats, nil
+}
+
+// reviewerStatsReport returns the handler for GET /reports/reviewers
+// (owner/admin only).
+func reviewerStatsReport(svc *Service, userRepo user.Repository) func(context.Context, *ReviewerStatsInput) (*ReviewerStatsOutput, error) {
+	return func(ctx context.Context, input *ReviewerStatsInput) (*ReviewerStatsOutput, error) {
+		workspaceID, authUser, err := requireWorkspaceAndUser(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		canReview, err := svc.canReviewChanges(ctx, authUser.ID, workspaceID)
+		if err != nil {
+			return nil, huma.Error500InternalServerError(msgFailedCheckPermissions)
+		}
+		if !canReview {
+			return nil, huma.Error403Forbidden("only owners and admins can view reviewer stats")
+		}
+
+		to := time.Now()
+		if input.To != "" {
+			if to, err = time.Parse(time.RFC3339, input.To); err != nil {
+				return nil, huma.Error400BadRequest("invalid to format, use RFC3339")
+			}
+		}
+		from := to.Add(-defaultReviewerStatsWindow)
+		if input.From != "" {
+			if from, err = time.Parse(time.RFC3339, input.From); err != nil {
+				return nil, huma.Error400BadRequest("invalid from format, use RFC3339")
+			}
+		}
+
+		stats, err := svc.ReviewerStats(ctx, workspaceID, from, to)
+		if errors.Is(err, shared.ErrInvalidInput) {
+			return nil, appMiddleware.MapDomainError(err)
+		}
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to aggregate reviewer stats")
+		}
+
+		users := newUserLookup(userRepo)
+		reviewers := make([]ReviewerStatResponse, len(stats))
+		for i, st := range stats {
+			reviewers[i] = ReviewerStatResponse{
+				ReviewerID:             st.ReviewerID,
+				Approved:               st.Approved,
+				Rejected:               st.Rejected,
+				Total:                  st.Total(),
+				AvgTimeToReviewSeconds: st.AvgTimeToReview.Seconds(),
+			}
+			// A reviewer whose account is gone still counts; only the name is missing.
+			if u, err := users.find(ctx, st.ReviewerID); err == nil {
+				name := u.FullName()
+				email := u.Email()
+				reviewers[i].ReviewerName = &name
+				reviewers[i].ReviewerEmail = &email
+			}
+		}
+
+		return &ReviewerStatsOutput{
+			Body: ReviewerStatsResponse{From: from, To: to, Reviewers: reviewers},
+		}, nil
+	}
+}
+
+type ReviewerStatsInput struct {
+	From string `query:"from" doc:"Start of the period, inclusive (RFC3339). Defaults to 30 days before to."`
+	To   string `query:"to" doc:"End of the period, exclusive (RFC3339). Defaults to now."`
+}
+
+type ReviewerStatsOutput struct {
+	Body ReviewerStatsResponse
+}
+
+type ReviewerStatsResponse struct {
+	From      time.Time              `json:"from"`
+	To        time.Time              `json:"to"`
+	Reviewers []ReviewerStatResponse `json:"reviewers" doc:"Reviewers with at least one decision in the period, busiest first"`
+}
+
+type ReviewerStatResponse struct {
+	ReviewerID             uuid.UUID `json:"reviewer_id"`
+	ReviewerName           *string   `json:"reviewer_name,omitempty"`
+	ReviewerEmail          *string   `json:"reviewer_email,omitempty"`
+	Approved               int       `json:"approved"`
+	Rejected               int       `json:"rejected"`
+	Total                  int       `json:"total"`
+	AvgTimeToReviewSeconds float64   `json:"avg_time_to_review_seconds" doc:"Mean time from submission to review"`
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
@@ -24,6 +25,7 @@ import (
 	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/wishlist"
 	"github.com/antti/home-warehouse/go-backend/internal/infra/events"
 	"github.com/antti/home-warehouse/go-backend/internal/infra/webpush"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
 )
 
 const (
@@ -52,6 +54,10 @@ func (noopTransactor) WithTx(ctx context.Context, fn func(context.Context) error
 	return fn(ctx)
 }
 
+// approvalPrefKey is the users.notification_preferences key gating the push
+// reviewers get when a member submits a change.
+const approvalPrefKey = "approval_alerts"
+
 // Service handles business logic for pending changes in the approval pipeline.
 // It coordinates between the pending change repository, the per-entity domain
 // services, and the SSE broadcaster to manage the complete approval workflow.
@@ -85,6 +91,7 @@ type Service struct {
 	tx             Transactor
 	broadcaster    *events.Broadcaster
 	pushSender     *webpush.Sender
+	quickAdd       QuickAddApplier
 }
 
 // NewService creates a new pending change service with all required dependencies.
@@ -144,9 +151,25 @@ func (s *Service) SetPushSender(sender *webpush.Sender) {
 	s.pushSender = sender
 }
 
+// QuickAddApplier applies an approved "quick_add" change: a member's quick
+// add of an item together with its first inventory entry. It returns the
+// created item's ID. Implemented by the quickadd service, which owns the
+// payload format.
+type QuickAddApplier interface {
+	ApplyQuickAdd(ctx context.Context, workspaceID uuid.UUID, payload json.RawMessage) (uuid.UUID, error)
+}
+
+// SetQuickAddApplier wires approval of quick add changes (optional). Without
+// it approving one fails.
+func (s *Service) SetQuickAddApplier(applier QuickAddApplier) {
+	s.quickAdd = applier
+}
+
 // CreatePendingChange creates a new pending change request and stores it in the queue.
 // This is called by the approval middleware when a member attempts to create, update, or delete an entity.
 // The change is validated, stored in the database, and an SSE event is published to notify admins.
+// Owners and admins other than the requester also get a push notification unless they have
+// turned off approval alerts.
 //
 // Returns the created PendingChange entity or an error if validation/storage fails.
 func (s *Service) CreatePendingChange(
@@ -174,15 +197,19 @@ func (s *Service) CreatePendingChange(
 		return nil, fmt.Errorf("failed to save pending change: %w", err)
 	}
 
+	if s.broadcaster == nil && !s.pushEnabled() {
+		return change, nil
+	}
+
+	// Get requester user info
+	var requesterName, requesterEmail string
+	if requesterUser, err := s.userRepo.FindByID(ctx, requesterID); err == nil {
+		requesterName = requesterUser.FullName()
+		requesterEmail = requesterUser.Email()
+	}
+
 	// Publish SSE event for pending change creation
 	if s.broadcaster != nil {
-		// Get requester user info
-		var requesterName, requesterEmail string
-		if requesterUser, err := s.userRepo.FindByID(ctx, requesterID); err == nil {
-			requesterName = requesterUser.FullName()
-			requesterEmail = requesterUser.Email()
-		}
-
 		s.broadcaster.Publish(workspaceID, events.Event{
 			Type:       "pendingchange.created",
 			EntityID:   change.ID().String(),
@@ -201,9 +228,80 @@ func (s *Service) CreatePendingChange(
 		})
 	}
 
+	s.notifyReviewers(ctx, change, requesterName)
+
 	return change, nil
 }
 
+// notifyReviewers sends a best-effort push notification for a new change to
+// the workspace owners and admins (see reviewerRecipients).
+func (s *Service) notifyReviewers(ctx context.Context, change *PendingChange, requesterName string) {
+	if !s.pushEnabled() {
+		return
+	}
+
+	recipients, err := s.reviewerRecipients(ctx, change.WorkspaceID(), change.RequesterID())
+	if err != nil {
+		log.Printf("Failed to find reviewers for pending change %s: %v", change.ID(), err)
+		return
+	}
+	if len(recipients) == 0 {
+		return
+	}
+
+	if requesterName == "" {
+		requesterName = "A member"
+	}
+	message := webpush.PushMessage{
+		Title: "Change Awaiting Approval",
+		Body:  fmt.Sprintf("%s requested a %s %s", requesterName, change.EntityType(), change.Action()),
+		Icon:  "/icon-192.png",
+		Badge: "/favicon-32x32.png",
+		Tag:   "change-pending",
+		URL:   "/dashboard/approvals",
+		Data: map[string]interface{}{
+			"type":         "pending_change_created",
+			"change_id":    change.ID().String(),
+			"entity_type":  change.EntityType(),
+			"action":       string(change.Action()),
+			"requester_id": change.RequesterID().String(),
+		},
+	}
+	if err := s.pushSender.SendToUsers(ctx, recipients, message); err != nil {
+		log.Printf("Failed to send push notification for pending change %s: %v", change.ID(), err)
+	}
+}
+
+// reviewerRecipients returns the owners and admins of the workspace, other
+// than the requester, who have not turned off approvalPrefKey.
+func (s *Service) reviewerRecipients(ctx context.Context, workspaceID, requesterID uuid.UUID) ([]uuid.UUID, error) {
+	members, err := s.memberRepo.ListByWorkspace(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	var recipients []uuid.UUID
+	for _, m := range members {
+		if !m.CanManageMembers() || m.UserID() == requesterID {
+			continue
+		}
+		u, err := s.userRepo.FindByID(ctx, m.UserID())
+		if err != nil {
+			log.Printf("Failed to load notification preferences for user %s: %v", m.UserID(), err)
+			continue
+		}
+		if !u.NotificationEnabled(approvalPrefKey) {
+			continue
+		}
+		recipients = append(recipients, m.UserID())
+	}
+	return recipients, nil
+}
+
+func (s *Service) pushEnabled() bool {
+	return s.pushSender != nil && s.pushSender.IsEnabled()
+}
+
 // ApproveChange approves a pending change and applies it to the database.
 // This operation:
 //  1. Verifies the reviewer has admin/owner permissions
@@ -314,10 +412,16 @@ func (s *Service) publishEntityEvent(change *PendingChange, reviewerID, appliedI
 		return
 	}
 
+	// A quick add creates an item (and its inventory); announce the item.
+	entityType := change.EntityType()
+	if entityType == "quick_add" {
+		entityType = "item"
+	}
+
 	s.broadcaster.Publish(change.WorkspaceID(), events.Event{
-		Type:       change.EntityType() + "." + suffix,
+		Type:       entityType + "." + suffix,
 		EntityID:   appliedID.String(),
-		EntityType: change.EntityType(),
+		EntityType: entityType,
 		UserID:     reviewerID, // the actor at apply time
 		Data: map[string]any{
 			"id":           appliedID.String(),
@@ -369,7 +473,7 @@ func (s *Service) notifyApproval(ctx context.Context, change *PendingChange, rev
 	}
 
 	// Send push notification to the requester
-	if s.pushSender != nil && s.pushSender.IsEnabled() {
+	if s.pushEnabled() {
 		message := webpush.PushMessage{
 			Title: "Change Approved",
 			Body:  fmt.Sprintf("Your %s %s has been approved by %s", change.EntityType(), change.Action(), reviewerName),
@@ -461,7 +565,7 @@ func (s *Service) RejectChange(ctx context.Context, changeID, workspaceID uuid.U
 	}
 
 	// Send push notification to the requester
-	if s.pushSender != nil && s.pushSender.IsEnabled() {
+	if s.pushEnabled() {
 		message := webpush.PushMessage{
 			Title: "Change Rejected",
 			Body:  fmt.Sprintf("Your %s %s has been rejected by %s: %s", change.EntityType(), change.Action(), reviewerName, reason),
@@ -485,16 +589,43 @@ func (s *Service) RejectChange(ctx context.Context, changeID, workspaceID uuid.U
 	return nil
 }
 
-// ListPendingForWorkspace retrieves all pending (not yet reviewed) changes for a workspace.
-// This is used to populate the approval queue for admins/owners.
-// Returns a list of changes awaiting review or an error if the query fails.
-func (s *Service) ListPendingForWorkspace(ctx context.Context, workspaceID uuid.UUID) ([]*PendingChange, error) {
+// ListPendingForWorkspace retrieves a page of pending (not yet reviewed) changes for a workspace.
+// This is used to populate the approval queue for admins/owners. Any status in filters is
+// overridden; the other filters apply as given. Returns the page and the total number of matches.
+func (s *Service) ListPendingForWorkspace(ctx context.Context, workspaceID uuid.UUID, filters ListFilters, pagination shared.Pagination) ([]*PendingChange, int, error) {
 	status := StatusPending
-	changes, err := s.repo.FindByWorkspace(ctx, workspaceID, &status)
+	filters.Status = &status
+	changes, total, err := s.repo.FindByWorkspace(ctx, workspaceID, filters, pagination)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list pending changes: %w", err)
+	}
+	return changes, total, nil
+}
+
+// ChangeCounts are the number of a user's submitted changes in each status.
+type ChangeCounts struct {
+	Pending  int
+	Approved int
+	Rejected int
+}
+
+// Total is the number of changes across all statuses.
+func (c ChangeCounts) Total() int {
+	return c.Pending + c.Approved + c.Rejected
+}
+
+// MyChangeCounts returns how many of the requester's changes in the workspace are
+// pending, approved and rejected. Used for badge counts without fetching the lists.
+func (s *Service) MyChangeCounts(ctx context.Context, workspaceID, requesterID uuid.UUID) (ChangeCounts, error) {
+	byStatus, err := s.repo.CountByRequesterStatus(ctx, workspaceID, requesterID)
 	if err != nil {
-		return nil, fmt.Errorf("failed to list pending changes: %w", err)
+		return ChangeCounts{}, fmt.Errorf("failed to count changes: %w", err)
 	}
-	return changes, nil
+	return ChangeCounts{
+		Pending:  byStatus[StatusPending],
+		Approved: byStatus[StatusApproved],
+		Rejected: byStatus[StatusRejected],
+	}, nil
 }
 
 // canReviewChanges checks if a user has permission to review changes (owner or admin role)
@@ -508,28 +639,9 @@ func (s *Service) canReviewChanges(ctx context.Context, userID uuid.UUID, worksp
 	return m.CanManageMembers(), nil
 }
 
-// isValidEntityType checks if the entity type is supported.
-//
-// This set must stay in sync with the approval middleware's extractEntityType
-// (internal/api/middleware/approval_middleware.go). Entity types not listed here
-// are deliberately NOT routed through the approval pipeline — see
-// docs/APPROVAL_PIPELINE.md ("Entity coverage and deliberate exclusions") for the
-// rationale on which member-mutable resources are gated and which are applied
-// atomically with their parent and therefore intentionally excluded.
+// isValidEntityType checks if the entity type is supported (see entityTypes).
 func (s *Service) isValidEntityType(entityType string) bool {
-	validTypes := map[string]bool{
-		"item":        true,
-		"category":    true,
-		"location":    true,
-		"container":   true,
-		"inventory":   true,
-		"borrower":    true,
-		"loan":        true,
-		"label":       true,
-		"maintenance": true,
-		"wishlist":    true,
-	}
-	return validTypes[entityType]
+	return slices.Contains(entityTypes, entityType)
 }
 
 // applyChange applies the approved change to the actual entity through the
@@ -559,11 +671,30 @@ func (s *Service) applyChange(ctx context.Context, change *PendingChange) (uuid.
 		return s.applyMaintenanceChange(ctx, change)
 	case "wishlist":
 		return s.applyWishlistChange(ctx, change)
+	case "quick_add":
+		return s.applyQuickAddChange(ctx, change)
 	default:
 		return uuid.Nil, ErrInvalidEntityType
 	}
 }
 
+// applyQuickAddChange creates the item and inventory entry of a member's quick
+// add. The photo is not part of the change; the member uploads it once the
+// item exists.
+func (s *Service) applyQuickAddChange(ctx context.Context, change *PendingChange) (uuid.UUID, error) {
+	if change.Action() != ActionCreate {
+		return uuid.Nil, fmt.Errorf(msgUnsupportedAction, change.Action())
+	}
+	if s.quickAdd == nil {
+		return uuid.Nil, errors.New("quick add is not configured")
+	}
+	itemID, err := s.quickAdd.ApplyQuickAdd(ctx, change.WorkspaceID(), change.Payload())
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("failed to apply quick add: %w", err)
+	}
+	return itemID, nil
+}
+
 // applyItemChange applies changes to items through item.Service, applying the
 // full field set the member submitted.
 func (s *Service) applyItemChange(ctx context.Context, change *PendingChange) (uuid.UUID, error) {
@@ -589,6 +720,7 @@ func (s *Service) applyItemChange(ctx context.Context, change *PendingChange) (u
 			ObsidianVaultPath *string    `json:"obsidian_vault_path"`
 			ObsidianNotePath  *string    `json:"obsidian_note_path"`
 			NeedsReview       *bool      `json:"needs_review"`
+			Loanable          *bool      `json:"loanable"`
 		}
 		if err := json.Unmarshal(change.Payload(), &p); err != nil {
 			return uuid.Nil, fmt.Errorf("failed to unmarshal item payload: %w", err)
@@ -614,6 +746,7 @@ func (s *Service) applyItemChange(ctx context.Context, change *PendingChange) (u
 			ObsidianVaultPath: p.ObsidianVaultPath,
 			ObsidianNotePath:  p.ObsidianNotePath,
 			NeedsReview:       p.NeedsReview,
+			Loanable:          p.Loanable,
 		})
 		if err != nil {
 			return uuid.Nil, fmt.Errorf("failed to create item: %w", err)
@@ -830,8 +963,9 @@ func (s *Service) applyContainerChange(ctx context.Context, change *PendingChang
 }
 
 // applyInventoryChange applies changes to inventory through inventory.Service
-// (create/update). Delete goes through the repository because the inventory
-// domain exposes no service-level Delete.
+// (create/update, or a transfer for an update carrying an
+// InventoryTransferPayload). Delete goes through the repository because the
+// inventory domain exposes no service-level Delete.
 func (s *Service) applyInventoryChange(ctx context.Context, change *PendingChange) (uuid.UUID, error) {
 	switch change.Action() {
 	case ActionCreate:
@@ -876,6 +1010,13 @@ func (s *Service) applyInventoryChange(ctx context.Context, change *PendingChang
 		if change.EntityID() == nil {
 			return uuid.Nil, errors.New(msgEntityIDRequiredForUpdateAction)
 		}
+		var transfer InventoryTransferPayload
+		if err := json.Unmarshal(change.Payload(), &transfer); err != nil {
+			return uuid.Nil, fmt.Errorf(msgFailedToUnmarshalUpdatePayload, err)
+		}
+		if transfer.Transfer != nil {
+			return s.applyInventoryTransfer(ctx, change, *transfer.Transfer)
+		}
 		var p struct {
 			LocationID      uuid.UUID  `json:"location_id"`
 			ContainerID     *uuid.UUID `json:"container_id"`
@@ -921,25 +1062,36 @@ func (s *Service) applyInventoryChange(ctx context.Context, change *PendingChang
 	}
 }
 
+// applyInventoryTransfer moves the inventory entry through
+// inventory.Service.Move, which also records the movement.
+func (s *Service) applyInventoryTransfer(ctx context.Context, change *PendingChange, transfer InventoryTransfer) (uuid.UUID, error) {
+	if _, err := s.inventorySvc.Move(ctx, *change.EntityID(), change.WorkspaceID(), transfer.LocationID, transfer.ContainerID); err != nil {
+		return uuid.Nil, fmt.Errorf("failed to transfer inventory: %w", err)
+	}
+	return *change.EntityID(), nil
+}
+
 // applyBorrowerChange applies changes to borrowers through borrower.Service.
 func (s *Service) applyBorrowerChange(ctx context.Context, change *PendingChange) (uuid.UUID, error) {
 	switch change.Action() {
 	case ActionCreate:
 		var p struct {
-			Name  string  `json:"name"`
-			Email *string `json:"email"`
-			Phone *string `json:"phone"`
-			Notes *string `json:"notes"`
+			Name            string                   `json:"name"`
+			Email           *string                  `json:"email"`
+			Phone           *string                  `json:"phone"`
+			Notes           *string                  `json:"notes"`
+			ReminderChannel borrower.ReminderChannel `json:"reminder_channel"`
 		}
 		if err := json.Unmarshal(change.Payload(), &p); err != nil {
 			return uuid.Nil, fmt.Errorf("failed to unmarshal borrower payload: %w", err)
 		}
 		created, err := s.borrowerSvc.Create(ctx, borrower.CreateInput{
-			WorkspaceID: change.WorkspaceID(),
-			Name:        p.Name,
-			Email:       p.Email,
-			Phone:       p.Phone,
-			Notes:       p.Notes,
+			WorkspaceID:     change.WorkspaceID(),
+			Name:            p.Name,
+			Email:           p.Email,
+			Phone:           p.Phone,
+			Notes:           p.Notes,
+			ReminderChannel: p.ReminderChannel,
 		})
 		if err != nil {
 			return uuid.Nil, fmt.Errorf("failed to create borrower: %w", err)
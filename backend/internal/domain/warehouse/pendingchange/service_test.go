@@ -46,12 +46,12 @@ func (m *MockPendingChangeRepository) FindByID(ctx context.Context, id, workspac
 	return args.Get(0).(*PendingChange), args.Error(1)
 }
 
-func (m *MockPendingChangeRepository) FindByWorkspace(ctx context.Context, workspaceID uuid.UUID, status *Status) ([]*PendingChange, error) {
-	args := m.Called(ctx, workspaceID, status)
+func (m *MockPendingChangeRepository) FindByWorkspace(ctx context.Context, workspaceID uuid.UUID, filters ListFilters, pagination shared.Pagination) ([]*PendingChange, int, error) {
+	args := m.Called(ctx, workspaceID, filters, pagination)
 	if args.Get(0) == nil {
-		return nil, args.Error(1)
+		return nil, args.Int(1), args.Error(2)
 	}
-	return args.Get(0).([]*PendingChange), args.Error(1)
+	return args.Get(0).([]*PendingChange), args.Int(1), args.Error(2)
 }
 
 func (m *MockPendingChangeRepository) FindByRequester(ctx context.Context, requesterID uuid.UUID, status *Status) ([]*PendingChange, error) {
@@ -70,6 +70,22 @@ func (m *MockPendingChangeRepository) FindByEntity(ctx context.Context, workspac
 	return args.Get(0).([]*PendingChange), args.Error(1)
 }
 
+func (m *MockPendingChangeRepository) CountByRequesterStatus(ctx context.Context, workspaceID, requesterID uuid.UUID) (map[Status]int, error) {
+	args := m.Called(ctx, workspaceID, requesterID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(map[Status]int), args.Error(1)
+}
+
+func (m *MockPendingChangeRepository) ReviewerStats(ctx context.Context, workspaceID uuid.UUID, from, to time.Time) ([]ReviewerStat, error) {
+	args := m.Called(ctx, workspaceID, from, to)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]ReviewerStat), args.Error(1)
+}
+
 func (m *MockPendingChangeRepository) Delete(ctx context.Context, id, workspaceID uuid.UUID) error {
 	return m.Called(ctx, id).Error(0)
 }
@@ -217,12 +233,24 @@ func (m *MockItemService) Restore(ctx context.Context, id, workspaceID uuid.UUID
 func (m *MockItemService) Search(ctx context.Context, workspaceID uuid.UUID, query string, limit int) ([]*item.Item, error) {
 	return nil, nil
 }
+func (m *MockItemService) GetByIDs(ctx context.Context, workspaceID uuid.UUID, ids []uuid.UUID) ([]*item.Item, error) {
+	return nil, nil
+}
 func (m *MockItemService) ListByCategory(ctx context.Context, workspaceID, categoryID uuid.UUID, pagination shared.Pagination) ([]*item.Item, error) {
 	return nil, nil
 }
-func (m *MockItemService) LookupByBarcode(ctx context.Context, workspaceID uuid.UUID, code string) (*item.Item, error) {
+func (m *MockItemService) LookupByBarcode(ctx context.Context, workspaceID uuid.UUID, code string) (*item.Item, *item.Identifier, error) {
+	return nil, nil, nil
+}
+func (m *MockItemService) ListIdentifiers(ctx context.Context, workspaceID, itemID uuid.UUID) ([]*item.Identifier, error) {
+	return nil, nil
+}
+func (m *MockItemService) AddIdentifier(ctx context.Context, workspaceID, itemID uuid.UUID, identifierType item.IdentifierType, value string) (*item.Identifier, error) {
 	return nil, nil
 }
+func (m *MockItemService) RemoveIdentifier(ctx context.Context, workspaceID, itemID, identifierID uuid.UUID) error {
+	return nil
+}
 func (m *MockItemService) AttachLabel(ctx context.Context, itemID, labelID, workspaceID uuid.UUID) error {
 	return nil
 }
@@ -232,6 +260,49 @@ func (m *MockItemService) DetachLabel(ctx context.Context, itemID, labelID, work
 func (m *MockItemService) GetItemLabels(ctx context.Context, itemID, workspaceID uuid.UUID) ([]uuid.UUID, error) {
 	return nil, nil
 }
+func (m *MockItemService) DuplicateItem(ctx context.Context, workspaceID, itemID uuid.UUID) (*item.Item, error) {
+	return nil, nil
+}
+
+func (m *MockItemService) ReassignCategory(ctx context.Context, workspaceID uuid.UUID, itemIDs []uuid.UUID, newCategoryID *uuid.UUID) ([]item.ReassignedItem, error) {
+	return nil, nil
+}
+
+func (m *MockItemService) RecordView(ctx context.Context, workspaceID, userID, itemID uuid.UUID) error {
+	return nil
+}
+
+func (m *MockItemService) ListRecentlyViewed(ctx context.Context, workspaceID, userID uuid.UUID, limit int) ([]*item.Item, error) {
+	return nil, nil
+}
+
+func (m *MockItemService) FindSimilarItems(ctx context.Context, workspaceID uuid.UUID, threshold float64) ([]item.SimilarPair, error) {
+	return nil, nil
+}
+
+func (m *MockItemService) MergeItems(ctx context.Context, workspaceID, keepID, mergeID uuid.UUID, sku string) (*item.MergeResult, error) {
+	return nil, nil
+}
+
+func (m *MockItemService) ListCategoryRules(ctx context.Context, workspaceID uuid.UUID) ([]*item.CategoryRule, error) {
+	return nil, nil
+}
+
+func (m *MockItemService) CreateCategoryRule(ctx context.Context, workspaceID uuid.UUID, input item.CategoryRuleInput) (*item.CategoryRule, error) {
+	return nil, nil
+}
+
+func (m *MockItemService) UpdateCategoryRule(ctx context.Context, workspaceID, id uuid.UUID, input item.CategoryRuleInput) (*item.CategoryRule, error) {
+	return nil, nil
+}
+
+func (m *MockItemService) DeleteCategoryRule(ctx context.Context, workspaceID, id uuid.UUID) error {
+	return nil
+}
+
+func (m *MockItemService) ReorderCategoryRules(ctx context.Context, workspaceID uuid.UUID, ruleIDs []uuid.UUID) ([]*item.CategoryRule, error) {
+	return nil, nil
+}
 
 type MockCategoryService struct{ mock.Mock }
 
@@ -274,6 +345,10 @@ func (m *MockCategoryService) GetBreadcrumb(ctx context.Context, categoryID, wor
 	return nil, nil
 }
 
+func (m *MockCategoryService) MergeCategories(ctx context.Context, workspaceID, keepID, mergeID uuid.UUID) (*category.MergeResult, error) {
+	return nil, nil
+}
+
 type MockLocationService struct{ mock.Mock }
 
 func (m *MockLocationService) Create(ctx context.Context, input location.CreateInput) (*location.Location, error) {
@@ -311,6 +386,9 @@ func (m *MockLocationService) GetBreadcrumb(ctx context.Context, locationID, wor
 func (m *MockLocationService) Search(ctx context.Context, workspaceID uuid.UUID, query string, limit int) ([]*location.Location, error) {
 	return nil, nil
 }
+func (m *MockLocationService) EvacuateLocation(ctx context.Context, workspaceID, locationID, targetLocationID uuid.UUID, includeContainers bool) (*location.EvacuateResult, error) {
+	return nil, nil
+}
 
 type MockContainerService struct{ mock.Mock }
 
@@ -346,6 +424,9 @@ func (m *MockContainerService) Restore(ctx context.Context, id, workspaceID uuid
 func (m *MockContainerService) Search(ctx context.Context, workspaceID uuid.UUID, query string, limit int) ([]*container.Container, error) {
 	return nil, nil
 }
+func (m *MockContainerService) ContainerContents(ctx context.Context, workspaceID, containerID uuid.UUID, pagination shared.Pagination) (*container.Contents, error) {
+	return nil, nil
+}
 
 type MockInventoryService struct{ mock.Mock }
 
@@ -373,7 +454,11 @@ func (m *MockInventoryService) UpdateQuantity(ctx context.Context, id, workspace
 	return nil, nil
 }
 func (m *MockInventoryService) Move(ctx context.Context, id, workspaceID, locationID uuid.UUID, containerID *uuid.UUID) (*inventory.Inventory, error) {
-	return nil, nil
+	args := m.Called(ctx, id, workspaceID, locationID, containerID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*inventory.Inventory), args.Error(1)
 }
 func (m *MockInventoryService) Archive(ctx context.Context, id, workspaceID uuid.UUID) error {
 	return nil
@@ -381,7 +466,10 @@ func (m *MockInventoryService) Archive(ctx context.Context, id, workspaceID uuid
 func (m *MockInventoryService) Restore(ctx context.Context, id, workspaceID uuid.UUID) error {
 	return nil
 }
-func (m *MockInventoryService) List(ctx context.Context, workspaceID uuid.UUID, pagination shared.Pagination) ([]*inventory.Inventory, int, error) {
+func (m *MockInventoryService) List(ctx context.Context, workspaceID uuid.UUID, pagination shared.Pagination, filters inventory.ListFilters) ([]*inventory.Inventory, int, error) {
+	return nil, 0, nil
+}
+func (m *MockInventoryService) ListWithItemDetails(ctx context.Context, workspaceID uuid.UUID, pagination shared.Pagination, filters inventory.ListFilters) ([]*inventory.InventoryWithDetails, int, error) {
 	return nil, 0, nil
 }
 func (m *MockInventoryService) ListByItem(ctx context.Context, workspaceID, itemID uuid.UUID) ([]*inventory.Inventory, error) {
@@ -399,9 +487,72 @@ func (m *MockInventoryService) GetAvailable(ctx context.Context, workspaceID, it
 func (m *MockInventoryService) GetTotalQuantity(ctx context.Context, workspaceID, itemID uuid.UUID) (int, error) {
 	return 0, nil
 }
+func (m *MockInventoryService) CheckLowStock(ctx context.Context, workspaceID, itemID uuid.UUID, previousQuantity, newQuantity int) (*inventory.LowStockAlert, error) {
+	return nil, nil
+}
 func (m *MockInventoryService) ListExpiring(ctx context.Context, workspaceID uuid.UUID, withinDays int) ([]inventory.ExpiringInventory, error) {
 	return nil, nil
 }
+func (m *MockInventoryService) ListStockLevels(ctx context.Context, workspaceID, itemID uuid.UUID) ([]inventory.LocationStockLevel, error) {
+	return nil, nil
+}
+func (m *MockInventoryService) SetStockLevel(ctx context.Context, workspaceID, itemID, locationID uuid.UUID, minStockLevel int) (*inventory.LocationStockLevel, error) {
+	return nil, nil
+}
+func (m *MockInventoryService) DeleteStockLevel(ctx context.Context, workspaceID, itemID, locationID uuid.UUID) error {
+	return nil
+}
+func (m *MockInventoryService) ListLowStock(ctx context.Context, workspaceID uuid.UUID) ([]inventory.LowStockEntry, error) {
+	return nil, nil
+}
+func (m *MockInventoryService) BulkUpdateStatus(ctx context.Context, workspaceID uuid.UUID, input inventory.BulkStatusInput) ([]inventory.BulkStatusResult, error) {
+	return nil, nil
+}
+
+func (m *MockInventoryService) FIFOSuggestion(ctx context.Context, workspaceID, itemID uuid.UUID) (*inventory.FIFOSuggestion, error) {
+	return nil, nil
+}
+
+func (m *MockInventoryService) ConsumeInventory(ctx context.Context, workspaceID, inventoryID uuid.UUID, quantity int, note *string, userID *uuid.UUID) (*inventory.Inventory, error) {
+	return nil, nil
+}
+
+func (m *MockInventoryService) ConsumeItem(ctx context.Context, workspaceID, itemID uuid.UUID, quantity int, note *string, userID *uuid.UUID) (*inventory.Inventory, error) {
+	return nil, nil
+}
+func (m *MockInventoryService) NeedsAttention(ctx context.Context, workspaceID uuid.UUID) (*inventory.NeedsAttention, error) {
+	return nil, nil
+}
+func (m *MockInventoryService) GetSettings(ctx context.Context, workspaceID uuid.UUID) (*inventory.Settings, error) {
+	return &inventory.Settings{}, nil
+}
+func (m *MockInventoryService) UpdateSettings(ctx context.Context, workspaceID uuid.UUID, settings inventory.Settings) (*inventory.Settings, error) {
+	return nil, nil
+}
+func (m *MockInventoryService) ListCategoryDefaults(ctx context.Context, workspaceID uuid.UUID) ([]*inventory.CategoryDefaults, error) {
+	return nil, nil
+}
+func (m *MockInventoryService) GetCategoryDefaults(ctx context.Context, workspaceID, categoryID uuid.UUID) (*inventory.CategoryDefaults, error) {
+	return nil, nil
+}
+func (m *MockInventoryService) SetCategoryDefaults(ctx context.Context, workspaceID uuid.UUID, defaults inventory.CategoryDefaults) (*inventory.CategoryDefaults, error) {
+	return nil, nil
+}
+func (m *MockInventoryService) ClearCategoryDefaults(ctx context.Context, workspaceID, categoryID uuid.UUID) error {
+	return nil
+}
+func (m *MockInventoryService) CreateSnapshot(ctx context.Context, workspaceID uuid.UUID, label string) (*inventory.Snapshot, error) {
+	return nil, nil
+}
+func (m *MockInventoryService) ListSnapshots(ctx context.Context, workspaceID uuid.UUID) ([]*inventory.Snapshot, error) {
+	return nil, nil
+}
+func (m *MockInventoryService) GetSnapshot(ctx context.Context, workspaceID, id uuid.UUID) (*inventory.Snapshot, error) {
+	return nil, nil
+}
+func (m *MockInventoryService) DiffSnapshots(ctx context.Context, workspaceID, fromID, toID uuid.UUID) (*inventory.SnapshotDiff, error) {
+	return nil, nil
+}
 
 type MockBorrowerService struct{ mock.Mock }
 
@@ -457,8 +608,8 @@ func (m *MockLoanService) Update(ctx context.Context, id, workspaceID uuid.UUID,
 func (m *MockLoanService) GetByID(ctx context.Context, id, workspaceID uuid.UUID) (*loan.Loan, error) {
 	return nil, nil
 }
-func (m *MockLoanService) Return(ctx context.Context, id, workspaceID uuid.UUID) (*loan.Loan, error) {
-	return nil, nil
+func (m *MockLoanService) Return(ctx context.Context, id, workspaceID uuid.UUID, input loan.ReturnInput) (*loan.Loan, *loan.ConditionChange, error) {
+	return nil, nil, nil
 }
 func (m *MockLoanService) ExtendDueDate(ctx context.Context, id, workspaceID uuid.UUID, newDueDate time.Time) (*loan.Loan, error) {
 	return nil, nil
@@ -481,6 +632,17 @@ func (m *MockLoanService) GetActiveLoans(ctx context.Context, workspaceID uuid.U
 func (m *MockLoanService) GetOverdueLoans(ctx context.Context, workspaceID uuid.UUID) ([]*loan.Loan, error) {
 	return nil, nil
 }
+func (m *MockLoanService) BorrowerLoanSummary(ctx context.Context, workspaceID, borrowerID uuid.UUID) (*loan.BorrowerLoanSummary, error) {
+	return nil, nil
+}
+
+func (m *MockLoanService) GetSettings(ctx context.Context, workspaceID uuid.UUID) (*loan.Settings, error) {
+	return nil, nil
+}
+
+func (m *MockLoanService) UpdateSettings(ctx context.Context, workspaceID uuid.UUID, settings loan.Settings) (*loan.Settings, error) {
+	return nil, nil
+}
 
 type MockLabelService struct{ mock.Mock }
 
@@ -571,6 +733,13 @@ func (m *MockWishlistService) List(ctx context.Context, workspaceID uuid.UUID, s
 	return nil, 0, nil
 }
 
+type MockQuickAddApplier struct{ mock.Mock }
+
+func (m *MockQuickAddApplier) ApplyQuickAdd(ctx context.Context, workspaceID uuid.UUID, payload json.RawMessage) (uuid.UUID, error) {
+	args := m.Called(ctx, workspaceID, payload)
+	return args.Get(0).(uuid.UUID), args.Error(1)
+}
+
 // Repository mocks used only for the inventory/loan delete paths.
 
 type MockInventoryRepository struct{ mock.Mock }
@@ -585,7 +754,10 @@ func (m *MockInventoryRepository) FindByID(ctx context.Context, id, workspaceID
 	}
 	return args.Get(0).(*inventory.Inventory), args.Error(1)
 }
-func (m *MockInventoryRepository) List(ctx context.Context, workspaceID uuid.UUID, pagination shared.Pagination) ([]*inventory.Inventory, int, error) {
+func (m *MockInventoryRepository) List(ctx context.Context, workspaceID uuid.UUID, pagination shared.Pagination, filters inventory.ListFilters) ([]*inventory.Inventory, int, error) {
+	return nil, 0, nil
+}
+func (m *MockInventoryRepository) ListWithItemDetails(ctx context.Context, workspaceID uuid.UUID, pagination shared.Pagination, filters inventory.ListFilters) ([]*inventory.InventoryWithDetails, int, error) {
 	return nil, 0, nil
 }
 func (m *MockInventoryRepository) FindByItem(ctx context.Context, workspaceID, itemID uuid.UUID) ([]*inventory.Inventory, error) {
@@ -597,7 +769,7 @@ func (m *MockInventoryRepository) FindByLocation(ctx context.Context, workspaceI
 func (m *MockInventoryRepository) FindByContainer(ctx context.Context, workspaceID, containerID uuid.UUID) ([]*inventory.Inventory, error) {
 	return nil, nil
 }
-func (m *MockInventoryRepository) FindAvailable(ctx context.Context, workspaceID, itemID uuid.UUID) ([]*inventory.Inventory, error) {
+func (m *MockInventoryRepository) FindAvailable(ctx context.Context, workspaceID, itemID uuid.UUID, includeReserved bool) ([]*inventory.Inventory, error) {
 	return nil, nil
 }
 func (m *MockInventoryRepository) FindExpiring(ctx context.Context, workspaceID uuid.UUID, withinDays int) ([]inventory.ExpiringInventory, error) {
@@ -606,6 +778,14 @@ func (m *MockInventoryRepository) FindExpiring(ctx context.Context, workspaceID
 func (m *MockInventoryRepository) GetTotalQuantity(ctx context.Context, workspaceID, itemID uuid.UUID) (int, error) {
 	return 0, nil
 }
+
+func (m *MockInventoryRepository) AddQuantity(ctx context.Context, id, workspaceID uuid.UUID, amount int) (*inventory.Inventory, error) {
+	args := m.Called(ctx, id, workspaceID, amount)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*inventory.Inventory), args.Error(1)
+}
 func (m *MockInventoryRepository) Delete(ctx context.Context, id, workspaceID uuid.UUID) error {
 	return m.Called(ctx, id).Error(0)
 }
@@ -673,6 +853,7 @@ type testMocks struct {
 	labelSvc       *MockLabelService
 	maintenanceSvc *MockMaintenanceService
 	wishlistSvc    *MockWishlistService
+	quickAdd       *MockQuickAddApplier
 	// broadcaster is nil unless a test opts in (see TestApproveChangePublishesEntityEvent).
 	broadcaster *events.Broadcaster
 }
@@ -694,11 +875,12 @@ func newMocks() *testMocks {
 		labelSvc:       new(MockLabelService),
 		maintenanceSvc: new(MockMaintenanceService),
 		wishlistSvc:    new(MockWishlistService),
+		quickAdd:       new(MockQuickAddApplier),
 	}
 }
 
 func (tm *testMocks) service() *Service {
-	return NewService(
+	svc := NewService(
 		tm.repo,
 		tm.memberRepo,
 		tm.userRepo,
@@ -717,6 +899,8 @@ func (tm *testMocks) service() *Service {
 		nil, // Transactor: nil -> noopTransactor (synchronous, no real tx in unit tests)
 		tm.broadcaster,
 	)
+	svc.SetQuickAddApplier(tm.quickAdd)
+	return svc
 }
 
 func ownerMember(workspaceID, userID uuid.UUID) *member.Member {
@@ -764,6 +948,29 @@ func TestCreatePendingChange(t *testing.T) {
 		tm.repo.AssertExpectations(t)
 	})
 
+	t.Run("broadcasts pendingchange.created with the requester", func(t *testing.T) {
+		capture := testutil.NewEventCapture(workspaceID, requesterID)
+		capture.Start()
+		defer capture.Stop()
+
+		tm := newMocks()
+		tm.broadcaster = capture.GetBroadcaster()
+		requester, _ := user.NewUser("requester@test.com", "Requester User", "password123")
+		tm.userRepo.On("FindByID", ctx, requesterID).Return(requester, nil)
+		tm.repo.On("Save", ctx, mock.AnythingOfType("*pendingchange.PendingChange")).Return(nil)
+
+		change, err := tm.service().CreatePendingChange(ctx, workspaceID, requesterID, "item", nil, ActionCreate, json.RawMessage(`{"name":"x"}`))
+		assert.NoError(t, err)
+
+		assert.True(t, capture.WaitForEvents(1, time.Second))
+		event := capture.GetAllEvents()[0]
+		assert.Equal(t, "pendingchange.created", event.Type)
+		assert.Equal(t, change.ID().String(), event.EntityID)
+		assert.Equal(t, requesterID, event.UserID)
+		assert.Equal(t, "item", event.Data["entity_type"])
+		assert.Equal(t, "Requester User", event.Data["requester_name"])
+	})
+
 	t.Run("rejects unsupported entity type", func(t *testing.T) {
 		tm := newMocks()
 		_, err := tm.service().CreatePendingChange(ctx, workspaceID, requesterID, "photo", nil, ActionCreate, json.RawMessage(`{}`))
@@ -778,6 +985,44 @@ func TestCreatePendingChange(t *testing.T) {
 	})
 }
 
+func TestReviewerRecipients(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+	requesterID, ownerID, adminID, mutedID, memberID := uuid.New(), uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	t.Run("owners and admins with approval alerts on, except the requester", func(t *testing.T) {
+		tm := newMocks()
+		tm.memberRepo.On("ListByWorkspace", ctx, workspaceID).Return([]*member.Member{
+			adminMember(workspaceID, requesterID),
+			ownerMember(workspaceID, ownerID),
+			adminMember(workspaceID, adminID),
+			adminMember(workspaceID, mutedID),
+			memberMember(workspaceID, memberID),
+		}, nil)
+		reviewer, _ := user.NewUser("reviewer@test.com", "Reviewer User", "password123")
+		muted, _ := user.NewUser("muted@test.com", "Muted User", "password123")
+		muted.UpdateNotificationPreferences(map[string]bool{approvalPrefKey: false})
+		tm.userRepo.On("FindByID", ctx, ownerID).Return(reviewer, nil)
+		tm.userRepo.On("FindByID", ctx, adminID).Return(reviewer, nil)
+		tm.userRepo.On("FindByID", ctx, mutedID).Return(muted, nil)
+
+		recipients, err := tm.service().reviewerRecipients(ctx, workspaceID, requesterID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{ownerID, adminID}, recipients)
+		tm.userRepo.AssertExpectations(t)
+	})
+
+	t.Run("propagates member lookup error", func(t *testing.T) {
+		tm := newMocks()
+		tm.memberRepo.On("ListByWorkspace", ctx, workspaceID).Return(nil, errors.New("db down"))
+
+		_, err := tm.service().reviewerRecipients(ctx, workspaceID, requesterID)
+
+		assert.Error(t, err)
+	})
+}
+
 // ---------------------------------------------------------------------------
 // ApproveChange
 // ---------------------------------------------------------------------------
@@ -1030,21 +1275,116 @@ func TestRejectChange(t *testing.T) {
 func TestListPendingForWorkspace(t *testing.T) {
 	ctx := context.Background()
 	workspaceID := uuid.New()
+	pagination := shared.Pagination{Page: 2, PageSize: 20}
 
 	t.Run("lists pending changes", func(t *testing.T) {
 		tm := newMocks()
 		status := StatusPending
-		tm.repo.On("FindByWorkspace", ctx, workspaceID, &status).Return([]*PendingChange{}, nil)
-		out, err := tm.service().ListPendingForWorkspace(ctx, workspaceID)
+		tm.repo.On("FindByWorkspace", ctx, workspaceID, ListFilters{Status: &status}, pagination).Return([]*PendingChange{}, 0, nil)
+		out, total, err := tm.service().ListPendingForWorkspace(ctx, workspaceID, ListFilters{}, pagination)
 		assert.NoError(t, err)
 		assert.NotNil(t, out)
+		assert.Zero(t, total)
+	})
+
+	t.Run("forces pending status and passes other filters through", func(t *testing.T) {
+		tm := newMocks()
+		approved := StatusApproved
+		action := ActionCreate
+		requesterID := uuid.New()
+		tm.repo.On("FindByWorkspace", ctx, workspaceID, mock.MatchedBy(func(f ListFilters) bool {
+			return f.Status != nil && *f.Status == StatusPending &&
+				f.EntityType == "item" &&
+				f.Action != nil && *f.Action == ActionCreate &&
+				f.RequesterID != nil && *f.RequesterID == requesterID
+		}), pagination).Return([]*PendingChange{}, 42, nil)
+
+		_, total, err := tm.service().ListPendingForWorkspace(ctx, workspaceID, ListFilters{
+			Status:      &approved,
+			EntityType:  "item",
+			Action:      &action,
+			RequesterID: &requesterID,
+		}, pagination)
+		assert.NoError(t, err)
+		assert.Equal(t, 42, total)
 	})
 
 	t.Run("propagates error", func(t *testing.T) {
 		tm := newMocks()
-		status := StatusPending
-		tm.repo.On("FindByWorkspace", ctx, workspaceID, &status).Return(nil, errors.New("boom"))
-		_, err := tm.service().ListPendingForWorkspace(ctx, workspaceID)
+		tm.repo.On("FindByWorkspace", ctx, workspaceID, mock.Anything, pagination).Return(nil, 0, errors.New("boom"))
+		_, _, err := tm.service().ListPendingForWorkspace(ctx, workspaceID, ListFilters{}, pagination)
+		assert.Error(t, err)
+	})
+}
+
+func TestMyChangeCounts(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+	requesterID := uuid.New()
+
+	t.Run("maps grouped counts onto statuses", func(t *testing.T) {
+		tm := newMocks()
+		tm.repo.On("CountByRequesterStatus", ctx, workspaceID, requesterID).Return(map[Status]int{
+			StatusPending:  3,
+			StatusRejected: 1,
+		}, nil)
+
+		counts, err := tm.service().MyChangeCounts(ctx, workspaceID, requesterID)
+		assert.NoError(t, err)
+		assert.Equal(t, ChangeCounts{Pending: 3, Approved: 0, Rejected: 1}, counts)
+		assert.Equal(t, 4, counts.Total())
+		tm.repo.AssertNumberOfCalls(t, "CountByRequesterStatus", 1)
+	})
+
+	t.Run("no changes yields zero counts", func(t *testing.T) {
+		tm := newMocks()
+		tm.repo.On("CountByRequesterStatus", ctx, workspaceID, requesterID).Return(map[Status]int{}, nil)
+
+		counts, err := tm.service().MyChangeCounts(ctx, workspaceID, requesterID)
+		assert.NoError(t, err)
+		assert.Zero(t, counts.Total())
+	})
+
+	t.Run("propagates error", func(t *testing.T) {
+		tm := newMocks()
+		tm.repo.On("CountByRequesterStatus", ctx, workspaceID, requesterID).Return(nil, errors.New("boom"))
+
+		_, err := tm.service().MyChangeCounts(ctx, workspaceID, requesterID)
+		assert.Error(t, err)
+	})
+}
+
+func TestReviewerStats(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+	to := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	from := to.AddDate(0, -1, 0)
+
+	t.Run("returns aggregated stats", func(t *testing.T) {
+		tm := newMocks()
+		reviewerID := uuid.New()
+		want := []ReviewerStat{{ReviewerID: reviewerID, Approved: 4, Rejected: 1, AvgTimeToReview: 90 * time.Minute}}
+		tm.repo.On("ReviewerStats", ctx, workspaceID, from, to).Return(want, nil)
+
+		stats, err := tm.service().ReviewerStats(ctx, workspaceID, from, to)
+		assert.NoError(t, err)
+		assert.Equal(t, want, stats)
+		assert.Equal(t, 5, stats[0].Total())
+	})
+
+	t.Run("rejects an empty period", func(t *testing.T) {
+		tm := newMocks()
+
+		_, err := tm.service().ReviewerStats(ctx, workspaceID, to, to)
+		assert.ErrorIs(t, err, shared.ErrInvalidInput)
+		tm.repo.AssertNotCalled(t, "ReviewerStats", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("propagates error", func(t *testing.T) {
+		tm := newMocks()
+		tm.repo.On("ReviewerStats", ctx, workspaceID, from, to).Return(nil, errors.New("boom"))
+
+		_, err := tm.service().ReviewerStats(ctx, workspaceID, from, to)
 		assert.Error(t, err)
 	})
 }
@@ -1062,7 +1402,7 @@ func TestNewService(t *testing.T) {
 
 func TestServiceIsValidEntityType(t *testing.T) {
 	svc := &Service{}
-	for _, et := range []string{"item", "category", "location", "container", "inventory", "borrower", "loan", "label", "maintenance", "wishlist"} {
+	for _, et := range []string{"item", "category", "location", "container", "inventory", "borrower", "loan", "label", "maintenance", "wishlist", "quick_add"} {
 		assert.True(t, svc.isValidEntityType(et), et)
 	}
 	for _, et := range []string{"invalid", "user", "workspace", "member", "", "ITEM", "photo", "attachment"} {
@@ -1245,6 +1585,16 @@ func TestApplyInventoryChange(t *testing.T) {
 			return tm.inventorySvc
 		},
 	})
+	// a transfer payload moves the entry instead of updating its fields.
+	containerID := uuid.New()
+	runApply(t, applyCase{
+		entityType: "inventory", action: ActionUpdate, withEntity: true,
+		payload: `{"transfer":{"location_id":"` + locID.String() + `","container_id":"` + containerID.String() + `"}}`,
+		expect: func(tm *testMocks, ctx context.Context, ws, eid uuid.UUID) interface{ AssertExpectations(mock.TestingT) bool } {
+			tm.inventorySvc.On("Move", ctx, eid, ws, locID, &containerID).Return(&inventory.Inventory{}, nil)
+			return tm.inventorySvc
+		},
+	})
 	// inventory delete goes through the repository (no service-level Delete).
 	runApply(t, applyCase{
 		entityType: "inventory", action: ActionDelete, withEntity: true, payload: `{}`,
@@ -1421,3 +1771,23 @@ func TestApplyWishlistChange(t *testing.T) {
 		},
 	})
 }
+
+func TestApplyQuickAddChange(t *testing.T) {
+	payload := `{"item":{"sku":"W1","name":"Widget"},"inventory":{"location_id":"` + uuid.New().String() + `","quantity":2}}`
+	runApply(t, applyCase{
+		entityType: "quick_add", action: ActionCreate, payload: payload,
+		expect: func(tm *testMocks, ctx context.Context, ws, _ uuid.UUID) interface{ AssertExpectations(mock.TestingT) bool } {
+			tm.quickAdd.On("ApplyQuickAdd", ctx, ws, json.RawMessage(payload)).Return(uuid.New(), nil)
+			return tm.quickAdd
+		},
+	})
+}
+
+func TestApplyQuickAddChange_NotConfigured(t *testing.T) {
+	svc := &Service{}
+	change := pendingChange(uuid.New(), uuid.New(), uuid.New(), "quick_add", nil, ActionCreate, `{}`)
+
+	_, err := svc.applyChange(context.Background(), change)
+
+	assert.EqualError(t, err, "quick add is not configured")
+}
@@ -0,0 +1,294 @@
+package quickadd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+
+	appMiddleware "github.com/antti/home-warehouse/go-backend/internal/api/middleware"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/inventory"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/item"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/itemphoto"
+	"github.com/antti/home-warehouse/go-backend/internal/infra/events"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// Photo statuses reported when a quick add created no photo.
+const (
+	photoStatusNone    = "none"    // no photo was uploaded
+	photoStatusSkipped = "skipped" // a member's photo is not part of the pending change
+)
+
+// Handler serves the quick add endpoint. The endpoint takes multipart, which
+// the approval middleware does not capture, so the handler routes members'
+// requests through the approval pipeline itself.
+type Handler struct {
+	svc         ServiceInterface
+	pending     appMiddleware.PendingChangeCreator
+	broadcaster *events.Broadcaster
+}
+
+// RegisterRoutes registers the quick add endpoint on a Chi router.
+func RegisterRoutes(r chi.Router, svc ServiceInterface, pending appMiddleware.PendingChangeCreator, broadcaster *events.Broadcaster) {
+	handler := &Handler{
+		svc:         svc,
+		pending:     pending,
+		broadcaster: broadcaster,
+	}
+	r.Post("/quick-add", handler.HandleQuickAdd)
+}
+
+// HandleQuickAdd creates an item, its inventory entry and optionally its
+// primary photo from one multipart request. The "item" and "inventory" form
+// fields hold the JSON bodies of POST /items and POST /inventory (without
+// item_id); "photo" and "caption" are optional.
+//
+// A member's quick add becomes a single "quick_add" pending change for the
+// item and inventory entry; their photo is not stored and can be uploaded
+// once the change is approved.
+func (h *Handler) HandleQuickAdd(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+	if !ok {
+		http.Error(w, "workspace context required", http.StatusUnauthorized)
+		return
+	}
+
+	authUser, ok := appMiddleware.GetAuthUser(ctx)
+	if !ok {
+		http.Error(w, "user context required", http.StatusUnauthorized)
+		return
+	}
+
+	if err := r.ParseMultipartForm(itemphoto.MaxFileSize); err != nil {
+		http.Error(w, "file too large or invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	var payload Payload
+	if err := decodeField(r, "item", &payload.Item); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if err := decodeField(r, "inventory", &payload.Inventory); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Get optional photo and caption
+	var photo *Photo
+	file, header, err := r.FormFile("photo")
+	switch {
+	case err == nil:
+		defer file.Close()
+		photo = &Photo{File: file, Header: header}
+		if c := r.FormValue("caption"); c != "" {
+			photo.Caption = &c
+		}
+	case !errors.Is(err, http.ErrMissingFile):
+		http.Error(w, "invalid photo file", http.StatusBadRequest)
+		return
+	}
+
+	if role, _ := appMiddleware.GetRole(ctx); role == "member" {
+		h.submitForApproval(w, r, workspaceID, authUser.ID, payload, photo != nil)
+		return
+	}
+
+	result, err := h.svc.Add(ctx, workspaceID, authUser.ID, payload, photo)
+	if err != nil {
+		writeAddError(w, err)
+		return
+	}
+
+	h.publish(r, workspaceID, authUser.ID, result)
+
+	response := QuickAddResponse{
+		Item: ItemResponse{
+			ID:        result.Item.ID(),
+			SKU:       result.Item.SKU(),
+			Name:      result.Item.Name(),
+			ShortCode: result.Item.ShortCode(),
+		},
+		Inventory: InventoryResponse{
+			ID:          result.Inventory.ID(),
+			LocationID:  result.Inventory.LocationID(),
+			ContainerID: result.Inventory.ContainerID(),
+			Quantity:    result.Inventory.Quantity(),
+			Condition:   string(result.Inventory.Condition()),
+			Status:      string(result.Inventory.Status()),
+		},
+		PhotoStatus: photoStatusNone,
+	}
+	if result.Photo != nil {
+		response.Photo = &PhotoResponse{
+			ID:              result.Photo.ID,
+			IsPrimary:       result.Photo.IsPrimary,
+			ThumbnailStatus: string(result.Photo.ThumbnailStatus),
+		}
+		response.PhotoStatus = string(result.Photo.ThumbnailStatus)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusCreated)
+	json.NewEncoder(w).Encode(response)
+}
+
+// submitForApproval stores a member's quick add as a pending change and
+// answers like the approval middleware does.
+func (h *Handler) submitForApproval(w http.ResponseWriter, r *http.Request, workspaceID, userID uuid.UUID, payload Payload, hasPhoto bool) {
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		http.Error(w, "failed to encode quick add", http.StatusInternalServerError)
+		return
+	}
+
+	changeID, err := h.pending.CreatePendingChange(r.Context(), workspaceID, userID, "quick_add", nil, "create", raw)
+	if err != nil {
+		http.Error(w, `{"error":"internal_error","message":"failed to create pending change"}`, http.StatusInternalServerError)
+		return
+	}
+
+	photoStatus := photoStatusNone
+	if hasPhoto {
+		photoStatus = photoStatusSkipped
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusAccepted)
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"pending_change_id": changeID,
+		"status":            "pending_approval",
+		"message":           "Your change is pending approval from workspace admin",
+		"entity_type":       "quick_add",
+		"action":            "create",
+		"photo_status":      photoStatus,
+	})
+}
+
+// publish announces each created entity, as the separate create endpoints do.
+func (h *Handler) publish(r *http.Request, workspaceID, userID uuid.UUID, result *Result) {
+	if h.broadcaster == nil {
+		return
+	}
+	userName := appMiddleware.GetUserDisplayName(r.Context())
+
+	h.broadcaster.Publish(workspaceID, events.Event{
+		Type:       "item.created",
+		EntityID:   result.Item.ID().String(),
+		EntityType: "item",
+		UserID:     userID,
+		Data: map[string]any{
+			"id":        result.Item.ID(),
+			"sku":       result.Item.SKU(),
+			"name":      result.Item.Name(),
+			"user_name": userName,
+		},
+	})
+	h.broadcaster.Publish(workspaceID, events.Event{
+		Type:       "inventory.created",
+		EntityID:   result.Inventory.ID().String(),
+		EntityType: "inventory",
+		UserID:     userID,
+		Data: map[string]any{
+			"id":        result.Inventory.ID(),
+			"item_id":   result.Item.ID(),
+			"user_name": userName,
+		},
+	})
+	if result.Photo != nil {
+		h.broadcaster.Publish(workspaceID, events.Event{
+			Type:       "item_photo.created",
+			EntityID:   result.Photo.ID.String(),
+			EntityType: "item_photo",
+			UserID:     userID,
+			Data: map[string]any{
+				"id":         result.Photo.ID,
+				"item_id":    result.Photo.ItemID,
+				"is_primary": result.Photo.IsPrimary,
+				"user_name":  userName,
+			},
+		})
+	}
+}
+
+// decodeField decodes the JSON in a required form field.
+func decodeField(r *http.Request, name string, v any) error {
+	value := r.FormValue(name)
+	if value == "" {
+		return fmt.Errorf("%s is required", name)
+	}
+	if err := json.Unmarshal([]byte(value), v); err != nil {
+		return fmt.Errorf("invalid %s: %v", name, err)
+	}
+	return nil
+}
+
+// writeAddError maps an error from Add to the status the separate create
+// endpoints would have returned.
+func writeAddError(w http.ResponseWriter, err error) {
+	var limitErr *itemphoto.PhotoLimitError
+	if errors.As(err, &limitErr) {
+		http.Error(w, limitErr.Error(), http.StatusConflict)
+		return
+	}
+	var domainErr *shared.DomainError
+	if errors.As(err, &domainErr) {
+		http.Error(w, err.Error(), appMiddleware.MapDomainError(err).GetStatus())
+		return
+	}
+
+	switch {
+	case errors.Is(err, itemphoto.ErrFileTooLarge):
+		http.Error(w, "file too large: maximum size is 10MB", http.StatusRequestEntityTooLarge)
+	case errors.Is(err, itemphoto.ErrInvalidFileType),
+		errors.Is(err, item.ErrSKUTaken),
+		errors.Is(err, item.ErrShortCodeTaken),
+		errors.Is(err, item.ErrInvalidMinStock),
+		errors.Is(err, inventory.ErrItemPhotoRequired),
+		errors.Is(err, inventory.ErrInvalidCondition),
+		errors.Is(err, inventory.ErrInvalidStatus),
+		errors.Is(err, inventory.ErrInsufficientQuantity):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	case errors.Is(err, shared.ErrNotFound):
+		http.Error(w, err.Error(), http.StatusNotFound)
+	default:
+		http.Error(w, fmt.Sprintf("failed to quick add item: %v", err), http.StatusInternalServerError)
+	}
+}
+
+// QuickAddResponse is what a quick add created. PhotoStatus is the photo's
+// thumbnail status, or "none" when no photo was uploaded.
+type QuickAddResponse struct {
+	Item        ItemResponse      `json:"item"`
+	Inventory   InventoryResponse `json:"inventory"`
+	Photo       *PhotoResponse    `json:"photo,omitempty"`
+	PhotoStatus string            `json:"photo_status"`
+}
+
+type ItemResponse struct {
+	ID        uuid.UUID `json:"id"`
+	SKU       string    `json:"sku"`
+	Name      string    `json:"name"`
+	ShortCode string    `json:"short_code"`
+}
+
+type InventoryResponse struct {
+	ID          uuid.UUID  `json:"id"`
+	LocationID  uuid.UUID  `json:"location_id"`
+	ContainerID *uuid.UUID `json:"container_id,omitempty"`
+	Quantity    int        `json:"quantity"`
+	Condition   string     `json:"condition"`
+	Status      string     `json:"status"`
+}
+
+type PhotoResponse struct {
+	ID              uuid.UUID `json:"id"`
+	IsPrimary       bool      `json:"is_primary"`
+	ThumbnailStatus string    `json:"thumbnail_status"`
+}
@@ -0,0 +1,177 @@
+package quickadd_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	appMiddleware "github.com/antti/home-warehouse/go-backend/internal/api/middleware"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/inventory"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/item"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/itemphoto"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/quickadd"
+)
+
+type MockService struct{ mock.Mock }
+
+func (m *MockService) Add(ctx context.Context, workspaceID, userID uuid.UUID, payload quickadd.Payload, photo *quickadd.Photo) (*quickadd.Result, error) {
+	args := m.Called(ctx, workspaceID, userID, payload, photo)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*quickadd.Result), args.Error(1)
+}
+
+type MockPendingChangeCreator struct{ mock.Mock }
+
+func (m *MockPendingChangeCreator) CreatePendingChange(ctx context.Context, workspaceID, requesterID uuid.UUID, entityType string, entityID *uuid.UUID, action string, payload json.RawMessage) (uuid.UUID, error) {
+	args := m.Called(ctx, workspaceID, requesterID, entityType, entityID, action, payload)
+	return args.Get(0).(uuid.UUID), args.Error(1)
+}
+
+// quickAddRequest builds a multipart quick add request in the given role's
+// workspace context. Empty fields are left out of the form.
+func quickAddRequest(t *testing.T, workspaceID, userID uuid.UUID, role, itemJSON, inventoryJSON string, photo []byte) *http.Request {
+	t.Helper()
+	body := &bytes.Buffer{}
+	writer := multipart.NewWriter(body)
+	if itemJSON != "" {
+		require.NoError(t, writer.WriteField("item", itemJSON))
+	}
+	if inventoryJSON != "" {
+		require.NoError(t, writer.WriteField("inventory", inventoryJSON))
+	}
+	if photo != nil {
+		part, err := writer.CreateFormFile("photo", "drill.jpg")
+		require.NoError(t, err)
+		part.Write(photo)
+	}
+	require.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(http.MethodPost, "/quick-add", body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	ctx := req.Context()
+	ctx = context.WithValue(ctx, appMiddleware.WorkspaceContextKey, workspaceID)
+	ctx = context.WithValue(ctx, appMiddleware.UserContextKey, &appMiddleware.AuthUser{ID: userID, FullName: "Test User"})
+	ctx = context.WithValue(ctx, appMiddleware.RoleContextKey, role)
+	return req.WithContext(ctx)
+}
+
+func serve(svc quickadd.ServiceInterface, pending appMiddleware.PendingChangeCreator, req *http.Request) *httptest.ResponseRecorder {
+	r := chi.NewRouter()
+	quickadd.RegisterRoutes(r, svc, pending, nil)
+	rr := httptest.NewRecorder()
+	r.ServeHTTP(rr, req)
+	return rr
+}
+
+func TestHandler_QuickAdd(t *testing.T) {
+	workspaceID := uuid.New()
+	userID := uuid.New()
+	locationID := uuid.New()
+	itemJSON := `{"sku":"DRILL-1","name":"Cordless drill"}`
+	inventoryJSON := `{"location_id":"` + locationID.String() + `","quantity":1}`
+
+	t.Run("creates item, inventory and photo for an admin", func(t *testing.T) {
+		svc := new(MockService)
+		pending := new(MockPendingChangeCreator)
+		created, _ := item.NewItem(workspaceID, "Cordless drill", "DRILL-1", 0)
+		entry, _ := inventory.NewInventory(workspaceID, created.ID(), locationID, nil, 1, inventory.ConditionGood, inventory.StatusAvailable, nil)
+		photo := &itemphoto.ItemPhoto{ID: uuid.New(), ItemID: created.ID(), IsPrimary: true, ThumbnailStatus: itemphoto.ThumbnailStatusPending}
+
+		svc.On("Add", mock.Anything, workspaceID, userID, mock.MatchedBy(func(p quickadd.Payload) bool {
+			return p.Item.SKU == "DRILL-1" && p.Inventory.LocationID == locationID && p.Inventory.Quantity == 1
+		}), mock.MatchedBy(func(p *quickadd.Photo) bool {
+			if p == nil || p.Header.Filename != "drill.jpg" {
+				return false
+			}
+			data, _ := io.ReadAll(p.File)
+			return string(data) == "fake jpeg"
+		})).Return(&quickadd.Result{Item: created, Inventory: entry, Photo: photo}, nil).Once()
+
+		rr := serve(svc, pending, quickAddRequest(t, workspaceID, userID, "admin", itemJSON, inventoryJSON, []byte("fake jpeg")))
+
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		var resp quickadd.QuickAddResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		assert.Equal(t, created.ID(), resp.Item.ID)
+		assert.Equal(t, entry.ID(), resp.Inventory.ID)
+		assert.Equal(t, locationID, resp.Inventory.LocationID)
+		require.NotNil(t, resp.Photo)
+		assert.Equal(t, photo.ID, resp.Photo.ID)
+		assert.True(t, resp.Photo.IsPrimary)
+		assert.Equal(t, "pending", resp.PhotoStatus)
+		svc.AssertExpectations(t)
+		pending.AssertNotCalled(t, "CreatePendingChange")
+	})
+
+	t.Run("reports no photo when none was uploaded", func(t *testing.T) {
+		svc := new(MockService)
+		created, _ := item.NewItem(workspaceID, "Cordless drill", "DRILL-1", 0)
+		entry, _ := inventory.NewInventory(workspaceID, created.ID(), locationID, nil, 1, inventory.ConditionGood, inventory.StatusAvailable, nil)
+		svc.On("Add", mock.Anything, workspaceID, userID, mock.Anything, (*quickadd.Photo)(nil)).
+			Return(&quickadd.Result{Item: created, Inventory: entry}, nil).Once()
+
+		rr := serve(svc, new(MockPendingChangeCreator), quickAddRequest(t, workspaceID, userID, "owner", itemJSON, inventoryJSON, nil))
+
+		require.Equal(t, http.StatusCreated, rr.Code, rr.Body.String())
+		var resp quickadd.QuickAddResponse
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		assert.Nil(t, resp.Photo)
+		assert.Equal(t, "none", resp.PhotoStatus)
+	})
+
+	t.Run("creates a pending change for a member", func(t *testing.T) {
+		svc := new(MockService)
+		pending := new(MockPendingChangeCreator)
+		changeID := uuid.New()
+		pending.On("CreatePendingChange", mock.Anything, workspaceID, userID, "quick_add", (*uuid.UUID)(nil), "create",
+			mock.MatchedBy(func(raw json.RawMessage) bool {
+				var p quickadd.Payload
+				return json.Unmarshal(raw, &p) == nil && p.Item.SKU == "DRILL-1" && p.Inventory.LocationID == locationID
+			})).Return(changeID, nil).Once()
+
+		rr := serve(svc, pending, quickAddRequest(t, workspaceID, userID, "member", itemJSON, inventoryJSON, []byte("fake jpeg")))
+
+		require.Equal(t, http.StatusAccepted, rr.Code, rr.Body.String())
+		var resp map[string]any
+		require.NoError(t, json.Unmarshal(rr.Body.Bytes(), &resp))
+		assert.Equal(t, changeID.String(), resp["pending_change_id"])
+		assert.Equal(t, "pending_approval", resp["status"])
+		assert.Equal(t, "quick_add", resp["entity_type"])
+		assert.Equal(t, "skipped", resp["photo_status"])
+		pending.AssertExpectations(t)
+		svc.AssertNotCalled(t, "Add")
+	})
+
+	t.Run("returns 400 when a part is missing", func(t *testing.T) {
+		svc := new(MockService)
+
+		rr := serve(svc, new(MockPendingChangeCreator), quickAddRequest(t, workspaceID, userID, "admin", "", inventoryJSON, nil))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		svc.AssertNotCalled(t, "Add")
+	})
+
+	t.Run("returns 400 when the photo rule blocks the inventory", func(t *testing.T) {
+		svc := new(MockService)
+		svc.On("Add", mock.Anything, workspaceID, userID, mock.Anything, (*quickadd.Photo)(nil)).
+			Return(nil, inventory.ErrItemPhotoRequired).Once()
+
+		rr := serve(svc, new(MockPendingChangeCreator), quickAddRequest(t, workspaceID, userID, "admin", itemJSON, inventoryJSON, nil))
+
+		assert.Equal(t, http.StatusBadRequest, rr.Code)
+		assert.Contains(t, rr.Body.String(), "needs a photo")
+	})
+}
@@ -0,0 +1,229 @@
+// Package quickadd catalogs an item in one request: the item, its first
+// inventory entry and optionally its primary photo, instead of three
+// separate create calls.
+package quickadd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"mime/multipart"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/inventory"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/item"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/itemphoto"
+)
+
+// ItemFields are the item part of a quick add, shaped like the POST /items
+// request body.
+type ItemFields struct {
+	SKU               string     `json:"sku"`
+	Name              string     `json:"name"`
+	Description       *string    `json:"description,omitempty"`
+	CategoryID        *uuid.UUID `json:"category_id,omitempty"`
+	Brand             *string    `json:"brand,omitempty"`
+	Model             *string    `json:"model,omitempty"`
+	ImageURL          *string    `json:"image_url,omitempty"`
+	SerialNumber      *string    `json:"serial_number,omitempty"`
+	Manufacturer      *string    `json:"manufacturer,omitempty"`
+	Barcode           *string    `json:"barcode,omitempty"`
+	IsInsured         *bool      `json:"is_insured,omitempty"`
+	LifetimeWarranty  *bool      `json:"lifetime_warranty,omitempty"`
+	WarrantyDetails   *string    `json:"warranty_details,omitempty"`
+	PurchasedFrom     *uuid.UUID `json:"purchased_from,omitempty"`
+	MinStockLevel     int        `json:"min_stock_level,omitempty"`
+	ShortCode         string     `json:"short_code,omitempty"`
+	ObsidianVaultPath *string    `json:"obsidian_vault_path,omitempty"`
+	ObsidianNotePath  *string    `json:"obsidian_note_path,omitempty"`
+	NeedsReview       *bool      `json:"needs_review,omitempty"`
+	Loanable          *bool      `json:"loanable,omitempty"`
+}
+
+// InventoryFields are the inventory part of a quick add, shaped like the
+// POST /inventory request body without item_id.
+type InventoryFields struct {
+	LocationID      uuid.UUID           `json:"location_id"`
+	ContainerID     *uuid.UUID          `json:"container_id,omitempty"`
+	Quantity        int                 `json:"quantity"`
+	Condition       inventory.Condition `json:"condition,omitempty"`
+	Status          inventory.Status    `json:"status,omitempty"`
+	DateAcquired    *time.Time          `json:"date_acquired,omitempty"`
+	PurchasePrice   *int                `json:"purchase_price,omitempty"`
+	CurrencyCode    *string             `json:"currency_code,omitempty"`
+	WarrantyExpires *time.Time          `json:"warranty_expires,omitempty"`
+	ExpirationDate  *time.Time          `json:"expiration_date,omitempty"`
+	Notes           *string             `json:"notes,omitempty"`
+}
+
+// Payload is the item and inventory of a quick add. It is also the payload of
+// the "quick_add" pending change a member's quick add submits for approval.
+type Payload struct {
+	Item      ItemFields      `json:"item"`
+	Inventory InventoryFields `json:"inventory"`
+}
+
+// Photo is the optional photo uploaded with a quick add.
+type Photo struct {
+	File    multipart.File
+	Header  *multipart.FileHeader
+	Caption *string
+}
+
+// Result is what a quick add created. Photo is nil when none was uploaded.
+type Result struct {
+	Item      *item.Item
+	Inventory *inventory.Inventory
+	Photo     *itemphoto.ItemPhoto
+}
+
+// ItemCreator creates items (item.Service).
+type ItemCreator interface {
+	Create(ctx context.Context, input item.CreateInput) (*item.Item, error)
+}
+
+// InventoryCreator creates inventory entries (inventory.Service).
+type InventoryCreator interface {
+	Create(ctx context.Context, input inventory.CreateInput) (*inventory.Inventory, error)
+}
+
+// PhotoUploader stores item photos (itemphoto.Service).
+type PhotoUploader interface {
+	UploadPhoto(ctx context.Context, itemID, workspaceID, userID uuid.UUID, inventoryID *uuid.UUID, file multipart.File, header *multipart.FileHeader, caption *string) (*itemphoto.ItemPhoto, error)
+}
+
+// Transactor runs a function inside a single database transaction; the
+// repositories pick the transaction up from the context.
+type Transactor interface {
+	WithTx(ctx context.Context, fn func(context.Context) error) error
+}
+
+// noopTransactor runs the function without a transaction (unit tests).
+type noopTransactor struct{}
+
+func (noopTransactor) WithTx(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+// ServiceInterface defines the quick add operations.
+type ServiceInterface interface {
+	Add(ctx context.Context, workspaceID, userID uuid.UUID, payload Payload, photo *Photo) (*Result, error)
+}
+
+type Service struct {
+	items     ItemCreator
+	inventory InventoryCreator
+	photos    PhotoUploader
+	tx        Transactor
+}
+
+func NewService(items ItemCreator, inventory InventoryCreator, photos PhotoUploader) *Service {
+	return &Service{
+		items:     items,
+		inventory: inventory,
+		photos:    photos,
+		tx:        noopTransactor{},
+	}
+}
+
+// SetTransactor makes Add atomic. Without it a failed step leaves the
+// earlier ones in place.
+func (s *Service) SetTransactor(tx Transactor) {
+	s.tx = tx
+}
+
+// Add creates the item, its photo and its inventory entry in one
+// transaction, through the same services as the separate create calls. The
+// photo is saved before the inventory so a workspace that requires a photo
+// before adding inventory accepts the quick add. Thumbnails are generated
+// asynchronously as for any upload.
+func (s *Service) Add(ctx context.Context, workspaceID, userID uuid.UUID, payload Payload, photo *Photo) (*Result, error) {
+	result := &Result{}
+	err := s.tx.WithTx(ctx, func(ctx context.Context) error {
+		created, err := s.items.Create(ctx, payload.Item.input(workspaceID))
+		if err != nil {
+			return err
+		}
+		result.Item = created
+
+		if photo != nil {
+			uploaded, err := s.photos.UploadPhoto(ctx, created.ID(), workspaceID, userID, nil, photo.File, photo.Header, photo.Caption)
+			if err != nil {
+				return err
+			}
+			result.Photo = uploaded
+		}
+
+		inv, err := s.inventory.Create(ctx, payload.Inventory.input(workspaceID, created.ID()))
+		if err != nil {
+			return err
+		}
+		result.Inventory = inv
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// ApplyQuickAdd creates the item and inventory entry of an approved
+// "quick_add" pending change and returns the item's ID
+// (pendingchange.QuickAddApplier).
+func (s *Service) ApplyQuickAdd(ctx context.Context, workspaceID uuid.UUID, raw json.RawMessage) (uuid.UUID, error) {
+	var payload Payload
+	if err := json.Unmarshal(raw, &payload); err != nil {
+		return uuid.Nil, fmt.Errorf("failed to unmarshal quick add payload: %w", err)
+	}
+	result, err := s.Add(ctx, workspaceID, uuid.Nil, payload, nil)
+	if err != nil {
+		return uuid.Nil, err
+	}
+	return result.Item.ID(), nil
+}
+
+func (f ItemFields) input(workspaceID uuid.UUID) item.CreateInput {
+	return item.CreateInput{
+		WorkspaceID:       workspaceID,
+		SKU:               f.SKU,
+		Name:              f.Name,
+		Description:       f.Description,
+		CategoryID:        f.CategoryID,
+		Brand:             f.Brand,
+		Model:             f.Model,
+		ImageURL:          f.ImageURL,
+		SerialNumber:      f.SerialNumber,
+		Manufacturer:      f.Manufacturer,
+		Barcode:           f.Barcode,
+		IsInsured:         f.IsInsured,
+		LifetimeWarranty:  f.LifetimeWarranty,
+		WarrantyDetails:   f.WarrantyDetails,
+		PurchasedFrom:     f.PurchasedFrom,
+		MinStockLevel:     f.MinStockLevel,
+		ShortCode:         f.ShortCode,
+		ObsidianVaultPath: f.ObsidianVaultPath,
+		ObsidianNotePath:  f.ObsidianNotePath,
+		NeedsReview:       f.NeedsReview,
+		Loanable:          f.Loanable,
+	}
+}
+
+func (f InventoryFields) input(workspaceID, itemID uuid.UUID) inventory.CreateInput {
+	return inventory.CreateInput{
+		WorkspaceID:     workspaceID,
+		ItemID:          itemID,
+		LocationID:      f.LocationID,
+		ContainerID:     f.ContainerID,
+		Quantity:        f.Quantity,
+		Condition:       f.Condition,
+		Status:          f.Status,
+		DateAcquired:    f.DateAcquired,
+		PurchasePrice:   f.PurchasePrice,
+		CurrencyCode:    f.CurrencyCode,
+		WarrantyExpires: f.WarrantyExpires,
+		ExpirationDate:  f.ExpirationDate,
+		Notes:           f.Notes,
+	}
+}
@@ -0,0 +1,192 @@
+package quickadd
+
+import (
+	"context"
+	"errors"
+	"mime/multipart"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/inventory"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/item"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/itemphoto"
+)
+
+type mockItems struct{ mock.Mock }
+
+func (m *mockItems) Create(ctx context.Context, input item.CreateInput) (*item.Item, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*item.Item), args.Error(1)
+}
+
+type mockInventory struct{ mock.Mock }
+
+func (m *mockInventory) Create(ctx context.Context, input inventory.CreateInput) (*inventory.Inventory, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*inventory.Inventory), args.Error(1)
+}
+
+type mockPhotos struct{ mock.Mock }
+
+func (m *mockPhotos) UploadPhoto(ctx context.Context, itemID, workspaceID, userID uuid.UUID, inventoryID *uuid.UUID, file multipart.File, header *multipart.FileHeader, caption *string) (*itemphoto.ItemPhoto, error) {
+	args := m.Called(ctx, itemID, workspaceID, userID, inventoryID, file, header, caption)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*itemphoto.ItemPhoto), args.Error(1)
+}
+
+// recordingTransactor records how many transactions were opened and whether
+// the last one failed.
+type recordingTransactor struct {
+	calls  int
+	failed bool
+}
+
+func (r *recordingTransactor) WithTx(ctx context.Context, fn func(context.Context) error) error {
+	r.calls++
+	err := fn(ctx)
+	r.failed = err != nil
+	return err
+}
+
+func newTestService() (*Service, *mockItems, *mockInventory, *mockPhotos, *recordingTransactor) {
+	items, inv, photos := new(mockItems), new(mockInventory), new(mockPhotos)
+	tx := &recordingTransactor{}
+	svc := NewService(items, inv, photos)
+	svc.SetTransactor(tx)
+	return svc, items, inv, photos, tx
+}
+
+func testPayload(locationID uuid.UUID) Payload {
+	return Payload{
+		Item:      ItemFields{SKU: "DRILL-1", Name: "Cordless drill"},
+		Inventory: InventoryFields{LocationID: locationID, Quantity: 1},
+	}
+}
+
+func TestService_Add(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+	userID := uuid.New()
+	locationID := uuid.New()
+
+	t.Run("creates item, photo and inventory in one transaction", func(t *testing.T) {
+		svc, items, inv, photos, tx := newTestService()
+		created, _ := item.NewItem(workspaceID, "Cordless drill", "DRILL-1", 0)
+		entry, _ := inventory.NewInventory(workspaceID, created.ID(), locationID, nil, 1, inventory.ConditionGood, inventory.StatusAvailable, nil)
+		photo := &itemphoto.ItemPhoto{ID: uuid.New(), ItemID: created.ID(), IsPrimary: true, ThumbnailStatus: itemphoto.ThumbnailStatusPending}
+		header := &multipart.FileHeader{Filename: "drill.jpg"}
+		caption := "front"
+
+		var order []string
+		items.On("Create", ctx, mock.MatchedBy(func(in item.CreateInput) bool {
+			return in.WorkspaceID == workspaceID && in.SKU == "DRILL-1" && in.Name == "Cordless drill"
+		})).Return(created, nil).Run(func(mock.Arguments) { order = append(order, "item") })
+		photos.On("UploadPhoto", ctx, created.ID(), workspaceID, userID, (*uuid.UUID)(nil), nil, header, &caption).
+			Return(photo, nil).Run(func(mock.Arguments) { order = append(order, "photo") })
+		inv.On("Create", ctx, mock.MatchedBy(func(in inventory.CreateInput) bool {
+			return in.WorkspaceID == workspaceID && in.ItemID == created.ID() && in.LocationID == locationID && in.Quantity == 1
+		})).Return(entry, nil).Run(func(mock.Arguments) { order = append(order, "inventory") })
+
+		result, err := svc.Add(ctx, workspaceID, userID, testPayload(locationID), &Photo{Header: header, Caption: &caption})
+
+		require.NoError(t, err)
+		assert.Equal(t, created, result.Item)
+		assert.Equal(t, entry, result.Inventory)
+		assert.Equal(t, photo, result.Photo)
+		assert.Equal(t, []string{"item", "photo", "inventory"}, order, "photo saved before inventory so the require-photo rule sees it")
+		assert.Equal(t, 1, tx.calls)
+	})
+
+	t.Run("without a photo", func(t *testing.T) {
+		svc, items, inv, photos, _ := newTestService()
+		created, _ := item.NewItem(workspaceID, "Cordless drill", "DRILL-1", 0)
+		entry, _ := inventory.NewInventory(workspaceID, created.ID(), locationID, nil, 1, inventory.ConditionGood, inventory.StatusAvailable, nil)
+		items.On("Create", ctx, mock.Anything).Return(created, nil)
+		inv.On("Create", ctx, mock.Anything).Return(entry, nil)
+
+		result, err := svc.Add(ctx, workspaceID, userID, testPayload(locationID), nil)
+
+		require.NoError(t, err)
+		assert.Nil(t, result.Photo)
+		photos.AssertNotCalled(t, "UploadPhoto")
+	})
+
+	t.Run("inventory failure fails the transaction", func(t *testing.T) {
+		svc, items, inv, _, tx := newTestService()
+		created, _ := item.NewItem(workspaceID, "Cordless drill", "DRILL-1", 0)
+		items.On("Create", ctx, mock.Anything).Return(created, nil)
+		inv.On("Create", ctx, mock.Anything).Return(nil, inventory.ErrItemPhotoRequired)
+
+		result, err := svc.Add(ctx, workspaceID, userID, testPayload(locationID), nil)
+
+		assert.ErrorIs(t, err, inventory.ErrItemPhotoRequired)
+		assert.Nil(t, result)
+		assert.True(t, tx.failed)
+	})
+
+	t.Run("item failure stops before the other steps", func(t *testing.T) {
+		svc, items, inv, photos, _ := newTestService()
+		items.On("Create", ctx, mock.Anything).Return(nil, item.ErrSKUTaken)
+
+		_, err := svc.Add(ctx, workspaceID, userID, testPayload(locationID), &Photo{})
+
+		assert.ErrorIs(t, err, item.ErrSKUTaken)
+		photos.AssertNotCalled(t, "UploadPhoto")
+		inv.AssertNotCalled(t, "Create")
+	})
+}
+
+func TestService_ApplyQuickAdd(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+	locationID := uuid.New()
+
+	t.Run("creates item and inventory from the payload", func(t *testing.T) {
+		svc, items, inv, photos, _ := newTestService()
+		created, _ := item.NewItem(workspaceID, "Cordless drill", "DRILL-1", 0)
+		entry, _ := inventory.NewInventory(workspaceID, created.ID(), locationID, nil, 3, inventory.ConditionGood, inventory.StatusAvailable, nil)
+		items.On("Create", ctx, mock.MatchedBy(func(in item.CreateInput) bool {
+			return in.SKU == "DRILL-1" && in.Name == "Cordless drill"
+		})).Return(created, nil)
+		inv.On("Create", ctx, mock.MatchedBy(func(in inventory.CreateInput) bool {
+			return in.ItemID == created.ID() && in.LocationID == locationID && in.Quantity == 3
+		})).Return(entry, nil)
+
+		raw := []byte(`{"item":{"sku":"DRILL-1","name":"Cordless drill"},"inventory":{"location_id":"` + locationID.String() + `","quantity":3}}`)
+		itemID, err := svc.ApplyQuickAdd(ctx, workspaceID, raw)
+
+		require.NoError(t, err)
+		assert.Equal(t, created.ID(), itemID)
+		photos.AssertNotCalled(t, "UploadPhoto")
+	})
+
+	t.Run("rejects a malformed payload", func(t *testing.T) {
+		svc, items, _, _, _ := newTestService()
+
+		_, err := svc.ApplyQuickAdd(ctx, workspaceID, []byte(`{`))
+
+		assert.Error(t, err)
+		items.AssertNotCalled(t, "Create")
+	})
+
+	t.Run("returns the create error", func(t *testing.T) {
+		svc, items, _, _, _ := newTestService()
+		items.On("Create", ctx, mock.Anything).Return(nil, errors.New("db down"))
+
+		_, err := svc.ApplyQuickAdd(ctx, workspaceID, []byte(`{"item":{"sku":"X","name":"Y"}}`))
+
+		assert.EqualError(t, err, "db down")
+	})
+}
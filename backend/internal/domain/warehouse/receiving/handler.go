@@ -0,0 +1,164 @@
+package receiving
+
+import (
+	"context"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/google/uuid"
+
+	appMiddleware "github.com/antti/home-warehouse/go-backend/internal/api/middleware"
+	"github.com/antti/home-warehouse/go-backend/internal/infra/events"
+)
+
+// RegisterRoutes registers the receiving endpoint. Receiving writes stock
+// directly instead of going through the approval queue, so only owners and
+// admins may receive.
+func RegisterRoutes(api huma.API, svc ServiceInterface, broadcaster *events.Broadcaster) {
+	huma.Post(api, "/receiving", receive(svc, broadcaster))
+}
+
+// receive books a shipment and publishes item.created for each stub item and
+// inventory.created or inventory.updated for each entry received into.
+func receive(svc ServiceInterface, broadcaster *events.Broadcaster) func(context.Context, *ReceiveRequest) (*ReceiveOutput, error) {
+	return func(ctx context.Context, input *ReceiveRequest) (*ReceiveOutput, error) {
+		workspaceID, err := appMiddleware.RequireWorkspaceID(ctx)
+		if err != nil {
+			return nil, huma.Error401Unauthorized(err.Error())
+		}
+		if role, ok := appMiddleware.GetRole(ctx); !ok || (role != "owner" && role != "admin") {
+			return nil, huma.Error403Forbidden("only workspace owners and admins can receive shipments")
+		}
+
+		var userID *uuid.UUID
+		if authUser, ok := appMiddleware.GetAuthUser(ctx); ok {
+			userID = &authUser.ID
+		}
+
+		lines := make([]Line, len(input.Body.Lines))
+		for i, l := range input.Body.Lines {
+			lines[i] = Line{Barcode: l.Barcode, Quantity: l.Quantity, LocationID: l.LocationID}
+		}
+
+		results, err := svc.Receive(ctx, Input{
+			WorkspaceID:   workspaceID,
+			UserID:        userID,
+			Lines:         lines,
+			CreateMissing: input.Body.CreateMissing,
+			Note:          input.Body.Note,
+		})
+		if err != nil {
+			return nil, appMiddleware.MapDomainError(err)
+		}
+
+		out := &ReceiveOutput{}
+		out.Body.Results = make([]ReceiveLineResponse, len(results))
+		for i, r := range results {
+			resp := ReceiveLineResponse{
+				Barcode:    r.Line.Barcode,
+				LocationID: r.Line.LocationID,
+				Quantity:   r.Line.Quantity,
+			}
+			if r.Err != nil {
+				resp.Error = r.Err.Error()
+				out.Body.Failed++
+				out.Body.Results[i] = resp
+				continue
+			}
+
+			resp.Success = true
+			itemID, inventoryID := r.Item.ID(), r.Inventory.ID()
+			resp.ItemID = &itemID
+			resp.InventoryID = &inventoryID
+			resp.ItemCreated = r.ItemCreated
+			resp.InventoryCreated = r.InventoryCreated
+			resp.StockQuantity = r.Inventory.Quantity()
+			out.Body.Received++
+			out.Body.Results[i] = resp
+
+			publish(ctx, broadcaster, workspaceID, r)
+		}
+		return out, nil
+	}
+}
+
+// publish announces what one received line created or changed, as the
+// separate item and inventory endpoints do.
+func publish(ctx context.Context, broadcaster *events.Broadcaster, workspaceID uuid.UUID, r LineResult) {
+	authUser, _ := appMiddleware.GetAuthUser(ctx)
+	if broadcaster == nil || authUser == nil {
+		return
+	}
+	userName := appMiddleware.GetUserDisplayName(ctx)
+
+	if r.ItemCreated {
+		broadcaster.Publish(workspaceID, events.Event{
+			Type:       "item.created",
+			EntityID:   r.Item.ID().String(),
+			EntityType: "item",
+			UserID:     authUser.ID,
+			Data: map[string]any{
+				"id":        r.Item.ID(),
+				"sku":       r.Item.SKU(),
+				"name":      r.Item.Name(),
+				"user_name": userName,
+			},
+		})
+	}
+
+	eventType := "inventory.updated"
+	if r.InventoryCreated {
+		eventType = "inventory.created"
+	}
+	broadcaster.Publish(workspaceID, events.Event{
+		Type:       eventType,
+		EntityID:   r.Inventory.ID().String(),
+		EntityType: "inventory",
+		UserID:     authUser.ID,
+		Data: map[string]any{
+			"id":        r.Inventory.ID(),
+			"item_id":   r.Item.ID(),
+			"quantity":  r.Inventory.Quantity(),
+			"received":  r.Line.Quantity,
+			"user_name": userName,
+		},
+	})
+}
+
+// Request/Response types
+
+type ReceiveRequest struct {
+	Body struct {
+		Lines         []ReceiveLineRequest `json:"lines" minItems:"1" maxItems:"200" doc:"Scanned barcodes of the shipment"`
+		CreateMissing bool                 `json:"create_missing,omitempty" doc:"Create a stub item, flagged for review, for a barcode no item has. Otherwise such a line fails"`
+		Note          *string              `json:"note,omitempty" maxLength:"200" doc:"Recorded on the movement of each line, e.g. a delivery reference"`
+	}
+}
+
+type ReceiveLineRequest struct {
+	Barcode    string    `json:"barcode" minLength:"1" maxLength:"50"`
+	Quantity   int       `json:"quantity" minimum:"1"`
+	LocationID uuid.UUID `json:"location_id" doc:"Where the stock is put"`
+}
+
+type ReceiveOutput struct {
+	Body ReceiveResponse
+}
+
+type ReceiveResponse struct {
+	Results  []ReceiveLineResponse `json:"results"`
+	Received int                   `json:"received"`
+	Failed   int                   `json:"failed"`
+}
+
+type ReceiveLineResponse struct {
+	Barcode          string     `json:"barcode"`
+	LocationID       uuid.UUID  `json:"location_id"`
+	Quantity         int        `json:"quantity" doc:"Quantity received on this line"`
+	Success          bool       `json:"success"`
+	ItemID           *uuid.UUID `json:"item_id,omitempty"`
+	InventoryID      *uuid.UUID `json:"inventory_id,omitempty"`
+	ItemCreated      bool       `json:"item_created,omitempty" doc:"A stub item was created for the barcode"`
+	InventoryCreated bool       `json:"inventory_created,omitempty" doc:"A new inventory entry was created rather than an existing one topped up"`
+	StockQuantity    int        `json:"stock_quantity,omitempty" doc:"Quantity of the entry after receiving"`
+	Error            string     `json:"error,omitempty"`
+}
@@ -0,0 +1,93 @@
+package receiving_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/inventory"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/item"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/receiving"
+	"github.com/antti/home-warehouse/go-backend/internal/testutil"
+)
+
+// MockService implements receiving.ServiceInterface
+type MockService struct {
+	mock.Mock
+}
+
+func (m *MockService) Receive(ctx context.Context, input receiving.Input) ([]receiving.LineResult, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]receiving.LineResult), args.Error(1)
+}
+
+func TestReceivingHandler_Receive(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	receiving.RegisterRoutes(setup.API, mockSvc, nil)
+	locationID := uuid.New()
+
+	t.Run("returns a result per line", func(t *testing.T) {
+		setup.SetRole("admin")
+		it, err := item.NewItem(setup.WorkspaceID, "Unknown item 0000000000000", "RCV-0000000000000", 0)
+		require.NoError(t, err)
+		inv, err := inventory.NewInventory(setup.WorkspaceID, it.ID(), locationID, nil, 12, inventory.ConditionNew, inventory.StatusAvailable, nil)
+		require.NoError(t, err)
+
+		mockSvc.On("Receive", mock.Anything, mock.MatchedBy(func(in receiving.Input) bool {
+			return in.WorkspaceID == setup.WorkspaceID && in.CreateMissing && len(in.Lines) == 2 &&
+				in.Lines[0] == receiving.Line{Barcode: "0000000000000", Quantity: 12, LocationID: locationID} &&
+				in.UserID != nil && *in.UserID == setup.UserID
+		})).Return([]receiving.LineResult{
+			{Line: receiving.Line{Barcode: "0000000000000", Quantity: 12, LocationID: locationID}, Item: it, Inventory: inv, ItemCreated: true, InventoryCreated: true},
+			{Line: receiving.Line{Barcode: "1111111111111", Quantity: 1, LocationID: locationID}, Err: receiving.ErrUnknownBarcode},
+		}, nil).Once()
+
+		rec := setup.Post("/receiving", `{"create_missing":true,"lines":[
+			{"barcode":"0000000000000","quantity":12,"location_id":"`+locationID.String()+`"},
+			{"barcode":"1111111111111","quantity":1,"location_id":"`+locationID.String()+`"}]}`)
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		body := testutil.ParseJSONResponse[receiving.ReceiveResponse](t, rec)
+		assert.Equal(t, 1, body.Received)
+		assert.Equal(t, 1, body.Failed)
+		require.Len(t, body.Results, 2)
+		ok, failed := body.Results[0], body.Results[1]
+		assert.True(t, ok.Success)
+		assert.True(t, ok.ItemCreated)
+		assert.True(t, ok.InventoryCreated)
+		assert.Equal(t, it.ID(), *ok.ItemID)
+		assert.Equal(t, inv.ID(), *ok.InventoryID)
+		assert.Equal(t, 12, ok.StockQuantity)
+		assert.False(t, failed.Success)
+		assert.Equal(t, "1111111111111", failed.Barcode)
+		assert.Equal(t, receiving.ErrUnknownBarcode.Error(), failed.Error)
+		mockSvc.AssertExpectations(t)
+	})
+
+	t.Run("rejects a line without quantity", func(t *testing.T) {
+		setup.SetRole("owner")
+
+		rec := setup.Post("/receiving", `{"lines":[{"barcode":"0000000000000","quantity":0,"location_id":"`+locationID.String()+`"}]}`)
+
+		testutil.AssertStatus(t, rec, http.StatusUnprocessableEntity)
+	})
+
+	for _, role := range []string{"member", "viewer"} {
+		t.Run(role+" cannot receive", func(t *testing.T) {
+			setup.SetRole(role)
+
+			rec := setup.Post("/receiving", `{"lines":[{"barcode":"0000000000000","quantity":1,"location_id":"`+locationID.String()+`"}]}`)
+
+			testutil.AssertStatus(t, rec, http.StatusForbidden)
+		})
+	}
+}
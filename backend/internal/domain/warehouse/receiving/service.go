@@ -0,0 +1,270 @@
+// Package receiving books a scanned shipment into stock: each line's barcode
+// is resolved to an item and its quantity added to the item's inventory at
+// the line's location, with a movement recorded for the delivery.
+package receiving
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/inventory"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/item"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/movement"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// MaxLines caps how many lines one receiving request may book.
+const MaxLines = 200
+
+// ReceiveReason is the reason recorded on the movement of received stock.
+const ReceiveReason = "received"
+
+// stubSKUPrefix prefixes the SKU of an item created for an unknown barcode.
+const stubSKUPrefix = "RCV-"
+
+// maxSKULength is the length of warehouse.items.sku.
+const maxSKULength = 50
+
+var (
+	ErrNoLines         = shared.NewFieldError(shared.ErrInvalidInput, "lines", "at least one line is required")
+	ErrTooManyLines    = shared.NewFieldError(shared.ErrInvalidInput, "lines", fmt.Sprintf("at most %d lines can be received at once", MaxLines))
+	ErrUnknownBarcode  = shared.NewDomainError(shared.ErrNotFound, "no item has this barcode")
+	ErrEmptyBarcode    = shared.NewFieldError(shared.ErrInvalidInput, "barcode", "barcode is required")
+	ErrInvalidQuantity = shared.NewFieldError(shared.ErrInvalidInput, "quantity", "quantity must be greater than zero")
+)
+
+// ItemService resolves and creates items (item.Service).
+type ItemService interface {
+	LookupByBarcode(ctx context.Context, workspaceID uuid.UUID, code string) (*item.Item, *item.Identifier, error)
+	Create(ctx context.Context, input item.CreateInput) (*item.Item, error)
+}
+
+// InventoryService finds, tops up and creates inventory entries
+// (inventory.Service).
+type InventoryService interface {
+	ListByItem(ctx context.Context, workspaceID, itemID uuid.UUID) ([]*inventory.Inventory, error)
+	AddQuantity(ctx context.Context, id, workspaceID uuid.UUID, amount int) (*inventory.Inventory, error)
+	Create(ctx context.Context, input inventory.CreateInput) (*inventory.Inventory, error)
+}
+
+// MovementRecorder records inventory movements (movement.Service).
+type MovementRecorder interface {
+	RecordMovement(ctx context.Context, input movement.RecordMovementInput) (*movement.InventoryMovement, error)
+}
+
+// Transactor runs a function inside a single database transaction; the
+// repositories pick the transaction up from the context.
+type Transactor interface {
+	WithTx(ctx context.Context, fn func(context.Context) error) error
+}
+
+// noopTransactor runs the function without a transaction (unit tests).
+type noopTransactor struct{}
+
+func (noopTransactor) WithTx(ctx context.Context, fn func(context.Context) error) error {
+	return fn(ctx)
+}
+
+// Line is one scanned barcode of a shipment.
+type Line struct {
+	Barcode    string
+	Quantity   int
+	LocationID uuid.UUID
+}
+
+// Input is a shipment to receive. CreateMissing creates a stub item, flagged
+// for review, for a barcode no item has; otherwise such a line fails.
+type Input struct {
+	WorkspaceID   uuid.UUID
+	UserID        *uuid.UUID
+	Lines         []Line
+	CreateMissing bool
+	Note          *string
+}
+
+// LineResult is the outcome of one line. Item and Inventory are nil when Err
+// is set.
+type LineResult struct {
+	Line             Line
+	Item             *item.Item
+	Inventory        *inventory.Inventory
+	ItemCreated      bool
+	InventoryCreated bool
+	Err              error
+}
+
+// ServiceInterface defines the receiving operations.
+type ServiceInterface interface {
+	Receive(ctx context.Context, input Input) ([]LineResult, error)
+}
+
+type Service struct {
+	items     ItemService
+	inventory InventoryService
+	movements MovementRecorder
+	tx        Transactor
+}
+
+func NewService(items ItemService, inventory InventoryService, movements MovementRecorder) *Service {
+	return &Service{
+		items:     items,
+		inventory: inventory,
+		movements: movements,
+		tx:        noopTransactor{},
+	}
+}
+
+// SetTransactor makes each line atomic. Without it a failed step leaves the
+// line's earlier ones in place.
+func (s *Service) SetTransactor(tx Transactor) {
+	s.tx = tx
+}
+
+// Receive books each line in its own transaction, so a line that cannot be
+// received (unknown barcode, missing location) is reported in the results
+// without holding back the rest of the shipment. The returned error is for
+// the request as a whole; lines before an unexpected failure stay received.
+func (s *Service) Receive(ctx context.Context, input Input) ([]LineResult, error) {
+	if len(input.Lines) == 0 {
+		return nil, ErrNoLines
+	}
+	if len(input.Lines) > MaxLines {
+		return nil, ErrTooManyLines
+	}
+
+	results := make([]LineResult, len(input.Lines))
+	for i, line := range input.Lines {
+		line.Barcode = strings.TrimSpace(line.Barcode)
+		results[i] = LineResult{Line: line}
+
+		var result LineResult
+		err := s.tx.WithTx(ctx, func(ctx context.Context) error {
+			var err error
+			result, err = s.receiveLine(ctx, input, line)
+			return err
+		})
+		if err != nil {
+			if !isLineError(err) {
+				return nil, err
+			}
+			results[i].Err = err
+			continue
+		}
+		results[i] = result
+	}
+	return results, nil
+}
+
+// receiveLine resolves the line's item and adds the quantity to its
+// available, uncontained entry at the location, creating the entry when
+// there is none.
+func (s *Service) receiveLine(ctx context.Context, input Input, line Line) (LineResult, error) {
+	result := LineResult{Line: line}
+	if line.Barcode == "" {
+		return result, ErrEmptyBarcode
+	}
+	if line.Quantity <= 0 {
+		return result, ErrInvalidQuantity
+	}
+
+	it, _, err := s.items.LookupByBarcode(ctx, input.WorkspaceID, line.Barcode)
+	switch {
+	case errors.Is(err, item.ErrItemNotFound):
+		if !input.CreateMissing {
+			return result, ErrUnknownBarcode
+		}
+		it, err = s.items.Create(ctx, stubItemInput(input.WorkspaceID, line.Barcode))
+		if err != nil {
+			return result, err
+		}
+		result.ItemCreated = true
+	case err != nil:
+		return result, err
+	}
+	result.Item = it
+
+	entries, err := s.inventory.ListByItem(ctx, input.WorkspaceID, it.ID())
+	if err != nil {
+		return result, err
+	}
+	var inv *inventory.Inventory
+	if existing := receivingEntry(entries, line.LocationID); existing != nil {
+		// Added in the database rather than from the quantity just read, so a
+		// concurrent receipt or edit of the entry is not overwritten.
+		inv, err = s.inventory.AddQuantity(ctx, existing.ID(), input.WorkspaceID, line.Quantity)
+	} else {
+		inv, err = s.inventory.Create(ctx, inventory.CreateInput{
+			WorkspaceID: input.WorkspaceID,
+			ItemID:      it.ID(),
+			LocationID:  line.LocationID,
+			Quantity:    line.Quantity,
+			Status:      inventory.StatusAvailable,
+		})
+		result.InventoryCreated = err == nil
+	}
+	if err != nil {
+		return result, err
+	}
+	result.Inventory = inv
+
+	reason := ReceiveReason
+	if input.Note != nil && *input.Note != "" {
+		reason = ReceiveReason + ": " + *input.Note
+	}
+	locationID := line.LocationID
+	if _, err := s.movements.RecordMovement(ctx, movement.RecordMovementInput{
+		WorkspaceID:  input.WorkspaceID,
+		InventoryID:  inv.ID(),
+		ToLocationID: &locationID,
+		Quantity:     line.Quantity,
+		MovedBy:      input.UserID,
+		Reason:       &reason,
+	}); err != nil {
+		return result, err
+	}
+	return result, nil
+}
+
+// receivingEntry returns the entry received stock is added to: the item's
+// unarchived, available entry at the location that is not in a container.
+func receivingEntry(entries []*inventory.Inventory, locationID uuid.UUID) *inventory.Inventory {
+	for _, inv := range entries {
+		if inv.LocationID() == locationID && inv.ContainerID() == nil &&
+			!inv.IsArchived() && inv.Status() == inventory.StatusAvailable {
+			return inv
+		}
+	}
+	return nil
+}
+
+// stubItemInput is the item created for an unknown barcode. It carries the
+// barcode so the next scan finds it, and is flagged for review so someone
+// fills in the details.
+func stubItemInput(workspaceID uuid.UUID, barcode string) item.CreateInput {
+	sku := stubSKUPrefix + barcode
+	if len(sku) > maxSKULength {
+		sku = sku[:maxSKULength]
+	}
+	needsReview := true
+	return item.CreateInput{
+		WorkspaceID: workspaceID,
+		SKU:         sku,
+		Name:        "Unknown item " + barcode,
+		Barcode:     &barcode,
+		NeedsReview: &needsReview,
+	}
+}
+
+// isLineError reports whether err is a problem with the line itself rather
+// than a failure of the request.
+func isLineError(err error) bool {
+	var domainErr *shared.DomainError
+	return errors.As(err, &domainErr) ||
+		errors.Is(err, item.ErrSKUTaken) ||
+		errors.Is(err, inventory.ErrItemPhotoRequired) ||
+		errors.Is(err, inventory.ErrInsufficientQuantity)
+}
@@ -0,0 +1,316 @@
+package receiving
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/inventory"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/item"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/movement"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// fakeItems resolves barcodes from a map and records the items it creates.
+type fakeItems struct {
+	byBarcode map[string]*item.Item
+	created   []item.CreateInput
+}
+
+func (f *fakeItems) LookupByBarcode(_ context.Context, _ uuid.UUID, code string) (*item.Item, *item.Identifier, error) {
+	if it, ok := f.byBarcode[code]; ok {
+		return it, nil, nil
+	}
+	return nil, nil, item.ErrItemNotFound
+}
+
+func (f *fakeItems) Create(_ context.Context, input item.CreateInput) (*item.Item, error) {
+	f.created = append(f.created, input)
+	it, err := item.NewItem(input.WorkspaceID, input.Name, input.SKU, 0)
+	if err != nil {
+		return nil, err
+	}
+	f.byBarcode[*input.Barcode] = it
+	return it, nil
+}
+
+// fakeInventory keeps entries in memory. Creating an entry at badLocation
+// fails as inventory.Service does for a location outside the workspace.
+type fakeInventory struct {
+	entries     []*inventory.Inventory
+	badLocation uuid.UUID
+	failList    error
+	// afterList runs once ListByItem has answered, to change entries behind
+	// the caller's back.
+	afterList func()
+}
+
+func (f *fakeInventory) ListByItem(_ context.Context, _, itemID uuid.UUID) ([]*inventory.Inventory, error) {
+	if f.failList != nil {
+		return nil, f.failList
+	}
+	var out []*inventory.Inventory
+	for _, inv := range f.entries {
+		if inv.ItemID() == itemID {
+			out = append(out, inv)
+		}
+	}
+	if f.afterList != nil {
+		// Hand out copies, so the later change is not visible through them.
+		for i, inv := range out {
+			out[i] = inventory.Reconstruct(inv.ID(), inv.WorkspaceID(), inv.ItemID(), inv.LocationID(), inv.ContainerID(), inv.Quantity(),
+				inv.Condition(), inv.Status(), nil, nil, nil, nil, nil, nil, inv.IsArchived(), inv.CreatedAt(), inv.UpdatedAt())
+		}
+		f.afterList()
+	}
+	return out, nil
+}
+
+// AddQuantity adds to the stored entry, as the database does, regardless of
+// the quantity the caller last read.
+func (f *fakeInventory) AddQuantity(_ context.Context, id, _ uuid.UUID, amount int) (*inventory.Inventory, error) {
+	for _, inv := range f.entries {
+		if inv.ID() == id {
+			if err := inv.UpdateQuantity(inv.Quantity() + amount); err != nil {
+				return nil, err
+			}
+			return inv, nil
+		}
+	}
+	return nil, inventory.ErrInventoryNotFound
+}
+
+func (f *fakeInventory) Create(_ context.Context, input inventory.CreateInput) (*inventory.Inventory, error) {
+	if input.LocationID == f.badLocation {
+		return nil, shared.NewFieldError(shared.ErrNotFound, "location_id", "location not found in this workspace")
+	}
+	inv, err := inventory.NewInventory(input.WorkspaceID, input.ItemID, input.LocationID, input.ContainerID,
+		input.Quantity, inventory.ConditionNew, input.Status, nil)
+	if err != nil {
+		return nil, err
+	}
+	f.entries = append(f.entries, inv)
+	return inv, nil
+}
+
+type fakeMovements struct {
+	recorded []movement.RecordMovementInput
+}
+
+func (f *fakeMovements) RecordMovement(_ context.Context, input movement.RecordMovementInput) (*movement.InventoryMovement, error) {
+	f.recorded = append(f.recorded, input)
+	return nil, nil
+}
+
+// countingTransactor counts the transactions it runs.
+type countingTransactor struct{ calls int }
+
+func (c *countingTransactor) WithTx(ctx context.Context, fn func(context.Context) error) error {
+	c.calls++
+	return fn(ctx)
+}
+
+func newEntry(workspaceID, itemID, locationID uuid.UUID, containerID *uuid.UUID, quantity int, status inventory.Status, archived bool) *inventory.Inventory {
+	now := time.Now()
+	return inventory.Reconstruct(uuid.New(), workspaceID, itemID, locationID, containerID, quantity,
+		inventory.ConditionGood, status, nil, nil, nil, nil, nil, nil, archived, now, now)
+}
+
+func TestService_Receive(t *testing.T) {
+	ctx := context.Background()
+	workspaceID, userID, locationID := uuid.New(), uuid.New(), uuid.New()
+
+	newFixture := func(t *testing.T) (*fakeItems, *fakeInventory, *fakeMovements, *item.Item) {
+		t.Helper()
+		it, err := item.NewItem(workspaceID, "AA batteries", "BAT-AA", 0)
+		require.NoError(t, err)
+		return &fakeItems{byBarcode: map[string]*item.Item{"6410405060457": it}}, &fakeInventory{}, &fakeMovements{}, it
+	}
+
+	t.Run("known barcode tops up the available entry at the location", func(t *testing.T) {
+		items, inv, movements, it := newFixture(t)
+		otherContainer := uuid.New()
+		target := newEntry(workspaceID, it.ID(), locationID, nil, 4, inventory.StatusAvailable, false)
+		inv.entries = []*inventory.Inventory{
+			newEntry(workspaceID, it.ID(), locationID, &otherContainer, 10, inventory.StatusAvailable, false),
+			newEntry(workspaceID, it.ID(), locationID, nil, 10, inventory.StatusInUse, false),
+			newEntry(workspaceID, it.ID(), locationID, nil, 10, inventory.StatusAvailable, true),
+			target,
+		}
+
+		results, err := NewService(items, inv, movements).Receive(ctx, Input{
+			WorkspaceID: workspaceID,
+			UserID:      &userID,
+			Lines:       []Line{{Barcode: " 6410405060457 ", Quantity: 6, LocationID: locationID}},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		r := results[0]
+		require.NoError(t, r.Err)
+		assert.Same(t, target, r.Inventory)
+		assert.Equal(t, 10, target.Quantity())
+		assert.False(t, r.ItemCreated)
+		assert.False(t, r.InventoryCreated)
+		assert.Empty(t, items.created)
+
+		require.Len(t, movements.recorded, 1)
+		m := movements.recorded[0]
+		assert.Equal(t, target.ID(), m.InventoryID)
+		assert.Equal(t, 6, m.Quantity)
+		assert.Nil(t, m.FromLocationID)
+		assert.Equal(t, &locationID, m.ToLocationID)
+		assert.Equal(t, &userID, m.MovedBy)
+		assert.Equal(t, ReceiveReason, *m.Reason)
+	})
+
+	t.Run("top-up adds to the stored quantity, not the one read", func(t *testing.T) {
+		items, inv, movements, it := newFixture(t)
+		target := newEntry(workspaceID, it.ID(), locationID, nil, 4, inventory.StatusAvailable, false)
+		inv.entries = []*inventory.Inventory{target}
+		// Another receipt of 5 lands between the read and the top-up.
+		inv.afterList = func() { require.NoError(t, target.UpdateQuantity(target.Quantity()+5)) }
+
+		results, err := NewService(items, inv, movements).Receive(ctx, Input{
+			WorkspaceID: workspaceID,
+			Lines:       []Line{{Barcode: "6410405060457", Quantity: 6, LocationID: locationID}},
+		})
+
+		require.NoError(t, err)
+		require.NoError(t, results[0].Err)
+		assert.Equal(t, 15, target.Quantity())
+		assert.Equal(t, 15, results[0].Inventory.Quantity())
+	})
+
+	t.Run("known barcode without an entry at the location creates one", func(t *testing.T) {
+		items, inv, movements, it := newFixture(t)
+		inv.entries = []*inventory.Inventory{newEntry(workspaceID, it.ID(), uuid.New(), nil, 3, inventory.StatusAvailable, false)}
+		note := "PO-1042"
+
+		results, err := NewService(items, inv, movements).Receive(ctx, Input{
+			WorkspaceID: workspaceID,
+			Lines:       []Line{{Barcode: "6410405060457", Quantity: 2, LocationID: locationID}},
+			Note:        &note,
+		})
+
+		require.NoError(t, err)
+		r := results[0]
+		require.NoError(t, r.Err)
+		assert.True(t, r.InventoryCreated)
+		assert.Equal(t, locationID, r.Inventory.LocationID())
+		assert.Equal(t, 2, r.Inventory.Quantity())
+		assert.Equal(t, inventory.StatusAvailable, r.Inventory.Status())
+		assert.Len(t, inv.entries, 2)
+		require.Len(t, movements.recorded, 1)
+		assert.Equal(t, "received: PO-1042", *movements.recorded[0].Reason)
+	})
+
+	t.Run("unknown barcode fails the line unless stubs are allowed", func(t *testing.T) {
+		items, inv, movements, _ := newFixture(t)
+
+		results, err := NewService(items, inv, movements).Receive(ctx, Input{
+			WorkspaceID: workspaceID,
+			Lines: []Line{
+				{Barcode: "0000000000000", Quantity: 1, LocationID: locationID},
+				{Barcode: "6410405060457", Quantity: 1, LocationID: locationID},
+			},
+		})
+
+		require.NoError(t, err)
+		require.Len(t, results, 2)
+		assert.ErrorIs(t, results[0].Err, ErrUnknownBarcode)
+		assert.Nil(t, results[0].Inventory)
+		assert.NoError(t, results[1].Err, "the rest of the shipment is received")
+		assert.Empty(t, items.created)
+		assert.Len(t, movements.recorded, 1)
+	})
+
+	t.Run("unknown barcode creates a stub item when allowed", func(t *testing.T) {
+		items, inv, movements, _ := newFixture(t)
+
+		results, err := NewService(items, inv, movements).Receive(ctx, Input{
+			WorkspaceID:   workspaceID,
+			Lines:         []Line{{Barcode: "0000000000000", Quantity: 12, LocationID: locationID}},
+			CreateMissing: true,
+		})
+
+		require.NoError(t, err)
+		r := results[0]
+		require.NoError(t, r.Err)
+		assert.True(t, r.ItemCreated)
+		assert.True(t, r.InventoryCreated)
+		assert.Equal(t, 12, r.Inventory.Quantity())
+		require.Len(t, items.created, 1)
+		stub := items.created[0]
+		assert.Equal(t, "RCV-0000000000000", stub.SKU)
+		assert.Equal(t, "0000000000000", *stub.Barcode)
+		assert.True(t, *stub.NeedsReview)
+		assert.Len(t, movements.recorded, 1)
+	})
+
+	t.Run("stub SKU fits the column", func(t *testing.T) {
+		barcode := "12345678901234567890123456789012345678901234567890"
+
+		input := stubItemInput(workspaceID, barcode)
+
+		assert.Len(t, input.SKU, maxSKULength)
+		assert.Equal(t, barcode, *input.Barcode)
+	})
+
+	t.Run("line with an unknown location fails on its own", func(t *testing.T) {
+		items, inv, movements, _ := newFixture(t)
+		inv.badLocation = uuid.New()
+		tx := &countingTransactor{}
+		svc := NewService(items, inv, movements)
+		svc.SetTransactor(tx)
+
+		results, err := svc.Receive(ctx, Input{
+			WorkspaceID: workspaceID,
+			Lines: []Line{
+				{Barcode: "6410405060457", Quantity: 1, LocationID: inv.badLocation},
+				{Barcode: "6410405060457", Quantity: 1, LocationID: locationID},
+			},
+		})
+
+		require.NoError(t, err)
+		assert.ErrorIs(t, results[0].Err, shared.ErrNotFound)
+		assert.NoError(t, results[1].Err)
+		assert.Equal(t, 2, tx.calls, "each line runs in its own transaction")
+	})
+
+	t.Run("unexpected failure aborts the request", func(t *testing.T) {
+		items, inv, movements, _ := newFixture(t)
+		inv.failList = errors.New("connection reset")
+
+		_, err := NewService(items, inv, movements).Receive(ctx, Input{
+			WorkspaceID: workspaceID,
+			Lines:       []Line{{Barcode: "6410405060457", Quantity: 1, LocationID: locationID}},
+		})
+
+		assert.EqualError(t, err, "connection reset")
+	})
+
+	t.Run("validates the lines", func(t *testing.T) {
+		items, inv, movements, _ := newFixture(t)
+		svc := NewService(items, inv, movements)
+
+		_, err := svc.Receive(ctx, Input{WorkspaceID: workspaceID})
+		assert.ErrorIs(t, err, ErrNoLines)
+
+		_, err = svc.Receive(ctx, Input{WorkspaceID: workspaceID, Lines: make([]Line, MaxLines+1)})
+		assert.ErrorIs(t, err, ErrTooManyLines)
+
+		results, err := svc.Receive(ctx, Input{WorkspaceID: workspaceID, Lines: []Line{
+			{Barcode: "  ", Quantity: 1, LocationID: locationID},
+			{Barcode: "6410405060457", Quantity: 0, LocationID: locationID},
+		}})
+		require.NoError(t, err)
+		assert.ErrorIs(t, results[0].Err, ErrEmptyBarcode)
+		assert.ErrorIs(t, results[1].Err, ErrInvalidQuantity)
+	})
+}
@@ -27,7 +27,7 @@ const (
 )
 
 // PhotoURLGenerator is a function that generates URLs for photos
-type PhotoURLGenerator func(workspaceID, repairLogID, photoID uuid.UUID, isThumbnail bool) string
+type PhotoURLGenerator func(ctx context.Context, workspaceID, repairLogID, photoID uuid.UUID, isThumbnail bool) string
 
 // StorageGetter is an interface for getting storage instances
 type StorageGetter interface {
@@ -76,7 +76,7 @@ func listPhotos(svc ServiceInterface, urlGenerator PhotoURLGenerator) func(conte
 
 		items := make([]RepairPhotoResponse, len(photos))
 		for i, photo := range photos {
-			items[i] = toRepairPhotoResponse(photo, urlGenerator)
+			items[i] = toRepairPhotoResponse(ctx, photo, urlGenerator)
 		}
 
 		return &ListPhotosOutput{
@@ -99,7 +99,7 @@ func getPhoto(svc ServiceInterface, urlGenerator PhotoURLGenerator) func(context
 		}
 
 		return &GetPhotoOutput{
-			Body: toRepairPhotoResponse(photo, urlGenerator),
+			Body: toRepairPhotoResponse(ctx, photo, urlGenerator),
 		}, nil
 	}
 }
@@ -145,7 +145,7 @@ func updateCaption(svc ServiceInterface, broadcaster *events.Broadcaster, urlGen
 		}
 
 		return &UpdateCaptionOutput{
-			Body: toRepairPhotoResponse(photo, urlGenerator),
+			Body: toRepairPhotoResponse(ctx, photo, urlGenerator),
 		}, nil
 	}
 }
@@ -309,7 +309,7 @@ func (h *UploadHandler) HandleUpload(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Return response
-	response := toRepairPhotoResponse(photo, h.urlGenerator)
+	response := toRepairPhotoResponse(r.Context(), photo, h.urlGenerator)
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(http.StatusCreated)
 	json.NewEncoder(w).Encode(response)
@@ -400,7 +400,7 @@ func (h *ServePhotoHandler) servePhoto(w http.ResponseWriter, r *http.Request, t
 }
 
 // Helper function to convert entity to response
-func toRepairPhotoResponse(p *RepairPhoto, urlGenerator PhotoURLGenerator) RepairPhotoResponse {
+func toRepairPhotoResponse(ctx context.Context, p *RepairPhoto, urlGenerator PhotoURLGenerator) RepairPhotoResponse {
 	return RepairPhotoResponse{
 		ID:           p.ID,
 		RepairLogID:  p.RepairLogID,
@@ -413,8 +413,8 @@ func toRepairPhotoResponse(p *RepairPhoto, urlGenerator PhotoURLGenerator) Repai
 		Height:       p.Height,
 		DisplayOrder: p.DisplayOrder,
 		Caption:      p.Caption,
-		URL:          urlGenerator(p.WorkspaceID, p.RepairLogID, p.ID, false),
-		ThumbnailURL: urlGenerator(p.WorkspaceID, p.RepairLogID, p.ID, true),
+		URL:          urlGenerator(ctx, p.WorkspaceID, p.RepairLogID, p.ID, false),
+		ThumbnailURL: urlGenerator(ctx, p.WorkspaceID, p.RepairLogID, p.ID, true),
 		CreatedAt:    p.CreatedAt,
 		UpdatedAt:    p.UpdatedAt,
 	}
@@ -83,7 +83,7 @@ func newTestRepairPhoto(workspaceID, repairLogID uuid.UUID, photoType repairphot
 }
 
 // testURLGenerator is a simple URL generator for testing
-func testURLGenerator(workspaceID, repairLogID, photoID uuid.UUID, isThumbnail bool) string {
+func testURLGenerator(_ context.Context, workspaceID, repairLogID, photoID uuid.UUID, isThumbnail bool) string {
 	if isThumbnail {
 		return fmt.Sprintf("http://test/repairs/%s/photos/%s/thumbnail", repairLogID, photoID)
 	}
@@ -0,0 +1,310 @@
+package webhook
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/antti/home-warehouse/go-backend/internal/infra/events"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// Event types a webhook can subscribe to. They are the SSE event names the
+// rest of the app already publishes, so dispatch is a straight match.
+const (
+	EventLoanCreated           = "loan.created"
+	EventItemLowStock          = "item.low_stock"
+	EventPendingChangeApproved = "pendingchange.approved"
+)
+
+// SupportedEventTypes lists every event type a webhook can subscribe to.
+var SupportedEventTypes = []string{
+	EventLoanCreated,
+	EventItemLowStock,
+	EventPendingChangeApproved,
+}
+
+// IsSupportedEventType reports whether eventType can be subscribed to.
+func IsSupportedEventType(eventType string) bool {
+	return slices.Contains(SupportedEventTypes, eventType)
+}
+
+// secretPrefix marks webhook signing secrets so they are recognizable in
+// receiver configuration.
+const secretPrefix = "whsec_"
+
+// DeliveryStatus is the state of a single webhook delivery.
+type DeliveryStatus string
+
+const (
+	// DeliveryPending means the worker has not yet delivered the event and is
+	// still retrying.
+	DeliveryPending DeliveryStatus = "pending"
+	// DeliverySucceeded means the receiver answered with a 2xx status.
+	DeliverySucceeded DeliveryStatus = "succeeded"
+	// DeliveryFailed means every attempt failed, or the webhook was disabled
+	// before the event could be delivered.
+	DeliveryFailed DeliveryStatus = "failed"
+)
+
+// Webhook is a workspace's subscription to outgoing event notifications.
+type Webhook struct {
+	id          uuid.UUID
+	workspaceID uuid.UUID
+	url         string
+	secret      string
+	eventTypes  []string
+	isActive    bool
+	createdBy   *uuid.UUID
+	createdAt   time.Time
+	updatedAt   time.Time
+}
+
+// NewWebhook creates an active webhook with a freshly generated signing secret.
+func NewWebhook(workspaceID uuid.UUID, rawURL string, eventTypes []string, createdBy *uuid.UUID) (*Webhook, error) {
+	if err := shared.ValidateUUID(workspaceID, "workspace_id"); err != nil {
+		return nil, err
+	}
+	if err := validateURL(rawURL); err != nil {
+		return nil, err
+	}
+	eventTypes, err := normalizeEventTypes(eventTypes)
+	if err != nil {
+		return nil, err
+	}
+	secret, err := generateSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	return &Webhook{
+		id:          shared.NewUUID(),
+		workspaceID: workspaceID,
+		url:         rawURL,
+		secret:      secret,
+		eventTypes:  eventTypes,
+		isActive:    true,
+		createdBy:   createdBy,
+		createdAt:   now,
+		updatedAt:   now,
+	}, nil
+}
+
+// Reconstruct rebuilds a webhook from persistence.
+func Reconstruct(id, workspaceID uuid.UUID, rawURL, secret string, eventTypes []string, isActive bool, createdBy *uuid.UUID, createdAt, updatedAt time.Time) *Webhook {
+	return &Webhook{id, workspaceID, rawURL, secret, eventTypes, isActive, createdBy, createdAt, updatedAt}
+}
+
+func (w *Webhook) ID() uuid.UUID          { return w.id }
+func (w *Webhook) WorkspaceID() uuid.UUID { return w.workspaceID }
+func (w *Webhook) URL() string            { return w.url }
+func (w *Webhook) Secret() string         { return w.secret }
+func (w *Webhook) EventTypes() []string   { return w.eventTypes }
+func (w *Webhook) IsActive() bool         { return w.isActive }
+func (w *Webhook) CreatedBy() *uuid.UUID  { return w.createdBy }
+func (w *Webhook) CreatedAt() time.Time   { return w.createdAt }
+func (w *Webhook) UpdatedAt() time.Time   { return w.updatedAt }
+
+// Update changes the webhook's target, subscriptions and active flag. Nil
+// arguments leave the field unchanged.
+func (w *Webhook) Update(rawURL *string, eventTypes []string, isActive *bool) error {
+	if rawURL != nil {
+		if err := validateURL(*rawURL); err != nil {
+			return err
+		}
+	}
+	if eventTypes != nil {
+		normalized, err := normalizeEventTypes(eventTypes)
+		if err != nil {
+			return err
+		}
+		eventTypes = normalized
+	}
+
+	if rawURL != nil {
+		w.url = *rawURL
+	}
+	if eventTypes != nil {
+		w.eventTypes = eventTypes
+	}
+	if isActive != nil {
+		w.isActive = *isActive
+	}
+	w.updatedAt = time.Now()
+	return nil
+}
+
+// RotateSecret replaces the signing secret. Deliveries already queued are
+// signed with the new secret when they are sent.
+func (w *Webhook) RotateSecret() error {
+	secret, err := generateSecret()
+	if err != nil {
+		return err
+	}
+	w.secret = secret
+	w.updatedAt = time.Now()
+	return nil
+}
+
+// validateURL accepts http(s) URLs with a host. Private network addresses are
+// allowed on purpose (home automation usually lives on the LAN), but loopback,
+// link-local (cloud metadata) and unspecified addresses are not, since the
+// worker would otherwise POST to services on the server itself. Only literal
+// addresses can be checked here; the delivery worker checks the address a
+// hostname resolves to when it dials.
+func validateURL(rawURL string) error {
+	if rawURL == "" {
+		return shared.NewFieldError(shared.ErrInvalidInput, "url", "webhook URL is required")
+	}
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+		return shared.NewFieldError(shared.ErrInvalidInput, "url", "webhook URL must be a valid http or https URL")
+	}
+	host := u.Hostname()
+	if host == "" {
+		return shared.NewFieldError(shared.ErrInvalidInput, "url", "webhook URL must have a host")
+	}
+	if strings.EqualFold(host, "localhost") || strings.HasSuffix(strings.ToLower(host), ".localhost") {
+		return shared.NewFieldError(shared.ErrInvalidInput, "url", "webhook URL must not target localhost")
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		if ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsUnspecified() {
+			return shared.NewFieldError(shared.ErrInvalidInput, "url", "webhook URL must not target a loopback or link-local address")
+		}
+	}
+	return nil
+}
+
+// normalizeEventTypes rejects unknown event types and drops duplicates.
+func normalizeEventTypes(eventTypes []string) ([]string, error) {
+	if len(eventTypes) == 0 {
+		return nil, shared.NewFieldError(shared.ErrInvalidInput, "event_types", "at least one event type is required")
+	}
+	out := make([]string, 0, len(eventTypes))
+	for _, t := range eventTypes {
+		if !IsSupportedEventType(t) {
+			return nil, shared.NewFieldError(shared.ErrInvalidInput, "event_types",
+				fmt.Sprintf("unsupported event type %q (supported: %s)", t, strings.Join(SupportedEventTypes, ", ")))
+		}
+		if !slices.Contains(out, t) {
+			out = append(out, t)
+		}
+	}
+	return out, nil
+}
+
+func generateSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+	return secretPrefix + hex.EncodeToString(buf), nil
+}
+
+// Payload is the JSON body POSTed to a webhook.
+type Payload struct {
+	// DeliveryID identifies this delivery; retries of the same event reuse it,
+	// so receivers can use it to drop duplicates.
+	DeliveryID  uuid.UUID      `json:"delivery_id"`
+	Event       string         `json:"event"`
+	WorkspaceID uuid.UUID      `json:"workspace_id"`
+	OccurredAt  time.Time      `json:"occurred_at"`
+	EntityType  string         `json:"entity_type"`
+	EntityID    string         `json:"entity_id,omitempty"`
+	ActorID     *uuid.UUID     `json:"actor_id,omitempty"`
+	Data        map[string]any `json:"data,omitempty"`
+}
+
+// Delivery is one event queued for, or sent to, a webhook.
+type Delivery struct {
+	id             uuid.UUID
+	webhookID      uuid.UUID
+	workspaceID    uuid.UUID
+	eventType      string
+	payload        json.RawMessage
+	status         DeliveryStatus
+	attempts       int
+	responseStatus *int
+	lastError      *string
+	deliveredAt    *time.Time
+	createdAt      time.Time
+	updatedAt      time.Time
+}
+
+// NewDelivery builds a pending delivery of event to w. The payload is
+// rendered once here and stored, so every retry sends identical bytes.
+func NewDelivery(w *Webhook, event events.Event) (*Delivery, error) {
+	id := shared.NewUUID()
+
+	var actorID *uuid.UUID
+	if event.UserID != uuid.Nil {
+		actorID = &event.UserID
+	}
+	occurredAt := event.Timestamp
+	if occurredAt.IsZero() {
+		occurredAt = time.Now().UTC()
+	}
+
+	body, err := json.Marshal(Payload{
+		DeliveryID:  id,
+		Event:       event.Type,
+		WorkspaceID: w.workspaceID,
+		OccurredAt:  occurredAt,
+		EntityType:  event.EntityType,
+		EntityID:    event.EntityID,
+		ActorID:     actorID,
+		Data:        event.Data,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode webhook payload: %w", err)
+	}
+
+	now := time.Now()
+	return &Delivery{
+		id:          id,
+		webhookID:   w.id,
+		workspaceID: w.workspaceID,
+		eventType:   event.Type,
+		payload:     body,
+		status:      DeliveryPending,
+		createdAt:   now,
+		updatedAt:   now,
+	}, nil
+}
+
+// ReconstructDelivery rebuilds a delivery from persistence.
+func ReconstructDelivery(
+	id, webhookID, workspaceID uuid.UUID,
+	eventType string,
+	payload json.RawMessage,
+	status DeliveryStatus,
+	attempts int,
+	responseStatus *int,
+	lastError *string,
+	deliveredAt *time.Time,
+	createdAt, updatedAt time.Time,
+) *Delivery {
+	return &Delivery{id, webhookID, workspaceID, eventType, payload, status, attempts, responseStatus, lastError, deliveredAt, createdAt, updatedAt}
+}
+
+func (d *Delivery) ID() uuid.UUID            { return d.id }
+func (d *Delivery) WebhookID() uuid.UUID     { return d.webhookID }
+func (d *Delivery) WorkspaceID() uuid.UUID   { return d.workspaceID }
+func (d *Delivery) EventType() string        { return d.eventType }
+func (d *Delivery) Payload() json.RawMessage { return d.payload }
+func (d *Delivery) Status() DeliveryStatus   { return d.status }
+func (d *Delivery) Attempts() int            { return d.attempts }
+func (d *Delivery) ResponseStatus() *int     { return d.responseStatus }
+func (d *Delivery) LastError() *string       { return d.lastError }
+func (d *Delivery) DeliveredAt() *time.Time  { return d.deliveredAt }
+func (d *Delivery) CreatedAt() time.Time     { return d.createdAt }
+func (d *Delivery) UpdatedAt() time.Time     { return d.updatedAt }
@@ -0,0 +1,108 @@
+package webhook
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/infra/events"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+func TestNewWebhook(t *testing.T) {
+	workspaceID := uuid.New()
+
+	t.Run("creates active webhook with generated secret", func(t *testing.T) {
+		w, err := NewWebhook(workspaceID, "https://hooks.example.com/in", []string{EventLoanCreated, EventLoanCreated, EventItemLowStock}, nil)
+
+		require.NoError(t, err)
+		assert.True(t, w.IsActive())
+		assert.Equal(t, []string{EventLoanCreated, EventItemLowStock}, w.EventTypes(), "duplicates are dropped")
+		assert.True(t, strings.HasPrefix(w.Secret(), secretPrefix))
+		assert.Len(t, w.Secret(), len(secretPrefix)+64)
+	})
+
+	t.Run("allows private network hosts", func(t *testing.T) {
+		_, err := NewWebhook(workspaceID, "http://192.168.1.20:8123/api/webhook/warehouse", []string{EventLoanCreated}, nil)
+		assert.NoError(t, err)
+	})
+
+	tests := []struct {
+		name       string
+		url        string
+		eventTypes []string
+	}{
+		{"empty URL", "", []string{EventLoanCreated}},
+		{"unsupported scheme", "ftp://example.com/hook", []string{EventLoanCreated}},
+		{"localhost", "http://localhost:8080/hook", []string{EventLoanCreated}},
+		{"loopback IP", "http://127.0.0.1/hook", []string{EventLoanCreated}},
+		{"link-local metadata IP", "http://169.254.169.254/latest", []string{EventLoanCreated}},
+		{"no event types", "https://example.com/hook", nil},
+		{"unknown event type", "https://example.com/hook", []string{"item.exploded"}},
+	}
+	for _, tt := range tests {
+		t.Run("rejects "+tt.name, func(t *testing.T) {
+			_, err := NewWebhook(workspaceID, tt.url, tt.eventTypes, nil)
+			assert.ErrorIs(t, err, shared.ErrInvalidInput)
+		})
+	}
+}
+
+func TestWebhook_Update(t *testing.T) {
+	w, err := NewWebhook(uuid.New(), "https://example.com/hook", []string{EventLoanCreated}, nil)
+	require.NoError(t, err)
+
+	inactive := false
+	require.NoError(t, w.Update(nil, []string{EventPendingChangeApproved}, &inactive))
+	assert.Equal(t, "https://example.com/hook", w.URL())
+	assert.Equal(t, []string{EventPendingChangeApproved}, w.EventTypes())
+	assert.False(t, w.IsActive())
+
+	bad := "http://localhost/hook"
+	assert.Error(t, w.Update(&bad, nil, nil))
+	assert.Equal(t, "https://example.com/hook", w.URL(), "rejected update leaves the webhook unchanged")
+}
+
+func TestWebhook_RotateSecret(t *testing.T) {
+	w, err := NewWebhook(uuid.New(), "https://example.com/hook", []string{EventLoanCreated}, nil)
+	require.NoError(t, err)
+	old := w.Secret()
+
+	require.NoError(t, w.RotateSecret())
+	assert.NotEqual(t, old, w.Secret())
+}
+
+func TestNewDelivery(t *testing.T) {
+	w, err := NewWebhook(uuid.New(), "https://example.com/hook", []string{EventLoanCreated}, nil)
+	require.NoError(t, err)
+	userID := uuid.New()
+	occurredAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	d, err := NewDelivery(w, events.Event{
+		Type:       EventLoanCreated,
+		EntityType: "loan",
+		EntityID:   "loan-1",
+		UserID:     userID,
+		Timestamp:  occurredAt,
+		Data:       map[string]any{"borrower_name": "Alice"},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, DeliveryPending, d.Status())
+	assert.Equal(t, w.ID(), d.WebhookID())
+
+	var payload Payload
+	require.NoError(t, json.Unmarshal(d.Payload(), &payload))
+	assert.Equal(t, d.ID(), payload.DeliveryID)
+	assert.Equal(t, EventLoanCreated, payload.Event)
+	assert.Equal(t, w.WorkspaceID(), payload.WorkspaceID)
+	assert.True(t, occurredAt.Equal(payload.OccurredAt))
+	require.NotNil(t, payload.ActorID)
+	assert.Equal(t, userID, *payload.ActorID)
+	assert.Equal(t, "Alice", payload.Data["borrower_name"])
+}
@@ -0,0 +1,7 @@
+package webhook
+
+import "github.com/antti/home-warehouse/go-backend/internal/shared"
+
+var (
+	ErrWebhookNotFound = shared.NewDomainError(shared.ErrNotFound, "webhook not found")
+)
@@ -0,0 +1,35 @@
+package webhook
+
+import (
+	"context"
+	"log/slog"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/antti/home-warehouse/go-backend/internal/infra/events"
+)
+
+// dispatchTimeout bounds recording and enqueuing the deliveries for one event.
+const dispatchTimeout = 5 * time.Second
+
+// NewEventTap returns a Broadcaster tap that hands subscribable events to the
+// webhook service. Wire it once at startup: broadcaster.AddTap(webhook.NewEventTap(svc, logger)).
+func NewEventTap(svc ServiceInterface, logger *slog.Logger) func(uuid.UUID, events.Event) {
+	return func(workspaceID uuid.UUID, e events.Event) {
+		// Filter before spawning: nearly every event is not webhook-relevant.
+		if !IsSupportedEventType(e.Type) {
+			return
+		}
+
+		go func() {
+			ctx, cancel := context.WithTimeout(context.Background(), dispatchTimeout)
+			defer cancel()
+
+			if err := svc.Dispatch(ctx, workspaceID, e); err != nil {
+				logger.Error("webhook tap: failed to dispatch event",
+					"error", err, "event_type", e.Type, "entity_id", e.EntityID)
+			}
+		}()
+	}
+}
@@ -0,0 +1,302 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/danielgtaylor/huma/v2"
+	"github.com/google/uuid"
+
+	appMiddleware "github.com/antti/home-warehouse/go-backend/internal/api/middleware"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+const (
+	msgWorkspaceContextRequired = "workspace context required"
+	routeWebhookByID            = "/webhooks/{id}"
+)
+
+// RegisterRoutes registers webhook management routes. Webhooks carry signing
+// secrets and send workspace data off-site, so every route is limited to
+// workspace owners and admins.
+func RegisterRoutes(api huma.API, svc ServiceInterface) {
+	huma.Get(api, "/webhooks", listWebhooks(svc))
+	huma.Post(api, "/webhooks", createWebhook(svc))
+	huma.Get(api, routeWebhookByID, getWebhook(svc))
+	huma.Patch(api, routeWebhookByID, updateWebhook(svc))
+	huma.Delete(api, routeWebhookByID, deleteWebhook(svc))
+	huma.Post(api, "/webhooks/{id}/rotate-secret", rotateWebhookSecret(svc))
+	huma.Get(api, "/webhooks/{id}/deliveries", listWebhookDeliveries(svc))
+}
+
+// requireAdmin returns the workspace ID when the caller is a workspace owner
+// or admin.
+func requireAdmin(ctx context.Context) (uuid.UUID, error) {
+	workspaceID, ok := appMiddleware.GetWorkspaceID(ctx)
+	if !ok {
+		return uuid.Nil, huma.Error401Unauthorized(msgWorkspaceContextRequired)
+	}
+	role, ok := appMiddleware.GetRole(ctx)
+	if !ok || (role != "owner" && role != "admin") {
+		return uuid.Nil, huma.Error403Forbidden("only workspace owners and admins can manage webhooks")
+	}
+	return workspaceID, nil
+}
+
+// mapError maps validation and not-found errors to their HTTP status and
+// anything else to a 500 with fallback as the message.
+func mapError(err error, fallback string) error {
+	var domainErr *shared.DomainError
+	if errors.As(err, &domainErr) {
+		return appMiddleware.MapDomainError(err)
+	}
+	return huma.Error500InternalServerError(fallback)
+}
+
+func listWebhooks(svc ServiceInterface) func(context.Context, *struct{}) (*ListWebhooksOutput, error) {
+	return func(ctx context.Context, _ *struct{}) (*ListWebhooksOutput, error) {
+		workspaceID, err := requireAdmin(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		webhooks, err := svc.ListByWorkspace(ctx, workspaceID)
+		if err != nil {
+			return nil, huma.Error500InternalServerError("failed to list webhooks")
+		}
+
+		items := make([]WebhookResponse, len(webhooks))
+		for i, w := range webhooks {
+			items[i] = toWebhookResponse(w, false)
+		}
+		return &ListWebhooksOutput{Body: WebhookListResponse{Items: items}}, nil
+	}
+}
+
+// createWebhook registers a webhook. The signing secret is returned only here
+// and from rotate-secret.
+func createWebhook(svc ServiceInterface) func(context.Context, *CreateWebhookInput) (*WebhookOutput, error) {
+	return func(ctx context.Context, input *CreateWebhookInput) (*WebhookOutput, error) {
+		workspaceID, err := requireAdmin(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		var createdBy *uuid.UUID
+		if authUser, ok := appMiddleware.GetAuthUser(ctx); ok {
+			createdBy = &authUser.ID
+		}
+
+		webhook, err := svc.Create(ctx, CreateInput{
+			WorkspaceID: workspaceID,
+			CreatedBy:   createdBy,
+			URL:         input.Body.URL,
+			EventTypes:  input.Body.EventTypes,
+		})
+		if err != nil {
+			return nil, mapError(err, "failed to create webhook")
+		}
+
+		return &WebhookOutput{Body: toWebhookResponse(webhook, true)}, nil
+	}
+}
+
+func getWebhook(svc ServiceInterface) func(context.Context, *WebhookIDInput) (*WebhookOutput, error) {
+	return func(ctx context.Context, input *WebhookIDInput) (*WebhookOutput, error) {
+		workspaceID, err := requireAdmin(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		webhook, err := svc.GetByID(ctx, input.ID, workspaceID)
+		if err != nil {
+			return nil, mapError(err, "failed to get webhook")
+		}
+
+		return &WebhookOutput{Body: toWebhookResponse(webhook, false)}, nil
+	}
+}
+
+func updateWebhook(svc ServiceInterface) func(context.Context, *UpdateWebhookInput) (*WebhookOutput, error) {
+	return func(ctx context.Context, input *UpdateWebhookInput) (*WebhookOutput, error) {
+		workspaceID, err := requireAdmin(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		webhook, err := svc.Update(ctx, input.ID, workspaceID, UpdateInput{
+			URL:        input.Body.URL,
+			EventTypes: input.Body.EventTypes,
+			IsActive:   input.Body.IsActive,
+		})
+		if err != nil {
+			return nil, mapError(err, "failed to update webhook")
+		}
+
+		return &WebhookOutput{Body: toWebhookResponse(webhook, false)}, nil
+	}
+}
+
+func deleteWebhook(svc ServiceInterface) func(context.Context, *WebhookIDInput) (*struct{}, error) {
+	return func(ctx context.Context, input *WebhookIDInput) (*struct{}, error) {
+		workspaceID, err := requireAdmin(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := svc.Delete(ctx, input.ID, workspaceID); err != nil {
+			return nil, mapError(err, "failed to delete webhook")
+		}
+		return nil, nil
+	}
+}
+
+func rotateWebhookSecret(svc ServiceInterface) func(context.Context, *WebhookIDInput) (*WebhookOutput, error) {
+	return func(ctx context.Context, input *WebhookIDInput) (*WebhookOutput, error) {
+		workspaceID, err := requireAdmin(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		webhook, err := svc.RotateSecret(ctx, input.ID, workspaceID)
+		if err != nil {
+			return nil, mapError(err, "failed to rotate webhook secret")
+		}
+
+		return &WebhookOutput{Body: toWebhookResponse(webhook, true)}, nil
+	}
+}
+
+func listWebhookDeliveries(svc ServiceInterface) func(context.Context, *ListDeliveriesInput) (*ListDeliveriesOutput, error) {
+	return func(ctx context.Context, input *ListDeliveriesInput) (*ListDeliveriesOutput, error) {
+		workspaceID, err := requireAdmin(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		pagination := shared.Pagination{Page: input.Page, PageSize: input.Limit}
+		deliveries, total, err := svc.ListDeliveries(ctx, input.ID, workspaceID, pagination)
+		if err != nil {
+			return nil, mapError(err, "failed to list webhook deliveries")
+		}
+
+		items := make([]DeliveryResponse, len(deliveries))
+		for i, d := range deliveries {
+			items[i] = toDeliveryResponse(d)
+		}
+
+		return &ListDeliveriesOutput{
+			Body: DeliveryListResponse{
+				Items:      items,
+				Total:      total,
+				Page:       input.Page,
+				TotalPages: (total + input.Limit - 1) / input.Limit,
+			},
+		}, nil
+	}
+}
+
+func toWebhookResponse(w *Webhook, withSecret bool) WebhookResponse {
+	resp := WebhookResponse{
+		ID:         w.ID(),
+		URL:        w.URL(),
+		EventTypes: w.EventTypes(),
+		IsActive:   w.IsActive(),
+		CreatedAt:  w.CreatedAt(),
+		UpdatedAt:  w.UpdatedAt(),
+	}
+	if withSecret {
+		secret := w.Secret()
+		resp.Secret = &secret
+	}
+	return resp
+}
+
+func toDeliveryResponse(d *Delivery) DeliveryResponse {
+	return DeliveryResponse{
+		ID:             d.ID(),
+		EventType:      d.EventType(),
+		Status:         string(d.Status()),
+		Attempts:       d.Attempts(),
+		ResponseStatus: d.ResponseStatus(),
+		LastError:      d.LastError(),
+		DeliveredAt:    d.DeliveredAt(),
+		CreatedAt:      d.CreatedAt(),
+	}
+}
+
+// Request/Response types
+
+type WebhookIDInput struct {
+	ID uuid.UUID `path:"id"`
+}
+
+type CreateWebhookInput struct {
+	Body struct {
+		URL        string   `json:"url" minLength:"1" maxLength:"2000" doc:"http(s) URL that receives the event POSTs"`
+		EventTypes []string `json:"event_types" minItems:"1" doc:"Events to deliver: loan.created, item.low_stock, pendingchange.approved"`
+	}
+}
+
+type UpdateWebhookInput struct {
+	ID   uuid.UUID `path:"id"`
+	Body struct {
+		URL        *string  `json:"url,omitempty" maxLength:"2000"`
+		EventTypes []string `json:"event_types,omitempty"`
+		IsActive   *bool    `json:"is_active,omitempty"`
+	}
+}
+
+type ListDeliveriesInput struct {
+	ID    uuid.UUID `path:"id"`
+	Page  int       `query:"page" default:"1" minimum:"1"`
+	Limit int       `query:"limit" default:"50" minimum:"1" maximum:"100"`
+}
+
+type WebhookOutput struct {
+	Body WebhookResponse
+}
+
+type ListWebhooksOutput struct {
+	Body WebhookListResponse
+}
+
+type ListDeliveriesOutput struct {
+	Body DeliveryListResponse
+}
+
+type WebhookResponse struct {
+	ID         uuid.UUID `json:"id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	IsActive   bool      `json:"is_active"`
+	// Secret is only returned when the webhook is created or its secret is
+	// rotated. Deliveries carry X-Webhook-Signature: sha256=<hex>, the
+	// HMAC-SHA256 of the raw request body keyed with this secret.
+	Secret    *string   `json:"secret,omitempty" doc:"Signing secret; only returned on create and rotate-secret"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+type WebhookListResponse struct {
+	Items []WebhookResponse `json:"items"`
+}
+
+type DeliveryResponse struct {
+	ID             uuid.UUID  `json:"id"`
+	EventType      string     `json:"event_type"`
+	Status         string     `json:"status" enum:"pending,succeeded,failed"`
+	Attempts       int        `json:"attempts"`
+	ResponseStatus *int       `json:"response_status,omitempty"`
+	LastError      *string    `json:"last_error,omitempty"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+type DeliveryListResponse struct {
+	Items      []DeliveryResponse `json:"items"`
+	Total      int                `json:"total"`
+	Page       int                `json:"page"`
+	TotalPages int                `json:"total_pages"`
+}
@@ -0,0 +1,157 @@
+package webhook_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/webhook"
+	"github.com/antti/home-warehouse/go-backend/internal/infra/events"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+	"github.com/antti/home-warehouse/go-backend/internal/testutil"
+)
+
+type MockService struct {
+	mock.Mock
+}
+
+func (m *MockService) Create(ctx context.Context, input webhook.CreateInput) (*webhook.Webhook, error) {
+	args := m.Called(ctx, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*webhook.Webhook), args.Error(1)
+}
+
+func (m *MockService) GetByID(ctx context.Context, id, workspaceID uuid.UUID) (*webhook.Webhook, error) {
+	args := m.Called(ctx, id, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*webhook.Webhook), args.Error(1)
+}
+
+func (m *MockService) ListByWorkspace(ctx context.Context, workspaceID uuid.UUID) ([]*webhook.Webhook, error) {
+	args := m.Called(ctx, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*webhook.Webhook), args.Error(1)
+}
+
+func (m *MockService) Update(ctx context.Context, id, workspaceID uuid.UUID, input webhook.UpdateInput) (*webhook.Webhook, error) {
+	args := m.Called(ctx, id, workspaceID, input)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*webhook.Webhook), args.Error(1)
+}
+
+func (m *MockService) RotateSecret(ctx context.Context, id, workspaceID uuid.UUID) (*webhook.Webhook, error) {
+	args := m.Called(ctx, id, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*webhook.Webhook), args.Error(1)
+}
+
+func (m *MockService) Delete(ctx context.Context, id, workspaceID uuid.UUID) error {
+	return m.Called(ctx, id, workspaceID).Error(0)
+}
+
+func (m *MockService) ListDeliveries(ctx context.Context, id, workspaceID uuid.UUID, pagination shared.Pagination) ([]*webhook.Delivery, int, error) {
+	args := m.Called(ctx, id, workspaceID, pagination)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*webhook.Delivery), args.Int(1), args.Error(2)
+}
+
+func (m *MockService) Dispatch(ctx context.Context, workspaceID uuid.UUID, event events.Event) error {
+	return m.Called(ctx, workspaceID, event).Error(0)
+}
+
+func TestWebhookHandler_Create(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	webhook.RegisterRoutes(setup.API, mockSvc)
+
+	t.Run("returns the signing secret", func(t *testing.T) {
+		created, _ := webhook.NewWebhook(setup.WorkspaceID, "https://hooks.example.com/in", []string{webhook.EventLoanCreated}, &setup.UserID)
+		mockSvc.On("Create", mock.Anything, webhook.CreateInput{
+			WorkspaceID: setup.WorkspaceID,
+			CreatedBy:   &setup.UserID,
+			URL:         "https://hooks.example.com/in",
+			EventTypes:  []string{webhook.EventLoanCreated},
+		}).Return(created, nil).Once()
+
+		rec := setup.Post("/webhooks", `{"url":"https://hooks.example.com/in","event_types":["loan.created"]}`)
+
+		testutil.AssertStatus(t, rec, http.StatusOK)
+		resp := testutil.ParseJSONResponse[webhook.WebhookResponse](t, rec)
+		if assert.NotNil(t, resp.Secret) {
+			assert.Equal(t, created.Secret(), *resp.Secret)
+		}
+	})
+
+	t.Run("maps validation errors to 400", func(t *testing.T) {
+		mockSvc.On("Create", mock.Anything, mock.Anything).
+			Return(nil, shared.NewFieldError(shared.ErrInvalidInput, "event_types", "unsupported event type")).Once()
+
+		rec := setup.Post("/webhooks", `{"url":"https://hooks.example.com/in","event_types":["nope"]}`)
+
+		testutil.AssertStatus(t, rec, http.StatusBadRequest)
+	})
+}
+
+func TestWebhookHandler_Get_HidesSecret(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	webhook.RegisterRoutes(setup.API, mockSvc)
+
+	w, _ := webhook.NewWebhook(setup.WorkspaceID, "https://hooks.example.com/in", []string{webhook.EventLoanCreated}, nil)
+	mockSvc.On("GetByID", mock.Anything, w.ID(), setup.WorkspaceID).Return(w, nil)
+
+	rec := setup.Get(fmt.Sprintf("/webhooks/%s", w.ID()))
+
+	testutil.AssertStatus(t, rec, http.StatusOK)
+	assert.NotContains(t, rec.Body.String(), w.Secret())
+}
+
+func TestWebhookHandler_NotFound(t *testing.T) {
+	setup := testutil.NewHandlerTestSetup()
+	mockSvc := new(MockService)
+	webhook.RegisterRoutes(setup.API, mockSvc)
+
+	id := uuid.New()
+	mockSvc.On("Delete", mock.Anything, id, setup.WorkspaceID).Return(webhook.ErrWebhookNotFound)
+
+	rec := setup.Delete(fmt.Sprintf("/webhooks/%s", id))
+
+	testutil.AssertStatus(t, rec, http.StatusNotFound)
+}
+
+func TestWebhookHandler_RequiresAdmin(t *testing.T) {
+	for _, role := range []string{"member", "viewer"} {
+		t.Run(role, func(t *testing.T) {
+			setup := testutil.NewHandlerTestSetup()
+			setup.SetRole(role)
+			mockSvc := new(MockService)
+			webhook.RegisterRoutes(setup.API, mockSvc)
+
+			rec := setup.Get("/webhooks")
+			testutil.AssertStatus(t, rec, http.StatusForbidden)
+
+			rec = setup.Post("/webhooks", `{"url":"https://hooks.example.com/in","event_types":["loan.created"]}`)
+			testutil.AssertStatus(t, rec, http.StatusForbidden)
+
+			mockSvc.AssertNotCalled(t, "ListByWorkspace", mock.Anything, mock.Anything)
+			mockSvc.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
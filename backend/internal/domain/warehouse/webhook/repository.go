@@ -0,0 +1,36 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// Repository defines webhook and delivery persistence.
+type Repository interface {
+	// Save creates or updates a webhook.
+	Save(ctx context.Context, webhook *Webhook) error
+
+	// FindByID retrieves a webhook in a workspace. Returns shared.ErrNotFound
+	// when it does not exist.
+	FindByID(ctx context.Context, id, workspaceID uuid.UUID) (*Webhook, error)
+
+	// FindByWorkspace retrieves every webhook in a workspace, oldest first.
+	FindByWorkspace(ctx context.Context, workspaceID uuid.UUID) ([]*Webhook, error)
+
+	// FindActiveForEvent retrieves the active webhooks in a workspace that
+	// subscribe to eventType.
+	FindActiveForEvent(ctx context.Context, workspaceID uuid.UUID, eventType string) ([]*Webhook, error)
+
+	// Delete removes a webhook together with its delivery history.
+	Delete(ctx context.Context, id, workspaceID uuid.UUID) error
+
+	// SaveDelivery persists a new delivery.
+	SaveDelivery(ctx context.Context, delivery *Delivery) error
+
+	// FindDeliveries retrieves a webhook's deliveries, newest first, along
+	// with the total count.
+	FindDeliveries(ctx context.Context, webhookID, workspaceID uuid.UUID, pagination shared.Pagination) ([]*Delivery, int, error)
+}
@@ -0,0 +1,154 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+
+	"github.com/antti/home-warehouse/go-backend/internal/infra/events"
+	"github.com/antti/home-warehouse/go-backend/internal/jobs"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// ServiceInterface defines the webhook service operations.
+type ServiceInterface interface {
+	Create(ctx context.Context, input CreateInput) (*Webhook, error)
+	GetByID(ctx context.Context, id, workspaceID uuid.UUID) (*Webhook, error)
+	ListByWorkspace(ctx context.Context, workspaceID uuid.UUID) ([]*Webhook, error)
+	Update(ctx context.Context, id, workspaceID uuid.UUID, input UpdateInput) (*Webhook, error)
+	RotateSecret(ctx context.Context, id, workspaceID uuid.UUID) (*Webhook, error)
+	Delete(ctx context.Context, id, workspaceID uuid.UUID) error
+	ListDeliveries(ctx context.Context, id, workspaceID uuid.UUID, pagination shared.Pagination) ([]*Delivery, int, error)
+	Dispatch(ctx context.Context, workspaceID uuid.UUID, event events.Event) error
+}
+
+// Service manages webhooks and fans workspace events out to them. Sending
+// happens in the job worker (see jobs.WebhookDeliveryProcessor); the service
+// only records each delivery and enqueues it.
+type Service struct {
+	repo     Repository
+	enqueuer jobs.Enqueuer
+}
+
+// NewService creates a webhook service that enqueues deliveries on enqueuer.
+func NewService(repo Repository, enqueuer jobs.Enqueuer) *Service {
+	return &Service{repo: repo, enqueuer: enqueuer}
+}
+
+type CreateInput struct {
+	WorkspaceID uuid.UUID
+	CreatedBy   *uuid.UUID
+	URL         string
+	EventTypes  []string
+}
+
+// UpdateInput holds optional changes; nil fields are left as they are.
+type UpdateInput struct {
+	URL        *string
+	EventTypes []string
+	IsActive   *bool
+}
+
+func (s *Service) Create(ctx context.Context, input CreateInput) (*Webhook, error) {
+	webhook, err := NewWebhook(input.WorkspaceID, input.URL, input.EventTypes, input.CreatedBy)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.Save(ctx, webhook); err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+func (s *Service) GetByID(ctx context.Context, id, workspaceID uuid.UUID) (*Webhook, error) {
+	webhook, err := s.repo.FindByID(ctx, id, workspaceID)
+	if err != nil {
+		if errors.Is(err, shared.ErrNotFound) {
+			return nil, ErrWebhookNotFound
+		}
+		return nil, err
+	}
+	return webhook, nil
+}
+
+func (s *Service) ListByWorkspace(ctx context.Context, workspaceID uuid.UUID) ([]*Webhook, error) {
+	return s.repo.FindByWorkspace(ctx, workspaceID)
+}
+
+func (s *Service) Update(ctx context.Context, id, workspaceID uuid.UUID, input UpdateInput) (*Webhook, error) {
+	webhook, err := s.GetByID(ctx, id, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if err := webhook.Update(input.URL, input.EventTypes, input.IsActive); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Save(ctx, webhook); err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+// RotateSecret issues a new signing secret for the webhook.
+func (s *Service) RotateSecret(ctx context.Context, id, workspaceID uuid.UUID) (*Webhook, error) {
+	webhook, err := s.GetByID(ctx, id, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	if err := webhook.RotateSecret(); err != nil {
+		return nil, err
+	}
+	if err := s.repo.Save(ctx, webhook); err != nil {
+		return nil, err
+	}
+	return webhook, nil
+}
+
+func (s *Service) Delete(ctx context.Context, id, workspaceID uuid.UUID) error {
+	if _, err := s.GetByID(ctx, id, workspaceID); err != nil {
+		return err
+	}
+	return s.repo.Delete(ctx, id, workspaceID)
+}
+
+// ListDeliveries returns a webhook's delivery history, newest first.
+func (s *Service) ListDeliveries(ctx context.Context, id, workspaceID uuid.UUID, pagination shared.Pagination) ([]*Delivery, int, error) {
+	if _, err := s.GetByID(ctx, id, workspaceID); err != nil {
+		return nil, 0, err
+	}
+	return s.repo.FindDeliveries(ctx, id, workspaceID, pagination)
+}
+
+// Dispatch records a delivery of event for every active webhook in the
+// workspace that subscribes to it, and enqueues each for the worker.
+// Unsupported event types are ignored. A failure for one webhook does not
+// stop delivery to the others; all failures are returned joined.
+func (s *Service) Dispatch(ctx context.Context, workspaceID uuid.UUID, event events.Event) error {
+	if !IsSupportedEventType(event.Type) {
+		return nil
+	}
+
+	webhooks, err := s.repo.FindActiveForEvent(ctx, workspaceID, event.Type)
+	if err != nil {
+		return fmt.Errorf("failed to find webhooks: %w", err)
+	}
+
+	var errs []error
+	for _, webhook := range webhooks {
+		delivery, err := NewDelivery(webhook, event)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if err := s.repo.SaveDelivery(ctx, delivery); err != nil {
+			errs = append(errs, fmt.Errorf("failed to record delivery for webhook %s: %w", webhook.ID(), err))
+			continue
+		}
+		if _, err := s.enqueuer.Enqueue(jobs.NewWebhookDeliveryTask(delivery.ID())); err != nil {
+			errs = append(errs, fmt.Errorf("failed to enqueue delivery %s: %w", delivery.ID(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,199 @@
+package webhook
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/infra/events"
+	"github.com/antti/home-warehouse/go-backend/internal/jobs"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+type MockRepository struct {
+	mock.Mock
+}
+
+func (m *MockRepository) Save(ctx context.Context, w *Webhook) error {
+	return m.Called(ctx, w).Error(0)
+}
+
+func (m *MockRepository) FindByID(ctx context.Context, id, workspaceID uuid.UUID) (*Webhook, error) {
+	args := m.Called(ctx, id, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*Webhook), args.Error(1)
+}
+
+func (m *MockRepository) FindByWorkspace(ctx context.Context, workspaceID uuid.UUID) ([]*Webhook, error) {
+	args := m.Called(ctx, workspaceID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*Webhook), args.Error(1)
+}
+
+func (m *MockRepository) FindActiveForEvent(ctx context.Context, workspaceID uuid.UUID, eventType string) ([]*Webhook, error) {
+	args := m.Called(ctx, workspaceID, eventType)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*Webhook), args.Error(1)
+}
+
+func (m *MockRepository) Delete(ctx context.Context, id, workspaceID uuid.UUID) error {
+	return m.Called(ctx, id, workspaceID).Error(0)
+}
+
+func (m *MockRepository) SaveDelivery(ctx context.Context, d *Delivery) error {
+	return m.Called(ctx, d).Error(0)
+}
+
+func (m *MockRepository) FindDeliveries(ctx context.Context, webhookID, workspaceID uuid.UUID, pagination shared.Pagination) ([]*Delivery, int, error) {
+	args := m.Called(ctx, webhookID, workspaceID, pagination)
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+	return args.Get(0).([]*Delivery), args.Int(1), args.Error(2)
+}
+
+type MockEnqueuer struct {
+	mock.Mock
+}
+
+func (m *MockEnqueuer) Enqueue(task *asynq.Task, opts ...asynq.Option) (*asynq.TaskInfo, error) {
+	args := m.Called(task)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*asynq.TaskInfo), args.Error(1)
+}
+
+func newTestWebhook(t *testing.T, workspaceID uuid.UUID, eventTypes ...string) *Webhook {
+	t.Helper()
+	w, err := NewWebhook(workspaceID, "https://hooks.example.com/in", eventTypes, nil)
+	require.NoError(t, err)
+	return w
+}
+
+func TestService_Create(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+
+	t.Run("saves valid webhook", func(t *testing.T) {
+		repo := new(MockRepository)
+		repo.On("Save", ctx, mock.AnythingOfType("*webhook.Webhook")).Return(nil)
+
+		w, err := NewService(repo, new(MockEnqueuer)).Create(ctx, CreateInput{
+			WorkspaceID: workspaceID,
+			URL:         "https://hooks.example.com/in",
+			EventTypes:  []string{EventLoanCreated},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, workspaceID, w.WorkspaceID())
+		repo.AssertExpectations(t)
+	})
+
+	t.Run("rejects invalid input without saving", func(t *testing.T) {
+		repo := new(MockRepository)
+
+		_, err := NewService(repo, new(MockEnqueuer)).Create(ctx, CreateInput{
+			WorkspaceID: workspaceID,
+			URL:         "https://hooks.example.com/in",
+			EventTypes:  []string{"nope"},
+		})
+
+		assert.ErrorIs(t, err, shared.ErrInvalidInput)
+		repo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+}
+
+func TestService_GetByID_NotFound(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockRepository)
+	id, workspaceID := uuid.New(), uuid.New()
+	repo.On("FindByID", ctx, id, workspaceID).Return(nil, shared.ErrNotFound)
+
+	_, err := NewService(repo, new(MockEnqueuer)).GetByID(ctx, id, workspaceID)
+
+	assert.ErrorIs(t, err, ErrWebhookNotFound)
+}
+
+func TestService_Dispatch(t *testing.T) {
+	ctx := context.Background()
+	workspaceID := uuid.New()
+	event := events.Event{Type: EventLoanCreated, EntityType: "loan", EntityID: uuid.NewString()}
+
+	t.Run("records and enqueues a delivery per subscribed webhook", func(t *testing.T) {
+		repo := new(MockRepository)
+		enqueuer := new(MockEnqueuer)
+		hooks := []*Webhook{
+			newTestWebhook(t, workspaceID, EventLoanCreated),
+			newTestWebhook(t, workspaceID, EventLoanCreated, EventItemLowStock),
+		}
+		repo.On("FindActiveForEvent", ctx, workspaceID, EventLoanCreated).Return(hooks, nil)
+
+		var saved []*Delivery
+		repo.On("SaveDelivery", ctx, mock.AnythingOfType("*webhook.Delivery")).
+			Run(func(args mock.Arguments) { saved = append(saved, args.Get(1).(*Delivery)) }).
+			Return(nil)
+		enqueuer.On("Enqueue", mock.MatchedBy(func(task *asynq.Task) bool {
+			return task.Type() == jobs.TypeWebhookDelivery
+		})).Return(&asynq.TaskInfo{}, nil)
+
+		err := NewService(repo, enqueuer).Dispatch(ctx, workspaceID, event)
+
+		require.NoError(t, err)
+		require.Len(t, saved, 2)
+		assert.Equal(t, hooks[0].ID(), saved[0].WebhookID())
+		assert.Equal(t, hooks[1].ID(), saved[1].WebhookID())
+		enqueuer.AssertNumberOfCalls(t, "Enqueue", 2)
+	})
+
+	t.Run("ignores unsupported event types", func(t *testing.T) {
+		repo := new(MockRepository)
+
+		err := NewService(repo, new(MockEnqueuer)).Dispatch(ctx, workspaceID, events.Event{Type: "item.updated"})
+
+		require.NoError(t, err)
+		repo.AssertNotCalled(t, "FindActiveForEvent", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("keeps going when one delivery cannot be recorded", func(t *testing.T) {
+		repo := new(MockRepository)
+		enqueuer := new(MockEnqueuer)
+		failing := newTestWebhook(t, workspaceID, EventLoanCreated)
+		working := newTestWebhook(t, workspaceID, EventLoanCreated)
+		repo.On("FindActiveForEvent", ctx, workspaceID, EventLoanCreated).Return([]*Webhook{failing, working}, nil)
+		repo.On("SaveDelivery", ctx, mock.MatchedBy(func(d *Delivery) bool { return d.WebhookID() == failing.ID() })).
+			Return(errors.New("db down"))
+		repo.On("SaveDelivery", ctx, mock.MatchedBy(func(d *Delivery) bool { return d.WebhookID() == working.ID() })).
+			Return(nil)
+		enqueuer.On("Enqueue", mock.Anything).Return(&asynq.TaskInfo{}, nil)
+
+		err := NewService(repo, enqueuer).Dispatch(ctx, workspaceID, event)
+
+		assert.ErrorContains(t, err, "db down")
+		enqueuer.AssertNumberOfCalls(t, "Enqueue", 1)
+	})
+}
+
+func TestService_ListDeliveries_UnknownWebhook(t *testing.T) {
+	ctx := context.Background()
+	repo := new(MockRepository)
+	id, workspaceID := uuid.New(), uuid.New()
+	repo.On("FindByID", ctx, id, workspaceID).Return(nil, shared.ErrNotFound)
+
+	_, _, err := NewService(repo, new(MockEnqueuer)).ListDeliveries(ctx, id, workspaceID, shared.Pagination{Page: 1, PageSize: 50})
+
+	assert.ErrorIs(t, err, ErrWebhookNotFound)
+	repo.AssertNotCalled(t, "FindDeliveries", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -96,6 +96,16 @@ func (m *MockCategoryRepository) HasChildren(ctx context.Context, workspaceID, p
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *MockCategoryRepository) MoveItems(ctx context.Context, workspaceID, fromCategoryID, toCategoryID uuid.UUID) (int, error) {
+	args := m.Called(ctx, workspaceID, fromCategoryID, toCategoryID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockCategoryRepository) MoveChildren(ctx context.Context, workspaceID, fromCategoryID, toCategoryID uuid.UUID) (int, error) {
+	args := m.Called(ctx, workspaceID, fromCategoryID, toCategoryID)
+	return args.Int(0), args.Error(1)
+}
+
 // MockItemRepository is a mock implementation of the item.Repository interface.
 type MockItemRepository struct {
 	mock.Mock
@@ -130,12 +140,16 @@ func (m *MockItemRepository) FindByShortCode(ctx context.Context, workspaceID uu
 	return args.Get(0).(*item.Item), args.Error(1)
 }
 
-func (m *MockItemRepository) FindByBarcode(ctx context.Context, workspaceID uuid.UUID, barcode string) (*item.Item, error) {
+func (m *MockItemRepository) FindByBarcode(ctx context.Context, workspaceID uuid.UUID, barcode string) (*item.Item, *item.Identifier, error) {
 	args := m.Called(ctx, workspaceID, barcode)
+	var identifier *item.Identifier
+	if args.Get(1) != nil {
+		identifier = args.Get(1).(*item.Identifier)
+	}
 	if args.Get(0) == nil {
-		return nil, args.Error(1)
+		return nil, identifier, args.Error(2)
 	}
-	return args.Get(0).(*item.Item), args.Error(1)
+	return args.Get(0).(*item.Item), identifier, args.Error(2)
 }
 
 func (m *MockItemRepository) FindByWorkspace(ctx context.Context, workspaceID uuid.UUID, pagination shared.Pagination) ([]*item.Item, int, error) {
@@ -162,6 +176,14 @@ func (m *MockItemRepository) FindNeedingReview(ctx context.Context, workspaceID
 	return args.Get(0).([]*item.Item), args.Int(1), args.Error(2)
 }
 
+func (m *MockItemRepository) FindByIDs(ctx context.Context, workspaceID uuid.UUID, ids []uuid.UUID) ([]*item.Item, error) {
+	args := m.Called(ctx, workspaceID, ids)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*item.Item), args.Error(1)
+}
+
 func (m *MockItemRepository) FindByCategory(ctx context.Context, workspaceID, categoryID uuid.UUID, pagination shared.Pagination) ([]*item.Item, error) {
 	args := m.Called(ctx, workspaceID, categoryID, pagination)
 	if args.Get(0) == nil {
@@ -193,6 +215,14 @@ func (m *MockItemRepository) ShortCodeExists(ctx context.Context, shortCode stri
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *MockItemRepository) ReassignCategory(ctx context.Context, workspaceID uuid.UUID, itemIDs []uuid.UUID, categoryID *uuid.UUID) ([]item.ReassignedItem, error) {
+	args := m.Called(ctx, workspaceID, itemIDs, categoryID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]item.ReassignedItem), args.Error(1)
+}
+
 func (m *MockItemRepository) AttachLabel(ctx context.Context, itemID, labelID uuid.UUID) error {
 	args := m.Called(ctx, itemID, labelID)
 	return args.Error(0)
@@ -211,6 +241,54 @@ func (m *MockItemRepository) GetItemLabels(ctx context.Context, itemID uuid.UUID
 	return args.Get(0).([]uuid.UUID), args.Error(1)
 }
 
+func (m *MockItemRepository) ListIdentifiers(ctx context.Context, workspaceID, itemID uuid.UUID) ([]*item.Identifier, error) {
+	args := m.Called(ctx, workspaceID, itemID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]*item.Identifier), args.Error(1)
+}
+
+func (m *MockItemRepository) SaveIdentifier(ctx context.Context, identifier *item.Identifier) error {
+	return m.Called(ctx, identifier).Error(0)
+}
+
+func (m *MockItemRepository) DeleteIdentifier(ctx context.Context, workspaceID, itemID, identifierID uuid.UUID) error {
+	return m.Called(ctx, workspaceID, itemID, identifierID).Error(0)
+}
+
+func (m *MockItemRepository) FindSimilar(ctx context.Context, workspaceID uuid.UUID, threshold float64, limit int) ([]item.SimilarPair, error) {
+	args := m.Called(ctx, workspaceID, threshold, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]item.SimilarPair), args.Error(1)
+}
+
+func (m *MockItemRepository) CountLoans(ctx context.Context, workspaceID, itemID uuid.UUID) (int, error) {
+	args := m.Called(ctx, workspaceID, itemID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockItemRepository) MoveInventory(ctx context.Context, workspaceID, fromItemID, toItemID uuid.UUID) (int, error) {
+	args := m.Called(ctx, workspaceID, fromItemID, toItemID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockItemRepository) MoveLabels(ctx context.Context, workspaceID, fromItemID, toItemID uuid.UUID) (int, error) {
+	args := m.Called(ctx, workspaceID, fromItemID, toItemID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockItemRepository) MovePhotos(ctx context.Context, workspaceID, fromItemID, toItemID uuid.UUID) (int, error) {
+	args := m.Called(ctx, workspaceID, fromItemID, toItemID)
+	return args.Int(0), args.Error(1)
+}
+
+func (m *MockItemRepository) UpdateSKU(ctx context.Context, workspaceID, itemID uuid.UUID, sku string) error {
+	return m.Called(ctx, workspaceID, itemID, sku).Error(0)
+}
+
 func newTestService(repo *MockRepository, catRepo *MockCategoryRepository, itemRepo *MockItemRepository) *Service {
 	return NewService(repo, catRepo, itemRepo)
 }
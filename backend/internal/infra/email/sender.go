@@ -0,0 +1,87 @@
+// Package email sends transactional email through the Resend HTTP API.
+package email
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const defaultBaseURL = "https://api.resend.com"
+
+var passwordResetTemplate = template.Must(template.New("password_reset").Parse(
+	`<p>Hi {{.Name}},</p>
+<p>Someone asked to reset the password for your Home Warehouse account. Follow the link below to choose a new one. The link works once and expires in an hour.</p>
+<p><a href="{{.URL}}">Reset your password</a></p>
+<p>If you didn't ask for this, you can ignore this email; your password stays the same.</p>`))
+
+// Sender sends email via Resend.
+type Sender struct {
+	apiKey  string
+	from    string
+	baseURL string
+	client  *http.Client
+}
+
+// NewSender creates a Resend sender. from is formatted from fromName and
+// fromAddress as "Name <address>".
+func NewSender(apiKey, fromAddress, fromName string) *Sender {
+	from := fromAddress
+	if fromName != "" {
+		from = fmt.Sprintf("%s <%s>", fromName, fromAddress)
+	}
+	return &Sender{
+		apiKey:  apiKey,
+		from:    from,
+		baseURL: defaultBaseURL,
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// SendPasswordReset emails a password reset link. Implements
+// user.EmailSender.
+func (s *Sender) SendPasswordReset(ctx context.Context, to, fullName, resetURL string) error {
+	var body bytes.Buffer
+	if err := passwordResetTemplate.Execute(&body, struct{ Name, URL string }{fullName, resetURL}); err != nil {
+		return fmt.Errorf("render password reset email: %w", err)
+	}
+	return s.send(ctx, to, "Reset your Home Warehouse password", body.String())
+}
+
+// send posts a single HTML email to the Resend API.
+func (s *Sender) send(ctx context.Context, to, subject, html string) error {
+	payload, err := json.Marshal(map[string]any{
+		"from":    s.from,
+		"to":      []string{to},
+		"subject": subject,
+		"html":    html,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.baseURL+"/emails", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+s.apiKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("send email: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("send email: resend returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(msg)))
+	}
+	return nil
+}
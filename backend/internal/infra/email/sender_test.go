@@ -0,0 +1,54 @@
+package email
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSender_SendPasswordReset(t *testing.T) {
+	t.Run("posts the email to resend", func(t *testing.T) {
+		var got map[string]any
+		var auth string
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/emails", r.URL.Path)
+			auth = r.Header.Get("Authorization")
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+			w.WriteHeader(http.StatusOK)
+		}))
+		defer srv.Close()
+
+		s := NewSender("re_test", "noreply@example.com", "Home Warehouse")
+		s.baseURL = srv.URL
+
+		err := s.SendPasswordReset(context.Background(), "jane@example.com", "Jane <Doe>", "https://app.example.com/reset-password?token=abc")
+
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer re_test", auth)
+		assert.Equal(t, "Home Warehouse <noreply@example.com>", got["from"])
+		assert.Equal(t, []any{"jane@example.com"}, got["to"])
+		assert.Contains(t, got["html"], "https://app.example.com/reset-password?token=abc")
+		assert.Contains(t, got["html"], "Jane &lt;Doe&gt;")
+	})
+
+	t.Run("returns error on non-2xx response", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "invalid from address", http.StatusUnprocessableEntity)
+		}))
+		defer srv.Close()
+
+		s := NewSender("re_test", "noreply@example.com", "")
+		s.baseURL = srv.URL
+
+		err := s.SendPasswordReset(context.Background(), "jane@example.com", "Jane", "https://app.example.com/reset-password?token=abc")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "422")
+		assert.Contains(t, err.Error(), "invalid from address")
+	})
+}
@@ -1,8 +1,8 @@
 package events
 
 import (
-	"fmt"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
@@ -19,28 +19,72 @@ type Event struct {
 	Data        map[string]interface{} `json:"data,omitempty"`
 }
 
+// DefaultClientBuffer is how many events a client queues unless changed with
+// SetClientBuffer.
+const DefaultClientBuffer = 100
+
 // Client represents an SSE connection
 type Client struct {
 	ID          uuid.UUID
 	WorkspaceID uuid.UUID
 	UserID      uuid.UUID
 	Channel     chan Event
+
+	dropped atomic.Uint64
+}
+
+// Dropped returns how many events were discarded because the client's
+// buffer was full.
+func (c *Client) Dropped() uint64 {
+	return c.dropped.Load()
+}
+
+// send queues event without blocking. When the buffer is full the oldest
+// queued event is discarded to make room, so a slow client loses history
+// rather than stalling the publisher and every other client.
+func (c *Client) send(event Event) {
+	for {
+		select {
+		case c.Channel <- event:
+			return
+		default:
+		}
+		select {
+		case <-c.Channel:
+			c.dropped.Add(1)
+		default:
+			// Drained by the reader in between; retry the send.
+		}
+	}
 }
 
 // Broadcaster manages SSE connections and event broadcasting
 type Broadcaster struct {
-	mu      sync.RWMutex
-	clients map[uuid.UUID]map[uuid.UUID]*Client // workspace_id -> client_id -> client
-	tap     func(workspaceID uuid.UUID, event Event)
+	mu         sync.RWMutex
+	clients    map[uuid.UUID]map[uuid.UUID]*Client // workspace_id -> client_id -> client
+	taps       []func(workspaceID uuid.UUID, event Event)
+	bufferSize int
 }
 
 // NewBroadcaster creates a new event broadcaster
 func NewBroadcaster() *Broadcaster {
 	return &Broadcaster{
-		clients: make(map[uuid.UUID]map[uuid.UUID]*Client),
+		clients:    make(map[uuid.UUID]map[uuid.UUID]*Client),
+		bufferSize: DefaultClientBuffer,
 	}
 }
 
+// SetClientBuffer sets the channel buffer of clients registered from now on.
+// Sizes below 1 are ignored.
+func (b *Broadcaster) SetClientBuffer(size int) {
+	if size < 1 {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.bufferSize = size
+}
+
 // Register adds a new client connection
 func (b *Broadcaster) Register(workspaceID, userID uuid.UUID) *Client {
 	b.mu.Lock()
@@ -50,7 +94,7 @@ func (b *Broadcaster) Register(workspaceID, userID uuid.UUID) *Client {
 		ID:          uuid.New(),
 		WorkspaceID: workspaceID,
 		UserID:      userID,
-		Channel:     make(chan Event, 100), // Buffer to prevent blocking
+		Channel:     make(chan Event, b.bufferSize),
 	}
 
 	if b.clients[workspaceID] == nil {
@@ -79,12 +123,13 @@ func (b *Broadcaster) Unregister(workspaceID, clientID uuid.UUID) {
 	}
 }
 
-// SetTap registers a side-effect hook invoked for every published event, whether
-// or not any SSE client is connected. It is the chokepoint the activity log hangs
-// off (see activity.NewEventTap). Not safe for concurrent use with Publish — call
-// it once during single-goroutine startup wiring, before the server accepts traffic.
-func (b *Broadcaster) SetTap(tap func(workspaceID uuid.UUID, event Event)) {
-	b.tap = tap
+// AddTap registers a side-effect hook invoked for every published event, whether
+// or not any SSE client is connected. It is the chokepoint the activity log and
+// outgoing webhooks hang off (see activity.NewEventTap, webhook.NewEventTap).
+// Taps run in registration order. Not safe for concurrent use with Publish — call
+// it during single-goroutine startup wiring, before the server accepts traffic.
+func (b *Broadcaster) AddTap(tap func(workspaceID uuid.UUID, event Event)) {
+	b.taps = append(b.taps, tap)
 }
 
 // Publish broadcasts an event to all clients in a workspace
@@ -94,8 +139,8 @@ func (b *Broadcaster) Publish(workspaceID uuid.UUID, event Event) {
 
 	// Tapped before the client lock: the audit write must not contend with the
 	// client map, and must happen even when no client is listening.
-	if b.tap != nil {
-		b.tap(workspaceID, event)
+	for _, tap := range b.taps {
+		tap(workspaceID, event)
 	}
 
 	b.mu.RLock()
@@ -112,13 +157,7 @@ func (b *Broadcaster) Publish(workspaceID uuid.UUID, event Event) {
 		// 	continue
 		// }
 
-		// Non-blocking send
-		select {
-		case client.Channel <- event:
-		default:
-			// Channel full, skip this client
-			fmt.Printf("Warning: client %s channel full, dropping event\n", client.ID)
-		}
+		client.send(event)
 	}
 }
 
@@ -129,16 +168,23 @@ func (b *Broadcaster) GetStats() map[string]interface{} {
 
 	totalClients := 0
 	workspaces := make(map[string]int)
+	dropped := make(map[string]uint64)
 
 	for workspaceID, clients := range b.clients {
 		count := len(clients)
 		totalClients += count
 		workspaces[workspaceID.String()] = count
+		for _, client := range clients {
+			if n := client.Dropped(); n > 0 {
+				dropped[client.ID.String()] = n
+			}
+		}
 	}
 
 	return map[string]interface{}{
 		"total_clients":         totalClients,
 		"active_workspaces":     len(b.clients),
 		"clients_per_workspace": workspaces,
+		"dropped_per_client":    dropped,
 	}
 }
@@ -160,7 +160,7 @@ func TestBroadcaster_TapFiresWithoutClients(t *testing.T) {
 	workspaceID := uuid.New()
 
 	var tapped []Event
-	b.SetTap(func(ws uuid.UUID, e Event) {
+	b.AddTap(func(ws uuid.UUID, e Event) {
 		assert.Equal(t, workspaceID, ws)
 		tapped = append(tapped, e)
 	})
@@ -175,36 +175,98 @@ func TestBroadcaster_TapFiresWithoutClients(t *testing.T) {
 	}
 }
 
+func TestBroadcaster_TapsRunInOrder(t *testing.T) {
+	b := NewBroadcaster()
+
+	var order []string
+	b.AddTap(func(uuid.UUID, Event) { order = append(order, "first") })
+	b.AddTap(func(uuid.UUID, Event) { order = append(order, "second") })
+
+	b.Publish(uuid.New(), Event{Type: "loan.created", EntityType: "loan"})
+
+	assert.Equal(t, []string{"first", "second"}, order)
+}
+
 func TestBroadcaster_ChannelBuffer(t *testing.T) {
 	b := NewBroadcaster()
 	workspaceID := uuid.New()
 	userID := uuid.New()
 
 	client := b.Register(workspaceID, userID)
+	assert.Equal(t, DefaultClientBuffer, cap(client.Channel))
 
-	// Publish 101 events (buffer is 100)
-	for i := 0; i < 101; i++ {
-		event := Event{
-			Type:       "test.event",
-			EntityType: "test",
-			UserID:     userID,
-		}
-		b.Publish(workspaceID, event)
+	b.SetClientBuffer(5)
+	assert.Equal(t, 5, cap(b.Register(workspaceID, userID).Channel))
+
+	b.SetClientBuffer(0)
+	assert.Equal(t, 5, cap(b.Register(workspaceID, userID).Channel), "non-positive sizes are ignored")
+}
+
+func TestBroadcaster_FullBufferDropsOldest(t *testing.T) {
+	b := NewBroadcaster()
+	b.SetClientBuffer(3)
+	workspaceID := uuid.New()
+
+	client := b.Register(workspaceID, uuid.New())
+
+	for i := 0; i < 5; i++ {
+		b.Publish(workspaceID, Event{Type: "test.event", EntityType: "test", Data: map[string]interface{}{"seq": i}})
 	}
 
-	// Should have received at least some events without blocking
-	// Note: The 101st event may be dropped due to full buffer
-	receivedCount := 0
-	for {
-		select {
-		case <-client.Channel:
-			receivedCount++
-		case <-time.After(10 * time.Millisecond):
-			// No more events
-			assert.Greater(t, receivedCount, 0)
-			return
+	assert.Equal(t, uint64(2), client.Dropped())
+	for _, want := range []int{2, 3, 4} {
+		event := <-client.Channel
+		assert.Equal(t, want, event.Data["seq"])
+	}
+
+	stats := b.GetStats()
+	assert.Equal(t, map[string]uint64{client.ID.String(): 2}, stats["dropped_per_client"])
+}
+
+func TestBroadcaster_SlowClientDoesNotBlockOthers(t *testing.T) {
+	b := NewBroadcaster()
+	workspaceID := uuid.New()
+
+	fast := b.Register(workspaceID, uuid.New()) // room for every event
+	b.SetClientBuffer(2)
+	slow := b.Register(workspaceID, uuid.New()) // never drained
+
+	const total = 50
+	received := make(chan int)
+	go func() {
+		count := 0
+		for range fast.Channel {
+			count++
+			if count == total {
+				break
+			}
+		}
+		received <- count
+	}()
+
+	done := make(chan struct{})
+	go func() {
+		for i := 0; i < total; i++ {
+			b.Publish(workspaceID, Event{Type: "test.event", EntityType: "test"})
 		}
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("publisher blocked on a slow client")
+	}
+
+	select {
+	case count := <-received:
+		assert.Equal(t, total, count)
+	case <-time.After(time.Second):
+		t.Fatal("fast client did not receive every event")
 	}
+	assert.Zero(t, fast.Dropped())
+	assert.Equal(t, uint64(total-2), slow.Dropped())
+	assert.Len(t, slow.Channel, 2)
 }
 
 func TestBroadcaster_UnregisterClosesChannel(t *testing.T) {
@@ -0,0 +1,166 @@
+package imageprocessor
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"math"
+	"strings"
+
+	"github.com/disintegration/imaging"
+)
+
+// blurHashSampleSize is the longest edge, in pixels, the image is downscaled
+// to before encoding. BlurHash only keeps a handful of low-frequency
+// components, so sampling the full-resolution image buys nothing but time.
+const blurHashSampleSize = 64
+
+// base83Chars is the BlurHash base83 alphabet.
+const base83Chars = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz#$%*+,-.:;=?@[]^_{|}~"
+
+// BlurHasher encodes images as BlurHash strings: a compact (~20-30 char)
+// representation of a blurred preview that clients can render as a
+// placeholder while the real thumbnail loads. See https://blurha.sh.
+type BlurHasher struct {
+	// XComponents and YComponents set the number of horizontal and vertical
+	// DCT components (1-9 each). More components keep more detail at the
+	// cost of a longer hash. Default: 4x3
+	XComponents int
+	YComponents int
+}
+
+// NewBlurHasher creates a new BlurHasher with default settings.
+func NewBlurHasher() *BlurHasher {
+	return &BlurHasher{
+		XComponents: 4,
+		YComponents: 3,
+	}
+}
+
+// GenerateBlurHash computes the BlurHash string for an image file.
+func (b *BlurHasher) GenerateBlurHash(ctx context.Context, imagePath string) (string, error) {
+	// Use imaging library which handles EXIF orientation
+	img, err := imaging.Open(imagePath, imaging.AutoOrientation(true))
+	if err != nil {
+		return "", fmt.Errorf("failed to open image: %w", err)
+	}
+	return b.GenerateBlurHashFromImage(ctx, img)
+}
+
+// GenerateBlurHashFromImage computes the BlurHash string for an in-memory image.
+func (b *BlurHasher) GenerateBlurHashFromImage(ctx context.Context, img image.Image) (string, error) {
+	cx, cy := b.XComponents, b.YComponents
+	if cx < 1 || cx > 9 || cy < 1 || cy > 9 {
+		return "", fmt.Errorf("blurhash components must be between 1 and 9, got %dx%d", cx, cy)
+	}
+
+	bounds := img.Bounds()
+	if bounds.Dx() == 0 || bounds.Dy() == 0 {
+		return "", fmt.Errorf("image has no pixels")
+	}
+	if bounds.Dx() > blurHashSampleSize || bounds.Dy() > blurHashSampleSize {
+		img = imaging.Fit(img, blurHashSampleSize, blurHashSampleSize, imaging.Box)
+	}
+	nrgba := imaging.Clone(img)
+	width, height := nrgba.Bounds().Dx(), nrgba.Bounds().Dy()
+
+	// Convert to linear RGB once; every component reads every pixel.
+	linear := make([][3]float64, width*height)
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			off := nrgba.PixOffset(x, y)
+			linear[y*width+x] = [3]float64{
+				sRGBToLinear(nrgba.Pix[off]),
+				sRGBToLinear(nrgba.Pix[off+1]),
+				sRGBToLinear(nrgba.Pix[off+2]),
+			}
+		}
+	}
+
+	factors := make([][3]float64, 0, cx*cy)
+	for j := 0; j < cy; j++ {
+		for i := 0; i < cx; i++ {
+			normalisation := 2.0
+			if i == 0 && j == 0 {
+				normalisation = 1.0
+			}
+			var r, g, bl float64
+			for y := 0; y < height; y++ {
+				basisY := math.Cos(math.Pi * float64(j) * float64(y) / float64(height))
+				for x := 0; x < width; x++ {
+					basis := normalisation * basisY * math.Cos(math.Pi*float64(i)*float64(x)/float64(width))
+					px := linear[y*width+x]
+					r += basis * px[0]
+					g += basis * px[1]
+					bl += basis * px[2]
+				}
+			}
+			scale := 1.0 / float64(width*height)
+			factors = append(factors, [3]float64{r * scale, g * scale, bl * scale})
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(encodeBase83((cx-1)+(cy-1)*9, 1))
+
+	ac := factors[1:]
+	maximumValue := 1.0
+	if len(ac) > 0 {
+		actualMax := 0.0
+		for _, f := range ac {
+			actualMax = math.Max(actualMax, math.Max(math.Abs(f[0]), math.Max(math.Abs(f[1]), math.Abs(f[2]))))
+		}
+		quantisedMax := int(math.Max(0, math.Min(82, math.Floor(actualMax*166-0.5))))
+		maximumValue = float64(quantisedMax+1) / 166
+		sb.WriteString(encodeBase83(quantisedMax, 1))
+	} else {
+		sb.WriteString(encodeBase83(0, 1))
+	}
+
+	sb.WriteString(encodeBase83(encodeDC(factors[0]), 4))
+	for _, f := range ac {
+		sb.WriteString(encodeBase83(encodeAC(f, maximumValue), 2))
+	}
+
+	return sb.String(), nil
+}
+
+func encodeDC(c [3]float64) int {
+	return linearToSRGB(c[0])<<16 + linearToSRGB(c[1])<<8 + linearToSRGB(c[2])
+}
+
+func encodeAC(c [3]float64, maximumValue float64) int {
+	quant := func(v float64) int {
+		return int(math.Max(0, math.Min(18, math.Floor(signPow(v/maximumValue, 0.5)*9+9.5))))
+	}
+	return quant(c[0])*19*19 + quant(c[1])*19 + quant(c[2])
+}
+
+func encodeBase83(value, length int) string {
+	out := make([]byte, length)
+	for i := 1; i <= length; i++ {
+		digit := (value / int(math.Pow(83, float64(length-i)))) % 83
+		out[i-1] = base83Chars[digit]
+	}
+	return string(out)
+}
+
+func sRGBToLinear(v uint8) float64 {
+	f := float64(v) / 255
+	if f <= 0.04045 {
+		return f / 12.92
+	}
+	return math.Pow((f+0.055)/1.055, 2.4)
+}
+
+func linearToSRGB(v float64) int {
+	v = math.Max(0, math.Min(1, v))
+	if v <= 0.0031308 {
+		return int(v*12.92*255 + 0.5)
+	}
+	return int((1.055*math.Pow(v, 1/2.4)-0.055)*255 + 0.5)
+}
+
+func signPow(v, exp float64) float64 {
+	return math.Copysign(math.Pow(math.Abs(v), exp), v)
+}
@@ -0,0 +1,106 @@
+package imageprocessor
+
+import (
+	"context"
+	"image"
+	"image/color"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBlurHasher_SolidColor(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 32, 32))
+	for y := 0; y < 32; y++ {
+		for x := 0; x < 32; x++ {
+			img.Set(x, y, color.RGBA{R: 255, G: 0, B: 0, A: 255})
+		}
+	}
+
+	hash, err := NewBlurHasher().GenerateBlurHashFromImage(context.Background(), img)
+	if err != nil {
+		t.Fatalf("GenerateBlurHashFromImage() error = %v", err)
+	}
+
+	// 1 size char + 1 max char + 4 DC chars + 11 AC components * 2 chars
+	if len(hash) != 28 {
+		t.Fatalf("len(hash) = %d, want 28 (%q)", len(hash), hash)
+	}
+	// Size flag for 4x3 is (4-1)+(3-1)*9 = 21 -> 'L'.
+	if hash[0] != 'L' {
+		t.Errorf("hash = %q, want prefix L", hash)
+	}
+	// DC is the average sRGB colour packed as 0xRRGGBB.
+	if got := decodeBase83(hash[2:6]); got != 0xFF0000 {
+		t.Errorf("DC = %#06x, want 0xff0000", got)
+	}
+	// Green and blue carry no energy, so every AC component quantises to the
+	// midpoint (9) in those channels.
+	for i := 6; i < len(hash); i += 2 {
+		ac := decodeBase83(hash[i : i+2])
+		if ac%(19*19) != 9*19+9 {
+			t.Errorf("AC component at %d = %q, want G/B at midpoint", i, hash[i:i+2])
+		}
+	}
+}
+
+func TestBlurHasher_GenerateBlurHash(t *testing.T) {
+	tmpDir := t.TempDir()
+	path := createTestImage(t, 800, 600, filepath.Join(tmpDir, "source.jpg"))
+
+	hasher := NewBlurHasher()
+	hash, err := hasher.GenerateBlurHash(context.Background(), path)
+	if err != nil {
+		t.Fatalf("GenerateBlurHash() error = %v", err)
+	}
+	if len(hash) != 28 {
+		t.Errorf("len(hash) = %d, want 28 (%q)", len(hash), hash)
+	}
+
+	// The gradient test image has structure, so some AC component must be
+	// away from the midpoint.
+	if strings.Count(hash[6:], "fQ") == 11 {
+		t.Errorf("hash = %q, expected non-flat AC components", hash)
+	}
+
+	again, err := hasher.GenerateBlurHash(context.Background(), path)
+	if err != nil {
+		t.Fatalf("GenerateBlurHash() error = %v", err)
+	}
+	if again != hash {
+		t.Errorf("hash not deterministic: %q vs %q", hash, again)
+	}
+}
+
+func TestBlurHasher_Components(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 8, 8))
+
+	hasher := &BlurHasher{XComponents: 1, YComponents: 1}
+	hash, err := hasher.GenerateBlurHashFromImage(context.Background(), img)
+	if err != nil {
+		t.Fatalf("GenerateBlurHashFromImage() error = %v", err)
+	}
+	if len(hash) != 6 {
+		t.Errorf("len(hash) = %d, want 6 (%q)", len(hash), hash)
+	}
+
+	hasher = &BlurHasher{XComponents: 10, YComponents: 3}
+	if _, err := hasher.GenerateBlurHashFromImage(context.Background(), img); err == nil {
+		t.Error("GenerateBlurHashFromImage() error = nil, want error for 10 components")
+	}
+}
+
+func TestBlurHasher_MissingFile(t *testing.T) {
+	_, err := NewBlurHasher().GenerateBlurHash(context.Background(), filepath.Join(t.TempDir(), "missing.jpg"))
+	if err == nil {
+		t.Error("GenerateBlurHash() error = nil, want error")
+	}
+}
+
+func decodeBase83(s string) int {
+	v := 0
+	for _, c := range s {
+		v = v*83 + strings.IndexRune(base83Chars, c)
+	}
+	return v
+}
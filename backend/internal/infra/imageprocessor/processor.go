@@ -9,9 +9,12 @@ import (
 	"image/png"
 	"io"
 	"os"
+	"os/exec"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/disintegration/imaging"
 	"github.com/kolesa-team/go-webp/encoder"
@@ -23,6 +26,8 @@ var (
 	ErrInvalidFormat     = errors.New("invalid image format")
 	ErrInvalidDimensions = errors.New("invalid image dimensions")
 	ErrCorruptedImage    = errors.New("corrupted image")
+	ErrProcessingTimeout = errors.New("image processing timed out")
+	ErrHEICUnsupported   = errors.New("HEIC conversion is not configured")
 )
 
 // ThumbnailSize represents a thumbnail dimension preset
@@ -34,34 +39,142 @@ const (
 	ThumbnailSizeLarge  ThumbnailSize = "large"
 )
 
+// ThumbnailFormat is the encoding used for generated thumbnails
+type ThumbnailFormat string
+
+const (
+	ThumbnailFormatJPEG ThumbnailFormat = "jpeg"
+	ThumbnailFormatWebP ThumbnailFormat = "webp"
+)
+
+// ParseThumbnailFormat parses a thumbnail format name (case-insensitive,
+// "jpg" is accepted as an alias for "jpeg")
+func ParseThumbnailFormat(s string) (ThumbnailFormat, error) {
+	switch strings.ToLower(strings.TrimSpace(s)) {
+	case "jpeg", "jpg":
+		return ThumbnailFormatJPEG, nil
+	case "webp":
+		return ThumbnailFormatWebP, nil
+	}
+	return "", fmt.Errorf("%w: unsupported thumbnail format %q", ErrInvalidFormat, s)
+}
+
+// Extension returns the file extension (with leading dot) for the format
+func (f ThumbnailFormat) Extension() string {
+	if f == ThumbnailFormatJPEG {
+		return ".jpg"
+	}
+	return ".webp"
+}
+
+// MimeType returns the MIME type for the format
+func (f ThumbnailFormat) MimeType() string {
+	if f == ThumbnailFormatJPEG {
+		return MimeTypeJPEG
+	}
+	return MimeTypeWebP
+}
+
+// Upload MIME types.
+const (
+	MimeTypeJPEG = "image/jpeg"
+	MimeTypePNG  = "image/png"
+	MimeTypeWebP = "image/webp"
+	MimeTypeHEIC = "image/heic"
+	MimeTypeHEIF = "image/heif"
+)
+
+// uploadFormats maps the format names accepted in PHOTO_ALLOWED_TYPES to
+// their MIME types.
+var uploadFormats = map[string][]string{
+	"jpeg": {MimeTypeJPEG},
+	"jpg":  {MimeTypeJPEG},
+	"png":  {MimeTypePNG},
+	"webp": {MimeTypeWebP},
+	"heic": {MimeTypeHEIC, MimeTypeHEIF},
+	"heif": {MimeTypeHEIC, MimeTypeHEIF},
+}
+
 // Config holds image processing configuration
 type Config struct {
-	SmallSize   int     // Default: 150
-	MediumSize  int     // Default: 400
-	LargeSize   int     // Default: 800
-	JPEGQuality int     // Default: 85
-	WebPQuality float32 // Default: 75
-	MinWidth    int     // Default: 100
-	MinHeight   int     // Default: 100
-	MaxWidth    int     // Default: 8192
-	MaxHeight   int     // Default: 8192
+	SmallSize        int             // Default: 150
+	MediumSize       int             // Default: 400
+	LargeSize        int             // Default: 800
+	JPEGQuality      int             // Default: 85
+	WebPQuality      float32         // Default: 75
+	ThumbnailQuality int             // Default: 75 (1-100, used for thumbnails in either format)
+	ThumbnailFormat  ThumbnailFormat // Default: webp
+	MinWidth         int             // Default: 100
+	MinHeight        int             // Default: 100
+	MaxWidth         int             // Default: 8192
+	MaxHeight        int             // Default: 8192
+	BlurHashEnabled  bool            // Default: false (compute blurhash placeholders on upload)
+	DedupUploads     bool            // Default: true (re-uploading an item's photo returns the existing one)
+
+	// AllowedMimeTypes are the upload types accepted for item photos.
+	AllowedMimeTypes []string // Default: image/jpeg, image/png, image/webp
+	// HEICConverter is the command used to convert HEIC/HEIF uploads to JPEG,
+	// invoked as "<cmd> -q <JPEGQuality> <source> <dest.jpg>" (the
+	// heif-convert tool from libheif). Empty disables HEIC support.
+	HEICConverter string // Default: "" (disabled)
+
+	// ProcessingTimeout bounds each processor operation so one malicious or
+	// corrupt file cannot stall a worker. Zero disables the limit.
+	ProcessingTimeout time.Duration // Default: 30s
+
+	// OnDemandThumbnails caps how many missing thumbnails the API generates
+	// synchronously while serving them; 0 disables on-demand generation.
+	OnDemandThumbnails int // Default: 2
+	// OnDemandThumbnailTimeout bounds one on-demand generation, after which
+	// the original is served. Zero disables the limit.
+	OnDemandThumbnailTimeout time.Duration // Default: 10s
 }
 
 // DefaultConfig returns default configuration
 func DefaultConfig() Config {
 	return Config{
-		SmallSize:   150,
-		MediumSize:  400,
-		LargeSize:   800,
-		JPEGQuality: 85,
-		WebPQuality: 75,
-		MinWidth:    100,
-		MinHeight:   100,
-		MaxWidth:    8192,
-		MaxHeight:   8192,
+		SmallSize:        150,
+		MediumSize:       400,
+		LargeSize:        800,
+		JPEGQuality:      85,
+		WebPQuality:      75,
+		ThumbnailQuality: 75,
+		ThumbnailFormat:  ThumbnailFormatWebP,
+		MinWidth:         100,
+		MinHeight:        100,
+		MaxWidth:         8192,
+		MaxHeight:        8192,
+		DedupUploads:     true,
+		AllowedMimeTypes: []string{MimeTypeJPEG, MimeTypePNG, MimeTypeWebP},
+
+		ProcessingTimeout: 30 * time.Second,
+
+		OnDemandThumbnails:       2,
+		OnDemandThumbnailTimeout: 10 * time.Second,
 	}
 }
 
+// ThumbnailVersion identifies the settings that affect generated thumbnails
+// (sizes, format and quality), e.g. "150-400-800-webp-q75". Photos record the
+// version their thumbnails were made with so a config change only requires
+// regenerating the photos whose version differs.
+func (c Config) ThumbnailVersion() string {
+	p := Processor{config: c}
+	format := p.thumbnailFormat()
+
+	var quality string
+	switch {
+	case c.ThumbnailQuality > 0:
+		quality = strconv.Itoa(c.ThumbnailQuality)
+	case format == ThumbnailFormatJPEG:
+		quality = strconv.Itoa(c.JPEGQuality)
+	default:
+		quality = strconv.FormatFloat(float64(c.WebPQuality), 'f', -1, 32)
+	}
+
+	return fmt.Sprintf("%d-%d-%d-%s-q%s", c.SmallSize, c.MediumSize, c.LargeSize, format, quality)
+}
+
 // LoadConfigFromEnv loads configuration from environment variables.
 // Environment variables:
 //   - PHOTO_THUMBNAIL_SMALL_SIZE: Small thumbnail size in pixels (default: 150)
@@ -69,10 +182,19 @@ func DefaultConfig() Config {
 //   - PHOTO_THUMBNAIL_LARGE_SIZE: Large thumbnail size in pixels (default: 800)
 //   - PHOTO_JPEG_QUALITY: JPEG compression quality 0-100 (default: 85)
 //   - PHOTO_WEBP_QUALITY: WebP compression quality 0-100 (default: 75)
+//   - PHOTO_THUMBNAIL_QUALITY: Thumbnail compression quality 1-100 (default: 75)
+//   - PHOTO_THUMBNAIL_FORMAT: Thumbnail format, jpeg or webp (default: webp)
 //   - PHOTO_MIN_WIDTH: Minimum image width (default: 100)
 //   - PHOTO_MIN_HEIGHT: Minimum image height (default: 100)
 //   - PHOTO_MAX_WIDTH: Maximum image width (default: 8192)
 //   - PHOTO_MAX_HEIGHT: Maximum image height (default: 8192)
+//   - PHOTO_BLURHASH_ENABLED: Compute blurhash placeholders on upload (default: false)
+//   - PHOTO_DEDUP_UPLOADS: Return the existing photo when an item's photo is uploaded again (default: true)
+//   - PHOTO_ALLOWED_TYPES: Comma-separated upload formats: jpeg, png, webp, heic (default: jpeg,png,webp)
+//   - PHOTO_HEIC_CONVERTER: Command converting HEIC to JPEG, e.g. "heif-convert" (default: unset, HEIC disabled)
+//   - PHOTO_PROCESSING_TIMEOUT: Time limit per image operation, e.g. "45s"; 0 disables (default: 30s)
+//   - PHOTO_ONDEMAND_THUMBNAILS: Concurrent thumbnail generations when serving a missing thumbnail; 0 disables (default: 2)
+//   - PHOTO_ONDEMAND_THUMBNAIL_TIMEOUT: Time limit per on-demand generation; 0 disables (default: 10s)
 func LoadConfigFromEnv() (Config, error) {
 	cfg := DefaultConfig()
 
@@ -85,10 +207,19 @@ func LoadConfigFromEnv() (Config, error) {
 		func() error { return envPositiveInt("PHOTO_THUMBNAIL_LARGE_SIZE", &cfg.LargeSize) },
 		func() error { return envIntInRange("PHOTO_JPEG_QUALITY", &cfg.JPEGQuality, 0, 100) },
 		func() error { return envIntInRange("PHOTO_WEBP_QUALITY", &webpQuality, 0, 100) },
+		func() error { return envIntInRange("PHOTO_THUMBNAIL_QUALITY", &cfg.ThumbnailQuality, 1, 100) },
+		func() error { return envThumbnailFormat("PHOTO_THUMBNAIL_FORMAT", &cfg.ThumbnailFormat) },
 		func() error { return envPositiveInt("PHOTO_MIN_WIDTH", &cfg.MinWidth) },
 		func() error { return envPositiveInt("PHOTO_MIN_HEIGHT", &cfg.MinHeight) },
 		func() error { return envPositiveInt("PHOTO_MAX_WIDTH", &cfg.MaxWidth) },
 		func() error { return envPositiveInt("PHOTO_MAX_HEIGHT", &cfg.MaxHeight) },
+		func() error { return envBool("PHOTO_BLURHASH_ENABLED", &cfg.BlurHashEnabled) },
+		func() error { return envBool("PHOTO_DEDUP_UPLOADS", &cfg.DedupUploads) },
+		func() error { return envMimeTypes("PHOTO_ALLOWED_TYPES", &cfg.AllowedMimeTypes) },
+		func() error { return envString("PHOTO_HEIC_CONVERTER", &cfg.HEICConverter) },
+		func() error { return envDuration("PHOTO_PROCESSING_TIMEOUT", &cfg.ProcessingTimeout) },
+		func() error { return envIntInRange("PHOTO_ONDEMAND_THUMBNAILS", &cfg.OnDemandThumbnails, 0, 64) },
+		func() error { return envDuration("PHOTO_ONDEMAND_THUMBNAIL_TIMEOUT", &cfg.OnDemandThumbnailTimeout) },
 	}
 	for _, load := range loaders {
 		if err := load(); err != nil {
@@ -138,6 +269,95 @@ func envIntChecked(name string, dst *int, validate func(int) error) error {
 	return validate(n)
 }
 
+// envThumbnailFormat reads an optional thumbnail format env var into *dst. An
+// unset var keeps the existing default.
+func envThumbnailFormat(name string, dst *ThumbnailFormat) error {
+	v := os.Getenv(name)
+	if v == "" {
+		return nil
+	}
+	f, err := ParseThumbnailFormat(v)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", name, err)
+	}
+	*dst = f
+	return nil
+}
+
+// envBool reads an optional boolean env var into *dst. An unset var keeps the
+// existing default.
+func envBool(name string, dst *bool) error {
+	v := os.Getenv(name)
+	if v == "" {
+		return nil
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", name, err)
+	}
+	*dst = b
+	return nil
+}
+
+// envString reads an optional string env var into *dst. An unset var keeps
+// the existing default.
+func envString(name string, dst *string) error {
+	if v := strings.TrimSpace(os.Getenv(name)); v != "" {
+		*dst = v
+	}
+	return nil
+}
+
+// envMimeTypes reads an optional comma-separated list of image formats into
+// *dst as MIME types. Formats may be given by name ("jpeg", "png", "webp",
+// "heic") or as MIME types; "heic" covers HEIF as well. An unset var keeps the
+// existing default.
+func envMimeTypes(name string, dst *[]string) error {
+	v := os.Getenv(name)
+	if v == "" {
+		return nil
+	}
+	var types []string
+	for _, part := range strings.Split(v, ",") {
+		part = strings.ToLower(strings.TrimSpace(part))
+		if part == "" {
+			continue
+		}
+		mimeTypes, ok := uploadFormats[strings.TrimPrefix(part, "image/")]
+		if !ok {
+			return fmt.Errorf("invalid %s: unsupported image format %q", name, part)
+		}
+		for _, m := range mimeTypes {
+			if !slices.Contains(types, m) {
+				types = append(types, m)
+			}
+		}
+	}
+	if len(types) == 0 {
+		return fmt.Errorf("%s must name at least one image format", name)
+	}
+	*dst = types
+	return nil
+}
+
+// envDuration reads an optional non-negative duration env var into *dst. An
+// unset var keeps the existing default.
+func envDuration(name string, dst *time.Duration) error {
+	v := os.Getenv(name)
+	if v == "" {
+		return nil
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", name, err)
+	}
+	if d < 0 {
+		return fmt.Errorf("%s must not be negative", name)
+	}
+	*dst = d
+	return nil
+}
+
 // ImageProcessor defines the interface for image processing operations
 type ImageProcessor interface {
 	// GenerateThumbnail generates a single thumbnail with the given max dimensions
@@ -166,10 +386,19 @@ func NewProcessor(config Config) *Processor {
 	return &Processor{config: config}
 }
 
-// GenerateThumbnail generates a single thumbnail maintaining aspect ratio
+// GenerateThumbnail generates a single thumbnail maintaining aspect ratio.
+// An explicit image extension on destPath selects the encoding; otherwise the
+// configured thumbnail format is used. JPEG and WebP output is encoded at the
+// configured thumbnail quality.
 func (p *Processor) GenerateThumbnail(ctx context.Context, sourcePath, destPath string, maxWidth, maxHeight int) error {
+	return p.withTimeout(ctx, func(ctx context.Context) error {
+		return p.generateThumbnail(ctx, sourcePath, destPath, maxWidth, maxHeight)
+	})
+}
+
+func (p *Processor) generateThumbnail(ctx context.Context, sourcePath, destPath string, maxWidth, maxHeight int) error {
 	// Open source image
-	src, err := imaging.Open(sourcePath, imaging.AutoOrientation(true))
+	src, err := openImage(ctx, sourcePath)
 	if err != nil {
 		return fmt.Errorf("failed to open image: %w", err)
 	}
@@ -188,15 +417,57 @@ func (p *Processor) GenerateThumbnail(ctx context.Context, sourcePath, destPath
 
 	switch ext {
 	case ".jpg", ".jpeg":
-		return imaging.Save(thumb, destPath, imaging.JPEGQuality(p.config.JPEGQuality))
+		return p.saveThumbnail(thumb, destPath, ThumbnailFormatJPEG)
 	case ".png":
 		return imaging.Save(thumb, destPath, imaging.PNGCompressionLevel(png.DefaultCompression))
 	case ".webp":
-		return p.saveWebP(thumb, destPath, p.config.WebPQuality)
+		return p.saveThumbnail(thumb, destPath, ThumbnailFormatWebP)
 	default:
-		// Default to JPEG
-		return imaging.Save(thumb, destPath, imaging.JPEGQuality(p.config.JPEGQuality))
+		return p.saveThumbnail(thumb, destPath, p.thumbnailFormat())
+	}
+}
+
+// saveThumbnail encodes a thumbnail in the given format at the thumbnail
+// quality.
+func (p *Processor) saveThumbnail(img image.Image, destPath string, format ThumbnailFormat) error {
+	if format == ThumbnailFormatJPEG {
+		quality := p.config.ThumbnailQuality
+		if quality <= 0 {
+			quality = p.config.JPEGQuality
+		}
+		return saveJPEG(img, destPath, quality)
+	}
+
+	quality := float32(p.config.ThumbnailQuality)
+	if quality <= 0 {
+		quality = p.config.WebPQuality
+	}
+	return p.saveWebP(img, destPath, quality)
+}
+
+// saveJPEG encodes an image as JPEG. Unlike imaging.Save it does not infer the
+// format from the extension, so destPath may have any (or no) extension.
+func saveJPEG(img image.Image, destPath string, quality int) error {
+	output, err := os.Create(destPath)
+	if err != nil {
+		return fmt.Errorf("failed to create output file: %w", err)
+	}
+	defer output.Close()
+
+	if err := imaging.Encode(output, img, imaging.JPEG, imaging.JPEGQuality(quality)); err != nil {
+		return fmt.Errorf("failed to encode jpeg: %w", err)
+	}
+
+	return nil
+}
+
+// thumbnailFormat returns the configured thumbnail format, falling back to
+// WebP for an unset or unknown value.
+func (p *Processor) thumbnailFormat() ThumbnailFormat {
+	if p.config.ThumbnailFormat == ThumbnailFormatJPEG {
+		return ThumbnailFormatJPEG
 	}
+	return ThumbnailFormatWebP
 }
 
 // saveWebP saves an image in WebP format
@@ -219,8 +490,24 @@ func (p *Processor) saveWebP(img image.Image, destPath string, quality float32)
 	return nil
 }
 
-// GenerateAllThumbnails generates all thumbnail sizes
+// GenerateAllThumbnails generates all thumbnail sizes. The returned paths
+// carry the configured thumbnail format's extension, which replaces any
+// extension on baseDestPath.
+// The time limit covers all sizes together.
 func (p *Processor) GenerateAllThumbnails(ctx context.Context, sourcePath, baseDestPath string) (map[ThumbnailSize]string, error) {
+	var paths map[ThumbnailSize]string
+	err := p.withTimeout(ctx, func(ctx context.Context) error {
+		var err error
+		paths, err = p.generateAllThumbnails(ctx, sourcePath, baseDestPath)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return paths, nil
+}
+
+func (p *Processor) generateAllThumbnails(ctx context.Context, sourcePath, baseDestPath string) (map[ThumbnailSize]string, error) {
 	sizes := map[ThumbnailSize]int{
 		ThumbnailSizeSmall:  p.config.SmallSize,
 		ThumbnailSizeMedium: p.config.MediumSize,
@@ -230,13 +517,17 @@ func (p *Processor) GenerateAllThumbnails(ctx context.Context, sourcePath, baseD
 	paths := make(map[ThumbnailSize]string)
 	var firstErr error
 
+	pathWithoutExt := strings.TrimSuffix(baseDestPath, filepath.Ext(baseDestPath))
+	ext := p.thumbnailFormat().Extension()
+
 	for size, maxDim := range sizes {
 		// Generate path with size suffix
-		ext := filepath.Ext(baseDestPath)
-		pathWithoutExt := strings.TrimSuffix(baseDestPath, ext)
 		destPath := fmt.Sprintf("%s_%s%s", pathWithoutExt, size, ext)
 
-		err := p.GenerateThumbnail(ctx, sourcePath, destPath, maxDim, maxDim)
+		err := p.generateThumbnail(ctx, sourcePath, destPath, maxDim, maxDim)
+		if ctxErr := ctx.Err(); ctxErr != nil {
+			return nil, ctxErr
+		}
 		if err != nil {
 			if firstErr == nil {
 				firstErr = fmt.Errorf("failed to generate %s thumbnail: %w", size, err)
@@ -262,24 +553,60 @@ func (p *Processor) GenerateAllThumbnails(ctx context.Context, sourcePath, baseD
 
 // GetDimensions returns the width and height of an image
 func (p *Processor) GetDimensions(ctx context.Context, path string) (int, int, error) {
-	file, err := os.Open(path)
-	if err != nil {
-		return 0, 0, fmt.Errorf("failed to open file: %w", err)
-	}
-	defer file.Close()
+	var width, height int
+	err := p.withTimeout(ctx, func(ctx context.Context) error {
+		file, err := os.Open(path)
+		if err != nil {
+			return fmt.Errorf("failed to open file: %w", err)
+		}
+		defer file.Close()
 
-	config, _, err := image.DecodeConfig(file)
+		config, _, err := image.DecodeConfig(contextReader{ctx: ctx, r: file})
+		if err != nil {
+			return fmt.Errorf("failed to decode image config: %w", err)
+		}
+		width, height = config.Width, config.Height
+		return nil
+	})
 	if err != nil {
-		return 0, 0, fmt.Errorf("failed to decode image config: %w", err)
+		return 0, 0, err
 	}
+	return width, height, nil
+}
 
-	return config.Width, config.Height, nil
+// ConvertToJPEG converts a HEIC/HEIF image to JPEG at destPath using the
+// configured HEICConverter command. Go has no HEIC decoder of its own, so the
+// conversion is delegated to an external tool. Returns ErrHEICUnsupported
+// when no converter is configured.
+func (p *Processor) ConvertToJPEG(ctx context.Context, sourcePath, destPath string) error {
+	if p.config.HEICConverter == "" {
+		return ErrHEICUnsupported
+	}
+	return p.withTimeout(ctx, func(ctx context.Context) error {
+		var stderr strings.Builder
+		cmd := exec.CommandContext(ctx, p.config.HEICConverter,
+			"-q", strconv.Itoa(p.config.JPEGQuality), sourcePath, destPath)
+		cmd.Stderr = &stderr
+		if err := cmd.Run(); err != nil {
+			if msg := strings.TrimSpace(stderr.String()); msg != "" {
+				return fmt.Errorf("%w: HEIC conversion failed: %s", ErrInvalidFormat, msg)
+			}
+			return fmt.Errorf("%w: HEIC conversion failed: %v", ErrInvalidFormat, err)
+		}
+		return nil
+	})
 }
 
 // Optimize compresses an image with quality settings
 func (p *Processor) Optimize(ctx context.Context, sourcePath, destPath string, quality int) error {
+	return p.withTimeout(ctx, func(ctx context.Context) error {
+		return p.optimize(ctx, sourcePath, destPath, quality)
+	})
+}
+
+func (p *Processor) optimize(ctx context.Context, sourcePath, destPath string, quality int) error {
 	// Open source image
-	src, err := imaging.Open(sourcePath, imaging.AutoOrientation(true))
+	src, err := openImage(ctx, sourcePath)
 	if err != nil {
 		return fmt.Errorf("failed to open image: %w", err)
 	}
@@ -310,6 +637,12 @@ func (p *Processor) Optimize(ctx context.Context, sourcePath, destPath string, q
 
 // Validate validates that a file is a valid image with acceptable dimensions
 func (p *Processor) Validate(ctx context.Context, path string) error {
+	return p.withTimeout(ctx, func(ctx context.Context) error {
+		return p.validate(ctx, path)
+	})
+}
+
+func (p *Processor) validate(ctx context.Context, path string) error {
 	// Try to open and decode the file
 	file, err := os.Open(path)
 	if err != nil {
@@ -318,7 +651,7 @@ func (p *Processor) Validate(ctx context.Context, path string) error {
 	defer file.Close()
 
 	// Check if file is actually an image by detecting format
-	config, format, err := image.DecodeConfig(file)
+	config, format, err := image.DecodeConfig(contextReader{ctx: ctx, r: file})
 	if err != nil {
 		if errors.Is(err, image.ErrFormat) {
 			return ErrInvalidFormat
@@ -362,3 +695,60 @@ func (p *Processor) Validate(ctx context.Context, path string) error {
 
 	return nil
 }
+
+// withTimeout runs fn under the configured processing timeout. fn's context
+// is cancelled at the deadline, which stops decoding at its next read; CPU-bound
+// work such as resizing cannot be interrupted, so the call returns at the
+// deadline regardless and fn finishes in the background.
+func (p *Processor) withTimeout(ctx context.Context, fn func(ctx context.Context) error) error {
+	if p.config.ProcessingTimeout <= 0 {
+		return fn(ctx)
+	}
+	ctx, cancel := context.WithTimeout(ctx, p.config.ProcessingTimeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() { done <- fn(ctx) }()
+
+	var err error
+	select {
+	case err = <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return fmt.Errorf("%w after %s", ErrProcessingTimeout, p.config.ProcessingTimeout)
+	}
+	return err
+}
+
+// openImage decodes the image at path, honouring EXIF orientation like
+// imaging.Open, but stops with the context's error once ctx is done.
+func openImage(ctx context.Context, path string) (image.Image, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	img, err := imaging.Decode(contextReader{ctx: ctx, r: file}, imaging.AutoOrientation(true))
+	if err != nil && ctx.Err() != nil {
+		// Decoders may report the failed read as a format error.
+		return nil, ctx.Err()
+	}
+	return img, err
+}
+
+// contextReader fails reads once its context is done, so decoders working
+// through a large or hostile file give up promptly.
+type contextReader struct {
+	ctx context.Context
+	r   io.Reader
+}
+
+func (c contextReader) Read(b []byte) (int, error) {
+	if err := c.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return c.r.Read(b)
+}
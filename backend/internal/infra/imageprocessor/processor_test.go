@@ -2,6 +2,8 @@ package imageprocessor
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"image"
 	"image/color"
 	_ "image/gif"  // Register GIF format
@@ -10,7 +12,11 @@ import (
 	_ "image/png" // Register PNG format
 	"os"
 	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/disintegration/imaging"
 )
@@ -453,6 +459,62 @@ func TestDefaultConfig(t *testing.T) {
 	if config.MaxHeight != 8192 {
 		t.Errorf("MaxHeight = %d, want 8192", config.MaxHeight)
 	}
+	if config.ThumbnailQuality != 75 {
+		t.Errorf("ThumbnailQuality = %d, want 75", config.ThumbnailQuality)
+	}
+	if config.ThumbnailFormat != ThumbnailFormatWebP {
+		t.Errorf("ThumbnailFormat = %q, want %q", config.ThumbnailFormat, ThumbnailFormatWebP)
+	}
+	if config.ProcessingTimeout != 30*time.Second {
+		t.Errorf("ProcessingTimeout = %s, want 30s", config.ProcessingTimeout)
+	}
+	if config.OnDemandThumbnails != 2 {
+		t.Errorf("OnDemandThumbnails = %d, want 2", config.OnDemandThumbnails)
+	}
+	if config.OnDemandThumbnailTimeout != 10*time.Second {
+		t.Errorf("OnDemandThumbnailTimeout = %s, want 10s", config.OnDemandThumbnailTimeout)
+	}
+}
+
+func TestConfig_ThumbnailVersion(t *testing.T) {
+	base := DefaultConfig()
+	if got := base.ThumbnailVersion(); got != "150-400-800-webp-q75" {
+		t.Errorf("default ThumbnailVersion() = %q, want %q", got, "150-400-800-webp-q75")
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		want   string
+	}{
+		{"medium size", func(c *Config) { c.MediumSize = 500 }, "150-500-800-webp-q75"},
+		{"format", func(c *Config) { c.ThumbnailFormat = ThumbnailFormatJPEG }, "150-400-800-jpeg-q75"},
+		{"quality", func(c *Config) { c.ThumbnailQuality = 90 }, "150-400-800-webp-q90"},
+		{"jpeg quality fallback", func(c *Config) {
+			c.ThumbnailFormat = ThumbnailFormatJPEG
+			c.ThumbnailQuality = 0
+		}, "150-400-800-jpeg-q85"},
+		{"unknown format falls back to webp", func(c *Config) { c.ThumbnailFormat = "gif" }, "150-400-800-webp-q75"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			tt.modify(&cfg)
+			if got := cfg.ThumbnailVersion(); got != tt.want {
+				t.Errorf("ThumbnailVersion() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+
+	t.Run("ignores settings that don't affect thumbnails", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.MaxWidth = 4096
+		cfg.BlurHashEnabled = true
+		cfg.ProcessingTimeout = time.Minute
+		if got := cfg.ThumbnailVersion(); got != base.ThumbnailVersion() {
+			t.Errorf("ThumbnailVersion() = %q, want %q", got, base.ThumbnailVersion())
+		}
+	})
 }
 
 func TestLoadConfigFromEnv(t *testing.T) {
@@ -463,10 +525,19 @@ func TestLoadConfigFromEnv(t *testing.T) {
 		os.Unsetenv("PHOTO_THUMBNAIL_LARGE_SIZE")
 		os.Unsetenv("PHOTO_JPEG_QUALITY")
 		os.Unsetenv("PHOTO_WEBP_QUALITY")
+		os.Unsetenv("PHOTO_THUMBNAIL_QUALITY")
+		os.Unsetenv("PHOTO_THUMBNAIL_FORMAT")
 		os.Unsetenv("PHOTO_MIN_WIDTH")
 		os.Unsetenv("PHOTO_MIN_HEIGHT")
 		os.Unsetenv("PHOTO_MAX_WIDTH")
 		os.Unsetenv("PHOTO_MAX_HEIGHT")
+		os.Unsetenv("PHOTO_BLURHASH_ENABLED")
+		os.Unsetenv("PHOTO_DEDUP_UPLOADS")
+		os.Unsetenv("PHOTO_PROCESSING_TIMEOUT")
+		os.Unsetenv("PHOTO_ALLOWED_TYPES")
+		os.Unsetenv("PHOTO_HEIC_CONVERTER")
+		os.Unsetenv("PHOTO_ONDEMAND_THUMBNAILS")
+		os.Unsetenv("PHOTO_ONDEMAND_THUMBNAIL_TIMEOUT")
 	}
 
 	t.Run("defaults_when_no_env_vars", func(t *testing.T) {
@@ -605,6 +676,161 @@ func TestLoadConfigFromEnv(t *testing.T) {
 		clearEnv()
 	})
 
+	t.Run("custom_thumbnail_format_and_quality", func(t *testing.T) {
+		clearEnv()
+		os.Setenv("PHOTO_THUMBNAIL_FORMAT", "JPG")
+		os.Setenv("PHOTO_THUMBNAIL_QUALITY", "40")
+
+		cfg, err := LoadConfigFromEnv()
+		if err != nil {
+			t.Fatalf("LoadConfigFromEnv() error = %v", err)
+		}
+
+		if cfg.ThumbnailFormat != ThumbnailFormatJPEG {
+			t.Errorf("ThumbnailFormat = %q, want %q", cfg.ThumbnailFormat, ThumbnailFormatJPEG)
+		}
+		if cfg.ThumbnailQuality != 40 {
+			t.Errorf("ThumbnailQuality = %d, want 40", cfg.ThumbnailQuality)
+		}
+		clearEnv()
+	})
+
+	t.Run("invalid_thumbnail_format", func(t *testing.T) {
+		clearEnv()
+		os.Setenv("PHOTO_THUMBNAIL_FORMAT", "gif")
+
+		_, err := LoadConfigFromEnv()
+		if err == nil {
+			t.Error("LoadConfigFromEnv() error = nil, want error")
+		}
+		clearEnv()
+	})
+
+	t.Run("thumbnail_quality_out_of_range", func(t *testing.T) {
+		clearEnv()
+		os.Setenv("PHOTO_THUMBNAIL_QUALITY", "0")
+
+		_, err := LoadConfigFromEnv()
+		if err == nil {
+			t.Error("LoadConfigFromEnv() error = nil, want error for quality < 1")
+		}
+		clearEnv()
+	})
+
+	t.Run("blurhash_enabled", func(t *testing.T) {
+		clearEnv()
+		os.Setenv("PHOTO_BLURHASH_ENABLED", "true")
+
+		cfg, err := LoadConfigFromEnv()
+		if err != nil {
+			t.Fatalf("LoadConfigFromEnv() error = %v", err)
+		}
+		if !cfg.BlurHashEnabled {
+			t.Error("BlurHashEnabled = false, want true")
+		}
+		clearEnv()
+	})
+
+	t.Run("dedup_uploads_disabled", func(t *testing.T) {
+		clearEnv()
+		os.Setenv("PHOTO_DEDUP_UPLOADS", "false")
+
+		cfg, err := LoadConfigFromEnv()
+		if err != nil {
+			t.Fatalf("LoadConfigFromEnv() error = %v", err)
+		}
+		if cfg.DedupUploads {
+			t.Error("DedupUploads = true, want false")
+		}
+		clearEnv()
+	})
+
+	t.Run("custom_processing_timeout", func(t *testing.T) {
+		clearEnv()
+		os.Setenv("PHOTO_PROCESSING_TIMEOUT", "45s")
+
+		cfg, err := LoadConfigFromEnv()
+		if err != nil {
+			t.Fatalf("LoadConfigFromEnv() error = %v", err)
+		}
+		if cfg.ProcessingTimeout != 45*time.Second {
+			t.Errorf("ProcessingTimeout = %s, want 45s", cfg.ProcessingTimeout)
+		}
+		clearEnv()
+	})
+
+	t.Run("invalid_processing_timeout", func(t *testing.T) {
+		for _, v := range []string{"soon", "-5s"} {
+			clearEnv()
+			os.Setenv("PHOTO_PROCESSING_TIMEOUT", v)
+
+			if _, err := LoadConfigFromEnv(); err == nil {
+				t.Errorf("LoadConfigFromEnv() error = nil for %q, want error", v)
+			}
+		}
+		clearEnv()
+	})
+
+	t.Run("custom_on_demand_thumbnails", func(t *testing.T) {
+		clearEnv()
+		os.Setenv("PHOTO_ONDEMAND_THUMBNAILS", "0")
+		os.Setenv("PHOTO_ONDEMAND_THUMBNAIL_TIMEOUT", "5s")
+
+		cfg, err := LoadConfigFromEnv()
+		if err != nil {
+			t.Fatalf("LoadConfigFromEnv() error = %v", err)
+		}
+		if cfg.OnDemandThumbnails != 0 {
+			t.Errorf("OnDemandThumbnails = %d, want 0", cfg.OnDemandThumbnails)
+		}
+		if cfg.OnDemandThumbnailTimeout != 5*time.Second {
+			t.Errorf("OnDemandThumbnailTimeout = %s, want 5s", cfg.OnDemandThumbnailTimeout)
+		}
+		clearEnv()
+	})
+
+	t.Run("custom_allowed_types", func(t *testing.T) {
+		clearEnv()
+		os.Setenv("PHOTO_ALLOWED_TYPES", "jpeg, image/webp,heic")
+		os.Setenv("PHOTO_HEIC_CONVERTER", "heif-convert")
+
+		cfg, err := LoadConfigFromEnv()
+		if err != nil {
+			t.Fatalf("LoadConfigFromEnv() error = %v", err)
+		}
+		want := []string{MimeTypeJPEG, MimeTypeWebP, MimeTypeHEIC, MimeTypeHEIF}
+		if !slices.Equal(cfg.AllowedMimeTypes, want) {
+			t.Errorf("AllowedMimeTypes = %v, want %v", cfg.AllowedMimeTypes, want)
+		}
+		if cfg.HEICConverter != "heif-convert" {
+			t.Errorf("HEICConverter = %q, want heif-convert", cfg.HEICConverter)
+		}
+		clearEnv()
+	})
+
+	t.Run("invalid_allowed_types", func(t *testing.T) {
+		for _, v := range []string{"gif", " , "} {
+			clearEnv()
+			os.Setenv("PHOTO_ALLOWED_TYPES", v)
+
+			if _, err := LoadConfigFromEnv(); err == nil {
+				t.Errorf("LoadConfigFromEnv() error = nil for %q, want error", v)
+			}
+		}
+		clearEnv()
+	})
+
+	t.Run("invalid_blurhash_enabled", func(t *testing.T) {
+		clearEnv()
+		os.Setenv("PHOTO_BLURHASH_ENABLED", "maybe")
+
+		_, err := LoadConfigFromEnv()
+		if err == nil {
+			t.Error("LoadConfigFromEnv() error = nil, want error")
+		}
+		clearEnv()
+	})
+
 	t.Run("invalid_webp_quality", func(t *testing.T) {
 		clearEnv()
 		os.Setenv("PHOTO_WEBP_QUALITY", "invalid")
@@ -616,3 +842,265 @@ func TestLoadConfigFromEnv(t *testing.T) {
 		clearEnv()
 	})
 }
+
+// detectFormat returns the decoded image format name of a file.
+func detectFormat(t *testing.T, path string) string {
+	t.Helper()
+
+	f, err := os.Open(path)
+	if err != nil {
+		t.Fatalf("failed to open %s: %v", path, err)
+	}
+	defer f.Close()
+
+	_, format, err := image.DecodeConfig(f)
+	if err != nil {
+		t.Fatalf("failed to decode %s: %v", path, err)
+	}
+	return format
+}
+
+func TestProcessor_ThumbnailFormat(t *testing.T) {
+	tmpDir := t.TempDir()
+	sourcePath := createTestImage(t, 1200, 900, filepath.Join(tmpDir, "source.jpg"))
+	ctx := context.Background()
+
+	tests := []struct {
+		format     ThumbnailFormat
+		wantExt    string
+		wantFormat string
+	}{
+		{ThumbnailFormatJPEG, ".jpg", "jpeg"},
+		{ThumbnailFormatWebP, ".webp", "webp"},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.format), func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.ThumbnailFormat = tt.format
+			processor := NewProcessor(cfg)
+
+			// The base path's extension is replaced by the configured one.
+			paths, err := processor.GenerateAllThumbnails(ctx, sourcePath, filepath.Join(tmpDir, "thumb-"+string(tt.format)+".png"))
+			if err != nil {
+				t.Fatalf("GenerateAllThumbnails() error = %v", err)
+			}
+
+			for size, path := range paths {
+				if ext := filepath.Ext(path); ext != tt.wantExt {
+					t.Errorf("size %s: extension = %q, want %q", size, ext, tt.wantExt)
+				}
+				if got := detectFormat(t, path); got != tt.wantFormat {
+					t.Errorf("size %s: format = %q, want %q", size, got, tt.wantFormat)
+				}
+			}
+		})
+	}
+
+	t.Run("extensionless_dest_uses_configured_format", func(t *testing.T) {
+		cfg := DefaultConfig()
+		cfg.ThumbnailFormat = ThumbnailFormatJPEG
+		processor := NewProcessor(cfg)
+
+		destPath := filepath.Join(tmpDir, "thumb-noext")
+		if err := processor.GenerateThumbnail(ctx, sourcePath, destPath, 200, 200); err != nil {
+			t.Fatalf("GenerateThumbnail() error = %v", err)
+		}
+		if got := detectFormat(t, destPath); got != "jpeg" {
+			t.Errorf("format = %q, want jpeg", got)
+		}
+	})
+}
+
+func TestProcessor_ThumbnailQualityAffectsSize(t *testing.T) {
+	tmpDir := t.TempDir()
+	sourcePath := createTestImage(t, 1200, 900, filepath.Join(tmpDir, "source.jpg"))
+	ctx := context.Background()
+
+	for _, format := range []ThumbnailFormat{ThumbnailFormatJPEG, ThumbnailFormatWebP} {
+		t.Run(string(format), func(t *testing.T) {
+			sizeAt := func(quality int) int64 {
+				cfg := DefaultConfig()
+				cfg.ThumbnailFormat = format
+				cfg.ThumbnailQuality = quality
+				processor := NewProcessor(cfg)
+
+				destPath := filepath.Join(tmpDir, fmt.Sprintf("q%d%s", quality, format.Extension()))
+				if err := processor.GenerateThumbnail(ctx, sourcePath, destPath, 800, 800); err != nil {
+					t.Fatalf("GenerateThumbnail() error = %v", err)
+				}
+				info, err := os.Stat(destPath)
+				if err != nil {
+					t.Fatalf("stat thumbnail: %v", err)
+				}
+				return info.Size()
+			}
+
+			low, high := sizeAt(10), sizeAt(95)
+			if low >= high {
+				t.Errorf("quality 10 produced %d bytes, quality 95 produced %d bytes; want lower quality to be smaller", low, high)
+			}
+		})
+	}
+}
+
+func TestParseThumbnailFormat(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    ThumbnailFormat
+		wantErr bool
+	}{
+		{"jpeg", ThumbnailFormatJPEG, false},
+		{"JPG", ThumbnailFormatJPEG, false},
+		{" webp ", ThumbnailFormatWebP, false},
+		{"png", "", true},
+		{"", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := ParseThumbnailFormat(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseThumbnailFormat(%q) error = %v, wantErr %v", tt.in, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseThumbnailFormat(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+			if !tt.wantErr && got.MimeType() != "image/"+string(got) {
+				t.Errorf("MimeType() = %q", got.MimeType())
+			}
+		})
+	}
+}
+
+// createLargeTestImage writes a large, cheaply encoded PNG whose decode and
+// resize take far longer than the millisecond timeouts used below.
+func createLargeTestImage(t *testing.T, size int) string {
+	t.Helper()
+
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	for i := range img.Pix {
+		img.Pix[i] = uint8(i * 7)
+	}
+
+	path := filepath.Join(t.TempDir(), "large.png")
+	file, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("failed to create test image file: %v", err)
+	}
+	defer file.Close()
+
+	enc := png.Encoder{CompressionLevel: png.BestSpeed}
+	if err := enc.Encode(file, img); err != nil {
+		t.Fatalf("failed to encode PNG: %v", err)
+	}
+	return path
+}
+
+func TestProcessor_ProcessingTimeout(t *testing.T) {
+	source := createLargeTestImage(t, 4000)
+
+	t.Run("thumbnail generation stops at the deadline", func(t *testing.T) {
+		config := DefaultConfig()
+		config.ProcessingTimeout = time.Millisecond
+		processor := NewProcessor(config)
+
+		start := time.Now()
+		err := processor.GenerateThumbnail(context.Background(), source, filepath.Join(t.TempDir(), "thumb.jpg"), 150, 150)
+		elapsed := time.Since(start)
+
+		if !errors.Is(err, ErrProcessingTimeout) {
+			t.Fatalf("GenerateThumbnail() error = %v, want ErrProcessingTimeout", err)
+		}
+		if elapsed > time.Second {
+			t.Errorf("GenerateThumbnail() returned after %s, want it to stop at the deadline", elapsed)
+		}
+	})
+
+	t.Run("all thumbnails share one deadline", func(t *testing.T) {
+		config := DefaultConfig()
+		config.ProcessingTimeout = time.Millisecond
+		processor := NewProcessor(config)
+
+		paths, err := processor.GenerateAllThumbnails(context.Background(), source, filepath.Join(t.TempDir(), "thumb"))
+		if !errors.Is(err, ErrProcessingTimeout) {
+			t.Fatalf("GenerateAllThumbnails() error = %v, want ErrProcessingTimeout", err)
+		}
+		if paths != nil {
+			t.Errorf("GenerateAllThumbnails() paths = %v, want nil", paths)
+		}
+	})
+
+	t.Run("zero timeout disables the limit", func(t *testing.T) {
+		config := DefaultConfig()
+		config.ProcessingTimeout = 0
+		processor := NewProcessor(config)
+
+		small := createTestImage(t, 200, 200, filepath.Join(t.TempDir(), "small.png"))
+		if err := processor.GenerateThumbnail(context.Background(), small, filepath.Join(t.TempDir(), "thumb.jpg"), 150, 150); err != nil {
+			t.Fatalf("GenerateThumbnail() error = %v", err)
+		}
+	})
+}
+
+func TestProcessor_ConvertToJPEG(t *testing.T) {
+	t.Run("fails without a converter", func(t *testing.T) {
+		processor := NewProcessor(DefaultConfig())
+
+		err := processor.ConvertToJPEG(context.Background(), "in.heic", "out.jpg")
+		if !errors.Is(err, ErrHEICUnsupported) {
+			t.Fatalf("ConvertToJPEG() error = %v, want ErrHEICUnsupported", err)
+		}
+	})
+
+	if runtime.GOOS == "windows" {
+		t.Skip("converter stub is a shell script")
+	}
+
+	// stub stands in for heif-convert: "<stub> -q <quality> <src> <dest>".
+	stub := func(t *testing.T, script string) string {
+		t.Helper()
+		path := filepath.Join(t.TempDir(), "heif-convert")
+		if err := os.WriteFile(path, []byte("#!/bin/sh\n"+script), 0o755); err != nil {
+			t.Fatalf("failed to write converter stub: %v", err)
+		}
+		return path
+	}
+
+	t.Run("runs the configured converter", func(t *testing.T) {
+		source := createTestImage(t, 200, 200, filepath.Join(t.TempDir(), "photo.png"))
+		dest := filepath.Join(t.TempDir(), "photo.jpg")
+		config := DefaultConfig()
+		config.HEICConverter = stub(t, `[ "$1" = "-q" ] && [ "$2" = "85" ] && cp "$3" "$4"`)
+
+		if err := NewProcessor(config).ConvertToJPEG(context.Background(), source, dest); err != nil {
+			t.Fatalf("ConvertToJPEG() error = %v", err)
+		}
+		if _, err := os.Stat(dest); err != nil {
+			t.Errorf("converted file missing: %v", err)
+		}
+	})
+
+	t.Run("reports converter failures", func(t *testing.T) {
+		config := DefaultConfig()
+		config.HEICConverter = stub(t, `echo "not a HEIF file" >&2; exit 1`)
+
+		err := NewProcessor(config).ConvertToJPEG(context.Background(), "in.heic", filepath.Join(t.TempDir(), "out.jpg"))
+		if !errors.Is(err, ErrInvalidFormat) {
+			t.Fatalf("ConvertToJPEG() error = %v, want ErrInvalidFormat", err)
+		}
+		if !strings.Contains(err.Error(), "not a HEIF file") {
+			t.Errorf("ConvertToJPEG() error = %v, want converter output", err)
+		}
+	})
+}
+
+func TestOpenImage_HonoursCancellation(t *testing.T) {
+	source := createLargeTestImage(t, 1000)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := openImage(ctx, source); !errors.Is(err, context.Canceled) {
+		t.Fatalf("openImage() error = %v, want context.Canceled", err)
+	}
+}
@@ -0,0 +1,96 @@
+// Package loginlockout locks out an email and IP address pair in Redis after
+// too many failed sign-ins, so a password cannot be guessed at full speed.
+package loginlockout
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Store counts failed sign-ins per (email, IP address) in a key that expires
+// with the counting window. Reaching maxAttempts replaces the counter with a
+// lock key that expires after the lockout duration. It implements
+// user.LoginLimiter.
+type Store struct {
+	client      *redis.Client
+	maxAttempts int
+	window      time.Duration
+	lockout     time.Duration
+}
+
+// NewStore creates a Store that locks a pair out for lockout once it has
+// failed maxAttempts times within window.
+func NewStore(client *redis.Client, maxAttempts int, window, lockout time.Duration) *Store {
+	return &Store{client: client, maxAttempts: maxAttempts, window: window, lockout: lockout}
+}
+
+func failuresKey(email, ip string) string {
+	return fmt.Sprintf("login_failures:%s:%s", normalizeEmail(email), ip)
+}
+
+func lockKey(email, ip string) string {
+	return fmt.Sprintf("login_lockout:%s:%s", normalizeEmail(email), ip)
+}
+
+// normalizeEmail makes "Jane@Example.com" and "jane@example.com" share a
+// counter, matching how accounts are looked up.
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// LockedFor returns how long the pair stays locked out, or zero if it is not.
+func (s *Store) LockedFor(ctx context.Context, email, ip string) (time.Duration, error) {
+	ttl, err := s.client.PTTL(ctx, lockKey(email, ip)).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to read login lockout: %w", err)
+	}
+	// PTTL is negative for a missing key (or one without an expiry, which
+	// this store never writes).
+	if ttl <= 0 {
+		return 0, nil
+	}
+	return ttl, nil
+}
+
+// RecordFailure counts a failed sign-in. When it is the one that reaches the
+// limit, the pair is locked out and the lockout duration is returned;
+// otherwise it returns zero.
+func (s *Store) RecordFailure(ctx context.Context, email, ip string) (time.Duration, error) {
+	k := failuresKey(email, ip)
+	count, err := s.client.Incr(ctx, k).Result()
+	if err != nil {
+		return 0, fmt.Errorf("failed to count failed sign-in: %w", err)
+	}
+	// The window starts at the first failure; later failures do not extend it.
+	if count == 1 {
+		if err := s.client.Expire(ctx, k, s.window).Err(); err != nil {
+			return 0, fmt.Errorf("failed to count failed sign-in: %w", err)
+		}
+	}
+	if count < int64(s.maxAttempts) {
+		return 0, nil
+	}
+
+	_, err = s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.Set(ctx, lockKey(email, ip), 1, s.lockout)
+		pipe.Del(ctx, k)
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to lock out sign-in: %w", err)
+	}
+	return s.lockout, nil
+}
+
+// Reset clears the pair's failure count and any lockout, after a successful
+// sign-in.
+func (s *Store) Reset(ctx context.Context, email, ip string) error {
+	if err := s.client.Del(ctx, failuresKey(email, ip), lockKey(email, ip)).Err(); err != nil {
+		return fmt.Errorf("failed to reset failed sign-ins: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,132 @@
+//go:build integration
+// +build integration
+
+package loginlockout
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testIP = "203.0.113.7"
+
+func newTestStore(t *testing.T, maxAttempts int, window, lockout time.Duration) (*Store, string) {
+	t.Helper()
+
+	addr := os.Getenv("TEST_REDIS_ADDR")
+	if addr == "" {
+		addr = "localhost:6379"
+	}
+	client := redis.NewClient(&redis.Options{Addr: addr})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		t.Skipf("skipping integration test: redis ping failed: %v", err)
+	}
+
+	// A fresh email per test so runs never share keys.
+	email := uuid.NewString() + "@example.com"
+	t.Cleanup(func() {
+		client.Del(context.Background(), failuresKey(email, testIP), lockKey(email, testIP))
+		client.Close()
+	})
+
+	return NewStore(client, maxAttempts, window, lockout), email
+}
+
+func TestStore_LocksOutAtThreshold(t *testing.T) {
+	s, email := newTestStore(t, 3, time.Minute, time.Hour)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		lockedFor, err := s.RecordFailure(ctx, email, testIP)
+		require.NoError(t, err)
+		assert.Zero(t, lockedFor)
+	}
+	lockedFor, err := s.LockedFor(ctx, email, testIP)
+	require.NoError(t, err)
+	assert.Zero(t, lockedFor, "not locked below the threshold")
+
+	lockedFor, err = s.RecordFailure(ctx, email, testIP)
+	require.NoError(t, err)
+	assert.Equal(t, time.Hour, lockedFor)
+
+	lockedFor, err = s.LockedFor(ctx, email, testIP)
+	require.NoError(t, err)
+	assert.Greater(t, lockedFor, 59*time.Minute)
+}
+
+func TestStore_KeysByEmailAndIP(t *testing.T) {
+	s, email := newTestStore(t, 1, time.Minute, time.Hour)
+	ctx := context.Background()
+
+	_, err := s.RecordFailure(ctx, "  "+email, testIP)
+	require.NoError(t, err)
+
+	lockedFor, err := s.LockedFor(ctx, email, testIP)
+	require.NoError(t, err)
+	assert.Positive(t, lockedFor, "email is matched case- and space-insensitively")
+
+	lockedFor, err = s.LockedFor(ctx, email, "198.51.100.4")
+	require.NoError(t, err)
+	assert.Zero(t, lockedFor, "another IP address is not locked out")
+}
+
+func TestStore_LockoutExpires(t *testing.T) {
+	s, email := newTestStore(t, 1, time.Minute, 200*time.Millisecond)
+	ctx := context.Background()
+
+	_, err := s.RecordFailure(ctx, email, testIP)
+	require.NoError(t, err)
+	lockedFor, err := s.LockedFor(ctx, email, testIP)
+	require.NoError(t, err)
+	assert.Positive(t, lockedFor)
+
+	time.Sleep(300 * time.Millisecond)
+
+	lockedFor, err = s.LockedFor(ctx, email, testIP)
+	require.NoError(t, err)
+	assert.Zero(t, lockedFor)
+}
+
+func TestStore_FailuresOutsideWindowDoNotCount(t *testing.T) {
+	s, email := newTestStore(t, 2, 200*time.Millisecond, time.Hour)
+	ctx := context.Background()
+
+	_, err := s.RecordFailure(ctx, email, testIP)
+	require.NoError(t, err)
+	time.Sleep(300 * time.Millisecond)
+
+	lockedFor, err := s.RecordFailure(ctx, email, testIP)
+	require.NoError(t, err)
+	assert.Zero(t, lockedFor, "the first failure's window has passed")
+}
+
+func TestStore_Reset(t *testing.T) {
+	s, email := newTestStore(t, 2, time.Minute, time.Hour)
+	ctx := context.Background()
+
+	_, err := s.RecordFailure(ctx, email, testIP)
+	require.NoError(t, err)
+	require.NoError(t, s.Reset(ctx, email, testIP))
+
+	lockedFor, err := s.RecordFailure(ctx, email, testIP)
+	require.NoError(t, err)
+	assert.Zero(t, lockedFor, "the count restarts after a reset")
+
+	_, err = s.RecordFailure(ctx, email, testIP)
+	require.NoError(t, err)
+	require.NoError(t, s.Reset(ctx, email, testIP))
+
+	lockedFor, err = s.LockedFor(ctx, email, testIP)
+	require.NoError(t, err)
+	assert.Zero(t, lockedFor, "a reset lifts a lockout")
+}
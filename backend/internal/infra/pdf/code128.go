@@ -0,0 +1,80 @@
+package pdf
+
+import "fmt"
+
+// code128Patterns are the bar/space widths, in modules, of every Code 128
+// symbol value. Each symbol starts with a bar and spans 11 modules; the stop
+// symbol (106) has a final 2-module bar and spans 13.
+var code128Patterns = [107]string{
+	"212222", "222122", "222221", "121223", "121322", "131222", "122213", "122312", "132212", "221213",
+	"221312", "231212", "112232", "122132", "122231", "113222", "123122", "123221", "223211", "221132",
+	"221231", "213212", "223112", "312131", "311222", "321122", "321221", "312212", "322112", "322211",
+	"212123", "212321", "232121", "111323", "131123", "131321", "112313", "132113", "132311", "211313",
+	"231113", "231311", "112133", "112331", "132131", "113123", "113321", "133121", "313121", "211331",
+	"231131", "213113", "213311", "213131", "311123", "311321", "331121", "312113", "312311", "332111",
+	"314111", "221411", "431111", "111224", "111422", "121124", "121421", "141122", "141221", "112214",
+	"112412", "122114", "122411", "142112", "142211", "241211", "221114", "413111", "241112", "134111",
+	"111242", "121142", "121241", "114212", "124112", "124211", "411212", "421112", "421211", "212141",
+	"214121", "412121", "111143", "111341", "131141", "114113", "114311", "411113", "411311", "113141",
+	"114131", "311141", "411131", "211412", "211214", "211232", "2331112",
+}
+
+const (
+	code128StartB = 104
+	code128Stop   = 106
+	// code128QuietZone is the blank margin scanners need on either side, in
+	// modules.
+	code128QuietZone = 10
+)
+
+// Code128 encodes s as a Code 128 (code set B) symbol: start, data, check
+// and stop symbols. It returns the values of the symbols in order. Code set
+// B covers printable ASCII, which is all short codes and SKUs use.
+func Code128(s string) ([]int, error) {
+	if s == "" {
+		return nil, fmt.Errorf("code 128: nothing to encode")
+	}
+	values := make([]int, 0, len(s)+3)
+	values = append(values, code128StartB)
+	check := code128StartB
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c < 32 || c > 126 {
+			return nil, fmt.Errorf("code 128: character %q is not printable ASCII", c)
+		}
+		v := int(c) - 32
+		values = append(values, v)
+		check += v * (i + 1)
+	}
+	values = append(values, check%103, code128Stop)
+	return values, nil
+}
+
+// Code128Width is the width in points of the barcode Barcode128 draws for
+// s, quiet zones included, at the given module width.
+func Code128Width(s string, module float64) float64 {
+	// 11 modules per symbol (start, data, check, stop) plus the stop's
+	// trailing bar, and a quiet zone on each side.
+	return float64(11*(len(s)+3)+2+2*code128QuietZone) * module
+}
+
+// Barcode128 draws s as a Code 128 barcode whose top-left corner (at the
+// start of the leading quiet zone) is (x, y). module is the width of the
+// narrowest bar; scanners read 0.75-1pt reliably from print.
+func (p *Page) Barcode128(x, y, module, height float64, s string) error {
+	values, err := Code128(s)
+	if err != nil {
+		return err
+	}
+	pos := x + code128QuietZone*module
+	for _, v := range values {
+		for i, w := range code128Patterns[v] {
+			width := float64(w-'0') * module
+			if i%2 == 0 { // even elements are bars, odd ones spaces
+				p.FillRect(pos, y, width, height)
+			}
+			pos += width
+		}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package pdf
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCode128Patterns(t *testing.T) {
+	for v, pattern := range code128Patterns {
+		modules := 0
+		for _, w := range pattern {
+			modules += int(w - '0')
+		}
+		if v == code128Stop {
+			assert.Equal(t, 13, modules, "stop symbol")
+			continue
+		}
+		assert.Equal(t, 11, modules, "symbol %d", v)
+		assert.Len(t, pattern, 6, "symbol %d", v)
+	}
+
+	seen := make(map[string]int)
+	for v, pattern := range code128Patterns {
+		prev, dup := seen[pattern]
+		assert.False(t, dup, "symbols %d and %d share a pattern", prev, v)
+		seen[pattern] = v
+	}
+}
+
+func TestCode128(t *testing.T) {
+	t.Run("start, data, check and stop", func(t *testing.T) {
+		values, err := Code128("AB")
+
+		require.NoError(t, err)
+		// A=33, B=34; check = (104 + 33*1 + 34*2) % 103 = 102.
+		assert.Equal(t, []int{code128StartB, 33, 34, 102, code128Stop}, values)
+	})
+
+	t.Run("rejects non-printable input", func(t *testing.T) {
+		_, err := Code128("ÄB")
+		assert.Error(t, err)
+
+		_, err = Code128("")
+		assert.Error(t, err)
+	})
+}
+
+func TestPage_Barcode128(t *testing.T) {
+	page := New().AddPage()
+
+	require.NoError(t, page.Barcode128(10, 10, 1, 30, "AB"))
+
+	// Three bars per symbol, four in the stop symbol.
+	assert.Equal(t, 3*4+4, strings.Count(page.content.String(), " re f\n"))
+	assert.Equal(t, float64(11*5+2+20), Code128Width("AB", 1))
+}
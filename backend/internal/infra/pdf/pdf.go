@@ -0,0 +1,177 @@
+// Package pdf writes simple A4 PDF documents: text in the standard Helvetica
+// faces, straight lines and solid colors. It is enough for printable forms
+// such as loan receipts and needs no font files, since every PDF reader
+// ships the standard 14 fonts.
+package pdf
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"strings"
+
+	"golang.org/x/text/encoding/charmap"
+)
+
+// A4 page size in points.
+const (
+	PageWidth  = 595.28
+	PageHeight = 841.89
+)
+
+// Font selects one of the standard Helvetica faces.
+type Font int
+
+const (
+	Regular Font = iota
+	Bold
+)
+
+// Resource names of the fonts in every page's resource dictionary.
+var fontNames = map[Font]string{
+	Regular: "F1",
+	Bold:    "F2",
+}
+
+// Document is a PDF under construction.
+type Document struct {
+	pages []*Page
+}
+
+// Page is one page of a Document. Coordinates are in points measured from
+// the top-left corner, y growing downwards.
+type Page struct {
+	content bytes.Buffer
+}
+
+// New returns an empty document.
+func New() *Document {
+	return &Document{}
+}
+
+// AddPage appends a blank A4 page and returns it.
+func (d *Document) AddPage() *Page {
+	p := &Page{}
+	d.pages = append(d.pages, p)
+	return p
+}
+
+// SetColor sets the fill and stroke color for what is drawn next. Components
+// are in the 0-1 range.
+func (p *Page) SetColor(r, g, b float64) {
+	fmt.Fprintf(&p.content, "%s %s %s rg %s %s %s RG\n",
+		num(r), num(g), num(b), num(r), num(g), num(b))
+}
+
+// Text draws s with its baseline starting at (x, y).
+func (p *Page) Text(x, y float64, font Font, size float64, s string) {
+	p.RotatedText(x, y, 0, font, size, s)
+}
+
+// RotatedText draws s with its baseline starting at (x, y), rotated
+// counter-clockwise by degrees.
+func (p *Page) RotatedText(x, y, degrees float64, font Font, size float64, s string) {
+	rad := degrees * math.Pi / 180
+	cos, sin := math.Cos(rad), math.Sin(rad)
+	fmt.Fprintf(&p.content, "BT /%s %s Tf %s %s %s %s %s %s Tm (%s) Tj ET\n",
+		fontNames[font], num(size),
+		num(cos), num(sin), num(-sin), num(cos), num(x), num(PageHeight-y),
+		escape(s))
+}
+
+// Line draws a straight line of the given stroke width.
+func (p *Page) Line(x1, y1, x2, y2, width float64) {
+	fmt.Fprintf(&p.content, "%s w %s %s m %s %s l S\n",
+		num(width), num(x1), num(PageHeight-y1), num(x2), num(PageHeight-y2))
+}
+
+// Rect draws the outline of a rectangle whose top-left corner is (x, y).
+func (p *Page) Rect(x, y, w, h, width float64) {
+	fmt.Fprintf(&p.content, "%s w %s %s %s %s re S\n",
+		num(width), num(x), num(PageHeight-y-h), num(w), num(h))
+}
+
+// FillRect draws a solid rectangle whose top-left corner is (x, y).
+func (p *Page) FillRect(x, y, w, h float64) {
+	fmt.Fprintf(&p.content, "%s %s %s %s re f\n",
+		num(x), num(PageHeight-y-h), num(w), num(h))
+}
+
+// Bytes serializes the document. A document without pages gets one blank
+// page, since a PDF must have at least one.
+func (d *Document) Bytes() []byte {
+	pages := d.pages
+	if len(pages) == 0 {
+		pages = []*Page{{}}
+	}
+
+	// Object layout: 1 catalog, 2 page tree, 3-4 fonts, then a page object
+	// and its content stream for every page.
+	const firstPageObj = 5
+	var objects []string
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", firstPageObj+2*i)
+	}
+	objects = append(objects,
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(pages)),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>",
+	)
+	for i, p := range pages {
+		objects = append(objects,
+			fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %s %s] "+
+				"/Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+				num(PageWidth), num(PageHeight), firstPageObj+2*i+1),
+			fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", p.content.Len(), p.content.String()),
+		)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, obj := range objects {
+		offsets[i] = buf.Len()
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+
+	xref := buf.Len()
+	fmt.Fprintf(&buf, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, off := range offsets {
+		fmt.Fprintf(&buf, "%010d 00000 n \n", off)
+	}
+	fmt.Fprintf(&buf, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+	return buf.Bytes()
+}
+
+// num formats a coordinate without exponent notation or trailing zeros.
+func num(f float64) string {
+	s := fmt.Sprintf("%.2f", f)
+	s = strings.TrimRight(strings.TrimRight(s, "0"), ".")
+	if s == "-0" {
+		return "0"
+	}
+	return s
+}
+
+// escape converts s to the fonts' WinAnsi encoding and escapes it for a PDF
+// literal string. Characters outside WinAnsi become '?'; control characters
+// become spaces.
+func escape(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		if r < 0x20 || r == 0x7f {
+			r = ' '
+		}
+		c, ok := charmap.Windows1252.EncodeRune(r)
+		if !ok {
+			c = '?'
+		}
+		if c == '(' || c == ')' || c == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(c)
+	}
+	return b.String()
+}
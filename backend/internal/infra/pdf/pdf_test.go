@@ -0,0 +1,73 @@
+package pdf
+
+import (
+	"bytes"
+	"regexp"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDocument_Structure(t *testing.T) {
+	doc := New()
+	page := doc.AddPage()
+	page.Text(50, 60, Bold, 18, "Hello")
+	page.Line(50, 70, 300, 70, 1)
+	doc.AddPage().Text(50, 60, Regular, 12, "Second")
+
+	out := doc.Bytes()
+
+	assert.True(t, bytes.HasPrefix(out, []byte("%PDF-1.4\n")))
+	assert.True(t, bytes.HasSuffix(out, []byte("%%EOF\n")))
+	assert.Contains(t, string(out), "/Count 2")
+	assert.Contains(t, string(out), "(Hello) Tj")
+	assert.Contains(t, string(out), "(Second) Tj")
+
+	// startxref must point at the xref table, and every xref entry at its object.
+	m := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(out)
+	require.NotNil(t, m)
+	xref, err := strconv.Atoi(string(m[1]))
+	require.NoError(t, err)
+	require.True(t, bytes.HasPrefix(out[xref:], []byte("xref\n")))
+
+	entries := regexp.MustCompile(`(\d{10}) 00000 n `).FindAllSubmatch(out[xref:], -1)
+	require.Len(t, entries, 8) // catalog, pages, 2 fonts, 2 x (page + content)
+	for i, e := range entries {
+		off, _ := strconv.Atoi(string(e[1]))
+		prefix := strconv.Itoa(i+1) + " 0 obj\n"
+		assert.True(t, bytes.HasPrefix(out[off:], []byte(prefix)), "object %d offset", i+1)
+	}
+}
+
+func TestDocument_EmptyHasOnePage(t *testing.T) {
+	out := New().Bytes()
+	assert.Contains(t, string(out), "/Count 1")
+}
+
+func TestPage_TextCoordinatesFromTop(t *testing.T) {
+	doc := New()
+	doc.AddPage().Text(10, 41.89, Regular, 12, "x")
+
+	assert.Contains(t, string(doc.Bytes()), "1 0 0 1 10 800 Tm")
+}
+
+func TestEscape(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{"plain", "Drill", "Drill"},
+		{"parentheses and backslash", `a (b) \c`, `a \(b\) \\c`},
+		{"latin-1 is re-encoded", "Jyväskylä", "Jyv\xe4skyl\xe4"},
+		{"unsupported runes are replaced", "工具", "??"},
+		{"control characters become spaces", "a\nb", "a b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, escape(tt.in))
+		})
+	}
+}
@@ -132,6 +132,53 @@ func (r *ActivityRepository) FindRecentActivity(ctx context.Context, workspaceID
 	return logs, nil
 }
 
+func (r *ActivityRepository) FindFeed(ctx context.Context, workspaceID uuid.UUID, filter activity.FeedFilter, pagination shared.Pagination) ([]activity.FeedEntry, int, error) {
+	var entityType queries.NullWarehouseActivityEntityEnum
+	if filter.EntityType != nil {
+		entityType = queries.NullWarehouseActivityEntityEnum{
+			WarehouseActivityEntityEnum: queries.WarehouseActivityEntityEnum(*filter.EntityType),
+			Valid:                       true,
+		}
+	}
+	var userID pgtype.UUID
+	if filter.UserID != nil {
+		userID = pgtype.UUID{Bytes: *filter.UserID, Valid: true}
+	}
+
+	rows, err := r.queries.ListActivityFeed(ctx, queries.ListActivityFeedParams{
+		WorkspaceID: workspaceID,
+		Limit:       int32(pagination.Limit()),
+		Offset:      int32(pagination.Offset()),
+		EntityType:  entityType,
+		UserID:      userID,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	total, err := r.queries.CountActivityFeed(ctx, queries.CountActivityFeedParams{
+		WorkspaceID: workspaceID,
+		EntityType:  entityType,
+		UserID:      userID,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	entries := make([]activity.FeedEntry, 0, len(rows))
+	for _, row := range rows {
+		entry := activity.FeedEntry{
+			// The feed row has the same columns as the workspace listing.
+			Log: r.rowToActivityLogFromWorkspace(queries.ListActivityByWorkspaceRow(row)),
+		}
+		if row.UserName != nil {
+			entry.ActorName = *row.UserName
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, int(total), nil
+}
+
 func (r *ActivityRepository) rowToActivityLog(row queries.WarehouseActivityLog) *activity.ActivityLog {
 	var userID *uuid.UUID
 	if row.UserID.Valid {
@@ -95,6 +95,51 @@ func TestActivityRepository_FindByWorkspace(t *testing.T) {
 	})
 }
 
+func TestActivityRepository_FindFeed(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	repo := NewActivityRepository(pool)
+	ctx := context.Background()
+
+	workspace := uuid.New()
+	testdb.CreateTestWorkspace(t, pool, workspace)
+	userID := testfixtures.TestUserID
+
+	item, _ := activity.NewActivityLog(workspace, &userID, activity.ActionCreate, activity.EntityItem, uuid.New(), "Drill", nil, nil)
+	require.NoError(t, repo.Save(ctx, item))
+	location, _ := activity.NewActivityLog(workspace, nil, activity.ActionUpdate, activity.EntityLocation, uuid.New(), "Garage", nil, nil)
+	require.NoError(t, repo.Save(ctx, location))
+
+	t.Run("lists newest first with the total", func(t *testing.T) {
+		entries, total, err := repo.FindFeed(ctx, workspace, activity.FeedFilter{}, shared.Pagination{Page: 1, PageSize: 1})
+		require.NoError(t, err)
+		assert.Equal(t, 2, total)
+		require.Len(t, entries, 1)
+		assert.Equal(t, location.ID(), entries[0].Log.ID())
+	})
+
+	t.Run("filters by entity type", func(t *testing.T) {
+		entityType := activity.EntityItem
+		entries, total, err := repo.FindFeed(ctx, workspace, activity.FeedFilter{EntityType: &entityType}, shared.Pagination{Page: 1, PageSize: 10})
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		require.Len(t, entries, 1)
+		assert.Equal(t, item.ID(), entries[0].Log.ID())
+		assert.NotEmpty(t, entries[0].ActorName)
+	})
+
+	t.Run("filters by actor", func(t *testing.T) {
+		entries, total, err := repo.FindFeed(ctx, workspace, activity.FeedFilter{UserID: &userID}, shared.Pagination{Page: 1, PageSize: 10})
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		require.Len(t, entries, 1)
+		assert.Equal(t, item.ID(), entries[0].Log.ID())
+	})
+}
+
 func TestActivityRepository_FindByEntity(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test")
@@ -2,13 +2,16 @@ package postgres
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/antti/home-warehouse/go-backend/internal/infra/queries"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
 )
 
 // AnalyticsRepository handles analytics-related database operations
@@ -93,3 +96,66 @@ func (r *AnalyticsRepository) GetMonthlyLoanActivity(ctx context.Context, worksp
 func (r *AnalyticsRepository) GetOutOfStockItems(ctx context.Context, workspaceID uuid.UUID) ([]queries.GetOutOfStockItemsRow, error) {
 	return r.q.GetOutOfStockItems(ctx, workspaceID)
 }
+
+// GetStaleInventory returns inventory whose last activity is older than cutoff
+func (r *AnalyticsRepository) GetStaleInventory(ctx context.Context, workspaceID uuid.UUID, cutoff time.Time, limit int32) ([]queries.GetStaleInventoryRow, error) {
+	return r.q.GetStaleInventory(ctx, queries.GetStaleInventoryParams{
+		WorkspaceID: workspaceID,
+		Cutoff:      cutoff,
+		RowLimit:    limit,
+	})
+}
+
+// GetTopValueItems returns the most valuable items in the workspace base currency
+func (r *AnalyticsRepository) GetTopValueItems(ctx context.Context, workspaceID uuid.UUID, limit int32) ([]queries.GetTopValueItemsRow, error) {
+	return r.q.GetTopValueItems(ctx, queries.GetTopValueItemsParams{
+		WorkspaceID: workspaceID,
+		RowLimit:    limit,
+	})
+}
+
+// CountTopValueExclusions counts inventory left out of the top-value report
+func (r *AnalyticsRepository) CountTopValueExclusions(ctx context.Context, workspaceID uuid.UUID) (queries.CountTopValueExclusionsRow, error) {
+	return r.q.CountTopValueExclusions(ctx, workspaceID)
+}
+
+// GetInventoryAging totals inventory by purchase-date aging bucket
+func (r *AnalyticsRepository) GetInventoryAging(ctx context.Context, workspaceID uuid.UUID, currentYear int32) ([]queries.GetInventoryAgingRow, error) {
+	return r.q.GetInventoryAging(ctx, queries.GetInventoryAgingParams{
+		WorkspaceID: workspaceID,
+		CurrentYear: currentYear,
+	})
+}
+
+// GetCurrencySettings returns the workspace currency settings, or
+// shared.ErrNotFound when none have been saved
+func (r *AnalyticsRepository) GetCurrencySettings(ctx context.Context, workspaceID uuid.UUID) (queries.WarehouseCurrencySetting, error) {
+	row, err := r.q.GetCurrencySettings(ctx, workspaceID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return row, shared.ErrNotFound
+	}
+	return row, err
+}
+
+// UpsertCurrencySettings creates or replaces the workspace currency settings
+func (r *AnalyticsRepository) UpsertCurrencySettings(ctx context.Context, workspaceID uuid.UUID, baseCurrency string, exchangeRates []byte) (queries.WarehouseCurrencySetting, error) {
+	return r.q.UpsertCurrencySettings(ctx, queries.UpsertCurrencySettingsParams{
+		WorkspaceID:   workspaceID,
+		BaseCurrency:  baseCurrency,
+		ExchangeRates: exchangeRates,
+	})
+}
+
+// GetWorkspaceStats returns the dashboard summary counts
+func (r *AnalyticsRepository) GetWorkspaceStats(ctx context.Context, workspaceID uuid.UUID, expiringDays int32) (queries.GetWorkspaceStatsRow, error) {
+	return r.q.GetWorkspaceStats(ctx, queries.GetWorkspaceStatsParams{
+		WorkspaceID:  workspaceID,
+		ExpiringDays: expiringDays,
+	})
+}
+
+// GetWorkspaceInventoryValue returns the total inventory value in the
+// workspace base currency
+func (r *AnalyticsRepository) GetWorkspaceInventoryValue(ctx context.Context, workspaceID uuid.UUID) (int64, error) {
+	return r.q.GetWorkspaceInventoryValue(ctx, workspaceID)
+}
@@ -20,6 +20,7 @@ import (
 	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/item"
 	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/loan"
 	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/location"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
 	"github.com/antti/home-warehouse/go-backend/tests/testdb"
 )
 
@@ -154,3 +155,176 @@ func TestAnalyticsRepository_GetDashboardStats(t *testing.T) {
 		assert.EqualValues(t, 2, stats1.TotalItems)
 	})
 }
+
+func TestAnalyticsRepository_GetWorkspaceStats(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	repos := newDashboardRepos(pool)
+	ctx := context.Background()
+
+	workspaceID := uuid.New()
+	testdb.CreateTestWorkspace(t, pool, workspaceID)
+	seedDashboardData(t, repos, ctx, workspaceID)
+
+	// Expire one of the seeded inventory rows soon and another well outside
+	// the window, and price the rows at 100.00 each.
+	_, err := pool.Exec(ctx, `
+		UPDATE warehouse.inventory SET purchase_price = 10000,
+			expiration_date = CASE WHEN quantity = 10 THEN CURRENT_DATE + 5 ELSE CURRENT_DATE + 90 END
+		WHERE workspace_id = $1`, workspaceID)
+	require.NoError(t, err)
+
+	t.Run("aggregates the summary counts", func(t *testing.T) {
+		stats, err := repos.analytics.GetWorkspaceStats(ctx, workspaceID, 30)
+		require.NoError(t, err)
+
+		assert.EqualValues(t, 2, stats.TotalItems)
+		assert.EqualValues(t, 12, stats.TotalQuantity)
+		assert.EqualValues(t, 2, stats.ActiveLoans)
+		assert.EqualValues(t, 1, stats.OverdueLoans)
+		assert.EqualValues(t, 1, stats.LowStockItems)
+		assert.EqualValues(t, 1, stats.ExpiringSoon)
+	})
+
+	t.Run("sums inventory value", func(t *testing.T) {
+		value, err := repos.analytics.GetWorkspaceInventoryValue(ctx, workspaceID)
+		require.NoError(t, err)
+		assert.EqualValues(t, 120000, value)
+	})
+
+	t.Run("empty workspace reports zeros", func(t *testing.T) {
+		other := uuid.New()
+		testdb.CreateTestWorkspace(t, pool, other)
+
+		stats, err := repos.analytics.GetWorkspaceStats(ctx, other, 30)
+		require.NoError(t, err)
+		assert.EqualValues(t, 0, stats.TotalItems)
+		assert.EqualValues(t, 0, stats.TotalQuantity)
+
+		value, err := repos.analytics.GetWorkspaceInventoryValue(ctx, other)
+		require.NoError(t, err)
+		assert.EqualValues(t, 0, value)
+	})
+}
+
+func TestAnalyticsRepository_GetStaleInventory(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	repos := newDashboardRepos(pool)
+	ctx := context.Background()
+
+	workspaceID := uuid.New()
+	testdb.CreateTestWorkspace(t, pool, workspaceID)
+	seedDashboardData(t, repos, ctx, workspaceID)
+
+	t.Run("freshly touched inventory is not stale", func(t *testing.T) {
+		rows, err := repos.analytics.GetStaleInventory(ctx, workspaceID, time.Now().AddDate(0, 0, -1), 100)
+		require.NoError(t, err)
+		assert.Empty(t, rows)
+	})
+
+	t.Run("everything is stale against a future cutoff", func(t *testing.T) {
+		rows, err := repos.analytics.GetStaleInventory(ctx, workspaceID, time.Now().Add(time.Hour), 100)
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+		for _, row := range rows {
+			assert.Equal(t, "Dash Location", row.LocationName)
+			assert.True(t, row.LastActivityAt.Valid)
+		}
+	})
+
+	t.Run("respects the limit", func(t *testing.T) {
+		rows, err := repos.analytics.GetStaleInventory(ctx, workspaceID, time.Now().Add(time.Hour), 1)
+		require.NoError(t, err)
+		assert.Len(t, rows, 1)
+	})
+
+	t.Run("archived items are left out", func(t *testing.T) {
+		_, err := pool.Exec(ctx, `UPDATE warehouse.items SET is_archived = true WHERE workspace_id = $1 AND name = 'Low Stock Item'`, workspaceID)
+		require.NoError(t, err)
+
+		rows, err := repos.analytics.GetStaleInventory(ctx, workspaceID, time.Now().Add(time.Hour), 100)
+		require.NoError(t, err)
+		require.Len(t, rows, 1)
+		assert.Equal(t, "Dash Item", rows[0].ItemName)
+
+		values, err := repos.analytics.GetInventoryValueByLocation(ctx, workspaceID, 10)
+		require.NoError(t, err)
+		require.Len(t, values, 1)
+		assert.EqualValues(t, 1, values[0].ItemCount)
+		assert.EqualValues(t, 10, values[0].TotalQuantity)
+	})
+}
+
+func TestAnalyticsRepository_GetTopValueItems(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	repos := newDashboardRepos(pool)
+	ctx := context.Background()
+
+	workspaceID := uuid.New()
+	testdb.CreateTestWorkspace(t, pool, workspaceID)
+
+	loc, err := location.NewLocation(workspaceID, "Vault", nil, nil, uuid.NewString()[:8])
+	require.NoError(t, err)
+	require.NoError(t, repos.location.Save(ctx, loc))
+
+	// addPricedInventory creates an item with one inventory row of qty units,
+	// priced at price cents of currency (nil price leaves it unpriced).
+	addPricedInventory := func(name string, qty int, price *int, currency string) uuid.UUID {
+		itm, err := item.NewItem(workspaceID, name, "SKU-"+uuid.NewString()[:8], 0)
+		require.NoError(t, err)
+		itm.SetShortCode(uuid.NewString()[:8])
+		require.NoError(t, repos.item.Save(ctx, itm))
+		inv, err := inventory.NewInventory(workspaceID, itm.ID(), loc.ID(), nil, qty, inventory.ConditionNew, inventory.StatusAvailable, nil)
+		require.NoError(t, err)
+		require.NoError(t, repos.inventory.Save(ctx, inv))
+		_, err = pool.Exec(ctx, `UPDATE warehouse.inventory SET purchase_price = $2, currency_code = $3 WHERE id = $1`, inv.ID(), price, currency)
+		require.NoError(t, err)
+		return itm.ID()
+	}
+	intPtr := func(v int) *int { return &v }
+
+	camera := addPricedInventory("Camera", 1, intPtr(90000), "EUR")
+	drills := addPricedInventory("Drill", 3, intPtr(10000), "USD")
+	addPricedInventory("Mystery box", 5, nil, "EUR")
+	addPricedInventory("Yen figurine", 1, intPtr(500000), "JPY")
+
+	_, err = repos.analytics.UpsertCurrencySettings(ctx, workspaceID, "EUR", []byte(`{"USD": 0.5}`))
+	require.NoError(t, err)
+
+	t.Run("ranks converted values and skips unconvertible rows", func(t *testing.T) {
+		rows, err := repos.analytics.GetTopValueItems(ctx, workspaceID, 10)
+		require.NoError(t, err)
+		require.Len(t, rows, 2)
+
+		assert.Equal(t, camera, rows[0].ID)
+		assert.EqualValues(t, 90000, rows[0].TotalValue)
+
+		assert.Equal(t, drills, rows[1].ID)
+		assert.EqualValues(t, 3, rows[1].Quantity)
+		assert.EqualValues(t, 5000, rows[1].UnitPrice)
+		assert.EqualValues(t, 15000, rows[1].TotalValue)
+	})
+
+	t.Run("counts exclusions", func(t *testing.T) {
+		counts, err := repos.analytics.CountTopValueExclusions(ctx, workspaceID)
+		require.NoError(t, err)
+		assert.EqualValues(t, 1, counts.Unpriced)
+		assert.EqualValues(t, 1, counts.Unconverted)
+	})
+
+	t.Run("missing settings report not found", func(t *testing.T) {
+		_, err := repos.analytics.GetCurrencySettings(ctx, uuid.New())
+		assert.ErrorIs(t, err, shared.ErrNotFound)
+	})
+}
@@ -0,0 +1,118 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/auth/apikey"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/auth/member"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+const apiKeyColumns = `id, workspace_id, name, key_prefix, key_hash, role, expires_at, last_used_at, revoked_at, created_by, created_at`
+
+// APIKeyRepository implements apikey.Repository using PostgreSQL.
+type APIKeyRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewAPIKeyRepository creates a new APIKeyRepository.
+func NewAPIKeyRepository(pool *pgxpool.Pool) *APIKeyRepository {
+	return &APIKeyRepository{pool: pool}
+}
+
+// Save persists a new key.
+func (r *APIKeyRepository) Save(ctx context.Context, k *apikey.APIKey) error {
+	query := `
+		INSERT INTO auth.api_keys (` + apiKeyColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	`
+	_, err := r.pool.Exec(ctx, query,
+		k.ID(),
+		k.WorkspaceID(),
+		k.Name(),
+		k.Prefix(),
+		k.KeyHash(),
+		string(k.Role()),
+		k.ExpiresAt(),
+		k.LastUsedAt(),
+		k.RevokedAt(),
+		k.CreatedBy(),
+		k.CreatedAt(),
+	)
+	return err
+}
+
+// FindByHash returns the key with the hash.
+func (r *APIKeyRepository) FindByHash(ctx context.Context, keyHash string) (*apikey.APIKey, error) {
+	query := `SELECT ` + apiKeyColumns + ` FROM auth.api_keys WHERE key_hash = $1`
+	k, err := scanAPIKey(r.pool.QueryRow(ctx, query, keyHash))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, shared.ErrNotFound
+	}
+	return k, err
+}
+
+// FindByWorkspace returns a workspace's keys, newest first.
+func (r *APIKeyRepository) FindByWorkspace(ctx context.Context, workspaceID uuid.UUID) ([]*apikey.APIKey, error) {
+	query := `
+		SELECT ` + apiKeyColumns + `
+		FROM auth.api_keys
+		WHERE workspace_id = $1
+		ORDER BY created_at DESC
+	`
+	rows, err := r.pool.Query(ctx, query, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	keys := []*apikey.APIKey{}
+	for rows.Next() {
+		k, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+	return keys, rows.Err()
+}
+
+// Revoke marks a workspace's unrevoked key revoked.
+func (r *APIKeyRepository) Revoke(ctx context.Context, workspaceID, id uuid.UUID, at time.Time) error {
+	tag, err := r.pool.Exec(ctx, `
+		UPDATE auth.api_keys SET revoked_at = $3
+		WHERE id = $1 AND workspace_id = $2 AND revoked_at IS NULL
+	`, id, workspaceID, at)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return shared.ErrNotFound
+	}
+	return nil
+}
+
+// TouchLastUsed records when a key was last used.
+func (r *APIKeyRepository) TouchLastUsed(ctx context.Context, id uuid.UUID, at time.Time) error {
+	_, err := r.pool.Exec(ctx, `UPDATE auth.api_keys SET last_used_at = $2 WHERE id = $1`, id, at)
+	return err
+}
+
+func scanAPIKey(row pgx.Row) (*apikey.APIKey, error) {
+	var (
+		id, workspaceID, createdBy       uuid.UUID
+		name, prefix, keyHash, role      string
+		expiresAt, lastUsedAt, revokedAt *time.Time
+		createdAt                        time.Time
+	)
+	if err := row.Scan(&id, &workspaceID, &name, &prefix, &keyHash, &role, &expiresAt, &lastUsedAt, &revokedAt, &createdBy, &createdAt); err != nil {
+		return nil, err
+	}
+	return apikey.Reconstruct(id, workspaceID, name, prefix, keyHash, member.Role(role), expiresAt, lastUsedAt, revokedAt, createdBy, createdAt), nil
+}
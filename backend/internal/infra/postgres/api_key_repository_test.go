@@ -0,0 +1,82 @@
+//go:build integration
+// +build integration
+
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/auth/apikey"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/auth/member"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+	"github.com/antti/home-warehouse/go-backend/tests/testdb"
+	"github.com/antti/home-warehouse/go-backend/tests/testfixtures"
+)
+
+func TestAPIKeyRepository_SaveAndFindByHash(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	repo := NewAPIKeyRepository(pool)
+	ctx := context.Background()
+
+	expiresAt := time.Now().Add(24 * time.Hour)
+	key, secret, err := apikey.NewAPIKey(testfixtures.TestWorkspaceID, testfixtures.TestUserID, "Home Assistant", member.RoleViewer, &expiresAt)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, key))
+
+	found, err := repo.FindByHash(ctx, apikey.HashKey(secret))
+	require.NoError(t, err)
+	assert.Equal(t, key.ID(), found.ID())
+	assert.Equal(t, "Home Assistant", found.Name())
+	assert.Equal(t, key.Prefix(), found.Prefix())
+	assert.Equal(t, member.RoleViewer, found.Role())
+	require.NotNil(t, found.ExpiresAt())
+	assert.WithinDuration(t, expiresAt, *found.ExpiresAt(), time.Millisecond)
+	assert.Nil(t, found.LastUsedAt())
+
+	_, err = repo.FindByHash(ctx, apikey.HashKey("hwk_unknown"))
+	assert.ErrorIs(t, err, shared.ErrNotFound)
+}
+
+func TestAPIKeyRepository_RevokeAndTouch(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	repo := NewAPIKeyRepository(pool)
+	ctx := context.Background()
+
+	key, secret, err := apikey.NewAPIKey(testfixtures.TestWorkspaceID, testfixtures.TestUserID, "script", member.RoleMember, nil)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, key))
+
+	usedAt := time.Now()
+	require.NoError(t, repo.TouchLastUsed(ctx, key.ID(), usedAt))
+
+	// Another workspace cannot revoke the key.
+	assert.ErrorIs(t, repo.Revoke(ctx, uuid.New(), key.ID(), time.Now()), shared.ErrNotFound)
+
+	require.NoError(t, repo.Revoke(ctx, testfixtures.TestWorkspaceID, key.ID(), time.Now()))
+	assert.ErrorIs(t, repo.Revoke(ctx, testfixtures.TestWorkspaceID, key.ID(), time.Now()), shared.ErrNotFound, "already revoked")
+
+	found, err := repo.FindByHash(ctx, apikey.HashKey(secret))
+	require.NoError(t, err)
+	assert.True(t, found.IsRevoked())
+	require.NotNil(t, found.LastUsedAt())
+	assert.WithinDuration(t, usedAt, *found.LastUsedAt(), time.Millisecond)
+
+	keys, err := repo.FindByWorkspace(ctx, testfixtures.TestWorkspaceID)
+	require.NoError(t, err)
+	require.Len(t, keys, 1)
+	assert.Equal(t, key.ID(), keys[0].ID())
+}
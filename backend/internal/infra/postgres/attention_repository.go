@@ -0,0 +1,67 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/inventory"
+	"github.com/antti/home-warehouse/go-backend/internal/infra/queries"
+)
+
+// AttentionRepository runs the overdue-loan and repair lookups of the
+// inventory needs-attention report.
+type AttentionRepository struct {
+	queries *queries.Queries
+}
+
+func NewAttentionRepository(pool *pgxpool.Pool) *AttentionRepository {
+	return &AttentionRepository{
+		queries: queries.New(pool),
+	}
+}
+
+func (r *AttentionRepository) FindOverdueLoans(ctx context.Context, workspaceID uuid.UUID) ([]inventory.AttentionEntry, error) {
+	rows, err := r.queries.ListOverdueLoanSummaries(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]inventory.AttentionEntry, len(rows))
+	for i, row := range rows {
+		entries[i] = inventory.AttentionEntry{
+			ItemID:       row.ItemID,
+			ItemName:     row.ItemName,
+			InventoryID:  &row.InventoryID,
+			LoanID:       &row.ID,
+			Quantity:     int(row.Quantity),
+			BorrowerName: &row.BorrowerName,
+		}
+		if row.DueDate.Valid {
+			entries[i].Date = &row.DueDate.Time
+		}
+	}
+	return entries, nil
+}
+
+func (r *AttentionRepository) FindForRepair(ctx context.Context, workspaceID uuid.UUID) ([]inventory.AttentionEntry, error) {
+	rows, err := r.queries.ListInventoryForRepair(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]inventory.AttentionEntry, len(rows))
+	for i, row := range rows {
+		entries[i] = inventory.AttentionEntry{
+			ItemID:      row.ItemID,
+			ItemName:    row.ItemName,
+			InventoryID: &row.ID,
+			Quantity:    int(row.Quantity),
+		}
+		if row.UpdatedAt.Valid {
+			entries[i].Date = &row.UpdatedAt.Time
+		}
+	}
+	return entries, nil
+}
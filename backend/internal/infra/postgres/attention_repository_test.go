@@ -0,0 +1,97 @@
+//go:build integration
+// +build integration
+
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/inventory"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/item"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/location"
+	"github.com/antti/home-warehouse/go-backend/tests/testdb"
+)
+
+func TestAttentionRepository(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	repos := newDashboardRepos(pool)
+	repo := NewAttentionRepository(pool)
+	ctx := context.Background()
+
+	workspaceID := uuid.New()
+	testdb.CreateTestWorkspace(t, pool, workspaceID)
+
+	loc, err := location.NewLocation(workspaceID, "Shed", nil, nil, uuid.NewString()[:8])
+	require.NoError(t, err)
+	require.NoError(t, repos.location.Save(ctx, loc))
+
+	addInventory := func(name string, condition inventory.Condition) *inventory.Inventory {
+		itm, err := item.NewItem(workspaceID, name, "SKU-"+uuid.NewString()[:8], 0)
+		require.NoError(t, err)
+		itm.SetShortCode(uuid.NewString()[:8])
+		require.NoError(t, repos.item.Save(ctx, itm))
+		inv, err := inventory.NewInventory(workspaceID, itm.ID(), loc.ID(), nil, 2, condition, inventory.StatusAvailable, nil)
+		require.NoError(t, err)
+		require.NoError(t, repos.inventory.Save(ctx, inv))
+		return inv
+	}
+	addLoan := func(inv *inventory.Inventory, borrowerName string, due time.Time) uuid.UUID {
+		var borrowerID, loanID uuid.UUID
+		require.NoError(t, pool.QueryRow(ctx,
+			`INSERT INTO warehouse.borrowers (workspace_id, name) VALUES ($1, $2) RETURNING id`,
+			workspaceID, borrowerName).Scan(&borrowerID))
+		require.NoError(t, pool.QueryRow(ctx,
+			`INSERT INTO warehouse.loans (workspace_id, inventory_id, borrower_id, quantity, due_date) VALUES ($1, $2, $3, 1, $4) RETURNING id`,
+			workspaceID, inv.ID(), borrowerID, due).Scan(&loanID))
+		return loanID
+	}
+
+	broken := addInventory("Broken lamp", inventory.ConditionForRepair)
+	addInventory("Good lamp", inventory.ConditionGood)
+
+	now := time.Now()
+	overdueLoan := addLoan(addInventory("Ladder", inventory.ConditionGood), "Bob", now.AddDate(0, 0, -10))
+	addLoan(addInventory("Saw", inventory.ConditionGood), "Carol", now.AddDate(0, 0, 10))
+	graceLoan := addLoan(addInventory("Tent", inventory.ConditionGood), "Dave", now.AddDate(0, 0, -2))
+
+	t.Run("finds inventory waiting for repair", func(t *testing.T) {
+		entries, err := repo.FindForRepair(ctx, workspaceID)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, broken.ID(), *entries[0].InventoryID)
+		assert.Equal(t, "Broken lamp", entries[0].ItemName)
+		assert.Equal(t, 2, entries[0].Quantity)
+	})
+
+	t.Run("finds overdue loans with borrower names", func(t *testing.T) {
+		entries, err := repo.FindOverdueLoans(ctx, workspaceID)
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		assert.Equal(t, overdueLoan, *entries[0].LoanID)
+		assert.Equal(t, "Ladder", entries[0].ItemName)
+		assert.Equal(t, "Bob", *entries[0].BorrowerName)
+		require.NotNil(t, entries[0].Date)
+		assert.Equal(t, graceLoan, *entries[1].LoanID)
+	})
+
+	t.Run("honours the overdue grace period", func(t *testing.T) {
+		_, err := pool.Exec(ctx,
+			`INSERT INTO warehouse.loan_settings (workspace_id, overdue_grace_days) VALUES ($1, 5)`, workspaceID)
+		require.NoError(t, err)
+
+		entries, err := repo.FindOverdueLoans(ctx, workspaceID)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, overdueLoan, *entries[0].LoanID)
+	})
+}
@@ -0,0 +1,154 @@
+package postgres
+
+import (
+	"context"
+	"net/netip"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/auth/authevent"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+const authEventColumns = `id, user_id, email, event_type, success, failure_reason, ip_address, user_agent, created_at`
+
+// AuthEventRepository implements authevent.Repository using PostgreSQL.
+type AuthEventRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewAuthEventRepository creates a new AuthEventRepository.
+func NewAuthEventRepository(pool *pgxpool.Pool) *AuthEventRepository {
+	return &AuthEventRepository{pool: pool}
+}
+
+// Save persists a new event.
+func (r *AuthEventRepository) Save(ctx context.Context, e *authevent.Event) error {
+	query := `
+		INSERT INTO auth.auth_events (` + authEventColumns + `)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+	`
+
+	// Parse IP address to netip.Addr for PostgreSQL INET type
+	var ipAddr *netip.Addr
+	if ipStr := e.IPAddress(); ipStr != "" {
+		if addr, err := netip.ParseAddr(ipStr); err == nil {
+			ipAddr = &addr
+		}
+	}
+
+	_, err := r.pool.Exec(ctx, query,
+		e.ID(),
+		e.UserID(),
+		oauthStrPtr(e.Email()),
+		string(e.Type()),
+		e.Success(),
+		oauthStrPtr(e.FailureReason()),
+		ipAddr,
+		oauthStrPtr(e.UserAgent()),
+		e.CreatedAt(),
+	)
+	return err
+}
+
+// FindByUser returns a user's events, newest first.
+func (r *AuthEventRepository) FindByUser(ctx context.Context, userID uuid.UUID, pagination shared.Pagination) ([]*authevent.Event, int, error) {
+	var total int
+	if err := r.pool.QueryRow(ctx, `SELECT COUNT(*) FROM auth.auth_events WHERE user_id = $1`, userID).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT ` + authEventColumns + `
+		FROM auth.auth_events
+		WHERE user_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.pool.Query(ctx, query, userID, pagination.Limit(), pagination.Offset())
+	if err != nil {
+		return nil, 0, err
+	}
+	events, err := r.scanEvents(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	return events, total, nil
+}
+
+// FindByWorkspace returns the events of a workspace's current members,
+// newest first.
+func (r *AuthEventRepository) FindByWorkspace(ctx context.Context, workspaceID uuid.UUID, pagination shared.Pagination) ([]*authevent.Event, int, error) {
+	members := `
+		FROM auth.auth_events e
+		JOIN auth.workspace_members m ON m.user_id = e.user_id
+		WHERE m.workspace_id = $1
+	`
+
+	var total int
+	if err := r.pool.QueryRow(ctx, `SELECT COUNT(*) `+members, workspaceID).Scan(&total); err != nil {
+		return nil, 0, err
+	}
+
+	query := `
+		SELECT e.id, e.user_id, e.email, e.event_type, e.success, e.failure_reason, e.ip_address, e.user_agent, e.created_at
+	` + members + `
+		ORDER BY e.created_at DESC
+		LIMIT $2 OFFSET $3
+	`
+	rows, err := r.pool.Query(ctx, query, workspaceID, pagination.Limit(), pagination.Offset())
+	if err != nil {
+		return nil, 0, err
+	}
+	events, err := r.scanEvents(rows)
+	if err != nil {
+		return nil, 0, err
+	}
+	return events, total, nil
+}
+
+// CountFailedLogins counts failed sign-ins for an email since a time.
+func (r *AuthEventRepository) CountFailedLogins(ctx context.Context, email string, since time.Time) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM auth.auth_events
+		WHERE email = $1 AND event_type = $2 AND NOT success AND created_at >= $3
+	`
+	var count int
+	err := r.pool.QueryRow(ctx, query, email, string(authevent.TypeLogin), since).Scan(&count)
+	return count, err
+}
+
+// scanEvents scans and closes rows of auth events.
+func (r *AuthEventRepository) scanEvents(rows pgx.Rows) ([]*authevent.Event, error) {
+	defer rows.Close()
+
+	var events []*authevent.Event
+	for rows.Next() {
+		var (
+			id            uuid.UUID
+			userID        *uuid.UUID
+			email         *string
+			eventType     string
+			success       bool
+			failureReason *string
+			ipAddress     *netip.Addr
+			userAgent     *string
+			createdAt     time.Time
+		)
+		if err := rows.Scan(&id, &userID, &email, &eventType, &success, &failureReason, &ipAddress, &userAgent, &createdAt); err != nil {
+			return nil, err
+		}
+
+		ip := ""
+		if ipAddress != nil {
+			ip = ipAddress.String()
+		}
+		events = append(events, authevent.Reconstitute(id, userID, oauthDerefStr(email), authevent.Type(eventType),
+			success, oauthDerefStr(failureReason), ip, oauthDerefStr(userAgent), createdAt))
+	}
+	return events, rows.Err()
+}
@@ -0,0 +1,102 @@
+//go:build integration
+// +build integration
+
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/auth/authevent"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+	"github.com/antti/home-warehouse/go-backend/tests/testdb"
+	"github.com/antti/home-warehouse/go-backend/tests/testfixtures"
+)
+
+func TestAuthEventRepository_SaveAndFindByUser(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	repo := NewAuthEventRepository(pool)
+	ctx := context.Background()
+	userID := testfixtures.TestUserID
+	client := authevent.Client{IPAddress: "203.0.113.7", UserAgent: "Mozilla/5.0"}
+
+	failed := authevent.NewEvent(&userID, "test@example.com", authevent.TypeLogin, false, authevent.ReasonInvalidCredentials, client)
+	require.NoError(t, repo.Save(ctx, failed))
+	time.Sleep(time.Millisecond)
+	succeeded := authevent.NewEvent(&userID, "test@example.com", authevent.TypeLogin, true, "", client)
+	require.NoError(t, repo.Save(ctx, succeeded))
+	// A failed sign-in for an unknown email belongs to no user.
+	require.NoError(t, repo.Save(ctx, authevent.NewEvent(nil, "nobody@example.com", authevent.TypeLogin, false, authevent.ReasonInvalidCredentials, client)))
+
+	events, total, err := repo.FindByUser(ctx, userID, shared.Pagination{Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 2, total)
+	require.Len(t, events, 2)
+	assert.Equal(t, succeeded.ID(), events[0].ID(), "newest first")
+	assert.Equal(t, failed.ID(), events[1].ID())
+	assert.False(t, events[1].Success())
+	assert.Equal(t, authevent.ReasonInvalidCredentials, events[1].FailureReason())
+	assert.Equal(t, "203.0.113.7", events[1].IPAddress())
+	assert.Equal(t, "Mozilla/5.0", events[1].UserAgent())
+	assert.Empty(t, events[0].FailureReason())
+}
+
+func TestAuthEventRepository_FindByWorkspace(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	repo := NewAuthEventRepository(pool)
+	ctx := context.Background()
+	memberID := testfixtures.TestUserID
+
+	require.NoError(t, repo.Save(ctx, authevent.NewEvent(&memberID, "test@example.com", authevent.TypePasswordChange, true, "", authevent.Client{})))
+	require.NoError(t, repo.Save(ctx, authevent.NewEvent(nil, "nobody@example.com", authevent.TypeLogin, false, authevent.ReasonInvalidCredentials, authevent.Client{})))
+
+	events, total, err := repo.FindByWorkspace(ctx, testfixtures.TestWorkspaceID, shared.Pagination{Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	require.Len(t, events, 1)
+	assert.Equal(t, authevent.TypePasswordChange, events[0].Type())
+
+	events, total, err = repo.FindByWorkspace(ctx, uuid.New(), shared.Pagination{Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	assert.Zero(t, total)
+	assert.Empty(t, events)
+}
+
+func TestAuthEventRepository_CountFailedLogins(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	repo := NewAuthEventRepository(pool)
+	ctx := context.Background()
+	email := "count-" + uuid.NewString()[:8] + "@example.com"
+	since := time.Now().Add(-time.Minute)
+
+	for i := 0; i < 3; i++ {
+		require.NoError(t, repo.Save(ctx, authevent.NewEvent(nil, email, authevent.TypeLogin, false, authevent.ReasonInvalidCredentials, authevent.Client{})))
+	}
+	require.NoError(t, repo.Save(ctx, authevent.NewEvent(nil, email, authevent.TypeLogin, true, "", authevent.Client{})))
+	require.NoError(t, repo.Save(ctx, authevent.NewEvent(nil, email, authevent.TypePasswordChange, false, authevent.ReasonInvalidPassword, authevent.Client{})))
+
+	count, err := repo.CountFailedLogins(ctx, email, since)
+	require.NoError(t, err)
+	assert.Equal(t, 3, count, "only failed sign-ins count")
+
+	count, err = repo.CountFailedLogins(ctx, email, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Zero(t, count)
+}
@@ -27,24 +27,26 @@ func NewBorrowerRepository(pool *pgxpool.Pool) *BorrowerRepository {
 
 func (r *BorrowerRepository) Create(ctx context.Context, b *borrower.Borrower) error {
 	_, err := r.queries.CreateBorrower(ctx, queries.CreateBorrowerParams{
-		ID:          b.ID(),
-		WorkspaceID: b.WorkspaceID(),
-		Name:        b.Name(),
-		Email:       b.Email(),
-		Phone:       b.Phone(),
-		Notes:       b.Notes(),
+		ID:              b.ID(),
+		WorkspaceID:     b.WorkspaceID(),
+		Name:            b.Name(),
+		Email:           b.Email(),
+		Phone:           b.Phone(),
+		Notes:           b.Notes(),
+		ReminderChannel: string(b.ReminderChannel()),
 	})
 	return err
 }
 
 func (r *BorrowerRepository) Save(ctx context.Context, b *borrower.Borrower) error {
 	_, err := r.queries.UpdateBorrower(ctx, queries.UpdateBorrowerParams{
-		ID:          b.ID(),
-		WorkspaceID: b.WorkspaceID(),
-		Name:        b.Name(),
-		Email:       b.Email(),
-		Phone:       b.Phone(),
-		Notes:       b.Notes(),
+		ID:              b.ID(),
+		WorkspaceID:     b.WorkspaceID(),
+		Name:            b.Name(),
+		Email:           b.Email(),
+		Phone:           b.Phone(),
+		Notes:           b.Notes(),
+		ReminderChannel: string(b.ReminderChannel()),
 	})
 	return err
 }
@@ -141,6 +143,7 @@ func (r *BorrowerRepository) rowToBorrower(row queries.WarehouseBorrower) *borro
 		row.Email,
 		row.Phone,
 		row.Notes,
+		borrower.ReminderChannel(row.ReminderChannel),
 		row.IsArchived,
 		row.CreatedAt.Time,
 		row.UpdatedAt.Time,
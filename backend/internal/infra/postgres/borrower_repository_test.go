@@ -47,6 +47,7 @@ func TestBorrowerRepository_Save(t *testing.T) {
 		notes := "VIP borrower"
 		b, err := borrower.NewBorrower(testfixtures.TestWorkspaceID, "Jane Doe", &email, &phone, &notes)
 		require.NoError(t, err)
+		require.NoError(t, b.SetReminderChannel(borrower.ReminderChannelSMS))
 
 		err = repo.Create(ctx, b)
 		require.NoError(t, err)
@@ -58,6 +59,7 @@ func TestBorrowerRepository_Save(t *testing.T) {
 		assert.Equal(t, email, *retrieved.Email())
 		assert.Equal(t, phone, *retrieved.Phone())
 		assert.Equal(t, notes, *retrieved.Notes())
+		assert.Equal(t, borrower.ReminderChannelSMS, retrieved.ReminderChannel())
 	})
 }
 
@@ -0,0 +1,104 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/inventory"
+	"github.com/antti/home-warehouse/go-backend/internal/infra/queries"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// CategoryInventoryDefaultsRepository persists the condition and status new
+// inventory gets per category (warehouse.category_inventory_defaults).
+type CategoryInventoryDefaultsRepository struct {
+	queries *queries.Queries
+}
+
+func NewCategoryInventoryDefaultsRepository(pool *pgxpool.Pool) *CategoryInventoryDefaultsRepository {
+	return &CategoryInventoryDefaultsRepository{
+		queries: queries.New(pool),
+	}
+}
+
+func (r *CategoryInventoryDefaultsRepository) Get(ctx context.Context, workspaceID, categoryID uuid.UUID) (*inventory.CategoryDefaults, error) {
+	row, err := r.queries.GetCategoryInventoryDefaults(ctx, queries.GetCategoryInventoryDefaultsParams{
+		CategoryID:  categoryID,
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, shared.ErrNotFound
+		}
+		return nil, err
+	}
+	return rowToCategoryDefaults(row), nil
+}
+
+func (r *CategoryInventoryDefaultsRepository) List(ctx context.Context, workspaceID uuid.UUID) ([]*inventory.CategoryDefaults, error) {
+	rows, err := r.queries.ListCategoryInventoryDefaults(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defaults := make([]*inventory.CategoryDefaults, len(rows))
+	for i, row := range rows {
+		defaults[i] = rowToCategoryDefaults(row)
+	}
+	return defaults, nil
+}
+
+func (r *CategoryInventoryDefaultsRepository) Upsert(ctx context.Context, workspaceID uuid.UUID, defaults inventory.CategoryDefaults) (*inventory.CategoryDefaults, error) {
+	params := queries.UpsertCategoryInventoryDefaultsParams{
+		CategoryID:  defaults.CategoryID,
+		WorkspaceID: workspaceID,
+	}
+	if defaults.Condition != nil {
+		params.DefaultCondition = queries.NullWarehouseItemConditionEnum{
+			WarehouseItemConditionEnum: queries.WarehouseItemConditionEnum(*defaults.Condition),
+			Valid:                      true,
+		}
+	}
+	if defaults.Status != nil {
+		params.DefaultStatus = queries.NullWarehouseItemStatusEnum{
+			WarehouseItemStatusEnum: queries.WarehouseItemStatusEnum(*defaults.Status),
+			Valid:                   true,
+		}
+	}
+
+	row, err := r.queries.UpsertCategoryInventoryDefaults(ctx, params)
+	if err != nil {
+		// No row: the category is not in this workspace.
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, shared.ErrNotFound
+		}
+		return nil, err
+	}
+	return rowToCategoryDefaults(row), nil
+}
+
+func (r *CategoryInventoryDefaultsRepository) Delete(ctx context.Context, workspaceID, categoryID uuid.UUID) error {
+	return r.queries.DeleteCategoryInventoryDefaults(ctx, queries.DeleteCategoryInventoryDefaultsParams{
+		CategoryID:  categoryID,
+		WorkspaceID: workspaceID,
+	})
+}
+
+func rowToCategoryDefaults(row queries.WarehouseCategoryInventoryDefault) *inventory.CategoryDefaults {
+	defaults := &inventory.CategoryDefaults{
+		CategoryID: row.CategoryID,
+		UpdatedAt:  &row.UpdatedAt,
+	}
+	if row.DefaultCondition.Valid {
+		condition := inventory.Condition(row.DefaultCondition.WarehouseItemConditionEnum)
+		defaults.Condition = &condition
+	}
+	if row.DefaultStatus.Valid {
+		status := inventory.Status(row.DefaultStatus.WarehouseItemStatusEnum)
+		defaults.Status = &status
+	}
+	return defaults
+}
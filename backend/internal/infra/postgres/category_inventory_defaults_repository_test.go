@@ -0,0 +1,62 @@
+//go:build integration
+// +build integration
+
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/category"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/inventory"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+	"github.com/antti/home-warehouse/go-backend/tests/testdb"
+	"github.com/antti/home-warehouse/go-backend/tests/testfixtures"
+)
+
+func TestCategoryInventoryDefaultsRepository(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	repo := NewCategoryInventoryDefaultsRepository(pool)
+	ctx := context.Background()
+
+	cat, err := category.NewCategory(testfixtures.TestWorkspaceID, "Tools", nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, NewCategoryRepository(pool).Save(ctx, cat))
+
+	_, err = repo.Get(ctx, testfixtures.TestWorkspaceID, cat.ID())
+	assert.ErrorIs(t, err, shared.ErrNotFound)
+
+	condition := inventory.ConditionGood
+	saved, err := repo.Upsert(ctx, testfixtures.TestWorkspaceID, inventory.CategoryDefaults{CategoryID: cat.ID(), Condition: &condition})
+	require.NoError(t, err)
+	assert.Equal(t, inventory.ConditionGood, *saved.Condition)
+	assert.Nil(t, saved.Status)
+
+	status := inventory.StatusInUse
+	_, err = repo.Upsert(ctx, testfixtures.TestWorkspaceID, inventory.CategoryDefaults{CategoryID: cat.ID(), Status: &status})
+	require.NoError(t, err)
+
+	got, err := repo.Get(ctx, testfixtures.TestWorkspaceID, cat.ID())
+	require.NoError(t, err)
+	assert.Nil(t, got.Condition)
+	assert.Equal(t, inventory.StatusInUse, *got.Status)
+
+	all, err := repo.List(ctx, testfixtures.TestWorkspaceID)
+	require.NoError(t, err)
+	assert.Len(t, all, 1)
+
+	_, err = repo.Upsert(ctx, testfixtures.TestWorkspaceID, inventory.CategoryDefaults{CategoryID: uuid.New(), Status: &status})
+	assert.ErrorIs(t, err, shared.ErrNotFound)
+
+	require.NoError(t, repo.Delete(ctx, testfixtures.TestWorkspaceID, cat.ID()))
+	_, err = repo.Get(ctx, testfixtures.TestWorkspaceID, cat.ID())
+	assert.ErrorIs(t, err, shared.ErrNotFound)
+}
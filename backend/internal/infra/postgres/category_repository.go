@@ -146,9 +146,10 @@ func (r *CategoryRepository) FindRootCategories(ctx context.Context, workspaceID
 	return categories, nil
 }
 
-// Delete removes a category by ID.
+// Delete removes a category by ID. Uses the transaction in ctx (if any) so a
+// merge's final delete commits with its moves.
 func (r *CategoryRepository) Delete(ctx context.Context, id, workspaceID uuid.UUID) error {
-	return r.queries.DeleteCategory(ctx, queries.DeleteCategoryParams{
+	return queries.New(GetDBTX(ctx, r.pool)).DeleteCategory(ctx, queries.DeleteCategoryParams{
 		ID:          id,
 		WorkspaceID: workspaceID,
 	})
@@ -162,6 +163,34 @@ func (r *CategoryRepository) HasChildren(ctx context.Context, workspaceID, paren
 	})
 }
 
+// MoveItems re-points every item in fromCategoryID to toCategoryID. Uses the
+// transaction in ctx (if any).
+func (r *CategoryRepository) MoveItems(ctx context.Context, workspaceID, fromCategoryID, toCategoryID uuid.UUID) (int, error) {
+	moved, err := queries.New(GetDBTX(ctx, r.pool)).MoveCategoryItems(ctx, queries.MoveCategoryItemsParams{
+		ToCategoryID:   toCategoryID,
+		WorkspaceID:    workspaceID,
+		FromCategoryID: pgtype.UUID{Bytes: fromCategoryID, Valid: true},
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(moved), nil
+}
+
+// MoveChildren re-parents every direct child of fromCategoryID onto
+// toCategoryID. Uses the transaction in ctx (if any).
+func (r *CategoryRepository) MoveChildren(ctx context.Context, workspaceID, fromCategoryID, toCategoryID uuid.UUID) (int, error) {
+	moved, err := queries.New(GetDBTX(ctx, r.pool)).MoveCategoryChildren(ctx, queries.MoveCategoryChildrenParams{
+		ToCategoryID:   toCategoryID,
+		WorkspaceID:    workspaceID,
+		FromCategoryID: pgtype.UUID{Bytes: fromCategoryID, Valid: true},
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(moved), nil
+}
+
 // rowToCategory converts a database row to a Category entity.
 func (r *CategoryRepository) rowToCategory(row queries.WarehouseCategory) *category.Category {
 	// Convert parent category ID
@@ -396,3 +396,56 @@ func TestCategoryRepository_HasChildren(t *testing.T) {
 		assert.False(t, hasChildren)
 	})
 }
+
+func TestCategoryRepository_MoveItemsAndChildren(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	repo := NewCategoryRepository(pool)
+	itemRepo := NewItemRepository(pool)
+	ctx := context.Background()
+
+	keep, err := category.NewCategory(testfixtures.TestWorkspaceID, "Keep "+uuid.NewString()[:4], nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, keep))
+
+	merged, err := category.NewCategory(testfixtures.TestWorkspaceID, "Merged "+uuid.NewString()[:4], nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, merged))
+
+	mergedID := merged.ID()
+	child, err := category.NewCategory(testfixtures.TestWorkspaceID, "Child "+uuid.NewString()[:4], &mergedID, nil)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, child))
+
+	itm := createTestItem(t, itemRepo, ctx, "Merged item "+uuid.NewString()[:4])
+	_, err = itemRepo.ReassignCategory(ctx, testfixtures.TestWorkspaceID, []uuid.UUID{itm.ID()}, &mergedID)
+	require.NoError(t, err)
+
+	t.Run("re-points items", func(t *testing.T) {
+		moved, err := repo.MoveItems(ctx, testfixtures.TestWorkspaceID, merged.ID(), keep.ID())
+		require.NoError(t, err)
+		assert.Equal(t, 1, moved)
+
+		found, err := itemRepo.FindByID(ctx, itm.ID(), testfixtures.TestWorkspaceID)
+		require.NoError(t, err)
+		require.NotNil(t, found.CategoryID())
+		assert.Equal(t, keep.ID(), *found.CategoryID())
+	})
+
+	t.Run("re-parents child categories", func(t *testing.T) {
+		moved, err := repo.MoveChildren(ctx, testfixtures.TestWorkspaceID, merged.ID(), keep.ID())
+		require.NoError(t, err)
+		assert.Equal(t, 1, moved)
+
+		found, err := repo.FindByID(ctx, child.ID(), testfixtures.TestWorkspaceID)
+		require.NoError(t, err)
+		require.NotNil(t, found.ParentCategoryID())
+		assert.Equal(t, keep.ID(), *found.ParentCategoryID())
+
+		// The merged category is now empty and can be deleted.
+		require.NoError(t, repo.Delete(ctx, merged.ID(), testfixtures.TestWorkspaceID))
+	})
+}
@@ -0,0 +1,106 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/item"
+	"github.com/antti/home-warehouse/go-backend/internal/infra/queries"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// CategoryRuleRepository persists the keyword rules that categorize new
+// items (warehouse.category_rules). It joins a surrounding transaction, so
+// a reorder commits as a whole.
+type CategoryRuleRepository struct {
+	pool *pgxpool.Pool
+}
+
+func NewCategoryRuleRepository(pool *pgxpool.Pool) *CategoryRuleRepository {
+	return &CategoryRuleRepository{pool: pool}
+}
+
+func (r *CategoryRuleRepository) List(ctx context.Context, workspaceID uuid.UUID) ([]*item.CategoryRule, error) {
+	q := queries.New(GetDBTX(ctx, r.pool))
+
+	rows, err := q.ListCategoryRules(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	rules := make([]*item.CategoryRule, len(rows))
+	for i, row := range rows {
+		rules[i] = rowToCategoryRule(row)
+	}
+	return rules, nil
+}
+
+func (r *CategoryRuleRepository) Create(ctx context.Context, workspaceID uuid.UUID, rule item.CategoryRule) (*item.CategoryRule, error) {
+	q := queries.New(GetDBTX(ctx, r.pool))
+
+	row, err := q.CreateCategoryRule(ctx, queries.CreateCategoryRuleParams{
+		ID:          rule.ID,
+		WorkspaceID: workspaceID,
+		CategoryID:  rule.CategoryID,
+		Pattern:     rule.Pattern,
+		MatchType:   string(rule.MatchType),
+		Position:    int32(rule.Position),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rowToCategoryRule(row), nil
+}
+
+func (r *CategoryRuleRepository) Update(ctx context.Context, workspaceID uuid.UUID, rule item.CategoryRule) (*item.CategoryRule, error) {
+	q := queries.New(GetDBTX(ctx, r.pool))
+
+	row, err := q.UpdateCategoryRule(ctx, queries.UpdateCategoryRuleParams{
+		ID:          rule.ID,
+		WorkspaceID: workspaceID,
+		CategoryID:  rule.CategoryID,
+		Pattern:     rule.Pattern,
+		MatchType:   string(rule.MatchType),
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, shared.ErrNotFound
+		}
+		return nil, err
+	}
+	return rowToCategoryRule(row), nil
+}
+
+func (r *CategoryRuleRepository) UpdatePosition(ctx context.Context, workspaceID, id uuid.UUID, position int) error {
+	q := queries.New(GetDBTX(ctx, r.pool))
+
+	return q.UpdateCategoryRulePosition(ctx, queries.UpdateCategoryRulePositionParams{
+		ID:          id,
+		WorkspaceID: workspaceID,
+		Position:    int32(position),
+	})
+}
+
+func (r *CategoryRuleRepository) Delete(ctx context.Context, workspaceID, id uuid.UUID) error {
+	q := queries.New(GetDBTX(ctx, r.pool))
+
+	return q.DeleteCategoryRule(ctx, queries.DeleteCategoryRuleParams{
+		ID:          id,
+		WorkspaceID: workspaceID,
+	})
+}
+
+func rowToCategoryRule(row queries.WarehouseCategoryRule) *item.CategoryRule {
+	return &item.CategoryRule{
+		ID:         row.ID,
+		CategoryID: row.CategoryID,
+		Pattern:    row.Pattern,
+		MatchType:  item.MatchType(row.MatchType),
+		Position:   int(row.Position),
+		CreatedAt:  row.CreatedAt,
+		UpdatedAt:  row.UpdatedAt,
+	}
+}
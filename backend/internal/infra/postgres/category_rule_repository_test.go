@@ -0,0 +1,88 @@
+//go:build integration
+// +build integration
+
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/category"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/item"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+	"github.com/antti/home-warehouse/go-backend/tests/testdb"
+	"github.com/antti/home-warehouse/go-backend/tests/testfixtures"
+)
+
+func TestCategoryRuleRepository(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	repo := NewCategoryRuleRepository(pool)
+	ctx := context.Background()
+
+	tools, err := category.NewCategory(testfixtures.TestWorkspaceID, "Tools", nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, NewCategoryRepository(pool).Save(ctx, tools))
+	cables, err := category.NewCategory(testfixtures.TestWorkspaceID, "Cables", nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, NewCategoryRepository(pool).Save(ctx, cables))
+
+	first, err := repo.Create(ctx, testfixtures.TestWorkspaceID, item.CategoryRule{
+		ID: uuid.New(), CategoryID: tools.ID(), Pattern: "drill", MatchType: item.MatchSubstring, Position: 0,
+	})
+	require.NoError(t, err)
+	second, err := repo.Create(ctx, testfixtures.TestWorkspaceID, item.CategoryRule{
+		ID: uuid.New(), CategoryID: cables.ID(), Pattern: `^usb-[ac]\b`, MatchType: item.MatchRegex, Position: 1,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, item.MatchRegex, second.MatchType)
+
+	all, err := repo.List(ctx, testfixtures.TestWorkspaceID)
+	require.NoError(t, err)
+	require.Len(t, all, 2)
+	assert.Equal(t, first.ID, all[0].ID)
+
+	t.Run("reorders", func(t *testing.T) {
+		require.NoError(t, repo.UpdatePosition(ctx, testfixtures.TestWorkspaceID, first.ID, 1))
+		require.NoError(t, repo.UpdatePosition(ctx, testfixtures.TestWorkspaceID, second.ID, 0))
+
+		all, err := repo.List(ctx, testfixtures.TestWorkspaceID)
+		require.NoError(t, err)
+		assert.Equal(t, []uuid.UUID{second.ID, first.ID}, []uuid.UUID{all[0].ID, all[1].ID})
+	})
+
+	t.Run("updates", func(t *testing.T) {
+		updated, err := repo.Update(ctx, testfixtures.TestWorkspaceID, item.CategoryRule{
+			ID: first.ID, CategoryID: cables.ID(), Pattern: "hdmi", MatchType: item.MatchSubstring,
+		})
+		require.NoError(t, err)
+		assert.Equal(t, cables.ID(), updated.CategoryID)
+		assert.Equal(t, "hdmi", updated.Pattern)
+		assert.Equal(t, 1, updated.Position)
+	})
+
+	t.Run("update of another workspace's rule is not found", func(t *testing.T) {
+		_, err := repo.Update(ctx, uuid.New(), item.CategoryRule{
+			ID: first.ID, CategoryID: cables.ID(), Pattern: "x", MatchType: item.MatchSubstring,
+		})
+		assert.ErrorIs(t, err, shared.ErrNotFound)
+	})
+
+	t.Run("deleting the category deletes its rules", func(t *testing.T) {
+		_, err := pool.Exec(ctx, "DELETE FROM warehouse.categories WHERE id = $1", tools.ID())
+		require.NoError(t, err)
+		require.NoError(t, repo.Delete(ctx, testfixtures.TestWorkspaceID, second.ID))
+
+		all, err := repo.List(ctx, testfixtures.TestWorkspaceID)
+		require.NoError(t, err)
+		require.Len(t, all, 1)
+		assert.Equal(t, first.ID, all[0].ID)
+	})
+}
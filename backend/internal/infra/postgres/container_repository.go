@@ -6,6 +6,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/container"
@@ -171,6 +172,63 @@ func (r *ContainerRepository) Search(ctx context.Context, workspaceID uuid.UUID,
 	return containers, nil
 }
 
+func (r *ContainerRepository) FindContents(ctx context.Context, workspaceID, containerID uuid.UUID, pagination shared.Pagination) ([]container.ContentsEntry, error) {
+	rows, err := r.queries.ListContainerContents(ctx, queries.ListContainerContentsParams{
+		WorkspaceID: workspaceID,
+		ContainerID: pgtype.UUID{Bytes: containerID, Valid: true},
+		RowLimit:    int32(pagination.Limit()),
+		RowOffset:   int32(pagination.Offset()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]container.ContentsEntry, 0, len(rows))
+	for _, row := range rows {
+		entry := container.ContentsEntry{
+			InventoryID:  row.ID,
+			ItemID:       row.ItemID,
+			ItemName:     row.ItemName,
+			ItemSKU:      row.ItemSku,
+			Quantity:     int(row.Quantity),
+			CurrencyCode: row.CurrencyCode,
+			Value:        row.Value,
+		}
+		if row.Condition.Valid {
+			entry.Condition = string(row.Condition.WarehouseItemConditionEnum)
+		}
+		if row.Status.Valid {
+			entry.Status = string(row.Status.WarehouseItemStatusEnum)
+		}
+		if row.PurchasePrice != nil {
+			price := int(*row.PurchasePrice)
+			entry.PurchasePrice = &price
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+func (r *ContainerRepository) GetContentsSummary(ctx context.Context, workspaceID, containerID uuid.UUID) (container.ContentsSummary, error) {
+	row, err := r.queries.GetContainerContentsSummary(ctx, queries.GetContainerContentsSummaryParams{
+		WorkspaceID: workspaceID,
+		ContainerID: pgtype.UUID{Bytes: containerID, Valid: true},
+	})
+	if err != nil {
+		return container.ContentsSummary{}, err
+	}
+
+	return container.ContentsSummary{
+		Entries:            int(row.Entries),
+		TotalQuantity:      row.TotalQuantity,
+		TotalValue:         row.TotalValue,
+		Currency:           row.BaseCurrency,
+		UnpricedEntries:    int(row.Unpriced),
+		UnconvertedEntries: int(row.Unconverted),
+	}, nil
+}
+
 func (r *ContainerRepository) rowToContainer(row queries.WarehouseContainer) *container.Container {
 	return container.Reconstruct(
 		row.ID,
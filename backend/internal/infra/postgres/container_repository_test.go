@@ -12,6 +12,8 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/container"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/inventory"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/item"
 	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/location"
 	"github.com/antti/home-warehouse/go-backend/internal/shared"
 	"github.com/antti/home-warehouse/go-backend/tests/testdb"
@@ -306,3 +308,95 @@ func TestContainerRepository_ShortCodeExists(t *testing.T) {
 		assert.False(t, exists)
 	})
 }
+
+func TestContainerRepository_Contents(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	repos := newDashboardRepos(pool)
+	ctx := context.Background()
+
+	workspaceID := uuid.New()
+	testdb.CreateTestWorkspace(t, pool, workspaceID)
+
+	loc, err := location.NewLocation(workspaceID, "Garage", nil, nil, uuid.NewString()[:8])
+	require.NoError(t, err)
+	require.NoError(t, repos.location.Save(ctx, loc))
+	box, err := container.NewContainer(workspaceID, loc.ID(), "Box 1", nil, nil, uuid.NewString()[:8])
+	require.NoError(t, err)
+	require.NoError(t, repos.container.Save(ctx, box))
+
+	// addInventory stores qty units of a new item in containerID, priced at
+	// price cents of currency (nil price leaves it unpriced).
+	addInventory := func(name string, containerID *uuid.UUID, qty int, price *int, currency string) {
+		itm, err := item.NewItem(workspaceID, name, "SKU-"+uuid.NewString()[:8], 0)
+		require.NoError(t, err)
+		itm.SetShortCode(uuid.NewString()[:8])
+		require.NoError(t, repos.item.Save(ctx, itm))
+		inv, err := inventory.NewInventory(workspaceID, itm.ID(), loc.ID(), containerID, qty, inventory.ConditionNew, inventory.StatusAvailable, nil)
+		require.NoError(t, err)
+		require.NoError(t, repos.inventory.Save(ctx, inv))
+		_, err = pool.Exec(ctx, `UPDATE warehouse.inventory SET purchase_price = $2, currency_code = $3 WHERE id = $1`, inv.ID(), price, currency)
+		require.NoError(t, err)
+	}
+	intPtr := func(v int) *int { return &v }
+
+	boxID := box.ID()
+	addInventory("Camera", &boxID, 1, intPtr(90000), "EUR")
+	addInventory("Drill", &boxID, 3, intPtr(10000), "USD")
+	addInventory("Mystery box", &boxID, 5, nil, "EUR")
+	addInventory("Yen figurine", &boxID, 1, intPtr(500000), "JPY")
+	addInventory("Loose screws", nil, 100, intPtr(10), "EUR")
+
+	_, err = repos.analytics.UpsertCurrencySettings(ctx, workspaceID, "EUR", []byte(`{"USD": 0.5}`))
+	require.NoError(t, err)
+
+	t.Run("lists the container's inventory by item name", func(t *testing.T) {
+		entries, err := repos.container.FindContents(ctx, workspaceID, boxID, shared.Pagination{Page: 1, PageSize: 10})
+		require.NoError(t, err)
+		require.Len(t, entries, 4)
+
+		assert.Equal(t, "Camera", entries[0].ItemName)
+		require.NotNil(t, entries[0].Value)
+		assert.EqualValues(t, 90000, *entries[0].Value)
+
+		assert.Equal(t, "Drill", entries[1].ItemName)
+		assert.Equal(t, 3, entries[1].Quantity)
+		assert.Equal(t, 10000, *entries[1].PurchasePrice)
+		assert.Equal(t, "USD", *entries[1].CurrencyCode)
+		require.NotNil(t, entries[1].Value)
+		assert.EqualValues(t, 15000, *entries[1].Value)
+
+		assert.Nil(t, entries[2].Value, "unpriced")
+		assert.Nil(t, entries[3].Value, "no exchange rate")
+	})
+
+	t.Run("paginates", func(t *testing.T) {
+		entries, err := repos.container.FindContents(ctx, workspaceID, boxID, shared.Pagination{Page: 2, PageSize: 3})
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, "Yen figurine", entries[0].ItemName)
+	})
+
+	t.Run("summarizes the whole container", func(t *testing.T) {
+		summary, err := repos.container.GetContentsSummary(ctx, workspaceID, boxID)
+		require.NoError(t, err)
+		assert.Equal(t, container.ContentsSummary{
+			Entries:            4,
+			TotalQuantity:      10,
+			TotalValue:         105000,
+			Currency:           "EUR",
+			UnpricedEntries:    1,
+			UnconvertedEntries: 1,
+		}, summary)
+	})
+
+	t.Run("empty container", func(t *testing.T) {
+		summary, err := repos.container.GetContentsSummary(ctx, workspaceID, uuid.New())
+		require.NoError(t, err)
+		assert.Equal(t, 0, summary.Entries)
+		assert.Zero(t, summary.TotalValue)
+	})
+}
@@ -0,0 +1,150 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/customfield"
+	"github.com/antti/home-warehouse/go-backend/internal/infra/queries"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// CustomFieldRepository persists custom field definitions
+// (warehouse.custom_fields) and item values (warehouse.item_custom_values).
+type CustomFieldRepository struct {
+	queries *queries.Queries
+}
+
+func NewCustomFieldRepository(pool *pgxpool.Pool) *CustomFieldRepository {
+	return &CustomFieldRepository{
+		queries: queries.New(pool),
+	}
+}
+
+func (r *CustomFieldRepository) Save(ctx context.Context, f *customfield.Field) error {
+	_, err := r.queries.GetCustomField(ctx, queries.GetCustomFieldParams{
+		ID:          f.ID(),
+		WorkspaceID: f.WorkspaceID(),
+	})
+	if err == nil {
+		_, err = r.queries.UpdateCustomField(ctx, queries.UpdateCustomFieldParams{
+			ID:          f.ID(),
+			WorkspaceID: f.WorkspaceID(),
+			Name:        f.Name(),
+		})
+		return err
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return err
+	}
+
+	_, err = r.queries.CreateCustomField(ctx, queries.CreateCustomFieldParams{
+		ID:          f.ID(),
+		WorkspaceID: f.WorkspaceID(),
+		Name:        f.Name(),
+		FieldType:   string(f.Type()),
+	})
+	return err
+}
+
+func (r *CustomFieldRepository) FindByID(ctx context.Context, id, workspaceID uuid.UUID) (*customfield.Field, error) {
+	row, err := r.queries.GetCustomField(ctx, queries.GetCustomFieldParams{
+		ID:          id,
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, shared.ErrNotFound
+		}
+		return nil, err
+	}
+	return rowToCustomField(row), nil
+}
+
+func (r *CustomFieldRepository) FindByWorkspace(ctx context.Context, workspaceID uuid.UUID) ([]*customfield.Field, error) {
+	rows, err := r.queries.ListCustomFields(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make([]*customfield.Field, 0, len(rows))
+	for _, row := range rows {
+		fields = append(fields, rowToCustomField(row))
+	}
+	return fields, nil
+}
+
+func (r *CustomFieldRepository) Delete(ctx context.Context, id, workspaceID uuid.UUID) error {
+	return r.queries.DeleteCustomField(ctx, queries.DeleteCustomFieldParams{
+		ID:          id,
+		WorkspaceID: workspaceID,
+	})
+}
+
+func (r *CustomFieldRepository) NameExists(ctx context.Context, workspaceID uuid.UUID, name string) (bool, error) {
+	return r.queries.CustomFieldNameExists(ctx, queries.CustomFieldNameExistsParams{
+		WorkspaceID: workspaceID,
+		Name:        name,
+	})
+}
+
+func (r *CustomFieldRepository) ItemExists(ctx context.Context, itemID, workspaceID uuid.UUID) (bool, error) {
+	return r.queries.ItemExistsInWorkspace(ctx, queries.ItemExistsInWorkspaceParams{
+		ID:          itemID,
+		WorkspaceID: workspaceID,
+	})
+}
+
+func (r *CustomFieldRepository) SetValue(ctx context.Context, workspaceID, itemID, fieldID uuid.UUID, value string) error {
+	return r.queries.UpsertItemCustomValue(ctx, queries.UpsertItemCustomValueParams{
+		ItemID:      itemID,
+		FieldID:     fieldID,
+		WorkspaceID: workspaceID,
+		Value:       value,
+	})
+}
+
+func (r *CustomFieldRepository) DeleteValue(ctx context.Context, workspaceID, itemID, fieldID uuid.UUID) error {
+	return r.queries.DeleteItemCustomValue(ctx, queries.DeleteItemCustomValueParams{
+		ItemID:      itemID,
+		FieldID:     fieldID,
+		WorkspaceID: workspaceID,
+	})
+}
+
+func (r *CustomFieldRepository) ListValues(ctx context.Context, workspaceID uuid.UUID, itemIDs []uuid.UUID) ([]customfield.ItemValue, error) {
+	rows, err := r.queries.ListItemCustomValuesByItemIDs(ctx, queries.ListItemCustomValuesByItemIDsParams{
+		WorkspaceID: workspaceID,
+		ItemIds:     itemIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([]customfield.ItemValue, 0, len(rows))
+	for _, row := range rows {
+		values = append(values, customfield.ItemValue{
+			ItemID:    row.ItemID,
+			FieldID:   row.FieldID,
+			FieldName: row.FieldName,
+			FieldType: customfield.FieldType(row.FieldType),
+			Value:     row.Value,
+		})
+	}
+	return values, nil
+}
+
+func rowToCustomField(row queries.WarehouseCustomField) *customfield.Field {
+	return customfield.Reconstruct(
+		row.ID,
+		row.WorkspaceID,
+		row.Name,
+		customfield.FieldType(row.FieldType),
+		row.CreatedAt,
+		row.UpdatedAt,
+	)
+}
@@ -0,0 +1,96 @@
+//go:build integration
+// +build integration
+
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/customfield"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+	"github.com/antti/home-warehouse/go-backend/tests/testdb"
+	"github.com/antti/home-warehouse/go-backend/tests/testfixtures"
+)
+
+func TestCustomFieldRepository_Fields(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	repo := NewCustomFieldRepository(pool)
+	ctx := context.Background()
+
+	f, err := customfield.NewField(testfixtures.TestWorkspaceID, "Voltage", customfield.FieldTypeNumber)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, f))
+
+	exists, err := repo.NameExists(ctx, testfixtures.TestWorkspaceID, "Voltage")
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	require.NoError(t, f.Rename("Rated voltage"))
+	require.NoError(t, repo.Save(ctx, f))
+
+	got, err := repo.FindByID(ctx, f.ID(), testfixtures.TestWorkspaceID)
+	require.NoError(t, err)
+	assert.Equal(t, "Rated voltage", got.Name())
+	assert.Equal(t, customfield.FieldTypeNumber, got.Type())
+
+	fields, err := repo.FindByWorkspace(ctx, testfixtures.TestWorkspaceID)
+	require.NoError(t, err)
+	assert.Len(t, fields, 1)
+
+	_, err = repo.FindByID(ctx, f.ID(), uuid.New())
+	assert.ErrorIs(t, err, shared.ErrNotFound)
+
+	require.NoError(t, repo.Delete(ctx, f.ID(), testfixtures.TestWorkspaceID))
+	_, err = repo.FindByID(ctx, f.ID(), testfixtures.TestWorkspaceID)
+	assert.ErrorIs(t, err, shared.ErrNotFound)
+}
+
+func TestCustomFieldRepository_Values(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	repo := NewCustomFieldRepository(pool)
+	ctx := context.Background()
+	itemID := testfixtures.CreateTestItem(t, pool, testfixtures.TestWorkspaceID)
+
+	size, err := customfield.NewField(testfixtures.TestWorkspaceID, "Size", customfield.FieldTypeText)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, size))
+	cordless, err := customfield.NewField(testfixtures.TestWorkspaceID, "Cordless", customfield.FieldTypeBool)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, cordless))
+
+	exists, err := repo.ItemExists(ctx, itemID, testfixtures.TestWorkspaceID)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	require.NoError(t, repo.SetValue(ctx, testfixtures.TestWorkspaceID, itemID, size.ID(), "M"))
+	require.NoError(t, repo.SetValue(ctx, testfixtures.TestWorkspaceID, itemID, size.ID(), "L"))
+	require.NoError(t, repo.SetValue(ctx, testfixtures.TestWorkspaceID, itemID, cordless.ID(), "true"))
+
+	values, err := repo.ListValues(ctx, testfixtures.TestWorkspaceID, []uuid.UUID{itemID})
+	require.NoError(t, err)
+	require.Len(t, values, 2)
+	assert.Equal(t, "Cordless", values[0].FieldName)
+	assert.Equal(t, "Size", values[1].FieldName)
+	assert.Equal(t, "L", values[1].Value)
+
+	require.NoError(t, repo.DeleteValue(ctx, testfixtures.TestWorkspaceID, itemID, size.ID()))
+
+	// Deleting the field cascades to its values.
+	require.NoError(t, repo.Delete(ctx, cordless.ID(), testfixtures.TestWorkspaceID))
+	values, err = repo.ListValues(ctx, testfixtures.TestWorkspaceID, []uuid.UUID{itemID})
+	require.NoError(t, err)
+	assert.Empty(t, values)
+}
@@ -0,0 +1,68 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/deletionimpact"
+	"github.com/antti/home-warehouse/go-backend/internal/infra/queries"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// DeletionImpactRepository implements the deletionimpact.Repository interface.
+type DeletionImpactRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewDeletionImpactRepository creates a new DeletionImpactRepository.
+func NewDeletionImpactRepository(pool *pgxpool.Pool) *DeletionImpactRepository {
+	return &DeletionImpactRepository{pool: pool}
+}
+
+// q returns Queries bound to the active transaction in ctx (if any) or the
+// pool, so a delete's impact check sees the same snapshot as the delete.
+func (r *DeletionImpactRepository) q(ctx context.Context) *queries.Queries {
+	return queries.New(GetDBTX(ctx, r.pool))
+}
+
+func (r *DeletionImpactRepository) CategoryImpact(ctx context.Context, workspaceID, categoryID uuid.UUID) (*deletionimpact.Impact, error) {
+	row, err := r.q(ctx).GetCategoryDeletionImpact(ctx, queries.GetCategoryDeletionImpactParams{
+		ID:          categoryID,
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, shared.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &deletionimpact.Impact{
+		ChildCategories: int(row.ChildCategories),
+		Items:           int(row.Items),
+	}, nil
+}
+
+func (r *DeletionImpactRepository) LocationImpact(ctx context.Context, workspaceID, locationID uuid.UUID) (*deletionimpact.Impact, error) {
+	row, err := r.q(ctx).GetLocationDeletionImpact(ctx, queries.GetLocationDeletionImpactParams{
+		ID:          locationID,
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, shared.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &deletionimpact.Impact{
+		ChildLocations: int(row.ChildLocations),
+		Inventory:      int(row.Inventory),
+		Containers:     int(row.Containers),
+		ActiveLoans:    int(row.ActiveLoans),
+	}, nil
+}
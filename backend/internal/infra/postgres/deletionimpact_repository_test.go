@@ -0,0 +1,96 @@
+//go:build integration
+// +build integration
+
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/category"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/container"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/location"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+	"github.com/antti/home-warehouse/go-backend/tests/testdb"
+	"github.com/antti/home-warehouse/go-backend/tests/testfixtures"
+)
+
+func TestDeletionImpactRepository_CategoryImpact(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	repo := NewDeletionImpactRepository(pool)
+	categoryRepo := NewCategoryRepository(pool)
+	ctx := context.Background()
+
+	t.Run("counts active children", func(t *testing.T) {
+		parent, err := category.NewCategory(testfixtures.TestWorkspaceID, "Parent "+uuid.NewString()[:4], nil, nil)
+		require.NoError(t, err)
+		require.NoError(t, categoryRepo.Save(ctx, parent))
+
+		parentID := parent.ID()
+		child, err := category.NewCategory(testfixtures.TestWorkspaceID, "Child "+uuid.NewString()[:4], &parentID, nil)
+		require.NoError(t, err)
+		require.NoError(t, categoryRepo.Save(ctx, child))
+
+		archived, err := category.NewCategory(testfixtures.TestWorkspaceID, "Archived "+uuid.NewString()[:4], &parentID, nil)
+		require.NoError(t, err)
+		archived.Archive()
+		require.NoError(t, categoryRepo.Save(ctx, archived))
+
+		impact, err := repo.CategoryImpact(ctx, testfixtures.TestWorkspaceID, parent.ID())
+		require.NoError(t, err)
+		assert.Equal(t, 1, impact.ChildCategories)
+		assert.Equal(t, 0, impact.Items)
+	})
+
+	t.Run("returns not found for missing category", func(t *testing.T) {
+		_, err := repo.CategoryImpact(ctx, testfixtures.TestWorkspaceID, uuid.New())
+		assert.ErrorIs(t, err, shared.ErrNotFound)
+	})
+}
+
+func TestDeletionImpactRepository_LocationImpact(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	repo := NewDeletionImpactRepository(pool)
+	locationRepo := NewLocationRepository(pool)
+	invRepo := NewInventoryRepository(pool)
+	itemRepo := NewItemRepository(pool)
+	containerRepo := NewContainerRepository(pool)
+	ctx := context.Background()
+
+	t.Run("counts inventory, containers and children", func(t *testing.T) {
+		_, source := createTestInventoryForMovement(t, invRepo, itemRepo, locationRepo, ctx)
+
+		sourceID := source.ID()
+		child, err := location.NewLocation(testfixtures.TestWorkspaceID, "Child "+uuid.NewString()[:4], &sourceID, nil, uuid.NewString()[:8])
+		require.NoError(t, err)
+		require.NoError(t, locationRepo.Save(ctx, child))
+
+		box, err := container.NewContainer(testfixtures.TestWorkspaceID, source.ID(), "Box "+uuid.NewString()[:4], nil, nil, uuid.NewString()[:8])
+		require.NoError(t, err)
+		require.NoError(t, containerRepo.Save(ctx, box))
+
+		impact, err := repo.LocationImpact(ctx, testfixtures.TestWorkspaceID, source.ID())
+		require.NoError(t, err)
+		assert.Equal(t, 1, impact.Inventory)
+		assert.Equal(t, 1, impact.ChildLocations)
+		assert.Equal(t, 1, impact.Containers)
+		assert.Equal(t, 0, impact.ActiveLoans)
+	})
+
+	t.Run("returns not found for missing location", func(t *testing.T) {
+		_, err := repo.LocationImpact(ctx, testfixtures.TestWorkspaceID, uuid.New())
+		assert.ErrorIs(t, err, shared.ErrNotFound)
+	})
+}
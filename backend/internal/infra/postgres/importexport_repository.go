@@ -2,31 +2,45 @@ package postgres
 
 import (
 	"context"
+	"errors"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/antti/home-warehouse/go-backend/internal/infra/queries"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
 )
 
 // ImportExportRepository handles import/export database operations
 type ImportExportRepository struct {
-	q *queries.Queries
+	pool    *pgxpool.Pool
+	queries *queries.Queries
 }
 
 // NewImportExportRepository creates a new import/export repository from a pool
 func NewImportExportRepository(pool *pgxpool.Pool) *ImportExportRepository {
-	return &ImportExportRepository{q: queries.New(pool)}
+	return &ImportExportRepository{pool: pool, queries: queries.New(pool)}
 }
 
 // NewImportExportRepositoryFromQueries creates a new import/export repository from queries
 func NewImportExportRepositoryFromQueries(q *queries.Queries) *ImportExportRepository {
-	return &ImportExportRepository{q: q}
+	return &ImportExportRepository{queries: q}
+}
+
+// q returns Queries bound to the active transaction in ctx (if any), so item
+// transfers run under TxManager.WithTx. A repository built from queries has no
+// pool and always uses them as given.
+func (r *ImportExportRepository) q(ctx context.Context) *queries.Queries {
+	if r.pool == nil {
+		return r.queries
+	}
+	return queries.New(GetDBTX(ctx, r.pool))
 }
 
 // ListAllItems returns all items in a workspace
 func (r *ImportExportRepository) ListAllItems(ctx context.Context, workspaceID uuid.UUID, includeArchived bool) ([]queries.WarehouseItem, error) {
-	return r.q.ListAllItems(ctx, queries.ListAllItemsParams{
+	return r.q(ctx).ListAllItems(ctx, queries.ListAllItemsParams{
 		WorkspaceID:     workspaceID,
 		IncludeArchived: includeArchived,
 	})
@@ -34,12 +48,12 @@ func (r *ImportExportRepository) ListAllItems(ctx context.Context, workspaceID u
 
 // CreateItem creates a new item
 func (r *ImportExportRepository) CreateItem(ctx context.Context, params queries.CreateItemParams) (queries.WarehouseItem, error) {
-	return r.q.CreateItem(ctx, params)
+	return r.q(ctx).CreateItem(ctx, params)
 }
 
 // GetCategoryByName gets a category by name
 func (r *ImportExportRepository) GetCategoryByName(ctx context.Context, workspaceID uuid.UUID, name string) (*queries.WarehouseCategory, error) {
-	cat, err := r.q.GetCategoryByName(ctx, queries.GetCategoryByNameParams{
+	cat, err := r.q(ctx).GetCategoryByName(ctx, queries.GetCategoryByNameParams{
 		WorkspaceID: workspaceID,
 		Name:        name,
 	})
@@ -51,7 +65,7 @@ func (r *ImportExportRepository) GetCategoryByName(ctx context.Context, workspac
 
 // ListAllLocations returns all locations in a workspace
 func (r *ImportExportRepository) ListAllLocations(ctx context.Context, workspaceID uuid.UUID, includeArchived bool) ([]queries.WarehouseLocation, error) {
-	return r.q.ListAllLocations(ctx, queries.ListAllLocationsParams{
+	return r.q(ctx).ListAllLocations(ctx, queries.ListAllLocationsParams{
 		WorkspaceID:     workspaceID,
 		IncludeArchived: includeArchived,
 	})
@@ -59,12 +73,12 @@ func (r *ImportExportRepository) ListAllLocations(ctx context.Context, workspace
 
 // CreateLocation creates a new location
 func (r *ImportExportRepository) CreateLocation(ctx context.Context, params queries.CreateLocationParams) (queries.WarehouseLocation, error) {
-	return r.q.CreateLocation(ctx, params)
+	return r.q(ctx).CreateLocation(ctx, params)
 }
 
 // GetLocationByName gets a location by name
 func (r *ImportExportRepository) GetLocationByName(ctx context.Context, workspaceID uuid.UUID, name string) (*queries.WarehouseLocation, error) {
-	loc, err := r.q.GetLocationByName(ctx, queries.GetLocationByNameParams{
+	loc, err := r.q(ctx).GetLocationByName(ctx, queries.GetLocationByNameParams{
 		WorkspaceID: workspaceID,
 		Name:        name,
 	})
@@ -76,7 +90,7 @@ func (r *ImportExportRepository) GetLocationByName(ctx context.Context, workspac
 
 // ListAllCategories returns all categories in a workspace
 func (r *ImportExportRepository) ListAllCategories(ctx context.Context, workspaceID uuid.UUID, includeArchived bool) ([]queries.WarehouseCategory, error) {
-	return r.q.ListAllCategories(ctx, queries.ListAllCategoriesParams{
+	return r.q(ctx).ListAllCategories(ctx, queries.ListAllCategoriesParams{
 		WorkspaceID:     workspaceID,
 		IncludeArchived: includeArchived,
 	})
@@ -84,12 +98,12 @@ func (r *ImportExportRepository) ListAllCategories(ctx context.Context, workspac
 
 // CreateCategory creates a new category
 func (r *ImportExportRepository) CreateCategory(ctx context.Context, params queries.CreateCategoryParams) (queries.WarehouseCategory, error) {
-	return r.q.CreateCategory(ctx, params)
+	return r.q(ctx).CreateCategory(ctx, params)
 }
 
 // ListAllContainers returns all containers in a workspace
 func (r *ImportExportRepository) ListAllContainers(ctx context.Context, workspaceID uuid.UUID, includeArchived bool) ([]queries.WarehouseContainer, error) {
-	return r.q.ListAllContainers(ctx, queries.ListAllContainersParams{
+	return r.q(ctx).ListAllContainers(ctx, queries.ListAllContainersParams{
 		WorkspaceID:     workspaceID,
 		IncludeArchived: includeArchived,
 	})
@@ -97,12 +111,12 @@ func (r *ImportExportRepository) ListAllContainers(ctx context.Context, workspac
 
 // CreateContainer creates a new container
 func (r *ImportExportRepository) CreateContainer(ctx context.Context, params queries.CreateContainerParams) (queries.WarehouseContainer, error) {
-	return r.q.CreateContainer(ctx, params)
+	return r.q(ctx).CreateContainer(ctx, params)
 }
 
 // ListAllLabels returns all labels in a workspace
 func (r *ImportExportRepository) ListAllLabels(ctx context.Context, workspaceID uuid.UUID, includeArchived bool) ([]queries.WarehouseLabel, error) {
-	return r.q.ListAllLabels(ctx, queries.ListAllLabelsParams{
+	return r.q(ctx).ListAllLabels(ctx, queries.ListAllLabelsParams{
 		WorkspaceID:     workspaceID,
 		IncludeArchived: includeArchived,
 	})
@@ -110,12 +124,12 @@ func (r *ImportExportRepository) ListAllLabels(ctx context.Context, workspaceID
 
 // CreateLabel creates a new label
 func (r *ImportExportRepository) CreateLabel(ctx context.Context, params queries.CreateLabelParams) (queries.WarehouseLabel, error) {
-	return r.q.CreateLabel(ctx, params)
+	return r.q(ctx).CreateLabel(ctx, params)
 }
 
 // ListAllCompanies returns all companies in a workspace
 func (r *ImportExportRepository) ListAllCompanies(ctx context.Context, workspaceID uuid.UUID, includeArchived bool) ([]queries.WarehouseCompany, error) {
-	return r.q.ListAllCompanies(ctx, queries.ListAllCompaniesParams{
+	return r.q(ctx).ListAllCompanies(ctx, queries.ListAllCompaniesParams{
 		WorkspaceID:     workspaceID,
 		IncludeArchived: includeArchived,
 	})
@@ -123,12 +137,12 @@ func (r *ImportExportRepository) ListAllCompanies(ctx context.Context, workspace
 
 // CreateCompany creates a new company
 func (r *ImportExportRepository) CreateCompany(ctx context.Context, params queries.CreateCompanyParams) (queries.WarehouseCompany, error) {
-	return r.q.CreateCompany(ctx, params)
+	return r.q(ctx).CreateCompany(ctx, params)
 }
 
 // ListAllBorrowers returns all borrowers in a workspace
 func (r *ImportExportRepository) ListAllBorrowers(ctx context.Context, workspaceID uuid.UUID, includeArchived bool) ([]queries.WarehouseBorrower, error) {
-	return r.q.ListAllBorrowers(ctx, queries.ListAllBorrowersParams{
+	return r.q(ctx).ListAllBorrowers(ctx, queries.ListAllBorrowersParams{
 		WorkspaceID:     workspaceID,
 		IncludeArchived: includeArchived,
 	})
@@ -136,5 +150,116 @@ func (r *ImportExportRepository) ListAllBorrowers(ctx context.Context, workspace
 
 // CreateBorrower creates a new borrower
 func (r *ImportExportRepository) CreateBorrower(ctx context.Context, params queries.CreateBorrowerParams) (queries.WarehouseBorrower, error) {
-	return r.q.CreateBorrower(ctx, params)
+	return r.q(ctx).CreateBorrower(ctx, params)
+}
+
+// GetItem gets an item by ID, returning shared.ErrNotFound when it does not
+// exist in the workspace
+func (r *ImportExportRepository) GetItem(ctx context.Context, workspaceID, itemID uuid.UUID) (*queries.WarehouseItem, error) {
+	item, err := r.q(ctx).GetItem(ctx, queries.GetItemParams{
+		ID:          itemID,
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, shared.ErrNotFound
+		}
+		return nil, err
+	}
+	return &item, nil
+}
+
+// ItemSKUExists reports whether a SKU is already used in a workspace
+func (r *ImportExportRepository) ItemSKUExists(ctx context.Context, workspaceID uuid.UUID, sku string) (bool, error) {
+	return r.q(ctx).ItemSKUExists(ctx, queries.ItemSKUExistsParams{
+		WorkspaceID: workspaceID,
+		Sku:         sku,
+	})
+}
+
+// GetCategory gets a category by ID
+func (r *ImportExportRepository) GetCategory(ctx context.Context, workspaceID, categoryID uuid.UUID) (*queries.WarehouseCategory, error) {
+	cat, err := r.q(ctx).GetCategory(ctx, queries.GetCategoryParams{
+		ID:          categoryID,
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &cat, nil
+}
+
+// GetLocation gets a location by ID
+func (r *ImportExportRepository) GetLocation(ctx context.Context, workspaceID, locationID uuid.UUID) (*queries.WarehouseLocation, error) {
+	loc, err := r.q(ctx).GetLocation(ctx, queries.GetLocationParams{
+		ID:          locationID,
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &loc, nil
+}
+
+// GetContainer gets a container by ID
+func (r *ImportExportRepository) GetContainer(ctx context.Context, workspaceID, containerID uuid.UUID) (*queries.WarehouseContainer, error) {
+	con, err := r.q(ctx).GetContainer(ctx, queries.GetContainerParams{
+		ID:          containerID,
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &con, nil
+}
+
+// ListInventoryByItem returns the inventory records of an item
+func (r *ImportExportRepository) ListInventoryByItem(ctx context.Context, workspaceID, itemID uuid.UUID) ([]queries.WarehouseInventory, error) {
+	return r.q(ctx).ListInventoryByItem(ctx, queries.ListInventoryByItemParams{
+		WorkspaceID: workspaceID,
+		ItemID:      itemID,
+	})
+}
+
+// CreateInventory creates a new inventory record
+func (r *ImportExportRepository) CreateInventory(ctx context.Context, params queries.CreateInventoryParams) (queries.WarehouseInventory, error) {
+	return r.q(ctx).CreateInventory(ctx, params)
+}
+
+// ListItemPhotos returns the photos of an item in display order
+func (r *ImportExportRepository) ListItemPhotos(ctx context.Context, workspaceID, itemID uuid.UUID) ([]queries.WarehouseItemPhoto, error) {
+	return r.q(ctx).ListItemPhotosByItem(ctx, queries.ListItemPhotosByItemParams{
+		ItemID:      itemID,
+		WorkspaceID: workspaceID,
+	})
+}
+
+// CreateItemPhoto creates a new item photo record
+func (r *ImportExportRepository) CreateItemPhoto(ctx context.Context, params queries.CreateItemPhotoParams) (queries.WarehouseItemPhoto, error) {
+	return r.q(ctx).CreateItemPhoto(ctx, params)
+}
+
+// ArchiveItem soft-deletes an item
+func (r *ImportExportRepository) ArchiveItem(ctx context.Context, workspaceID, itemID uuid.UUID) error {
+	return r.q(ctx).ArchiveItem(ctx, queries.ArchiveItemParams{
+		ID:          itemID,
+		WorkspaceID: workspaceID,
+	})
+}
+
+// ArchiveInventory soft-deletes an inventory record
+func (r *ImportExportRepository) ArchiveInventory(ctx context.Context, workspaceID, inventoryID uuid.UUID) error {
+	return r.q(ctx).ArchiveInventory(ctx, queries.ArchiveInventoryParams{
+		ID:          inventoryID,
+		WorkspaceID: workspaceID,
+	})
+}
+
+// DeleteItemPhotos removes the photo rows of an item, leaving the stored
+// files alone
+func (r *ImportExportRepository) DeleteItemPhotos(ctx context.Context, workspaceID, itemID uuid.UUID) error {
+	return r.q(ctx).DeleteItemPhotosByItem(ctx, queries.DeleteItemPhotosByItemParams{
+		ItemID:      itemID,
+		WorkspaceID: workspaceID,
+	})
 }
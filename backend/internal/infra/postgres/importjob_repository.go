@@ -277,6 +277,27 @@ func (r *ImportJobRepository) SaveError(ctx context.Context, importError *import
 	return err
 }
 
+// SaveErrors copies the errors in with COPY, one round trip for the batch.
+func (r *ImportJobRepository) SaveErrors(ctx context.Context, importErrors []*importjob.ImportError) error {
+	if len(importErrors) == 0 {
+		return nil
+	}
+
+	_, err := r.pool.CopyFrom(ctx,
+		pgx.Identifier{"warehouse", "import_errors"},
+		[]string{"id", "import_job_id", "row_number", "field_name", "error_message", "row_data", "created_at"},
+		pgx.CopyFromSlice(len(importErrors), func(i int) ([]any, error) {
+			e := importErrors[i]
+			rowDataJSON, err := json.Marshal(e.RowData())
+			if err != nil {
+				return nil, fmt.Errorf("failed to marshal row data: %w", err)
+			}
+			return []any{e.ID(), e.ImportJobID(), e.RowNumber(), e.FieldName(), e.ErrorMessage(), rowDataJSON, e.CreatedAt()}, nil
+		}),
+	)
+	return err
+}
+
 func (r *ImportJobRepository) FindErrorsByJobID(ctx context.Context, jobID uuid.UUID) ([]*importjob.ImportError, error) {
 	query := `
 		SELECT id, import_job_id, row_number, field_name, error_message, row_data, created_at
@@ -331,3 +352,193 @@ func (r *ImportJobRepository) DeleteErrorsByJobID(ctx context.Context, jobID uui
 	_, err := r.pool.Exec(ctx, query, jobID)
 	return err
 }
+
+func (r *ImportJobRepository) SaveRowResult(ctx context.Context, result *importjob.ImportRowResult) error {
+	query := `
+		INSERT INTO warehouse.import_row_results (
+			id, import_job_id, row_number, action, entity_id, auto_categorized, created_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := r.pool.Exec(ctx, query,
+		result.ID(),
+		result.ImportJobID(),
+		result.RowNumber(),
+		result.Action(),
+		result.EntityID(),
+		result.AutoCategorized(),
+		result.CreatedAt(),
+	)
+
+	return err
+}
+
+// SaveRowResults copies the results in with COPY, one round trip for the
+// batch.
+func (r *ImportJobRepository) SaveRowResults(ctx context.Context, results []*importjob.ImportRowResult) error {
+	if len(results) == 0 {
+		return nil
+	}
+
+	_, err := r.pool.CopyFrom(ctx,
+		pgx.Identifier{"warehouse", "import_row_results"},
+		[]string{"id", "import_job_id", "row_number", "action", "entity_id", "auto_categorized", "created_at"},
+		pgx.CopyFromSlice(len(results), func(i int) ([]any, error) {
+			res := results[i]
+			return []any{res.ID(), res.ImportJobID(), res.RowNumber(), string(res.Action()), res.EntityID(), res.AutoCategorized(), res.CreatedAt()}, nil
+		}),
+	)
+	return err
+}
+
+func (r *ImportJobRepository) FindRowResultsByJobID(ctx context.Context, jobID uuid.UUID) ([]*importjob.ImportRowResult, error) {
+	query := `
+		SELECT id, import_job_id, row_number, action, entity_id, auto_categorized, created_at
+		FROM warehouse.import_row_results
+		WHERE import_job_id = $1
+		ORDER BY row_number ASC
+	`
+
+	rows, err := r.pool.Query(ctx, query, jobID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*importjob.ImportRowResult
+	for rows.Next() {
+		var (
+			id, importJobID uuid.UUID
+			rowNumber       int
+			action          string
+			entityID        *uuid.UUID
+			autoCategorized bool
+			createdAt       time.Time
+		)
+
+		if err := rows.Scan(&id, &importJobID, &rowNumber, &action, &entityID, &autoCategorized, &createdAt); err != nil {
+			return nil, err
+		}
+
+		results = append(results, importjob.ReconstructImportRowResult(
+			id, importJobID, rowNumber, importjob.RowAction(action), entityID, autoCategorized, createdAt,
+		))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+func (r *ImportJobRepository) SavePhotoResult(ctx context.Context, result *importjob.ImportPhotoResult) error {
+	query := `
+		INSERT INTO warehouse.import_photo_results (
+			id, import_job_id, row_number, item_id, url, status, photo_id, message, created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+
+	_, err := r.pool.Exec(ctx, query,
+		result.ID(),
+		result.ImportJobID(),
+		result.RowNumber(),
+		result.ItemID(),
+		result.URL(),
+		result.Status(),
+		result.PhotoID(),
+		result.Message(),
+		result.CreatedAt(),
+		result.UpdatedAt(),
+	)
+
+	return err
+}
+
+func (r *ImportJobRepository) UpdatePhotoResult(ctx context.Context, id uuid.UUID, status importjob.PhotoStatus, photoID *uuid.UUID, message *string) error {
+	query := `
+		UPDATE warehouse.import_photo_results
+		SET status = $2, photo_id = $3, message = $4, updated_at = now()
+		WHERE id = $1
+	`
+
+	tag, err := r.pool.Exec(ctx, query, id, status, photoID, message)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return shared.ErrNotFound
+	}
+	return nil
+}
+
+func (r *ImportJobRepository) FindPhotoResultsByJobID(ctx context.Context, jobID uuid.UUID) ([]*importjob.ImportPhotoResult, error) {
+	query := `
+		SELECT id, import_job_id, row_number, item_id, url, status, photo_id, message, is_primary, created_at, updated_at
+		FROM warehouse.import_photo_results
+		WHERE import_job_id = $1
+		ORDER BY row_number ASC, id ASC
+	`
+
+	return r.queryPhotoResults(ctx, query, jobID)
+}
+
+func (r *ImportJobRepository) FindPhotoResultsByItem(ctx context.Context, jobID, itemID uuid.UUID) ([]*importjob.ImportPhotoResult, error) {
+	query := `
+		SELECT id, import_job_id, row_number, item_id, url, status, photo_id, message, is_primary, created_at, updated_at
+		FROM warehouse.import_photo_results
+		WHERE import_job_id = $1 AND item_id = $2
+		ORDER BY row_number ASC, id ASC
+	`
+
+	return r.queryPhotoResults(ctx, query, jobID, itemID)
+}
+
+func (r *ImportJobRepository) MarkPhotoResultPrimary(ctx context.Context, jobID, itemID, resultID uuid.UUID) error {
+	query := `
+		UPDATE warehouse.import_photo_results
+		SET is_primary = (id = $3), updated_at = now()
+		WHERE import_job_id = $1 AND item_id = $2
+		  AND is_primary <> (id = $3)
+	`
+
+	_, err := r.pool.Exec(ctx, query, jobID, itemID, resultID)
+	return err
+}
+
+// queryPhotoResults runs a photo result SELECT whose columns match
+// FindPhotoResultsByJobID's.
+func (r *ImportJobRepository) queryPhotoResults(ctx context.Context, query string, args ...any) ([]*importjob.ImportPhotoResult, error) {
+	rows, err := r.pool.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*importjob.ImportPhotoResult
+	for rows.Next() {
+		var (
+			id, importJobID, itemID uuid.UUID
+			rowNumber               int
+			url, status             string
+			photoID                 *uuid.UUID
+			message                 *string
+			isPrimary               bool
+			createdAt, updatedAt    time.Time
+		)
+
+		if err := rows.Scan(&id, &importJobID, &rowNumber, &itemID, &url, &status, &photoID, &message, &isPrimary, &createdAt, &updatedAt); err != nil {
+			return nil, err
+		}
+
+		results = append(results, importjob.ReconstructImportPhotoResult(
+			id, importJobID, rowNumber, itemID, url, importjob.PhotoStatus(status), photoID, message, isPrimary, createdAt, updatedAt,
+		))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
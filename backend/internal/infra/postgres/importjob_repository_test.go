@@ -279,6 +279,41 @@ func TestImportJobRepository_SaveAndFindErrors(t *testing.T) {
 	})
 }
 
+func TestImportJobRepository_SaveErrors(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	repo := NewImportJobRepository(pool)
+	ctx := context.Background()
+
+	t.Run("saves a batch of errors", func(t *testing.T) {
+		job := newTestImportJob(t, testfixtures.TestWorkspaceID, testfixtures.TestUserID)
+		require.NoError(t, repo.SaveJob(ctx, job))
+
+		fieldName := "name"
+		var batch []*importjob.ImportError
+		for row := 1; row <= 3; row++ {
+			e, err := importjob.NewImportError(job.ID(), row, &fieldName, "name is required", map[string]any{"sku": "ABC"})
+			require.NoError(t, err)
+			batch = append(batch, e)
+		}
+		require.NoError(t, repo.SaveErrors(ctx, batch))
+
+		found, err := repo.FindErrorsByJobID(ctx, job.ID())
+		require.NoError(t, err)
+		require.Len(t, found, 3)
+		assert.Equal(t, 3, found[2].RowNumber())
+		assert.Equal(t, "name", *found[2].FieldName())
+		assert.Equal(t, "ABC", found[2].RowData()["sku"])
+	})
+
+	t.Run("empty batch is a no-op", func(t *testing.T) {
+		require.NoError(t, repo.SaveErrors(ctx, nil))
+	})
+}
+
 func TestImportJobRepository_DeleteErrorsByJobID(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test")
@@ -314,3 +349,87 @@ func TestImportJobRepository_DeleteErrorsByJobID(t *testing.T) {
 		assert.Len(t, foundOther, 1)
 	})
 }
+
+func TestImportJobRepository_SaveAndFindRowResults(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	repo := NewImportJobRepository(pool)
+	ctx := context.Background()
+
+	t.Run("saves and lists row results ordered by row number", func(t *testing.T) {
+		job := newTestImportJob(t, testfixtures.TestWorkspaceID, testfixtures.TestUserID)
+		require.NoError(t, repo.SaveJob(ctx, job))
+
+		entityID := uuid.New()
+		updated, err := importjob.NewImportRowResult(job.ID(), 2, importjob.RowActionUpdated, &entityID)
+		require.NoError(t, err)
+		require.NoError(t, repo.SaveRowResult(ctx, updated))
+
+		skipped, err := importjob.NewImportRowResult(job.ID(), 1, importjob.RowActionSkipped, nil)
+		require.NoError(t, err)
+		require.NoError(t, repo.SaveRowResult(ctx, skipped))
+
+		found, err := repo.FindRowResultsByJobID(ctx, job.ID())
+		require.NoError(t, err)
+		require.Len(t, found, 2)
+		assert.Equal(t, 1, found[0].RowNumber())
+		assert.Equal(t, importjob.RowActionSkipped, found[0].Action())
+		assert.Nil(t, found[0].EntityID())
+		assert.Equal(t, 2, found[1].RowNumber())
+		assert.Equal(t, importjob.RowActionUpdated, found[1].Action())
+		require.NotNil(t, found[1].EntityID())
+		assert.Equal(t, entityID, *found[1].EntityID())
+	})
+
+	t.Run("row results are removed with their job", func(t *testing.T) {
+		job := newTestImportJob(t, testfixtures.TestWorkspaceID, testfixtures.TestUserID)
+		require.NoError(t, repo.SaveJob(ctx, job))
+
+		result, err := importjob.NewImportRowResult(job.ID(), 1, importjob.RowActionCreated, nil)
+		require.NoError(t, err)
+		require.NoError(t, repo.SaveRowResult(ctx, result))
+
+		require.NoError(t, repo.DeleteJob(ctx, job.ID()))
+
+		found, err := repo.FindRowResultsByJobID(ctx, job.ID())
+		require.NoError(t, err)
+		assert.Empty(t, found)
+	})
+}
+
+func TestImportJobRepository_SaveRowResults(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	repo := NewImportJobRepository(pool)
+	ctx := context.Background()
+
+	t.Run("saves a batch of row results", func(t *testing.T) {
+		job := newTestImportJob(t, testfixtures.TestWorkspaceID, testfixtures.TestUserID)
+		require.NoError(t, repo.SaveJob(ctx, job))
+
+		entityID := uuid.New()
+		created, err := importjob.NewImportRowResult(job.ID(), 1, importjob.RowActionCreated, &entityID)
+		require.NoError(t, err)
+		created.MarkAutoCategorized()
+		skipped, err := importjob.NewImportRowResult(job.ID(), 2, importjob.RowActionSkipped, nil)
+		require.NoError(t, err)
+		require.NoError(t, repo.SaveRowResults(ctx, []*importjob.ImportRowResult{created, skipped}))
+
+		found, err := repo.FindRowResultsByJobID(ctx, job.ID())
+		require.NoError(t, err)
+		require.Len(t, found, 2)
+		assert.Equal(t, importjob.RowActionCreated, found[0].Action())
+		require.NotNil(t, found[0].EntityID())
+		assert.Equal(t, entityID, *found[0].EntityID())
+		assert.True(t, found[0].AutoCategorized())
+		assert.Equal(t, importjob.RowActionSkipped, found[1].Action())
+		assert.Nil(t, found[1].EntityID())
+		assert.False(t, found[1].AutoCategorized())
+	})
+}
@@ -0,0 +1,69 @@
+//go:build integration
+// +build integration
+
+package postgres
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/inventory"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/location"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/movement"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+	"github.com/antti/home-warehouse/go-backend/tests/testdb"
+	"github.com/antti/home-warehouse/go-backend/tests/testfixtures"
+)
+
+// failAfterRecording writes the movement and then fails, like an error
+// raised after the last write of the operation.
+type failAfterRecording struct {
+	movement.ServiceInterface
+}
+
+func (f failAfterRecording) RecordMovement(ctx context.Context, input movement.RecordMovementInput) (*movement.InventoryMovement, error) {
+	if _, err := f.ServiceInterface.RecordMovement(ctx, input); err != nil {
+		return nil, err
+	}
+	return nil, errors.New("failed after recording the movement")
+}
+
+func TestInventoryMove_FailureRollsBackAllWrites(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	ctx := context.Background()
+	ws := testfixtures.TestWorkspaceID
+
+	invID, _, _ := createTestInventoryWithItem(t, pool, ws, "Moved Item")
+	target, err := location.NewLocation(ws, "Move Target", nil, nil, uuid.NewString()[:8])
+	require.NoError(t, err)
+	require.NoError(t, NewLocationRepository(pool).Save(ctx, target))
+
+	inventoryRepo := NewInventoryRepository(pool)
+	movementRepo := NewMovementRepository(pool)
+	before, err := inventoryRepo.FindByID(ctx, invID, ws)
+	require.NoError(t, err)
+
+	svc := inventory.NewService(inventoryRepo, failAfterRecording{movement.NewService(movementRepo)},
+		NewItemRepository(pool), NewLocationRepository(pool), NewContainerRepository(pool))
+	svc.SetTransactor(NewTxManager(pool))
+
+	_, err = svc.Move(ctx, invID, ws, target.ID(), nil)
+	require.Error(t, err)
+
+	after, err := inventoryRepo.FindByID(ctx, invID, ws)
+	require.NoError(t, err)
+	assert.Equal(t, before.LocationID(), after.LocationID(), "the location change must be rolled back")
+
+	movements, err := movementRepo.FindByInventory(ctx, invID, ws, shared.Pagination{Page: 1, PageSize: 10})
+	require.NoError(t, err)
+	assert.Empty(t, movements, "the movement row must be rolled back")
+}
@@ -137,9 +137,17 @@ func (r *InventoryRepository) FindByID(ctx context.Context, id, workspaceID uuid
 	return r.rowToInventory(row), nil
 }
 
-func (r *InventoryRepository) List(ctx context.Context, workspaceID uuid.UUID, pagination shared.Pagination) ([]*inventory.Inventory, int, error) {
+func (r *InventoryRepository) List(ctx context.Context, workspaceID uuid.UUID, pagination shared.Pagination, filters inventory.ListFilters) ([]*inventory.Inventory, int, error) {
+	itemID, locationID, conditions, statuses := inventoryFilterArgs(filters)
+
 	// Get total count
-	total, err := r.q(ctx).CountInventory(ctx, workspaceID)
+	total, err := r.q(ctx).CountInventory(ctx, queries.CountInventoryParams{
+		WorkspaceID: workspaceID,
+		ItemID:      itemID,
+		LocationID:  locationID,
+		Conditions:  conditions,
+		Statuses:    statuses,
+	})
 	if err != nil {
 		return nil, 0, err
 	}
@@ -149,6 +157,10 @@ func (r *InventoryRepository) List(ctx context.Context, workspaceID uuid.UUID, p
 		WorkspaceID: workspaceID,
 		Limit:       int32(pagination.Limit()),
 		Offset:      int32(pagination.Offset()),
+		ItemID:      itemID,
+		LocationID:  locationID,
+		Conditions:  conditions,
+		Statuses:    statuses,
 	})
 	if err != nil {
 		return nil, 0, err
@@ -162,6 +174,81 @@ func (r *InventoryRepository) List(ctx context.Context, workspaceID uuid.UUID, p
 	return inventories, int(total), nil
 }
 
+func (r *InventoryRepository) ListWithItemDetails(ctx context.Context, workspaceID uuid.UUID, pagination shared.Pagination, filters inventory.ListFilters) ([]*inventory.InventoryWithDetails, int, error) {
+	itemID, locationID, conditions, statuses := inventoryFilterArgs(filters)
+
+	total, err := r.q(ctx).CountInventory(ctx, queries.CountInventoryParams{
+		WorkspaceID: workspaceID,
+		ItemID:      itemID,
+		LocationID:  locationID,
+		Conditions:  conditions,
+		Statuses:    statuses,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	rows, err := r.q(ctx).ListInventoryWithDetails(ctx, queries.ListInventoryWithDetailsParams{
+		WorkspaceID: workspaceID,
+		Limit:       int32(pagination.Limit()),
+		Offset:      int32(pagination.Offset()),
+		ItemID:      itemID,
+		LocationID:  locationID,
+		Conditions:  conditions,
+		Statuses:    statuses,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	result := make([]*inventory.InventoryWithDetails, 0, len(rows))
+	for _, row := range rows {
+		inv := r.rowToInventory(queries.WarehouseInventory{
+			ID:              row.ID,
+			WorkspaceID:     row.WorkspaceID,
+			ItemID:          row.ItemID,
+			LocationID:      row.LocationID,
+			ContainerID:     row.ContainerID,
+			Quantity:        row.Quantity,
+			Condition:       row.Condition,
+			Status:          row.Status,
+			DateAcquired:    row.DateAcquired,
+			PurchasePrice:   row.PurchasePrice,
+			CurrencyCode:    row.CurrencyCode,
+			WarrantyExpires: row.WarrantyExpires,
+			ExpirationDate:  row.ExpirationDate,
+			Notes:           row.Notes,
+			LastUsedAt:      row.LastUsedAt,
+			IsArchived:      row.IsArchived,
+			CreatedAt:       row.CreatedAt,
+			UpdatedAt:       row.UpdatedAt,
+		})
+		result = append(result, &inventory.InventoryWithDetails{
+			Inventory:    inv,
+			ItemName:     row.ItemName,
+			ItemBrand:    row.ItemBrand,
+			ItemSKU:      row.Sku,
+			LocationName: row.LocationName,
+		})
+	}
+
+	return result, int(total), nil
+}
+
+// inventoryFilterArgs converts list filters to query arguments. Unset
+// filters become NULL, which the queries treat as "match everything".
+func inventoryFilterArgs(f inventory.ListFilters) (itemID, locationID pgtype.UUID, conditions, statuses []string) {
+	itemID = uuidPtrToPgtype(f.ItemID)
+	locationID = uuidPtrToPgtype(f.LocationID)
+	for _, c := range f.Conditions {
+		conditions = append(conditions, string(c))
+	}
+	for _, st := range f.Statuses {
+		statuses = append(statuses, string(st))
+	}
+	return itemID, locationID, conditions, statuses
+}
+
 func (r *InventoryRepository) FindByItem(ctx context.Context, workspaceID, itemID uuid.UUID) ([]*inventory.Inventory, error) {
 	rows, err := r.q(ctx).ListInventoryByItem(ctx, queries.ListInventoryByItemParams{
 		WorkspaceID: workspaceID,
@@ -213,10 +300,11 @@ func (r *InventoryRepository) FindByContainer(ctx context.Context, workspaceID,
 	return inventories, nil
 }
 
-func (r *InventoryRepository) FindAvailable(ctx context.Context, workspaceID, itemID uuid.UUID) ([]*inventory.Inventory, error) {
+func (r *InventoryRepository) FindAvailable(ctx context.Context, workspaceID, itemID uuid.UUID, includeReserved bool) ([]*inventory.Inventory, error) {
 	rows, err := r.q(ctx).GetAvailableInventory(ctx, queries.GetAvailableInventoryParams{
-		WorkspaceID: workspaceID,
-		ItemID:      itemID,
+		WorkspaceID:     workspaceID,
+		ItemID:          itemID,
+		IncludeReserved: includeReserved,
 	})
 	if err != nil {
 		return nil, err
@@ -241,6 +329,21 @@ func (r *InventoryRepository) GetTotalQuantity(ctx context.Context, workspaceID,
 	return int(total), nil
 }
 
+func (r *InventoryRepository) AddQuantity(ctx context.Context, id, workspaceID uuid.UUID, amount int) (*inventory.Inventory, error) {
+	row, err := r.q(ctx).AddInventoryQuantity(ctx, queries.AddInventoryQuantityParams{
+		Amount:      int32(amount),
+		ID:          id,
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, shared.ErrNotFound
+		}
+		return nil, err
+	}
+	return r.rowToInventory(row), nil
+}
+
 func (r *InventoryRepository) Delete(ctx context.Context, id, workspaceID uuid.UUID) error {
 	return r.q(ctx).ArchiveInventory(ctx, queries.ArchiveInventoryParams{
 		ID:          id,
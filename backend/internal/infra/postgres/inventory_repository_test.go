@@ -320,14 +320,14 @@ func TestInventoryRepository_List(t *testing.T) {
 
 		// Test page 1 with limit 2
 		pagination := shared.Pagination{Page: 1, PageSize: 2}
-		inventories, total, err := invRepo.List(ctx, testfixtures.TestWorkspaceID, pagination)
+		inventories, total, err := invRepo.List(ctx, testfixtures.TestWorkspaceID, pagination, inventory.ListFilters{})
 		require.NoError(t, err)
 		assert.Equal(t, 3, total)     // Total should be 3
 		assert.Len(t, inventories, 2) // Page 1 should have 2 items
 
 		// Test page 2 with limit 2
 		pagination = shared.Pagination{Page: 2, PageSize: 2}
-		inventories, total, err = invRepo.List(ctx, testfixtures.TestWorkspaceID, pagination)
+		inventories, total, err = invRepo.List(ctx, testfixtures.TestWorkspaceID, pagination, inventory.ListFilters{})
 		require.NoError(t, err)
 		assert.Equal(t, 3, total)     // Total should still be 3
 		assert.Len(t, inventories, 1) // Page 2 should have 1 item
@@ -342,7 +342,7 @@ func TestInventoryRepository_List(t *testing.T) {
 
 		// Request with page size larger than total
 		pagination := shared.Pagination{Page: 1, PageSize: 50}
-		inventories, total, err := invRepo.List(ctx, testfixtures.TestWorkspaceID, pagination)
+		inventories, total, err := invRepo.List(ctx, testfixtures.TestWorkspaceID, pagination, inventory.ListFilters{})
 		require.NoError(t, err)
 		assert.Greater(t, total, 0)                 // Should have at least the one we just created
 		assert.LessOrEqual(t, len(inventories), 50) // Should not exceed page size
@@ -350,7 +350,7 @@ func TestInventoryRepository_List(t *testing.T) {
 
 	t.Run("returns empty list for page beyond total pages", func(t *testing.T) {
 		pagination := shared.Pagination{Page: 999, PageSize: 10}
-		inventories, total, err := invRepo.List(ctx, testfixtures.TestWorkspaceID, pagination)
+		inventories, total, err := invRepo.List(ctx, testfixtures.TestWorkspaceID, pagination, inventory.ListFilters{})
 		require.NoError(t, err)
 		assert.GreaterOrEqual(t, total, 0) // Total is based on count, not affected by page
 		assert.Empty(t, inventories)       // Should have no items on page 999
@@ -382,14 +382,14 @@ func TestInventoryRepository_List(t *testing.T) {
 
 		// List for workspace1 should only show workspace1 inventory
 		pagination := shared.Pagination{Page: 1, PageSize: 50}
-		inventories, _, err := invRepo.List(ctx, workspace1, pagination)
+		inventories, _, err := invRepo.List(ctx, workspace1, pagination, inventory.ListFilters{})
 		require.NoError(t, err)
 		for _, inv := range inventories {
 			assert.Equal(t, workspace1, inv.WorkspaceID())
 		}
 
 		// List for workspace2 should only show workspace2 inventory
-		inventories, _, err = invRepo.List(ctx, workspace2, pagination)
+		inventories, _, err = invRepo.List(ctx, workspace2, pagination, inventory.ListFilters{})
 		require.NoError(t, err)
 		for _, inv := range inventories {
 			assert.Equal(t, workspace2, inv.WorkspaceID())
@@ -406,14 +406,14 @@ func TestInventoryRepository_List(t *testing.T) {
 
 		// Get count before archiving
 		pagination := shared.Pagination{Page: 1, PageSize: 50}
-		_, totalBefore, err := invRepo.List(ctx, testfixtures.TestWorkspaceID, pagination)
+		_, totalBefore, err := invRepo.List(ctx, testfixtures.TestWorkspaceID, pagination, inventory.ListFilters{})
 		require.NoError(t, err)
 
 		// Archive the inventory
 		require.NoError(t, invRepo.Delete(ctx, inv.ID(), testfixtures.TestWorkspaceID))
 
 		// Get count after archiving
-		_, totalAfter, err := invRepo.List(ctx, testfixtures.TestWorkspaceID, pagination)
+		_, totalAfter, err := invRepo.List(ctx, testfixtures.TestWorkspaceID, pagination, inventory.ListFilters{})
 		require.NoError(t, err)
 
 		// Total should be less after archiving (assuming Delete archives)
@@ -434,7 +434,7 @@ func TestInventoryRepository_List(t *testing.T) {
 
 		// List should return newest first
 		pagination := shared.Pagination{Page: 1, PageSize: 10}
-		inventories, _, err := invRepo.List(ctx, testfixtures.TestWorkspaceID, pagination)
+		inventories, _, err := invRepo.List(ctx, testfixtures.TestWorkspaceID, pagination, inventory.ListFilters{})
 		require.NoError(t, err)
 		require.GreaterOrEqual(t, len(inventories), 2)
 
@@ -455,3 +455,119 @@ func TestInventoryRepository_List(t *testing.T) {
 		}
 	})
 }
+
+func TestInventoryRepository_ListWithItemDetails(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	invRepo := NewInventoryRepository(pool)
+	itemRepo := NewItemRepository(pool)
+	locRepo := NewLocationRepository(pool)
+	ctx := context.Background()
+
+	itm := createTestItem(t, itemRepo, ctx, "Expanded Item")
+	other := createTestItem(t, itemRepo, ctx, "Other Item")
+	loc := createTestLocationForInv(t, locRepo, ctx, "Expanded Location")
+
+	inv, _ := inventory.NewInventory(testfixtures.TestWorkspaceID, itm.ID(), loc.ID(), nil, 4, inventory.ConditionGood, inventory.StatusAvailable, nil)
+	otherInv, _ := inventory.NewInventory(testfixtures.TestWorkspaceID, other.ID(), loc.ID(), nil, 1, inventory.ConditionGood, inventory.StatusAvailable, nil)
+	require.NoError(t, invRepo.Save(ctx, inv))
+	require.NoError(t, invRepo.Save(ctx, otherInv))
+
+	t.Run("joins item and location fields", func(t *testing.T) {
+		itemID := itm.ID()
+		rows, total, err := invRepo.ListWithItemDetails(ctx, testfixtures.TestWorkspaceID,
+			shared.Pagination{Page: 1, PageSize: 10}, inventory.ListFilters{ItemID: &itemID})
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		require.Len(t, rows, 1)
+		assert.Equal(t, inv.ID(), rows[0].Inventory.ID())
+		assert.Equal(t, 4, rows[0].Inventory.Quantity())
+		assert.Equal(t, "Expanded Item", rows[0].ItemName)
+		assert.Equal(t, itm.SKU(), rows[0].ItemSKU)
+		assert.Equal(t, "Expanded Location", rows[0].LocationName)
+	})
+
+	t.Run("location filter applies to lean list too", func(t *testing.T) {
+		locationID := loc.ID()
+		filters := inventory.ListFilters{LocationID: &locationID}
+		pagination := shared.Pagination{Page: 1, PageSize: 10}
+
+		lean, leanTotal, err := invRepo.List(ctx, testfixtures.TestWorkspaceID, pagination, filters)
+		require.NoError(t, err)
+		expanded, expandedTotal, err := invRepo.ListWithItemDetails(ctx, testfixtures.TestWorkspaceID, pagination, filters)
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, leanTotal)
+		assert.Equal(t, leanTotal, expandedTotal)
+		require.Len(t, expanded, len(lean))
+		for i := range lean {
+			assert.Equal(t, lean[i].ID(), expanded[i].Inventory.ID())
+		}
+	})
+}
+
+func TestInventoryRepository_List_ConditionStatusFilters(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	invRepo := NewInventoryRepository(pool)
+	itemRepo := NewItemRepository(pool)
+	locRepo := NewLocationRepository(pool)
+	ctx := context.Background()
+
+	loc := createTestLocationForInv(t, locRepo, ctx, "Filter Location")
+	locationID := loc.ID()
+	pagination := shared.Pagination{Page: 1, PageSize: 50}
+
+	repair, _ := inventory.NewInventory(testfixtures.TestWorkspaceID, createTestItem(t, itemRepo, ctx, "Repair Item").ID(), loc.ID(), nil, 1, inventory.ConditionForRepair, inventory.StatusAvailable, nil)
+	damaged, _ := inventory.NewInventory(testfixtures.TestWorkspaceID, createTestItem(t, itemRepo, ctx, "Damaged Item").ID(), loc.ID(), nil, 1, inventory.ConditionDamaged, inventory.StatusInUse, nil)
+	good, _ := inventory.NewInventory(testfixtures.TestWorkspaceID, createTestItem(t, itemRepo, ctx, "Good Item").ID(), loc.ID(), nil, 1, inventory.ConditionGood, inventory.StatusInUse, nil)
+	for _, inv := range []*inventory.Inventory{repair, damaged, good} {
+		require.NoError(t, invRepo.Save(ctx, inv))
+	}
+
+	ids := func(invs []*inventory.Inventory) []uuid.UUID {
+		out := make([]uuid.UUID, len(invs))
+		for i, inv := range invs {
+			out[i] = inv.ID()
+		}
+		return out
+	}
+
+	t.Run("matches any listed condition", func(t *testing.T) {
+		invs, total, err := invRepo.List(ctx, testfixtures.TestWorkspaceID, pagination, inventory.ListFilters{
+			LocationID: &locationID,
+			Conditions: []inventory.Condition{inventory.ConditionForRepair, inventory.ConditionDamaged},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, total)
+		assert.ElementsMatch(t, []uuid.UUID{repair.ID(), damaged.ID()}, ids(invs))
+	})
+
+	t.Run("combines condition and status", func(t *testing.T) {
+		invs, total, err := invRepo.List(ctx, testfixtures.TestWorkspaceID, pagination, inventory.ListFilters{
+			LocationID: &locationID,
+			Conditions: []inventory.Condition{inventory.ConditionDamaged, inventory.ConditionGood},
+			Statuses:   []inventory.Status{inventory.StatusInUse},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 2, total)
+		assert.ElementsMatch(t, []uuid.UUID{damaged.ID(), good.ID()}, ids(invs))
+	})
+
+	t.Run("applies to expanded list", func(t *testing.T) {
+		rows, total, err := invRepo.ListWithItemDetails(ctx, testfixtures.TestWorkspaceID, pagination, inventory.ListFilters{
+			LocationID: &locationID,
+			Statuses:   []inventory.Status{inventory.StatusAvailable},
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		require.Len(t, rows, 1)
+		assert.Equal(t, "Repair Item", rows[0].ItemName)
+	})
+}
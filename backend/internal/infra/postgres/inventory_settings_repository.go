@@ -0,0 +1,68 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/inventory"
+	"github.com/antti/home-warehouse/go-backend/internal/infra/queries"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// InventorySettingsRepository persists per-workspace inventory settings
+// (warehouse.inventory_settings).
+type InventorySettingsRepository struct {
+	queries *queries.Queries
+}
+
+func NewInventorySettingsRepository(pool *pgxpool.Pool) *InventorySettingsRepository {
+	return &InventorySettingsRepository{
+		queries: queries.New(pool),
+	}
+}
+
+func (r *InventorySettingsRepository) Get(ctx context.Context, workspaceID uuid.UUID) (*inventory.Settings, error) {
+	row, err := r.queries.GetInventorySettings(ctx, workspaceID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, shared.ErrNotFound
+		}
+		return nil, err
+	}
+	return rowToInventorySettings(row), nil
+}
+
+func (r *InventorySettingsRepository) Upsert(ctx context.Context, workspaceID uuid.UUID, settings inventory.Settings) (*inventory.Settings, error) {
+	row, err := r.queries.UpsertInventorySettings(ctx, queries.UpsertInventorySettingsParams{
+		WorkspaceID:         workspaceID,
+		ReservedIsAvailable: settings.ReservedIsAvailable,
+		ExpiredStatus:       statusToNullStatus(settings.ExpiredStatus),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rowToInventorySettings(row), nil
+}
+
+func rowToInventorySettings(row queries.WarehouseInventorySetting) *inventory.Settings {
+	settings := &inventory.Settings{ReservedIsAvailable: row.ReservedIsAvailable}
+	if row.ExpiredStatus.Valid {
+		status := inventory.Status(row.ExpiredStatus.WarehouseItemStatusEnum)
+		settings.ExpiredStatus = &status
+	}
+	return settings
+}
+
+func statusToNullStatus(status *inventory.Status) queries.NullWarehouseItemStatusEnum {
+	if status == nil {
+		return queries.NullWarehouseItemStatusEnum{}
+	}
+	return queries.NullWarehouseItemStatusEnum{
+		WarehouseItemStatusEnum: queries.WarehouseItemStatusEnum(*status),
+		Valid:                   true,
+	}
+}
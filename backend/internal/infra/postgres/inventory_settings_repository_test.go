@@ -0,0 +1,73 @@
+//go:build integration
+// +build integration
+
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/inventory"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+	"github.com/antti/home-warehouse/go-backend/tests/testdb"
+	"github.com/antti/home-warehouse/go-backend/tests/testfixtures"
+)
+
+func TestInventorySettingsRepository_GetAndUpsert(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	repo := NewInventorySettingsRepository(pool)
+	ctx := context.Background()
+
+	_, err := repo.Get(ctx, testfixtures.TestWorkspaceID)
+	assert.ErrorIs(t, err, shared.ErrNotFound)
+
+	saved, err := repo.Upsert(ctx, testfixtures.TestWorkspaceID, inventory.Settings{ReservedIsAvailable: true})
+	require.NoError(t, err)
+	assert.True(t, saved.ReservedIsAvailable)
+
+	got, err := repo.Get(ctx, testfixtures.TestWorkspaceID)
+	require.NoError(t, err)
+	assert.True(t, got.ReservedIsAvailable)
+	assert.Nil(t, got.ExpiredStatus)
+
+	disposed := inventory.StatusDisposed
+	_, err = repo.Upsert(ctx, testfixtures.TestWorkspaceID, inventory.Settings{ExpiredStatus: &disposed})
+	require.NoError(t, err)
+
+	got, err = repo.Get(ctx, testfixtures.TestWorkspaceID)
+	require.NoError(t, err)
+	require.NotNil(t, got.ExpiredStatus)
+	assert.Equal(t, inventory.StatusDisposed, *got.ExpiredStatus)
+}
+
+func TestInventoryRepository_FindAvailable_IncludeReserved(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	repo := NewInventoryRepository(pool)
+	ctx := context.Background()
+
+	invID, itemID, _ := createTestInventoryWithItem(t, pool, testfixtures.TestWorkspaceID, "Reserved Item")
+	inv, err := repo.FindByID(ctx, invID, testfixtures.TestWorkspaceID)
+	require.NoError(t, err)
+	require.NoError(t, inv.UpdateStatus(inventory.StatusReserved))
+	require.NoError(t, repo.Save(ctx, inv))
+
+	available, err := repo.FindAvailable(ctx, testfixtures.TestWorkspaceID, itemID, false)
+	require.NoError(t, err)
+	assert.Empty(t, available)
+
+	available, err = repo.FindAvailable(ctx, testfixtures.TestWorkspaceID, itemID, true)
+	require.NoError(t, err)
+	require.Len(t, available, 1)
+	assert.Equal(t, invID, available[0].ID())
+}
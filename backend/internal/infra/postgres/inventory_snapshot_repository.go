@@ -0,0 +1,187 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/inventory"
+	"github.com/antti/home-warehouse/go-backend/internal/infra/queries"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// defaultSnapshotCurrency is the base currency of workspaces without
+// currency settings, as in the valuation queries.
+const defaultSnapshotCurrency = "EUR"
+
+// InventorySnapshotRepository computes and persists point-in-time inventory
+// summaries (warehouse.inventory_snapshots). The per-item lines are stored
+// as JSON in the snapshot row.
+type InventorySnapshotRepository struct {
+	queries *queries.Queries
+}
+
+func NewInventorySnapshotRepository(pool *pgxpool.Pool) *InventorySnapshotRepository {
+	return &InventorySnapshotRepository{
+		queries: queries.New(pool),
+	}
+}
+
+// snapshotItemJSON is the stored form of an inventory.SnapshotItem.
+type snapshotItemJSON struct {
+	ItemID     uuid.UUID      `json:"item_id"`
+	ItemName   string         `json:"item_name"`
+	SKU        string         `json:"sku"`
+	Quantity   int            `json:"quantity"`
+	Conditions map[string]int `json:"conditions"`
+	TotalValue int64          `json:"total_value"`
+	Unvalued   int            `json:"unvalued"`
+}
+
+func (r *InventorySnapshotRepository) Summarize(ctx context.Context, workspaceID uuid.UUID) (string, []inventory.SnapshotItem, error) {
+	currencyCode := defaultSnapshotCurrency
+	settings, err := r.queries.GetCurrencySettings(ctx, workspaceID)
+	if err == nil {
+		currencyCode = settings.BaseCurrency
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return "", nil, err
+	}
+
+	rows, err := r.queries.SummarizeInventoryForSnapshot(ctx, workspaceID)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// Rows come per item and condition, ordered by item.
+	items := []inventory.SnapshotItem{}
+	for _, row := range rows {
+		if len(items) == 0 || items[len(items)-1].ItemID != row.ItemID {
+			items = append(items, inventory.SnapshotItem{
+				ItemID:     row.ItemID,
+				ItemName:   row.ItemName,
+				SKU:        row.Sku,
+				Conditions: map[inventory.Condition]int{},
+			})
+		}
+		line := &items[len(items)-1]
+		line.Quantity += int(row.Quantity)
+		line.TotalValue += row.TotalValue
+		line.Unvalued += int(row.Unvalued)
+		if row.Condition != "" {
+			line.Conditions[inventory.Condition(row.Condition)] += int(row.Quantity)
+		}
+	}
+	return currencyCode, items, nil
+}
+
+func (r *InventorySnapshotRepository) Create(ctx context.Context, workspaceID uuid.UUID, snapshot inventory.Snapshot) (*inventory.Snapshot, error) {
+	lines := make([]snapshotItemJSON, len(snapshot.Items))
+	for i, line := range snapshot.Items {
+		conditions := make(map[string]int, len(line.Conditions))
+		for condition, quantity := range line.Conditions {
+			conditions[string(condition)] = quantity
+		}
+		lines[i] = snapshotItemJSON{
+			ItemID:     line.ItemID,
+			ItemName:   line.ItemName,
+			SKU:        line.SKU,
+			Quantity:   line.Quantity,
+			Conditions: conditions,
+			TotalValue: line.TotalValue,
+			Unvalued:   line.Unvalued,
+		}
+	}
+	items, err := json.Marshal(lines)
+	if err != nil {
+		return nil, err
+	}
+
+	row, err := r.queries.CreateInventorySnapshot(ctx, queries.CreateInventorySnapshotParams{
+		ID:            snapshot.ID,
+		WorkspaceID:   workspaceID,
+		Label:         snapshot.Label,
+		CurrencyCode:  snapshot.CurrencyCode,
+		TotalQuantity: int64(snapshot.TotalQuantity),
+		TotalValue:    snapshot.TotalValue,
+		UnvaluedCount: int32(snapshot.Unvalued),
+		Items:         items,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rowToInventorySnapshot(row)
+}
+
+func (r *InventorySnapshotRepository) List(ctx context.Context, workspaceID uuid.UUID) ([]*inventory.Snapshot, error) {
+	rows, err := r.queries.ListInventorySnapshots(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	snapshots := make([]*inventory.Snapshot, len(rows))
+	for i, row := range rows {
+		snapshots[i] = &inventory.Snapshot{
+			ID:            row.ID,
+			Label:         row.Label,
+			CurrencyCode:  row.CurrencyCode,
+			TotalQuantity: int(row.TotalQuantity),
+			TotalValue:    row.TotalValue,
+			Unvalued:      int(row.UnvaluedCount),
+			CreatedAt:     row.CreatedAt,
+		}
+	}
+	return snapshots, nil
+}
+
+func (r *InventorySnapshotRepository) Get(ctx context.Context, workspaceID, id uuid.UUID) (*inventory.Snapshot, error) {
+	row, err := r.queries.GetInventorySnapshot(ctx, queries.GetInventorySnapshotParams{
+		ID:          id,
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, shared.ErrNotFound
+		}
+		return nil, err
+	}
+	return rowToInventorySnapshot(row)
+}
+
+func rowToInventorySnapshot(row queries.WarehouseInventorySnapshot) (*inventory.Snapshot, error) {
+	var lines []snapshotItemJSON
+	if err := json.Unmarshal(row.Items, &lines); err != nil {
+		return nil, err
+	}
+
+	items := make([]inventory.SnapshotItem, len(lines))
+	for i, line := range lines {
+		conditions := make(map[inventory.Condition]int, len(line.Conditions))
+		for condition, quantity := range line.Conditions {
+			conditions[inventory.Condition(condition)] = quantity
+		}
+		items[i] = inventory.SnapshotItem{
+			ItemID:     line.ItemID,
+			ItemName:   line.ItemName,
+			SKU:        line.SKU,
+			Quantity:   line.Quantity,
+			Conditions: conditions,
+			TotalValue: line.TotalValue,
+			Unvalued:   line.Unvalued,
+		}
+	}
+
+	return &inventory.Snapshot{
+		ID:            row.ID,
+		Label:         row.Label,
+		CurrencyCode:  row.CurrencyCode,
+		TotalQuantity: int(row.TotalQuantity),
+		TotalValue:    row.TotalValue,
+		Unvalued:      int(row.UnvaluedCount),
+		Items:         items,
+		CreatedAt:     row.CreatedAt,
+	}, nil
+}
@@ -0,0 +1,97 @@
+//go:build integration
+// +build integration
+
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/inventory"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+	"github.com/antti/home-warehouse/go-backend/tests/testdb"
+	"github.com/antti/home-warehouse/go-backend/tests/testfixtures"
+)
+
+func TestInventorySnapshotRepository(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	invRepo := NewInventoryRepository(pool)
+	repo := NewInventorySnapshotRepository(pool)
+	svc := inventory.NewService(invRepo, nil, nil, nil, nil)
+	svc.SetSnapshotRepository(repo)
+	ctx := context.Background()
+
+	drill := createTestItem(t, NewItemRepository(pool), ctx, "Drill")
+	loc := createTestLocationForInv(t, NewLocationRepository(pool), ctx, "Garage")
+
+	addInventory := func(quantity int, condition inventory.Condition, price *int) *inventory.Inventory {
+		inv, err := inventory.NewInventory(testfixtures.TestWorkspaceID, drill.ID(), loc.ID(), nil, quantity, condition, inventory.StatusAvailable, nil)
+		require.NoError(t, err)
+		require.NoError(t, inv.Update(inventory.UpdateInput{
+			LocationID: loc.ID(), Quantity: quantity, Condition: condition, PurchasePrice: price,
+		}))
+		require.NoError(t, invRepo.Save(ctx, inv))
+		return inv
+	}
+	price := 5000
+	good := addInventory(2, inventory.ConditionGood, &price)
+	addInventory(1, inventory.ConditionFair, nil)
+
+	snapshot, err := svc.CreateSnapshot(ctx, testfixtures.TestWorkspaceID, "Year end 2025")
+	require.NoError(t, err)
+
+	assertYearEnd := func(t *testing.T, s *inventory.Snapshot) {
+		t.Helper()
+		assert.Equal(t, "EUR", s.CurrencyCode)
+		assert.Equal(t, 3, s.TotalQuantity)
+		assert.Equal(t, int64(10000), s.TotalValue)
+		assert.Equal(t, 1, s.Unvalued)
+		require.Len(t, s.Items, 1)
+		assert.Equal(t, drill.ID(), s.Items[0].ItemID)
+		assert.Equal(t, 3, s.Items[0].Quantity)
+		assert.Equal(t, map[inventory.Condition]int{inventory.ConditionGood: 2, inventory.ConditionFair: 1}, s.Items[0].Conditions)
+	}
+	assertYearEnd(t, snapshot)
+
+	t.Run("is unaffected by later inventory changes", func(t *testing.T) {
+		require.NoError(t, good.Update(inventory.UpdateInput{
+			LocationID: loc.ID(), Quantity: 7, Condition: inventory.ConditionDamaged, PurchasePrice: &price,
+		}))
+		require.NoError(t, invRepo.Save(ctx, good))
+		addInventory(4, inventory.ConditionNew, &price)
+
+		stored, err := svc.GetSnapshot(ctx, testfixtures.TestWorkspaceID, snapshot.ID)
+		require.NoError(t, err)
+		assertYearEnd(t, stored)
+
+		later, err := svc.CreateSnapshot(ctx, testfixtures.TestWorkspaceID, "After the move")
+		require.NoError(t, err)
+		assert.Equal(t, 12, later.TotalQuantity)
+
+		diff, err := svc.DiffSnapshots(ctx, testfixtures.TestWorkspaceID, snapshot.ID, later.ID)
+		require.NoError(t, err)
+		assert.Equal(t, 9, diff.QuantityChange)
+		assert.Equal(t, int64(45000), diff.ValueChange)
+	})
+
+	t.Run("lists headers newest first", func(t *testing.T) {
+		all, err := repo.List(ctx, testfixtures.TestWorkspaceID)
+		require.NoError(t, err)
+		require.Len(t, all, 2)
+		assert.Equal(t, "After the move", all[0].Label)
+		assert.Nil(t, all[0].Items)
+	})
+
+	t.Run("snapshot of another workspace is not found", func(t *testing.T) {
+		_, err := repo.Get(ctx, uuid.New(), snapshot.ID)
+		assert.ErrorIs(t, err, shared.ErrNotFound)
+	})
+}
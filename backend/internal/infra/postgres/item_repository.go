@@ -6,6 +6,7 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 
@@ -15,20 +16,24 @@ import (
 )
 
 type ItemRepository struct {
-	pool    *pgxpool.Pool
-	queries *queries.Queries
+	pool *pgxpool.Pool
 }
 
 func NewItemRepository(pool *pgxpool.Pool) *ItemRepository {
 	return &ItemRepository{
-		pool:    pool,
-		queries: queries.New(pool),
+		pool: pool,
 	}
 }
 
+// q returns Queries bound to the active transaction in ctx (if any) or the
+// pool, so item writes and the reads that follow them share a transaction.
+func (r *ItemRepository) q(ctx context.Context) *queries.Queries {
+	return queries.New(GetDBTX(ctx, r.pool))
+}
+
 func (r *ItemRepository) Save(ctx context.Context, i *item.Item) error {
 	// Check if item already exists
-	existing, err := r.queries.GetItem(ctx, queries.GetItemParams{
+	existing, err := r.q(ctx).GetItem(ctx, queries.GetItemParams{
 		ID:          i.ID(),
 		WorkspaceID: i.WorkspaceID(),
 	})
@@ -51,21 +56,21 @@ func (r *ItemRepository) Save(ctx context.Context, i *item.Item) error {
 
 		// If item is being archived (active → archived)
 		if itemArchived && !existingArchived {
-			return r.queries.ArchiveItem(ctx, queries.ArchiveItemParams{
+			return r.q(ctx).ArchiveItem(ctx, queries.ArchiveItemParams{
 				ID:          i.ID(),
 				WorkspaceID: i.WorkspaceID(),
 			})
 		}
 		// If item is being restored (archived → active)
 		if !itemArchived && existingArchived {
-			return r.queries.RestoreItem(ctx, queries.RestoreItemParams{
+			return r.q(ctx).RestoreItem(ctx, queries.RestoreItemParams{
 				ID:          i.ID(),
 				WorkspaceID: i.WorkspaceID(),
 			})
 		}
 
 		// Otherwise, update the item
-		_, err = r.queries.UpdateItem(ctx, queries.UpdateItemParams{
+		_, err = r.q(ctx).UpdateItem(ctx, queries.UpdateItemParams{
 			ID:                i.ID(),
 			WorkspaceID:       i.WorkspaceID(),
 			Name:              i.Name(),
@@ -85,12 +90,13 @@ func (r *ItemRepository) Save(ctx context.Context, i *item.Item) error {
 			ObsidianVaultPath: i.ObsidianVaultPath(),
 			ObsidianNotePath:  i.ObsidianNotePath(),
 			NeedsReview:       i.NeedsReview(),
+			Loanable:          i.IsLoanable(),
 		})
 		return err
 	}
 
 	// Create new item
-	_, err = r.queries.CreateItem(ctx, queries.CreateItemParams{
+	_, err = r.q(ctx).CreateItem(ctx, queries.CreateItemParams{
 		ID:                i.ID(),
 		WorkspaceID:       i.WorkspaceID(),
 		Sku:               i.SKU(),
@@ -112,12 +118,13 @@ func (r *ItemRepository) Save(ctx context.Context, i *item.Item) error {
 		ObsidianVaultPath: i.ObsidianVaultPath(),
 		ObsidianNotePath:  i.ObsidianNotePath(),
 		NeedsReview:       i.NeedsReview(),
+		Loanable:          i.IsLoanable(),
 	})
 	return err
 }
 
 func (r *ItemRepository) FindByID(ctx context.Context, id, workspaceID uuid.UUID) (*item.Item, error) {
-	row, err := r.queries.GetItem(ctx, queries.GetItemParams{
+	row, err := r.q(ctx).GetItem(ctx, queries.GetItemParams{
 		ID:          id,
 		WorkspaceID: workspaceID,
 	})
@@ -131,8 +138,25 @@ func (r *ItemRepository) FindByID(ctx context.Context, id, workspaceID uuid.UUID
 	return r.rowToItem(row), nil
 }
 
+func (r *ItemRepository) FindByIDs(ctx context.Context, workspaceID uuid.UUID, ids []uuid.UUID) ([]*item.Item, error) {
+	rows, err := r.q(ctx).ListItemsByIDs(ctx, queries.ListItemsByIDsParams{
+		WorkspaceID: workspaceID,
+		Ids:         ids,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]*item.Item, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, r.rowToItem(row))
+	}
+
+	return items, nil
+}
+
 func (r *ItemRepository) FindBySKU(ctx context.Context, workspaceID uuid.UUID, sku string) (*item.Item, error) {
-	row, err := r.queries.GetItemBySKU(ctx, queries.GetItemBySKUParams{
+	row, err := r.q(ctx).GetItemBySKU(ctx, queries.GetItemBySKUParams{
 		WorkspaceID: workspaceID,
 		Sku:         sku,
 	})
@@ -147,7 +171,7 @@ func (r *ItemRepository) FindBySKU(ctx context.Context, workspaceID uuid.UUID, s
 }
 
 func (r *ItemRepository) FindByShortCode(ctx context.Context, workspaceID uuid.UUID, shortCode string) (*item.Item, error) {
-	row, err := r.queries.GetItemByShortCode(ctx, queries.GetItemByShortCodeParams{
+	row, err := r.q(ctx).GetItemByShortCode(ctx, queries.GetItemByShortCodeParams{
 		WorkspaceID: workspaceID,
 		ShortCode:   shortCode,
 	})
@@ -161,23 +185,38 @@ func (r *ItemRepository) FindByShortCode(ctx context.Context, workspaceID uuid.U
 	return r.rowToItem(row), nil
 }
 
-func (r *ItemRepository) FindByBarcode(ctx context.Context, workspaceID uuid.UUID, barcode string) (*item.Item, error) {
-	row, err := r.queries.GetItemByBarcode(ctx, queries.GetItemByBarcodeParams{
+func (r *ItemRepository) FindByBarcode(ctx context.Context, workspaceID uuid.UUID, barcode string) (*item.Item, *item.Identifier, error) {
+	row, err := r.q(ctx).GetItemByBarcode(ctx, queries.GetItemByBarcodeParams{
 		WorkspaceID: workspaceID,
 		Barcode:     &barcode,
 	})
+	if err == nil {
+		return r.rowToItem(row), nil, nil
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil, err
+	}
+
+	ident, err := r.q(ctx).GetItemIdentifierByValue(ctx, queries.GetItemIdentifierByValueParams{
+		WorkspaceID: workspaceID,
+		Value:       barcode,
+	})
 	if err != nil {
-		if errors.Is(err, pgx.ErrNoRows) {
-			return nil, shared.ErrNotFound
-		}
-		return nil, err
+		return nil, nil, HandleNotFound(err)
+	}
+	row, err = r.q(ctx).GetItem(ctx, queries.GetItemParams{
+		ID:          ident.ItemID,
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		return nil, nil, HandleNotFound(err)
 	}
 
-	return r.rowToItem(row), nil
+	return r.rowToItem(row), rowToIdentifier(ident), nil
 }
 
 func (r *ItemRepository) FindByWorkspace(ctx context.Context, workspaceID uuid.UUID, pagination shared.Pagination) ([]*item.Item, int, error) {
-	rows, err := r.queries.ListItems(ctx, queries.ListItemsParams{
+	rows, err := r.q(ctx).ListItems(ctx, queries.ListItemsParams{
 		WorkspaceID: workspaceID,
 		Limit:       int32(pagination.Limit()),
 		Offset:      int32(pagination.Offset()),
@@ -201,7 +240,7 @@ func (r *ItemRepository) FindByWorkspace(ctx context.Context, workspaceID uuid.U
 }
 
 func (r *ItemRepository) FindNeedingReview(ctx context.Context, workspaceID uuid.UUID, pagination shared.Pagination) ([]*item.Item, int, error) {
-	rows, err := r.queries.ListItemsNeedingReview(ctx, queries.ListItemsNeedingReviewParams{
+	rows, err := r.q(ctx).ListItemsNeedingReview(ctx, queries.ListItemsNeedingReviewParams{
 		WorkspaceID: workspaceID,
 		Limit:       int32(pagination.Limit()),
 		Offset:      int32(pagination.Offset()),
@@ -210,7 +249,7 @@ func (r *ItemRepository) FindNeedingReview(ctx context.Context, workspaceID uuid
 		return nil, 0, err
 	}
 
-	count, err := r.queries.CountItemsNeedingReview(ctx, workspaceID)
+	count, err := r.q(ctx).CountItemsNeedingReview(ctx, workspaceID)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -223,8 +262,30 @@ func (r *ItemRepository) FindNeedingReview(ctx context.Context, workspaceID uuid
 	return items, int(count), nil
 }
 
+func (r *ItemRepository) ReassignCategory(ctx context.Context, workspaceID uuid.UUID, itemIDs []uuid.UUID, categoryID *uuid.UUID) ([]item.ReassignedItem, error) {
+	var category pgtype.UUID
+	if categoryID != nil {
+		category = pgtype.UUID{Bytes: *categoryID, Valid: true}
+	}
+
+	rows, err := r.q(ctx).ReassignItemsCategory(ctx, queries.ReassignItemsCategoryParams{
+		CategoryID:  category,
+		WorkspaceID: workspaceID,
+		ItemIds:     itemIDs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	updated := make([]item.ReassignedItem, len(rows))
+	for i, row := range rows {
+		updated[i] = item.ReassignedItem{ID: row.ID, Name: row.Name}
+	}
+	return updated, nil
+}
+
 func (r *ItemRepository) FindByCategory(ctx context.Context, workspaceID, categoryID uuid.UUID, pagination shared.Pagination) ([]*item.Item, error) {
-	rows, err := r.queries.ListItemsByCategory(ctx, queries.ListItemsByCategoryParams{
+	rows, err := r.q(ctx).ListItemsByCategory(ctx, queries.ListItemsByCategoryParams{
 		WorkspaceID: workspaceID,
 		CategoryID:  pgtype.UUID{Bytes: categoryID, Valid: true},
 		Limit:       int32(pagination.Limit()),
@@ -243,7 +304,7 @@ func (r *ItemRepository) FindByCategory(ctx context.Context, workspaceID, catego
 }
 
 func (r *ItemRepository) Search(ctx context.Context, workspaceID uuid.UUID, query string, limit int) ([]*item.Item, error) {
-	rows, err := r.queries.SearchItems(ctx, queries.SearchItemsParams{
+	rows, err := r.q(ctx).SearchItems(ctx, queries.SearchItemsParams{
 		WorkspaceID:    workspaceID,
 		PlaintoTsquery: query,
 		Limit:          int32(limit),
@@ -265,8 +326,9 @@ func (r *ItemRepository) Search(ctx context.Context, workspaceID uuid.UUID, quer
 // that runs through Save when the entity's is_archived flag flips. Previous
 // implementation wrongly called ArchiveItem — fixed per Phase 60 Pitfall 3
 // (mirrors the Phase 59 borrower fix).
+// Delete removes the item. Uses the transaction in ctx (if any).
 func (r *ItemRepository) Delete(ctx context.Context, id, workspaceID uuid.UUID) error {
-	return r.queries.DeleteItem(ctx, queries.DeleteItemParams{
+	return r.q(ctx).DeleteItem(ctx, queries.DeleteItemParams{
 		ID:          id,
 		WorkspaceID: workspaceID,
 	})
@@ -305,6 +367,12 @@ func (r *ItemRepository) FindByWorkspaceFiltered(
 		categoryParam = pgtype.UUID{Bytes: *filters.CategoryID, Valid: true}
 	}
 
+	var brandParam *string
+	if filters.Brand != nil && *filters.Brand != "" {
+		b := *filters.Brand
+		brandParam = &b
+	}
+
 	sortField := filters.Sort
 	if sortField == "" {
 		sortField = "name"
@@ -314,29 +382,33 @@ func (r *ItemRepository) FindByWorkspaceFiltered(
 		sortDir = "asc"
 	}
 
-	rows, err := r.queries.ListItemsFiltered(ctx, queries.ListItemsFilteredParams{
-		WorkspaceID: workspaceID,
-		Archived:    archivedParam,
-		Search:      searchParam,
-		CategoryID:  categoryParam,
-		IsInsured:   filters.IsInsured,
-		NeedsReview: filters.NeedsReview,
-		SortField:   sortField,
-		SortDir:     sortDir,
-		Limit:       int32(pagination.Limit()),
-		Offset:      int32(pagination.Offset()),
+	rows, err := r.q(ctx).ListItemsFiltered(ctx, queries.ListItemsFilteredParams{
+		WorkspaceID:  workspaceID,
+		Archived:     archivedParam,
+		Search:       searchParam,
+		CategoryID:   categoryParam,
+		IsInsured:    filters.IsInsured,
+		NeedsReview:  filters.NeedsReview,
+		Brand:        brandParam,
+		HasInventory: filters.HasInventory,
+		SortField:    sortField,
+		SortDir:      sortDir,
+		Limit:        int32(pagination.Limit()),
+		Offset:       int32(pagination.Offset()),
 	})
 	if err != nil {
 		return nil, 0, err
 	}
 
-	total, err := r.queries.CountItemsFiltered(ctx, queries.CountItemsFilteredParams{
-		WorkspaceID: workspaceID,
-		Archived:    archivedParam,
-		Search:      searchParam,
-		CategoryID:  categoryParam,
-		IsInsured:   filters.IsInsured,
-		NeedsReview: filters.NeedsReview,
+	total, err := r.q(ctx).CountItemsFiltered(ctx, queries.CountItemsFilteredParams{
+		WorkspaceID:  workspaceID,
+		Archived:     archivedParam,
+		Search:       searchParam,
+		CategoryID:   categoryParam,
+		IsInsured:    filters.IsInsured,
+		NeedsReview:  filters.NeedsReview,
+		Brand:        brandParam,
+		HasInventory: filters.HasInventory,
 	})
 	if err != nil {
 		return nil, 0, err
@@ -350,34 +422,129 @@ func (r *ItemRepository) FindByWorkspaceFiltered(
 }
 
 func (r *ItemRepository) SKUExists(ctx context.Context, workspaceID uuid.UUID, sku string) (bool, error) {
-	return r.queries.ItemSKUExists(ctx, queries.ItemSKUExistsParams{
+	return r.q(ctx).ItemSKUExists(ctx, queries.ItemSKUExistsParams{
+		WorkspaceID: workspaceID,
+		Sku:         sku,
+	})
+}
+
+// FindSimilar lists trigram-similar pairs of active items. It compares every
+// pair in the workspace, which is fine for an on-demand suggestion list at
+// household scale.
+func (r *ItemRepository) FindSimilar(ctx context.Context, workspaceID uuid.UUID, threshold float64, limit int) ([]item.SimilarPair, error) {
+	rows, err := r.q(ctx).FindSimilarItems(ctx, queries.FindSimilarItemsParams{
+		WorkspaceID: workspaceID,
+		Threshold:   threshold,
+		MaxResults:  int32(limit),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pairs := make([]item.SimilarPair, len(rows))
+	for i, row := range rows {
+		pairs[i] = item.SimilarPair{
+			Item:       item.SimilarItem{ID: row.ItemID, Name: row.ItemName, SKU: row.ItemSku, Brand: row.ItemBrand},
+			Match:      item.SimilarItem{ID: row.MatchID, Name: row.MatchName, SKU: row.MatchSku, Brand: row.MatchBrand},
+			Similarity: row.Similarity,
+		}
+	}
+	return pairs, nil
+}
+
+// CountLoans counts loans against the item's inventory. Uses the
+// transaction in ctx (if any).
+func (r *ItemRepository) CountLoans(ctx context.Context, workspaceID, itemID uuid.UUID) (int, error) {
+	count, err := r.q(ctx).CountItemLoans(ctx, queries.CountItemLoansParams{
+		WorkspaceID: workspaceID,
+		ItemID:      itemID,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(count), nil
+}
+
+// MoveInventory re-points fromItemID's inventory to toItemID. Uses the
+// transaction in ctx (if any).
+func (r *ItemRepository) MoveInventory(ctx context.Context, workspaceID, fromItemID, toItemID uuid.UUID) (int, error) {
+	moved, err := r.q(ctx).MoveItemInventory(ctx, queries.MoveItemInventoryParams{
+		ToItemID:    toItemID,
+		WorkspaceID: workspaceID,
+		FromItemID:  fromItemID,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(moved), nil
+}
+
+// MoveLabels attaches fromItemID's labels to toItemID. Uses the transaction
+// in ctx (if any).
+func (r *ItemRepository) MoveLabels(ctx context.Context, workspaceID, fromItemID, toItemID uuid.UUID) (int, error) {
+	moved, err := r.q(ctx).MoveItemLabels(ctx, queries.MoveItemLabelsParams{
+		ToItemID:    toItemID,
+		WorkspaceID: workspaceID,
+		FromItemID:  fromItemID,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(moved), nil
+}
+
+// MovePhotos re-points fromItemID's photos to toItemID. Uses the
+// transaction in ctx (if any).
+func (r *ItemRepository) MovePhotos(ctx context.Context, workspaceID, fromItemID, toItemID uuid.UUID) (int, error) {
+	moved, err := r.q(ctx).MoveItemPhotos(ctx, queries.MoveItemPhotosParams{
+		ToItemID:    toItemID,
+		WorkspaceID: workspaceID,
+		FromItemID:  fromItemID,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(moved), nil
+}
+
+// UpdateSKU changes the item's SKU. Uses the transaction in ctx (if any).
+func (r *ItemRepository) UpdateSKU(ctx context.Context, workspaceID, itemID uuid.UUID, sku string) error {
+	err := r.q(ctx).UpdateItemSKU(ctx, queries.UpdateItemSKUParams{
+		ID:          itemID,
 		WorkspaceID: workspaceID,
 		Sku:         sku,
 	})
+	// 23505 unique_violation: another item took the SKU after the service
+	// checked it.
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return item.ErrSKUTaken
+	}
+	return err
 }
 
 // ShortCodeExists checks the global warehouse.short_codes registry
 // (migration 005): short codes are globally unique, not per-workspace.
 func (r *ItemRepository) ShortCodeExists(ctx context.Context, shortCode string) (bool, error) {
-	return r.queries.ShortCodeExists(ctx, shortCode)
+	return r.q(ctx).ShortCodeExists(ctx, shortCode)
 }
 
 func (r *ItemRepository) AttachLabel(ctx context.Context, itemID, labelID uuid.UUID) error {
-	return r.queries.AttachLabel(ctx, queries.AttachLabelParams{
+	return r.q(ctx).AttachLabel(ctx, queries.AttachLabelParams{
 		ItemID:  itemID,
 		LabelID: labelID,
 	})
 }
 
 func (r *ItemRepository) DetachLabel(ctx context.Context, itemID, labelID uuid.UUID) error {
-	return r.queries.DetachLabel(ctx, queries.DetachLabelParams{
+	return r.q(ctx).DetachLabel(ctx, queries.DetachLabelParams{
 		ItemID:  itemID,
 		LabelID: labelID,
 	})
 }
 
 func (r *ItemRepository) GetItemLabels(ctx context.Context, itemID uuid.UUID) ([]uuid.UUID, error) {
-	labels, err := r.queries.GetItemLabels(ctx, itemID)
+	labels, err := r.q(ctx).GetItemLabels(ctx, itemID)
 	if err != nil {
 		return nil, err
 	}
@@ -389,6 +556,58 @@ func (r *ItemRepository) GetItemLabels(ctx context.Context, itemID uuid.UUID) ([
 	return labelIDs, nil
 }
 
+func (r *ItemRepository) ListIdentifiers(ctx context.Context, workspaceID, itemID uuid.UUID) ([]*item.Identifier, error) {
+	rows, err := r.q(ctx).ListItemIdentifiers(ctx, queries.ListItemIdentifiersParams{
+		WorkspaceID: workspaceID,
+		ItemID:      itemID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	identifiers := make([]*item.Identifier, len(rows))
+	for i, row := range rows {
+		identifiers[i] = rowToIdentifier(row)
+	}
+	return identifiers, nil
+}
+
+func (r *ItemRepository) SaveIdentifier(ctx context.Context, identifier *item.Identifier) error {
+	_, err := r.q(ctx).CreateItemIdentifier(ctx, queries.CreateItemIdentifierParams{
+		ID:             identifier.ID(),
+		WorkspaceID:    identifier.WorkspaceID(),
+		ItemID:         identifier.ItemID(),
+		IdentifierType: string(identifier.Type()),
+		Value:          identifier.Value(),
+	})
+	// 23505 unique_violation: a concurrent add took the value between the
+	// service's lookup and this insert.
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == "23505" {
+		return item.ErrIdentifierTaken
+	}
+	return err
+}
+
+func (r *ItemRepository) DeleteIdentifier(ctx context.Context, workspaceID, itemID, identifierID uuid.UUID) error {
+	n, err := r.q(ctx).DeleteItemIdentifier(ctx, queries.DeleteItemIdentifierParams{
+		WorkspaceID: workspaceID,
+		ItemID:      itemID,
+		ID:          identifierID,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return shared.ErrNotFound
+	}
+	return nil
+}
+
+func rowToIdentifier(row queries.WarehouseItemIdentifier) *item.Identifier {
+	return item.ReconstructIdentifier(row.ID, row.WorkspaceID, row.ItemID, item.IdentifierType(row.IdentifierType), row.Value, row.CreatedAt)
+}
+
 func (r *ItemRepository) rowToItem(row queries.WarehouseItem) *item.Item {
 	var categoryID, purchasedFrom *uuid.UUID
 	if row.CategoryID.Valid {
@@ -426,6 +645,7 @@ func (r *ItemRepository) rowToItem(row queries.WarehouseItem) *item.Item {
 		row.ObsidianVaultPath,
 		row.ObsidianNotePath,
 		row.NeedsReview,
+		row.Loanable,
 		row.CreatedAt.Time,
 		row.UpdatedAt.Time,
 	)
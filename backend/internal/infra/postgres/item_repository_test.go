@@ -12,7 +12,10 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/category"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/inventory"
 	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/item"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/label"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/location"
 	"github.com/antti/home-warehouse/go-backend/internal/shared"
 	"github.com/antti/home-warehouse/go-backend/tests/testdb"
 	"github.com/antti/home-warehouse/go-backend/tests/testfixtures"
@@ -58,6 +61,25 @@ func TestItemRepository_Save(t *testing.T) {
 		require.NotNil(t, retrieved)
 		assert.Equal(t, 10, retrieved.MinStockLevel())
 	})
+
+	t.Run("persists the loanable flag", func(t *testing.T) {
+		itm, err := item.NewItem(testfixtures.TestWorkspaceID, "Wall shelf", "SKU-003", 0)
+		require.NoError(t, err)
+		itm.SetShortCode(uuid.NewString()[:8])
+		require.NoError(t, repo.Save(ctx, itm))
+
+		retrieved, err := repo.FindByID(ctx, itm.ID(), testfixtures.TestWorkspaceID)
+		require.NoError(t, err)
+		assert.True(t, retrieved.IsLoanable(), "items are loanable by default")
+
+		notLoanable := false
+		require.NoError(t, retrieved.Update(item.UpdateInput{Name: retrieved.Name(), Loanable: &notLoanable}))
+		require.NoError(t, repo.Save(ctx, retrieved))
+
+		retrieved, err = repo.FindByID(ctx, itm.ID(), testfixtures.TestWorkspaceID)
+		require.NoError(t, err)
+		assert.False(t, retrieved.IsLoanable())
+	})
 }
 
 func TestItemRepository_FindByID(t *testing.T) {
@@ -115,6 +137,42 @@ func TestItemRepository_FindByID(t *testing.T) {
 	})
 }
 
+func TestItemRepository_FindByIDs(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	repo := NewItemRepository(pool)
+	ctx := context.Background()
+
+	save := func(workspaceID uuid.UUID, name string) *item.Item {
+		itm, err := item.NewItem(workspaceID, name, "SKU-IDS-"+uuid.NewString()[:8], 0)
+		require.NoError(t, err)
+		itm.SetShortCode(uuid.NewString()[:8])
+		require.NoError(t, repo.Save(ctx, itm))
+		return itm
+	}
+
+	first := save(testfixtures.TestWorkspaceID, "First")
+	second := save(testfixtures.TestWorkspaceID, "Second")
+	third := save(testfixtures.TestWorkspaceID, "Third")
+	otherWorkspace := uuid.New()
+	testdb.CreateTestWorkspace(t, pool, otherWorkspace)
+	foreign := save(otherWorkspace, "Foreign")
+
+	found, err := repo.FindByIDs(ctx, testfixtures.TestWorkspaceID,
+		[]uuid.UUID{third.ID(), uuid.New(), first.ID(), foreign.ID(), second.ID()})
+
+	require.NoError(t, err)
+	ids := make([]uuid.UUID, len(found))
+	for i, itm := range found {
+		ids[i] = itm.ID()
+	}
+	assert.Equal(t, []uuid.UUID{third.ID(), first.ID(), second.ID()}, ids,
+		"requested order is kept; unknown and other-workspace IDs are omitted")
+}
+
 func TestItemRepository_FindBySKU(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test")
@@ -178,6 +236,54 @@ func TestItemRepository_FindByShortCode(t *testing.T) {
 	})
 }
 
+func TestItemRepository_Identifiers(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	repo := NewItemRepository(pool)
+	ctx := context.Background()
+	ws := testfixtures.TestWorkspaceID
+
+	itm, err := item.NewItem(ws, "Identified Item", "SKU-IDENT", 0)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, itm))
+
+	identifier, err := item.NewIdentifier(ws, itm.ID(), item.IdentifierTypeAssetTag, "TAG-0001")
+	require.NoError(t, err)
+	require.NoError(t, repo.SaveIdentifier(ctx, identifier))
+
+	t.Run("scan resolves an identifier", func(t *testing.T) {
+		found, matched, err := repo.FindByBarcode(ctx, ws, "TAG-0001")
+		require.NoError(t, err)
+		assert.Equal(t, itm.ID(), found.ID())
+		require.NotNil(t, matched)
+		assert.Equal(t, identifier.ID(), matched.ID())
+		assert.Equal(t, item.IdentifierTypeAssetTag, matched.Type())
+	})
+
+	t.Run("values are unique per workspace", func(t *testing.T) {
+		dup, err := item.NewIdentifier(ws, itm.ID(), item.IdentifierTypeOther, "TAG-0001")
+		require.NoError(t, err)
+
+		assert.ErrorIs(t, repo.SaveIdentifier(ctx, dup), item.ErrIdentifierTaken)
+	})
+
+	t.Run("lists and deletes", func(t *testing.T) {
+		listed, err := repo.ListIdentifiers(ctx, ws, itm.ID())
+		require.NoError(t, err)
+		require.Len(t, listed, 1)
+		assert.Equal(t, "TAG-0001", listed[0].Value())
+
+		require.NoError(t, repo.DeleteIdentifier(ctx, ws, itm.ID(), identifier.ID()))
+		assert.True(t, shared.IsNotFound(repo.DeleteIdentifier(ctx, ws, itm.ID(), identifier.ID())))
+
+		_, _, err = repo.FindByBarcode(ctx, ws, "TAG-0001")
+		assert.True(t, shared.IsNotFound(err))
+	})
+}
+
 func TestItemRepository_FindByWorkspace(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test")
@@ -444,6 +550,72 @@ func TestItemRepository_FindByWorkspaceFiltered(t *testing.T) {
 		assert.Equal(t, 5, total)
 	})
 
+	t.Run("Brand_CaseInsensitiveExactMatch", func(t *testing.T) {
+		ws := uuid.New()
+		testdb.CreateTestWorkspace(t, pool, ws)
+
+		for name, brand := range map[string]string{"Drill": "Makita", "Saw": "makita", "Sander": "Makita Pro"} {
+			itm := mkItem(t, ws, name, "BRD-"+uuid.NewString()[:8])
+			b := brand
+			require.NoError(t, itm.Update(item.UpdateInput{Name: name, Brand: &b}))
+			require.NoError(t, repo.Save(ctx, itm))
+		}
+		mkItem(t, ws, "Unbranded", "BRD-"+uuid.NewString()[:8])
+
+		brand := "MAKITA"
+		items, total, err := repo.FindByWorkspaceFiltered(ctx, ws,
+			item.ListFilters{Brand: &brand, Sort: "name", SortDir: "asc"},
+			shared.Pagination{Page: 1, PageSize: 50})
+		require.NoError(t, err)
+		assert.Equal(t, 2, total)
+		require.Len(t, items, 2)
+		assert.Equal(t, "Drill", items[0].Name())
+		assert.Equal(t, "Saw", items[1].Name())
+	})
+
+	t.Run("HasInventory_TrueAndFalse", func(t *testing.T) {
+		ws := uuid.New()
+		testdb.CreateTestWorkspace(t, pool, ws)
+
+		loc, err := location.NewLocation(ws, "Shelf", nil, nil, uuid.NewString()[:8])
+		require.NoError(t, err)
+		require.NoError(t, NewLocationRepository(pool).Save(ctx, loc))
+		invRepo := NewInventoryRepository(pool)
+
+		stocked := mkItem(t, ws, "Stocked", "INV-S-"+uuid.NewString()[:6])
+		inv, err := inventory.NewInventory(ws, stocked.ID(), loc.ID(), nil, 3, inventory.ConditionNew, inventory.StatusAvailable, nil)
+		require.NoError(t, err)
+		require.NoError(t, invRepo.Save(ctx, inv))
+
+		// Archived stock does not count as having inventory.
+		shelved := mkItem(t, ws, "Shelved", "INV-A-"+uuid.NewString()[:6])
+		archivedInv, err := inventory.NewInventory(ws, shelved.ID(), loc.ID(), nil, 2, inventory.ConditionNew, inventory.StatusAvailable, nil)
+		require.NoError(t, err)
+		require.NoError(t, invRepo.Save(ctx, archivedInv))
+		_, err = pool.Exec(ctx, `UPDATE warehouse.inventory SET is_archived = true WHERE id = $1`, archivedInv.ID())
+		require.NoError(t, err)
+
+		mkItem(t, ws, "Empty", "INV-E-"+uuid.NewString()[:6])
+
+		yes, no := true, false
+		items, total, err := repo.FindByWorkspaceFiltered(ctx, ws,
+			item.ListFilters{HasInventory: &yes, Sort: "name", SortDir: "asc"},
+			shared.Pagination{Page: 1, PageSize: 50})
+		require.NoError(t, err)
+		assert.Equal(t, 1, total)
+		require.Len(t, items, 1)
+		assert.Equal(t, "Stocked", items[0].Name())
+
+		items, total, err = repo.FindByWorkspaceFiltered(ctx, ws,
+			item.ListFilters{HasInventory: &no, Sort: "name", SortDir: "asc"},
+			shared.Pagination{Page: 1, PageSize: 50})
+		require.NoError(t, err)
+		assert.Equal(t, 2, total)
+		require.Len(t, items, 2)
+		assert.Equal(t, "Empty", items[0].Name())
+		assert.Equal(t, "Shelved", items[1].Name())
+	})
+
 	t.Run("IgnoresOtherWorkspaces", func(t *testing.T) {
 		wsA := uuid.New()
 		wsB := uuid.New()
@@ -470,6 +642,105 @@ func TestItemRepository_FindByWorkspaceFiltered(t *testing.T) {
 	})
 }
 
+func TestItemService_DuplicateItem(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	repo := NewItemRepository(pool)
+	svc := item.NewService(repo, NewCategoryRepository(pool))
+	ctx := context.Background()
+
+	ws := uuid.New()
+	testdb.CreateTestWorkspace(t, pool, ws)
+
+	source, err := item.NewItem(ws, "Cordless Drill", "DUP-"+uuid.NewString()[:8], 2)
+	require.NoError(t, err)
+	source.SetShortCode(uuid.NewString()[:8])
+	require.NoError(t, repo.Save(ctx, source))
+
+	labelRepo := NewLabelRepository(pool)
+	var labelIDs []uuid.UUID
+	for _, name := range []string{"Power tools", "Garage"} {
+		lbl, err := label.NewLabel(ws, name+" "+uuid.NewString()[:4], nil, nil)
+		require.NoError(t, err)
+		require.NoError(t, labelRepo.Save(ctx, lbl))
+		require.NoError(t, repo.AttachLabel(ctx, source.ID(), lbl.ID()))
+		labelIDs = append(labelIDs, lbl.ID())
+	}
+
+	first, err := svc.DuplicateItem(ctx, ws, source.ID())
+	require.NoError(t, err)
+	second, err := svc.DuplicateItem(ctx, ws, source.ID())
+	require.NoError(t, err)
+
+	assert.Equal(t, "Cordless Drill (copy)", first.Name())
+	assert.Equal(t, source.SKU()+"-COPY", first.SKU())
+	assert.Equal(t, source.SKU()+"-COPY-2", second.SKU())
+	assert.NotEqual(t, source.ShortCode(), first.ShortCode())
+	assert.NotEqual(t, first.ShortCode(), second.ShortCode())
+
+	copied, err := repo.GetItemLabels(ctx, first.ID())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, labelIDs, copied)
+
+	// The source keeps its own labels.
+	original, err := repo.GetItemLabels(ctx, source.ID())
+	require.NoError(t, err)
+	assert.ElementsMatch(t, labelIDs, original)
+}
+
+func TestItemRepository_ReassignCategory(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	repo := NewItemRepository(pool)
+	ctx := context.Background()
+
+	ws, otherWS := uuid.New(), uuid.New()
+	testdb.CreateTestWorkspace(t, pool, ws)
+	testdb.CreateTestWorkspace(t, pool, otherWS)
+
+	cat, err := category.NewCategory(ws, "Tools", nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, NewCategoryRepository(pool).Save(ctx, cat))
+	catID := cat.ID()
+
+	newItem := func(workspaceID uuid.UUID, name string) *item.Item {
+		itm, err := item.NewItem(workspaceID, name, "RC-"+uuid.NewString()[:8], 0)
+		require.NoError(t, err)
+		itm.SetShortCode(uuid.NewString()[:8])
+		require.NoError(t, repo.Save(ctx, itm))
+		return itm
+	}
+	a, b := newItem(ws, "Hammer"), newItem(ws, "Saw")
+	foreign := newItem(otherWS, "Foreign")
+
+	updated, err := repo.ReassignCategory(ctx, ws, []uuid.UUID{a.ID(), b.ID(), foreign.ID()}, &catID)
+	require.NoError(t, err)
+	assert.Len(t, updated, 2, "items from another workspace must not be touched")
+
+	got, err := repo.FindByID(ctx, a.ID(), ws)
+	require.NoError(t, err)
+	require.NotNil(t, got.CategoryID())
+	assert.Equal(t, catID, *got.CategoryID())
+
+	other, err := repo.FindByID(ctx, foreign.ID(), otherWS)
+	require.NoError(t, err)
+	assert.Nil(t, other.CategoryID())
+
+	updated, err = repo.ReassignCategory(ctx, ws, []uuid.UUID{a.ID()}, nil)
+	require.NoError(t, err)
+	assert.Len(t, updated, 1)
+
+	got, err = repo.FindByID(ctx, a.ID(), ws)
+	require.NoError(t, err)
+	assert.Nil(t, got.CategoryID())
+}
+
 func TestItemRepository_SKUExists(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test")
@@ -532,3 +803,158 @@ func TestItemRepository_ShortCodeExists(t *testing.T) {
 func NewTestCategoryForWorkspace(workspaceID uuid.UUID, name string) (*category.Category, error) {
 	return category.NewCategory(workspaceID, name, nil, nil)
 }
+
+func TestItemRepository_FindSimilar(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	repo := NewItemRepository(pool)
+	ctx := context.Background()
+
+	ws := uuid.New()
+	testdb.CreateTestWorkspace(t, pool, ws)
+
+	mkItem := func(name, brand string) *item.Item {
+		itm, err := item.NewItem(ws, name, "SIM-"+uuid.NewString()[:8], 0)
+		require.NoError(t, err)
+		itm.SetShortCode(uuid.NewString()[:8])
+		require.NoError(t, itm.Update(item.UpdateInput{Name: name, Brand: &brand}))
+		require.NoError(t, repo.Save(ctx, itm))
+		return itm
+	}
+	drill := mkItem("Cordless Drill", "Makita")
+	drill2 := mkItem("Cordless Drill 18V", "Makita")
+	mkItem("Garden Hose", "Gardena")
+
+	pairs, err := repo.FindSimilar(ctx, ws, 0.5, 10)
+	require.NoError(t, err)
+	require.Len(t, pairs, 1)
+	assert.ElementsMatch(t, []uuid.UUID{drill.ID(), drill2.ID()}, []uuid.UUID{pairs[0].Item.ID, pairs[0].Match.ID})
+	assert.GreaterOrEqual(t, pairs[0].Similarity, 0.5)
+
+	none, err := repo.FindSimilar(ctx, ws, 0.99, 10)
+	require.NoError(t, err)
+	assert.Empty(t, none)
+}
+
+func TestItemService_MergeItems(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	repo := NewItemRepository(pool)
+	svc := item.NewService(repo, NewCategoryRepository(pool))
+	svc.SetTransactor(NewTxManager(pool))
+	ctx := context.Background()
+
+	ws := uuid.New()
+	testdb.CreateTestWorkspace(t, pool, ws)
+
+	exec := func(sql string, args ...any) {
+		t.Helper()
+		_, err := pool.Exec(ctx, sql, args...)
+		require.NoError(t, err)
+	}
+	mkItem := func(name string) *item.Item {
+		itm, err := item.NewItem(ws, name, "MRG-"+uuid.NewString()[:8], 0)
+		require.NoError(t, err)
+		itm.SetShortCode(uuid.NewString()[:8])
+		require.NoError(t, repo.Save(ctx, itm))
+		return itm
+	}
+	mkLabel := func() uuid.UUID {
+		lbl, err := label.NewLabel(ws, "Label "+uuid.NewString()[:8], nil, nil)
+		require.NoError(t, err)
+		require.NoError(t, NewLabelRepository(pool).Save(ctx, lbl))
+		return lbl.ID()
+	}
+	attach := func(itemID, labelID uuid.UUID) {
+		exec(`INSERT INTO warehouse.item_labels (item_id, label_id, workspace_id) VALUES ($1, $2, $3)`, itemID, labelID, ws)
+	}
+	addPhoto := func(itemID uuid.UUID, order int, primary bool) uuid.UUID {
+		id := uuid.New()
+		exec(`INSERT INTO warehouse.item_photos (id, item_id, workspace_id, filename, storage_path, thumbnail_path, file_size, mime_type, width, height, display_order, is_primary)
+			VALUES ($1, $2, $3, 'p.jpg', 'p.jpg', 't.jpg', 1, 'image/jpeg', 1, 1, $4, $5)`, id, itemID, ws, order, primary)
+		return id
+	}
+
+	locationID, borrowerID := uuid.New(), uuid.New()
+	exec(`INSERT INTO warehouse.locations (id, workspace_id, name, short_code) VALUES ($1, $2, 'Shelf', $3)`, locationID, ws, "L"+uuid.NewString()[:7])
+	exec(`INSERT INTO warehouse.borrowers (id, workspace_id, name) VALUES ($1, $2, 'Neighbour')`, borrowerID, ws)
+
+	keep, merge := mkItem("Cordless Drill"), mkItem("Cordless drill 18V")
+
+	inventoryID := uuid.New()
+	exec(`INSERT INTO warehouse.inventory (id, workspace_id, item_id, location_id, quantity, condition, status) VALUES ($1, $2, $3, $4, 1, 'NEW', 'ON_LOAN')`,
+		inventoryID, ws, merge.ID(), locationID)
+	loanID := uuid.New()
+	exec(`INSERT INTO warehouse.loans (id, workspace_id, inventory_id, borrower_id, quantity, loaned_at) VALUES ($1, $2, $3, $4, 1, NOW())`,
+		loanID, ws, inventoryID, borrowerID)
+
+	common, ownLabel := mkLabel(), mkLabel()
+	attach(keep.ID(), common)
+	attach(merge.ID(), common)
+	attach(merge.ID(), ownLabel)
+
+	keepPrimary := addPhoto(keep.ID(), 0, true)
+	movedPrimary := addPhoto(merge.ID(), 0, true)
+
+	result, err := svc.MergeItems(ctx, ws, keep.ID(), merge.ID(), merge.SKU())
+	require.NoError(t, err)
+	assert.Equal(t, &item.MergeResult{InventoryMoved: 1, LoansMoved: 1, LabelsMoved: 1, PhotosMoved: 1}, result)
+
+	t.Run("inventory is re-pointed", func(t *testing.T) {
+		var itemID uuid.UUID
+		require.NoError(t, pool.QueryRow(ctx, `SELECT item_id FROM warehouse.inventory WHERE id = $1`, inventoryID).Scan(&itemID))
+		assert.Equal(t, keep.ID(), itemID)
+	})
+
+	t.Run("loans follow their inventory", func(t *testing.T) {
+		var itemID uuid.UUID
+		require.NoError(t, pool.QueryRow(ctx, `SELECT inv.item_id FROM warehouse.loans l
+			JOIN warehouse.inventory inv ON inv.id = l.inventory_id WHERE l.id = $1`, loanID).Scan(&itemID))
+		assert.Equal(t, keep.ID(), itemID)
+	})
+
+	t.Run("labels are merged", func(t *testing.T) {
+		labels, err := repo.GetItemLabels(ctx, keep.ID())
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []uuid.UUID{common, ownLabel}, labels)
+	})
+
+	t.Run("photos are re-pointed behind the kept primary", func(t *testing.T) {
+		var itemID uuid.UUID
+		var order int
+		var primary bool
+		require.NoError(t, pool.QueryRow(ctx, `SELECT item_id, display_order, is_primary FROM warehouse.item_photos WHERE id = $1`, movedPrimary).
+			Scan(&itemID, &order, &primary))
+		assert.Equal(t, keep.ID(), itemID)
+		assert.Equal(t, 1, order)
+		assert.False(t, primary)
+
+		require.NoError(t, pool.QueryRow(ctx, `SELECT is_primary FROM warehouse.item_photos WHERE id = $1`, keepPrimary).Scan(&primary))
+		assert.True(t, primary)
+	})
+
+	t.Run("merged item is deleted and its SKU taken over", func(t *testing.T) {
+		_, err := repo.FindByID(ctx, merge.ID(), ws)
+		assert.ErrorIs(t, err, shared.ErrNotFound)
+
+		kept, err := repo.FindByID(ctx, keep.ID(), ws)
+		require.NoError(t, err)
+		assert.Equal(t, merge.SKU(), kept.SKU())
+	})
+
+	t.Run("rejects a SKU held by another item", func(t *testing.T) {
+		other, victim := mkItem("Hammer"), mkItem("Claw hammer")
+
+		_, err := svc.MergeItems(ctx, ws, keep.ID(), victim.ID(), other.SKU())
+		assert.ErrorIs(t, err, item.ErrSKUTaken)
+
+		_, err = repo.FindByID(ctx, victim.ID(), ws)
+		assert.NoError(t, err)
+	})
+}
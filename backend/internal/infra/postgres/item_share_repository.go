@@ -0,0 +1,108 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/itemshare"
+	"github.com/antti/home-warehouse/go-backend/internal/infra/queries"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+type ItemShareRepository struct {
+	pool    *pgxpool.Pool
+	queries *queries.Queries
+}
+
+func NewItemShareRepository(pool *pgxpool.Pool) *ItemShareRepository {
+	return &ItemShareRepository{
+		pool:    pool,
+		queries: queries.New(pool),
+	}
+}
+
+func (r *ItemShareRepository) Save(ctx context.Context, s *itemshare.Share) error {
+	var expiresAt pgtype.Timestamptz
+	if s.ExpiresAt() != nil {
+		expiresAt = pgtype.Timestamptz{Time: *s.ExpiresAt(), Valid: true}
+	}
+	_, err := r.queries.CreateItemShare(ctx, queries.CreateItemShareParams{
+		ID:            s.ID(),
+		WorkspaceID:   s.WorkspaceID(),
+		ItemID:        s.ItemID(),
+		TokenHash:     s.TokenHash(),
+		IncludePrices: s.IncludePrices(),
+		ExpiresAt:     expiresAt,
+		CreatedBy:     uuidPtrToPgtype(s.CreatedBy()),
+	})
+	return err
+}
+
+func (r *ItemShareRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*itemshare.Share, error) {
+	row, err := r.queries.GetItemShareByTokenHash(ctx, tokenHash)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, shared.ErrNotFound
+		}
+		return nil, err
+	}
+	return rowToItemShare(row), nil
+}
+
+func (r *ItemShareRepository) FindByItem(ctx context.Context, workspaceID, itemID uuid.UUID) ([]*itemshare.Share, error) {
+	rows, err := r.queries.ListItemShares(ctx, queries.ListItemSharesParams{
+		WorkspaceID: workspaceID,
+		ItemID:      itemID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	shares := make([]*itemshare.Share, len(rows))
+	for i, row := range rows {
+		shares[i] = rowToItemShare(row)
+	}
+	return shares, nil
+}
+
+func (r *ItemShareRepository) Revoke(ctx context.Context, workspaceID, itemID, id uuid.UUID) error {
+	n, err := r.queries.RevokeItemShare(ctx, queries.RevokeItemShareParams{
+		ID:          id,
+		WorkspaceID: workspaceID,
+		ItemID:      itemID,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return shared.ErrNotFound
+	}
+	return nil
+}
+
+func rowToItemShare(row queries.WarehouseItemShare) *itemshare.Share {
+	var expiresAt, revokedAt *time.Time
+	if row.ExpiresAt.Valid {
+		expiresAt = &row.ExpiresAt.Time
+	}
+	if row.RevokedAt.Valid {
+		revokedAt = &row.RevokedAt.Time
+	}
+	return itemshare.Reconstruct(
+		row.ID,
+		row.WorkspaceID,
+		row.ItemID,
+		row.TokenHash,
+		row.IncludePrices,
+		expiresAt,
+		revokedAt,
+		pgtypeToUUIDPtr(row.CreatedBy),
+		row.CreatedAt,
+	)
+}
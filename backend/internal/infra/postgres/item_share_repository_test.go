@@ -0,0 +1,78 @@
+//go:build integration
+// +build integration
+
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/itemshare"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+	"github.com/antti/home-warehouse/go-backend/tests/testdb"
+	"github.com/antti/home-warehouse/go-backend/tests/testfixtures"
+)
+
+func TestItemShareRepository_SaveAndFindByTokenHash(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	repo := NewItemShareRepository(pool)
+	ctx := context.Background()
+	itemID := testfixtures.CreateTestItem(t, pool, testfixtures.TestWorkspaceID)
+
+	expiresAt := time.Now().Add(24 * time.Hour)
+	share, token, err := itemshare.NewShare(testfixtures.TestWorkspaceID, itemID, true, &expiresAt, testfixtures.UUIDPtr(testfixtures.TestUserID))
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, share))
+
+	found, err := repo.FindByTokenHash(ctx, itemshare.HashToken(token))
+	require.NoError(t, err)
+	assert.Equal(t, share.ID(), found.ID())
+	assert.Equal(t, itemID, found.ItemID())
+	assert.True(t, found.IncludePrices())
+	require.NotNil(t, found.ExpiresAt())
+	assert.WithinDuration(t, expiresAt, *found.ExpiresAt(), time.Millisecond)
+	require.NotNil(t, found.CreatedBy())
+	assert.Equal(t, testfixtures.TestUserID, *found.CreatedBy())
+
+	_, err = repo.FindByTokenHash(ctx, itemshare.HashToken("unknown"))
+	assert.ErrorIs(t, err, shared.ErrNotFound)
+}
+
+func TestItemShareRepository_Revoke(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	repo := NewItemShareRepository(pool)
+	ctx := context.Background()
+	itemID := testfixtures.CreateTestItem(t, pool, testfixtures.TestWorkspaceID)
+
+	share, token, err := itemshare.NewShare(testfixtures.TestWorkspaceID, itemID, false, nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, repo.Save(ctx, share))
+
+	// Another workspace cannot revoke the share.
+	assert.ErrorIs(t, repo.Revoke(ctx, uuid.New(), itemID, share.ID()), shared.ErrNotFound)
+
+	require.NoError(t, repo.Revoke(ctx, testfixtures.TestWorkspaceID, itemID, share.ID()))
+	assert.ErrorIs(t, repo.Revoke(ctx, testfixtures.TestWorkspaceID, itemID, share.ID()), shared.ErrNotFound, "already revoked")
+
+	found, err := repo.FindByTokenHash(ctx, itemshare.HashToken(token))
+	require.NoError(t, err)
+	assert.False(t, found.IsActive(time.Now()))
+
+	shares, err := repo.FindByItem(ctx, testfixtures.TestWorkspaceID, itemID)
+	require.NoError(t, err)
+	require.Len(t, shares, 1)
+	assert.NotNil(t, shares[0].RevokedAt())
+}
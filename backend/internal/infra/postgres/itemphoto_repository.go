@@ -47,6 +47,8 @@ func (r *ItemPhotoRepository) Create(ctx context.Context, photo *itemphoto.ItemP
 		IsPrimary:     photo.IsPrimary,
 		Caption:       photo.Caption,
 		UploadedBy:    pgtype.UUID{Bytes: photo.UploadedBy, Valid: photo.UploadedBy != uuid.Nil},
+		ContentHash:   photo.ContentHash,
+		InventoryID:   uuidPtrToPgtype(photo.InventoryID),
 	})
 	if err != nil {
 		return nil, err
@@ -81,6 +83,37 @@ func (r *ItemPhotoRepository) CountByItem(ctx context.Context, itemID, workspace
 	})
 }
 
+func (r *ItemPhotoRepository) ListItemsWithoutPhotos(ctx context.Context, workspaceID uuid.UUID) ([]itemphoto.IncompleteItem, error) {
+	db := GetDBTX(ctx, r.pool)
+	q := queries.New(db)
+
+	rows, err := q.ListItemsWithoutPhotos(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	items := make([]itemphoto.IncompleteItem, len(rows))
+	for i, row := range rows {
+		items[i] = itemphoto.IncompleteItem{
+			ItemID:    row.ID,
+			SKU:       row.Sku,
+			Name:      row.Name,
+			ShortCode: row.ShortCode,
+		}
+	}
+	return items, nil
+}
+
+func (r *ItemPhotoRepository) MaxDisplayOrder(ctx context.Context, itemID, workspaceID uuid.UUID) (int32, error) {
+	db := GetDBTX(ctx, r.pool)
+	q := queries.New(db)
+
+	return q.GetMaxItemPhotoDisplayOrder(ctx, queries.GetMaxItemPhotoDisplayOrderParams{
+		ItemID:      itemID,
+		WorkspaceID: workspaceID,
+	})
+}
+
 func (r *ItemPhotoRepository) GetByItem(ctx context.Context, itemID, workspaceID uuid.UUID) ([]*itemphoto.ItemPhoto, error) {
 	db := GetDBTX(ctx, r.pool)
 	q := queries.New(db)
@@ -101,6 +134,51 @@ func (r *ItemPhotoRepository) GetByItem(ctx context.Context, itemID, workspaceID
 	return photos, nil
 }
 
+// GetByInventory returns the photos tagged with an inventory record,
+// workspace-scoped.
+func (r *ItemPhotoRepository) GetByInventory(ctx context.Context, inventoryID, workspaceID uuid.UUID) ([]*itemphoto.ItemPhoto, error) {
+	db := GetDBTX(ctx, r.pool)
+	q := queries.New(db)
+
+	rows, err := q.ListItemPhotosByInventory(ctx, queries.ListItemPhotosByInventoryParams{
+		InventoryID: pgtype.UUID{Bytes: inventoryID, Valid: true},
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	photos := make([]*itemphoto.ItemPhoto, 0, len(rows))
+	for _, row := range rows {
+		photos = append(photos, r.rowToItemPhoto(row))
+	}
+
+	return photos, nil
+}
+
+// ItemHasInventory reports whether the inventory record is a unit of the item.
+func (r *ItemPhotoRepository) ItemHasInventory(ctx context.Context, itemID, inventoryID, workspaceID uuid.UUID) (bool, error) {
+	db := GetDBTX(ctx, r.pool)
+	q := queries.New(db)
+
+	return q.ItemHasInventory(ctx, queries.ItemHasInventoryParams{
+		InventoryID: inventoryID,
+		ItemID:      itemID,
+		WorkspaceID: workspaceID,
+	})
+}
+
+// ItemExists reports whether the item exists in the workspace.
+func (r *ItemPhotoRepository) ItemExists(ctx context.Context, itemID, workspaceID uuid.UUID) (bool, error) {
+	db := GetDBTX(ctx, r.pool)
+	q := queries.New(db)
+
+	return q.ItemExistsInWorkspace(ctx, queries.ItemExistsInWorkspaceParams{
+		ID:          itemID,
+		WorkspaceID: workspaceID,
+	})
+}
+
 func (r *ItemPhotoRepository) GetPrimary(ctx context.Context, itemID, workspaceID uuid.UUID) (*itemphoto.ItemPhoto, error) {
 	db := GetDBTX(ctx, r.pool)
 	q := queries.New(db)
@@ -167,6 +245,20 @@ func (r *ItemPhotoRepository) UpdateDisplayOrder(ctx context.Context, photoID uu
 	})
 }
 
+// MoveToItem re-points a photo to itemID at the given display order, clearing
+// its primary flag and inventory tag. Uses the transaction in ctx (if any).
+func (r *ItemPhotoRepository) MoveToItem(ctx context.Context, photoID, workspaceID, itemID uuid.UUID, order int32) error {
+	db := GetDBTX(ctx, r.pool)
+	q := queries.New(db)
+
+	return q.MoveItemPhotoToItem(ctx, queries.MoveItemPhotoToItemParams{
+		ItemID:       itemID,
+		DisplayOrder: order,
+		ID:           photoID,
+		WorkspaceID:  workspaceID,
+	})
+}
+
 func (r *ItemPhotoRepository) SetPrimary(ctx context.Context, photoID uuid.UUID) error {
 	// First, get the photo to know which item it belongs to
 	photo, err := r.GetByID(ctx, photoID)
@@ -227,29 +319,33 @@ func (r *ItemPhotoRepository) DeleteByItem(ctx context.Context, itemID, workspac
 
 func (r *ItemPhotoRepository) rowToItemPhoto(row queries.WarehouseItemPhoto) *itemphoto.ItemPhoto {
 	photo := &itemphoto.ItemPhoto{
-		ID:                  row.ID,
-		ItemID:              row.ItemID,
-		WorkspaceID:         row.WorkspaceID,
-		Filename:            row.Filename,
-		StoragePath:         row.StoragePath,
-		ThumbnailPath:       row.ThumbnailPath,
-		FileSize:            row.FileSize,
-		MimeType:            row.MimeType,
-		Width:               row.Width,
-		Height:              row.Height,
-		DisplayOrder:        row.DisplayOrder,
-		IsPrimary:           row.IsPrimary,
-		Caption:             row.Caption,
-		UploadedBy:          uuid.UUID(row.UploadedBy.Bytes),
-		CreatedAt:           row.CreatedAt,
-		UpdatedAt:           row.UpdatedAt,
-		ThumbnailStatus:     itemphoto.ThumbnailStatus(row.ThumbnailStatus),
-		ThumbnailSmallPath:  row.ThumbnailSmallPath,
-		ThumbnailMediumPath: row.ThumbnailMediumPath,
-		ThumbnailLargePath:  row.ThumbnailLargePath,
-		ThumbnailAttempts:   row.ThumbnailAttempts,
-		ThumbnailError:      row.ThumbnailError,
-		PerceptualHash:      row.PerceptualHash,
+		ID:                     row.ID,
+		ItemID:                 row.ItemID,
+		WorkspaceID:            row.WorkspaceID,
+		Filename:               row.Filename,
+		StoragePath:            row.StoragePath,
+		ThumbnailPath:          row.ThumbnailPath,
+		FileSize:               row.FileSize,
+		MimeType:               row.MimeType,
+		Width:                  row.Width,
+		Height:                 row.Height,
+		DisplayOrder:           row.DisplayOrder,
+		IsPrimary:              row.IsPrimary,
+		Caption:                row.Caption,
+		UploadedBy:             uuid.UUID(row.UploadedBy.Bytes),
+		CreatedAt:              row.CreatedAt,
+		UpdatedAt:              row.UpdatedAt,
+		ThumbnailStatus:        itemphoto.ThumbnailStatus(row.ThumbnailStatus),
+		ThumbnailSmallPath:     row.ThumbnailSmallPath,
+		ThumbnailMediumPath:    row.ThumbnailMediumPath,
+		ThumbnailLargePath:     row.ThumbnailLargePath,
+		ThumbnailAttempts:      row.ThumbnailAttempts,
+		ThumbnailError:         row.ThumbnailError,
+		PerceptualHash:         row.PerceptualHash,
+		BlurHash:               row.Blurhash,
+		ContentHash:            row.ContentHash,
+		ThumbnailConfigVersion: row.ThumbnailConfigVersion,
+		InventoryID:            pgtypeToUUIDPtr(row.InventoryID),
 	}
 	return photo
 }
@@ -331,6 +427,25 @@ func (r *ItemPhotoRepository) GetItemPhotosWithHashes(ctx context.Context, itemI
 	return photos, nil
 }
 
+func (r *ItemPhotoRepository) FindByContentHash(ctx context.Context, itemID, workspaceID uuid.UUID, contentHash string) (*itemphoto.ItemPhoto, error) {
+	db := GetDBTX(ctx, r.pool)
+	q := queries.New(db)
+
+	row, err := q.GetItemPhotoByContentHash(ctx, queries.GetItemPhotoByContentHashParams{
+		ItemID:      itemID,
+		WorkspaceID: workspaceID,
+		ContentHash: &contentHash,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, shared.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return r.rowToItemPhoto(row), nil
+}
+
 func (r *ItemPhotoRepository) UpdatePerceptualHash(ctx context.Context, id uuid.UUID, hash int64) error {
 	db := GetDBTX(ctx, r.pool)
 	q := queries.New(db)
@@ -340,3 +455,24 @@ func (r *ItemPhotoRepository) UpdatePerceptualHash(ctx context.Context, id uuid.
 		PerceptualHash: &hash,
 	})
 }
+
+func (r *ItemPhotoRepository) UpdateBlurHash(ctx context.Context, id uuid.UUID, blurHash string) error {
+	db := GetDBTX(ctx, r.pool)
+	q := queries.New(db)
+
+	return q.UpdateBlurhash(ctx, queries.UpdateBlurhashParams{
+		ID:       id,
+		Blurhash: &blurHash,
+	})
+}
+
+func (r *ItemPhotoRepository) UpdateThumbnailStatus(ctx context.Context, id uuid.UUID, status itemphoto.ThumbnailStatus, errMsg *string) error {
+	db := GetDBTX(ctx, r.pool)
+	q := queries.New(db)
+
+	return q.UpdateThumbnailStatus(ctx, queries.UpdateThumbnailStatusParams{
+		ID:              id,
+		ThumbnailStatus: string(status),
+		ThumbnailError:  errMsg,
+	})
+}
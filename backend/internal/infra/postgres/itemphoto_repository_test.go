@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/itemphoto"
+	"github.com/antti/home-warehouse/go-backend/internal/infra/queries"
 	"github.com/antti/home-warehouse/go-backend/internal/shared"
 	"github.com/antti/home-warehouse/go-backend/tests/testdb"
 	"github.com/antti/home-warehouse/go-backend/tests/testfixtures"
@@ -279,6 +280,131 @@ func TestItemPhotoRepository_UpdateDisplayOrder(t *testing.T) {
 	})
 }
 
+func TestItemPhotoRepository_MoveToItem(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	txManager := NewTxManager(pool)
+	repo := NewItemPhotoRepository(pool, txManager)
+	ctx := context.Background()
+
+	t.Run("re-points photo to another item", func(t *testing.T) {
+		sourceID := testfixtures.CreateTestItem(t, pool, testfixtures.TestWorkspaceID)
+		targetID := testfixtures.CreateTestItem(t, pool, testfixtures.TestWorkspaceID)
+		photo := createTestItemPhoto(testfixtures.TestWorkspaceID, sourceID, testfixtures.TestUserID)
+		photo.IsPrimary = true
+		_, err := repo.Create(ctx, photo)
+		require.NoError(t, err)
+
+		err = repo.MoveToItem(ctx, photo.ID, testfixtures.TestWorkspaceID, targetID, 20)
+		require.NoError(t, err)
+
+		moved, err := repo.GetByID(ctx, photo.ID)
+		require.NoError(t, err)
+		assert.Equal(t, targetID, moved.ItemID)
+		assert.Equal(t, int32(20), moved.DisplayOrder)
+		assert.False(t, moved.IsPrimary)
+		assert.Nil(t, moved.InventoryID)
+	})
+
+	t.Run("ignores photo of another workspace", func(t *testing.T) {
+		sourceID := testfixtures.CreateTestItem(t, pool, testfixtures.TestWorkspaceID)
+		targetID := testfixtures.CreateTestItem(t, pool, testfixtures.TestWorkspaceID)
+		photo := createTestItemPhoto(testfixtures.TestWorkspaceID, sourceID, testfixtures.TestUserID)
+		_, err := repo.Create(ctx, photo)
+		require.NoError(t, err)
+
+		err = repo.MoveToItem(ctx, photo.ID, uuid.New(), targetID, 20)
+		require.NoError(t, err)
+
+		unchanged, err := repo.GetByID(ctx, photo.ID)
+		require.NoError(t, err)
+		assert.Equal(t, sourceID, unchanged.ItemID)
+	})
+}
+
+func TestItemPhotoRepository_ItemExists(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	txManager := NewTxManager(pool)
+	repo := NewItemPhotoRepository(pool, txManager)
+	ctx := context.Background()
+
+	itemID := testfixtures.CreateTestItem(t, pool, testfixtures.TestWorkspaceID)
+
+	exists, err := repo.ItemExists(ctx, itemID, testfixtures.TestWorkspaceID)
+	require.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = repo.ItemExists(ctx, itemID, uuid.New())
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
+
+func TestItemPhotoRepository_MaxDisplayOrder(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	txManager := NewTxManager(pool)
+	repo := NewItemPhotoRepository(pool, txManager)
+	ctx := context.Background()
+
+	t.Run("returns 0 for an item without photos", func(t *testing.T) {
+		itemID := testfixtures.CreateTestItem(t, pool, testfixtures.TestWorkspaceID)
+
+		order, err := repo.MaxDisplayOrder(ctx, itemID, testfixtures.TestWorkspaceID)
+		require.NoError(t, err)
+		assert.Equal(t, int32(0), order)
+	})
+
+	t.Run("returns the highest display order", func(t *testing.T) {
+		itemID := testfixtures.CreateTestItem(t, pool, testfixtures.TestWorkspaceID)
+		for _, order := range []int32{0, 2048, 1024} {
+			photo := createTestItemPhoto(testfixtures.TestWorkspaceID, itemID, testfixtures.TestUserID)
+			photo.DisplayOrder = order
+			_, err := repo.Create(ctx, photo)
+			require.NoError(t, err)
+		}
+
+		order, err := repo.MaxDisplayOrder(ctx, itemID, testfixtures.TestWorkspaceID)
+		require.NoError(t, err)
+		assert.Equal(t, int32(2048), order)
+	})
+}
+
+func TestItemPhotoRepository_ListItemsWithoutPhotos(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	txManager := NewTxManager(pool)
+	repo := NewItemPhotoRepository(pool, txManager)
+	ctx := context.Background()
+
+	withPhoto := testfixtures.CreateTestItem(t, pool, testfixtures.TestWorkspaceID)
+	_, err := repo.Create(ctx, createTestItemPhoto(testfixtures.TestWorkspaceID, withPhoto, testfixtures.TestUserID))
+	require.NoError(t, err)
+	withoutPhoto := testfixtures.CreateTestItem(t, pool, testfixtures.TestWorkspaceID)
+
+	items, err := repo.ListItemsWithoutPhotos(ctx, testfixtures.TestWorkspaceID)
+	require.NoError(t, err)
+
+	ids := make([]uuid.UUID, len(items))
+	for i, it := range items {
+		ids[i] = it.ItemID
+	}
+	assert.Contains(t, ids, withoutPhoto)
+	assert.NotContains(t, ids, withPhoto)
+}
+
 func TestItemPhotoRepository_SetPrimary(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test")
@@ -443,3 +569,157 @@ func TestItemPhotoRepository_CascadeDeleteOnItemDelete(t *testing.T) {
 		assert.True(t, shared.IsNotFound(err))
 	})
 }
+
+func TestItemPhotoRepository_FindByContentHash(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	txManager := NewTxManager(pool)
+	repo := NewItemPhotoRepository(pool, txManager)
+	ctx := context.Background()
+
+	t.Run("finds photo of the same item by content hash", func(t *testing.T) {
+		itemID := testfixtures.CreateTestItem(t, pool, testfixtures.TestWorkspaceID)
+		otherItemID := testfixtures.CreateTestItem(t, pool, testfixtures.TestWorkspaceID)
+		hash := "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+
+		photo := createTestItemPhoto(testfixtures.TestWorkspaceID, itemID, testfixtures.TestUserID)
+		photo.ContentHash = &hash
+		_, err := repo.Create(ctx, photo)
+		require.NoError(t, err)
+
+		found, err := repo.FindByContentHash(ctx, itemID, testfixtures.TestWorkspaceID, hash)
+		require.NoError(t, err)
+		assert.Equal(t, photo.ID, found.ID)
+		require.NotNil(t, found.ContentHash)
+		assert.Equal(t, hash, *found.ContentHash)
+
+		_, err = repo.FindByContentHash(ctx, otherItemID, testfixtures.TestWorkspaceID, hash)
+		assert.True(t, shared.IsNotFound(err))
+	})
+}
+
+func TestItemPhotoRepository_ThumbnailConfigVersion(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	txManager := NewTxManager(pool)
+	repo := NewItemPhotoRepository(pool, txManager)
+	ctx := context.Background()
+
+	t.Run("records the version thumbnails were generated with", func(t *testing.T) {
+		itemID := testfixtures.CreateTestItem(t, pool, testfixtures.TestWorkspaceID)
+		photo := createTestItemPhoto(testfixtures.TestWorkspaceID, itemID, testfixtures.TestUserID)
+		_, err := repo.Create(ctx, photo)
+		require.NoError(t, err)
+
+		created, err := repo.GetByID(ctx, photo.ID)
+		require.NoError(t, err)
+		assert.Nil(t, created.ThumbnailConfigVersion)
+
+		small, medium, large := "small.webp", "medium.webp", "large.webp"
+		version := "150-400-800-webp-q75"
+		_, err = queries.New(pool).UpdateThumbnailPaths(ctx, queries.UpdateThumbnailPathsParams{
+			ID:                     photo.ID,
+			ThumbnailSmallPath:     &small,
+			ThumbnailMediumPath:    &medium,
+			ThumbnailLargePath:     &large,
+			ThumbnailConfigVersion: &version,
+		})
+		require.NoError(t, err)
+
+		updated, err := repo.GetByID(ctx, photo.ID)
+		require.NoError(t, err)
+		require.NotNil(t, updated.ThumbnailConfigVersion)
+		assert.Equal(t, version, *updated.ThumbnailConfigVersion)
+		assert.Equal(t, itemphoto.ThumbnailStatusComplete, updated.ThumbnailStatus)
+	})
+}
+
+func TestItemPhotoRepository_UpdateThumbnailStatus(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	txManager := NewTxManager(pool)
+	repo := NewItemPhotoRepository(pool, txManager)
+	ctx := context.Background()
+
+	t.Run("sets status and error", func(t *testing.T) {
+		itemID := testfixtures.CreateTestItem(t, pool, testfixtures.TestWorkspaceID)
+		photo := createTestItemPhoto(testfixtures.TestWorkspaceID, itemID, testfixtures.TestUserID)
+		_, err := repo.Create(ctx, photo)
+		require.NoError(t, err)
+
+		msg := "decode failed"
+		require.NoError(t, repo.UpdateThumbnailStatus(ctx, photo.ID, itemphoto.ThumbnailStatusFailed, &msg))
+
+		updated, err := repo.GetByID(ctx, photo.ID)
+		require.NoError(t, err)
+		assert.Equal(t, itemphoto.ThumbnailStatusFailed, updated.ThumbnailStatus)
+		require.NotNil(t, updated.ThumbnailError)
+		assert.Equal(t, msg, *updated.ThumbnailError)
+
+		require.NoError(t, repo.UpdateThumbnailStatus(ctx, photo.ID, itemphoto.ThumbnailStatusProcessing, nil))
+
+		updated, err = repo.GetByID(ctx, photo.ID)
+		require.NoError(t, err)
+		assert.Equal(t, itemphoto.ThumbnailStatusProcessing, updated.ThumbnailStatus)
+		assert.Nil(t, updated.ThumbnailError)
+	})
+}
+
+func TestItemPhotoRepository_Inventory(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	txManager := NewTxManager(pool)
+	repo := NewItemPhotoRepository(pool, txManager)
+	ctx := context.Background()
+
+	t.Run("lists a unit's photos separately from the item's", func(t *testing.T) {
+		inventoryID, itemID, _ := createTestInventoryWithItem(t, pool, testfixtures.TestWorkspaceID, "Photo Unit")
+
+		general := createTestItemPhoto(testfixtures.TestWorkspaceID, itemID, testfixtures.TestUserID)
+		general.IsPrimary = true
+		_, err := repo.Create(ctx, general)
+		require.NoError(t, err)
+
+		unit := createTestItemPhoto(testfixtures.TestWorkspaceID, itemID, testfixtures.TestUserID)
+		unit.InventoryID = &inventoryID
+		unit.DisplayOrder = itemphoto.DisplayOrderGap
+		_, err = repo.Create(ctx, unit)
+		require.NoError(t, err)
+
+		byInventory, err := repo.GetByInventory(ctx, inventoryID, testfixtures.TestWorkspaceID)
+		require.NoError(t, err)
+		require.Len(t, byInventory, 1)
+		assert.Equal(t, unit.ID, byInventory[0].ID)
+		require.NotNil(t, byInventory[0].InventoryID)
+		assert.Equal(t, inventoryID, *byInventory[0].InventoryID)
+
+		byItem, err := repo.GetByItem(ctx, itemID, testfixtures.TestWorkspaceID)
+		require.NoError(t, err)
+		assert.Len(t, byItem, 2)
+	})
+
+	t.Run("checks the inventory record belongs to the item", func(t *testing.T) {
+		inventoryID, itemID, _ := createTestInventoryWithItem(t, pool, testfixtures.TestWorkspaceID, "Owned Unit")
+		otherItemID := testfixtures.CreateTestItem(t, pool, testfixtures.TestWorkspaceID)
+
+		ok, err := repo.ItemHasInventory(ctx, itemID, inventoryID, testfixtures.TestWorkspaceID)
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		ok, err = repo.ItemHasInventory(ctx, otherItemID, inventoryID, testfixtures.TestWorkspaceID)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+}
@@ -22,7 +22,7 @@ type PrimaryPhotoLookup interface {
 // PhotoURLGenerator mirrors item.PrimaryPhotoURLGenerator so the loan
 // decoration lookup emits the same thumbnail URL shape as item/itemphoto
 // handlers. isThumbnail must always be true for the loan decoration path.
-type PhotoURLGenerator func(workspaceID, itemID, photoID uuid.UUID, isThumbnail bool) string
+type PhotoURLGenerator func(ctx context.Context, workspaceID, itemID, photoID uuid.UUID, isThumbnail bool) string
 
 // LoanDecorationLookup is the postgres-backed implementation of
 // loan.DecorationLookup. It batches item, primary-photo, and borrower reads
@@ -85,7 +85,7 @@ func (l *LoanDecorationLookup) PrimaryPhotoThumbnailURLsByItemIDs(ctx context.Co
 		if photo == nil {
 			continue
 		}
-		url := l.photoURLGen(photo.WorkspaceID, photo.ItemID, photo.ID, true)
+		url := l.photoURLGen(ctx, photo.WorkspaceID, photo.ItemID, photo.ID, true)
 		if url != "" {
 			out[itemID] = url
 		}
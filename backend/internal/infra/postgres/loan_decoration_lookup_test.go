@@ -122,7 +122,7 @@ func TestLoanDecorationLookup_PrimaryPhotoThumbnailURLsByItemIDs(t *testing.T) {
 
 	pool := testdb.SetupTestDB(t)
 	photoRepo := NewItemPhotoRepository(pool, NewTxManager(pool))
-	urlGen := func(workspaceID, itemID, photoID uuid.UUID, isThumbnail bool) string {
+	urlGen := func(_ context.Context, workspaceID, itemID, photoID uuid.UUID, isThumbnail bool) string {
 		return fmt.Sprintf("https://example.com/%s/%s/%s?thumb=%v", workspaceID, itemID, photoID, isThumbnail)
 	}
 	ctx := context.Background()
@@ -152,7 +152,7 @@ func TestLoanDecorationLookup_PrimaryPhotoThumbnailURLsByItemIDs(t *testing.T) {
 		found, err := lookup.PrimaryPhotoThumbnailURLsByItemIDs(ctx, testfixtures.TestWorkspaceID, []uuid.UUID{itemID})
 		require.NoError(t, err)
 		require.Contains(t, found, itemID)
-		assert.Equal(t, urlGen(testfixtures.TestWorkspaceID, itemID, created.ID, true), found[itemID])
+		assert.Equal(t, urlGen(ctx, testfixtures.TestWorkspaceID, itemID, created.ID, true), found[itemID])
 	})
 
 	t.Run("returns an empty map when the photos dependency is nil", func(t *testing.T) {
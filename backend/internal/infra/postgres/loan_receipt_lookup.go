@@ -0,0 +1,59 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/loan"
+	"github.com/antti/home-warehouse/go-backend/internal/infra/queries"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// LoanReceiptLookup is the postgres-backed implementation of
+// loan.ReceiptLookup.
+type LoanReceiptLookup struct {
+	queries *queries.Queries
+}
+
+// NewLoanReceiptLookup creates a new LoanReceiptLookup.
+func NewLoanReceiptLookup(pool *pgxpool.Pool) *LoanReceiptLookup {
+	return &LoanReceiptLookup{queries: queries.New(pool)}
+}
+
+// LoanReceiptDetails reads the workspace, item and borrower fields printed on
+// a loan receipt in a single query.
+func (l *LoanReceiptLookup) LoanReceiptDetails(ctx context.Context, workspaceID, loanID uuid.UUID) (*loan.ReceiptDetails, error) {
+	row, err := l.queries.GetLoanReceiptDetails(ctx, queries.GetLoanReceiptDetailsParams{
+		ID:          loanID,
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, shared.ErrNotFound
+		}
+		return nil, err
+	}
+
+	var condition *string
+	if row.Condition.Valid {
+		c := string(row.Condition.WarehouseItemConditionEnum)
+		condition = &c
+	}
+
+	return &loan.ReceiptDetails{
+		WorkspaceName:    row.WorkspaceName,
+		ItemName:         row.ItemName,
+		ItemSKU:          row.Sku,
+		ItemBrand:        row.Brand,
+		ItemModel:        row.Model,
+		ItemSerialNumber: row.SerialNumber,
+		Condition:        condition,
+		BorrowerName:     row.BorrowerName,
+		BorrowerEmail:    row.BorrowerEmail,
+		BorrowerPhone:    row.BorrowerPhone,
+	}, nil
+}
@@ -0,0 +1,51 @@
+//go:build integration
+// +build integration
+
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/loan"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+	"github.com/antti/home-warehouse/go-backend/tests/testdb"
+	"github.com/antti/home-warehouse/go-backend/tests/testfixtures"
+)
+
+func TestLoanReceiptLookup_LoanReceiptDetails(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	lookup := NewLoanReceiptLookup(pool)
+	ctx := context.Background()
+
+	inventoryID, _, itemName := createTestInventoryWithItem(t, pool, testfixtures.TestWorkspaceID, "Receipt Drill")
+	borrowerID := createTestBorrowerInWorkspace(t, pool, testfixtures.TestWorkspaceID, "Receipt Borrower")
+	l, err := loan.NewLoan(testfixtures.TestWorkspaceID, inventoryID, borrowerID, 1, time.Now(), nil, nil)
+	require.NoError(t, err)
+	require.NoError(t, NewLoanRepository(pool).Save(ctx, l))
+
+	t.Run("joins workspace, item and borrower", func(t *testing.T) {
+		details, err := lookup.LoanReceiptDetails(ctx, testfixtures.TestWorkspaceID, l.ID())
+		require.NoError(t, err)
+		assert.NotEmpty(t, details.WorkspaceName)
+		assert.Equal(t, itemName, details.ItemName)
+		assert.NotEmpty(t, details.ItemSKU)
+		assert.Equal(t, "Receipt Borrower", details.BorrowerName)
+		require.NotNil(t, details.Condition)
+		assert.Equal(t, "NEW", *details.Condition)
+	})
+
+	t.Run("is scoped by workspace", func(t *testing.T) {
+		_, err := lookup.LoanReceiptDetails(ctx, uuid.New(), l.ID())
+		assert.ErrorIs(t, err, shared.ErrNotFound)
+	})
+}
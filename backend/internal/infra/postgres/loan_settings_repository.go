@@ -0,0 +1,56 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/loan"
+	"github.com/antti/home-warehouse/go-backend/internal/infra/queries"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// LoanSettingsRepository persists per-workspace loan settings
+// (warehouse.loan_settings).
+type LoanSettingsRepository struct {
+	queries *queries.Queries
+}
+
+func NewLoanSettingsRepository(pool *pgxpool.Pool) *LoanSettingsRepository {
+	return &LoanSettingsRepository{
+		queries: queries.New(pool),
+	}
+}
+
+func (r *LoanSettingsRepository) Get(ctx context.Context, workspaceID uuid.UUID) (*loan.Settings, error) {
+	row, err := r.queries.GetLoanSettings(ctx, workspaceID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, shared.ErrNotFound
+		}
+		return nil, err
+	}
+	return rowToLoanSettings(row), nil
+}
+
+func (r *LoanSettingsRepository) Upsert(ctx context.Context, workspaceID uuid.UUID, settings loan.Settings) (*loan.Settings, error) {
+	row, err := r.queries.UpsertLoanSettings(ctx, queries.UpsertLoanSettingsParams{
+		WorkspaceID:      workspaceID,
+		OverdueGraceDays: int32(settings.OverdueGraceDays),
+		DefaultLoanDays:  int32(settings.DefaultLoanDays),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rowToLoanSettings(row), nil
+}
+
+func rowToLoanSettings(row queries.WarehouseLoanSetting) *loan.Settings {
+	return &loan.Settings{
+		OverdueGraceDays: int(row.OverdueGraceDays),
+		DefaultLoanDays:  int(row.DefaultLoanDays),
+	}
+}
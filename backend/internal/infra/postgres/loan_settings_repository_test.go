@@ -0,0 +1,93 @@
+//go:build integration
+// +build integration
+
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/loan"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+	"github.com/antti/home-warehouse/go-backend/tests/testdb"
+	"github.com/antti/home-warehouse/go-backend/tests/testfixtures"
+)
+
+func TestLoanSettingsRepository_GetAndUpsert(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	repo := NewLoanSettingsRepository(pool)
+	ctx := context.Background()
+
+	_, err := repo.Get(ctx, testfixtures.TestWorkspaceID)
+	assert.ErrorIs(t, err, shared.ErrNotFound)
+
+	saved, err := repo.Upsert(ctx, testfixtures.TestWorkspaceID, loan.Settings{OverdueGraceDays: 3, DefaultLoanDays: loan.DefaultLoanDays})
+	require.NoError(t, err)
+	assert.Equal(t, 3, saved.OverdueGraceDays)
+	assert.Equal(t, loan.DefaultLoanDays, saved.DefaultLoanDays)
+
+	saved, err = repo.Upsert(ctx, testfixtures.TestWorkspaceID, loan.Settings{OverdueGraceDays: 5, DefaultLoanDays: 30})
+	require.NoError(t, err)
+	assert.Equal(t, 5, saved.OverdueGraceDays)
+
+	got, err := repo.Get(ctx, testfixtures.TestWorkspaceID)
+	require.NoError(t, err)
+	assert.Equal(t, 5, got.OverdueGraceDays)
+	assert.Equal(t, 30, got.DefaultLoanDays)
+}
+
+func TestLoanRepository_FindOverdueLoans_GracePeriod(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	loanRepo := NewLoanRepository(pool)
+	ctx := context.Background()
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	newDueLoan := func(daysAgo int) *loan.Loan {
+		inventoryID, _, _ := createTestInventoryWithItem(t, pool, testfixtures.TestWorkspaceID, "Grace Item")
+		borrowerID := createTestBorrowerInWorkspace(t, pool, testfixtures.TestWorkspaceID, "Grace Borrower")
+		due := today.AddDate(0, 0, -daysAgo)
+		l, err := loan.NewLoan(testfixtures.TestWorkspaceID, inventoryID, borrowerID, 1, due.AddDate(0, 0, -7), &due, nil)
+		require.NoError(t, err)
+		require.NoError(t, loanRepo.Save(ctx, l))
+		return l
+	}
+	withinGrace := newDueLoan(1)
+	pastGrace := newDueLoan(3)
+
+	overdueIDs := func() map[string]bool {
+		loans, err := loanRepo.FindOverdueLoans(ctx, testfixtures.TestWorkspaceID)
+		require.NoError(t, err)
+		ids := map[string]bool{}
+		for _, l := range loans {
+			ids[l.ID().String()] = true
+		}
+		return ids
+	}
+
+	t.Run("without settings anything past due is overdue", func(t *testing.T) {
+		ids := overdueIDs()
+		assert.True(t, ids[withinGrace.ID().String()])
+		assert.True(t, ids[pastGrace.ID().String()])
+	})
+
+	t.Run("grace period hides recently due loans", func(t *testing.T) {
+		_, err := NewLoanSettingsRepository(pool).Upsert(ctx, testfixtures.TestWorkspaceID, loan.Settings{OverdueGraceDays: 2, DefaultLoanDays: loan.DefaultLoanDays})
+		require.NoError(t, err)
+
+		ids := overdueIDs()
+		assert.False(t, ids[withinGrace.ID().String()])
+		assert.True(t, ids[pastGrace.ID().String()])
+	})
+}
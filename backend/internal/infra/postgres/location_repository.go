@@ -191,3 +191,42 @@ func (r *LocationRepository) rowToLocation(row queries.WarehouseLocation) *locat
 		row.UpdatedAt.Time,
 	)
 }
+
+// MoveInventory records a movement for, then relocates, every inventory row
+// at fromLocationID. Uses the transaction in ctx (if any) so the two
+// statements commit together under TxManager.WithTx.
+func (r *LocationRepository) MoveInventory(ctx context.Context, workspaceID, fromLocationID, toLocationID uuid.UUID, keepContainers bool) (int, error) {
+	q := queries.New(GetDBTX(ctx, r.pool))
+
+	if err := q.RecordLocationEvacuationMovements(ctx, queries.RecordLocationEvacuationMovementsParams{
+		ToLocationID:   toLocationID,
+		KeepContainers: keepContainers,
+		WorkspaceID:    workspaceID,
+		FromLocationID: fromLocationID,
+	}); err != nil {
+		return 0, err
+	}
+
+	moved, err := q.EvacuateLocationInventory(ctx, queries.EvacuateLocationInventoryParams{
+		ToLocationID:   toLocationID,
+		KeepContainers: keepContainers,
+		WorkspaceID:    workspaceID,
+		FromLocationID: fromLocationID,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(moved), nil
+}
+
+func (r *LocationRepository) MoveContainers(ctx context.Context, workspaceID, fromLocationID, toLocationID uuid.UUID) (int, error) {
+	moved, err := queries.New(GetDBTX(ctx, r.pool)).EvacuateLocationContainers(ctx, queries.EvacuateLocationContainersParams{
+		ToLocationID:   toLocationID,
+		WorkspaceID:    workspaceID,
+		FromLocationID: fromLocationID,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return int(moved), nil
+}
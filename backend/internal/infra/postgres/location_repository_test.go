@@ -11,6 +11,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/container"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/inventory"
 	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/location"
 	"github.com/antti/home-warehouse/go-backend/internal/shared"
 	"github.com/antti/home-warehouse/go-backend/tests/testdb"
@@ -292,3 +294,89 @@ func TestLocationRepository_ShortCodeExists(t *testing.T) {
 		assert.False(t, exists)
 	})
 }
+
+func TestLocationRepository_MoveInventory(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	repo := NewLocationRepository(pool)
+	invRepo := NewInventoryRepository(pool)
+	itemRepo := NewItemRepository(pool)
+	containerRepo := NewContainerRepository(pool)
+	movRepo := NewMovementRepository(pool)
+	ctx := context.Background()
+
+	newTarget := func(t *testing.T) *location.Location {
+		t.Helper()
+		loc, err := location.NewLocation(testfixtures.TestWorkspaceID, "Target "+uuid.NewString()[:4], nil, nil, uuid.NewString()[:8])
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(ctx, loc))
+		return loc
+	}
+
+	t.Run("moves inventory and records movements", func(t *testing.T) {
+		inv, source := createTestInventoryForMovement(t, invRepo, itemRepo, repo, ctx)
+		target := newTarget(t)
+
+		moved, err := repo.MoveInventory(ctx, testfixtures.TestWorkspaceID, source.ID(), target.ID(), false)
+		require.NoError(t, err)
+		assert.Equal(t, 1, moved)
+
+		found, err := invRepo.FindByID(ctx, inv.ID(), testfixtures.TestWorkspaceID)
+		require.NoError(t, err)
+		assert.Equal(t, target.ID(), found.LocationID())
+
+		movements, err := movRepo.FindByInventory(ctx, inv.ID(), testfixtures.TestWorkspaceID, shared.Pagination{Page: 1, PageSize: 10})
+		require.NoError(t, err)
+		require.Len(t, movements, 1)
+		require.NotNil(t, movements[0].ToLocationID())
+		assert.Equal(t, target.ID(), *movements[0].ToLocationID())
+
+		// The source no longer blocks deletion.
+		require.NoError(t, repo.Delete(ctx, source.ID(), testfixtures.TestWorkspaceID))
+	})
+
+	t.Run("clears container unless containers move too", func(t *testing.T) {
+		_, source := createTestInventoryForMovement(t, invRepo, itemRepo, repo, ctx)
+		target := newTarget(t)
+
+		box, err := container.NewContainer(testfixtures.TestWorkspaceID, source.ID(), "Box "+uuid.NewString()[:4], nil, nil, uuid.NewString()[:8])
+		require.NoError(t, err)
+		require.NoError(t, containerRepo.Save(ctx, box))
+
+		boxID := box.ID()
+		itm := createTestItem(t, itemRepo, ctx, "Boxed "+uuid.NewString()[:4])
+		boxed, err := inventory.NewInventory(testfixtures.TestWorkspaceID, itm.ID(), source.ID(), &boxID, 1, inventory.ConditionNew, inventory.StatusAvailable, nil)
+		require.NoError(t, err)
+		require.NoError(t, invRepo.Save(ctx, boxed))
+
+		moved, err := repo.MoveInventory(ctx, testfixtures.TestWorkspaceID, source.ID(), target.ID(), true)
+		require.NoError(t, err)
+		assert.Equal(t, 2, moved)
+
+		movedContainers, err := repo.MoveContainers(ctx, testfixtures.TestWorkspaceID, source.ID(), target.ID())
+		require.NoError(t, err)
+		assert.Equal(t, 1, movedContainers)
+
+		found, err := invRepo.FindByID(ctx, boxed.ID(), testfixtures.TestWorkspaceID)
+		require.NoError(t, err)
+		require.NotNil(t, found.ContainerID())
+		assert.Equal(t, boxID, *found.ContainerID())
+
+		foundBox, err := containerRepo.FindByID(ctx, boxID, testfixtures.TestWorkspaceID)
+		require.NoError(t, err)
+		assert.Equal(t, target.ID(), foundBox.LocationID())
+
+		// Moving on without containers takes the inventory out of the box.
+		other := newTarget(t)
+		_, err = repo.MoveInventory(ctx, testfixtures.TestWorkspaceID, target.ID(), other.ID(), false)
+		require.NoError(t, err)
+
+		found, err = invRepo.FindByID(ctx, boxed.ID(), testfixtures.TestWorkspaceID)
+		require.NoError(t, err)
+		assert.Equal(t, other.ID(), found.LocationID())
+		assert.Nil(t, found.ContainerID())
+	})
+}
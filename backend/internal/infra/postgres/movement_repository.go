@@ -13,15 +13,18 @@ import (
 )
 
 type MovementRepository struct {
-	pool    *pgxpool.Pool
-	queries *queries.Queries
+	pool *pgxpool.Pool
 }
 
 func NewMovementRepository(pool *pgxpool.Pool) *MovementRepository {
-	return &MovementRepository{
-		pool:    pool,
-		queries: queries.New(pool),
-	}
+	return &MovementRepository{pool: pool}
+}
+
+// q returns Queries bound to the active transaction in ctx (if any) or the
+// pool, so a movement is recorded in the same transaction as the inventory
+// change it describes.
+func (r *MovementRepository) q(ctx context.Context) *queries.Queries {
+	return queries.New(GetDBTX(ctx, r.pool))
 }
 
 func (r *MovementRepository) Save(ctx context.Context, m *movement.InventoryMovement) error {
@@ -43,7 +46,7 @@ func (r *MovementRepository) Save(ctx context.Context, m *movement.InventoryMove
 		movedBy = pgtype.UUID{Bytes: *m.MovedBy(), Valid: true}
 	}
 
-	_, err := r.queries.CreateMovement(ctx, queries.CreateMovementParams{
+	_, err := r.q(ctx).CreateMovement(ctx, queries.CreateMovementParams{
 		ID:              m.ID(),
 		WorkspaceID:     m.WorkspaceID(),
 		InventoryID:     m.InventoryID(),
@@ -59,7 +62,7 @@ func (r *MovementRepository) Save(ctx context.Context, m *movement.InventoryMove
 }
 
 func (r *MovementRepository) FindByID(ctx context.Context, id, workspaceID uuid.UUID) (*movement.InventoryMovement, error) {
-	row, err := r.queries.GetMovement(ctx, queries.GetMovementParams{
+	row, err := r.q(ctx).GetMovement(ctx, queries.GetMovementParams{
 		ID:          id,
 		WorkspaceID: workspaceID,
 	})
@@ -70,7 +73,7 @@ func (r *MovementRepository) FindByID(ctx context.Context, id, workspaceID uuid.
 }
 
 func (r *MovementRepository) FindByInventory(ctx context.Context, inventoryID, workspaceID uuid.UUID, pagination shared.Pagination) ([]*movement.InventoryMovement, error) {
-	rows, err := r.queries.ListMovementsByInventory(ctx, queries.ListMovementsByInventoryParams{
+	rows, err := r.q(ctx).ListMovementsByInventory(ctx, queries.ListMovementsByInventoryParams{
 		InventoryID: inventoryID,
 		WorkspaceID: workspaceID,
 		Limit:       int32(pagination.Limit()),
@@ -88,7 +91,7 @@ func (r *MovementRepository) FindByInventory(ctx context.Context, inventoryID, w
 }
 
 func (r *MovementRepository) FindByLocation(ctx context.Context, locationID, workspaceID uuid.UUID, pagination shared.Pagination) ([]*movement.InventoryMovement, error) {
-	rows, err := r.queries.ListMovementsByLocation(ctx, queries.ListMovementsByLocationParams{
+	rows, err := r.q(ctx).ListMovementsByLocation(ctx, queries.ListMovementsByLocationParams{
 		WorkspaceID:    workspaceID,
 		FromLocationID: pgtype.UUID{Bytes: locationID, Valid: true},
 		Limit:          int32(pagination.Limit()),
@@ -106,7 +109,7 @@ func (r *MovementRepository) FindByLocation(ctx context.Context, locationID, wor
 }
 
 func (r *MovementRepository) FindByWorkspace(ctx context.Context, workspaceID uuid.UUID, pagination shared.Pagination) ([]*movement.InventoryMovement, error) {
-	rows, err := r.queries.ListMovementsByWorkspace(ctx, queries.ListMovementsByWorkspaceParams{
+	rows, err := r.q(ctx).ListMovementsByWorkspace(ctx, queries.ListMovementsByWorkspaceParams{
 		WorkspaceID: workspaceID,
 		Limit:       int32(pagination.Limit()),
 		Offset:      int32(pagination.Offset()),
@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/auth/user"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// PasswordResetRepository implements user.PasswordResetRepository using
+// PostgreSQL (auth.password_reset_tokens).
+type PasswordResetRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewPasswordResetRepository creates a new PasswordResetRepository.
+func NewPasswordResetRepository(pool *pgxpool.Pool) *PasswordResetRepository {
+	return &PasswordResetRepository{pool: pool}
+}
+
+// Create stores a new token hash for the user.
+func (r *PasswordResetRepository) Create(ctx context.Context, userID uuid.UUID, tokenHash string, expiresAt time.Time) error {
+	query := `
+		INSERT INTO auth.password_reset_tokens (user_id, token_hash, expires_at)
+		VALUES ($1, $2, $3)
+	`
+	_, err := r.pool.Exec(ctx, query, userID, tokenHash, expiresAt)
+	return err
+}
+
+// FindByHash returns the token with the given hash.
+func (r *PasswordResetRepository) FindByHash(ctx context.Context, tokenHash string) (*user.PasswordResetToken, error) {
+	query := `
+		SELECT id, user_id, expires_at, used_at
+		FROM auth.password_reset_tokens
+		WHERE token_hash = $1
+	`
+
+	var t user.PasswordResetToken
+	err := r.pool.QueryRow(ctx, query, tokenHash).Scan(&t.ID, &t.UserID, &t.ExpiresAt, &t.UsedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, shared.ErrNotFound
+		}
+		return nil, err
+	}
+	return &t, nil
+}
+
+// Consume marks the token used in a single conditional UPDATE, so of two
+// concurrent callers only one sees true.
+func (r *PasswordResetRepository) Consume(ctx context.Context, id uuid.UUID) (bool, error) {
+	query := `
+		UPDATE auth.password_reset_tokens
+		SET used_at = now()
+		WHERE id = $1 AND used_at IS NULL AND expires_at > now()
+	`
+	tag, err := r.pool.Exec(ctx, query, id)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// InvalidateForUser marks every outstanding token of the user used.
+func (r *PasswordResetRepository) InvalidateForUser(ctx context.Context, userID uuid.UUID) error {
+	query := `
+		UPDATE auth.password_reset_tokens
+		SET used_at = now()
+		WHERE user_id = $1 AND used_at IS NULL
+	`
+	_, err := r.pool.Exec(ctx, query, userID)
+	return err
+}
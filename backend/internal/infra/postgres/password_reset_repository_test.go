@@ -0,0 +1,78 @@
+//go:build integration
+// +build integration
+
+package postgres
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+	"github.com/antti/home-warehouse/go-backend/tests/testdb"
+	"github.com/antti/home-warehouse/go-backend/tests/testfixtures"
+)
+
+func TestPasswordResetRepository(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	repo := NewPasswordResetRepository(pool)
+	ctx := context.Background()
+
+	t.Run("token can be consumed only once", func(t *testing.T) {
+		hash := "hash-" + uuid.NewString()
+		require.NoError(t, repo.Create(ctx, testfixtures.TestUserID, hash, time.Now().Add(time.Hour)))
+
+		token, err := repo.FindByHash(ctx, hash)
+		require.NoError(t, err)
+		assert.Equal(t, testfixtures.TestUserID, token.UserID)
+		assert.Nil(t, token.UsedAt)
+
+		consumed, err := repo.Consume(ctx, token.ID)
+		require.NoError(t, err)
+		assert.True(t, consumed)
+
+		consumed, err = repo.Consume(ctx, token.ID)
+		require.NoError(t, err)
+		assert.False(t, consumed)
+
+		token, err = repo.FindByHash(ctx, hash)
+		require.NoError(t, err)
+		assert.NotNil(t, token.UsedAt)
+	})
+
+	t.Run("expired token cannot be consumed", func(t *testing.T) {
+		hash := "hash-" + uuid.NewString()
+		require.NoError(t, repo.Create(ctx, testfixtures.TestUserID, hash, time.Now().Add(-time.Minute)))
+
+		token, err := repo.FindByHash(ctx, hash)
+		require.NoError(t, err)
+
+		consumed, err := repo.Consume(ctx, token.ID)
+		require.NoError(t, err)
+		assert.False(t, consumed)
+	})
+
+	t.Run("invalidates outstanding tokens for a user", func(t *testing.T) {
+		hash := "hash-" + uuid.NewString()
+		require.NoError(t, repo.Create(ctx, testfixtures.TestUserID, hash, time.Now().Add(time.Hour)))
+
+		require.NoError(t, repo.InvalidateForUser(ctx, testfixtures.TestUserID))
+
+		token, err := repo.FindByHash(ctx, hash)
+		require.NoError(t, err)
+		assert.NotNil(t, token.UsedAt)
+	})
+
+	t.Run("returns not found for unknown hash", func(t *testing.T) {
+		_, err := repo.FindByHash(ctx, "missing-"+uuid.NewString())
+		assert.ErrorIs(t, err, shared.ErrNotFound)
+	})
+}
@@ -103,25 +103,59 @@ func (r *PendingChangeRepository) FindByID(ctx context.Context, id, workspaceID
 	return r.rowToPendingChange(row), nil
 }
 
-// FindByWorkspace retrieves all pending changes for a workspace, optionally filtered by status.
-// If status is nil, returns changes in all statuses (pending, approved, rejected).
-// If status is provided, returns only changes matching that status.
+// FindByWorkspace retrieves a page of a workspace's pending changes matching filters,
+// newest first, plus the total match count for the pager. A nil status returns
+// changes in all statuses (pending, approved, rejected).
 // Used to populate the approval queue for admins.
-func (r *PendingChangeRepository) FindByWorkspace(ctx context.Context, workspaceID uuid.UUID, status *pendingchange.Status) ([]*pendingchange.PendingChange, error) {
+func (r *PendingChangeRepository) FindByWorkspace(ctx context.Context, workspaceID uuid.UUID, filters pendingchange.ListFilters, pagination shared.Pagination) ([]*pendingchange.PendingChange, int, error) {
 	var statusEnum queries.NullWarehousePendingChangeStatusEnum
-	if status != nil {
+	if filters.Status != nil {
 		statusEnum = queries.NullWarehousePendingChangeStatusEnum{
-			WarehousePendingChangeStatusEnum: statusToSqlc(*status),
+			WarehousePendingChangeStatusEnum: statusToSqlc(*filters.Status),
+			Valid:                            true,
+		}
+	}
+
+	var actionEnum queries.NullWarehousePendingChangeActionEnum
+	if filters.Action != nil {
+		actionEnum = queries.NullWarehousePendingChangeActionEnum{
+			WarehousePendingChangeActionEnum: actionToSqlc(*filters.Action),
 			Valid:                            true,
 		}
 	}
 
+	var entityType *string
+	if filters.EntityType != "" {
+		entityType = &filters.EntityType
+	}
+
+	var requesterID pgtype.UUID
+	if filters.RequesterID != nil {
+		requesterID = pgtype.UUID{Bytes: *filters.RequesterID, Valid: true}
+	}
+
 	rows, err := r.queries.ListPendingChangesByWorkspace(ctx, queries.ListPendingChangesByWorkspaceParams{
 		WorkspaceID: workspaceID,
+		Limit:       int32(pagination.Limit()),
+		Offset:      int32(pagination.Offset()),
 		Status:      statusEnum,
+		EntityType:  entityType,
+		Action:      actionEnum,
+		RequesterID: requesterID,
 	})
 	if err != nil {
-		return nil, err
+		return nil, 0, err
+	}
+
+	total, err := r.queries.CountPendingChangesByWorkspaceFiltered(ctx, queries.CountPendingChangesByWorkspaceFilteredParams{
+		WorkspaceID: workspaceID,
+		Status:      statusEnum,
+		EntityType:  entityType,
+		Action:      actionEnum,
+		RequesterID: requesterID,
+	})
+	if err != nil {
+		return nil, 0, err
 	}
 
 	changes := make([]*pendingchange.PendingChange, 0, len(rows))
@@ -129,7 +163,7 @@ func (r *PendingChangeRepository) FindByWorkspace(ctx context.Context, workspace
 		changes = append(changes, r.rowToPendingChange(row))
 	}
 
-	return changes, nil
+	return changes, int(total), nil
 }
 
 // FindByRequester retrieves all pending changes submitted by a specific user, optionally filtered by status.
@@ -161,6 +195,24 @@ func (r *PendingChangeRepository) FindByRequester(ctx context.Context, requester
 	return changes, nil
 }
 
+// CountByRequesterStatus counts a requester's changes in a workspace per status
+// in one grouped query. Statuses with no changes are absent from the map.
+func (r *PendingChangeRepository) CountByRequesterStatus(ctx context.Context, workspaceID, requesterID uuid.UUID) (map[pendingchange.Status]int, error) {
+	rows, err := r.queries.CountPendingChangesByRequesterStatus(ctx, queries.CountPendingChangesByRequesterStatusParams{
+		WorkspaceID: workspaceID,
+		RequesterID: requesterID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	counts := make(map[pendingchange.Status]int, len(rows))
+	for _, row := range rows {
+		counts[statusFromSqlc(row.Status)] = int(row.Count)
+	}
+	return counts, nil
+}
+
 func (r *PendingChangeRepository) FindByEntity(ctx context.Context, workspaceID uuid.UUID, entityType string, entityID uuid.UUID) ([]*pendingchange.PendingChange, error) {
 	rows, err := r.queries.ListPendingChangesByEntity(ctx, queries.ListPendingChangesByEntityParams{
 		WorkspaceID: workspaceID,
@@ -274,3 +326,27 @@ func statusFromSqlc(status queries.WarehousePendingChangeStatusEnum) pendingchan
 		return pendingchange.StatusPending
 	}
 }
+
+// ReviewerStats aggregates per-reviewer decisions for changes reviewed in
+// [from, to) in a single grouped query.
+func (r *PendingChangeRepository) ReviewerStats(ctx context.Context, workspaceID uuid.UUID, from, to time.Time) ([]pendingchange.ReviewerStat, error) {
+	rows, err := r.queries.ReviewerStats(ctx, queries.ReviewerStatsParams{
+		WorkspaceID: workspaceID,
+		FromTime:    from,
+		ToTime:      to,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	stats := make([]pendingchange.ReviewerStat, 0, len(rows))
+	for _, row := range rows {
+		stats = append(stats, pendingchange.ReviewerStat{
+			ReviewerID:      row.ReviewerID,
+			Approved:        int(row.ApprovedCount),
+			Rejected:        int(row.RejectedCount),
+			AvgTimeToReview: time.Duration(row.AvgReviewSeconds * float64(time.Second)),
+		})
+	}
+	return stats, nil
+}
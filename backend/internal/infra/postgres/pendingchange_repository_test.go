@@ -7,6 +7,7 @@ import (
 	"context"
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
@@ -143,9 +144,10 @@ func TestPendingChangeRepository_FindByWorkspace(t *testing.T) {
 			require.NoError(t, repo.Save(ctx, change))
 		}
 
-		changes, err := repo.FindByWorkspace(ctx, workspace, nil)
+		changes, total, err := repo.FindByWorkspace(ctx, workspace, pendingchange.ListFilters{}, shared.DefaultPagination())
 		require.NoError(t, err)
 		assert.GreaterOrEqual(t, len(changes), 3)
+		assert.Equal(t, len(changes), total)
 	})
 
 	t.Run("filters by status", func(t *testing.T) {
@@ -181,7 +183,7 @@ func TestPendingChangeRepository_FindByWorkspace(t *testing.T) {
 
 		// Filter by pending status
 		status := pendingchange.StatusPending
-		pendingChanges, err := repo.FindByWorkspace(ctx, workspace, &status)
+		pendingChanges, _, err := repo.FindByWorkspace(ctx, workspace, pendingchange.ListFilters{Status: &status}, shared.DefaultPagination())
 		require.NoError(t, err)
 		assert.GreaterOrEqual(t, len(pendingChanges), 1)
 		for _, c := range pendingChanges {
@@ -193,9 +195,69 @@ func TestPendingChangeRepository_FindByWorkspace(t *testing.T) {
 		workspace := uuid.New()
 		testdb.CreateTestWorkspace(t, pool, workspace)
 
-		changes, err := repo.FindByWorkspace(ctx, workspace, nil)
+		changes, total, err := repo.FindByWorkspace(ctx, workspace, pendingchange.ListFilters{}, shared.DefaultPagination())
 		require.NoError(t, err)
 		assert.Empty(t, changes)
+		assert.Zero(t, total)
+	})
+
+	t.Run("filters by entity type, action and requester", func(t *testing.T) {
+		workspace := uuid.New()
+		testdb.CreateTestWorkspace(t, pool, workspace)
+		user := testfixtures.TestUserID
+		payload := json.RawMessage(`{"test": "data"}`)
+		entityID := uuid.New()
+
+		itemCreate, _ := pendingchange.NewPendingChange(workspace, user, "item", nil, pendingchange.ActionCreate, payload)
+		require.NoError(t, repo.Save(ctx, itemCreate))
+		itemDelete, _ := pendingchange.NewPendingChange(workspace, user, "item", &entityID, pendingchange.ActionDelete, payload)
+		require.NoError(t, repo.Save(ctx, itemDelete))
+		categoryCreate, _ := pendingchange.NewPendingChange(workspace, user, "category", nil, pendingchange.ActionCreate, payload)
+		require.NoError(t, repo.Save(ctx, categoryCreate))
+
+		action := pendingchange.ActionCreate
+		changes, total, err := repo.FindByWorkspace(ctx, workspace, pendingchange.ListFilters{
+			EntityType:  "item",
+			Action:      &action,
+			RequesterID: &user,
+		}, shared.DefaultPagination())
+		require.NoError(t, err)
+		require.Len(t, changes, 1)
+		assert.Equal(t, 1, total)
+		assert.Equal(t, itemCreate.ID(), changes[0].ID())
+
+		otherUser := uuid.New()
+		changes, total, err = repo.FindByWorkspace(ctx, workspace, pendingchange.ListFilters{RequesterID: &otherUser}, shared.DefaultPagination())
+		require.NoError(t, err)
+		assert.Empty(t, changes)
+		assert.Zero(t, total)
+	})
+
+	t.Run("paginates newest first with total across pages", func(t *testing.T) {
+		workspace := uuid.New()
+		testdb.CreateTestWorkspace(t, pool, workspace)
+		user := testfixtures.TestUserID
+		payload := json.RawMessage(`{"test": "data"}`)
+
+		for i := 0; i < 5; i++ {
+			change, _ := pendingchange.NewPendingChange(workspace, user, "item", nil, pendingchange.ActionCreate, payload)
+			require.NoError(t, repo.Save(ctx, change))
+		}
+
+		page2, total, err := repo.FindByWorkspace(ctx, workspace, pendingchange.ListFilters{}, shared.Pagination{Page: 2, PageSize: 2})
+		require.NoError(t, err)
+		assert.Len(t, page2, 2)
+		assert.Equal(t, 5, total)
+
+		page3, _, err := repo.FindByWorkspace(ctx, workspace, pendingchange.ListFilters{}, shared.Pagination{Page: 3, PageSize: 2})
+		require.NoError(t, err)
+		assert.Len(t, page3, 1)
+
+		all, _, err := repo.FindByWorkspace(ctx, workspace, pendingchange.ListFilters{}, shared.DefaultPagination())
+		require.NoError(t, err)
+		for i := 1; i < len(all); i++ {
+			assert.False(t, all[i].CreatedAt().After(all[i-1].CreatedAt()), "changes must be ordered newest first")
+		}
 	})
 }
 
@@ -253,6 +315,97 @@ func TestPendingChangeRepository_FindByRequester(t *testing.T) {
 	})
 }
 
+func TestPendingChangeRepository_CountByRequesterStatus(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	repo := NewPendingChangeRepository(pool)
+	ctx := context.Background()
+
+	user := testfixtures.TestUserID
+	payload := json.RawMessage(`{"test": "data"}`)
+
+	before, err := repo.CountByRequesterStatus(ctx, testfixtures.TestWorkspaceID, user)
+	require.NoError(t, err)
+
+	for i := 0; i < 2; i++ {
+		change, _ := pendingchange.NewPendingChange(
+			testfixtures.TestWorkspaceID,
+			user,
+			"items",
+			nil,
+			pendingchange.ActionCreate,
+			payload,
+		)
+		require.NoError(t, repo.Save(ctx, change))
+	}
+
+	counts, err := repo.CountByRequesterStatus(ctx, testfixtures.TestWorkspaceID, user)
+	require.NoError(t, err)
+	assert.Equal(t, before[pendingchange.StatusPending]+2, counts[pendingchange.StatusPending])
+
+	other, err := repo.CountByRequesterStatus(ctx, testfixtures.TestWorkspaceID, uuid.New())
+	require.NoError(t, err)
+	assert.Empty(t, other)
+}
+
+func TestPendingChangeRepository_ReviewerStats(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	repo := NewPendingChangeRepository(pool)
+	ctx := context.Background()
+
+	reviewer := testfixtures.TestUserID
+	from := time.Now().Add(-time.Hour)
+	to := time.Now().Add(time.Hour)
+	statFor := func(stats []pendingchange.ReviewerStat) pendingchange.ReviewerStat {
+		for _, s := range stats {
+			if s.ReviewerID == reviewer {
+				return s
+			}
+		}
+		return pendingchange.ReviewerStat{}
+	}
+
+	before, err := repo.ReviewerStats(ctx, testfixtures.TestWorkspaceID, from, to)
+	require.NoError(t, err)
+
+	for i := 0; i < 3; i++ {
+		change, err := pendingchange.NewPendingChange(
+			testfixtures.TestWorkspaceID,
+			testfixtures.TestUserID,
+			"items",
+			nil,
+			pendingchange.ActionCreate,
+			json.RawMessage(`{"test": "data"}`),
+		)
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(ctx, change))
+		if i < 2 {
+			require.NoError(t, change.Approve(reviewer))
+		} else {
+			require.NoError(t, change.Reject(reviewer, "duplicate"))
+		}
+		require.NoError(t, repo.Save(ctx, change))
+	}
+
+	after, err := repo.ReviewerStats(ctx, testfixtures.TestWorkspaceID, from, to)
+	require.NoError(t, err)
+	got := statFor(after)
+	assert.Equal(t, statFor(before).Approved+2, got.Approved)
+	assert.Equal(t, statFor(before).Rejected+1, got.Rejected)
+	assert.GreaterOrEqual(t, got.AvgTimeToReview, time.Duration(0))
+
+	outside, err := repo.ReviewerStats(ctx, testfixtures.TestWorkspaceID, to, to.Add(time.Hour))
+	require.NoError(t, err)
+	assert.Empty(t, outside)
+}
+
 func TestPendingChangeRepository_FindByEntity(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test")
@@ -0,0 +1,58 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/itemphoto"
+	"github.com/antti/home-warehouse/go-backend/internal/infra/queries"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// PhotoSettingsRepository persists per-workspace photo settings
+// (warehouse.photo_settings).
+type PhotoSettingsRepository struct {
+	queries *queries.Queries
+}
+
+func NewPhotoSettingsRepository(pool *pgxpool.Pool) *PhotoSettingsRepository {
+	return &PhotoSettingsRepository{
+		queries: queries.New(pool),
+	}
+}
+
+func (r *PhotoSettingsRepository) Get(ctx context.Context, workspaceID uuid.UUID) (*itemphoto.Settings, error) {
+	row, err := r.queries.GetPhotoSettings(ctx, workspaceID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, shared.ErrNotFound
+		}
+		return nil, err
+	}
+	return rowToPhotoSettings(row), nil
+}
+
+func (r *PhotoSettingsRepository) Upsert(ctx context.Context, workspaceID uuid.UUID, settings itemphoto.Settings) (*itemphoto.Settings, error) {
+	row, err := r.queries.UpsertPhotoSettings(ctx, queries.UpsertPhotoSettingsParams{
+		WorkspaceID:                workspaceID,
+		MaxPhotosPerItem:           int32(settings.MaxPhotosPerItem),
+		RequirePhoto:               settings.RequirePhoto,
+		BlockInventoryWithoutPhoto: settings.BlockInventoryWithoutPhoto,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rowToPhotoSettings(row), nil
+}
+
+func rowToPhotoSettings(row queries.WarehousePhotoSetting) *itemphoto.Settings {
+	return &itemphoto.Settings{
+		MaxPhotosPerItem:           int(row.MaxPhotosPerItem),
+		RequirePhoto:               row.RequirePhoto,
+		BlockInventoryWithoutPhoto: row.BlockInventoryWithoutPhoto,
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/antti/home-warehouse/go-backend/internal/domain/shortlink"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/labelprint"
 )
 
 // ShortlinkRepository resolves a short_code against the global
@@ -53,3 +54,31 @@ func (r *ShortlinkRepository) Resolve(ctx context.Context, code string, workspac
 	}
 	return &m, nil
 }
+
+// listShortCodesSQL returns a workspace's registry rows, with the same
+// lowercased entity tags as Resolve.
+const listShortCodesSQL = `
+SELECT code, lower(entity_type::text), entity_id
+  FROM warehouse.short_codes
+ WHERE workspace_id = $1
+ ORDER BY code`
+
+// ListShortCodes returns every short code registered to workspaceID. It
+// implements labelprint.ShortCodeLister.
+func (r *ShortlinkRepository) ListShortCodes(ctx context.Context, workspaceID uuid.UUID) ([]labelprint.ShortCode, error) {
+	rows, err := r.pool.Query(ctx, listShortCodesSQL, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	codes := []labelprint.ShortCode{}
+	for rows.Next() {
+		var c labelprint.ShortCode
+		if err := rows.Scan(&c.Code, &c.EntityType, &c.EntityID); err != nil {
+			return nil, err
+		}
+		codes = append(codes, c)
+	}
+	return codes, rows.Err()
+}
@@ -258,3 +258,34 @@ func TestShortCodesRegistry_WritePath_Integration(t *testing.T) {
 		assert.Contains(t, err.Error(), "chk_short_codes_code")
 	})
 }
+
+// TestShortlinkRepository_ListShortCodes_Integration checks the registry
+// listing behind GET /short-codes: one workspace's codes only, with the same
+// entity tags as Resolve.
+func TestShortlinkRepository_ListShortCodes_Integration(t *testing.T) {
+	pool := testdb.SetupTestDB(t)
+	ctx := context.Background()
+
+	wsA := uuid.MustParse("00000000-0000-0000-0000-000000000001")
+	wsB := uuid.New()
+	testdb.CreateTestWorkspace(t, pool, wsB)
+
+	itemID := seedItem(t, pool, wsA, "LST00001")
+	locID := seedLocation(t, pool, wsA, "lst00001")
+	seedItem(t, pool, wsB, "lst00002")
+
+	codes, err := postgres.NewShortlinkRepository(pool).ListShortCodes(ctx, wsA)
+	require.NoError(t, err)
+
+	byCode := map[string]uuid.UUID{}
+	types := map[string]string{}
+	for _, c := range codes {
+		byCode[c.Code] = c.EntityID
+		types[c.Code] = c.EntityType
+	}
+	assert.Equal(t, itemID, byCode["LST00001"])
+	assert.Equal(t, shortlink.TypeItem, types["LST00001"])
+	assert.Equal(t, locID, byCode["lst00001"])
+	assert.Equal(t, shortlink.TypeLocation, types["lst00001"])
+	assert.NotContains(t, byCode, "lst00002", "codes of other workspaces must not be listed")
+}
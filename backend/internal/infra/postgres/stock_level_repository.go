@@ -0,0 +1,93 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/inventory"
+	"github.com/antti/home-warehouse/go-backend/internal/infra/queries"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// StockLevelRepository persists per-location minimum stock levels
+// (warehouse.item_location_stock_levels) and runs the low-stock report.
+type StockLevelRepository struct {
+	queries *queries.Queries
+}
+
+func NewStockLevelRepository(pool *pgxpool.Pool) *StockLevelRepository {
+	return &StockLevelRepository{
+		queries: queries.New(pool),
+	}
+}
+
+func (r *StockLevelRepository) ListByItem(ctx context.Context, workspaceID, itemID uuid.UUID) ([]inventory.LocationStockLevel, error) {
+	rows, err := r.queries.ListItemLocationStockLevels(ctx, queries.ListItemLocationStockLevelsParams{
+		WorkspaceID: workspaceID,
+		ItemID:      itemID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	levels := make([]inventory.LocationStockLevel, len(rows))
+	for i, row := range rows {
+		levels[i] = inventory.LocationStockLevel{
+			ItemID:        row.ItemID,
+			LocationID:    row.LocationID,
+			LocationName:  row.LocationName,
+			MinStockLevel: int(row.MinStockLevel),
+		}
+	}
+	return levels, nil
+}
+
+func (r *StockLevelRepository) Upsert(ctx context.Context, workspaceID uuid.UUID, level inventory.LocationStockLevel) error {
+	_, err := r.queries.UpsertItemLocationStockLevel(ctx, queries.UpsertItemLocationStockLevelParams{
+		ItemID:        level.ItemID,
+		LocationID:    level.LocationID,
+		WorkspaceID:   workspaceID,
+		MinStockLevel: int32(level.MinStockLevel),
+	})
+	return err
+}
+
+func (r *StockLevelRepository) Delete(ctx context.Context, workspaceID, itemID, locationID uuid.UUID) error {
+	n, err := r.queries.DeleteItemLocationStockLevel(ctx, queries.DeleteItemLocationStockLevelParams{
+		WorkspaceID: workspaceID,
+		ItemID:      itemID,
+		LocationID:  locationID,
+	})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return shared.ErrNotFound
+	}
+	return nil
+}
+
+func (r *StockLevelRepository) FindLowStock(ctx context.Context, workspaceID uuid.UUID) ([]inventory.LowStockEntry, error) {
+	rows, err := r.queries.GetLowStockItems(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := make([]inventory.LowStockEntry, len(rows))
+	for i, row := range rows {
+		entries[i] = inventory.LowStockEntry{
+			ItemID:        row.ItemID,
+			ItemName:      row.ItemName,
+			LocationName:  row.LocationName,
+			CurrentStock:  int(row.CurrentStock),
+			MinStockLevel: int(row.MinStockLevel),
+		}
+		if row.LocationID.Valid {
+			id := uuid.UUID(row.LocationID.Bytes)
+			entries[i].LocationID = &id
+		}
+	}
+	return entries, nil
+}
@@ -0,0 +1,125 @@
+//go:build integration
+// +build integration
+
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/inventory"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/item"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+	"github.com/antti/home-warehouse/go-backend/tests/testdb"
+	"github.com/antti/home-warehouse/go-backend/tests/testfixtures"
+)
+
+func TestStockLevelRepository_UpsertListDelete(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	repo := NewStockLevelRepository(pool)
+	ctx := context.Background()
+
+	itm := createTestItem(t, NewItemRepository(pool), ctx, "Batteries")
+	garage := createTestLocationForInv(t, NewLocationRepository(pool), ctx, "Garage")
+
+	level := inventory.LocationStockLevel{ItemID: itm.ID(), LocationID: garage.ID(), MinStockLevel: 5}
+	require.NoError(t, repo.Upsert(ctx, testfixtures.TestWorkspaceID, level))
+	level.MinStockLevel = 3
+	require.NoError(t, repo.Upsert(ctx, testfixtures.TestWorkspaceID, level))
+
+	levels, err := repo.ListByItem(ctx, testfixtures.TestWorkspaceID, itm.ID())
+	require.NoError(t, err)
+	require.Len(t, levels, 1)
+	assert.Equal(t, "Garage", levels[0].LocationName)
+	assert.Equal(t, 3, levels[0].MinStockLevel)
+
+	require.NoError(t, repo.Delete(ctx, testfixtures.TestWorkspaceID, itm.ID(), garage.ID()))
+	err = repo.Delete(ctx, testfixtures.TestWorkspaceID, itm.ID(), garage.ID())
+	assert.ErrorIs(t, err, shared.ErrNotFound)
+}
+
+func TestStockLevelRepository_FindLowStock_Precedence(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	repo := NewStockLevelRepository(pool)
+	itemRepo := NewItemRepository(pool)
+	invRepo := NewInventoryRepository(pool)
+	ctx := context.Background()
+
+	garage := createTestLocationForInv(t, NewLocationRepository(pool), ctx, "Garage")
+	shed := createTestLocationForInv(t, NewLocationRepository(pool), ctx, "Shed")
+
+	newItem := func(name string, minStock int) *item.Item {
+		itm, err := item.NewItem(testfixtures.TestWorkspaceID, name, "SKU-"+uuid.NewString()[:8], minStock)
+		require.NoError(t, err)
+		itm.SetShortCode(uuid.NewString()[:8])
+		require.NoError(t, itemRepo.Save(ctx, itm))
+		return itm
+	}
+	stock := func(itemID, locationID uuid.UUID, quantity int) {
+		inv, err := inventory.NewInventory(testfixtures.TestWorkspaceID, itemID, locationID, nil, quantity, inventory.ConditionNew, inventory.StatusAvailable, nil)
+		require.NoError(t, err)
+		require.NoError(t, invRepo.Save(ctx, inv))
+	}
+	setLevel := func(itemID, locationID uuid.UUID, minStock int) {
+		require.NoError(t, repo.Upsert(ctx, testfixtures.TestWorkspaceID, inventory.LocationStockLevel{
+			ItemID: itemID, LocationID: locationID, MinStockLevel: minStock,
+		}))
+	}
+
+	// No overrides: the item-level minimum applies to total stock.
+	tape := newItem("Tape", 5)
+	stock(tape.ID(), garage.ID(), 3)
+
+	// Overrides replace the item-level minimum: 5 at the Garage is short, 2 at
+	// the Shed is met, and the item-level 10 is not checked at all.
+	screws := newItem("Screws", 10)
+	stock(screws.ID(), garage.ID(), 3)
+	stock(screws.ID(), shed.ID(), 2)
+	setLevel(screws.ID(), garage.ID(), 5)
+	setLevel(screws.ID(), shed.ID(), 2)
+
+	// An override applies even without an item-level minimum or any stock.
+	fuses := newItem("Fuses", 0)
+	setLevel(fuses.ID(), shed.ID(), 4)
+
+	// Met item-level minimum: not reported.
+	glue := newItem("Glue", 1)
+	stock(glue.ID(), garage.ID(), 1)
+
+	entries, err := repo.FindLowStock(ctx, testfixtures.TestWorkspaceID)
+	require.NoError(t, err)
+
+	byItem := map[uuid.UUID][]inventory.LowStockEntry{}
+	for _, e := range entries {
+		byItem[e.ItemID] = append(byItem[e.ItemID], e)
+	}
+
+	require.Len(t, byItem[tape.ID()], 1)
+	assert.Nil(t, byItem[tape.ID()][0].LocationID)
+	assert.Equal(t, 3, byItem[tape.ID()][0].CurrentStock)
+	assert.Equal(t, 2, byItem[tape.ID()][0].ReorderQuantity())
+
+	require.Len(t, byItem[screws.ID()], 1)
+	require.NotNil(t, byItem[screws.ID()][0].LocationID)
+	assert.Equal(t, garage.ID(), *byItem[screws.ID()][0].LocationID)
+	assert.Equal(t, 5, byItem[screws.ID()][0].MinStockLevel)
+	assert.Equal(t, 2, byItem[screws.ID()][0].ReorderQuantity())
+
+	require.Len(t, byItem[fuses.ID()], 1)
+	assert.Equal(t, shed.ID(), *byItem[fuses.ID()][0].LocationID)
+	assert.Equal(t, 0, byItem[fuses.ID()][0].CurrentStock)
+
+	assert.Empty(t, byItem[glue.ID()])
+}
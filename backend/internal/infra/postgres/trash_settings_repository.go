@@ -0,0 +1,52 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/deleted"
+	"github.com/antti/home-warehouse/go-backend/internal/infra/queries"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// TrashSettingsRepository persists per-workspace trash settings
+// (warehouse.trash_settings).
+type TrashSettingsRepository struct {
+	queries *queries.Queries
+}
+
+func NewTrashSettingsRepository(pool *pgxpool.Pool) *TrashSettingsRepository {
+	return &TrashSettingsRepository{
+		queries: queries.New(pool),
+	}
+}
+
+func (r *TrashSettingsRepository) Get(ctx context.Context, workspaceID uuid.UUID) (*deleted.Settings, error) {
+	row, err := r.queries.GetTrashSettings(ctx, workspaceID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, shared.ErrNotFound
+		}
+		return nil, err
+	}
+	return &deleted.Settings{TrashRetentionDays: int(row.TrashRetentionDays)}, nil
+}
+
+func (r *TrashSettingsRepository) Upsert(ctx context.Context, workspaceID uuid.UUID, settings deleted.Settings) (*deleted.Settings, error) {
+	row, err := r.queries.UpsertTrashSettings(ctx, queries.UpsertTrashSettingsParams{
+		WorkspaceID:        workspaceID,
+		TrashRetentionDays: int32(settings.TrashRetentionDays),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &deleted.Settings{TrashRetentionDays: int(row.TrashRetentionDays)}, nil
+}
+
+func (r *TrashSettingsRepository) Delete(ctx context.Context, workspaceID uuid.UUID) error {
+	return r.queries.DeleteTrashSettings(ctx, workspaceID)
+}
@@ -0,0 +1,46 @@
+//go:build integration
+// +build integration
+
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/deleted"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+	"github.com/antti/home-warehouse/go-backend/tests/testdb"
+	"github.com/antti/home-warehouse/go-backend/tests/testfixtures"
+)
+
+func TestTrashSettingsRepository_GetUpsertDelete(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	repo := NewTrashSettingsRepository(pool)
+	ctx := context.Background()
+
+	_, err := repo.Get(ctx, testfixtures.TestWorkspaceID)
+	assert.ErrorIs(t, err, shared.ErrNotFound)
+
+	saved, err := repo.Upsert(ctx, testfixtures.TestWorkspaceID, deleted.Settings{TrashRetentionDays: 30})
+	require.NoError(t, err)
+	assert.Equal(t, 30, saved.TrashRetentionDays)
+
+	saved, err = repo.Upsert(ctx, testfixtures.TestWorkspaceID, deleted.Settings{TrashRetentionDays: 7})
+	require.NoError(t, err)
+	assert.Equal(t, 7, saved.TrashRetentionDays)
+
+	got, err := repo.Get(ctx, testfixtures.TestWorkspaceID)
+	require.NoError(t, err)
+	assert.Equal(t, 7, got.TrashRetentionDays)
+
+	require.NoError(t, repo.Delete(ctx, testfixtures.TestWorkspaceID))
+	_, err = repo.Get(ctx, testfixtures.TestWorkspaceID)
+	assert.ErrorIs(t, err, shared.ErrNotFound)
+}
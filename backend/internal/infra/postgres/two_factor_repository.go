@@ -0,0 +1,149 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/auth/user"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// TwoFactorRepository implements user.TwoFactorRepository using PostgreSQL
+// (auth.user_two_factor, auth.user_recovery_codes).
+type TwoFactorRepository struct {
+	pool *pgxpool.Pool
+}
+
+// NewTwoFactorRepository creates a new TwoFactorRepository.
+func NewTwoFactorRepository(pool *pgxpool.Pool) *TwoFactorRepository {
+	return &TwoFactorRepository{pool: pool}
+}
+
+// Get returns the user's two-factor settings.
+func (r *TwoFactorRepository) Get(ctx context.Context, userID uuid.UUID) (*user.TwoFactorSettings, error) {
+	query := `
+		SELECT user_id, totp_secret_encrypted, last_used_step, enabled_at
+		FROM auth.user_two_factor
+		WHERE user_id = $1
+	`
+
+	var s user.TwoFactorSettings
+	err := r.pool.QueryRow(ctx, query, userID).Scan(&s.UserID, &s.SecretEncrypted, &s.LastUsedStep, &s.EnabledAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, shared.ErrNotFound
+		}
+		return nil, err
+	}
+	return &s, nil
+}
+
+// SavePending stores an unconfirmed secret. An already enabled row is left
+// alone.
+func (r *TwoFactorRepository) SavePending(ctx context.Context, userID uuid.UUID, secretEncrypted string) error {
+	query := `
+		INSERT INTO auth.user_two_factor (user_id, totp_secret_encrypted)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+		SET totp_secret_encrypted = EXCLUDED.totp_secret_encrypted,
+		    last_used_step = 0,
+		    created_at = now()
+		WHERE auth.user_two_factor.enabled_at IS NULL
+	`
+	_, err := r.pool.Exec(ctx, query, userID, secretEncrypted)
+	return err
+}
+
+// Enable confirms the enrollment.
+func (r *TwoFactorRepository) Enable(ctx context.Context, userID uuid.UUID, step int64) error {
+	query := `
+		UPDATE auth.user_two_factor
+		SET enabled_at = now(), last_used_step = $2
+		WHERE user_id = $1 AND enabled_at IS NULL
+	`
+	tag, err := r.pool.Exec(ctx, query, userID, step)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return shared.ErrNotFound
+	}
+	return nil
+}
+
+// MarkStepUsed advances last_used_step in a single conditional UPDATE, so of
+// two concurrent logins with the same code only one sees true.
+func (r *TwoFactorRepository) MarkStepUsed(ctx context.Context, userID uuid.UUID, step int64) (bool, error) {
+	query := `
+		UPDATE auth.user_two_factor
+		SET last_used_step = $2
+		WHERE user_id = $1 AND last_used_step < $2
+	`
+	tag, err := r.pool.Exec(ctx, query, userID, step)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// Delete removes the settings; recovery codes go with them via ON DELETE
+// CASCADE.
+func (r *TwoFactorRepository) Delete(ctx context.Context, userID uuid.UUID) error {
+	_, err := r.pool.Exec(ctx, `DELETE FROM auth.user_two_factor WHERE user_id = $1`, userID)
+	return err
+}
+
+// ReplaceRecoveryCodes deletes the user's recovery codes and inserts the new
+// hashes in one transaction.
+func (r *TwoFactorRepository) ReplaceRecoveryCodes(ctx context.Context, userID uuid.UUID, codeHashes []string) error {
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
+
+	if _, err := tx.Exec(ctx, `DELETE FROM auth.user_recovery_codes WHERE user_id = $1`, userID); err != nil {
+		return err
+	}
+	if _, err := tx.Exec(ctx, `
+		INSERT INTO auth.user_recovery_codes (user_id, code_hash)
+		SELECT $1, unnest($2::text[])
+	`, userID, codeHashes); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// ConsumeRecoveryCode marks a matching unused code used in a single
+// conditional UPDATE.
+func (r *TwoFactorRepository) ConsumeRecoveryCode(ctx context.Context, userID uuid.UUID, codeHash string) (bool, error) {
+	query := `
+		UPDATE auth.user_recovery_codes
+		SET used_at = now()
+		WHERE id = (
+			SELECT id FROM auth.user_recovery_codes
+			WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL
+			LIMIT 1
+			FOR UPDATE
+		)
+	`
+	tag, err := r.pool.Exec(ctx, query, userID, codeHash)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() == 1, nil
+}
+
+// CountUnusedRecoveryCodes returns how many recovery codes remain.
+func (r *TwoFactorRepository) CountUnusedRecoveryCodes(ctx context.Context, userID uuid.UUID) (int, error) {
+	var n int
+	err := r.pool.QueryRow(ctx, `
+		SELECT count(*) FROM auth.user_recovery_codes
+		WHERE user_id = $1 AND used_at IS NULL
+	`, userID).Scan(&n)
+	return n, err
+}
@@ -0,0 +1,101 @@
+//go:build integration
+// +build integration
+
+package postgres
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+	"github.com/antti/home-warehouse/go-backend/tests/testdb"
+	"github.com/antti/home-warehouse/go-backend/tests/testfixtures"
+)
+
+func TestTwoFactorRepository(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pool := testdb.SetupTestDB(t)
+	repo := NewTwoFactorRepository(pool)
+	ctx := context.Background()
+	userID := testfixtures.TestUserID
+	t.Cleanup(func() { _ = repo.Delete(ctx, userID) })
+
+	t.Run("enrollment lifecycle", func(t *testing.T) {
+		_, err := repo.Get(ctx, userID)
+		assert.ErrorIs(t, err, shared.ErrNotFound)
+
+		require.NoError(t, repo.SavePending(ctx, userID, "secret-1"))
+		require.NoError(t, repo.SavePending(ctx, userID, "secret-2"))
+
+		s, err := repo.Get(ctx, userID)
+		require.NoError(t, err)
+		assert.Equal(t, "secret-2", s.SecretEncrypted)
+		assert.Nil(t, s.EnabledAt)
+
+		require.NoError(t, repo.Enable(ctx, userID, 100))
+
+		// An enabled secret is not replaced by a new enrollment.
+		require.NoError(t, repo.SavePending(ctx, userID, "secret-3"))
+		s, err = repo.Get(ctx, userID)
+		require.NoError(t, err)
+		assert.Equal(t, "secret-2", s.SecretEncrypted)
+		assert.NotNil(t, s.EnabledAt)
+		assert.Equal(t, int64(100), s.LastUsedStep)
+	})
+
+	t.Run("steps are accepted once and in order", func(t *testing.T) {
+		ok, err := repo.MarkStepUsed(ctx, userID, 101)
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		ok, err = repo.MarkStepUsed(ctx, userID, 101)
+		require.NoError(t, err)
+		assert.False(t, ok)
+
+		ok, err = repo.MarkStepUsed(ctx, userID, 100)
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
+
+	t.Run("recovery codes are single use and replaceable", func(t *testing.T) {
+		require.NoError(t, repo.ReplaceRecoveryCodes(ctx, userID, []string{"h1", "h2"}))
+
+		n, err := repo.CountUnusedRecoveryCodes(ctx, userID)
+		require.NoError(t, err)
+		assert.Equal(t, 2, n)
+
+		ok, err := repo.ConsumeRecoveryCode(ctx, userID, "h1")
+		require.NoError(t, err)
+		assert.True(t, ok)
+
+		ok, err = repo.ConsumeRecoveryCode(ctx, userID, "h1")
+		require.NoError(t, err)
+		assert.False(t, ok)
+
+		require.NoError(t, repo.ReplaceRecoveryCodes(ctx, userID, []string{"h3"}))
+		ok, err = repo.ConsumeRecoveryCode(ctx, userID, "h2")
+		require.NoError(t, err)
+		assert.False(t, ok, "replaced codes stop working")
+
+		n, err = repo.CountUnusedRecoveryCodes(ctx, userID)
+		require.NoError(t, err)
+		assert.Equal(t, 1, n)
+	})
+
+	t.Run("delete removes settings and codes", func(t *testing.T) {
+		require.NoError(t, repo.Delete(ctx, userID))
+
+		_, err := repo.Get(ctx, userID)
+		assert.ErrorIs(t, err, shared.ErrNotFound)
+
+		n, err := repo.CountUnusedRecoveryCodes(ctx, userID)
+		require.NoError(t, err)
+		assert.Zero(t, n)
+	})
+}
@@ -0,0 +1,195 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/webhook"
+	"github.com/antti/home-warehouse/go-backend/internal/infra/queries"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+type WebhookRepository struct {
+	pool    *pgxpool.Pool
+	queries *queries.Queries
+}
+
+func NewWebhookRepository(pool *pgxpool.Pool) *WebhookRepository {
+	return &WebhookRepository{
+		pool:    pool,
+		queries: queries.New(pool),
+	}
+}
+
+func (r *WebhookRepository) Save(ctx context.Context, w *webhook.Webhook) error {
+	_, err := r.queries.GetWebhook(ctx, queries.GetWebhookParams{
+		ID:          w.ID(),
+		WorkspaceID: w.WorkspaceID(),
+	})
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return err
+	}
+
+	if err == nil {
+		_, err = r.queries.UpdateWebhook(ctx, queries.UpdateWebhookParams{
+			ID:          w.ID(),
+			WorkspaceID: w.WorkspaceID(),
+			Url:         w.URL(),
+			Secret:      w.Secret(),
+			EventTypes:  w.EventTypes(),
+			IsActive:    w.IsActive(),
+		})
+		return err
+	}
+
+	var createdBy pgtype.UUID
+	if w.CreatedBy() != nil {
+		createdBy = pgtype.UUID{Bytes: *w.CreatedBy(), Valid: true}
+	}
+	_, err = r.queries.CreateWebhook(ctx, queries.CreateWebhookParams{
+		ID:          w.ID(),
+		WorkspaceID: w.WorkspaceID(),
+		Url:         w.URL(),
+		Secret:      w.Secret(),
+		EventTypes:  w.EventTypes(),
+		IsActive:    w.IsActive(),
+		CreatedBy:   createdBy,
+	})
+	return err
+}
+
+func (r *WebhookRepository) FindByID(ctx context.Context, id, workspaceID uuid.UUID) (*webhook.Webhook, error) {
+	row, err := r.queries.GetWebhook(ctx, queries.GetWebhookParams{
+		ID:          id,
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, shared.ErrNotFound
+		}
+		return nil, err
+	}
+	return rowToWebhook(row), nil
+}
+
+func (r *WebhookRepository) FindByWorkspace(ctx context.Context, workspaceID uuid.UUID) ([]*webhook.Webhook, error) {
+	rows, err := r.queries.ListWebhooksByWorkspace(ctx, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	return rowsToWebhooks(rows), nil
+}
+
+func (r *WebhookRepository) FindActiveForEvent(ctx context.Context, workspaceID uuid.UUID, eventType string) ([]*webhook.Webhook, error) {
+	rows, err := r.queries.ListActiveWebhooksForEvent(ctx, queries.ListActiveWebhooksForEventParams{
+		WorkspaceID: workspaceID,
+		EventType:   eventType,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rowsToWebhooks(rows), nil
+}
+
+func (r *WebhookRepository) Delete(ctx context.Context, id, workspaceID uuid.UUID) error {
+	return r.queries.DeleteWebhook(ctx, queries.DeleteWebhookParams{
+		ID:          id,
+		WorkspaceID: workspaceID,
+	})
+}
+
+func (r *WebhookRepository) SaveDelivery(ctx context.Context, d *webhook.Delivery) error {
+	_, err := r.queries.CreateWebhookDelivery(ctx, queries.CreateWebhookDeliveryParams{
+		ID:          d.ID(),
+		WebhookID:   d.WebhookID(),
+		WorkspaceID: d.WorkspaceID(),
+		EventType:   d.EventType(),
+		Payload:     d.Payload(),
+	})
+	return err
+}
+
+func (r *WebhookRepository) FindDeliveries(ctx context.Context, webhookID, workspaceID uuid.UUID, pagination shared.Pagination) ([]*webhook.Delivery, int, error) {
+	rows, err := r.queries.ListWebhookDeliveries(ctx, queries.ListWebhookDeliveriesParams{
+		WebhookID:   webhookID,
+		WorkspaceID: workspaceID,
+		Limit:       int32(pagination.Limit()),
+		Offset:      int32(pagination.Offset()),
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	total, err := r.queries.CountWebhookDeliveries(ctx, queries.CountWebhookDeliveriesParams{
+		WebhookID:   webhookID,
+		WorkspaceID: workspaceID,
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	deliveries := make([]*webhook.Delivery, len(rows))
+	for i, row := range rows {
+		deliveries[i] = rowToWebhookDelivery(row)
+	}
+	return deliveries, int(total), nil
+}
+
+func rowsToWebhooks(rows []queries.WarehouseWebhook) []*webhook.Webhook {
+	webhooks := make([]*webhook.Webhook, len(rows))
+	for i, row := range rows {
+		webhooks[i] = rowToWebhook(row)
+	}
+	return webhooks
+}
+
+func rowToWebhook(row queries.WarehouseWebhook) *webhook.Webhook {
+	var createdBy *uuid.UUID
+	if row.CreatedBy.Valid {
+		id := uuid.UUID(row.CreatedBy.Bytes)
+		createdBy = &id
+	}
+	return webhook.Reconstruct(
+		row.ID,
+		row.WorkspaceID,
+		row.Url,
+		row.Secret,
+		row.EventTypes,
+		row.IsActive,
+		createdBy,
+		row.CreatedAt,
+		row.UpdatedAt,
+	)
+}
+
+func rowToWebhookDelivery(row queries.WarehouseWebhookDelivery) *webhook.Delivery {
+	var responseStatus *int
+	if row.ResponseStatus != nil {
+		status := int(*row.ResponseStatus)
+		responseStatus = &status
+	}
+	var deliveredAt *time.Time
+	if row.DeliveredAt.Valid {
+		deliveredAt = &row.DeliveredAt.Time
+	}
+	return webhook.ReconstructDelivery(
+		row.ID,
+		row.WebhookID,
+		row.WorkspaceID,
+		row.EventType,
+		row.Payload,
+		webhook.DeliveryStatus(row.Status),
+		int(row.Attempts),
+		responseStatus,
+		row.LastError,
+		deliveredAt,
+		row.CreatedAt,
+		row.UpdatedAt,
+	)
+}
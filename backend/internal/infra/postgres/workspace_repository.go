@@ -3,6 +3,8 @@ package postgres
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strings"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -124,9 +126,150 @@ func (r *WorkspaceRepository) FindByUserID(ctx context.Context, userID uuid.UUID
 	return workspaces, nil
 }
 
-// Delete removes a workspace by ID.
+// Delete removes a workspace and all of its data. Every dependent table
+// cascades from auth.workspaces, but loans -> borrowers and inventory ->
+// locations are ON DELETE RESTRICT, and a single cascade may reach the
+// parent before the child. Deleting loans and inventory first keeps the
+// cascade from tripping them. Run it in a transaction (see TxManager) so a
+// failure leaves the workspace whole.
 func (r *WorkspaceRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.queries.DeleteWorkspace(ctx, id)
+	db := GetDBTX(ctx, r.pool)
+	for _, stmt := range []string{
+		`DELETE FROM warehouse.loans WHERE workspace_id = $1`,
+		`DELETE FROM warehouse.inventory WHERE workspace_id = $1`,
+	} {
+		if _, err := db.Exec(ctx, stmt, id); err != nil {
+			return err
+		}
+	}
+	return queries.New(db).DeleteWorkspace(ctx, id)
+}
+
+// workspaceDependentTables are the tables holding a workspace's data, each
+// keyed by a workspace_id. Deleting the workspace cascades to all of them,
+// directly or through the row they belong to (category defaults and rules go
+// with their category).
+var workspaceDependentTables = []string{
+	"auth.api_keys",
+	"auth.notifications",
+	"auth.workspace_docspell_settings",
+	"auth.workspace_exports",
+	"auth.workspace_members",
+	"warehouse.activity_log",
+	"warehouse.attachments",
+	"warehouse.borrowers",
+	"warehouse.categories",
+	"warehouse.category_inventory_defaults",
+	"warehouse.category_rules",
+	"warehouse.companies",
+	"warehouse.container_tags",
+	"warehouse.containers",
+	"warehouse.currency_settings",
+	"warehouse.custom_fields",
+	"warehouse.deleted_records",
+	"warehouse.favorites",
+	"warehouse.files",
+	"warehouse.idempotency_keys",
+	"warehouse.import_jobs",
+	"warehouse.inventory",
+	"warehouse.inventory_movements",
+	"warehouse.inventory_settings",
+	"warehouse.inventory_snapshots",
+	"warehouse.item_custom_values",
+	"warehouse.item_identifiers",
+	"warehouse.item_labels",
+	"warehouse.item_location_stock_levels",
+	"warehouse.item_photos",
+	"warehouse.item_shares",
+	"warehouse.items",
+	"warehouse.labels",
+	"warehouse.loan_settings",
+	"warehouse.loans",
+	"warehouse.locations",
+	"warehouse.maintenance_schedules",
+	"warehouse.pending_changes",
+	"warehouse.photo_settings",
+	"warehouse.repair_attachments",
+	"warehouse.repair_logs",
+	"warehouse.repair_photos",
+	"warehouse.short_codes",
+	"warehouse.trash_settings",
+	"warehouse.webhooks",
+	"warehouse.wishlist_items",
+}
+
+// DeletionImpact counts the workspace's rows per dependent table (keyed by
+// table name without schema) and its uploaded files.
+func (r *WorkspaceRepository) DeletionImpact(ctx context.Context, id uuid.UUID) (*workspace.DeletionImpact, error) {
+	counts := make([]string, len(workspaceDependentTables))
+	for i, table := range workspaceDependentTables {
+		name := table[strings.Index(table, ".")+1:]
+		counts[i] = fmt.Sprintf("SELECT '%s', count(*) FROM %s WHERE workspace_id = $1", name, table)
+	}
+
+	rows, err := r.pool.Query(ctx, strings.Join(counts, "\nUNION ALL\n"), id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	impact := &workspace.DeletionImpact{Counts: make(map[string]int64, len(workspaceDependentTables))}
+	for rows.Next() {
+		var name string
+		var n int64
+		if err := rows.Scan(&name, &n); err != nil {
+			return nil, err
+		}
+		impact.Counts[name] = n
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	const storageQuery = `
+		SELECT count(*), COALESCE(SUM(size), 0)::bigint FROM (
+			SELECT file_size AS size FROM warehouse.item_photos WHERE workspace_id = $1
+			UNION ALL
+			SELECT file_size FROM warehouse.repair_photos WHERE workspace_id = $1
+			UNION ALL
+			SELECT COALESCE(size_bytes, 0) FROM warehouse.files WHERE workspace_id = $1 AND storage_key IS NOT NULL
+		) stored`
+	if err := r.pool.QueryRow(ctx, storageQuery, id).Scan(&impact.StorageFiles, &impact.StorageBytes); err != nil {
+		return nil, err
+	}
+
+	return impact, nil
+}
+
+// StoredFilePaths lists the storage paths of the workspace's photos, their
+// thumbnails and its attachment files.
+func (r *WorkspaceRepository) StoredFilePaths(ctx context.Context, id uuid.UUID) ([]string, error) {
+	const query = `
+		SELECT DISTINCT path FROM (
+			SELECT unnest(ARRAY[storage_path, thumbnail_path, thumbnail_small_path, thumbnail_medium_path, thumbnail_large_path]) AS path
+			FROM warehouse.item_photos WHERE workspace_id = $1
+			UNION ALL
+			SELECT unnest(ARRAY[storage_path, thumbnail_path]) FROM warehouse.repair_photos WHERE workspace_id = $1
+			UNION ALL
+			SELECT storage_key FROM warehouse.files WHERE workspace_id = $1
+		) stored
+		WHERE path IS NOT NULL AND path <> ''`
+
+	rows, err := GetDBTX(ctx, r.pool).Query(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var paths []string
+	for rows.Next() {
+		var path string
+		if err := rows.Scan(&path); err != nil {
+			return nil, err
+		}
+		paths = append(paths, path)
+	}
+	return paths, rows.Err()
 }
 
 // ExistsBySlug checks if a workspace with the given slug exists.
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
@@ -226,6 +227,58 @@ func TestWorkspaceRepository_Delete(t *testing.T) {
 		assert.Nil(t, found)
 	})
 
+	t.Run("deletes workspace with loaned inventory", func(t *testing.T) {
+		ws, err := workspace.NewWorkspace("Loaned", "loaned-"+uuid.New().String()[:8], nil, false)
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(ctx, ws))
+		seedLoanedInventory(t, ctx, pool, ws.ID())
+
+		impact, err := repo.DeletionImpact(ctx, ws.ID())
+		require.NoError(t, err)
+		assert.Equal(t, int64(1), impact.Counts["items"])
+		assert.Equal(t, int64(1), impact.Counts["inventory"])
+		assert.Equal(t, int64(1), impact.Counts["loans"])
+		assert.Equal(t, int64(1), impact.Counts["borrowers"])
+		assert.Equal(t, int64(1), impact.Counts["locations"])
+		assert.Zero(t, impact.StorageFiles)
+
+		// loans -> borrowers and inventory -> locations are ON DELETE RESTRICT.
+		err = NewTxManager(pool).WithTx(ctx, func(ctx context.Context) error {
+			return repo.Delete(ctx, ws.ID())
+		})
+		require.NoError(t, err)
+
+		impact, err = repo.DeletionImpact(ctx, ws.ID())
+		require.NoError(t, err)
+		for table, n := range impact.Counts {
+			assert.Zero(t, n, table)
+		}
+	})
+
+	t.Run("counts and deletes category rules, snapshots, API keys and shares", func(t *testing.T) {
+		ws, err := workspace.NewWorkspace("Extras", "extras-"+uuid.New().String()[:8], nil, false)
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(ctx, ws))
+		seedWorkspaceExtras(t, ctx, pool, ws.ID())
+
+		impact, err := repo.DeletionImpact(ctx, ws.ID())
+		require.NoError(t, err)
+		for _, table := range []string{"category_inventory_defaults", "category_rules", "inventory_snapshots", "api_keys", "item_shares"} {
+			assert.Equal(t, int64(1), impact.Counts[table], table)
+		}
+
+		err = NewTxManager(pool).WithTx(ctx, func(ctx context.Context) error {
+			return repo.Delete(ctx, ws.ID())
+		})
+		require.NoError(t, err)
+
+		impact, err = repo.DeletionImpact(ctx, ws.ID())
+		require.NoError(t, err)
+		for table, n := range impact.Counts {
+			assert.Zero(t, n, table)
+		}
+	})
+
 	t.Run("delete non-existent workspace does not error", func(t *testing.T) {
 		nonExistentID := uuid.New()
 		err := repo.Delete(ctx, nonExistentID)
@@ -233,6 +286,61 @@ func TestWorkspaceRepository_Delete(t *testing.T) {
 	})
 }
 
+// seedLoanedInventory adds an item at a location, loaned to a borrower.
+func seedLoanedInventory(t *testing.T, ctx context.Context, pool *pgxpool.Pool, workspaceID uuid.UUID) {
+	t.Helper()
+	locationID, itemID, inventoryID, borrowerID := uuid.New(), uuid.New(), uuid.New(), uuid.New()
+
+	for _, stmt := range []struct {
+		sql  string
+		args []any
+	}{
+		{`INSERT INTO warehouse.locations (id, workspace_id, name, short_code) VALUES ($1, $2, 'Shelf', $3)`,
+			[]any{locationID, workspaceID, "L" + uuid.New().String()[:7]}},
+		{`INSERT INTO warehouse.items (id, workspace_id, name, sku, short_code, min_stock_level) VALUES ($1, $2, 'Drill', 'SKU-1', $3, 0)`,
+			[]any{itemID, workspaceID, "I" + uuid.New().String()[:7]}},
+		{`INSERT INTO warehouse.inventory (id, workspace_id, item_id, location_id, quantity, condition, status) VALUES ($1, $2, $3, $4, 1, 'NEW', 'ON_LOAN')`,
+			[]any{inventoryID, workspaceID, itemID, locationID}},
+		{`INSERT INTO warehouse.borrowers (id, workspace_id, name) VALUES ($1, $2, 'Neighbour')`,
+			[]any{borrowerID, workspaceID}},
+		{`INSERT INTO warehouse.loans (id, workspace_id, inventory_id, borrower_id, quantity, loaned_at) VALUES ($1, $2, $3, $4, 1, NOW())`,
+			[]any{uuid.New(), workspaceID, inventoryID, borrowerID}},
+	} {
+		_, err := pool.Exec(ctx, stmt.sql, stmt.args...)
+		require.NoError(t, err)
+	}
+}
+
+// seedWorkspaceExtras adds a category with inventory defaults and a rule, an
+// inventory snapshot, an API key and a share link for an item.
+func seedWorkspaceExtras(t *testing.T, ctx context.Context, pool *pgxpool.Pool, workspaceID uuid.UUID) {
+	t.Helper()
+	categoryID, itemID := uuid.New(), uuid.New()
+
+	for _, stmt := range []struct {
+		sql  string
+		args []any
+	}{
+		{`INSERT INTO warehouse.categories (id, workspace_id, name) VALUES ($1, $2, 'Tools')`,
+			[]any{categoryID, workspaceID}},
+		{`INSERT INTO warehouse.category_inventory_defaults (category_id, workspace_id, default_condition) VALUES ($1, $2, 'GOOD')`,
+			[]any{categoryID, workspaceID}},
+		{`INSERT INTO warehouse.category_rules (workspace_id, category_id, pattern, match_type, position) VALUES ($1, $2, 'drill', 'substring', 0)`,
+			[]any{workspaceID, categoryID}},
+		{`INSERT INTO warehouse.inventory_snapshots (workspace_id, label, currency_code, total_quantity, total_value, unvalued_count, items) VALUES ($1, 'Year end', 'EUR', 0, 0, 0, '[]')`,
+			[]any{workspaceID}},
+		{`INSERT INTO auth.api_keys (workspace_id, name, key_prefix, key_hash, role, created_by) VALUES ($1, 'CI', 'hws_test', $2, 'viewer', $3)`,
+			[]any{workspaceID, uuid.New().String(), testfixtures.TestUserID}},
+		{`INSERT INTO warehouse.items (id, workspace_id, name, sku, short_code, min_stock_level) VALUES ($1, $2, 'Drill', 'SKU-1', $3, 0)`,
+			[]any{itemID, workspaceID, "I" + uuid.New().String()[:7]}},
+		{`INSERT INTO warehouse.item_shares (workspace_id, item_id, token_hash) VALUES ($1, $2, $3)`,
+			[]any{workspaceID, itemID, uuid.New().String()}},
+	} {
+		_, err := pool.Exec(ctx, stmt.sql, stmt.args...)
+		require.NoError(t, err)
+	}
+}
+
 func TestWorkspaceRepository_ExistsBySlug(t *testing.T) {
 	if testing.Short() {
 		t.Skip("skipping integration test")
@@ -0,0 +1,92 @@
+// Package printqueue keeps each user's label print queue in Redis.
+package printqueue
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// queueTTL expires queues nobody printed. It is refreshed on every Add, so
+// a queue built up over a working day survives until it is printed.
+const queueTTL = 7 * 24 * time.Hour
+
+// Store is a Redis list per (workspace, user) holding item IDs in the order
+// they were queued. It implements labelprint.QueueStore.
+type Store struct {
+	client *redis.Client
+}
+
+func NewStore(client *redis.Client) *Store {
+	return &Store{client: client}
+}
+
+func key(workspaceID, userID uuid.UUID) string {
+	return fmt.Sprintf("print_queue:%s:%s", workspaceID, userID)
+}
+
+// Add appends itemIDs to the queue. An item already queued is not added
+// twice.
+func (s *Store) Add(ctx context.Context, workspaceID, userID uuid.UUID, itemIDs []uuid.UUID) error {
+	k := key(workspaceID, userID)
+
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, id := range itemIDs {
+			// Remove-then-push keeps one entry per item, at its latest position.
+			pipe.LRem(ctx, k, 0, id.String())
+			pipe.RPush(ctx, k, id.String())
+		}
+		pipe.Expire(ctx, k, queueTTL)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add to print queue: %w", err)
+	}
+	return nil
+}
+
+// Remove takes itemIDs off the queue. IDs that are not queued are ignored.
+func (s *Store) Remove(ctx context.Context, workspaceID, userID uuid.UUID, itemIDs []uuid.UUID) error {
+	k := key(workspaceID, userID)
+
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		for _, id := range itemIDs {
+			pipe.LRem(ctx, k, 0, id.String())
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove from print queue: %w", err)
+	}
+	return nil
+}
+
+// List returns the queued item IDs, oldest first. Entries that are not valid
+// UUIDs are skipped.
+func (s *Store) List(ctx context.Context, workspaceID, userID uuid.UUID) ([]uuid.UUID, error) {
+	raw, err := s.client.LRange(ctx, key(workspaceID, userID), 0, -1).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list print queue: %w", err)
+	}
+
+	ids := make([]uuid.UUID, 0, len(raw))
+	for _, r := range raw {
+		id, err := uuid.Parse(r)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// Clear empties the queue.
+func (s *Store) Clear(ctx context.Context, workspaceID, userID uuid.UUID) error {
+	if err := s.client.Del(ctx, key(workspaceID, userID)).Err(); err != nil {
+		return fmt.Errorf("failed to clear print queue: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+//go:build integration
+// +build integration
+
+package printqueue
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStore(t *testing.T) (*Store, uuid.UUID, uuid.UUID) {
+	t.Helper()
+
+	addr := os.Getenv("TEST_REDIS_ADDR")
+	if addr == "" {
+		addr = "localhost:6379"
+	}
+	client := redis.NewClient(&redis.Options{Addr: addr})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		t.Skipf("skipping integration test: redis ping failed: %v", err)
+	}
+
+	// Fresh workspace/user IDs per test so runs never share keys.
+	workspaceID, userID := uuid.New(), uuid.New()
+	t.Cleanup(func() {
+		client.Del(context.Background(), key(workspaceID, userID))
+		client.Close()
+	})
+
+	return NewStore(client), workspaceID, userID
+}
+
+func TestStore_ListOldestFirst(t *testing.T) {
+	s, ws, user := newTestStore(t)
+	ctx := context.Background()
+
+	a, b, c := uuid.New(), uuid.New(), uuid.New()
+	require.NoError(t, s.Add(ctx, ws, user, []uuid.UUID{a, b}))
+	require.NoError(t, s.Add(ctx, ws, user, []uuid.UUID{c}))
+
+	ids, err := s.List(ctx, ws, user)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{a, b, c}, ids)
+}
+
+func TestStore_AddDoesNotDuplicate(t *testing.T) {
+	s, ws, user := newTestStore(t)
+	ctx := context.Background()
+
+	a, b := uuid.New(), uuid.New()
+	require.NoError(t, s.Add(ctx, ws, user, []uuid.UUID{a, b}))
+	require.NoError(t, s.Add(ctx, ws, user, []uuid.UUID{a}))
+
+	ids, err := s.List(ctx, ws, user)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{b, a}, ids)
+}
+
+func TestStore_RemoveAndClear(t *testing.T) {
+	s, ws, user := newTestStore(t)
+	ctx := context.Background()
+
+	a, b, c := uuid.New(), uuid.New(), uuid.New()
+	require.NoError(t, s.Add(ctx, ws, user, []uuid.UUID{a, b, c}))
+
+	require.NoError(t, s.Remove(ctx, ws, user, []uuid.UUID{b, uuid.New()}))
+	ids, err := s.List(ctx, ws, user)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{a, c}, ids)
+
+	require.NoError(t, s.Clear(ctx, ws, user))
+	ids, err = s.List(ctx, ws, user)
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+}
+
+func TestStore_QueuesArePerUser(t *testing.T) {
+	s, ws, user := newTestStore(t)
+	ctx := context.Background()
+
+	require.NoError(t, s.Add(ctx, ws, user, []uuid.UUID{uuid.New()}))
+
+	ids, err := s.List(ctx, ws, uuid.New())
+	require.NoError(t, err)
+	assert.Empty(t, ids)
+}
@@ -22,6 +22,26 @@ func (q *Queries) CleanupOldActivity(ctx context.Context, createdAt pgtype.Times
 	return err
 }
 
+const countActivityFeed = `-- name: CountActivityFeed :one
+SELECT COUNT(*)::int FROM warehouse.activity_log
+WHERE workspace_id = $1
+  AND ($2::warehouse.activity_entity_enum IS NULL OR entity_type = $2)
+  AND ($3::uuid IS NULL OR user_id = $3)
+`
+
+type CountActivityFeedParams struct {
+	WorkspaceID uuid.UUID                       `json:"workspace_id"`
+	EntityType  NullWarehouseActivityEntityEnum `json:"entity_type"`
+	UserID      pgtype.UUID                     `json:"user_id"`
+}
+
+func (q *Queries) CountActivityFeed(ctx context.Context, arg CountActivityFeedParams) (int32, error) {
+	row := q.db.QueryRow(ctx, countActivityFeed, arg.WorkspaceID, arg.EntityType, arg.UserID)
+	var column_1 int32
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
 const createActivityLog = `-- name: CreateActivityLog :one
 INSERT INTO warehouse.activity_log (id, workspace_id, user_id, action, entity_type, entity_id, entity_name, changes, metadata)
 VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
@@ -249,6 +269,77 @@ func (q *Queries) ListActivityByWorkspace(ctx context.Context, arg ListActivityB
 	return items, nil
 }
 
+const listActivityFeed = `-- name: ListActivityFeed :many
+SELECT a.id, a.workspace_id, a.user_id, a.action, a.entity_type, a.entity_id, a.entity_name, a.changes, a.metadata, a.created_at, u.full_name as user_name
+FROM warehouse.activity_log a
+LEFT JOIN auth.users u ON a.user_id = u.id
+WHERE a.workspace_id = $1
+  AND ($4::warehouse.activity_entity_enum IS NULL OR a.entity_type = $4)
+  AND ($5::uuid IS NULL OR a.user_id = $5)
+ORDER BY a.created_at DESC, a.id DESC
+LIMIT $2 OFFSET $3
+`
+
+type ListActivityFeedParams struct {
+	WorkspaceID uuid.UUID                       `json:"workspace_id"`
+	Limit       int32                           `json:"limit"`
+	Offset      int32                           `json:"offset"`
+	EntityType  NullWarehouseActivityEntityEnum `json:"entity_type"`
+	UserID      pgtype.UUID                     `json:"user_id"`
+}
+
+type ListActivityFeedRow struct {
+	ID          uuid.UUID                   `json:"id"`
+	WorkspaceID uuid.UUID                   `json:"workspace_id"`
+	UserID      pgtype.UUID                 `json:"user_id"`
+	Action      WarehouseActivityActionEnum `json:"action"`
+	EntityType  WarehouseActivityEntityEnum `json:"entity_type"`
+	EntityID    uuid.UUID                   `json:"entity_id"`
+	EntityName  *string                     `json:"entity_name"`
+	Changes     []byte                      `json:"changes"`
+	Metadata    []byte                      `json:"metadata"`
+	CreatedAt   pgtype.Timestamptz          `json:"created_at"`
+	UserName    *string                     `json:"user_name"`
+}
+
+func (q *Queries) ListActivityFeed(ctx context.Context, arg ListActivityFeedParams) ([]ListActivityFeedRow, error) {
+	rows, err := q.db.Query(ctx, listActivityFeed,
+		arg.WorkspaceID,
+		arg.Limit,
+		arg.Offset,
+		arg.EntityType,
+		arg.UserID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListActivityFeedRow{}
+	for rows.Next() {
+		var i ListActivityFeedRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.UserID,
+			&i.Action,
+			&i.EntityType,
+			&i.EntityID,
+			&i.EntityName,
+			&i.Changes,
+			&i.Metadata,
+			&i.CreatedAt,
+			&i.UserName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listRecentActivity = `-- name: ListRecentActivity :many
 SELECT a.id, a.workspace_id, a.user_id, a.action, a.entity_type, a.entity_id, a.entity_name, a.changes, a.metadata, a.created_at, u.full_name as user_name
 FROM warehouse.activity_log a
@@ -7,11 +7,50 @@ package queries
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countTopValueExclusions = `-- name: CountTopValueExclusions :one
+WITH settings AS (
+    SELECT
+        COALESCE(cs.base_currency, 'EUR') AS base_currency,
+        COALESCE(cs.exchange_rates, '{}'::jsonb) AS exchange_rates
+    FROM (SELECT 1) AS one
+    LEFT JOIN warehouse.currency_settings cs ON cs.workspace_id = $1
+)
+SELECT
+    COUNT(*) FILTER (WHERE inv.purchase_price IS NULL)::int AS unpriced,
+    COUNT(*) FILTER (
+        WHERE inv.purchase_price IS NOT NULL
+          AND COALESCE(inv.currency_code, s.base_currency) <> s.base_currency
+          AND (s.exchange_rates ->> inv.currency_code) IS NULL
+    )::int AS unconverted
+FROM warehouse.inventory inv
+JOIN warehouse.items it ON it.id = inv.item_id
+CROSS JOIN settings s
+WHERE inv.workspace_id = $1
+  AND inv.is_archived = false
+  AND it.is_archived = false
+`
+
+type CountTopValueExclusionsRow struct {
+	Unpriced    int32 `json:"unpriced"`
+	Unconverted int32 `json:"unconverted"`
+}
+
+// Inventory rows GetTopValueItems leaves out: unpriced rows have no
+// purchase_price; unconverted rows are priced in a currency other than the
+// base currency with no exchange rate configured.
+func (q *Queries) CountTopValueExclusions(ctx context.Context, workspaceID uuid.UUID) (CountTopValueExclusionsRow, error) {
+	row := q.db.QueryRow(ctx, countTopValueExclusions, workspaceID)
+	var i CountTopValueExclusionsRow
+	err := row.Scan(&i.Unpriced, &i.Unconverted)
+	return i, err
+}
+
 const getCategoryStats = `-- name: GetCategoryStats :many
 SELECT
     c.id,
@@ -76,13 +115,22 @@ SELECT
     (SELECT COUNT(*) FROM warehouse.containers con WHERE con.workspace_id = $1 AND con.is_archived = false)::int as total_containers,
     (SELECT COUNT(*) FROM warehouse.loans ln WHERE ln.workspace_id = $1 AND ln.returned_at IS NULL)::int as active_loans,
     (SELECT COUNT(*) FROM warehouse.loans ln2 WHERE ln2.workspace_id = $1 AND ln2.returned_at IS NULL AND ln2.due_date < CURRENT_DATE)::int as overdue_loans,
-    (SELECT COUNT(*) FROM (
+    (SELECT COUNT(DISTINCT low_stock.id) FROM (
         SELECT i.id
         FROM warehouse.items i
         LEFT JOIN warehouse.inventory inven ON i.id = inven.item_id AND inven.is_archived = false
         WHERE i.workspace_id = $1 AND i.is_archived = false AND i.min_stock_level > 0
+          AND NOT EXISTS (SELECT 1 FROM warehouse.item_location_stock_levels sl WHERE sl.item_id = i.id)
         GROUP BY i.id, i.min_stock_level
         HAVING COALESCE(SUM(inven.quantity), 0) < i.min_stock_level
+        UNION ALL
+        SELECT sl.item_id
+        FROM warehouse.item_location_stock_levels sl
+        JOIN warehouse.items i ON i.id = sl.item_id AND i.is_archived = false
+        LEFT JOIN warehouse.inventory inven ON inven.item_id = sl.item_id AND inven.location_id = sl.location_id AND inven.is_archived = false
+        WHERE sl.workspace_id = $1
+        GROUP BY sl.item_id, sl.location_id, sl.min_stock_level
+        HAVING COALESCE(SUM(inven.quantity), 0) < sl.min_stock_level
     ) low_stock)::int as low_stock_items,
     (SELECT COUNT(*) FROM warehouse.categories cat WHERE cat.workspace_id = $1 AND cat.is_archived = false)::int as total_categories,
     (SELECT COUNT(*) FROM warehouse.borrowers bor WHERE bor.workspace_id = $1 AND bor.is_archived = false)::int as total_borrowers
@@ -119,6 +167,92 @@ func (q *Queries) GetDashboardStats(ctx context.Context, workspaceID uuid.UUID)
 	return i, err
 }
 
+const getInventoryAging = `-- name: GetInventoryAging :many
+WITH settings AS (
+    SELECT
+        COALESCE(cs.base_currency, 'EUR') AS base_currency,
+        COALESCE(cs.exchange_rates, '{}'::jsonb) AS exchange_rates
+    FROM (SELECT 1) AS one
+    LEFT JOIN warehouse.currency_settings cs ON cs.workspace_id = $1
+),
+aged AS (
+    SELECT
+        CASE
+            WHEN inv.date_acquired IS NULL THEN 'unknown'
+            WHEN EXTRACT(YEAR FROM inv.date_acquired) >= $2::int THEN 'this_year'
+            WHEN EXTRACT(YEAR FROM inv.date_acquired) >= $2::int - 2 THEN '1_2_years'
+            ELSE '3_plus_years'
+        END AS bucket,
+        inv.quantity,
+        inv.purchase_price,
+        inv.purchase_price * CASE
+            WHEN COALESCE(inv.currency_code, s.base_currency) = s.base_currency THEN 1::numeric
+            ELSE (s.exchange_rates ->> inv.currency_code)::numeric
+        END AS base_price
+    FROM warehouse.inventory inv
+    JOIN warehouse.items it ON it.id = inv.item_id
+    CROSS JOIN settings s
+    WHERE inv.workspace_id = $1
+      AND inv.is_archived = false
+      AND it.is_archived = false
+)
+SELECT
+    bucket::text AS bucket,
+    COUNT(*)::int AS inventory_count,
+    COALESCE(SUM(quantity), 0)::bigint AS quantity,
+    COALESCE(ROUND(SUM(base_price * quantity)), 0)::bigint AS total_value,
+    COUNT(*) FILTER (WHERE purchase_price IS NULL)::int AS unpriced,
+    COUNT(*) FILTER (WHERE purchase_price IS NOT NULL AND base_price IS NULL)::int AS unconverted
+FROM aged
+GROUP BY bucket
+`
+
+type GetInventoryAgingParams struct {
+	WorkspaceID uuid.UUID `json:"workspace_id"`
+	CurrentYear int32     `json:"current_year"`
+}
+
+type GetInventoryAgingRow struct {
+	Bucket         string `json:"bucket"`
+	InventoryCount int32  `json:"inventory_count"`
+	Quantity       int64  `json:"quantity"`
+	TotalValue     int64  `json:"total_value"`
+	Unpriced       int32  `json:"unpriced"`
+	Unconverted    int32  `json:"unconverted"`
+}
+
+// Inventory grouped by how many calendar years before current_year it was
+// acquired (date_acquired): this_year, 1_2_years, 3_plus_years, or unknown
+// without a date. total_value is purchase_price * quantity converted to the
+// workspace base currency as in GetTopValueItems; unpriced and unconverted
+// count the rows it leaves out.
+func (q *Queries) GetInventoryAging(ctx context.Context, arg GetInventoryAgingParams) ([]GetInventoryAgingRow, error) {
+	rows, err := q.db.Query(ctx, getInventoryAging, arg.WorkspaceID, arg.CurrentYear)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetInventoryAgingRow{}
+	for rows.Next() {
+		var i GetInventoryAgingRow
+		if err := rows.Scan(
+			&i.Bucket,
+			&i.InventoryCount,
+			&i.Quantity,
+			&i.TotalValue,
+			&i.Unpriced,
+			&i.Unconverted,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getInventoryValueByLocation = `-- name: GetInventoryValueByLocation :many
 SELECT
     l.id,
@@ -127,7 +261,10 @@ SELECT
     COALESCE(SUM(inv.quantity), 0)::int as total_quantity,
     COALESCE(SUM(COALESCE(inv.purchase_price, 0) * inv.quantity), 0)::int as total_value
 FROM warehouse.locations l
-LEFT JOIN warehouse.inventory inv ON inv.location_id = l.id AND inv.is_archived = false
+LEFT JOIN (
+    warehouse.inventory inv
+    JOIN warehouse.items it ON it.id = inv.item_id AND it.is_archived = false
+) ON inv.location_id = l.id AND inv.is_archived = false
 WHERE l.workspace_id = $1 AND l.is_archived = false
 GROUP BY l.id, l.name
 ORDER BY total_value DESC
@@ -369,6 +506,91 @@ func (q *Queries) GetRecentActivity(ctx context.Context, arg GetRecentActivityPa
 	return items, nil
 }
 
+const getStaleInventory = `-- name: GetStaleInventory :many
+SELECT
+    inv.id,
+    inv.item_id,
+    it.name AS item_name,
+    it.sku,
+    inv.location_id,
+    l.name AS location_name,
+    inv.container_id,
+    c.name AS container_name,
+    inv.quantity,
+    activity.last_activity_at::timestamptz AS last_activity_at
+FROM warehouse.inventory inv
+JOIN warehouse.items it ON it.id = inv.item_id
+JOIN warehouse.locations l ON l.id = inv.location_id
+LEFT JOIN warehouse.containers c ON c.id = inv.container_id
+CROSS JOIN LATERAL (
+    SELECT GREATEST(
+        inv.updated_at,
+        inv.last_used_at,
+        (SELECT MAX(m.created_at) FROM warehouse.inventory_movements m WHERE m.inventory_id = inv.id),
+        (SELECT MAX(COALESCE(ln.returned_at, ln.loaned_at)) FROM warehouse.loans ln WHERE ln.inventory_id = inv.id)
+    ) AS last_activity_at
+) activity
+WHERE inv.workspace_id = $1
+  AND inv.is_archived = false
+  AND it.is_archived = false
+  AND activity.last_activity_at < $2::timestamptz
+ORDER BY activity.last_activity_at ASC, inv.id
+LIMIT $3
+`
+
+type GetStaleInventoryParams struct {
+	WorkspaceID uuid.UUID `json:"workspace_id"`
+	Cutoff      time.Time `json:"cutoff"`
+	RowLimit    int32     `json:"row_limit"`
+}
+
+type GetStaleInventoryRow struct {
+	ID             uuid.UUID          `json:"id"`
+	ItemID         uuid.UUID          `json:"item_id"`
+	ItemName       string             `json:"item_name"`
+	Sku            string             `json:"sku"`
+	LocationID     uuid.UUID          `json:"location_id"`
+	LocationName   string             `json:"location_name"`
+	ContainerID    pgtype.UUID        `json:"container_id"`
+	ContainerName  *string            `json:"container_name"`
+	Quantity       int32              `json:"quantity"`
+	LastActivityAt pgtype.Timestamptz `json:"last_activity_at"`
+}
+
+// Inventory rows whose most recent activity is older than the cutoff. Activity
+// is the latest of the row's own updated_at/last_used_at, its newest movement
+// and its newest loan checkout/return. Oldest activity first.
+func (q *Queries) GetStaleInventory(ctx context.Context, arg GetStaleInventoryParams) ([]GetStaleInventoryRow, error) {
+	rows, err := q.db.Query(ctx, getStaleInventory, arg.WorkspaceID, arg.Cutoff, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetStaleInventoryRow{}
+	for rows.Next() {
+		var i GetStaleInventoryRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ItemID,
+			&i.ItemName,
+			&i.Sku,
+			&i.LocationID,
+			&i.LocationName,
+			&i.ContainerID,
+			&i.ContainerName,
+			&i.Quantity,
+			&i.LastActivityAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getTopBorrowers = `-- name: GetTopBorrowers :many
 SELECT
     b.id,
@@ -422,3 +644,180 @@ func (q *Queries) GetTopBorrowers(ctx context.Context, arg GetTopBorrowersParams
 	}
 	return items, nil
 }
+
+const getTopValueItems = `-- name: GetTopValueItems :many
+WITH settings AS (
+    SELECT
+        COALESCE(cs.base_currency, 'EUR') AS base_currency,
+        COALESCE(cs.exchange_rates, '{}'::jsonb) AS exchange_rates
+    FROM (SELECT 1) AS one
+    LEFT JOIN warehouse.currency_settings cs ON cs.workspace_id = $1
+),
+converted AS (
+    SELECT
+        inv.item_id,
+        inv.quantity,
+        inv.purchase_price * CASE
+            WHEN COALESCE(inv.currency_code, s.base_currency) = s.base_currency THEN 1::numeric
+            ELSE (s.exchange_rates ->> inv.currency_code)::numeric
+        END AS base_price
+    FROM warehouse.inventory inv
+    CROSS JOIN settings s
+    WHERE inv.workspace_id = $1
+      AND inv.is_archived = false
+      AND inv.purchase_price IS NOT NULL
+)
+SELECT
+    it.id,
+    it.name,
+    it.sku,
+    SUM(c.quantity)::int AS quantity,
+    ROUND(SUM(c.base_price * c.quantity) / SUM(c.quantity))::bigint AS unit_price,
+    ROUND(SUM(c.base_price * c.quantity))::bigint AS total_value
+FROM converted c
+JOIN warehouse.items it ON it.id = c.item_id
+WHERE c.base_price IS NOT NULL
+  AND it.is_archived = false
+GROUP BY it.id, it.name, it.sku
+HAVING SUM(c.quantity) > 0
+ORDER BY total_value DESC, it.name
+LIMIT $2
+`
+
+type GetTopValueItemsParams struct {
+	WorkspaceID uuid.UUID `json:"workspace_id"`
+	RowLimit    int32     `json:"row_limit"`
+}
+
+type GetTopValueItemsRow struct {
+	ID         uuid.UUID `json:"id"`
+	Name       string    `json:"name"`
+	Sku        string    `json:"sku"`
+	Quantity   int32     `json:"quantity"`
+	UnitPrice  int64     `json:"unit_price"`
+	TotalValue int64     `json:"total_value"`
+}
+
+// Items ranked by total inventory value (purchase_price * quantity), with each
+// inventory row's price converted to the workspace base currency. Rows without
+// a purchase price, or priced in a currency with no configured exchange rate,
+// are left out; CountTopValueExclusions counts them. unit_price is the
+// quantity-weighted average across the item's inventory.
+func (q *Queries) GetTopValueItems(ctx context.Context, arg GetTopValueItemsParams) ([]GetTopValueItemsRow, error) {
+	rows, err := q.db.Query(ctx, getTopValueItems, arg.WorkspaceID, arg.RowLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []GetTopValueItemsRow{}
+	for rows.Next() {
+		var i GetTopValueItemsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Sku,
+			&i.Quantity,
+			&i.UnitPrice,
+			&i.TotalValue,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getWorkspaceInventoryValue = `-- name: GetWorkspaceInventoryValue :one
+WITH settings AS (
+    SELECT
+        COALESCE(cs.base_currency, 'EUR') AS base_currency,
+        COALESCE(cs.exchange_rates, '{}'::jsonb) AS exchange_rates
+    FROM (SELECT 1) AS one
+    LEFT JOIN warehouse.currency_settings cs ON cs.workspace_id = $1
+)
+SELECT
+    COALESCE(ROUND(SUM(inv.quantity * inv.purchase_price * CASE
+        WHEN COALESCE(inv.currency_code, s.base_currency) = s.base_currency THEN 1::numeric
+        ELSE (s.exchange_rates ->> inv.currency_code)::numeric
+    END)), 0)::bigint AS total_value
+FROM warehouse.inventory inv
+JOIN warehouse.items it ON it.id = inv.item_id
+CROSS JOIN settings s
+WHERE inv.workspace_id = $1
+  AND inv.is_archived = false
+  AND it.is_archived = false
+  AND inv.purchase_price IS NOT NULL
+`
+
+// Total purchase value of the workspace's inventory in its base currency,
+// converted as in GetTopValueItems. Unpriced rows and rows with no exchange
+// rate are left out.
+func (q *Queries) GetWorkspaceInventoryValue(ctx context.Context, workspaceID uuid.UUID) (int64, error) {
+	row := q.db.QueryRow(ctx, getWorkspaceInventoryValue, workspaceID)
+	var total_value int64
+	err := row.Scan(&total_value)
+	return total_value, err
+}
+
+const getWorkspaceStats = `-- name: GetWorkspaceStats :one
+SELECT
+    (SELECT COUNT(*) FROM warehouse.items it WHERE it.workspace_id = $1 AND it.is_archived = false)::int AS total_items,
+    (SELECT COALESCE(SUM(inv.quantity), 0) FROM warehouse.inventory inv WHERE inv.workspace_id = $1 AND inv.is_archived = false)::bigint AS total_quantity,
+    (SELECT COUNT(*) FROM warehouse.loans ln WHERE ln.workspace_id = $1 AND ln.returned_at IS NULL)::int AS active_loans,
+    (SELECT COUNT(*) FROM warehouse.loans ln2 WHERE ln2.workspace_id = $1 AND ln2.returned_at IS NULL AND ln2.due_date < CURRENT_DATE)::int AS overdue_loans,
+    (SELECT COUNT(DISTINCT low_stock.id) FROM (
+        SELECT i.id
+        FROM warehouse.items i
+        LEFT JOIN warehouse.inventory inven ON i.id = inven.item_id AND inven.is_archived = false
+        WHERE i.workspace_id = $1 AND i.is_archived = false AND i.min_stock_level > 0
+          AND NOT EXISTS (SELECT 1 FROM warehouse.item_location_stock_levels sl WHERE sl.item_id = i.id)
+        GROUP BY i.id, i.min_stock_level
+        HAVING COALESCE(SUM(inven.quantity), 0) < i.min_stock_level
+        UNION ALL
+        SELECT sl.item_id
+        FROM warehouse.item_location_stock_levels sl
+        JOIN warehouse.items i ON i.id = sl.item_id AND i.is_archived = false
+        LEFT JOIN warehouse.inventory inven ON inven.item_id = sl.item_id AND inven.location_id = sl.location_id AND inven.is_archived = false
+        WHERE sl.workspace_id = $1
+        GROUP BY sl.item_id, sl.location_id, sl.min_stock_level
+        HAVING COALESCE(SUM(inven.quantity), 0) < sl.min_stock_level
+    ) low_stock)::int AS low_stock_items,
+    (SELECT COUNT(*) FROM warehouse.inventory ex
+     WHERE ex.workspace_id = $1
+       AND ex.is_archived = false
+       AND ex.expiration_date >= CURRENT_DATE
+       AND ex.expiration_date <= CURRENT_DATE + $2::int)::int AS expiring_soon
+`
+
+type GetWorkspaceStatsParams struct {
+	WorkspaceID  uuid.UUID `json:"workspace_id"`
+	ExpiringDays int32     `json:"expiring_days"`
+}
+
+type GetWorkspaceStatsRow struct {
+	TotalItems    int32 `json:"total_items"`
+	TotalQuantity int64 `json:"total_quantity"`
+	ActiveLoans   int32 `json:"active_loans"`
+	OverdueLoans  int32 `json:"overdue_loans"`
+	LowStockItems int32 `json:"low_stock_items"`
+	ExpiringSoon  int32 `json:"expiring_soon"`
+}
+
+// Headline counts for the dashboard summary. expiring_soon counts inventory
+// whose expiration_date falls between today and today + expiring_days.
+func (q *Queries) GetWorkspaceStats(ctx context.Context, arg GetWorkspaceStatsParams) (GetWorkspaceStatsRow, error) {
+	row := q.db.QueryRow(ctx, getWorkspaceStats, arg.WorkspaceID, arg.ExpiringDays)
+	var i GetWorkspaceStatsRow
+	err := row.Scan(
+		&i.TotalItems,
+		&i.TotalQuantity,
+		&i.ActiveLoans,
+		&i.OverdueLoans,
+		&i.LowStockItems,
+		&i.ExpiringSoon,
+	)
+	return i, err
+}
@@ -28,18 +28,19 @@ func (q *Queries) ArchiveBorrower(ctx context.Context, arg ArchiveBorrowerParams
 }
 
 const createBorrower = `-- name: CreateBorrower :one
-INSERT INTO warehouse.borrowers (id, workspace_id, name, email, phone, notes)
-VALUES ($1, $2, $3, $4, $5, $6)
-RETURNING id, workspace_id, name, email, phone, notes, is_archived, search_vector, created_at, updated_at
+INSERT INTO warehouse.borrowers (id, workspace_id, name, email, phone, notes, reminder_channel)
+VALUES ($1, $2, $3, $4, $5, $6, $7)
+RETURNING id, workspace_id, name, email, phone, notes, is_archived, search_vector, created_at, updated_at, reminder_channel
 `
 
 type CreateBorrowerParams struct {
-	ID          uuid.UUID `json:"id"`
-	WorkspaceID uuid.UUID `json:"workspace_id"`
-	Name        string    `json:"name"`
-	Email       *string   `json:"email"`
-	Phone       *string   `json:"phone"`
-	Notes       *string   `json:"notes"`
+	ID              uuid.UUID `json:"id"`
+	WorkspaceID     uuid.UUID `json:"workspace_id"`
+	Name            string    `json:"name"`
+	Email           *string   `json:"email"`
+	Phone           *string   `json:"phone"`
+	Notes           *string   `json:"notes"`
+	ReminderChannel string    `json:"reminder_channel"`
 }
 
 func (q *Queries) CreateBorrower(ctx context.Context, arg CreateBorrowerParams) (WarehouseBorrower, error) {
@@ -50,6 +51,7 @@ func (q *Queries) CreateBorrower(ctx context.Context, arg CreateBorrowerParams)
 		arg.Email,
 		arg.Phone,
 		arg.Notes,
+		arg.ReminderChannel,
 	)
 	var i WarehouseBorrower
 	err := row.Scan(
@@ -63,6 +65,7 @@ func (q *Queries) CreateBorrower(ctx context.Context, arg CreateBorrowerParams)
 		&i.SearchVector,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ReminderChannel,
 	)
 	return i, err
 }
@@ -82,7 +85,7 @@ func (q *Queries) DeleteBorrower(ctx context.Context, arg DeleteBorrowerParams)
 }
 
 const getBorrower = `-- name: GetBorrower :one
-SELECT id, workspace_id, name, email, phone, notes, is_archived, search_vector, created_at, updated_at FROM warehouse.borrowers
+SELECT id, workspace_id, name, email, phone, notes, is_archived, search_vector, created_at, updated_at, reminder_channel FROM warehouse.borrowers
 WHERE id = $1 AND workspace_id = $2
 `
 
@@ -105,6 +108,7 @@ func (q *Queries) GetBorrower(ctx context.Context, arg GetBorrowerParams) (Wareh
 		&i.SearchVector,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ReminderChannel,
 	)
 	return i, err
 }
@@ -124,7 +128,7 @@ func (q *Queries) HasActiveLoans(ctx context.Context, borrowerID uuid.UUID) (boo
 }
 
 const listBorrowers = `-- name: ListBorrowers :many
-SELECT id, workspace_id, name, email, phone, notes, is_archived, search_vector, created_at, updated_at FROM warehouse.borrowers
+SELECT id, workspace_id, name, email, phone, notes, is_archived, search_vector, created_at, updated_at, reminder_channel FROM warehouse.borrowers
 WHERE workspace_id = $1
   AND ($4::bool IS NULL OR $4::bool = true OR is_archived = false)
 ORDER BY name
@@ -163,6 +167,7 @@ func (q *Queries) ListBorrowers(ctx context.Context, arg ListBorrowersParams) ([
 			&i.SearchVector,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.ReminderChannel,
 		); err != nil {
 			return nil, err
 		}
@@ -191,7 +196,7 @@ func (q *Queries) RestoreBorrower(ctx context.Context, arg RestoreBorrowerParams
 }
 
 const searchBorrowers = `-- name: SearchBorrowers :many
-SELECT id, workspace_id, name, email, phone, notes, is_archived, search_vector, created_at, updated_at FROM warehouse.borrowers
+SELECT id, workspace_id, name, email, phone, notes, is_archived, search_vector, created_at, updated_at, reminder_channel FROM warehouse.borrowers
 WHERE workspace_id = $1
   AND is_archived = false
   AND search_vector @@ plainto_tsquery('english', $2)
@@ -225,6 +230,7 @@ func (q *Queries) SearchBorrowers(ctx context.Context, arg SearchBorrowersParams
 			&i.SearchVector,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.ReminderChannel,
 		); err != nil {
 			return nil, err
 		}
@@ -238,18 +244,19 @@ func (q *Queries) SearchBorrowers(ctx context.Context, arg SearchBorrowersParams
 
 const updateBorrower = `-- name: UpdateBorrower :one
 UPDATE warehouse.borrowers
-SET name = $3, email = $4, phone = $5, notes = $6, updated_at = now()
+SET name = $3, email = $4, phone = $5, notes = $6, reminder_channel = $7, updated_at = now()
 WHERE id = $1 AND workspace_id = $2
-RETURNING id, workspace_id, name, email, phone, notes, is_archived, search_vector, created_at, updated_at
+RETURNING id, workspace_id, name, email, phone, notes, is_archived, search_vector, created_at, updated_at, reminder_channel
 `
 
 type UpdateBorrowerParams struct {
-	ID          uuid.UUID `json:"id"`
-	WorkspaceID uuid.UUID `json:"workspace_id"`
-	Name        string    `json:"name"`
-	Email       *string   `json:"email"`
-	Phone       *string   `json:"phone"`
-	Notes       *string   `json:"notes"`
+	ID              uuid.UUID `json:"id"`
+	WorkspaceID     uuid.UUID `json:"workspace_id"`
+	Name            string    `json:"name"`
+	Email           *string   `json:"email"`
+	Phone           *string   `json:"phone"`
+	Notes           *string   `json:"notes"`
+	ReminderChannel string    `json:"reminder_channel"`
 }
 
 func (q *Queries) UpdateBorrower(ctx context.Context, arg UpdateBorrowerParams) (WarehouseBorrower, error) {
@@ -260,6 +267,7 @@ func (q *Queries) UpdateBorrower(ctx context.Context, arg UpdateBorrowerParams)
 		arg.Email,
 		arg.Phone,
 		arg.Notes,
+		arg.ReminderChannel,
 	)
 	var i WarehouseBorrower
 	err := row.Scan(
@@ -273,6 +281,7 @@ func (q *Queries) UpdateBorrower(ctx context.Context, arg UpdateBorrowerParams)
 		&i.SearchVector,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ReminderChannel,
 	)
 	return i, err
 }
@@ -233,6 +233,52 @@ func (q *Queries) ListRootCategories(ctx context.Context, workspaceID uuid.UUID)
 	return items, nil
 }
 
+const moveCategoryChildren = `-- name: MoveCategoryChildren :execrows
+UPDATE warehouse.categories
+SET parent_category_id = $1::uuid,
+    updated_at = now()
+WHERE workspace_id = $2
+  AND parent_category_id = $3
+`
+
+type MoveCategoryChildrenParams struct {
+	ToCategoryID   uuid.UUID   `json:"to_category_id"`
+	WorkspaceID    uuid.UUID   `json:"workspace_id"`
+	FromCategoryID pgtype.UUID `json:"from_category_id"`
+}
+
+// Re-parent every direct child of one category onto another (category merge).
+func (q *Queries) MoveCategoryChildren(ctx context.Context, arg MoveCategoryChildrenParams) (int64, error) {
+	result, err := q.db.Exec(ctx, moveCategoryChildren, arg.ToCategoryID, arg.WorkspaceID, arg.FromCategoryID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const moveCategoryItems = `-- name: MoveCategoryItems :execrows
+UPDATE warehouse.items
+SET category_id = $1::uuid,
+    updated_at = now()
+WHERE workspace_id = $2
+  AND category_id = $3
+`
+
+type MoveCategoryItemsParams struct {
+	ToCategoryID   uuid.UUID   `json:"to_category_id"`
+	WorkspaceID    uuid.UUID   `json:"workspace_id"`
+	FromCategoryID pgtype.UUID `json:"from_category_id"`
+}
+
+// Re-point every item in one category to another (category merge).
+func (q *Queries) MoveCategoryItems(ctx context.Context, arg MoveCategoryItemsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, moveCategoryItems, arg.ToCategoryID, arg.WorkspaceID, arg.FromCategoryID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const restoreCategory = `-- name: RestoreCategory :exec
 UPDATE warehouse.categories
 SET is_archived = false, updated_at = now()
@@ -9,6 +9,7 @@ import (
 	"context"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const archiveContainer = `-- name: ArchiveContainer :exec
@@ -113,6 +114,69 @@ func (q *Queries) GetContainer(ctx context.Context, arg GetContainerParams) (War
 	return i, err
 }
 
+const getContainerContentsSummary = `-- name: GetContainerContentsSummary :one
+WITH settings AS (
+    SELECT
+        COALESCE(cs.base_currency, 'EUR') AS base_currency,
+        COALESCE(cs.exchange_rates, '{}'::jsonb) AS exchange_rates
+    FROM (SELECT 1) AS one
+    LEFT JOIN warehouse.currency_settings cs ON cs.workspace_id = $1
+),
+contents AS (
+    SELECT
+        inv.quantity,
+        inv.purchase_price,
+        inv.quantity * inv.purchase_price * CASE
+            WHEN COALESCE(inv.currency_code, s.base_currency) = s.base_currency THEN 1::numeric
+            ELSE (s.exchange_rates ->> inv.currency_code)::numeric
+        END AS value
+    FROM warehouse.inventory inv
+    CROSS JOIN settings s
+    WHERE inv.workspace_id = $1
+      AND inv.container_id = $2
+      AND inv.is_archived = false
+)
+SELECT
+    (SELECT base_currency FROM settings)::text AS base_currency,
+    COUNT(*)::int AS entries,
+    COALESCE(SUM(c.quantity), 0)::bigint AS total_quantity,
+    COALESCE(ROUND(SUM(c.value)), 0)::bigint AS total_value,
+    COUNT(*) FILTER (WHERE c.purchase_price IS NULL)::int AS unpriced,
+    COUNT(*) FILTER (WHERE c.purchase_price IS NOT NULL AND c.value IS NULL)::int AS unconverted
+FROM contents c
+`
+
+type GetContainerContentsSummaryParams struct {
+	WorkspaceID uuid.UUID   `json:"workspace_id"`
+	ContainerID pgtype.UUID `json:"container_id"`
+}
+
+type GetContainerContentsSummaryRow struct {
+	BaseCurrency  string `json:"base_currency"`
+	Entries       int32  `json:"entries"`
+	TotalQuantity int64  `json:"total_quantity"`
+	TotalValue    int64  `json:"total_value"`
+	Unpriced      int32  `json:"unpriced"`
+	Unconverted   int32  `json:"unconverted"`
+}
+
+// Totals over ListContainerContents. total_value is in the workspace base
+// currency and leaves out unpriced rows and rows priced in a currency with no
+// exchange rate, which are counted as unpriced and unconverted instead.
+func (q *Queries) GetContainerContentsSummary(ctx context.Context, arg GetContainerContentsSummaryParams) (GetContainerContentsSummaryRow, error) {
+	row := q.db.QueryRow(ctx, getContainerContentsSummary, arg.WorkspaceID, arg.ContainerID)
+	var i GetContainerContentsSummaryRow
+	err := row.Scan(
+		&i.BaseCurrency,
+		&i.Entries,
+		&i.TotalQuantity,
+		&i.TotalValue,
+		&i.Unpriced,
+		&i.Unconverted,
+	)
+	return i, err
+}
+
 const getContainerByShortCode = `-- name: GetContainerByShortCode :one
 SELECT id, workspace_id, name, location_id, description, capacity, short_code, is_archived, search_vector, created_at, updated_at FROM warehouse.containers
 WHERE workspace_id = $1 AND short_code = $2
@@ -142,6 +206,98 @@ func (q *Queries) GetContainerByShortCode(ctx context.Context, arg GetContainerB
 	return i, err
 }
 
+const listContainerContents = `-- name: ListContainerContents :many
+WITH settings AS (
+    SELECT
+        COALESCE(cs.base_currency, 'EUR') AS base_currency,
+        COALESCE(cs.exchange_rates, '{}'::jsonb) AS exchange_rates
+    FROM (SELECT 1) AS one
+    LEFT JOIN warehouse.currency_settings cs ON cs.workspace_id = $1
+)
+SELECT
+    inv.id,
+    inv.item_id,
+    it.name AS item_name,
+    it.sku AS item_sku,
+    inv.quantity,
+    inv.condition,
+    inv.status,
+    inv.purchase_price,
+    inv.currency_code,
+    ROUND(inv.quantity * inv.purchase_price * CASE
+        WHEN COALESCE(inv.currency_code, s.base_currency) = s.base_currency THEN 1::numeric
+        ELSE (s.exchange_rates ->> inv.currency_code)::numeric
+    END)::bigint AS value
+FROM warehouse.inventory inv
+JOIN warehouse.items it ON it.id = inv.item_id
+CROSS JOIN settings s
+WHERE inv.workspace_id = $1
+  AND inv.container_id = $2
+  AND inv.is_archived = false
+ORDER BY it.name, inv.created_at, inv.id
+LIMIT $3 OFFSET $4
+`
+
+type ListContainerContentsParams struct {
+	WorkspaceID uuid.UUID   `json:"workspace_id"`
+	ContainerID pgtype.UUID `json:"container_id"`
+	RowLimit    int32       `json:"row_limit"`
+	RowOffset   int32       `json:"row_offset"`
+}
+
+type ListContainerContentsRow struct {
+	ID            uuid.UUID                      `json:"id"`
+	ItemID        uuid.UUID                      `json:"item_id"`
+	ItemName      string                         `json:"item_name"`
+	ItemSku       string                         `json:"item_sku"`
+	Quantity      int32                          `json:"quantity"`
+	Condition     NullWarehouseItemConditionEnum `json:"condition"`
+	Status        NullWarehouseItemStatusEnum    `json:"status"`
+	PurchasePrice *int32                         `json:"purchase_price"`
+	CurrencyCode  *string                        `json:"currency_code"`
+	Value         *int64                         `json:"value"`
+}
+
+// Inventory stored in a container with its item, ordered by item name. value
+// is quantity * purchase_price converted to the workspace base currency as in
+// GetTopValueItems; it is NULL for unpriced rows and rows priced in a
+// currency with no exchange rate.
+func (q *Queries) ListContainerContents(ctx context.Context, arg ListContainerContentsParams) ([]ListContainerContentsRow, error) {
+	rows, err := q.db.Query(ctx, listContainerContents,
+		arg.WorkspaceID,
+		arg.ContainerID,
+		arg.RowLimit,
+		arg.RowOffset,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListContainerContentsRow{}
+	for rows.Next() {
+		var i ListContainerContentsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ItemID,
+			&i.ItemName,
+			&i.ItemSku,
+			&i.Quantity,
+			&i.Condition,
+			&i.Status,
+			&i.PurchasePrice,
+			&i.CurrencyCode,
+			&i.Value,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listContainersByLocation = `-- name: ListContainersByLocation :many
 SELECT id, workspace_id, name, location_id, description, capacity, short_code, is_archived, search_vector, created_at, updated_at FROM warehouse.containers
 WHERE workspace_id = $1 AND location_id = $2 AND is_archived = false
@@ -13,6 +13,29 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const cleanupExpiredDeletedRecords = `-- name: CleanupExpiredDeletedRecords :execrows
+DELETE FROM warehouse.deleted_records d
+WHERE d.deleted_at < $1::timestamptz - make_interval(days => COALESCE(
+    (SELECT s.trash_retention_days FROM warehouse.trash_settings s WHERE s.workspace_id = d.workspace_id),
+    $2::int
+))
+`
+
+type CleanupExpiredDeletedRecordsParams struct {
+	Now                  time.Time `json:"now"`
+	DefaultRetentionDays int32     `json:"default_retention_days"`
+}
+
+// Each workspace keeps its records for its own trash_retention_days, or
+// default_retention_days when it has no trash settings.
+func (q *Queries) CleanupExpiredDeletedRecords(ctx context.Context, arg CleanupExpiredDeletedRecordsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, cleanupExpiredDeletedRecords, arg.Now, arg.DefaultRetentionDays)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const cleanupOldDeletedRecords = `-- name: CleanupOldDeletedRecords :exec
 DELETE FROM warehouse.deleted_records
 WHERE deleted_at < $1
@@ -94,3 +117,46 @@ func (q *Queries) ListDeletedSince(ctx context.Context, arg ListDeletedSincePara
 	}
 	return items, nil
 }
+
+const purgeExpiredDeletedRecords = `-- name: PurgeExpiredDeletedRecords :many
+DELETE FROM warehouse.deleted_records d
+WHERE d.deleted_at < $1::timestamptz - make_interval(days => COALESCE(
+    (SELECT s.trash_retention_days FROM warehouse.trash_settings s WHERE s.workspace_id = d.workspace_id),
+    $2::int
+))
+RETURNING id, workspace_id, entity_type, entity_id, deleted_at, deleted_by
+`
+
+type PurgeExpiredDeletedRecordsParams struct {
+	Now                  time.Time `json:"now"`
+	DefaultRetentionDays int32     `json:"default_retention_days"`
+}
+
+// CleanupExpiredDeletedRecords returning the removed rows, so they can be
+// archived before the transaction commits.
+func (q *Queries) PurgeExpiredDeletedRecords(ctx context.Context, arg PurgeExpiredDeletedRecordsParams) ([]WarehouseDeletedRecord, error) {
+	rows, err := q.db.Query(ctx, purgeExpiredDeletedRecords, arg.Now, arg.DefaultRetentionDays)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []WarehouseDeletedRecord{}
+	for rows.Next() {
+		var i WarehouseDeletedRecord
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.EntityType,
+			&i.EntityID,
+			&i.DeletedAt,
+			&i.DeletedBy,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
@@ -143,7 +143,7 @@ func (q *Queries) ListAllAttachments(ctx context.Context, workspaceID uuid.UUID)
 }
 
 const listAllBorrowers = `-- name: ListAllBorrowers :many
-SELECT id, workspace_id, name, email, phone, notes, is_archived, search_vector, created_at, updated_at FROM warehouse.borrowers
+SELECT id, workspace_id, name, email, phone, notes, is_archived, search_vector, created_at, updated_at, reminder_channel FROM warehouse.borrowers
 WHERE workspace_id = $1 
   AND ($2::boolean OR is_archived = false)
 ORDER BY name
@@ -174,6 +174,7 @@ func (q *Queries) ListAllBorrowers(ctx context.Context, arg ListAllBorrowersPara
 			&i.SearchVector,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.ReminderChannel,
 		); err != nil {
 			return nil, err
 		}
@@ -186,7 +187,7 @@ func (q *Queries) ListAllBorrowers(ctx context.Context, arg ListAllBorrowersPara
 }
 
 const listAllBorrowersIncludingArchived = `-- name: ListAllBorrowersIncludingArchived :many
-SELECT id, workspace_id, name, email, phone, notes, is_archived, search_vector, created_at, updated_at FROM warehouse.borrowers
+SELECT id, workspace_id, name, email, phone, notes, is_archived, search_vector, created_at, updated_at, reminder_channel FROM warehouse.borrowers
 WHERE workspace_id = $1
 ORDER BY created_at
 `
@@ -211,6 +212,7 @@ func (q *Queries) ListAllBorrowersIncludingArchived(ctx context.Context, workspa
 			&i.SearchVector,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.ReminderChannel,
 		); err != nil {
 			return nil, err
 		}
@@ -503,7 +505,7 @@ func (q *Queries) ListAllInventory(ctx context.Context, workspaceID uuid.UUID) (
 
 const listAllItems = `-- name: ListAllItems :many
 
-SELECT id, workspace_id, sku, name, description, category_id, brand, model, image_url, serial_number, manufacturer, barcode, is_insured, is_archived, needs_review, lifetime_warranty, warranty_details, purchased_from, min_stock_level, short_code, obsidian_vault_path, obsidian_note_path, search_vector, created_at, updated_at FROM warehouse.items
+SELECT id, workspace_id, sku, name, description, category_id, brand, model, image_url, serial_number, manufacturer, barcode, is_insured, is_archived, needs_review, lifetime_warranty, warranty_details, purchased_from, min_stock_level, short_code, obsidian_vault_path, obsidian_note_path, search_vector, created_at, updated_at, loanable FROM warehouse.items
 WHERE workspace_id = $1 
   AND ($2::boolean OR is_archived = false)
 ORDER BY name
@@ -551,6 +553,7 @@ func (q *Queries) ListAllItems(ctx context.Context, arg ListAllItemsParams) ([]W
 			&i.SearchVector,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Loanable,
 		); err != nil {
 			return nil, err
 		}
@@ -563,7 +566,7 @@ func (q *Queries) ListAllItems(ctx context.Context, arg ListAllItemsParams) ([]W
 }
 
 const listAllItemsIncludingArchived = `-- name: ListAllItemsIncludingArchived :many
-SELECT id, workspace_id, sku, name, description, category_id, brand, model, image_url, serial_number, manufacturer, barcode, is_insured, is_archived, needs_review, lifetime_warranty, warranty_details, purchased_from, min_stock_level, short_code, obsidian_vault_path, obsidian_note_path, search_vector, created_at, updated_at FROM warehouse.items
+SELECT id, workspace_id, sku, name, description, category_id, brand, model, image_url, serial_number, manufacturer, barcode, is_insured, is_archived, needs_review, lifetime_warranty, warranty_details, purchased_from, min_stock_level, short_code, obsidian_vault_path, obsidian_note_path, search_vector, created_at, updated_at, loanable FROM warehouse.items
 WHERE workspace_id = $1
 ORDER BY created_at
 `
@@ -603,6 +606,7 @@ func (q *Queries) ListAllItemsIncludingArchived(ctx context.Context, workspaceID
 			&i.SearchVector,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Loanable,
 		); err != nil {
 			return nil, err
 		}
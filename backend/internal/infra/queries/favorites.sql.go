@@ -92,7 +92,7 @@ func (q *Queries) DeleteFavoriteByTarget(ctx context.Context, arg DeleteFavorite
 }
 
 const getFavoriteItems = `-- name: GetFavoriteItems :many
-SELECT f.id as favorite_id, f.created_at as favorited_at, i.id, i.workspace_id, i.sku, i.name, i.description, i.category_id, i.brand, i.model, i.image_url, i.serial_number, i.manufacturer, i.barcode, i.is_insured, i.is_archived, i.needs_review, i.lifetime_warranty, i.warranty_details, i.purchased_from, i.min_stock_level, i.short_code, i.obsidian_vault_path, i.obsidian_note_path, i.search_vector, i.created_at, i.updated_at
+SELECT f.id as favorite_id, f.created_at as favorited_at, i.id, i.workspace_id, i.sku, i.name, i.description, i.category_id, i.brand, i.model, i.image_url, i.serial_number, i.manufacturer, i.barcode, i.is_insured, i.is_archived, i.needs_review, i.lifetime_warranty, i.warranty_details, i.purchased_from, i.min_stock_level, i.short_code, i.obsidian_vault_path, i.obsidian_note_path, i.search_vector, i.created_at, i.updated_at, i.loanable
 FROM warehouse.favorites f
 JOIN warehouse.items i ON f.item_id = i.id
 WHERE f.user_id = $1 AND f.workspace_id = $2 AND f.favorite_type = 'ITEM'
@@ -132,6 +132,7 @@ type GetFavoriteItemsRow struct {
 	SearchVector      interface{}        `json:"search_vector"`
 	CreatedAt         pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt         pgtype.Timestamptz `json:"updated_at"`
+	Loanable          bool               `json:"loanable"`
 }
 
 func (q *Queries) GetFavoriteItems(ctx context.Context, arg GetFavoriteItemsParams) ([]GetFavoriteItemsRow, error) {
@@ -171,6 +172,7 @@ func (q *Queries) GetFavoriteItems(ctx context.Context, arg GetFavoriteItemsPara
 			&i.SearchVector,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Loanable,
 		); err != nil {
 			return nil, err
 		}
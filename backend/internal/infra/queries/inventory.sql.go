@@ -12,6 +12,46 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const addInventoryQuantity = `-- name: AddInventoryQuantity :one
+UPDATE warehouse.inventory
+SET quantity = quantity + $1, updated_at = now()
+WHERE id = $2 AND workspace_id = $3
+RETURNING id, workspace_id, item_id, location_id, container_id, quantity, condition, status, date_acquired, purchase_price, currency_code, warranty_expires, expiration_date, notes, last_used_at, is_archived, created_at, updated_at
+`
+
+type AddInventoryQuantityParams struct {
+	Amount      int32     `json:"amount"`
+	ID          uuid.UUID `json:"id"`
+	WorkspaceID uuid.UUID `json:"workspace_id"`
+}
+
+// Adds in one statement, so concurrent additions to an entry are not lost.
+func (q *Queries) AddInventoryQuantity(ctx context.Context, arg AddInventoryQuantityParams) (WarehouseInventory, error) {
+	row := q.db.QueryRow(ctx, addInventoryQuantity, arg.Amount, arg.ID, arg.WorkspaceID)
+	var i WarehouseInventory
+	err := row.Scan(
+		&i.ID,
+		&i.WorkspaceID,
+		&i.ItemID,
+		&i.LocationID,
+		&i.ContainerID,
+		&i.Quantity,
+		&i.Condition,
+		&i.Status,
+		&i.DateAcquired,
+		&i.PurchasePrice,
+		&i.CurrencyCode,
+		&i.WarrantyExpires,
+		&i.ExpirationDate,
+		&i.Notes,
+		&i.LastUsedAt,
+		&i.IsArchived,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
 const archiveInventory = `-- name: ArchiveInventory :exec
 UPDATE warehouse.inventory
 SET is_archived = true, updated_at = now()
@@ -31,10 +71,28 @@ func (q *Queries) ArchiveInventory(ctx context.Context, arg ArchiveInventoryPara
 const countInventory = `-- name: CountInventory :one
 SELECT COUNT(*) FROM warehouse.inventory
 WHERE workspace_id = $1 AND is_archived = false
+  AND ($2::uuid IS NULL OR item_id = $2)
+  AND ($3::uuid IS NULL OR location_id = $3)
+  AND ($4::text[] IS NULL OR COALESCE(condition, 'NEW')::text = ANY($4::text[]))
+  AND ($5::text[] IS NULL OR COALESCE(status, 'AVAILABLE')::text = ANY($5::text[]))
 `
 
-func (q *Queries) CountInventory(ctx context.Context, workspaceID uuid.UUID) (int64, error) {
-	row := q.db.QueryRow(ctx, countInventory, workspaceID)
+type CountInventoryParams struct {
+	WorkspaceID uuid.UUID   `json:"workspace_id"`
+	ItemID      pgtype.UUID `json:"item_id"`
+	LocationID  pgtype.UUID `json:"location_id"`
+	Conditions  []string    `json:"conditions"`
+	Statuses    []string    `json:"statuses"`
+}
+
+func (q *Queries) CountInventory(ctx context.Context, arg CountInventoryParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countInventory,
+		arg.WorkspaceID,
+		arg.ItemID,
+		arg.LocationID,
+		arg.Conditions,
+		arg.Statuses,
+	)
 	var count int64
 	err := row.Scan(&count)
 	return count, err
@@ -110,16 +168,19 @@ func (q *Queries) CreateInventory(ctx context.Context, arg CreateInventoryParams
 
 const getAvailableInventory = `-- name: GetAvailableInventory :many
 SELECT id, workspace_id, item_id, location_id, container_id, quantity, condition, status, date_acquired, purchase_price, currency_code, warranty_expires, expiration_date, notes, last_used_at, is_archived, created_at, updated_at FROM warehouse.inventory
-WHERE workspace_id = $1 AND item_id = $2 AND status = 'AVAILABLE' AND is_archived = false
+WHERE workspace_id = $1 AND item_id = $2 AND is_archived = false
+  AND (status = 'AVAILABLE' OR ($3::boolean AND status = 'RESERVED'))
 `
 
 type GetAvailableInventoryParams struct {
-	WorkspaceID uuid.UUID `json:"workspace_id"`
-	ItemID      uuid.UUID `json:"item_id"`
+	WorkspaceID     uuid.UUID `json:"workspace_id"`
+	ItemID          uuid.UUID `json:"item_id"`
+	IncludeReserved bool      `json:"include_reserved"`
 }
 
+// RESERVED entries count too when the workspace policy says so.
 func (q *Queries) GetAvailableInventory(ctx context.Context, arg GetAvailableInventoryParams) ([]WarehouseInventory, error) {
-	rows, err := q.db.Query(ctx, getAvailableInventory, arg.WorkspaceID, arg.ItemID)
+	rows, err := q.db.Query(ctx, getAvailableInventory, arg.WorkspaceID, arg.ItemID, arg.IncludeReserved)
 	if err != nil {
 		return nil, err
 	}
@@ -263,21 +324,44 @@ func (q *Queries) GetInventoryWithDetails(ctx context.Context, arg GetInventoryW
 }
 
 const getLowStockItems = `-- name: GetLowStockItems :many
-SELECT i.id, i.name, i.min_stock_level, COALESCE(SUM(inv.quantity), 0)::int as current_stock
+-- Low-stock report. An item with per-location minimums
+-- (item_location_stock_levels) is checked at each of those locations against
+-- the stock there; any other item is checked on its total stock against
+-- items.min_stock_level, and its row has no location.
+SELECT i.id AS item_id, i.name AS item_name, NULL::uuid AS location_id, NULL::text AS location_name,
+       i.min_stock_level, COALESCE(SUM(inv.quantity), 0)::int as current_stock
 FROM warehouse.items i
 LEFT JOIN warehouse.inventory inv ON i.id = inv.item_id AND inv.is_archived = false
 WHERE i.workspace_id = $1 AND i.is_archived = false AND i.min_stock_level > 0
+  AND NOT EXISTS (SELECT 1 FROM warehouse.item_location_stock_levels sl WHERE sl.item_id = i.id)
 GROUP BY i.id, i.name, i.min_stock_level
 HAVING COALESCE(SUM(inv.quantity), 0) < i.min_stock_level
+UNION ALL
+SELECT i.id, i.name, sl.location_id, l.name,
+       sl.min_stock_level, COALESCE(SUM(inv.quantity), 0)::int
+FROM warehouse.item_location_stock_levels sl
+JOIN warehouse.items i ON i.id = sl.item_id AND i.is_archived = false
+JOIN warehouse.locations l ON l.id = sl.location_id
+LEFT JOIN warehouse.inventory inv ON inv.item_id = sl.item_id AND inv.location_id = sl.location_id AND inv.is_archived = false
+WHERE sl.workspace_id = $1
+GROUP BY i.id, i.name, sl.location_id, l.name, sl.min_stock_level
+HAVING COALESCE(SUM(inv.quantity), 0) < sl.min_stock_level
+ORDER BY item_name, location_name NULLS FIRST
 `
 
 type GetLowStockItemsRow struct {
-	ID            uuid.UUID `json:"id"`
-	Name          string    `json:"name"`
-	MinStockLevel int32     `json:"min_stock_level"`
-	CurrentStock  int32     `json:"current_stock"`
+	ItemID        uuid.UUID   `json:"item_id"`
+	ItemName      string      `json:"item_name"`
+	LocationID    pgtype.UUID `json:"location_id"`
+	LocationName  *string     `json:"location_name"`
+	MinStockLevel int32       `json:"min_stock_level"`
+	CurrentStock  int32       `json:"current_stock"`
 }
 
+// Low-stock report. An item with per-location minimums
+// (item_location_stock_levels) is checked at each of those locations against
+// the stock there; any other item is checked on its total stock against
+// items.min_stock_level, and its row has no location.
 func (q *Queries) GetLowStockItems(ctx context.Context, workspaceID uuid.UUID) ([]GetLowStockItemsRow, error) {
 	rows, err := q.db.Query(ctx, getLowStockItems, workspaceID)
 	if err != nil {
@@ -288,8 +372,10 @@ func (q *Queries) GetLowStockItems(ctx context.Context, workspaceID uuid.UUID) (
 	for rows.Next() {
 		var i GetLowStockItemsRow
 		if err := rows.Scan(
-			&i.ID,
-			&i.Name,
+			&i.ItemID,
+			&i.ItemName,
+			&i.LocationID,
+			&i.LocationName,
 			&i.MinStockLevel,
 			&i.CurrentStock,
 		); err != nil {
@@ -372,18 +458,35 @@ func (q *Queries) GetTotalQuantityByItem(ctx context.Context, arg GetTotalQuanti
 const listInventory = `-- name: ListInventory :many
 SELECT id, workspace_id, item_id, location_id, container_id, quantity, condition, status, date_acquired, purchase_price, currency_code, warranty_expires, expiration_date, notes, last_used_at, is_archived, created_at, updated_at FROM warehouse.inventory
 WHERE workspace_id = $1 AND is_archived = false
+  AND ($4::uuid IS NULL OR item_id = $4)
+  AND ($5::uuid IS NULL OR location_id = $5)
+  AND ($6::text[] IS NULL OR COALESCE(condition, 'NEW')::text = ANY($6::text[]))
+  AND ($7::text[] IS NULL OR COALESCE(status, 'AVAILABLE')::text = ANY($7::text[]))
 ORDER BY created_at DESC
 LIMIT $2 OFFSET $3
 `
 
 type ListInventoryParams struct {
-	WorkspaceID uuid.UUID `json:"workspace_id"`
-	Limit       int32     `json:"limit"`
-	Offset      int32     `json:"offset"`
+	WorkspaceID uuid.UUID   `json:"workspace_id"`
+	Limit       int32       `json:"limit"`
+	Offset      int32       `json:"offset"`
+	ItemID      pgtype.UUID `json:"item_id"`
+	LocationID  pgtype.UUID `json:"location_id"`
+	Conditions  []string    `json:"conditions"`
+	Statuses    []string    `json:"statuses"`
 }
 
+// A NULL condition/status filters as the domain default (NEW / AVAILABLE).
 func (q *Queries) ListInventory(ctx context.Context, arg ListInventoryParams) ([]WarehouseInventory, error) {
-	rows, err := q.db.Query(ctx, listInventory, arg.WorkspaceID, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, listInventory,
+		arg.WorkspaceID,
+		arg.Limit,
+		arg.Offset,
+		arg.ItemID,
+		arg.LocationID,
+		arg.Conditions,
+		arg.Statuses,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -571,6 +674,52 @@ func (q *Queries) ListInventoryByLocation(ctx context.Context, arg ListInventory
 	return items, nil
 }
 
+const listInventoryForRepair = `-- name: ListInventoryForRepair :many
+SELECT inv.id, inv.item_id, inv.quantity, inv.updated_at, it.name AS item_name
+FROM warehouse.inventory inv
+JOIN warehouse.items it ON inv.item_id = it.id AND it.workspace_id = inv.workspace_id
+WHERE inv.workspace_id = $1
+  AND inv.is_archived = false
+  AND inv.condition = 'FOR_REPAIR'
+ORDER BY inv.updated_at, inv.id
+`
+
+type ListInventoryForRepairRow struct {
+	ID        uuid.UUID          `json:"id"`
+	ItemID    uuid.UUID          `json:"item_id"`
+	Quantity  int32              `json:"quantity"`
+	UpdatedAt pgtype.Timestamptz `json:"updated_at"`
+	ItemName  string             `json:"item_name"`
+}
+
+// Inventory rows in FOR_REPAIR condition with their item name, longest waiting
+// (least recently updated) first. Workspace-scoped; archived rows excluded.
+func (q *Queries) ListInventoryForRepair(ctx context.Context, workspaceID uuid.UUID) ([]ListInventoryForRepairRow, error) {
+	rows, err := q.db.Query(ctx, listInventoryForRepair, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListInventoryForRepairRow{}
+	for rows.Next() {
+		var i ListInventoryForRepairRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ItemID,
+			&i.Quantity,
+			&i.UpdatedAt,
+			&i.ItemName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const listInventoryExpiringSoon = `-- name: ListInventoryExpiringSoon :many
 SELECT inv.id, inv.workspace_id, inv.item_id, inv.quantity,
        inv.expiration_date, it.name AS item_name
@@ -628,21 +777,112 @@ func (q *Queries) ListInventoryExpiringSoon(ctx context.Context, arg ListInvento
 	return items, nil
 }
 
+const listInventoryExpired = `-- name: ListInventoryExpired :many
+SELECT inv.id, inv.workspace_id, inv.item_id, inv.status,
+       inv.expiration_date, it.name AS item_name
+FROM warehouse.inventory inv
+JOIN warehouse.items it ON inv.item_id = it.id AND it.workspace_id = inv.workspace_id
+WHERE inv.workspace_id = $1
+  AND inv.is_archived = false
+  AND inv.expiration_date IS NOT NULL
+  AND inv.expiration_date < $2
+ORDER BY inv.expiration_date, inv.id
+`
+
+type ListInventoryExpiredParams struct {
+	WorkspaceID    uuid.UUID   `json:"workspace_id"`
+	ExpirationDate pgtype.Date `json:"expiration_date"`
+}
+
+type ListInventoryExpiredRow struct {
+	ID             uuid.UUID                   `json:"id"`
+	WorkspaceID    uuid.UUID                   `json:"workspace_id"`
+	ItemID         uuid.UUID                   `json:"item_id"`
+	Status         NullWarehouseItemStatusEnum `json:"status"`
+	ExpirationDate pgtype.Date                 `json:"expiration_date"`
+	ItemName       string                      `json:"item_name"`
+}
+
+// Inventory rows whose expiration_date is before the given date (today).
+// Used by the expiry status job. Workspace-scoped; archived rows excluded.
+func (q *Queries) ListInventoryExpired(ctx context.Context, arg ListInventoryExpiredParams) ([]ListInventoryExpiredRow, error) {
+	rows, err := q.db.Query(ctx, listInventoryExpired, arg.WorkspaceID, arg.ExpirationDate)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListInventoryExpiredRow{}
+	for rows.Next() {
+		var i ListInventoryExpiredRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.ItemID,
+			&i.Status,
+			&i.ExpirationDate,
+			&i.ItemName,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const expireInventory = `-- name: ExpireInventory :execrows
+UPDATE warehouse.inventory
+SET status = $1, updated_at = now()
+WHERE id = $2 AND workspace_id = $3 AND status = $4
+`
+
+type ExpireInventoryParams struct {
+	ExpiredStatus NullWarehouseItemStatusEnum `json:"expired_status"`
+	ID            uuid.UUID                   `json:"id"`
+	WorkspaceID   uuid.UUID                   `json:"workspace_id"`
+	Status        NullWarehouseItemStatusEnum `json:"status"`
+}
+
+// Moves an expired row to the workspace's expired status, but only while it
+// is still in the status the job saw, so a loan that starts in between wins.
+func (q *Queries) ExpireInventory(ctx context.Context, arg ExpireInventoryParams) (int64, error) {
+	result, err := q.db.Exec(ctx, expireInventory,
+		arg.ExpiredStatus,
+		arg.ID,
+		arg.WorkspaceID,
+		arg.Status,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const listInventoryWithDetails = `-- name: ListInventoryWithDetails :many
-SELECT i.id, i.workspace_id, i.item_id, i.location_id, i.container_id, i.quantity, i.condition, i.status, i.date_acquired, i.purchase_price, i.currency_code, i.warranty_expires, i.expiration_date, i.notes, i.last_used_at, i.is_archived, i.created_at, i.updated_at, it.name as item_name, it.sku, l.name as location_name, c.name as container_name
+SELECT i.id, i.workspace_id, i.item_id, i.location_id, i.container_id, i.quantity, i.condition, i.status, i.date_acquired, i.purchase_price, i.currency_code, i.warranty_expires, i.expiration_date, i.notes, i.last_used_at, i.is_archived, i.created_at, i.updated_at, it.name as item_name, it.brand as item_brand, it.sku, l.name as location_name, c.name as container_name
 FROM warehouse.inventory i
 JOIN warehouse.items it ON i.item_id = it.id
 JOIN warehouse.locations l ON i.location_id = l.id
 LEFT JOIN warehouse.containers c ON i.container_id = c.id
 WHERE i.workspace_id = $1 AND i.is_archived = false
-ORDER BY it.name
+  AND ($4::uuid IS NULL OR i.item_id = $4)
+  AND ($5::uuid IS NULL OR i.location_id = $5)
+  AND ($6::text[] IS NULL OR COALESCE(i.condition, 'NEW')::text = ANY($6::text[]))
+  AND ($7::text[] IS NULL OR COALESCE(i.status, 'AVAILABLE')::text = ANY($7::text[]))
+ORDER BY i.created_at DESC
 LIMIT $2 OFFSET $3
 `
 
 type ListInventoryWithDetailsParams struct {
-	WorkspaceID uuid.UUID `json:"workspace_id"`
-	Limit       int32     `json:"limit"`
-	Offset      int32     `json:"offset"`
+	WorkspaceID uuid.UUID   `json:"workspace_id"`
+	Limit       int32       `json:"limit"`
+	Offset      int32       `json:"offset"`
+	ItemID      pgtype.UUID `json:"item_id"`
+	LocationID  pgtype.UUID `json:"location_id"`
+	Conditions  []string    `json:"conditions"`
+	Statuses    []string    `json:"statuses"`
 }
 
 type ListInventoryWithDetailsRow struct {
@@ -665,13 +905,22 @@ type ListInventoryWithDetailsRow struct {
 	CreatedAt       pgtype.Timestamptz             `json:"created_at"`
 	UpdatedAt       pgtype.Timestamptz             `json:"updated_at"`
 	ItemName        string                         `json:"item_name"`
+	ItemBrand       *string                        `json:"item_brand"`
 	Sku             string                         `json:"sku"`
 	LocationName    string                         `json:"location_name"`
 	ContainerName   *string                        `json:"container_name"`
 }
 
 func (q *Queries) ListInventoryWithDetails(ctx context.Context, arg ListInventoryWithDetailsParams) ([]ListInventoryWithDetailsRow, error) {
-	rows, err := q.db.Query(ctx, listInventoryWithDetails, arg.WorkspaceID, arg.Limit, arg.Offset)
+	rows, err := q.db.Query(ctx, listInventoryWithDetails,
+		arg.WorkspaceID,
+		arg.Limit,
+		arg.Offset,
+		arg.ItemID,
+		arg.LocationID,
+		arg.Conditions,
+		arg.Statuses,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -699,6 +948,7 @@ func (q *Queries) ListInventoryWithDetails(ctx context.Context, arg ListInventor
 			&i.CreatedAt,
 			&i.UpdatedAt,
 			&i.ItemName,
+			&i.ItemBrand,
 			&i.Sku,
 			&i.LocationName,
 			&i.ContainerName,
@@ -50,10 +50,10 @@ const createItemPhoto = `-- name: CreateItemPhoto :one
 INSERT INTO warehouse.item_photos (
     id, item_id, workspace_id, filename, storage_path, thumbnail_path,
     file_size, mime_type, width, height, display_order, is_primary,
-    caption, uploaded_by
+    caption, uploaded_by, content_hash, inventory_id
 )
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
-RETURNING id, item_id, workspace_id, filename, storage_path, thumbnail_path, file_size, mime_type, width, height, display_order, is_primary, caption, uploaded_by, thumbnail_status, thumbnail_small_path, thumbnail_medium_path, thumbnail_large_path, thumbnail_attempts, thumbnail_error, perceptual_hash, created_at, updated_at
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+RETURNING id, item_id, workspace_id, filename, storage_path, thumbnail_path, file_size, mime_type, width, height, display_order, is_primary, caption, uploaded_by, thumbnail_status, thumbnail_small_path, thumbnail_medium_path, thumbnail_large_path, thumbnail_attempts, thumbnail_error, perceptual_hash, created_at, updated_at, blurhash, content_hash, thumbnail_config_version, inventory_id
 `
 
 type CreateItemPhotoParams struct {
@@ -71,6 +71,8 @@ type CreateItemPhotoParams struct {
 	IsPrimary     bool        `json:"is_primary"`
 	Caption       *string     `json:"caption"`
 	UploadedBy    pgtype.UUID `json:"uploaded_by"`
+	ContentHash   *string     `json:"content_hash"`
+	InventoryID   pgtype.UUID `json:"inventory_id"`
 }
 
 func (q *Queries) CreateItemPhoto(ctx context.Context, arg CreateItemPhotoParams) (WarehouseItemPhoto, error) {
@@ -89,6 +91,8 @@ func (q *Queries) CreateItemPhoto(ctx context.Context, arg CreateItemPhotoParams
 		arg.IsPrimary,
 		arg.Caption,
 		arg.UploadedBy,
+		arg.ContentHash,
+		arg.InventoryID,
 	)
 	var i WarehouseItemPhoto
 	err := row.Scan(
@@ -115,6 +119,10 @@ func (q *Queries) CreateItemPhoto(ctx context.Context, arg CreateItemPhotoParams
 		&i.PerceptualHash,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Blurhash,
+		&i.ContentHash,
+		&i.ThumbnailConfigVersion,
+		&i.InventoryID,
 	)
 	return i, err
 }
@@ -150,7 +158,7 @@ func (q *Queries) DeleteItemPhotosByItem(ctx context.Context, arg DeleteItemPhot
 }
 
 const getItemPhoto = `-- name: GetItemPhoto :one
-SELECT id, item_id, workspace_id, filename, storage_path, thumbnail_path, file_size, mime_type, width, height, display_order, is_primary, caption, uploaded_by, thumbnail_status, thumbnail_small_path, thumbnail_medium_path, thumbnail_large_path, thumbnail_attempts, thumbnail_error, perceptual_hash, created_at, updated_at FROM warehouse.item_photos
+SELECT id, item_id, workspace_id, filename, storage_path, thumbnail_path, file_size, mime_type, width, height, display_order, is_primary, caption, uploaded_by, thumbnail_status, thumbnail_small_path, thumbnail_medium_path, thumbnail_large_path, thumbnail_attempts, thumbnail_error, perceptual_hash, created_at, updated_at, blurhash, content_hash, thumbnail_config_version, inventory_id FROM warehouse.item_photos
 WHERE id = $1
 `
 
@@ -181,12 +189,65 @@ func (q *Queries) GetItemPhoto(ctx context.Context, id uuid.UUID) (WarehouseItem
 		&i.PerceptualHash,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Blurhash,
+		&i.ContentHash,
+		&i.ThumbnailConfigVersion,
+		&i.InventoryID,
+	)
+	return i, err
+}
+
+const getItemPhotoByContentHash = `-- name: GetItemPhotoByContentHash :one
+SELECT id, item_id, workspace_id, filename, storage_path, thumbnail_path, file_size, mime_type, width, height, display_order, is_primary, caption, uploaded_by, thumbnail_status, thumbnail_small_path, thumbnail_medium_path, thumbnail_large_path, thumbnail_attempts, thumbnail_error, perceptual_hash, created_at, updated_at, blurhash, content_hash, thumbnail_config_version, inventory_id FROM warehouse.item_photos
+WHERE item_id = $1 AND workspace_id = $2 AND content_hash = $3
+ORDER BY created_at ASC
+LIMIT 1
+`
+
+type GetItemPhotoByContentHashParams struct {
+	ItemID      uuid.UUID `json:"item_id"`
+	WorkspaceID uuid.UUID `json:"workspace_id"`
+	ContentHash *string   `json:"content_hash"`
+}
+
+// Oldest photo of an item with the given file content, for upload dedup.
+func (q *Queries) GetItemPhotoByContentHash(ctx context.Context, arg GetItemPhotoByContentHashParams) (WarehouseItemPhoto, error) {
+	row := q.db.QueryRow(ctx, getItemPhotoByContentHash, arg.ItemID, arg.WorkspaceID, arg.ContentHash)
+	var i WarehouseItemPhoto
+	err := row.Scan(
+		&i.ID,
+		&i.ItemID,
+		&i.WorkspaceID,
+		&i.Filename,
+		&i.StoragePath,
+		&i.ThumbnailPath,
+		&i.FileSize,
+		&i.MimeType,
+		&i.Width,
+		&i.Height,
+		&i.DisplayOrder,
+		&i.IsPrimary,
+		&i.Caption,
+		&i.UploadedBy,
+		&i.ThumbnailStatus,
+		&i.ThumbnailSmallPath,
+		&i.ThumbnailMediumPath,
+		&i.ThumbnailLargePath,
+		&i.ThumbnailAttempts,
+		&i.ThumbnailError,
+		&i.PerceptualHash,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Blurhash,
+		&i.ContentHash,
+		&i.ThumbnailConfigVersion,
+		&i.InventoryID,
 	)
 	return i, err
 }
 
 const getItemPhotoByID = `-- name: GetItemPhotoByID :one
-SELECT id, item_id, workspace_id, filename, storage_path, thumbnail_path, file_size, mime_type, width, height, display_order, is_primary, caption, uploaded_by, thumbnail_status, thumbnail_small_path, thumbnail_medium_path, thumbnail_large_path, thumbnail_attempts, thumbnail_error, perceptual_hash, created_at, updated_at FROM warehouse.item_photos
+SELECT id, item_id, workspace_id, filename, storage_path, thumbnail_path, file_size, mime_type, width, height, display_order, is_primary, caption, uploaded_by, thumbnail_status, thumbnail_small_path, thumbnail_medium_path, thumbnail_large_path, thumbnail_attempts, thumbnail_error, perceptual_hash, created_at, updated_at, blurhash, content_hash, thumbnail_config_version, inventory_id FROM warehouse.item_photos
 WHERE id = $1 AND workspace_id = $2
 `
 
@@ -222,42 +283,50 @@ func (q *Queries) GetItemPhotoByID(ctx context.Context, arg GetItemPhotoByIDPara
 		&i.PerceptualHash,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Blurhash,
+		&i.ContentHash,
+		&i.ThumbnailConfigVersion,
+		&i.InventoryID,
 	)
 	return i, err
 }
 
 const getItemPhotoForProcessing = `-- name: GetItemPhotoForProcessing :one
-SELECT ip.id, ip.item_id, ip.workspace_id, ip.filename, ip.storage_path, ip.thumbnail_path, ip.file_size, ip.mime_type, ip.width, ip.height, ip.display_order, ip.is_primary, ip.caption, ip.uploaded_by, ip.thumbnail_status, ip.thumbnail_small_path, ip.thumbnail_medium_path, ip.thumbnail_large_path, ip.thumbnail_attempts, ip.thumbnail_error, ip.perceptual_hash, ip.created_at, ip.updated_at, i.workspace_id as item_workspace_id
+SELECT ip.id, ip.item_id, ip.workspace_id, ip.filename, ip.storage_path, ip.thumbnail_path, ip.file_size, ip.mime_type, ip.width, ip.height, ip.display_order, ip.is_primary, ip.caption, ip.uploaded_by, ip.thumbnail_status, ip.thumbnail_small_path, ip.thumbnail_medium_path, ip.thumbnail_large_path, ip.thumbnail_attempts, ip.thumbnail_error, ip.perceptual_hash, ip.created_at, ip.updated_at, ip.blurhash, ip.content_hash, ip.thumbnail_config_version, ip.inventory_id, i.workspace_id as item_workspace_id
 FROM warehouse.item_photos ip
 JOIN warehouse.items i ON i.id = ip.item_id
 WHERE ip.id = $1
 `
 
 type GetItemPhotoForProcessingRow struct {
-	ID                  uuid.UUID   `json:"id"`
-	ItemID              uuid.UUID   `json:"item_id"`
-	WorkspaceID         uuid.UUID   `json:"workspace_id"`
-	Filename            string      `json:"filename"`
-	StoragePath         string      `json:"storage_path"`
-	ThumbnailPath       string      `json:"thumbnail_path"`
-	FileSize            int64       `json:"file_size"`
-	MimeType            string      `json:"mime_type"`
-	Width               int32       `json:"width"`
-	Height              int32       `json:"height"`
-	DisplayOrder        int32       `json:"display_order"`
-	IsPrimary           bool        `json:"is_primary"`
-	Caption             *string     `json:"caption"`
-	UploadedBy          pgtype.UUID `json:"uploaded_by"`
-	ThumbnailStatus     string      `json:"thumbnail_status"`
-	ThumbnailSmallPath  *string     `json:"thumbnail_small_path"`
-	ThumbnailMediumPath *string     `json:"thumbnail_medium_path"`
-	ThumbnailLargePath  *string     `json:"thumbnail_large_path"`
-	ThumbnailAttempts   int32       `json:"thumbnail_attempts"`
-	ThumbnailError      *string     `json:"thumbnail_error"`
-	PerceptualHash      *int64      `json:"perceptual_hash"`
-	CreatedAt           time.Time   `json:"created_at"`
-	UpdatedAt           time.Time   `json:"updated_at"`
-	ItemWorkspaceID     uuid.UUID   `json:"item_workspace_id"`
+	ID                     uuid.UUID   `json:"id"`
+	ItemID                 uuid.UUID   `json:"item_id"`
+	WorkspaceID            uuid.UUID   `json:"workspace_id"`
+	Filename               string      `json:"filename"`
+	StoragePath            string      `json:"storage_path"`
+	ThumbnailPath          string      `json:"thumbnail_path"`
+	FileSize               int64       `json:"file_size"`
+	MimeType               string      `json:"mime_type"`
+	Width                  int32       `json:"width"`
+	Height                 int32       `json:"height"`
+	DisplayOrder           int32       `json:"display_order"`
+	IsPrimary              bool        `json:"is_primary"`
+	Caption                *string     `json:"caption"`
+	UploadedBy             pgtype.UUID `json:"uploaded_by"`
+	ThumbnailStatus        string      `json:"thumbnail_status"`
+	ThumbnailSmallPath     *string     `json:"thumbnail_small_path"`
+	ThumbnailMediumPath    *string     `json:"thumbnail_medium_path"`
+	ThumbnailLargePath     *string     `json:"thumbnail_large_path"`
+	ThumbnailAttempts      int32       `json:"thumbnail_attempts"`
+	ThumbnailError         *string     `json:"thumbnail_error"`
+	PerceptualHash         *int64      `json:"perceptual_hash"`
+	CreatedAt              time.Time   `json:"created_at"`
+	UpdatedAt              time.Time   `json:"updated_at"`
+	Blurhash               *string     `json:"blurhash"`
+	ContentHash            *string     `json:"content_hash"`
+	ThumbnailConfigVersion *string     `json:"thumbnail_config_version"`
+	InventoryID            pgtype.UUID `json:"inventory_id"`
+	ItemWorkspaceID        uuid.UUID   `json:"item_workspace_id"`
 }
 
 // Get photo with workspace for background job processing
@@ -288,6 +357,10 @@ func (q *Queries) GetItemPhotoForProcessing(ctx context.Context, id uuid.UUID) (
 		&i.PerceptualHash,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Blurhash,
+		&i.ContentHash,
+		&i.ThumbnailConfigVersion,
+		&i.InventoryID,
 		&i.ItemWorkspaceID,
 	)
 	return i, err
@@ -295,7 +368,7 @@ func (q *Queries) GetItemPhotoForProcessing(ctx context.Context, id uuid.UUID) (
 
 const getItemPhotosByIDs = `-- name: GetItemPhotosByIDs :many
 
-SELECT id, item_id, workspace_id, filename, storage_path, thumbnail_path, file_size, mime_type, width, height, display_order, is_primary, caption, uploaded_by, thumbnail_status, thumbnail_small_path, thumbnail_medium_path, thumbnail_large_path, thumbnail_attempts, thumbnail_error, perceptual_hash, created_at, updated_at FROM warehouse.item_photos
+SELECT id, item_id, workspace_id, filename, storage_path, thumbnail_path, file_size, mime_type, width, height, display_order, is_primary, caption, uploaded_by, thumbnail_status, thumbnail_small_path, thumbnail_medium_path, thumbnail_large_path, thumbnail_attempts, thumbnail_error, perceptual_hash, created_at, updated_at, blurhash, content_hash, thumbnail_config_version, inventory_id FROM warehouse.item_photos
 WHERE id = ANY($1::UUID[]) AND workspace_id = $2
 ORDER BY display_order ASC
 `
@@ -340,6 +413,10 @@ func (q *Queries) GetItemPhotosByIDs(ctx context.Context, arg GetItemPhotosByIDs
 			&i.PerceptualHash,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Blurhash,
+			&i.ContentHash,
+			&i.ThumbnailConfigVersion,
+			&i.InventoryID,
 		); err != nil {
 			return nil, err
 		}
@@ -352,7 +429,7 @@ func (q *Queries) GetItemPhotosByIDs(ctx context.Context, arg GetItemPhotosByIDs
 }
 
 const getItemPhotosWithHashes = `-- name: GetItemPhotosWithHashes :many
-SELECT id, item_id, workspace_id, filename, storage_path, thumbnail_path, file_size, mime_type, width, height, display_order, is_primary, caption, uploaded_by, thumbnail_status, thumbnail_small_path, thumbnail_medium_path, thumbnail_large_path, thumbnail_attempts, thumbnail_error, perceptual_hash, created_at, updated_at FROM warehouse.item_photos
+SELECT id, item_id, workspace_id, filename, storage_path, thumbnail_path, file_size, mime_type, width, height, display_order, is_primary, caption, uploaded_by, thumbnail_status, thumbnail_small_path, thumbnail_medium_path, thumbnail_large_path, thumbnail_attempts, thumbnail_error, perceptual_hash, created_at, updated_at, blurhash, content_hash, thumbnail_config_version, inventory_id FROM warehouse.item_photos
 WHERE item_id = $1
   AND workspace_id = $2
   AND perceptual_hash IS NOT NULL
@@ -398,6 +475,10 @@ func (q *Queries) GetItemPhotosWithHashes(ctx context.Context, arg GetItemPhotos
 			&i.PerceptualHash,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Blurhash,
+			&i.ContentHash,
+			&i.ThumbnailConfigVersion,
+			&i.InventoryID,
 		); err != nil {
 			return nil, err
 		}
@@ -409,26 +490,26 @@ func (q *Queries) GetItemPhotosWithHashes(ctx context.Context, arg GetItemPhotos
 	return items, nil
 }
 
-const getNextDisplayOrder = `-- name: GetNextDisplayOrder :one
-SELECT COALESCE(MAX(display_order) + 1, 0) as next_order
+const getMaxItemPhotoDisplayOrder = `-- name: GetMaxItemPhotoDisplayOrder :one
+SELECT COALESCE(MAX(display_order), 0)::int as max_order
 FROM warehouse.item_photos
 WHERE item_id = $1 AND workspace_id = $2
 `
 
-type GetNextDisplayOrderParams struct {
+type GetMaxItemPhotoDisplayOrderParams struct {
 	ItemID      uuid.UUID `json:"item_id"`
 	WorkspaceID uuid.UUID `json:"workspace_id"`
 }
 
-func (q *Queries) GetNextDisplayOrder(ctx context.Context, arg GetNextDisplayOrderParams) (interface{}, error) {
-	row := q.db.QueryRow(ctx, getNextDisplayOrder, arg.ItemID, arg.WorkspaceID)
-	var next_order interface{}
-	err := row.Scan(&next_order)
-	return next_order, err
+func (q *Queries) GetMaxItemPhotoDisplayOrder(ctx context.Context, arg GetMaxItemPhotoDisplayOrderParams) (int32, error) {
+	row := q.db.QueryRow(ctx, getMaxItemPhotoDisplayOrder, arg.ItemID, arg.WorkspaceID)
+	var max_order int32
+	err := row.Scan(&max_order)
+	return max_order, err
 }
 
 const getPhotosWithHashes = `-- name: GetPhotosWithHashes :many
-SELECT ip.id, ip.item_id, ip.workspace_id, ip.filename, ip.storage_path, ip.thumbnail_path, ip.file_size, ip.mime_type, ip.width, ip.height, ip.display_order, ip.is_primary, ip.caption, ip.uploaded_by, ip.thumbnail_status, ip.thumbnail_small_path, ip.thumbnail_medium_path, ip.thumbnail_large_path, ip.thumbnail_attempts, ip.thumbnail_error, ip.perceptual_hash, ip.created_at, ip.updated_at FROM warehouse.item_photos ip
+SELECT ip.id, ip.item_id, ip.workspace_id, ip.filename, ip.storage_path, ip.thumbnail_path, ip.file_size, ip.mime_type, ip.width, ip.height, ip.display_order, ip.is_primary, ip.caption, ip.uploaded_by, ip.thumbnail_status, ip.thumbnail_small_path, ip.thumbnail_medium_path, ip.thumbnail_large_path, ip.thumbnail_attempts, ip.thumbnail_error, ip.perceptual_hash, ip.created_at, ip.updated_at, ip.blurhash, ip.content_hash, ip.thumbnail_config_version, ip.inventory_id FROM warehouse.item_photos ip
 JOIN warehouse.items i ON i.id = ip.item_id
 WHERE ip.workspace_id = $1
   AND ip.perceptual_hash IS NOT NULL
@@ -471,6 +552,10 @@ func (q *Queries) GetPhotosWithHashes(ctx context.Context, workspaceID uuid.UUID
 			&i.PerceptualHash,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Blurhash,
+			&i.ContentHash,
+			&i.ThumbnailConfigVersion,
+			&i.InventoryID,
 		); err != nil {
 			return nil, err
 		}
@@ -483,7 +568,7 @@ func (q *Queries) GetPhotosWithHashes(ctx context.Context, workspaceID uuid.UUID
 }
 
 const getPrimaryItemPhoto = `-- name: GetPrimaryItemPhoto :one
-SELECT id, item_id, workspace_id, filename, storage_path, thumbnail_path, file_size, mime_type, width, height, display_order, is_primary, caption, uploaded_by, thumbnail_status, thumbnail_small_path, thumbnail_medium_path, thumbnail_large_path, thumbnail_attempts, thumbnail_error, perceptual_hash, created_at, updated_at FROM warehouse.item_photos
+SELECT id, item_id, workspace_id, filename, storage_path, thumbnail_path, file_size, mime_type, width, height, display_order, is_primary, caption, uploaded_by, thumbnail_status, thumbnail_small_path, thumbnail_medium_path, thumbnail_large_path, thumbnail_attempts, thumbnail_error, perceptual_hash, created_at, updated_at, blurhash, content_hash, thumbnail_config_version, inventory_id FROM warehouse.item_photos
 WHERE item_id = $1 AND workspace_id = $2 AND is_primary = true
 LIMIT 1
 `
@@ -520,12 +605,16 @@ func (q *Queries) GetPrimaryItemPhoto(ctx context.Context, arg GetPrimaryItemPho
 		&i.PerceptualHash,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Blurhash,
+		&i.ContentHash,
+		&i.ThumbnailConfigVersion,
+		&i.InventoryID,
 	)
 	return i, err
 }
 
 const getPrimaryPhotosByItemIDs = `-- name: GetPrimaryPhotosByItemIDs :many
-SELECT id, item_id, workspace_id, filename, storage_path, thumbnail_path, file_size, mime_type, width, height, display_order, is_primary, caption, uploaded_by, thumbnail_status, thumbnail_small_path, thumbnail_medium_path, thumbnail_large_path, thumbnail_attempts, thumbnail_error, perceptual_hash, created_at, updated_at FROM warehouse.item_photos
+SELECT id, item_id, workspace_id, filename, storage_path, thumbnail_path, file_size, mime_type, width, height, display_order, is_primary, caption, uploaded_by, thumbnail_status, thumbnail_small_path, thumbnail_medium_path, thumbnail_large_path, thumbnail_attempts, thumbnail_error, perceptual_hash, created_at, updated_at, blurhash, content_hash, thumbnail_config_version, inventory_id FROM warehouse.item_photos
 WHERE workspace_id = $1
   AND item_id = ANY($2::uuid[])
   AND is_primary = true
@@ -571,6 +660,91 @@ func (q *Queries) GetPrimaryPhotosByItemIDs(ctx context.Context, arg GetPrimaryP
 			&i.PerceptualHash,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Blurhash,
+			&i.ContentHash,
+			&i.ThumbnailConfigVersion,
+			&i.InventoryID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const itemHasInventory = `-- name: ItemHasInventory :one
+SELECT EXISTS(
+    SELECT 1 FROM warehouse.inventory
+    WHERE id = $1 AND item_id = $2 AND workspace_id = $3
+)
+`
+
+type ItemHasInventoryParams struct {
+	InventoryID uuid.UUID `json:"inventory_id"`
+	ItemID      uuid.UUID `json:"item_id"`
+	WorkspaceID uuid.UUID `json:"workspace_id"`
+}
+
+// Whether an inventory record is a unit of the item, for tagging photos.
+func (q *Queries) ItemHasInventory(ctx context.Context, arg ItemHasInventoryParams) (bool, error) {
+	row := q.db.QueryRow(ctx, itemHasInventory, arg.InventoryID, arg.ItemID, arg.WorkspaceID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const listItemPhotosByInventory = `-- name: ListItemPhotosByInventory :many
+SELECT id, item_id, workspace_id, filename, storage_path, thumbnail_path, file_size, mime_type, width, height, display_order, is_primary, caption, uploaded_by, thumbnail_status, thumbnail_small_path, thumbnail_medium_path, thumbnail_large_path, thumbnail_attempts, thumbnail_error, perceptual_hash, created_at, updated_at, blurhash, content_hash, thumbnail_config_version, inventory_id FROM warehouse.item_photos
+WHERE inventory_id = $1 AND workspace_id = $2
+ORDER BY display_order ASC, created_at ASC
+`
+
+type ListItemPhotosByInventoryParams struct {
+	InventoryID pgtype.UUID `json:"inventory_id"`
+	WorkspaceID uuid.UUID   `json:"workspace_id"`
+}
+
+// Photos of one physical unit, in the item's gallery order.
+func (q *Queries) ListItemPhotosByInventory(ctx context.Context, arg ListItemPhotosByInventoryParams) ([]WarehouseItemPhoto, error) {
+	rows, err := q.db.Query(ctx, listItemPhotosByInventory, arg.InventoryID, arg.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []WarehouseItemPhoto{}
+	for rows.Next() {
+		var i WarehouseItemPhoto
+		if err := rows.Scan(
+			&i.ID,
+			&i.ItemID,
+			&i.WorkspaceID,
+			&i.Filename,
+			&i.StoragePath,
+			&i.ThumbnailPath,
+			&i.FileSize,
+			&i.MimeType,
+			&i.Width,
+			&i.Height,
+			&i.DisplayOrder,
+			&i.IsPrimary,
+			&i.Caption,
+			&i.UploadedBy,
+			&i.ThumbnailStatus,
+			&i.ThumbnailSmallPath,
+			&i.ThumbnailMediumPath,
+			&i.ThumbnailLargePath,
+			&i.ThumbnailAttempts,
+			&i.ThumbnailError,
+			&i.PerceptualHash,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Blurhash,
+			&i.ContentHash,
+			&i.ThumbnailConfigVersion,
+			&i.InventoryID,
 		); err != nil {
 			return nil, err
 		}
@@ -583,7 +757,7 @@ func (q *Queries) GetPrimaryPhotosByItemIDs(ctx context.Context, arg GetPrimaryP
 }
 
 const listItemPhotosByItem = `-- name: ListItemPhotosByItem :many
-SELECT id, item_id, workspace_id, filename, storage_path, thumbnail_path, file_size, mime_type, width, height, display_order, is_primary, caption, uploaded_by, thumbnail_status, thumbnail_small_path, thumbnail_medium_path, thumbnail_large_path, thumbnail_attempts, thumbnail_error, perceptual_hash, created_at, updated_at FROM warehouse.item_photos
+SELECT id, item_id, workspace_id, filename, storage_path, thumbnail_path, file_size, mime_type, width, height, display_order, is_primary, caption, uploaded_by, thumbnail_status, thumbnail_small_path, thumbnail_medium_path, thumbnail_large_path, thumbnail_attempts, thumbnail_error, perceptual_hash, created_at, updated_at, blurhash, content_hash, thumbnail_config_version, inventory_id FROM warehouse.item_photos
 WHERE item_id = $1 AND workspace_id = $2
 ORDER BY display_order ASC, created_at ASC
 `
@@ -626,6 +800,54 @@ func (q *Queries) ListItemPhotosByItem(ctx context.Context, arg ListItemPhotosBy
 			&i.PerceptualHash,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Blurhash,
+			&i.ContentHash,
+			&i.ThumbnailConfigVersion,
+			&i.InventoryID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listItemsWithoutPhotos = `-- name: ListItemsWithoutPhotos :many
+SELECT i.id, i.sku, i.name, i.short_code FROM warehouse.items i
+WHERE i.workspace_id = $1
+  AND i.is_archived = false
+  AND NOT EXISTS (
+      SELECT 1 FROM warehouse.item_photos p
+      WHERE p.item_id = i.id AND p.workspace_id = i.workspace_id
+  )
+ORDER BY i.name ASC, i.id ASC
+`
+
+type ListItemsWithoutPhotosRow struct {
+	ID        uuid.UUID `json:"id"`
+	Sku       string    `json:"sku"`
+	Name      string    `json:"name"`
+	ShortCode string    `json:"short_code"`
+}
+
+// Active items with no photo at all, for the workspace require-photo rule.
+func (q *Queries) ListItemsWithoutPhotos(ctx context.Context, workspaceID uuid.UUID) ([]ListItemsWithoutPhotosRow, error) {
+	rows, err := q.db.Query(ctx, listItemsWithoutPhotos, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListItemsWithoutPhotosRow{}
+	for rows.Next() {
+		var i ListItemsWithoutPhotosRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Sku,
+			&i.Name,
+			&i.ShortCode,
 		); err != nil {
 			return nil, err
 		}
@@ -638,7 +860,7 @@ func (q *Queries) ListItemPhotosByItem(ctx context.Context, arg ListItemPhotosBy
 }
 
 const listPendingThumbnails = `-- name: ListPendingThumbnails :many
-SELECT id, item_id, workspace_id, filename, storage_path, thumbnail_path, file_size, mime_type, width, height, display_order, is_primary, caption, uploaded_by, thumbnail_status, thumbnail_small_path, thumbnail_medium_path, thumbnail_large_path, thumbnail_attempts, thumbnail_error, perceptual_hash, created_at, updated_at FROM warehouse.item_photos
+SELECT id, item_id, workspace_id, filename, storage_path, thumbnail_path, file_size, mime_type, width, height, display_order, is_primary, caption, uploaded_by, thumbnail_status, thumbnail_small_path, thumbnail_medium_path, thumbnail_large_path, thumbnail_attempts, thumbnail_error, perceptual_hash, created_at, updated_at, blurhash, content_hash, thumbnail_config_version, inventory_id FROM warehouse.item_photos
 WHERE thumbnail_status IN ('pending', 'processing')
   AND thumbnail_attempts < 5
 ORDER BY created_at ASC
@@ -679,6 +901,10 @@ func (q *Queries) ListPendingThumbnails(ctx context.Context, limit int32) ([]War
 			&i.PerceptualHash,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Blurhash,
+			&i.ContentHash,
+			&i.ThumbnailConfigVersion,
+			&i.InventoryID,
 		); err != nil {
 			return nil, err
 		}
@@ -690,6 +916,36 @@ func (q *Queries) ListPendingThumbnails(ctx context.Context, limit int32) ([]War
 	return items, nil
 }
 
+const moveItemPhotoToItem = `-- name: MoveItemPhotoToItem :exec
+UPDATE warehouse.item_photos
+SET item_id = $1,
+    display_order = $2,
+    is_primary = false,
+    inventory_id = NULL,
+    updated_at = now()
+WHERE id = $3 AND workspace_id = $4
+`
+
+type MoveItemPhotoToItemParams struct {
+	ItemID       uuid.UUID `json:"item_id"`
+	DisplayOrder int32     `json:"display_order"`
+	ID           uuid.UUID `json:"id"`
+	WorkspaceID  uuid.UUID `json:"workspace_id"`
+}
+
+// Re-point one photo to another item at a gallery position. It arrives
+// without the primary flag and without its unit tag, since the inventory
+// record belongs to the old item.
+func (q *Queries) MoveItemPhotoToItem(ctx context.Context, arg MoveItemPhotoToItemParams) error {
+	_, err := q.db.Exec(ctx, moveItemPhotoToItem,
+		arg.ItemID,
+		arg.DisplayOrder,
+		arg.ID,
+		arg.WorkspaceID,
+	)
+	return err
+}
+
 const setItemPhotoAsPrimary = `-- name: SetItemPhotoAsPrimary :exec
 UPDATE warehouse.item_photos
 SET is_primary = true, updated_at = now()
@@ -722,6 +978,23 @@ func (q *Queries) UnsetPrimaryPhotosForItem(ctx context.Context, arg UnsetPrimar
 	return err
 }
 
+const updateBlurhash = `-- name: UpdateBlurhash :exec
+UPDATE warehouse.item_photos
+SET blurhash = $1, updated_at = now()
+WHERE id = $2
+`
+
+type UpdateBlurhashParams struct {
+	Blurhash *string   `json:"blurhash"`
+	ID       uuid.UUID `json:"id"`
+}
+
+// Set blurhash placeholder after upload processing
+func (q *Queries) UpdateBlurhash(ctx context.Context, arg UpdateBlurhashParams) error {
+	_, err := q.db.Exec(ctx, updateBlurhash, arg.Blurhash, arg.ID)
+	return err
+}
+
 const updateItemPhoto = `-- name: UpdateItemPhoto :one
 UPDATE warehouse.item_photos
 SET
@@ -731,7 +1004,7 @@ SET
     display_order = COALESCE($4, display_order),
     updated_at = now()
 WHERE id = $5
-RETURNING id, item_id, workspace_id, filename, storage_path, thumbnail_path, file_size, mime_type, width, height, display_order, is_primary, caption, uploaded_by, thumbnail_status, thumbnail_small_path, thumbnail_medium_path, thumbnail_large_path, thumbnail_attempts, thumbnail_error, perceptual_hash, created_at, updated_at
+RETURNING id, item_id, workspace_id, filename, storage_path, thumbnail_path, file_size, mime_type, width, height, display_order, is_primary, caption, uploaded_by, thumbnail_status, thumbnail_small_path, thumbnail_medium_path, thumbnail_large_path, thumbnail_attempts, thumbnail_error, perceptual_hash, created_at, updated_at, blurhash, content_hash, thumbnail_config_version, inventory_id
 `
 
 type UpdateItemPhotoParams struct {
@@ -775,6 +1048,10 @@ func (q *Queries) UpdateItemPhoto(ctx context.Context, arg UpdateItemPhotoParams
 		&i.PerceptualHash,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Blurhash,
+		&i.ContentHash,
+		&i.ThumbnailConfigVersion,
+		&i.InventoryID,
 	)
 	return i, err
 }
@@ -835,27 +1112,31 @@ UPDATE warehouse.item_photos
 SET thumbnail_small_path = $2,
     thumbnail_medium_path = $3,
     thumbnail_large_path = $4,
+    thumbnail_config_version = $5,
     thumbnail_status = 'complete',
     thumbnail_error = NULL,
     updated_at = now()
 WHERE id = $1
-RETURNING id, item_id, workspace_id, filename, storage_path, thumbnail_path, file_size, mime_type, width, height, display_order, is_primary, caption, uploaded_by, thumbnail_status, thumbnail_small_path, thumbnail_medium_path, thumbnail_large_path, thumbnail_attempts, thumbnail_error, perceptual_hash, created_at, updated_at
+RETURNING id, item_id, workspace_id, filename, storage_path, thumbnail_path, file_size, mime_type, width, height, display_order, is_primary, caption, uploaded_by, thumbnail_status, thumbnail_small_path, thumbnail_medium_path, thumbnail_large_path, thumbnail_attempts, thumbnail_error, perceptual_hash, created_at, updated_at, blurhash, content_hash, thumbnail_config_version, inventory_id
 `
 
 type UpdateThumbnailPathsParams struct {
-	ID                  uuid.UUID `json:"id"`
-	ThumbnailSmallPath  *string   `json:"thumbnail_small_path"`
-	ThumbnailMediumPath *string   `json:"thumbnail_medium_path"`
-	ThumbnailLargePath  *string   `json:"thumbnail_large_path"`
+	ID                     uuid.UUID `json:"id"`
+	ThumbnailSmallPath     *string   `json:"thumbnail_small_path"`
+	ThumbnailMediumPath    *string   `json:"thumbnail_medium_path"`
+	ThumbnailLargePath     *string   `json:"thumbnail_large_path"`
+	ThumbnailConfigVersion *string   `json:"thumbnail_config_version"`
 }
 
-// Set all thumbnail paths and mark as complete
+// Set all thumbnail paths, record the config version they were generated
+// with and mark as complete
 func (q *Queries) UpdateThumbnailPaths(ctx context.Context, arg UpdateThumbnailPathsParams) (WarehouseItemPhoto, error) {
 	row := q.db.QueryRow(ctx, updateThumbnailPaths,
 		arg.ID,
 		arg.ThumbnailSmallPath,
 		arg.ThumbnailMediumPath,
 		arg.ThumbnailLargePath,
+		arg.ThumbnailConfigVersion,
 	)
 	var i WarehouseItemPhoto
 	err := row.Scan(
@@ -882,6 +1163,10 @@ func (q *Queries) UpdateThumbnailPaths(ctx context.Context, arg UpdateThumbnailP
 		&i.PerceptualHash,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Blurhash,
+		&i.ContentHash,
+		&i.ThumbnailConfigVersion,
+		&i.InventoryID,
 	)
 	return i, err
 }
@@ -44,6 +44,25 @@ func (q *Queries) AttachLabel(ctx context.Context, arg AttachLabelParams) error
 	return err
 }
 
+const countItemLoans = `-- name: CountItemLoans :one
+SELECT COUNT(*) FROM warehouse.loans l
+JOIN warehouse.inventory inv ON inv.id = l.inventory_id
+WHERE inv.workspace_id = $1 AND inv.item_id = $2
+`
+
+type CountItemLoansParams struct {
+	WorkspaceID uuid.UUID `json:"workspace_id"`
+	ItemID      uuid.UUID `json:"item_id"`
+}
+
+// Loans (open and returned) against any of an item's inventory.
+func (q *Queries) CountItemLoans(ctx context.Context, arg CountItemLoansParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countItemLoans, arg.WorkspaceID, arg.ItemID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
 const countItemsFiltered = `-- name: CountItemsFiltered :one
 SELECT COUNT(*) FROM warehouse.items
 WHERE workspace_id = $1
@@ -59,15 +78,26 @@ WHERE workspace_id = $1
        OR is_insured = $5::bool)
   AND ($6::bool IS NULL
        OR needs_review = $6::bool)
+  AND ($7::text IS NULL
+       OR lower(brand) = lower($7::text))
+  AND ($8::bool IS NULL
+       OR EXISTS (
+            SELECT 1 FROM warehouse.inventory inv
+            WHERE inv.item_id = items.id
+              AND inv.is_archived = false
+              AND inv.quantity > 0
+          ) = $8::bool)
 `
 
 type CountItemsFilteredParams struct {
-	WorkspaceID uuid.UUID   `json:"workspace_id"`
-	Archived    *bool       `json:"archived"`
-	Search      *string     `json:"search"`
-	CategoryID  pgtype.UUID `json:"category_id"`
-	IsInsured   *bool       `json:"is_insured"`
-	NeedsReview *bool       `json:"needs_review"`
+	WorkspaceID  uuid.UUID   `json:"workspace_id"`
+	Archived     *bool       `json:"archived"`
+	Search       *string     `json:"search"`
+	CategoryID   pgtype.UUID `json:"category_id"`
+	IsInsured    *bool       `json:"is_insured"`
+	NeedsReview  *bool       `json:"needs_review"`
+	Brand        *string     `json:"brand"`
+	HasInventory *bool       `json:"has_inventory"`
 }
 
 func (q *Queries) CountItemsFiltered(ctx context.Context, arg CountItemsFilteredParams) (int64, error) {
@@ -78,6 +108,8 @@ func (q *Queries) CountItemsFiltered(ctx context.Context, arg CountItemsFiltered
 		arg.CategoryID,
 		arg.IsInsured,
 		arg.NeedsReview,
+		arg.Brand,
+		arg.HasInventory,
 	)
 	var count int64
 	err := row.Scan(&count)
@@ -101,10 +133,10 @@ INSERT INTO warehouse.items (
     id, workspace_id, sku, name, description, category_id, brand, model,
     image_url, serial_number, manufacturer, barcode, is_insured,
     lifetime_warranty, warranty_details, purchased_from, min_stock_level,
-    short_code, obsidian_vault_path, obsidian_note_path, needs_review
+    short_code, obsidian_vault_path, obsidian_note_path, needs_review, loanable
 )
-VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21)
-RETURNING id, workspace_id, sku, name, description, category_id, brand, model, image_url, serial_number, manufacturer, barcode, is_insured, is_archived, needs_review, lifetime_warranty, warranty_details, purchased_from, min_stock_level, short_code, obsidian_vault_path, obsidian_note_path, search_vector, created_at, updated_at
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22)
+RETURNING id, workspace_id, sku, name, description, category_id, brand, model, image_url, serial_number, manufacturer, barcode, is_insured, is_archived, needs_review, lifetime_warranty, warranty_details, purchased_from, min_stock_level, short_code, obsidian_vault_path, obsidian_note_path, search_vector, created_at, updated_at, loanable
 `
 
 type CreateItemParams struct {
@@ -129,6 +161,7 @@ type CreateItemParams struct {
 	ObsidianVaultPath *string     `json:"obsidian_vault_path"`
 	ObsidianNotePath  *string     `json:"obsidian_note_path"`
 	NeedsReview       *bool       `json:"needs_review"`
+	Loanable          bool        `json:"loanable"`
 }
 
 func (q *Queries) CreateItem(ctx context.Context, arg CreateItemParams) (WarehouseItem, error) {
@@ -154,6 +187,7 @@ func (q *Queries) CreateItem(ctx context.Context, arg CreateItemParams) (Warehou
 		arg.ObsidianVaultPath,
 		arg.ObsidianNotePath,
 		arg.NeedsReview,
+		arg.Loanable,
 	)
 	var i WarehouseItem
 	err := row.Scan(
@@ -182,6 +216,7 @@ func (q *Queries) CreateItem(ctx context.Context, arg CreateItemParams) (Warehou
 		&i.SearchVector,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Loanable,
 	)
 	return i, err
 }
@@ -215,8 +250,72 @@ func (q *Queries) DetachLabel(ctx context.Context, arg DetachLabelParams) error
 	return err
 }
 
+const findSimilarItems = `-- name: FindSimilarItems :many
+SELECT a.id AS item_id, a.name AS item_name, a.sku AS item_sku, a.brand AS item_brand,
+       b.id AS match_id, b.name AS match_name, b.sku AS match_sku, b.brand AS match_brand,
+       similarity(concat_ws(' ', a.name, a.brand), concat_ws(' ', b.name, b.brand))::float8 AS similarity
+FROM warehouse.items a
+JOIN warehouse.items b ON b.workspace_id = a.workspace_id AND a.id < b.id
+WHERE a.workspace_id = $1
+  AND a.is_archived = false
+  AND b.is_archived = false
+  AND similarity(concat_ws(' ', a.name, a.brand), concat_ws(' ', b.name, b.brand)) >= $2::float8
+ORDER BY similarity DESC, a.name, b.name
+LIMIT $3
+`
+
+type FindSimilarItemsParams struct {
+	WorkspaceID uuid.UUID `json:"workspace_id"`
+	Threshold   float64   `json:"threshold"`
+	MaxResults  int32     `json:"max_results"`
+}
+
+type FindSimilarItemsRow struct {
+	ItemID     uuid.UUID `json:"item_id"`
+	ItemName   string    `json:"item_name"`
+	ItemSku    string    `json:"item_sku"`
+	ItemBrand  *string   `json:"item_brand"`
+	MatchID    uuid.UUID `json:"match_id"`
+	MatchName  string    `json:"match_name"`
+	MatchSku   string    `json:"match_sku"`
+	MatchBrand *string   `json:"match_brand"`
+	Similarity float64   `json:"similarity"`
+}
+
+// Pairs of active items whose "name brand" text is trigram-similar, most
+// similar first. Each pair is listed once (lower id first).
+func (q *Queries) FindSimilarItems(ctx context.Context, arg FindSimilarItemsParams) ([]FindSimilarItemsRow, error) {
+	rows, err := q.db.Query(ctx, findSimilarItems, arg.WorkspaceID, arg.Threshold, arg.MaxResults)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []FindSimilarItemsRow{}
+	for rows.Next() {
+		var i FindSimilarItemsRow
+		if err := rows.Scan(
+			&i.ItemID,
+			&i.ItemName,
+			&i.ItemSku,
+			&i.ItemBrand,
+			&i.MatchID,
+			&i.MatchName,
+			&i.MatchSku,
+			&i.MatchBrand,
+			&i.Similarity,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getItem = `-- name: GetItem :one
-SELECT id, workspace_id, sku, name, description, category_id, brand, model, image_url, serial_number, manufacturer, barcode, is_insured, is_archived, needs_review, lifetime_warranty, warranty_details, purchased_from, min_stock_level, short_code, obsidian_vault_path, obsidian_note_path, search_vector, created_at, updated_at FROM warehouse.items
+SELECT id, workspace_id, sku, name, description, category_id, brand, model, image_url, serial_number, manufacturer, barcode, is_insured, is_archived, needs_review, lifetime_warranty, warranty_details, purchased_from, min_stock_level, short_code, obsidian_vault_path, obsidian_note_path, search_vector, created_at, updated_at, loanable FROM warehouse.items
 WHERE id = $1 AND workspace_id = $2
 `
 
@@ -254,12 +353,13 @@ func (q *Queries) GetItem(ctx context.Context, arg GetItemParams) (WarehouseItem
 		&i.SearchVector,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Loanable,
 	)
 	return i, err
 }
 
 const getItemByBarcode = `-- name: GetItemByBarcode :one
-SELECT id, workspace_id, sku, name, description, category_id, brand, model, image_url, serial_number, manufacturer, barcode, is_insured, is_archived, needs_review, lifetime_warranty, warranty_details, purchased_from, min_stock_level, short_code, obsidian_vault_path, obsidian_note_path, search_vector, created_at, updated_at FROM warehouse.items
+SELECT id, workspace_id, sku, name, description, category_id, brand, model, image_url, serial_number, manufacturer, barcode, is_insured, is_archived, needs_review, lifetime_warranty, warranty_details, purchased_from, min_stock_level, short_code, obsidian_vault_path, obsidian_note_path, search_vector, created_at, updated_at, loanable FROM warehouse.items
 WHERE workspace_id = $1 AND barcode = $2
 `
 
@@ -297,12 +397,13 @@ func (q *Queries) GetItemByBarcode(ctx context.Context, arg GetItemByBarcodePara
 		&i.SearchVector,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Loanable,
 	)
 	return i, err
 }
 
 const getItemBySKU = `-- name: GetItemBySKU :one
-SELECT id, workspace_id, sku, name, description, category_id, brand, model, image_url, serial_number, manufacturer, barcode, is_insured, is_archived, needs_review, lifetime_warranty, warranty_details, purchased_from, min_stock_level, short_code, obsidian_vault_path, obsidian_note_path, search_vector, created_at, updated_at FROM warehouse.items
+SELECT id, workspace_id, sku, name, description, category_id, brand, model, image_url, serial_number, manufacturer, barcode, is_insured, is_archived, needs_review, lifetime_warranty, warranty_details, purchased_from, min_stock_level, short_code, obsidian_vault_path, obsidian_note_path, search_vector, created_at, updated_at, loanable FROM warehouse.items
 WHERE workspace_id = $1 AND sku = $2
 `
 
@@ -340,12 +441,13 @@ func (q *Queries) GetItemBySKU(ctx context.Context, arg GetItemBySKUParams) (War
 		&i.SearchVector,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Loanable,
 	)
 	return i, err
 }
 
 const getItemByShortCode = `-- name: GetItemByShortCode :one
-SELECT id, workspace_id, sku, name, description, category_id, brand, model, image_url, serial_number, manufacturer, barcode, is_insured, is_archived, needs_review, lifetime_warranty, warranty_details, purchased_from, min_stock_level, short_code, obsidian_vault_path, obsidian_note_path, search_vector, created_at, updated_at FROM warehouse.items
+SELECT id, workspace_id, sku, name, description, category_id, brand, model, image_url, serial_number, manufacturer, barcode, is_insured, is_archived, needs_review, lifetime_warranty, warranty_details, purchased_from, min_stock_level, short_code, obsidian_vault_path, obsidian_note_path, search_vector, created_at, updated_at, loanable FROM warehouse.items
 WHERE workspace_id = $1 AND short_code = $2
 `
 
@@ -383,6 +485,7 @@ func (q *Queries) GetItemByShortCode(ctx context.Context, arg GetItemByShortCode
 		&i.SearchVector,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Loanable,
 	)
 	return i, err
 }
@@ -423,7 +526,7 @@ func (q *Queries) GetItemLabels(ctx context.Context, itemID uuid.UUID) ([]Wareho
 }
 
 const getItemWithDetails = `-- name: GetItemWithDetails :one
-SELECT i.id, i.workspace_id, i.sku, i.name, i.description, i.category_id, i.brand, i.model, i.image_url, i.serial_number, i.manufacturer, i.barcode, i.is_insured, i.is_archived, i.needs_review, i.lifetime_warranty, i.warranty_details, i.purchased_from, i.min_stock_level, i.short_code, i.obsidian_vault_path, i.obsidian_note_path, i.search_vector, i.created_at, i.updated_at, c.name as category_name, co.name as company_name
+SELECT i.id, i.workspace_id, i.sku, i.name, i.description, i.category_id, i.brand, i.model, i.image_url, i.serial_number, i.manufacturer, i.barcode, i.is_insured, i.is_archived, i.needs_review, i.lifetime_warranty, i.warranty_details, i.purchased_from, i.min_stock_level, i.short_code, i.obsidian_vault_path, i.obsidian_note_path, i.search_vector, i.created_at, i.updated_at, i.loanable, c.name as category_name, co.name as company_name
 FROM warehouse.items i
 LEFT JOIN warehouse.categories c ON i.category_id = c.id
 LEFT JOIN warehouse.companies co ON i.purchased_from = co.id
@@ -461,6 +564,7 @@ type GetItemWithDetailsRow struct {
 	SearchVector      interface{}        `json:"search_vector"`
 	CreatedAt         pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt         pgtype.Timestamptz `json:"updated_at"`
+	Loanable          bool               `json:"loanable"`
 	CategoryName      *string            `json:"category_name"`
 	CompanyName       *string            `json:"company_name"`
 }
@@ -494,6 +598,7 @@ func (q *Queries) GetItemWithDetails(ctx context.Context, arg GetItemWithDetails
 		&i.SearchVector,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Loanable,
 		&i.CategoryName,
 		&i.CompanyName,
 	)
@@ -520,7 +625,7 @@ func (q *Queries) ItemSKUExists(ctx context.Context, arg ItemSKUExistsParams) (b
 }
 
 const listItems = `-- name: ListItems :many
-SELECT id, workspace_id, sku, name, description, category_id, brand, model, image_url, serial_number, manufacturer, barcode, is_insured, is_archived, needs_review, lifetime_warranty, warranty_details, purchased_from, min_stock_level, short_code, obsidian_vault_path, obsidian_note_path, search_vector, created_at, updated_at FROM warehouse.items
+SELECT id, workspace_id, sku, name, description, category_id, brand, model, image_url, serial_number, manufacturer, barcode, is_insured, is_archived, needs_review, lifetime_warranty, warranty_details, purchased_from, min_stock_level, short_code, obsidian_vault_path, obsidian_note_path, search_vector, created_at, updated_at, loanable FROM warehouse.items
 WHERE workspace_id = $1 AND is_archived = false
 ORDER BY name
 LIMIT $2 OFFSET $3
@@ -567,6 +672,7 @@ func (q *Queries) ListItems(ctx context.Context, arg ListItemsParams) ([]Warehou
 			&i.SearchVector,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Loanable,
 		); err != nil {
 			return nil, err
 		}
@@ -579,7 +685,7 @@ func (q *Queries) ListItems(ctx context.Context, arg ListItemsParams) ([]Warehou
 }
 
 const listItemsByCategory = `-- name: ListItemsByCategory :many
-SELECT id, workspace_id, sku, name, description, category_id, brand, model, image_url, serial_number, manufacturer, barcode, is_insured, is_archived, needs_review, lifetime_warranty, warranty_details, purchased_from, min_stock_level, short_code, obsidian_vault_path, obsidian_note_path, search_vector, created_at, updated_at FROM warehouse.items
+SELECT id, workspace_id, sku, name, description, category_id, brand, model, image_url, serial_number, manufacturer, barcode, is_insured, is_archived, needs_review, lifetime_warranty, warranty_details, purchased_from, min_stock_level, short_code, obsidian_vault_path, obsidian_note_path, search_vector, created_at, updated_at, loanable FROM warehouse.items
 WHERE workspace_id = $1 AND category_id = $2 AND is_archived = false
 ORDER BY name
 LIMIT $3 OFFSET $4
@@ -632,6 +738,67 @@ func (q *Queries) ListItemsByCategory(ctx context.Context, arg ListItemsByCatego
 			&i.SearchVector,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Loanable,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listItemsByIDs = `-- name: ListItemsByIDs :many
+SELECT id, workspace_id, sku, name, description, category_id, brand, model, image_url, serial_number, manufacturer, barcode, is_insured, is_archived, needs_review, lifetime_warranty, warranty_details, purchased_from, min_stock_level, short_code, obsidian_vault_path, obsidian_note_path, search_vector, created_at, updated_at, loanable FROM warehouse.items
+WHERE workspace_id = $1 AND id = ANY($2::uuid[])
+ORDER BY array_position($2::uuid[], id)
+`
+
+type ListItemsByIDsParams struct {
+	WorkspaceID uuid.UUID   `json:"workspace_id"`
+	Ids         []uuid.UUID `json:"ids"`
+}
+
+// Items with the given IDs, in the order the IDs are listed. IDs that do not
+// exist in the workspace are skipped.
+func (q *Queries) ListItemsByIDs(ctx context.Context, arg ListItemsByIDsParams) ([]WarehouseItem, error) {
+	rows, err := q.db.Query(ctx, listItemsByIDs, arg.WorkspaceID, arg.Ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []WarehouseItem{}
+	for rows.Next() {
+		var i WarehouseItem
+		if err := rows.Scan(
+			&i.ID,
+			&i.WorkspaceID,
+			&i.Sku,
+			&i.Name,
+			&i.Description,
+			&i.CategoryID,
+			&i.Brand,
+			&i.Model,
+			&i.ImageUrl,
+			&i.SerialNumber,
+			&i.Manufacturer,
+			&i.Barcode,
+			&i.IsInsured,
+			&i.IsArchived,
+			&i.NeedsReview,
+			&i.LifetimeWarranty,
+			&i.WarrantyDetails,
+			&i.PurchasedFrom,
+			&i.MinStockLevel,
+			&i.ShortCode,
+			&i.ObsidianVaultPath,
+			&i.ObsidianNotePath,
+			&i.SearchVector,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.Loanable,
 		); err != nil {
 			return nil, err
 		}
@@ -644,7 +811,7 @@ func (q *Queries) ListItemsByCategory(ctx context.Context, arg ListItemsByCatego
 }
 
 const listItemsFiltered = `-- name: ListItemsFiltered :many
-SELECT id, workspace_id, sku, name, description, category_id, brand, model, image_url, serial_number, manufacturer, barcode, is_insured, is_archived, needs_review, lifetime_warranty, warranty_details, purchased_from, min_stock_level, short_code, obsidian_vault_path, obsidian_note_path, search_vector, created_at, updated_at FROM warehouse.items
+SELECT id, workspace_id, sku, name, description, category_id, brand, model, image_url, serial_number, manufacturer, barcode, is_insured, is_archived, needs_review, lifetime_warranty, warranty_details, purchased_from, min_stock_level, short_code, obsidian_vault_path, obsidian_note_path, search_vector, created_at, updated_at, loanable FROM warehouse.items
 WHERE workspace_id = $1
   AND ($4::bool IS NULL
        OR $4::bool = true
@@ -658,29 +825,40 @@ WHERE workspace_id = $1
        OR is_insured = $7::bool)
   AND ($8::bool IS NULL
        OR needs_review = $8::bool)
+  AND ($9::text IS NULL
+       OR lower(brand) = lower($9::text))
+  AND ($10::bool IS NULL
+       OR EXISTS (
+            SELECT 1 FROM warehouse.inventory inv
+            WHERE inv.item_id = items.id
+              AND inv.is_archived = false
+              AND inv.quantity > 0
+          ) = $10::bool)
 ORDER BY
-  CASE WHEN $9::text = 'name'        AND $10::text = 'asc'  THEN name        END ASC NULLS LAST,
-  CASE WHEN $9::text = 'name'        AND $10::text = 'desc' THEN name        END DESC NULLS LAST,
-  CASE WHEN $9::text = 'sku'         AND $10::text = 'asc'  THEN sku         END ASC NULLS LAST,
-  CASE WHEN $9::text = 'sku'         AND $10::text = 'desc' THEN sku         END DESC NULLS LAST,
-  CASE WHEN $9::text = 'created_at'  AND $10::text = 'asc'  THEN created_at  END ASC NULLS LAST,
-  CASE WHEN $9::text = 'created_at'  AND $10::text = 'desc' THEN created_at  END DESC NULLS LAST,
-  CASE WHEN $9::text = 'updated_at'  AND $10::text = 'asc'  THEN updated_at  END ASC NULLS LAST,
-  CASE WHEN $9::text = 'updated_at'  AND $10::text = 'desc' THEN updated_at  END DESC NULLS LAST
+  CASE WHEN $11::text = 'name'        AND $12::text = 'asc'  THEN name        END ASC NULLS LAST,
+  CASE WHEN $11::text = 'name'        AND $12::text = 'desc' THEN name        END DESC NULLS LAST,
+  CASE WHEN $11::text = 'sku'         AND $12::text = 'asc'  THEN sku         END ASC NULLS LAST,
+  CASE WHEN $11::text = 'sku'         AND $12::text = 'desc' THEN sku         END DESC NULLS LAST,
+  CASE WHEN $11::text = 'created_at'  AND $12::text = 'asc'  THEN created_at  END ASC NULLS LAST,
+  CASE WHEN $11::text = 'created_at'  AND $12::text = 'desc' THEN created_at  END DESC NULLS LAST,
+  CASE WHEN $11::text = 'updated_at'  AND $12::text = 'asc'  THEN updated_at  END ASC NULLS LAST,
+  CASE WHEN $11::text = 'updated_at'  AND $12::text = 'desc' THEN updated_at  END DESC NULLS LAST
 LIMIT $2 OFFSET $3
 `
 
 type ListItemsFilteredParams struct {
-	WorkspaceID uuid.UUID   `json:"workspace_id"`
-	Limit       int32       `json:"limit"`
-	Offset      int32       `json:"offset"`
-	Archived    *bool       `json:"archived"`
-	Search      *string     `json:"search"`
-	CategoryID  pgtype.UUID `json:"category_id"`
-	IsInsured   *bool       `json:"is_insured"`
-	NeedsReview *bool       `json:"needs_review"`
-	SortField   string      `json:"sort_field"`
-	SortDir     string      `json:"sort_dir"`
+	WorkspaceID  uuid.UUID   `json:"workspace_id"`
+	Limit        int32       `json:"limit"`
+	Offset       int32       `json:"offset"`
+	Archived     *bool       `json:"archived"`
+	Search       *string     `json:"search"`
+	CategoryID   pgtype.UUID `json:"category_id"`
+	IsInsured    *bool       `json:"is_insured"`
+	NeedsReview  *bool       `json:"needs_review"`
+	Brand        *string     `json:"brand"`
+	HasInventory *bool       `json:"has_inventory"`
+	SortField    string      `json:"sort_field"`
+	SortDir      string      `json:"sort_dir"`
 }
 
 func (q *Queries) ListItemsFiltered(ctx context.Context, arg ListItemsFilteredParams) ([]WarehouseItem, error) {
@@ -693,6 +871,8 @@ func (q *Queries) ListItemsFiltered(ctx context.Context, arg ListItemsFilteredPa
 		arg.CategoryID,
 		arg.IsInsured,
 		arg.NeedsReview,
+		arg.Brand,
+		arg.HasInventory,
 		arg.SortField,
 		arg.SortDir,
 	)
@@ -729,6 +909,7 @@ func (q *Queries) ListItemsFiltered(ctx context.Context, arg ListItemsFilteredPa
 			&i.SearchVector,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Loanable,
 		); err != nil {
 			return nil, err
 		}
@@ -741,7 +922,7 @@ func (q *Queries) ListItemsFiltered(ctx context.Context, arg ListItemsFilteredPa
 }
 
 const listItemsNeedingReview = `-- name: ListItemsNeedingReview :many
-SELECT id, workspace_id, sku, name, description, category_id, brand, model, image_url, serial_number, manufacturer, barcode, is_insured, is_archived, needs_review, lifetime_warranty, warranty_details, purchased_from, min_stock_level, short_code, obsidian_vault_path, obsidian_note_path, search_vector, created_at, updated_at FROM warehouse.items
+SELECT id, workspace_id, sku, name, description, category_id, brand, model, image_url, serial_number, manufacturer, barcode, is_insured, is_archived, needs_review, lifetime_warranty, warranty_details, purchased_from, min_stock_level, short_code, obsidian_vault_path, obsidian_note_path, search_vector, created_at, updated_at, loanable FROM warehouse.items
 WHERE workspace_id = $1 AND needs_review = true AND is_archived = false
 ORDER BY updated_at DESC
 LIMIT $2 OFFSET $3
@@ -788,6 +969,7 @@ func (q *Queries) ListItemsNeedingReview(ctx context.Context, arg ListItemsNeedi
 			&i.SearchVector,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Loanable,
 		); err != nil {
 			return nil, err
 		}
@@ -799,6 +981,127 @@ func (q *Queries) ListItemsNeedingReview(ctx context.Context, arg ListItemsNeedi
 	return items, nil
 }
 
+const moveItemInventory = `-- name: MoveItemInventory :execrows
+UPDATE warehouse.inventory
+SET item_id = $1, updated_at = now()
+WHERE workspace_id = $2
+  AND item_id = $3
+`
+
+type MoveItemInventoryParams struct {
+	ToItemID    uuid.UUID `json:"to_item_id"`
+	WorkspaceID uuid.UUID `json:"workspace_id"`
+	FromItemID  uuid.UUID `json:"from_item_id"`
+}
+
+// Re-point every inventory row of one item to another (item merge). Loans
+// follow their inventory.
+func (q *Queries) MoveItemInventory(ctx context.Context, arg MoveItemInventoryParams) (int64, error) {
+	result, err := q.db.Exec(ctx, moveItemInventory, arg.ToItemID, arg.WorkspaceID, arg.FromItemID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const moveItemLabels = `-- name: MoveItemLabels :execrows
+INSERT INTO warehouse.item_labels (item_id, label_id, workspace_id)
+SELECT $1, label_id, workspace_id
+FROM warehouse.item_labels
+WHERE workspace_id = $2
+  AND item_id = $3
+ON CONFLICT DO NOTHING
+`
+
+type MoveItemLabelsParams struct {
+	ToItemID    uuid.UUID `json:"to_item_id"`
+	WorkspaceID uuid.UUID `json:"workspace_id"`
+	FromItemID  uuid.UUID `json:"from_item_id"`
+}
+
+// Copy one item's labels onto another (item merge), skipping labels the
+// target already has. The source rows go when the source item is deleted.
+func (q *Queries) MoveItemLabels(ctx context.Context, arg MoveItemLabelsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, moveItemLabels, arg.ToItemID, arg.WorkspaceID, arg.FromItemID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const moveItemPhotos = `-- name: MoveItemPhotos :execrows
+UPDATE warehouse.item_photos p
+SET item_id = $1,
+    display_order = p.display_order + (
+        SELECT COALESCE(MAX(t.display_order) + 1, 0) FROM warehouse.item_photos t
+        WHERE t.workspace_id = $2 AND t.item_id = $1
+    ),
+    is_primary = p.is_primary AND NOT EXISTS (
+        SELECT 1 FROM warehouse.item_photos t
+        WHERE t.workspace_id = $2 AND t.item_id = $1 AND t.is_primary
+    ),
+    updated_at = now()
+WHERE p.workspace_id = $2
+  AND p.item_id = $3
+`
+
+type MoveItemPhotosParams struct {
+	ToItemID    uuid.UUID `json:"to_item_id"`
+	WorkspaceID uuid.UUID `json:"workspace_id"`
+	FromItemID  uuid.UUID `json:"from_item_id"`
+}
+
+// Re-point every photo of one item to another (item merge), after the
+// target's own photos. A moved primary photo stays primary only if the
+// target has none.
+func (q *Queries) MoveItemPhotos(ctx context.Context, arg MoveItemPhotosParams) (int64, error) {
+	result, err := q.db.Exec(ctx, moveItemPhotos, arg.ToItemID, arg.WorkspaceID, arg.FromItemID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const reassignItemsCategory = `-- name: ReassignItemsCategory :many
+UPDATE warehouse.items
+SET category_id = $1, updated_at = now()
+WHERE workspace_id = $2 AND id = ANY($3::uuid[])
+RETURNING id, name
+`
+
+type ReassignItemsCategoryParams struct {
+	CategoryID  pgtype.UUID `json:"category_id"`
+	WorkspaceID uuid.UUID   `json:"workspace_id"`
+	ItemIds     []uuid.UUID `json:"item_ids"`
+}
+
+type ReassignItemsCategoryRow struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+}
+
+// Moves a batch of items to a category (NULL clears it) in one statement.
+// Scoped on workspace_id: IDs from other workspaces are not touched.
+func (q *Queries) ReassignItemsCategory(ctx context.Context, arg ReassignItemsCategoryParams) ([]ReassignItemsCategoryRow, error) {
+	rows, err := q.db.Query(ctx, reassignItemsCategory, arg.CategoryID, arg.WorkspaceID, arg.ItemIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ReassignItemsCategoryRow{}
+	for rows.Next() {
+		var i ReassignItemsCategoryRow
+		if err := rows.Scan(&i.ID, &i.Name); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const restoreItem = `-- name: RestoreItem :exec
 UPDATE warehouse.items
 SET is_archived = false, updated_at = now()
@@ -816,7 +1119,7 @@ func (q *Queries) RestoreItem(ctx context.Context, arg RestoreItemParams) error
 }
 
 const searchItems = `-- name: SearchItems :many
-SELECT id, workspace_id, sku, name, description, category_id, brand, model, image_url, serial_number, manufacturer, barcode, is_insured, is_archived, needs_review, lifetime_warranty, warranty_details, purchased_from, min_stock_level, short_code, obsidian_vault_path, obsidian_note_path, search_vector, created_at, updated_at FROM warehouse.items
+SELECT id, workspace_id, sku, name, description, category_id, brand, model, image_url, serial_number, manufacturer, barcode, is_insured, is_archived, needs_review, lifetime_warranty, warranty_details, purchased_from, min_stock_level, short_code, obsidian_vault_path, obsidian_note_path, search_vector, created_at, updated_at, loanable FROM warehouse.items
 WHERE workspace_id = $1
   AND is_archived = false
   AND search_vector @@ plainto_tsquery('english', $2)
@@ -865,6 +1168,7 @@ func (q *Queries) SearchItems(ctx context.Context, arg SearchItemsParams) ([]War
 			&i.SearchVector,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Loanable,
 		); err != nil {
 			return nil, err
 		}
@@ -882,9 +1186,9 @@ SET name = $2, description = $3, category_id = $4, brand = $5, model = $6,
     image_url = $7, serial_number = $8, manufacturer = $9, barcode = $10,
     is_insured = $11, lifetime_warranty = $12, warranty_details = $13,
     purchased_from = $14, min_stock_level = $15, obsidian_vault_path = $16,
-    obsidian_note_path = $17, needs_review = $18, updated_at = now()
-WHERE id = $1 AND workspace_id = $19
-RETURNING id, workspace_id, sku, name, description, category_id, brand, model, image_url, serial_number, manufacturer, barcode, is_insured, is_archived, needs_review, lifetime_warranty, warranty_details, purchased_from, min_stock_level, short_code, obsidian_vault_path, obsidian_note_path, search_vector, created_at, updated_at
+    obsidian_note_path = $17, needs_review = $18, loanable = $19, updated_at = now()
+WHERE id = $1 AND workspace_id = $20
+RETURNING id, workspace_id, sku, name, description, category_id, brand, model, image_url, serial_number, manufacturer, barcode, is_insured, is_archived, needs_review, lifetime_warranty, warranty_details, purchased_from, min_stock_level, short_code, obsidian_vault_path, obsidian_note_path, search_vector, created_at, updated_at, loanable
 `
 
 type UpdateItemParams struct {
@@ -906,6 +1210,7 @@ type UpdateItemParams struct {
 	ObsidianVaultPath *string     `json:"obsidian_vault_path"`
 	ObsidianNotePath  *string     `json:"obsidian_note_path"`
 	NeedsReview       *bool       `json:"needs_review"`
+	Loanable          bool        `json:"loanable"`
 	WorkspaceID       uuid.UUID   `json:"workspace_id"`
 }
 
@@ -929,6 +1234,7 @@ func (q *Queries) UpdateItem(ctx context.Context, arg UpdateItemParams) (Warehou
 		arg.ObsidianVaultPath,
 		arg.ObsidianNotePath,
 		arg.NeedsReview,
+		arg.Loanable,
 		arg.WorkspaceID,
 	)
 	var i WarehouseItem
@@ -958,6 +1264,24 @@ func (q *Queries) UpdateItem(ctx context.Context, arg UpdateItemParams) (Warehou
 		&i.SearchVector,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Loanable,
 	)
 	return i, err
 }
+
+const updateItemSKU = `-- name: UpdateItemSKU :exec
+UPDATE warehouse.items
+SET sku = $3, updated_at = now()
+WHERE id = $1 AND workspace_id = $2
+`
+
+type UpdateItemSKUParams struct {
+	ID          uuid.UUID `json:"id"`
+	WorkspaceID uuid.UUID `json:"workspace_id"`
+	Sku         string    `json:"sku"`
+}
+
+func (q *Queries) UpdateItemSKU(ctx context.Context, arg UpdateItemSKUParams) error {
+	_, err := q.db.Exec(ctx, updateItemSKU, arg.ID, arg.WorkspaceID, arg.Sku)
+	return err
+}
@@ -141,6 +141,57 @@ func (q *Queries) GetLoan(ctx context.Context, arg GetLoanParams) (WarehouseLoan
 	return i, err
 }
 
+const getLoanReceiptDetails = `-- name: GetLoanReceiptDetails :one
+SELECT w.name as workspace_name,
+       it.name as item_name, it.sku, it.brand, it.model, it.serial_number,
+       i.condition,
+       b.name as borrower_name, b.email as borrower_email, b.phone as borrower_phone
+FROM warehouse.loans l
+JOIN auth.workspaces w ON l.workspace_id = w.id
+JOIN warehouse.inventory i ON l.inventory_id = i.id
+JOIN warehouse.items it ON i.item_id = it.id
+JOIN warehouse.borrowers b ON l.borrower_id = b.id
+WHERE l.id = $1 AND l.workspace_id = $2
+`
+
+type GetLoanReceiptDetailsParams struct {
+	ID          uuid.UUID `json:"id"`
+	WorkspaceID uuid.UUID `json:"workspace_id"`
+}
+
+type GetLoanReceiptDetailsRow struct {
+	WorkspaceName string                         `json:"workspace_name"`
+	ItemName      string                         `json:"item_name"`
+	Sku           string                         `json:"sku"`
+	Brand         *string                        `json:"brand"`
+	Model         *string                        `json:"model"`
+	SerialNumber  *string                        `json:"serial_number"`
+	Condition     NullWarehouseItemConditionEnum `json:"condition"`
+	BorrowerName  string                         `json:"borrower_name"`
+	BorrowerEmail *string                        `json:"borrower_email"`
+	BorrowerPhone *string                        `json:"borrower_phone"`
+}
+
+// Everything a printed loan receipt shows besides the loan row itself.
+// Scoped by workspace_id.
+func (q *Queries) GetLoanReceiptDetails(ctx context.Context, arg GetLoanReceiptDetailsParams) (GetLoanReceiptDetailsRow, error) {
+	row := q.db.QueryRow(ctx, getLoanReceiptDetails, arg.ID, arg.WorkspaceID)
+	var i GetLoanReceiptDetailsRow
+	err := row.Scan(
+		&i.WorkspaceName,
+		&i.ItemName,
+		&i.Sku,
+		&i.Brand,
+		&i.Model,
+		&i.SerialNumber,
+		&i.Condition,
+		&i.BorrowerName,
+		&i.BorrowerEmail,
+		&i.BorrowerPhone,
+	)
+	return i, err
+}
+
 const getLoanWithDetails = `-- name: GetLoanWithDetails :one
 SELECT l.id, l.workspace_id, l.inventory_id, l.borrower_id, l.quantity, l.loaned_at, l.due_date, l.returned_at, l.notes, l.created_at, l.updated_at,
        i.quantity as inventory_quantity, i.status as inventory_status,
@@ -602,31 +653,39 @@ func (q *Queries) ListLoansByWorkspace(ctx context.Context, arg ListLoansByWorks
 const listLoansNeedingReminder = `-- name: ListLoansNeedingReminder :many
 SELECT l.id, l.workspace_id, l.due_date, l.quantity, l.notes,
        b.id as borrower_id, b.name as borrower_name, b.email as borrower_email,
-       it.name as item_name, it.sku
+       b.phone as borrower_phone, b.reminder_channel as borrower_reminder_channel,
+       it.name as item_name, it.sku,
+       COALESCE(s.overdue_grace_days, 0)::int as overdue_grace_days
 FROM warehouse.loans l
 JOIN warehouse.borrowers b ON l.borrower_id = b.id
 JOIN warehouse.inventory inv ON l.inventory_id = inv.id
 JOIN warehouse.items it ON inv.item_id = it.id
+LEFT JOIN warehouse.loan_settings s ON s.workspace_id = l.workspace_id
 WHERE l.returned_at IS NULL 
   AND l.due_date <= $1 
-  AND b.email IS NOT NULL
+  AND ((b.reminder_channel = 'email' AND b.email IS NOT NULL)
+       OR (b.reminder_channel = 'sms' AND b.phone IS NOT NULL))
 ORDER BY l.due_date ASC
 `
 
 type ListLoansNeedingReminderRow struct {
-	ID            uuid.UUID   `json:"id"`
-	WorkspaceID   uuid.UUID   `json:"workspace_id"`
-	DueDate       pgtype.Date `json:"due_date"`
-	Quantity      int32       `json:"quantity"`
-	Notes         *string     `json:"notes"`
-	BorrowerID    uuid.UUID   `json:"borrower_id"`
-	BorrowerName  string      `json:"borrower_name"`
-	BorrowerEmail *string     `json:"borrower_email"`
-	ItemName      string      `json:"item_name"`
-	Sku           string      `json:"sku"`
-}
-
-// Lists loans that are due within the specified date and have borrowers with email addresses.
+	ID                      uuid.UUID   `json:"id"`
+	WorkspaceID             uuid.UUID   `json:"workspace_id"`
+	DueDate                 pgtype.Date `json:"due_date"`
+	Quantity                int32       `json:"quantity"`
+	Notes                   *string     `json:"notes"`
+	BorrowerID              uuid.UUID   `json:"borrower_id"`
+	BorrowerName            string      `json:"borrower_name"`
+	BorrowerEmail           *string     `json:"borrower_email"`
+	BorrowerPhone           *string     `json:"borrower_phone"`
+	BorrowerReminderChannel string      `json:"borrower_reminder_channel"`
+	ItemName                string      `json:"item_name"`
+	Sku                     string      `json:"sku"`
+	OverdueGraceDays        int32       `json:"overdue_grace_days"`
+}
+
+// Lists loans that are due within the specified date and whose borrowers can be reached on their
+// reminder channel (an email address for email, a phone number for sms).
 // Used by the background job to send reminder notifications.
 func (q *Queries) ListLoansNeedingReminder(ctx context.Context, dueDate pgtype.Date) ([]ListLoansNeedingReminderRow, error) {
 	rows, err := q.db.Query(ctx, listLoansNeedingReminder, dueDate)
@@ -646,8 +705,11 @@ func (q *Queries) ListLoansNeedingReminder(ctx context.Context, dueDate pgtype.D
 			&i.BorrowerID,
 			&i.BorrowerName,
 			&i.BorrowerEmail,
+			&i.BorrowerPhone,
+			&i.BorrowerReminderChannel,
 			&i.ItemName,
 			&i.Sku,
+			&i.OverdueGraceDays,
 		); err != nil {
 			return nil, err
 		}
@@ -660,9 +722,11 @@ func (q *Queries) ListLoansNeedingReminder(ctx context.Context, dueDate pgtype.D
 }
 
 const listOverdueLoans = `-- name: ListOverdueLoans :many
-SELECT id, workspace_id, inventory_id, borrower_id, quantity, loaned_at, due_date, returned_at, notes, created_at, updated_at FROM warehouse.loans
-WHERE workspace_id = $1 AND returned_at IS NULL AND due_date < now()
-ORDER BY due_date ASC
+SELECT l.id, l.workspace_id, l.inventory_id, l.borrower_id, l.quantity, l.loaned_at, l.due_date, l.returned_at, l.notes, l.created_at, l.updated_at FROM warehouse.loans l
+LEFT JOIN warehouse.loan_settings s ON s.workspace_id = l.workspace_id
+WHERE l.workspace_id = $1 AND l.returned_at IS NULL
+  AND l.due_date + COALESCE(s.overdue_grace_days, 0) < now()
+ORDER BY l.due_date ASC
 `
 
 func (q *Queries) ListOverdueLoans(ctx context.Context, workspaceID uuid.UUID) ([]WarehouseLoan, error) {
@@ -697,6 +761,59 @@ func (q *Queries) ListOverdueLoans(ctx context.Context, workspaceID uuid.UUID) (
 	return items, nil
 }
 
+const listOverdueLoanSummaries = `-- name: ListOverdueLoanSummaries :many
+SELECT l.id, l.inventory_id, inv.item_id, it.name AS item_name,
+       b.name AS borrower_name, l.quantity, l.due_date
+FROM warehouse.loans l
+JOIN warehouse.inventory inv ON inv.id = l.inventory_id
+JOIN warehouse.items it ON it.id = inv.item_id
+JOIN warehouse.borrowers b ON b.id = l.borrower_id
+LEFT JOIN warehouse.loan_settings s ON s.workspace_id = l.workspace_id
+WHERE l.workspace_id = $1 AND l.returned_at IS NULL
+  AND l.due_date + COALESCE(s.overdue_grace_days, 0) < now()
+ORDER BY l.due_date ASC, l.id
+`
+
+type ListOverdueLoanSummariesRow struct {
+	ID           uuid.UUID   `json:"id"`
+	InventoryID  uuid.UUID   `json:"inventory_id"`
+	ItemID       uuid.UUID   `json:"item_id"`
+	ItemName     string      `json:"item_name"`
+	BorrowerName string      `json:"borrower_name"`
+	Quantity     int32       `json:"quantity"`
+	DueDate      pgtype.Date `json:"due_date"`
+}
+
+// The loans ListOverdueLoans returns, with the loaned item and the borrower's
+// name.
+func (q *Queries) ListOverdueLoanSummaries(ctx context.Context, workspaceID uuid.UUID) ([]ListOverdueLoanSummariesRow, error) {
+	rows, err := q.db.Query(ctx, listOverdueLoanSummaries, workspaceID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ListOverdueLoanSummariesRow{}
+	for rows.Next() {
+		var i ListOverdueLoanSummariesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.InventoryID,
+			&i.ItemID,
+			&i.ItemName,
+			&i.BorrowerName,
+			&i.Quantity,
+			&i.DueDate,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const returnLoan = `-- name: ReturnLoan :one
 UPDATE warehouse.loans
 SET returned_at = now(), updated_at = now()
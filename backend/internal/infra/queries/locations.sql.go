@@ -82,6 +82,60 @@ func (q *Queries) DeleteLocation(ctx context.Context, arg DeleteLocationParams)
 	return err
 }
 
+const evacuateLocationContainers = `-- name: EvacuateLocationContainers :execrows
+UPDATE warehouse.containers
+SET location_id = $1::uuid,
+    updated_at = now()
+WHERE workspace_id = $2
+  AND location_id = $3
+`
+
+type EvacuateLocationContainersParams struct {
+	ToLocationID   uuid.UUID `json:"to_location_id"`
+	WorkspaceID    uuid.UUID `json:"workspace_id"`
+	FromLocationID uuid.UUID `json:"from_location_id"`
+}
+
+// Move every container out of a location.
+func (q *Queries) EvacuateLocationContainers(ctx context.Context, arg EvacuateLocationContainersParams) (int64, error) {
+	result, err := q.db.Exec(ctx, evacuateLocationContainers, arg.ToLocationID, arg.WorkspaceID, arg.FromLocationID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const evacuateLocationInventory = `-- name: EvacuateLocationInventory :execrows
+UPDATE warehouse.inventory
+SET location_id = $1::uuid,
+    container_id = CASE WHEN $2::boolean THEN container_id END,
+    updated_at = now()
+WHERE workspace_id = $3
+  AND location_id = $4
+`
+
+type EvacuateLocationInventoryParams struct {
+	ToLocationID   uuid.UUID `json:"to_location_id"`
+	KeepContainers bool      `json:"keep_containers"`
+	WorkspaceID    uuid.UUID `json:"workspace_id"`
+	FromLocationID uuid.UUID `json:"from_location_id"`
+}
+
+// Move every inventory row out of a location, optionally taking it out of
+// its container.
+func (q *Queries) EvacuateLocationInventory(ctx context.Context, arg EvacuateLocationInventoryParams) (int64, error) {
+	result, err := q.db.Exec(ctx, evacuateLocationInventory,
+		arg.ToLocationID,
+		arg.KeepContainers,
+		arg.WorkspaceID,
+		arg.FromLocationID,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
 const getLocation = `-- name: GetLocation :one
 SELECT id, workspace_id, name, parent_location, description, short_code, is_archived, search_vector, created_at, updated_at FROM warehouse.locations
 WHERE id = $1 AND workspace_id = $2
@@ -261,6 +315,41 @@ func (q *Queries) ListRootLocations(ctx context.Context, workspaceID uuid.UUID)
 	return items, nil
 }
 
+const recordLocationEvacuationMovements = `-- name: RecordLocationEvacuationMovements :exec
+INSERT INTO warehouse.inventory_movements (
+    workspace_id, inventory_id, from_location_id, from_container_id,
+    to_location_id, to_container_id, quantity, reason
+)
+SELECT
+    workspace_id, id, location_id, container_id,
+    $1::uuid,
+    CASE WHEN $2::boolean THEN container_id END,
+    quantity, 'Location evacuated'
+FROM warehouse.inventory
+WHERE workspace_id = $3
+  AND location_id = $4
+  AND quantity > 0
+`
+
+type RecordLocationEvacuationMovementsParams struct {
+	ToLocationID   uuid.UUID `json:"to_location_id"`
+	KeepContainers bool      `json:"keep_containers"`
+	WorkspaceID    uuid.UUID `json:"workspace_id"`
+	FromLocationID uuid.UUID `json:"from_location_id"`
+}
+
+// Write one movement per inventory row about to leave a location (run before
+// EvacuateLocationInventory, in the same transaction).
+func (q *Queries) RecordLocationEvacuationMovements(ctx context.Context, arg RecordLocationEvacuationMovementsParams) error {
+	_, err := q.db.Exec(ctx, recordLocationEvacuationMovements,
+		arg.ToLocationID,
+		arg.KeepContainers,
+		arg.WorkspaceID,
+		arg.FromLocationID,
+	)
+	return err
+}
+
 const restoreLocation = `-- name: RestoreLocation :exec
 UPDATE warehouse.locations
 SET is_archived = false, updated_at = now()
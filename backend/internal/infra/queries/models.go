@@ -891,6 +891,8 @@ type WarehouseBorrower struct {
 	SearchVector interface{}        `json:"search_vector"`
 	CreatedAt    pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt    pgtype.Timestamptz `json:"updated_at"`
+	// Channel loan reminders are sent to the borrower on: email, sms or none.
+	ReminderChannel string `json:"reminder_channel"`
 }
 
 type WarehouseCategory struct {
@@ -904,6 +906,31 @@ type WarehouseCategory struct {
 	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
 }
 
+// Condition and status applied to new inventory of items in the category when the request omits them.
+type WarehouseCategoryInventoryDefault struct {
+	CategoryID  uuid.UUID `json:"category_id"`
+	WorkspaceID uuid.UUID `json:"workspace_id"`
+	// Condition for new inventory. NULL uses the global default (NEW).
+	DefaultCondition NullWarehouseItemConditionEnum `json:"default_condition"`
+	// Status for new inventory. NULL uses the global default (AVAILABLE).
+	DefaultStatus NullWarehouseItemStatusEnum `json:"default_status"`
+	UpdatedAt     time.Time                   `json:"updated_at"`
+}
+
+// Keyword rules assigning a category to new items created without one. Rules are tried in position order; the first match wins.
+type WarehouseCategoryRule struct {
+	ID          uuid.UUID `json:"id"`
+	WorkspaceID uuid.UUID `json:"workspace_id"`
+	CategoryID  uuid.UUID `json:"category_id"`
+	// Text matched case-insensitively against the item name: a plain substring or an RE2 regular expression, per match_type.
+	Pattern   string `json:"pattern"`
+	MatchType string `json:"match_type"`
+	// Evaluation order within the workspace, lowest first.
+	Position  int32     `json:"position"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
 type WarehouseCompany struct {
 	ID          uuid.UUID          `json:"id"`
 	WorkspaceID uuid.UUID          `json:"workspace_id"`
@@ -940,6 +967,25 @@ type WarehouseContainerTag struct {
 	WorkspaceID uuid.UUID            `json:"workspace_id"`
 }
 
+// Workspace base currency and exchange rates used to convert inventory prices in value reports. Workspaces without a row report in EUR.
+type WarehouseCurrencySetting struct {
+	WorkspaceID  uuid.UUID `json:"workspace_id"`
+	BaseCurrency string    `json:"base_currency"`
+	// Map of ISO 4217 code to the amount of base currency one unit of that currency is worth, e.g. {"USD": 0.92}.
+	ExchangeRates []byte    `json:"exchange_rates"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// Per-workspace custom item attribute definitions.
+type WarehouseCustomField struct {
+	ID          uuid.UUID `json:"id"`
+	WorkspaceID uuid.UUID `json:"workspace_id"`
+	Name        string    `json:"name"`
+	FieldType   string    `json:"field_type"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
 // Tombstone table tracking hard-deleted records for PWA offline sync.
 type WarehouseDeletedRecord struct {
 	ID          uuid.UUID                   `json:"id"`
@@ -1053,6 +1099,32 @@ type WarehouseInventoryMovement struct {
 	CreatedAt       pgtype.Timestamptz `json:"created_at"`
 }
 
+// Workspace inventory policy. Workspaces without a row use the defaults.
+type WarehouseInventorySetting struct {
+	WorkspaceID uuid.UUID `json:"workspace_id"`
+	// Whether RESERVED entries count as available stock and can be loaned out. Off by default.
+	ReservedIsAvailable bool      `json:"reserved_is_available"`
+	UpdatedAt           time.Time `json:"updated_at"`
+	// Status the daily expiry job moves expired, non-loaned inventory to (e.g. DISPOSED). NULL turns the job off for the workspace.
+	ExpiredStatus NullWarehouseItemStatusEnum `json:"expired_status"`
+}
+
+// Frozen summaries of a workspace's inventory, computed when taken and never updated.
+type WarehouseInventorySnapshot struct {
+	ID          uuid.UUID `json:"id"`
+	WorkspaceID uuid.UUID `json:"workspace_id"`
+	Label       string    `json:"label"`
+	// Workspace base currency at the time; total_value and the item values are in it.
+	CurrencyCode  string `json:"currency_code"`
+	TotalQuantity int64  `json:"total_quantity"`
+	TotalValue    int64  `json:"total_value"`
+	// Inventory rows left out of total_value: unpriced, or priced in a currency with no exchange rate.
+	UnvaluedCount int32 `json:"unvalued_count"`
+	// Per-item summary: quantity, quantity by condition and total value of the item's unarchived inventory.
+	Items     []byte    `json:"items"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
 type WarehouseItem struct {
 	ID           uuid.UUID   `json:"id"`
 	WorkspaceID  uuid.UUID   `json:"workspace_id"`
@@ -1084,6 +1156,29 @@ type WarehouseItem struct {
 	SearchVector     interface{}        `json:"search_vector"`
 	CreatedAt        pgtype.Timestamptz `json:"created_at"`
 	UpdatedAt        pgtype.Timestamptz `json:"updated_at"`
+	// False for fixed assets (a mounted shelf, a built-in) that are never lent out.
+	Loanable bool `json:"loanable"`
+}
+
+// Custom field values of items, one row per item and field.
+type WarehouseItemCustomValue struct {
+	ItemID      uuid.UUID `json:"item_id"`
+	FieldID     uuid.UUID `json:"field_id"`
+	WorkspaceID uuid.UUID `json:"workspace_id"`
+	// Canonical text form of the value for the field type.
+	Value     string    `json:"value"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Additional barcodes and codes an item can be scanned by. items.barcode remains the primary one.
+type WarehouseItemIdentifier struct {
+	ID             uuid.UUID `json:"id"`
+	WorkspaceID    uuid.UUID `json:"workspace_id"`
+	ItemID         uuid.UUID `json:"item_id"`
+	IdentifierType string    `json:"identifier_type"`
+	// The scanned code, matched exactly. Unique per workspace.
+	Value     string    `json:"value"`
+	CreatedAt time.Time `json:"created_at"`
 }
 
 type WarehouseItemLabel struct {
@@ -1092,21 +1187,32 @@ type WarehouseItemLabel struct {
 	WorkspaceID uuid.UUID `json:"workspace_id"`
 }
 
+// Minimum stock of an item at one location. Overrides items.min_stock_level for low-stock reporting.
+type WarehouseItemLocationStockLevel struct {
+	ItemID      uuid.UUID `json:"item_id"`
+	LocationID  uuid.UUID `json:"location_id"`
+	WorkspaceID uuid.UUID `json:"workspace_id"`
+	// The item is low at this location when its stock there falls below this.
+	MinStockLevel int32     `json:"min_stock_level"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
 type WarehouseItemPhoto struct {
-	ID            uuid.UUID   `json:"id"`
-	ItemID        uuid.UUID   `json:"item_id"`
-	WorkspaceID   uuid.UUID   `json:"workspace_id"`
-	Filename      string      `json:"filename"`
-	StoragePath   string      `json:"storage_path"`
-	ThumbnailPath string      `json:"thumbnail_path"`
-	FileSize      int64       `json:"file_size"`
-	MimeType      string      `json:"mime_type"`
-	Width         int32       `json:"width"`
-	Height        int32       `json:"height"`
-	DisplayOrder  int32       `json:"display_order"`
-	IsPrimary     bool        `json:"is_primary"`
-	Caption       *string     `json:"caption"`
-	UploadedBy    pgtype.UUID `json:"uploaded_by"`
+	ID            uuid.UUID `json:"id"`
+	ItemID        uuid.UUID `json:"item_id"`
+	WorkspaceID   uuid.UUID `json:"workspace_id"`
+	Filename      string    `json:"filename"`
+	StoragePath   string    `json:"storage_path"`
+	ThumbnailPath string    `json:"thumbnail_path"`
+	FileSize      int64     `json:"file_size"`
+	MimeType      string    `json:"mime_type"`
+	Width         int32     `json:"width"`
+	Height        int32     `json:"height"`
+	// Gallery position. Spaced 1024 apart so a photo can move between neighbours without renumbering the rest.
+	DisplayOrder int32       `json:"display_order"`
+	IsPrimary    bool        `json:"is_primary"`
+	Caption      *string     `json:"caption"`
+	UploadedBy   pgtype.UUID `json:"uploaded_by"`
 	// Thumbnail generation status: pending (not started), processing (in queue), complete (ready), failed (max retries exceeded)
 	ThumbnailStatus string `json:"thumbnail_status"`
 	// Path to 150px thumbnail (used for lists/grids)
@@ -1123,6 +1229,29 @@ type WarehouseItemPhoto struct {
 	PerceptualHash *int64    `json:"perceptual_hash"`
 	CreatedAt      time.Time `json:"created_at"`
 	UpdatedAt      time.Time `json:"updated_at"`
+	// BlurHash placeholder string for the photo. NULL when not yet computed or when blurhash generation is disabled.
+	Blurhash *string `json:"blurhash"`
+	// Hex SHA-256 of the original file bytes, for exact-duplicate detection within an item. NULL for photos uploaded before it was recorded.
+	ContentHash *string `json:"content_hash"`
+	// Thumbnail config version (sizes, format, quality) the thumbnails were generated with. NULL when unknown.
+	ThumbnailConfigVersion *string `json:"thumbnail_config_version"`
+	// Inventory record (physical unit) the photo shows. NULL when it shows the item in general.
+	InventoryID pgtype.UUID `json:"inventory_id"`
+}
+
+type WarehouseItemShare struct {
+	ID          uuid.UUID `json:"id"`
+	WorkspaceID uuid.UUID `json:"workspace_id"`
+	ItemID      uuid.UUID `json:"item_id"`
+	// SHA-256 of the link token, hex. The token itself is shown once and never stored.
+	TokenHash string `json:"token_hash"`
+	// Whether the shared view shows the purchase prices of the item's inventory.
+	IncludePrices bool `json:"include_prices"`
+	// When the link stops working. NULL never expires.
+	ExpiresAt pgtype.Timestamptz `json:"expires_at"`
+	RevokedAt pgtype.Timestamptz `json:"revoked_at"`
+	CreatedBy pgtype.UUID        `json:"created_by"`
+	CreatedAt time.Time          `json:"created_at"`
 }
 
 type WarehouseLabel struct {
@@ -1150,6 +1279,16 @@ type WarehouseLoan struct {
 	UpdatedAt   pgtype.Timestamptz `json:"updated_at"`
 }
 
+// Workspace loan policy. Workspaces without a row have no overdue grace period and a 14-day default loan period.
+type WarehouseLoanSetting struct {
+	WorkspaceID uuid.UUID `json:"workspace_id"`
+	// Days after due_date before a loan is reported and reminded as overdue.
+	OverdueGraceDays int32     `json:"overdue_grace_days"`
+	UpdatedAt        time.Time `json:"updated_at"`
+	// Loan period in days used for the due date when a loan is created without one.
+	DefaultLoanDays int32 `json:"default_loan_days"`
+}
+
 type WarehouseLocation struct {
 	ID             uuid.UUID   `json:"id"`
 	WorkspaceID    uuid.UUID   `json:"workspace_id"`
@@ -1203,6 +1342,18 @@ type WarehousePendingChange struct {
 }
 
 // Links repair logs to uploaded files (receipts, invoices, warranty documents).
+// Workspace photo policy. Workspaces without a row allow 20 photos per item.
+type WarehousePhotoSetting struct {
+	WorkspaceID uuid.UUID `json:"workspace_id"`
+	// Maximum number of photos a single item may have.
+	MaxPhotosPerItem int32     `json:"max_photos_per_item"`
+	UpdatedAt        time.Time `json:"updated_at"`
+	// Items need at least one photo to count as cataloged; items without one are reported as incomplete.
+	RequirePhoto bool `json:"require_photo"`
+	// Refuse new inventory for items without a photo. Only applies with require_photo.
+	BlockInventoryWithoutPhoto bool `json:"block_inventory_without_photo"`
+}
+
 type WarehouseRepairAttachment struct {
 	ID             uuid.UUID                   `json:"id"`
 	RepairLogID    uuid.UUID                   `json:"repair_log_id"`
@@ -1272,6 +1423,14 @@ type WarehouseShortCode struct {
 	CreatedAt  time.Time                 `json:"created_at"`
 }
 
+// Workspace trash policy. Workspaces without a row use the server-wide retention.
+type WarehouseTrashSetting struct {
+	WorkspaceID uuid.UUID `json:"workspace_id"`
+	// Days deleted records are kept before the cleanup job removes them for good.
+	TrashRetentionDays int32     `json:"trash_retention_days"`
+	UpdatedAt          time.Time `json:"updated_at"`
+}
+
 // All soft-deleted records across entity types for restoration UI.
 type WarehouseVArchivedRecord struct {
 	EntityType  string             `json:"entity_type"`
@@ -1281,6 +1440,37 @@ type WarehouseVArchivedRecord struct {
 	ArchivedAt  pgtype.Timestamptz `json:"archived_at"`
 }
 
+// Outgoing webhook subscriptions. Matching workspace events are POSTed to url, signed with HMAC-SHA256 using secret.
+type WarehouseWebhook struct {
+	ID          uuid.UUID `json:"id"`
+	WorkspaceID uuid.UUID `json:"workspace_id"`
+	Url         string    `json:"url"`
+	Secret      string    `json:"secret"`
+	// Event types delivered to this webhook, e.g. loan.created, item.low_stock, pendingchange.approved.
+	EventTypes []string    `json:"event_types"`
+	IsActive   bool        `json:"is_active"`
+	CreatedBy  pgtype.UUID `json:"created_by"`
+	CreatedAt  time.Time   `json:"created_at"`
+	UpdatedAt  time.Time   `json:"updated_at"`
+}
+
+// One row per event sent to a webhook. status stays pending while the worker retries and becomes succeeded or failed once it settles.
+type WarehouseWebhookDelivery struct {
+	ID          uuid.UUID `json:"id"`
+	WebhookID   uuid.UUID `json:"webhook_id"`
+	WorkspaceID uuid.UUID `json:"workspace_id"`
+	EventType   string    `json:"event_type"`
+	// Exact JSON body POSTed to the webhook; retries resend it byte-for-byte so the signature stays stable.
+	Payload        []byte             `json:"payload"`
+	Status         string             `json:"status"`
+	Attempts       int32              `json:"attempts"`
+	ResponseStatus *int32             `json:"response_status"`
+	LastError      *string            `json:"last_error"`
+	DeliveredAt    pgtype.Timestamptz `json:"delivered_at"`
+	CreatedAt      time.Time          `json:"created_at"`
+	UpdatedAt      time.Time          `json:"updated_at"`
+}
+
 // Purchase-planning entries: items the workspace intends to acquire. Converted into a real item on purchase (acquired_item_id links back).
 type WarehouseWishlistItem struct {
 	ID          uuid.UUID `json:"id"`
@@ -7,11 +7,48 @@ package queries
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const countPendingChangesByRequesterStatus = `-- name: CountPendingChangesByRequesterStatus :many
+SELECT status, COUNT(*)::int AS count FROM warehouse.pending_changes
+WHERE workspace_id = $1 AND requester_id = $2
+GROUP BY status
+`
+
+type CountPendingChangesByRequesterStatusParams struct {
+	WorkspaceID uuid.UUID `json:"workspace_id"`
+	RequesterID uuid.UUID `json:"requester_id"`
+}
+
+type CountPendingChangesByRequesterStatusRow struct {
+	Status WarehousePendingChangeStatusEnum `json:"status"`
+	Count  int32                            `json:"count"`
+}
+
+func (q *Queries) CountPendingChangesByRequesterStatus(ctx context.Context, arg CountPendingChangesByRequesterStatusParams) ([]CountPendingChangesByRequesterStatusRow, error) {
+	rows, err := q.db.Query(ctx, countPendingChangesByRequesterStatus, arg.WorkspaceID, arg.RequesterID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []CountPendingChangesByRequesterStatusRow{}
+	for rows.Next() {
+		var i CountPendingChangesByRequesterStatusRow
+		if err := rows.Scan(&i.Status, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const countPendingChangesByWorkspace = `-- name: CountPendingChangesByWorkspace :one
 SELECT COUNT(*) FROM warehouse.pending_changes
 WHERE workspace_id = $1 AND status = 'pending'
@@ -24,6 +61,36 @@ func (q *Queries) CountPendingChangesByWorkspace(ctx context.Context, workspaceI
 	return count, err
 }
 
+const countPendingChangesByWorkspaceFiltered = `-- name: CountPendingChangesByWorkspaceFiltered :one
+SELECT COUNT(*)::int FROM warehouse.pending_changes
+WHERE workspace_id = $1
+  AND ($2::warehouse.pending_change_status_enum IS NULL OR status = $2)
+  AND ($3::text IS NULL OR entity_type = $3)
+  AND ($4::warehouse.pending_change_action_enum IS NULL OR action = $4)
+  AND ($5::uuid IS NULL OR requester_id = $5)
+`
+
+type CountPendingChangesByWorkspaceFilteredParams struct {
+	WorkspaceID uuid.UUID                            `json:"workspace_id"`
+	Status      NullWarehousePendingChangeStatusEnum `json:"status"`
+	EntityType  *string                              `json:"entity_type"`
+	Action      NullWarehousePendingChangeActionEnum `json:"action"`
+	RequesterID pgtype.UUID                          `json:"requester_id"`
+}
+
+func (q *Queries) CountPendingChangesByWorkspaceFiltered(ctx context.Context, arg CountPendingChangesByWorkspaceFilteredParams) (int32, error) {
+	row := q.db.QueryRow(ctx, countPendingChangesByWorkspaceFiltered,
+		arg.WorkspaceID,
+		arg.Status,
+		arg.EntityType,
+		arg.Action,
+		arg.RequesterID,
+	)
+	var column_1 int32
+	err := row.Scan(&column_1)
+	return column_1, err
+}
+
 const createPendingChange = `-- name: CreatePendingChange :one
 INSERT INTO warehouse.pending_changes (
     id,
@@ -229,17 +296,34 @@ func (q *Queries) ListPendingChangesByRequester(ctx context.Context, arg ListPen
 const listPendingChangesByWorkspace = `-- name: ListPendingChangesByWorkspace :many
 SELECT id, workspace_id, requester_id, entity_type, entity_id, action, payload, status, reviewed_by, reviewed_at, rejection_reason, created_at, updated_at, client_change_id, base_updated_at FROM warehouse.pending_changes
 WHERE workspace_id = $1
-  AND ($2::warehouse.pending_change_status_enum IS NULL OR status = $2)
+  AND ($4::warehouse.pending_change_status_enum IS NULL OR status = $4)
+  AND ($5::text IS NULL OR entity_type = $5)
+  AND ($6::warehouse.pending_change_action_enum IS NULL OR action = $6)
+  AND ($7::uuid IS NULL OR requester_id = $7)
 ORDER BY created_at DESC
+LIMIT $2 OFFSET $3
 `
 
 type ListPendingChangesByWorkspaceParams struct {
 	WorkspaceID uuid.UUID                            `json:"workspace_id"`
+	Limit       int32                                `json:"limit"`
+	Offset      int32                                `json:"offset"`
 	Status      NullWarehousePendingChangeStatusEnum `json:"status"`
+	EntityType  *string                              `json:"entity_type"`
+	Action      NullWarehousePendingChangeActionEnum `json:"action"`
+	RequesterID pgtype.UUID                          `json:"requester_id"`
 }
 
 func (q *Queries) ListPendingChangesByWorkspace(ctx context.Context, arg ListPendingChangesByWorkspaceParams) ([]WarehousePendingChange, error) {
-	rows, err := q.db.Query(ctx, listPendingChangesByWorkspace, arg.WorkspaceID, arg.Status)
+	rows, err := q.db.Query(ctx, listPendingChangesByWorkspace,
+		arg.WorkspaceID,
+		arg.Limit,
+		arg.Offset,
+		arg.Status,
+		arg.EntityType,
+		arg.Action,
+		arg.RequesterID,
+	)
 	if err != nil {
 		return nil, err
 	}
@@ -274,6 +358,60 @@ func (q *Queries) ListPendingChangesByWorkspace(ctx context.Context, arg ListPen
 	return items, nil
 }
 
+const reviewerStats = `-- name: ReviewerStats :many
+SELECT reviewed_by::uuid AS reviewer_id,
+       COUNT(*) FILTER (WHERE status = 'approved')::int AS approved_count,
+       COUNT(*) FILTER (WHERE status = 'rejected')::int AS rejected_count,
+       AVG(EXTRACT(EPOCH FROM (reviewed_at - created_at)))::float8 AS avg_review_seconds
+FROM warehouse.pending_changes
+WHERE workspace_id = $1
+  AND reviewed_by IS NOT NULL
+  AND reviewed_at >= $2::timestamptz
+  AND reviewed_at < $3::timestamptz
+GROUP BY reviewed_by
+ORDER BY COUNT(*) DESC, reviewed_by
+`
+
+type ReviewerStatsParams struct {
+	WorkspaceID uuid.UUID `json:"workspace_id"`
+	FromTime    time.Time `json:"from_time"`
+	ToTime      time.Time `json:"to_time"`
+}
+
+type ReviewerStatsRow struct {
+	ReviewerID       uuid.UUID `json:"reviewer_id"`
+	ApprovedCount    int32     `json:"approved_count"`
+	RejectedCount    int32     `json:"rejected_count"`
+	AvgReviewSeconds float64   `json:"avg_review_seconds"`
+}
+
+// Per-reviewer approve/reject counts and mean time from submission to review
+// for changes reviewed in [from_time, to_time), busiest reviewer first.
+func (q *Queries) ReviewerStats(ctx context.Context, arg ReviewerStatsParams) ([]ReviewerStatsRow, error) {
+	rows, err := q.db.Query(ctx, reviewerStats, arg.WorkspaceID, arg.FromTime, arg.ToTime)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	items := []ReviewerStatsRow{}
+	for rows.Next() {
+		var i ReviewerStatsRow
+		if err := rows.Scan(
+			&i.ReviewerID,
+			&i.ApprovedCount,
+			&i.RejectedCount,
+			&i.AvgReviewSeconds,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updatePendingChangeStatus = `-- name: UpdatePendingChangeStatus :one
 UPDATE warehouse.pending_changes
 SET
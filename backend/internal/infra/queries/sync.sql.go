@@ -116,7 +116,7 @@ func (q *Queries) CountLocationsModifiedSince(ctx context.Context, arg CountLoca
 }
 
 const listBorrowersModifiedSince = `-- name: ListBorrowersModifiedSince :many
-SELECT id, workspace_id, name, email, phone, notes, is_archived, search_vector, created_at, updated_at FROM warehouse.borrowers
+SELECT id, workspace_id, name, email, phone, notes, is_archived, search_vector, created_at, updated_at, reminder_channel FROM warehouse.borrowers
 WHERE workspace_id = $1 
   AND updated_at > $2
 ORDER BY updated_at ASC
@@ -149,6 +149,7 @@ func (q *Queries) ListBorrowersModifiedSince(ctx context.Context, arg ListBorrow
 			&i.SearchVector,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.ReminderChannel,
 		); err != nil {
 			return nil, err
 		}
@@ -388,7 +389,7 @@ func (q *Queries) ListInventoryModifiedSince(ctx context.Context, arg ListInvent
 
 const listItemsModifiedSince = `-- name: ListItemsModifiedSince :many
 
-SELECT id, workspace_id, sku, name, description, category_id, brand, model, image_url, serial_number, manufacturer, barcode, is_insured, is_archived, needs_review, lifetime_warranty, warranty_details, purchased_from, min_stock_level, short_code, obsidian_vault_path, obsidian_note_path, search_vector, created_at, updated_at FROM warehouse.items
+SELECT id, workspace_id, sku, name, description, category_id, brand, model, image_url, serial_number, manufacturer, barcode, is_insured, is_archived, needs_review, lifetime_warranty, warranty_details, purchased_from, min_stock_level, short_code, obsidian_vault_path, obsidian_note_path, search_vector, created_at, updated_at, loanable FROM warehouse.items
 WHERE workspace_id = $1 
   AND updated_at > $2
 ORDER BY updated_at ASC
@@ -438,6 +439,7 @@ func (q *Queries) ListItemsModifiedSince(ctx context.Context, arg ListItemsModif
 			&i.SearchVector,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Loanable,
 		); err != nil {
 			return nil, err
 		}
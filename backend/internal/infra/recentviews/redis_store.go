@@ -0,0 +1,75 @@
+// Package recentviews keeps each user's recently viewed items in Redis.
+package recentviews
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// maxEntries caps each list; older views fall off the end.
+	maxEntries = 20
+	// listTTL expires lists for users who stop using a workspace. It is
+	// refreshed on every Push.
+	listTTL = 90 * 24 * time.Hour
+)
+
+// Store is a Redis list per (workspace, user) holding item IDs, newest
+// first. It implements item.RecentViewStore.
+type Store struct {
+	client *redis.Client
+}
+
+func NewStore(client *redis.Client) *Store {
+	return &Store{client: client}
+}
+
+func key(workspaceID, userID uuid.UUID) string {
+	return fmt.Sprintf("recent_items:%s:%s", workspaceID, userID)
+}
+
+// Push moves itemID to the front of the list: any earlier entry for it is
+// removed first so a re-view bumps the item instead of duplicating it.
+func (s *Store) Push(ctx context.Context, workspaceID, userID, itemID uuid.UUID) error {
+	k := key(workspaceID, userID)
+	id := itemID.String()
+
+	_, err := s.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		pipe.LRem(ctx, k, 0, id)
+		pipe.LPush(ctx, k, id)
+		pipe.LTrim(ctx, k, 0, maxEntries-1)
+		pipe.Expire(ctx, k, listTTL)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record recent view: %w", err)
+	}
+	return nil
+}
+
+// List returns up to limit item IDs, newest first. Entries that are not
+// valid UUIDs are skipped.
+func (s *Store) List(ctx context.Context, workspaceID, userID uuid.UUID, limit int) ([]uuid.UUID, error) {
+	if limit <= 0 || limit > maxEntries {
+		limit = maxEntries
+	}
+
+	raw, err := s.client.LRange(ctx, key(workspaceID, userID), 0, int64(limit-1)).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to list recent views: %w", err)
+	}
+
+	ids := make([]uuid.UUID, 0, len(raw))
+	for _, r := range raw {
+		id, err := uuid.Parse(r)
+		if err != nil {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
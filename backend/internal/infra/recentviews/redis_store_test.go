@@ -0,0 +1,98 @@
+//go:build integration
+// +build integration
+
+package recentviews
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStore(t *testing.T) (*Store, uuid.UUID, uuid.UUID) {
+	t.Helper()
+
+	addr := os.Getenv("TEST_REDIS_ADDR")
+	if addr == "" {
+		addr = "localhost:6379"
+	}
+	client := redis.NewClient(&redis.Options{Addr: addr})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		t.Skipf("skipping integration test: redis ping failed: %v", err)
+	}
+
+	// Fresh workspace/user IDs per test so runs never share keys.
+	workspaceID, userID := uuid.New(), uuid.New()
+	t.Cleanup(func() {
+		client.Del(context.Background(), key(workspaceID, userID))
+		client.Close()
+	})
+
+	return NewStore(client), workspaceID, userID
+}
+
+func TestStore_ListNewestFirst(t *testing.T) {
+	s, ws, user := newTestStore(t)
+	ctx := context.Background()
+
+	a, b, c := uuid.New(), uuid.New(), uuid.New()
+	for _, id := range []uuid.UUID{a, b, c} {
+		require.NoError(t, s.Push(ctx, ws, user, id))
+	}
+
+	ids, err := s.List(ctx, ws, user, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{c, b, a}, ids)
+}
+
+func TestStore_ReviewBumpsWithoutDuplicating(t *testing.T) {
+	s, ws, user := newTestStore(t)
+	ctx := context.Background()
+
+	a, b := uuid.New(), uuid.New()
+	require.NoError(t, s.Push(ctx, ws, user, a))
+	require.NoError(t, s.Push(ctx, ws, user, b))
+	require.NoError(t, s.Push(ctx, ws, user, a))
+
+	ids, err := s.List(ctx, ws, user, 10)
+	require.NoError(t, err)
+	assert.Equal(t, []uuid.UUID{a, b}, ids)
+}
+
+func TestStore_CapsEntries(t *testing.T) {
+	s, ws, user := newTestStore(t)
+	ctx := context.Background()
+
+	var last uuid.UUID
+	for i := 0; i < maxEntries+5; i++ {
+		last = uuid.New()
+		require.NoError(t, s.Push(ctx, ws, user, last))
+	}
+
+	ids, err := s.List(ctx, ws, user, maxEntries+5)
+	require.NoError(t, err)
+	assert.Len(t, ids, maxEntries)
+	assert.Equal(t, last, ids[0])
+}
+
+func TestStore_ListRespectsLimit(t *testing.T) {
+	s, ws, user := newTestStore(t)
+	ctx := context.Background()
+
+	for i := 0; i < 5; i++ {
+		require.NoError(t, s.Push(ctx, ws, user, uuid.New()))
+	}
+
+	ids, err := s.List(ctx, ws, user, 2)
+	require.NoError(t, err)
+	assert.Len(t, ids, 2)
+}
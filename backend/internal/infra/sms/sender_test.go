@@ -0,0 +1,72 @@
+package sms
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSender_SendSMS(t *testing.T) {
+	t.Run("posts the message to twilio", func(t *testing.T) {
+		var form url.Values
+		var user, pass string
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			assert.Equal(t, "/2010-04-01/Accounts/AC123/Messages.json", r.URL.Path)
+			user, pass, _ = r.BasicAuth()
+			require.NoError(t, r.ParseForm())
+			form = r.PostForm
+			w.WriteHeader(http.StatusCreated)
+		}))
+		defer srv.Close()
+
+		s := NewSender("AC123", "secret", "+15005550006")
+		s.baseURL = srv.URL
+
+		err := s.SendSMS(context.Background(), "+358401234567", "Reminder: Drill is due back on Mar 3.")
+
+		require.NoError(t, err)
+		assert.Equal(t, "AC123", user)
+		assert.Equal(t, "secret", pass)
+		assert.Equal(t, "+358401234567", form.Get("To"))
+		assert.Equal(t, "+15005550006", form.Get("From"))
+		assert.Equal(t, "Reminder: Drill is due back on Mar 3.", form.Get("Body"))
+	})
+
+	t.Run("sends from a messaging service", func(t *testing.T) {
+		var form url.Values
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			require.NoError(t, r.ParseForm())
+			form = r.PostForm
+			w.WriteHeader(http.StatusCreated)
+		}))
+		defer srv.Close()
+
+		s := NewSender("AC123", "secret", "MG456")
+		s.baseURL = srv.URL
+
+		require.NoError(t, s.SendSMS(context.Background(), "+358401234567", "hi"))
+		assert.Equal(t, "MG456", form.Get("MessagingServiceSid"))
+		assert.Empty(t, form.Get("From"))
+	})
+
+	t.Run("returns error on non-2xx response", func(t *testing.T) {
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, `{"message":"The 'To' number is not a valid phone number."}`, http.StatusBadRequest)
+		}))
+		defer srv.Close()
+
+		s := NewSender("AC123", "secret", "+15005550006")
+		s.baseURL = srv.URL
+
+		err := s.SendSMS(context.Background(), "+1", "hi")
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "400")
+		assert.Contains(t, err.Error(), "not a valid phone number")
+	})
+}
@@ -24,6 +24,9 @@ type Config struct {
 
 	// AllowedMimeTypes is a list of allowed MIME types
 	AllowedMimeTypes []string
+
+	// PathLayout is where files go under StoragePath (PHOTO_STORAGE_LAYOUT)
+	PathLayout *PathLayout
 }
 
 // LoadConfigFromEnv loads storage configuration from environment variables.
@@ -49,6 +52,12 @@ func LoadConfigFromEnv() (*Config, error) {
 		cfg.MaxFileSizeMB = size
 	}
 
+	layout, err := LoadPathLayoutFromEnv()
+	if err != nil {
+		return nil, err
+	}
+	cfg.PathLayout = layout
+
 	// Trim whitespace from MIME types
 	for i, t := range cfg.AllowedMimeTypes {
 		cfg.AllowedMimeTypes[i] = strings.TrimSpace(t)
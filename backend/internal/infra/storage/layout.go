@@ -0,0 +1,228 @@
+package storage
+
+import (
+	"fmt"
+	"hash/fnv"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// DefaultPathLayout is the layout used when PHOTO_STORAGE_LAYOUT is unset,
+// and the one every file stored before layouts were configurable follows.
+const DefaultPathLayout = "{workspace}/{item}/{filename}"
+
+// Layout variables. {workspace}, {item} and {filename} are required so every
+// path can be traced back to its owner; the others spread files over more
+// directories for filesystems that slow down with huge ones.
+const (
+	layoutWorkspace = "workspace"
+	layoutItem      = "item"
+	layoutFilename  = "filename"
+	layoutYear      = "yyyy" // upload year, e.g. 2024
+	layoutMonth     = "mm"   // upload month, 01-12
+	layoutDay       = "dd"   // upload day, 01-31
+	layoutShard     = "shard"
+)
+
+var (
+	layoutLiteralPattern = regexp.MustCompile(`^[a-zA-Z0-9_\-.]+$`)
+
+	// layoutValuePatterns is what each variable renders to, for Parse. The
+	// shard is two hex digits: 256 buckets.
+	layoutValuePatterns = map[string]*regexp.Regexp{
+		layoutWorkspace: regexp.MustCompile(`^[^/]+$`),
+		layoutItem:      regexp.MustCompile(`^[^/]+$`),
+		layoutFilename:  regexp.MustCompile(`^[^/]+$`),
+		layoutYear:      regexp.MustCompile(`^[0-9]{4}$`),
+		layoutMonth:     regexp.MustCompile(`^(0[1-9]|1[0-2])$`),
+		layoutDay:       regexp.MustCompile(`^(0[1-9]|[12][0-9]|3[01])$`),
+		layoutShard:     regexp.MustCompile(`^[0-9a-f]{2}$`),
+	}
+)
+
+// PathLayout turns a template such as "{yyyy}/{mm}/{workspace}/{item}/{filename}"
+// into storage paths, and reads the owner back out of a stored path. Each
+// path segment of the template is either one variable or literal text.
+type PathLayout struct {
+	template string
+	segments []layoutSegment
+}
+
+type layoutSegment struct {
+	variable string // set for a variable segment
+	literal  string // set for a literal segment
+}
+
+// PathVars are the values a storage path is built from.
+type PathVars struct {
+	WorkspaceID string
+	ItemID      string
+	Filename    string
+	Time        time.Time // for the date variables
+}
+
+// ParsePathLayout validates a layout template. It must use each of
+// {workspace}, {item} and {filename} exactly once, with {filename} last,
+// and no variables other than those plus {yyyy}, {mm}, {dd} and {shard}.
+func ParsePathLayout(template string) (*PathLayout, error) {
+	if template == "" {
+		return nil, fmt.Errorf("path layout cannot be empty")
+	}
+	if strings.HasPrefix(template, "/") {
+		return nil, fmt.Errorf("path layout %q must be relative", template)
+	}
+
+	parts := strings.Split(template, "/")
+	layout := &PathLayout{template: template, segments: make([]layoutSegment, 0, len(parts))}
+	seen := make(map[string]bool)
+	for _, part := range parts {
+		if isLayoutVariable(part) {
+			name := part[1 : len(part)-1]
+			if _, ok := layoutValuePatterns[name]; !ok {
+				return nil, fmt.Errorf("path layout %q: unknown variable {%s}; allowed are {workspace}, {item}, {filename}, {yyyy}, {mm}, {dd}, {shard}", template, name)
+			}
+			if seen[name] {
+				return nil, fmt.Errorf("path layout %q: {%s} is used more than once", template, name)
+			}
+			seen[name] = true
+			layout.segments = append(layout.segments, layoutSegment{variable: name})
+			continue
+		}
+		if strings.ContainsAny(part, "{}") {
+			return nil, fmt.Errorf("path layout %q: segment %q must be a single variable or plain text", template, part)
+		}
+		if part == "." || part == ".." || !layoutLiteralPattern.MatchString(part) {
+			return nil, fmt.Errorf("path layout %q: invalid segment %q", template, part)
+		}
+		layout.segments = append(layout.segments, layoutSegment{literal: part})
+	}
+
+	for _, required := range []string{layoutWorkspace, layoutItem, layoutFilename} {
+		if !seen[required] {
+			return nil, fmt.Errorf("path layout %q must contain {%s}", template, required)
+		}
+	}
+	if layout.segments[len(layout.segments)-1].variable != layoutFilename {
+		return nil, fmt.Errorf("path layout %q must end with {filename}", template)
+	}
+	return layout, nil
+}
+
+// MustParsePathLayout is ParsePathLayout for templates known to be valid.
+func MustParsePathLayout(template string) *PathLayout {
+	layout, err := ParsePathLayout(template)
+	if err != nil {
+		panic(err)
+	}
+	return layout
+}
+
+var defaultPathLayout = MustParsePathLayout(DefaultPathLayout)
+
+// DefaultLayout returns the layout for DefaultPathLayout.
+func DefaultLayout() *PathLayout {
+	return defaultPathLayout
+}
+
+// LoadPathLayoutFromEnv returns the layout configured in
+// PHOTO_STORAGE_LAYOUT, or the default layout when it is unset.
+func LoadPathLayoutFromEnv() (*PathLayout, error) {
+	template := os.Getenv("PHOTO_STORAGE_LAYOUT")
+	if template == "" {
+		return DefaultLayout(), nil
+	}
+	layout, err := ParsePathLayout(template)
+	if err != nil {
+		return nil, fmt.Errorf("invalid PHOTO_STORAGE_LAYOUT: %w", err)
+	}
+	return layout, nil
+}
+
+// String returns the layout template.
+func (l *PathLayout) String() string {
+	return l.template
+}
+
+// Render builds the storage path for vars.
+func (l *PathLayout) Render(vars PathVars) string {
+	parts := make([]string, len(l.segments))
+	for i, seg := range l.segments {
+		switch seg.variable {
+		case "":
+			parts[i] = seg.literal
+		case layoutWorkspace:
+			parts[i] = vars.WorkspaceID
+		case layoutItem:
+			parts[i] = vars.ItemID
+		case layoutFilename:
+			parts[i] = vars.Filename
+		case layoutYear:
+			parts[i] = fmt.Sprintf("%04d", vars.Time.Year())
+		case layoutMonth:
+			parts[i] = fmt.Sprintf("%02d", int(vars.Time.Month()))
+		case layoutDay:
+			parts[i] = fmt.Sprintf("%02d", vars.Time.Day())
+		case layoutShard:
+			parts[i] = shardOf(vars.Filename)
+		}
+	}
+	return strings.Join(parts, "/")
+}
+
+// Parse reads the owner and filename back out of a path rendered by this
+// layout. ok is false when the path does not follow the layout. Time is not
+// recovered.
+func (l *PathLayout) Parse(path string) (vars PathVars, ok bool) {
+	parts := strings.Split(strings.ReplaceAll(path, "\\", "/"), "/")
+	if len(parts) != len(l.segments) {
+		return PathVars{}, false
+	}
+	for i, seg := range l.segments {
+		part := parts[i]
+		if seg.variable == "" {
+			if part != seg.literal {
+				return PathVars{}, false
+			}
+			continue
+		}
+		if part == "." || part == ".." || !layoutValuePatterns[seg.variable].MatchString(part) {
+			return PathVars{}, false
+		}
+		switch seg.variable {
+		case layoutWorkspace:
+			vars.WorkspaceID = part
+		case layoutItem:
+			vars.ItemID = part
+		case layoutFilename:
+			vars.Filename = part
+		}
+	}
+	return vars, true
+}
+
+// ParseStoragePath reads a stored path with the first of layouts it follows,
+// so tools keep recognizing files stored under an earlier layout.
+func ParseStoragePath(path string, layouts ...*PathLayout) (PathVars, bool) {
+	for _, l := range layouts {
+		if vars, ok := l.Parse(path); ok {
+			return vars, true
+		}
+	}
+	return PathVars{}, false
+}
+
+// isLayoutVariable reports whether a template segment is exactly one
+// {variable}.
+func isLayoutVariable(part string) bool {
+	return strings.HasPrefix(part, "{") && strings.HasSuffix(part, "}") &&
+		strings.Count(part, "{") == 1 && strings.Count(part, "}") == 1
+}
+
+// shardOf spreads filenames over 256 buckets.
+func shardOf(filename string) string {
+	h := fnv.New32a()
+	h.Write([]byte(filename))
+	return fmt.Sprintf("%02x", h.Sum32()&0xff)
+}
@@ -0,0 +1,156 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePathLayout(t *testing.T) {
+	valid := []string{
+		DefaultPathLayout,
+		"{yyyy}/{mm}/{dd}/{workspace}/{item}/{filename}",
+		"{shard}/{workspace}/{item}/{filename}",
+		"photos/{workspace}/{item}/{filename}",
+		"{item}/{workspace}/{filename}",
+	}
+	for _, template := range valid {
+		t.Run(template, func(t *testing.T) {
+			layout, err := ParsePathLayout(template)
+			require.NoError(t, err)
+			assert.Equal(t, template, layout.String())
+		})
+	}
+
+	invalid := []struct {
+		template string
+		wantErr  string
+	}{
+		{"", "cannot be empty"},
+		{"/{workspace}/{item}/{filename}", "must be relative"},
+		{"{tenant}/{item}/{filename}", "unknown variable {tenant}"},
+		{"{workspace}/{filename}", "must contain {item}"},
+		{"{item}/{filename}", "must contain {workspace}"},
+		{"{workspace}/{item}", "must contain {filename}"},
+		{"{workspace}/{filename}/{item}", "must end with {filename}"},
+		{"{workspace}/{workspace}/{item}/{filename}", "used more than once"},
+		{"{yyyy}-{mm}/{workspace}/{item}/{filename}", "single variable or plain text"},
+		{"../{workspace}/{item}/{filename}", "invalid segment"},
+		{"{workspace}//{item}/{filename}", "invalid segment"},
+	}
+	for _, tt := range invalid {
+		t.Run("rejects "+tt.template, func(t *testing.T) {
+			_, err := ParsePathLayout(tt.template)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.wantErr)
+		})
+	}
+}
+
+func TestPathLayout_RenderAndParse(t *testing.T) {
+	vars := PathVars{
+		WorkspaceID: "ws-1",
+		ItemID:      "item-1",
+		Filename:    "abc_photo.jpg",
+		Time:        time.Date(2024, time.March, 7, 12, 0, 0, 0, time.UTC),
+	}
+
+	t.Run("default layout matches GenerateStoragePath", func(t *testing.T) {
+		assert.Equal(t, GenerateStoragePath("ws-1", "item-1", "abc_photo.jpg"), DefaultLayout().Render(vars))
+	})
+
+	t.Run("date and shard variables", func(t *testing.T) {
+		layout := MustParsePathLayout("photos/{yyyy}/{mm}/{dd}/{shard}/{workspace}/{item}/{filename}")
+
+		path := layout.Render(vars)
+
+		assert.True(t, strings.HasPrefix(path, "photos/2024/03/07/"), path)
+		assert.True(t, strings.HasSuffix(path, "/ws-1/item-1/abc_photo.jpg"), path)
+		assert.Equal(t, path, layout.Render(vars), "the shard is stable for a filename")
+
+		got, ok := layout.Parse(path)
+		require.True(t, ok)
+		assert.Equal(t, PathVars{WorkspaceID: "ws-1", ItemID: "item-1", Filename: "abc_photo.jpg"}, got)
+	})
+
+	t.Run("parse rejects paths that do not follow the layout", func(t *testing.T) {
+		layout := MustParsePathLayout("{yyyy}/{workspace}/{item}/{filename}")
+
+		for _, path := range []string{
+			"ws-1/item-1/abc_photo.jpg",      // too few segments
+			"24/ws-1/item-1/abc_photo.jpg",   // not a year
+			"2024/ws-1/../abc_photo.jpg",     // traversal
+			"2024/ws-1/item-1/abc/photo.jpg", // too many segments
+		} {
+			_, ok := layout.Parse(path)
+			assert.False(t, ok, path)
+		}
+	})
+}
+
+func TestParseStoragePath(t *testing.T) {
+	dated := MustParsePathLayout("{yyyy}/{mm}/{workspace}/{item}/{filename}")
+
+	vars, ok := ParseStoragePath("2024/03/ws-1/item-1/a.jpg", dated, DefaultLayout())
+	require.True(t, ok)
+	assert.Equal(t, "ws-1", vars.WorkspaceID)
+
+	// Files stored before the layout changed still resolve.
+	vars, ok = ParseStoragePath("ws-2/item-2/b.jpg", dated, DefaultLayout())
+	require.True(t, ok)
+	assert.Equal(t, "item-2", vars.ItemID)
+
+	_, ok = ParseStoragePath("ws-2/item-2/b.jpg", dated)
+	assert.False(t, ok)
+}
+
+func TestLoadPathLayoutFromEnv(t *testing.T) {
+	t.Run("default when unset", func(t *testing.T) {
+		t.Setenv("PHOTO_STORAGE_LAYOUT", "")
+
+		layout, err := LoadPathLayoutFromEnv()
+		require.NoError(t, err)
+		assert.Equal(t, DefaultPathLayout, layout.String())
+	})
+
+	t.Run("invalid template", func(t *testing.T) {
+		t.Setenv("PHOTO_STORAGE_LAYOUT", "{workspace}/{filename}")
+
+		_, err := LoadPathLayoutFromEnv()
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "PHOTO_STORAGE_LAYOUT")
+	})
+}
+
+func TestLocalStorage_SaveWithPathLayout(t *testing.T) {
+	baseDir := t.TempDir()
+	s, err := NewLocalStorage(baseDir)
+	require.NoError(t, err)
+	layout := MustParsePathLayout("{yyyy}/{mm}/{workspace}/{item}/{filename}")
+	s.SetPathLayout(layout)
+
+	path, err := s.Save(context.Background(), "ws-1", "item-1", "photo.jpg", bytes.NewReader([]byte("data")))
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(path, time.Now().Format("2006/01")+"/ws-1/item-1/"), path)
+	vars, ok := layout.Parse(path)
+	require.True(t, ok)
+	assert.True(t, strings.HasSuffix(vars.Filename, "_photo.jpg"))
+
+	_, err = os.Stat(filepath.Join(baseDir, path))
+	assert.NoError(t, err)
+
+	t.Run("nil restores the default", func(t *testing.T) {
+		s.SetPathLayout(nil)
+		path, err := s.Save(context.Background(), "ws-1", "item-1", "photo.jpg", bytes.NewReader([]byte("data")))
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(path, "ws-1/item-1/"), path)
+	})
+}
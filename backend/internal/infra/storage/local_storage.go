@@ -9,6 +9,7 @@ import (
 	"path/filepath"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -26,7 +27,8 @@ var (
 
 // LocalStorage implements the Storage interface using the local filesystem.
 type LocalStorage struct {
-	baseDir string // Base directory for all uploads
+	baseDir string      // Base directory for all uploads
+	layout  *PathLayout // Where files go under baseDir
 }
 
 // NewLocalStorage creates a new LocalStorage instance.
@@ -50,11 +52,22 @@ func NewLocalStorage(baseDir string) (*LocalStorage, error) {
 
 	return &LocalStorage{
 		baseDir: baseDir,
+		layout:  DefaultLayout(),
 	}, nil
 }
 
-// Save stores a file and returns the storage path.
-// Path format: {workspace_id}/{item_id}/{uuid}_{filename}
+// SetPathLayout sets the layout new files are saved under. Files already
+// stored keep their paths. This is optional - if not set, DefaultPathLayout
+// is used.
+func (s *LocalStorage) SetPathLayout(layout *PathLayout) {
+	if layout == nil {
+		layout = DefaultLayout()
+	}
+	s.layout = layout
+}
+
+// Save stores a file and returns the storage path, rendered from the path
+// layout with {filename} being {uuid}_{filename}.
 func (s *LocalStorage) Save(ctx context.Context, workspaceID, itemID, filename string, reader io.Reader) (string, error) {
 	if workspaceID == "" || itemID == "" || filename == "" {
 		return "", errors.New("workspaceID, itemID, and filename are required")
@@ -70,7 +83,12 @@ func (s *LocalStorage) Save(ctx context.Context, workspaceID, itemID, filename s
 	uniqueFilename := fmt.Sprintf("%s_%s", uuid.New().String(), sanitized)
 
 	// Generate storage path
-	relativePath := GenerateStoragePath(workspaceID, itemID, uniqueFilename)
+	relativePath := s.layout.Render(PathVars{
+		WorkspaceID: workspaceID,
+		ItemID:      itemID,
+		Filename:    uniqueFilename,
+		Time:        time.Now(),
+	})
 	fullPath := filepath.Join(s.baseDir, relativePath)
 
 	// Create directory structure
@@ -251,8 +269,8 @@ func SanitizeFilename(filename string) string {
 	return filename
 }
 
-// GenerateStoragePath creates a storage path for a file.
-// Format: {workspace_id}/{item_id}/{filename}
+// GenerateStoragePath creates a storage path for a file under
+// DefaultPathLayout. Format: {workspace_id}/{item_id}/{filename}
 func GenerateStoragePath(workspaceID, itemID, filename string) string {
 	return filepath.Join(workspaceID, itemID, filename)
 }
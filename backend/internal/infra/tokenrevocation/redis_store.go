@@ -0,0 +1,58 @@
+// Package tokenrevocation records "sign out everywhere" cutoffs in Redis so
+// stateless access tokens can be rejected before they expire.
+package tokenrevocation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// Store keeps one key per user holding the Unix time (seconds) before which
+// that user's access tokens are no longer accepted. It implements
+// session.TokenRevoker and middleware.TokenRevocationChecker.
+type Store struct {
+	client *redis.Client
+	ttl    time.Duration
+}
+
+// NewStore creates a Store. ttl must be at least the access token lifetime:
+// once it has passed, every token the cutoff was meant to reject has expired
+// on its own and the key can go.
+func NewStore(client *redis.Client, ttl time.Duration) *Store {
+	return &Store{client: client, ttl: ttl}
+}
+
+func key(userID uuid.UUID) string {
+	return fmt.Sprintf("tokens_revoked_before:%s", userID)
+}
+
+// RevokeUserTokens rejects every access token of userID issued at or before at.
+func (s *Store) RevokeUserTokens(ctx context.Context, userID uuid.UUID, at time.Time) error {
+	if err := s.client.Set(ctx, key(userID), at.Unix(), s.ttl).Err(); err != nil {
+		return fmt.Errorf("failed to record token revocation: %w", err)
+	}
+	return nil
+}
+
+// RevokedBefore returns the user's revocation cutoff, if one is set.
+func (s *Store) RevokedBefore(ctx context.Context, userID uuid.UUID) (time.Time, bool, error) {
+	val, err := s.client.Get(ctx, key(userID)).Result()
+	if errors.Is(err, redis.Nil) {
+		return time.Time{}, false, nil
+	}
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("failed to read token revocation: %w", err)
+	}
+
+	secs, err := strconv.ParseInt(val, 10, 64)
+	if err != nil {
+		return time.Time{}, false, fmt.Errorf("malformed token revocation for %s: %w", userID, err)
+	}
+	return time.Unix(secs, 0), true, nil
+}
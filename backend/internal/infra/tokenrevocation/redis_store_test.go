@@ -0,0 +1,61 @@
+//go:build integration
+// +build integration
+
+package tokenrevocation
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestStore(t *testing.T) (*Store, *redis.Client, uuid.UUID) {
+	t.Helper()
+
+	addr := os.Getenv("TEST_REDIS_ADDR")
+	if addr == "" {
+		addr = "localhost:6379"
+	}
+	client := redis.NewClient(&redis.Options{Addr: addr})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		t.Skipf("skipping integration test: redis ping failed: %v", err)
+	}
+
+	userID := uuid.New()
+	t.Cleanup(func() {
+		client.Del(context.Background(), key(userID))
+		client.Close()
+	})
+
+	return NewStore(client, time.Hour), client, userID
+}
+
+func TestStore_RevokedBefore(t *testing.T) {
+	store, client, userID := newTestStore(t)
+	ctx := context.Background()
+
+	_, ok, err := store.RevokedBefore(ctx, userID)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	at := time.Now()
+	require.NoError(t, store.RevokeUserTokens(ctx, userID, at))
+
+	cutoff, ok, err := store.RevokedBefore(ctx, userID)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, at.Unix(), cutoff.Unix())
+
+	ttl, err := client.TTL(ctx, key(userID)).Result()
+	require.NoError(t, err)
+	assert.Greater(t, ttl, 59*time.Minute)
+}
@@ -0,0 +1,85 @@
+package urlfetch
+
+import (
+	"bytes"
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func allowAll(net.IP) bool { return false }
+
+func TestValidateURL(t *testing.T) {
+	assert.NoError(t, ValidateURL("https://example.com/a.jpg"))
+	assert.NoError(t, ValidateURL("http://example.com:8080/a.jpg"))
+	for _, raw := range []string{"", "example.com/a.jpg", "ftp://example.com/a.jpg", "file:///etc/passwd", "http://"} {
+		assert.ErrorIs(t, ValidateURL(raw), ErrInvalidURL, raw)
+	}
+}
+
+func TestFetcher_Fetch(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/photo.png":
+			w.Header().Set("Content-Type", "image/png")
+			w.Write([]byte("0123456789"))
+		case "/big":
+			w.Write([]byte(strings.Repeat("x", 64)))
+		case "/redirect":
+			http.Redirect(w, r, "/photo.png", http.StatusFound)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer srv.Close()
+
+	t.Run("downloads body and content type", func(t *testing.T) {
+		var buf bytes.Buffer
+		ct, err := newFetcher(32, allowAll).Fetch(context.Background(), srv.URL+"/photo.png", &buf)
+		require.NoError(t, err)
+		assert.Equal(t, "image/png", ct)
+		assert.Equal(t, "0123456789", buf.String())
+	})
+
+	t.Run("follows redirects", func(t *testing.T) {
+		var buf bytes.Buffer
+		_, err := newFetcher(32, allowAll).Fetch(context.Background(), srv.URL+"/redirect", &buf)
+		require.NoError(t, err)
+		assert.Equal(t, "0123456789", buf.String())
+	})
+
+	t.Run("rejects oversized body", func(t *testing.T) {
+		_, err := newFetcher(32, allowAll).Fetch(context.Background(), srv.URL+"/big", &bytes.Buffer{})
+		assert.ErrorIs(t, err, ErrTooLarge)
+	})
+
+	t.Run("rejects non-200 response", func(t *testing.T) {
+		_, err := newFetcher(32, allowAll).Fetch(context.Background(), srv.URL+"/missing", &bytes.Buffer{})
+		assert.ErrorContains(t, err, "404")
+	})
+
+	t.Run("blocks loopback by default", func(t *testing.T) {
+		_, err := New(32).Fetch(context.Background(), srv.URL+"/photo.png", &bytes.Buffer{})
+		assert.ErrorIs(t, err, ErrBlockedAddress)
+	})
+
+	t.Run("rejects invalid URL", func(t *testing.T) {
+		_, err := New(32).Fetch(context.Background(), "gopher://example.com", &bytes.Buffer{})
+		assert.ErrorIs(t, err, ErrInvalidURL)
+	})
+}
+
+func TestIsBlockedIP(t *testing.T) {
+	for _, s := range []string{"127.0.0.1", "10.1.2.3", "192.168.1.1", "172.16.0.1", "169.254.169.254", "0.0.0.0", "::1", "fe80::1", "fd00::1"} {
+		assert.True(t, isBlockedIP(net.ParseIP(s)), s)
+	}
+	for _, s := range []string{"93.184.216.34", "2606:2800:220:1::1"} {
+		assert.False(t, isBlockedIP(net.ParseIP(s)), s)
+	}
+}
@@ -16,10 +16,33 @@ import (
 // CleanupConfig holds configuration for cleanup jobs.
 type CleanupConfig struct {
 	// DeletedRecordsRetentionDays is how long to keep deleted records (default: 90 days).
+	// Workspaces can override it with their trash settings.
 	DeletedRecordsRetentionDays int
 
+	// DeletedRecordsArchiveDir, when set, makes the deleted records cleanup
+	// write the records it purges to a timestamped JSON file in this
+	// directory first, grouped by workspace. Empty (the default) purges
+	// without a snapshot.
+	DeletedRecordsArchiveDir string
+
 	// ActivityLogsRetentionDays is how long to keep activity logs (default: 365 days).
 	ActivityLogsRetentionDays int
+
+	// AuthEventsRetentionDays is how long to keep the security log of
+	// authentication events (default: 365 days). Zero keeps them forever.
+	AuthEventsRetentionDays int
+
+	// PurgeOrphanedJoins enables removing many-to-many join rows (item
+	// labels, repair attachments, favorites) whose referenced entity no longer
+	// exists (default: true). Foreign keys cascade on current schemas; this
+	// repairs databases restored or migrated without them.
+	PurgeOrphanedJoins bool
+
+	// UploadDir is the photo processing directory swept for temp files left
+	// by crashed jobs, and UploadTempMaxAge how old they must be (default:
+	// 24 hours). No directory or a zero age disables the sweep.
+	UploadDir        string
+	UploadTempMaxAge time.Duration
 }
 
 // DefaultCleanupConfig returns the default cleanup configuration.
@@ -27,6 +50,9 @@ func DefaultCleanupConfig() CleanupConfig {
 	return CleanupConfig{
 		DeletedRecordsRetentionDays: 90,
 		ActivityLogsRetentionDays:   365,
+		AuthEventsRetentionDays:     365,
+		PurgeOrphanedJoins:          true,
+		UploadTempMaxAge:            24 * time.Hour,
 	}
 }
 
@@ -44,24 +70,66 @@ func NewCleanupProcessor(pool *pgxpool.Pool, config CleanupConfig) *CleanupProce
 	}
 }
 
-// ProcessDeletedRecordsCleanup removes old deleted records.
+// ProcessDeletedRecordsCleanup removes old deleted records. Workspaces with
+// their own trash_retention_days keep records for that long instead of
+// DeletedRecordsRetentionDays. With DeletedRecordsArchiveDir set, the records
+// are archived first and only purged once the archive is written.
 func (p *CleanupProcessor) ProcessDeletedRecordsCleanup(ctx context.Context, t *asynq.Task) error {
+	log.Printf("Cleaning up deleted records older than %d days (or the workspace's trash retention)", p.config.DeletedRecordsRetentionDays)
+
+	if p.config.DeletedRecordsArchiveDir != "" {
+		return p.archiveAndPurgeDeletedRecords(ctx)
+	}
+
 	q := queries.New(p.pool)
+	removed, err := q.CleanupExpiredDeletedRecords(ctx, queries.CleanupExpiredDeletedRecordsParams{
+		Now:                  time.Now(),
+		DefaultRetentionDays: int32(p.config.DeletedRecordsRetentionDays),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to cleanup deleted records: %w", err)
+	}
 
-	cutoffDate := time.Now().AddDate(0, 0, -p.config.DeletedRecordsRetentionDays)
+	log.Printf("Deleted records cleanup completed: removed %d records", removed)
+	return nil
+}
 
-	log.Printf("Cleaning up deleted records older than %s", cutoffDate.Format(time.RFC3339))
+// archiveAndPurgeDeletedRecords deletes the expired records in a transaction
+// that commits only after their archive file has been written. Should the
+// commit itself fail, the records stay and appear again in the next run's
+// archive.
+func (p *CleanupProcessor) archiveAndPurgeDeletedRecords(ctx context.Context) error {
+	tx, err := p.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback(ctx) }()
 
-	err := q.CleanupOldDeletedRecords(ctx, cutoffDate)
+	report, err := archiveDeletedRecords(ctx, queries.New(tx), queries.PurgeExpiredDeletedRecordsParams{
+		Now:                  time.Now(),
+		DefaultRetentionDays: int32(p.config.DeletedRecordsRetentionDays),
+	}, p.config.DeletedRecordsArchiveDir)
 	if err != nil {
-		return fmt.Errorf("failed to cleanup deleted records: %w", err)
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit deleted records cleanup: %w", err)
 	}
 
-	log.Printf("Deleted records cleanup completed")
+	if report.Records == 0 {
+		log.Printf("Deleted records cleanup completed: nothing to archive")
+		return nil
+	}
+	log.Printf("Deleted records cleanup completed: archived and removed %d records from %d workspaces to %s",
+		report.Records, len(report.Workspaces), report.Path)
+	for workspaceID, n := range report.Workspaces {
+		log.Printf("  workspace %s: %d records", workspaceID, n)
+	}
 	return nil
 }
 
-// ProcessActivityCleanup removes old activity logs.
+// ProcessActivityCleanup removes old activity logs and, unless their
+// retention is zero, old auth events.
 func (p *CleanupProcessor) ProcessActivityCleanup(ctx context.Context, t *asynq.Task) error {
 	q := queries.New(p.pool)
 
@@ -80,9 +148,72 @@ func (p *CleanupProcessor) ProcessActivityCleanup(ctx context.Context, t *asynq.
 	}
 
 	log.Printf("Activity logs cleanup completed")
+
+	if p.config.AuthEventsRetentionDays > 0 {
+		authCutoff := time.Now().AddDate(0, 0, -p.config.AuthEventsRetentionDays)
+		removed, err := q.CleanupOldAuthEvents(ctx, authCutoff)
+		if err != nil {
+			return fmt.Errorf("failed to cleanup auth events: %w", err)
+		}
+		log.Printf("Auth events cleanup completed: removed %d events older than %s", removed, authCutoff.Format(time.RFC3339))
+	}
+	return nil
+}
+
+// OrphanedJoinsResult counts the join rows removed by an orphan purge.
+type OrphanedJoinsResult struct {
+	ItemLabels        int64
+	RepairAttachments int64
+	Favorites         int64
+}
+
+// Total returns the number of rows removed across all join tables.
+func (r OrphanedJoinsResult) Total() int64 {
+	return r.ItemLabels + r.RepairAttachments + r.Favorites
+}
+
+// orphanedJoinsStore is the subset of queries used to purge orphaned join
+// rows, split out so tests don't need a database.
+type orphanedJoinsStore interface {
+	PurgeOrphanedItemLabels(ctx context.Context) (int64, error)
+	PurgeOrphanedRepairAttachments(ctx context.Context) (int64, error)
+	PurgeOrphanedFavorites(ctx context.Context) (int64, error)
+}
+
+// ProcessOrphanedJoinsCleanup removes join rows that point at deleted
+// entities, when enabled by CleanupConfig.PurgeOrphanedJoins.
+func (p *CleanupProcessor) ProcessOrphanedJoinsCleanup(ctx context.Context, t *asynq.Task) error {
+	if !p.config.PurgeOrphanedJoins {
+		log.Printf("Orphaned join rows cleanup disabled, skipping")
+		return nil
+	}
+
+	result, err := purgeOrphanedJoins(ctx, queries.New(p.pool))
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Orphaned join rows cleanup completed: removed %d item labels, %d repair attachments, %d favorites",
+		result.ItemLabels, result.RepairAttachments, result.Favorites)
 	return nil
 }
 
+func purgeOrphanedJoins(ctx context.Context, store orphanedJoinsStore) (OrphanedJoinsResult, error) {
+	var result OrphanedJoinsResult
+	var err error
+
+	if result.ItemLabels, err = store.PurgeOrphanedItemLabels(ctx); err != nil {
+		return result, fmt.Errorf("failed to purge orphaned item labels: %w", err)
+	}
+	if result.RepairAttachments, err = store.PurgeOrphanedRepairAttachments(ctx); err != nil {
+		return result, fmt.Errorf("failed to purge orphaned repair attachments: %w", err)
+	}
+	if result.Favorites, err = store.PurgeOrphanedFavorites(ctx); err != nil {
+		return result, fmt.Errorf("failed to purge orphaned favorites: %w", err)
+	}
+	return result, nil
+}
+
 // NewCleanupDeletedRecordsTask creates a task to cleanup deleted records.
 func NewCleanupDeletedRecordsTask() *asynq.Task {
 	return asynq.NewTask(TypeCleanupDeletedRecords, nil)
@@ -92,3 +223,8 @@ func NewCleanupDeletedRecordsTask() *asynq.Task {
 func NewCleanupActivityTask() *asynq.Task {
 	return asynq.NewTask(TypeCleanupOldActivity, nil)
 }
+
+// NewCleanupOrphanedJoinsTask creates a task to purge orphaned join rows.
+func NewCleanupOrphanedJoinsTask() *asynq.Task {
+	return asynq.NewTask(TypeCleanupOrphanedJoins, nil)
+}
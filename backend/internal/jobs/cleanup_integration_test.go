@@ -5,7 +5,9 @@ package jobs
 
 import (
 	"context"
+	"encoding/json"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -223,3 +225,116 @@ func TestCleanupProcessor_RetainsRecentRecords(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, 1, count, "should retain recent deleted records")
 }
+
+func TestCleanupProcessor_ProcessDeletedRecordsCleanup_WorkspaceRetention(t *testing.T) {
+	pool := getTestPool(t)
+	ctx := context.Background()
+
+	workspaceID := setupTestWorkspace(t, pool)
+
+	_, err := pool.Exec(ctx, `
+		INSERT INTO warehouse.trash_settings (workspace_id, trash_retention_days)
+		VALUES ($1, 7)
+	`, workspaceID)
+	require.NoError(t, err)
+
+	// 30 days is within the global retention but past the workspace's.
+	entityID := uuid.New()
+	_, err = pool.Exec(ctx, `
+		INSERT INTO warehouse.deleted_records (id, workspace_id, entity_type, entity_id, deleted_at)
+		VALUES (gen_random_uuid(), $1, 'CATEGORY', $2, $3)
+	`, workspaceID, entityID, time.Now().AddDate(0, 0, -30))
+	require.NoError(t, err)
+
+	processor := NewCleanupProcessor(pool, DefaultCleanupConfig())
+	err = processor.ProcessDeletedRecordsCleanup(ctx, asynq.NewTask(TypeCleanupDeletedRecords, nil))
+	require.NoError(t, err)
+
+	var count int
+	err = pool.QueryRow(ctx, `
+		SELECT COUNT(*) FROM warehouse.deleted_records
+		WHERE workspace_id = $1 AND entity_id = $2
+	`, workspaceID, entityID).Scan(&count)
+	require.NoError(t, err)
+	assert.Zero(t, count, "workspace retention should override the global default")
+}
+
+func TestCleanupProcessor_ProcessDeletedRecordsCleanup_Archive(t *testing.T) {
+	pool := getTestPool(t)
+	ctx := context.Background()
+
+	workspaceID := setupTestWorkspace(t, pool)
+
+	expiredID, recentID := uuid.New(), uuid.New()
+	_, err := pool.Exec(ctx, `
+		INSERT INTO warehouse.deleted_records (id, workspace_id, entity_type, entity_id, deleted_at)
+		VALUES (gen_random_uuid(), $1, 'CATEGORY', $2, $3), (gen_random_uuid(), $1, 'ITEM', $4, $5)
+	`, workspaceID, expiredID, time.Now().AddDate(0, 0, -100), recentID, time.Now().AddDate(0, 0, -10))
+	require.NoError(t, err)
+
+	config := DefaultCleanupConfig()
+	config.DeletedRecordsArchiveDir = t.TempDir()
+	processor := NewCleanupProcessor(pool, config)
+	err = processor.ProcessDeletedRecordsCleanup(ctx, asynq.NewTask(TypeCleanupDeletedRecords, nil))
+	require.NoError(t, err)
+
+	var count int
+	err = pool.QueryRow(ctx, `
+		SELECT COUNT(*) FROM warehouse.deleted_records WHERE workspace_id = $1
+	`, workspaceID).Scan(&count)
+	require.NoError(t, err)
+	assert.Equal(t, 1, count, "only the expired record is purged")
+
+	files, err := filepath.Glob(filepath.Join(config.DeletedRecordsArchiveDir, "deleted-records-*.json"))
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+	data, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	var archive DeletedRecordsArchive
+	require.NoError(t, json.Unmarshal(data, &archive))
+
+	var archived []uuid.UUID
+	for _, group := range archive.Workspaces {
+		if group.WorkspaceID != workspaceID {
+			continue
+		}
+		for _, r := range group.Records {
+			archived = append(archived, r.EntityID)
+		}
+	}
+	assert.Equal(t, []uuid.UUID{expiredID}, archived)
+}
+
+func TestCleanupProcessor_ProcessOrphanedJoinsCleanup(t *testing.T) {
+	pool := getTestPool(t)
+	ctx := context.Background()
+
+	workspaceID := setupTestWorkspace(t, pool)
+
+	// Orphans can only exist when foreign keys were bypassed (e.g. a restore
+	// with triggers disabled), so insert one the same way.
+	conn, err := pool.Acquire(ctx)
+	require.NoError(t, err)
+	_, err = conn.Exec(ctx, `SET session_replication_role = replica`)
+	if err != nil {
+		conn.Release()
+		t.Skipf("skipping: cannot bypass foreign keys: %v", err)
+	}
+	_, err = conn.Exec(ctx, `
+		INSERT INTO warehouse.item_labels (item_id, label_id, workspace_id)
+		VALUES ($1, $2, $3)
+	`, uuid.New(), uuid.New(), workspaceID)
+	_, resetErr := conn.Exec(ctx, `SET session_replication_role = DEFAULT`)
+	conn.Release()
+	require.NoError(t, err)
+	require.NoError(t, resetErr)
+
+	processor := NewCleanupProcessor(pool, DefaultCleanupConfig())
+	err = processor.ProcessOrphanedJoinsCleanup(ctx, NewCleanupOrphanedJoinsTask())
+	require.NoError(t, err)
+
+	var count int
+	err = pool.QueryRow(ctx, `SELECT COUNT(*) FROM warehouse.item_labels WHERE workspace_id = $1`, workspaceID).Scan(&count)
+	require.NoError(t, err)
+	assert.Zero(t, count, "orphaned item label should be purged")
+}
@@ -1,6 +1,8 @@
 package jobs
 
 import (
+	"context"
+	"errors"
 	"math"
 	"testing"
 	"time"
@@ -19,6 +21,12 @@ func TestDefaultCleanupConfigValues(t *testing.T) {
 	assert.Equal(t, 90, config.DeletedRecordsRetentionDays)
 	// Default should be 365 days for activity logs
 	assert.Equal(t, 365, config.ActivityLogsRetentionDays)
+	// Auth events are kept as long as activity logs
+	assert.Equal(t, 365, config.AuthEventsRetentionDays)
+	// Orphaned join purge is on by default
+	assert.True(t, config.PurgeOrphanedJoins)
+	// Upload temp files are kept for a day
+	assert.Equal(t, 24*time.Hour, config.UploadTempMaxAge)
 }
 
 func TestCleanupConfig_NegativeValues(t *testing.T) {
@@ -279,3 +287,59 @@ func TestDefaultCleanupConfig_Immutability(t *testing.T) {
 	// config2 should still have default value
 	assert.Equal(t, 90, config2.DeletedRecordsRetentionDays)
 }
+
+// =============================================================================
+// Orphaned Join Purge Tests
+// =============================================================================
+
+type fakeOrphanedJoinsStore struct {
+	itemLabels, repairAttachments, favorites int64
+	err                                      error
+	calls                                    []string
+}
+
+func (f *fakeOrphanedJoinsStore) PurgeOrphanedItemLabels(ctx context.Context) (int64, error) {
+	f.calls = append(f.calls, "item_labels")
+	return f.itemLabels, nil
+}
+
+func (f *fakeOrphanedJoinsStore) PurgeOrphanedRepairAttachments(ctx context.Context) (int64, error) {
+	f.calls = append(f.calls, "repair_attachments")
+	return f.repairAttachments, f.err
+}
+
+func (f *fakeOrphanedJoinsStore) PurgeOrphanedFavorites(ctx context.Context) (int64, error) {
+	f.calls = append(f.calls, "favorites")
+	return f.favorites, nil
+}
+
+func TestPurgeOrphanedJoins(t *testing.T) {
+	t.Run("reports per-table counts", func(t *testing.T) {
+		store := &fakeOrphanedJoinsStore{itemLabels: 3, repairAttachments: 1, favorites: 2}
+
+		result, err := purgeOrphanedJoins(context.Background(), store)
+
+		assert.NoError(t, err)
+		assert.Equal(t, OrphanedJoinsResult{ItemLabels: 3, RepairAttachments: 1, Favorites: 2}, result)
+		assert.Equal(t, int64(6), result.Total())
+	})
+
+	t.Run("stops at the first failure", func(t *testing.T) {
+		store := &fakeOrphanedJoinsStore{itemLabels: 3, err: errors.New("db down")}
+
+		result, err := purgeOrphanedJoins(context.Background(), store)
+
+		assert.ErrorContains(t, err, "repair attachments")
+		assert.Equal(t, int64(3), result.ItemLabels)
+		assert.Equal(t, []string{"item_labels", "repair_attachments"}, store.calls)
+	})
+}
+
+func TestProcessOrphanedJoinsCleanup_Disabled(t *testing.T) {
+	// With the purge disabled the processor never touches the (nil) pool.
+	processor := NewCleanupProcessor(nil, CleanupConfig{PurgeOrphanedJoins: false})
+
+	err := processor.ProcessOrphanedJoinsCleanup(context.Background(), NewCleanupOrphanedJoinsTask())
+
+	assert.NoError(t, err)
+}
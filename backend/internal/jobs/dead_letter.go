@@ -0,0 +1,190 @@
+package jobs
+
+import (
+	"encoding/json"
+	"errors"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/hibiken/asynq"
+)
+
+// defaultDeadLetterLimit caps how many dead letters a single listing returns.
+const defaultDeadLetterLimit = 50
+
+// queueInspector is the subset of asynq.Inspector used for dead-letter
+// inspection, split out so tests don't need Redis.
+type queueInspector interface {
+	GetQueueInfo(queue string) (*asynq.QueueInfo, error)
+	ListArchivedTasks(queue string, opts ...asynq.ListOption) ([]*asynq.TaskInfo, error)
+}
+
+// QueueStatus summarizes one queue's task counts.
+type QueueStatus struct {
+	Queue     string `json:"queue"`
+	Pending   int    `json:"pending"`
+	Active    int    `json:"active"`
+	Scheduled int    `json:"scheduled"`
+	Retry     int    `json:"retry"`
+	// DeadLetters are tasks that exhausted their retries (asynq "archived").
+	DeadLetters int `json:"dead_letters"`
+	// ProcessedToday and FailedToday are asynq's daily counters.
+	ProcessedToday int `json:"processed_today"`
+	FailedToday    int `json:"failed_today"`
+}
+
+// SchedulerStatus is the payload of the scheduler's /status endpoint.
+type SchedulerStatus struct {
+	Status      string        `json:"status"`
+	DeadLetters int           `json:"dead_letters"`
+	Queues      []QueueStatus `json:"queues"`
+}
+
+// DeadLetter is a task that permanently failed. The payload is left out: the
+// health server is unauthenticated and payloads carry user data such as
+// email addresses and webhook bodies.
+type DeadLetter struct {
+	ID           string    `json:"id"`
+	Queue        string    `json:"queue"`
+	Type         string    `json:"type"`
+	Retried      int       `json:"retried"`
+	MaxRetry     int       `json:"max_retry"`
+	LastError    string    `json:"last_error"`
+	LastFailedAt time.Time `json:"last_failed_at"`
+}
+
+// DeadLetterInspector reports queue health and lists permanently failed
+// tasks. asynq keeps those in its archive (retained for up to 90 days), so
+// this is a read-only view over it.
+type DeadLetterInspector struct {
+	inspector queueInspector
+	queues    []string
+}
+
+// NewDeadLetterInspector creates an inspector over the given queues.
+func NewDeadLetterInspector(inspector queueInspector, queues []string) *DeadLetterInspector {
+	return &DeadLetterInspector{inspector: inspector, queues: queues}
+}
+
+// Status returns per-queue counts. The overall status is "degraded" when any
+// dead letters exist. Queues that have never seen a task are reported empty.
+func (d *DeadLetterInspector) Status() (*SchedulerStatus, error) {
+	status := &SchedulerStatus{Status: "healthy", Queues: make([]QueueStatus, 0, len(d.queues))}
+
+	for _, queue := range d.queues {
+		info, err := d.inspector.GetQueueInfo(queue)
+		if errors.Is(err, asynq.ErrQueueNotFound) {
+			status.Queues = append(status.Queues, QueueStatus{Queue: queue})
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		status.Queues = append(status.Queues, QueueStatus{
+			Queue:          queue,
+			Pending:        info.Pending,
+			Active:         info.Active,
+			Scheduled:      info.Scheduled,
+			Retry:          info.Retry,
+			DeadLetters:    info.Archived,
+			ProcessedToday: info.Processed,
+			FailedToday:    info.Failed,
+		})
+		status.DeadLetters += info.Archived
+	}
+
+	if status.DeadLetters > 0 {
+		status.Status = "degraded"
+	}
+	return status, nil
+}
+
+// ListDeadLetters returns up to limit permanently failed tasks, from one
+// queue or (when queue is empty) from every queue.
+func (d *DeadLetterInspector) ListDeadLetters(queue string, limit int) ([]DeadLetter, error) {
+	if limit <= 0 {
+		limit = defaultDeadLetterLimit
+	}
+
+	queues := d.queues
+	if queue != "" {
+		queues = []string{queue}
+	}
+
+	deadLetters := []DeadLetter{}
+	for _, q := range queues {
+		if len(deadLetters) >= limit {
+			break
+		}
+
+		tasks, err := d.inspector.ListArchivedTasks(q, asynq.PageSize(limit-len(deadLetters)))
+		if errors.Is(err, asynq.ErrQueueNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		for _, t := range tasks {
+			deadLetters = append(deadLetters, DeadLetter{
+				ID:           t.ID,
+				Queue:        t.Queue,
+				Type:         t.Type,
+				Retried:      t.Retried,
+				MaxRetry:     t.MaxRetry,
+				LastError:    t.LastErr,
+				LastFailedAt: t.LastFailedAt,
+			})
+		}
+	}
+
+	return deadLetters, nil
+}
+
+// StatusHandler serves GET /status with queue counts and the dead-letter total.
+func (d *DeadLetterInspector) StatusHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		status, err := d.Status()
+		if err != nil {
+			log.Printf("Failed to inspect queues: %v", err)
+			writeJSON(w, http.StatusServiceUnavailable, map[string]string{"status": "unhealthy", "error": "queue inspection failed"})
+			return
+		}
+		writeJSON(w, http.StatusOK, status)
+	}
+}
+
+// DeadLettersHandler serves GET /status/dead-letters, optionally filtered by
+// ?queue= and bounded by ?limit=.
+func (d *DeadLetterInspector) DeadLettersHandler() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		limit := defaultDeadLetterLimit
+		if v := r.URL.Query().Get("limit"); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 1 {
+				writeJSON(w, http.StatusBadRequest, map[string]string{"error": "limit must be a positive integer"})
+				return
+			}
+			limit = n
+		}
+
+		deadLetters, err := d.ListDeadLetters(r.URL.Query().Get("queue"), limit)
+		if err != nil {
+			log.Printf("Failed to list dead letters: %v", err)
+			writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "queue inspection failed"})
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]any{"dead_letters": deadLetters})
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		log.Printf("Failed to write response: %v", err)
+	}
+}
@@ -0,0 +1,172 @@
+package jobs_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/jobs"
+)
+
+// fakeInspector serves canned queue info and archived tasks.
+type fakeInspector struct {
+	info     map[string]*asynq.QueueInfo
+	archived map[string][]*asynq.TaskInfo
+	err      error
+}
+
+func (f *fakeInspector) GetQueueInfo(queue string) (*asynq.QueueInfo, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	info, ok := f.info[queue]
+	if !ok {
+		return nil, asynq.ErrQueueNotFound
+	}
+	return info, nil
+}
+
+func (f *fakeInspector) ListArchivedTasks(queue string, _ ...asynq.ListOption) ([]*asynq.TaskInfo, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	tasks, ok := f.archived[queue]
+	if !ok {
+		return nil, asynq.ErrQueueNotFound
+	}
+	return tasks, nil
+}
+
+func newFakeInspector() *fakeInspector {
+	failedAt := time.Date(2026, 1, 2, 9, 0, 5, 0, time.UTC)
+	return &fakeInspector{
+		info: map[string]*asynq.QueueInfo{
+			jobs.QueueDefault: {Queue: jobs.QueueDefault, Pending: 2, Retry: 1, Archived: 1, Processed: 10, Failed: 3},
+			jobs.QueueLow:     {Queue: jobs.QueueLow},
+		},
+		archived: map[string][]*asynq.TaskInfo{
+			jobs.QueueDefault: {{
+				ID:           "task-1",
+				Queue:        jobs.QueueDefault,
+				Type:         jobs.TypeLoanReminder,
+				Payload:      []byte(`{"loan_id":"abc"}`),
+				Retried:      5,
+				MaxRetry:     5,
+				LastErr:      "connection refused",
+				LastFailedAt: failedAt,
+			}},
+			jobs.QueueLow: {},
+		},
+	}
+}
+
+func TestDeadLetterInspector_Status(t *testing.T) {
+	t.Run("degraded when dead letters exist", func(t *testing.T) {
+		inspector := jobs.NewDeadLetterInspector(newFakeInspector(), []string{jobs.QueueCritical, jobs.QueueDefault, jobs.QueueLow})
+
+		status, err := inspector.Status()
+
+		require.NoError(t, err)
+		assert.Equal(t, "degraded", status.Status)
+		assert.Equal(t, 1, status.DeadLetters)
+		require.Len(t, status.Queues, 3)
+		// Unknown queue is reported empty rather than failing
+		assert.Equal(t, jobs.QueueStatus{Queue: jobs.QueueCritical}, status.Queues[0])
+		assert.Equal(t, 2, status.Queues[1].Pending)
+		assert.Equal(t, 1, status.Queues[1].Retry)
+		assert.Equal(t, 3, status.Queues[1].FailedToday)
+	})
+
+	t.Run("healthy without dead letters", func(t *testing.T) {
+		inspector := jobs.NewDeadLetterInspector(newFakeInspector(), []string{jobs.QueueLow})
+
+		status, err := inspector.Status()
+
+		require.NoError(t, err)
+		assert.Equal(t, "healthy", status.Status)
+		assert.Zero(t, status.DeadLetters)
+	})
+
+	t.Run("propagates redis errors", func(t *testing.T) {
+		fake := newFakeInspector()
+		fake.err = errors.New("redis down")
+		inspector := jobs.NewDeadLetterInspector(fake, []string{jobs.QueueDefault})
+
+		_, err := inspector.Status()
+
+		assert.Error(t, err)
+	})
+}
+
+func TestDeadLetterInspector_ListDeadLetters(t *testing.T) {
+	inspector := jobs.NewDeadLetterInspector(newFakeInspector(), []string{jobs.QueueCritical, jobs.QueueDefault, jobs.QueueLow})
+
+	t.Run("lists across queues", func(t *testing.T) {
+		deadLetters, err := inspector.ListDeadLetters("", 0)
+
+		require.NoError(t, err)
+		require.Len(t, deadLetters, 1)
+		assert.Equal(t, "task-1", deadLetters[0].ID)
+		assert.Equal(t, jobs.TypeLoanReminder, deadLetters[0].Type)
+		assert.Equal(t, "connection refused", deadLetters[0].LastError)
+	})
+
+	t.Run("filters by queue", func(t *testing.T) {
+		deadLetters, err := inspector.ListDeadLetters(jobs.QueueLow, 10)
+
+		require.NoError(t, err)
+		assert.Empty(t, deadLetters)
+	})
+}
+
+func TestDeadLetterInspector_Handlers(t *testing.T) {
+	inspector := jobs.NewDeadLetterInspector(newFakeInspector(), []string{jobs.QueueDefault})
+
+	t.Run("status", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		inspector.StatusHandler()(rec, httptest.NewRequest(http.MethodGet, "/status", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var status jobs.SchedulerStatus
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &status))
+		assert.Equal(t, "degraded", status.Status)
+		assert.Equal(t, 1, status.DeadLetters)
+	})
+
+	t.Run("dead letters", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		inspector.DeadLettersHandler()(rec, httptest.NewRequest(http.MethodGet, "/status/dead-letters?limit=5", nil))
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		var body struct {
+			DeadLetters []jobs.DeadLetter `json:"dead_letters"`
+		}
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		require.Len(t, body.DeadLetters, 1)
+		assert.Equal(t, 5, body.DeadLetters[0].Retried)
+		assert.NotContains(t, rec.Body.String(), "loan_id", "payloads are not exposed")
+	})
+
+	t.Run("rejects invalid limit", func(t *testing.T) {
+		rec := httptest.NewRecorder()
+		inspector.DeadLettersHandler()(rec, httptest.NewRequest(http.MethodGet, "/status/dead-letters?limit=zero", nil))
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+
+	t.Run("unavailable when redis fails", func(t *testing.T) {
+		fake := newFakeInspector()
+		fake.err = errors.New("redis down")
+		rec := httptest.NewRecorder()
+		jobs.NewDeadLetterInspector(fake, []string{jobs.QueueDefault}).StatusHandler()(rec, httptest.NewRequest(http.MethodGet, "/status", nil))
+
+		assert.Equal(t, http.StatusServiceUnavailable, rec.Code)
+	})
+}
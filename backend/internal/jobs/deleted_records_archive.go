@@ -0,0 +1,155 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+
+	"github.com/antti/home-warehouse/go-backend/internal/infra/queries"
+)
+
+// DeletedRecordsArchive is the JSON snapshot written before expired deleted
+// records are purged, grouped by workspace.
+type DeletedRecordsArchive struct {
+	ArchivedAt time.Time                    `json:"archived_at"`
+	Workspaces []DeletedRecordsArchiveGroup `json:"workspaces"`
+}
+
+// DeletedRecordsArchiveGroup holds one workspace's purged records.
+type DeletedRecordsArchiveGroup struct {
+	WorkspaceID uuid.UUID                     `json:"workspace_id"`
+	Records     []DeletedRecordsArchiveRecord `json:"records"`
+}
+
+// DeletedRecordsArchiveRecord is one purged deleted_records row.
+type DeletedRecordsArchiveRecord struct {
+	ID         uuid.UUID  `json:"id"`
+	EntityType string     `json:"entity_type"`
+	EntityID   uuid.UUID  `json:"entity_id"`
+	DeletedAt  time.Time  `json:"deleted_at"`
+	DeletedBy  *uuid.UUID `json:"deleted_by,omitempty"`
+}
+
+// DeletedRecordsArchiveReport says what a cleanup run archived. Path is empty
+// when nothing expired, in which case no file is written.
+type DeletedRecordsArchiveReport struct {
+	Path       string
+	Records    int
+	Workspaces map[uuid.UUID]int
+}
+
+// deletedRecordsPurger is the query that removes expired deleted records and
+// returns them, split out so tests don't need a database.
+type deletedRecordsPurger interface {
+	PurgeExpiredDeletedRecords(ctx context.Context, arg queries.PurgeExpiredDeletedRecordsParams) ([]queries.WarehouseDeletedRecord, error)
+}
+
+// archiveDeletedRecords purges the expired records through store and writes
+// them to a timestamped JSON file in dir. store is expected to run in a
+// transaction the caller commits only when this returns no error, so a
+// failed write leaves the records in place for the next run.
+func archiveDeletedRecords(ctx context.Context, store deletedRecordsPurger, params queries.PurgeExpiredDeletedRecordsParams, dir string) (DeletedRecordsArchiveReport, error) {
+	report := DeletedRecordsArchiveReport{Workspaces: map[uuid.UUID]int{}}
+
+	rows, err := store.PurgeExpiredDeletedRecords(ctx, params)
+	if err != nil {
+		return report, fmt.Errorf("failed to purge deleted records: %w", err)
+	}
+	if len(rows) == 0 {
+		return report, nil
+	}
+
+	archive := buildDeletedRecordsArchive(rows, params.Now)
+	for _, group := range archive.Workspaces {
+		report.Workspaces[group.WorkspaceID] = len(group.Records)
+	}
+	report.Records = len(rows)
+
+	report.Path, err = writeDeletedRecordsArchive(dir, archive)
+	if err != nil {
+		return report, fmt.Errorf("failed to write deleted records archive: %w", err)
+	}
+	return report, nil
+}
+
+// buildDeletedRecordsArchive groups rows by workspace, workspaces and their
+// records in a stable order.
+func buildDeletedRecordsArchive(rows []queries.WarehouseDeletedRecord, now time.Time) DeletedRecordsArchive {
+	groups := map[uuid.UUID]*DeletedRecordsArchiveGroup{}
+	for _, row := range rows {
+		group, ok := groups[row.WorkspaceID]
+		if !ok {
+			group = &DeletedRecordsArchiveGroup{WorkspaceID: row.WorkspaceID}
+			groups[row.WorkspaceID] = group
+		}
+
+		record := DeletedRecordsArchiveRecord{
+			ID:         row.ID,
+			EntityType: string(row.EntityType),
+			EntityID:   row.EntityID,
+			DeletedAt:  row.DeletedAt,
+		}
+		if row.DeletedBy.Valid {
+			deletedBy := uuid.UUID(row.DeletedBy.Bytes)
+			record.DeletedBy = &deletedBy
+		}
+		group.Records = append(group.Records, record)
+	}
+
+	archive := DeletedRecordsArchive{ArchivedAt: now.UTC()}
+	for _, group := range groups {
+		sort.Slice(group.Records, func(i, j int) bool {
+			return group.Records[i].DeletedAt.Before(group.Records[j].DeletedAt)
+		})
+		archive.Workspaces = append(archive.Workspaces, *group)
+	}
+	sort.Slice(archive.Workspaces, func(i, j int) bool {
+		return archive.Workspaces[i].WorkspaceID.String() < archive.Workspaces[j].WorkspaceID.String()
+	})
+	return archive
+}
+
+// writeDeletedRecordsArchive writes archive to
+// dir/deleted-records-<timestamp>.json. The file is written under a temporary
+// name and renamed into place, so a crash never leaves a truncated archive.
+func writeDeletedRecordsArchive(dir string, archive DeletedRecordsArchive) (string, error) {
+	if err := os.MkdirAll(dir, 0o750); err != nil {
+		return "", err
+	}
+
+	data, err := json.MarshalIndent(archive, "", "  ")
+	if err != nil {
+		return "", err
+	}
+
+	name := fmt.Sprintf("deleted-records-%s.json", archive.ArchivedAt.Format("20060102T150405Z"))
+	path := filepath.Join(dir, name)
+
+	tmp, err := os.CreateTemp(dir, name+".*.tmp")
+	if err != nil {
+		return "", err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return "", err
+	}
+	if err := tmp.Close(); err != nil {
+		return "", err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return "", err
+	}
+	return path, nil
+}
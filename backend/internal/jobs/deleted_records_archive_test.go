@@ -0,0 +1,132 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/infra/queries"
+)
+
+type fakeDeletedRecordsPurger struct {
+	rows   []queries.WarehouseDeletedRecord
+	err    error
+	params queries.PurgeExpiredDeletedRecordsParams
+}
+
+func (f *fakeDeletedRecordsPurger) PurgeExpiredDeletedRecords(ctx context.Context, arg queries.PurgeExpiredDeletedRecordsParams) ([]queries.WarehouseDeletedRecord, error) {
+	f.params = arg
+	return f.rows, f.err
+}
+
+func TestArchiveDeletedRecords(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 3, 1, 3, 0, 0, 0, time.UTC)
+	params := queries.PurgeExpiredDeletedRecordsParams{Now: now, DefaultRetentionDays: 90}
+	wsA, wsB, userID := uuid.New(), uuid.New(), uuid.New()
+
+	row := func(ws uuid.UUID, entityType queries.WarehouseActivityEntityEnum, daysAgo int, deletedBy pgtype.UUID) queries.WarehouseDeletedRecord {
+		return queries.WarehouseDeletedRecord{
+			ID:          uuid.New(),
+			WorkspaceID: ws,
+			EntityType:  entityType,
+			EntityID:    uuid.New(),
+			DeletedAt:   now.AddDate(0, 0, -daysAgo),
+			DeletedBy:   deletedBy,
+		}
+	}
+
+	t.Run("writes purged records grouped by workspace", func(t *testing.T) {
+		dir := t.TempDir()
+		rows := []queries.WarehouseDeletedRecord{
+			row(wsA, "ITEM", 100, pgtype.UUID{Bytes: userID, Valid: true}),
+			row(wsB, "CATEGORY", 120, pgtype.UUID{}),
+			row(wsA, "LOCATION", 200, pgtype.UUID{}),
+		}
+		store := &fakeDeletedRecordsPurger{rows: rows}
+
+		report, err := archiveDeletedRecords(ctx, store, params, dir)
+
+		require.NoError(t, err)
+		assert.Equal(t, params, store.params)
+		assert.Equal(t, 3, report.Records)
+		assert.Equal(t, map[uuid.UUID]int{wsA: 2, wsB: 1}, report.Workspaces)
+		assert.Equal(t, filepath.Join(dir, "deleted-records-20260301T030000Z.json"), report.Path)
+
+		data, err := os.ReadFile(report.Path)
+		require.NoError(t, err)
+		var archive DeletedRecordsArchive
+		require.NoError(t, json.Unmarshal(data, &archive))
+		assert.True(t, archive.ArchivedAt.Equal(now))
+		require.Len(t, archive.Workspaces, 2)
+
+		groups := map[uuid.UUID]DeletedRecordsArchiveGroup{}
+		for _, g := range archive.Workspaces {
+			groups[g.WorkspaceID] = g
+		}
+		require.Len(t, groups[wsA].Records, 2)
+		// Oldest first within a workspace.
+		assert.Equal(t, rows[2].ID, groups[wsA].Records[0].ID)
+		assert.Equal(t, "LOCATION", groups[wsA].Records[0].EntityType)
+		assert.Nil(t, groups[wsA].Records[0].DeletedBy)
+		require.NotNil(t, groups[wsA].Records[1].DeletedBy)
+		assert.Equal(t, userID, *groups[wsA].Records[1].DeletedBy)
+		require.Len(t, groups[wsB].Records, 1)
+		assert.Equal(t, rows[1].EntityID, groups[wsB].Records[0].EntityID)
+
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Len(t, entries, 1, "no temp file left behind")
+	})
+
+	t.Run("writes nothing when no records expired", func(t *testing.T) {
+		dir := t.TempDir()
+
+		report, err := archiveDeletedRecords(ctx, &fakeDeletedRecordsPurger{}, params, dir)
+
+		require.NoError(t, err)
+		assert.Zero(t, report.Records)
+		assert.Empty(t, report.Path)
+		entries, err := os.ReadDir(dir)
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+
+	t.Run("creates the archive directory", func(t *testing.T) {
+		dir := filepath.Join(t.TempDir(), "compliance", "deleted")
+		store := &fakeDeletedRecordsPurger{rows: []queries.WarehouseDeletedRecord{row(wsA, "ITEM", 100, pgtype.UUID{})}}
+
+		report, err := archiveDeletedRecords(ctx, store, params, dir)
+
+		require.NoError(t, err)
+		assert.FileExists(t, report.Path)
+	})
+
+	t.Run("fails when the archive cannot be written", func(t *testing.T) {
+		// A regular file where the directory should be.
+		dir := filepath.Join(t.TempDir(), "archive")
+		require.NoError(t, os.WriteFile(dir, nil, 0o600))
+		store := &fakeDeletedRecordsPurger{rows: []queries.WarehouseDeletedRecord{row(wsA, "ITEM", 100, pgtype.UUID{})}}
+
+		_, err := archiveDeletedRecords(ctx, store, params, dir)
+
+		assert.ErrorContains(t, err, "failed to write deleted records archive")
+	})
+
+	t.Run("returns purge errors", func(t *testing.T) {
+		store := &fakeDeletedRecordsPurger{err: errors.New("db down")}
+
+		_, err := archiveDeletedRecords(ctx, store, params, t.TempDir())
+
+		assert.ErrorContains(t, err, "db down")
+	})
+}
@@ -0,0 +1,182 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/antti/home-warehouse/go-backend/internal/infra/events"
+	"github.com/antti/home-warehouse/go-backend/internal/infra/queries"
+)
+
+// ExpiryCutoff is the first expiration date that has not yet expired at now:
+// today (UTC). An entry expiring today is still good for the rest of the day.
+func ExpiryCutoff(now time.Time) time.Time {
+	now = now.UTC()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// ShouldExpire reports whether an expired entry in status from moves to the
+// workspace's expired status. Entries on loan are left alone (the borrower
+// still has them), as are disposed entries and those already there.
+// inventory.Settings.Validate only accepts expired statuses every other
+// status can move to.
+func ShouldExpire(from, expired queries.WarehouseItemStatusEnum) bool {
+	switch from {
+	case queries.WarehouseItemStatusEnumONLOAN, queries.WarehouseItemStatusEnumDISPOSED, expired:
+		return false
+	}
+	return true
+}
+
+// ExpiredInventoryProcessor moves inventory past its expiration date to the
+// status the workspace chose (inventory.Settings.ExpiredStatus), so expired
+// consumables stop counting as available stock. Workspaces without an
+// expired status are skipped. Each transition is written to the activity log
+// and published as inventory.updated.
+type ExpiredInventoryProcessor struct {
+	pool        *pgxpool.Pool
+	broadcaster *events.Broadcaster
+}
+
+// NewExpiredInventoryProcessor creates a new expired inventory processor.
+// broadcaster may be nil.
+func NewExpiredInventoryProcessor(pool *pgxpool.Pool, broadcaster *events.Broadcaster) *ExpiredInventoryProcessor {
+	return &ExpiredInventoryProcessor{
+		pool:        pool,
+		broadcaster: broadcaster,
+	}
+}
+
+// ProcessTask handles the daily expired inventory task.
+func (p *ExpiredInventoryProcessor) ProcessTask(ctx context.Context, t *asynq.Task) error {
+	_, err := p.ExpireInventory(ctx, time.Now())
+	return err
+}
+
+// ExpireInventory transitions every opted-in workspace's expired inventory
+// and returns how many entries changed status.
+func (p *ExpiredInventoryProcessor) ExpireInventory(ctx context.Context, now time.Time) (int, error) {
+	q := queries.New(p.pool)
+
+	settings, err := q.ListExpiredStatusSettings(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list expiry settings: %w", err)
+	}
+
+	cutoff := pgtype.Date{Time: ExpiryCutoff(now), Valid: true}
+
+	expired := 0
+	for _, ws := range settings {
+		target := ws.ExpiredStatus.WarehouseItemStatusEnum
+		rows, err := q.ListInventoryExpired(ctx, queries.ListInventoryExpiredParams{
+			WorkspaceID:    ws.WorkspaceID,
+			ExpirationDate: cutoff,
+		})
+		if err != nil {
+			log.Printf("Failed to list expired inventory for workspace %s: %v", ws.WorkspaceID, err)
+			continue
+		}
+		for _, row := range rows {
+			from := queries.WarehouseItemStatusEnumAVAILABLE
+			if row.Status.Valid {
+				from = row.Status.WarehouseItemStatusEnum
+			}
+			if !ShouldExpire(from, target) {
+				continue
+			}
+
+			changed, err := p.expire(ctx, row, from, target)
+			if err != nil {
+				log.Printf("Failed to expire inventory %s: %v", row.ID, err)
+				continue
+			}
+			if !changed {
+				continue // status changed since the listing (e.g. loaned out)
+			}
+			expired++
+
+			log.Printf("Inventory %s (%s) expired on %s: status %s -> %s",
+				row.ID, row.ItemName, row.ExpirationDate.Time.Format("2006-01-02"), from, target)
+			p.publish(row, from, target)
+		}
+	}
+
+	log.Printf("Expired %d inventory entries across %d workspaces", expired, len(settings))
+	return expired, nil
+}
+
+// expire updates the entry's status and records it in the activity log in
+// one transaction. It reports false when the entry was no longer in status
+// from.
+func (p *ExpiredInventoryProcessor) expire(ctx context.Context, row queries.ListInventoryExpiredRow, from, target queries.WarehouseItemStatusEnum) (bool, error) {
+	changed := false
+	err := pgx.BeginFunc(ctx, p.pool, func(tx pgx.Tx) error {
+		q := queries.New(tx)
+		n, err := q.ExpireInventory(ctx, queries.ExpireInventoryParams{
+			ExpiredStatus: queries.NullWarehouseItemStatusEnum{WarehouseItemStatusEnum: target, Valid: true},
+			ID:            row.ID,
+			WorkspaceID:   row.WorkspaceID,
+			Status:        queries.NullWarehouseItemStatusEnum{WarehouseItemStatusEnum: from, Valid: true},
+		})
+		if err != nil || n == 0 {
+			return err
+		}
+		changed = true
+
+		changes, _ := json.Marshal(map[string]any{
+			"status": map[string]any{"old": from, "new": target},
+		})
+		metadata, _ := json.Marshal(map[string]any{
+			"reason":          "expired",
+			"expiration_date": row.ExpirationDate.Time.Format("2006-01-02"),
+		})
+		name := row.ItemName
+		_, err = q.CreateActivityLog(ctx, queries.CreateActivityLogParams{
+			ID:          uuid.New(),
+			WorkspaceID: row.WorkspaceID,
+			Action:      queries.WarehouseActivityActionEnumUPDATE,
+			EntityType:  queries.WarehouseActivityEntityEnumINVENTORY,
+			EntityID:    row.ID,
+			EntityName:  &name,
+			Changes:     changes,
+			Metadata:    metadata,
+		})
+		return err
+	})
+	return changed, err
+}
+
+// publish announces the transition. There is no acting user.
+func (p *ExpiredInventoryProcessor) publish(row queries.ListInventoryExpiredRow, from, target queries.WarehouseItemStatusEnum) {
+	if p.broadcaster == nil {
+		return
+	}
+	p.broadcaster.Publish(row.WorkspaceID, events.Event{
+		Type:       "inventory.updated",
+		EntityID:   row.ID.String(),
+		EntityType: "inventory",
+		Data: map[string]any{
+			"id":              row.ID,
+			"item_id":         row.ItemID,
+			"name":            row.ItemName,
+			"status":          target,
+			"previous_status": from,
+			"reason":          "expired",
+		},
+	})
+}
+
+// NewExpireInventoryTask creates the daily expired inventory task. Used by
+// the periodic scheduler.
+func NewExpireInventoryTask() *asynq.Task {
+	return asynq.NewTask(TypeExpireInventory, nil)
+}
@@ -0,0 +1,90 @@
+//go:build integration
+// +build integration
+
+package jobs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setExpiredStatus opts the fixture's workspace in to the expiry status job.
+func (f *expiryFixture) setExpiredStatus(t *testing.T, status string) {
+	t.Helper()
+	_, err := f.pool.Exec(context.Background(), `
+		INSERT INTO warehouse.inventory_settings (workspace_id, expired_status)
+		VALUES ($1, $2)
+	`, f.workspaceID, status)
+	require.NoError(t, err)
+}
+
+func (f *expiryFixture) setStatus(t *testing.T, inventoryID uuid.UUID, status string) {
+	t.Helper()
+	_, err := f.pool.Exec(context.Background(),
+		`UPDATE warehouse.inventory SET status = $2 WHERE id = $1`, inventoryID, status)
+	require.NoError(t, err)
+}
+
+func (f *expiryFixture) status(t *testing.T, inventoryID uuid.UUID) string {
+	t.Helper()
+	var status string
+	err := f.pool.QueryRow(context.Background(),
+		`SELECT status FROM warehouse.inventory WHERE id = $1`, inventoryID).Scan(&status)
+	require.NoError(t, err)
+	return status
+}
+
+func TestExpiredInventoryProcessor_Integration(t *testing.T) {
+	pool := getTestPoolForExpiry(t)
+	ctx := context.Background()
+
+	f := newExpiryFixture(t, pool)
+	f.setExpiredStatus(t, "DISPOSED")
+	informational := newExpiryFixture(t, pool) // never opted in
+
+	now := time.Now().UTC()
+	justExpired := f.addInventory(t, "Yesterday's Milk", false, datePtr(now.AddDate(0, 0, -1)), nil, false)
+	expiresToday := f.addInventory(t, "Today's Milk", false, datePtr(now), nil, false)
+	expiresTomorrow := f.addInventory(t, "Tomorrow's Milk", false, datePtr(now.AddDate(0, 0, 1)), nil, false)
+	loaned := f.addInventory(t, "Lent Batteries", false, datePtr(now.AddDate(0, 0, -10)), nil, false)
+	f.setStatus(t, loaned, "ON_LOAN")
+	inUse := f.addInventory(t, "Opened Pills", false, datePtr(now.AddDate(0, 0, -3)), nil, false)
+	f.setStatus(t, inUse, "IN_USE")
+	archived := f.addInventory(t, "Archived Yogurt", false, datePtr(now.AddDate(0, 0, -1)), nil, true)
+	untracked := informational.addInventory(t, "Informational Milk", false, datePtr(now.AddDate(0, 0, -1)), nil, false)
+
+	processor := NewExpiredInventoryProcessor(pool, nil)
+	_, err := processor.ExpireInventory(ctx, now)
+	require.NoError(t, err)
+
+	assert.Equal(t, "DISPOSED", f.status(t, justExpired), "expired yesterday")
+	assert.Equal(t, "DISPOSED", f.status(t, inUse), "expired while in use")
+	assert.Equal(t, "AVAILABLE", f.status(t, expiresToday), "still good today")
+	assert.Equal(t, "AVAILABLE", f.status(t, expiresTomorrow))
+	assert.Equal(t, "ON_LOAN", f.status(t, loaned), "loaned entries are left alone")
+	assert.Equal(t, "AVAILABLE", f.status(t, archived))
+	assert.Equal(t, "AVAILABLE", informational.status(t, untracked), "workspace did not opt in")
+
+	var logged int
+	err = pool.QueryRow(ctx, `
+		SELECT COUNT(*) FROM warehouse.activity_log
+		WHERE workspace_id = $1 AND entity_type = 'INVENTORY' AND action = 'UPDATE'
+		  AND changes -> 'status' ->> 'new' = 'DISPOSED'
+	`, f.workspaceID).Scan(&logged)
+	require.NoError(t, err)
+	assert.Equal(t, 2, logged, "each transition is logged")
+
+	// A second run finds nothing left to do.
+	_, err = processor.ExpireInventory(ctx, now)
+	require.NoError(t, err)
+	err = pool.QueryRow(ctx, `
+		SELECT COUNT(*) FROM warehouse.activity_log WHERE workspace_id = $1 AND entity_type = 'INVENTORY'
+	`, f.workspaceID).Scan(&logged)
+	require.NoError(t, err)
+	assert.Equal(t, 2, logged)
+}
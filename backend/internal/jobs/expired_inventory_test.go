@@ -0,0 +1,70 @@
+package jobs
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/antti/home-warehouse/go-backend/internal/infra/queries"
+)
+
+func TestExpiryCutoff(t *testing.T) {
+	now := time.Date(2026, 3, 15, 0, 30, 0, 0, time.UTC)
+	cutoff := ExpiryCutoff(now)
+
+	assert.Equal(t, time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC), cutoff)
+
+	// The job lists entries whose expiration date is before the cutoff.
+	tests := []struct {
+		name    string
+		date    time.Time
+		expired bool
+	}{
+		{"expired yesterday", time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC), true},
+		{"expires today", time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC), false},
+		{"expires tomorrow", time.Date(2026, 3, 16, 0, 0, 0, 0, time.UTC), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expired, tt.date.Before(cutoff))
+		})
+	}
+}
+
+func TestExpiryCutoff_UsesUTCDay(t *testing.T) {
+	helsinki := time.FixedZone("EET", 2*60*60)
+	// 01:00 in Helsinki is still the previous day in UTC.
+	now := time.Date(2026, 3, 15, 1, 0, 0, 0, helsinki)
+
+	assert.Equal(t, time.Date(2026, 3, 14, 0, 0, 0, 0, time.UTC), ExpiryCutoff(now))
+}
+
+func TestShouldExpire(t *testing.T) {
+	disposed := queries.WarehouseItemStatusEnumDISPOSED
+	tests := []struct {
+		from queries.WarehouseItemStatusEnum
+		want bool
+	}{
+		{queries.WarehouseItemStatusEnumAVAILABLE, true},
+		{queries.WarehouseItemStatusEnumINUSE, true},
+		{queries.WarehouseItemStatusEnumRESERVED, true},
+		{queries.WarehouseItemStatusEnumINTRANSIT, true},
+		{queries.WarehouseItemStatusEnumMISSING, true},
+		{queries.WarehouseItemStatusEnumONLOAN, false},
+		{queries.WarehouseItemStatusEnumDISPOSED, false},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.from), func(t *testing.T) {
+			assert.Equal(t, tt.want, ShouldExpire(tt.from, disposed))
+		})
+	}
+
+	t.Run("already in the expired status", func(t *testing.T) {
+		assert.False(t, ShouldExpire(queries.WarehouseItemStatusEnumMISSING, queries.WarehouseItemStatusEnumMISSING))
+	})
+}
+
+func TestNewExpireInventoryTask(t *testing.T) {
+	assert.Equal(t, TypeExpireInventory, NewExpireInventoryTask().Type())
+}
@@ -259,18 +259,25 @@ func prefEnabled(raw []byte, key string) bool {
 // per row+kind. The processor performs the per-user dedupe, so running this
 // daily is safe.
 type ExpiryReminderScheduler struct {
-	pool   *pgxpool.Pool
-	client *asynq.Client
+	pool     *pgxpool.Pool
+	client   *asynq.Client
+	maxRetry int
 }
 
 // NewExpiryReminderScheduler creates a new expiry reminder scheduler.
 func NewExpiryReminderScheduler(pool *pgxpool.Pool, client *asynq.Client) *ExpiryReminderScheduler {
 	return &ExpiryReminderScheduler{
-		pool:   pool,
-		client: client,
+		pool:     pool,
+		client:   client,
+		maxRetry: defaultReminderMaxRetry,
 	}
 }
 
+// SetMaxRetry sets how many times each enqueued reminder task is retried.
+func (s *ExpiryReminderScheduler) SetMaxRetry(n int) {
+	s.maxRetry = n
+}
+
 // ScheduleReminders finds inventory needing expiry/warranty reminders and
 // enqueues tasks, iterating per workspace (the queries are workspace-scoped).
 func (s *ExpiryReminderScheduler) ScheduleReminders(ctx context.Context) error {
@@ -354,7 +361,7 @@ func (s *ExpiryReminderScheduler) enqueueIfInWindow(payload ExpiryReminderPayloa
 	task := asynq.NewTask(TypeExpiryReminder, payloadBytes)
 	if _, err := s.client.Enqueue(task,
 		asynq.Queue(QueueDefault),
-		asynq.MaxRetry(3),
+		asynq.MaxRetry(s.maxRetry),
 		asynq.Timeout(30*time.Second),
 	); err != nil {
 		log.Printf("Failed to enqueue expiry reminder for inventory %s: %v", payload.InventoryID, err)
@@ -8,7 +8,12 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/borrower"
+	"github.com/antti/home-warehouse/go-backend/internal/infra/queries"
 )
 
 // =============================================================================
@@ -340,3 +345,82 @@ func TestScheduler_RegisterHandlers_WithCustomCleanupConfigInternal(t *testing.T
 
 	assert.NotNil(t, mux)
 }
+
+// fakeReminderStore serves the loan and repair reminder queries from memory.
+type fakeReminderStore struct {
+	loans   []queries.ListLoansNeedingReminderRow
+	repairs []queries.ListRepairsNeedingReminderRow
+}
+
+func (f *fakeReminderStore) ListLoansNeedingReminder(ctx context.Context, dueDate pgtype.Date) ([]queries.ListLoansNeedingReminderRow, error) {
+	return f.loans, nil
+}
+
+func (f *fakeReminderStore) ListRepairsNeedingReminder(ctx context.Context, reminderDate pgtype.Date) ([]queries.ListRepairsNeedingReminderRow, error) {
+	return f.repairs, nil
+}
+
+// recordingEnqueuer records enqueued tasks instead of talking to Redis.
+type recordingEnqueuer struct {
+	tasks []*asynq.Task
+}
+
+func (r *recordingEnqueuer) Enqueue(task *asynq.Task, opts ...asynq.Option) (*asynq.TaskInfo, error) {
+	r.tasks = append(r.tasks, task)
+	return &asynq.TaskInfo{Type: task.Type()}, nil
+}
+
+// newTestMuxScheduler returns a scheduler whose reminder schedulers read
+// store and enqueue into enqueuer.
+func newTestMuxScheduler(store *fakeReminderStore, enqueuer *recordingEnqueuer) *Scheduler {
+	scheduler := NewScheduler(nil, DefaultSchedulerConfig("localhost:6379"))
+	scheduler.reminders = store
+	scheduler.enqueuer = enqueuer
+	return scheduler
+}
+
+func TestScheduler_RegisterHandlers_ScheduleTasksReachSchedulers(t *testing.T) {
+	store := &fakeReminderStore{
+		loans: []queries.ListLoansNeedingReminderRow{{
+			ID:                      uuid.New(),
+			WorkspaceID:             uuid.New(),
+			DueDate:                 pgtype.Date{Time: time.Now().AddDate(0, 0, 1), Valid: true},
+			BorrowerName:            "Jane",
+			BorrowerEmail:           ptrString("jane@example.com"),
+			BorrowerReminderChannel: string(borrower.ReminderChannelEmail),
+			ItemName:                "Ladder",
+		}},
+		repairs: []queries.ListRepairsNeedingReminderRow{{
+			ID:           uuid.New(),
+			WorkspaceID:  uuid.New(),
+			InventoryID:  uuid.New(),
+			Description:  "Replace chain",
+			ReminderDate: pgtype.Date{Time: time.Now(), Valid: true},
+			ItemName:     "Bike",
+		}},
+	}
+
+	tests := []struct {
+		name     string
+		task     *asynq.Task
+		wantType string
+	}{
+		{"loan reminders", NewScheduleLoanRemindersTask(), TypeLoanReminder},
+		{"repair reminders", NewScheduleRepairRemindersTask(), TypeRepairReminder},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enqueuer := &recordingEnqueuer{}
+			mux := newTestMuxScheduler(store, enqueuer).RegisterHandlers(&mockEmailSender{}, nil, DefaultCleanupConfig(), nil)
+
+			// The per-row processor would fail to decode the empty payload.
+			err := mux.ProcessTask(context.Background(), tt.task)
+
+			require.NoError(t, err)
+			require.Len(t, enqueuer.tasks, 1)
+			assert.Equal(t, tt.wantType, enqueuer.tasks[0].Type())
+			assert.NotEmpty(t, enqueuer.tasks[0].Payload())
+		})
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/borrower"
 	"github.com/antti/home-warehouse/go-backend/internal/infra/queries"
 	"github.com/antti/home-warehouse/go-backend/internal/infra/webpush"
 )
@@ -25,12 +26,19 @@ type LoanReminderPayload struct {
 	ItemName      string    `json:"item_name"`
 	DueDate       time.Time `json:"due_date"`
 	IsOverdue     bool      `json:"is_overdue"`
+	// Channel is the borrower's reminder channel, "email" or "sms". Tasks
+	// enqueued before channels existed have none and are emailed.
+	Channel borrower.ReminderChannel `json:"channel,omitempty"`
+	// BorrowerPhone is the borrower's phone number in E.164, set for SMS
+	// reminders.
+	BorrowerPhone string `json:"borrower_phone,omitempty"`
 }
 
 // LoanReminderProcessor handles loan reminder tasks.
 type LoanReminderProcessor struct {
 	pool        *pgxpool.Pool
 	emailSender EmailSender
+	smsSender   SMSSender
 	pushSender  *webpush.Sender
 }
 
@@ -39,6 +47,11 @@ type EmailSender interface {
 	SendLoanReminder(ctx context.Context, to, borrowerName, itemName string, dueDate time.Time, isOverdue bool) error
 }
 
+// SMSSender sends text messages; *sms.Sender implements it.
+type SMSSender interface {
+	SendSMS(ctx context.Context, to, body string) error
+}
+
 // NewLoanReminderProcessor creates a new loan reminder processor.
 func NewLoanReminderProcessor(pool *pgxpool.Pool, emailSender EmailSender, pushSender *webpush.Sender) *LoanReminderProcessor {
 	return &LoanReminderProcessor{
@@ -48,6 +61,12 @@ func NewLoanReminderProcessor(pool *pgxpool.Pool, emailSender EmailSender, pushS
 	}
 }
 
+// SetSMSSender enables reminders for borrowers whose reminder channel is
+// SMS. Without it those reminders are skipped.
+func (p *LoanReminderProcessor) SetSMSSender(sender SMSSender) {
+	p.smsSender = sender
+}
+
 // ProcessTask handles the loan reminder task.
 func (p *LoanReminderProcessor) ProcessTask(ctx context.Context, t *asynq.Task) error {
 	var payload LoanReminderPayload
@@ -58,17 +77,26 @@ func (p *LoanReminderProcessor) ProcessTask(ctx context.Context, t *asynq.Task)
 	log.Printf("Processing loan reminder for loan %s, borrower: %s, item: %s",
 		payload.LoanID, payload.BorrowerName, payload.ItemName)
 
-	// Send the reminder email
-	if p.emailSender != nil {
-		if err := p.emailSender.SendLoanReminder(
-			ctx,
-			payload.BorrowerEmail,
-			payload.BorrowerName,
-			payload.ItemName,
-			payload.DueDate,
-			payload.IsOverdue,
-		); err != nil {
-			return fmt.Errorf("failed to send loan reminder email: %w", err)
+	// Remind the borrower on their one channel
+	switch payload.Channel {
+	case borrower.ReminderChannelSMS:
+		if p.smsSender == nil {
+			log.Printf("SMS not configured, skipping SMS reminder for loan %s", payload.LoanID)
+		} else if err := p.smsSender.SendSMS(ctx, payload.BorrowerPhone, loanReminderSMS(payload)); err != nil {
+			return fmt.Errorf("failed to send loan reminder sms: %w", err)
+		}
+	default:
+		if p.emailSender != nil {
+			if err := p.emailSender.SendLoanReminder(
+				ctx,
+				payload.BorrowerEmail,
+				payload.BorrowerName,
+				payload.ItemName,
+				payload.DueDate,
+				payload.IsOverdue,
+			); err != nil {
+				return fmt.Errorf("failed to send loan reminder email: %w", err)
+			}
 		}
 	}
 
@@ -84,6 +112,22 @@ func (p *LoanReminderProcessor) ProcessTask(ctx context.Context, t *asynq.Task)
 	return nil
 }
 
+// maxSMSItemName keeps a reminder within a single 160-character SMS.
+const maxSMSItemName = 80
+
+// loanReminderSMS is the text of an SMS loan reminder.
+func loanReminderSMS(payload LoanReminderPayload) string {
+	item := []rune(payload.ItemName)
+	if len(item) > maxSMSItemName {
+		item = append(item[:maxSMSItemName-1], '…')
+	}
+	due := payload.DueDate.Format("Jan 2")
+	if payload.IsOverdue {
+		return fmt.Sprintf("Reminder: %s was due back on %s. Please return it as soon as you can.", string(item), due)
+	}
+	return fmt.Sprintf("Reminder: %s is due back on %s.", string(item), due)
+}
+
 // sendPushNotifications sends push notifications to workspace admins/owners about the loan.
 func (p *LoanReminderProcessor) sendPushNotifications(ctx context.Context, payload LoanReminderPayload) error {
 	q := queries.New(p.pool)
@@ -138,31 +182,53 @@ func (p *LoanReminderProcessor) sendPushNotifications(ctx context.Context, paylo
 	return p.pushSender.SendToUsers(ctx, userIDs, message)
 }
 
+// loanReminderStore is the query that finds loans needing reminders, split
+// out so tests don't need a database.
+type loanReminderStore interface {
+	ListLoansNeedingReminder(ctx context.Context, dueDate pgtype.Date) ([]queries.ListLoansNeedingReminderRow, error)
+}
+
 // LoanReminderScheduler schedules loan reminder tasks.
 type LoanReminderScheduler struct {
-	pool   *pgxpool.Pool
-	client *asynq.Client
+	loans       loanReminderStore
+	client      Enqueuer
+	maxRetry    int
+	phoneRegion string
 }
 
 // NewLoanReminderScheduler creates a new loan reminder scheduler.
 func NewLoanReminderScheduler(pool *pgxpool.Pool, client *asynq.Client) *LoanReminderScheduler {
+	return newLoanReminderScheduler(queries.New(pool), client)
+}
+
+func newLoanReminderScheduler(loans loanReminderStore, client Enqueuer) *LoanReminderScheduler {
 	return &LoanReminderScheduler{
-		pool:   pool,
-		client: client,
+		loans:    loans,
+		client:   client,
+		maxRetry: defaultReminderMaxRetry,
 	}
 }
 
+// SetMaxRetry sets how many times each enqueued reminder task is retried.
+func (s *LoanReminderScheduler) SetMaxRetry(n int) {
+	s.maxRetry = n
+}
+
+// SetPhoneRegion sets the region borrower phone numbers without a country
+// code are read in before SMS reminders are sent to them.
+func (s *LoanReminderScheduler) SetPhoneRegion(region string) {
+	s.phoneRegion = region
+}
+
 // ScheduleReminders finds loans needing reminders and enqueues tasks.
 func (s *LoanReminderScheduler) ScheduleReminders(ctx context.Context) error {
-	q := queries.New(s.pool)
-
 	// Find loans due within the next 3 days (including overdue)
 	reminderDate := time.Now().AddDate(0, 0, 3)
 	var pgDate pgtype.Date
 	pgDate.Time = reminderDate
 	pgDate.Valid = true
 
-	loans, err := q.ListLoansNeedingReminder(ctx, pgDate)
+	loans, err := s.loans.ListLoansNeedingReminder(ctx, pgDate)
 	if err != nil {
 		return fmt.Errorf("failed to list loans needing reminder: %w", err)
 	}
@@ -171,23 +237,21 @@ func (s *LoanReminderScheduler) ScheduleReminders(ctx context.Context) error {
 
 	now := time.Now()
 	for _, loan := range loans {
-		if loan.BorrowerEmail == nil || *loan.BorrowerEmail == "" {
-			continue
-		}
-
 		var dueDate time.Time
 		if loan.DueDate.Valid {
 			dueDate = loan.DueDate.Time
 		}
 
 		payload := LoanReminderPayload{
-			LoanID:        loan.ID,
-			WorkspaceID:   loan.WorkspaceID,
-			BorrowerName:  loan.BorrowerName,
-			BorrowerEmail: *loan.BorrowerEmail,
-			ItemName:      loan.ItemName,
-			DueDate:       dueDate,
-			IsOverdue:     dueDate.Before(now),
+			LoanID:       loan.ID,
+			WorkspaceID:  loan.WorkspaceID,
+			BorrowerName: loan.BorrowerName,
+			ItemName:     loan.ItemName,
+			DueDate:      dueDate,
+			IsOverdue:    loanReminderIsOverdue(dueDate, int(loan.OverdueGraceDays), now),
+		}
+		if !s.addReminderRecipient(&payload, loan) {
+			continue
 		}
 
 		payloadBytes, err := json.Marshal(payload)
@@ -199,7 +263,7 @@ func (s *LoanReminderScheduler) ScheduleReminders(ctx context.Context) error {
 		task := asynq.NewTask(TypeLoanReminder, payloadBytes)
 		_, err = s.client.Enqueue(task,
 			asynq.Queue(QueueDefault),
-			asynq.MaxRetry(3),
+			asynq.MaxRetry(s.maxRetry),
 			asynq.Timeout(30*time.Second),
 		)
 		if err != nil {
@@ -213,6 +277,43 @@ func (s *LoanReminderScheduler) ScheduleReminders(ctx context.Context) error {
 	return nil
 }
 
+// addReminderRecipient fills in where payload is delivered from the
+// borrower's reminder channel, reporting false when the borrower cannot be
+// reached on it. SMS numbers are normalized to E.164 the same way the
+// borrower service does; numbers stored before that validation existed may
+// not be.
+func (s *LoanReminderScheduler) addReminderRecipient(payload *LoanReminderPayload, loan queries.ListLoansNeedingReminderRow) bool {
+	switch borrower.ReminderChannel(loan.BorrowerReminderChannel) {
+	case borrower.ReminderChannelSMS:
+		if loan.BorrowerPhone == nil {
+			return false
+		}
+		phone, err := borrower.NormalizePhone(*loan.BorrowerPhone, s.phoneRegion)
+		if err != nil {
+			log.Printf("Skipping SMS reminder for loan %s: %v", loan.ID, err)
+			return false
+		}
+		payload.Channel = borrower.ReminderChannelSMS
+		payload.BorrowerPhone = phone
+	case borrower.ReminderChannelEmail:
+		if loan.BorrowerEmail == nil || *loan.BorrowerEmail == "" {
+			return false
+		}
+		payload.Channel = borrower.ReminderChannelEmail
+		payload.BorrowerEmail = *loan.BorrowerEmail
+	default:
+		return false
+	}
+	return true
+}
+
+// loanReminderIsOverdue reports whether a reminder for a loan due on dueDate
+// should say overdue rather than due soon. Loans within the workspace grace
+// period, including on its last day, still get a due-soon reminder.
+func loanReminderIsOverdue(dueDate time.Time, graceDays int, now time.Time) bool {
+	return now.After(dueDate.AddDate(0, 0, graceDays))
+}
+
 // NewScheduleLoanRemindersTask creates a task that schedules all loan reminders.
 // This is used by the scheduler to periodically check for loans needing reminders.
 func NewScheduleLoanRemindersTask() *asynq.Task {
@@ -10,8 +10,12 @@ import (
 
 	"github.com/google/uuid"
 	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/borrower"
+	"github.com/antti/home-warehouse/go-backend/internal/infra/queries"
 )
 
 // =============================================================================
@@ -65,6 +69,24 @@ func (c *testContextAwareEmailSender) SendLoanReminder(ctx context.Context, to,
 	}
 }
 
+// testTrackingSMSSender records every SMS sent
+type testTrackingSMSSender struct {
+	sent      []testTrackedSMS
+	sendError error
+}
+
+type testTrackedSMS struct {
+	to   string
+	body string
+}
+
+func (s *testTrackingSMSSender) SendSMS(ctx context.Context, to, body string) error {
+	s.sent = append(s.sent, testTrackedSMS{to: to, body: body})
+	return s.sendError
+}
+
+func ptrString(s string) *string { return &s }
+
 // =============================================================================
 // LoanReminderPayload Tests
 // =============================================================================
@@ -258,6 +280,66 @@ func TestLoanReminderPayload_OverdueFlag(t *testing.T) {
 	}
 }
 
+func TestLoanReminderIsOverdue(t *testing.T) {
+	due := time.Date(2026, 5, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		now       time.Time
+		graceDays int
+		want      bool
+	}{
+		{"before due date", due.AddDate(0, 0, -1), 2, false},
+		{"at due date", due, 2, false},
+		{"exactly at grace", due.AddDate(0, 0, 2), 2, false},
+		{"one day past grace", due.AddDate(0, 0, 3), 2, true},
+		{"no grace, one day past due", due.AddDate(0, 0, 1), 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, loanReminderIsOverdue(due, tt.graceDays, tt.now))
+		})
+	}
+}
+
+func TestScheduler_ScheduledLoanRemindersHonorGracePeriod(t *testing.T) {
+	dueDaysAgo := func(days int) pgtype.Date {
+		return pgtype.Date{Time: time.Now().AddDate(0, 0, -days), Valid: true}
+	}
+	loan := func(email string, due pgtype.Date, graceDays int32) queries.ListLoansNeedingReminderRow {
+		return queries.ListLoansNeedingReminderRow{
+			ID:                      uuid.New(),
+			WorkspaceID:             uuid.New(),
+			DueDate:                 due,
+			BorrowerName:            "Jane",
+			BorrowerEmail:           ptrString(email),
+			BorrowerReminderChannel: string(borrower.ReminderChannelEmail),
+			ItemName:                "Ladder",
+			OverdueGraceDays:        graceDays,
+		}
+	}
+	scheduler := NewScheduler(nil, DefaultSchedulerConfig("localhost:6379"))
+	scheduler.reminders = &fakeReminderStore{loans: []queries.ListLoansNeedingReminderRow{
+		loan("within-grace@example.com", dueDaysAgo(2), 3),
+		loan("past-grace@example.com", dueDaysAgo(5), 3),
+		loan("no-grace@example.com", dueDaysAgo(2), 0),
+	}}
+	emailSender := &testTrackingEmailSender{}
+
+	runScheduledLoanReminders(t, scheduler, emailSender)
+
+	overdue := map[string]bool{}
+	for _, email := range emailSender.sentEmails {
+		overdue[email.to] = email.isOverdue
+	}
+	assert.Equal(t, map[string]bool{
+		"within-grace@example.com": false,
+		"past-grace@example.com":   true,
+		"no-grace@example.com":     true,
+	}, overdue)
+}
+
 func TestLoanReminderPayload_FutureDates(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -430,6 +512,219 @@ func TestLoanReminderProcessor_ProcessTask_NilEmailSender(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestLoanReminderProcessor_ProcessTask_SMS(t *testing.T) {
+	emailSender := &testTrackingEmailSender{}
+	smsSender := &testTrackingSMSSender{}
+	processor := NewLoanReminderProcessor(nil, emailSender, nil)
+	processor.SetSMSSender(smsSender)
+
+	payload := LoanReminderPayload{
+		LoanID:        uuid.New(),
+		WorkspaceID:   uuid.New(),
+		BorrowerName:  "John Doe",
+		BorrowerEmail: "john@example.com",
+		BorrowerPhone: "+358401234567",
+		Channel:       borrower.ReminderChannelSMS,
+		ItemName:      "Power Drill",
+		DueDate:       time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC),
+	}
+	payloadBytes, err := json.Marshal(payload)
+	require.NoError(t, err)
+
+	err = processor.ProcessTask(context.Background(), asynq.NewTask(TypeLoanReminder, payloadBytes))
+	require.NoError(t, err)
+
+	require.Len(t, smsSender.sent, 1)
+	assert.Equal(t, "+358401234567", smsSender.sent[0].to)
+	assert.Equal(t, "Reminder: Power Drill is due back on Mar 3.", smsSender.sent[0].body)
+	assert.Empty(t, emailSender.sentEmails, "an SMS borrower must not also be emailed")
+}
+
+func TestLoanReminderProcessor_ProcessTask_SMSNotConfigured(t *testing.T) {
+	emailSender := &testTrackingEmailSender{}
+	processor := NewLoanReminderProcessor(nil, emailSender, nil)
+
+	payload := LoanReminderPayload{
+		LoanID:        uuid.New(),
+		WorkspaceID:   uuid.New(),
+		BorrowerName:  "John Doe",
+		BorrowerPhone: "+358401234567",
+		Channel:       borrower.ReminderChannelSMS,
+		ItemName:      "Power Drill",
+		DueDate:       time.Now().Add(24 * time.Hour),
+	}
+	payloadBytes, err := json.Marshal(payload)
+	require.NoError(t, err)
+
+	err = processor.ProcessTask(context.Background(), asynq.NewTask(TypeLoanReminder, payloadBytes))
+
+	assert.NoError(t, err)
+	assert.Empty(t, emailSender.sentEmails)
+}
+
+func TestLoanReminderProcessor_ProcessTask_SMSSenderError(t *testing.T) {
+	processor := NewLoanReminderProcessor(nil, nil, nil)
+	processor.SetSMSSender(&testTrackingSMSSender{sendError: errors.New("twilio unavailable")})
+
+	payloadBytes, err := json.Marshal(LoanReminderPayload{
+		LoanID:        uuid.New(),
+		BorrowerPhone: "+358401234567",
+		Channel:       borrower.ReminderChannelSMS,
+		ItemName:      "Power Drill",
+	})
+	require.NoError(t, err)
+
+	err = processor.ProcessTask(context.Background(), asynq.NewTask(TypeLoanReminder, payloadBytes))
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "failed to send loan reminder sms")
+	assert.Contains(t, err.Error(), "twilio unavailable")
+}
+
+func TestLoanReminderProcessor_ProcessTask_EmailChannelSkipsSMS(t *testing.T) {
+	emailSender := &testTrackingEmailSender{}
+	smsSender := &testTrackingSMSSender{}
+	processor := NewLoanReminderProcessor(nil, emailSender, nil)
+	processor.SetSMSSender(smsSender)
+
+	payloadBytes, err := json.Marshal(LoanReminderPayload{
+		LoanID:        uuid.New(),
+		BorrowerName:  "John Doe",
+		BorrowerEmail: "john@example.com",
+		BorrowerPhone: "+358401234567",
+		Channel:       borrower.ReminderChannelEmail,
+		ItemName:      "Power Drill",
+	})
+	require.NoError(t, err)
+
+	err = processor.ProcessTask(context.Background(), asynq.NewTask(TypeLoanReminder, payloadBytes))
+
+	require.NoError(t, err)
+	assert.Len(t, emailSender.sentEmails, 1)
+	assert.Empty(t, smsSender.sent)
+}
+
+func TestLoanReminderSMS(t *testing.T) {
+	due := time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC)
+
+	t.Run("due soon", func(t *testing.T) {
+		body := loanReminderSMS(LoanReminderPayload{ItemName: "Power Drill", DueDate: due})
+		assert.Equal(t, "Reminder: Power Drill is due back on Mar 3.", body)
+	})
+
+	t.Run("overdue", func(t *testing.T) {
+		body := loanReminderSMS(LoanReminderPayload{ItemName: "Power Drill", DueDate: due, IsOverdue: true})
+		assert.Equal(t, "Reminder: Power Drill was due back on Mar 3. Please return it as soon as you can.", body)
+	})
+
+	t.Run("long item names fit one message", func(t *testing.T) {
+		body := loanReminderSMS(LoanReminderPayload{ItemName: strings.Repeat("Ä", 300), DueDate: due, IsOverdue: true})
+		assert.LessOrEqual(t, len([]rune(body)), 160)
+		assert.Contains(t, body, "…")
+	})
+}
+
+func TestLoanReminderScheduler_AddReminderRecipient(t *testing.T) {
+	s := NewLoanReminderScheduler(nil, nil)
+	s.SetPhoneRegion("FI")
+
+	tests := []struct {
+		name      string
+		loan      queries.ListLoansNeedingReminderRow
+		wantOK    bool
+		wantEmail string
+		wantPhone string
+		wantChan  borrower.ReminderChannel
+	}{
+		{
+			name:      "email borrower",
+			loan:      queries.ListLoansNeedingReminderRow{BorrowerReminderChannel: "email", BorrowerEmail: ptrString("john@example.com"), BorrowerPhone: ptrString("+358401234567")},
+			wantOK:    true,
+			wantEmail: "john@example.com",
+			wantChan:  borrower.ReminderChannelEmail,
+		},
+		{
+			name:      "sms borrower with national number",
+			loan:      queries.ListLoansNeedingReminderRow{BorrowerReminderChannel: "sms", BorrowerEmail: ptrString("john@example.com"), BorrowerPhone: ptrString("040 123 4567")},
+			wantOK:    true,
+			wantPhone: "+358401234567",
+			wantChan:  borrower.ReminderChannelSMS,
+		},
+		{
+			name: "sms borrower with invalid phone",
+			loan: queries.ListLoansNeedingReminderRow{BorrowerReminderChannel: "sms", BorrowerPhone: ptrString("call the office")},
+		},
+		{
+			name: "sms borrower without phone",
+			loan: queries.ListLoansNeedingReminderRow{BorrowerReminderChannel: "sms", BorrowerEmail: ptrString("john@example.com")},
+		},
+		{
+			name: "email borrower without email",
+			loan: queries.ListLoansNeedingReminderRow{BorrowerReminderChannel: "email", BorrowerPhone: ptrString("+358401234567")},
+		},
+		{
+			name: "borrower opted out",
+			loan: queries.ListLoansNeedingReminderRow{BorrowerReminderChannel: "none", BorrowerEmail: ptrString("john@example.com")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var payload LoanReminderPayload
+			ok := s.addReminderRecipient(&payload, tt.loan)
+
+			assert.Equal(t, tt.wantOK, ok)
+			if tt.wantOK {
+				assert.Equal(t, tt.wantChan, payload.Channel)
+				assert.Equal(t, tt.wantEmail, payload.BorrowerEmail)
+				assert.Equal(t, tt.wantPhone, payload.BorrowerPhone)
+			}
+		})
+	}
+}
+
+// runScheduledLoanReminders runs the loan reminder schedule task through the
+// registered handlers, as the cron does, then processes every reminder task
+// it enqueued.
+func runScheduledLoanReminders(t *testing.T, scheduler *Scheduler, emailSender EmailSender) {
+	t.Helper()
+	enqueuer := &recordingEnqueuer{}
+	scheduler.enqueuer = enqueuer
+	mux := scheduler.RegisterHandlers(emailSender, nil, DefaultCleanupConfig(), nil)
+
+	require.NoError(t, mux.ProcessTask(context.Background(), NewScheduleLoanRemindersTask()))
+	for _, task := range enqueuer.tasks {
+		require.NoError(t, mux.ProcessTask(context.Background(), task))
+	}
+}
+
+func TestScheduler_ScheduledLoanRemindersSendSMS(t *testing.T) {
+	store := &fakeReminderStore{loans: []queries.ListLoansNeedingReminderRow{{
+		ID:                      uuid.New(),
+		WorkspaceID:             uuid.New(),
+		DueDate:                 pgtype.Date{Time: time.Date(2026, 3, 3, 0, 0, 0, 0, time.UTC), Valid: true},
+		BorrowerName:            "John Doe",
+		BorrowerEmail:           ptrString("john@example.com"),
+		BorrowerPhone:           ptrString("040 123 4567"),
+		BorrowerReminderChannel: string(borrower.ReminderChannelSMS),
+		ItemName:                "Power Drill",
+	}}}
+	config := DefaultSchedulerConfig("localhost:6379")
+	config.PhoneRegion = "FI"
+	scheduler := NewScheduler(nil, config)
+	scheduler.reminders = store
+	smsSender := &testTrackingSMSSender{}
+	scheduler.SetSMSSender(smsSender)
+	emailSender := &testTrackingEmailSender{}
+
+	runScheduledLoanReminders(t, scheduler, emailSender)
+
+	require.Len(t, smsSender.sent, 1)
+	assert.Equal(t, "+358401234567", smsSender.sent[0].to)
+	assert.Contains(t, smsSender.sent[0].body, "Power Drill")
+	assert.Empty(t, emailSender.sentEmails)
+}
+
 func TestLoanReminderProcessor_ProcessTask_InvalidPayload(t *testing.T) {
 	processor := NewLoanReminderProcessor(nil, nil, nil)
 
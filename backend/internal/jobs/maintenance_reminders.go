@@ -196,18 +196,25 @@ func maintenanceMessage(payload MaintenanceReminderPayload) (title, body string)
 // within the reminder window (or overdue) and enqueues one task per schedule.
 // The processor performs the per-user dedupe, so running this daily is safe.
 type MaintenanceReminderScheduler struct {
-	pool   *pgxpool.Pool
-	client *asynq.Client
+	pool     *pgxpool.Pool
+	client   *asynq.Client
+	maxRetry int
 }
 
 // NewMaintenanceReminderScheduler creates a new maintenance reminder scheduler.
 func NewMaintenanceReminderScheduler(pool *pgxpool.Pool, client *asynq.Client) *MaintenanceReminderScheduler {
 	return &MaintenanceReminderScheduler{
-		pool:   pool,
-		client: client,
+		pool:     pool,
+		client:   client,
+		maxRetry: defaultReminderMaxRetry,
 	}
 }
 
+// SetMaxRetry sets how many times each enqueued reminder task is retried.
+func (s *MaintenanceReminderScheduler) SetMaxRetry(n int) {
+	s.maxRetry = n
+}
+
 // ScheduleReminders finds schedules needing reminders and enqueues tasks,
 // iterating per workspace (the query is workspace-scoped).
 func (s *MaintenanceReminderScheduler) ScheduleReminders(ctx context.Context) error {
@@ -253,7 +260,7 @@ func (s *MaintenanceReminderScheduler) ScheduleReminders(ctx context.Context) er
 			task := asynq.NewTask(TypeMaintenanceReminder, payloadBytes)
 			if _, err := s.client.Enqueue(task,
 				asynq.Queue(QueueDefault),
-				asynq.MaxRetry(3),
+				asynq.MaxRetry(s.maxRetry),
 				asynq.Timeout(30*time.Second),
 			); err != nil {
 				log.Printf("Failed to enqueue maintenance reminder for schedule %s: %v", row.ID, err)
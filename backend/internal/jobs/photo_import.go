@@ -0,0 +1,221 @@
+package jobs
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/importjob"
+	"github.com/antti/home-warehouse/go-backend/internal/infra/urlfetch"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// photoImportMaxRetry is how many times a failed download is retried before
+// the photo is reported as failed on its import job.
+const photoImportMaxRetry = 2
+
+// PhotoImportPayload contains data for downloading one photo_url cell of an
+// item import.
+type PhotoImportPayload struct {
+	ResultID    uuid.UUID `json:"result_id"`
+	ImportJobID uuid.UUID `json:"import_job_id"`
+	WorkspaceID uuid.UUID `json:"workspace_id"`
+	ItemID      uuid.UUID `json:"item_id"`
+	UserID      uuid.UUID `json:"user_id"`
+	URL         string    `json:"url"`
+	// PrimaryPhoto is the job's importjob.PrimaryPhotoPreference; empty in
+	// tasks queued before the option existed, which means "first".
+	PrimaryPhoto string `json:"primary_photo,omitempty"`
+}
+
+// NewPhotoImportTask creates a task that attaches the photo at payload.URL
+// to the imported item.
+func NewPhotoImportTask(payload PhotoImportPayload) *asynq.Task {
+	data, _ := json.Marshal(payload)
+	return asynq.NewTask(TypePhotoImportURL, data,
+		asynq.MaxRetry(photoImportMaxRetry),
+		asynq.Timeout(2*time.Minute),
+		asynq.Queue(QueueLow),
+	)
+}
+
+// PhotoFromURLUploader downloads rawURL and adds it to the item as a photo,
+// returning the new photo's ID. It is backed by
+// itemphoto.Service.UploadPhotoFromURL, which this package cannot import.
+type PhotoFromURLUploader func(ctx context.Context, workspaceID, itemID, userID uuid.UUID, rawURL string) (uuid.UUID, error)
+
+// PhotoImportResultStore records photo outcomes on the import job. It is the
+// subset of importjob.Repository the processor needs.
+type PhotoImportResultStore interface {
+	UpdatePhotoResult(ctx context.Context, id uuid.UUID, status importjob.PhotoStatus, photoID *uuid.UUID, message *string) error
+	FindPhotoResultsByItem(ctx context.Context, jobID, itemID uuid.UUID) ([]*importjob.ImportPhotoResult, error)
+	MarkPhotoResultPrimary(ctx context.Context, jobID, itemID, resultID uuid.UUID) error
+}
+
+// ImportPrimaryPhotos reads and sets an item's primary photo. It is backed by
+// itemphoto.Service, which this package cannot import.
+type ImportPrimaryPhotos interface {
+	// PrimaryPhotoID returns the item's primary photo, or nil if it has none.
+	PrimaryPhotoID(ctx context.Context, workspaceID, itemID uuid.UUID) (*uuid.UUID, error)
+	// PhotoArea returns the photo's width times height in pixels.
+	PhotoArea(ctx context.Context, workspaceID, photoID uuid.UUID) (int64, error)
+	SetPrimaryPhoto(ctx context.Context, workspaceID, photoID uuid.UUID) error
+}
+
+// PhotoImportProcessor downloads photos queued by the item import worker.
+type PhotoImportProcessor struct {
+	upload  PhotoFromURLUploader
+	results PhotoImportResultStore
+	primary ImportPrimaryPhotos
+	// isFinalAttempt reports whether asynq will not retry this task again.
+	isFinalAttempt func(ctx context.Context) bool
+}
+
+// NewPhotoImportProcessor creates a new photo import processor.
+func NewPhotoImportProcessor(upload PhotoFromURLUploader, results PhotoImportResultStore) *PhotoImportProcessor {
+	return &PhotoImportProcessor{
+		upload:         upload,
+		results:        results,
+		isFinalAttempt: isFinalAttempt,
+	}
+}
+
+// SetPrimaryPhotos enables choosing each imported item's primary photo by
+// the job's primary_photo preference. Without it the item keeps whichever
+// photo finished downloading first, the upload's own primary fallback.
+func (p *PhotoImportProcessor) SetPrimaryPhotos(primary ImportPrimaryPhotos) {
+	p.primary = primary
+}
+
+// ProcessTask downloads one photo. Download errors are retried with backoff
+// unless retrying cannot help (a blocked address, an oversized file); once
+// the photo is given up on it is marked failed and the task completes, since
+// a bad link in a spreadsheet is not a fault worth a dead letter.
+func (p *PhotoImportProcessor) ProcessTask(ctx context.Context, t *asynq.Task) error {
+	var payload PhotoImportPayload
+	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+		return fmt.Errorf("unmarshal payload: %w", err)
+	}
+
+	photoID, err := p.upload(ctx, payload.WorkspaceID, payload.ItemID, payload.UserID, payload.URL)
+	if err == nil {
+		if err := p.record(ctx, payload.ResultID, importjob.PhotoStatusAttached, &photoID, nil); err != nil {
+			return err
+		}
+		p.settlePrimary(ctx, payload)
+		return nil
+	}
+
+	if !isPermanentFetchError(err) && !p.isFinalAttempt(ctx) {
+		return err
+	}
+	log.Printf("Import %s row photo %s failed: %v", payload.ImportJobID, payload.ResultID, err)
+	message := err.Error()
+	if err := p.record(ctx, payload.ResultID, importjob.PhotoStatusFailed, nil, &message); err != nil {
+		return err
+	}
+	p.settlePrimary(ctx, payload)
+	return nil
+}
+
+// settlePrimary picks the item's primary photo once the last of its photos
+// in this import has been fetched or given up on. The first upload already
+// became primary by the upload's fallback, which may be whichever download
+// finished first; the preference decides among the attached photos instead.
+// A primary the item had before the import is left alone. Best-effort: the
+// photos themselves are attached either way.
+func (p *PhotoImportProcessor) settlePrimary(ctx context.Context, payload PhotoImportPayload) {
+	if p.primary == nil {
+		return
+	}
+	if err := p.choosePrimary(ctx, payload); err != nil {
+		log.Printf("Import %s: choosing primary photo of item %s failed: %v", payload.ImportJobID, payload.ItemID, err)
+	}
+}
+
+func (p *PhotoImportProcessor) choosePrimary(ctx context.Context, payload PhotoImportPayload) error {
+	results, err := p.results.FindPhotoResultsByItem(ctx, payload.ImportJobID, payload.ItemID)
+	if err != nil {
+		return err
+	}
+
+	var attached []*importjob.ImportPhotoResult
+	for _, r := range results {
+		switch r.Status() {
+		case importjob.PhotoStatusPending:
+			// Another task will settle the item when its photo finishes.
+			return nil
+		case importjob.PhotoStatusAttached:
+			if r.PhotoID() != nil {
+				attached = append(attached, r)
+			}
+		}
+	}
+	if len(attached) == 0 {
+		return nil
+	}
+
+	current, err := p.primary.PrimaryPhotoID(ctx, payload.WorkspaceID, payload.ItemID)
+	if err != nil {
+		return err
+	}
+	if current != nil && !containsPhoto(attached, *current) {
+		return nil
+	}
+
+	chosen := attached[0]
+	if importjob.PrimaryPhotoPreference(payload.PrimaryPhoto) == importjob.PrimaryPhotoLargest {
+		var largest int64 = -1
+		for _, r := range attached {
+			area, err := p.primary.PhotoArea(ctx, payload.WorkspaceID, *r.PhotoID())
+			if err != nil {
+				return err
+			}
+			if area > largest {
+				chosen, largest = r, area
+			}
+		}
+	}
+
+	if current == nil || *current != *chosen.PhotoID() {
+		if err := p.primary.SetPrimaryPhoto(ctx, payload.WorkspaceID, *chosen.PhotoID()); err != nil {
+			return err
+		}
+	}
+	return p.results.MarkPhotoResultPrimary(ctx, payload.ImportJobID, payload.ItemID, chosen.ID())
+}
+
+func containsPhoto(results []*importjob.ImportPhotoResult, photoID uuid.UUID) bool {
+	for _, r := range results {
+		if *r.PhotoID() == photoID {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *PhotoImportProcessor) record(ctx context.Context, id uuid.UUID, status importjob.PhotoStatus, photoID *uuid.UUID, message *string) error {
+	err := p.results.UpdatePhotoResult(ctx, id, status, photoID, message)
+	if errors.Is(err, shared.ErrNotFound) {
+		// The import job was deleted, taking its photo results with it.
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("record photo result: %w", err)
+	}
+	return nil
+}
+
+// isPermanentFetchError reports whether a download failed for a reason that
+// will not change on retry.
+func isPermanentFetchError(err error) bool {
+	return errors.Is(err, urlfetch.ErrInvalidURL) ||
+		errors.Is(err, urlfetch.ErrBlockedAddress) ||
+		errors.Is(err, urlfetch.ErrTooLarge)
+}
@@ -0,0 +1,257 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/importjob"
+	"github.com/antti/home-warehouse/go-backend/internal/infra/urlfetch"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+type photoImportUpdate struct {
+	status  importjob.PhotoStatus
+	photoID *uuid.UUID
+	message *string
+}
+
+type fakePhotoImportResultStore struct {
+	updates map[uuid.UUID]photoImportUpdate
+	err     error
+	// results are the job's photo results, kept in step with updates.
+	results []*importjob.ImportPhotoResult
+	primary map[uuid.UUID]uuid.UUID // item ID -> result ID
+}
+
+func (f *fakePhotoImportResultStore) UpdatePhotoResult(ctx context.Context, id uuid.UUID, status importjob.PhotoStatus, photoID *uuid.UUID, message *string) error {
+	if f.err != nil {
+		return f.err
+	}
+	if f.updates == nil {
+		f.updates = map[uuid.UUID]photoImportUpdate{}
+	}
+	f.updates[id] = photoImportUpdate{status: status, photoID: photoID, message: message}
+	for i, r := range f.results {
+		if r.ID() == id {
+			f.results[i] = importjob.ReconstructImportPhotoResult(r.ID(), r.ImportJobID(), r.RowNumber(), r.ItemID(), r.URL(),
+				status, photoID, message, r.IsPrimary(), r.CreatedAt(), r.UpdatedAt())
+		}
+	}
+	return nil
+}
+
+func (f *fakePhotoImportResultStore) FindPhotoResultsByItem(ctx context.Context, jobID, itemID uuid.UUID) ([]*importjob.ImportPhotoResult, error) {
+	var results []*importjob.ImportPhotoResult
+	for _, r := range f.results {
+		if r.ImportJobID() == jobID && r.ItemID() == itemID {
+			results = append(results, r)
+		}
+	}
+	return results, nil
+}
+
+func (f *fakePhotoImportResultStore) MarkPhotoResultPrimary(ctx context.Context, jobID, itemID, resultID uuid.UUID) error {
+	if f.primary == nil {
+		f.primary = map[uuid.UUID]uuid.UUID{}
+	}
+	f.primary[itemID] = resultID
+	return nil
+}
+
+// fakeItemPhotos mimics itemphoto.Service for imported photos: uploads are
+// numbered in URL order, and the first to finish becomes primary when the
+// item has none.
+type fakeItemPhotos struct {
+	photos  map[string]uuid.UUID // URL -> photo ID
+	areas   map[uuid.UUID]int64
+	primary *uuid.UUID
+	setCall int
+}
+
+func (f *fakeItemPhotos) upload(ctx context.Context, workspaceID, itemID, userID uuid.UUID, rawURL string) (uuid.UUID, error) {
+	id := f.photos[rawURL]
+	if f.primary == nil {
+		f.primary = &id
+	}
+	return id, nil
+}
+
+func (f *fakeItemPhotos) PrimaryPhotoID(ctx context.Context, workspaceID, itemID uuid.UUID) (*uuid.UUID, error) {
+	return f.primary, nil
+}
+
+func (f *fakeItemPhotos) PhotoArea(ctx context.Context, workspaceID, photoID uuid.UUID) (int64, error) {
+	return f.areas[photoID], nil
+}
+
+func (f *fakeItemPhotos) SetPrimaryPhoto(ctx context.Context, workspaceID, photoID uuid.UUID) error {
+	f.setCall++
+	f.primary = &photoID
+	return nil
+}
+
+func newPhotoImportTestProcessor(uploadErr error, final bool) (*PhotoImportProcessor, *fakePhotoImportResultStore, uuid.UUID) {
+	photoID := uuid.New()
+	store := &fakePhotoImportResultStore{}
+	p := NewPhotoImportProcessor(func(ctx context.Context, workspaceID, itemID, userID uuid.UUID, rawURL string) (uuid.UUID, error) {
+		if uploadErr != nil {
+			return uuid.Nil, uploadErr
+		}
+		return photoID, nil
+	}, store)
+	p.isFinalAttempt = func(context.Context) bool { return final }
+	return p, store, photoID
+}
+
+func newPhotoImportTestPayload() PhotoImportPayload {
+	return PhotoImportPayload{
+		ResultID:    uuid.New(),
+		ImportJobID: uuid.New(),
+		WorkspaceID: uuid.New(),
+		ItemID:      uuid.New(),
+		UserID:      uuid.New(),
+		URL:         "https://example.com/drill.jpg",
+	}
+}
+
+func TestPhotoImportProcessor_ProcessTask(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("marks photo attached", func(t *testing.T) {
+		p, store, photoID := newPhotoImportTestProcessor(nil, false)
+		payload := newPhotoImportTestPayload()
+
+		require.NoError(t, p.ProcessTask(ctx, NewPhotoImportTask(payload)))
+		got := store.updates[payload.ResultID]
+		assert.Equal(t, importjob.PhotoStatusAttached, got.status)
+		require.NotNil(t, got.photoID)
+		assert.Equal(t, photoID, *got.photoID)
+	})
+
+	t.Run("retries transient errors", func(t *testing.T) {
+		p, store, _ := newPhotoImportTestProcessor(errors.New("connection reset"), false)
+
+		assert.Error(t, p.ProcessTask(ctx, NewPhotoImportTask(newPhotoImportTestPayload())))
+		assert.Empty(t, store.updates)
+	})
+
+	t.Run("marks photo failed on the final attempt", func(t *testing.T) {
+		p, store, _ := newPhotoImportTestProcessor(errors.New("connection reset"), true)
+		payload := newPhotoImportTestPayload()
+
+		require.NoError(t, p.ProcessTask(ctx, NewPhotoImportTask(payload)))
+		got := store.updates[payload.ResultID]
+		assert.Equal(t, importjob.PhotoStatusFailed, got.status)
+		require.NotNil(t, got.message)
+		assert.Contains(t, *got.message, "connection reset")
+	})
+
+	t.Run("does not retry blocked addresses", func(t *testing.T) {
+		p, store, _ := newPhotoImportTestProcessor(fmt.Errorf("failed to download photo: %w", urlfetch.ErrBlockedAddress), false)
+		payload := newPhotoImportTestPayload()
+
+		require.NoError(t, p.ProcessTask(ctx, NewPhotoImportTask(payload)))
+		assert.Equal(t, importjob.PhotoStatusFailed, store.updates[payload.ResultID].status)
+	})
+
+	t.Run("ignores results of deleted import jobs", func(t *testing.T) {
+		p, store, _ := newPhotoImportTestProcessor(nil, false)
+		store.err = shared.ErrNotFound
+
+		assert.NoError(t, p.ProcessTask(ctx, NewPhotoImportTask(newPhotoImportTestPayload())))
+	})
+}
+
+func TestPhotoImportProcessor_PrimaryPhoto(t *testing.T) {
+	ctx := context.Background()
+	jobID, workspaceID, itemID := uuid.New(), uuid.New(), uuid.New()
+	urls := []string{"https://example.com/small.jpg", "https://example.com/large.jpg", "https://example.com/medium.jpg"}
+
+	// setup queues the three photos of one row, as the import worker does.
+	setup := func(t *testing.T) (*PhotoImportProcessor, *fakePhotoImportResultStore, *fakeItemPhotos, []PhotoImportPayload) {
+		t.Helper()
+		photos := &fakeItemPhotos{photos: map[string]uuid.UUID{}, areas: map[uuid.UUID]int64{}}
+		store := &fakePhotoImportResultStore{}
+		var payloads []PhotoImportPayload
+		for i, url := range urls {
+			id := uuid.New()
+			photos.photos[url] = id
+			photos.areas[id] = []int64{640 * 480, 4000 * 3000, 1920 * 1080}[i]
+
+			result, err := importjob.NewImportPhotoResult(jobID, 2, itemID, url, importjob.PhotoStatusPending, nil)
+			require.NoError(t, err)
+			store.results = append(store.results, result)
+			payloads = append(payloads, PhotoImportPayload{
+				ResultID:    result.ID(),
+				ImportJobID: jobID,
+				WorkspaceID: workspaceID,
+				ItemID:      itemID,
+				URL:         url,
+			})
+		}
+		p := NewPhotoImportProcessor(photos.upload, store)
+		p.SetPrimaryPhotos(photos)
+		p.isFinalAttempt = func(context.Context) bool { return true }
+		return p, store, photos, payloads
+	}
+
+	// process runs the tasks in the given order, e.g. as downloads finish.
+	process := func(t *testing.T, p *PhotoImportProcessor, payloads []PhotoImportPayload, pref importjob.PrimaryPhotoPreference, order ...int) {
+		t.Helper()
+		for _, i := range order {
+			payloads[i].PrimaryPhoto = string(pref)
+			require.NoError(t, p.ProcessTask(ctx, NewPhotoImportTask(payloads[i])))
+		}
+	}
+
+	t.Run("first photo becomes primary even when it finishes last", func(t *testing.T) {
+		p, store, photos, payloads := setup(t)
+
+		process(t, p, payloads, importjob.PrimaryPhotoFirst, 2, 1)
+		assert.Empty(t, store.primary, "not settled while a photo is pending")
+
+		process(t, p, payloads, importjob.PrimaryPhotoFirst, 0)
+
+		require.NotNil(t, photos.primary)
+		assert.Equal(t, photos.photos[urls[0]], *photos.primary)
+		assert.Equal(t, payloads[0].ResultID, store.primary[itemID])
+	})
+
+	t.Run("largest photo becomes primary", func(t *testing.T) {
+		p, store, photos, payloads := setup(t)
+
+		process(t, p, payloads, importjob.PrimaryPhotoLargest, 0, 2, 1)
+
+		require.NotNil(t, photos.primary)
+		assert.Equal(t, photos.photos[urls[1]], *photos.primary)
+		assert.Equal(t, payloads[1].ResultID, store.primary[itemID])
+	})
+
+	t.Run("skips failed photos", func(t *testing.T) {
+		p, store, photos, payloads := setup(t)
+		require.NoError(t, store.UpdatePhotoResult(ctx, payloads[0].ResultID, importjob.PhotoStatusFailed, nil, nil))
+
+		process(t, p, payloads, importjob.PrimaryPhotoFirst, 2, 1)
+
+		assert.Equal(t, photos.photos[urls[1]], *photos.primary)
+		assert.Equal(t, payloads[1].ResultID, store.primary[itemID])
+	})
+
+	t.Run("keeps a primary the item already had", func(t *testing.T) {
+		p, store, photos, payloads := setup(t)
+		existing := uuid.New()
+		photos.primary = &existing
+
+		process(t, p, payloads, importjob.PrimaryPhotoLargest, 0, 1, 2)
+
+		assert.Equal(t, existing, *photos.primary)
+		assert.Zero(t, photos.setCall)
+		assert.Empty(t, store.primary)
+	})
+}
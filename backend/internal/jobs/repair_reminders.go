@@ -178,31 +178,46 @@ func (p *RepairReminderProcessor) sendPushNotifications(ctx context.Context, q *
 	return p.pushSender.SendToUsers(ctx, userIDs, message)
 }
 
+// repairReminderStore is the query that finds repairs needing reminders,
+// split out so tests don't need a database.
+type repairReminderStore interface {
+	ListRepairsNeedingReminder(ctx context.Context, reminderDate pgtype.Date) ([]queries.ListRepairsNeedingReminderRow, error)
+}
+
 // RepairReminderScheduler schedules repair reminder tasks.
 type RepairReminderScheduler struct {
-	pool   *pgxpool.Pool
-	client *asynq.Client
+	repairs  repairReminderStore
+	client   Enqueuer
+	maxRetry int
 }
 
 // NewRepairReminderScheduler creates a new repair reminder scheduler.
 func NewRepairReminderScheduler(pool *pgxpool.Pool, client *asynq.Client) *RepairReminderScheduler {
+	return newRepairReminderScheduler(queries.New(pool), client)
+}
+
+func newRepairReminderScheduler(repairs repairReminderStore, client Enqueuer) *RepairReminderScheduler {
 	return &RepairReminderScheduler{
-		pool:   pool,
-		client: client,
+		repairs:  repairs,
+		client:   client,
+		maxRetry: defaultReminderMaxRetry,
 	}
 }
 
+// SetMaxRetry sets how many times each enqueued reminder task is retried.
+func (s *RepairReminderScheduler) SetMaxRetry(n int) {
+	s.maxRetry = n
+}
+
 // ScheduleReminders finds repairs needing reminders and enqueues tasks.
 func (s *RepairReminderScheduler) ScheduleReminders(ctx context.Context) error {
-	q := queries.New(s.pool)
-
 	// Find repairs with reminder_date within the next 3 days (including past due)
 	reminderDate := time.Now().AddDate(0, 0, 3)
 	var pgDate pgtype.Date
 	pgDate.Time = reminderDate
 	pgDate.Valid = true
 
-	repairs, err := q.ListRepairsNeedingReminder(ctx, pgDate)
+	repairs, err := s.repairs.ListRepairsNeedingReminder(ctx, pgDate)
 	if err != nil {
 		return fmt.Errorf("failed to list repairs needing reminder: %w", err)
 	}
@@ -233,7 +248,7 @@ func (s *RepairReminderScheduler) ScheduleReminders(ctx context.Context) error {
 		task := asynq.NewTask(TypeRepairReminder, payloadBytes)
 		_, err = s.client.Enqueue(task,
 			asynq.Queue(QueueDefault),
-			asynq.MaxRetry(3),
+			asynq.MaxRetry(s.maxRetry),
 			asynq.Timeout(30*time.Second),
 		)
 		if err != nil {
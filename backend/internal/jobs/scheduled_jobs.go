@@ -0,0 +1,132 @@
+package jobs
+
+import (
+	"errors"
+
+	"github.com/hibiken/asynq"
+)
+
+// ErrUnknownJob is returned when triggering a job name that isn't scheduled.
+var ErrUnknownJob = errors.New("unknown scheduled job")
+
+// ScheduledJob describes a periodic task: when the cron runs it and how to
+// build the task it enqueues. The scheduler and the manual "run now"
+// trigger both read this table, so a manual run enqueues exactly what the
+// cron would.
+type ScheduledJob struct {
+	Name         string // Stable identifier used by the run-now API
+	Description  string
+	Cronspec     string
+	ScheduleText string // Human-readable form of Cronspec
+	Queue        string
+	NewTask      func() *asynq.Task
+}
+
+// ScheduledJobs returns every periodic task, in registration order.
+func ScheduledJobs() []ScheduledJob {
+	return []ScheduledJob{
+		{
+			Name:         "loan-reminders",
+			Description:  "loan reminders",
+			Cronspec:     cronDailyAt9AM,
+			ScheduleText: "daily at 9 AM",
+			Queue:        QueueDefault,
+			NewTask:      NewScheduleLoanRemindersTask,
+		},
+		{
+			Name:         "repair-reminders",
+			Description:  "repair reminders",
+			Cronspec:     cronDailyAt9AM,
+			ScheduleText: "daily at 9 AM",
+			Queue:        QueueDefault,
+			NewTask:      NewScheduleRepairRemindersTask,
+		},
+		{
+			Name:         "expiry-reminders",
+			Description:  "expiry reminders",
+			Cronspec:     cronDailyAt9AM,
+			ScheduleText: "daily at 9 AM",
+			Queue:        QueueDefault,
+			NewTask:      NewScheduleExpiryRemindersTask,
+		},
+		{
+			Name:         "maintenance-reminders",
+			Description:  "maintenance reminders",
+			Cronspec:     cronDailyAt9AM,
+			ScheduleText: "daily at 9 AM",
+			Queue:        QueueDefault,
+			NewTask:      NewScheduleMaintenanceRemindersTask,
+		},
+		{
+			Name:         "expire-inventory",
+			Description:  "expired inventory status",
+			Cronspec:     "0 1 * * *",
+			ScheduleText: "daily at 1 AM",
+			Queue:        QueueDefault,
+			NewTask:      NewExpireInventoryTask,
+		},
+		{
+			Name:         "cleanup-deleted-records",
+			Description:  "deleted records cleanup",
+			Cronspec:     "0 3 * * 0",
+			ScheduleText: "weekly Sunday 3 AM",
+			Queue:        QueueLow,
+			NewTask:      NewCleanupDeletedRecordsTask,
+		},
+		{
+			Name:         "cleanup-activity",
+			Description:  "activity logs cleanup",
+			Cronspec:     "0 4 * * 0",
+			ScheduleText: "weekly Sunday 4 AM",
+			Queue:        QueueLow,
+			NewTask:      NewCleanupActivityTask,
+		},
+		{
+			Name:         "cleanup-orphaned-joins",
+			Description:  "orphaned join rows cleanup",
+			Cronspec:     "0 5 * * 0",
+			ScheduleText: "weekly Sunday 5 AM",
+			Queue:        QueueLow,
+			NewTask:      NewCleanupOrphanedJoinsTask,
+		},
+		{
+			Name:         "cleanup-upload-temp",
+			Description:  "upload temp files cleanup",
+			Cronspec:     "0 * * * *",
+			ScheduleText: "hourly",
+			Queue:        QueueLow,
+			NewTask:      NewCleanupUploadTempTask,
+		},
+	}
+}
+
+// Enqueuer is the subset of asynq.Client used to enqueue tasks.
+type Enqueuer interface {
+	Enqueue(task *asynq.Task, opts ...asynq.Option) (*asynq.TaskInfo, error)
+}
+
+// JobTrigger enqueues scheduled jobs on demand, outside their cron schedule.
+type JobTrigger struct {
+	client   Enqueuer
+	maxRetry int
+}
+
+// NewJobTrigger creates a trigger that enqueues with the given retry budget
+// (use the scheduler's MaxRetry so manual runs behave like cron runs).
+func NewJobTrigger(client Enqueuer, maxRetry int) *JobTrigger {
+	return &JobTrigger{client: client, maxRetry: maxRetry}
+}
+
+// RunNow enqueues the named job immediately and returns the enqueued task.
+func (t *JobTrigger) RunNow(name string) (*asynq.TaskInfo, error) {
+	for _, job := range ScheduledJobs() {
+		if job.Name != name {
+			continue
+		}
+		return t.client.Enqueue(job.NewTask(),
+			asynq.Queue(job.Queue),
+			asynq.MaxRetry(t.maxRetry),
+		)
+	}
+	return nil, ErrUnknownJob
+}
@@ -0,0 +1,95 @@
+package jobs_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/jobs"
+)
+
+// fakeEnqueuer records enqueued tasks instead of talking to Redis.
+type fakeEnqueuer struct {
+	tasks []*asynq.Task
+	opts  [][]asynq.Option
+	err   error
+}
+
+func (f *fakeEnqueuer) Enqueue(task *asynq.Task, opts ...asynq.Option) (*asynq.TaskInfo, error) {
+	if f.err != nil {
+		return nil, f.err
+	}
+	f.tasks = append(f.tasks, task)
+	f.opts = append(f.opts, opts)
+	return &asynq.TaskInfo{ID: "task-123", Type: task.Type()}, nil
+}
+
+func TestScheduledJobs(t *testing.T) {
+	scheduled := jobs.ScheduledJobs()
+
+	names := make(map[string]bool)
+	for _, job := range scheduled {
+		assert.NotEmpty(t, job.Name)
+		assert.NotEmpty(t, job.Cronspec)
+		assert.NotNil(t, job.NewTask)
+		assert.False(t, names[job.Name], "duplicate job name %s", job.Name)
+		names[job.Name] = true
+	}
+
+	assert.True(t, names["loan-reminders"])
+	assert.True(t, names["repair-reminders"])
+	assert.True(t, names["expire-inventory"])
+	assert.True(t, names["cleanup-deleted-records"])
+	assert.True(t, names["cleanup-activity"])
+	assert.True(t, names["cleanup-orphaned-joins"])
+	assert.True(t, names["cleanup-upload-temp"])
+}
+
+func TestJobTrigger_RunNow(t *testing.T) {
+	t.Run("enqueues the cron task", func(t *testing.T) {
+		enqueuer := &fakeEnqueuer{}
+		trigger := jobs.NewJobTrigger(enqueuer, 4)
+
+		info, err := trigger.RunNow("loan-reminders")
+
+		require.NoError(t, err)
+		assert.Equal(t, "task-123", info.ID)
+		require.Len(t, enqueuer.tasks, 1)
+		assert.Equal(t, jobs.NewScheduleLoanRemindersTask().Type(), enqueuer.tasks[0].Type())
+		assert.Contains(t, enqueuer.opts[0], asynq.Queue(jobs.QueueDefault))
+		assert.Contains(t, enqueuer.opts[0], asynq.MaxRetry(4))
+	})
+
+	t.Run("cleanup goes to low queue", func(t *testing.T) {
+		enqueuer := &fakeEnqueuer{}
+		trigger := jobs.NewJobTrigger(enqueuer, 4)
+
+		_, err := trigger.RunNow("cleanup-activity")
+
+		require.NoError(t, err)
+		assert.Equal(t, jobs.TypeCleanupOldActivity, enqueuer.tasks[0].Type())
+		assert.Contains(t, enqueuer.opts[0], asynq.Queue(jobs.QueueLow))
+	})
+
+	t.Run("unknown job", func(t *testing.T) {
+		enqueuer := &fakeEnqueuer{}
+		trigger := jobs.NewJobTrigger(enqueuer, 4)
+
+		_, err := trigger.RunNow("nope")
+
+		assert.ErrorIs(t, err, jobs.ErrUnknownJob)
+		assert.Empty(t, enqueuer.tasks)
+	})
+
+	t.Run("enqueue failure", func(t *testing.T) {
+		enqueuer := &fakeEnqueuer{err: errors.New("redis down")}
+		trigger := jobs.NewJobTrigger(enqueuer, 4)
+
+		_, err := trigger.RunNow("repair-reminders")
+
+		assert.Error(t, err)
+	})
+}
@@ -3,6 +3,7 @@ package jobs
 import (
 	"context"
 	"log"
+	"sort"
 	"time"
 
 	"github.com/hibiken/asynq"
@@ -10,16 +11,33 @@ import (
 
 	"github.com/antti/home-warehouse/go-backend/internal/infra/events"
 	"github.com/antti/home-warehouse/go-backend/internal/infra/imageprocessor"
+	"github.com/antti/home-warehouse/go-backend/internal/infra/queries"
 	"github.com/antti/home-warehouse/go-backend/internal/infra/storage"
 	"github.com/antti/home-warehouse/go-backend/internal/infra/webpush"
 )
 
 const cronDailyAt9AM = "0 9 * * *"
 
+// defaultReminderMaxRetry is the retry budget for per-row reminder tasks
+// when the scheduler doesn't override it.
+const defaultReminderMaxRetry = 3
+
 // SchedulerConfig holds configuration for the job scheduler.
 type SchedulerConfig struct {
 	RedisAddr string
 	Queues    map[string]int
+
+	// MaxRetry is how many times a failed task is retried before it is
+	// archived as a dead letter.
+	MaxRetry int
+	// RetryBaseDelay is the delay before the first retry; each further retry
+	// doubles it, up to RetryMaxDelay.
+	RetryBaseDelay time.Duration
+	RetryMaxDelay  time.Duration
+
+	// PhoneRegion is the region borrower phone numbers without a country
+	// code are read in for SMS reminders (see borrower.NormalizePhone).
+	PhoneRegion string
 }
 
 // DefaultSchedulerConfig returns the default scheduler configuration.
@@ -31,6 +49,31 @@ func DefaultSchedulerConfig(redisAddr string) SchedulerConfig {
 			QueueDefault:  3,
 			QueueLow:      1,
 		},
+		MaxRetry:       5,
+		RetryBaseDelay: 30 * time.Second,
+		RetryMaxDelay:  time.Hour,
+	}
+}
+
+// RetryBackoff returns an asynq retry delay function that backs off
+// exponentially: base, 2*base, 4*base, ... capped at max. n is the number of
+// times the task has already been retried.
+func RetryBackoff(base, max time.Duration) asynq.RetryDelayFunc {
+	return func(n int, _ error, _ *asynq.Task) time.Duration {
+		if base <= 0 {
+			return 0
+		}
+		delay := base
+		for i := 0; i < n; i++ {
+			delay *= 2
+			if max > 0 && delay >= max {
+				return max
+			}
+		}
+		if max > 0 && delay > max {
+			return max
+		}
+		return delay
 	}
 }
 
@@ -39,8 +82,17 @@ type Scheduler struct {
 	client    *asynq.Client
 	server    *asynq.Server
 	scheduler *asynq.Scheduler
+	inspector *asynq.Inspector
 	pool      *pgxpool.Pool
 	config    SchedulerConfig
+	smsSender SMSSender
+
+	// reminders and enqueuer back the loan and repair reminder schedulers;
+	// they default to the pool's queries and the asynq client.
+	reminders reminderStore
+	enqueuer  Enqueuer
+
+	broadcaster *events.Broadcaster
 }
 
 // NewScheduler creates a new job scheduler.
@@ -52,12 +104,10 @@ func NewScheduler(pool *pgxpool.Pool, config SchedulerConfig) *Scheduler {
 	server := asynq.NewServer(
 		redisOpt,
 		asynq.Config{
-			Queues:      config.Queues,
-			Concurrency: 10,
-			// Retry configuration
-			RetryDelayFunc: func(n int, e error, t *asynq.Task) time.Duration {
-				return time.Duration(n) * time.Minute
-			},
+			Queues:         config.Queues,
+			Concurrency:    10,
+			RetryDelayFunc: RetryBackoff(config.RetryBaseDelay, config.RetryMaxDelay),
+			ErrorHandler:   asynq.ErrorHandlerFunc(logTaskFailure),
 		},
 	)
 
@@ -67,9 +117,44 @@ func NewScheduler(pool *pgxpool.Pool, config SchedulerConfig) *Scheduler {
 		client:    client,
 		server:    server,
 		scheduler: scheduler,
+		inspector: asynq.NewInspector(redisOpt),
 		pool:      pool,
 		config:    config,
+		reminders: queries.New(pool),
+		enqueuer:  client,
+	}
+}
+
+// reminderStore is the subset of queries the reminder schedulers read.
+type reminderStore interface {
+	loanReminderStore
+	repairReminderStore
+}
+
+// SetSMSSender enables SMS loan reminders for borrowers who chose them.
+// Must be called before RegisterHandlers.
+func (s *Scheduler) SetSMSSender(sender SMSSender) {
+	s.smsSender = sender
+}
+
+// SetBroadcaster publishes events for changes the periodic jobs make (e.g.
+// expired inventory). Must be called before RegisterHandlers.
+func (s *Scheduler) SetBroadcaster(broadcaster *events.Broadcaster) {
+	s.broadcaster = broadcaster
+}
+
+// logTaskFailure logs every failed attempt, and loudly when the task has used
+// up its retries: asynq then archives it, where it shows up as a dead letter.
+func logTaskFailure(ctx context.Context, task *asynq.Task, err error) {
+	retried, _ := asynq.GetRetryCount(ctx)
+	maxRetry, _ := asynq.GetMaxRetry(ctx)
+	taskID, _ := asynq.GetTaskID(ctx)
+
+	if retried >= maxRetry {
+		log.Printf("Task %s (%s) permanently failed after %d retries, moved to dead letters: %v", taskID, task.Type(), retried, err)
+		return
 	}
+	log.Printf("Task %s (%s) failed (attempt %d of %d), will retry: %v", taskID, task.Type(), retried+1, maxRetry+1, err)
 }
 
 // ThumbnailConfig holds configuration for thumbnail processing.
@@ -78,6 +163,18 @@ type ThumbnailConfig struct {
 	Storage     storage.Storage
 	Broadcaster *events.Broadcaster
 	UploadDir   string
+
+	// ConfigVersion is recorded on photos as their thumbnails are generated
+	// (imageprocessor.Config.ThumbnailVersion of Processor's config).
+	ConfigVersion string
+
+	// ImportUploader and ImportResults enable downloading the photo_url
+	// column of item imports. Both must be set.
+	ImportUploader PhotoFromURLUploader
+	ImportResults  PhotoImportResultStore
+	// ImportPrimary, when set, chooses each imported item's primary photo by
+	// the import's primary_photo preference.
+	ImportPrimary ImportPrimaryPhotos
 }
 
 // RegisterHandlers registers all task handlers.
@@ -86,32 +183,50 @@ func (s *Scheduler) RegisterHandlers(emailSender EmailSender, pushSender *webpus
 
 	// Loan reminder processor
 	loanProcessor := NewLoanReminderProcessor(s.pool, emailSender, pushSender)
+	loanProcessor.SetSMSSender(s.smsSender)
 	mux.HandleFunc(TypeLoanReminder, loanProcessor.ProcessTask)
+	// The ":schedule" handlers are registered explicitly (asynq's ServeMux
+	// is prefix-based, the longer pattern wins) so the periodic task fans
+	// out into per-row reminder tasks instead of reaching the processor.
+	mux.HandleFunc(TypeLoanReminder+":schedule", func(ctx context.Context, t *asynq.Task) error {
+		return s.loanReminderScheduler().ScheduleReminders(ctx)
+	})
 
-	// Repair reminder processor
+	// Repair reminder processor (same explicit ":schedule" pattern).
 	repairProcessor := NewRepairReminderProcessor(s.pool, pushSender)
 	mux.HandleFunc(TypeRepairReminder, repairProcessor.ProcessTask)
+	mux.HandleFunc(TypeRepairReminder+":schedule", func(ctx context.Context, t *asynq.Task) error {
+		return s.repairReminderScheduler().ScheduleReminders(ctx)
+	})
 
-	// Expiry reminder processor. The ":schedule" handler is registered
-	// explicitly (asynq's ServeMux is prefix-based, the longer pattern wins)
-	// so the periodic task fans out into per-row reminder tasks.
+	// Expiry reminder processor (same explicit ":schedule" pattern).
 	expiryProcessor := NewExpiryReminderProcessor(s.pool, pushSender)
 	mux.HandleFunc(TypeExpiryReminder, expiryProcessor.ProcessTask)
 	mux.HandleFunc(TypeExpiryReminder+":schedule", func(ctx context.Context, t *asynq.Task) error {
-		return NewExpiryReminderScheduler(s.pool, s.client).ScheduleReminders(ctx)
+		return s.expiryReminderScheduler().ScheduleReminders(ctx)
 	})
 
 	// Maintenance reminder processor (same explicit ":schedule" pattern).
 	maintenanceProcessor := NewMaintenanceReminderProcessor(s.pool, pushSender)
 	mux.HandleFunc(TypeMaintenanceReminder, maintenanceProcessor.ProcessTask)
 	mux.HandleFunc(TypeMaintenanceReminder+":schedule", func(ctx context.Context, t *asynq.Task) error {
-		return NewMaintenanceReminderScheduler(s.pool, s.client).ScheduleReminders(ctx)
+		return s.maintenanceReminderScheduler().ScheduleReminders(ctx)
 	})
 
+	// Expired inventory processor (opt-in per workspace)
+	expiredInventoryProcessor := NewExpiredInventoryProcessor(s.pool, s.broadcaster)
+	mux.HandleFunc(TypeExpireInventory, expiredInventoryProcessor.ProcessTask)
+
 	// Cleanup processor
 	cleanupProcessor := NewCleanupProcessor(s.pool, cleanupConfig)
 	mux.HandleFunc(TypeCleanupDeletedRecords, cleanupProcessor.ProcessDeletedRecordsCleanup)
 	mux.HandleFunc(TypeCleanupOldActivity, cleanupProcessor.ProcessActivityCleanup)
+	mux.HandleFunc(TypeCleanupOrphanedJoins, cleanupProcessor.ProcessOrphanedJoinsCleanup)
+	mux.HandleFunc(TypeCleanupUploadTemp, cleanupProcessor.ProcessUploadTempCleanup)
+
+	// Webhook delivery processor
+	webhookProcessor := NewWebhookDeliveryProcessor(s.pool)
+	mux.HandleFunc(TypeWebhookDelivery, webhookProcessor.ProcessTask)
 
 	// Thumbnail processor (optional - only if config provided)
 	if thumbnailConfig != nil {
@@ -122,8 +237,16 @@ func (s *Scheduler) RegisterHandlers(emailSender EmailSender, pushSender *webpus
 			thumbnailConfig.Broadcaster,
 			thumbnailConfig.UploadDir,
 		)
+		thumbnailProcessor.SetConfigVersion(thumbnailConfig.ConfigVersion)
 		mux.HandleFunc(TypeThumbnailGeneration, thumbnailProcessor.ProcessTask)
 		log.Println("Registered thumbnail processor")
+
+		if thumbnailConfig.ImportUploader != nil && thumbnailConfig.ImportResults != nil {
+			photoImportProcessor := NewPhotoImportProcessor(thumbnailConfig.ImportUploader, thumbnailConfig.ImportResults)
+			photoImportProcessor.SetPrimaryPhotos(thumbnailConfig.ImportPrimary)
+			mux.HandleFunc(TypePhotoImportURL, photoImportProcessor.ProcessTask)
+			log.Println("Registered import photo processor")
+		}
 	}
 
 	return mux
@@ -131,59 +254,16 @@ func (s *Scheduler) RegisterHandlers(emailSender EmailSender, pushSender *webpus
 
 // RegisterScheduledTasks registers all scheduled/periodic tasks.
 func (s *Scheduler) RegisterScheduledTasks() error {
-	// Schedule loan reminders check daily at 9 AM
-	_, err := s.scheduler.Register(cronDailyAt9AM, NewScheduleLoanRemindersTask(),
-		asynq.Queue(QueueDefault),
-	)
-	if err != nil {
-		return err
-	}
-	log.Println("Registered scheduled task: loan reminders (daily at 9 AM)")
-
-	// Schedule repair reminders check daily at 9 AM (same schedule as loan reminders)
-	_, err = s.scheduler.Register(cronDailyAt9AM, NewScheduleRepairRemindersTask(),
-		asynq.Queue(QueueDefault),
-	)
-	if err != nil {
-		return err
-	}
-	log.Println("Registered scheduled task: repair reminders (daily at 9 AM)")
-
-	// Schedule expiry/warranty reminders check daily at 9 AM
-	_, err = s.scheduler.Register(cronDailyAt9AM, NewScheduleExpiryRemindersTask(),
-		asynq.Queue(QueueDefault),
-	)
-	if err != nil {
-		return err
-	}
-	log.Println("Registered scheduled task: expiry reminders (daily at 9 AM)")
-
-	// Schedule maintenance due/overdue reminders check daily at 9 AM
-	_, err = s.scheduler.Register(cronDailyAt9AM, NewScheduleMaintenanceRemindersTask(),
-		asynq.Queue(QueueDefault),
-	)
-	if err != nil {
-		return err
-	}
-	log.Println("Registered scheduled task: maintenance reminders (daily at 9 AM)")
-
-	// Schedule deleted records cleanup weekly on Sunday at 3 AM
-	_, err = s.scheduler.Register("0 3 * * 0", NewCleanupDeletedRecordsTask(),
-		asynq.Queue(QueueLow),
-	)
-	if err != nil {
-		return err
-	}
-	log.Println("Registered scheduled task: deleted records cleanup (weekly Sunday 3 AM)")
-
-	// Schedule activity logs cleanup weekly on Sunday at 4 AM
-	_, err = s.scheduler.Register("0 4 * * 0", NewCleanupActivityTask(),
-		asynq.Queue(QueueLow),
-	)
-	if err != nil {
-		return err
+	for _, job := range ScheduledJobs() {
+		_, err := s.scheduler.Register(job.Cronspec, job.NewTask(),
+			asynq.Queue(job.Queue),
+			asynq.MaxRetry(s.config.MaxRetry),
+		)
+		if err != nil {
+			return err
+		}
+		log.Printf("Registered scheduled task: %s (%s)", job.Description, job.ScheduleText)
 	}
-	log.Println("Registered scheduled task: activity logs cleanup (weekly Sunday 4 AM)")
 
 	return nil
 }
@@ -215,6 +295,22 @@ func (s *Scheduler) Stop() {
 
 	log.Println("Closing asynq client...")
 	s.client.Close()
+	s.inspector.Close()
+}
+
+// Inspector returns a dead-letter inspector over the scheduler's queues.
+func (s *Scheduler) Inspector() *DeadLetterInspector {
+	return NewDeadLetterInspector(s.inspector, s.queueNames())
+}
+
+// queueNames returns the configured queue names in a stable order.
+func (s *Scheduler) queueNames() []string {
+	names := make([]string, 0, len(s.config.Queues))
+	for name := range s.config.Queues {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
 }
 
 // Client returns the asynq client for enqueueing tasks.
@@ -225,27 +321,51 @@ func (s *Scheduler) Client() *asynq.Client {
 // EnqueueLoanReminders manually triggers loan reminder scheduling.
 // This is useful for testing or manual triggering.
 func (s *Scheduler) EnqueueLoanReminders() error {
-	scheduler := NewLoanReminderScheduler(s.pool, s.client)
-	return scheduler.ScheduleReminders(context.Background())
+	return s.loanReminderScheduler().ScheduleReminders(context.Background())
 }
 
 // EnqueueRepairReminders manually triggers repair reminder scheduling.
 // This is useful for testing or manual triggering.
 func (s *Scheduler) EnqueueRepairReminders() error {
-	scheduler := NewRepairReminderScheduler(s.pool, s.client)
-	return scheduler.ScheduleReminders(context.Background())
+	return s.repairReminderScheduler().ScheduleReminders(context.Background())
 }
 
 // EnqueueExpiryReminders manually triggers expiry reminder scheduling.
 // This is useful for testing or manual triggering.
 func (s *Scheduler) EnqueueExpiryReminders() error {
-	scheduler := NewExpiryReminderScheduler(s.pool, s.client)
-	return scheduler.ScheduleReminders(context.Background())
+	return s.expiryReminderScheduler().ScheduleReminders(context.Background())
 }
 
 // EnqueueMaintenanceReminders manually triggers maintenance reminder scheduling.
 // This is useful for testing or manual triggering.
 func (s *Scheduler) EnqueueMaintenanceReminders() error {
-	scheduler := NewMaintenanceReminderScheduler(s.pool, s.client)
-	return scheduler.ScheduleReminders(context.Background())
+	return s.maintenanceReminderScheduler().ScheduleReminders(context.Background())
+}
+
+// The reminder schedulers fan out one task per row; these give those tasks
+// the configured retry budget.
+
+func (s *Scheduler) loanReminderScheduler() *LoanReminderScheduler {
+	rs := newLoanReminderScheduler(s.reminders, s.enqueuer)
+	rs.SetMaxRetry(s.config.MaxRetry)
+	rs.SetPhoneRegion(s.config.PhoneRegion)
+	return rs
+}
+
+func (s *Scheduler) repairReminderScheduler() *RepairReminderScheduler {
+	rs := newRepairReminderScheduler(s.reminders, s.enqueuer)
+	rs.SetMaxRetry(s.config.MaxRetry)
+	return rs
+}
+
+func (s *Scheduler) expiryReminderScheduler() *ExpiryReminderScheduler {
+	rs := NewExpiryReminderScheduler(s.pool, s.client)
+	rs.SetMaxRetry(s.config.MaxRetry)
+	return rs
+}
+
+func (s *Scheduler) maintenanceReminderScheduler() *MaintenanceReminderScheduler {
+	rs := NewMaintenanceReminderScheduler(s.pool, s.client)
+	rs.SetMaxRetry(s.config.MaxRetry)
+	return rs
 }
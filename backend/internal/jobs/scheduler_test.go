@@ -25,6 +25,46 @@ func TestDefaultSchedulerConfig(t *testing.T) {
 	assert.Contains(t, config.Queues, jobs.QueueLow)
 }
 
+func TestDefaultSchedulerConfig_RetryPolicy(t *testing.T) {
+	config := jobs.DefaultSchedulerConfig("localhost:6379")
+
+	assert.Equal(t, 5, config.MaxRetry)
+	assert.Equal(t, 30*time.Second, config.RetryBaseDelay)
+	assert.Equal(t, time.Hour, config.RetryMaxDelay)
+}
+
+func TestRetryBackoff(t *testing.T) {
+	backoff := jobs.RetryBackoff(30*time.Second, 5*time.Minute)
+
+	tests := []struct {
+		retried int
+		want    time.Duration
+	}{
+		{0, 30 * time.Second},
+		{1, time.Minute},
+		{2, 2 * time.Minute},
+		{3, 4 * time.Minute},
+		{4, 5 * time.Minute},
+		{50, 5 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, backoff(tt.retried, nil, nil), "retried=%d", tt.retried)
+	}
+}
+
+func TestRetryBackoff_NoCap(t *testing.T) {
+	backoff := jobs.RetryBackoff(time.Second, 0)
+
+	assert.Equal(t, 8*time.Second, backoff(3, nil, nil))
+}
+
+func TestRetryBackoff_ZeroBase(t *testing.T) {
+	backoff := jobs.RetryBackoff(0, time.Minute)
+
+	assert.Equal(t, time.Duration(0), backoff(2, nil, nil))
+}
+
 func TestDefaultSchedulerConfig_QueuePriorities(t *testing.T) {
 	config := jobs.DefaultSchedulerConfig("localhost:6379")
 
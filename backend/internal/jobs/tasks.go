@@ -16,14 +16,33 @@ const (
 	// due/overdue notifications.
 	TypeMaintenanceReminder = "maintenance:reminder"
 
+	// TypeExpireInventory is the task type for moving inventory past its
+	// expiration date to the workspace's expired status.
+	TypeExpireInventory = "inventory:expire"
+
 	// TypeCleanupDeletedRecords is the task type for cleaning up old deleted records.
 	TypeCleanupDeletedRecords = "cleanup:deleted_records"
 
 	// TypeCleanupOldActivity is the task type for cleaning up old activity logs.
 	TypeCleanupOldActivity = "cleanup:old_activity"
 
+	// TypeCleanupOrphanedJoins is the task type for purging join rows whose
+	// referenced entities no longer exist.
+	TypeCleanupOrphanedJoins = "cleanup:orphaned_joins"
+
+	// TypeCleanupUploadTemp is the task type for removing stale temp files
+	// from the photo processing directory.
+	TypeCleanupUploadTemp = "cleanup:upload_temp"
+
 	// TypeThumbnailGeneration is the task type for generating photo thumbnails.
 	TypeThumbnailGeneration = "photo:generate_thumbnails"
+
+	// TypePhotoImportURL is the task type for downloading a photo named in the
+	// photo_url column of an item import.
+	TypePhotoImportURL = "photo:import_url"
+
+	// TypeWebhookDelivery is the task type for POSTing one event to a webhook.
+	TypeWebhookDelivery = "webhook:deliver"
 )
 
 // Queue names for task prioritization.
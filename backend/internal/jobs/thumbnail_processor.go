@@ -3,6 +3,7 @@ package jobs
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -50,6 +51,10 @@ type ThumbnailProcessor struct {
 	storage     storage.Storage
 	broadcaster *events.Broadcaster
 	uploadDir   string
+
+	// configVersion is recorded on each photo whose thumbnails this
+	// processor generates; see imageprocessor.Config.ThumbnailVersion.
+	configVersion string
 }
 
 // NewThumbnailProcessor creates a new thumbnail processor.
@@ -69,6 +74,13 @@ func NewThumbnailProcessor(
 	}
 }
 
+// SetConfigVersion sets the thumbnail config version recorded on processed
+// photos. When unset no version is recorded and the photos count as stale for
+// photo-admin regenerate.
+func (p *ThumbnailProcessor) SetConfigVersion(version string) {
+	p.configVersion = version
+}
+
 // ProcessTask handles the thumbnail generation task.
 func (p *ThumbnailProcessor) ProcessTask(ctx context.Context, t *asynq.Task) error {
 	var payload ThumbnailPayload
@@ -78,6 +90,25 @@ func (p *ThumbnailProcessor) ProcessTask(ctx context.Context, t *asynq.Task) err
 
 	log.Printf("Processing thumbnails for photo %s", payload.PhotoID)
 
+	if _, err := p.Generate(ctx, payload); err != nil {
+		if errors.Is(err, imageprocessor.ErrProcessingTimeout) {
+			// The same file would time out again; leave the photo failed
+			// rather than tying up the queue with retries.
+			log.Printf("Thumbnail generation for photo %s timed out: %v", payload.PhotoID, err)
+			return nil
+		}
+		return err
+	}
+
+	log.Printf("Thumbnails ready for photo %s", payload.PhotoID)
+	return nil
+}
+
+// Generate creates all thumbnail sizes for one photo, stores them, records
+// their paths on the photo and returns them by size. On failure the photo is
+// marked failed. ProcessTask runs it for queued work; the photo serve handler
+// calls it directly to generate a missing thumbnail on demand.
+func (p *ThumbnailProcessor) Generate(ctx context.Context, payload ThumbnailPayload) (map[imageprocessor.ThumbnailSize]string, error) {
 	q := queries.New(p.pool)
 
 	// Update status to processing
@@ -86,7 +117,7 @@ func (p *ThumbnailProcessor) ProcessTask(ctx context.Context, t *asynq.Task) err
 		ThumbnailStatus: "processing",
 		ThumbnailError:  nil,
 	}); err != nil {
-		return fmt.Errorf("update status to processing: %w", err)
+		return nil, fmt.Errorf("update status to processing: %w", err)
 	}
 
 	// Download original file to temp location
@@ -96,51 +127,58 @@ func (p *ThumbnailProcessor) ProcessTask(ctx context.Context, t *asynq.Task) err
 	reader, err := p.storage.Get(ctx, payload.StoragePath)
 	if err != nil {
 		p.handleFailure(ctx, q, payload, fmt.Errorf("get original: %w", err))
-		return err
+		return nil, err
 	}
 	defer reader.Close()
 
 	tempFile, err := os.Create(tempPath)
 	if err != nil {
 		p.handleFailure(ctx, q, payload, fmt.Errorf("create temp: %w", err))
-		return err
+		return nil, err
 	}
 	if _, err := io.Copy(tempFile, reader); err != nil {
 		tempFile.Close()
 		p.handleFailure(ctx, q, payload, fmt.Errorf("copy to temp: %w", err))
-		return err
+		return nil, err
 	}
 	tempFile.Close()
 
-	// Generate thumbnails in all sizes
-	baseDest := filepath.Join(p.uploadDir, fmt.Sprintf("thumb-%s.webp", payload.PhotoID))
+	// Generate thumbnails in all sizes. The processor picks the extension
+	// from its configured thumbnail format.
+	baseDest := filepath.Join(p.uploadDir, fmt.Sprintf("thumb-%s", payload.PhotoID))
 	thumbnails, err := p.processor.GenerateAllThumbnails(ctx, tempPath, baseDest)
 	if err != nil {
 		p.handleFailure(ctx, q, payload, fmt.Errorf("generate thumbnails: %w", err))
-		return err
+		return nil, err
 	}
 
+	// Remove every local thumbnail however the upload goes, not just the
+	// ones reached before a failure.
+	defer func() {
+		for _, localPath := range thumbnails {
+			os.Remove(localPath)
+		}
+	}()
+
 	// Upload thumbnails to storage
 	paths := make(map[imageprocessor.ThumbnailSize]string)
 	for size, localPath := range thumbnails {
-		defer os.Remove(localPath)
-
 		thumbFile, err := os.Open(localPath)
 		if err != nil {
 			p.handleFailure(ctx, q, payload, fmt.Errorf("open %s thumbnail: %w", size, err))
-			return err
+			return nil, err
 		}
 
 		storagePath, err := p.storage.Save(ctx,
 			payload.WorkspaceID.String(),
 			payload.ItemID.String(),
-			fmt.Sprintf("thumb_%s_%s.webp", size, payload.PhotoID),
+			fmt.Sprintf("thumb_%s_%s%s", size, payload.PhotoID, filepath.Ext(localPath)),
 			thumbFile,
 		)
 		thumbFile.Close()
 		if err != nil {
 			p.handleFailure(ctx, q, payload, fmt.Errorf("save %s thumbnail: %w", size, err))
-			return err
+			return nil, err
 		}
 		paths[size] = storagePath
 	}
@@ -157,15 +195,21 @@ func (p *ThumbnailProcessor) ProcessTask(ctx context.Context, t *asynq.Task) err
 		largePath = &p
 	}
 
+	var configVersion *string
+	if p.configVersion != "" {
+		configVersion = &p.configVersion
+	}
+
 	_, err = q.UpdateThumbnailPaths(ctx, queries.UpdateThumbnailPathsParams{
-		ID:                  payload.PhotoID,
-		ThumbnailSmallPath:  smallPath,
-		ThumbnailMediumPath: mediumPath,
-		ThumbnailLargePath:  largePath,
+		ID:                     payload.PhotoID,
+		ThumbnailSmallPath:     smallPath,
+		ThumbnailMediumPath:    mediumPath,
+		ThumbnailLargePath:     largePath,
+		ThumbnailConfigVersion: configVersion,
 	})
 	if err != nil {
 		p.handleFailure(ctx, q, payload, fmt.Errorf("update paths: %w", err))
-		return err
+		return nil, err
 	}
 
 	// Emit SSE event
@@ -182,11 +226,13 @@ func (p *ThumbnailProcessor) ProcessTask(ctx context.Context, t *asynq.Task) err
 		},
 	})
 
-	log.Printf("Thumbnails ready for photo %s", payload.PhotoID)
-	return nil
+	return paths, nil
 }
 
 func (p *ThumbnailProcessor) handleFailure(ctx context.Context, q *queries.Queries, payload ThumbnailPayload, err error) {
+	// The failure may be ctx running out (an on-demand generation's time
+	// limit), which must not stop the photo being marked failed.
+	ctx = context.WithoutCancel(ctx)
 	errMsg := err.Error()
 	q.UpdateThumbnailStatus(ctx, queries.UpdateThumbnailStatusParams{
 		ID:              payload.PhotoID,
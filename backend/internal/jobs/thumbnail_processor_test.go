@@ -281,6 +281,14 @@ func TestNewThumbnailProcessor_EmptyUploadDir(t *testing.T) {
 	assert.NotNil(t, processor)
 }
 
+func TestThumbnailProcessor_SetConfigVersion(t *testing.T) {
+	processor := NewThumbnailProcessor(nil, nil, nil, nil, "/tmp/uploads")
+	assert.Empty(t, processor.configVersion)
+
+	processor.SetConfigVersion("150-400-800-webp-q75")
+	assert.Equal(t, "150-400-800-webp-q75", processor.configVersion)
+}
+
 // =============================================================================
 // ThumbnailProcessor.ProcessTask Tests - Invalid Payload
 // =============================================================================
@@ -0,0 +1,73 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/hibiken/asynq"
+)
+
+// CleanupUploadDir removes the files in dir last modified more than maxAge
+// before now and returns how many it removed. Uploads and thumbnail jobs
+// remove their own temp files; this catches the ones left by a process that
+// crashed mid-way. Subdirectories are left alone and a missing dir is empty.
+func CleanupUploadDir(dir string, maxAge time.Duration, now time.Time) (int, error) {
+	entries, err := os.ReadDir(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to read upload dir: %w", err)
+	}
+
+	cutoff := now.Add(-maxAge)
+	removed := 0
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			// Removed by its owner since the listing.
+			continue
+		}
+		if !info.ModTime().Before(cutoff) {
+			continue
+		}
+		if err := os.Remove(filepath.Join(dir, entry.Name())); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return removed, fmt.Errorf("failed to remove %s: %w", entry.Name(), err)
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// ProcessUploadTempCleanup removes stale temp files from the photo
+// processing directory, when CleanupConfig.UploadDir is set and
+// UploadTempMaxAge is positive.
+func (p *CleanupProcessor) ProcessUploadTempCleanup(ctx context.Context, t *asynq.Task) error {
+	if p.config.UploadDir == "" || p.config.UploadTempMaxAge <= 0 {
+		log.Printf("Upload temp file cleanup disabled, skipping")
+		return nil
+	}
+
+	removed, err := CleanupUploadDir(p.config.UploadDir, p.config.UploadTempMaxAge, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to cleanup upload temp files: %w", err)
+	}
+
+	log.Printf("Upload temp file cleanup completed: removed %d files older than %s from %s",
+		removed, p.config.UploadTempMaxAge, p.config.UploadDir)
+	return nil
+}
+
+// NewCleanupUploadTempTask creates a task to remove stale upload temp files.
+func NewCleanupUploadTempTask() *asynq.Task {
+	return asynq.NewTask(TypeCleanupUploadTemp, nil)
+}
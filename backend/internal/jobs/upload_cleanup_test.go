@@ -0,0 +1,78 @@
+package jobs
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeUploadFile(t *testing.T, dir, name string, modTime time.Time) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	require.NoError(t, os.WriteFile(path, []byte("x"), 0o644))
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+	return path
+}
+
+func TestCleanupUploadDir(t *testing.T) {
+	now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+	t.Run("removes only files older than the max age", func(t *testing.T) {
+		dir := t.TempDir()
+		stale := writeUploadFile(t, dir, "upload-stale.jpg", now.Add(-25*time.Hour))
+		fresh := writeUploadFile(t, dir, "upload-fresh.jpg", now.Add(-time.Hour))
+		require.NoError(t, os.Mkdir(filepath.Join(dir, "nested"), 0o755))
+
+		removed, err := CleanupUploadDir(dir, 24*time.Hour, now)
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, removed)
+		assert.NoFileExists(t, stale)
+		assert.FileExists(t, fresh)
+		assert.DirExists(t, filepath.Join(dir, "nested"))
+	})
+
+	t.Run("missing directory is empty", func(t *testing.T) {
+		removed, err := CleanupUploadDir(filepath.Join(t.TempDir(), "gone"), time.Hour, now)
+
+		require.NoError(t, err)
+		assert.Zero(t, removed)
+	})
+}
+
+func TestProcessUploadTempCleanup(t *testing.T) {
+	t.Run("sweeps the configured directory", func(t *testing.T) {
+		dir := t.TempDir()
+		stale := writeUploadFile(t, dir, "thumb-src-1", time.Now().Add(-48*time.Hour))
+		config := DefaultCleanupConfig()
+		config.UploadDir = dir
+
+		err := NewCleanupProcessor(nil, config).ProcessUploadTempCleanup(context.Background(), NewCleanupUploadTempTask())
+
+		require.NoError(t, err)
+		assert.NoFileExists(t, stale)
+	})
+
+	t.Run("disabled with a zero max age", func(t *testing.T) {
+		dir := t.TempDir()
+		stale := writeUploadFile(t, dir, "thumb-src-2", time.Now().Add(-48*time.Hour))
+		config := CleanupConfig{UploadDir: dir}
+
+		err := NewCleanupProcessor(nil, config).ProcessUploadTempCleanup(context.Background(), NewCleanupUploadTempTask())
+
+		require.NoError(t, err)
+		assert.FileExists(t, stale)
+	})
+}
+
+func TestNewCleanupUploadTempTask_Type(t *testing.T) {
+	task := NewCleanupUploadTempTask()
+
+	assert.Equal(t, "cleanup:upload_temp", task.Type())
+	assert.Nil(t, task.Payload())
+}
@@ -0,0 +1,243 @@
+package jobs
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"github.com/antti/home-warehouse/go-backend/internal/infra/queries"
+)
+
+// Webhook delivery request headers.
+//
+// Receivers verify a delivery by computing HMAC-SHA256 over the raw request
+// body, keyed with the webhook's secret, and comparing its hex encoding
+// (in constant time) with the value after "sha256=" in X-Webhook-Signature.
+// The body must be verified exactly as received, before any JSON decoding.
+// Retries of one event resend the same body with the same X-Webhook-Delivery
+// ID, so receivers can drop duplicates by that ID.
+const (
+	HeaderWebhookSignature = "X-Webhook-Signature"
+	HeaderWebhookEvent     = "X-Webhook-Event"
+	HeaderWebhookDelivery  = "X-Webhook-Delivery"
+)
+
+const (
+	// webhookMaxRetry is how many times a failed delivery is retried, spaced
+	// out by the scheduler's exponential RetryDelayFunc.
+	webhookMaxRetry = 8
+
+	// webhookRequestTimeout bounds a single POST to a receiver.
+	webhookRequestTimeout = 10 * time.Second
+	webhookDialTimeout    = 5 * time.Second
+
+	webhookUserAgent = "HomeWarehouse-Webhooks/1.0"
+)
+
+// Delivery statuses, mirroring webhook.DeliveryStatus.
+const (
+	webhookDeliveryPending   = "pending"
+	webhookDeliverySucceeded = "succeeded"
+	webhookDeliveryFailed    = "failed"
+)
+
+// errWebhookBlockedAddress is returned when a webhook's host resolves to an
+// address deliveries must not reach.
+var errWebhookBlockedAddress = errors.New("webhook URL resolves to a loopback, link-local or unspecified address")
+
+// WebhookDeliveryPayload contains data for a webhook delivery task.
+type WebhookDeliveryPayload struct {
+	DeliveryID uuid.UUID `json:"delivery_id"`
+}
+
+// NewWebhookDeliveryTask creates a task that sends a recorded delivery.
+func NewWebhookDeliveryTask(deliveryID uuid.UUID) *asynq.Task {
+	payload, _ := json.Marshal(WebhookDeliveryPayload{DeliveryID: deliveryID})
+	return asynq.NewTask(TypeWebhookDelivery, payload,
+		asynq.MaxRetry(webhookMaxRetry),
+		asynq.Timeout(time.Minute),
+		asynq.Queue(QueueDefault),
+	)
+}
+
+// SignWebhookPayload returns the X-Webhook-Signature value for body:
+// "sha256=" followed by the hex HMAC-SHA256 of body keyed with secret.
+func SignWebhookPayload(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// webhookDeliveryStore is the subset of queries used to send deliveries,
+// split out so tests don't need a database.
+type webhookDeliveryStore interface {
+	GetWebhookDeliveryTarget(ctx context.Context, id uuid.UUID) (queries.GetWebhookDeliveryTargetRow, error)
+	RecordWebhookDeliveryAttempt(ctx context.Context, arg queries.RecordWebhookDeliveryAttemptParams) error
+}
+
+// WebhookDeliveryProcessor POSTs recorded webhook deliveries and records
+// the outcome of every attempt.
+type WebhookDeliveryProcessor struct {
+	store  webhookDeliveryStore
+	client *http.Client
+	// isFinalAttempt reports whether asynq will not retry this task again.
+	isFinalAttempt func(ctx context.Context) bool
+}
+
+// NewWebhookDeliveryProcessor creates a new webhook delivery processor.
+func NewWebhookDeliveryProcessor(pool *pgxpool.Pool) *WebhookDeliveryProcessor {
+	return newWebhookDeliveryProcessor(queries.New(pool), newWebhookHTTPClient(isBlockedWebhookIP))
+}
+
+// newWebhookHTTPClient returns the client deliveries are POSTed with.
+// webhook.validateURL only sees literal addresses when a webhook is saved, so
+// blocked reports whether the address actually dialed, after DNS
+// resolution, may be reached.
+func newWebhookHTTPClient(blocked func(net.IP) bool) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: webhookDialTimeout,
+		Control: func(_, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || blocked(ip) {
+				return errWebhookBlockedAddress
+			}
+			return nil
+		},
+	}
+	return &http.Client{
+		Transport: &http.Transport{DialContext: dialer.DialContext},
+		Timeout:   webhookRequestTimeout,
+		// Redirects are not followed: the registered URL is the only target
+		// the workspace admin approved.
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// isBlockedWebhookIP mirrors webhook.validateURL: private networks are
+// allowed, since home automation usually lives on the LAN, but the server
+// itself and link-local addresses (cloud metadata) are not.
+func isBlockedWebhookIP(ip net.IP) bool {
+	return ip.IsLoopback() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsUnspecified()
+}
+
+func newWebhookDeliveryProcessor(store webhookDeliveryStore, client *http.Client) *WebhookDeliveryProcessor {
+	return &WebhookDeliveryProcessor{
+		store:          store,
+		client:         client,
+		isFinalAttempt: isFinalAttempt,
+	}
+}
+
+func isFinalAttempt(ctx context.Context) bool {
+	retried, _ := asynq.GetRetryCount(ctx)
+	maxRetry, _ := asynq.GetMaxRetry(ctx)
+	return retried >= maxRetry
+}
+
+// ProcessTask sends one delivery. A non-2xx answer or transport error is
+// recorded and returned so asynq retries with backoff; once retries are
+// exhausted, or at once for a blocked address, the delivery is marked failed
+// and the task completes, since an unreachable receiver is not a fault worth
+// a dead letter.
+func (p *WebhookDeliveryProcessor) ProcessTask(ctx context.Context, t *asynq.Task) error {
+	var payload WebhookDeliveryPayload
+	if err := json.Unmarshal(t.Payload(), &payload); err != nil {
+		return fmt.Errorf("unmarshal payload: %w", err)
+	}
+
+	target, err := p.store.GetWebhookDeliveryTarget(ctx, payload.DeliveryID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// The webhook was deleted, taking its deliveries with it.
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("load delivery: %w", err)
+	}
+	if target.Status != webhookDeliveryPending {
+		return nil
+	}
+	if !target.IsActive {
+		return p.record(ctx, target.ID, webhookDeliveryFailed, nil, "webhook disabled before delivery")
+	}
+
+	statusCode, sendErr := p.send(ctx, target)
+	var responseStatus *int32
+	if statusCode != 0 {
+		code := int32(statusCode)
+		responseStatus = &code
+	}
+
+	if sendErr == nil {
+		return p.record(ctx, target.ID, webhookDeliverySucceeded, responseStatus, "")
+	}
+
+	// A blocked address will not unblock by retrying.
+	if errors.Is(sendErr, errWebhookBlockedAddress) || p.isFinalAttempt(ctx) {
+		log.Printf("Webhook delivery %s failed permanently: %v", target.ID, sendErr)
+		return p.record(ctx, target.ID, webhookDeliveryFailed, responseStatus, sendErr.Error())
+	}
+	if err := p.record(ctx, target.ID, webhookDeliveryPending, responseStatus, sendErr.Error()); err != nil {
+		return err
+	}
+	return sendErr
+}
+
+// send POSTs the stored payload and returns the response status code (0 when
+// no response was received) and an error unless the receiver answered 2xx.
+func (p *WebhookDeliveryProcessor) send(ctx context.Context, target queries.GetWebhookDeliveryTargetRow) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target.Url, bytes.NewReader(target.Payload))
+	if err != nil {
+		return 0, fmt.Errorf("build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", webhookUserAgent)
+	req.Header.Set(HeaderWebhookEvent, target.EventType)
+	req.Header.Set(HeaderWebhookDelivery, target.ID.String())
+	req.Header.Set(HeaderWebhookSignature, SignWebhookPayload(target.Secret, target.Payload))
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("post webhook: %w", err)
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("webhook responded with status %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+func (p *WebhookDeliveryProcessor) record(ctx context.Context, id uuid.UUID, status string, responseStatus *int32, lastError string) error {
+	params := queries.RecordWebhookDeliveryAttemptParams{
+		ID:             id,
+		Status:         status,
+		ResponseStatus: responseStatus,
+	}
+	if lastError != "" {
+		params.LastError = &lastError
+	}
+	if err := p.store.RecordWebhookDeliveryAttempt(ctx, params); err != nil {
+		return fmt.Errorf("record delivery attempt: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+package jobs
+
+import (
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/infra/queries"
+)
+
+type fakeWebhookDeliveryStore struct {
+	target   queries.GetWebhookDeliveryTargetRow
+	getErr   error
+	recorded []queries.RecordWebhookDeliveryAttemptParams
+}
+
+func (f *fakeWebhookDeliveryStore) GetWebhookDeliveryTarget(ctx context.Context, id uuid.UUID) (queries.GetWebhookDeliveryTargetRow, error) {
+	return f.target, f.getErr
+}
+
+func (f *fakeWebhookDeliveryStore) RecordWebhookDeliveryAttempt(ctx context.Context, arg queries.RecordWebhookDeliveryAttemptParams) error {
+	f.recorded = append(f.recorded, arg)
+	return nil
+}
+
+func newWebhookTestTarget(url string) queries.GetWebhookDeliveryTargetRow {
+	return queries.GetWebhookDeliveryTargetRow{
+		ID:        uuid.New(),
+		EventType: "loan.created",
+		Payload:   []byte(`{"event":"loan.created"}`),
+		Status:    webhookDeliveryPending,
+		Url:       url,
+		Secret:    "whsec_test",
+		IsActive:  true,
+	}
+}
+
+func TestSignWebhookPayload(t *testing.T) {
+	// Reference value: printf '{"a":1}' | openssl dgst -sha256 -hmac secret
+	assert.Equal(t,
+		"sha256=aa9e2e3575f5d7098b6caccd790888c36d5fdb63342a73bada2d6a51747a8494",
+		SignWebhookPayload("secret", []byte(`{"a":1}`)))
+	assert.NotEqual(t, SignWebhookPayload("secret", []byte("a")), SignWebhookPayload("other", []byte("a")))
+}
+
+func TestWebhookDeliveryProcessor_Success(t *testing.T) {
+	var gotHeaders http.Header
+	var gotBody []byte
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeaders = r.Header.Clone()
+		gotBody, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	store := &fakeWebhookDeliveryStore{target: newWebhookTestTarget(server.URL)}
+	p := newWebhookDeliveryProcessor(store, server.Client())
+
+	err := p.ProcessTask(context.Background(), NewWebhookDeliveryTask(store.target.ID))
+
+	require.NoError(t, err)
+	assert.Equal(t, store.target.Payload, gotBody)
+	assert.Equal(t, SignWebhookPayload("whsec_test", gotBody), gotHeaders.Get(HeaderWebhookSignature))
+	assert.Equal(t, "loan.created", gotHeaders.Get(HeaderWebhookEvent))
+	assert.Equal(t, store.target.ID.String(), gotHeaders.Get(HeaderWebhookDelivery))
+
+	require.Len(t, store.recorded, 1)
+	assert.Equal(t, webhookDeliverySucceeded, store.recorded[0].Status)
+	require.NotNil(t, store.recorded[0].ResponseStatus)
+	assert.EqualValues(t, http.StatusNoContent, *store.recorded[0].ResponseStatus)
+	assert.Nil(t, store.recorded[0].LastError)
+}
+
+func TestWebhookDeliveryProcessor_Failure(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	t.Run("retries while attempts remain", func(t *testing.T) {
+		store := &fakeWebhookDeliveryStore{target: newWebhookTestTarget(server.URL)}
+		p := newWebhookDeliveryProcessor(store, server.Client())
+		p.isFinalAttempt = func(context.Context) bool { return false }
+
+		err := p.ProcessTask(context.Background(), NewWebhookDeliveryTask(store.target.ID))
+
+		assert.ErrorContains(t, err, "status 500")
+		require.Len(t, store.recorded, 1)
+		assert.Equal(t, webhookDeliveryPending, store.recorded[0].Status)
+		require.NotNil(t, store.recorded[0].LastError)
+	})
+
+	t.Run("marks failed on the final attempt", func(t *testing.T) {
+		store := &fakeWebhookDeliveryStore{target: newWebhookTestTarget(server.URL)}
+		p := newWebhookDeliveryProcessor(store, server.Client())
+		p.isFinalAttempt = func(context.Context) bool { return true }
+
+		err := p.ProcessTask(context.Background(), NewWebhookDeliveryTask(store.target.ID))
+
+		require.NoError(t, err)
+		require.Len(t, store.recorded, 1)
+		assert.Equal(t, webhookDeliveryFailed, store.recorded[0].Status)
+	})
+}
+
+func TestWebhookDeliveryProcessor_SkipsWithoutSending(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+	}))
+	defer server.Close()
+
+	t.Run("deleted webhook", func(t *testing.T) {
+		store := &fakeWebhookDeliveryStore{getErr: pgx.ErrNoRows}
+		p := newWebhookDeliveryProcessor(store, server.Client())
+
+		require.NoError(t, p.ProcessTask(context.Background(), NewWebhookDeliveryTask(uuid.New())))
+		assert.Empty(t, store.recorded)
+	})
+
+	t.Run("already delivered", func(t *testing.T) {
+		target := newWebhookTestTarget(server.URL)
+		target.Status = webhookDeliverySucceeded
+		store := &fakeWebhookDeliveryStore{target: target}
+		p := newWebhookDeliveryProcessor(store, server.Client())
+
+		require.NoError(t, p.ProcessTask(context.Background(), NewWebhookDeliveryTask(target.ID)))
+		assert.Empty(t, store.recorded)
+	})
+
+	t.Run("disabled webhook", func(t *testing.T) {
+		target := newWebhookTestTarget(server.URL)
+		target.IsActive = false
+		store := &fakeWebhookDeliveryStore{target: target}
+		p := newWebhookDeliveryProcessor(store, server.Client())
+
+		require.NoError(t, p.ProcessTask(context.Background(), NewWebhookDeliveryTask(target.ID)))
+		require.Len(t, store.recorded, 1)
+		assert.Equal(t, webhookDeliveryFailed, store.recorded[0].Status)
+	})
+
+	assert.Zero(t, calls)
+}
+
+func TestWebhookDeliveryProcessor_BlocksResolvedLoopback(t *testing.T) {
+	var hits int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+	// "localhost" passes as a hostname but resolves to the loopback address
+	// the server listens on.
+	_, port, err := net.SplitHostPort(server.Listener.Addr().String())
+	require.NoError(t, err)
+	url := "http://localhost:" + port + "/hook"
+
+	t.Run("fails the delivery without connecting", func(t *testing.T) {
+		store := &fakeWebhookDeliveryStore{target: newWebhookTestTarget(url)}
+		p := newWebhookDeliveryProcessor(store, newWebhookHTTPClient(isBlockedWebhookIP))
+		p.isFinalAttempt = func(context.Context) bool { return false }
+
+		err := p.ProcessTask(context.Background(), NewWebhookDeliveryTask(store.target.ID))
+
+		require.NoError(t, err, "a blocked address is not retried")
+		assert.Zero(t, hits)
+		require.Len(t, store.recorded, 1)
+		assert.Equal(t, webhookDeliveryFailed, store.recorded[0].Status)
+		require.NotNil(t, store.recorded[0].LastError)
+		assert.Contains(t, *store.recorded[0].LastError, errWebhookBlockedAddress.Error())
+	})
+
+	t.Run("delivers when the address is allowed", func(t *testing.T) {
+		store := &fakeWebhookDeliveryStore{target: newWebhookTestTarget(url)}
+		p := newWebhookDeliveryProcessor(store, newWebhookHTTPClient(func(net.IP) bool { return false }))
+
+		err := p.ProcessTask(context.Background(), NewWebhookDeliveryTask(store.target.ID))
+
+		require.NoError(t, err)
+		assert.Equal(t, 1, hits)
+		require.Len(t, store.recorded, 1)
+		assert.Equal(t, webhookDeliverySucceeded, store.recorded[0].Status)
+	})
+}
+
+func TestIsBlockedWebhookIP(t *testing.T) {
+	for _, addr := range []string{"127.0.0.1", "::1", "169.254.169.254", "0.0.0.0"} {
+		assert.True(t, isBlockedWebhookIP(net.ParseIP(addr)), addr)
+	}
+	for _, addr := range []string{"192.168.1.20", "10.0.0.5", "203.0.113.7"} {
+		assert.False(t, isBlockedWebhookIP(net.ParseIP(addr)), addr)
+	}
+}
@@ -137,6 +137,9 @@ func TestErrorCodes_Auth(t *testing.T) {
 	assert.Equal(t, ErrorCode("AUTH_TOKEN_EXPIRED"), ErrCodeTokenExpired)
 	assert.Equal(t, ErrorCode("AUTH_INVALID_CREDENTIALS"), ErrCodeInvalidCredentials)
 	assert.Equal(t, ErrorCode("AUTH_SESSION_EXPIRED"), ErrCodeSessionExpired)
+	assert.Equal(t, ErrorCode("AUTH_TOTP_REQUIRED"), ErrCodeTOTPRequired)
+	assert.Equal(t, ErrorCode("AUTH_TOTP_INVALID"), ErrCodeTOTPInvalid)
+	assert.Equal(t, ErrorCode("AUTH_LOGIN_LOCKED"), ErrCodeLoginLocked)
 }
 
 func TestErrorCodes_User(t *testing.T) {
@@ -10,6 +10,9 @@ const (
 	ErrCodeTokenExpired       ErrorCode = "AUTH_TOKEN_EXPIRED"       // 1003
 	ErrCodeInvalidCredentials ErrorCode = "AUTH_INVALID_CREDENTIALS" // 1004
 	ErrCodeSessionExpired     ErrorCode = "AUTH_SESSION_EXPIRED"     // 1005
+	ErrCodeTOTPRequired       ErrorCode = "AUTH_TOTP_REQUIRED"       // 1006
+	ErrCodeTOTPInvalid        ErrorCode = "AUTH_TOTP_INVALID"        // 1007
+	ErrCodeLoginLocked        ErrorCode = "AUTH_LOGIN_LOCKED"        // 1008
 )
 
 // User errors (2xxx)
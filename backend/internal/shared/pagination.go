@@ -6,10 +6,29 @@ const (
 	DefaultPageSize = 50
 	// MinPageSize is the minimum allowed page size
 	MinPageSize = 1
-	// MaxPageSize is the maximum allowed page size
+	// MaxPageSize is the default maximum page size (see SetMaxPageSize)
 	MaxPageSize = 100
 )
 
+// maxPageSize is the page-size cap every listing is clamped to.
+var maxPageSize = MaxPageSize
+
+// SetMaxPageSize changes the page-size cap from MaxPageSize. Sizes below
+// MinPageSize are ignored. Not safe for concurrent use with queries — call
+// it during startup wiring. Endpoints whose limit parameter declares its own
+// maximum still reject values above it.
+func SetMaxPageSize(size int) {
+	if size < MinPageSize {
+		return
+	}
+	maxPageSize = size
+}
+
+// MaxPageSizeLimit returns the page-size cap currently in effect.
+func MaxPageSizeLimit() int {
+	return maxPageSize
+}
+
 // Pagination holds pagination parameters.
 type Pagination struct {
 	Page     int
@@ -24,6 +43,20 @@ func DefaultPagination() Pagination {
 	}
 }
 
+// Normalize validates the pagination a client asked for and returns the one
+// a query will use: a page or page size below 1 is rejected with
+// ErrInvalidInput, and a page size above MaxPageSizeLimit is clamped to it.
+func (p Pagination) Normalize() (Pagination, error) {
+	if p.Page < 1 {
+		return p, NewFieldError(ErrInvalidInput, "page", "page must be at least 1")
+	}
+	if p.PageSize < MinPageSize {
+		return p, NewFieldError(ErrInvalidInput, "page_size", "page size must be at least 1")
+	}
+	p.PageSize = min(p.PageSize, maxPageSize)
+	return p, nil
+}
+
 // Offset calculates the offset for SQL queries. It uses the same clamped
 // page size as Limit() so the read window [Offset, Offset+Limit) advances
 // consistently page over page — using the raw PageSize here while Limit()
@@ -35,15 +68,13 @@ func (p Pagination) Offset() int {
 	return (p.Page - 1) * p.Limit()
 }
 
-// Limit returns the page size, ensuring it's within bounds.
+// Limit returns the page size, ensuring it's within bounds. An unset page
+// size is DefaultPageSize (capped like any other).
 func (p Pagination) Limit() int {
 	if p.PageSize < MinPageSize {
-		return DefaultPageSize
-	}
-	if p.PageSize > MaxPageSize {
-		return MaxPageSize
+		return min(DefaultPageSize, maxPageSize)
 	}
-	return p.PageSize
+	return min(p.PageSize, maxPageSize)
 }
 
 // PagedResult represents a paginated response.
@@ -55,18 +86,20 @@ type PagedResult[T any] struct {
 	TotalPages int `json:"total_pages"`
 }
 
-// NewPagedResult creates a new paged result.
+// NewPagedResult creates a new paged result. PageSize and TotalPages
+// reflect the clamped page size the query used (Limit), not the requested one.
 func NewPagedResult[T any](items []T, total int, pagination Pagination) PagedResult[T] {
-	totalPages := total / pagination.PageSize
-	if total%pagination.PageSize > 0 {
+	pageSize := pagination.Limit()
+	totalPages := total / pageSize
+	if total%pageSize > 0 {
 		totalPages++
 	}
 
 	return PagedResult[T]{
 		Items:      items,
 		Total:      total,
-		Page:       pagination.Page,
-		PageSize:   pagination.PageSize,
+		Page:       max(pagination.Page, 1),
+		PageSize:   pageSize,
 		TotalPages: totalPages,
 	}
 }
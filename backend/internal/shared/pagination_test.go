@@ -1,6 +1,9 @@
 package shared
 
-import "testing"
+import (
+	"errors"
+	"testing"
+)
 
 func TestPaginationLimit(t *testing.T) {
 	tests := []struct {
@@ -58,3 +61,70 @@ func TestPaginationOffsetMatchesLimit(t *testing.T) {
 		})
 	}
 }
+
+func TestPaginationNormalize(t *testing.T) {
+	tests := []struct {
+		name     string
+		page     int
+		pageSize int
+		want     int
+		wantErr  bool
+	}{
+		{name: "in-range page size is kept", page: 1, pageSize: 25, want: 25},
+		{name: "over-limit page size is clamped", page: 2, pageSize: 10000, want: MaxPageSize},
+		{name: "zero page size is rejected", page: 1, pageSize: 0, wantErr: true},
+		{name: "negative page size is rejected", page: 1, pageSize: -5, wantErr: true},
+		{name: "zero page is rejected", page: 0, pageSize: 25, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Pagination{Page: tt.page, PageSize: tt.pageSize}.Normalize()
+			if tt.wantErr {
+				if !errors.Is(err, ErrInvalidInput) {
+					t.Fatalf("Normalize() error = %v, want ErrInvalidInput", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Normalize() error = %v", err)
+			}
+			if got.PageSize != tt.want || got.Page != tt.page {
+				t.Errorf("Normalize() = %+v, want page %d size %d", got, tt.page, tt.want)
+			}
+		})
+	}
+}
+
+func TestSetMaxPageSize(t *testing.T) {
+	t.Cleanup(func() { SetMaxPageSize(MaxPageSize) })
+
+	SetMaxPageSize(20)
+	if got := (Pagination{Page: 1, PageSize: 50}).Limit(); got != 20 {
+		t.Errorf("Limit() = %d, want the configured cap 20", got)
+	}
+	if got := (Pagination{Page: 1}).Limit(); got != 20 {
+		t.Errorf("Limit() with unset size = %d, want the default capped to 20", got)
+	}
+
+	SetMaxPageSize(0)
+	if got := MaxPageSizeLimit(); got != 20 {
+		t.Errorf("MaxPageSizeLimit() = %d after SetMaxPageSize(0), want 20 unchanged", got)
+	}
+}
+
+func TestNewPagedResultReportsEffectivePageSize(t *testing.T) {
+	result := NewPagedResult([]int{1, 2, 3}, 250, Pagination{Page: 1, PageSize: 10000})
+
+	if result.PageSize != MaxPageSize {
+		t.Errorf("PageSize = %d, want clamped %d", result.PageSize, MaxPageSize)
+	}
+	if result.TotalPages != 3 {
+		t.Errorf("TotalPages = %d, want 3", result.TotalPages)
+	}
+
+	unset := NewPagedResult([]int{}, 0, Pagination{})
+	if unset.Page != 1 || unset.PageSize != DefaultPageSize {
+		t.Errorf("unset pagination reported page %d size %d, want 1 and %d", unset.Page, unset.PageSize, DefaultPageSize)
+	}
+}
@@ -0,0 +1,112 @@
+package totp
+
+import (
+	"encoding/base32"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rfcSecret is the SHA1 key from RFC 6238 Appendix B.
+var rfcSecret = base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString([]byte("12345678901234567890"))
+
+func TestCode_RFC6238Vectors(t *testing.T) {
+	// The RFC lists 8-digit values; these are their last 6 digits.
+	vectors := []struct {
+		unix int64
+		want string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+		{20000000000, "353130"},
+	}
+
+	for _, v := range vectors {
+		got, err := Code(rfcSecret, time.Unix(v.unix, 0))
+		require.NoError(t, err)
+		assert.Equal(t, v.want, got, "t=%d", v.unix)
+	}
+}
+
+func TestValidate_Window(t *testing.T) {
+	secret, err := GenerateSecret()
+	require.NoError(t, err)
+	now := time.Unix(1_700_000_010, 0)
+
+	codeAt := func(offset time.Duration) string {
+		c, err := Code(secret, now.Add(offset))
+		require.NoError(t, err)
+		return c
+	}
+
+	tests := []struct {
+		name   string
+		offset time.Duration
+		skew   int
+		want   bool
+	}{
+		{"current step", 0, 1, true},
+		{"previous step within skew", -Period, 1, true},
+		{"next step within skew", Period, 1, true},
+		{"two steps old", -2 * Period, 1, false},
+		{"two steps ahead", 2 * Period, 1, false},
+		{"previous step without skew", -Period, 0, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok := Validate(secret, codeAt(tt.offset), now, tt.skew)
+			assert.Equal(t, tt.want, ok)
+			if ok {
+				assert.Equal(t, Step(now.Add(tt.offset)), step)
+			}
+		})
+	}
+}
+
+func TestValidate_RejectsMalformedInput(t *testing.T) {
+	now := time.Now()
+	code, err := Code(rfcSecret, now)
+	require.NoError(t, err)
+
+	_, ok := Validate(rfcSecret, " "+code+" ", now, 1)
+	assert.True(t, ok, "surrounding whitespace is ignored")
+
+	for _, c := range []string{"", "12345", "1234567", "abcdef"} {
+		_, ok := Validate(rfcSecret, c, now, 1)
+		assert.False(t, ok, "code %q", c)
+	}
+
+	_, ok = Validate("not base32!", code, now, 1)
+	assert.False(t, ok)
+}
+
+func TestGenerateSecret(t *testing.T) {
+	a, err := GenerateSecret()
+	require.NoError(t, err)
+	b, err := GenerateSecret()
+	require.NoError(t, err)
+
+	assert.Len(t, a, 32) // 20 bytes, unpadded base32
+	assert.NotEqual(t, a, b)
+}
+
+func TestProvisioningURI(t *testing.T) {
+	uri := ProvisioningURI("Home Warehouse", "jane@example.com", "JBSWY3DPEHPK3PXP")
+
+	u, err := url.Parse(uri)
+	require.NoError(t, err)
+	assert.Equal(t, "otpauth", u.Scheme)
+	assert.Equal(t, "totp", u.Host)
+	assert.Equal(t, "/Home Warehouse:jane@example.com", u.Path)
+	assert.Equal(t, "JBSWY3DPEHPK3PXP", u.Query().Get("secret"))
+	assert.Equal(t, "Home Warehouse", u.Query().Get("issuer"))
+	assert.Equal(t, "6", u.Query().Get("digits"))
+	assert.Equal(t, "30", u.Query().Get("period"))
+}
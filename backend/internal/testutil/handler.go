@@ -91,6 +91,16 @@ func (h *HandlerTestSetup) Get(path string) *httptest.ResponseRecorder {
 	return h.Request("GET", path, "")
 }
 
+// GetWithHeader makes a GET request with one extra request header, e.g.
+// If-None-Match
+func (h *HandlerTestSetup) GetWithHeader(path, key, value string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("GET", path, nil)
+	req.Header.Set(key, value)
+	rec := httptest.NewRecorder()
+	h.Router.ServeHTTP(rec, req)
+	return rec
+}
+
 // Post makes a POST request with JSON body
 func (h *HandlerTestSetup) Post(path, body string) *httptest.ResponseRecorder {
 	return h.Request("POST", path, body)
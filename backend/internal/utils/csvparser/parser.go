@@ -11,12 +11,43 @@ import (
 type CSVParser struct {
 	filePath string
 	headers  []string
+	mapping  map[string]string
 }
 
 func NewCSVParser(filePath string) *CSVParser {
 	return &CSVParser{filePath: filePath}
 }
 
+// SetColumnMapping renames source headers to the given names before rows are
+// keyed by them. Keys are matched case-insensitively; headers without an
+// entry keep their normalized (lowercased, trimmed) name.
+func (p *CSVParser) SetColumnMapping(mapping map[string]string) {
+	p.mapping = make(map[string]string, len(mapping))
+	for from, to := range mapping {
+		p.mapping[normalizeHeader(from)] = normalizeHeader(to)
+	}
+}
+
+// ReadHeaders reads just the header row, normalized and mapped, without
+// consuming any data rows.
+func (p *CSVParser) ReadHeaders() ([]string, error) {
+	file, err := os.Open(p.filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	reader := csv.NewReader(file)
+	reader.TrimLeadingSpace = true
+
+	headers, err := reader.Read()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read headers: %w", err)
+	}
+	p.headers = p.normalizeHeaders(headers)
+	return p.headers, nil
+}
+
 func (p *CSVParser) Parse() ([]map[string]string, error) {
 	file, err := os.Open(p.filePath)
 	if err != nil {
@@ -33,10 +64,8 @@ func (p *CSVParser) Parse() ([]map[string]string, error) {
 		return nil, fmt.Errorf("failed to read headers: %w", err)
 	}
 
-	// Normalize headers (lowercase, trim spaces)
-	for i, h := range headers {
-		headers[i] = strings.TrimSpace(strings.ToLower(h))
-	}
+	// Normalize headers (lowercase, trim spaces, apply column mapping)
+	headers = p.normalizeHeaders(headers)
 	p.headers = headers
 
 	// Read all rows
@@ -82,10 +111,8 @@ func (p *CSVParser) ParseStream(callback func(rowNum int, row map[string]string)
 		return fmt.Errorf("failed to read headers: %w", err)
 	}
 
-	// Normalize headers
-	for i, h := range headers {
-		headers[i] = strings.TrimSpace(strings.ToLower(h))
-	}
+	// Normalize headers (lowercase, trim spaces, apply column mapping)
+	headers = p.normalizeHeaders(headers)
 	p.headers = headers
 
 	// Stream rows
@@ -118,6 +145,22 @@ func (p *CSVParser) ParseStream(callback func(rowNum int, row map[string]string)
 	return nil
 }
 
+func (p *CSVParser) normalizeHeaders(headers []string) []string {
+	normalized := make([]string, len(headers))
+	for i, h := range headers {
+		h = normalizeHeader(h)
+		if mapped, ok := p.mapping[h]; ok {
+			h = mapped
+		}
+		normalized[i] = h
+	}
+	return normalized
+}
+
+func normalizeHeader(h string) string {
+	return strings.TrimSpace(strings.ToLower(h))
+}
+
 func (p *CSVParser) Headers() []string {
 	return p.headers
 }
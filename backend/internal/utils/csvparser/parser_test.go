@@ -636,3 +636,58 @@ func TestParse_MultipleCalls(t *testing.T) {
 	// Data should be the same
 	assert.Equal(t, rows1[0]["name"], rows2[0]["name"])
 }
+
+// =============================================================================
+// Column Mapping Tests
+// =============================================================================
+
+func writeTempCSV(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "mapping.csv")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestParseStream_ColumnMapping(t *testing.T) {
+	path := writeTempCSV(t, "Product,Maker,Qty\nDrill,Makita,2\n")
+
+	parser := NewCSVParser(path)
+	parser.SetColumnMapping(map[string]string{"product": "name", "MAKER": "Brand"})
+
+	var rows []map[string]string
+	err := parser.ParseStream(func(rowNum int, row map[string]string) error {
+		rows = append(rows, maps.Clone(row))
+		return nil
+	})
+
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, map[string]string{"name": "Drill", "brand": "Makita", "qty": "2"}, rows[0])
+	assert.Equal(t, []string{"name", "brand", "qty"}, parser.Headers())
+}
+
+func TestReadHeaders(t *testing.T) {
+	t.Run("returns normalized headers without mapping", func(t *testing.T) {
+		parser := NewCSVParser(writeTempCSV(t, " Name ,SKU\nDrill,D-1\n"))
+
+		headers, err := parser.ReadHeaders()
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"name", "sku"}, headers)
+	})
+
+	t.Run("applies column mapping", func(t *testing.T) {
+		parser := NewCSVParser(writeTempCSV(t, "Product,SKU\nDrill,D-1\n"))
+		parser.SetColumnMapping(map[string]string{"Product": "name"})
+
+		headers, err := parser.ReadHeaders()
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"name", "sku"}, headers)
+	})
+
+	t.Run("errors on missing file", func(t *testing.T) {
+		_, err := NewCSVParser(testdataPath("nonexistent.csv")).ReadHeaders()
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,303 @@
+package jsonparser
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// ErrNotArray is returned when the file is not a JSON array.
+var ErrNotArray = errors.New("expected a JSON array of objects")
+
+// Record is one element of the array. Scalar values are stringified into
+// Fields under their normalized, mapped key; values that are an object or an
+// array of objects go to Nested, e.g. an item's "inventory".
+type Record struct {
+	Fields map[string]string
+	Nested map[string][]map[string]string
+	// Err reports an element that cannot be imported as it is: not an
+	// object, or holding a value of an unsupported type. Fields still holds
+	// what could be read, for error reporting.
+	Err error
+
+	keys []string // Fields keys in document order, for ReadHeaders
+}
+
+// JSONParser reads an import file holding a JSON array of objects, one
+// record per object, with the same header semantics as csvparser.CSVParser.
+type JSONParser struct {
+	filePath string
+	headers  []string
+	mapping  map[string]string
+}
+
+func NewJSONParser(filePath string) *JSONParser {
+	return &JSONParser{filePath: filePath}
+}
+
+// SetColumnMapping renames top-level keys to the given names. Keys are
+// matched case-insensitively; keys without an entry keep their normalized
+// (lowercased, trimmed) name. Keys of nested objects are only normalized.
+func (p *JSONParser) SetColumnMapping(mapping map[string]string) {
+	p.mapping = make(map[string]string, len(mapping))
+	for from, to := range mapping {
+		p.mapping[normalizeKey(from)] = normalizeKey(to)
+	}
+}
+
+// ReadHeaders returns the scalar keys found across all records, in order of
+// first appearance. It reads the whole file, so a malformed document is
+// reported here before any record is processed.
+func (p *JSONParser) ReadHeaders() ([]string, error) {
+	var headers []string
+	seen := make(map[string]bool)
+	err := p.ParseStream(func(_ int, rec Record) error {
+		for _, key := range rec.keys {
+			if !seen[key] {
+				seen[key] = true
+				headers = append(headers, key)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	p.headers = headers
+	return headers, nil
+}
+
+func (p *JSONParser) Headers() []string {
+	return p.headers
+}
+
+func (p *JSONParser) CountRows() (int, error) {
+	count := 0
+	err := p.ParseStream(func(int, Record) error {
+		count++
+		return nil
+	})
+	return count, err
+}
+
+// ParseStream calls callback for each element of the array, numbering them
+// from 1. A document that is not an array, or is not valid JSON, stops the
+// stream with an error; elements that are valid JSON but not usable records
+// are passed on with Record.Err set.
+func (p *JSONParser) ParseStream(callback func(rowNum int, rec Record) error) error {
+	file, err := os.Open(p.filePath)
+	if err != nil {
+		return fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	dec := json.NewDecoder(file)
+	dec.UseNumber()
+
+	tok, err := dec.Token()
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrNotArray, err)
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return ErrNotArray
+	}
+
+	rowNum := 1
+	for dec.More() {
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return fmt.Errorf("invalid JSON in element %d: %w", rowNum, err)
+		}
+		if err := callback(rowNum, p.record(raw)); err != nil {
+			return err
+		}
+		rowNum++
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return fmt.Errorf("invalid JSON after element %d: %w", rowNum-1, err)
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return fmt.Errorf("unexpected data after the JSON array")
+	}
+	return nil
+}
+
+func (p *JSONParser) record(raw json.RawMessage) Record {
+	rec := Record{Fields: map[string]string{}}
+
+	obj, keys, err := decodeObject(raw)
+	if err != nil {
+		rec.Err = err
+		return rec
+	}
+
+	for _, key := range keys {
+		name := normalizeKey(key)
+		if mapped, ok := p.mapping[name]; ok {
+			name = mapped
+		}
+
+		value := obj[key]
+		if nested, ok, err := decodeNested(value); ok {
+			if err != nil {
+				rec.Err = fmt.Errorf("%s: %w", name, err)
+				continue
+			}
+			if len(nested) == 0 {
+				continue
+			}
+			if rec.Nested == nil {
+				rec.Nested = map[string][]map[string]string{}
+			}
+			rec.Nested[name] = nested
+			continue
+		}
+
+		s, present, err := scalarString(value)
+		if err != nil {
+			rec.Err = fmt.Errorf("%s: %w", name, err)
+			continue
+		}
+		if present {
+			rec.Fields[name] = s
+			rec.keys = append(rec.keys, name)
+		}
+	}
+	return rec
+}
+
+// decodeObject decodes a JSON object, returning its keys in document order.
+func decodeObject(raw json.RawMessage) (map[string]json.RawMessage, []string, error) {
+	trimmed := bytes.TrimSpace(raw)
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		return nil, nil, fmt.Errorf("expected an object, got %s", jsonKind(trimmed))
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(trimmed))
+	dec.UseNumber()
+	if _, err := dec.Token(); err != nil {
+		return nil, nil, err
+	}
+	obj := make(map[string]json.RawMessage)
+	var keys []string
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, nil, err
+		}
+		key, _ := tok.(string)
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, nil, err
+		}
+		if _, dup := obj[key]; !dup {
+			keys = append(keys, key)
+		}
+		obj[key] = value
+	}
+	return obj, keys, nil
+}
+
+// decodeNested reports whether value is an object or an array of objects
+// and, if so, decodes it into string-valued maps. An empty array counts as
+// nested with no elements; an array mixing objects with other values is an
+// error.
+func decodeNested(value json.RawMessage) ([]map[string]string, bool, error) {
+	trimmed := bytes.TrimSpace(value)
+	if len(trimmed) == 0 {
+		return nil, false, nil
+	}
+
+	var elems []json.RawMessage
+	switch trimmed[0] {
+	case '{':
+		elems = []json.RawMessage{trimmed}
+	case '[':
+		if err := json.Unmarshal(trimmed, &elems); err != nil {
+			return nil, true, err
+		}
+		if len(elems) == 0 {
+			return nil, true, nil
+		}
+		if bytes.TrimSpace(elems[0])[0] != '{' {
+			return nil, false, nil
+		}
+	default:
+		return nil, false, nil
+	}
+
+	nested := make([]map[string]string, 0, len(elems))
+	for i, elem := range elems {
+		obj, keys, err := decodeObject(elem)
+		if err != nil {
+			return nil, true, fmt.Errorf("element %d: %w", i+1, err)
+		}
+		fields := make(map[string]string, len(keys))
+		for _, key := range keys {
+			s, present, err := scalarString(obj[key])
+			if err != nil {
+				return nil, true, fmt.Errorf("element %d: %s: %w", i+1, normalizeKey(key), err)
+			}
+			if present {
+				fields[normalizeKey(key)] = s
+			}
+		}
+		nested = append(nested, fields)
+	}
+	return nested, true, nil
+}
+
+// scalarString converts a string, number or boolean to its cell value.
+// present is false for null.
+func scalarString(value json.RawMessage) (s string, present bool, err error) {
+	dec := json.NewDecoder(bytes.NewReader(value))
+	dec.UseNumber()
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return "", false, err
+	}
+	switch v := v.(type) {
+	case nil:
+		return "", false, nil
+	case string:
+		return strings.TrimSpace(v), true, nil
+	case json.Number:
+		return v.String(), true, nil
+	case bool:
+		if v {
+			return "true", true, nil
+		}
+		return "false", true, nil
+	default:
+		return "", false, fmt.Errorf("must be a string, number or boolean, got %s", jsonKind(bytes.TrimSpace(value)))
+	}
+}
+
+func jsonKind(raw []byte) string {
+	if len(raw) == 0 {
+		return "nothing"
+	}
+	switch raw[0] {
+	case '{':
+		return "an object"
+	case '[':
+		return "an array"
+	case '"':
+		return "a string"
+	case 't', 'f':
+		return "a boolean"
+	case 'n':
+		return "null"
+	default:
+		return "a number"
+	}
+}
+
+func normalizeKey(k string) string {
+	return strings.TrimSpace(strings.ToLower(k))
+}
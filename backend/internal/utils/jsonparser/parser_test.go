@@ -0,0 +1,140 @@
+package jsonparser
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeJSON(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "import.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func collect(t *testing.T, p *JSONParser) []Record {
+	t.Helper()
+	var records []Record
+	require.NoError(t, p.ParseStream(func(rowNum int, rec Record) error {
+		assert.Equal(t, len(records)+1, rowNum)
+		records = append(records, rec)
+		return nil
+	}))
+	return records
+}
+
+func TestParseStream_Records(t *testing.T) {
+	p := NewJSONParser(writeJSON(t, `[
+		{"Name": " Drill ", "sku": "D-1", "min_stock_level": 2, "insured": true, "notes": null},
+		{"name": "Saw", "inventory": [{"Location": "Garage", "quantity": 3}, {"location": "Shed"}]},
+		{"name": "Ladder", "inventory": {"location": "Garage"}},
+		{"name": "Rake", "inventory": []}
+	]`))
+
+	records := collect(t, p)
+	require.Len(t, records, 4)
+
+	assert.NoError(t, records[0].Err)
+	assert.Equal(t, map[string]string{"name": "Drill", "sku": "D-1", "min_stock_level": "2", "insured": "true"}, records[0].Fields)
+	assert.Nil(t, records[0].Nested)
+
+	assert.Equal(t, map[string]string{"name": "Saw"}, records[1].Fields)
+	assert.Equal(t, []map[string]string{{"location": "Garage", "quantity": "3"}, {"location": "Shed"}}, records[1].Nested["inventory"])
+
+	assert.Equal(t, []map[string]string{{"location": "Garage"}}, records[2].Nested["inventory"])
+
+	assert.NoError(t, records[3].Err)
+	assert.Nil(t, records[3].Nested)
+}
+
+func TestParseStream_PerRecordErrors(t *testing.T) {
+	p := NewJSONParser(writeJSON(t, `[
+		42,
+		{"name": "Drill", "tags": ["a", "b"]},
+		{"name": "Saw", "inventory": [{"location": "Garage"}, "Shed"]},
+		{"name": "Ladder", "inventory": [{"location": {"name": "Garage"}}]},
+		{"name": "Rake"}
+	]`))
+
+	records := collect(t, p)
+	require.Len(t, records, 5)
+
+	assert.EqualError(t, records[0].Err, "expected an object, got a number")
+	assert.EqualError(t, records[1].Err, "tags: must be a string, number or boolean, got an array")
+	assert.Equal(t, "Drill", records[1].Fields["name"], "readable fields are kept for the error report")
+	assert.EqualError(t, records[2].Err, "inventory: element 2: expected an object, got a string")
+	assert.EqualError(t, records[3].Err, "inventory: element 1: location: must be a string, number or boolean, got an object")
+	assert.NoError(t, records[4].Err)
+}
+
+func TestParseStream_MalformedDocument(t *testing.T) {
+	tests := map[string]string{
+		"object":          `{"name": "Drill"}`,
+		"empty file":      ``,
+		"truncated":       `[{"name": "Drill"}, {"name": `,
+		"invalid element": `[{"name": "Drill"}, {name: "Saw"}]`,
+		"trailing data":   `[{"name": "Drill"}] [{}]`,
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := NewJSONParser(writeJSON(t, content)).ParseStream(func(int, Record) error { return nil })
+			assert.Error(t, err)
+		})
+	}
+
+	err := NewJSONParser(writeJSON(t, `{"items": []}`)).ParseStream(func(int, Record) error { return nil })
+	assert.True(t, errors.Is(err, ErrNotArray))
+}
+
+func TestParseStream_CallbackError(t *testing.T) {
+	p := NewJSONParser(writeJSON(t, `[{"name": "a"}, {"name": "b"}]`))
+	stop := errors.New("stop")
+
+	calls := 0
+	err := p.ParseStream(func(int, Record) error {
+		calls++
+		return stop
+	})
+
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 1, calls)
+}
+
+func TestReadHeaders(t *testing.T) {
+	p := NewJSONParser(writeJSON(t, `[
+		{"Product": "Drill", "SKU": "D-1", "inventory": [{"location": "Garage"}]},
+		{"product": "Saw", "brand": "Acme"}
+	]`))
+	p.SetColumnMapping(map[string]string{"PRODUCT": "Name"})
+
+	headers, err := p.ReadHeaders()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"name", "sku", "brand"}, headers)
+	assert.Equal(t, headers, p.Headers())
+
+	records := collect(t, p)
+	assert.Equal(t, "Saw", records[1].Fields["name"])
+}
+
+func TestReadHeaders_Malformed(t *testing.T) {
+	_, err := NewJSONParser(writeJSON(t, `[{"name": "Drill"},`)).ReadHeaders()
+	assert.Error(t, err)
+}
+
+func TestCountRows(t *testing.T) {
+	count, err := NewJSONParser(writeJSON(t, `[{"name": "a"}, 1, {"name": "b"}]`)).CountRows()
+	require.NoError(t, err)
+	assert.Equal(t, 3, count)
+
+	count, err = NewJSONParser(writeJSON(t, `[]`)).CountRows()
+	require.NoError(t, err)
+	assert.Zero(t, count)
+
+	_, err = NewJSONParser(filepath.Join(t.TempDir(), "missing.json")).CountRows()
+	assert.Error(t, err)
+}
@@ -4,11 +4,15 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"path/filepath"
 	"strconv"
 	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/borrower"
@@ -22,8 +26,11 @@ import (
 	"github.com/antti/home-warehouse/go-backend/internal/infra/events"
 	"github.com/antti/home-warehouse/go-backend/internal/infra/postgres"
 	"github.com/antti/home-warehouse/go-backend/internal/infra/queue"
+	"github.com/antti/home-warehouse/go-backend/internal/infra/urlfetch"
+	"github.com/antti/home-warehouse/go-backend/internal/jobs"
 	"github.com/antti/home-warehouse/go-backend/internal/shared"
 	"github.com/antti/home-warehouse/go-backend/internal/utils/csvparser"
+	"github.com/antti/home-warehouse/go-backend/internal/utils/jsonparser"
 )
 
 const (
@@ -33,10 +40,34 @@ const (
 )
 
 type ImportWorker struct {
-	queue       *queue.Queue
+	queue       jobQueue
 	importRepo  importjob.Repository
 	broadcaster *events.Broadcaster
 	dbPool      *pgxpool.Pool
+	photoTasks  PhotoTaskEnqueuer
+
+	rowsPerSecond int
+	batchSize     int
+	phoneRegion   string
+	concurrency   int
+	active        *atomic.Int32
+	// Per-job state, set up by beginJob.
+	pacer *rowPacer
+	rows  *rowLog
+}
+
+// jobQueue is the part of queue.Queue the worker uses.
+type jobQueue interface {
+	Dequeue(ctx context.Context, timeout time.Duration) (*queue.Job, error)
+	Complete(ctx context.Context, jobID string) error
+	Fail(ctx context.Context, jobID string, errorMsg string) (dead bool, err error)
+	RecoverInFlight(ctx context.Context) (int, error)
+	Length(ctx context.Context) (int64, error)
+}
+
+// PhotoTaskEnqueuer queues background tasks; *asynq.Client satisfies it.
+type PhotoTaskEnqueuer interface {
+	EnqueueContext(ctx context.Context, task *asynq.Task, opts ...asynq.Option) (*asynq.TaskInfo, error)
 }
 
 func NewImportWorker(
@@ -50,15 +81,32 @@ func NewImportWorker(
 		importRepo:  importRepo,
 		broadcaster: broadcaster,
 		dbPool:      dbPool,
+		concurrency: 1,
+		active:      new(atomic.Int32),
 	}
 }
 
-// Start runs the dequeue/process loop until ctx is cancelled. Cancellation is
-// a drain signal: the loop stops dequeuing new jobs, but an in-flight job is
-// finished with a context detached from the shutdown cancellation, so a
-// deploy mid-import does not abort the import half-written. Start returns
-// only after any in-flight job has completed — callers wanting a bounded
-// shutdown should wait on Start's return with their own timeout.
+// SetPhotoEnqueuer enables the photo_url column of item imports: each
+// created item's photo is downloaded by a background task rather than
+// during the import. Without it photo URLs are reported as skipped.
+func (w *ImportWorker) SetPhotoEnqueuer(enqueuer PhotoTaskEnqueuer) {
+	w.photoTasks = enqueuer
+}
+
+// SetPhoneRegion sets the region borrower phone numbers without a country
+// code are read in (see borrower.Service.SetDefaultPhoneRegion).
+func (w *ImportWorker) SetPhoneRegion(region string) {
+	w.phoneRegion = region
+}
+
+// Start runs the dequeue/process loop until ctx is cancelled, processing up
+// to the configured concurrency of jobs at once (see SetConcurrency).
+// Cancellation is a drain signal: the loop stops dequeuing new jobs, but
+// in-flight jobs are finished with a context detached from the shutdown
+// cancellation, so a deploy mid-import does not abort the import
+// half-written. Start returns only after every in-flight job has completed —
+// callers wanting a bounded shutdown should wait on Start's return with
+// their own timeout.
 func (w *ImportWorker) Start(ctx context.Context) error {
 	// Re-enqueue any jobs a previous worker crash left on the in-flight list.
 	if recovered, err := w.queue.RecoverInFlight(ctx); err != nil {
@@ -67,64 +115,82 @@ func (w *ImportWorker) Start(ctx context.Context) error {
 		log.Printf("Recovered %d in-flight job(s) from previous run", recovered)
 	}
 
+	// A job is only dequeued once a slot is free, so jobs over the limit
+	// wait in the queue rather than on the in-flight list.
+	slots := make(chan struct{}, w.concurrency)
+	var inFlight sync.WaitGroup
+	defer inFlight.Wait()
+
 	for {
 		select {
 		case <-ctx.Done():
 			log.Println("Context cancelled, stopping worker...")
 			return nil
+		case slots <- struct{}{}:
+		}
 
-		default:
-			if stop := w.dequeueAndProcess(ctx); stop {
-				return nil
-			}
+		job, stop := w.dequeue(ctx)
+		if stop {
+			return nil
+		}
+		if job == nil {
+			<-slots
+			continue
 		}
+
+		w.active.Add(1)
+		inFlight.Add(1)
+		go func() {
+			defer func() {
+				w.active.Add(-1)
+				<-slots
+				inFlight.Done()
+			}()
+			// Process with a context that survives shutdown cancellation so
+			// an in-flight import drains instead of being killed mid-write.
+			w.process(context.WithoutCancel(ctx), job)
+		}()
 	}
 }
 
-// dequeueAndProcess runs one worker iteration: dequeue a job (blocking up to
-// 5s), process it, and settle its queue state. It returns stop=true only when
+// dequeue waits up to 5s for the next job. It returns stop=true only when
 // shutdown raced the blocking dequeue, signalling Start to return; transient
-// dequeue/process errors are logged and swallowed so the loop keeps running.
-func (w *ImportWorker) dequeueAndProcess(ctx context.Context) (stop bool) {
+// dequeue errors are logged and swallowed so the loop keeps running.
+func (w *ImportWorker) dequeue(ctx context.Context) (job *queue.Job, stop bool) {
 	job, err := w.queue.Dequeue(ctx, 5*time.Second)
 	if err != nil {
 		if ctx.Err() != nil {
 			// Shutdown raced the blocking dequeue — not an error.
 			log.Println("Context cancelled, stopping worker...")
-			return true
+			return nil, true
 		}
 		log.Printf("Error dequeuing job: %v", err)
 		time.Sleep(1 * time.Second)
-		return false
+		return nil, false
 	}
+	return job, false
+}
 
-	if job == nil {
-		// No job available
-		return false
-	}
-
-	// Process with a context that survives shutdown cancellation so an in-flight
-	// import drains instead of being killed mid-write.
-	procCtx := context.WithoutCancel(ctx)
-
-	if err := w.processJob(procCtx, job); err != nil {
+// process runs one dequeued job and settles its queue state. Processing
+// errors are logged; the queue retries or dead-letters the job.
+func (w *ImportWorker) process(ctx context.Context, job *queue.Job) {
+	if err := w.processJob(ctx, job); err != nil {
 		log.Printf("Error processing job %s: %v", job.ID, err)
-		dead, failErr := w.queue.Fail(procCtx, job.ID, err.Error())
+		dead, failErr := w.queue.Fail(ctx, job.ID, err.Error())
 		if failErr != nil {
 			log.Printf("Error marking job as failed: %v", failErr)
 		}
 		if dead {
 			// Retries exhausted: the queue job is dead-lettered; make sure the
 			// import job row reflects the terminal failure.
-			w.markImportJobFailed(procCtx, job, err.Error())
+			w.markImportJobFailed(ctx, job, err.Error())
 		}
-		return false
+		return
 	}
 
-	if err := w.queue.Complete(procCtx, job.ID); err != nil {
+	if err := w.queue.Complete(ctx, job.ID); err != nil {
 		log.Printf("Error completing job: %v", err)
 	}
-	return false
 }
 
 // markImportJobFailed best-effort marks the import job referenced by a
@@ -183,31 +249,113 @@ func (w *ImportWorker) processJob(ctx context.Context, job *queue.Job) error {
 		return fmt.Errorf("failed to fetch import job: %w", err)
 	}
 
+	// Conflict policy for rows whose SKU already exists (absent in payloads
+	// enqueued before the option existed, which parses to the default)
+	onConflict, _ := job.Payload["on_conflict"].(string)
+	policy, err := importjob.ParseConflictPolicy(onConflict)
+	if err != nil {
+		return fmt.Errorf("invalid on_conflict: %w", err)
+	}
+
+	// Which imported photo becomes an item's primary photo (absent in older
+	// payloads, which parses to the default)
+	primaryPhotoStr, _ := job.Payload["primary_photo"].(string)
+	primaryPhoto, err := importjob.ParsePrimaryPhotoPreference(primaryPhotoStr)
+	if err != nil {
+		return fmt.Errorf("invalid primary_photo: %w", err)
+	}
+
+	mapping, err := importjob.ColumnMappingFromPayload(job.Payload["column_mapping"])
+	if err != nil {
+		return fmt.Errorf("invalid column_mapping: %w", err)
+	}
+
+	jw, endJob := w.beginJob()
+	defer endJob(ctx)
+
+	if isJSONImport(importJob) {
+		return jw.processJSONImport(ctx, importJob, mapping, policy, primaryPhoto)
+	}
+
+	parser := csvparser.NewCSVParser(importJob.FilePath())
+	parser.SetColumnMapping(mapping)
+	if ok := jw.checkRequiredColumns(ctx, importJob, parser); !ok {
+		return nil
+	}
+
 	// Process based on entity type
 	switch importJob.EntityType() {
 	case importjob.EntityTypeItems:
-		return w.processItemImport(ctx, importJob)
+		return jw.processItemImport(ctx, importJob, csvItemRecords{parser}, policy, primaryPhoto)
 	case importjob.EntityTypeLocations:
-		return w.processLocationImport(ctx, importJob)
+		return jw.processLocationImport(ctx, importJob, parser)
 	case importjob.EntityTypeContainers:
-		return w.processContainerImport(ctx, importJob)
+		return jw.processContainerImport(ctx, importJob, parser)
 	case importjob.EntityTypeCategories:
-		return w.processCategoryImport(ctx, importJob)
+		return jw.processCategoryImport(ctx, importJob, parser)
 	case importjob.EntityTypeBorrowers:
-		return w.processBorrowerImport(ctx, importJob)
+		return jw.processBorrowerImport(ctx, importJob, parser)
 	case importjob.EntityTypeInventory:
-		return w.processInventoryImport(ctx, importJob)
+		return jw.processInventoryImport(ctx, importJob, parser)
 	default:
 		return fmt.Errorf("unsupported entity type: %s", importJob.EntityType())
 	}
 }
 
-func (w *ImportWorker) processItemImport(ctx context.Context, job *importjob.ImportJob) error {
-	// Parse CSV
-	parser := csvparser.NewCSVParser(job.FilePath())
+// processJSONImport imports a JSON array of item objects. Other entity types
+// have no JSON format; such a job fails without being retried.
+func (w *ImportWorker) processJSONImport(ctx context.Context, job *importjob.ImportJob, mapping importjob.ColumnMapping, policy importjob.ConflictPolicy, primaryPhoto importjob.PrimaryPhotoPreference) error {
+	if job.EntityType() != importjob.EntityTypeItems {
+		job.Fail(fmt.Sprintf("JSON imports are only supported for items, not %s", job.EntityType()))
+		w.saveJob(ctx, job)
+		w.publishProgress(job, 100)
+		return nil
+	}
+
+	parser := jsonparser.NewJSONParser(job.FilePath())
+	parser.SetColumnMapping(mapping)
+	if ok := w.checkRequiredColumns(ctx, job, parser); !ok {
+		return nil
+	}
+	return w.processItemImport(ctx, job, jsonItemRecords{parser}, policy, primaryPhoto)
+}
+
+// isJSONImport reports whether the job's file was uploaded as JSON.
+func isJSONImport(job *importjob.ImportJob) bool {
+	return strings.EqualFold(filepath.Ext(job.FilePath()), importjob.AllowedJSONExt)
+}
+
+// headerReader is the part of an import file parser the column pre-flight
+// needs. For JSON files reading the headers reads the whole document, so a
+// malformed one fails here.
+type headerReader interface {
+	ReadHeaders() ([]string, error)
+}
+
+// checkRequiredColumns fails the import job before any rows are processed
+// when the file's headers, after column mapping, lack a column the entity
+// type requires. It reports whether processing should continue. A failed
+// pre-flight is a problem with the file, not the worker, so it is not
+// retried.
+func (w *ImportWorker) checkRequiredColumns(ctx context.Context, job *importjob.ImportJob, parser headerReader) bool {
+	headers, err := parser.ReadHeaders()
+	if err != nil {
+		job.Fail(fmt.Sprintf("Failed to read headers: %v", err))
+	} else if missing := importjob.MissingColumns(job.EntityType(), headers); len(missing) > 0 {
+		job.Fail(fmt.Sprintf("missing required column(s): %s; found: %s (use column_mapping to map your headers)",
+			strings.Join(missing, ", "), strings.Join(headers, ", ")))
+	} else {
+		return true
+	}
+
+	w.saveJob(ctx, job)
+	w.publishProgress(job, 100)
+	return false
+}
 
+func (w *ImportWorker) processItemImport(ctx context.Context, job *importjob.ImportJob, records itemRecordSource, policy importjob.ConflictPolicy, primaryPhoto importjob.PrimaryPhotoPreference) error {
 	// Count total rows
-	totalRows, err := parser.CountRows()
+	totalRows, err := records.CountRows()
 	if err != nil {
 		job.Fail(fmt.Sprintf(msgFailedToCountRows, err))
 		w.saveJob(ctx, job)
@@ -227,46 +375,40 @@ func (w *ImportWorker) processItemImport(ctx context.Context, job *importjob.Imp
 	itemRepo := postgres.NewItemRepository(w.dbPool)
 	categoryRepo := postgres.NewCategoryRepository(w.dbPool)
 	itemService := item.NewService(itemRepo, categoryRepo)
+	itemService.SetCategoryRuleRepository(postgres.NewCategoryRuleRepository(w.dbPool))
+	store := itemImportStore{Service: itemService, repo: itemRepo}
+	nested := &nestedInventoryImporter{worker: w, job: job}
 
 	// Process rows
 	processedRows := 0
 	successCount := 0
 	errorCount := 0
 
-	err = parser.ParseStream(func(rowNum int, row map[string]string) error {
-		// Map CSV fields to item
-		name := row["name"]
-		if name == "" {
+	err = records.eachRecord(func(rowNum int, rec itemRecord) error {
+		w.pacer.wait()
+		row := rec.row
+		if rec.err != nil {
+			w.saveRowError(ctx, job.ID(), rowNum, nil, rec.err.Error(), row)
+			errorCount++
+		} else if row["name"] == "" {
 			w.saveRowError(ctx, job.ID(), rowNum, strPtr("name"), msgNameIsRequired, row)
 			errorCount++
 		} else {
-			// Get or generate SKU
-			sku := row["sku"]
-			if sku == "" {
-				// Generate a SKU based on name
-				nameLen := len(name)
-				if nameLen > 10 {
-					nameLen = 10
-				}
-				sku = fmt.Sprintf("AUTO-%s-%d", name[:nameLen], time.Now().Unix())
-			}
-
-			// Create item
-			_, err := itemService.Create(ctx, item.CreateInput{
-				WorkspaceID:  job.WorkspaceID(),
-				Name:         name,
-				SKU:          sku,
-				Description:  strPtrFromMap(row, "description"),
-				Brand:        strPtrFromMap(row, "brand"),
-				Model:        strPtrFromMap(row, "model"),
-				Manufacturer: strPtrFromMap(row, "manufacturer"),
-			})
-
+			action, itm, err := importItemRow(ctx, store, job.WorkspaceID(), policy, row)
 			if err != nil {
 				w.saveRowError(ctx, job.ID(), rowNum, nil, err.Error(), row)
 				errorCount++
 			} else {
+				id := itm.ID()
+				// Rows carry no category, so a created item that has one got
+				// it from a category rule.
+				autoCategorized := action == importjob.RowActionCreated && itm.CategoryID() != nil
+				w.saveItemRowResult(ctx, job.ID(), rowNum, action, &id, autoCategorized)
 				successCount++
+				if action == importjob.RowActionCreated {
+					w.queueRowPhotos(ctx, job, rowNum, id, row["photo_url"], primaryPhoto)
+					nested.importAll(ctx, rowNum, itm, rec.inventory)
+				}
 			}
 		}
 
@@ -290,6 +432,7 @@ func (w *ImportWorker) processItemImport(ctx context.Context, job *importjob.Imp
 	}
 
 	// Save final state
+	w.rows.flush(ctx)
 	if err := w.importRepo.SaveJob(ctx, job); err != nil {
 		return err
 	}
@@ -300,8 +443,192 @@ func (w *ImportWorker) processItemImport(ctx context.Context, job *importjob.Imp
 	return nil
 }
 
-func (w *ImportWorker) processLocationImport(ctx context.Context, job *importjob.ImportJob) error {
-	parser := csvparser.NewCSVParser(job.FilePath())
+// photoURLSeparator separates several photo URLs in one photo_url cell.
+const photoURLSeparator = "|"
+
+// queueRowPhotos queues each URL of a created item's photo_url cell, in cell
+// order. Once all of them settle, the import picks the item's primary photo
+// among them according to primaryPhoto.
+func (w *ImportWorker) queueRowPhotos(ctx context.Context, job *importjob.ImportJob, rowNum int, itemID uuid.UUID, cell string, primaryPhoto importjob.PrimaryPhotoPreference) {
+	for _, rawURL := range strings.Split(cell, photoURLSeparator) {
+		w.queueRowPhoto(ctx, job, rowNum, itemID, strings.TrimSpace(rawURL), primaryPhoto)
+	}
+}
+
+// queueRowPhoto records one photo URL of a created item and queues its
+// download. Photo problems never fail the row: an invalid URL, or a server
+// without photo downloads, is recorded as skipped with the reason.
+func (w *ImportWorker) queueRowPhoto(ctx context.Context, job *importjob.ImportJob, rowNum int, itemID uuid.UUID, rawURL string, primaryPhoto importjob.PrimaryPhotoPreference) {
+	if rawURL == "" {
+		return
+	}
+
+	status, message := importjob.PhotoStatusPending, ""
+	if err := urlfetch.ValidateURL(rawURL); err != nil {
+		status, message = importjob.PhotoStatusSkipped, err.Error()
+	} else if w.photoTasks == nil {
+		status, message = importjob.PhotoStatusSkipped, "photo downloads are not enabled on this server"
+	}
+
+	result, err := importjob.NewImportPhotoResult(job.ID(), rowNum, itemID, rawURL, status, optionalString(message))
+	if err != nil {
+		log.Printf("Error building import photo result (job %s row %d): %v", job.ID(), rowNum, err)
+		return
+	}
+	if err := w.importRepo.SavePhotoResult(ctx, result); err != nil {
+		log.Printf("Error saving import photo result (job %s row %d): %v", job.ID(), rowNum, err)
+		return
+	}
+	if status != importjob.PhotoStatusPending {
+		return
+	}
+
+	task := jobs.NewPhotoImportTask(jobs.PhotoImportPayload{
+		ResultID:     result.ID(),
+		ImportJobID:  job.ID(),
+		WorkspaceID:  job.WorkspaceID(),
+		ItemID:       itemID,
+		UserID:       job.UserID(),
+		URL:          rawURL,
+		PrimaryPhoto: string(primaryPhoto),
+	})
+	if _, err := w.photoTasks.EnqueueContext(ctx, task); err != nil {
+		log.Printf("Error queueing import photo (job %s row %d): %v", job.ID(), rowNum, err)
+		msg := "failed to queue photo download"
+		if err := w.importRepo.UpdatePhotoResult(ctx, result.ID(), importjob.PhotoStatusFailed, nil, &msg); err != nil {
+			log.Printf("Error saving import photo result (job %s row %d): %v", job.ID(), rowNum, err)
+		}
+	}
+}
+
+// itemRowStore is what importItemRow needs from the item domain, split out
+// so the conflict policies can be tested without a database.
+type itemRowStore interface {
+	FindBySKU(ctx context.Context, workspaceID uuid.UUID, sku string) (*item.Item, error)
+	Create(ctx context.Context, input item.CreateInput) (*item.Item, error)
+	Update(ctx context.Context, id, workspaceID uuid.UUID, input item.UpdateInput) (*item.Item, error)
+}
+
+// itemImportStore serves creates/updates through the item service (so its
+// validation applies) and SKU lookups straight from the repository.
+type itemImportStore struct {
+	*item.Service
+	repo item.Repository
+}
+
+func (s itemImportStore) FindBySKU(ctx context.Context, workspaceID uuid.UUID, sku string) (*item.Item, error) {
+	return s.repo.FindBySKU(ctx, workspaceID, sku)
+}
+
+// importItemRow creates the item described by row, or applies the conflict
+// policy when its SKU already exists: ConflictError fails the row,
+// ConflictSkip leaves the existing item alone, and ConflictUpdate patches the
+// existing item's mutable fields (name, brand, description, min_stock_level)
+// from the row, leaving blank columns unchanged. The caller has already
+// checked that name is present.
+func importItemRow(ctx context.Context, store itemRowStore, workspaceID uuid.UUID, policy importjob.ConflictPolicy, row map[string]string) (importjob.RowAction, *item.Item, error) {
+	name := row["name"]
+
+	minStockLevel, hasMinStock, err := parseMinStockLevel(row)
+	if err != nil {
+		return "", nil, err
+	}
+
+	sku := row["sku"]
+	if sku == "" {
+		// Generate a SKU based on name
+		nameLen := len(name)
+		if nameLen > 10 {
+			nameLen = 10
+		}
+		sku = fmt.Sprintf("AUTO-%s-%d", name[:nameLen], time.Now().Unix())
+	} else {
+		existing, err := store.FindBySKU(ctx, workspaceID, sku)
+		if err != nil && !shared.IsNotFound(err) {
+			return "", nil, err
+		}
+		if existing != nil {
+			switch policy {
+			case importjob.ConflictSkip:
+				return importjob.RowActionSkipped, existing, nil
+			case importjob.ConflictUpdate:
+				input := itemUpdateInputFrom(existing)
+				input.Name = name
+				if v := strPtrFromMap(row, "brand"); v != nil {
+					input.Brand = v
+				}
+				if v := strPtrFromMap(row, "description"); v != nil {
+					input.Description = v
+				}
+				if hasMinStock {
+					input.MinStockLevel = minStockLevel
+				}
+				updated, err := store.Update(ctx, existing.ID(), workspaceID, input)
+				if err != nil {
+					return "", nil, err
+				}
+				return importjob.RowActionUpdated, updated, nil
+			default:
+				return "", nil, item.ErrSKUTaken
+			}
+		}
+	}
+
+	created, err := store.Create(ctx, item.CreateInput{
+		WorkspaceID:   workspaceID,
+		Name:          name,
+		SKU:           sku,
+		Description:   strPtrFromMap(row, "description"),
+		Brand:         strPtrFromMap(row, "brand"),
+		Model:         strPtrFromMap(row, "model"),
+		Manufacturer:  strPtrFromMap(row, "manufacturer"),
+		MinStockLevel: minStockLevel,
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	return importjob.RowActionCreated, created, nil
+}
+
+// parseMinStockLevel reads the optional min_stock_level column. ok is false
+// when the column is blank.
+func parseMinStockLevel(row map[string]string) (level int, ok bool, err error) {
+	raw := strings.TrimSpace(row["min_stock_level"])
+	if raw == "" {
+		return 0, false, nil
+	}
+	level, err = strconv.Atoi(raw)
+	if err != nil || level < 0 {
+		return 0, false, fmt.Errorf("invalid min_stock_level %q: must be a non-negative integer", raw)
+	}
+	return level, true, nil
+}
+
+// itemUpdateInputFrom returns an UpdateInput that leaves every field of the
+// item as it is, for callers that only want to change a few fields.
+func itemUpdateInputFrom(i *item.Item) item.UpdateInput {
+	return item.UpdateInput{
+		Name:              i.Name(),
+		Description:       i.Description(),
+		CategoryID:        i.CategoryID(),
+		Brand:             i.Brand(),
+		Model:             i.Model(),
+		ImageURL:          i.ImageURL(),
+		SerialNumber:      i.SerialNumber(),
+		Manufacturer:      i.Manufacturer(),
+		Barcode:           i.Barcode(),
+		IsInsured:         i.IsInsured(),
+		LifetimeWarranty:  i.LifetimeWarranty(),
+		WarrantyDetails:   i.WarrantyDetails(),
+		PurchasedFrom:     i.PurchasedFrom(),
+		MinStockLevel:     i.MinStockLevel(),
+		ObsidianVaultPath: i.ObsidianVaultPath(),
+		ObsidianNotePath:  i.ObsidianNotePath(),
+		NeedsReview:       i.NeedsReview(),
+	}
+}
+
+func (w *ImportWorker) processLocationImport(ctx context.Context, job *importjob.ImportJob, parser *csvparser.CSVParser) error {
 
 	totalRows, err := parser.CountRows()
 	if err != nil {
@@ -337,6 +664,7 @@ func (w *ImportWorker) processLocationImport(ctx context.Context, job *importjob
 	errorCount := 0
 
 	err = parser.ParseStream(func(rowNum int, row map[string]string) error {
+		w.pacer.wait()
 		name := row["name"]
 		if name == "" {
 			w.saveRowError(ctx, job.ID(), rowNum, strPtr("name"), msgNameIsRequired, row)
@@ -365,6 +693,8 @@ func (w *ImportWorker) processLocationImport(ctx context.Context, job *importjob
 				// Add to cache for potential parent references
 				locationCache[strings.ToLower(newLoc.Name())] = newLoc
 				locationCache[strings.ToLower(newLoc.ShortCode())] = newLoc
+				id := newLoc.ID()
+				w.saveRowResult(ctx, job.ID(), rowNum, importjob.RowActionCreated, &id)
 				successCount++
 			}
 		}
@@ -390,8 +720,7 @@ func (w *ImportWorker) processLocationImport(ctx context.Context, job *importjob
 	return nil
 }
 
-func (w *ImportWorker) processContainerImport(ctx context.Context, job *importjob.ImportJob) error {
-	parser := csvparser.NewCSVParser(job.FilePath())
+func (w *ImportWorker) processContainerImport(ctx context.Context, job *importjob.ImportJob, parser *csvparser.CSVParser) error {
 
 	totalRows, err := parser.CountRows()
 	if err != nil {
@@ -428,6 +757,7 @@ func (w *ImportWorker) processContainerImport(ctx context.Context, job *importjo
 	errorCount := 0
 
 	err = parser.ParseStream(func(rowNum int, row map[string]string) error {
+		w.pacer.wait()
 		name := row["name"]
 		locationRef := row["location"]
 
@@ -443,7 +773,7 @@ func (w *ImportWorker) processContainerImport(ctx context.Context, job *importjo
 				w.saveRowError(ctx, job.ID(), rowNum, strPtr("location"), fmt.Sprintf("location '%s' not found", locationRef), row)
 				errorCount++
 			} else {
-				_, err := containerService.Create(ctx, container.CreateInput{
+				newContainer, err := containerService.Create(ctx, container.CreateInput{
 					WorkspaceID: job.WorkspaceID(),
 					LocationID:  loc.ID(),
 					Name:        name,
@@ -456,6 +786,8 @@ func (w *ImportWorker) processContainerImport(ctx context.Context, job *importjo
 					w.saveRowError(ctx, job.ID(), rowNum, nil, err.Error(), row)
 					errorCount++
 				} else {
+					id := newContainer.ID()
+					w.saveRowResult(ctx, job.ID(), rowNum, importjob.RowActionCreated, &id)
 					successCount++
 				}
 			}
@@ -482,8 +814,7 @@ func (w *ImportWorker) processContainerImport(ctx context.Context, job *importjo
 	return nil
 }
 
-func (w *ImportWorker) processCategoryImport(ctx context.Context, job *importjob.ImportJob) error {
-	parser := csvparser.NewCSVParser(job.FilePath())
+func (w *ImportWorker) processCategoryImport(ctx context.Context, job *importjob.ImportJob, parser *csvparser.CSVParser) error {
 
 	totalRows, err := parser.CountRows()
 	if err != nil {
@@ -516,6 +847,7 @@ func (w *ImportWorker) processCategoryImport(ctx context.Context, job *importjob
 	errorCount := 0
 
 	err = parser.ParseStream(func(rowNum int, row map[string]string) error {
+		w.pacer.wait()
 		name := row["name"]
 		if name == "" {
 			w.saveRowError(ctx, job.ID(), rowNum, strPtr("name"), msgNameIsRequired, row)
@@ -542,6 +874,8 @@ func (w *ImportWorker) processCategoryImport(ctx context.Context, job *importjob
 			} else {
 				// Add to cache for potential parent references
 				categoryCache[strings.ToLower(newCat.Name())] = newCat
+				id := newCat.ID()
+				w.saveRowResult(ctx, job.ID(), rowNum, importjob.RowActionCreated, &id)
 				successCount++
 			}
 		}
@@ -567,8 +901,7 @@ func (w *ImportWorker) processCategoryImport(ctx context.Context, job *importjob
 	return nil
 }
 
-func (w *ImportWorker) processBorrowerImport(ctx context.Context, job *importjob.ImportJob) error {
-	parser := csvparser.NewCSVParser(job.FilePath())
+func (w *ImportWorker) processBorrowerImport(ctx context.Context, job *importjob.ImportJob, parser *csvparser.CSVParser) error {
 
 	totalRows, err := parser.CountRows()
 	if err != nil {
@@ -585,18 +918,20 @@ func (w *ImportWorker) processBorrowerImport(ctx context.Context, job *importjob
 
 	borrowerRepo := postgres.NewBorrowerRepository(w.dbPool)
 	borrowerService := borrower.NewService(borrowerRepo)
+	borrowerService.SetDefaultPhoneRegion(w.phoneRegion)
 
 	processedRows := 0
 	successCount := 0
 	errorCount := 0
 
 	err = parser.ParseStream(func(rowNum int, row map[string]string) error {
+		w.pacer.wait()
 		name := row["name"]
 		if name == "" {
 			w.saveRowError(ctx, job.ID(), rowNum, strPtr("name"), msgNameIsRequired, row)
 			errorCount++
 		} else {
-			_, err := borrowerService.Create(ctx, borrower.CreateInput{
+			newBorrower, err := borrowerService.Create(ctx, borrower.CreateInput{
 				WorkspaceID: job.WorkspaceID(),
 				Name:        name,
 				Email:       strPtrFromMap(row, "email"),
@@ -608,6 +943,8 @@ func (w *ImportWorker) processBorrowerImport(ctx context.Context, job *importjob
 				w.saveRowError(ctx, job.ID(), rowNum, nil, err.Error(), row)
 				errorCount++
 			} else {
+				id := newBorrower.ID()
+				w.saveRowResult(ctx, job.ID(), rowNum, importjob.RowActionCreated, &id)
 				successCount++
 			}
 		}
@@ -703,6 +1040,20 @@ func parseInventoryQuantity(quantityStr string) int {
 	return 1
 }
 
+// resolveLocation looks up the required location reference of an inventory
+// row. When it is blank or unknown it returns nil and the row error message.
+func (c *inventoryImportCaches) resolveLocation(row map[string]string) (*location.Location, string) {
+	locationRef := row["location"]
+	if locationRef == "" {
+		return nil, "location is required"
+	}
+	loc, ok := c.locations[strings.ToLower(locationRef)]
+	if !ok {
+		return nil, fmt.Sprintf("location '%s' not found", locationRef)
+	}
+	return loc, ""
+}
+
 // resolveContainerID looks up an optional container reference, returning nil
 // when the field is blank or unknown.
 func (c *inventoryImportCaches) resolveContainerID(row map[string]string) *uuid.UUID {
@@ -777,8 +1128,7 @@ func buildInventoryCreateInput(workspaceID uuid.UUID, itm *item.Item, loc *locat
 	}
 }
 
-func (w *ImportWorker) processInventoryImport(ctx context.Context, job *importjob.ImportJob) error {
-	parser := csvparser.NewCSVParser(job.FilePath())
+func (w *ImportWorker) processInventoryImport(ctx context.Context, job *importjob.ImportJob, parser *csvparser.CSVParser) error {
 
 	totalRows, err := parser.CountRows()
 	if err != nil {
@@ -811,6 +1161,7 @@ func (w *ImportWorker) processInventoryImport(ctx context.Context, job *importjo
 	errorCount := 0
 
 	err = parser.ParseStream(func(rowNum int, row map[string]string) error {
+		w.pacer.wait()
 		if w.importInventoryRow(ctx, job, inventoryService, caches, rowNum, row) {
 			successCount++
 		} else {
@@ -859,17 +1210,20 @@ func (w *ImportWorker) importInventoryRow(ctx context.Context, job *importjob.Im
 		w.saveRowError(ctx, job.ID(), rowNum, strPtr("item"), fmt.Sprintf("item '%s' not found", itemRef), row)
 		return false
 	}
-	loc, locOk := caches.locations[strings.ToLower(locationRef)]
-	if !locOk {
-		w.saveRowError(ctx, job.ID(), rowNum, strPtr("location"), fmt.Sprintf("location '%s' not found", locationRef), row)
+	loc, msg := caches.resolveLocation(row)
+	if loc == nil {
+		w.saveRowError(ctx, job.ID(), rowNum, strPtr("location"), msg, row)
 		return false
 	}
 
 	input := buildInventoryCreateInput(job.WorkspaceID(), itm, loc, caches, row)
-	if _, err := inventoryService.Create(ctx, input); err != nil {
+	inv, err := inventoryService.Create(ctx, input)
+	if err != nil {
 		w.saveRowError(ctx, job.ID(), rowNum, nil, err.Error(), row)
 		return false
 	}
+	id := inv.ID()
+	w.saveRowResult(ctx, job.ID(), rowNum, importjob.RowActionCreated, &id)
 	return true
 }
 
@@ -897,6 +1251,11 @@ func (w *ImportWorker) publishProgress(job *importjob.ImportJob, progressPercent
 // silently dropping them. Progress saves are best-effort by design (the next
 // save typically supersedes), but failures must be visible in the logs.
 func (w *ImportWorker) saveJob(ctx context.Context, job *importjob.ImportJob) {
+	// A finished job's row errors and results must be readable once its
+	// final state is.
+	if s := job.Status(); s == importjob.StatusCompleted || s == importjob.StatusFailed {
+		w.rows.flush(ctx)
+	}
 	if err := w.importRepo.SaveJob(ctx, job); err != nil {
 		log.Printf("Error saving import job %s: %v", job.ID(), err)
 	}
@@ -910,11 +1269,41 @@ func (w *ImportWorker) saveRowError(ctx context.Context, jobID uuid.UUID, rowNum
 		log.Printf("Error building import row error (job %s row %d): %v", jobID, rowNum, err)
 		return
 	}
+	if w.rows != nil {
+		w.rows.addError(ctx, importError)
+		return
+	}
 	if err := w.importRepo.SaveError(ctx, importError); err != nil {
 		log.Printf("Error saving import row error (job %s row %d): %v", jobID, rowNum, err)
 	}
 }
 
+// saveRowResult records what happened to a successfully handled row, logging
+// (rather than ignoring) construction or persistence failures.
+func (w *ImportWorker) saveRowResult(ctx context.Context, jobID uuid.UUID, rowNum int, action importjob.RowAction, entityID *uuid.UUID) {
+	w.saveItemRowResult(ctx, jobID, rowNum, action, entityID, false)
+}
+
+// saveItemRowResult is saveRowResult for item rows, which also record
+// whether a category rule categorized the created item.
+func (w *ImportWorker) saveItemRowResult(ctx context.Context, jobID uuid.UUID, rowNum int, action importjob.RowAction, entityID *uuid.UUID, autoCategorized bool) {
+	result, err := importjob.NewImportRowResult(jobID, rowNum, action, entityID)
+	if err != nil {
+		log.Printf("Error building import row result (job %s row %d): %v", jobID, rowNum, err)
+		return
+	}
+	if autoCategorized {
+		result.MarkAutoCategorized()
+	}
+	if w.rows != nil {
+		w.rows.addResult(ctx, result)
+		return
+	}
+	if err := w.importRepo.SaveRowResult(ctx, result); err != nil {
+		log.Printf("Error saving import row result (job %s row %d): %v", jobID, rowNum, err)
+	}
+}
+
 // failJob marks the import job failed, persists it, notifies subscribers, and
 // returns the failure as an error for the queue-level retry path. Used when a
 // job-level precondition (e.g. a lookup-cache load) fails — proceeding with an
@@ -951,6 +1340,13 @@ func strPtr(s string) *string {
 	return &s
 }
 
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
 func strPtrFromMap(m map[string]string, key string) *string {
 	if val, ok := m[key]; ok && val != "" {
 		return &val
@@ -0,0 +1,79 @@
+package worker
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/importjob"
+	"github.com/antti/home-warehouse/go-backend/internal/utils/csvparser"
+)
+
+// savedJobsRepo records SaveJob calls; other Repository methods are unused
+// by the header pre-flight.
+type savedJobsRepo struct {
+	importjob.Repository
+	saved []*importjob.ImportJob
+}
+
+func (r *savedJobsRepo) SaveJob(ctx context.Context, job *importjob.ImportJob) error {
+	r.saved = append(r.saved, job)
+	return nil
+}
+
+func newHeaderCheckJob(t *testing.T, entityType importjob.EntityType, csv string) (*importjob.ImportJob, string) {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "import.csv")
+	require.NoError(t, os.WriteFile(path, []byte(csv), 0o600))
+	job, err := importjob.NewImportJob(uuid.New(), uuid.New(), entityType, "import.csv", path, int64(len(csv)))
+	require.NoError(t, err)
+	return job, path
+}
+
+func TestCheckRequiredColumns(t *testing.T) {
+	t.Run("passes with case-insensitive canonical headers", func(t *testing.T) {
+		repo := &savedJobsRepo{}
+		w := &ImportWorker{importRepo: repo}
+		job, path := newHeaderCheckJob(t, importjob.EntityTypeItems, "NAME,Sku\nDrill,D-1\n")
+
+		ok := w.checkRequiredColumns(context.Background(), job, csvparser.NewCSVParser(path))
+
+		assert.True(t, ok)
+		assert.Empty(t, repo.saved)
+		assert.Equal(t, importjob.StatusPending, job.Status())
+	})
+
+	t.Run("passes when mapping supplies the required column", func(t *testing.T) {
+		repo := &savedJobsRepo{}
+		w := &ImportWorker{importRepo: repo}
+		job, path := newHeaderCheckJob(t, importjob.EntityTypeItems, "Product,SKU\nDrill,D-1\n")
+		parser := csvparser.NewCSVParser(path)
+		parser.SetColumnMapping(map[string]string{"product": "name"})
+
+		ok := w.checkRequiredColumns(context.Background(), job, parser)
+
+		assert.True(t, ok)
+	})
+
+	t.Run("fails the job when a required column is unmapped", func(t *testing.T) {
+		repo := &savedJobsRepo{}
+		w := &ImportWorker{importRepo: repo}
+		job, path := newHeaderCheckJob(t, importjob.EntityTypeInventory, "Product,Where\nDrill,Garage\n")
+		parser := csvparser.NewCSVParser(path)
+		parser.SetColumnMapping(map[string]string{"product": "item"})
+
+		ok := w.checkRequiredColumns(context.Background(), job, parser)
+
+		assert.False(t, ok)
+		assert.Equal(t, importjob.StatusFailed, job.Status())
+		require.NotNil(t, job.ErrorMessage())
+		assert.Contains(t, *job.ErrorMessage(), "missing required column(s): location")
+		assert.Equal(t, 0, job.ProcessedRows())
+		require.Len(t, repo.saved, 1)
+	})
+}
@@ -0,0 +1,34 @@
+package worker
+
+import "context"
+
+// SetConcurrency caps how many import jobs the worker processes at once;
+// further jobs wait in the queue until one finishes. Values below 1 mean 1.
+// Must be called before Start.
+func (w *ImportWorker) SetConcurrency(n int) {
+	if n < 1 {
+		n = 1
+	}
+	w.concurrency = n
+}
+
+// ImportStats is a snapshot of the worker's load, for the health endpoint.
+type ImportStats struct {
+	Queued      int64 // jobs waiting in the queue
+	Active      int   // jobs being processed
+	Concurrency int   // most jobs processed at once
+}
+
+// Stats reports how many jobs are queued and how many are being processed.
+func (w *ImportWorker) Stats(ctx context.Context) (ImportStats, error) {
+	stats := ImportStats{
+		Active:      int(w.active.Load()),
+		Concurrency: w.concurrency,
+	}
+	queued, err := w.queue.Length(ctx)
+	if err != nil {
+		return stats, err
+	}
+	stats.Queued = queued
+	return stats, nil
+}
@@ -0,0 +1,137 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/importjob"
+	"github.com/antti/home-warehouse/go-backend/internal/infra/queue"
+)
+
+// memoryQueue is an in-memory jobQueue that records settled jobs.
+type memoryQueue struct {
+	mu      sync.Mutex
+	jobs    []*queue.Job
+	settled int
+}
+
+func (q *memoryQueue) Dequeue(ctx context.Context, timeout time.Duration) (*queue.Job, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if len(q.jobs) == 0 {
+		time.Sleep(time.Millisecond) // stands in for the blocking wait
+		return nil, nil
+	}
+	job := q.jobs[0]
+	q.jobs = q.jobs[1:]
+	return job, nil
+}
+
+func (q *memoryQueue) Complete(ctx context.Context, jobID string) error {
+	q.settle()
+	return nil
+}
+
+func (q *memoryQueue) Fail(ctx context.Context, jobID string, errorMsg string) (bool, error) {
+	q.settle()
+	return false, nil
+}
+
+func (q *memoryQueue) RecoverInFlight(ctx context.Context) (int, error) { return 0, nil }
+
+func (q *memoryQueue) Length(ctx context.Context) (int64, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return int64(len(q.jobs)), nil
+}
+
+func (q *memoryQueue) settle() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.settled++
+}
+
+func (q *memoryQueue) settledJobs() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.settled
+}
+
+// slowJobsRepo holds every job lookup for a while, tracking how many run at
+// the same time, then fails it so the job ends there.
+type slowJobsRepo struct {
+	importjob.Repository
+	running    atomic.Int32
+	maxRunning atomic.Int32
+}
+
+func (r *slowJobsRepo) FindJobByID(ctx context.Context, id, workspaceID uuid.UUID) (*importjob.ImportJob, error) {
+	n := r.running.Add(1)
+	defer r.running.Add(-1)
+	for {
+		max := r.maxRunning.Load()
+		if n <= max || r.maxRunning.CompareAndSwap(max, n) {
+			break
+		}
+	}
+	time.Sleep(30 * time.Millisecond)
+	return nil, errors.New("stop here")
+}
+
+func TestImportWorker_ConcurrencyCap(t *testing.T) {
+	const jobs = 8
+
+	q := &memoryQueue{}
+	for i := 0; i < jobs; i++ {
+		q.jobs = append(q.jobs, &queue.Job{
+			ID:   uuid.NewString(),
+			Type: "import",
+			Payload: map[string]interface{}{
+				"import_job_id": uuid.NewString(),
+				"workspace_id":  uuid.NewString(),
+			},
+		})
+	}
+	repo := &slowJobsRepo{}
+	w := &ImportWorker{queue: q, importRepo: repo, concurrency: 1, active: new(atomic.Int32)}
+	w.SetConcurrency(3)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_ = w.Start(ctx)
+	}()
+
+	require.Eventually(t, func() bool {
+		stats, err := w.Stats(context.Background())
+		return err == nil && stats.Active == 3 && stats.Queued == jobs-3
+	}, time.Second, time.Millisecond, "three jobs run, the rest stay queued")
+
+	require.Eventually(t, func() bool { return q.settledJobs() == jobs }, 5*time.Second, 5*time.Millisecond)
+	cancel()
+	<-done
+
+	assert.Equal(t, int32(3), repo.maxRunning.Load(), "never more than the cap at once")
+	stats, err := w.Stats(context.Background())
+	require.NoError(t, err)
+	assert.Zero(t, stats.Active)
+	assert.Zero(t, stats.Queued)
+}
+
+func TestImportWorker_SetConcurrency(t *testing.T) {
+	w := &ImportWorker{}
+	w.SetConcurrency(0)
+	assert.Equal(t, 1, w.concurrency, "below 1 means one job at a time")
+
+	w.SetConcurrency(4)
+	assert.Equal(t, 4, w.concurrency)
+}
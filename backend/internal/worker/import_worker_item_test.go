@@ -0,0 +1,144 @@
+package worker
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/importjob"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/item"
+	"github.com/antti/home-warehouse/go-backend/internal/shared"
+)
+
+// fakeItemRowStore is an in-memory itemRowStore keyed by SKU.
+type fakeItemRowStore struct {
+	bySKU   map[string]*item.Item
+	creates []item.CreateInput
+	updates []item.UpdateInput
+}
+
+func newFakeItemRowStore(existing ...*item.Item) *fakeItemRowStore {
+	s := &fakeItemRowStore{bySKU: map[string]*item.Item{}}
+	for _, i := range existing {
+		s.bySKU[i.SKU()] = i
+	}
+	return s
+}
+
+func (s *fakeItemRowStore) FindBySKU(ctx context.Context, workspaceID uuid.UUID, sku string) (*item.Item, error) {
+	if i, ok := s.bySKU[sku]; ok {
+		return i, nil
+	}
+	return nil, shared.ErrNotFound
+}
+
+func (s *fakeItemRowStore) Create(ctx context.Context, input item.CreateInput) (*item.Item, error) {
+	s.creates = append(s.creates, input)
+	return item.NewItem(input.WorkspaceID, input.Name, input.SKU, input.MinStockLevel)
+}
+
+func (s *fakeItemRowStore) Update(ctx context.Context, id, workspaceID uuid.UUID, input item.UpdateInput) (*item.Item, error) {
+	s.updates = append(s.updates, input)
+	for _, i := range s.bySKU {
+		if i.ID() == id {
+			if err := i.Update(input); err != nil {
+				return nil, err
+			}
+			return i, nil
+		}
+	}
+	return nil, shared.ErrNotFound
+}
+
+func TestImportItemRow_ConflictPolicies(t *testing.T) {
+	workspaceID := uuid.New()
+	row := map[string]string{
+		"name":            "Cordless Drill v2",
+		"sku":             "DRILL-001",
+		"brand":           "Makita",
+		"description":     "",
+		"min_stock_level": "2",
+	}
+
+	newExisting := func(t *testing.T) *item.Item {
+		existing, err := item.NewItem(workspaceID, "Cordless Drill", "DRILL-001", 0)
+		require.NoError(t, err)
+		return existing
+	}
+
+	t.Run("error policy fails the row", func(t *testing.T) {
+		store := newFakeItemRowStore(newExisting(t))
+
+		_, _, err := importItemRow(context.Background(), store, workspaceID, importjob.ConflictError, row)
+
+		assert.ErrorIs(t, err, item.ErrSKUTaken)
+		assert.Empty(t, store.creates)
+		assert.Empty(t, store.updates)
+	})
+
+	t.Run("skip policy leaves the existing item alone", func(t *testing.T) {
+		existing := newExisting(t)
+		store := newFakeItemRowStore(existing)
+
+		action, got, err := importItemRow(context.Background(), store, workspaceID, importjob.ConflictSkip, row)
+
+		require.NoError(t, err)
+		assert.Equal(t, importjob.RowActionSkipped, action)
+		assert.Equal(t, existing.ID(), got.ID())
+		assert.Equal(t, "Cordless Drill", got.Name())
+		assert.Empty(t, store.creates)
+		assert.Empty(t, store.updates)
+	})
+
+	t.Run("update policy patches non-blank fields", func(t *testing.T) {
+		existing := newExisting(t)
+		store := newFakeItemRowStore(existing)
+
+		action, got, err := importItemRow(context.Background(), store, workspaceID, importjob.ConflictUpdate, row)
+
+		require.NoError(t, err)
+		assert.Equal(t, importjob.RowActionUpdated, action)
+		assert.Equal(t, existing.ID(), got.ID())
+		require.Len(t, store.updates, 1)
+		update := store.updates[0]
+		assert.Equal(t, "Cordless Drill v2", update.Name)
+		require.NotNil(t, update.Brand)
+		assert.Equal(t, "Makita", *update.Brand)
+		assert.Nil(t, update.Description, "blank column must not overwrite")
+		assert.Equal(t, 2, update.MinStockLevel)
+		assert.Empty(t, store.creates)
+	})
+}
+
+func TestImportItemRow_CreatesNewSKU(t *testing.T) {
+	workspaceID := uuid.New()
+	store := newFakeItemRowStore()
+
+	action, got, err := importItemRow(context.Background(), store, workspaceID, importjob.ConflictSkip, map[string]string{
+		"name":            "Hammer",
+		"sku":             "HAMMER-001",
+		"min_stock_level": "3",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, importjob.RowActionCreated, action)
+	assert.Equal(t, "HAMMER-001", got.SKU())
+	require.Len(t, store.creates, 1)
+	assert.Equal(t, 3, store.creates[0].MinStockLevel)
+}
+
+func TestImportItemRow_InvalidMinStockLevel(t *testing.T) {
+	store := newFakeItemRowStore()
+
+	_, _, err := importItemRow(context.Background(), store, uuid.New(), importjob.ConflictError, map[string]string{
+		"name":            "Hammer",
+		"sku":             "HAMMER-001",
+		"min_stock_level": "lots",
+	})
+
+	assert.ErrorContains(t, err, "min_stock_level")
+	assert.Empty(t, store.creates)
+}
@@ -0,0 +1,107 @@
+package worker
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/importjob"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/inventory"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/item"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/movement"
+	"github.com/antti/home-warehouse/go-backend/internal/infra/postgres"
+	"github.com/antti/home-warehouse/go-backend/internal/utils/csvparser"
+	"github.com/antti/home-warehouse/go-backend/internal/utils/jsonparser"
+)
+
+// itemRecord is one item of an import file, whichever format it came in.
+type itemRecord struct {
+	row       map[string]string   // canonical column -> value
+	inventory []map[string]string // inventory nested in a JSON item object
+	err       error               // the record is unusable; reported as a row error
+}
+
+// itemRecordSource is an item import file: CSV rows or a JSON array of item
+// objects. Both go through the same row validation in processItemImport.
+type itemRecordSource interface {
+	CountRows() (int, error)
+	eachRecord(callback func(rowNum int, rec itemRecord) error) error
+}
+
+type csvItemRecords struct {
+	*csvparser.CSVParser
+}
+
+func (s csvItemRecords) eachRecord(callback func(rowNum int, rec itemRecord) error) error {
+	return s.ParseStream(func(rowNum int, row map[string]string) error {
+		return callback(rowNum, itemRecord{row: row})
+	})
+}
+
+type jsonItemRecords struct {
+	*jsonparser.JSONParser
+}
+
+func (s jsonItemRecords) eachRecord(callback func(rowNum int, rec itemRecord) error) error {
+	return s.ParseStream(func(rowNum int, rec jsonparser.Record) error {
+		return callback(rowNum, itemRecord{row: rec.Fields, inventory: rec.Nested["inventory"], err: rec.Err})
+	})
+}
+
+// inventoryCreator is what nested inventory needs from the inventory domain;
+// *inventory.Service satisfies it.
+type inventoryCreator interface {
+	Create(ctx context.Context, input inventory.CreateInput) (*inventory.Inventory, error)
+}
+
+// nestedInventoryImporter creates the inventory nested in JSON item objects,
+// using the same columns and defaults as an inventory import (without the
+// item column). Its lookup caches are loaded on first use, so imports
+// without nested inventory never pay for them.
+type nestedInventoryImporter struct {
+	worker  *ImportWorker
+	job     *importjob.ImportJob
+	creator inventoryCreator
+	caches  *inventoryImportCaches
+	loadErr error
+}
+
+func (n *nestedInventoryImporter) load(ctx context.Context) error {
+	if n.caches != nil || n.loadErr != nil {
+		return n.loadErr
+	}
+
+	itemRepo := postgres.NewItemRepository(n.worker.dbPool)
+	locationRepo := postgres.NewLocationRepository(n.worker.dbPool)
+	containerRepo := postgres.NewContainerRepository(n.worker.dbPool)
+	inventoryRepo := postgres.NewInventoryRepository(n.worker.dbPool)
+	movementService := movement.NewService(postgres.NewMovementRepository(n.worker.dbPool))
+	n.creator = inventory.NewService(inventoryRepo, movementService, itemRepo, locationRepo, containerRepo)
+	n.caches, n.loadErr = buildInventoryImportCaches(ctx, n.job.WorkspaceID(), itemRepo, locationRepo, containerRepo)
+	return n.loadErr
+}
+
+// importAll creates the inventory entries of a newly created item. Problems
+// are recorded as errors on the item's row, with the field naming the entry
+// (e.g. "inventory[2].location"); the item itself stays imported.
+func (n *nestedInventoryImporter) importAll(ctx context.Context, rowNum int, itm *item.Item, entries []map[string]string) {
+	if len(entries) == 0 {
+		return
+	}
+	if err := n.load(ctx); err != nil {
+		n.worker.saveRowError(ctx, n.job.ID(), rowNum, strPtr("inventory"), fmt.Sprintf("inventory not imported: %v", err), nil)
+		return
+	}
+
+	for i, entry := range entries {
+		field := fmt.Sprintf("inventory[%d]", i+1)
+		loc, msg := n.caches.resolveLocation(entry)
+		if loc == nil {
+			n.worker.saveRowError(ctx, n.job.ID(), rowNum, strPtr(field+".location"), msg, entry)
+			continue
+		}
+		input := buildInventoryCreateInput(n.job.WorkspaceID(), itm, loc, n.caches, entry)
+		if _, err := n.creator.Create(ctx, input); err != nil {
+			n.worker.saveRowError(ctx, n.job.ID(), rowNum, strPtr(field), err.Error(), entry)
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package worker
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/importjob"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/inventory"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/item"
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/location"
+	"github.com/antti/home-warehouse/go-backend/internal/utils/csvparser"
+	"github.com/antti/home-warehouse/go-backend/internal/utils/jsonparser"
+)
+
+// rowErrorsRepo records SaveJob and SaveError calls.
+type rowErrorsRepo struct {
+	savedJobsRepo
+	errors []*importjob.ImportError
+}
+
+func (r *rowErrorsRepo) SaveError(ctx context.Context, importError *importjob.ImportError) error {
+	r.errors = append(r.errors, importError)
+	return nil
+}
+
+// fakeInventoryCreator records creates, failing those at failLocation.
+type fakeInventoryCreator struct {
+	creates      []inventory.CreateInput
+	failLocation uuid.UUID
+}
+
+func (f *fakeInventoryCreator) Create(ctx context.Context, input inventory.CreateInput) (*inventory.Inventory, error) {
+	if input.LocationID == f.failLocation {
+		return nil, errors.New("location is archived")
+	}
+	f.creates = append(f.creates, input)
+	return nil, nil
+}
+
+func newJSONImportJob(t *testing.T, entityType importjob.EntityType, content string) *importjob.ImportJob {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "import.json")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	job, err := importjob.NewImportJob(uuid.New(), uuid.New(), entityType, "export.json", path, int64(len(content)))
+	require.NoError(t, err)
+	return job
+}
+
+func collectItemRecords(t *testing.T, src itemRecordSource) []itemRecord {
+	t.Helper()
+	var records []itemRecord
+	require.NoError(t, src.eachRecord(func(rowNum int, rec itemRecord) error {
+		assert.Equal(t, len(records)+1, rowNum)
+		records = append(records, rec)
+		return nil
+	}))
+	return records
+}
+
+func TestItemRecordSources(t *testing.T) {
+	t.Run("CSV rows have no nested inventory", func(t *testing.T) {
+		_, path := newHeaderCheckJob(t, importjob.EntityTypeItems, "name,sku\nDrill,D-1\n")
+
+		records := collectItemRecords(t, csvItemRecords{csvparser.NewCSVParser(path)})
+
+		require.Len(t, records, 1)
+		assert.Equal(t, map[string]string{"name": "Drill", "sku": "D-1"}, records[0].row)
+		assert.Nil(t, records[0].inventory)
+		assert.NoError(t, records[0].err)
+	})
+
+	t.Run("JSON objects carry their inventory and errors", func(t *testing.T) {
+		job := newJSONImportJob(t, importjob.EntityTypeItems,
+			`[{"name": "Drill", "sku": "D-1", "inventory": [{"location": "Garage", "quantity": 2}]}, "Saw"]`)
+
+		records := collectItemRecords(t, jsonItemRecords{jsonparser.NewJSONParser(job.FilePath())})
+
+		require.Len(t, records, 2)
+		assert.Equal(t, map[string]string{"name": "Drill", "sku": "D-1"}, records[0].row)
+		assert.Equal(t, []map[string]string{{"location": "Garage", "quantity": "2"}}, records[0].inventory)
+		assert.EqualError(t, records[1].err, "expected an object, got a string")
+	})
+}
+
+func TestProcessJSONImport_FailsFast(t *testing.T) {
+	t.Run("malformed JSON", func(t *testing.T) {
+		repo := &savedJobsRepo{}
+		w := &ImportWorker{importRepo: repo}
+		job := newJSONImportJob(t, importjob.EntityTypeItems, `[{"name": "Drill"}, {"name": `)
+
+		err := w.processJSONImport(context.Background(), job, nil, importjob.ConflictError, importjob.PrimaryPhotoFirst)
+
+		require.NoError(t, err, "a bad file is not retried")
+		assert.Equal(t, importjob.StatusFailed, job.Status())
+		require.NotNil(t, job.ErrorMessage())
+		assert.Contains(t, *job.ErrorMessage(), "Failed to read headers")
+		assert.Equal(t, 0, job.ProcessedRows())
+	})
+
+	t.Run("not an array", func(t *testing.T) {
+		repo := &savedJobsRepo{}
+		w := &ImportWorker{importRepo: repo}
+		job := newJSONImportJob(t, importjob.EntityTypeItems, `{"items": [{"name": "Drill"}]}`)
+
+		require.NoError(t, w.processJSONImport(context.Background(), job, nil, importjob.ConflictError, importjob.PrimaryPhotoFirst))
+
+		require.NotNil(t, job.ErrorMessage())
+		assert.Contains(t, *job.ErrorMessage(), "expected a JSON array of objects")
+	})
+
+	t.Run("no name in any object", func(t *testing.T) {
+		repo := &savedJobsRepo{}
+		w := &ImportWorker{importRepo: repo}
+		job := newJSONImportJob(t, importjob.EntityTypeItems, `[{"title": "Drill"}]`)
+
+		require.NoError(t, w.processJSONImport(context.Background(), job, nil, importjob.ConflictError, importjob.PrimaryPhotoFirst))
+
+		require.NotNil(t, job.ErrorMessage())
+		assert.Contains(t, *job.ErrorMessage(), "missing required column(s): name")
+	})
+
+	t.Run("entity type other than items", func(t *testing.T) {
+		repo := &savedJobsRepo{}
+		w := &ImportWorker{importRepo: repo}
+		job := newJSONImportJob(t, importjob.EntityTypeLocations, `[{"name": "Garage"}]`)
+
+		require.NoError(t, w.processJSONImport(context.Background(), job, nil, importjob.ConflictError, importjob.PrimaryPhotoFirst))
+
+		assert.Equal(t, importjob.StatusFailed, job.Status())
+		require.NotNil(t, job.ErrorMessage())
+		assert.Contains(t, *job.ErrorMessage(), "JSON imports are only supported for items")
+		require.Len(t, repo.saved, 1)
+	})
+}
+
+func TestIsJSONImport(t *testing.T) {
+	assert.True(t, isJSONImport(newJSONImportJob(t, importjob.EntityTypeItems, `[]`)))
+
+	job, _ := newHeaderCheckJob(t, importjob.EntityTypeItems, "name\n")
+	assert.False(t, isJSONImport(job))
+}
+
+func TestNestedInventoryImporter(t *testing.T) {
+	garage := mustLocation(t)
+	shed, err := location.NewLocation(garage.WorkspaceID(), "Shed", nil, nil, "SHD")
+	require.NoError(t, err)
+	itm, err := item.NewItem(garage.WorkspaceID(), "Drill", "D-1", 0)
+	require.NoError(t, err)
+
+	repo := &rowErrorsRepo{}
+	job := newJSONImportJob(t, importjob.EntityTypeItems, `[]`)
+	creator := &fakeInventoryCreator{failLocation: shed.ID()}
+	n := &nestedInventoryImporter{
+		worker:  &ImportWorker{importRepo: repo},
+		job:     job,
+		creator: creator,
+		caches: &inventoryImportCaches{
+			locations: map[string]*location.Location{"garage": garage, "shed": shed},
+		},
+	}
+
+	n.importAll(context.Background(), 3, itm, []map[string]string{
+		{"location": "Garage", "quantity": "4", "condition": "NEW"},
+		{"quantity": "1"},
+		{"location": "Attic"},
+		{"location": "shed"},
+	})
+
+	require.Len(t, creator.creates, 1)
+	assert.Equal(t, itm.ID(), creator.creates[0].ItemID)
+	assert.Equal(t, garage.ID(), creator.creates[0].LocationID)
+	assert.Equal(t, 4, creator.creates[0].Quantity)
+	assert.Equal(t, inventory.ConditionNew, creator.creates[0].Condition)
+
+	require.Len(t, repo.errors, 3)
+	for _, e := range repo.errors {
+		assert.Equal(t, 3, e.RowNumber(), "errors belong to the item's row")
+	}
+	assert.Equal(t, "inventory[2].location", *repo.errors[0].FieldName())
+	assert.Equal(t, "location is required", repo.errors[0].ErrorMessage())
+	assert.Equal(t, "inventory[3].location", *repo.errors[1].FieldName())
+	assert.Equal(t, "location 'Attic' not found", repo.errors[1].ErrorMessage())
+	assert.Equal(t, "inventory[4]", *repo.errors[2].FieldName())
+	assert.Equal(t, "location is archived", repo.errors[2].ErrorMessage())
+}
+
+func TestNestedInventoryImporter_NoEntries(t *testing.T) {
+	// No entries must not touch the (nil) database to load caches.
+	n := &nestedInventoryImporter{worker: &ImportWorker{}}
+
+	n.importAll(context.Background(), 1, nil, nil)
+
+	assert.Nil(t, n.caches)
+}
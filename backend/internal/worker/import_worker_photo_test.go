@@ -0,0 +1,151 @@
+package worker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/importjob"
+	"github.com/antti/home-warehouse/go-backend/internal/jobs"
+)
+
+// photoResultsRepo records photo result writes; other Repository methods are
+// unused by queueRowPhoto.
+type photoResultsRepo struct {
+	importjob.Repository
+	saved   []*importjob.ImportPhotoResult
+	updated map[uuid.UUID]importjob.PhotoStatus
+}
+
+func (r *photoResultsRepo) SavePhotoResult(ctx context.Context, result *importjob.ImportPhotoResult) error {
+	r.saved = append(r.saved, result)
+	return nil
+}
+
+func (r *photoResultsRepo) UpdatePhotoResult(ctx context.Context, id uuid.UUID, status importjob.PhotoStatus, photoID *uuid.UUID, message *string) error {
+	if r.updated == nil {
+		r.updated = map[uuid.UUID]importjob.PhotoStatus{}
+	}
+	r.updated[id] = status
+	return nil
+}
+
+type fakePhotoEnqueuer struct {
+	tasks []*asynq.Task
+	err   error
+}
+
+func (e *fakePhotoEnqueuer) EnqueueContext(ctx context.Context, task *asynq.Task, opts ...asynq.Option) (*asynq.TaskInfo, error) {
+	if e.err != nil {
+		return nil, e.err
+	}
+	e.tasks = append(e.tasks, task)
+	return &asynq.TaskInfo{}, nil
+}
+
+func TestImportWorker_QueueRowPhoto(t *testing.T) {
+	ctx := context.Background()
+	job, err := importjob.NewImportJob(uuid.New(), uuid.New(), importjob.EntityTypeItems, "items.csv", "/tmp/items.csv", 10)
+	require.NoError(t, err)
+	itemID := uuid.New()
+
+	t.Run("queues a download for a valid URL", func(t *testing.T) {
+		repo := &photoResultsRepo{}
+		enqueuer := &fakePhotoEnqueuer{}
+		w := &ImportWorker{importRepo: repo}
+		w.SetPhotoEnqueuer(enqueuer)
+
+		w.queueRowPhoto(ctx, job, 3, itemID, "https://example.com/drill.jpg", importjob.PrimaryPhotoFirst)
+
+		require.Len(t, repo.saved, 1)
+		assert.Equal(t, importjob.PhotoStatusPending, repo.saved[0].Status())
+		assert.Equal(t, 3, repo.saved[0].RowNumber())
+		require.Len(t, enqueuer.tasks, 1)
+		assert.Equal(t, jobs.TypePhotoImportURL, enqueuer.tasks[0].Type())
+
+		var payload jobs.PhotoImportPayload
+		require.NoError(t, json.Unmarshal(enqueuer.tasks[0].Payload(), &payload))
+		assert.Equal(t, repo.saved[0].ID(), payload.ResultID)
+		assert.Equal(t, job.WorkspaceID(), payload.WorkspaceID)
+		assert.Equal(t, job.UserID(), payload.UserID)
+		assert.Equal(t, itemID, payload.ItemID)
+		assert.Equal(t, "https://example.com/drill.jpg", payload.URL)
+	})
+
+	t.Run("skips an invalid URL with a warning", func(t *testing.T) {
+		repo := &photoResultsRepo{}
+		enqueuer := &fakePhotoEnqueuer{}
+		w := &ImportWorker{importRepo: repo}
+		w.SetPhotoEnqueuer(enqueuer)
+
+		w.queueRowPhoto(ctx, job, 4, itemID, "file:///etc/passwd", importjob.PrimaryPhotoFirst)
+
+		require.Len(t, repo.saved, 1)
+		assert.Equal(t, importjob.PhotoStatusSkipped, repo.saved[0].Status())
+		require.NotNil(t, repo.saved[0].Message())
+		assert.Empty(t, enqueuer.tasks)
+	})
+
+	t.Run("skips when photo downloads are not enabled", func(t *testing.T) {
+		repo := &photoResultsRepo{}
+		w := &ImportWorker{importRepo: repo}
+
+		w.queueRowPhoto(ctx, job, 5, itemID, "https://example.com/drill.jpg", importjob.PrimaryPhotoFirst)
+
+		require.Len(t, repo.saved, 1)
+		assert.Equal(t, importjob.PhotoStatusSkipped, repo.saved[0].Status())
+	})
+
+	t.Run("marks the photo failed when queueing fails", func(t *testing.T) {
+		repo := &photoResultsRepo{}
+		w := &ImportWorker{importRepo: repo}
+		w.SetPhotoEnqueuer(&fakePhotoEnqueuer{err: errors.New("redis down")})
+
+		w.queueRowPhoto(ctx, job, 6, itemID, "https://example.com/drill.jpg", importjob.PrimaryPhotoFirst)
+
+		require.Len(t, repo.saved, 1)
+		assert.Equal(t, importjob.PhotoStatusFailed, repo.updated[repo.saved[0].ID()])
+	})
+
+	t.Run("ignores an empty cell", func(t *testing.T) {
+		repo := &photoResultsRepo{}
+		w := &ImportWorker{importRepo: repo}
+
+		w.queueRowPhoto(ctx, job, 7, itemID, "", importjob.PrimaryPhotoFirst)
+
+		assert.Empty(t, repo.saved)
+	})
+}
+
+func TestImportWorker_QueueRowPhotos(t *testing.T) {
+	ctx := context.Background()
+	job, err := importjob.NewImportJob(uuid.New(), uuid.New(), importjob.EntityTypeItems, "items.csv", "/tmp/items.csv", 10)
+	require.NoError(t, err)
+	itemID := uuid.New()
+
+	t.Run("queues every URL of a multi-photo cell in order", func(t *testing.T) {
+		repo := &photoResultsRepo{}
+		enqueuer := &fakePhotoEnqueuer{}
+		w := &ImportWorker{importRepo: repo}
+		w.SetPhotoEnqueuer(enqueuer)
+
+		w.queueRowPhotos(ctx, job, 2, itemID, " https://example.com/front.jpg | https://example.com/back.jpg ||", importjob.PrimaryPhotoLargest)
+
+		require.Len(t, repo.saved, 2)
+		assert.Equal(t, "https://example.com/front.jpg", repo.saved[0].URL())
+		assert.Equal(t, "https://example.com/back.jpg", repo.saved[1].URL())
+		require.Len(t, enqueuer.tasks, 2)
+		for i, task := range enqueuer.tasks {
+			var payload jobs.PhotoImportPayload
+			require.NoError(t, json.Unmarshal(task.Payload(), &payload))
+			assert.Equal(t, repo.saved[i].ID(), payload.ResultID)
+			assert.Equal(t, string(importjob.PrimaryPhotoLargest), payload.PrimaryPhoto)
+		}
+	})
+}
@@ -0,0 +1,119 @@
+package worker
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/importjob"
+)
+
+// SetThrottle paces imports and batches their bookkeeping writes.
+// rowsPerSecond caps how fast rows are processed (0 = unlimited), leaving
+// database capacity for the API during large imports. batchSize is how many
+// row errors and row results are buffered before being written with a single
+// COPY; 1 or less writes each one as it is recorded.
+func (w *ImportWorker) SetThrottle(rowsPerSecond, batchSize int) {
+	w.rowsPerSecond = rowsPerSecond
+	w.batchSize = batchSize
+}
+
+// beginJob returns the worker the job about to be processed runs on: a copy
+// of w with the job's own pacer and row log, so jobs running at the same
+// time do not share them. The returned func writes whatever is still
+// buffered; processJob defers it so an early return does not lose row errors.
+func (w *ImportWorker) beginJob() (*ImportWorker, func(ctx context.Context)) {
+	jw := *w
+	jw.pacer = newRowPacer(w.rowsPerSecond)
+	jw.rows = newRowLog(w.importRepo, w.batchSize)
+	return &jw, func(ctx context.Context) {
+		jw.rows.flush(ctx)
+		jw.pacer, jw.rows = nil, nil
+	}
+}
+
+// rowPacer spaces rows evenly at a fixed rate. A nil pacer does not wait.
+type rowPacer struct {
+	interval time.Duration
+	next     time.Time
+}
+
+func newRowPacer(rowsPerSecond int) *rowPacer {
+	if rowsPerSecond <= 0 {
+		return nil
+	}
+	return &rowPacer{interval: time.Second / time.Duration(rowsPerSecond)}
+}
+
+// wait blocks until the next row may be processed.
+func (p *rowPacer) wait() {
+	if p == nil {
+		return
+	}
+	now := time.Now()
+	if p.next.After(now) {
+		time.Sleep(p.next.Sub(now))
+		now = p.next
+	}
+	p.next = now.Add(p.interval)
+}
+
+// rowLog buffers a job's row errors and row results, writing each kind in a
+// single round trip once batchSize of them are pending. A nil rowLog means
+// write-through; see saveRowError and saveRowResult.
+type rowLog struct {
+	repo      importjob.Repository
+	batchSize int
+	errors    []*importjob.ImportError
+	results   []*importjob.ImportRowResult
+}
+
+func newRowLog(repo importjob.Repository, batchSize int) *rowLog {
+	if batchSize <= 1 {
+		return nil
+	}
+	return &rowLog{repo: repo, batchSize: batchSize}
+}
+
+func (l *rowLog) addError(ctx context.Context, e *importjob.ImportError) {
+	l.errors = append(l.errors, e)
+	if len(l.errors) >= l.batchSize {
+		l.flushErrors(ctx)
+	}
+}
+
+func (l *rowLog) addResult(ctx context.Context, r *importjob.ImportRowResult) {
+	l.results = append(l.results, r)
+	if len(l.results) >= l.batchSize {
+		l.flushResults(ctx)
+	}
+}
+
+// flush writes everything still buffered. Safe to call on a nil rowLog.
+func (l *rowLog) flush(ctx context.Context) {
+	if l == nil {
+		return
+	}
+	l.flushErrors(ctx)
+	l.flushResults(ctx)
+}
+
+func (l *rowLog) flushErrors(ctx context.Context) {
+	if len(l.errors) == 0 {
+		return
+	}
+	if err := l.repo.SaveErrors(ctx, l.errors); err != nil {
+		log.Printf("Error saving %d import row errors (job %s): %v", len(l.errors), l.errors[0].ImportJobID(), err)
+	}
+	l.errors = nil
+}
+
+func (l *rowLog) flushResults(ctx context.Context) {
+	if len(l.results) == 0 {
+		return
+	}
+	if err := l.repo.SaveRowResults(ctx, l.results); err != nil {
+		log.Printf("Error saving %d import row results (job %s): %v", len(l.results), l.results[0].ImportJobID(), err)
+	}
+	l.results = nil
+}
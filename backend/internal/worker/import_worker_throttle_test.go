@@ -0,0 +1,138 @@
+package worker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/antti/home-warehouse/go-backend/internal/domain/warehouse/importjob"
+)
+
+// roundTripRepo counts the writes that reach the database, one per call.
+type roundTripRepo struct {
+	savedJobsRepo
+	singleErrors, errorBatches   int
+	singleResults, resultBatches int
+	errors, results              int
+}
+
+func (r *roundTripRepo) SaveError(ctx context.Context, importError *importjob.ImportError) error {
+	r.singleErrors++
+	r.errors++
+	return nil
+}
+
+func (r *roundTripRepo) SaveErrors(ctx context.Context, errs []*importjob.ImportError) error {
+	r.errorBatches++
+	r.errors += len(errs)
+	return nil
+}
+
+func (r *roundTripRepo) SaveRowResult(ctx context.Context, result *importjob.ImportRowResult) error {
+	r.singleResults++
+	r.results++
+	return nil
+}
+
+func (r *roundTripRepo) SaveRowResults(ctx context.Context, results []*importjob.ImportRowResult) error {
+	r.resultBatches++
+	r.results += len(results)
+	return nil
+}
+
+func (r *roundTripRepo) roundTrips() int {
+	return r.singleErrors + r.errorBatches + r.singleResults + r.resultBatches
+}
+
+// recordRows records a large import's bookkeeping: every tenth row fails.
+// It returns the worker the job ran on.
+func recordRows(w *ImportWorker, rows int) *ImportWorker {
+	ctx := context.Background()
+	jobID := uuid.New()
+	jw, end := w.beginJob()
+	for rowNum := 1; rowNum <= rows; rowNum++ {
+		if rowNum%10 == 0 {
+			jw.saveRowError(ctx, jobID, rowNum, nil, "bad row", nil)
+			continue
+		}
+		id := uuid.New()
+		jw.saveRowResult(ctx, jobID, rowNum, importjob.RowActionCreated, &id)
+	}
+	end(ctx)
+	return jw
+}
+
+func TestRowLog_BatchingReducesRoundTrips(t *testing.T) {
+	const rows = 5000
+
+	unbatched := &roundTripRepo{}
+	w := &ImportWorker{importRepo: unbatched}
+	w.SetThrottle(0, 1)
+	recordRows(w, rows)
+
+	batched := &roundTripRepo{}
+	w = &ImportWorker{importRepo: batched}
+	w.SetThrottle(0, 100)
+	recordRows(w, rows)
+
+	assert.Equal(t, rows, unbatched.roundTrips(), "one write per row without batching")
+	assert.Equal(t, 0, batched.singleErrors+batched.singleResults)
+	assert.Equal(t, 5, batched.errorBatches, "500 errors in batches of 100")
+	assert.Equal(t, 45, batched.resultBatches, "4500 results in batches of 100")
+
+	// Nothing is lost by batching.
+	assert.Equal(t, unbatched.errors, batched.errors)
+	assert.Equal(t, unbatched.results, batched.results)
+	assert.Equal(t, rows, batched.errors+batched.results)
+}
+
+func TestRowLog_FlushesPartialBatch(t *testing.T) {
+	repo := &roundTripRepo{}
+	w := &ImportWorker{importRepo: repo}
+	w.SetThrottle(0, 100)
+	jw := recordRows(w, 25)
+
+	assert.Equal(t, 1, repo.errorBatches)
+	assert.Equal(t, 2, repo.errors)
+	assert.Equal(t, 1, repo.resultBatches)
+	assert.Equal(t, 23, repo.results)
+	assert.Nil(t, jw.rows, "the row log does not outlive the job")
+	assert.Nil(t, w.rows, "jobs do not share the worker's row log")
+}
+
+func TestSaveJob_FlushesRowsWhenFinished(t *testing.T) {
+	ctx := context.Background()
+	repo := &roundTripRepo{}
+	w := &ImportWorker{importRepo: repo}
+	w.SetThrottle(0, 100)
+	jw, end := w.beginJob()
+	defer end(ctx)
+
+	job, _ := newHeaderCheckJob(t, importjob.EntityTypeItems, "name\nDrill\n")
+	job.Start(1)
+	jw.saveRowError(ctx, job.ID(), 1, nil, "bad row", nil)
+
+	jw.saveJob(ctx, job)
+	assert.Zero(t, repo.errors, "progress saves leave the batch buffered")
+
+	job.Complete()
+	jw.saveJob(ctx, job)
+	assert.Equal(t, 1, repo.errors)
+}
+
+func TestRowPacer(t *testing.T) {
+	assert.Nil(t, newRowPacer(0), "0 means unlimited")
+
+	p := newRowPacer(200)
+	require.NotNil(t, p)
+
+	start := time.Now()
+	for i := 0; i < 11; i++ {
+		p.wait()
+	}
+	assert.GreaterOrEqual(t, time.Since(start), 50*time.Millisecond, "11 rows at 200/s span 10 intervals")
+}
@@ -201,6 +201,26 @@ func TestItemPhotos_Reorder(t *testing.T) {
 	assert.Less(t, order[second.ID], order[first.ID], "reordered photo should sort first")
 }
 
+func TestItemPhotos_Move(t *testing.T) {
+	ts, wsID, itemID := photoFixture(t)
+	first := uploadPhoto(t, ts, wsID, itemID, "")
+	second := uploadPhoto(t, ts, wsID, itemID, "")
+	third := uploadPhoto(t, ts, wsID, itemID, "")
+
+	// Move the third photo between the first two.
+	resp := ts.Put(fmt.Sprintf("/workspaces/%s/photos/%s/position", wsID, third.ID), map[string]interface{}{
+		"before_photo_id": second.ID,
+	})
+	RequireStatus(t, resp, http.StatusNoContent)
+	resp.Body.Close()
+
+	var ids []uuid.UUID
+	for _, p := range listPhotos(t, ts, wsID, itemID) {
+		ids = append(ids, p.ID)
+	}
+	assert.Equal(t, []uuid.UUID{first.ID, third.ID, second.ID}, ids)
+}
+
 func TestItemPhotos_Delete(t *testing.T) {
 	ts, wsID, itemID := photoFixture(t)
 	keep := uploadPhoto(t, ts, wsID, itemID, "")
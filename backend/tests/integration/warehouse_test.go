@@ -1557,10 +1557,11 @@ func TestBorrowerSearch(t *testing.T) {
 	assert.Equal(t, 1, len(emailSearchResult.Items), "Should find 1 borrower by email")
 	assert.Equal(t, "Jane Doe", emailSearchResult.Items[0].Name)
 
-	// Test search by phone. Full-text search tokenizes the stored phone, so the
-	// query must be the full phone string (a bare fragment like "555-0103" is
-	// not a matching lexeme).
-	resp = ts.Get(workspacePath + "/borrowers/search?q=%2B1-555-0103&limit=10")
+	// Test search by phone. Phones are stored normalized to E.164 and
+	// full-text search tokenizes the stored phone, so the query must be the
+	// full normalized number (a bare fragment like "555-0103" is not a
+	// matching lexeme).
+	resp = ts.Get(workspacePath + "/borrowers/search?q=%2B15550103&limit=10")
 	RequireStatus(t, resp, http.StatusOK)
 
 	searchResult = ParseResponse[struct {
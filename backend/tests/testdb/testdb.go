@@ -114,6 +114,7 @@ func CleanupTestDB(t *testing.T, pool *pgxpool.Pool) {
 		"warehouse.item_labels",
 		"warehouse.labels",
 		"warehouse.item_photos",
+		"warehouse.item_shares",
 		"warehouse.items",
 		"warehouse.container_tags",
 		"warehouse.containers",
@@ -122,6 +123,8 @@ func CleanupTestDB(t *testing.T, pool *pgxpool.Pool) {
 		"warehouse.companies",
 		"warehouse.pending_changes",
 		// Auth schema - reverse dependency order
+		"auth.api_keys",
+		"auth.auth_events",
 		"auth.user_sessions",
 		"auth.notifications",
 		"auth.workspace_members",